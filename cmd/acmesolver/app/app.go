@@ -80,6 +80,8 @@ func NewACMESolverCommand(_ context.Context) *cobra.Command {
 	cmd.Flags().StringVar(&s.Domain, "domain", "", "the domain name to verify")
 	cmd.Flags().StringVar(&s.Token, "token", "", "the challenge token to verify against")
 	cmd.Flags().StringVar(&s.Key, "key", "", "the challenge key to respond with")
+	cmd.Flags().StringVar(&s.ChallengesDir, "challenges-dir", "", "if set, serve the key for any requested token from a file of the same name in this directory, "+
+		"e.g. a mounted ConfigMap written to by the custom HTTP01 solver. --domain, --token and --key are ignored when set")
 
 	// TODO(@inteon): use flags to configure the log configuration (https://github.com/cert-manager/cert-manager/issues/6021)
 
@@ -23,11 +23,14 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"reflect"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
 	"golang.org/x/sync/errgroup"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes"
@@ -40,9 +43,18 @@ import (
 	"github.com/cert-manager/cert-manager/controller-binary/app/options"
 	config "github.com/cert-manager/cert-manager/internal/apis/config/controller"
 	"github.com/cert-manager/cert-manager/internal/apis/config/shared"
+	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
+	"github.com/cert-manager/cert-manager/internal/controller/certificates/keyprovider"
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	"github.com/cert-manager/cert-manager/internal/controller/issuerhealth"
+	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
+	"github.com/cert-manager/cert-manager/internal/tlsclient"
 	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/controller/acmechallenges"
+	"github.com/cert-manager/cert-manager/pkg/controller/acmeorders"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificatemigrations"
 	"github.com/cert-manager/cert-manager/pkg/controller/clusterissuers"
 	"github.com/cert-manager/cert-manager/pkg/healthz"
 	dnsutil "github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
@@ -52,6 +64,7 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/server/tls"
 	"github.com/cert-manager/cert-manager/pkg/server/tls/authority"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	"github.com/cert-manager/cert-manager/pkg/util/profiling"
 )
 
@@ -64,6 +77,16 @@ const (
 	// * controller-runtime:
 	//   https://github.com/kubernetes-sigs/controller-runtime/blob/1ea2be573f7887a9fbd766e9a921c5af344da6eb/pkg/internal/httpserver/server.go#L14
 	defaultReadHeaderTimeout = 32 * time.Second
+
+	// csrIssuerControllerPrefix is the common prefix of the controllers that
+	// sign cluster-scoped CertificateSigningRequests.
+	csrIssuerControllerPrefix = "certificatesigningrequests-issuer-"
+
+	// certificateControllerPrefix and certificateRequestControllerPrefix are
+	// the common prefixes of the controllers that manage Certificates and
+	// CertificateRequests.
+	certificateControllerPrefix        = "certificates-"
+	certificateRequestControllerPrefix = "certificaterequests-"
 )
 
 func Run(rootCtx context.Context, opts *config.ControllerConfiguration) error {
@@ -87,6 +110,9 @@ func Run(rootCtx context.Context, opts *config.ControllerConfiguration) error {
 
 	enabledControllers := options.EnabledControllers(opts)
 	log.Info(fmt.Sprintf("enabled controllers: %s", sets.List(enabledControllers)))
+	for _, warning := range options.ControllerDependencyWarnings(enabledControllers) {
+		log.V(logf.WarnLevel).Info(warning)
+	}
 
 	// start the CertificateSource if provided
 	certificateSource := buildCertificateSource(log, opts.MetricsTLSConfig, ctx.RESTConfig)
@@ -112,6 +138,13 @@ func Run(rootCtx context.Context, opts *config.ControllerConfiguration) error {
 	if err != nil {
 		return fmt.Errorf("failed to listen on prometheus address %s: %v", opts.MetricsListenAddress, err)
 	}
+	// Serve the issuer health summary alongside the metrics. Its informers
+	// are only started once this instance has been elected leader. Requests
+	// carry bearer tokens, so they are only served over TLS.
+	if certificateSource == nil {
+		log.V(logf.InfoLevel).Info("the issuer health summary is only served over TLS, configure the metrics TLS options to enable it", "path", issuerhealth.Path)
+	}
+	ctx.Metrics.Handle(issuerhealth.Path, issuerhealth.NewHandler(log, ctx.IssuerHealth, ctx.Client, ctx.SharedInformerFactory, ctx.Namespace))
 	metricsServer := ctx.Metrics.NewServer(metricsLn)
 
 	g.Go(func() error {
@@ -217,48 +250,50 @@ func Run(rootCtx context.Context, opts *config.ControllerConfiguration) error {
 		// Continue with setting up controller
 	}
 
-	for n, fn := range controller.Known() {
-		log := log.WithValues("controller", n)
+	if ctx.Namespace != "" {
+		log.V(logf.InfoLevel).Info("cert-manager is scoped to a single namespace; ClusterIssuers and CertificateSigningRequests are not supported", "namespace", ctx.Namespace)
+	}
 
-		// only run a controller if it's been enabled
-		if !enabledControllers.Has(n) {
-			log.V(logf.InfoLevel).Info("not starting controller as it's disabled")
-			continue
-		}
+	// Only the enabled controllers are built, so that disabled controllers
+	// never register informers, and never watch resources that cert-manager
+	// may not have been granted access to.
+	controllers, err := buildControllers(log, ctxFactory, enabledControllers, ctx.Namespace)
+	if err != nil {
+		err = fmt.Errorf("error starting controller: %v", err)
 
-		// don't run clusterissuers controller if scoped to a single namespace
-		if ctx.Namespace != "" && n == clusterissuers.ControllerName {
-			log.V(logf.InfoLevel).Info("not starting controller as cert-manager has been scoped to a single namespace")
-			continue
+		cancelContext()
+		err2 := g.Wait() // Don't process errors, we already have an error
+		if err2 != nil {
+			return utilerrors.NewAggregate([]error{err, err2})
 		}
+		return err
+	}
 
-		iface, err := fn(ctxFactory)
-		if err != nil {
-			err = fmt.Errorf("error starting controller: %v", err)
+	for n, iface := range controllers {
+		log := log.WithValues("controller", n)
 
-			cancelContext()
-			err2 := g.Wait() // Don't process errors, we already have an error
-			if err2 != nil {
-				return utilerrors.NewAggregate([]error{err, err2})
-			}
-			return err
+		if h, ok := iface.(healthz.ControllerHealth); ok {
+			healthzServer.RegisterController(n, h)
 		}
 
 		g.Go(func() error {
 			log.V(logf.InfoLevel).Info("starting controller")
 
-			return iface.Run(opts.NumberOfConcurrentWorkers, rootCtx)
+			return iface.Run(concurrentWorkers(opts, n), rootCtx)
 		})
 	}
 
 	log.V(logf.DebugLevel).Info("starting shared informer factories")
-	ctx.SharedInformerFactory.Start(rootCtx.Done())
-	ctx.KubeSharedInformerFactory.Start(rootCtx.Done())
-	ctx.HTTP01ResourceMetadataInformersFactory.Start(rootCtx.Done())
-
+	factories := []internalinformers.Startable{
+		internalinformers.NewStartable[reflect.Type]("cert-manager", ctx.SharedInformerFactory),
+		internalinformers.NewStartable[string]("kubernetes", ctx.KubeSharedInformerFactory),
+		internalinformers.NewStartable[schema.GroupVersionResource]("http01-resources-metadata", ctx.HTTP01ResourceMetadataInformersFactory),
+		internalinformers.NewStartable[schema.GroupVersionResource]("metadata", ctx.MetadataInformersFactory),
+	}
 	if utilfeature.DefaultFeatureGate.Enabled(feature.ExperimentalGatewayAPISupport) && opts.EnableGatewayAPI {
-		ctx.GWShared.Start(rootCtx.Done())
+		factories = append(factories, internalinformers.NewStartable[reflect.Type]("gateway-api", ctx.GWShared))
 	}
+	internalinformers.StartStaggered(rootCtx, opts.InformerSyncBudget, factories...)
 
 	err = g.Wait()
 	if err != nil {
@@ -269,6 +304,57 @@ func Run(rootCtx context.Context, opts *config.ControllerConfiguration) error {
 	return nil
 }
 
+// buildControllers builds each of the enabled controllers. Controllers that
+// are disabled, or that are cluster-scoped while cert-manager is scoped to a
+// single namespace, are not built at all, so they do not register any
+// informers with the shared informer factories.
+func buildControllers(log logr.Logger, ctxFactory *controller.ContextFactory, enabledControllers sets.Set[string], namespace string) (map[string]controller.Interface, error) {
+	controllers := make(map[string]controller.Interface)
+	for n, fn := range controller.Known() {
+		log := log.WithValues("controller", n)
+
+		// only run a controller if it's been enabled
+		if !enabledControllers.Has(n) {
+			log.V(logf.InfoLevel).Info("not starting controller as it's disabled")
+			continue
+		}
+
+		// don't run cluster-scoped controllers if scoped to a single namespace
+		if namespace != "" && (n == clusterissuers.ControllerName || n == certificatemigrations.ControllerName || strings.HasPrefix(n, csrIssuerControllerPrefix)) {
+			log.V(logf.InfoLevel).Info("not starting controller as cert-manager has been scoped to a single namespace")
+			continue
+		}
+
+		iface, err := fn(ctxFactory)
+		if err != nil {
+			return nil, err
+		}
+		controllers[n] = iface
+	}
+	return controllers, nil
+}
+
+// concurrentWorkers returns the number of workers to start for the named
+// controller, which is NumberOfConcurrentWorkers unless another number is
+// configured for the controller.
+func concurrentWorkers(opts *config.ControllerConfiguration, name string) int {
+	var workers int
+	switch {
+	case strings.HasPrefix(name, certificateControllerPrefix):
+		workers = opts.ConcurrentCertificateSyncs
+	case strings.HasPrefix(name, certificateRequestControllerPrefix):
+		workers = opts.ConcurrentCertificateRequestSyncs
+	case name == acmeorders.ControllerName:
+		workers = opts.ConcurrentOrderSyncs
+	case name == acmechallenges.ControllerName:
+		workers = opts.ConcurrentChallengeSyncs
+	}
+	if workers == 0 {
+		return opts.NumberOfConcurrentWorkers
+	}
+	return workers
+}
+
 // buildControllerContextFactory builds a new controller ContextFactory which
 // can build controller contexts for each component.
 func buildControllerContextFactory(ctx context.Context, opts *config.ControllerConfiguration) (*controller.ContextFactory, error) {
@@ -283,6 +369,11 @@ func buildControllerContextFactory(ctx context.Context, opts *config.ControllerC
 		WithValues("nameservers", nameservers).
 		Info("configured acme dns01 nameservers")
 
+	certificateRequestEncodings := make(map[string]pki.CertificateRequestEncoding, len(opts.CertificateRequestEncodings))
+	for group, encoding := range opts.CertificateRequestEncodings {
+		certificateRequestEncodings[group] = pki.CertificateRequestEncoding(encoding)
+	}
+
 	http01SolverResourceRequestCPU, err := resource.ParseQuantity(opts.ACMEHTTP01Config.SolverResourceRequestCPU)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing ACMEHTTP01SolverResourceRequestCPU: %w", err)
@@ -303,19 +394,39 @@ func buildControllerContextFactory(ctx context.Context, opts *config.ControllerC
 		return nil, fmt.Errorf("error parsing ACMEHTTP01SolverResourceLimitsMemory: %w", err)
 	}
 
+	if err := tlsclient.SetDefaults(tlsclient.Options{
+		MinTLSVersion: opts.OutboundTLSConfig.MinTLSVersion,
+		CipherSuites:  opts.OutboundTLSConfig.CipherSuites,
+	}); err != nil {
+		return nil, fmt.Errorf("error configuring outbound TLS: %w", err)
+	}
+
+	var keyProvider keyprovider.Provider
+	if utilfeature.DefaultFeatureGate.Enabled(feature.ExternalPrivateKeys) && opts.ExternalPrivateKeySignerAddress != "" {
+		signerService, err := keyprovider.Dial(opts.ExternalPrivateKeySignerAddress)
+		if err != nil {
+			return nil, fmt.Errorf("error configuring external private key signer: %w", err)
+		}
+		keyProvider = keyprovider.NewProvider(signerService)
+	}
+
 	ACMEHTTP01SolverRunAsNonRoot := opts.ACMEHTTP01Config.SolverRunAsNonRoot
 	acmeAccountRegistry := accounts.NewDefaultRegistry()
 
 	ctxFactory, err := controller.NewContextFactory(ctx, controller.ContextOptions{
-		Kubeconfig:         opts.KubeConfig,
-		KubernetesAPIQPS:   opts.KubernetesAPIQPS,
-		KubernetesAPIBurst: opts.KubernetesAPIBurst,
-		APIServerHost:      opts.APIServerHost,
+		Kubeconfig:            opts.KubeConfig,
+		KubernetesAPIQPS:      opts.KubernetesAPIQPS,
+		KubernetesAPIBurst:    opts.KubernetesAPIBurst,
+		InformerListChunkSize: opts.InformerListChunkSize,
+		SyncTimeout:           opts.SyncTimeout,
+		ProgressDeadline:      opts.ProgressDeadline,
+		APIServerHost:         opts.APIServerHost,
 
 		Namespace: opts.Namespace,
 
-		Clock:   clock.RealClock{},
-		Metrics: metrics.New(log, clock.RealClock{}),
+		Clock:        clock.RealClock{},
+		Metrics:      metrics.New(log, clock.RealClock{}),
+		IssuerHealth: issuerhealth.NewTracker(clock.RealClock{}),
 
 		ACMEOptions: controller.ACMEOptions{
 			HTTP01SolverResourceRequestCPU:    http01SolverResourceRequestCPU,
@@ -323,6 +434,7 @@ func buildControllerContextFactory(ctx context.Context, opts *config.ControllerC
 			HTTP01SolverResourceLimitsCPU:     http01SolverResourceLimitsCPU,
 			HTTP01SolverResourceLimitsMemory:  http01SolverResourceLimitsMemory,
 			ACMEHTTP01SolverRunAsNonRoot:      ACMEHTTP01SolverRunAsNonRoot,
+			HTTP01SolverSharedPods:            opts.ACMEHTTP01Config.SolverSharedPods,
 			HTTP01SolverImage:                 opts.ACMEHTTP01Config.SolverImage,
 			// Allows specifying a list of custom nameservers to perform HTTP01 checks on.
 			HTTP01SolverNameservers: opts.ACMEHTTP01Config.SolverNameservers,
@@ -331,7 +443,11 @@ func buildControllerContextFactory(ctx context.Context, opts *config.ControllerC
 			DNS01CheckRetryPeriod:   opts.ACMEDNS01Config.CheckRetryPeriod,
 			DNS01CheckAuthoritative: !opts.ACMEDNS01Config.RecursiveNameserversOnly,
 
-			AccountRegistry: acmeAccountRegistry,
+			DNS01OrphanedRecordSweepInterval: opts.ACMEDNS01Config.OrphanedRecordSweepInterval,
+			DNS01OrphanedRecordMinAge:        opts.ACMEDNS01Config.OrphanedRecordMinAge,
+
+			AccountRegistry:             acmeAccountRegistry,
+			AccountVerificationInterval: opts.ACMEAccountVerificationInterval,
 		},
 
 		SchedulerOptions: controller.SchedulerOptions{
@@ -352,8 +468,24 @@ func buildControllerContextFactory(ctx context.Context, opts *config.ControllerC
 		},
 
 		CertificateOptions: controller.CertificateOptions{
-			EnableOwnerRef:           opts.EnableCertificateOwnerRef,
-			CopiedAnnotationPrefixes: opts.CopiedAnnotationPrefixes,
+			EnableOwnerRef:               opts.EnableCertificateOwnerRef,
+			CopiedAnnotationPrefixes:     opts.CopiedAnnotationPrefixes,
+			CertificateRequestEncodings:  certificateRequestEncodings,
+			IssuanceTimeout:              opts.IssuanceTimeout,
+			ClockSkewTolerance:           opts.ClockSkewTolerance,
+			MaxNotBeforeWait:             opts.MaxNotBeforeWait,
+			VerifyIssuedCertificates:     opts.VerifyIssuedCertificates,
+			VerifyIssuedCertificateChain: opts.VerifyIssuedCertificateChain,
+			MaxIssuanceFailureEvents:     opts.MaxIssuanceFailureEvents,
+			IssuanceFailureEventWindow:   opts.IssuanceFailureEventWindow,
+			IssuanceHistoryLimit:         opts.IssuanceHistoryLimit,
+			MaxSecretSize:                opts.MaxSecretSize,
+			PrivateKeyDefaults: internalcertificates.PrivateKeyDefaults{
+				Algorithm:      cmapi.PrivateKeyAlgorithm(opts.DefaultPrivateKeyAlgorithm),
+				Size:           opts.DefaultPrivateKeySize,
+				RotationPolicy: cmapi.PrivateKeyRotationPolicy(opts.DefaultPrivateKeyRotationPolicy),
+			},
+			KeyProvider: keyProvider,
 		},
 
 		ConfigOptions: controller.ConfigOptions{
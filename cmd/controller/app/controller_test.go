@@ -0,0 +1,153 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/go-logr/logr"
+
+	"github.com/cert-manager/cert-manager/controller-binary/app/options"
+	config "github.com/cert-manager/cert-manager/internal/apis/config/controller"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+func TestBuildControllersOnlyStartsInformersOfEnabledControllers(t *testing.T) {
+	var (
+		orders       = reflect.TypeOf(&cmacme.Order{}).String()
+		challenges   = reflect.TypeOf(&cmacme.Challenge{}).String()
+		certificates = reflect.TypeOf(&cmapi.Certificate{}).String()
+		ingresses    = "*v1.Ingress"
+	)
+
+	tests := map[string]struct {
+		controllers   []string
+		expStarted    []string
+		expNotStarted []string
+	}{
+		"all default controllers": {
+			controllers: []string{"*"},
+			expStarted:  []string{orders, challenges, certificates, ingresses},
+		},
+		"ingress-shim and the ACME controllers disabled": {
+			controllers:   []string{"*", "-ingress-shim", "-orders", "-challenges", "-certificaterequests-issuer-acme"},
+			expStarted:    []string{certificates},
+			expNotStarted: []string{orders, challenges, ingresses},
+		},
+		"orders informer is still started for the enabled ACME issuer": {
+			controllers:   []string{"*", "-ingress-shim", "-orders", "-challenges"},
+			expStarted:    []string{orders, certificates},
+			expNotStarted: []string{challenges, ingresses},
+		},
+		"only the certificates controllers": {
+			controllers: []string{
+				"certificates-trigger",
+				"certificates-issuing",
+				"certificates-key-manager",
+				"certificates-request-manager",
+				"certificates-readiness",
+			},
+			expStarted:    []string{certificates},
+			expNotStarted: []string{orders, challenges, ingresses},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			opts, err := options.NewControllerConfiguration()
+			if err != nil {
+				t.Fatal(err)
+			}
+			// The informers are never synced, so the API server does not
+			// need to be reachable.
+			opts.APIServerHost = "https://127.0.0.1:1"
+			opts.Controllers = test.controllers
+
+			ctxFactory, err := buildControllerContextFactory(ctx, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cmCtx, err := ctxFactory.Build()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := buildControllers(logr.Discard(), ctxFactory, options.EnabledControllers(opts), opts.Namespace); err != nil {
+				t.Fatal(err)
+			}
+
+			cmCtx.SharedInformerFactory.Start(ctx.Done())
+			cmCtx.KubeSharedInformerFactory.Start(ctx.Done())
+
+			// WaitForCacheSync only reports the informers that have been
+			// started, and returns straight away when the channel is closed.
+			closed := make(chan struct{})
+			close(closed)
+			started := make(map[string]bool)
+			for informerType := range cmCtx.SharedInformerFactory.WaitForCacheSync(closed) {
+				started[informerType.String()] = true
+			}
+			for informerType := range cmCtx.KubeSharedInformerFactory.WaitForCacheSync(closed) {
+				started[informerType] = true
+			}
+
+			for _, informerType := range test.expStarted {
+				if !started[informerType] {
+					t.Errorf("expected %s informer to be started, started informers: %v", informerType, started)
+				}
+			}
+			for _, informerType := range test.expNotStarted {
+				if started[informerType] {
+					t.Errorf("expected %s informer not to be started, started informers: %v", informerType, started)
+				}
+			}
+		})
+	}
+}
+
+func TestConcurrentWorkers(t *testing.T) {
+	opts := &config.ControllerConfiguration{
+		NumberOfConcurrentWorkers:         5,
+		ConcurrentCertificateSyncs:        20,
+		ConcurrentCertificateRequestSyncs: 10,
+		ConcurrentOrderSyncs:              3,
+	}
+
+	tests := map[string]int{
+		"certificates-issuing":                 20,
+		"certificates-trigger":                 20,
+		"certificaterequests-issuer-acme":      10,
+		"certificaterequests-approver":         10,
+		"orders":                               3,
+		"challenges":                           5,
+		"certificatesigningrequests-issuer-ca": 5,
+		"issuers":                              5,
+	}
+	for name, expWorkers := range tests {
+		t.Run(name, func(t *testing.T) {
+			if workers := concurrentWorkers(opts, name); workers != expWorkers {
+				t.Errorf("expected %d workers, got %d", expWorkers, workers)
+			}
+		})
+	}
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	configv1alpha1 "github.com/cert-manager/cert-manager/pkg/apis/config/controller/v1alpha1"
 	shimgatewaycontroller "github.com/cert-manager/cert-manager/pkg/controller/certificate-shim/gateways"
+	secretannotationmigrationcontroller "github.com/cert-manager/cert-manager/pkg/controller/secretannotationmigration"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 )
@@ -70,6 +71,22 @@ func AddConfigFlags(fs *pflag.FlagSet, c *config.ControllerConfiguration) {
 		"Paths to a kubeconfig. Only required if out-of-cluster.")
 	fs.Float32Var(&c.KubernetesAPIQPS, "kube-api-qps", c.KubernetesAPIQPS, "indicates the maximum queries-per-second requests to the Kubernetes apiserver")
 	fs.IntVar(&c.KubernetesAPIBurst, "kube-api-burst", c.KubernetesAPIBurst, "the maximum burst queries-per-second of requests sent to the Kubernetes apiserver")
+	fs.IntVar(&c.InformerListChunkSize, "informer-list-chunk-size", c.InformerListChunkSize, ""+
+		"The maximum number of objects fetched by each LIST call made to fill the informer caches, using the "+
+		"limit and continue parameters. Reduces the load on the Kubernetes apiserver on startup in clusters "+
+		"with many Secrets or CertificateRequests. A value of 0 lists all the objects of a type at once.")
+	fs.DurationVar(&c.InformerSyncBudget, "informer-sync-budget", c.InformerSyncBudget, ""+
+		"The maximum amount of time given to each group of informers to sync their caches on startup before "+
+		"the next group is started, so that the initial LIST calls are not all sent to the Kubernetes apiserver "+
+		"at once. A value of 0 starts all informers at once.")
+	fs.DurationVar(&c.SyncTimeout, "sync-timeout", c.SyncTimeout, ""+
+		"The maximum amount of time a controller may spend processing a single item, such as while waiting "+
+		"for a response from an issuer or a DNS provider, after which the item is re-queued. A value of 0 "+
+		"applies no timeout.")
+	fs.DurationVar(&c.ProgressDeadline, "progress-deadline", c.ProgressDeadline, ""+
+		"The maximum amount of time a controller with items in its workqueue may go without successfully "+
+		"processing one before it is reported as not ready on the /readyz endpoint. A value of 0 disables "+
+		"this check.")
 	fs.StringVar(&c.ClusterResourceNamespace, "cluster-resource-namespace", c.ClusterResourceNamespace, ""+
 		"Namespace to store resources owned by cluster scoped resources such as ClusterIssuer in. "+
 		"This must be specified if ClusterIssuers are enabled.")
@@ -126,6 +143,11 @@ func AddConfigFlags(fs *pflag.FlagSet, c *config.ControllerConfiguration) {
 	fs.BoolVar(&c.ACMEHTTP01Config.SolverRunAsNonRoot, "acme-http01-solver-run-as-non-root", c.ACMEHTTP01Config.SolverRunAsNonRoot, ""+
 		"Defines the ability to run the http01 solver as root for troubleshooting issues")
 
+	fs.BoolVar(&c.ACMEHTTP01Config.SolverSharedPods, "acme-http01-solver-shared-pods", c.ACMEHTTP01Config.SolverSharedPods, ""+
+		"If true, the HTTP01 Challenges of an Order are solved by a single solver pod, which serves each "+
+		"Challenge's key authorization from a ConfigMap, rather than by one solver pod per Challenge. "+
+		"This reduces the number of pods needed for Certificates with many DNS names.")
+
 	fs.StringSliceVar(&c.ACMEHTTP01Config.SolverNameservers, "acme-http01-solver-nameservers",
 		c.ACMEHTTP01Config.SolverNameservers, "A list of comma separated dns server endpoints used for "+
 			"ACME HTTP01 check requests. This should be a list containing host and "+
@@ -164,6 +186,14 @@ func AddConfigFlags(fs *pflag.FlagSet, c *config.ControllerConfiguration) {
 	fs.DurationVar(&c.ACMEDNS01Config.CheckRetryPeriod, "dns01-check-retry-period", c.ACMEDNS01Config.CheckRetryPeriod, ""+
 		"The duration the controller should wait between a propagation check. Despite the name, this flag is used to configure the wait period for both DNS01 and HTTP01 challenge propagation checks. For DNS01 challenges the propagation check verifies that a TXT record with the challenge token has been created. For HTTP01 challenges the propagation check verifies that the challenge token is served at the challenge URL."+
 		"This should be a valid duration string, for example 180s or 1h")
+	fs.DurationVar(&c.ACMEDNS01Config.OrphanedRecordSweepInterval, "dns01-orphaned-record-sweep-interval", c.ACMEDNS01Config.OrphanedRecordSweepInterval, ""+
+		"The interval at which the DNS providers of ACME issuers are swept for DNS01 challenge TXT records which were never "+
+		"cleaned up, e.g. because the controller was restarted between presenting and cleaning up a challenge. "+
+		"Only DNS providers which can list their records are swept, and only the records presented by cert-manager while the sweeper "+
+		"is enabled are deleted. A value of 0 disables the sweeper.")
+	fs.DurationVar(&c.ACMEDNS01Config.OrphanedRecordMinAge, "dns01-orphaned-record-min-age", c.ACMEDNS01Config.OrphanedRecordMinAge, ""+
+		"The minimum amount of time for which a DNS01 challenge TXT record must have been found without a corresponding "+
+		"Challenge before it is deleted by the sweeper, see --dns01-orphaned-record-sweep-interval.")
 
 	fs.BoolVar(&c.EnableCertificateOwnerRef, "enable-certificate-owner-ref", c.EnableCertificateOwnerRef, ""+
 		"Whether to set the certificate resource as an owner of secret where the tls certificate is stored. "+
@@ -175,11 +205,81 @@ func AddConfigFlags(fs *pflag.FlagSet, c *config.ControllerConfiguration) {
 		"from Certificate to CertificateRequest and Order, as well as from CertificateSigningRequest to Order, by passing a list of annotation key prefixes."+
 		"A prefix starting with a dash(-) specifies an annotation that shouldn't be copied. Example: '*,-kubectl.kuberenetes.io/'- all annotations"+
 		"will be copied apart from the ones where the key is prefixed with 'kubectl.kubernetes.io/'.")
+	fs.Var(cliflag.NewMapStringString(&c.CertificateRequestEncodings), "certificate-request-encodings", ""+
+		"A set of issuer-group=encoding pairs configuring how the CSR in spec.request of CertificateRequests "+
+		"created for Certificates is encoded, for external issuers which cannot parse the default encoding. "+
+		"Supported encodings are PEM (the default), LegacyPEM, which uses the 'NEW CERTIFICATE REQUEST' PEM block type, "+
+		"and DER, which stores the DER encoded CSR without PEM armor. Example: 'example.com=LegacyPEM'.")
+	fs.DurationVar(&c.IssuanceTimeout, "issuance-timeout", c.IssuanceTimeout, ""+
+		"The maximum amount of time a CertificateRequest may go without any status progress before the issuance "+
+		"attempt is failed and retried. Can be overridden per Certificate with the 'cert-manager.io/issuance-timeout' "+
+		"annotation. A value of 0 disables the timeout.")
+	fs.DurationVar(&c.ClockSkewTolerance, "clock-skew-tolerance", c.ClockSkewTolerance, ""+
+		"The maximum amount of time by which an issued certificate's NotBefore may be in the future when it is "+
+		"stored in the Certificate's Secret. Certificates which are not yet valid beyond this tolerance are only "+
+		"stored once they are. A value of 0 means that certificates are only stored once their NotBefore has passed.")
+	fs.DurationVar(&c.MaxNotBeforeWait, "max-not-before-wait", c.MaxNotBeforeWait, ""+
+		"The maximum amount of time the controller waits for an issued certificate to become valid, beyond the "+
+		"clock skew tolerance, before storing it. The issuance of certificates which are not valid until later fails. "+
+		"A value of 0 fails the issuance of any certificate which is not yet valid beyond the clock skew tolerance.")
+	fs.BoolVar(&c.VerifyIssuedCertificates, "verify-issued-certificates", c.VerifyIssuedCertificates, ""+
+		"Whether certificates returned by issuers are verified before they are stored in the Certificate's Secret. "+
+		"Certificates which do not parse, lack the requested subject alternative names or have expired fail the "+
+		"CertificateRequest and leave the existing Secret untouched. Only disable this for issuers which return "+
+		"certificates that cannot pass these checks.")
+	fs.BoolVar(&c.VerifyIssuedCertificateChain, "verify-issued-certificate-chain", c.VerifyIssuedCertificateChain, ""+
+		"Whether the certificate chain returned by issuers is also verified against the CA certificate stored in the "+
+		"Secret's ca.crt, if any. Only used if --verify-issued-certificates is enabled.")
+	fs.BoolVar(&c.MigrateSecretAnnotations, "migrate-secret-annotations", c.MigrateSecretAnnotations, ""+
+		"Whether the issuer annotations of Secrets written by older versions of cert-manager, e.g. with an empty "+
+		"issuer group or with the deprecated certmanager.k8s.io keys, are rewritten into their current form so that "+
+		"their Certificates are not re-issued after an upgrade. Secrets are updated in place at a limited rate, "+
+		"their certificate data is not changed, and the migration resumes where it left off after a restart.")
+	fs.IntVar(&c.MaxIssuanceFailureEvents, "max-issuance-failure-events", c.MaxIssuanceFailureEvents, ""+
+		"The maximum number of Certificates for which an Event is recorded when they fail to be issued by the same "+
+		"issuer with the same reason within --issuance-failure-event-window. Failures of further Certificates are "+
+		"only recorded in their conditions, and summarised by an Event on the issuer at most once per window. "+
+		"A value of 0 records an Event for every failure.")
+	fs.DurationVar(&c.IssuanceFailureEventWindow, "issuance-failure-event-window", c.IssuanceFailureEventWindow, ""+
+		"The window over which Certificates failing to be issued by the same issuer with the same reason are "+
+		"counted, see --max-issuance-failure-events.")
+	fs.IntVar(&c.IssuanceHistoryLimit, "issuance-history-limit", c.IssuanceHistoryLimit, ""+
+		"The number of the most recent issuance attempts recorded in the status.issuanceHistory of each "+
+		"Certificate. A value of 0 disables recording the issuance history.")
+	fs.IntVar(&c.MaxSecretSize, "max-secret-size", c.MaxSecretSize, ""+
+		"The maximum size in bytes of the data written to a Certificate's Secret. Issuance fails with the "+
+		"reason SecretTooLarge rather than writing a larger Secret, which the API server would reject. "+
+		"A value of 0 disables the check.")
+	fs.StringVar(&c.DefaultPrivateKeyAlgorithm, "default-private-key-algorithm", c.DefaultPrivateKeyAlgorithm, ""+
+		"The private key algorithm used for Certificates which do not set spec.privateKey.algorithm. "+
+		"One of RSA, ECDSA or Ed25519. If empty, RSA is used. Changing this does not cause existing "+
+		"certificates to be re-issued.")
+	fs.IntVar(&c.DefaultPrivateKeySize, "default-private-key-size", c.DefaultPrivateKeySize, ""+
+		"The private key size used for Certificates which do not set spec.privateKey.algorithm. "+
+		"Requires --default-private-key-algorithm to be set. If 0, the default size for the algorithm is used.")
+	fs.StringVar(&c.DefaultPrivateKeyRotationPolicy, "default-private-key-rotation-policy", c.DefaultPrivateKeyRotationPolicy, ""+
+		"The private key rotation policy used for Certificates which do not set spec.privateKey.rotationPolicy. "+
+		"One of Never or Always. If empty, Never is used.")
+	fs.StringVar(&c.ExternalPrivateKeySignerAddress, "external-private-key-signer-address", c.ExternalPrivateKeySignerAddress, ""+
+		"The address of the signer plugin used to create and use private keys for Certificates which set "+
+		"spec.privateKey.external, such as unix:///run/kms/signer.sock. Requires the ExternalPrivateKeys feature gate.")
+	fs.DurationVar(&c.ACMEAccountVerificationInterval, "acme-account-verification-interval", c.ACMEAccountVerificationInterval, ""+
+		"The interval at which the ACME account of each ACME issuer is verified with the ACME server, so that "+
+		"accounts which have been deactivated or revoked are reported in the status of the issuer. A value of 0 "+
+		"only verifies the account when the issuer is registered or changed.")
 	fs.Var(cliflag.NewMapStringBool(&c.FeatureGates), "feature-gates", "A set of key=value pairs that describe feature gates for alpha/experimental features. "+
 		"Options are:\n"+strings.Join(utilfeature.DefaultFeatureGate.KnownFeatures(), "\n"))
 
 	fs.IntVar(&c.NumberOfConcurrentWorkers, "concurrent-workers", c.NumberOfConcurrentWorkers, ""+
 		"The number of concurrent workers for each controller.")
+	fs.IntVar(&c.ConcurrentCertificateSyncs, "concurrent-certificate-syncs", c.ConcurrentCertificateSyncs, ""+
+		"The number of concurrent workers for each of the certificates-* controllers. If 0, --concurrent-workers is used.")
+	fs.IntVar(&c.ConcurrentCertificateRequestSyncs, "concurrent-certificaterequest-syncs", c.ConcurrentCertificateRequestSyncs, ""+
+		"The number of concurrent workers for each of the certificaterequests-* controllers. If 0, --concurrent-workers is used.")
+	fs.IntVar(&c.ConcurrentOrderSyncs, "concurrent-order-syncs", c.ConcurrentOrderSyncs, ""+
+		"The number of concurrent workers for the orders controller. If 0, --concurrent-workers is used.")
+	fs.IntVar(&c.ConcurrentChallengeSyncs, "concurrent-challenge-syncs", c.ConcurrentChallengeSyncs, ""+
+		"The number of concurrent workers for the challenges controller. If 0, --concurrent-workers is used.")
 	fs.IntVar(&c.MaxConcurrentChallenges, "max-concurrent-challenges", c.MaxConcurrentChallenges, ""+
 		"The maximum number of challenges that can be scheduled as 'processing' at once.")
 
@@ -207,6 +307,15 @@ func AddConfigFlags(fs *pflag.FlagSet, c *config.ControllerConfiguration) {
 		"Minimum TLS version supported. If omitted, the default Go minimum version will be used. "+
 			"Possible values: "+strings.Join(tlsPossibleVersions, ", "))
 
+	fs.StringSliceVar(&c.OutboundTLSConfig.CipherSuites, "outbound-tls-cipher-suites", c.OutboundTLSConfig.CipherSuites,
+		"Comma-separated list of TLS 1.2 cipher suites used for connections to ACME servers, Vault, Venafi and DNS providers. "+
+			"Can be overridden per issuer. If omitted, the default Go cipher suites will be used. "+
+			"Possible values: "+strings.Join(tlsCipherPossibleValues, ","))
+	fs.StringVar(&c.OutboundTLSConfig.MinTLSVersion, "outbound-tls-min-version", c.OutboundTLSConfig.MinTLSVersion,
+		"Minimum TLS version used for connections to ACME servers, Vault, Venafi and DNS providers. "+
+			"Can be overridden per issuer. If omitted, the default Go minimum version will be used. "+
+			"Possible values: "+strings.Join(tlsPossibleVersions, ", "))
+
 	// The healthz related flags are given the prefix "internal-" and are hidden,
 	// to discourage users from overriding them.
 	// We may want to rename or remove these flags when we have feedback from
@@ -260,5 +369,25 @@ func EnabledControllers(o *config.ControllerConfiguration) sets.Set[string] {
 		enabled = enabled.Insert(shimgatewaycontroller.ControllerName)
 	}
 
+	if o.MigrateSecretAnnotations {
+		logf.Log.Info("enabling the migration of legacy issuer annotations on Secrets")
+		enabled = enabled.Insert(secretannotationmigrationcontroller.ControllerName)
+	}
+
 	return enabled
 }
+
+// ControllerDependencyWarnings returns a warning for each enabled controller
+// which depends on a controller that has been disabled, for example when the
+// challenges controller is disabled but the orders controller is not.
+func ControllerDependencyWarnings(enabled sets.Set[string]) []string {
+	var warnings []string
+	for _, controller := range sets.List(enabled) {
+		for _, dependency := range defaults.ControllerDependencies[controller] {
+			if !enabled.Has(dependency) {
+				warnings = append(warnings, fmt.Sprintf("controller %q is enabled but depends on controller %q, which is disabled", controller, dependency))
+			}
+		}
+	}
+	return warnings
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package options
 
 import (
+	"reflect"
 	"testing"
 
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -27,8 +28,9 @@ import (
 
 func TestEnabledControllers(t *testing.T) {
 	tests := map[string]struct {
-		controllers []string
-		expEnabled  sets.Set[string]
+		controllers              []string
+		migrateSecretAnnotations bool
+		expEnabled               sets.Set[string]
 	}{
 		"if no controllers enabled, return empty": {
 			controllers: []string{},
@@ -58,12 +60,18 @@ func TestEnabledControllers(t *testing.T) {
 			controllers: []string{"foo", "-bar"},
 			expEnabled:  sets.New("foo"),
 		},
+		"if secret annotations are migrated, enable the migration controller": {
+			controllers:              []string{"*"},
+			migrateSecretAnnotations: true,
+			expEnabled:               sets.New(defaults.DefaultEnabledControllers...).Insert("secret-annotation-migration"),
+		},
 	}
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
 			o := config.ControllerConfiguration{
-				Controllers: test.controllers,
+				Controllers:              test.controllers,
+				MigrateSecretAnnotations: test.migrateSecretAnnotations,
 			}
 
 			got := EnabledControllers(&o)
@@ -74,3 +82,51 @@ func TestEnabledControllers(t *testing.T) {
 		})
 	}
 }
+
+func TestControllerDependencyWarnings(t *testing.T) {
+	tests := map[string]struct {
+		controllers []string
+		expWarnings []string
+	}{
+		"all default controllers have their dependencies enabled": {
+			controllers: []string{"*"},
+		},
+		"disabling the ACME controllers and ingress-shim together": {
+			controllers: []string{"*", "-ingress-shim", "-orders", "-challenges", "-certificaterequests-issuer-acme"},
+		},
+		"disabling challenges while orders is enabled": {
+			controllers: []string{"*", "-challenges"},
+			expWarnings: []string{
+				`controller "orders" is enabled but depends on controller "challenges", which is disabled`,
+			},
+		},
+		"disabling orders and challenges while the ACME issuer is enabled": {
+			controllers: []string{"*", "-ingress-shim", "-orders", "-challenges"},
+			expWarnings: []string{
+				`controller "certificaterequests-issuer-acme" is enabled but depends on controller "orders", which is disabled`,
+			},
+		},
+		"enabling ingress-shim without the certificates controllers": {
+			controllers: []string{"ingress-shim", "certificates-trigger"},
+			expWarnings: []string{
+				`controller "certificates-trigger" is enabled but depends on controller "certificates-key-manager", which is disabled`,
+				`controller "certificates-trigger" is enabled but depends on controller "certificates-request-manager", which is disabled`,
+				`controller "certificates-trigger" is enabled but depends on controller "certificates-issuing", which is disabled`,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := config.ControllerConfiguration{
+				Controllers: test.controllers,
+			}
+
+			got := ControllerDependencyWarnings(EnabledControllers(&o))
+			if !reflect.DeepEqual(got, test.expWarnings) {
+				t.Errorf("got unexpected warnings, exp=%q got=%q",
+					test.expWarnings, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package install
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apiextensions-apiserver/pkg/registry/customresource/tableconvertor"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	"github.com/cert-manager/cert-manager/internal/test/paths"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+// TestPrinterColumns asserts the columns printed by kubectl for the ACME
+// resources, using the additionalPrinterColumns defined in their CRDs.
+func TestPrinterColumns(t *testing.T) {
+	created := metav1.NewTime(time.Now().Add(-2 * time.Hour))
+	retryAfter := metav1.NewTime(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+
+	tests := map[string]struct {
+		crd     string
+		obj     runtime.Object
+		wide    bool
+		expCols []string
+		expRow  []interface{}
+	}{
+		"Order": {
+			crd: "orders",
+			obj: &cmacme.Order{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", CreationTimestamp: created},
+				Spec: cmacme.OrderSpec{
+					DNSNames:  []string{"example.com", "www.example.com"},
+					IssuerRef: cmmeta.ObjectReference{Name: "letsencrypt"},
+				},
+				Status: cmacme.OrderStatus{State: cmacme.Pending},
+			},
+			expCols: []string{"Name", "State", "Retry After", "Age"},
+			expRow:  []interface{}{"test", "pending", nil, "120m"},
+		},
+		"Order that hit a rate limit, wide": {
+			crd: "orders",
+			obj: &cmacme.Order{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", CreationTimestamp: created},
+				Spec: cmacme.OrderSpec{
+					DNSNames:  []string{"example.com", "www.example.com"},
+					IssuerRef: cmmeta.ObjectReference{Name: "letsencrypt"},
+				},
+				Status: cmacme.OrderStatus{
+					State:           cmacme.Errored,
+					Reason:          "Failed to create Order: 429 urn:ietf:params:acme:error:rateLimited: too many certificates",
+					RetryAfter:      &retryAfter,
+					LastProblemType: "urn:ietf:params:acme:error:rateLimited",
+				},
+			},
+			wide:    true,
+			expCols: []string{"Name", "State", "Domains", "Issuer", "Reason", "Problem", "Retry After", "Age"},
+			expRow: []interface{}{
+				"test",
+				"errored",
+				`["example.com","www.example.com"]`,
+				"letsencrypt",
+				"Failed to create Order: 429 urn:ietf:params:acme:error:rateLimited: too many certificates",
+				"urn:ietf:params:acme:error:rateLimited",
+				"2024-01-01T12:00:00Z",
+				"120m",
+			},
+		},
+		"Challenge": {
+			crd: "challenges",
+			obj: &cmacme.Challenge{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", CreationTimestamp: created},
+				Spec: cmacme.ChallengeSpec{
+					DNSName: "example.com",
+					Type:    cmacme.ACMEChallengeTypeDNS01,
+				},
+				Status: cmacme.ChallengeStatus{
+					State:     cmacme.Pending,
+					Presented: true,
+				},
+			},
+			expCols: []string{"Name", "State", "Domain", "Type", "Presented", "Age"},
+			expRow:  []interface{}{"test", "pending", "example.com", "DNS-01", true, "120m"},
+		},
+		"Challenge, wide": {
+			crd: "challenges",
+			obj: &cmacme.Challenge{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", CreationTimestamp: created},
+				Spec: cmacme.ChallengeSpec{
+					DNSName: "example.com",
+					Type:    cmacme.ACMEChallengeTypeHTTP01,
+				},
+				Status: cmacme.ChallengeStatus{
+					State:  cmacme.Invalid,
+					Reason: "Error accepting authorization",
+				},
+			},
+			wide:    true,
+			expCols: []string{"Name", "State", "Domain", "Type", "Presented", "Reason", "Age"},
+			expRow:  []interface{}{"test", "invalid", "example.com", "HTTP-01", false, "Error accepting authorization", "120m"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			convertor, err := tableconvertor.New(printerColumns(t, test.crd))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			table, err := convertor.ConvertToTable(context.Background(), test.obj, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(table.Rows) != 1 {
+				t.Fatalf("expected a single row, got %d", len(table.Rows))
+			}
+
+			// Only keep the columns kubectl prints, i.e. priority 0 columns
+			// unless wide output is requested.
+			var cols []string
+			var row []interface{}
+			for i, col := range table.ColumnDefinitions {
+				if col.Priority > 0 && !test.wide {
+					continue
+				}
+				cols = append(cols, col.Name)
+				row = append(row, table.Rows[0].Cells[i])
+			}
+
+			if !reflect.DeepEqual(cols, test.expCols) {
+				t.Errorf("unexpected columns, exp=%q got=%q", test.expCols, cols)
+			}
+			if !reflect.DeepEqual(row, test.expRow) {
+				t.Errorf("unexpected row, exp=%#v got=%#v", test.expRow, row)
+			}
+		})
+	}
+}
+
+// printerColumns reads the additionalPrinterColumns of the served version of
+// the named CRD in deploy/crds.
+func printerColumns(t *testing.T, name string) []apiextensionsv1.CustomResourceColumnDefinition {
+	f, err := os.Open(filepath.Join(paths.ModuleRootDir, "deploy", "crds", "crd-"+name+".yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var crd apiextensionsv1.CustomResourceDefinition
+	if err := yaml.NewYAMLOrJSONDecoder(f, 4096).Decode(&crd); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, version := range crd.Spec.Versions {
+		if version.Served && version.Storage {
+			return version.AdditionalPrinterColumns
+		}
+	}
+
+	t.Fatalf("no served storage version found in CRD %q", crd.Name)
+	return nil
+}
@@ -87,6 +87,11 @@ type OrderStatus struct {
 	// This is used to obtain certificates for this order once it has been completed.
 	FinalizeURL string
 
+	// CertificateURL of the Order.
+	// This is the URL the issued certificate is downloaded from, and is set
+	// once the order has been finalized.
+	CertificateURL string
+
 	// Certificate is a copy of the PEM encoded certificate for this Order.
 	// This field will be populated after the order has been successfully
 	// finalized with the ACME server, and the order has transitioned to the
@@ -109,6 +114,18 @@ type OrderStatus struct {
 	// FailureTime stores the time that this order failed.
 	// This is used to influence garbage collection and back-off.
 	FailureTime *metav1.Time
+
+	// RetryAfter is the time after which the ACME server asked for the last
+	// failed request for this order to be retried, as indicated by the
+	// Retry-After header of its response (e.g. when a rate limit was hit).
+	// It is cleared once the order makes progress again.
+	RetryAfter *metav1.Time
+
+	// LastProblemType is the type of the last problem document returned by the
+	// ACME server for this order, e.g.
+	// `urn:ietf:params:acme:error:rateLimited`.
+	// It is cleared once the order makes progress again.
+	LastProblemType string
 }
 
 // ACMEAuthorization contains data returned from the ACME server on an
@@ -94,6 +94,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEChallengeSolverHTTP01Custom)(nil), (*acme.ACMEChallengeSolverHTTP01Custom)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEChallengeSolverHTTP01Custom_To_acme_ACMEChallengeSolverHTTP01Custom(a.(*v1.ACMEChallengeSolverHTTP01Custom), b.(*acme.ACMEChallengeSolverHTTP01Custom), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverHTTP01Custom)(nil), (*v1.ACMEChallengeSolverHTTP01Custom)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverHTTP01Custom_To_v1_ACMEChallengeSolverHTTP01Custom(a.(*acme.ACMEChallengeSolverHTTP01Custom), b.(*v1.ACMEChallengeSolverHTTP01Custom), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.ACMEChallengeSolverHTTP01GatewayHTTPRoute)(nil), (*acme.ACMEChallengeSolverHTTP01GatewayHTTPRoute)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_acme_ACMEChallengeSolverHTTP01GatewayHTTPRoute(a.(*v1.ACMEChallengeSolverHTTP01GatewayHTTPRoute), b.(*acme.ACMEChallengeSolverHTTP01GatewayHTTPRoute), scope)
 	}); err != nil {
@@ -264,6 +274,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEIssuerDirectoryStatus)(nil), (*acme.ACMEIssuerDirectoryStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEIssuerDirectoryStatus_To_acme_ACMEIssuerDirectoryStatus(a.(*v1.ACMEIssuerDirectoryStatus), b.(*acme.ACMEIssuerDirectoryStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEIssuerDirectoryStatus)(nil), (*v1.ACMEIssuerDirectoryStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEIssuerDirectoryStatus_To_v1_ACMEIssuerDirectoryStatus(a.(*acme.ACMEIssuerDirectoryStatus), b.(*v1.ACMEIssuerDirectoryStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.ACMEIssuerStatus)(nil), (*acme.ACMEIssuerStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_ACMEIssuerStatus_To_acme_ACMEIssuerStatus(a.(*v1.ACMEIssuerStatus), b.(*acme.ACMEIssuerStatus), scope)
 	}); err != nil {
@@ -511,6 +531,8 @@ func Convert_acme_ACMEChallengeSolver_To_v1_ACMEChallengeSolver(in *acme.ACMECha
 
 func autoConvert_v1_ACMEChallengeSolverDNS01_To_acme_ACMEChallengeSolverDNS01(in *v1.ACMEChallengeSolverDNS01, out *acme.ACMEChallengeSolverDNS01, s conversion.Scope) error {
 	out.CNAMEStrategy = acme.CNAMEStrategy(in.CNAMEStrategy)
+	out.InitialDelay = (*pkgapismetav1.Duration)(unsafe.Pointer(in.InitialDelay))
+	out.PropagationTimeout = (*pkgapismetav1.Duration)(unsafe.Pointer(in.PropagationTimeout))
 	if in.Akamai != nil {
 		in, out := &in.Akamai, &out.Akamai
 		*out = new(acme.ACMEIssuerDNS01ProviderAkamai)
@@ -594,6 +616,8 @@ func Convert_v1_ACMEChallengeSolverDNS01_To_acme_ACMEChallengeSolverDNS01(in *v1
 
 func autoConvert_acme_ACMEChallengeSolverDNS01_To_v1_ACMEChallengeSolverDNS01(in *acme.ACMEChallengeSolverDNS01, out *v1.ACMEChallengeSolverDNS01, s conversion.Scope) error {
 	out.CNAMEStrategy = v1.CNAMEStrategy(in.CNAMEStrategy)
+	out.InitialDelay = (*pkgapismetav1.Duration)(unsafe.Pointer(in.InitialDelay))
+	out.PropagationTimeout = (*pkgapismetav1.Duration)(unsafe.Pointer(in.PropagationTimeout))
 	if in.Akamai != nil {
 		in, out := &in.Akamai, &out.Akamai
 		*out = new(v1.ACMEIssuerDNS01ProviderAkamai)
@@ -678,6 +702,7 @@ func Convert_acme_ACMEChallengeSolverDNS01_To_v1_ACMEChallengeSolverDNS01(in *ac
 func autoConvert_v1_ACMEChallengeSolverHTTP01_To_acme_ACMEChallengeSolverHTTP01(in *v1.ACMEChallengeSolverHTTP01, out *acme.ACMEChallengeSolverHTTP01, s conversion.Scope) error {
 	out.Ingress = (*acme.ACMEChallengeSolverHTTP01Ingress)(unsafe.Pointer(in.Ingress))
 	out.GatewayHTTPRoute = (*acme.ACMEChallengeSolverHTTP01GatewayHTTPRoute)(unsafe.Pointer(in.GatewayHTTPRoute))
+	out.Custom = (*acme.ACMEChallengeSolverHTTP01Custom)(unsafe.Pointer(in.Custom))
 	return nil
 }
 
@@ -689,6 +714,7 @@ func Convert_v1_ACMEChallengeSolverHTTP01_To_acme_ACMEChallengeSolverHTTP01(in *
 func autoConvert_acme_ACMEChallengeSolverHTTP01_To_v1_ACMEChallengeSolverHTTP01(in *acme.ACMEChallengeSolverHTTP01, out *v1.ACMEChallengeSolverHTTP01, s conversion.Scope) error {
 	out.Ingress = (*v1.ACMEChallengeSolverHTTP01Ingress)(unsafe.Pointer(in.Ingress))
 	out.GatewayHTTPRoute = (*v1.ACMEChallengeSolverHTTP01GatewayHTTPRoute)(unsafe.Pointer(in.GatewayHTTPRoute))
+	out.Custom = (*v1.ACMEChallengeSolverHTTP01Custom)(unsafe.Pointer(in.Custom))
 	return nil
 }
 
@@ -697,6 +723,26 @@ func Convert_acme_ACMEChallengeSolverHTTP01_To_v1_ACMEChallengeSolverHTTP01(in *
 	return autoConvert_acme_ACMEChallengeSolverHTTP01_To_v1_ACMEChallengeSolverHTTP01(in, out, s)
 }
 
+func autoConvert_v1_ACMEChallengeSolverHTTP01Custom_To_acme_ACMEChallengeSolverHTTP01Custom(in *v1.ACMEChallengeSolverHTTP01Custom, out *acme.ACMEChallengeSolverHTTP01Custom, s conversion.Scope) error {
+	out.ConfigMapName = in.ConfigMapName
+	return nil
+}
+
+// Convert_v1_ACMEChallengeSolverHTTP01Custom_To_acme_ACMEChallengeSolverHTTP01Custom is an autogenerated conversion function.
+func Convert_v1_ACMEChallengeSolverHTTP01Custom_To_acme_ACMEChallengeSolverHTTP01Custom(in *v1.ACMEChallengeSolverHTTP01Custom, out *acme.ACMEChallengeSolverHTTP01Custom, s conversion.Scope) error {
+	return autoConvert_v1_ACMEChallengeSolverHTTP01Custom_To_acme_ACMEChallengeSolverHTTP01Custom(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverHTTP01Custom_To_v1_ACMEChallengeSolverHTTP01Custom(in *acme.ACMEChallengeSolverHTTP01Custom, out *v1.ACMEChallengeSolverHTTP01Custom, s conversion.Scope) error {
+	out.ConfigMapName = in.ConfigMapName
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverHTTP01Custom_To_v1_ACMEChallengeSolverHTTP01Custom is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverHTTP01Custom_To_v1_ACMEChallengeSolverHTTP01Custom(in *acme.ACMEChallengeSolverHTTP01Custom, out *v1.ACMEChallengeSolverHTTP01Custom, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverHTTP01Custom_To_v1_ACMEChallengeSolverHTTP01Custom(in, out, s)
+}
+
 func autoConvert_v1_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_acme_ACMEChallengeSolverHTTP01GatewayHTTPRoute(in *v1.ACMEChallengeSolverHTTP01GatewayHTTPRoute, out *acme.ACMEChallengeSolverHTTP01GatewayHTTPRoute, s conversion.Scope) error {
 	out.ServiceType = corev1.ServiceType(in.ServiceType)
 	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
@@ -802,6 +848,8 @@ func autoConvert_v1_ACMEChallengeSolverHTTP01IngressPodSpec_To_acme_ACMEChalleng
 	out.PriorityClassName = in.PriorityClassName
 	out.ServiceAccountName = in.ServiceAccountName
 	out.ImagePullSecrets = *(*[]corev1.LocalObjectReference)(unsafe.Pointer(&in.ImagePullSecrets))
+	out.Image = in.Image
+	out.ImagePullPolicy = corev1.PullPolicy(in.ImagePullPolicy)
 	return nil
 }
 
@@ -817,6 +865,8 @@ func autoConvert_acme_ACMEChallengeSolverHTTP01IngressPodSpec_To_v1_ACMEChalleng
 	out.PriorityClassName = in.PriorityClassName
 	out.ServiceAccountName = in.ServiceAccountName
 	out.ImagePullSecrets = *(*[]corev1.LocalObjectReference)(unsafe.Pointer(&in.ImagePullSecrets))
+	out.Image = in.Image
+	out.ImagePullPolicy = corev1.PullPolicy(in.ImagePullPolicy)
 	return nil
 }
 
@@ -912,7 +962,25 @@ func autoConvert_v1_ACMEIssuer_To_acme_ACMEIssuer(in *v1.ACMEIssuer, out *acme.A
 	out.Server = in.Server
 	out.PreferredChain = in.PreferredChain
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if in.CABundleSecretRef != nil {
+		in, out := &in.CABundleSecretRef, &out.CABundleSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CABundleSecretRef = nil
+	}
 	out.SkipTLSVerify = in.SkipTLSVerify
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(meta.ClientTLSConfig)
+		if err := metav1.Convert_v1_ClientTLSConfig_To_meta_ClientTLSConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TLS = nil
+	}
 	if in.ExternalAccountBinding != nil {
 		in, out := &in.ExternalAccountBinding, &out.ExternalAccountBinding
 		*out = new(acme.ACMEExternalAccountBinding)
@@ -938,6 +1006,9 @@ func autoConvert_v1_ACMEIssuer_To_acme_ACMEIssuer(in *v1.ACMEIssuer, out *acme.A
 	}
 	out.DisableAccountKeyGeneration = in.DisableAccountKeyGeneration
 	out.EnableDurationFeature = in.EnableDurationFeature
+	out.DirectoryMaxAge = (*pkgapismetav1.Duration)(unsafe.Pointer(in.DirectoryMaxAge))
+	out.AllowedZones = *(*[]string)(unsafe.Pointer(&in.AllowedZones))
+	out.RequireEmbeddedSCTs = in.RequireEmbeddedSCTs
 	return nil
 }
 
@@ -946,7 +1017,25 @@ func autoConvert_acme_ACMEIssuer_To_v1_ACMEIssuer(in *acme.ACMEIssuer, out *v1.A
 	out.Server = in.Server
 	out.PreferredChain = in.PreferredChain
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if in.CABundleSecretRef != nil {
+		in, out := &in.CABundleSecretRef, &out.CABundleSecretRef
+		*out = new(apismetav1.SecretKeySelector)
+		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CABundleSecretRef = nil
+	}
 	out.SkipTLSVerify = in.SkipTLSVerify
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(apismetav1.ClientTLSConfig)
+		if err := metav1.Convert_meta_ClientTLSConfig_To_v1_ClientTLSConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TLS = nil
+	}
 	if in.ExternalAccountBinding != nil {
 		in, out := &in.ExternalAccountBinding, &out.ExternalAccountBinding
 		*out = new(v1.ACMEExternalAccountBinding)
@@ -972,6 +1061,9 @@ func autoConvert_acme_ACMEIssuer_To_v1_ACMEIssuer(in *acme.ACMEIssuer, out *v1.A
 	}
 	out.DisableAccountKeyGeneration = in.DisableAccountKeyGeneration
 	out.EnableDurationFeature = in.EnableDurationFeature
+	out.DirectoryMaxAge = (*pkgapismetav1.Duration)(unsafe.Pointer(in.DirectoryMaxAge))
+	out.AllowedZones = *(*[]string)(unsafe.Pointer(&in.AllowedZones))
+	out.RequireEmbeddedSCTs = in.RequireEmbeddedSCTs
 	return nil
 }
 
@@ -1056,6 +1148,7 @@ func autoConvert_v1_ACMEIssuerDNS01ProviderAzureDNS_To_acme_ACMEIssuerDNS01Provi
 	out.HostedZoneName = in.HostedZoneName
 	out.Environment = acme.AzureDNSEnvironment(in.Environment)
 	out.ManagedIdentity = (*acme.AzureManagedIdentity)(unsafe.Pointer(in.ManagedIdentity))
+	out.TTL = (*int64)(unsafe.Pointer(in.TTL))
 	return nil
 }
 
@@ -1081,6 +1174,7 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderAzureDNS_To_v1_ACMEIssuerDNS01Provi
 	out.HostedZoneName = in.HostedZoneName
 	out.Environment = v1.AzureDNSEnvironment(in.Environment)
 	out.ManagedIdentity = (*v1.AzureManagedIdentity)(unsafe.Pointer(in.ManagedIdentity))
+	out.TTL = (*int64)(unsafe.Pointer(in.TTL))
 	return nil
 }
 
@@ -1101,6 +1195,8 @@ func autoConvert_v1_ACMEIssuerDNS01ProviderCloudDNS_To_acme_ACMEIssuerDNS01Provi
 	}
 	out.Project = in.Project
 	out.HostedZoneName = in.HostedZoneName
+	out.TTL = (*int64)(unsafe.Pointer(in.TTL))
+	out.ImpersonateServiceAccount = in.ImpersonateServiceAccount
 	return nil
 }
 
@@ -1121,6 +1217,8 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderCloudDNS_To_v1_ACMEIssuerDNS01Provi
 	}
 	out.Project = in.Project
 	out.HostedZoneName = in.HostedZoneName
+	out.TTL = (*int64)(unsafe.Pointer(in.TTL))
+	out.ImpersonateServiceAccount = in.ImpersonateServiceAccount
 	return nil
 }
 
@@ -1256,7 +1354,9 @@ func autoConvert_v1_ACMEIssuerDNS01ProviderRoute53_To_acme_ACMEIssuerDNS01Provid
 	}
 	out.Role = in.Role
 	out.HostedZoneID = in.HostedZoneID
+	out.PreferPrivateZones = in.PreferPrivateZones
 	out.Region = in.Region
+	out.TTL = (*int64)(unsafe.Pointer(in.TTL))
 	return nil
 }
 
@@ -1282,7 +1382,9 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderRoute53_To_v1_ACMEIssuerDNS01Provid
 	}
 	out.Role = in.Role
 	out.HostedZoneID = in.HostedZoneID
+	out.PreferPrivateZones = in.PreferPrivateZones
 	out.Region = in.Region
+	out.TTL = (*int64)(unsafe.Pointer(in.TTL))
 	return nil
 }
 
@@ -1315,10 +1417,41 @@ func Convert_acme_ACMEIssuerDNS01ProviderWebhook_To_v1_ACMEIssuerDNS01ProviderWe
 	return autoConvert_acme_ACMEIssuerDNS01ProviderWebhook_To_v1_ACMEIssuerDNS01ProviderWebhook(in, out, s)
 }
 
+func autoConvert_v1_ACMEIssuerDirectoryStatus_To_acme_ACMEIssuerDirectoryStatus(in *v1.ACMEIssuerDirectoryStatus, out *acme.ACMEIssuerDirectoryStatus, s conversion.Scope) error {
+	out.NewNonce = in.NewNonce
+	out.NewAccount = in.NewAccount
+	out.NewOrder = in.NewOrder
+	out.RevokeCert = in.RevokeCert
+	out.KeyChange = in.KeyChange
+	return nil
+}
+
+// Convert_v1_ACMEIssuerDirectoryStatus_To_acme_ACMEIssuerDirectoryStatus is an autogenerated conversion function.
+func Convert_v1_ACMEIssuerDirectoryStatus_To_acme_ACMEIssuerDirectoryStatus(in *v1.ACMEIssuerDirectoryStatus, out *acme.ACMEIssuerDirectoryStatus, s conversion.Scope) error {
+	return autoConvert_v1_ACMEIssuerDirectoryStatus_To_acme_ACMEIssuerDirectoryStatus(in, out, s)
+}
+
+func autoConvert_acme_ACMEIssuerDirectoryStatus_To_v1_ACMEIssuerDirectoryStatus(in *acme.ACMEIssuerDirectoryStatus, out *v1.ACMEIssuerDirectoryStatus, s conversion.Scope) error {
+	out.NewNonce = in.NewNonce
+	out.NewAccount = in.NewAccount
+	out.NewOrder = in.NewOrder
+	out.RevokeCert = in.RevokeCert
+	out.KeyChange = in.KeyChange
+	return nil
+}
+
+// Convert_acme_ACMEIssuerDirectoryStatus_To_v1_ACMEIssuerDirectoryStatus is an autogenerated conversion function.
+func Convert_acme_ACMEIssuerDirectoryStatus_To_v1_ACMEIssuerDirectoryStatus(in *acme.ACMEIssuerDirectoryStatus, out *v1.ACMEIssuerDirectoryStatus, s conversion.Scope) error {
+	return autoConvert_acme_ACMEIssuerDirectoryStatus_To_v1_ACMEIssuerDirectoryStatus(in, out, s)
+}
+
 func autoConvert_v1_ACMEIssuerStatus_To_acme_ACMEIssuerStatus(in *v1.ACMEIssuerStatus, out *acme.ACMEIssuerStatus, s conversion.Scope) error {
 	out.URI = in.URI
 	out.LastRegisteredEmail = in.LastRegisteredEmail
 	out.LastPrivateKeyHash = in.LastPrivateKeyHash
+	out.Directory = (*acme.ACMEIssuerDirectoryStatus)(unsafe.Pointer(in.Directory))
+	out.AccountStatus = in.AccountStatus
+	out.LastVerifiedTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.LastVerifiedTime))
 	return nil
 }
 
@@ -1331,6 +1464,9 @@ func autoConvert_acme_ACMEIssuerStatus_To_v1_ACMEIssuerStatus(in *acme.ACMEIssue
 	out.URI = in.URI
 	out.LastRegisteredEmail = in.LastRegisteredEmail
 	out.LastPrivateKeyHash = in.LastPrivateKeyHash
+	out.Directory = (*v1.ACMEIssuerDirectoryStatus)(unsafe.Pointer(in.Directory))
+	out.AccountStatus = in.AccountStatus
+	out.LastVerifiedTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.LastVerifiedTime))
 	return nil
 }
 
@@ -1640,11 +1776,14 @@ func Convert_acme_OrderSpec_To_v1_OrderSpec(in *acme.OrderSpec, out *v1.OrderSpe
 func autoConvert_v1_OrderStatus_To_acme_OrderStatus(in *v1.OrderStatus, out *acme.OrderStatus, s conversion.Scope) error {
 	out.URL = in.URL
 	out.FinalizeURL = in.FinalizeURL
+	out.CertificateURL = in.CertificateURL
 	out.Authorizations = *(*[]acme.ACMEAuthorization)(unsafe.Pointer(&in.Authorizations))
 	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
 	out.State = acme.State(in.State)
 	out.Reason = in.Reason
 	out.FailureTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.FailureTime))
+	out.RetryAfter = (*pkgapismetav1.Time)(unsafe.Pointer(in.RetryAfter))
+	out.LastProblemType = in.LastProblemType
 	return nil
 }
 
@@ -1656,11 +1795,14 @@ func Convert_v1_OrderStatus_To_acme_OrderStatus(in *v1.OrderStatus, out *acme.Or
 func autoConvert_acme_OrderStatus_To_v1_OrderStatus(in *acme.OrderStatus, out *v1.OrderStatus, s conversion.Scope) error {
 	out.URL = in.URL
 	out.FinalizeURL = in.FinalizeURL
+	out.CertificateURL = in.CertificateURL
 	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
 	out.State = v1.State(in.State)
 	out.Reason = in.Reason
 	out.Authorizations = *(*[]v1.ACMEAuthorization)(unsafe.Pointer(&in.Authorizations))
 	out.FailureTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.FailureTime))
+	out.RetryAfter = (*pkgapismetav1.Time)(unsafe.Pointer(in.RetryAfter))
+	out.LastProblemType = in.LastProblemType
 	return nil
 }
 
@@ -19,6 +19,7 @@ package v1alpha2
 import (
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	gwapi "sigs.k8s.io/gateway-api/apis/v1"
 
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
@@ -63,6 +64,17 @@ type ACMEIssuer struct {
 	// +optional
 	CABundle []byte `json:"caBundle,omitempty"`
 
+	// CABundleSecretRef is a reference to a key of a Secret containing a bundle
+	// of PEM CAs which can be used to validate the certificate chain presented
+	// by the ACME server, in addition to CABundle. Updates of the Secret are
+	// picked up without restarting cert-manager.
+	// If `key` is not specified, a default of `ca.crt` will be used.
+	// For ClusterIssuers, the Secret is looked up in the cluster resource
+	// namespace.
+	// Mutually exclusive with SkipTLSVerify.
+	// +optional
+	CABundleSecretRef *cmmeta.SecretKeySelector `json:"caBundleSecretRef,omitempty"`
+
 	// INSECURE: Enables or disables validation of the ACME server TLS certificate.
 	// If true, requests to the ACME server will not have the TLS certificate chain
 	// validated.
@@ -75,6 +87,11 @@ type ACMEIssuer struct {
 	// +optional
 	SkipTLSVerify bool `json:"skipTLSVerify,omitempty"`
 
+	// TLS configures the minimum TLS version and cipher suites used when
+	// connecting to the ACME server, overriding the controller-wide defaults.
+	// +optional
+	TLS *cmmeta.ClientTLSConfig `json:"tls,omitempty"`
+
 	// ExternalAccountBinding is a reference to a CA external account of the ACME
 	// server.
 	// If set, upon registration cert-manager will attempt to associate the given
@@ -113,6 +130,39 @@ type ACMEIssuer struct {
 	// Defaults to false.
 	// +optional
 	EnableDurationFeature bool `json:"enableDurationFeature,omitempty"`
+
+	// DirectoryMaxAge is the maximum amount of time the discovered ACME
+	// directory is cached for before it is fetched again from the server.
+	// The directory is also re-fetched whenever a request to one of its
+	// endpoints returns a 404 or 410 response.
+	// If unset, the directory is cached until such a response is received.
+	// +optional
+	DirectoryMaxAge *metav1.Duration `json:"directoryMaxAge,omitempty"`
+
+	// AllowedZones is a list of DNS zones that the issuer is allowed to request
+	// certificates for. If set, the zone of each DNS name on a
+	// CertificateRequest is looked up using its SOA record before an Order is
+	// created, and the request fails with the reason DomainNotAllowed if the
+	// zone does not match one of these entries. Wildcard names are checked
+	// against their base domain. Entries may contain '*' wildcards, for
+	// example "*.example.com" or "example.*". The check can be skipped for a
+	// Certificate with the "acme.cert-manager.io/skip-allowed-zones-check"
+	// annotation.
+	// If unset, requests are not checked.
+	// +optional
+	AllowedZones []string `json:"allowedZones,omitempty"`
+
+	// RequireEmbeddedSCTs is the minimum number of Signed Certificate
+	// Timestamps (SCTs) which must be embedded in certificates issued by the
+	// ACME server. A certificate which embeds fewer SCTs is not stored, and the
+	// issuance fails with the reason MissingSCTs, leaving the certificate
+	// which is currently stored in the Secret in place. This is useful for
+	// private ACME servers whose certificates are used by clients which
+	// enforce Certificate Transparency.
+	// If unset or 0, the SCTs of issued certificates are not checked.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	RequireEmbeddedSCTs int `json:"requireEmbeddedSCTs,omitempty"`
 }
 
 // ACMEExternalAccountBinding is a reference to a CA external account of the ACME
@@ -224,6 +274,23 @@ type ACMEChallengeSolverHTTP01 struct {
 	// This solver is experimental, and fields / behaviour may change in the future.
 	// +optional
 	GatewayHTTPRoute *ACMEChallengeSolverHTTP01GatewayHTTPRoute `json:"gatewayHTTPRoute,omitempty"`
+
+	// The custom HTTP01 challenge solver does not create any pods, services,
+	// ingresses or routes. Instead, the key authorization of each Challenge is
+	// published to a ConfigMap, keyed by the challenge token, from which a
+	// user-managed solver is expected to serve it. The entry is removed once
+	// the Challenge has been cleaned up.
+	// +optional
+	Custom *ACMEChallengeSolverHTTP01Custom `json:"custom,omitempty"`
+}
+
+// ACMEChallengeSolverHTTP01Custom configures a user-managed HTTP01 solver
+// which serves the key authorizations published to a ConfigMap.
+type ACMEChallengeSolverHTTP01Custom struct {
+	// The name of the ConfigMap that the key authorizations are published to.
+	// The ConfigMap is always in the namespace of the Challenge, and is
+	// created if it does not exist.
+	ConfigMapName string `json:"configMapName"`
 }
 
 type ACMEChallengeSolverHTTP01Ingress struct {
@@ -335,6 +402,16 @@ type ACMEChallengeSolverHTTP01IngressPodSpec struct {
 	// If specified, the pod's imagePullSecrets
 	// +optional
 	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty" patchMergeKey:"name" patchStrategy:"merge"`
+
+	// If specified, the image of the acmesolver container. Defaults to the
+	// image configured with the controller's --acme-http01-solver-image flag.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// If specified, the pull policy of the acmesolver container, one of
+	// Always, Never or IfNotPresent. Defaults to IfNotPresent.
+	// +optional
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
 }
 
 type ACMEChallengeSolverHTTP01IngressTemplate struct {
@@ -365,6 +442,23 @@ type ACMEChallengeSolverDNS01 struct {
 	// +optional
 	CNAMEStrategy CNAMEStrategy `json:"cnameStrategy,omitempty"`
 
+	// InitialDelay is the amount of time to wait after the DNS01 challenge
+	// record has been observed on the authoritative nameservers before the
+	// ACME server is asked to validate the challenge. Increase this for zones
+	// with slow secondary nameserver synchronisation.
+	// Defaults to 60s if not specified.
+	// +optional
+	InitialDelay *metav1.Duration `json:"initialDelay,omitempty"`
+
+	// PropagationTimeout is the maximum amount of time, measured from the
+	// creation of the Challenge, to wait for the DNS01 challenge record to be
+	// observed on the authoritative nameservers. If the record has not
+	// propagated within this time the Challenge is marked as errored.
+	// If not specified, cert-manager will keep waiting for the record to
+	// propagate.
+	// +optional
+	PropagationTimeout *metav1.Duration `json:"propagationTimeout,omitempty"`
+
 	// Use the Akamai DNS zone management API to manage DNS01 challenge records.
 	// +optional
 	Akamai *ACMEIssuerDNS01ProviderAkamai `json:"akamai,omitempty"`
@@ -445,6 +539,22 @@ type ACMEIssuerDNS01ProviderCloudDNS struct {
 	// If left empty cert-manager will automatically choose a zone.
 	// +optional
 	HostedZoneName string `json:"hostedZoneName,omitempty"`
+
+	// TTL is the time to live, in seconds, of the TXT record created to solve
+	// the DNS01 challenge.
+	// Defaults to 60 if not specified.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	TTL *int64 `json:"ttl,omitempty"`
+
+	// ImpersonateServiceAccount is the email address of a Google Cloud service
+	// account to impersonate when managing the challenge records, e.g.
+	// `dns-solver@my-dns-project.iam.gserviceaccount.com`. The credentials
+	// from `serviceAccountSecretRef`, or the ambient credentials, which may be
+	// federated credentials configured for Workload Identity Federation, must
+	// be granted the `roles/iam.serviceAccountTokenCreator` role on it.
+	// +optional
+	ImpersonateServiceAccount string `json:"impersonateServiceAccount,omitempty"`
 }
 
 // ACMEIssuerDNS01ProviderCloudflare is a structure containing the DNS
@@ -503,8 +613,22 @@ type ACMEIssuerDNS01ProviderRoute53 struct {
 	// +optional
 	HostedZoneID string `json:"hostedZoneID,omitempty"`
 
+	// PreferPrivateZones allows private hosted zones to be discovered as the
+	// hosted zone of a challenge record, and selects them over public hosted
+	// zones with the same name. By default private hosted zones are ignored.
+	// Ignored if HostedZoneID is set.
+	// +optional
+	PreferPrivateZones bool `json:"preferPrivateZones,omitempty"`
+
 	// Always set the region when using AccessKeyID and SecretAccessKey
 	Region string `json:"region"`
+
+	// TTL is the time to live, in seconds, of the TXT record created to solve
+	// the DNS01 challenge.
+	// Defaults to 10 if not specified.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	TTL *int64 `json:"ttl,omitempty"`
 }
 
 // Route53Auth is configuration used to authenticate with a Route53.
@@ -569,6 +693,13 @@ type ACMEIssuerDNS01ProviderAzureDNS struct {
 	// managed identity configuration, can not be used at the same time as clientID, clientSecretSecretRef or tenantID
 	// +optional
 	ManagedIdentity *AzureManagedIdentity `json:"managedIdentity,omitempty"`
+
+	// TTL is the time to live, in seconds, of the TXT record created to solve
+	// the DNS01 challenge.
+	// Defaults to 60 if not specified.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	TTL *int64 `json:"ttl,omitempty"`
 }
 
 type AzureManagedIdentity struct {
@@ -668,4 +799,46 @@ type ACMEIssuerStatus struct {
 	// registered ACME account, in order to track changes made to registered account
 	// associated with the Issuer
 	LastPrivateKeyHash string `json:"lastPrivateKeyHash,omitempty"`
+
+	// Directory contains the endpoints most recently discovered from the
+	// ACME server's directory. It is informational and intended to help
+	// debug issues with ACME servers whose endpoints change.
+	// +optional
+	Directory *ACMEIssuerDirectoryStatus `json:"directory,omitempty"`
+
+	// AccountStatus is the status of the ACME account as most recently
+	// reported by the ACME server, one of valid, deactivated or revoked.
+	// Issuers whose account has been deactivated or revoked are not Ready.
+	// +optional
+	AccountStatus string `json:"accountStatus,omitempty"`
+
+	// LastVerifiedTime is the time at which the ACME account was most
+	// recently verified with the ACME server. The account is verified
+	// periodically, at the interval configured on the controller.
+	// +optional
+	LastVerifiedTime *metav1.Time `json:"lastVerifiedTime,omitempty"`
+}
+
+// ACMEIssuerDirectoryStatus contains the endpoints discovered from an ACME
+// server's directory.
+type ACMEIssuerDirectoryStatus struct {
+	// NewNonce is the URL used to fetch fresh anti-replay nonces.
+	// +optional
+	NewNonce string `json:"newNonce,omitempty"`
+
+	// NewAccount is the URL used to register and look up accounts.
+	// +optional
+	NewAccount string `json:"newAccount,omitempty"`
+
+	// NewOrder is the URL used to create new orders.
+	// +optional
+	NewOrder string `json:"newOrder,omitempty"`
+
+	// RevokeCert is the URL used to revoke certificates.
+	// +optional
+	RevokeCert string `json:"revokeCert,omitempty"`
+
+	// KeyChange is the URL used to roll over account keys.
+	// +optional
+	KeyChange string `json:"keyChange,omitempty"`
 }
@@ -95,6 +95,12 @@ type OrderStatus struct {
 	// +optional
 	FinalizeURL string `json:"finalizeURL,omitempty"`
 
+	// CertificateURL of the Order.
+	// This is the URL the issued certificate is downloaded from, and is set
+	// once the order has been finalized.
+	// +optional
+	CertificateURL string `json:"certificateURL,omitempty"`
+
 	// Authorizations contains data returned from the ACME server on what
 	// authorizations must be completed in order to validate the DNS names
 	// specified on the Order.
@@ -122,6 +128,20 @@ type OrderStatus struct {
 	// This is used to influence garbage collection and back-off.
 	// +optional
 	FailureTime *metav1.Time `json:"failureTime,omitempty"`
+
+	// RetryAfter is the time after which the ACME server asked for the last
+	// failed request for this order to be retried, as indicated by the
+	// Retry-After header of its response (e.g. when a rate limit was hit).
+	// It is cleared once the order makes progress again.
+	// +optional
+	RetryAfter *metav1.Time `json:"retryAfter,omitempty"`
+
+	// LastProblemType is the type of the last problem document returned by the
+	// ACME server for this order, e.g.
+	// `urn:ietf:params:acme:error:rateLimited`.
+	// It is cleared once the order makes progress again.
+	// +optional
+	LastProblemType string `json:"lastProblemType,omitempty"`
 }
 
 // ACMEAuthorization contains data returned from the ACME server on an
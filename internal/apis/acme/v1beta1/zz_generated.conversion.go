@@ -93,6 +93,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ACMEChallengeSolverHTTP01Custom)(nil), (*acme.ACMEChallengeSolverHTTP01Custom)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMEChallengeSolverHTTP01Custom_To_acme_ACMEChallengeSolverHTTP01Custom(a.(*ACMEChallengeSolverHTTP01Custom), b.(*acme.ACMEChallengeSolverHTTP01Custom), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverHTTP01Custom)(nil), (*ACMEChallengeSolverHTTP01Custom)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverHTTP01Custom_To_v1beta1_ACMEChallengeSolverHTTP01Custom(a.(*acme.ACMEChallengeSolverHTTP01Custom), b.(*ACMEChallengeSolverHTTP01Custom), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ACMEChallengeSolverHTTP01GatewayHTTPRoute)(nil), (*acme.ACMEChallengeSolverHTTP01GatewayHTTPRoute)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_acme_ACMEChallengeSolverHTTP01GatewayHTTPRoute(a.(*ACMEChallengeSolverHTTP01GatewayHTTPRoute), b.(*acme.ACMEChallengeSolverHTTP01GatewayHTTPRoute), scope)
 	}); err != nil {
@@ -263,6 +273,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ACMEIssuerDirectoryStatus)(nil), (*acme.ACMEIssuerDirectoryStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMEIssuerDirectoryStatus_To_acme_ACMEIssuerDirectoryStatus(a.(*ACMEIssuerDirectoryStatus), b.(*acme.ACMEIssuerDirectoryStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEIssuerDirectoryStatus)(nil), (*ACMEIssuerDirectoryStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEIssuerDirectoryStatus_To_v1beta1_ACMEIssuerDirectoryStatus(a.(*acme.ACMEIssuerDirectoryStatus), b.(*ACMEIssuerDirectoryStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ACMEIssuerStatus)(nil), (*acme.ACMEIssuerStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_ACMEIssuerStatus_To_acme_ACMEIssuerStatus(a.(*ACMEIssuerStatus), b.(*acme.ACMEIssuerStatus), scope)
 	}); err != nil {
@@ -510,6 +530,8 @@ func Convert_acme_ACMEChallengeSolver_To_v1beta1_ACMEChallengeSolver(in *acme.AC
 
 func autoConvert_v1beta1_ACMEChallengeSolverDNS01_To_acme_ACMEChallengeSolverDNS01(in *ACMEChallengeSolverDNS01, out *acme.ACMEChallengeSolverDNS01, s conversion.Scope) error {
 	out.CNAMEStrategy = acme.CNAMEStrategy(in.CNAMEStrategy)
+	out.InitialDelay = (*pkgapismetav1.Duration)(unsafe.Pointer(in.InitialDelay))
+	out.PropagationTimeout = (*pkgapismetav1.Duration)(unsafe.Pointer(in.PropagationTimeout))
 	if in.Akamai != nil {
 		in, out := &in.Akamai, &out.Akamai
 		*out = new(acme.ACMEIssuerDNS01ProviderAkamai)
@@ -593,6 +615,8 @@ func Convert_v1beta1_ACMEChallengeSolverDNS01_To_acme_ACMEChallengeSolverDNS01(i
 
 func autoConvert_acme_ACMEChallengeSolverDNS01_To_v1beta1_ACMEChallengeSolverDNS01(in *acme.ACMEChallengeSolverDNS01, out *ACMEChallengeSolverDNS01, s conversion.Scope) error {
 	out.CNAMEStrategy = CNAMEStrategy(in.CNAMEStrategy)
+	out.InitialDelay = (*pkgapismetav1.Duration)(unsafe.Pointer(in.InitialDelay))
+	out.PropagationTimeout = (*pkgapismetav1.Duration)(unsafe.Pointer(in.PropagationTimeout))
 	if in.Akamai != nil {
 		in, out := &in.Akamai, &out.Akamai
 		*out = new(ACMEIssuerDNS01ProviderAkamai)
@@ -677,6 +701,7 @@ func Convert_acme_ACMEChallengeSolverDNS01_To_v1beta1_ACMEChallengeSolverDNS01(i
 func autoConvert_v1beta1_ACMEChallengeSolverHTTP01_To_acme_ACMEChallengeSolverHTTP01(in *ACMEChallengeSolverHTTP01, out *acme.ACMEChallengeSolverHTTP01, s conversion.Scope) error {
 	out.Ingress = (*acme.ACMEChallengeSolverHTTP01Ingress)(unsafe.Pointer(in.Ingress))
 	out.GatewayHTTPRoute = (*acme.ACMEChallengeSolverHTTP01GatewayHTTPRoute)(unsafe.Pointer(in.GatewayHTTPRoute))
+	out.Custom = (*acme.ACMEChallengeSolverHTTP01Custom)(unsafe.Pointer(in.Custom))
 	return nil
 }
 
@@ -688,6 +713,7 @@ func Convert_v1beta1_ACMEChallengeSolverHTTP01_To_acme_ACMEChallengeSolverHTTP01
 func autoConvert_acme_ACMEChallengeSolverHTTP01_To_v1beta1_ACMEChallengeSolverHTTP01(in *acme.ACMEChallengeSolverHTTP01, out *ACMEChallengeSolverHTTP01, s conversion.Scope) error {
 	out.Ingress = (*ACMEChallengeSolverHTTP01Ingress)(unsafe.Pointer(in.Ingress))
 	out.GatewayHTTPRoute = (*ACMEChallengeSolverHTTP01GatewayHTTPRoute)(unsafe.Pointer(in.GatewayHTTPRoute))
+	out.Custom = (*ACMEChallengeSolverHTTP01Custom)(unsafe.Pointer(in.Custom))
 	return nil
 }
 
@@ -696,6 +722,26 @@ func Convert_acme_ACMEChallengeSolverHTTP01_To_v1beta1_ACMEChallengeSolverHTTP01
 	return autoConvert_acme_ACMEChallengeSolverHTTP01_To_v1beta1_ACMEChallengeSolverHTTP01(in, out, s)
 }
 
+func autoConvert_v1beta1_ACMEChallengeSolverHTTP01Custom_To_acme_ACMEChallengeSolverHTTP01Custom(in *ACMEChallengeSolverHTTP01Custom, out *acme.ACMEChallengeSolverHTTP01Custom, s conversion.Scope) error {
+	out.ConfigMapName = in.ConfigMapName
+	return nil
+}
+
+// Convert_v1beta1_ACMEChallengeSolverHTTP01Custom_To_acme_ACMEChallengeSolverHTTP01Custom is an autogenerated conversion function.
+func Convert_v1beta1_ACMEChallengeSolverHTTP01Custom_To_acme_ACMEChallengeSolverHTTP01Custom(in *ACMEChallengeSolverHTTP01Custom, out *acme.ACMEChallengeSolverHTTP01Custom, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMEChallengeSolverHTTP01Custom_To_acme_ACMEChallengeSolverHTTP01Custom(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverHTTP01Custom_To_v1beta1_ACMEChallengeSolverHTTP01Custom(in *acme.ACMEChallengeSolverHTTP01Custom, out *ACMEChallengeSolverHTTP01Custom, s conversion.Scope) error {
+	out.ConfigMapName = in.ConfigMapName
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverHTTP01Custom_To_v1beta1_ACMEChallengeSolverHTTP01Custom is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverHTTP01Custom_To_v1beta1_ACMEChallengeSolverHTTP01Custom(in *acme.ACMEChallengeSolverHTTP01Custom, out *ACMEChallengeSolverHTTP01Custom, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverHTTP01Custom_To_v1beta1_ACMEChallengeSolverHTTP01Custom(in, out, s)
+}
+
 func autoConvert_v1beta1_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_acme_ACMEChallengeSolverHTTP01GatewayHTTPRoute(in *ACMEChallengeSolverHTTP01GatewayHTTPRoute, out *acme.ACMEChallengeSolverHTTP01GatewayHTTPRoute, s conversion.Scope) error {
 	out.ServiceType = v1.ServiceType(in.ServiceType)
 	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
@@ -801,6 +847,8 @@ func autoConvert_v1beta1_ACMEChallengeSolverHTTP01IngressPodSpec_To_acme_ACMECha
 	out.PriorityClassName = in.PriorityClassName
 	out.ServiceAccountName = in.ServiceAccountName
 	out.ImagePullSecrets = *(*[]v1.LocalObjectReference)(unsafe.Pointer(&in.ImagePullSecrets))
+	out.Image = in.Image
+	out.ImagePullPolicy = v1.PullPolicy(in.ImagePullPolicy)
 	return nil
 }
 
@@ -816,6 +864,8 @@ func autoConvert_acme_ACMEChallengeSolverHTTP01IngressPodSpec_To_v1beta1_ACMECha
 	out.PriorityClassName = in.PriorityClassName
 	out.ServiceAccountName = in.ServiceAccountName
 	out.ImagePullSecrets = *(*[]v1.LocalObjectReference)(unsafe.Pointer(&in.ImagePullSecrets))
+	out.Image = in.Image
+	out.ImagePullPolicy = v1.PullPolicy(in.ImagePullPolicy)
 	return nil
 }
 
@@ -911,7 +961,25 @@ func autoConvert_v1beta1_ACMEIssuer_To_acme_ACMEIssuer(in *ACMEIssuer, out *acme
 	out.Server = in.Server
 	out.PreferredChain = in.PreferredChain
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if in.CABundleSecretRef != nil {
+		in, out := &in.CABundleSecretRef, &out.CABundleSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CABundleSecretRef = nil
+	}
 	out.SkipTLSVerify = in.SkipTLSVerify
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(meta.ClientTLSConfig)
+		if err := metav1.Convert_v1_ClientTLSConfig_To_meta_ClientTLSConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TLS = nil
+	}
 	if in.ExternalAccountBinding != nil {
 		in, out := &in.ExternalAccountBinding, &out.ExternalAccountBinding
 		*out = new(acme.ACMEExternalAccountBinding)
@@ -937,6 +1005,9 @@ func autoConvert_v1beta1_ACMEIssuer_To_acme_ACMEIssuer(in *ACMEIssuer, out *acme
 	}
 	out.DisableAccountKeyGeneration = in.DisableAccountKeyGeneration
 	out.EnableDurationFeature = in.EnableDurationFeature
+	out.DirectoryMaxAge = (*pkgapismetav1.Duration)(unsafe.Pointer(in.DirectoryMaxAge))
+	out.AllowedZones = *(*[]string)(unsafe.Pointer(&in.AllowedZones))
+	out.RequireEmbeddedSCTs = in.RequireEmbeddedSCTs
 	return nil
 }
 
@@ -945,7 +1016,25 @@ func autoConvert_acme_ACMEIssuer_To_v1beta1_ACMEIssuer(in *acme.ACMEIssuer, out
 	out.Server = in.Server
 	out.PreferredChain = in.PreferredChain
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if in.CABundleSecretRef != nil {
+		in, out := &in.CABundleSecretRef, &out.CABundleSecretRef
+		*out = new(apismetav1.SecretKeySelector)
+		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CABundleSecretRef = nil
+	}
 	out.SkipTLSVerify = in.SkipTLSVerify
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(apismetav1.ClientTLSConfig)
+		if err := metav1.Convert_meta_ClientTLSConfig_To_v1_ClientTLSConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TLS = nil
+	}
 	if in.ExternalAccountBinding != nil {
 		in, out := &in.ExternalAccountBinding, &out.ExternalAccountBinding
 		*out = new(ACMEExternalAccountBinding)
@@ -971,6 +1060,9 @@ func autoConvert_acme_ACMEIssuer_To_v1beta1_ACMEIssuer(in *acme.ACMEIssuer, out
 	}
 	out.DisableAccountKeyGeneration = in.DisableAccountKeyGeneration
 	out.EnableDurationFeature = in.EnableDurationFeature
+	out.DirectoryMaxAge = (*pkgapismetav1.Duration)(unsafe.Pointer(in.DirectoryMaxAge))
+	out.AllowedZones = *(*[]string)(unsafe.Pointer(&in.AllowedZones))
+	out.RequireEmbeddedSCTs = in.RequireEmbeddedSCTs
 	return nil
 }
 
@@ -1055,6 +1147,7 @@ func autoConvert_v1beta1_ACMEIssuerDNS01ProviderAzureDNS_To_acme_ACMEIssuerDNS01
 	out.HostedZoneName = in.HostedZoneName
 	out.Environment = acme.AzureDNSEnvironment(in.Environment)
 	out.ManagedIdentity = (*acme.AzureManagedIdentity)(unsafe.Pointer(in.ManagedIdentity))
+	out.TTL = (*int64)(unsafe.Pointer(in.TTL))
 	return nil
 }
 
@@ -1080,6 +1173,7 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderAzureDNS_To_v1beta1_ACMEIssuerDNS01
 	out.HostedZoneName = in.HostedZoneName
 	out.Environment = AzureDNSEnvironment(in.Environment)
 	out.ManagedIdentity = (*AzureManagedIdentity)(unsafe.Pointer(in.ManagedIdentity))
+	out.TTL = (*int64)(unsafe.Pointer(in.TTL))
 	return nil
 }
 
@@ -1100,6 +1194,8 @@ func autoConvert_v1beta1_ACMEIssuerDNS01ProviderCloudDNS_To_acme_ACMEIssuerDNS01
 	}
 	out.Project = in.Project
 	out.HostedZoneName = in.HostedZoneName
+	out.TTL = (*int64)(unsafe.Pointer(in.TTL))
+	out.ImpersonateServiceAccount = in.ImpersonateServiceAccount
 	return nil
 }
 
@@ -1120,6 +1216,8 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderCloudDNS_To_v1beta1_ACMEIssuerDNS01
 	}
 	out.Project = in.Project
 	out.HostedZoneName = in.HostedZoneName
+	out.TTL = (*int64)(unsafe.Pointer(in.TTL))
+	out.ImpersonateServiceAccount = in.ImpersonateServiceAccount
 	return nil
 }
 
@@ -1255,7 +1353,9 @@ func autoConvert_v1beta1_ACMEIssuerDNS01ProviderRoute53_To_acme_ACMEIssuerDNS01P
 	}
 	out.Role = in.Role
 	out.HostedZoneID = in.HostedZoneID
+	out.PreferPrivateZones = in.PreferPrivateZones
 	out.Region = in.Region
+	out.TTL = (*int64)(unsafe.Pointer(in.TTL))
 	return nil
 }
 
@@ -1281,7 +1381,9 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderRoute53_To_v1beta1_ACMEIssuerDNS01P
 	}
 	out.Role = in.Role
 	out.HostedZoneID = in.HostedZoneID
+	out.PreferPrivateZones = in.PreferPrivateZones
 	out.Region = in.Region
+	out.TTL = (*int64)(unsafe.Pointer(in.TTL))
 	return nil
 }
 
@@ -1314,10 +1416,41 @@ func Convert_acme_ACMEIssuerDNS01ProviderWebhook_To_v1beta1_ACMEIssuerDNS01Provi
 	return autoConvert_acme_ACMEIssuerDNS01ProviderWebhook_To_v1beta1_ACMEIssuerDNS01ProviderWebhook(in, out, s)
 }
 
+func autoConvert_v1beta1_ACMEIssuerDirectoryStatus_To_acme_ACMEIssuerDirectoryStatus(in *ACMEIssuerDirectoryStatus, out *acme.ACMEIssuerDirectoryStatus, s conversion.Scope) error {
+	out.NewNonce = in.NewNonce
+	out.NewAccount = in.NewAccount
+	out.NewOrder = in.NewOrder
+	out.RevokeCert = in.RevokeCert
+	out.KeyChange = in.KeyChange
+	return nil
+}
+
+// Convert_v1beta1_ACMEIssuerDirectoryStatus_To_acme_ACMEIssuerDirectoryStatus is an autogenerated conversion function.
+func Convert_v1beta1_ACMEIssuerDirectoryStatus_To_acme_ACMEIssuerDirectoryStatus(in *ACMEIssuerDirectoryStatus, out *acme.ACMEIssuerDirectoryStatus, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMEIssuerDirectoryStatus_To_acme_ACMEIssuerDirectoryStatus(in, out, s)
+}
+
+func autoConvert_acme_ACMEIssuerDirectoryStatus_To_v1beta1_ACMEIssuerDirectoryStatus(in *acme.ACMEIssuerDirectoryStatus, out *ACMEIssuerDirectoryStatus, s conversion.Scope) error {
+	out.NewNonce = in.NewNonce
+	out.NewAccount = in.NewAccount
+	out.NewOrder = in.NewOrder
+	out.RevokeCert = in.RevokeCert
+	out.KeyChange = in.KeyChange
+	return nil
+}
+
+// Convert_acme_ACMEIssuerDirectoryStatus_To_v1beta1_ACMEIssuerDirectoryStatus is an autogenerated conversion function.
+func Convert_acme_ACMEIssuerDirectoryStatus_To_v1beta1_ACMEIssuerDirectoryStatus(in *acme.ACMEIssuerDirectoryStatus, out *ACMEIssuerDirectoryStatus, s conversion.Scope) error {
+	return autoConvert_acme_ACMEIssuerDirectoryStatus_To_v1beta1_ACMEIssuerDirectoryStatus(in, out, s)
+}
+
 func autoConvert_v1beta1_ACMEIssuerStatus_To_acme_ACMEIssuerStatus(in *ACMEIssuerStatus, out *acme.ACMEIssuerStatus, s conversion.Scope) error {
 	out.URI = in.URI
 	out.LastRegisteredEmail = in.LastRegisteredEmail
 	out.LastPrivateKeyHash = in.LastPrivateKeyHash
+	out.Directory = (*acme.ACMEIssuerDirectoryStatus)(unsafe.Pointer(in.Directory))
+	out.AccountStatus = in.AccountStatus
+	out.LastVerifiedTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.LastVerifiedTime))
 	return nil
 }
 
@@ -1330,6 +1463,9 @@ func autoConvert_acme_ACMEIssuerStatus_To_v1beta1_ACMEIssuerStatus(in *acme.ACME
 	out.URI = in.URI
 	out.LastRegisteredEmail = in.LastRegisteredEmail
 	out.LastPrivateKeyHash = in.LastPrivateKeyHash
+	out.Directory = (*ACMEIssuerDirectoryStatus)(unsafe.Pointer(in.Directory))
+	out.AccountStatus = in.AccountStatus
+	out.LastVerifiedTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.LastVerifiedTime))
 	return nil
 }
 
@@ -1639,11 +1775,14 @@ func Convert_acme_OrderSpec_To_v1beta1_OrderSpec(in *acme.OrderSpec, out *OrderS
 func autoConvert_v1beta1_OrderStatus_To_acme_OrderStatus(in *OrderStatus, out *acme.OrderStatus, s conversion.Scope) error {
 	out.URL = in.URL
 	out.FinalizeURL = in.FinalizeURL
+	out.CertificateURL = in.CertificateURL
 	out.Authorizations = *(*[]acme.ACMEAuthorization)(unsafe.Pointer(&in.Authorizations))
 	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
 	out.State = acme.State(in.State)
 	out.Reason = in.Reason
 	out.FailureTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.FailureTime))
+	out.RetryAfter = (*pkgapismetav1.Time)(unsafe.Pointer(in.RetryAfter))
+	out.LastProblemType = in.LastProblemType
 	return nil
 }
 
@@ -1655,11 +1794,14 @@ func Convert_v1beta1_OrderStatus_To_acme_OrderStatus(in *OrderStatus, out *acme.
 func autoConvert_acme_OrderStatus_To_v1beta1_OrderStatus(in *acme.OrderStatus, out *OrderStatus, s conversion.Scope) error {
 	out.URL = in.URL
 	out.FinalizeURL = in.FinalizeURL
+	out.CertificateURL = in.CertificateURL
 	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
 	out.State = State(in.State)
 	out.Reason = in.Reason
 	out.Authorizations = *(*[]ACMEAuthorization)(unsafe.Pointer(&in.Authorizations))
 	out.FailureTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.FailureTime))
+	out.RetryAfter = (*pkgapismetav1.Time)(unsafe.Pointer(in.RetryAfter))
+	out.LastProblemType = in.LastProblemType
 	return nil
 }
 
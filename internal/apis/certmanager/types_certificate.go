@@ -128,12 +128,21 @@ type CertificateSpec struct {
 	// Cannot be set if the `literalSubject` field is set.
 	CommonName string
 
+	// CommonNameFromFirstDNSName defaults `commonName` to the first entry of
+	// `dnsNames` when `commonName` is not set, for issuers which require the
+	// common name to be present. The defaulted value is written to
+	// `commonName` by the webhook so that the effective common name is
+	// visible on the Certificate.
+	// The common name is not defaulted if the first DNS name is longer than 64
+	// characters, or if the `literalSubject` field is set.
+	CommonNameFromFirstDNSName bool
+
 	// Requested 'duration' (i.e. lifetime) of the Certificate. Note that the
 	// issuer may choose to ignore the requested duration, just like any other
 	// requested attribute.
 	//
 	// If unset, this defaults to 90 days.
-	// Minimum accepted duration is 1 hour.
+	// Minimum accepted duration is 5 minutes.
 	// Value must be in units accepted by Go time.ParseDuration https://golang.org/pkg/time/#ParseDuration.
 	Duration *metav1.Duration
 
@@ -152,6 +161,38 @@ type CertificateSpec struct {
 	// Value must be in units accepted by Go time.ParseDuration https://golang.org/pkg/time/#ParseDuration.
 	RenewBefore *metav1.Duration
 
+	// RenewBeforeChainExpiry makes the renewal time of the certificate take the
+	// issuer chain stored in the Secret into account: the intermediate
+	// certificates in `tls.crt` and, unless `secretCAPolicy` copies it from
+	// another Secret, the certificates in `ca.crt`. If one of them expires
+	// before the issued certificate, the renewal time is computed
+	// as if the issued certificate expired at the same time, so that the
+	// certificate is re-issued with a fresh chain before the chain expires.
+	// This is useful for bundles such as PKCS#12 keystores, whose clients
+	// reject the whole bundle once any certificate in it has expired.
+	RenewBeforeChainExpiry bool
+
+	// ChainExpiryExcludedFingerprints lists the SHA-256 fingerprints, hex
+	// encoded as in `status.sha256Fingerprint`, of certificates in the issuer
+	// chain whose expiry is ignored by `renewBeforeChainExpiry`, for example
+	// an expired cross-signed certificate which the issuer always returns.
+	// May only be set if `renewBeforeChainExpiry` is true.
+	ChainExpiryExcludedFingerprints []string
+
+	// RolloutDelay is how long a renewed certificate is held back once it has
+	// been issued before it is written to the Secret named in `secretName`, for
+	// clients which cache the previous certificate and break if it changes
+	// while still in use. The issued certificate is held in the
+	// CertificateRequest in the meantime, and the `Issuing` condition has the
+	// reason `PendingRollout`.
+	// The certificate is written before the delay has elapsed if the
+	// certificate stored in the Secret would otherwise expire within 10 minutes.
+	// The first certificate issued for a Certificate is never held back, nor
+	// is a certificate issued for another reason than a renewal, e.g. because
+	// the spec changed or the issuance was triggered manually.
+	// Value must be in units accepted by Go time.ParseDuration https://golang.org/pkg/time/#ParseDuration.
+	RolloutDelay *metav1.Duration
+
 	// Requested DNS subject alternative names.
 	DNSNames []string
 
@@ -250,6 +291,111 @@ type CertificateSpec struct {
 	// the controller and webhook components.
 	// +optional
 	NameConstraints *NameConstraints
+
+	// PolicyOIDs are the object identifiers of the certificate policies
+	// requested for the certificate, expressed as dotted strings, for example
+	// "2.23.140.1.2.1". They are encoded in the certificatePolicies extension
+	// of the CSR. Issuers may ignore the requested policies; the CA issuer
+	// only includes the policies listed in its `allowedPolicyOIDs`.
+	// More Info: https://datatracker.ietf.org/doc/html/rfc5280#section-4.2.1.4
+	PolicyOIDs []string
+
+	// RevokeOnDelete, if true, will cause the certificate to be revoked with
+	// the issuer when this Certificate is deleted. Deletion is blocked by a
+	// finalizer until the certificate has been revoked, or until revocation
+	// has failed a number of times, in which case a Warning event is
+	// recorded and the Certificate is deleted anyway.
+	// Only the ACME, Vault and Venafi issuers, and CA issuers generating a CRL,
+	// support revocation.
+	RevokeOnDelete *bool
+
+	// ExternalCSR configures the Certificate to be issued for a CSR that is
+	// provided by the user, for example when the private key is held in a
+	// hardware security module and never stored in the cluster.
+	// When set, cert-manager does not generate or store a private key: the
+	// provided CSR is used verbatim for every CertificateRequest, and only
+	// `tls.crt` and `ca.crt` are written to the Secret named in
+	// `spec.secretName`. The CSR only needs to be rotated when a new key
+	// should be used.
+	// `spec.privateKey` is ignored, and `spec.keystores` and
+	// `spec.additionalOutputFormats` may not be set.
+	ExternalCSR *CertificateExternalCSR
+
+	// SecretCAPolicy controls what is stored in the `ca.crt` key of the
+	// Certificate's Secret. Changing the policy updates the Secret without
+	// re-issuing the certificate.
+	// If unset, the CA provided by the issuer is stored.
+	SecretCAPolicy *CertificateSecretCAPolicy
+
+	// Defines annotations and labels to be set on each CertificateRequest
+	// created for this Certificate. They are applied again whenever a new
+	// CertificateRequest revision is created, so changes take effect on the
+	// next issuance. Annotations and labels in the `cert-manager.io` domain
+	// are reserved and may not be set.
+	CertificateRequestTemplate *CertificateRequestTemplate
+
+	// IssuanceWindow restricts the times at which the Certificate is
+	// re-issued, e.g. to the maintenance windows of the workloads using it.
+	// Re-issuances required outside of the window are deferred until it
+	// opens, unless the certificate would expire before, or less than a third
+	// of its duration (at most 24 hours) after the window opens, in which case
+	// it is re-issued immediately and a warning event is recorded.
+	// Certificates which have not been issued yet, or whose Secret is missing
+	// or invalid, are issued immediately.
+	// +optional
+	IssuanceWindow *CertificateIssuanceWindow
+}
+
+// CertificateExternalCSR references a user provided CSR to be used to request
+// certificates for a Certificate.
+type CertificateExternalCSR struct {
+	// SecretRef is a reference to a key in a Secret in the Certificate's
+	// namespace containing the PEM encoded CSR. The key defaults to
+	// `tls.csr`.
+	SecretRef cmmeta.SecretKeySelector
+}
+
+// CertificateSecretCAPolicy controls the contents of the `ca.crt` key of a
+// Certificate's Secret.
+type CertificateSecretCAPolicy struct {
+	// Type of the policy. One of `IssuerProvided`, `Omit` or `FromSecretRef`.
+	// `IssuerProvided` stores the CA returned by the issuer, `Omit` never
+	// stores a `ca.crt` key, and `FromSecretRef` stores the fixed bundle
+	// referenced by `bundleRef`.
+	Type CertificateSecretCAPolicyType
+
+	// BundleRef references the PEM encoded CA bundle to store in `ca.crt`.
+	// Required when, and only allowed when, `type` is `FromSecretRef`.
+	BundleRef *CertificateCABundleReference
+}
+
+// CertificateSecretCAPolicyType is the type of a CertificateSecretCAPolicy.
+type CertificateSecretCAPolicyType string
+
+const (
+	// CertificateSecretCAPolicyIssuerProvided stores the CA returned by the
+	// issuer in `ca.crt`. This is the default.
+	CertificateSecretCAPolicyIssuerProvided CertificateSecretCAPolicyType = "IssuerProvided"
+
+	// CertificateSecretCAPolicyOmit never stores a `ca.crt` key.
+	CertificateSecretCAPolicyOmit CertificateSecretCAPolicyType = "Omit"
+
+	// CertificateSecretCAPolicyFromSecretRef stores the bundle referenced by
+	// the policy's `bundleRef` in `ca.crt`.
+	CertificateSecretCAPolicyFromSecretRef CertificateSecretCAPolicyType = "FromSecretRef"
+)
+
+// CertificateCABundleReference references a key of a ConfigMap or Secret in
+// the Certificate's namespace which contains a PEM encoded CA bundle.
+type CertificateCABundleReference struct {
+	// Kind of the referenced resource, either `ConfigMap` or `Secret`.
+	Kind string
+
+	// Name of the referenced resource.
+	Name string
+
+	// Key of the entry in the resource's data which contains the bundle.
+	Key string
 }
 
 type OtherName struct {
@@ -307,6 +453,21 @@ type CertificatePrivateKey struct {
 	// If `algorithm` is set to `Ed25519`, Size is ignored.
 	// No other values are allowed.
 	Size int
+
+	// External configures the private key to be created and held by an
+	// external key management service (KMS), rather than being generated by
+	// cert-manager. Only a reference to the key is stored in the cluster, and
+	// the CSR is signed by the KMS.
+	External *CertificateExternalPrivateKey
+}
+
+// CertificateExternalPrivateKey configures a private key which is created and
+// held by an external key management service.
+type CertificateExternalPrivateKey struct {
+	// KeyRing identifies where the key is created in the key management
+	// service, for example the resource name of a Cloud KMS key ring. It is
+	// passed as-is to the external signer.
+	KeyRing string
 }
 
 // Denotes how private keys should be generated or sourced when a Certificate
@@ -388,6 +549,12 @@ type CertificateKeystores struct {
 	// PKCS12 configures options for storing a PKCS12 keystore in the
 	// `spec.secretName` Secret resource.
 	PKCS12 *PKCS12Keystore
+
+	// SecretName is the name of a Secret resource that keystores should be
+	// written to instead of the `spec.secretName` Secret resource. This can
+	// be used to keep the `spec.secretName` Secret below the maximum size of
+	// a Secret when keystores and long certificate chains are both stored.
+	SecretName string
 }
 
 // JKS configures options for storing a JKS keystore in the `spec.secretName`
@@ -483,6 +650,12 @@ type CertificateStatus struct {
 	// If not set, no upcoming renewal is scheduled.
 	RenewalTime *metav1.Time
 
+	// NextIssuanceWindowTime is the time at which the issuance window of the
+	// Certificate next opens. It is only set while a re-issuance is deferred
+	// until the window opens.
+	// +optional
+	NextIssuanceWindowTime *metav1.Time
+
 	// The current 'revision' of the certificate as issued.
 	//
 	// When a CertificateRequest resource is created, it will have the
@@ -513,8 +686,119 @@ type CertificateStatus struct {
 	// delay till the next issuance will be calculated using formula
 	// time.Hour * 2 ^ (failedIssuanceAttempts - 1).
 	FailedIssuanceAttempts *int
+
+	// The serial number of the certificate stored in the secret named by this
+	// resource in `spec.secretName`, as a colon separated hex string.
+	// It is recorded upon issuance so that the certificate can be revoked
+	// when `spec.revokeOnDelete` is set.
+	SerialNumber string
+
+	// The hex encoded SHA-256 fingerprint of the certificate stored in the
+	// secret named by this resource in `spec.secretName`.
+	SHA256Fingerprint string
+
+	// The first 16 characters of `status.sha256Fingerprint`, displayed by
+	// kubectl in place of the full fingerprint.
+	ShortSHA256Fingerprint string
+
+	// The distinguished name of the issuer of the certificate stored in the
+	// secret named by this resource in `spec.secretName`.
+	IssuerDN string
+
+	// The distinguished name of the subject of the certificate stored in the
+	// secret named by this resource in `spec.secretName`.
+	SubjectDN string
+
+	// The lowercase hex encoded SHA-256 fingerprints of the public keys of the
+	// CA certificates returned by the issuer along with the certificate
+	// stored in the secret named by this resource in `spec.secretName`.
+	// They are only recorded if the `cert-manager.io/verify-issuer-ca`
+	// annotation is set to "true" on this resource or on its issuer, in which
+	// case the certificate is re-issued if it is not signed by one of these
+	// keys.
+	IssuerCAFingerprints []string
+
+	// IssuanceHistory records the most recent attempts to issue the
+	// certificate, ordered from the oldest to the newest. The number of
+	// attempts which are kept is configured on the controller, and older
+	// attempts are removed.
+	IssuanceHistory []CertificateIssuanceAttempt
+
+	// LastEvaluation is the outcome of the last evaluation of the policies
+	// which decide whether the certificate must be re-issued. It is only
+	// updated when the outcome of the evaluation changes, and only recorded if
+	// the CertificatePolicyEvaluationStatus feature gate is enabled.
+	LastEvaluation *CertificatePolicyEvaluation
+}
+
+// CertificatePolicyEvaluation is the outcome of an evaluation of the policies
+// which decide whether a Certificate must be re-issued.
+type CertificatePolicyEvaluation struct {
+	// EvaluationTime is the time of the evaluation at which the outcome last
+	// changed.
+	EvaluationTime metav1.Time
+
+	// Policies are the results of the evaluated policies, in the order in
+	// which they were evaluated. The evaluation stops at the first violated
+	// policy, so only the last policy may be violated.
+	Policies []CertificatePolicyResult
+}
+
+// CertificatePolicyResult is the result of the evaluation of a single policy.
+type CertificatePolicyResult struct {
+	// PolicyName is the stable name of the policy.
+	PolicyName string
+
+	// Violated is true if the Certificate violates the policy.
+	Violated bool
+
+	// Reason is a brief machine readable explanation of the violation.
+	Reason string
+
+	// Message is a human readable description of the violation.
+	Message string
 }
 
+// CertificateIssuanceAttempt records the outcome of an attempt to issue a
+// Certificate.
+type CertificateIssuanceAttempt struct {
+	// The revision of the Certificate which was being issued.
+	Revision int
+
+	// The name of the CertificateRequest used for the attempt.
+	CertificateRequestName string
+
+	// StartTime is the time at which the CertificateRequest was created.
+	StartTime *metav1.Time
+
+	// EndTime is the time at which the attempt completed.
+	EndTime *metav1.Time
+
+	// Outcome of the attempt, one of (`Issued`, `Failed`).
+	Outcome CertificateIssuanceOutcome
+
+	// Reason is a brief machine readable explanation of the outcome.
+	Reason string
+
+	// Message is a human readable description of the outcome. Long messages
+	// are truncated.
+	Message string
+}
+
+// CertificateIssuanceOutcome is the outcome of an attempt to issue a
+// Certificate.
+type CertificateIssuanceOutcome string
+
+const (
+	// CertificateIssuanceOutcomeIssued means that the certificate was issued
+	// and stored in the Secret.
+	CertificateIssuanceOutcomeIssued CertificateIssuanceOutcome = "Issued"
+
+	// CertificateIssuanceOutcomeFailed means that the certificate could not
+	// be issued, and that the issuance will be retried.
+	CertificateIssuanceOutcomeFailed CertificateIssuanceOutcome = "Failed"
+)
+
 // CertificateCondition contains condition information for an Certificate.
 type CertificateCondition struct {
 	// Type of the condition, known values are (`Ready`, `Issuing`).
@@ -572,6 +856,39 @@ const (
 	//
 	// It will be removed by the 'issuing' controller upon completing issuance.
 	CertificateConditionIssuing CertificateConditionType = "Issuing"
+
+	// CertificateConditionIssuerKindNotFound indicates that the group and kind
+	// of the Certificate's issuerRef are not served by the API server, for
+	// example because the external issuer's CRDs are not installed or the
+	// group is misspelled. It is set to `False` once the kind is found.
+	CertificateConditionIssuerKindNotFound CertificateConditionType = "IssuerKindNotFound"
+
+	// CertificateConditionSecretTypeConflict indicates that the Certificate's
+	// Secret is of type `Opaque` and contains data unrelated to the
+	// Certificate, so cannot be converted to a `kubernetes.io/tls` Secret.
+	// It is removed by the 'issuing' controller upon completing issuance.
+	CertificateConditionSecretTypeConflict CertificateConditionType = "SecretTypeConflict"
+
+	// CertificateConditionInvalidCABundle indicates that the CA bundle
+	// referenced by the Certificate's `secretCAPolicy.bundleRef` does not exist
+	// or does not contain PEM encoded certificates, so the `ca.crt` key of the
+	// Certificate's Secret is left untouched. It is removed by the 'issuing'
+	// controller once the bundle can be used.
+	CertificateConditionInvalidCABundle CertificateConditionType = "InvalidCABundle"
+
+	// CertificateConditionExternalCSRInvalid indicates that the CSR referenced
+	// by the Certificate's `externalCSR.secretRef` does not exist, cannot be
+	// decoded or does not match the Certificate's spec, so no
+	// CertificateRequest can be created for it. It is removed by the
+	// 'requestmanager' controller once the CSR can be used.
+	CertificateConditionExternalCSRInvalid CertificateConditionType = "ExternalCSRInvalid"
+
+	// CertificateConditionWaitingForApproval indicates that the
+	// CertificateRequest for the next revision of the Certificate has been
+	// neither approved nor denied, e.g. by an approval plugin. It is set to
+	// `False` once the CertificateRequest is approved, and removed by the
+	// 'issuing' controller once the issuance completes or fails.
+	CertificateConditionWaitingForApproval CertificateConditionType = "WaitingForApproval"
 )
 
 // CertificateSecretTemplate defines the default labels and annotations
@@ -584,6 +901,80 @@ type CertificateSecretTemplate struct {
 	// Labels is a key value map to be copied to the target Kubernetes Secret.
 	// +optional
 	Labels map[string]string
+
+	// Immutable marks the target Kubernetes Secret as immutable, which
+	// reduces the load on the kube-apiserver as kubelets stop watching it.
+	// As the data of an immutable Secret cannot be changed, the Secret is
+	// deleted and re-created each time the certificate is issued. The new
+	// data is staged in a temporary Secret named `<secretName>-rotation`
+	// beforehand, so the Secret is only missing for the short time between
+	// the delete and the create, during which pods mounting it cannot start.
+	// Running pods keep the data they have mounted, and must be restarted to
+	// use the renewed certificate.
+	// Unsetting this field also re-creates the Secret.
+	// +optional
+	Immutable bool
+}
+
+// CertificateRequestTemplate defines the labels and annotations to be set on
+// the CertificateRequest resources created for a Certificate.
+type CertificateRequestTemplate struct {
+	// Annotations is a key value map to be copied to the CertificateRequest.
+	// +optional
+	Annotations map[string]string
+
+	// Labels is a key value map to be copied to the CertificateRequest.
+	// +optional
+	Labels map[string]string
+}
+
+// CertificateIssuanceWindow defines the daily time ranges during which a
+// Certificate may be re-issued.
+type CertificateIssuanceWindow struct {
+	// TimeZone is the IANA name of the time zone in which the ranges are
+	// interpreted, e.g. `Europe/London`. Daylight saving time transitions are
+	// taken into account. Defaults to `UTC`.
+	// +optional
+	TimeZone string
+
+	// Ranges are the daily time ranges during which the Certificate may be
+	// re-issued.
+	Ranges []CertificateIssuanceWindowRange
+}
+
+// CertificateIssuanceWindowRange is a daily time range.
+type CertificateIssuanceWindowRange struct {
+	// Start is the time of day at which the range starts, in the 24-hour
+	// `HH:MM` format.
+	Start string
+
+	// End is the time of day at which the range ends, in the 24-hour `HH:MM`
+	// format. If it is before Start, the range ends on the next day.
+	End string
+}
+
+// CertificateReadinessGate is an external endpoint which must accept the
+// certificates issued by an issuer before the Certificates referencing it are
+// marked as Ready.
+type CertificateReadinessGate struct {
+	// Name identifies the gate in the Ready condition of Certificates.
+	Name string
+
+	// URL is the HTTPS endpoint of the gate. The PEM encoded leaf certificate
+	// is sent to it in the `certificate` field of a JSON POST request, and it
+	// must respond with a JSON object whose boolean `allowed` field is the
+	// verdict of the gate, and whose optional `message` field explains it.
+	URL string
+
+	// CABundle is a PEM encoded CA bundle used to verify the certificate of
+	// the endpoint. Defaults to the system trust store.
+	// +optional
+	CABundle []byte
+
+	// Timeout of each request to the gate. Defaults to 10 seconds, and may not
+	// be more than 30 seconds.
+	// +optional
+	Timeout *metav1.Duration
 }
 
 // NameConstraints is a type to represent x509 NameConstraints
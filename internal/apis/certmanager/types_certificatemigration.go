@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certmanager
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmmeta "github.com/cert-manager/cert-manager/internal/apis/meta"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// A CertificateMigration is a campaign which moves a set of Certificates to a
+// new issuer at a controlled rate.
+type CertificateMigration struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+
+	// Desired state of the CertificateMigration.
+	Spec CertificateMigrationSpec
+
+	// Status of the CertificateMigration.
+	Status CertificateMigrationStatus
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CertificateMigrationList is a list of CertificateMigrations.
+type CertificateMigrationList struct {
+	metav1.TypeMeta
+	metav1.ListMeta
+
+	Items []CertificateMigration
+}
+
+// CertificateMigrationSpec defines the Certificates to migrate, the issuer to
+// migrate them to, and the rate at which to do so.
+type CertificateMigrationSpec struct {
+	// Namespaces is the list of namespaces in which Certificates are
+	// selected. If empty, Certificates in all namespaces are selected.
+	Namespaces []string
+
+	// Selector is a label selector for the Certificates to migrate.
+	Selector *metav1.LabelSelector
+
+	// IssuerRef is the issuer that the selected Certificates are migrated to.
+	IssuerRef cmmeta.ObjectReference
+
+	// MaxReissuancesPerHour is the maximum number of Certificates that are
+	// migrated, and therefore re-issued, per hour.
+	MaxReissuancesPerHour int32
+
+	// Paused stops any further Certificates from being migrated while true.
+	Paused bool
+}
+
+// CertificateMigrationStatus defines the observed state of a
+// CertificateMigration.
+type CertificateMigrationStatus struct {
+	// Total is the number of Certificates selected by the
+	// CertificateMigration.
+	Total int32
+
+	// Migrated is the number of selected Certificates that reference the
+	// new issuer and have been re-issued by it.
+	Migrated int32
+
+	// Pending is the number of selected Certificates that have not been
+	// migrated yet, or are being re-issued by the new issuer.
+	Pending int32
+
+	// Failed is the number of selected Certificates that reference the new
+	// issuer, but whose last issuance attempt failed, or that could not be
+	// updated to reference the new issuer.
+	Failed int32
+
+	// LastMigrationTime is the time at which a Certificate was last migrated
+	// to the new issuer.
+	LastMigrationTime *metav1.Time
+
+	// CompletionTime is the time at which every selected Certificate was
+	// first observed to be migrated.
+	CompletionTime *metav1.Time
+
+	// ObservedGeneration is the `metadata.generation` of the
+	// CertificateMigration that the status was computed for.
+	ObservedGeneration int64
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certmanager
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmmeta "github.com/cert-manager/cert-manager/internal/apis/meta"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// An IssuancePreview is used to check whether a Certificate would be issued
+// successfully by an issuer, without issuing it.
+type IssuancePreview struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+
+	// Specification of the Certificate to evaluate.
+	Spec IssuancePreviewSpec
+
+	// Status of the IssuancePreview.
+	Status IssuancePreviewStatus
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// IssuancePreviewList is a list of IssuancePreviews.
+type IssuancePreviewList struct {
+	metav1.TypeMeta
+	metav1.ListMeta
+
+	Items []IssuancePreview
+}
+
+// IssuancePreviewSpec defines the Certificate to evaluate.
+type IssuancePreviewSpec struct {
+	// CertificateSpec is the spec of the Certificate to evaluate.
+	CertificateSpec CertificateSpec
+
+	// IssuerRef, if set, overrides `certificateSpec.issuerRef`.
+	IssuerRef *cmmeta.ObjectReference
+
+	// TTLSecondsAfterFinished is the number of seconds after the
+	// IssuancePreview has been evaluated before it is deleted.
+	TTLSecondsAfterFinished *int32
+}
+
+// IssuancePreviewVerdict is the overall result of an IssuancePreview.
+type IssuancePreviewVerdict string
+
+// IssuancePreviewStatus defines the observed state of an IssuancePreview.
+type IssuancePreviewStatus struct {
+	// Verdict is `Pass` if every check passed, and `Fail` otherwise.
+	Verdict IssuancePreviewVerdict
+
+	// Checks contains the result of each check that was performed.
+	Checks []IssuancePreviewCheck
+
+	// ObservedGeneration is the `metadata.generation` of the
+	// IssuancePreview that was evaluated.
+	ObservedGeneration int64
+
+	// CompletionTime is the time at which the IssuancePreview was evaluated.
+	CompletionTime *metav1.Time
+}
+
+// IssuancePreviewCheck is the result of a single check.
+type IssuancePreviewCheck struct {
+	// Name of the check.
+	Name string
+
+	// Passed is true if the check passed.
+	Passed bool
+
+	// Reason is a brief machine readable explanation of the result.
+	Reason string
+
+	// Message is a human readable description of the result.
+	Message string
+}
@@ -54,6 +54,56 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.CAIssuerCRL)(nil), (*certmanager.CAIssuerCRL)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CAIssuerCRL_To_certmanager_CAIssuerCRL(a.(*v1.CAIssuerCRL), b.(*certmanager.CAIssuerCRL), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CAIssuerCRL)(nil), (*v1.CAIssuerCRL)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CAIssuerCRL_To_v1_CAIssuerCRL(a.(*certmanager.CAIssuerCRL), b.(*v1.CAIssuerCRL), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CAIssuerRevokedCertificate)(nil), (*certmanager.CAIssuerRevokedCertificate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CAIssuerRevokedCertificate_To_certmanager_CAIssuerRevokedCertificate(a.(*v1.CAIssuerRevokedCertificate), b.(*certmanager.CAIssuerRevokedCertificate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CAIssuerRevokedCertificate)(nil), (*v1.CAIssuerRevokedCertificate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CAIssuerRevokedCertificate_To_v1_CAIssuerRevokedCertificate(a.(*certmanager.CAIssuerRevokedCertificate), b.(*v1.CAIssuerRevokedCertificate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CAIssuerRotationStatus)(nil), (*certmanager.CAIssuerRotationStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CAIssuerRotationStatus_To_certmanager_CAIssuerRotationStatus(a.(*v1.CAIssuerRotationStatus), b.(*certmanager.CAIssuerRotationStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CAIssuerRotationStatus)(nil), (*v1.CAIssuerRotationStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CAIssuerRotationStatus_To_v1_CAIssuerRotationStatus(a.(*certmanager.CAIssuerRotationStatus), b.(*v1.CAIssuerRotationStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CAIssuerSecretReference)(nil), (*certmanager.CAIssuerSecretReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CAIssuerSecretReference_To_certmanager_CAIssuerSecretReference(a.(*v1.CAIssuerSecretReference), b.(*certmanager.CAIssuerSecretReference), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CAIssuerSecretReference)(nil), (*v1.CAIssuerSecretReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CAIssuerSecretReference_To_v1_CAIssuerSecretReference(a.(*certmanager.CAIssuerSecretReference), b.(*v1.CAIssuerSecretReference), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CAIssuerStatus)(nil), (*certmanager.CAIssuerStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CAIssuerStatus_To_certmanager_CAIssuerStatus(a.(*v1.CAIssuerStatus), b.(*certmanager.CAIssuerStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CAIssuerStatus)(nil), (*v1.CAIssuerStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CAIssuerStatus_To_v1_CAIssuerStatus(a.(*certmanager.CAIssuerStatus), b.(*v1.CAIssuerStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.Certificate)(nil), (*certmanager.Certificate)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_Certificate_To_certmanager_Certificate(a.(*v1.Certificate), b.(*certmanager.Certificate), scope)
 	}); err != nil {
@@ -74,6 +124,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateCABundleReference)(nil), (*certmanager.CertificateCABundleReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateCABundleReference_To_certmanager_CertificateCABundleReference(a.(*v1.CertificateCABundleReference), b.(*certmanager.CertificateCABundleReference), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateCABundleReference)(nil), (*v1.CertificateCABundleReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateCABundleReference_To_v1_CertificateCABundleReference(a.(*certmanager.CertificateCABundleReference), b.(*v1.CertificateCABundleReference), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.CertificateCondition)(nil), (*certmanager.CertificateCondition)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_CertificateCondition_To_certmanager_CertificateCondition(a.(*v1.CertificateCondition), b.(*certmanager.CertificateCondition), scope)
 	}); err != nil {
@@ -84,6 +144,56 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateExternalCSR)(nil), (*certmanager.CertificateExternalCSR)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateExternalCSR_To_certmanager_CertificateExternalCSR(a.(*v1.CertificateExternalCSR), b.(*certmanager.CertificateExternalCSR), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateExternalCSR)(nil), (*v1.CertificateExternalCSR)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateExternalCSR_To_v1_CertificateExternalCSR(a.(*certmanager.CertificateExternalCSR), b.(*v1.CertificateExternalCSR), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateExternalPrivateKey)(nil), (*certmanager.CertificateExternalPrivateKey)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateExternalPrivateKey_To_certmanager_CertificateExternalPrivateKey(a.(*v1.CertificateExternalPrivateKey), b.(*certmanager.CertificateExternalPrivateKey), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateExternalPrivateKey)(nil), (*v1.CertificateExternalPrivateKey)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateExternalPrivateKey_To_v1_CertificateExternalPrivateKey(a.(*certmanager.CertificateExternalPrivateKey), b.(*v1.CertificateExternalPrivateKey), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateIssuanceAttempt)(nil), (*certmanager.CertificateIssuanceAttempt)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateIssuanceAttempt_To_certmanager_CertificateIssuanceAttempt(a.(*v1.CertificateIssuanceAttempt), b.(*certmanager.CertificateIssuanceAttempt), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateIssuanceAttempt)(nil), (*v1.CertificateIssuanceAttempt)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateIssuanceAttempt_To_v1_CertificateIssuanceAttempt(a.(*certmanager.CertificateIssuanceAttempt), b.(*v1.CertificateIssuanceAttempt), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateIssuanceWindow)(nil), (*certmanager.CertificateIssuanceWindow)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateIssuanceWindow_To_certmanager_CertificateIssuanceWindow(a.(*v1.CertificateIssuanceWindow), b.(*certmanager.CertificateIssuanceWindow), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateIssuanceWindow)(nil), (*v1.CertificateIssuanceWindow)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateIssuanceWindow_To_v1_CertificateIssuanceWindow(a.(*certmanager.CertificateIssuanceWindow), b.(*v1.CertificateIssuanceWindow), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateIssuanceWindowRange)(nil), (*certmanager.CertificateIssuanceWindowRange)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateIssuanceWindowRange_To_certmanager_CertificateIssuanceWindowRange(a.(*v1.CertificateIssuanceWindowRange), b.(*certmanager.CertificateIssuanceWindowRange), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateIssuanceWindowRange)(nil), (*v1.CertificateIssuanceWindowRange)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateIssuanceWindowRange_To_v1_CertificateIssuanceWindowRange(a.(*certmanager.CertificateIssuanceWindowRange), b.(*v1.CertificateIssuanceWindowRange), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.CertificateKeystores)(nil), (*certmanager.CertificateKeystores)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_CertificateKeystores_To_certmanager_CertificateKeystores(a.(*v1.CertificateKeystores), b.(*certmanager.CertificateKeystores), scope)
 	}); err != nil {
@@ -104,6 +214,66 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateMigration)(nil), (*certmanager.CertificateMigration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateMigration_To_certmanager_CertificateMigration(a.(*v1.CertificateMigration), b.(*certmanager.CertificateMigration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateMigration)(nil), (*v1.CertificateMigration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateMigration_To_v1_CertificateMigration(a.(*certmanager.CertificateMigration), b.(*v1.CertificateMigration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateMigrationList)(nil), (*certmanager.CertificateMigrationList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateMigrationList_To_certmanager_CertificateMigrationList(a.(*v1.CertificateMigrationList), b.(*certmanager.CertificateMigrationList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateMigrationList)(nil), (*v1.CertificateMigrationList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateMigrationList_To_v1_CertificateMigrationList(a.(*certmanager.CertificateMigrationList), b.(*v1.CertificateMigrationList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateMigrationSpec)(nil), (*certmanager.CertificateMigrationSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateMigrationSpec_To_certmanager_CertificateMigrationSpec(a.(*v1.CertificateMigrationSpec), b.(*certmanager.CertificateMigrationSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateMigrationSpec)(nil), (*v1.CertificateMigrationSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateMigrationSpec_To_v1_CertificateMigrationSpec(a.(*certmanager.CertificateMigrationSpec), b.(*v1.CertificateMigrationSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateMigrationStatus)(nil), (*certmanager.CertificateMigrationStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateMigrationStatus_To_certmanager_CertificateMigrationStatus(a.(*v1.CertificateMigrationStatus), b.(*certmanager.CertificateMigrationStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateMigrationStatus)(nil), (*v1.CertificateMigrationStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateMigrationStatus_To_v1_CertificateMigrationStatus(a.(*certmanager.CertificateMigrationStatus), b.(*v1.CertificateMigrationStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificatePolicyEvaluation)(nil), (*certmanager.CertificatePolicyEvaluation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificatePolicyEvaluation_To_certmanager_CertificatePolicyEvaluation(a.(*v1.CertificatePolicyEvaluation), b.(*certmanager.CertificatePolicyEvaluation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificatePolicyEvaluation)(nil), (*v1.CertificatePolicyEvaluation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificatePolicyEvaluation_To_v1_CertificatePolicyEvaluation(a.(*certmanager.CertificatePolicyEvaluation), b.(*v1.CertificatePolicyEvaluation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificatePolicyResult)(nil), (*certmanager.CertificatePolicyResult)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificatePolicyResult_To_certmanager_CertificatePolicyResult(a.(*v1.CertificatePolicyResult), b.(*certmanager.CertificatePolicyResult), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificatePolicyResult)(nil), (*v1.CertificatePolicyResult)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificatePolicyResult_To_v1_CertificatePolicyResult(a.(*certmanager.CertificatePolicyResult), b.(*v1.CertificatePolicyResult), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.CertificatePrivateKey)(nil), (*certmanager.CertificatePrivateKey)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_CertificatePrivateKey_To_certmanager_CertificatePrivateKey(a.(*v1.CertificatePrivateKey), b.(*certmanager.CertificatePrivateKey), scope)
 	}); err != nil {
@@ -114,6 +284,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateReadinessGate)(nil), (*certmanager.CertificateReadinessGate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateReadinessGate_To_certmanager_CertificateReadinessGate(a.(*v1.CertificateReadinessGate), b.(*certmanager.CertificateReadinessGate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateReadinessGate)(nil), (*v1.CertificateReadinessGate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateReadinessGate_To_v1_CertificateReadinessGate(a.(*certmanager.CertificateReadinessGate), b.(*v1.CertificateReadinessGate), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.CertificateRequest)(nil), (*certmanager.CertificateRequest)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_CertificateRequest_To_certmanager_CertificateRequest(a.(*v1.CertificateRequest), b.(*certmanager.CertificateRequest), scope)
 	}); err != nil {
@@ -164,6 +344,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateRequestTemplate)(nil), (*certmanager.CertificateRequestTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateRequestTemplate_To_certmanager_CertificateRequestTemplate(a.(*v1.CertificateRequestTemplate), b.(*certmanager.CertificateRequestTemplate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateRequestTemplate)(nil), (*v1.CertificateRequestTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateRequestTemplate_To_v1_CertificateRequestTemplate(a.(*certmanager.CertificateRequestTemplate), b.(*v1.CertificateRequestTemplate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateSecretCAPolicy)(nil), (*certmanager.CertificateSecretCAPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateSecretCAPolicy_To_certmanager_CertificateSecretCAPolicy(a.(*v1.CertificateSecretCAPolicy), b.(*certmanager.CertificateSecretCAPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateSecretCAPolicy)(nil), (*v1.CertificateSecretCAPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateSecretCAPolicy_To_v1_CertificateSecretCAPolicy(a.(*certmanager.CertificateSecretCAPolicy), b.(*v1.CertificateSecretCAPolicy), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.CertificateSecretTemplate)(nil), (*certmanager.CertificateSecretTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_CertificateSecretTemplate_To_certmanager_CertificateSecretTemplate(a.(*v1.CertificateSecretTemplate), b.(*certmanager.CertificateSecretTemplate), scope)
 	}); err != nil {
@@ -214,6 +414,56 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.IssuancePreview)(nil), (*certmanager.IssuancePreview)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_IssuancePreview_To_certmanager_IssuancePreview(a.(*v1.IssuancePreview), b.(*certmanager.IssuancePreview), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuancePreview)(nil), (*v1.IssuancePreview)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuancePreview_To_v1_IssuancePreview(a.(*certmanager.IssuancePreview), b.(*v1.IssuancePreview), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.IssuancePreviewCheck)(nil), (*certmanager.IssuancePreviewCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_IssuancePreviewCheck_To_certmanager_IssuancePreviewCheck(a.(*v1.IssuancePreviewCheck), b.(*certmanager.IssuancePreviewCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuancePreviewCheck)(nil), (*v1.IssuancePreviewCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuancePreviewCheck_To_v1_IssuancePreviewCheck(a.(*certmanager.IssuancePreviewCheck), b.(*v1.IssuancePreviewCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.IssuancePreviewList)(nil), (*certmanager.IssuancePreviewList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_IssuancePreviewList_To_certmanager_IssuancePreviewList(a.(*v1.IssuancePreviewList), b.(*certmanager.IssuancePreviewList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuancePreviewList)(nil), (*v1.IssuancePreviewList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuancePreviewList_To_v1_IssuancePreviewList(a.(*certmanager.IssuancePreviewList), b.(*v1.IssuancePreviewList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.IssuancePreviewSpec)(nil), (*certmanager.IssuancePreviewSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_IssuancePreviewSpec_To_certmanager_IssuancePreviewSpec(a.(*v1.IssuancePreviewSpec), b.(*certmanager.IssuancePreviewSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuancePreviewSpec)(nil), (*v1.IssuancePreviewSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuancePreviewSpec_To_v1_IssuancePreviewSpec(a.(*certmanager.IssuancePreviewSpec), b.(*v1.IssuancePreviewSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.IssuancePreviewStatus)(nil), (*certmanager.IssuancePreviewStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_IssuancePreviewStatus_To_certmanager_IssuancePreviewStatus(a.(*v1.IssuancePreviewStatus), b.(*certmanager.IssuancePreviewStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuancePreviewStatus)(nil), (*v1.IssuancePreviewStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuancePreviewStatus_To_v1_IssuancePreviewStatus(a.(*certmanager.IssuancePreviewStatus), b.(*v1.IssuancePreviewStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.Issuer)(nil), (*certmanager.Issuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_Issuer_To_certmanager_Issuer(a.(*v1.Issuer), b.(*certmanager.Issuer), scope)
 	}); err != nil {
@@ -284,6 +534,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.ManualIssuer)(nil), (*certmanager.ManualIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ManualIssuer_To_certmanager_ManualIssuer(a.(*v1.ManualIssuer), b.(*certmanager.ManualIssuer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.ManualIssuer)(nil), (*v1.ManualIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_ManualIssuer_To_v1_ManualIssuer(a.(*certmanager.ManualIssuer), b.(*v1.ManualIssuer), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.NameConstraintItem)(nil), (*certmanager.NameConstraintItem)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_NameConstraintItem_To_certmanager_NameConstraintItem(a.(*v1.NameConstraintItem), b.(*certmanager.NameConstraintItem), scope)
 	}); err != nil {
@@ -429,9 +689,14 @@ func RegisterConversions(s *runtime.Scheme) error {
 
 func autoConvert_v1_CAIssuer_To_certmanager_CAIssuer(in *v1.CAIssuer, out *certmanager.CAIssuer, s conversion.Scope) error {
 	out.SecretName = in.SecretName
+	out.SecretRef = (*certmanager.CAIssuerSecretReference)(unsafe.Pointer(in.SecretRef))
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	out.OCSPServers = *(*[]string)(unsafe.Pointer(&in.OCSPServers))
 	out.IssuingCertificateURLs = *(*[]string)(unsafe.Pointer(&in.IssuingCertificateURLs))
+	out.AllowedPolicyOIDs = *(*[]string)(unsafe.Pointer(&in.AllowedPolicyOIDs))
+	out.CRL = (*certmanager.CAIssuerCRL)(unsafe.Pointer(in.CRL))
+	out.ReissueOnCARotation = in.ReissueOnCARotation
+	out.SpreadDuration = (*metav1.Duration)(unsafe.Pointer(in.SpreadDuration))
 	return nil
 }
 
@@ -442,9 +707,14 @@ func Convert_v1_CAIssuer_To_certmanager_CAIssuer(in *v1.CAIssuer, out *certmanag
 
 func autoConvert_certmanager_CAIssuer_To_v1_CAIssuer(in *certmanager.CAIssuer, out *v1.CAIssuer, s conversion.Scope) error {
 	out.SecretName = in.SecretName
+	out.SecretRef = (*v1.CAIssuerSecretReference)(unsafe.Pointer(in.SecretRef))
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	out.OCSPServers = *(*[]string)(unsafe.Pointer(&in.OCSPServers))
 	out.IssuingCertificateURLs = *(*[]string)(unsafe.Pointer(&in.IssuingCertificateURLs))
+	out.AllowedPolicyOIDs = *(*[]string)(unsafe.Pointer(&in.AllowedPolicyOIDs))
+	out.CRL = (*v1.CAIssuerCRL)(unsafe.Pointer(in.CRL))
+	out.ReissueOnCARotation = in.ReissueOnCARotation
+	out.SpreadDuration = (*metav1.Duration)(unsafe.Pointer(in.SpreadDuration))
 	return nil
 }
 
@@ -453,6 +723,128 @@ func Convert_certmanager_CAIssuer_To_v1_CAIssuer(in *certmanager.CAIssuer, out *
 	return autoConvert_certmanager_CAIssuer_To_v1_CAIssuer(in, out, s)
 }
 
+func autoConvert_v1_CAIssuerCRL_To_certmanager_CAIssuerCRL(in *v1.CAIssuerCRL, out *certmanager.CAIssuerCRL, s conversion.Scope) error {
+	out.SecretName = in.SecretName
+	out.ConfigMapName = in.ConfigMapName
+	out.UpdateInterval = (*metav1.Duration)(unsafe.Pointer(in.UpdateInterval))
+	return nil
+}
+
+// Convert_v1_CAIssuerCRL_To_certmanager_CAIssuerCRL is an autogenerated conversion function.
+func Convert_v1_CAIssuerCRL_To_certmanager_CAIssuerCRL(in *v1.CAIssuerCRL, out *certmanager.CAIssuerCRL, s conversion.Scope) error {
+	return autoConvert_v1_CAIssuerCRL_To_certmanager_CAIssuerCRL(in, out, s)
+}
+
+func autoConvert_certmanager_CAIssuerCRL_To_v1_CAIssuerCRL(in *certmanager.CAIssuerCRL, out *v1.CAIssuerCRL, s conversion.Scope) error {
+	out.SecretName = in.SecretName
+	out.ConfigMapName = in.ConfigMapName
+	out.UpdateInterval = (*metav1.Duration)(unsafe.Pointer(in.UpdateInterval))
+	return nil
+}
+
+// Convert_certmanager_CAIssuerCRL_To_v1_CAIssuerCRL is an autogenerated conversion function.
+func Convert_certmanager_CAIssuerCRL_To_v1_CAIssuerCRL(in *certmanager.CAIssuerCRL, out *v1.CAIssuerCRL, s conversion.Scope) error {
+	return autoConvert_certmanager_CAIssuerCRL_To_v1_CAIssuerCRL(in, out, s)
+}
+
+func autoConvert_v1_CAIssuerRevokedCertificate_To_certmanager_CAIssuerRevokedCertificate(in *v1.CAIssuerRevokedCertificate, out *certmanager.CAIssuerRevokedCertificate, s conversion.Scope) error {
+	out.SerialNumber = in.SerialNumber
+	out.RevocationTime = in.RevocationTime
+	out.NotAfter = (*metav1.Time)(unsafe.Pointer(in.NotAfter))
+	return nil
+}
+
+// Convert_v1_CAIssuerRevokedCertificate_To_certmanager_CAIssuerRevokedCertificate is an autogenerated conversion function.
+func Convert_v1_CAIssuerRevokedCertificate_To_certmanager_CAIssuerRevokedCertificate(in *v1.CAIssuerRevokedCertificate, out *certmanager.CAIssuerRevokedCertificate, s conversion.Scope) error {
+	return autoConvert_v1_CAIssuerRevokedCertificate_To_certmanager_CAIssuerRevokedCertificate(in, out, s)
+}
+
+func autoConvert_certmanager_CAIssuerRevokedCertificate_To_v1_CAIssuerRevokedCertificate(in *certmanager.CAIssuerRevokedCertificate, out *v1.CAIssuerRevokedCertificate, s conversion.Scope) error {
+	out.SerialNumber = in.SerialNumber
+	out.RevocationTime = in.RevocationTime
+	out.NotAfter = (*metav1.Time)(unsafe.Pointer(in.NotAfter))
+	return nil
+}
+
+// Convert_certmanager_CAIssuerRevokedCertificate_To_v1_CAIssuerRevokedCertificate is an autogenerated conversion function.
+func Convert_certmanager_CAIssuerRevokedCertificate_To_v1_CAIssuerRevokedCertificate(in *certmanager.CAIssuerRevokedCertificate, out *v1.CAIssuerRevokedCertificate, s conversion.Scope) error {
+	return autoConvert_certmanager_CAIssuerRevokedCertificate_To_v1_CAIssuerRevokedCertificate(in, out, s)
+}
+
+func autoConvert_v1_CAIssuerRotationStatus_To_certmanager_CAIssuerRotationStatus(in *v1.CAIssuerRotationStatus, out *certmanager.CAIssuerRotationStatus, s conversion.Scope) error {
+	out.StartTime = in.StartTime
+	out.Total = in.Total
+	out.Triggered = in.Triggered
+	out.Reissued = in.Reissued
+	out.CompletionTime = (*metav1.Time)(unsafe.Pointer(in.CompletionTime))
+	return nil
+}
+
+// Convert_v1_CAIssuerRotationStatus_To_certmanager_CAIssuerRotationStatus is an autogenerated conversion function.
+func Convert_v1_CAIssuerRotationStatus_To_certmanager_CAIssuerRotationStatus(in *v1.CAIssuerRotationStatus, out *certmanager.CAIssuerRotationStatus, s conversion.Scope) error {
+	return autoConvert_v1_CAIssuerRotationStatus_To_certmanager_CAIssuerRotationStatus(in, out, s)
+}
+
+func autoConvert_certmanager_CAIssuerRotationStatus_To_v1_CAIssuerRotationStatus(in *certmanager.CAIssuerRotationStatus, out *v1.CAIssuerRotationStatus, s conversion.Scope) error {
+	out.StartTime = in.StartTime
+	out.Total = in.Total
+	out.Triggered = in.Triggered
+	out.Reissued = in.Reissued
+	out.CompletionTime = (*metav1.Time)(unsafe.Pointer(in.CompletionTime))
+	return nil
+}
+
+// Convert_certmanager_CAIssuerRotationStatus_To_v1_CAIssuerRotationStatus is an autogenerated conversion function.
+func Convert_certmanager_CAIssuerRotationStatus_To_v1_CAIssuerRotationStatus(in *certmanager.CAIssuerRotationStatus, out *v1.CAIssuerRotationStatus, s conversion.Scope) error {
+	return autoConvert_certmanager_CAIssuerRotationStatus_To_v1_CAIssuerRotationStatus(in, out, s)
+}
+
+func autoConvert_v1_CAIssuerSecretReference_To_certmanager_CAIssuerSecretReference(in *v1.CAIssuerSecretReference, out *certmanager.CAIssuerSecretReference, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Namespace = in.Namespace
+	return nil
+}
+
+// Convert_v1_CAIssuerSecretReference_To_certmanager_CAIssuerSecretReference is an autogenerated conversion function.
+func Convert_v1_CAIssuerSecretReference_To_certmanager_CAIssuerSecretReference(in *v1.CAIssuerSecretReference, out *certmanager.CAIssuerSecretReference, s conversion.Scope) error {
+	return autoConvert_v1_CAIssuerSecretReference_To_certmanager_CAIssuerSecretReference(in, out, s)
+}
+
+func autoConvert_certmanager_CAIssuerSecretReference_To_v1_CAIssuerSecretReference(in *certmanager.CAIssuerSecretReference, out *v1.CAIssuerSecretReference, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Namespace = in.Namespace
+	return nil
+}
+
+// Convert_certmanager_CAIssuerSecretReference_To_v1_CAIssuerSecretReference is an autogenerated conversion function.
+func Convert_certmanager_CAIssuerSecretReference_To_v1_CAIssuerSecretReference(in *certmanager.CAIssuerSecretReference, out *v1.CAIssuerSecretReference, s conversion.Scope) error {
+	return autoConvert_certmanager_CAIssuerSecretReference_To_v1_CAIssuerSecretReference(in, out, s)
+}
+
+func autoConvert_v1_CAIssuerStatus_To_certmanager_CAIssuerStatus(in *v1.CAIssuerStatus, out *certmanager.CAIssuerStatus, s conversion.Scope) error {
+	out.RevokedCertificates = *(*[]certmanager.CAIssuerRevokedCertificate)(unsafe.Pointer(&in.RevokedCertificates))
+	out.SigningCertificateFingerprint = in.SigningCertificateFingerprint
+	out.Rotation = (*certmanager.CAIssuerRotationStatus)(unsafe.Pointer(in.Rotation))
+	return nil
+}
+
+// Convert_v1_CAIssuerStatus_To_certmanager_CAIssuerStatus is an autogenerated conversion function.
+func Convert_v1_CAIssuerStatus_To_certmanager_CAIssuerStatus(in *v1.CAIssuerStatus, out *certmanager.CAIssuerStatus, s conversion.Scope) error {
+	return autoConvert_v1_CAIssuerStatus_To_certmanager_CAIssuerStatus(in, out, s)
+}
+
+func autoConvert_certmanager_CAIssuerStatus_To_v1_CAIssuerStatus(in *certmanager.CAIssuerStatus, out *v1.CAIssuerStatus, s conversion.Scope) error {
+	out.RevokedCertificates = *(*[]v1.CAIssuerRevokedCertificate)(unsafe.Pointer(&in.RevokedCertificates))
+	out.SigningCertificateFingerprint = in.SigningCertificateFingerprint
+	out.Rotation = (*v1.CAIssuerRotationStatus)(unsafe.Pointer(in.Rotation))
+	return nil
+}
+
+// Convert_certmanager_CAIssuerStatus_To_v1_CAIssuerStatus is an autogenerated conversion function.
+func Convert_certmanager_CAIssuerStatus_To_v1_CAIssuerStatus(in *certmanager.CAIssuerStatus, out *v1.CAIssuerStatus, s conversion.Scope) error {
+	return autoConvert_certmanager_CAIssuerStatus_To_v1_CAIssuerStatus(in, out, s)
+}
+
 func autoConvert_v1_Certificate_To_certmanager_Certificate(in *v1.Certificate, out *certmanager.Certificate, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1_CertificateSpec_To_certmanager_CertificateSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -505,6 +897,30 @@ func Convert_certmanager_CertificateAdditionalOutputFormat_To_v1_CertificateAddi
 	return autoConvert_certmanager_CertificateAdditionalOutputFormat_To_v1_CertificateAdditionalOutputFormat(in, out, s)
 }
 
+func autoConvert_v1_CertificateCABundleReference_To_certmanager_CertificateCABundleReference(in *v1.CertificateCABundleReference, out *certmanager.CertificateCABundleReference, s conversion.Scope) error {
+	out.Kind = in.Kind
+	out.Name = in.Name
+	out.Key = in.Key
+	return nil
+}
+
+// Convert_v1_CertificateCABundleReference_To_certmanager_CertificateCABundleReference is an autogenerated conversion function.
+func Convert_v1_CertificateCABundleReference_To_certmanager_CertificateCABundleReference(in *v1.CertificateCABundleReference, out *certmanager.CertificateCABundleReference, s conversion.Scope) error {
+	return autoConvert_v1_CertificateCABundleReference_To_certmanager_CertificateCABundleReference(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateCABundleReference_To_v1_CertificateCABundleReference(in *certmanager.CertificateCABundleReference, out *v1.CertificateCABundleReference, s conversion.Scope) error {
+	out.Kind = in.Kind
+	out.Name = in.Name
+	out.Key = in.Key
+	return nil
+}
+
+// Convert_certmanager_CertificateCABundleReference_To_v1_CertificateCABundleReference is an autogenerated conversion function.
+func Convert_certmanager_CertificateCABundleReference_To_v1_CertificateCABundleReference(in *certmanager.CertificateCABundleReference, out *v1.CertificateCABundleReference, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateCABundleReference_To_v1_CertificateCABundleReference(in, out, s)
+}
+
 func autoConvert_v1_CertificateCondition_To_certmanager_CertificateCondition(in *v1.CertificateCondition, out *certmanager.CertificateCondition, s conversion.Scope) error {
 	out.Type = certmanager.CertificateConditionType(in.Type)
 	out.Status = meta.ConditionStatus(in.Status)
@@ -535,6 +951,126 @@ func Convert_certmanager_CertificateCondition_To_v1_CertificateCondition(in *cer
 	return autoConvert_certmanager_CertificateCondition_To_v1_CertificateCondition(in, out, s)
 }
 
+func autoConvert_v1_CertificateExternalCSR_To_certmanager_CertificateExternalCSR(in *v1.CertificateExternalCSR, out *certmanager.CertificateExternalCSR, s conversion.Scope) error {
+	if err := internalapismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1_CertificateExternalCSR_To_certmanager_CertificateExternalCSR is an autogenerated conversion function.
+func Convert_v1_CertificateExternalCSR_To_certmanager_CertificateExternalCSR(in *v1.CertificateExternalCSR, out *certmanager.CertificateExternalCSR, s conversion.Scope) error {
+	return autoConvert_v1_CertificateExternalCSR_To_certmanager_CertificateExternalCSR(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateExternalCSR_To_v1_CertificateExternalCSR(in *certmanager.CertificateExternalCSR, out *v1.CertificateExternalCSR, s conversion.Scope) error {
+	if err := internalapismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_CertificateExternalCSR_To_v1_CertificateExternalCSR is an autogenerated conversion function.
+func Convert_certmanager_CertificateExternalCSR_To_v1_CertificateExternalCSR(in *certmanager.CertificateExternalCSR, out *v1.CertificateExternalCSR, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateExternalCSR_To_v1_CertificateExternalCSR(in, out, s)
+}
+
+func autoConvert_v1_CertificateExternalPrivateKey_To_certmanager_CertificateExternalPrivateKey(in *v1.CertificateExternalPrivateKey, out *certmanager.CertificateExternalPrivateKey, s conversion.Scope) error {
+	out.KeyRing = in.KeyRing
+	return nil
+}
+
+// Convert_v1_CertificateExternalPrivateKey_To_certmanager_CertificateExternalPrivateKey is an autogenerated conversion function.
+func Convert_v1_CertificateExternalPrivateKey_To_certmanager_CertificateExternalPrivateKey(in *v1.CertificateExternalPrivateKey, out *certmanager.CertificateExternalPrivateKey, s conversion.Scope) error {
+	return autoConvert_v1_CertificateExternalPrivateKey_To_certmanager_CertificateExternalPrivateKey(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateExternalPrivateKey_To_v1_CertificateExternalPrivateKey(in *certmanager.CertificateExternalPrivateKey, out *v1.CertificateExternalPrivateKey, s conversion.Scope) error {
+	out.KeyRing = in.KeyRing
+	return nil
+}
+
+// Convert_certmanager_CertificateExternalPrivateKey_To_v1_CertificateExternalPrivateKey is an autogenerated conversion function.
+func Convert_certmanager_CertificateExternalPrivateKey_To_v1_CertificateExternalPrivateKey(in *certmanager.CertificateExternalPrivateKey, out *v1.CertificateExternalPrivateKey, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateExternalPrivateKey_To_v1_CertificateExternalPrivateKey(in, out, s)
+}
+
+func autoConvert_v1_CertificateIssuanceAttempt_To_certmanager_CertificateIssuanceAttempt(in *v1.CertificateIssuanceAttempt, out *certmanager.CertificateIssuanceAttempt, s conversion.Scope) error {
+	out.Revision = in.Revision
+	out.CertificateRequestName = in.CertificateRequestName
+	out.StartTime = (*metav1.Time)(unsafe.Pointer(in.StartTime))
+	out.EndTime = (*metav1.Time)(unsafe.Pointer(in.EndTime))
+	out.Outcome = certmanager.CertificateIssuanceOutcome(in.Outcome)
+	out.Reason = in.Reason
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_v1_CertificateIssuanceAttempt_To_certmanager_CertificateIssuanceAttempt is an autogenerated conversion function.
+func Convert_v1_CertificateIssuanceAttempt_To_certmanager_CertificateIssuanceAttempt(in *v1.CertificateIssuanceAttempt, out *certmanager.CertificateIssuanceAttempt, s conversion.Scope) error {
+	return autoConvert_v1_CertificateIssuanceAttempt_To_certmanager_CertificateIssuanceAttempt(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateIssuanceAttempt_To_v1_CertificateIssuanceAttempt(in *certmanager.CertificateIssuanceAttempt, out *v1.CertificateIssuanceAttempt, s conversion.Scope) error {
+	out.Revision = in.Revision
+	out.CertificateRequestName = in.CertificateRequestName
+	out.StartTime = (*metav1.Time)(unsafe.Pointer(in.StartTime))
+	out.EndTime = (*metav1.Time)(unsafe.Pointer(in.EndTime))
+	out.Outcome = v1.CertificateIssuanceOutcome(in.Outcome)
+	out.Reason = in.Reason
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_certmanager_CertificateIssuanceAttempt_To_v1_CertificateIssuanceAttempt is an autogenerated conversion function.
+func Convert_certmanager_CertificateIssuanceAttempt_To_v1_CertificateIssuanceAttempt(in *certmanager.CertificateIssuanceAttempt, out *v1.CertificateIssuanceAttempt, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateIssuanceAttempt_To_v1_CertificateIssuanceAttempt(in, out, s)
+}
+
+func autoConvert_v1_CertificateIssuanceWindow_To_certmanager_CertificateIssuanceWindow(in *v1.CertificateIssuanceWindow, out *certmanager.CertificateIssuanceWindow, s conversion.Scope) error {
+	out.TimeZone = in.TimeZone
+	out.Ranges = *(*[]certmanager.CertificateIssuanceWindowRange)(unsafe.Pointer(&in.Ranges))
+	return nil
+}
+
+// Convert_v1_CertificateIssuanceWindow_To_certmanager_CertificateIssuanceWindow is an autogenerated conversion function.
+func Convert_v1_CertificateIssuanceWindow_To_certmanager_CertificateIssuanceWindow(in *v1.CertificateIssuanceWindow, out *certmanager.CertificateIssuanceWindow, s conversion.Scope) error {
+	return autoConvert_v1_CertificateIssuanceWindow_To_certmanager_CertificateIssuanceWindow(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateIssuanceWindow_To_v1_CertificateIssuanceWindow(in *certmanager.CertificateIssuanceWindow, out *v1.CertificateIssuanceWindow, s conversion.Scope) error {
+	out.TimeZone = in.TimeZone
+	out.Ranges = *(*[]v1.CertificateIssuanceWindowRange)(unsafe.Pointer(&in.Ranges))
+	return nil
+}
+
+// Convert_certmanager_CertificateIssuanceWindow_To_v1_CertificateIssuanceWindow is an autogenerated conversion function.
+func Convert_certmanager_CertificateIssuanceWindow_To_v1_CertificateIssuanceWindow(in *certmanager.CertificateIssuanceWindow, out *v1.CertificateIssuanceWindow, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateIssuanceWindow_To_v1_CertificateIssuanceWindow(in, out, s)
+}
+
+func autoConvert_v1_CertificateIssuanceWindowRange_To_certmanager_CertificateIssuanceWindowRange(in *v1.CertificateIssuanceWindowRange, out *certmanager.CertificateIssuanceWindowRange, s conversion.Scope) error {
+	out.Start = in.Start
+	out.End = in.End
+	return nil
+}
+
+// Convert_v1_CertificateIssuanceWindowRange_To_certmanager_CertificateIssuanceWindowRange is an autogenerated conversion function.
+func Convert_v1_CertificateIssuanceWindowRange_To_certmanager_CertificateIssuanceWindowRange(in *v1.CertificateIssuanceWindowRange, out *certmanager.CertificateIssuanceWindowRange, s conversion.Scope) error {
+	return autoConvert_v1_CertificateIssuanceWindowRange_To_certmanager_CertificateIssuanceWindowRange(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateIssuanceWindowRange_To_v1_CertificateIssuanceWindowRange(in *certmanager.CertificateIssuanceWindowRange, out *v1.CertificateIssuanceWindowRange, s conversion.Scope) error {
+	out.Start = in.Start
+	out.End = in.End
+	return nil
+}
+
+// Convert_certmanager_CertificateIssuanceWindowRange_To_v1_CertificateIssuanceWindowRange is an autogenerated conversion function.
+func Convert_certmanager_CertificateIssuanceWindowRange_To_v1_CertificateIssuanceWindowRange(in *certmanager.CertificateIssuanceWindowRange, out *v1.CertificateIssuanceWindowRange, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateIssuanceWindowRange_To_v1_CertificateIssuanceWindowRange(in, out, s)
+}
+
 func autoConvert_v1_CertificateKeystores_To_certmanager_CertificateKeystores(in *v1.CertificateKeystores, out *certmanager.CertificateKeystores, s conversion.Scope) error {
 	if in.JKS != nil {
 		in, out := &in.JKS, &out.JKS
@@ -554,6 +1090,7 @@ func autoConvert_v1_CertificateKeystores_To_certmanager_CertificateKeystores(in
 	} else {
 		out.PKCS12 = nil
 	}
+	out.SecretName = in.SecretName
 	return nil
 }
 
@@ -581,6 +1118,7 @@ func autoConvert_certmanager_CertificateKeystores_To_v1_CertificateKeystores(in
 	} else {
 		out.PKCS12 = nil
 	}
+	out.SecretName = in.SecretName
 	return nil
 }
 
@@ -631,11 +1169,178 @@ func Convert_certmanager_CertificateList_To_v1_CertificateList(in *certmanager.C
 	return autoConvert_certmanager_CertificateList_To_v1_CertificateList(in, out, s)
 }
 
+func autoConvert_v1_CertificateMigration_To_certmanager_CertificateMigration(in *v1.CertificateMigration, out *certmanager.CertificateMigration, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1_CertificateMigrationSpec_To_certmanager_CertificateMigrationSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1_CertificateMigrationStatus_To_certmanager_CertificateMigrationStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1_CertificateMigration_To_certmanager_CertificateMigration is an autogenerated conversion function.
+func Convert_v1_CertificateMigration_To_certmanager_CertificateMigration(in *v1.CertificateMigration, out *certmanager.CertificateMigration, s conversion.Scope) error {
+	return autoConvert_v1_CertificateMigration_To_certmanager_CertificateMigration(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateMigration_To_v1_CertificateMigration(in *certmanager.CertificateMigration, out *v1.CertificateMigration, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_certmanager_CertificateMigrationSpec_To_v1_CertificateMigrationSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_certmanager_CertificateMigrationStatus_To_v1_CertificateMigrationStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_CertificateMigration_To_v1_CertificateMigration is an autogenerated conversion function.
+func Convert_certmanager_CertificateMigration_To_v1_CertificateMigration(in *certmanager.CertificateMigration, out *v1.CertificateMigration, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateMigration_To_v1_CertificateMigration(in, out, s)
+}
+
+func autoConvert_v1_CertificateMigrationList_To_certmanager_CertificateMigrationList(in *v1.CertificateMigrationList, out *certmanager.CertificateMigrationList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]certmanager.CertificateMigration)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1_CertificateMigrationList_To_certmanager_CertificateMigrationList is an autogenerated conversion function.
+func Convert_v1_CertificateMigrationList_To_certmanager_CertificateMigrationList(in *v1.CertificateMigrationList, out *certmanager.CertificateMigrationList, s conversion.Scope) error {
+	return autoConvert_v1_CertificateMigrationList_To_certmanager_CertificateMigrationList(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateMigrationList_To_v1_CertificateMigrationList(in *certmanager.CertificateMigrationList, out *v1.CertificateMigrationList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]v1.CertificateMigration)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_certmanager_CertificateMigrationList_To_v1_CertificateMigrationList is an autogenerated conversion function.
+func Convert_certmanager_CertificateMigrationList_To_v1_CertificateMigrationList(in *certmanager.CertificateMigrationList, out *v1.CertificateMigrationList, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateMigrationList_To_v1_CertificateMigrationList(in, out, s)
+}
+
+func autoConvert_v1_CertificateMigrationSpec_To_certmanager_CertificateMigrationSpec(in *v1.CertificateMigrationSpec, out *certmanager.CertificateMigrationSpec, s conversion.Scope) error {
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	out.Selector = (*metav1.LabelSelector)(unsafe.Pointer(in.Selector))
+	if err := internalapismetav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+		return err
+	}
+	out.MaxReissuancesPerHour = in.MaxReissuancesPerHour
+	out.Paused = in.Paused
+	return nil
+}
+
+// Convert_v1_CertificateMigrationSpec_To_certmanager_CertificateMigrationSpec is an autogenerated conversion function.
+func Convert_v1_CertificateMigrationSpec_To_certmanager_CertificateMigrationSpec(in *v1.CertificateMigrationSpec, out *certmanager.CertificateMigrationSpec, s conversion.Scope) error {
+	return autoConvert_v1_CertificateMigrationSpec_To_certmanager_CertificateMigrationSpec(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateMigrationSpec_To_v1_CertificateMigrationSpec(in *certmanager.CertificateMigrationSpec, out *v1.CertificateMigrationSpec, s conversion.Scope) error {
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	out.Selector = (*metav1.LabelSelector)(unsafe.Pointer(in.Selector))
+	if err := internalapismetav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+		return err
+	}
+	out.MaxReissuancesPerHour = in.MaxReissuancesPerHour
+	out.Paused = in.Paused
+	return nil
+}
+
+// Convert_certmanager_CertificateMigrationSpec_To_v1_CertificateMigrationSpec is an autogenerated conversion function.
+func Convert_certmanager_CertificateMigrationSpec_To_v1_CertificateMigrationSpec(in *certmanager.CertificateMigrationSpec, out *v1.CertificateMigrationSpec, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateMigrationSpec_To_v1_CertificateMigrationSpec(in, out, s)
+}
+
+func autoConvert_v1_CertificateMigrationStatus_To_certmanager_CertificateMigrationStatus(in *v1.CertificateMigrationStatus, out *certmanager.CertificateMigrationStatus, s conversion.Scope) error {
+	out.Total = in.Total
+	out.Migrated = in.Migrated
+	out.Pending = in.Pending
+	out.Failed = in.Failed
+	out.LastMigrationTime = (*metav1.Time)(unsafe.Pointer(in.LastMigrationTime))
+	out.CompletionTime = (*metav1.Time)(unsafe.Pointer(in.CompletionTime))
+	out.ObservedGeneration = in.ObservedGeneration
+	return nil
+}
+
+// Convert_v1_CertificateMigrationStatus_To_certmanager_CertificateMigrationStatus is an autogenerated conversion function.
+func Convert_v1_CertificateMigrationStatus_To_certmanager_CertificateMigrationStatus(in *v1.CertificateMigrationStatus, out *certmanager.CertificateMigrationStatus, s conversion.Scope) error {
+	return autoConvert_v1_CertificateMigrationStatus_To_certmanager_CertificateMigrationStatus(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateMigrationStatus_To_v1_CertificateMigrationStatus(in *certmanager.CertificateMigrationStatus, out *v1.CertificateMigrationStatus, s conversion.Scope) error {
+	out.Total = in.Total
+	out.Migrated = in.Migrated
+	out.Pending = in.Pending
+	out.Failed = in.Failed
+	out.LastMigrationTime = (*metav1.Time)(unsafe.Pointer(in.LastMigrationTime))
+	out.CompletionTime = (*metav1.Time)(unsafe.Pointer(in.CompletionTime))
+	out.ObservedGeneration = in.ObservedGeneration
+	return nil
+}
+
+// Convert_certmanager_CertificateMigrationStatus_To_v1_CertificateMigrationStatus is an autogenerated conversion function.
+func Convert_certmanager_CertificateMigrationStatus_To_v1_CertificateMigrationStatus(in *certmanager.CertificateMigrationStatus, out *v1.CertificateMigrationStatus, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateMigrationStatus_To_v1_CertificateMigrationStatus(in, out, s)
+}
+
+func autoConvert_v1_CertificatePolicyEvaluation_To_certmanager_CertificatePolicyEvaluation(in *v1.CertificatePolicyEvaluation, out *certmanager.CertificatePolicyEvaluation, s conversion.Scope) error {
+	out.EvaluationTime = in.EvaluationTime
+	out.Policies = *(*[]certmanager.CertificatePolicyResult)(unsafe.Pointer(&in.Policies))
+	return nil
+}
+
+// Convert_v1_CertificatePolicyEvaluation_To_certmanager_CertificatePolicyEvaluation is an autogenerated conversion function.
+func Convert_v1_CertificatePolicyEvaluation_To_certmanager_CertificatePolicyEvaluation(in *v1.CertificatePolicyEvaluation, out *certmanager.CertificatePolicyEvaluation, s conversion.Scope) error {
+	return autoConvert_v1_CertificatePolicyEvaluation_To_certmanager_CertificatePolicyEvaluation(in, out, s)
+}
+
+func autoConvert_certmanager_CertificatePolicyEvaluation_To_v1_CertificatePolicyEvaluation(in *certmanager.CertificatePolicyEvaluation, out *v1.CertificatePolicyEvaluation, s conversion.Scope) error {
+	out.EvaluationTime = in.EvaluationTime
+	out.Policies = *(*[]v1.CertificatePolicyResult)(unsafe.Pointer(&in.Policies))
+	return nil
+}
+
+// Convert_certmanager_CertificatePolicyEvaluation_To_v1_CertificatePolicyEvaluation is an autogenerated conversion function.
+func Convert_certmanager_CertificatePolicyEvaluation_To_v1_CertificatePolicyEvaluation(in *certmanager.CertificatePolicyEvaluation, out *v1.CertificatePolicyEvaluation, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificatePolicyEvaluation_To_v1_CertificatePolicyEvaluation(in, out, s)
+}
+
+func autoConvert_v1_CertificatePolicyResult_To_certmanager_CertificatePolicyResult(in *v1.CertificatePolicyResult, out *certmanager.CertificatePolicyResult, s conversion.Scope) error {
+	out.PolicyName = in.PolicyName
+	out.Violated = in.Violated
+	out.Reason = in.Reason
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_v1_CertificatePolicyResult_To_certmanager_CertificatePolicyResult is an autogenerated conversion function.
+func Convert_v1_CertificatePolicyResult_To_certmanager_CertificatePolicyResult(in *v1.CertificatePolicyResult, out *certmanager.CertificatePolicyResult, s conversion.Scope) error {
+	return autoConvert_v1_CertificatePolicyResult_To_certmanager_CertificatePolicyResult(in, out, s)
+}
+
+func autoConvert_certmanager_CertificatePolicyResult_To_v1_CertificatePolicyResult(in *certmanager.CertificatePolicyResult, out *v1.CertificatePolicyResult, s conversion.Scope) error {
+	out.PolicyName = in.PolicyName
+	out.Violated = in.Violated
+	out.Reason = in.Reason
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_certmanager_CertificatePolicyResult_To_v1_CertificatePolicyResult is an autogenerated conversion function.
+func Convert_certmanager_CertificatePolicyResult_To_v1_CertificatePolicyResult(in *certmanager.CertificatePolicyResult, out *v1.CertificatePolicyResult, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificatePolicyResult_To_v1_CertificatePolicyResult(in, out, s)
+}
+
 func autoConvert_v1_CertificatePrivateKey_To_certmanager_CertificatePrivateKey(in *v1.CertificatePrivateKey, out *certmanager.CertificatePrivateKey, s conversion.Scope) error {
 	out.RotationPolicy = certmanager.PrivateKeyRotationPolicy(in.RotationPolicy)
 	out.Encoding = certmanager.PrivateKeyEncoding(in.Encoding)
 	out.Algorithm = certmanager.PrivateKeyAlgorithm(in.Algorithm)
 	out.Size = in.Size
+	out.External = (*certmanager.CertificateExternalPrivateKey)(unsafe.Pointer(in.External))
 	return nil
 }
 
@@ -649,6 +1354,7 @@ func autoConvert_certmanager_CertificatePrivateKey_To_v1_CertificatePrivateKey(i
 	out.Encoding = v1.PrivateKeyEncoding(in.Encoding)
 	out.Algorithm = v1.PrivateKeyAlgorithm(in.Algorithm)
 	out.Size = in.Size
+	out.External = (*v1.CertificateExternalPrivateKey)(unsafe.Pointer(in.External))
 	return nil
 }
 
@@ -657,6 +1363,32 @@ func Convert_certmanager_CertificatePrivateKey_To_v1_CertificatePrivateKey(in *c
 	return autoConvert_certmanager_CertificatePrivateKey_To_v1_CertificatePrivateKey(in, out, s)
 }
 
+func autoConvert_v1_CertificateReadinessGate_To_certmanager_CertificateReadinessGate(in *v1.CertificateReadinessGate, out *certmanager.CertificateReadinessGate, s conversion.Scope) error {
+	out.Name = in.Name
+	out.URL = in.URL
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.Timeout = (*metav1.Duration)(unsafe.Pointer(in.Timeout))
+	return nil
+}
+
+// Convert_v1_CertificateReadinessGate_To_certmanager_CertificateReadinessGate is an autogenerated conversion function.
+func Convert_v1_CertificateReadinessGate_To_certmanager_CertificateReadinessGate(in *v1.CertificateReadinessGate, out *certmanager.CertificateReadinessGate, s conversion.Scope) error {
+	return autoConvert_v1_CertificateReadinessGate_To_certmanager_CertificateReadinessGate(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateReadinessGate_To_v1_CertificateReadinessGate(in *certmanager.CertificateReadinessGate, out *v1.CertificateReadinessGate, s conversion.Scope) error {
+	out.Name = in.Name
+	out.URL = in.URL
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.Timeout = (*metav1.Duration)(unsafe.Pointer(in.Timeout))
+	return nil
+}
+
+// Convert_certmanager_CertificateReadinessGate_To_v1_CertificateReadinessGate is an autogenerated conversion function.
+func Convert_certmanager_CertificateReadinessGate_To_v1_CertificateReadinessGate(in *certmanager.CertificateReadinessGate, out *v1.CertificateReadinessGate, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateReadinessGate_To_v1_CertificateReadinessGate(in, out, s)
+}
+
 func autoConvert_v1_CertificateRequest_To_certmanager_CertificateRequest(in *v1.CertificateRequest, out *certmanager.CertificateRequest, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1_CertificateRequestSpec_To_certmanager_CertificateRequestSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -825,9 +1557,54 @@ func Convert_certmanager_CertificateRequestStatus_To_v1_CertificateRequestStatus
 	return autoConvert_certmanager_CertificateRequestStatus_To_v1_CertificateRequestStatus(in, out, s)
 }
 
+func autoConvert_v1_CertificateRequestTemplate_To_certmanager_CertificateRequestTemplate(in *v1.CertificateRequestTemplate, out *certmanager.CertificateRequestTemplate, s conversion.Scope) error {
+	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	return nil
+}
+
+// Convert_v1_CertificateRequestTemplate_To_certmanager_CertificateRequestTemplate is an autogenerated conversion function.
+func Convert_v1_CertificateRequestTemplate_To_certmanager_CertificateRequestTemplate(in *v1.CertificateRequestTemplate, out *certmanager.CertificateRequestTemplate, s conversion.Scope) error {
+	return autoConvert_v1_CertificateRequestTemplate_To_certmanager_CertificateRequestTemplate(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateRequestTemplate_To_v1_CertificateRequestTemplate(in *certmanager.CertificateRequestTemplate, out *v1.CertificateRequestTemplate, s conversion.Scope) error {
+	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	return nil
+}
+
+// Convert_certmanager_CertificateRequestTemplate_To_v1_CertificateRequestTemplate is an autogenerated conversion function.
+func Convert_certmanager_CertificateRequestTemplate_To_v1_CertificateRequestTemplate(in *certmanager.CertificateRequestTemplate, out *v1.CertificateRequestTemplate, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateRequestTemplate_To_v1_CertificateRequestTemplate(in, out, s)
+}
+
+func autoConvert_v1_CertificateSecretCAPolicy_To_certmanager_CertificateSecretCAPolicy(in *v1.CertificateSecretCAPolicy, out *certmanager.CertificateSecretCAPolicy, s conversion.Scope) error {
+	out.Type = certmanager.CertificateSecretCAPolicyType(in.Type)
+	out.BundleRef = (*certmanager.CertificateCABundleReference)(unsafe.Pointer(in.BundleRef))
+	return nil
+}
+
+// Convert_v1_CertificateSecretCAPolicy_To_certmanager_CertificateSecretCAPolicy is an autogenerated conversion function.
+func Convert_v1_CertificateSecretCAPolicy_To_certmanager_CertificateSecretCAPolicy(in *v1.CertificateSecretCAPolicy, out *certmanager.CertificateSecretCAPolicy, s conversion.Scope) error {
+	return autoConvert_v1_CertificateSecretCAPolicy_To_certmanager_CertificateSecretCAPolicy(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateSecretCAPolicy_To_v1_CertificateSecretCAPolicy(in *certmanager.CertificateSecretCAPolicy, out *v1.CertificateSecretCAPolicy, s conversion.Scope) error {
+	out.Type = v1.CertificateSecretCAPolicyType(in.Type)
+	out.BundleRef = (*v1.CertificateCABundleReference)(unsafe.Pointer(in.BundleRef))
+	return nil
+}
+
+// Convert_certmanager_CertificateSecretCAPolicy_To_v1_CertificateSecretCAPolicy is an autogenerated conversion function.
+func Convert_certmanager_CertificateSecretCAPolicy_To_v1_CertificateSecretCAPolicy(in *certmanager.CertificateSecretCAPolicy, out *v1.CertificateSecretCAPolicy, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateSecretCAPolicy_To_v1_CertificateSecretCAPolicy(in, out, s)
+}
+
 func autoConvert_v1_CertificateSecretTemplate_To_certmanager_CertificateSecretTemplate(in *v1.CertificateSecretTemplate, out *certmanager.CertificateSecretTemplate, s conversion.Scope) error {
 	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
 	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	out.Immutable = in.Immutable
 	return nil
 }
 
@@ -839,6 +1616,7 @@ func Convert_v1_CertificateSecretTemplate_To_certmanager_CertificateSecretTempla
 func autoConvert_certmanager_CertificateSecretTemplate_To_v1_CertificateSecretTemplate(in *certmanager.CertificateSecretTemplate, out *v1.CertificateSecretTemplate, s conversion.Scope) error {
 	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
 	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	out.Immutable = in.Immutable
 	return nil
 }
 
@@ -851,8 +1629,12 @@ func autoConvert_v1_CertificateSpec_To_certmanager_CertificateSpec(in *v1.Certif
 	out.Subject = (*certmanager.X509Subject)(unsafe.Pointer(in.Subject))
 	out.LiteralSubject = in.LiteralSubject
 	out.CommonName = in.CommonName
+	out.CommonNameFromFirstDNSName = in.CommonNameFromFirstDNSName
 	out.Duration = (*metav1.Duration)(unsafe.Pointer(in.Duration))
 	out.RenewBefore = (*metav1.Duration)(unsafe.Pointer(in.RenewBefore))
+	out.RenewBeforeChainExpiry = in.RenewBeforeChainExpiry
+	out.ChainExpiryExcludedFingerprints = *(*[]string)(unsafe.Pointer(&in.ChainExpiryExcludedFingerprints))
+	out.RolloutDelay = (*metav1.Duration)(unsafe.Pointer(in.RolloutDelay))
 	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
 	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
 	out.URIs = *(*[]string)(unsafe.Pointer(&in.URIs))
@@ -879,6 +1661,20 @@ func autoConvert_v1_CertificateSpec_To_certmanager_CertificateSpec(in *v1.Certif
 	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
 	out.AdditionalOutputFormats = *(*[]certmanager.CertificateAdditionalOutputFormat)(unsafe.Pointer(&in.AdditionalOutputFormats))
 	out.NameConstraints = (*certmanager.NameConstraints)(unsafe.Pointer(in.NameConstraints))
+	out.PolicyOIDs = *(*[]string)(unsafe.Pointer(&in.PolicyOIDs))
+	out.RevokeOnDelete = (*bool)(unsafe.Pointer(in.RevokeOnDelete))
+	if in.ExternalCSR != nil {
+		in, out := &in.ExternalCSR, &out.ExternalCSR
+		*out = new(certmanager.CertificateExternalCSR)
+		if err := Convert_v1_CertificateExternalCSR_To_certmanager_CertificateExternalCSR(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ExternalCSR = nil
+	}
+	out.SecretCAPolicy = (*certmanager.CertificateSecretCAPolicy)(unsafe.Pointer(in.SecretCAPolicy))
+	out.CertificateRequestTemplate = (*certmanager.CertificateRequestTemplate)(unsafe.Pointer(in.CertificateRequestTemplate))
+	out.IssuanceWindow = (*certmanager.CertificateIssuanceWindow)(unsafe.Pointer(in.IssuanceWindow))
 	return nil
 }
 
@@ -891,8 +1687,12 @@ func autoConvert_certmanager_CertificateSpec_To_v1_CertificateSpec(in *certmanag
 	out.Subject = (*v1.X509Subject)(unsafe.Pointer(in.Subject))
 	out.LiteralSubject = in.LiteralSubject
 	out.CommonName = in.CommonName
+	out.CommonNameFromFirstDNSName = in.CommonNameFromFirstDNSName
 	out.Duration = (*metav1.Duration)(unsafe.Pointer(in.Duration))
 	out.RenewBefore = (*metav1.Duration)(unsafe.Pointer(in.RenewBefore))
+	out.RenewBeforeChainExpiry = in.RenewBeforeChainExpiry
+	out.ChainExpiryExcludedFingerprints = *(*[]string)(unsafe.Pointer(&in.ChainExpiryExcludedFingerprints))
+	out.RolloutDelay = (*metav1.Duration)(unsafe.Pointer(in.RolloutDelay))
 	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
 	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
 	out.URIs = *(*[]string)(unsafe.Pointer(&in.URIs))
@@ -919,6 +1719,20 @@ func autoConvert_certmanager_CertificateSpec_To_v1_CertificateSpec(in *certmanag
 	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
 	out.AdditionalOutputFormats = *(*[]v1.CertificateAdditionalOutputFormat)(unsafe.Pointer(&in.AdditionalOutputFormats))
 	out.NameConstraints = (*v1.NameConstraints)(unsafe.Pointer(in.NameConstraints))
+	out.PolicyOIDs = *(*[]string)(unsafe.Pointer(&in.PolicyOIDs))
+	out.RevokeOnDelete = (*bool)(unsafe.Pointer(in.RevokeOnDelete))
+	if in.ExternalCSR != nil {
+		in, out := &in.ExternalCSR, &out.ExternalCSR
+		*out = new(v1.CertificateExternalCSR)
+		if err := Convert_certmanager_CertificateExternalCSR_To_v1_CertificateExternalCSR(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ExternalCSR = nil
+	}
+	out.SecretCAPolicy = (*v1.CertificateSecretCAPolicy)(unsafe.Pointer(in.SecretCAPolicy))
+	out.CertificateRequestTemplate = (*v1.CertificateRequestTemplate)(unsafe.Pointer(in.CertificateRequestTemplate))
+	out.IssuanceWindow = (*v1.CertificateIssuanceWindow)(unsafe.Pointer(in.IssuanceWindow))
 	return nil
 }
 
@@ -933,9 +1747,18 @@ func autoConvert_v1_CertificateStatus_To_certmanager_CertificateStatus(in *v1.Ce
 	out.NotBefore = (*metav1.Time)(unsafe.Pointer(in.NotBefore))
 	out.NotAfter = (*metav1.Time)(unsafe.Pointer(in.NotAfter))
 	out.RenewalTime = (*metav1.Time)(unsafe.Pointer(in.RenewalTime))
+	out.NextIssuanceWindowTime = (*metav1.Time)(unsafe.Pointer(in.NextIssuanceWindowTime))
 	out.Revision = (*int)(unsafe.Pointer(in.Revision))
 	out.NextPrivateKeySecretName = (*string)(unsafe.Pointer(in.NextPrivateKeySecretName))
 	out.FailedIssuanceAttempts = (*int)(unsafe.Pointer(in.FailedIssuanceAttempts))
+	out.SerialNumber = in.SerialNumber
+	out.SHA256Fingerprint = in.SHA256Fingerprint
+	out.ShortSHA256Fingerprint = in.ShortSHA256Fingerprint
+	out.IssuerDN = in.IssuerDN
+	out.SubjectDN = in.SubjectDN
+	out.IssuerCAFingerprints = *(*[]string)(unsafe.Pointer(&in.IssuerCAFingerprints))
+	out.IssuanceHistory = *(*[]certmanager.CertificateIssuanceAttempt)(unsafe.Pointer(&in.IssuanceHistory))
+	out.LastEvaluation = (*certmanager.CertificatePolicyEvaluation)(unsafe.Pointer(in.LastEvaluation))
 	return nil
 }
 
@@ -950,9 +1773,18 @@ func autoConvert_certmanager_CertificateStatus_To_v1_CertificateStatus(in *certm
 	out.NotBefore = (*metav1.Time)(unsafe.Pointer(in.NotBefore))
 	out.NotAfter = (*metav1.Time)(unsafe.Pointer(in.NotAfter))
 	out.RenewalTime = (*metav1.Time)(unsafe.Pointer(in.RenewalTime))
+	out.NextIssuanceWindowTime = (*metav1.Time)(unsafe.Pointer(in.NextIssuanceWindowTime))
 	out.Revision = (*int)(unsafe.Pointer(in.Revision))
 	out.NextPrivateKeySecretName = (*string)(unsafe.Pointer(in.NextPrivateKeySecretName))
 	out.FailedIssuanceAttempts = (*int)(unsafe.Pointer(in.FailedIssuanceAttempts))
+	out.SerialNumber = in.SerialNumber
+	out.SHA256Fingerprint = in.SHA256Fingerprint
+	out.ShortSHA256Fingerprint = in.ShortSHA256Fingerprint
+	out.IssuerDN = in.IssuerDN
+	out.SubjectDN = in.SubjectDN
+	out.IssuerCAFingerprints = *(*[]string)(unsafe.Pointer(&in.IssuerCAFingerprints))
+	out.IssuanceHistory = *(*[]v1.CertificateIssuanceAttempt)(unsafe.Pointer(&in.IssuanceHistory))
+	out.LastEvaluation = (*v1.CertificatePolicyEvaluation)(unsafe.Pointer(in.LastEvaluation))
 	return nil
 }
 
@@ -1035,6 +1867,160 @@ func Convert_certmanager_ClusterIssuerList_To_v1_ClusterIssuerList(in *certmanag
 	return autoConvert_certmanager_ClusterIssuerList_To_v1_ClusterIssuerList(in, out, s)
 }
 
+func autoConvert_v1_IssuancePreview_To_certmanager_IssuancePreview(in *v1.IssuancePreview, out *certmanager.IssuancePreview, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1_IssuancePreviewSpec_To_certmanager_IssuancePreviewSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1_IssuancePreviewStatus_To_certmanager_IssuancePreviewStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1_IssuancePreview_To_certmanager_IssuancePreview is an autogenerated conversion function.
+func Convert_v1_IssuancePreview_To_certmanager_IssuancePreview(in *v1.IssuancePreview, out *certmanager.IssuancePreview, s conversion.Scope) error {
+	return autoConvert_v1_IssuancePreview_To_certmanager_IssuancePreview(in, out, s)
+}
+
+func autoConvert_certmanager_IssuancePreview_To_v1_IssuancePreview(in *certmanager.IssuancePreview, out *v1.IssuancePreview, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_certmanager_IssuancePreviewSpec_To_v1_IssuancePreviewSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_certmanager_IssuancePreviewStatus_To_v1_IssuancePreviewStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_IssuancePreview_To_v1_IssuancePreview is an autogenerated conversion function.
+func Convert_certmanager_IssuancePreview_To_v1_IssuancePreview(in *certmanager.IssuancePreview, out *v1.IssuancePreview, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuancePreview_To_v1_IssuancePreview(in, out, s)
+}
+
+func autoConvert_v1_IssuancePreviewCheck_To_certmanager_IssuancePreviewCheck(in *v1.IssuancePreviewCheck, out *certmanager.IssuancePreviewCheck, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Passed = in.Passed
+	out.Reason = in.Reason
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_v1_IssuancePreviewCheck_To_certmanager_IssuancePreviewCheck is an autogenerated conversion function.
+func Convert_v1_IssuancePreviewCheck_To_certmanager_IssuancePreviewCheck(in *v1.IssuancePreviewCheck, out *certmanager.IssuancePreviewCheck, s conversion.Scope) error {
+	return autoConvert_v1_IssuancePreviewCheck_To_certmanager_IssuancePreviewCheck(in, out, s)
+}
+
+func autoConvert_certmanager_IssuancePreviewCheck_To_v1_IssuancePreviewCheck(in *certmanager.IssuancePreviewCheck, out *v1.IssuancePreviewCheck, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Passed = in.Passed
+	out.Reason = in.Reason
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_certmanager_IssuancePreviewCheck_To_v1_IssuancePreviewCheck is an autogenerated conversion function.
+func Convert_certmanager_IssuancePreviewCheck_To_v1_IssuancePreviewCheck(in *certmanager.IssuancePreviewCheck, out *v1.IssuancePreviewCheck, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuancePreviewCheck_To_v1_IssuancePreviewCheck(in, out, s)
+}
+
+func autoConvert_v1_IssuancePreviewList_To_certmanager_IssuancePreviewList(in *v1.IssuancePreviewList, out *certmanager.IssuancePreviewList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]certmanager.IssuancePreview, len(*in))
+		for i := range *in {
+			if err := Convert_v1_IssuancePreview_To_certmanager_IssuancePreview(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_v1_IssuancePreviewList_To_certmanager_IssuancePreviewList is an autogenerated conversion function.
+func Convert_v1_IssuancePreviewList_To_certmanager_IssuancePreviewList(in *v1.IssuancePreviewList, out *certmanager.IssuancePreviewList, s conversion.Scope) error {
+	return autoConvert_v1_IssuancePreviewList_To_certmanager_IssuancePreviewList(in, out, s)
+}
+
+func autoConvert_certmanager_IssuancePreviewList_To_v1_IssuancePreviewList(in *certmanager.IssuancePreviewList, out *v1.IssuancePreviewList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]v1.IssuancePreview, len(*in))
+		for i := range *in {
+			if err := Convert_certmanager_IssuancePreview_To_v1_IssuancePreview(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_certmanager_IssuancePreviewList_To_v1_IssuancePreviewList is an autogenerated conversion function.
+func Convert_certmanager_IssuancePreviewList_To_v1_IssuancePreviewList(in *certmanager.IssuancePreviewList, out *v1.IssuancePreviewList, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuancePreviewList_To_v1_IssuancePreviewList(in, out, s)
+}
+
+func autoConvert_v1_IssuancePreviewSpec_To_certmanager_IssuancePreviewSpec(in *v1.IssuancePreviewSpec, out *certmanager.IssuancePreviewSpec, s conversion.Scope) error {
+	if err := Convert_v1_CertificateSpec_To_certmanager_CertificateSpec(&in.CertificateSpec, &out.CertificateSpec, s); err != nil {
+		return err
+	}
+	out.IssuerRef = (*meta.ObjectReference)(unsafe.Pointer(in.IssuerRef))
+	out.TTLSecondsAfterFinished = (*int32)(unsafe.Pointer(in.TTLSecondsAfterFinished))
+	return nil
+}
+
+// Convert_v1_IssuancePreviewSpec_To_certmanager_IssuancePreviewSpec is an autogenerated conversion function.
+func Convert_v1_IssuancePreviewSpec_To_certmanager_IssuancePreviewSpec(in *v1.IssuancePreviewSpec, out *certmanager.IssuancePreviewSpec, s conversion.Scope) error {
+	return autoConvert_v1_IssuancePreviewSpec_To_certmanager_IssuancePreviewSpec(in, out, s)
+}
+
+func autoConvert_certmanager_IssuancePreviewSpec_To_v1_IssuancePreviewSpec(in *certmanager.IssuancePreviewSpec, out *v1.IssuancePreviewSpec, s conversion.Scope) error {
+	if err := Convert_certmanager_CertificateSpec_To_v1_CertificateSpec(&in.CertificateSpec, &out.CertificateSpec, s); err != nil {
+		return err
+	}
+	out.IssuerRef = (*apismetav1.ObjectReference)(unsafe.Pointer(in.IssuerRef))
+	out.TTLSecondsAfterFinished = (*int32)(unsafe.Pointer(in.TTLSecondsAfterFinished))
+	return nil
+}
+
+// Convert_certmanager_IssuancePreviewSpec_To_v1_IssuancePreviewSpec is an autogenerated conversion function.
+func Convert_certmanager_IssuancePreviewSpec_To_v1_IssuancePreviewSpec(in *certmanager.IssuancePreviewSpec, out *v1.IssuancePreviewSpec, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuancePreviewSpec_To_v1_IssuancePreviewSpec(in, out, s)
+}
+
+func autoConvert_v1_IssuancePreviewStatus_To_certmanager_IssuancePreviewStatus(in *v1.IssuancePreviewStatus, out *certmanager.IssuancePreviewStatus, s conversion.Scope) error {
+	out.Verdict = certmanager.IssuancePreviewVerdict(in.Verdict)
+	out.Checks = *(*[]certmanager.IssuancePreviewCheck)(unsafe.Pointer(&in.Checks))
+	out.ObservedGeneration = in.ObservedGeneration
+	out.CompletionTime = (*metav1.Time)(unsafe.Pointer(in.CompletionTime))
+	return nil
+}
+
+// Convert_v1_IssuancePreviewStatus_To_certmanager_IssuancePreviewStatus is an autogenerated conversion function.
+func Convert_v1_IssuancePreviewStatus_To_certmanager_IssuancePreviewStatus(in *v1.IssuancePreviewStatus, out *certmanager.IssuancePreviewStatus, s conversion.Scope) error {
+	return autoConvert_v1_IssuancePreviewStatus_To_certmanager_IssuancePreviewStatus(in, out, s)
+}
+
+func autoConvert_certmanager_IssuancePreviewStatus_To_v1_IssuancePreviewStatus(in *certmanager.IssuancePreviewStatus, out *v1.IssuancePreviewStatus, s conversion.Scope) error {
+	out.Verdict = v1.IssuancePreviewVerdict(in.Verdict)
+	out.Checks = *(*[]v1.IssuancePreviewCheck)(unsafe.Pointer(&in.Checks))
+	out.ObservedGeneration = in.ObservedGeneration
+	out.CompletionTime = (*metav1.Time)(unsafe.Pointer(in.CompletionTime))
+	return nil
+}
+
+// Convert_certmanager_IssuancePreviewStatus_To_v1_IssuancePreviewStatus is an autogenerated conversion function.
+func Convert_certmanager_IssuancePreviewStatus_To_v1_IssuancePreviewStatus(in *certmanager.IssuancePreviewStatus, out *v1.IssuancePreviewStatus, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuancePreviewStatus_To_v1_IssuancePreviewStatus(in, out, s)
+}
+
 func autoConvert_v1_Issuer_To_certmanager_Issuer(in *v1.Issuer, out *certmanager.Issuer, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1_IssuerSpec_To_certmanager_IssuerSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -1127,6 +2113,7 @@ func autoConvert_v1_IssuerConfig_To_certmanager_IssuerConfig(in *v1.IssuerConfig
 	} else {
 		out.Venafi = nil
 	}
+	out.Manual = (*certmanager.ManualIssuer)(unsafe.Pointer(in.Manual))
 	return nil
 }
 
@@ -1165,6 +2152,7 @@ func autoConvert_certmanager_IssuerConfig_To_v1_IssuerConfig(in *certmanager.Iss
 	} else {
 		out.Venafi = nil
 	}
+	out.Manual = (*v1.ManualIssuer)(unsafe.Pointer(in.Manual))
 	return nil
 }
 
@@ -1219,6 +2207,7 @@ func autoConvert_v1_IssuerSpec_To_certmanager_IssuerSpec(in *v1.IssuerSpec, out
 	if err := Convert_v1_IssuerConfig_To_certmanager_IssuerConfig(&in.IssuerConfig, &out.IssuerConfig, s); err != nil {
 		return err
 	}
+	out.ReadinessGates = *(*[]certmanager.CertificateReadinessGate)(unsafe.Pointer(&in.ReadinessGates))
 	return nil
 }
 
@@ -1231,6 +2220,7 @@ func autoConvert_certmanager_IssuerSpec_To_v1_IssuerSpec(in *certmanager.IssuerS
 	if err := Convert_certmanager_IssuerConfig_To_v1_IssuerConfig(&in.IssuerConfig, &out.IssuerConfig, s); err != nil {
 		return err
 	}
+	out.ReadinessGates = *(*[]v1.CertificateReadinessGate)(unsafe.Pointer(&in.ReadinessGates))
 	return nil
 }
 
@@ -1242,6 +2232,7 @@ func Convert_certmanager_IssuerSpec_To_v1_IssuerSpec(in *certmanager.IssuerSpec,
 func autoConvert_v1_IssuerStatus_To_certmanager_IssuerStatus(in *v1.IssuerStatus, out *certmanager.IssuerStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]certmanager.IssuerCondition)(unsafe.Pointer(&in.Conditions))
 	out.ACME = (*acme.ACMEIssuerStatus)(unsafe.Pointer(in.ACME))
+	out.CA = (*certmanager.CAIssuerStatus)(unsafe.Pointer(in.CA))
 	return nil
 }
 
@@ -1253,6 +2244,7 @@ func Convert_v1_IssuerStatus_To_certmanager_IssuerStatus(in *v1.IssuerStatus, ou
 func autoConvert_certmanager_IssuerStatus_To_v1_IssuerStatus(in *certmanager.IssuerStatus, out *v1.IssuerStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]v1.IssuerCondition)(unsafe.Pointer(&in.Conditions))
 	out.ACME = (*apisacmev1.ACMEIssuerStatus)(unsafe.Pointer(in.ACME))
+	out.CA = (*v1.CAIssuerStatus)(unsafe.Pointer(in.CA))
 	return nil
 }
 
@@ -1289,6 +2281,24 @@ func Convert_certmanager_JKSKeystore_To_v1_JKSKeystore(in *certmanager.JKSKeysto
 	return autoConvert_certmanager_JKSKeystore_To_v1_JKSKeystore(in, out, s)
 }
 
+func autoConvert_v1_ManualIssuer_To_certmanager_ManualIssuer(in *v1.ManualIssuer, out *certmanager.ManualIssuer, s conversion.Scope) error {
+	return nil
+}
+
+// Convert_v1_ManualIssuer_To_certmanager_ManualIssuer is an autogenerated conversion function.
+func Convert_v1_ManualIssuer_To_certmanager_ManualIssuer(in *v1.ManualIssuer, out *certmanager.ManualIssuer, s conversion.Scope) error {
+	return autoConvert_v1_ManualIssuer_To_certmanager_ManualIssuer(in, out, s)
+}
+
+func autoConvert_certmanager_ManualIssuer_To_v1_ManualIssuer(in *certmanager.ManualIssuer, out *v1.ManualIssuer, s conversion.Scope) error {
+	return nil
+}
+
+// Convert_certmanager_ManualIssuer_To_v1_ManualIssuer is an autogenerated conversion function.
+func Convert_certmanager_ManualIssuer_To_v1_ManualIssuer(in *certmanager.ManualIssuer, out *v1.ManualIssuer, s conversion.Scope) error {
+	return autoConvert_certmanager_ManualIssuer_To_v1_ManualIssuer(in, out, s)
+}
+
 func autoConvert_v1_NameConstraintItem_To_certmanager_NameConstraintItem(in *v1.NameConstraintItem, out *certmanager.NameConstraintItem, s conversion.Scope) error {
 	out.DNSDomains = *(*[]string)(unsafe.Pointer(&in.DNSDomains))
 	out.IPRanges = *(*[]string)(unsafe.Pointer(&in.IPRanges))
@@ -1437,6 +2447,7 @@ func autoConvert_v1_VaultAppRole_To_certmanager_VaultAppRole(in *v1.VaultAppRole
 	if err := internalapismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
 		return err
 	}
+	out.ResponseWrapped = in.ResponseWrapped
 	return nil
 }
 
@@ -1451,6 +2462,7 @@ func autoConvert_certmanager_VaultAppRole_To_v1_VaultAppRole(in *certmanager.Vau
 	if err := internalapismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
 		return err
 	}
+	out.ResponseWrapped = in.ResponseWrapped
 	return nil
 }
 
@@ -1566,6 +2578,19 @@ func autoConvert_v1_VaultIssuer_To_certmanager_VaultIssuer(in *v1.VaultIssuer, o
 	} else {
 		out.ClientKeySecretRef = nil
 	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(meta.ClientTLSConfig)
+		if err := internalapismetav1.Convert_v1_ClientTLSConfig_To_meta_ClientTLSConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TLS = nil
+	}
+	out.IssuerRef = in.IssuerRef
+	out.ExcludeCNFromSANs = (*bool)(unsafe.Pointer(in.ExcludeCNFromSANs))
+	out.ExtraParameters = *(*map[string]string)(unsafe.Pointer(&in.ExtraParameters))
+	out.SignMode = certmanager.VaultSignMode(in.SignMode)
 	return nil
 }
 
@@ -1609,6 +2634,19 @@ func autoConvert_certmanager_VaultIssuer_To_v1_VaultIssuer(in *certmanager.Vault
 	} else {
 		out.ClientKeySecretRef = nil
 	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(apismetav1.ClientTLSConfig)
+		if err := internalapismetav1.Convert_meta_ClientTLSConfig_To_v1_ClientTLSConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TLS = nil
+	}
+	out.IssuerRef = in.IssuerRef
+	out.ExcludeCNFromSANs = (*bool)(unsafe.Pointer(in.ExcludeCNFromSANs))
+	out.ExtraParameters = *(*map[string]string)(unsafe.Pointer(&in.ExtraParameters))
+	out.SignMode = v1.VaultSignMode(in.SignMode)
 	return nil
 }
 
@@ -1693,6 +2731,15 @@ func autoConvert_v1_VenafiIssuer_To_certmanager_VenafiIssuer(in *v1.VenafiIssuer
 	} else {
 		out.Cloud = nil
 	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(meta.ClientTLSConfig)
+		if err := internalapismetav1.Convert_v1_ClientTLSConfig_To_meta_ClientTLSConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TLS = nil
+	}
 	return nil
 }
 
@@ -1721,6 +2768,15 @@ func autoConvert_certmanager_VenafiIssuer_To_v1_VenafiIssuer(in *certmanager.Ven
 	} else {
 		out.Cloud = nil
 	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(apismetav1.ClientTLSConfig)
+		if err := internalapismetav1.Convert_meta_ClientTLSConfig_To_v1_ClientTLSConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TLS = nil
+	}
 	return nil
 }
 
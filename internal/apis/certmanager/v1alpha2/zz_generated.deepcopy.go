@@ -31,6 +31,11 @@ import (
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CAIssuer) DeepCopyInto(out *CAIssuer) {
 	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(CAIssuerSecretReference)
+		**out = **in
+	}
 	if in.CRLDistributionPoints != nil {
 		in, out := &in.CRLDistributionPoints, &out.CRLDistributionPoints
 		*out = make([]string, len(*in))
@@ -46,6 +51,21 @@ func (in *CAIssuer) DeepCopyInto(out *CAIssuer) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.AllowedPolicyOIDs != nil {
+		in, out := &in.AllowedPolicyOIDs, &out.AllowedPolicyOIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CRL != nil {
+		in, out := &in.CRL, &out.CRL
+		*out = new(CAIssuerCRL)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SpreadDuration != nil {
+		in, out := &in.SpreadDuration, &out.SpreadDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -59,6 +79,113 @@ func (in *CAIssuer) DeepCopy() *CAIssuer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CAIssuerCRL) DeepCopyInto(out *CAIssuerCRL) {
+	*out = *in
+	if in.UpdateInterval != nil {
+		in, out := &in.UpdateInterval, &out.UpdateInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CAIssuerCRL.
+func (in *CAIssuerCRL) DeepCopy() *CAIssuerCRL {
+	if in == nil {
+		return nil
+	}
+	out := new(CAIssuerCRL)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CAIssuerRevokedCertificate) DeepCopyInto(out *CAIssuerRevokedCertificate) {
+	*out = *in
+	in.RevocationTime.DeepCopyInto(&out.RevocationTime)
+	if in.NotAfter != nil {
+		in, out := &in.NotAfter, &out.NotAfter
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CAIssuerRevokedCertificate.
+func (in *CAIssuerRevokedCertificate) DeepCopy() *CAIssuerRevokedCertificate {
+	if in == nil {
+		return nil
+	}
+	out := new(CAIssuerRevokedCertificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CAIssuerRotationStatus) DeepCopyInto(out *CAIssuerRotationStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CAIssuerRotationStatus.
+func (in *CAIssuerRotationStatus) DeepCopy() *CAIssuerRotationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CAIssuerRotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CAIssuerSecretReference) DeepCopyInto(out *CAIssuerSecretReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CAIssuerSecretReference.
+func (in *CAIssuerSecretReference) DeepCopy() *CAIssuerSecretReference {
+	if in == nil {
+		return nil
+	}
+	out := new(CAIssuerSecretReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CAIssuerStatus) DeepCopyInto(out *CAIssuerStatus) {
+	*out = *in
+	if in.RevokedCertificates != nil {
+		in, out := &in.RevokedCertificates, &out.RevokedCertificates
+		*out = make([]CAIssuerRevokedCertificate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Rotation != nil {
+		in, out := &in.Rotation, &out.Rotation
+		*out = new(CAIssuerRotationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CAIssuerStatus.
+func (in *CAIssuerStatus) DeepCopy() *CAIssuerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CAIssuerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Certificate) DeepCopyInto(out *Certificate) {
 	*out = *in
@@ -103,6 +230,22 @@ func (in *CertificateAdditionalOutputFormat) DeepCopy() *CertificateAdditionalOu
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateCABundleReference) DeepCopyInto(out *CertificateCABundleReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateCABundleReference.
+func (in *CertificateCABundleReference) DeepCopy() *CertificateCABundleReference {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateCABundleReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertificateCondition) DeepCopyInto(out *CertificateCondition) {
 	*out = *in
@@ -123,6 +266,100 @@ func (in *CertificateCondition) DeepCopy() *CertificateCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateExternalCSR) DeepCopyInto(out *CertificateExternalCSR) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateExternalCSR.
+func (in *CertificateExternalCSR) DeepCopy() *CertificateExternalCSR {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateExternalCSR)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateExternalPrivateKey) DeepCopyInto(out *CertificateExternalPrivateKey) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateExternalPrivateKey.
+func (in *CertificateExternalPrivateKey) DeepCopy() *CertificateExternalPrivateKey {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateExternalPrivateKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateIssuanceAttempt) DeepCopyInto(out *CertificateIssuanceAttempt) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateIssuanceAttempt.
+func (in *CertificateIssuanceAttempt) DeepCopy() *CertificateIssuanceAttempt {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateIssuanceAttempt)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateIssuanceWindow) DeepCopyInto(out *CertificateIssuanceWindow) {
+	*out = *in
+	if in.Ranges != nil {
+		in, out := &in.Ranges, &out.Ranges
+		*out = make([]CertificateIssuanceWindowRange, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateIssuanceWindow.
+func (in *CertificateIssuanceWindow) DeepCopy() *CertificateIssuanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateIssuanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateIssuanceWindowRange) DeepCopyInto(out *CertificateIssuanceWindowRange) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateIssuanceWindowRange.
+func (in *CertificateIssuanceWindowRange) DeepCopy() *CertificateIssuanceWindowRange {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateIssuanceWindowRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertificateKeystores) DeepCopyInto(out *CertificateKeystores) {
 	*out = *in
@@ -182,9 +419,52 @@ func (in *CertificateList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificatePolicyEvaluation) DeepCopyInto(out *CertificatePolicyEvaluation) {
+	*out = *in
+	in.EvaluationTime.DeepCopyInto(&out.EvaluationTime)
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]CertificatePolicyResult, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificatePolicyEvaluation.
+func (in *CertificatePolicyEvaluation) DeepCopy() *CertificatePolicyEvaluation {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificatePolicyEvaluation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificatePolicyResult) DeepCopyInto(out *CertificatePolicyResult) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificatePolicyResult.
+func (in *CertificatePolicyResult) DeepCopy() *CertificatePolicyResult {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificatePolicyResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertificatePrivateKey) DeepCopyInto(out *CertificatePrivateKey) {
 	*out = *in
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(CertificateExternalPrivateKey)
+		**out = **in
+	}
 	return
 }
 
@@ -198,6 +478,32 @@ func (in *CertificatePrivateKey) DeepCopy() *CertificatePrivateKey {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateReadinessGate) DeepCopyInto(out *CertificateReadinessGate) {
+	*out = *in
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateReadinessGate.
+func (in *CertificateReadinessGate) DeepCopy() *CertificateReadinessGate {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateReadinessGate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertificateRequest) DeepCopyInto(out *CertificateRequest) {
 	*out = *in
@@ -368,6 +674,57 @@ func (in *CertificateRequestStatus) DeepCopy() *CertificateRequestStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRequestTemplate) DeepCopyInto(out *CertificateRequestTemplate) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestTemplate.
+func (in *CertificateRequestTemplate) DeepCopy() *CertificateRequestTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRequestTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateSecretCAPolicy) DeepCopyInto(out *CertificateSecretCAPolicy) {
+	*out = *in
+	if in.BundleRef != nil {
+		in, out := &in.BundleRef, &out.BundleRef
+		*out = new(CertificateCABundleReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateSecretCAPolicy.
+func (in *CertificateSecretCAPolicy) DeepCopy() *CertificateSecretCAPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateSecretCAPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertificateSecretTemplate) DeepCopyInto(out *CertificateSecretTemplate) {
 	*out = *in
@@ -421,6 +778,16 @@ func (in *CertificateSpec) DeepCopyInto(out *CertificateSpec) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.ChainExpiryExcludedFingerprints != nil {
+		in, out := &in.ChainExpiryExcludedFingerprints, &out.ChainExpiryExcludedFingerprints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RolloutDelay != nil {
+		in, out := &in.RolloutDelay, &out.RolloutDelay
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	if in.DNSNames != nil {
 		in, out := &in.DNSNames, &out.DNSNames
 		*out = make([]string, len(*in))
@@ -465,7 +832,7 @@ func (in *CertificateSpec) DeepCopyInto(out *CertificateSpec) {
 	if in.PrivateKey != nil {
 		in, out := &in.PrivateKey, &out.PrivateKey
 		*out = new(CertificatePrivateKey)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.EncodeUsagesInRequest != nil {
 		in, out := &in.EncodeUsagesInRequest, &out.EncodeUsagesInRequest
@@ -487,6 +854,36 @@ func (in *CertificateSpec) DeepCopyInto(out *CertificateSpec) {
 		*out = new(NameConstraints)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PolicyOIDs != nil {
+		in, out := &in.PolicyOIDs, &out.PolicyOIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RevokeOnDelete != nil {
+		in, out := &in.RevokeOnDelete, &out.RevokeOnDelete
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ExternalCSR != nil {
+		in, out := &in.ExternalCSR, &out.ExternalCSR
+		*out = new(CertificateExternalCSR)
+		**out = **in
+	}
+	if in.SecretCAPolicy != nil {
+		in, out := &in.SecretCAPolicy, &out.SecretCAPolicy
+		*out = new(CertificateSecretCAPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CertificateRequestTemplate != nil {
+		in, out := &in.CertificateRequestTemplate, &out.CertificateRequestTemplate
+		*out = new(CertificateRequestTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IssuanceWindow != nil {
+		in, out := &in.IssuanceWindow, &out.IssuanceWindow
+		*out = new(CertificateIssuanceWindow)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -526,6 +923,10 @@ func (in *CertificateStatus) DeepCopyInto(out *CertificateStatus) {
 		in, out := &in.RenewalTime, &out.RenewalTime
 		*out = (*in).DeepCopy()
 	}
+	if in.NextIssuanceWindowTime != nil {
+		in, out := &in.NextIssuanceWindowTime, &out.NextIssuanceWindowTime
+		*out = (*in).DeepCopy()
+	}
 	if in.Revision != nil {
 		in, out := &in.Revision, &out.Revision
 		*out = new(int)
@@ -541,6 +942,23 @@ func (in *CertificateStatus) DeepCopyInto(out *CertificateStatus) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.IssuerCAFingerprints != nil {
+		in, out := &in.IssuerCAFingerprints, &out.IssuerCAFingerprints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IssuanceHistory != nil {
+		in, out := &in.IssuanceHistory, &out.IssuanceHistory
+		*out = make([]CertificateIssuanceAttempt, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastEvaluation != nil {
+		in, out := &in.LastEvaluation, &out.LastEvaluation
+		*out = new(CertificatePolicyEvaluation)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -691,6 +1109,11 @@ func (in *IssuerConfig) DeepCopyInto(out *IssuerConfig) {
 		*out = new(VenafiIssuer)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Manual != nil {
+		in, out := &in.Manual, &out.Manual
+		*out = new(ManualIssuer)
+		**out = **in
+	}
 	return
 }
 
@@ -741,6 +1164,13 @@ func (in *IssuerList) DeepCopyObject() runtime.Object {
 func (in *IssuerSpec) DeepCopyInto(out *IssuerSpec) {
 	*out = *in
 	in.IssuerConfig.DeepCopyInto(&out.IssuerConfig)
+	if in.ReadinessGates != nil {
+		in, out := &in.ReadinessGates, &out.ReadinessGates
+		*out = make([]CertificateReadinessGate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -767,7 +1197,12 @@ func (in *IssuerStatus) DeepCopyInto(out *IssuerStatus) {
 	if in.ACME != nil {
 		in, out := &in.ACME, &out.ACME
 		*out = new(acmev1alpha2.ACMEIssuerStatus)
-		**out = **in
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(CAIssuerStatus)
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
@@ -804,6 +1239,22 @@ func (in *JKSKeystore) DeepCopy() *JKSKeystore {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManualIssuer) DeepCopyInto(out *ManualIssuer) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManualIssuer.
+func (in *ManualIssuer) DeepCopy() *ManualIssuer {
+	if in == nil {
+		return nil
+	}
+	out := new(ManualIssuer)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NameConstraintItem) DeepCopyInto(out *NameConstraintItem) {
 	*out = *in
@@ -1013,6 +1464,23 @@ func (in *VaultIssuer) DeepCopyInto(out *VaultIssuer) {
 		*out = new(metav1.SecretKeySelector)
 		**out = **in
 	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(metav1.ClientTLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExcludeCNFromSANs != nil {
+		in, out := &in.ExcludeCNFromSANs, &out.ExcludeCNFromSANs
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ExtraParameters != nil {
+		in, out := &in.ExtraParameters, &out.ExtraParameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -1078,6 +1546,11 @@ func (in *VenafiIssuer) DeepCopyInto(out *VenafiIssuer) {
 		*out = new(VenafiCloud)
 		**out = **in
 	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(metav1.ClientTLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -87,6 +87,15 @@ type IssuerList struct {
 // configuration required for the issuer.
 type IssuerSpec struct {
 	IssuerConfig `json:",inline"`
+
+	// ReadinessGates are external endpoints which must accept the
+	// certificates issued by this issuer before the Certificates referencing
+	// it are marked as Ready, e.g. to check that a certificate has not been
+	// revoked or that it has been logged to certificate transparency logs.
+	// The gates are called in order once a certificate is otherwise up to
+	// date, and are retried with backoff until all of them accept it.
+	// +optional
+	ReadinessGates []CertificateReadinessGate `json:"readinessGates,omitempty"`
 }
 
 // The configuration for the issuer.
@@ -117,6 +126,11 @@ type IssuerConfig struct {
 	// or Venafi Cloud policy zone.
 	// +optional
 	Venafi *VenafiIssuer `json:"venafi,omitempty"`
+
+	// Manual configures this issuer to deliver certificates which are issued
+	// outside of cert-manager, and are provided by an operator.
+	// +optional
+	Manual *ManualIssuer `json:"manual,omitempty"`
 }
 
 // Configures an issuer to sign certificates using a Venafi TPP
@@ -137,6 +151,12 @@ type VenafiIssuer struct {
 	// Only one of TPP or Cloud may be specified.
 	// +optional
 	Cloud *VenafiCloud `json:"cloud,omitempty"`
+
+	// TLS configures the minimum TLS version and cipher suites used when
+	// connecting to Venafi TPP or Venafi Cloud, overriding the controller-wide
+	// defaults.
+	// +optional
+	TLS *cmmeta.ClientTLSConfig `json:"tls,omitempty"`
 }
 
 // VenafiTPP defines connection configuration details for a Venafi TPP instance
@@ -179,6 +199,15 @@ type SelfSignedIssuer struct {
 	CRLDistributionPoints []string `json:"crlDistributionPoints,omitempty"`
 }
 
+// Configures an issuer to deliver certificates which are issued outside of
+// cert-manager. The certificate of a CertificateRequest is either read from
+// the "<certificate name>-staging" Secret in the namespace of the
+// CertificateRequest, or set on the status of the CertificateRequest by an
+// operator. It is then stored and renewed like the certificate of any other
+// issuer, except that each renewal waits for an operator to provide the new
+// certificate.
+type ManualIssuer struct{}
+
 // Configures an issuer to sign certificates using a HashiCorp Vault
 // PKI backend.
 type VaultIssuer struct {
@@ -224,8 +253,60 @@ type VaultIssuer struct {
 	// Vault server requires mTLS.
 	// +optional
 	ClientKeySecretRef *cmmeta.SecretKeySelector `json:"clientKeySecretRef,omitempty"`
+
+	// TLS configures the minimum TLS version and cipher suites used when
+	// connecting to the Vault server, overriding the controller-wide defaults.
+	// +optional
+	TLS *cmmeta.ClientTLSConfig `json:"tls,omitempty"`
+
+	// IssuerRef is the name or ID of the issuer within the Vault PKI mount that
+	// should sign certificates, sent as the `issuer_ref` parameter.
+	// If not set, the default issuer of the mount or role is used.
+	// +optional
+	IssuerRef string `json:"issuerRef,omitempty"`
+
+	// ExcludeCNFromSANs controls the `exclude_cn_from_sans` parameter sent to
+	// Vault when signing. If true, the common name is not included in the DNS
+	// or email subject alternative names of the issued certificate.
+	// Defaults to true.
+	// +optional
+	ExcludeCNFromSANs *bool `json:"excludeCNFromSANs,omitempty"`
+
+	// ExtraParameters are additional parameters sent to Vault when signing,
+	// for example `ttl` or `not_after`. Parameters set here take precedence
+	// over the values computed by cert-manager, except for `csr` and `format`,
+	// which may not be set, and `issuer_ref` and `exclude_cn_from_sans`, which
+	// must be set using the dedicated fields.
+	// +optional
+	ExtraParameters map[string]string `json:"extraParameters,omitempty"`
+
+	// SignMode selects the endpoint of the Vault PKI secrets engine used to
+	// sign certificates. With `Role`, the `sign/:role` endpoint of the role
+	// named in the path is used, so that the constraints of the role, such as
+	// `allowed_domains` and `max_ttl`, are enforced by Vault, and the subject
+	// alternative names of the CSR are sent as request parameters. With
+	// `Verbatim`, the `sign-verbatim/:role` endpoint is used, which signs the
+	// CSR as it is and ignores most of the constraints of the role.
+	// If not set, the path is used as it is.
+	// +optional
+	SignMode VaultSignMode `json:"signMode,omitempty"`
 }
 
+// VaultSignMode selects the endpoint of the Vault PKI secrets engine used to
+// sign certificates.
+// +kubebuilder:validation:Enum=Role;Verbatim
+type VaultSignMode string
+
+const (
+	// VaultSignModeRole signs certificates using the `sign/:role` endpoint,
+	// which enforces the constraints of the role.
+	VaultSignModeRole VaultSignMode = "Role"
+
+	// VaultSignModeVerbatim signs certificates using the `sign-verbatim/:role`
+	// endpoint, which signs CSRs as they are.
+	VaultSignModeVerbatim VaultSignMode = "Verbatim"
+)
+
 // Configuration used to authenticate with a Vault server.
 // Only one of `tokenSecretRef`, `appRole` or `kubernetes` may be specified.
 type VaultAuth struct {
@@ -260,6 +341,16 @@ type VaultAppRole struct {
 	// The `key` field must be specified and denotes which entry within the Secret
 	// resource is used as the app role secret.
 	SecretRef cmmeta.SecretKeySelector `json:"secretRef"`
+
+	// ResponseWrapped indicates that the value referenced by `secretRef` is
+	// a response-wrapping token rather than the App Role secret itself.
+	// The token is unwrapped using Vault's `sys/wrapping/unwrap` endpoint
+	// before logging in. As wrapping tokens can only be used once, the
+	// wrapping token in the referenced Secret is replaced with the unwrapped
+	// secret, and the Secret is annotated with
+	// `cert-manager.io/vault-approle-unwrapped-secret-ids`.
+	// +optional
+	ResponseWrapped bool `json:"responseWrapped,omitempty"`
 }
 
 // Authenticate against Vault using a Kubernetes ServiceAccount token stored in
@@ -309,7 +400,15 @@ type ServiceAccountRef struct {
 type CAIssuer struct {
 	// SecretName is the name of the secret used to sign Certificates issued
 	// by this Issuer.
-	SecretName string `json:"secretName"`
+	// Either secretName or secretRef must be set.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// SecretRef is a reference to the secret used to sign Certificates issued
+	// by this Issuer, which may be in another namespace.
+	// Either secretName or secretRef must be set.
+	// +optional
+	SecretRef *CAIssuerSecretReference `json:"secretRef,omitempty"`
 
 	// The CRL distribution points is an X.509 v3 certificate extension which identifies
 	// the location of the CRL from which the revocation of this certificate can be checked.
@@ -330,12 +429,81 @@ type CAIssuer struct {
 	// As an example, such a URL might be "http://ca.domain.com/ca.crt".
 	// +optional
 	IssuingCertificateURLs []string `json:"issuingCertificateURLs,omitempty"`
+
+	// AllowedPolicyOIDs are the object identifiers of the certificate
+	// policies which this issuer includes in the certificates it signs, if
+	// they are requested. Other requested policies are left out of the
+	// certificate. If not set, certificates are issued without policies.
+	// +optional
+	AllowedPolicyOIDs []string `json:"allowedPolicyOIDs,omitempty"`
+
+	// CRL configures the issuer to generate and sign a certificate revocation
+	// list (CRL) of the certificates it has issued and which have been
+	// revoked, e.g. because their Certificate was deleted with
+	// `revokeOnDelete` set. If not set, no CRL is generated and revocation is
+	// not supported.
+	// +optional
+	CRL *CAIssuerCRL `json:"crl,omitempty"`
+
+	// ReissueOnCARotation configures the issuer to re-issue the Certificates
+	// referencing it when its signing CA certificate is rotated, so that they
+	// chain to the new CA certificate before the previous one expires. The
+	// rotation is detected by a change of the fingerprint of the signing CA
+	// certificate, and the re-issuances are spread over spreadDuration.
+	// +optional
+	ReissueOnCARotation bool `json:"reissueOnCARotation,omitempty"`
+
+	// SpreadDuration is the duration over which the re-issuances triggered
+	// by reissueOnCARotation are spread evenly, to avoid re-issuing every
+	// Certificate at once. Defaults to 24 hours.
+	// +optional
+	SpreadDuration *metav1.Duration `json:"spreadDuration,omitempty"`
+}
+
+// CAIssuerSecretReference is a reference to a Secret containing the signing
+// CA keypair of a CA issuer.
+type CAIssuerSecretReference struct {
+	// Name of the Secret.
+	Name string `json:"name"`
+
+	// Namespace of the Secret. Defaults to the namespace of the Issuer, or the
+	// cluster resource namespace for ClusterIssuers.
+	// A Secret in another namespace is only used if it grants the namespace of
+	// the Issuer access to it, by listing it in its
+	// "cert-manager.io/allowed-issuer-namespaces" annotation.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// CAIssuerCRL configures the certificate revocation list (CRL) generated by a
+// CA issuer. The CRL is signed by the CA, and stored PEM encoded in the "ca.crl"
+// key of a Secret and, optionally, of a ConfigMap, from which it can be served
+// to clients.
+type CAIssuerCRL struct {
+	// SecretName is the name of the Secret in which the CRL is stored. It is
+	// created in the namespace of the Issuer, or the cluster resource
+	// namespace for ClusterIssuers.
+	SecretName string `json:"secretName"`
+
+	// ConfigMapName is the name of a ConfigMap in which the CRL is also
+	// stored, in the same namespace as the Secret, e.g. to be served by an
+	// ingress.
+	// +optional
+	ConfigMapName string `json:"configMapName,omitempty"`
+
+	// UpdateInterval is the interval at which the CRL is signed again,
+	// in addition to whenever a certificate is revoked or the CA changes.
+	// The nextUpdate of the CRL is set to twice the interval after it is
+	// signed, so that it remains valid if signing it again is delayed.
+	// Defaults to 24 hours.
+	// +optional
+	UpdateInterval *metav1.Duration `json:"updateInterval,omitempty"`
 }
 
 // IssuerStatus contains status information about an Issuer
 type IssuerStatus struct {
 	// List of status conditions to indicate the status of a CertificateRequest.
-	// Known condition types are `Ready`.
+	// Known condition types are `Ready` and `SharedAccountKeyConflict`.
 	// +listType=map
 	// +listMapKey=type
 	// +optional
@@ -346,11 +514,86 @@ type IssuerStatus struct {
 	// server to issue certificates.
 	// +optional
 	ACME *cmacme.ACMEIssuerStatus `json:"acme,omitempty"`
+
+	// CA specific status options.
+	// This field is only set if the Issuer is a CA issuer which has revoked
+	// certificates, or which re-issues its Certificates when its signing CA
+	// certificate is rotated.
+	// +optional
+	CA *CAIssuerStatus `json:"ca,omitempty"`
+}
+
+// CAIssuerStatus contains status information specific to CA issuers.
+type CAIssuerStatus struct {
+	// RevokedCertificates are the certificates issued by this issuer which
+	// have been revoked, and are listed in its CRL.
+	// +listType=map
+	// +listMapKey=serialNumber
+	// +optional
+	RevokedCertificates []CAIssuerRevokedCertificate `json:"revokedCertificates,omitempty"`
+
+	// SigningCertificateFingerprint is the SHA-256 fingerprint of the signing
+	// CA certificate last observed by the issuer, used to detect its rotation.
+	// It is only recorded if reissueOnCARotation is set.
+	// +optional
+	SigningCertificateFingerprint string `json:"signingCertificateFingerprint,omitempty"`
+
+	// Rotation reports the progress of the re-issuance of the Certificates
+	// referencing this issuer, triggered by the last rotation of its signing
+	// CA certificate.
+	// +optional
+	Rotation *CAIssuerRotationStatus `json:"rotation,omitempty"`
+}
+
+// CAIssuerRevokedCertificate is a certificate revoked by a CA issuer.
+type CAIssuerRevokedCertificate struct {
+	// SerialNumber is the serial number of the revoked certificate, as colon
+	// separated hexadecimal bytes.
+	SerialNumber string `json:"serialNumber"`
+
+	// RevocationTime is the time at which the certificate was revoked.
+	RevocationTime metav1.Time `json:"revocationTime"`
+
+	// NotAfter is the expiry time of the revoked certificate. The
+	// certificate is removed from the list, and from the issuer's CRL, once
+	// it has expired. It is not recorded if the certificate was revoked by
+	// its serial number only, in which case it is listed indefinitely.
+	// +optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+}
+
+// CAIssuerRotationStatus reports the progress of the re-issuance of the
+// Certificates referencing a CA issuer after its signing CA certificate has
+// been rotated.
+type CAIssuerRotationStatus struct {
+	// StartTime is the time at which the rotation was detected. Certificates
+	// issued before it are re-issued.
+	StartTime metav1.Time `json:"startTime"`
+
+	// Total is the number of Certificates which were issued before the
+	// rotation, when it was detected.
+	// +optional
+	Total int32 `json:"total"`
+
+	// Triggered is the number of Certificates whose re-issuance has been
+	// triggered.
+	// +optional
+	Triggered int32 `json:"triggered"`
+
+	// Reissued is the number of Certificates which have been re-issued since
+	// the rotation.
+	// +optional
+	Reissued int32 `json:"reissued"`
+
+	// CompletionTime is the time at which every Certificate was first
+	// observed to have been re-issued since the rotation.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
 }
 
 // IssuerCondition contains condition information for an Issuer.
 type IssuerCondition struct {
-	// Type of the condition, known values are (`Ready`).
+	// Type of the condition, known values are (`Ready`, `SharedAccountKeyConflict`).
 	Type IssuerConditionType `json:"type"`
 
 	// Status of the condition, one of (`True`, `False`, `Unknown`).
@@ -389,4 +632,19 @@ const (
 	// If the `status` of this condition is `False`, CertificateRequest controllers
 	// should prevent attempts to sign certificates.
 	IssuerConditionReady IssuerConditionType = "Ready"
+
+	// IssuerConditionSharedAccountKeyConflict indicates that the ACME account
+	// private key Secret of an Issuer is also used by other Issuers with
+	// different registration parameters. While the condition is `True`, the
+	// ACME account is not registered or updated.
+	IssuerConditionSharedAccountKeyConflict IssuerConditionType = "SharedAccountKeyConflict"
+
+	// IssuerConditionACMEServerUnavailable indicates that the ACME server of
+	// an Issuer could not be reached or responded with a server error, either
+	// when verifying the ACME account or when processing an Order or
+	// Challenge. While the condition is set, the ACME server is probed at a
+	// short interval. The condition is removed once the ACME server responds
+	// again, at which point pending Orders and Challenges for the Issuer are
+	// retried without waiting for their backoff to expire.
+	IssuerConditionACMEServerUnavailable IssuerConditionType = "ACMEServerUnavailable"
 )
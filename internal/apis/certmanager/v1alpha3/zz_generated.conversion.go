@@ -52,6 +52,56 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CAIssuerCRL)(nil), (*certmanager.CAIssuerCRL)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CAIssuerCRL_To_certmanager_CAIssuerCRL(a.(*CAIssuerCRL), b.(*certmanager.CAIssuerCRL), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CAIssuerCRL)(nil), (*CAIssuerCRL)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CAIssuerCRL_To_v1alpha3_CAIssuerCRL(a.(*certmanager.CAIssuerCRL), b.(*CAIssuerCRL), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CAIssuerRevokedCertificate)(nil), (*certmanager.CAIssuerRevokedCertificate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CAIssuerRevokedCertificate_To_certmanager_CAIssuerRevokedCertificate(a.(*CAIssuerRevokedCertificate), b.(*certmanager.CAIssuerRevokedCertificate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CAIssuerRevokedCertificate)(nil), (*CAIssuerRevokedCertificate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CAIssuerRevokedCertificate_To_v1alpha3_CAIssuerRevokedCertificate(a.(*certmanager.CAIssuerRevokedCertificate), b.(*CAIssuerRevokedCertificate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CAIssuerRotationStatus)(nil), (*certmanager.CAIssuerRotationStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CAIssuerRotationStatus_To_certmanager_CAIssuerRotationStatus(a.(*CAIssuerRotationStatus), b.(*certmanager.CAIssuerRotationStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CAIssuerRotationStatus)(nil), (*CAIssuerRotationStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CAIssuerRotationStatus_To_v1alpha3_CAIssuerRotationStatus(a.(*certmanager.CAIssuerRotationStatus), b.(*CAIssuerRotationStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CAIssuerSecretReference)(nil), (*certmanager.CAIssuerSecretReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CAIssuerSecretReference_To_certmanager_CAIssuerSecretReference(a.(*CAIssuerSecretReference), b.(*certmanager.CAIssuerSecretReference), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CAIssuerSecretReference)(nil), (*CAIssuerSecretReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CAIssuerSecretReference_To_v1alpha3_CAIssuerSecretReference(a.(*certmanager.CAIssuerSecretReference), b.(*CAIssuerSecretReference), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CAIssuerStatus)(nil), (*certmanager.CAIssuerStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CAIssuerStatus_To_certmanager_CAIssuerStatus(a.(*CAIssuerStatus), b.(*certmanager.CAIssuerStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CAIssuerStatus)(nil), (*CAIssuerStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CAIssuerStatus_To_v1alpha3_CAIssuerStatus(a.(*certmanager.CAIssuerStatus), b.(*CAIssuerStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Certificate)(nil), (*certmanager.Certificate)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_Certificate_To_certmanager_Certificate(a.(*Certificate), b.(*certmanager.Certificate), scope)
 	}); err != nil {
@@ -72,6 +122,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CertificateCABundleReference)(nil), (*certmanager.CertificateCABundleReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CertificateCABundleReference_To_certmanager_CertificateCABundleReference(a.(*CertificateCABundleReference), b.(*certmanager.CertificateCABundleReference), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateCABundleReference)(nil), (*CertificateCABundleReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateCABundleReference_To_v1alpha3_CertificateCABundleReference(a.(*certmanager.CertificateCABundleReference), b.(*CertificateCABundleReference), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CertificateCondition)(nil), (*certmanager.CertificateCondition)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_CertificateCondition_To_certmanager_CertificateCondition(a.(*CertificateCondition), b.(*certmanager.CertificateCondition), scope)
 	}); err != nil {
@@ -82,6 +142,56 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CertificateExternalCSR)(nil), (*certmanager.CertificateExternalCSR)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CertificateExternalCSR_To_certmanager_CertificateExternalCSR(a.(*CertificateExternalCSR), b.(*certmanager.CertificateExternalCSR), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateExternalCSR)(nil), (*CertificateExternalCSR)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateExternalCSR_To_v1alpha3_CertificateExternalCSR(a.(*certmanager.CertificateExternalCSR), b.(*CertificateExternalCSR), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CertificateExternalPrivateKey)(nil), (*certmanager.CertificateExternalPrivateKey)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CertificateExternalPrivateKey_To_certmanager_CertificateExternalPrivateKey(a.(*CertificateExternalPrivateKey), b.(*certmanager.CertificateExternalPrivateKey), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateExternalPrivateKey)(nil), (*CertificateExternalPrivateKey)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateExternalPrivateKey_To_v1alpha3_CertificateExternalPrivateKey(a.(*certmanager.CertificateExternalPrivateKey), b.(*CertificateExternalPrivateKey), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CertificateIssuanceAttempt)(nil), (*certmanager.CertificateIssuanceAttempt)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CertificateIssuanceAttempt_To_certmanager_CertificateIssuanceAttempt(a.(*CertificateIssuanceAttempt), b.(*certmanager.CertificateIssuanceAttempt), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateIssuanceAttempt)(nil), (*CertificateIssuanceAttempt)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateIssuanceAttempt_To_v1alpha3_CertificateIssuanceAttempt(a.(*certmanager.CertificateIssuanceAttempt), b.(*CertificateIssuanceAttempt), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CertificateIssuanceWindow)(nil), (*certmanager.CertificateIssuanceWindow)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CertificateIssuanceWindow_To_certmanager_CertificateIssuanceWindow(a.(*CertificateIssuanceWindow), b.(*certmanager.CertificateIssuanceWindow), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateIssuanceWindow)(nil), (*CertificateIssuanceWindow)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateIssuanceWindow_To_v1alpha3_CertificateIssuanceWindow(a.(*certmanager.CertificateIssuanceWindow), b.(*CertificateIssuanceWindow), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CertificateIssuanceWindowRange)(nil), (*certmanager.CertificateIssuanceWindowRange)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CertificateIssuanceWindowRange_To_certmanager_CertificateIssuanceWindowRange(a.(*CertificateIssuanceWindowRange), b.(*certmanager.CertificateIssuanceWindowRange), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateIssuanceWindowRange)(nil), (*CertificateIssuanceWindowRange)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateIssuanceWindowRange_To_v1alpha3_CertificateIssuanceWindowRange(a.(*certmanager.CertificateIssuanceWindowRange), b.(*CertificateIssuanceWindowRange), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CertificateKeystores)(nil), (*certmanager.CertificateKeystores)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_CertificateKeystores_To_certmanager_CertificateKeystores(a.(*CertificateKeystores), b.(*certmanager.CertificateKeystores), scope)
 	}); err != nil {
@@ -102,11 +212,41 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CertificatePolicyEvaluation)(nil), (*certmanager.CertificatePolicyEvaluation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CertificatePolicyEvaluation_To_certmanager_CertificatePolicyEvaluation(a.(*CertificatePolicyEvaluation), b.(*certmanager.CertificatePolicyEvaluation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificatePolicyEvaluation)(nil), (*CertificatePolicyEvaluation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificatePolicyEvaluation_To_v1alpha3_CertificatePolicyEvaluation(a.(*certmanager.CertificatePolicyEvaluation), b.(*CertificatePolicyEvaluation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CertificatePolicyResult)(nil), (*certmanager.CertificatePolicyResult)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CertificatePolicyResult_To_certmanager_CertificatePolicyResult(a.(*CertificatePolicyResult), b.(*certmanager.CertificatePolicyResult), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificatePolicyResult)(nil), (*CertificatePolicyResult)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificatePolicyResult_To_v1alpha3_CertificatePolicyResult(a.(*certmanager.CertificatePolicyResult), b.(*CertificatePolicyResult), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CertificatePrivateKey)(nil), (*certmanager.CertificatePrivateKey)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_CertificatePrivateKey_To_certmanager_CertificatePrivateKey(a.(*CertificatePrivateKey), b.(*certmanager.CertificatePrivateKey), scope)
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CertificateReadinessGate)(nil), (*certmanager.CertificateReadinessGate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CertificateReadinessGate_To_certmanager_CertificateReadinessGate(a.(*CertificateReadinessGate), b.(*certmanager.CertificateReadinessGate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateReadinessGate)(nil), (*CertificateReadinessGate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateReadinessGate_To_v1alpha3_CertificateReadinessGate(a.(*certmanager.CertificateReadinessGate), b.(*CertificateReadinessGate), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CertificateRequest)(nil), (*certmanager.CertificateRequest)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_CertificateRequest_To_certmanager_CertificateRequest(a.(*CertificateRequest), b.(*certmanager.CertificateRequest), scope)
 	}); err != nil {
@@ -147,6 +287,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CertificateRequestTemplate)(nil), (*certmanager.CertificateRequestTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CertificateRequestTemplate_To_certmanager_CertificateRequestTemplate(a.(*CertificateRequestTemplate), b.(*certmanager.CertificateRequestTemplate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateRequestTemplate)(nil), (*CertificateRequestTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateRequestTemplate_To_v1alpha3_CertificateRequestTemplate(a.(*certmanager.CertificateRequestTemplate), b.(*CertificateRequestTemplate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CertificateSecretCAPolicy)(nil), (*certmanager.CertificateSecretCAPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CertificateSecretCAPolicy_To_certmanager_CertificateSecretCAPolicy(a.(*CertificateSecretCAPolicy), b.(*certmanager.CertificateSecretCAPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateSecretCAPolicy)(nil), (*CertificateSecretCAPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateSecretCAPolicy_To_v1alpha3_CertificateSecretCAPolicy(a.(*certmanager.CertificateSecretCAPolicy), b.(*CertificateSecretCAPolicy), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CertificateSecretTemplate)(nil), (*certmanager.CertificateSecretTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_CertificateSecretTemplate_To_certmanager_CertificateSecretTemplate(a.(*CertificateSecretTemplate), b.(*certmanager.CertificateSecretTemplate), scope)
 	}); err != nil {
@@ -257,6 +417,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ManualIssuer)(nil), (*certmanager.ManualIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ManualIssuer_To_certmanager_ManualIssuer(a.(*ManualIssuer), b.(*certmanager.ManualIssuer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.ManualIssuer)(nil), (*ManualIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_ManualIssuer_To_v1alpha3_ManualIssuer(a.(*certmanager.ManualIssuer), b.(*ManualIssuer), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*NameConstraintItem)(nil), (*certmanager.NameConstraintItem)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_NameConstraintItem_To_certmanager_NameConstraintItem(a.(*NameConstraintItem), b.(*certmanager.NameConstraintItem), scope)
 	}); err != nil {
@@ -427,9 +597,14 @@ func RegisterConversions(s *runtime.Scheme) error {
 
 func autoConvert_v1alpha3_CAIssuer_To_certmanager_CAIssuer(in *CAIssuer, out *certmanager.CAIssuer, s conversion.Scope) error {
 	out.SecretName = in.SecretName
+	out.SecretRef = (*certmanager.CAIssuerSecretReference)(unsafe.Pointer(in.SecretRef))
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	out.OCSPServers = *(*[]string)(unsafe.Pointer(&in.OCSPServers))
 	out.IssuingCertificateURLs = *(*[]string)(unsafe.Pointer(&in.IssuingCertificateURLs))
+	out.AllowedPolicyOIDs = *(*[]string)(unsafe.Pointer(&in.AllowedPolicyOIDs))
+	out.CRL = (*certmanager.CAIssuerCRL)(unsafe.Pointer(in.CRL))
+	out.ReissueOnCARotation = in.ReissueOnCARotation
+	out.SpreadDuration = (*v1.Duration)(unsafe.Pointer(in.SpreadDuration))
 	return nil
 }
 
@@ -440,9 +615,14 @@ func Convert_v1alpha3_CAIssuer_To_certmanager_CAIssuer(in *CAIssuer, out *certma
 
 func autoConvert_certmanager_CAIssuer_To_v1alpha3_CAIssuer(in *certmanager.CAIssuer, out *CAIssuer, s conversion.Scope) error {
 	out.SecretName = in.SecretName
+	out.SecretRef = (*CAIssuerSecretReference)(unsafe.Pointer(in.SecretRef))
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	out.OCSPServers = *(*[]string)(unsafe.Pointer(&in.OCSPServers))
 	out.IssuingCertificateURLs = *(*[]string)(unsafe.Pointer(&in.IssuingCertificateURLs))
+	out.AllowedPolicyOIDs = *(*[]string)(unsafe.Pointer(&in.AllowedPolicyOIDs))
+	out.CRL = (*CAIssuerCRL)(unsafe.Pointer(in.CRL))
+	out.ReissueOnCARotation = in.ReissueOnCARotation
+	out.SpreadDuration = (*v1.Duration)(unsafe.Pointer(in.SpreadDuration))
 	return nil
 }
 
@@ -451,6 +631,128 @@ func Convert_certmanager_CAIssuer_To_v1alpha3_CAIssuer(in *certmanager.CAIssuer,
 	return autoConvert_certmanager_CAIssuer_To_v1alpha3_CAIssuer(in, out, s)
 }
 
+func autoConvert_v1alpha3_CAIssuerCRL_To_certmanager_CAIssuerCRL(in *CAIssuerCRL, out *certmanager.CAIssuerCRL, s conversion.Scope) error {
+	out.SecretName = in.SecretName
+	out.ConfigMapName = in.ConfigMapName
+	out.UpdateInterval = (*v1.Duration)(unsafe.Pointer(in.UpdateInterval))
+	return nil
+}
+
+// Convert_v1alpha3_CAIssuerCRL_To_certmanager_CAIssuerCRL is an autogenerated conversion function.
+func Convert_v1alpha3_CAIssuerCRL_To_certmanager_CAIssuerCRL(in *CAIssuerCRL, out *certmanager.CAIssuerCRL, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CAIssuerCRL_To_certmanager_CAIssuerCRL(in, out, s)
+}
+
+func autoConvert_certmanager_CAIssuerCRL_To_v1alpha3_CAIssuerCRL(in *certmanager.CAIssuerCRL, out *CAIssuerCRL, s conversion.Scope) error {
+	out.SecretName = in.SecretName
+	out.ConfigMapName = in.ConfigMapName
+	out.UpdateInterval = (*v1.Duration)(unsafe.Pointer(in.UpdateInterval))
+	return nil
+}
+
+// Convert_certmanager_CAIssuerCRL_To_v1alpha3_CAIssuerCRL is an autogenerated conversion function.
+func Convert_certmanager_CAIssuerCRL_To_v1alpha3_CAIssuerCRL(in *certmanager.CAIssuerCRL, out *CAIssuerCRL, s conversion.Scope) error {
+	return autoConvert_certmanager_CAIssuerCRL_To_v1alpha3_CAIssuerCRL(in, out, s)
+}
+
+func autoConvert_v1alpha3_CAIssuerRevokedCertificate_To_certmanager_CAIssuerRevokedCertificate(in *CAIssuerRevokedCertificate, out *certmanager.CAIssuerRevokedCertificate, s conversion.Scope) error {
+	out.SerialNumber = in.SerialNumber
+	out.RevocationTime = in.RevocationTime
+	out.NotAfter = (*v1.Time)(unsafe.Pointer(in.NotAfter))
+	return nil
+}
+
+// Convert_v1alpha3_CAIssuerRevokedCertificate_To_certmanager_CAIssuerRevokedCertificate is an autogenerated conversion function.
+func Convert_v1alpha3_CAIssuerRevokedCertificate_To_certmanager_CAIssuerRevokedCertificate(in *CAIssuerRevokedCertificate, out *certmanager.CAIssuerRevokedCertificate, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CAIssuerRevokedCertificate_To_certmanager_CAIssuerRevokedCertificate(in, out, s)
+}
+
+func autoConvert_certmanager_CAIssuerRevokedCertificate_To_v1alpha3_CAIssuerRevokedCertificate(in *certmanager.CAIssuerRevokedCertificate, out *CAIssuerRevokedCertificate, s conversion.Scope) error {
+	out.SerialNumber = in.SerialNumber
+	out.RevocationTime = in.RevocationTime
+	out.NotAfter = (*v1.Time)(unsafe.Pointer(in.NotAfter))
+	return nil
+}
+
+// Convert_certmanager_CAIssuerRevokedCertificate_To_v1alpha3_CAIssuerRevokedCertificate is an autogenerated conversion function.
+func Convert_certmanager_CAIssuerRevokedCertificate_To_v1alpha3_CAIssuerRevokedCertificate(in *certmanager.CAIssuerRevokedCertificate, out *CAIssuerRevokedCertificate, s conversion.Scope) error {
+	return autoConvert_certmanager_CAIssuerRevokedCertificate_To_v1alpha3_CAIssuerRevokedCertificate(in, out, s)
+}
+
+func autoConvert_v1alpha3_CAIssuerRotationStatus_To_certmanager_CAIssuerRotationStatus(in *CAIssuerRotationStatus, out *certmanager.CAIssuerRotationStatus, s conversion.Scope) error {
+	out.StartTime = in.StartTime
+	out.Total = in.Total
+	out.Triggered = in.Triggered
+	out.Reissued = in.Reissued
+	out.CompletionTime = (*v1.Time)(unsafe.Pointer(in.CompletionTime))
+	return nil
+}
+
+// Convert_v1alpha3_CAIssuerRotationStatus_To_certmanager_CAIssuerRotationStatus is an autogenerated conversion function.
+func Convert_v1alpha3_CAIssuerRotationStatus_To_certmanager_CAIssuerRotationStatus(in *CAIssuerRotationStatus, out *certmanager.CAIssuerRotationStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CAIssuerRotationStatus_To_certmanager_CAIssuerRotationStatus(in, out, s)
+}
+
+func autoConvert_certmanager_CAIssuerRotationStatus_To_v1alpha3_CAIssuerRotationStatus(in *certmanager.CAIssuerRotationStatus, out *CAIssuerRotationStatus, s conversion.Scope) error {
+	out.StartTime = in.StartTime
+	out.Total = in.Total
+	out.Triggered = in.Triggered
+	out.Reissued = in.Reissued
+	out.CompletionTime = (*v1.Time)(unsafe.Pointer(in.CompletionTime))
+	return nil
+}
+
+// Convert_certmanager_CAIssuerRotationStatus_To_v1alpha3_CAIssuerRotationStatus is an autogenerated conversion function.
+func Convert_certmanager_CAIssuerRotationStatus_To_v1alpha3_CAIssuerRotationStatus(in *certmanager.CAIssuerRotationStatus, out *CAIssuerRotationStatus, s conversion.Scope) error {
+	return autoConvert_certmanager_CAIssuerRotationStatus_To_v1alpha3_CAIssuerRotationStatus(in, out, s)
+}
+
+func autoConvert_v1alpha3_CAIssuerSecretReference_To_certmanager_CAIssuerSecretReference(in *CAIssuerSecretReference, out *certmanager.CAIssuerSecretReference, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Namespace = in.Namespace
+	return nil
+}
+
+// Convert_v1alpha3_CAIssuerSecretReference_To_certmanager_CAIssuerSecretReference is an autogenerated conversion function.
+func Convert_v1alpha3_CAIssuerSecretReference_To_certmanager_CAIssuerSecretReference(in *CAIssuerSecretReference, out *certmanager.CAIssuerSecretReference, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CAIssuerSecretReference_To_certmanager_CAIssuerSecretReference(in, out, s)
+}
+
+func autoConvert_certmanager_CAIssuerSecretReference_To_v1alpha3_CAIssuerSecretReference(in *certmanager.CAIssuerSecretReference, out *CAIssuerSecretReference, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Namespace = in.Namespace
+	return nil
+}
+
+// Convert_certmanager_CAIssuerSecretReference_To_v1alpha3_CAIssuerSecretReference is an autogenerated conversion function.
+func Convert_certmanager_CAIssuerSecretReference_To_v1alpha3_CAIssuerSecretReference(in *certmanager.CAIssuerSecretReference, out *CAIssuerSecretReference, s conversion.Scope) error {
+	return autoConvert_certmanager_CAIssuerSecretReference_To_v1alpha3_CAIssuerSecretReference(in, out, s)
+}
+
+func autoConvert_v1alpha3_CAIssuerStatus_To_certmanager_CAIssuerStatus(in *CAIssuerStatus, out *certmanager.CAIssuerStatus, s conversion.Scope) error {
+	out.RevokedCertificates = *(*[]certmanager.CAIssuerRevokedCertificate)(unsafe.Pointer(&in.RevokedCertificates))
+	out.SigningCertificateFingerprint = in.SigningCertificateFingerprint
+	out.Rotation = (*certmanager.CAIssuerRotationStatus)(unsafe.Pointer(in.Rotation))
+	return nil
+}
+
+// Convert_v1alpha3_CAIssuerStatus_To_certmanager_CAIssuerStatus is an autogenerated conversion function.
+func Convert_v1alpha3_CAIssuerStatus_To_certmanager_CAIssuerStatus(in *CAIssuerStatus, out *certmanager.CAIssuerStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CAIssuerStatus_To_certmanager_CAIssuerStatus(in, out, s)
+}
+
+func autoConvert_certmanager_CAIssuerStatus_To_v1alpha3_CAIssuerStatus(in *certmanager.CAIssuerStatus, out *CAIssuerStatus, s conversion.Scope) error {
+	out.RevokedCertificates = *(*[]CAIssuerRevokedCertificate)(unsafe.Pointer(&in.RevokedCertificates))
+	out.SigningCertificateFingerprint = in.SigningCertificateFingerprint
+	out.Rotation = (*CAIssuerRotationStatus)(unsafe.Pointer(in.Rotation))
+	return nil
+}
+
+// Convert_certmanager_CAIssuerStatus_To_v1alpha3_CAIssuerStatus is an autogenerated conversion function.
+func Convert_certmanager_CAIssuerStatus_To_v1alpha3_CAIssuerStatus(in *certmanager.CAIssuerStatus, out *CAIssuerStatus, s conversion.Scope) error {
+	return autoConvert_certmanager_CAIssuerStatus_To_v1alpha3_CAIssuerStatus(in, out, s)
+}
+
 func autoConvert_v1alpha3_Certificate_To_certmanager_Certificate(in *Certificate, out *certmanager.Certificate, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha3_CertificateSpec_To_certmanager_CertificateSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -503,6 +805,30 @@ func Convert_certmanager_CertificateAdditionalOutputFormat_To_v1alpha3_Certifica
 	return autoConvert_certmanager_CertificateAdditionalOutputFormat_To_v1alpha3_CertificateAdditionalOutputFormat(in, out, s)
 }
 
+func autoConvert_v1alpha3_CertificateCABundleReference_To_certmanager_CertificateCABundleReference(in *CertificateCABundleReference, out *certmanager.CertificateCABundleReference, s conversion.Scope) error {
+	out.Kind = in.Kind
+	out.Name = in.Name
+	out.Key = in.Key
+	return nil
+}
+
+// Convert_v1alpha3_CertificateCABundleReference_To_certmanager_CertificateCABundleReference is an autogenerated conversion function.
+func Convert_v1alpha3_CertificateCABundleReference_To_certmanager_CertificateCABundleReference(in *CertificateCABundleReference, out *certmanager.CertificateCABundleReference, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CertificateCABundleReference_To_certmanager_CertificateCABundleReference(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateCABundleReference_To_v1alpha3_CertificateCABundleReference(in *certmanager.CertificateCABundleReference, out *CertificateCABundleReference, s conversion.Scope) error {
+	out.Kind = in.Kind
+	out.Name = in.Name
+	out.Key = in.Key
+	return nil
+}
+
+// Convert_certmanager_CertificateCABundleReference_To_v1alpha3_CertificateCABundleReference is an autogenerated conversion function.
+func Convert_certmanager_CertificateCABundleReference_To_v1alpha3_CertificateCABundleReference(in *certmanager.CertificateCABundleReference, out *CertificateCABundleReference, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateCABundleReference_To_v1alpha3_CertificateCABundleReference(in, out, s)
+}
+
 func autoConvert_v1alpha3_CertificateCondition_To_certmanager_CertificateCondition(in *CertificateCondition, out *certmanager.CertificateCondition, s conversion.Scope) error {
 	out.Type = certmanager.CertificateConditionType(in.Type)
 	out.Status = meta.ConditionStatus(in.Status)
@@ -533,6 +859,126 @@ func Convert_certmanager_CertificateCondition_To_v1alpha3_CertificateCondition(i
 	return autoConvert_certmanager_CertificateCondition_To_v1alpha3_CertificateCondition(in, out, s)
 }
 
+func autoConvert_v1alpha3_CertificateExternalCSR_To_certmanager_CertificateExternalCSR(in *CertificateExternalCSR, out *certmanager.CertificateExternalCSR, s conversion.Scope) error {
+	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha3_CertificateExternalCSR_To_certmanager_CertificateExternalCSR is an autogenerated conversion function.
+func Convert_v1alpha3_CertificateExternalCSR_To_certmanager_CertificateExternalCSR(in *CertificateExternalCSR, out *certmanager.CertificateExternalCSR, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CertificateExternalCSR_To_certmanager_CertificateExternalCSR(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateExternalCSR_To_v1alpha3_CertificateExternalCSR(in *certmanager.CertificateExternalCSR, out *CertificateExternalCSR, s conversion.Scope) error {
+	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_CertificateExternalCSR_To_v1alpha3_CertificateExternalCSR is an autogenerated conversion function.
+func Convert_certmanager_CertificateExternalCSR_To_v1alpha3_CertificateExternalCSR(in *certmanager.CertificateExternalCSR, out *CertificateExternalCSR, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateExternalCSR_To_v1alpha3_CertificateExternalCSR(in, out, s)
+}
+
+func autoConvert_v1alpha3_CertificateExternalPrivateKey_To_certmanager_CertificateExternalPrivateKey(in *CertificateExternalPrivateKey, out *certmanager.CertificateExternalPrivateKey, s conversion.Scope) error {
+	out.KeyRing = in.KeyRing
+	return nil
+}
+
+// Convert_v1alpha3_CertificateExternalPrivateKey_To_certmanager_CertificateExternalPrivateKey is an autogenerated conversion function.
+func Convert_v1alpha3_CertificateExternalPrivateKey_To_certmanager_CertificateExternalPrivateKey(in *CertificateExternalPrivateKey, out *certmanager.CertificateExternalPrivateKey, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CertificateExternalPrivateKey_To_certmanager_CertificateExternalPrivateKey(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateExternalPrivateKey_To_v1alpha3_CertificateExternalPrivateKey(in *certmanager.CertificateExternalPrivateKey, out *CertificateExternalPrivateKey, s conversion.Scope) error {
+	out.KeyRing = in.KeyRing
+	return nil
+}
+
+// Convert_certmanager_CertificateExternalPrivateKey_To_v1alpha3_CertificateExternalPrivateKey is an autogenerated conversion function.
+func Convert_certmanager_CertificateExternalPrivateKey_To_v1alpha3_CertificateExternalPrivateKey(in *certmanager.CertificateExternalPrivateKey, out *CertificateExternalPrivateKey, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateExternalPrivateKey_To_v1alpha3_CertificateExternalPrivateKey(in, out, s)
+}
+
+func autoConvert_v1alpha3_CertificateIssuanceAttempt_To_certmanager_CertificateIssuanceAttempt(in *CertificateIssuanceAttempt, out *certmanager.CertificateIssuanceAttempt, s conversion.Scope) error {
+	out.Revision = in.Revision
+	out.CertificateRequestName = in.CertificateRequestName
+	out.StartTime = (*v1.Time)(unsafe.Pointer(in.StartTime))
+	out.EndTime = (*v1.Time)(unsafe.Pointer(in.EndTime))
+	out.Outcome = certmanager.CertificateIssuanceOutcome(in.Outcome)
+	out.Reason = in.Reason
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_v1alpha3_CertificateIssuanceAttempt_To_certmanager_CertificateIssuanceAttempt is an autogenerated conversion function.
+func Convert_v1alpha3_CertificateIssuanceAttempt_To_certmanager_CertificateIssuanceAttempt(in *CertificateIssuanceAttempt, out *certmanager.CertificateIssuanceAttempt, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CertificateIssuanceAttempt_To_certmanager_CertificateIssuanceAttempt(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateIssuanceAttempt_To_v1alpha3_CertificateIssuanceAttempt(in *certmanager.CertificateIssuanceAttempt, out *CertificateIssuanceAttempt, s conversion.Scope) error {
+	out.Revision = in.Revision
+	out.CertificateRequestName = in.CertificateRequestName
+	out.StartTime = (*v1.Time)(unsafe.Pointer(in.StartTime))
+	out.EndTime = (*v1.Time)(unsafe.Pointer(in.EndTime))
+	out.Outcome = CertificateIssuanceOutcome(in.Outcome)
+	out.Reason = in.Reason
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_certmanager_CertificateIssuanceAttempt_To_v1alpha3_CertificateIssuanceAttempt is an autogenerated conversion function.
+func Convert_certmanager_CertificateIssuanceAttempt_To_v1alpha3_CertificateIssuanceAttempt(in *certmanager.CertificateIssuanceAttempt, out *CertificateIssuanceAttempt, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateIssuanceAttempt_To_v1alpha3_CertificateIssuanceAttempt(in, out, s)
+}
+
+func autoConvert_v1alpha3_CertificateIssuanceWindow_To_certmanager_CertificateIssuanceWindow(in *CertificateIssuanceWindow, out *certmanager.CertificateIssuanceWindow, s conversion.Scope) error {
+	out.TimeZone = in.TimeZone
+	out.Ranges = *(*[]certmanager.CertificateIssuanceWindowRange)(unsafe.Pointer(&in.Ranges))
+	return nil
+}
+
+// Convert_v1alpha3_CertificateIssuanceWindow_To_certmanager_CertificateIssuanceWindow is an autogenerated conversion function.
+func Convert_v1alpha3_CertificateIssuanceWindow_To_certmanager_CertificateIssuanceWindow(in *CertificateIssuanceWindow, out *certmanager.CertificateIssuanceWindow, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CertificateIssuanceWindow_To_certmanager_CertificateIssuanceWindow(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateIssuanceWindow_To_v1alpha3_CertificateIssuanceWindow(in *certmanager.CertificateIssuanceWindow, out *CertificateIssuanceWindow, s conversion.Scope) error {
+	out.TimeZone = in.TimeZone
+	out.Ranges = *(*[]CertificateIssuanceWindowRange)(unsafe.Pointer(&in.Ranges))
+	return nil
+}
+
+// Convert_certmanager_CertificateIssuanceWindow_To_v1alpha3_CertificateIssuanceWindow is an autogenerated conversion function.
+func Convert_certmanager_CertificateIssuanceWindow_To_v1alpha3_CertificateIssuanceWindow(in *certmanager.CertificateIssuanceWindow, out *CertificateIssuanceWindow, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateIssuanceWindow_To_v1alpha3_CertificateIssuanceWindow(in, out, s)
+}
+
+func autoConvert_v1alpha3_CertificateIssuanceWindowRange_To_certmanager_CertificateIssuanceWindowRange(in *CertificateIssuanceWindowRange, out *certmanager.CertificateIssuanceWindowRange, s conversion.Scope) error {
+	out.Start = in.Start
+	out.End = in.End
+	return nil
+}
+
+// Convert_v1alpha3_CertificateIssuanceWindowRange_To_certmanager_CertificateIssuanceWindowRange is an autogenerated conversion function.
+func Convert_v1alpha3_CertificateIssuanceWindowRange_To_certmanager_CertificateIssuanceWindowRange(in *CertificateIssuanceWindowRange, out *certmanager.CertificateIssuanceWindowRange, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CertificateIssuanceWindowRange_To_certmanager_CertificateIssuanceWindowRange(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateIssuanceWindowRange_To_v1alpha3_CertificateIssuanceWindowRange(in *certmanager.CertificateIssuanceWindowRange, out *CertificateIssuanceWindowRange, s conversion.Scope) error {
+	out.Start = in.Start
+	out.End = in.End
+	return nil
+}
+
+// Convert_certmanager_CertificateIssuanceWindowRange_To_v1alpha3_CertificateIssuanceWindowRange is an autogenerated conversion function.
+func Convert_certmanager_CertificateIssuanceWindowRange_To_v1alpha3_CertificateIssuanceWindowRange(in *certmanager.CertificateIssuanceWindowRange, out *CertificateIssuanceWindowRange, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateIssuanceWindowRange_To_v1alpha3_CertificateIssuanceWindowRange(in, out, s)
+}
+
 func autoConvert_v1alpha3_CertificateKeystores_To_certmanager_CertificateKeystores(in *CertificateKeystores, out *certmanager.CertificateKeystores, s conversion.Scope) error {
 	if in.JKS != nil {
 		in, out := &in.JKS, &out.JKS
@@ -552,6 +998,7 @@ func autoConvert_v1alpha3_CertificateKeystores_To_certmanager_CertificateKeystor
 	} else {
 		out.PKCS12 = nil
 	}
+	out.SecretName = in.SecretName
 	return nil
 }
 
@@ -579,6 +1026,7 @@ func autoConvert_certmanager_CertificateKeystores_To_v1alpha3_CertificateKeystor
 	} else {
 		out.PKCS12 = nil
 	}
+	out.SecretName = in.SecretName
 	return nil
 }
 
@@ -629,8 +1077,57 @@ func Convert_certmanager_CertificateList_To_v1alpha3_CertificateList(in *certman
 	return autoConvert_certmanager_CertificateList_To_v1alpha3_CertificateList(in, out, s)
 }
 
+func autoConvert_v1alpha3_CertificatePolicyEvaluation_To_certmanager_CertificatePolicyEvaluation(in *CertificatePolicyEvaluation, out *certmanager.CertificatePolicyEvaluation, s conversion.Scope) error {
+	out.EvaluationTime = in.EvaluationTime
+	out.Policies = *(*[]certmanager.CertificatePolicyResult)(unsafe.Pointer(&in.Policies))
+	return nil
+}
+
+// Convert_v1alpha3_CertificatePolicyEvaluation_To_certmanager_CertificatePolicyEvaluation is an autogenerated conversion function.
+func Convert_v1alpha3_CertificatePolicyEvaluation_To_certmanager_CertificatePolicyEvaluation(in *CertificatePolicyEvaluation, out *certmanager.CertificatePolicyEvaluation, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CertificatePolicyEvaluation_To_certmanager_CertificatePolicyEvaluation(in, out, s)
+}
+
+func autoConvert_certmanager_CertificatePolicyEvaluation_To_v1alpha3_CertificatePolicyEvaluation(in *certmanager.CertificatePolicyEvaluation, out *CertificatePolicyEvaluation, s conversion.Scope) error {
+	out.EvaluationTime = in.EvaluationTime
+	out.Policies = *(*[]CertificatePolicyResult)(unsafe.Pointer(&in.Policies))
+	return nil
+}
+
+// Convert_certmanager_CertificatePolicyEvaluation_To_v1alpha3_CertificatePolicyEvaluation is an autogenerated conversion function.
+func Convert_certmanager_CertificatePolicyEvaluation_To_v1alpha3_CertificatePolicyEvaluation(in *certmanager.CertificatePolicyEvaluation, out *CertificatePolicyEvaluation, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificatePolicyEvaluation_To_v1alpha3_CertificatePolicyEvaluation(in, out, s)
+}
+
+func autoConvert_v1alpha3_CertificatePolicyResult_To_certmanager_CertificatePolicyResult(in *CertificatePolicyResult, out *certmanager.CertificatePolicyResult, s conversion.Scope) error {
+	out.PolicyName = in.PolicyName
+	out.Violated = in.Violated
+	out.Reason = in.Reason
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_v1alpha3_CertificatePolicyResult_To_certmanager_CertificatePolicyResult is an autogenerated conversion function.
+func Convert_v1alpha3_CertificatePolicyResult_To_certmanager_CertificatePolicyResult(in *CertificatePolicyResult, out *certmanager.CertificatePolicyResult, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CertificatePolicyResult_To_certmanager_CertificatePolicyResult(in, out, s)
+}
+
+func autoConvert_certmanager_CertificatePolicyResult_To_v1alpha3_CertificatePolicyResult(in *certmanager.CertificatePolicyResult, out *CertificatePolicyResult, s conversion.Scope) error {
+	out.PolicyName = in.PolicyName
+	out.Violated = in.Violated
+	out.Reason = in.Reason
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_certmanager_CertificatePolicyResult_To_v1alpha3_CertificatePolicyResult is an autogenerated conversion function.
+func Convert_certmanager_CertificatePolicyResult_To_v1alpha3_CertificatePolicyResult(in *certmanager.CertificatePolicyResult, out *CertificatePolicyResult, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificatePolicyResult_To_v1alpha3_CertificatePolicyResult(in, out, s)
+}
+
 func autoConvert_v1alpha3_CertificatePrivateKey_To_certmanager_CertificatePrivateKey(in *CertificatePrivateKey, out *certmanager.CertificatePrivateKey, s conversion.Scope) error {
 	out.RotationPolicy = certmanager.PrivateKeyRotationPolicy(in.RotationPolicy)
+	out.External = (*certmanager.CertificateExternalPrivateKey)(unsafe.Pointer(in.External))
 	return nil
 }
 
@@ -644,9 +1141,36 @@ func autoConvert_certmanager_CertificatePrivateKey_To_v1alpha3_CertificatePrivat
 	// WARNING: in.Encoding requires manual conversion: does not exist in peer-type
 	// WARNING: in.Algorithm requires manual conversion: does not exist in peer-type
 	// WARNING: in.Size requires manual conversion: does not exist in peer-type
+	out.External = (*CertificateExternalPrivateKey)(unsafe.Pointer(in.External))
+	return nil
+}
+
+func autoConvert_v1alpha3_CertificateReadinessGate_To_certmanager_CertificateReadinessGate(in *CertificateReadinessGate, out *certmanager.CertificateReadinessGate, s conversion.Scope) error {
+	out.Name = in.Name
+	out.URL = in.URL
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.Timeout = (*v1.Duration)(unsafe.Pointer(in.Timeout))
+	return nil
+}
+
+// Convert_v1alpha3_CertificateReadinessGate_To_certmanager_CertificateReadinessGate is an autogenerated conversion function.
+func Convert_v1alpha3_CertificateReadinessGate_To_certmanager_CertificateReadinessGate(in *CertificateReadinessGate, out *certmanager.CertificateReadinessGate, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CertificateReadinessGate_To_certmanager_CertificateReadinessGate(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateReadinessGate_To_v1alpha3_CertificateReadinessGate(in *certmanager.CertificateReadinessGate, out *CertificateReadinessGate, s conversion.Scope) error {
+	out.Name = in.Name
+	out.URL = in.URL
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.Timeout = (*v1.Duration)(unsafe.Pointer(in.Timeout))
 	return nil
 }
 
+// Convert_certmanager_CertificateReadinessGate_To_v1alpha3_CertificateReadinessGate is an autogenerated conversion function.
+func Convert_certmanager_CertificateReadinessGate_To_v1alpha3_CertificateReadinessGate(in *certmanager.CertificateReadinessGate, out *CertificateReadinessGate, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateReadinessGate_To_v1alpha3_CertificateReadinessGate(in, out, s)
+}
+
 func autoConvert_v1alpha3_CertificateRequest_To_certmanager_CertificateRequest(in *CertificateRequest, out *certmanager.CertificateRequest, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha3_CertificateRequestSpec_To_certmanager_CertificateRequestSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -805,9 +1329,54 @@ func Convert_certmanager_CertificateRequestStatus_To_v1alpha3_CertificateRequest
 	return autoConvert_certmanager_CertificateRequestStatus_To_v1alpha3_CertificateRequestStatus(in, out, s)
 }
 
+func autoConvert_v1alpha3_CertificateRequestTemplate_To_certmanager_CertificateRequestTemplate(in *CertificateRequestTemplate, out *certmanager.CertificateRequestTemplate, s conversion.Scope) error {
+	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	return nil
+}
+
+// Convert_v1alpha3_CertificateRequestTemplate_To_certmanager_CertificateRequestTemplate is an autogenerated conversion function.
+func Convert_v1alpha3_CertificateRequestTemplate_To_certmanager_CertificateRequestTemplate(in *CertificateRequestTemplate, out *certmanager.CertificateRequestTemplate, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CertificateRequestTemplate_To_certmanager_CertificateRequestTemplate(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateRequestTemplate_To_v1alpha3_CertificateRequestTemplate(in *certmanager.CertificateRequestTemplate, out *CertificateRequestTemplate, s conversion.Scope) error {
+	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	return nil
+}
+
+// Convert_certmanager_CertificateRequestTemplate_To_v1alpha3_CertificateRequestTemplate is an autogenerated conversion function.
+func Convert_certmanager_CertificateRequestTemplate_To_v1alpha3_CertificateRequestTemplate(in *certmanager.CertificateRequestTemplate, out *CertificateRequestTemplate, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateRequestTemplate_To_v1alpha3_CertificateRequestTemplate(in, out, s)
+}
+
+func autoConvert_v1alpha3_CertificateSecretCAPolicy_To_certmanager_CertificateSecretCAPolicy(in *CertificateSecretCAPolicy, out *certmanager.CertificateSecretCAPolicy, s conversion.Scope) error {
+	out.Type = certmanager.CertificateSecretCAPolicyType(in.Type)
+	out.BundleRef = (*certmanager.CertificateCABundleReference)(unsafe.Pointer(in.BundleRef))
+	return nil
+}
+
+// Convert_v1alpha3_CertificateSecretCAPolicy_To_certmanager_CertificateSecretCAPolicy is an autogenerated conversion function.
+func Convert_v1alpha3_CertificateSecretCAPolicy_To_certmanager_CertificateSecretCAPolicy(in *CertificateSecretCAPolicy, out *certmanager.CertificateSecretCAPolicy, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CertificateSecretCAPolicy_To_certmanager_CertificateSecretCAPolicy(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateSecretCAPolicy_To_v1alpha3_CertificateSecretCAPolicy(in *certmanager.CertificateSecretCAPolicy, out *CertificateSecretCAPolicy, s conversion.Scope) error {
+	out.Type = CertificateSecretCAPolicyType(in.Type)
+	out.BundleRef = (*CertificateCABundleReference)(unsafe.Pointer(in.BundleRef))
+	return nil
+}
+
+// Convert_certmanager_CertificateSecretCAPolicy_To_v1alpha3_CertificateSecretCAPolicy is an autogenerated conversion function.
+func Convert_certmanager_CertificateSecretCAPolicy_To_v1alpha3_CertificateSecretCAPolicy(in *certmanager.CertificateSecretCAPolicy, out *CertificateSecretCAPolicy, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateSecretCAPolicy_To_v1alpha3_CertificateSecretCAPolicy(in, out, s)
+}
+
 func autoConvert_v1alpha3_CertificateSecretTemplate_To_certmanager_CertificateSecretTemplate(in *CertificateSecretTemplate, out *certmanager.CertificateSecretTemplate, s conversion.Scope) error {
 	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
 	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	out.Immutable = in.Immutable
 	return nil
 }
 
@@ -819,6 +1388,7 @@ func Convert_v1alpha3_CertificateSecretTemplate_To_certmanager_CertificateSecret
 func autoConvert_certmanager_CertificateSecretTemplate_To_v1alpha3_CertificateSecretTemplate(in *certmanager.CertificateSecretTemplate, out *CertificateSecretTemplate, s conversion.Scope) error {
 	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
 	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	out.Immutable = in.Immutable
 	return nil
 }
 
@@ -839,8 +1409,12 @@ func autoConvert_v1alpha3_CertificateSpec_To_certmanager_CertificateSpec(in *Cer
 	}
 	out.LiteralSubject = in.LiteralSubject
 	out.CommonName = in.CommonName
+	out.CommonNameFromFirstDNSName = in.CommonNameFromFirstDNSName
 	out.Duration = (*v1.Duration)(unsafe.Pointer(in.Duration))
 	out.RenewBefore = (*v1.Duration)(unsafe.Pointer(in.RenewBefore))
+	out.RenewBeforeChainExpiry = in.RenewBeforeChainExpiry
+	out.ChainExpiryExcludedFingerprints = *(*[]string)(unsafe.Pointer(&in.ChainExpiryExcludedFingerprints))
+	out.RolloutDelay = (*v1.Duration)(unsafe.Pointer(in.RolloutDelay))
 	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
 	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
 	// WARNING: in.URISANs requires manual conversion: does not exist in peer-type
@@ -878,6 +1452,20 @@ func autoConvert_v1alpha3_CertificateSpec_To_certmanager_CertificateSpec(in *Cer
 	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
 	out.AdditionalOutputFormats = *(*[]certmanager.CertificateAdditionalOutputFormat)(unsafe.Pointer(&in.AdditionalOutputFormats))
 	out.NameConstraints = (*certmanager.NameConstraints)(unsafe.Pointer(in.NameConstraints))
+	out.PolicyOIDs = *(*[]string)(unsafe.Pointer(&in.PolicyOIDs))
+	out.RevokeOnDelete = (*bool)(unsafe.Pointer(in.RevokeOnDelete))
+	if in.ExternalCSR != nil {
+		in, out := &in.ExternalCSR, &out.ExternalCSR
+		*out = new(certmanager.CertificateExternalCSR)
+		if err := Convert_v1alpha3_CertificateExternalCSR_To_certmanager_CertificateExternalCSR(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ExternalCSR = nil
+	}
+	out.SecretCAPolicy = (*certmanager.CertificateSecretCAPolicy)(unsafe.Pointer(in.SecretCAPolicy))
+	out.CertificateRequestTemplate = (*certmanager.CertificateRequestTemplate)(unsafe.Pointer(in.CertificateRequestTemplate))
+	out.IssuanceWindow = (*certmanager.CertificateIssuanceWindow)(unsafe.Pointer(in.IssuanceWindow))
 	return nil
 }
 
@@ -893,8 +1481,12 @@ func autoConvert_certmanager_CertificateSpec_To_v1alpha3_CertificateSpec(in *cer
 	}
 	out.LiteralSubject = in.LiteralSubject
 	out.CommonName = in.CommonName
+	out.CommonNameFromFirstDNSName = in.CommonNameFromFirstDNSName
 	out.Duration = (*v1.Duration)(unsafe.Pointer(in.Duration))
 	out.RenewBefore = (*v1.Duration)(unsafe.Pointer(in.RenewBefore))
+	out.RenewBeforeChainExpiry = in.RenewBeforeChainExpiry
+	out.ChainExpiryExcludedFingerprints = *(*[]string)(unsafe.Pointer(&in.ChainExpiryExcludedFingerprints))
+	out.RolloutDelay = (*v1.Duration)(unsafe.Pointer(in.RolloutDelay))
 	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
 	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
 	// WARNING: in.URIs requires manual conversion: does not exist in peer-type
@@ -929,6 +1521,20 @@ func autoConvert_certmanager_CertificateSpec_To_v1alpha3_CertificateSpec(in *cer
 	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
 	out.AdditionalOutputFormats = *(*[]CertificateAdditionalOutputFormat)(unsafe.Pointer(&in.AdditionalOutputFormats))
 	out.NameConstraints = (*NameConstraints)(unsafe.Pointer(in.NameConstraints))
+	out.PolicyOIDs = *(*[]string)(unsafe.Pointer(&in.PolicyOIDs))
+	out.RevokeOnDelete = (*bool)(unsafe.Pointer(in.RevokeOnDelete))
+	if in.ExternalCSR != nil {
+		in, out := &in.ExternalCSR, &out.ExternalCSR
+		*out = new(CertificateExternalCSR)
+		if err := Convert_certmanager_CertificateExternalCSR_To_v1alpha3_CertificateExternalCSR(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ExternalCSR = nil
+	}
+	out.SecretCAPolicy = (*CertificateSecretCAPolicy)(unsafe.Pointer(in.SecretCAPolicy))
+	out.CertificateRequestTemplate = (*CertificateRequestTemplate)(unsafe.Pointer(in.CertificateRequestTemplate))
+	out.IssuanceWindow = (*CertificateIssuanceWindow)(unsafe.Pointer(in.IssuanceWindow))
 	return nil
 }
 
@@ -938,9 +1544,18 @@ func autoConvert_v1alpha3_CertificateStatus_To_certmanager_CertificateStatus(in
 	out.NotBefore = (*v1.Time)(unsafe.Pointer(in.NotBefore))
 	out.NotAfter = (*v1.Time)(unsafe.Pointer(in.NotAfter))
 	out.RenewalTime = (*v1.Time)(unsafe.Pointer(in.RenewalTime))
+	out.NextIssuanceWindowTime = (*v1.Time)(unsafe.Pointer(in.NextIssuanceWindowTime))
 	out.Revision = (*int)(unsafe.Pointer(in.Revision))
 	out.NextPrivateKeySecretName = (*string)(unsafe.Pointer(in.NextPrivateKeySecretName))
 	out.FailedIssuanceAttempts = (*int)(unsafe.Pointer(in.FailedIssuanceAttempts))
+	out.SerialNumber = in.SerialNumber
+	out.SHA256Fingerprint = in.SHA256Fingerprint
+	out.ShortSHA256Fingerprint = in.ShortSHA256Fingerprint
+	out.IssuerDN = in.IssuerDN
+	out.SubjectDN = in.SubjectDN
+	out.IssuerCAFingerprints = *(*[]string)(unsafe.Pointer(&in.IssuerCAFingerprints))
+	out.IssuanceHistory = *(*[]certmanager.CertificateIssuanceAttempt)(unsafe.Pointer(&in.IssuanceHistory))
+	out.LastEvaluation = (*certmanager.CertificatePolicyEvaluation)(unsafe.Pointer(in.LastEvaluation))
 	return nil
 }
 
@@ -955,9 +1570,18 @@ func autoConvert_certmanager_CertificateStatus_To_v1alpha3_CertificateStatus(in
 	out.NotBefore = (*v1.Time)(unsafe.Pointer(in.NotBefore))
 	out.NotAfter = (*v1.Time)(unsafe.Pointer(in.NotAfter))
 	out.RenewalTime = (*v1.Time)(unsafe.Pointer(in.RenewalTime))
+	out.NextIssuanceWindowTime = (*v1.Time)(unsafe.Pointer(in.NextIssuanceWindowTime))
 	out.Revision = (*int)(unsafe.Pointer(in.Revision))
 	out.NextPrivateKeySecretName = (*string)(unsafe.Pointer(in.NextPrivateKeySecretName))
 	out.FailedIssuanceAttempts = (*int)(unsafe.Pointer(in.FailedIssuanceAttempts))
+	out.SerialNumber = in.SerialNumber
+	out.SHA256Fingerprint = in.SHA256Fingerprint
+	out.ShortSHA256Fingerprint = in.ShortSHA256Fingerprint
+	out.IssuerDN = in.IssuerDN
+	out.SubjectDN = in.SubjectDN
+	out.IssuerCAFingerprints = *(*[]string)(unsafe.Pointer(&in.IssuerCAFingerprints))
+	out.IssuanceHistory = *(*[]CertificateIssuanceAttempt)(unsafe.Pointer(&in.IssuanceHistory))
+	out.LastEvaluation = (*CertificatePolicyEvaluation)(unsafe.Pointer(in.LastEvaluation))
 	return nil
 }
 
@@ -1132,6 +1756,7 @@ func autoConvert_v1alpha3_IssuerConfig_To_certmanager_IssuerConfig(in *IssuerCon
 	} else {
 		out.Venafi = nil
 	}
+	out.Manual = (*certmanager.ManualIssuer)(unsafe.Pointer(in.Manual))
 	return nil
 }
 
@@ -1170,6 +1795,7 @@ func autoConvert_certmanager_IssuerConfig_To_v1alpha3_IssuerConfig(in *certmanag
 	} else {
 		out.Venafi = nil
 	}
+	out.Manual = (*ManualIssuer)(unsafe.Pointer(in.Manual))
 	return nil
 }
 
@@ -1224,6 +1850,7 @@ func autoConvert_v1alpha3_IssuerSpec_To_certmanager_IssuerSpec(in *IssuerSpec, o
 	if err := Convert_v1alpha3_IssuerConfig_To_certmanager_IssuerConfig(&in.IssuerConfig, &out.IssuerConfig, s); err != nil {
 		return err
 	}
+	out.ReadinessGates = *(*[]certmanager.CertificateReadinessGate)(unsafe.Pointer(&in.ReadinessGates))
 	return nil
 }
 
@@ -1236,6 +1863,7 @@ func autoConvert_certmanager_IssuerSpec_To_v1alpha3_IssuerSpec(in *certmanager.I
 	if err := Convert_certmanager_IssuerConfig_To_v1alpha3_IssuerConfig(&in.IssuerConfig, &out.IssuerConfig, s); err != nil {
 		return err
 	}
+	out.ReadinessGates = *(*[]CertificateReadinessGate)(unsafe.Pointer(&in.ReadinessGates))
 	return nil
 }
 
@@ -1247,6 +1875,7 @@ func Convert_certmanager_IssuerSpec_To_v1alpha3_IssuerSpec(in *certmanager.Issue
 func autoConvert_v1alpha3_IssuerStatus_To_certmanager_IssuerStatus(in *IssuerStatus, out *certmanager.IssuerStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]certmanager.IssuerCondition)(unsafe.Pointer(&in.Conditions))
 	out.ACME = (*acme.ACMEIssuerStatus)(unsafe.Pointer(in.ACME))
+	out.CA = (*certmanager.CAIssuerStatus)(unsafe.Pointer(in.CA))
 	return nil
 }
 
@@ -1258,6 +1887,7 @@ func Convert_v1alpha3_IssuerStatus_To_certmanager_IssuerStatus(in *IssuerStatus,
 func autoConvert_certmanager_IssuerStatus_To_v1alpha3_IssuerStatus(in *certmanager.IssuerStatus, out *IssuerStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]IssuerCondition)(unsafe.Pointer(&in.Conditions))
 	out.ACME = (*acmev1alpha3.ACMEIssuerStatus)(unsafe.Pointer(in.ACME))
+	out.CA = (*CAIssuerStatus)(unsafe.Pointer(in.CA))
 	return nil
 }
 
@@ -1294,6 +1924,24 @@ func Convert_certmanager_JKSKeystore_To_v1alpha3_JKSKeystore(in *certmanager.JKS
 	return autoConvert_certmanager_JKSKeystore_To_v1alpha3_JKSKeystore(in, out, s)
 }
 
+func autoConvert_v1alpha3_ManualIssuer_To_certmanager_ManualIssuer(in *ManualIssuer, out *certmanager.ManualIssuer, s conversion.Scope) error {
+	return nil
+}
+
+// Convert_v1alpha3_ManualIssuer_To_certmanager_ManualIssuer is an autogenerated conversion function.
+func Convert_v1alpha3_ManualIssuer_To_certmanager_ManualIssuer(in *ManualIssuer, out *certmanager.ManualIssuer, s conversion.Scope) error {
+	return autoConvert_v1alpha3_ManualIssuer_To_certmanager_ManualIssuer(in, out, s)
+}
+
+func autoConvert_certmanager_ManualIssuer_To_v1alpha3_ManualIssuer(in *certmanager.ManualIssuer, out *ManualIssuer, s conversion.Scope) error {
+	return nil
+}
+
+// Convert_certmanager_ManualIssuer_To_v1alpha3_ManualIssuer is an autogenerated conversion function.
+func Convert_certmanager_ManualIssuer_To_v1alpha3_ManualIssuer(in *certmanager.ManualIssuer, out *ManualIssuer, s conversion.Scope) error {
+	return autoConvert_certmanager_ManualIssuer_To_v1alpha3_ManualIssuer(in, out, s)
+}
+
 func autoConvert_v1alpha3_NameConstraintItem_To_certmanager_NameConstraintItem(in *NameConstraintItem, out *certmanager.NameConstraintItem, s conversion.Scope) error {
 	out.DNSDomains = *(*[]string)(unsafe.Pointer(&in.DNSDomains))
 	out.IPRanges = *(*[]string)(unsafe.Pointer(&in.IPRanges))
@@ -1442,6 +2090,7 @@ func autoConvert_v1alpha3_VaultAppRole_To_certmanager_VaultAppRole(in *VaultAppR
 	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
 		return err
 	}
+	out.ResponseWrapped = in.ResponseWrapped
 	return nil
 }
 
@@ -1456,6 +2105,7 @@ func autoConvert_certmanager_VaultAppRole_To_v1alpha3_VaultAppRole(in *certmanag
 	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
 		return err
 	}
+	out.ResponseWrapped = in.ResponseWrapped
 	return nil
 }
 
@@ -1571,6 +2221,19 @@ func autoConvert_v1alpha3_VaultIssuer_To_certmanager_VaultIssuer(in *VaultIssuer
 	} else {
 		out.ClientKeySecretRef = nil
 	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(meta.ClientTLSConfig)
+		if err := apismetav1.Convert_v1_ClientTLSConfig_To_meta_ClientTLSConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TLS = nil
+	}
+	out.IssuerRef = in.IssuerRef
+	out.ExcludeCNFromSANs = (*bool)(unsafe.Pointer(in.ExcludeCNFromSANs))
+	out.ExtraParameters = *(*map[string]string)(unsafe.Pointer(&in.ExtraParameters))
+	out.SignMode = certmanager.VaultSignMode(in.SignMode)
 	return nil
 }
 
@@ -1614,6 +2277,19 @@ func autoConvert_certmanager_VaultIssuer_To_v1alpha3_VaultIssuer(in *certmanager
 	} else {
 		out.ClientKeySecretRef = nil
 	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(metav1.ClientTLSConfig)
+		if err := apismetav1.Convert_meta_ClientTLSConfig_To_v1_ClientTLSConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TLS = nil
+	}
+	out.IssuerRef = in.IssuerRef
+	out.ExcludeCNFromSANs = (*bool)(unsafe.Pointer(in.ExcludeCNFromSANs))
+	out.ExtraParameters = *(*map[string]string)(unsafe.Pointer(&in.ExtraParameters))
+	out.SignMode = VaultSignMode(in.SignMode)
 	return nil
 }
 
@@ -1693,6 +2369,15 @@ func autoConvert_v1alpha3_VenafiIssuer_To_certmanager_VenafiIssuer(in *VenafiIss
 	} else {
 		out.Cloud = nil
 	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(meta.ClientTLSConfig)
+		if err := apismetav1.Convert_v1_ClientTLSConfig_To_meta_ClientTLSConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TLS = nil
+	}
 	return nil
 }
 
@@ -1721,6 +2406,15 @@ func autoConvert_certmanager_VenafiIssuer_To_v1alpha3_VenafiIssuer(in *certmanag
 	} else {
 		out.Cloud = nil
 	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(metav1.ClientTLSConfig)
+		if err := apismetav1.Convert_meta_ClientTLSConfig_To_v1_ClientTLSConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TLS = nil
+	}
 	return nil
 }
 
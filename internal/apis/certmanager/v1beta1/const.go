@@ -20,7 +20,7 @@ import "time"
 
 const (
 	// minimum permitted certificate duration by cert-manager
-	MinimumCertificateDuration = time.Hour
+	MinimumCertificateDuration = time.Minute * 5
 
 	// default certificate duration if Issuer.spec.duration is not set
 	DefaultCertificateDuration = time.Hour * 24 * 90
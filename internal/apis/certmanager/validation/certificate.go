@@ -17,10 +17,14 @@ limitations under the License.
 package validation
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"net"
 	"net/mail"
+	"strconv"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	admissionv1 "k8s.io/api/admission/v1"
@@ -112,8 +116,12 @@ func ValidateCertificateSpec(crt *internalcmapi.CertificateSpec, fldPath *field.
 	}
 
 	// if a common name has been specified, ensure it is no longer than 64 chars
-	if len(commonName) > 64 {
-		el = append(el, field.TooLong(fldPath.Child("commonName"), commonName, 64))
+	if len(commonName) > pki.MaxCommonNameLength {
+		el = append(el, field.TooLong(fldPath.Child("commonName"), commonName, pki.MaxCommonNameLength))
+	}
+
+	if len(crt.DNSNames) > 0 {
+		el = append(el, validateDNSNames(crt, fldPath)...)
 	}
 
 	if len(crt.IPAddresses) > 0 {
@@ -144,6 +152,12 @@ func ValidateCertificateSpec(crt *internalcmapi.CertificateSpec, fldPath *field.
 		}
 	}
 
+	for i, policyOID := range crt.PolicyOIDs {
+		if _, err := pki.ParsePolicyOID(policyOID); err != nil {
+			el = append(el, field.Invalid(fldPath.Child("policyOIDs").Index(i), policyOID, "oid syntax invalid"))
+		}
+	}
+
 	if crt.PrivateKey != nil {
 		switch crt.PrivateKey.Algorithm {
 		case "", internalcmapi.RSAKeyAlgorithm:
@@ -151,8 +165,12 @@ func ValidateCertificateSpec(crt *internalcmapi.CertificateSpec, fldPath *field.
 				el = append(el, field.Invalid(fldPath.Child("privateKey", "size"), crt.PrivateKey.Size, "must be between 2048 & 8192 for rsa keyAlgorithm"))
 			}
 		case internalcmapi.ECDSAKeyAlgorithm:
-			if crt.PrivateKey.Size > 0 && crt.PrivateKey.Size != 256 && crt.PrivateKey.Size != 384 && crt.PrivateKey.Size != 521 {
-				el = append(el, field.NotSupported(fldPath.Child("privateKey", "size"), crt.PrivateKey.Size, []string{"256", "384", "521"}))
+			if crt.PrivateKey.Size > 0 && !pki.IsSupportedECDSAKeySize(crt.PrivateKey.Size) {
+				var sizes []string
+				for _, size := range pki.SupportedECDSAKeySizes() {
+					sizes = append(sizes, strconv.Itoa(size))
+				}
+				el = append(el, field.NotSupported(fldPath.Child("privateKey", "size"), crt.PrivateKey.Size, sizes))
 			}
 		case internalcmapi.Ed25519KeyAlgorithm:
 			break
@@ -180,6 +198,10 @@ func ValidateCertificateSpec(crt *internalcmapi.CertificateSpec, fldPath *field.
 		}
 	}
 
+	if crt.CertificateRequestTemplate != nil {
+		el = append(el, validateCertificateRequestTemplate(crt.CertificateRequestTemplate, fldPath.Child("certificateRequestTemplate"))...)
+	}
+
 	if crt.NameConstraints != nil {
 		if !utilfeature.DefaultFeatureGate.Enabled(feature.NameConstraints) {
 			el = append(el, field.Forbidden(fldPath.Child("nameConstraints"), "feature gate NameConstraints must be enabled"))
@@ -196,19 +218,83 @@ func ValidateCertificateSpec(crt *internalcmapi.CertificateSpec, fldPath *field.
 
 	el = append(el, validateAdditionalOutputFormats(crt, fldPath)...)
 
+	if crt.ExternalCSR != nil {
+		el = append(el, validateExternalCSR(crt, fldPath)...)
+	}
+
+	if crt.PrivateKey != nil && crt.PrivateKey.External != nil {
+		el = append(el, validateExternalPrivateKey(crt, fldPath)...)
+	}
+
+	if crt.SecretCAPolicy != nil {
+		el = append(el, validateSecretCAPolicy(crt.SecretCAPolicy, fldPath.Child("secretCAPolicy"))...)
+	}
+
+	if crt.IssuanceWindow != nil {
+		el = append(el, validateIssuanceWindow(crt.IssuanceWindow, fldPath.Child("issuanceWindow"))...)
+	}
+
+	if crt.RolloutDelay != nil {
+		el = append(el, validateRolloutDelay(crt, fldPath.Child("rolloutDelay"))...)
+	}
+
+	if len(crt.ChainExpiryExcludedFingerprints) > 0 {
+		el = append(el, validateChainExpiryExcludedFingerprints(crt, fldPath.Child("chainExpiryExcludedFingerprints"))...)
+	}
+
+	if crt.Keystores != nil && crt.Keystores.SecretName != "" {
+		el = append(el, validateKeystoresSecretName(crt, fldPath.Child("keystores", "secretName"))...)
+	}
+
 	return el
 }
 
 func ValidateCertificate(a *admissionv1.AdmissionRequest, obj runtime.Object) (field.ErrorList, []string) {
 	crt := obj.(*internalcmapi.Certificate)
 	allErrs := ValidateCertificateSpec(&crt.Spec, field.NewPath("spec"))
-	return allErrs, nil
+	return allErrs, dnsNamesWarnings(&crt.Spec, field.NewPath("spec"))
 }
 
 func ValidateUpdateCertificate(a *admissionv1.AdmissionRequest, oldObj, obj runtime.Object) (field.ErrorList, []string) {
 	crt := obj.(*internalcmapi.Certificate)
 	allErrs := ValidateCertificateSpec(&crt.Spec, field.NewPath("spec"))
-	return allErrs, nil
+	return allErrs, dnsNamesWarnings(&crt.Spec, field.NewPath("spec"))
+}
+
+// ValidateCertificateSANCount returns a warning if the Certificate requests
+// more than warningThreshold subject alternative names, and an error if it
+// requests more than maxSANs. Certificates with many names are slow to issue,
+// and most ACME servers reject orders for more than 100 names. A limit of 0
+// disables the respective check.
+func ValidateCertificateSANCount(crt *internalcmapi.CertificateSpec, fldPath *field.Path, warningThreshold, maxSANs int) (field.ErrorList, []string) {
+	el := field.ErrorList{}
+	var warnings []string
+
+	count := certificateSANCount(crt)
+	switch {
+	case maxSANs > 0 && count > maxSANs:
+		el = append(el, field.Forbidden(fldPath, fmt.Sprintf("requests %d subject alternative names, but at most %d are allowed", count, maxSANs)))
+	case warningThreshold > 0 && count > warningThreshold:
+		warnings = append(warnings, fmt.Sprintf("%s requests %d subject alternative names, which may be slow to issue or be rejected by the issuer", fldPath, count))
+	}
+
+	return el, warnings
+}
+
+// ValidateUpdateCertificateSANCount is ValidateCertificateSANCount for
+// updates. A Certificate which already requests more than maxSANs subject
+// alternative names, e.g. because it was created before the limit was
+// configured, may still be updated as long as the number of names does not
+// grow, in which case a warning is returned instead of an error.
+func ValidateUpdateCertificateSANCount(oldCrt, crt *internalcmapi.CertificateSpec, fldPath *field.Path, warningThreshold, maxSANs int) (field.ErrorList, []string) {
+	if count := certificateSANCount(crt); maxSANs > 0 && count > maxSANs && count <= certificateSANCount(oldCrt) {
+		return field.ErrorList{}, []string{fmt.Sprintf("%s requests %d subject alternative names, but at most %d are allowed; the number of names may not be increased", fldPath, count, maxSANs)}
+	}
+	return ValidateCertificateSANCount(crt, fldPath, warningThreshold, maxSANs)
+}
+
+func certificateSANCount(crt *internalcmapi.CertificateSpec) int {
+	return len(crt.DNSNames) + len(crt.IPAddresses) + len(crt.URIs) + len(crt.EmailAddresses) + len(crt.OtherNames)
 }
 
 func validateIssuerRef(issuerRef cmmeta.ObjectReference, fldPath *field.Path) field.ErrorList {
@@ -267,6 +353,63 @@ func validateIPAddresses(a *internalcmapi.CertificateSpec, fldPath *field.Path)
 	return el
 }
 
+// validateDNSNames ensures that internationalized DNS names can be converted
+// to the A-labels which will be requested, and are not ambiguous. Wildcards
+// are only allowed as the whole of the leftmost label, so that names such as
+// "*.*.example.com", which no CA will issue, are rejected.
+func validateDNSNames(a *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
+	if len(a.DNSNames) == 0 {
+		return nil
+	}
+	el := field.ErrorList{}
+	for i, d := range a.DNSNames {
+		if err := pki.ValidateWildcardDNSName(d); err != nil {
+			el = append(el, field.Invalid(fldPath.Child("dnsNames").Index(i), d, fmt.Sprintf("invalid wildcard DNS name: %s", err)))
+			continue
+		}
+		if _, err := pki.DNSNameToASCII(d); err != nil {
+			el = append(el, field.Invalid(fldPath.Child("dnsNames").Index(i), d, fmt.Sprintf("invalid internationalized DNS name: %s", err)))
+		}
+	}
+	return el
+}
+
+// dnsNamesWarnings warns about DNS names which are requested more than once,
+// ignoring case, trailing dots and the form of internationalized names, and
+// about DNS names which are already covered by a wildcard DNS name in the same
+// list. Duplicate names are removed by the webhook before validation, so the
+// former only applies if the Certificate was not mutated.
+func dnsNamesWarnings(crt *internalcmapi.CertificateSpec, fldPath *field.Path) []string {
+	if len(crt.DNSNames) < 2 {
+		return nil
+	}
+	var warnings []string
+	seen := make(map[string]int, len(crt.DNSNames))
+	for i, d := range crt.DNSNames {
+		normalized := pki.NormalizeDNSName(d)
+		if j, ok := seen[normalized]; ok {
+			warnings = append(warnings, fmt.Sprintf("%s: %q is the same DNS name as %s and will only be requested once", fldPath.Child("dnsNames").Index(i), d, fldPath.Child("dnsNames").Index(j)))
+			continue
+		}
+		seen[normalized] = i
+	}
+	// Names with an invalid wildcard are already rejected by
+	// validateDNSNames, so they neither cover nor are covered by other names.
+	valid := make([]string, 0, len(crt.DNSNames))
+	for _, d := range crt.DNSNames {
+		if pki.ValidateWildcardDNSName(d) == nil {
+			valid = append(valid, d)
+		}
+	}
+	covered := pki.DNSNamesCoveredByWildcard(valid)
+	for i, d := range crt.DNSNames {
+		if wildcard, ok := covered[d]; ok {
+			warnings = append(warnings, fmt.Sprintf("%s: %q is already covered by the wildcard DNS name %q", fldPath.Child("dnsNames").Index(i), d, wildcard))
+		}
+	}
+	return warnings
+}
+
 func validateEmailAddresses(a *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
 	if len(a.EmailAddresses) == 0 {
 		return nil
@@ -315,6 +458,38 @@ func validateSecretTemplateAnnotations(crt *internalcmapi.CertificateSpec, fldPa
 	return el
 }
 
+// validateCertificateRequestTemplate validates the annotations and labels to
+// be set on CertificateRequests. Keys in the cert-manager.io domain are
+// reserved for cert-manager and may not be set.
+func validateCertificateRequestTemplate(tmpl *internalcmapi.CertificateRequestTemplate, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	annotationsPath := fldPath.Child("annotations")
+	for a := range tmpl.Annotations {
+		if isCertManagerKey(a) {
+			el = append(el, field.Invalid(annotationsPath, a, "cert-manager.io/* annotations are not allowed"))
+		}
+	}
+	el = append(el, apivalidation.ValidateAnnotations(tmpl.Annotations, annotationsPath)...)
+
+	labelsPath := fldPath.Child("labels")
+	for l := range tmpl.Labels {
+		if isCertManagerKey(l) {
+			el = append(el, field.Invalid(labelsPath, l, "cert-manager.io/* labels are not allowed"))
+		}
+	}
+	el = append(el, metavalidation.ValidateLabels(tmpl.Labels, labelsPath)...)
+
+	return el
+}
+
+// isCertManagerKey returns true if the annotation or label key is in the
+// cert-manager.io domain, or one of its subdomains.
+func isCertManagerKey(key string) bool {
+	prefix, _, found := strings.Cut(key, "/")
+	return found && (prefix == "cert-manager.io" || strings.HasSuffix(prefix, ".cert-manager.io"))
+}
+
 func ValidateDuration(crt *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
 	el := field.ErrorList{}
 
@@ -333,6 +508,171 @@ func ValidateDuration(crt *internalcmapi.CertificateSpec, fldPath *field.Path) f
 	return el
 }
 
+// validateRolloutDelay validates that the rollout delay is not negative, and
+// is shorter than the duration of the certificate.
+func validateRolloutDelay(crt *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
+	var el field.ErrorList
+
+	delay := crt.RolloutDelay.Duration
+	if delay < 0 {
+		el = append(el, field.Invalid(fldPath, delay, "must not be negative"))
+	}
+	if duration := util.DefaultCertDuration(crt.Duration); delay >= duration {
+		el = append(el, field.Invalid(fldPath, delay, fmt.Sprintf("must be less than the certificate duration %s", duration)))
+	}
+	return el
+}
+
+// validateChainExpiryExcludedFingerprints validates that the excluded
+// fingerprints are only set along with renewBeforeChainExpiry, and that each
+// of them is a hex encoded SHA-256 fingerprint.
+func validateChainExpiryExcludedFingerprints(crt *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
+	var el field.ErrorList
+
+	if !crt.RenewBeforeChainExpiry {
+		el = append(el, field.Forbidden(fldPath, "may only be set if renewBeforeChainExpiry is true"))
+	}
+	for i, fingerprint := range crt.ChainExpiryExcludedFingerprints {
+		if decoded, err := hex.DecodeString(fingerprint); err != nil || len(decoded) != sha256.Size {
+			el = append(el, field.Invalid(fldPath.Index(i), fingerprint, "must be a hex encoded SHA-256 fingerprint"))
+		}
+	}
+	return el
+}
+
+// validateExternalCSR validates the externalCSR field. Options that require
+// cert-manager to hold the private key cannot be used with an external CSR.
+func validateExternalCSR(crt *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
+	var el field.ErrorList
+
+	if crt.ExternalCSR.SecretRef.Name == "" {
+		el = append(el, field.Required(fldPath.Child("externalCSR", "secretRef", "name"), "must be specified"))
+	}
+	if crt.Keystores != nil {
+		el = append(el, field.Forbidden(fldPath.Child("keystores"), "keystores cannot be created when externalCSR is set as the private key is not available"))
+	}
+	if len(crt.AdditionalOutputFormats) > 0 {
+		el = append(el, field.Forbidden(fldPath.Child("additionalOutputFormats"), "additional output formats cannot be created when externalCSR is set as the private key is not available"))
+	}
+
+	return el
+}
+
+// validateExternalPrivateKey validates the privateKey.external field. A
+// private key held by a KMS never leaves it, so options that require the raw
+// private key cannot be used.
+func validateExternalPrivateKey(crt *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
+	var el field.ErrorList
+
+	externalPath := fldPath.Child("privateKey", "external")
+	if !utilfeature.DefaultFeatureGate.Enabled(feature.ExternalPrivateKeys) {
+		return append(el, field.Forbidden(externalPath, "feature gate ExternalPrivateKeys must be enabled on both webhook and controller to use the alpha `privateKey.external` field"))
+	}
+
+	if crt.PrivateKey.External.KeyRing == "" {
+		el = append(el, field.Required(externalPath.Child("keyRing"), "must be specified"))
+	}
+	if crt.ExternalCSR != nil {
+		el = append(el, field.Forbidden(externalPath, "cannot be set together with externalCSR"))
+	}
+	if crt.Keystores != nil {
+		el = append(el, field.Forbidden(fldPath.Child("keystores"), "keystores cannot be created when privateKey.external is set as the private key is not available"))
+	}
+	if len(crt.AdditionalOutputFormats) > 0 {
+		el = append(el, field.Forbidden(fldPath.Child("additionalOutputFormats"), "additional output formats cannot be created when privateKey.external is set as the private key is not available"))
+	}
+
+	return el
+}
+
+// validateKeystoresSecretName validates the keystores.secretName field. The
+// keystores Secret must be distinct from the Secret holding the certificate.
+func validateKeystoresSecretName(crt *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
+	var el field.ErrorList
+
+	name := crt.Keystores.SecretName
+	for _, msg := range apivalidation.NameIsDNSSubdomain(name, false) {
+		el = append(el, field.Invalid(fldPath, name, msg))
+	}
+	if name == crt.SecretName {
+		el = append(el, field.Invalid(fldPath, name, "must be different from spec.secretName"))
+	}
+
+	return el
+}
+
+// validateSecretCAPolicy validates the secretCAPolicy field. A bundle
+// reference must be given if, and only if, the bundle is taken from it.
+func validateSecretCAPolicy(policy *internalcmapi.CertificateSecretCAPolicy, fldPath *field.Path) field.ErrorList {
+	var el field.ErrorList
+
+	switch policy.Type {
+	case internalcmapi.CertificateSecretCAPolicyIssuerProvided, internalcmapi.CertificateSecretCAPolicyOmit:
+		if policy.BundleRef != nil {
+			el = append(el, field.Forbidden(fldPath.Child("bundleRef"), fmt.Sprintf("bundleRef may only be set when type is %s", internalcmapi.CertificateSecretCAPolicyFromSecretRef)))
+		}
+	case internalcmapi.CertificateSecretCAPolicyFromSecretRef:
+		if policy.BundleRef == nil {
+			el = append(el, field.Required(fldPath.Child("bundleRef"), fmt.Sprintf("must be specified when type is %s", internalcmapi.CertificateSecretCAPolicyFromSecretRef)))
+			break
+		}
+		refPath := fldPath.Child("bundleRef")
+		switch policy.BundleRef.Kind {
+		case "ConfigMap", "Secret":
+		default:
+			el = append(el, field.NotSupported(refPath.Child("kind"), policy.BundleRef.Kind, []string{"ConfigMap", "Secret"}))
+		}
+		if policy.BundleRef.Name == "" {
+			el = append(el, field.Required(refPath.Child("name"), "must be specified"))
+		}
+		if policy.BundleRef.Key == "" {
+			el = append(el, field.Required(refPath.Child("key"), "must be specified"))
+		}
+	default:
+		el = append(el, field.NotSupported(fldPath.Child("type"), policy.Type, []string{
+			string(internalcmapi.CertificateSecretCAPolicyIssuerProvided),
+			string(internalcmapi.CertificateSecretCAPolicyOmit),
+			string(internalcmapi.CertificateSecretCAPolicyFromSecretRef),
+		}))
+	}
+
+	return el
+}
+
+// validateIssuanceWindow validates that the time zone of the issuance window
+// can be loaded, and that its ranges are non-empty daily time ranges.
+func validateIssuanceWindow(window *internalcmapi.CertificateIssuanceWindow, fldPath *field.Path) field.ErrorList {
+	var el field.ErrorList
+
+	if window.TimeZone != "" {
+		// "Local" is accepted by time.LoadLocation, but would depend on the
+		// time zone of the controller.
+		if _, err := time.LoadLocation(window.TimeZone); err != nil || window.TimeZone == "Local" {
+			el = append(el, field.Invalid(fldPath.Child("timeZone"), window.TimeZone, "must be an IANA time zone name"))
+		}
+	}
+
+	if len(window.Ranges) == 0 {
+		el = append(el, field.Required(fldPath.Child("ranges"), "at least one range must be specified"))
+	}
+	for i, r := range window.Ranges {
+		rangePath := fldPath.Child("ranges").Index(i)
+		start, startErr := time.Parse("15:04", r.Start)
+		if startErr != nil {
+			el = append(el, field.Invalid(rangePath.Child("start"), r.Start, "must be a time of day in the HH:MM format"))
+		}
+		end, endErr := time.Parse("15:04", r.End)
+		if endErr != nil {
+			el = append(el, field.Invalid(rangePath.Child("end"), r.End, "must be a time of day in the HH:MM format"))
+		}
+		if startErr == nil && endErr == nil && start.Equal(end) {
+			el = append(el, field.Invalid(rangePath.Child("end"), r.End, "must differ from start"))
+		}
+	}
+
+	return el
+}
+
 func validateAdditionalOutputFormats(crt *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
 	var el field.ErrorList
 
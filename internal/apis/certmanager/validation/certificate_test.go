@@ -34,6 +34,7 @@ import (
 	"github.com/cert-manager/cert-manager/internal/webhook/feature"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
 var (
@@ -59,6 +60,8 @@ func TestValidateCertificate(t *testing.T) {
 		errs                          []*field.Error
 		warnings                      []string
 		nameConstraintsFeatureEnabled bool
+
+		externalPrivateKeysFeatureEnabled bool
 	}{
 		"valid basic certificate": {
 			cfg: &internalcmapi.Certificate{
@@ -588,6 +591,85 @@ func TestValidateCertificate(t *testing.T) {
 				field.Invalid(fldPath.Child("emailAddresses").Index(0), "mailto:alice@example.com", "invalid email address: mail: expected comma"),
 			},
 		},
+		"valid certificate with internationalized dnsNames": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					DNSNames:   []string{"münchen.example.com", "*.XN--BCHER-KVA.example.com", "example.com."},
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"invalid certificate with ambiguous internationalized dnsName": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					DNSNames:   []string{"example.com", "faß.de"},
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("dnsNames").Index(1), "faß.de", `invalid internationalized DNS name: "faß.de" is ambiguous as it is converted to "xn--fa-hia.de" by IDNA2008 but to "fass.de" by IDNA2003`),
+			},
+		},
+		"invalid certificate with multi-label and partial wildcard dnsNames": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					DNSNames:   []string{"*.example.com", "*.*.example.com", "www.*.example.com", "w*.example.com", "*"},
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("dnsNames").Index(1), "*.*.example.com", "invalid wildcard DNS name: a wildcard is only allowed in the leftmost label"),
+				field.Invalid(fldPath.Child("dnsNames").Index(2), "www.*.example.com", "invalid wildcard DNS name: a wildcard is only allowed in the leftmost label"),
+				field.Invalid(fldPath.Child("dnsNames").Index(3), "w*.example.com", "invalid wildcard DNS name: a wildcard must be the whole of the leftmost label"),
+				field.Invalid(fldPath.Child("dnsNames").Index(4), "*", "invalid wildcard DNS name: a wildcard must be followed by at least one label"),
+			},
+		},
+		"valid certificate with dnsNames differing only in case, trailing dot or IDNA form": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					DNSNames:   []string{"example.com", "Example.COM.", "bücher.example.com", "XN--BCHER-KVA.example.com"},
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+			warnings: []string{
+				`spec.dnsNames[1]: "Example.COM." is the same DNS name as spec.dnsNames[0] and will only be requested once`,
+				`spec.dnsNames[3]: "XN--BCHER-KVA.example.com" is the same DNS name as spec.dnsNames[2] and will only be requested once`,
+			},
+		},
+		"valid certificate with dnsNames covered by a wildcard": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					DNSNames:   []string{"www.example.com", "*.example.com", "example.com", "a.b.example.com", "API.Example.com", "*.b.example.com"},
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+			warnings: []string{
+				`spec.dnsNames[0]: "www.example.com" is already covered by the wildcard DNS name "*.example.com"`,
+				`spec.dnsNames[3]: "a.b.example.com" is already covered by the wildcard DNS name "*.b.example.com"`,
+				`spec.dnsNames[4]: "API.Example.com" is already covered by the wildcard DNS name "*.example.com"`,
+			},
+		},
+		"valid certificate with a commonName equal to a wildcard dnsName": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "*.example.com",
+					DNSNames:   []string{"*.example.com", "example.com"},
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+		},
 		"valid certificate with revision history limit == 1": {
 			cfg: &internalcmapi.Certificate{
 				Spec: internalcmapi.CertificateSpec{
@@ -706,6 +788,50 @@ func TestValidateCertificate(t *testing.T) {
 						"alphanumeric character (e.g. 'MyValue',  or 'my_value',  or '12345', regex used for validation is '(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])?')"),
 			},
 		},
+		"valid with 'CertificateRequestTemplate' labels and annotations": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					CertificateRequestTemplate: &internalcmapi.CertificateRequestTemplate{
+						Annotations: map[string]string{
+							"my-ca.example.com/profile": "server",
+						},
+						Labels: map[string]string{
+							"my-label.com/foo": "env-production",
+						},
+					},
+					IssuerRef: validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"invalid with reserved 'CertificateRequestTemplate' annotations and labels": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					CertificateRequestTemplate: &internalcmapi.CertificateRequestTemplate{
+						Annotations: map[string]string{
+							"app.com/valid":                    "valid",
+							"not-cert-manager.io/valid":        "valid",
+							"cert-manager.io/certificate-name": "other-cert",
+							"acme.cert-manager.io/order-url":   "https://example.com",
+						},
+						Labels: map[string]string{
+							"cert-manager.io/revision": "1",
+						},
+					},
+					IssuerRef: validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("certificateRequestTemplate", "annotations"), "cert-manager.io/certificate-name", "cert-manager.io/* annotations are not allowed"),
+				field.Invalid(fldPath.Child("certificateRequestTemplate", "annotations"), "acme.cert-manager.io/order-url", "cert-manager.io/* annotations are not allowed"),
+				field.Invalid(fldPath.Child("certificateRequestTemplate", "labels"), "cert-manager.io/revision", "cert-manager.io/* labels are not allowed"),
+			},
+		},
 		"valid with name constraints": {
 			cfg: &internalcmapi.Certificate{
 				Spec: internalcmapi.CertificateSpec{
@@ -760,10 +886,427 @@ func TestValidateCertificate(t *testing.T) {
 					fldPath.Child("nameConstraints"), "feature gate NameConstraints must be enabled"),
 			},
 		},
+		"valid with externalCSR": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					ExternalCSR: &internalcmapi.CertificateExternalCSR{
+						SecretRef: cmmeta.SecretKeySelector{
+							LocalObjectReference: cmmeta.LocalObjectReference{Name: "csr"},
+						},
+					},
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"invalid externalCSR without a secret name": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName:  "testcn",
+					SecretName:  "abc",
+					IssuerRef:   validIssuerRef,
+					ExternalCSR: &internalcmapi.CertificateExternalCSR{},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Required(fldPath.Child("externalCSR", "secretRef", "name"), "must be specified"),
+			},
+		},
+		"invalid externalCSR with keystores and additional output formats": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					ExternalCSR: &internalcmapi.CertificateExternalCSR{
+						SecretRef: cmmeta.SecretKeySelector{
+							LocalObjectReference: cmmeta.LocalObjectReference{Name: "csr"},
+						},
+					},
+					Keystores: &internalcmapi.CertificateKeystores{},
+					AdditionalOutputFormats: []internalcmapi.CertificateAdditionalOutputFormat{
+						{Type: internalcmapi.CertificateOutputFormatDER},
+					},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("keystores"), "keystores cannot be created when externalCSR is set as the private key is not available"),
+				field.Forbidden(fldPath.Child("additionalOutputFormats"), "additional output formats cannot be created when externalCSR is set as the private key is not available"),
+			},
+		},
+		"valid with an external private key": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					PrivateKey: &internalcmapi.CertificatePrivateKey{
+						External: &internalcmapi.CertificateExternalPrivateKey{KeyRing: "projects/p/locations/l/keyRings/r"},
+					},
+				},
+			},
+			a:                                 someAdmissionRequest,
+			externalPrivateKeysFeatureEnabled: true,
+		},
+		"invalid external private key with feature gate disabled": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					PrivateKey: &internalcmapi.CertificatePrivateKey{
+						External: &internalcmapi.CertificateExternalPrivateKey{KeyRing: "projects/p/locations/l/keyRings/r"},
+					},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("privateKey", "external"), "feature gate ExternalPrivateKeys must be enabled on both webhook and controller to use the alpha `privateKey.external` field"),
+			},
+		},
+		"invalid external private key with keystores and additional output formats": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					PrivateKey: &internalcmapi.CertificatePrivateKey{
+						External: &internalcmapi.CertificateExternalPrivateKey{},
+					},
+					Keystores: &internalcmapi.CertificateKeystores{},
+					AdditionalOutputFormats: []internalcmapi.CertificateAdditionalOutputFormat{
+						{Type: internalcmapi.CertificateOutputFormatCombinedPEM},
+					},
+				},
+			},
+			a:                                 someAdmissionRequest,
+			externalPrivateKeysFeatureEnabled: true,
+			errs: []*field.Error{
+				field.Required(fldPath.Child("privateKey", "external", "keyRing"), "must be specified"),
+				field.Forbidden(fldPath.Child("keystores"), "keystores cannot be created when privateKey.external is set as the private key is not available"),
+				field.Forbidden(fldPath.Child("additionalOutputFormats"), "additional output formats cannot be created when privateKey.external is set as the private key is not available"),
+			},
+		},
+		"invalid external private key with externalCSR": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					PrivateKey: &internalcmapi.CertificatePrivateKey{
+						External: &internalcmapi.CertificateExternalPrivateKey{KeyRing: "projects/p/locations/l/keyRings/r"},
+					},
+					ExternalCSR: &internalcmapi.CertificateExternalCSR{
+						SecretRef: cmmeta.SecretKeySelector{
+							LocalObjectReference: cmmeta.LocalObjectReference{Name: "csr"},
+						},
+					},
+				},
+			},
+			a:                                 someAdmissionRequest,
+			externalPrivateKeysFeatureEnabled: true,
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("privateKey", "external"), "cannot be set together with externalCSR"),
+			},
+		},
+		"valid with secretCAPolicy Omit": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					SecretCAPolicy: &internalcmapi.CertificateSecretCAPolicy{
+						Type: internalcmapi.CertificateSecretCAPolicyOmit,
+					},
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"valid with secretCAPolicy FromSecretRef": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					SecretCAPolicy: &internalcmapi.CertificateSecretCAPolicy{
+						Type:      internalcmapi.CertificateSecretCAPolicyFromSecretRef,
+						BundleRef: &internalcmapi.CertificateCABundleReference{Kind: "ConfigMap", Name: "bundle", Key: "ca.crt"},
+					},
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"invalid secretCAPolicy FromSecretRef without a bundleRef": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					SecretCAPolicy: &internalcmapi.CertificateSecretCAPolicy{
+						Type: internalcmapi.CertificateSecretCAPolicyFromSecretRef,
+					},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Required(fldPath.Child("secretCAPolicy", "bundleRef"), "must be specified when type is FromSecretRef"),
+			},
+		},
+		"invalid secretCAPolicy FromSecretRef with an incomplete bundleRef": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					SecretCAPolicy: &internalcmapi.CertificateSecretCAPolicy{
+						Type:      internalcmapi.CertificateSecretCAPolicyFromSecretRef,
+						BundleRef: &internalcmapi.CertificateCABundleReference{Kind: "Issuer"},
+					},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.NotSupported(fldPath.Child("secretCAPolicy", "bundleRef", "kind"), "Issuer", []string{"ConfigMap", "Secret"}),
+				field.Required(fldPath.Child("secretCAPolicy", "bundleRef", "name"), "must be specified"),
+				field.Required(fldPath.Child("secretCAPolicy", "bundleRef", "key"), "must be specified"),
+			},
+		},
+		"invalid secretCAPolicy Omit with a bundleRef": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					SecretCAPolicy: &internalcmapi.CertificateSecretCAPolicy{
+						Type:      internalcmapi.CertificateSecretCAPolicyOmit,
+						BundleRef: &internalcmapi.CertificateCABundleReference{Kind: "Secret", Name: "bundle", Key: "ca.crt"},
+					},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("secretCAPolicy", "bundleRef"), "bundleRef may only be set when type is FromSecretRef"),
+			},
+		},
+		"invalid secretCAPolicy type": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					SecretCAPolicy: &internalcmapi.CertificateSecretCAPolicy{
+						Type: "Pinned",
+					},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.NotSupported(fldPath.Child("secretCAPolicy", "type"), internalcmapi.CertificateSecretCAPolicyType("Pinned"), []string{"IssuerProvided", "Omit", "FromSecretRef"}),
+			},
+		},
+		"valid with keystores.secretName": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					Keystores: &internalcmapi.CertificateKeystores{
+						SecretName: "abc-keystores",
+					},
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"invalid keystores.secretName equal to secretName": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					Keystores: &internalcmapi.CertificateKeystores{
+						SecretName: "abc",
+					},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("keystores", "secretName"), "abc", "must be different from spec.secretName"),
+			},
+		},
+		"valid issuanceWindow": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					IssuanceWindow: &internalcmapi.CertificateIssuanceWindow{
+						TimeZone: "Europe/London",
+						Ranges: []internalcmapi.CertificateIssuanceWindowRange{
+							{Start: "01:00", End: "05:00"},
+							{Start: "22:30", End: "00:30"},
+						},
+					},
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"invalid issuanceWindow": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					IssuanceWindow: &internalcmapi.CertificateIssuanceWindow{
+						TimeZone: "Europe/Nowhere",
+						Ranges: []internalcmapi.CertificateIssuanceWindowRange{
+							{Start: "1am", End: "24:00"},
+							{Start: "12:00", End: "12:00"},
+						},
+					},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("issuanceWindow", "timeZone"), "Europe/Nowhere", "must be an IANA time zone name"),
+				field.Invalid(fldPath.Child("issuanceWindow", "ranges").Index(0).Child("start"), "1am", "must be a time of day in the HH:MM format"),
+				field.Invalid(fldPath.Child("issuanceWindow", "ranges").Index(0).Child("end"), "24:00", "must be a time of day in the HH:MM format"),
+				field.Invalid(fldPath.Child("issuanceWindow", "ranges").Index(1).Child("end"), "12:00", "must differ from start"),
+			},
+		},
+		"issuanceWindow without ranges": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName:     "testcn",
+					SecretName:     "abc",
+					IssuerRef:      validIssuerRef,
+					IssuanceWindow: &internalcmapi.CertificateIssuanceWindow{},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Required(fldPath.Child("issuanceWindow", "ranges"), "at least one range must be specified"),
+			},
+		},
+		"valid rolloutDelay": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName:   "testcn",
+					SecretName:   "abc",
+					IssuerRef:    validIssuerRef,
+					RolloutDelay: &metav1.Duration{Duration: time.Hour},
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"negative rolloutDelay": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName:   "testcn",
+					SecretName:   "abc",
+					IssuerRef:    validIssuerRef,
+					RolloutDelay: &metav1.Duration{Duration: -time.Minute},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("rolloutDelay"), -time.Minute, "must not be negative"),
+			},
+		},
+		"rolloutDelay longer than the certificate duration": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName:   "testcn",
+					SecretName:   "abc",
+					IssuerRef:    validIssuerRef,
+					Duration:     &metav1.Duration{Duration: 24 * time.Hour},
+					RolloutDelay: &metav1.Duration{Duration: 24 * time.Hour},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("rolloutDelay"), 24*time.Hour, "must be less than the certificate duration 24h0m0s"),
+			},
+		},
+		"valid chainExpiryExcludedFingerprints": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName:                      "testcn",
+					SecretName:                      "abc",
+					IssuerRef:                       validIssuerRef,
+					RenewBeforeChainExpiry:          true,
+					ChainExpiryExcludedFingerprints: []string{strings.Repeat("ab", 32), strings.Repeat("CD", 32)},
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"chainExpiryExcludedFingerprints without renewBeforeChainExpiry": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName:                      "testcn",
+					SecretName:                      "abc",
+					IssuerRef:                       validIssuerRef,
+					ChainExpiryExcludedFingerprints: []string{strings.Repeat("ab", 32)},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("chainExpiryExcludedFingerprints"), "may only be set if renewBeforeChainExpiry is true"),
+			},
+		},
+		"invalid chainExpiryExcludedFingerprints": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName:                      "testcn",
+					SecretName:                      "abc",
+					IssuerRef:                       validIssuerRef,
+					RenewBeforeChainExpiry:          true,
+					ChainExpiryExcludedFingerprints: []string{"AB:CD", strings.Repeat("ab", 20)},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("chainExpiryExcludedFingerprints").Index(0), "AB:CD", "must be a hex encoded SHA-256 fingerprint"),
+				field.Invalid(fldPath.Child("chainExpiryExcludedFingerprints").Index(1), strings.Repeat("ab", 20), "must be a hex encoded SHA-256 fingerprint"),
+			},
+		},
+		"valid policyOIDs": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					PolicyOIDs: []string{"2.23.140.1.2.1", "1.3.6.1.4.1.11129.2.5.3"},
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"malformed policyOIDs": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					PolicyOIDs: []string{"2.23.140.1.2.1", "", "not.an.oid", "1.02.3", "3.1", "1"},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("policyOIDs").Index(1), "", "oid syntax invalid"),
+				field.Invalid(fldPath.Child("policyOIDs").Index(2), "not.an.oid", "oid syntax invalid"),
+				field.Invalid(fldPath.Child("policyOIDs").Index(3), "1.02.3", "oid syntax invalid"),
+				field.Invalid(fldPath.Child("policyOIDs").Index(4), "3.1", "oid syntax invalid"),
+				field.Invalid(fldPath.Child("policyOIDs").Index(5), "1", "oid syntax invalid"),
+			},
+		},
 	}
 	for n, s := range scenarios {
 		t.Run(n, func(t *testing.T) {
 			defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, feature.NameConstraints, s.nameConstraintsFeatureEnabled)()
+			defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, feature.ExternalPrivateKeys, s.externalPrivateKeysFeatureEnabled)()
 			errs, warnings := ValidateCertificate(s.a, s.cfg)
 			assert.ElementsMatch(t, errs, s.errs)
 			assert.ElementsMatch(t, warnings, s.warnings)
@@ -774,6 +1317,7 @@ func TestValidateCertificate(t *testing.T) {
 func TestValidateDuration(t *testing.T) {
 	usefulDurations := map[string]*metav1.Duration{
 		"one second":  {Duration: time.Second},
+		"one minute":  {Duration: time.Minute},
 		"ten minutes": {Duration: time.Minute * 10},
 		"half hour":   {Duration: time.Minute * 30},
 		"one hour":    {Duration: time.Hour},
@@ -862,7 +1406,17 @@ func TestValidateDuration(t *testing.T) {
 			},
 			errs: []*field.Error{field.Invalid(fldPath.Child("renewBefore"), usefulDurations["one second"].Duration, fmt.Sprintf("certificate renewBefore must be greater than %s", cmapi.MinimumRenewBefore))},
 		},
-		"duration is less than the minimum permitted value": {
+		"short-lived certificate with default renewBefore": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					Duration:   usefulDurations["ten minutes"],
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+				},
+			},
+		},
+		"short-lived certificate with renewBefore": {
 			cfg: &internalcmapi.Certificate{
 				Spec: internalcmapi.CertificateSpec{
 					Duration:    usefulDurations["half hour"],
@@ -872,7 +1426,17 @@ func TestValidateDuration(t *testing.T) {
 					IssuerRef:   validIssuerRef,
 				},
 			},
-			errs: []*field.Error{field.Invalid(fldPath.Child("duration"), usefulDurations["half hour"].Duration, fmt.Sprintf("certificate duration must be greater than %s", cmapi.MinimumCertificateDuration))},
+		},
+		"duration is less than the minimum permitted value": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					Duration:   usefulDurations["one minute"],
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+				},
+			},
+			errs: []*field.Error{field.Invalid(fldPath.Child("duration"), usefulDurations["one minute"].Duration, fmt.Sprintf("certificate duration must be greater than %s", cmapi.MinimumCertificateDuration))},
 		},
 	}
 	for n, s := range scenarios {
@@ -1131,3 +1695,86 @@ func Test_validateLiteralSubject(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateCertificateECDSAKeySize(t *testing.T) {
+	fldPath := field.NewPath("spec")
+	for _, size := range append([]int{0}, pki.SupportedECDSAKeySizes()...) {
+		t.Run(fmt.Sprintf("supported size %d", size), func(t *testing.T) {
+			spec := &internalcmapi.CertificateSpec{
+				CommonName: "testcn",
+				SecretName: "abc",
+				IssuerRef:  validIssuerRef,
+				PrivateKey: &internalcmapi.CertificatePrivateKey{Algorithm: internalcmapi.ECDSAKeyAlgorithm, Size: size},
+			}
+			assert.Empty(t, ValidateCertificateSpec(spec, fldPath))
+		})
+	}
+
+	spec := &internalcmapi.CertificateSpec{
+		CommonName: "testcn",
+		SecretName: "abc",
+		IssuerRef:  validIssuerRef,
+		PrivateKey: &internalcmapi.CertificatePrivateKey{Algorithm: internalcmapi.ECDSAKeyAlgorithm, Size: 512},
+	}
+	assert.Equal(t, field.ErrorList{
+		field.NotSupported(fldPath.Child("privateKey", "size"), 512, []string{"256", "384", "521"}),
+	}, ValidateCertificateSpec(spec, fldPath))
+}
+
+func TestValidateCertificateSANCount(t *testing.T) {
+	fldPath := field.NewPath("spec")
+	specWithSANs := func(n int) *internalcmapi.CertificateSpec {
+		spec := &internalcmapi.CertificateSpec{
+			SecretName: "abc",
+			IssuerRef:  validIssuerRef,
+			// IP addresses are counted towards the subject alternative names.
+			IPAddresses: []string{"10.0.0.1"},
+		}
+		for i := 1; i < n; i++ {
+			spec.DNSNames = append(spec.DNSNames, fmt.Sprintf("test-%03d.example.com", i))
+		}
+		return spec
+	}
+
+	tests := map[string]struct {
+		spec             *internalcmapi.CertificateSpec
+		warningThreshold int
+		maxSANs          int
+
+		errs     field.ErrorList
+		warnings []string
+	}{
+		"no warning or error below the warning threshold": {
+			spec:             specWithSANs(50),
+			warningThreshold: 50,
+			maxSANs:          100,
+		},
+		"warning above the warning threshold": {
+			spec:             specWithSANs(51),
+			warningThreshold: 50,
+			maxSANs:          100,
+			warnings:         []string{"spec requests 51 subject alternative names, which may be slow to issue or be rejected by the issuer"},
+		},
+		"error above the maximum": {
+			spec:             specWithSANs(150),
+			warningThreshold: 50,
+			maxSANs:          100,
+			errs: field.ErrorList{
+				field.Forbidden(fldPath, "requests 150 subject alternative names, but at most 100 are allowed"),
+			},
+		},
+		"no warning or error if the checks are disabled": {
+			spec:             specWithSANs(150),
+			warningThreshold: 0,
+			maxSANs:          0,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			errs, warnings := ValidateCertificateSANCount(test.spec, fldPath, test.warningThreshold, test.maxSANs)
+			assert.ElementsMatch(t, test.errs, errs)
+			assert.ElementsMatch(t, test.warnings, warnings)
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 
 	admissionv1 "k8s.io/api/admission/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	cmapi "github.com/cert-manager/cert-manager/internal/apis/certmanager"
@@ -38,10 +39,32 @@ func ValidateCertificateRequest(a *admissionv1.AdmissionRequest, obj runtime.Obj
 	allErrs := ValidateCertificateRequestSpec(&cr.Spec, field.NewPath("spec"))
 	allErrs = append(allErrs,
 		ValidateCertificateRequestApprovalCondition(cr.Status.Conditions, field.NewPath("status", "conditions"))...)
+	allErrs = append(allErrs, validateCertificateRequestDeliverToSecret(cr, field.NewPath("metadata", "annotations"))...)
 
 	return allErrs, nil
 }
 
+// validateCertificateRequestDeliverToSecret validates the name of the Secret
+// that a CertificateRequest's signed certificate is delivered to. Requests
+// created for a Certificate may not set it, as the Certificate's Secret is
+// managed by the Certificate controllers.
+func validateCertificateRequestDeliverToSecret(cr *cmapi.CertificateRequest, fldPath *field.Path) field.ErrorList {
+	secretName, ok := cr.Annotations[cmapiv1.CertificateRequestDeliverToSecretAnnotationKey]
+	if !ok {
+		return nil
+	}
+
+	var el field.ErrorList
+	fldPath = fldPath.Key(cmapiv1.CertificateRequestDeliverToSecretAnnotationKey)
+	if _, ok := cr.Annotations[cmapiv1.CertificateRequestRevisionAnnotationKey]; ok {
+		el = append(el, field.Forbidden(fldPath, "cannot be set on CertificateRequests created for a Certificate"))
+	}
+	for _, msg := range validation.IsDNS1123Subdomain(secretName) {
+		el = append(el, field.Invalid(fldPath, secretName, msg))
+	}
+	return el
+}
+
 func ValidateUpdateCertificateRequest(a *admissionv1.AdmissionRequest, oldObj, newObj runtime.Object) (field.ErrorList, []string) {
 	oldCR, newCR := oldObj.(*cmapi.CertificateRequest), newObj.(*cmapi.CertificateRequest)
 
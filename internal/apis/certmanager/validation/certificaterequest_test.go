@@ -857,6 +857,57 @@ func TestValidateCertificateRequest(t *testing.T) {
 				field.Forbidden(fldPathConditions, `multiple "Denied" conditions present`),
 			},
 		},
+		"Test deliver-to-secret annotation with a valid Secret name": {
+			cr: &cminternal.CertificateRequest{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						cmapi.CertificateRequestDeliverToSecretAnnotationKey: "my-tls",
+					},
+				},
+				Spec: cminternal.CertificateRequestSpec{
+					Request:   mustGenerateCSR(t, gen.Certificate("test", gen.SetCertificateDNSNames("example.com"))),
+					IssuerRef: validIssuerRef,
+				},
+			},
+			a:     someAdmissionRequest,
+			wantE: []*field.Error{},
+		},
+		"Error on deliver-to-secret annotation with an invalid Secret name": {
+			cr: &cminternal.CertificateRequest{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						cmapi.CertificateRequestDeliverToSecretAnnotationKey: "My_TLS",
+					},
+				},
+				Spec: cminternal.CertificateRequestSpec{
+					Request:   mustGenerateCSR(t, gen.Certificate("test", gen.SetCertificateDNSNames("example.com"))),
+					IssuerRef: validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+			wantE: []*field.Error{
+				field.Invalid(field.NewPath("metadata", "annotations").Key(cmapi.CertificateRequestDeliverToSecretAnnotationKey), nil,
+					"a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')"),
+			},
+		},
+		"Error on deliver-to-secret annotation on a CertificateRequest created for a Certificate": {
+			cr: &cminternal.CertificateRequest{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						cmapi.CertificateRequestDeliverToSecretAnnotationKey: "my-tls",
+						cmapi.CertificateRequestRevisionAnnotationKey:        "1",
+					},
+				},
+				Spec: cminternal.CertificateRequestSpec{
+					Request:   mustGenerateCSR(t, gen.Certificate("test", gen.SetCertificateDNSNames("example.com"))),
+					IssuerRef: validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+			wantE: []*field.Error{
+				field.Forbidden(field.NewPath("metadata", "annotations").Key(cmapi.CertificateRequestDeliverToSecretAnnotationKey), "cannot be set on CertificateRequests created for a Certificate"),
+			},
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
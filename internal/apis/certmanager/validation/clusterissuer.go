@@ -33,7 +33,7 @@ func ValidateClusterIssuer(a *admissionv1.AdmissionRequest, obj runtime.Object)
 }
 
 func ValidateUpdateClusterIssuer(a *admissionv1.AdmissionRequest, oldObj, obj runtime.Object) (field.ErrorList, []string) {
-	iss := obj.(*cmapi.ClusterIssuer)
-	allErrs, warnings := ValidateIssuerSpec(&iss.Spec, field.NewPath("spec"))
+	oldIss, iss := oldObj.(*cmapi.ClusterIssuer), obj.(*cmapi.ClusterIssuer)
+	allErrs, warnings := ValidateUpdateIssuerSpec(&oldIss.Spec, &iss.Spec, field.NewPath("spec"))
 	return allErrs, warnings
 }
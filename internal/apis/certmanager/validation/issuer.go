@@ -19,18 +19,25 @@ package validation
 import (
 	"crypto/x509"
 	"fmt"
+	"net/url"
+	"path"
 	"strings"
+	"time"
 
 	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
 
 	cmacme "github.com/cert-manager/cert-manager/internal/apis/acme"
 	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
 	"github.com/cert-manager/cert-manager/internal/apis/certmanager/validation/util"
 	cmmeta "github.com/cert-manager/cert-manager/internal/apis/meta"
+	"github.com/cert-manager/cert-manager/internal/tlsclient"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
 // Validation functions for cert-manager Issuer types.
@@ -42,14 +49,106 @@ func ValidateIssuer(a *admissionv1.AdmissionRequest, obj runtime.Object) (field.
 }
 
 func ValidateUpdateIssuer(a *admissionv1.AdmissionRequest, oldObj, obj runtime.Object) (field.ErrorList, []string) {
-	iss := obj.(*certmanager.Issuer)
-	allErrs, warnings := ValidateIssuerSpec(&iss.Spec, field.NewPath("spec"))
+	oldIss, iss := oldObj.(*certmanager.Issuer), obj.(*certmanager.Issuer)
+	allErrs, warnings := ValidateUpdateIssuerSpec(&oldIss.Spec, &iss.Spec, field.NewPath("spec"))
 	// Admission request should never be nil
 	return allErrs, warnings
 }
 
+// MaxReadinessGateTimeout is the maximum timeout of the requests to the
+// readiness gates of an issuer.
+const MaxReadinessGateTimeout = 30 * time.Second
+
 func ValidateIssuerSpec(iss *certmanager.IssuerSpec, fldPath *field.Path) (field.ErrorList, []string) {
-	return ValidateIssuerConfig(&iss.IssuerConfig, fldPath)
+	el, warnings := ValidateIssuerConfig(&iss.IssuerConfig, fldPath)
+	el = append(el, validateReadinessGates(iss.ReadinessGates, fldPath.Child("readinessGates"))...)
+	return el, warnings
+}
+
+// ValidateUpdateIssuerSpec is ValidateIssuerSpec for updates. HTTP01 ingress
+// solvers setting more than one of class, ingressClassName and name were
+// accepted before, so an issuer which already has such a solver may still be
+// updated, including its status, as long as none of those fields changes, in
+// which case a warning is returned instead of an error.
+func ValidateUpdateIssuerSpec(oldIss, iss *certmanager.IssuerSpec, fldPath *field.Path) (field.ErrorList, []string) {
+	el, warnings := ValidateIssuerSpec(iss, fldPath)
+	if oldIss.ACME == nil || iss.ACME == nil {
+		return el, warnings
+	}
+
+	unchanged := sets.New[string]()
+	for i, sol := range iss.ACME.Solvers {
+		if sol.HTTP01 == nil || sol.HTTP01.Ingress == nil {
+			continue
+		}
+		for _, oldSol := range oldIss.ACME.Solvers {
+			if oldSol.HTTP01 != nil && oldSol.HTTP01.Ingress != nil && sameIngressRouting(oldSol.HTTP01.Ingress, sol.HTTP01.Ingress) {
+				unchanged.Insert(fldPath.Child("acme", "solvers").Index(i).Child("http01", "ingress").String())
+				break
+			}
+		}
+	}
+
+	allowed := field.ErrorList{}
+	for _, err := range el {
+		if err.Type == field.ErrorTypeForbidden && err.Detail == ingressRoutingExclusiveDetail && unchanged.Has(err.Field) {
+			warnings = append(warnings, fmt.Sprintf("%s: %s", err.Field, err.Detail))
+			continue
+		}
+		allowed = append(allowed, err)
+	}
+
+	return allowed, warnings
+}
+
+// sameIngressRouting returns true if both HTTP01 ingress solvers route the
+// challenge requests in the same way.
+func sameIngressRouting(a, b *cmacme.ACMEChallengeSolverHTTP01Ingress) bool {
+	return ptr.Equal(a.Class, b.Class) && ptr.Equal(a.IngressClassName, b.IngressClassName) && a.Name == b.Name
+}
+
+// validateReadinessGates validates that the readiness gates have unique names
+// and HTTPS URLs, and that their timeouts and CA bundles are valid.
+// Timeouts are capped, as the gates are called by the readiness controller
+// while it processes Certificates.
+func validateReadinessGates(gates []certmanager.CertificateReadinessGate, fldPath *field.Path) field.ErrorList {
+	var el field.ErrorList
+
+	names := sets.New[string]()
+	for i, gate := range gates {
+		gatePath := fldPath.Index(i)
+
+		switch {
+		case gate.Name == "":
+			el = append(el, field.Required(gatePath.Child("name"), "must be specified"))
+		case names.Has(gate.Name):
+			el = append(el, field.Duplicate(gatePath.Child("name"), gate.Name))
+		}
+		names.Insert(gate.Name)
+
+		if gate.URL == "" {
+			el = append(el, field.Required(gatePath.Child("url"), "must be specified"))
+		} else if u, err := url.ParseRequestURI(gate.URL); err != nil || u.Scheme != "https" || u.Host == "" {
+			el = append(el, field.Invalid(gatePath.Child("url"), gate.URL, "must be a valid HTTPS URL"))
+		}
+
+		if len(gate.CABundle) > 0 {
+			if err := validateCABundleNotEmpty(gate.CABundle); err != nil {
+				el = append(el, field.Invalid(gatePath.Child("caBundle"), "<snip>", err.Error()))
+			}
+		}
+
+		if gate.Timeout != nil {
+			switch {
+			case gate.Timeout.Duration <= 0:
+				el = append(el, field.Invalid(gatePath.Child("timeout"), gate.Timeout.Duration, "must be greater than zero"))
+			case gate.Timeout.Duration > MaxReadinessGateTimeout:
+				el = append(el, field.Invalid(gatePath.Child("timeout"), gate.Timeout.Duration, fmt.Sprintf("must not be more than %s", MaxReadinessGateTimeout)))
+			}
+		}
+	}
+
+	return el
 }
 
 func ValidateIssuerConfig(iss *certmanager.IssuerConfig, fldPath *field.Path) (field.ErrorList, []string) {
@@ -97,6 +196,13 @@ func ValidateIssuerConfig(iss *certmanager.IssuerConfig, fldPath *field.Path) (f
 			el = append(el, ValidateVenafiIssuerConfig(iss.Venafi, fldPath.Child("venafi"))...)
 		}
 	}
+	if iss.Manual != nil {
+		if numConfigs > 0 {
+			el = append(el, field.Forbidden(fldPath.Child("manual"), "may not specify more than one issuer type"))
+		} else {
+			numConfigs++
+		}
+	}
 	if numConfigs == 0 {
 		el = append(el, field.Required(fldPath, "at least one issuer must be configured"))
 	}
@@ -120,6 +226,18 @@ func ValidateACMEIssuerConfig(iss *cmacme.ACMEIssuer, fldPath *field.Path) (fiel
 		}
 	}
 
+	if iss.CABundleSecretRef != nil {
+		if iss.SkipTLSVerify {
+			el = append(el, field.Invalid(fldPath.Child("caBundleSecretRef"), iss.CABundleSecretRef.Name, "caBundleSecretRef and skipTLSVerify are mutually exclusive and cannot both be set"))
+			el = append(el, field.Invalid(fldPath.Child("skipTLSVerify"), iss.SkipTLSVerify, "caBundleSecretRef and skipTLSVerify are mutually exclusive and cannot both be set"))
+		}
+		if len(iss.CABundleSecretRef.Name) == 0 {
+			el = append(el, field.Required(fldPath.Child("caBundleSecretRef", "name"), "secret name is required"))
+		}
+	}
+
+	el = append(el, validateClientTLSConfig(iss.TLS, fldPath.Child("tls"))...)
+
 	if len(iss.PrivateKey.Name) == 0 {
 		el = append(el, field.Required(fldPath.Child("privateKeySecretRef", "name"), "private key secret name is a required field"))
 	}
@@ -128,6 +246,20 @@ func ValidateACMEIssuerConfig(iss *cmacme.ACMEIssuer, fldPath *field.Path) (fiel
 		el = append(el, field.Required(fldPath.Child("server"), "acme server URL is a required field"))
 	}
 
+	if iss.DirectoryMaxAge != nil && iss.DirectoryMaxAge.Duration <= 0 {
+		el = append(el, field.Invalid(fldPath.Child("directoryMaxAge"), iss.DirectoryMaxAge.Duration, "must be greater than zero"))
+	}
+
+	for i, zone := range iss.AllowedZones {
+		if _, err := path.Match(zone, ""); err != nil || zone == "" {
+			el = append(el, field.Invalid(fldPath.Child("allowedZones").Index(i), zone, "must be a DNS zone, optionally containing '*' wildcards"))
+		}
+	}
+
+	if iss.RequireEmbeddedSCTs < 0 {
+		el = append(el, field.Invalid(fldPath.Child("requireEmbeddedSCTs"), iss.RequireEmbeddedSCTs, "must not be negative"))
+	}
+
 	if eab := iss.ExternalAccountBinding; eab != nil {
 		eabFldPath := fldPath.Child("externalAccountBinding")
 		if len(eab.KeyID) == 0 {
@@ -184,6 +316,10 @@ func ValidateACMEIssuerChallengeSolverHTTP01Config(http01 *cmacme.ACMEChallengeS
 		numDefined++
 		el = append(el, ValidateACMEIssuerChallengeSolverHTTP01GatewayConfig(http01.GatewayHTTPRoute, fldPath.Child("gateway"))...)
 	}
+	if http01.Custom != nil {
+		numDefined++
+		el = append(el, ValidateACMEIssuerChallengeSolverHTTP01CustomConfig(http01.Custom, fldPath.Child("custom"))...)
+	}
 	if numDefined == 0 {
 		el = append(el, field.Required(fldPath, "no HTTP01 solver type configured"))
 	}
@@ -194,11 +330,26 @@ func ValidateACMEIssuerChallengeSolverHTTP01Config(http01 *cmacme.ACMEChallengeS
 	return el
 }
 
+const ingressRoutingExclusiveDetail = "only one of 'ingressClassName', 'name' or 'class' should be specified"
+
 func ValidateACMEIssuerChallengeSolverHTTP01IngressConfig(ingress *cmacme.ACMEChallengeSolverHTTP01Ingress, fldPath *field.Path) field.ErrorList {
 	el := field.ErrorList{}
 
-	if ingress.Class != nil && ingress.IngressClassName != nil && len(ingress.Name) > 0 {
-		el = append(el, field.Forbidden(fldPath, "only one of 'ingressClassName', 'name' or 'class' should be specified"))
+	// The 'class' annotation, the 'ingressClassName' field and editing an
+	// existing Ingress by 'name' are mutually exclusive ways to route the
+	// challenge requests.
+	numDefined := 0
+	if ingress.Class != nil {
+		numDefined++
+	}
+	if ingress.IngressClassName != nil {
+		numDefined++
+	}
+	if len(ingress.Name) > 0 {
+		numDefined++
+	}
+	if numDefined > 1 {
+		el = append(el, field.Forbidden(fldPath, ingressRoutingExclusiveDetail))
 	}
 
 	// Since "class" used to be a free string, let's have a stricter validation
@@ -218,6 +369,34 @@ func ValidateACMEIssuerChallengeSolverHTTP01IngressConfig(ingress *cmacme.ACMECh
 		el = append(el, field.Invalid(fldPath.Child("serviceType"), ingress.ServiceType, `must be empty, "ClusterIP" or "NodePort"`))
 	}
 
+	if ingress.PodTemplate != nil {
+		el = append(el, ValidateACMEIssuerChallengeSolverHTTP01PodSpec(&ingress.PodTemplate.Spec, fldPath.Child("podTemplate", "spec"))...)
+	}
+
+	return el
+}
+
+func ValidateACMEIssuerChallengeSolverHTTP01PodSpec(spec *cmacme.ACMEChallengeSolverHTTP01IngressPodSpec, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	if len(spec.Image) > 0 {
+		if err := util.ValidImageReference(spec.Image); err != nil {
+			el = append(el, field.Invalid(fldPath.Child("image"), spec.Image, err.Error()))
+		}
+	}
+
+	switch spec.ImagePullPolicy {
+	case "", corev1.PullAlways, corev1.PullNever, corev1.PullIfNotPresent:
+	default:
+		el = append(el, field.NotSupported(fldPath.Child("imagePullPolicy"), spec.ImagePullPolicy, []string{string(corev1.PullAlways), string(corev1.PullNever), string(corev1.PullIfNotPresent)}))
+	}
+
+	for i, secret := range spec.ImagePullSecrets {
+		if len(secret.Name) == 0 {
+			el = append(el, field.Required(fldPath.Child("imagePullSecrets").Index(i).Child("name"), ""))
+		}
+	}
+
 	return el
 }
 
@@ -235,10 +414,35 @@ func ValidateACMEIssuerChallengeSolverHTTP01GatewayConfig(gateway *cmacme.ACMECh
 	return el
 }
 
+func ValidateACMEIssuerChallengeSolverHTTP01CustomConfig(custom *cmacme.ACMEChallengeSolverHTTP01Custom, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	if len(custom.ConfigMapName) == 0 {
+		el = append(el, field.Required(fldPath.Child("configMapName"), ""))
+	} else {
+		for _, msg := range validation.IsDNS1123Subdomain(custom.ConfigMapName) {
+			el = append(el, field.Invalid(fldPath.Child("configMapName"), custom.ConfigMapName, msg))
+		}
+	}
+	return el
+}
+
 func ValidateCAIssuerConfig(iss *certmanager.CAIssuer, fldPath *field.Path) field.ErrorList {
 	el := field.ErrorList{}
-	if len(iss.SecretName) == 0 {
+	switch {
+	case len(iss.SecretName) == 0 && iss.SecretRef == nil:
 		el = append(el, field.Required(fldPath.Child("secretName"), ""))
+	case len(iss.SecretName) > 0 && iss.SecretRef != nil:
+		el = append(el, field.Forbidden(fldPath.Child("secretRef"), "may not be set together with secretName"))
+	case iss.SecretRef != nil:
+		if len(iss.SecretRef.Name) == 0 {
+			el = append(el, field.Required(fldPath.Child("secretRef", "name"), ""))
+		}
+		if iss.SecretRef.Namespace != "" {
+			for _, msg := range validation.IsDNS1123Label(iss.SecretRef.Namespace) {
+				el = append(el, field.Invalid(fldPath.Child("secretRef", "namespace"), iss.SecretRef.Namespace, msg))
+			}
+		}
 	}
 	for i, ocspURL := range iss.OCSPServers {
 		if ocspURL == "" {
@@ -250,6 +454,42 @@ func ValidateCAIssuerConfig(iss *certmanager.CAIssuer, fldPath *field.Path) fiel
 			el = append(el, field.Invalid(fldPath.Child("issuingCertificateURLs").Index(i), issuerURL, "must be a valid URL"))
 		}
 	}
+	for i, policyOID := range iss.AllowedPolicyOIDs {
+		if _, err := pki.ParsePolicyOID(policyOID); err != nil {
+			el = append(el, field.Invalid(fldPath.Child("allowedPolicyOIDs").Index(i), policyOID, "oid syntax invalid"))
+		}
+	}
+	if iss.CRL != nil {
+		el = append(el, validateCAIssuerCRL(iss, fldPath.Child("crl"))...)
+	}
+	if iss.SpreadDuration != nil && iss.SpreadDuration.Duration < 0 {
+		el = append(el, field.Invalid(fldPath.Child("spreadDuration"), iss.SpreadDuration.Duration, "must not be negative"))
+	}
+	return el
+}
+
+func validateCAIssuerCRL(iss *certmanager.CAIssuer, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	crl := iss.CRL
+	if len(crl.SecretName) == 0 {
+		el = append(el, field.Required(fldPath.Child("secretName"), ""))
+	} else {
+		for _, msg := range validation.IsDNS1123Subdomain(crl.SecretName) {
+			el = append(el, field.Invalid(fldPath.Child("secretName"), crl.SecretName, msg))
+		}
+		// The CRL must not overwrite the signing CA keypair.
+		if crl.SecretName == iss.SecretName || (iss.SecretRef != nil && iss.SecretRef.Namespace == "" && crl.SecretName == iss.SecretRef.Name) {
+			el = append(el, field.Invalid(fldPath.Child("secretName"), crl.SecretName, "must not be the Secret containing the signing CA keypair"))
+		}
+	}
+	if len(crl.ConfigMapName) > 0 {
+		for _, msg := range validation.IsDNS1123Subdomain(crl.ConfigMapName) {
+			el = append(el, field.Invalid(fldPath.Child("configMapName"), crl.ConfigMapName, msg))
+		}
+	}
+	if crl.UpdateInterval != nil && crl.UpdateInterval.Duration < time.Minute {
+		el = append(el, field.Invalid(fldPath.Child("updateInterval"), crl.UpdateInterval.Duration, "must be at least 1m"))
+	}
 	return el
 }
 
@@ -268,6 +508,19 @@ func ValidateVaultIssuerConfig(iss *certmanager.VaultIssuer, fldPath *field.Path
 		el = append(el, field.Required(fldPath.Child("path"), ""))
 	}
 
+	switch iss.SignMode {
+	case "":
+	case certmanager.VaultSignModeRole, certmanager.VaultSignModeVerbatim:
+		// The endpoint selected by the sign mode replaces the endpoint of the
+		// role in the path.
+		segments := strings.Split(strings.Trim(iss.Path, "/"), "/")
+		if len(segments) < 3 || (segments[len(segments)-2] != "sign" && segments[len(segments)-2] != "sign-verbatim") {
+			el = append(el, field.Invalid(fldPath.Child("path"), iss.Path, fmt.Sprintf(`must be the sign or sign-verbatim endpoint of a role, such as "pki/sign/my-role", if signMode is %q`, iss.SignMode)))
+		}
+	default:
+		el = append(el, field.NotSupported(fldPath.Child("signMode"), iss.SignMode, []string{string(certmanager.VaultSignModeRole), string(certmanager.VaultSignModeVerbatim)}))
+	}
+
 	if len(iss.CABundle) > 0 {
 		if err := validateCABundleNotEmpty(iss.CABundle); err != nil {
 			el = append(el, field.Invalid(fldPath.Child("caBundle"), "<snip>", err.Error()))
@@ -287,6 +540,19 @@ func ValidateVaultIssuerConfig(iss *certmanager.VaultIssuer, fldPath *field.Path
 		el = append(el, field.Invalid(fldPath.Child("clientCertSecretRef"), "<snip>", "clientCertSecretRef must be provided when defining the clientKeySecretRef"))
 	}
 
+	el = append(el, validateClientTLSConfig(iss.TLS, fldPath.Child("tls"))...)
+
+	for _, name := range sets.List(sets.KeySet(iss.ExtraParameters)) {
+		switch name {
+		case "csr", "format":
+			el = append(el, field.Forbidden(fldPath.Child("extraParameters").Key(name), "parameter is set by cert-manager"))
+		case "issuer_ref":
+			el = append(el, field.Forbidden(fldPath.Child("extraParameters").Key(name), "use the issuerRef field instead"))
+		case "exclude_cn_from_sans":
+			el = append(el, field.Forbidden(fldPath.Child("extraParameters").Key(name), "use the excludeCNFromSANs field instead"))
+		}
+	}
+
 	el = append(el, ValidateVaultIssuerAuth(&iss.Auth, fldPath.Child("auth"))...)
 
 	return el
@@ -357,7 +623,11 @@ func ValidateVenafiTPP(tpp *certmanager.VenafiTPP, fldPath *field.Path) (el fiel
 		el = append(el, field.Required(fldPath.Child("url"), ""))
 	}
 
-	// TODO: validate CABundle using validateCABundleNotEmpty
+	if len(tpp.CABundle) > 0 {
+		if err := validateCABundleNotEmpty(tpp.CABundle); err != nil {
+			el = append(el, field.Invalid(fldPath.Child("caBundle"), "<snip>", err.Error()))
+		}
+	}
 
 	return el
 }
@@ -387,6 +657,8 @@ func ValidateVenafiIssuerConfig(iss *certmanager.VenafiIssuer, fldPath *field.Pa
 		el = append(el, field.Forbidden(fldPath, "please supply one of: tpp, cloud"))
 	}
 
+	el = append(el, validateClientTLSConfig(iss.TLS, fldPath.Child("tls"))...)
+
 	return el
 }
 
@@ -411,6 +683,12 @@ func ValidateACMEChallengeSolverDNS01(p *cmacme.ACMEChallengeSolverDNS01, fldPat
 			el = append(el, field.Invalid(fldPath.Child("cnameStrategy"), p.CNAMEStrategy, fmt.Sprintf("must be one of %q or %q", cmacme.NoneStrategy, cmacme.FollowStrategy)))
 		}
 	}
+	if p.InitialDelay != nil && p.InitialDelay.Duration < 0 {
+		el = append(el, field.Invalid(fldPath.Child("initialDelay"), p.InitialDelay.Duration, "must not be negative"))
+	}
+	if p.PropagationTimeout != nil && p.PropagationTimeout.Duration <= 0 {
+		el = append(el, field.Invalid(fldPath.Child("propagationTimeout"), p.PropagationTimeout.Duration, "must be greater than zero"))
+	}
 	numProviders := 0
 	if p.Akamai != nil {
 		numProviders++
@@ -455,6 +733,7 @@ func ValidateACMEChallengeSolverDNS01(p *cmacme.ACMEChallengeSolverDNS01, fldPat
 			if len(p.AzureDNS.ResourceGroupName) == 0 {
 				el = append(el, field.Required(fldPath.Child("azureDNS", "resourceGroupName"), ""))
 			}
+			el = append(el, validateDNS01RecordTTL(p.AzureDNS.TTL, fldPath.Child("azureDNS", "ttl"))...)
 			switch p.AzureDNS.Environment {
 			case "", cmacme.AzurePublicCloud, cmacme.AzureChinaCloud, cmacme.AzureGermanCloud, cmacme.AzureUSGovernmentCloud:
 			default:
@@ -476,6 +755,7 @@ func ValidateACMEChallengeSolverDNS01(p *cmacme.ACMEChallengeSolverDNS01, fldPat
 			if len(p.CloudDNS.Project) == 0 {
 				el = append(el, field.Required(fldPath.Child("cloudDNS", "project"), ""))
 			}
+			el = append(el, validateDNS01RecordTTL(p.CloudDNS.TTL, fldPath.Child("cloudDNS", "ttl"))...)
 		}
 	}
 	if p.Cloudflare != nil {
@@ -519,6 +799,7 @@ func ValidateACMEChallengeSolverDNS01(p *cmacme.ACMEChallengeSolverDNS01, fldPat
 			if p.Route53.SecretAccessKeyID != nil {
 				el = append(el, ValidateSecretKeySelector(p.Route53.SecretAccessKeyID, fldPath.Child("route53", "accessKeyIDSecretRef"))...)
 			}
+			el = append(el, validateDNS01RecordTTL(p.Route53.TTL, fldPath.Child("route53", "ttl"))...)
 		}
 	}
 	if p.AcmeDNS != nil {
@@ -590,6 +871,13 @@ func ValidateACMEChallengeSolverDNS01(p *cmacme.ACMEChallengeSolverDNS01, fldPat
 	return el
 }
 
+func validateDNS01RecordTTL(ttl *int64, fldPath *field.Path) field.ErrorList {
+	if ttl != nil && *ttl < 1 {
+		return field.ErrorList{field.Invalid(fldPath, *ttl, "must be greater than zero")}
+	}
+	return nil
+}
+
 func ValidateSecretKeySelector(sks *cmmeta.SecretKeySelector, fldPath *field.Path) field.ErrorList {
 	el := field.ErrorList{}
 	if sks.Name == "" {
@@ -601,6 +889,28 @@ func ValidateSecretKeySelector(sks *cmmeta.SecretKeySelector, fldPath *field.Pat
 	return el
 }
 
+// validateClientTLSConfig validates the TLS configuration used by an issuer
+// when connecting to its server.
+func validateClientTLSConfig(cfg *cmmeta.ClientTLSConfig, fldPath *field.Path) field.ErrorList {
+	if cfg == nil {
+		return nil
+	}
+
+	el := field.ErrorList{}
+
+	minVersion, err := tlsclient.ParseMinTLSVersion(cfg.MinVersion)
+	if err != nil {
+		el = append(el, field.Invalid(fldPath.Child("minVersion"), cfg.MinVersion, err.Error()))
+		return el
+	}
+
+	if _, err := tlsclient.ParseCipherSuites(cfg.CipherSuites, minVersion); err != nil {
+		el = append(el, field.Invalid(fldPath.Child("cipherSuites"), cfg.CipherSuites, err.Error()))
+	}
+
+	return el
+}
+
 // validateCABundleNotEmpty performs a soft check on the CA bundle to see if there's at least one
 // valid CA certificate inside.
 // This uses the standard library crypto/x509.CertPool.AppendCertsFromPEM function, which
@@ -19,10 +19,12 @@ package validation
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/clock"
 	"k8s.io/utils/ptr"
@@ -108,6 +110,21 @@ func TestValidateVaultIssuerConfig(t *testing.T) {
 				field.Required(fldPath.Child("auth"), "please supply one of: appRole, kubernetes, tokenSecretRef"),
 			},
 		},
+		"vault issuer with an invalid TLS configuration": {
+			spec: &cmapi.VaultIssuer{
+				Server: "https://vault.example.com",
+				Path:   "secret/path",
+				Auth: cmapi.VaultAuth{
+					TokenSecretRef: &validSecretKeyRef,
+				},
+				TLS: &cmmeta.ClientTLSConfig{
+					CipherSuites: []string{"TLS_AES_128_GCM_SHA256"},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("tls", "cipherSuites"), []string{"TLS_AES_128_GCM_SHA256"}, "invalid cipher suites: TLS_AES_128_GCM_SHA256 is a TLS 1.3 cipher suite, which is not configurable"),
+			},
+		},
 		"vault issuer with a CA bundle containing no valid certificates": {
 			spec: &cmapi.VaultIssuer{
 				Server:   "something",
@@ -169,6 +186,79 @@ func TestValidateVaultIssuerConfig(t *testing.T) {
 				field.Invalid(fldPath.Child("clientCertSecretRef"), "<snip>", "clientCertSecretRef must be provided when defining the clientKeySecretRef"),
 			},
 		},
+		"vault issuer with valid extra parameters": {
+			spec: &cmapi.VaultIssuer{
+				Server:    "https://vault.example.com",
+				Path:      "secret/path",
+				IssuerRef: "intermediate",
+				ExtraParameters: map[string]string{
+					"ttl":                     "24h",
+					"use_csr_common_name":     "false",
+					"remove_roots_from_chain": "true",
+				},
+				Auth: cmapi.VaultAuth{
+					TokenSecretRef: &validSecretKeyRef,
+				},
+			},
+		},
+		"vault issuer with a sign mode and the endpoint of a role": {
+			spec: &cmapi.VaultIssuer{
+				Server:   "https://vault.example.com",
+				Path:     "pki/sign-verbatim/my-role",
+				SignMode: cmapi.VaultSignModeRole,
+				Auth: cmapi.VaultAuth{
+					TokenSecretRef: &validSecretKeyRef,
+				},
+			},
+		},
+		"vault issuer with a sign mode and a path which is not the endpoint of a role": {
+			spec: &cmapi.VaultIssuer{
+				Server:   "https://vault.example.com",
+				Path:     "pki/root/sign-intermediate",
+				SignMode: cmapi.VaultSignModeVerbatim,
+				Auth: cmapi.VaultAuth{
+					TokenSecretRef: &validSecretKeyRef,
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("path"), "pki/root/sign-intermediate", `must be the sign or sign-verbatim endpoint of a role, such as "pki/sign/my-role", if signMode is "Verbatim"`),
+			},
+		},
+		"vault issuer with an unknown sign mode": {
+			spec: &cmapi.VaultIssuer{
+				Server:   "https://vault.example.com",
+				Path:     "pki/sign/my-role",
+				SignMode: "Strict",
+				Auth: cmapi.VaultAuth{
+					TokenSecretRef: &validSecretKeyRef,
+				},
+			},
+			errs: []*field.Error{
+				field.NotSupported(fldPath.Child("signMode"), cmapi.VaultSignMode("Strict"), []string{"Role", "Verbatim"}),
+			},
+		},
+		"vault issuer with extra parameters owned by cert-manager": {
+			spec: &cmapi.VaultIssuer{
+				Server: "https://vault.example.com",
+				Path:   "secret/path",
+				ExtraParameters: map[string]string{
+					"csr":                  "csr",
+					"exclude_cn_from_sans": "false",
+					"format":               "der",
+					"issuer_ref":           "intermediate",
+					"ttl":                  "24h",
+				},
+				Auth: cmapi.VaultAuth{
+					TokenSecretRef: &validSecretKeyRef,
+				},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("extraParameters").Key("csr"), "parameter is set by cert-manager"),
+				field.Forbidden(fldPath.Child("extraParameters").Key("exclude_cn_from_sans"), "use the excludeCNFromSANs field instead"),
+				field.Forbidden(fldPath.Child("extraParameters").Key("format"), "parameter is set by cert-manager"),
+				field.Forbidden(fldPath.Child("extraParameters").Key("issuer_ref"), "use the issuerRef field instead"),
+			},
+		},
 	}
 	for n, s := range scenarios {
 		t.Run(n, func(t *testing.T) {
@@ -409,6 +499,44 @@ func TestValidateACMEIssuerConfig(t *testing.T) {
 				field.Invalid(fldPath.Child("skipTLSVerify"), true, "caBundle and skipTLSVerify are mutually exclusive and cannot both be set"),
 			},
 		},
+		"acme issuer with both a CA bundle Secret and SkipTLSVerify": {
+			spec: &cmacme.ACMEIssuer{
+				Email:             "valid-email",
+				Server:            "valid-server",
+				CABundleSecretRef: &validSecretKeyRef,
+				SkipTLSVerify:     true,
+				PrivateKey:        validSecretKeyRef,
+				Solvers: []cmacme.ACMEChallengeSolver{
+					{
+						DNS01: &cmacme.ACMEChallengeSolverDNS01{
+							CloudDNS: &validCloudDNSProvider,
+						},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("caBundleSecretRef"), validSecretKeyRef.Name, "caBundleSecretRef and skipTLSVerify are mutually exclusive and cannot both be set"),
+				field.Invalid(fldPath.Child("skipTLSVerify"), true, "caBundleSecretRef and skipTLSVerify are mutually exclusive and cannot both be set"),
+			},
+		},
+		"acme issuer with a CA bundle Secret without a name": {
+			spec: &cmacme.ACMEIssuer{
+				Email:             "valid-email",
+				Server:            "valid-server",
+				CABundleSecretRef: &cmmeta.SecretKeySelector{Key: "ca.crt"},
+				PrivateKey:        validSecretKeyRef,
+				Solvers: []cmacme.ACMEChallengeSolver{
+					{
+						DNS01: &cmacme.ACMEChallengeSolverDNS01{
+							CloudDNS: &validCloudDNSProvider,
+						},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("caBundleSecretRef", "name"), "secret name is required"),
+			},
+		},
 		"acme solver without any config": {
 			spec: &cmacme.ACMEIssuer{
 				Email:      "valid-email",
@@ -563,6 +691,89 @@ func TestValidateACMEIssuerConfig(t *testing.T) {
 				),
 			},
 		},
+		"acme issuer with non-positive directoryMaxAge": {
+			spec: &cmacme.ACMEIssuer{
+				Email:           "valid-email",
+				Server:          "valid-server",
+				PrivateKey:      validSecretKeyRef,
+				DirectoryMaxAge: &metav1.Duration{Duration: 0},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("directoryMaxAge"), time.Duration(0), "must be greater than zero"),
+			},
+		},
+		"acme issuer with allowedZones": {
+			spec: &cmacme.ACMEIssuer{
+				Email:        "valid-email",
+				Server:       "valid-server",
+				PrivateKey:   validSecretKeyRef,
+				AllowedZones: []string{"example.com", "*.example.org", "example.*"},
+			},
+		},
+		"acme issuer with invalid allowedZones": {
+			spec: &cmacme.ACMEIssuer{
+				Email:        "valid-email",
+				Server:       "valid-server",
+				PrivateKey:   validSecretKeyRef,
+				AllowedZones: []string{"example.com", "", "[example.com"},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("allowedZones").Index(1), "", "must be a DNS zone, optionally containing '*' wildcards"),
+				field.Invalid(fldPath.Child("allowedZones").Index(2), "[example.com", "must be a DNS zone, optionally containing '*' wildcards"),
+			},
+		},
+		"acme issuer with requireEmbeddedSCTs": {
+			spec: &cmacme.ACMEIssuer{
+				Email:               "valid-email",
+				Server:              "valid-server",
+				PrivateKey:          validSecretKeyRef,
+				RequireEmbeddedSCTs: 2,
+			},
+		},
+		"acme issuer with negative requireEmbeddedSCTs": {
+			spec: &cmacme.ACMEIssuer{
+				Email:               "valid-email",
+				Server:              "valid-server",
+				PrivateKey:          validSecretKeyRef,
+				RequireEmbeddedSCTs: -1,
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("requireEmbeddedSCTs"), -1, "must not be negative"),
+			},
+		},
+		"acme solver with valid http01 custom config": {
+			spec: &cmacme.ACMEIssuer{
+				Email:      "valid-email",
+				Server:     "valid-server",
+				PrivateKey: validSecretKeyRef,
+				Solvers: []cmacme.ACMEChallengeSolver{
+					{
+						HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+							Custom: &cmacme.ACMEChallengeSolverHTTP01Custom{
+								ConfigMapName: "acme-challenges",
+							},
+						},
+					},
+				},
+			},
+		},
+		"acme solver with http01 custom config missing configMapName": {
+			spec: &cmacme.ACMEIssuer{
+				Email:      "valid-email",
+				Server:     "valid-server",
+				PrivateKey: validSecretKeyRef,
+				Solvers: []cmacme.ACMEChallengeSolver{
+					{
+						HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+							Custom: &cmacme.ACMEChallengeSolverHTTP01Custom{},
+						},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("solvers").Index(0).Child("http01", "custom", "configMapName"), ""),
+			},
+		},
 		"acme solver with multiple http01 solver configs": {
 			spec: &cmacme.ACMEIssuer{
 				Email:      "valid-email",
@@ -674,6 +885,66 @@ func TestValidateACMEIssuerConfig(t *testing.T) {
 				},
 			},
 		},
+		"acme issue with pod template image overrides": {
+			spec: &cmacme.ACMEIssuer{
+				Email:      "valid-email",
+				Server:     "valid-server",
+				PrivateKey: validSecretKeyRef,
+				Solvers: []cmacme.ACMEChallengeSolver{
+					{
+						HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+							Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+								PodTemplate: &cmacme.ACMEChallengeSolverHTTP01IngressPodTemplate{
+									Spec: cmacme.ACMEChallengeSolverHTTP01IngressPodSpec{
+										Image:            "registry.internal:5000/mirror/acmesolver:v1",
+										ImagePullPolicy:  corev1.PullAlways,
+										ImagePullSecrets: []corev1.LocalObjectReference{{Name: "mirror-credentials"}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"acme issue with invalid pod template image overrides": {
+			spec: &cmacme.ACMEIssuer{
+				Email:      "valid-email",
+				Server:     "valid-server",
+				PrivateKey: validSecretKeyRef,
+				Solvers: []cmacme.ACMEChallengeSolver{
+					{
+						HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+							Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+								PodTemplate: &cmacme.ACMEChallengeSolverHTTP01IngressPodTemplate{
+									Spec: cmacme.ACMEChallengeSolverHTTP01IngressPodSpec{
+										Image:            "https://registry.internal/acmesolver",
+										ImagePullPolicy:  "Sometimes",
+										ImagePullSecrets: []corev1.LocalObjectReference{{Name: ""}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(
+					fldPath.Child("solvers").Index(0).Child("http01", "ingress", "podTemplate", "spec", "image"),
+					"https://registry.internal/acmesolver",
+					`image reference "https://registry.internal/acmesolver" is not a valid reference, expected the form [registry/]repository[:tag][@digest]`,
+				),
+				field.NotSupported(
+					fldPath.Child("solvers").Index(0).Child("http01", "ingress", "podTemplate", "spec", "imagePullPolicy"),
+					corev1.PullPolicy("Sometimes"),
+					[]string{"Always", "Never", "IfNotPresent"},
+				),
+				field.Required(
+					fldPath.Child("solvers").Index(0).Child("http01", "ingress", "podTemplate", "spec", "imagePullSecrets").Index(0).Child("name"),
+					"",
+				),
+			},
+		},
 	}
 	for n, s := range scenarios {
 		t.Run(n, func(t *testing.T) {
@@ -719,6 +990,74 @@ func TestValidateIssuerSpec(t *testing.T) {
 			},
 			errs: []*field.Error{field.Required(fldPath.Child("ca", "secretName"), "")},
 		},
+		"valid ca issuer with a secret in another namespace": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretRef: &cmapi.CAIssuerSecretReference{Name: "valid", Namespace: "pki"},
+					},
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"ca issuer with both secret name and secret reference specified": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+						SecretRef:  &cmapi.CAIssuerSecretReference{Name: "valid", Namespace: "pki"},
+					},
+				},
+			},
+			errs: []*field.Error{field.Forbidden(fldPath.Child("ca", "secretRef"), "may not be set together with secretName")},
+		},
+		"ca issuer with an invalid secret reference": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretRef: &cmapi.CAIssuerSecretReference{Namespace: "PKI"},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("ca", "secretRef", "name"), ""),
+				field.Invalid(fldPath.Child("ca", "secretRef", "namespace"), "PKI", "a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')"),
+			},
+		},
+		"valid readinessGates": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{SecretName: "valid"},
+				},
+				ReadinessGates: []cmapi.CertificateReadinessGate{
+					{Name: "ocsp", URL: "https://ocsp-checker.internal/check"},
+					{Name: "ct", URL: "https://ct-checker.internal:8443/check", Timeout: &metav1.Duration{Duration: 30 * time.Second}},
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"invalid readinessGates": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{SecretName: "valid"},
+				},
+				ReadinessGates: []cmapi.CertificateReadinessGate{
+					{URL: "http://ocsp-checker.internal/check"},
+					{Name: "ct", CABundle: []byte("not a certificate"), Timeout: &metav1.Duration{}},
+					{Name: "ct", URL: "ct-checker.internal", Timeout: &metav1.Duration{Duration: time.Minute}},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("readinessGates").Index(0).Child("name"), "must be specified"),
+				field.Invalid(fldPath.Child("readinessGates").Index(0).Child("url"), "http://ocsp-checker.internal/check", "must be a valid HTTPS URL"),
+				field.Required(fldPath.Child("readinessGates").Index(1).Child("url"), "must be specified"),
+				field.Invalid(fldPath.Child("readinessGates").Index(1).Child("caBundle"), "<snip>", "cert bundle didn't contain any valid certificates"),
+				field.Invalid(fldPath.Child("readinessGates").Index(1).Child("timeout"), time.Duration(0), "must be greater than zero"),
+				field.Duplicate(fldPath.Child("readinessGates").Index(2).Child("name"), "ct"),
+				field.Invalid(fldPath.Child("readinessGates").Index(2).Child("url"), "ct-checker.internal", "must be a valid HTTPS URL"),
+				field.Invalid(fldPath.Child("readinessGates").Index(2).Child("timeout"), time.Minute, "must not be more than 30s"),
+			},
+		},
 		"valid self signed issuer": {
 			spec: &cmapi.IssuerSpec{
 				IssuerConfig: cmapi.IssuerConfig{
@@ -743,6 +1082,27 @@ func TestValidateIssuerSpec(t *testing.T) {
 			},
 			errs: []*field.Error{},
 		},
+		"valid manual issuer": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					Manual: &cmapi.ManualIssuer{},
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"manual issuer with another issuer type": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+					},
+					Manual: &cmapi.ManualIssuer{},
+				},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("manual"), "may not specify more than one issuer type"),
+			},
+		},
 		"missing issuer config": {
 			spec: &cmapi.IssuerSpec{
 				IssuerConfig: cmapi.IssuerConfig{},
@@ -812,6 +1172,113 @@ func TestValidateIssuerSpec(t *testing.T) {
 				field.Invalid(fldPath.Child("ca", "issuingCertificateURLs").Index(0), "", `must be a valid URL`),
 			},
 		},
+		"valid AllowedPolicyOIDs": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName:        "valid",
+						AllowedPolicyOIDs: []string{"2.23.140.1.2.1"},
+					},
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"invalid AllowedPolicyOIDs": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName:        "valid",
+						AllowedPolicyOIDs: []string{"2.23.140.1.2.1", "2.23..1"},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("ca", "allowedPolicyOIDs").Index(1), "2.23..1", "oid syntax invalid"),
+			},
+		},
+		"valid CRL": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+						CRL: &cmapi.CAIssuerCRL{
+							SecretName:     "valid-crl",
+							ConfigMapName:  "valid-crl",
+							UpdateInterval: &metav1.Duration{Duration: time.Hour},
+						},
+					},
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"CRL without a Secret name": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+						CRL:        &cmapi.CAIssuerCRL{},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("ca", "crl", "secretName"), ""),
+			},
+		},
+		"CRL stored in the signing CA Secret": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretRef: &cmapi.CAIssuerSecretReference{Name: "valid"},
+						CRL:       &cmapi.CAIssuerCRL{SecretName: "valid"},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("ca", "crl", "secretName"), "valid", "must not be the Secret containing the signing CA keypair"),
+			},
+		},
+		"CRL with a too short update interval": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+						CRL: &cmapi.CAIssuerCRL{
+							SecretName:     "valid-crl",
+							UpdateInterval: &metav1.Duration{Duration: time.Second},
+						},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("ca", "crl", "updateInterval"), time.Second, "must be at least 1m"),
+			},
+		},
+		"CA rotation re-issuance spread over a duration": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName:          "valid",
+						ReissueOnCARotation: true,
+						SpreadDuration:      &metav1.Duration{Duration: time.Hour * 24 * 7},
+					},
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"CA rotation re-issuance with a negative spread duration": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName:          "valid",
+						ReissueOnCARotation: true,
+						SpreadDuration:      &metav1.Duration{Duration: -time.Hour},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("ca", "spreadDuration"), -time.Hour, "must not be negative"),
+			},
+		},
 	}
 	for n, s := range scenarios {
 		t.Run(n, func(t *testing.T) {
@@ -868,6 +1335,39 @@ func TestValidateACMEIssuerHTTP01Config(t *testing.T) {
 				field.Forbidden(fldPath.Child("ingress"), "only one of 'ingressClassName', 'name' or 'class' should be specified"),
 			},
 		},
+		"class and ingressClassName specified": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+					Class:            ptr.To("abc"),
+					IngressClassName: ptr.To("abc"),
+				},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("ingress"), "only one of 'ingressClassName', 'name' or 'class' should be specified"),
+			},
+		},
+		"class and name specified": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+					Name:  "abc",
+					Class: ptr.To("abc"),
+				},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("ingress"), "only one of 'ingressClassName', 'name' or 'class' should be specified"),
+			},
+		},
+		"ingressClassName and name specified": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+					Name:             "abc",
+					IngressClassName: ptr.To("abc"),
+				},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("ingress"), "only one of 'ingressClassName', 'name' or 'class' should be specified"),
+			},
+		},
 		"ingressClassName is invalid": {
 			cfg: &cmacme.ACMEChallengeSolverHTTP01{
 				Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
@@ -927,6 +1427,59 @@ func TestValidateACMEIssuerHTTP01Config(t *testing.T) {
 	}
 }
 
+func TestValidateUpdateIssuerSpecHTTP01Ingress(t *testing.T) {
+	fldPath := field.NewPath("spec")
+	ingressPath := fldPath.Child("acme", "solvers").Index(0).Child("http01", "ingress")
+	detail := "only one of 'ingressClassName', 'name' or 'class' should be specified"
+
+	spec := func(ingress cmacme.ACMEChallengeSolverHTTP01Ingress) *cmapi.IssuerSpec {
+		return &cmapi.IssuerSpec{
+			IssuerConfig: cmapi.IssuerConfig{
+				ACME: &cmacme.ACMEIssuer{
+					Server:     "https://acme.example.com/directory",
+					PrivateKey: validSecretKeyRef,
+					Solvers: []cmacme.ACMEChallengeSolver{
+						{HTTP01: &cmacme.ACMEChallengeSolverHTTP01{Ingress: &ingress}},
+					},
+				},
+			},
+		}
+	}
+
+	scenarios := map[string]struct {
+		old, new *cmapi.IssuerSpec
+		errs     []*field.Error
+		warnings []string
+	}{
+		"unchanged class and ingressClassName only warns": {
+			old:      spec(cmacme.ACMEChallengeSolverHTTP01Ingress{Class: ptr.To("nginx"), IngressClassName: ptr.To("nginx")}),
+			new:      spec(cmacme.ACMEChallengeSolverHTTP01Ingress{Class: ptr.To("nginx"), IngressClassName: ptr.To("nginx"), ServiceType: corev1.ServiceTypeNodePort}),
+			warnings: []string{ingressPath.String() + ": " + detail},
+		},
+		"changed class is rejected": {
+			old:  spec(cmacme.ACMEChallengeSolverHTTP01Ingress{Class: ptr.To("nginx"), IngressClassName: ptr.To("nginx")}),
+			new:  spec(cmacme.ACMEChallengeSolverHTTP01Ingress{Class: ptr.To("traefik"), IngressClassName: ptr.To("nginx")}),
+			errs: []*field.Error{field.Forbidden(ingressPath, detail)},
+		},
+		"name added to a valid solver is rejected": {
+			old:  spec(cmacme.ACMEChallengeSolverHTTP01Ingress{Class: ptr.To("nginx")}),
+			new:  spec(cmacme.ACMEChallengeSolverHTTP01Ingress{Class: ptr.To("nginx"), Name: "web"}),
+			errs: []*field.Error{field.Forbidden(ingressPath, detail)},
+		},
+		"valid solver is accepted": {
+			old: spec(cmacme.ACMEChallengeSolverHTTP01Ingress{Class: ptr.To("nginx"), Name: "web"}),
+			new: spec(cmacme.ACMEChallengeSolverHTTP01Ingress{IngressClassName: ptr.To("nginx")}),
+		},
+	}
+	for n, s := range scenarios {
+		t.Run(n, func(t *testing.T) {
+			errs, warnings := ValidateUpdateIssuerSpec(s.old, s.new, fldPath)
+			assert.ElementsMatch(t, s.errs, errs)
+			assert.ElementsMatch(t, s.warnings, warnings)
+		})
+	}
+}
+
 func TestValidateACMEIssuerDNS01Config(t *testing.T) {
 	fldPath := field.NewPath("test")
 	scenarios := map[string]struct {
@@ -978,6 +1531,61 @@ func TestValidateACMEIssuerDNS01Config(t *testing.T) {
 				},
 			},
 		},
+		"invalid clouddns ttl": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				CloudDNS: &cmacme.ACMEIssuerDNS01ProviderCloudDNS{
+					Project: "valid",
+					TTL:     ptr.To(int64(0)),
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("cloudDNS", "ttl"), int64(0), "must be greater than zero"),
+			},
+		},
+		"valid route53 ttl": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				Route53: &cmacme.ACMEIssuerDNS01ProviderRoute53{
+					Region: "valid",
+					TTL:    ptr.To(int64(300)),
+				},
+			},
+		},
+		"invalid route53 ttl": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				Route53: &cmacme.ACMEIssuerDNS01ProviderRoute53{
+					Region: "valid",
+					TTL:    ptr.To(int64(-1)),
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("route53", "ttl"), int64(-1), "must be greater than zero"),
+			},
+		},
+		"valid initialDelay and propagationTimeout": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				InitialDelay:       &metav1.Duration{Duration: 5 * time.Minute},
+				PropagationTimeout: &metav1.Duration{Duration: time.Hour},
+				CloudDNS:           &validCloudDNSProvider,
+			},
+		},
+		"negative initialDelay": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				InitialDelay: &metav1.Duration{Duration: -time.Second},
+				CloudDNS:     &validCloudDNSProvider,
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("initialDelay"), -time.Second, "must not be negative"),
+			},
+		},
+		"zero propagationTimeout": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				PropagationTimeout: &metav1.Duration{},
+				CloudDNS:           &validCloudDNSProvider,
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("propagationTimeout"), time.Duration(0), "must be greater than zero"),
+			},
+		},
 		"missing cloudflare api key fields": {
 			cfg: &cmacme.ACMEChallengeSolverDNS01{
 				Cloudflare: &cmacme.ACMEIssuerDNS01ProviderCloudflare{
@@ -1615,6 +2223,45 @@ func TestValidateVenafiIssuerConfig(t *testing.T) {
 				field.Required(fldPath, "please supply one of: tpp, cloud"),
 			},
 		},
+		"valid TLS configuration": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone: "a\\b\\c",
+				TPP: &cmapi.VenafiTPP{
+					URL: "https://tpp.example.com/vedsdk",
+				},
+				TLS: &cmmeta.ClientTLSConfig{
+					MinVersion:   "VersionTLS12",
+					CipherSuites: []string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"},
+				},
+			},
+		},
+		"unknown minimum TLS version": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone: "a\\b\\c",
+				TPP: &cmapi.VenafiTPP{
+					URL: "https://tpp.example.com/vedsdk",
+				},
+				TLS: &cmmeta.ClientTLSConfig{
+					MinVersion: "VersionTLS14",
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("tls", "minVersion"), "VersionTLS14", `invalid minimum TLS version: unknown tls version "VersionTLS14"`),
+			},
+		},
+		"cipher suites with a minimum TLS version of 1.3": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone:  "a\\b\\c",
+				Cloud: &cmapi.VenafiCloud{},
+				TLS: &cmmeta.ClientTLSConfig{
+					MinVersion:   "VersionTLS13",
+					CipherSuites: []string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("tls", "cipherSuites"), []string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"}, "cipher suites cannot be configured when the minimum TLS version is VersionTLS13, as TLS 1.3 cipher suites are not configurable"),
+			},
+		},
 		"multiple configuration": {
 			cfg: &cmapi.VenafiIssuer{
 				Zone: "a\\b\\c",
@@ -1662,6 +2309,15 @@ func TestValidateVenafiTPP(t *testing.T) {
 				field.Required(fldPath.Child("url"), ""),
 			},
 		},
+		"CA bundle containing no valid certificates": {
+			cfg: &cmapi.VenafiTPP{
+				URL:      "https://tpp.example.com/vedsdk",
+				CABundle: []byte("invalid"),
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("caBundle"), "<snip>", "cert bundle didn't contain any valid certificates"),
+			},
+		},
 	}
 
 	for n, s := range scenarios {
@@ -0,0 +1,62 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// maxImageNameLength is the maximum length of the name of an image reference,
+// excluding its tag and digest.
+const maxImageNameLength = 255
+
+var (
+	// The grammar of image references, as defined by the distribution
+	// project: https://github.com/distribution/reference/blob/main/reference.go
+	imageDomainComponent = `(?:[a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9])`
+	imageDomain          = imageDomainComponent + `(?:\.` + imageDomainComponent + `)*(?::[0-9]+)?`
+	imagePathComponent   = `[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*`
+	imageName            = `(?:` + imageDomain + `/)?` + imagePathComponent + `(?:/` + imagePathComponent + `)*`
+	imageTag             = `[\w][\w.-]{0,127}`
+	imageDigest          = `[A-Za-z][A-Za-z0-9]*(?:[-_+.][A-Za-z][A-Za-z0-9]*)*:[0-9a-fA-F]{32,}`
+
+	imageReferenceRegexp = regexp.MustCompile(`^(` + imageName + `)(?::` + imageTag + `)?(?:@` + imageDigest + `)?$`)
+)
+
+// ValidImageReference validates that the given string is a well formed
+// container image reference, such as "registry.example.com:5000/acmesolver:v1"
+// or "acmesolver@sha256:<digest>".
+func ValidImageReference(image string) error {
+	if image == "" {
+		return fmt.Errorf("image reference must not be empty")
+	}
+	if strings.TrimSpace(image) != image {
+		return fmt.Errorf("image reference %q must not contain leading or trailing whitespace", image)
+	}
+
+	matches := imageReferenceRegexp.FindStringSubmatch(image)
+	if matches == nil {
+		return fmt.Errorf("image reference %q is not a valid reference, expected the form [registry/]repository[:tag][@digest]", image)
+	}
+	if len(matches[1]) > maxImageNameLength {
+		return fmt.Errorf("image reference %q has a name longer than %d characters", image, maxImageNameLength)
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidImageReference(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("a", 64)
+
+	tests := []struct {
+		name    string
+		image   string
+		wantErr bool
+	}{
+		{name: "repository only", image: "acmesolver"},
+		{name: "repository with tag", image: "cert-manager-acmesolver:v1.15.0"},
+		{name: "repository with path", image: "jetstack/cert-manager-acmesolver"},
+		{name: "registry with repository and tag", image: "quay.io/jetstack/cert-manager-acmesolver:v1.15.0"},
+		{name: "registry with port", image: "registry.internal:5000/mirror/acmesolver:v1"},
+		{name: "registry as a single component with port", image: "localhost:5000/acmesolver"},
+		{name: "repository with digest", image: "acmesolver@" + digest},
+		{name: "repository with tag and digest", image: "quay.io/jetstack/acmesolver:v1@" + digest},
+		{name: "empty", image: "", wantErr: true},
+		{name: "leading whitespace", image: " acmesolver", wantErr: true},
+		{name: "uppercase repository", image: "registry.internal/ACMESolver", wantErr: true},
+		{name: "empty tag", image: "acmesolver:", wantErr: true},
+		{name: "invalid tag", image: "acmesolver:-v1", wantErr: true},
+		{name: "scheme", image: "https://registry.internal/acmesolver", wantErr: true},
+		{name: "short digest", image: "acmesolver@sha256:abc", wantErr: true},
+		{name: "double slash", image: "registry.internal//acmesolver", wantErr: true},
+		{name: "name too long", image: "registry.internal/" + strings.Repeat("a", 250), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidImageReference(tt.image)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidImageReference(%q) error = %v, wantErr %v", tt.image, err, tt.wantErr)
+			}
+		})
+	}
+}
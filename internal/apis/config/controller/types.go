@@ -43,6 +43,30 @@ type ControllerConfiguration struct {
 	// The maximum burst queries-per-second of requests sent to the Kubernetes apiserver
 	KubernetesAPIBurst int
 
+	// The maximum number of objects fetched by each LIST call the informers
+	// make to fill their caches, using the limit and continue parameters. This
+	// reduces the load on the Kubernetes apiserver on startup in clusters with
+	// many Secrets or CertificateRequests. A value of 0 lists all the objects
+	// of a type at once.
+	InformerListChunkSize int
+
+	// The maximum amount of time given to the informers of each informer
+	// factory to sync their caches on startup before the informers of the next
+	// factory are started. If 0, all informers are started at once.
+	InformerSyncBudget time.Duration
+
+	// The maximum amount of time a controller may spend processing a single
+	// item, such as while waiting for a response from an issuer or a DNS
+	// provider, after which the item is re-queued. If 0, no timeout is
+	// applied.
+	SyncTimeout time.Duration
+
+	// The maximum amount of time a controller with items in its workqueue
+	// may go without successfully processing one before it is reported as
+	// not ready on the /readyz endpoint. If 0, controllers are always
+	// reported as ready once their informers have synced.
+	ProgressDeadline time.Duration
+
 	// If set, this limits the scope of cert-manager to a single namespace and
 	// ClusterIssuers are disabled. If not specified, all namespaces will be
 	// watched"
@@ -94,9 +118,124 @@ type ControllerConfiguration struct {
 	// ones where the key is prefixed with 'kubectl.kubernetes.io/'.
 	CopiedAnnotationPrefixes []string
 
+	// The encoding of the CSR in spec.request of the CertificateRequests
+	// created for Certificates, keyed by the group of the referenced issuer,
+	// for external issuers which cannot parse the default encoding. Supported
+	// encodings are 'PEM' (the default), 'LegacyPEM', which uses the 'NEW
+	// CERTIFICATE REQUEST' PEM block type, and 'DER', which stores the DER
+	// encoded CSR without PEM armor. Example: 'example.com=LegacyPEM'.
+	CertificateRequestEncodings map[string]string
+
+	// The maximum amount of time a CertificateRequest may go without any status
+	// progress before the issuance attempt is failed and retried. The timeout
+	// can be overridden per Certificate using the
+	// 'cert-manager.io/issuance-timeout' annotation. A value of 0 disables the
+	// timeout.
+	IssuanceTimeout time.Duration
+
+	// The maximum amount of time by which an issued certificate's NotBefore
+	// may be in the future when it is stored in the Certificate's Secret.
+	// Certificates which are not yet valid beyond this tolerance are only
+	// stored once they are. A value of 0 means that certificates are only
+	// stored once their NotBefore has passed.
+	ClockSkewTolerance time.Duration
+
+	// The maximum amount of time the controller waits for an issued
+	// certificate to become valid, beyond the clock skew tolerance, before
+	// storing it. The issuance of certificates which are not valid until
+	// later fails. A value of 0 fails the issuance of any certificate which
+	// is not yet valid beyond the clock skew tolerance.
+	MaxNotBeforeWait time.Duration
+
+	// Whether certificates returned by issuers are verified before they are
+	// stored in the Certificate's Secret. The certificate chain must parse,
+	// the leaf certificate must include the requested subject alternative
+	// names and must not have expired. Certificates which fail verification
+	// fail the CertificateRequest with the IssuedCertificateInvalid reason and
+	// leave the existing Secret untouched. Only disable this for issuers which
+	// return certificates that cannot pass these checks.
+	VerifyIssuedCertificates bool
+
+	// Whether the certificate chain returned by issuers is also verified
+	// against the CA certificate which is stored in the Secret's ca.crt, if
+	// any. Only used if issued certificates are verified.
+	VerifyIssuedCertificateChain bool
+
+	// Whether the issuer annotations of Secrets written by older versions of
+	// cert-manager are rewritten into their current form, so that the
+	// Certificates are not re-issued only because of the annotation format.
+	// Secrets are updated at a limited rate and their data is not changed.
+	MigrateSecretAnnotations bool
+
+	// The maximum number of Certificates for which an Event is recorded when
+	// they fail to be issued by the same issuer with the same reason within
+	// IssuanceFailureEventWindow. Failures of further Certificates are only
+	// recorded in their conditions, and summarised by an Event on the issuer
+	// at most once per window. A value of 0 records an Event for every
+	// failure.
+	MaxIssuanceFailureEvents int
+
+	// The window over which Certificates failing to be issued by the same
+	// issuer with the same reason are counted, see MaxIssuanceFailureEvents.
+	IssuanceFailureEventWindow time.Duration
+
+	// The number of the most recent issuance attempts recorded in the
+	// issuance history of each Certificate. A value of 0 disables recording
+	// the issuance history.
+	IssuanceHistoryLimit int
+
+	// The maximum size in bytes of the data written to a Certificate's
+	// Secret. Issuance fails with the reason SecretTooLarge rather than
+	// writing a larger Secret, which the API server would reject. A value of
+	// 0 disables the check.
+	MaxSecretSize int
+
+	// The private key algorithm used for Certificates which do not set
+	// spec.privateKey.algorithm. One of RSA, ECDSA or Ed25519. If empty, RSA
+	// is used.
+	DefaultPrivateKeyAlgorithm string
+
+	// The private key size used for Certificates which do not set
+	// spec.privateKey.algorithm. Only valid if DefaultPrivateKeyAlgorithm is
+	// set. If 0, the default size for the algorithm is used.
+	DefaultPrivateKeySize int
+
+	// The private key rotation policy used for Certificates which do not set
+	// spec.privateKey.rotationPolicy. One of Never or Always. If empty, Never
+	// is used.
+	DefaultPrivateKeyRotationPolicy string
+
+	// The address of the signer plugin used to create and use private keys
+	// for Certificates which set spec.privateKey.external, such as
+	// unix:///run/kms/signer.sock. Requires the ExternalPrivateKeys feature
+	// gate to be enabled.
+	ExternalPrivateKeySignerAddress string
+
+	// The interval at which the ACME account of each ACME issuer is verified
+	// with the ACME server, so that accounts which have been deactivated or
+	// revoked are reported on the issuer. A value of 0 only verifies the
+	// account when the issuer is registered or changed.
+	ACMEAccountVerificationInterval time.Duration
+
 	// The number of concurrent workers for each controller.
 	NumberOfConcurrentWorkers int
 
+	// The number of concurrent workers for each of the certificates-*
+	// controllers. If 0, NumberOfConcurrentWorkers is used.
+	ConcurrentCertificateSyncs int
+
+	// The number of concurrent workers for each of the certificaterequests-*
+	// controllers. If 0, NumberOfConcurrentWorkers is used.
+	ConcurrentCertificateRequestSyncs int
+
+	// The number of concurrent workers for the orders controller. If 0,
+	// NumberOfConcurrentWorkers is used.
+	ConcurrentOrderSyncs int
+
+	// The number of concurrent workers for the challenges controller. If 0,
+	// NumberOfConcurrentWorkers is used.
+	ConcurrentChallengeSyncs int
+
 	// The maximum number of challenges that can be scheduled as 'processing' at once.
 	MaxConcurrentChallenges int
 
@@ -133,6 +272,10 @@ type ControllerConfiguration struct {
 
 	// ACMEDNS01Config configures the behaviour of the ACME DNS01 challenge solver
 	ACMEDNS01Config ACMEDNS01Config
+
+	// OutboundTLSConfig configures the TLS settings of the connections made by
+	// the controller to ACME servers, Vault, Venafi and DNS providers.
+	OutboundTLSConfig OutboundTLSConfig
 }
 
 type LeaderElectionConfig struct {
@@ -188,6 +331,12 @@ type ACMEHTTP01Config struct {
 	// issues
 	SolverRunAsNonRoot bool
 
+	// Whether the Challenges of an Order are solved by a single solver pod
+	// serving each Challenge's key authorization from a ConfigMap, rather
+	// than by one solver pod per Challenge. The pod is deleted once all of
+	// its Challenges have been cleaned up.
+	SolverSharedPods bool
+
 	// A list of comma separated dns server endpoints used for
 	// ACME HTTP01 check requests. This should be a list containing host and
 	// port, for example ["8.8.8.8:53","8.8.4.4:53"]
@@ -218,4 +367,30 @@ type ACMEDNS01Config struct {
 	// token is served at the challenge URL. This should be a valid duration
 	// string, for example 180s or 1h
 	CheckRetryPeriod time.Duration
+
+	// The interval at which the DNS providers of ACME issuers are swept for
+	// DNS01 challenge TXT records which were never cleaned up, e.g. because
+	// the controller was restarted between presenting and cleaning up a
+	// challenge. Only DNS providers which can list their records are swept,
+	// and only the records presented by cert-manager while the sweeper is
+	// enabled are deleted.
+	// A value of 0 disables the sweeper.
+	OrphanedRecordSweepInterval time.Duration
+
+	// The minimum amount of time for which a DNS01 challenge TXT record must
+	// have been found without a corresponding Challenge before it is deleted
+	// by the sweeper.
+	OrphanedRecordMinAge time.Duration
+}
+
+type OutboundTLSConfig struct {
+	// Minimum TLS version used for outbound connections, e.g. VersionTLS12.
+	// Values are from tls package constants (https://golang.org/pkg/crypto/tls/#pkg-constants).
+	// Can be overridden per issuer. If not set, the Go default is used.
+	MinTLSVersion string
+
+	// Cipher suites which may be used for outbound connections using TLS 1.2.
+	// Values are from tls package constants (https://golang.org/pkg/crypto/tls/#pkg-constants).
+	// Can be overridden per issuer. If not set, the Go defaults are used.
+	CipherSuites []string
 }
@@ -28,11 +28,16 @@ import (
 	sharedv1alpha1 "github.com/cert-manager/cert-manager/pkg/apis/config/shared/v1alpha1"
 	challengescontroller "github.com/cert-manager/cert-manager/pkg/controller/acmechallenges"
 	orderscontroller "github.com/cert-manager/cert-manager/pkg/controller/acmeorders"
+	cacrlcontroller "github.com/cert-manager/cert-manager/pkg/controller/cacrl"
+	carotationcontroller "github.com/cert-manager/cert-manager/pkg/controller/carotation"
 	shimgatewaycontroller "github.com/cert-manager/cert-manager/pkg/controller/certificate-shim/gateways"
 	shimingresscontroller "github.com/cert-manager/cert-manager/pkg/controller/certificate-shim/ingresses"
+	certificatemigrationscontroller "github.com/cert-manager/cert-manager/pkg/controller/certificatemigrations"
 	cracmecontroller "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/acme"
 	crapprovercontroller "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/approver"
 	crcacontroller "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/ca"
+	crdeliverycontroller "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/delivery"
+	crmanualcontroller "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/manual"
 	crselfsignedcontroller "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/selfsigned"
 	crvaultcontroller "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/vault"
 	crvenaficontroller "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/venafi"
@@ -42,6 +47,7 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/readiness"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/requestmanager"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/revisionmanager"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates/revocation"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/trigger"
 	csracmecontroller "github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/acme"
 	csrcacontroller "github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/ca"
@@ -49,7 +55,9 @@ import (
 	csrvaultcontroller "github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/vault"
 	csrvenaficontroller "github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/venafi"
 	clusterissuerscontroller "github.com/cert-manager/cert-manager/pkg/controller/clusterissuers"
+	issuancepreviewscontroller "github.com/cert-manager/cert-manager/pkg/controller/issuancepreviews"
 	issuerscontroller "github.com/cert-manager/cert-manager/pkg/controller/issuers"
+	secretannotationmigrationcontroller "github.com/cert-manager/cert-manager/pkg/controller/secretannotationmigration"
 	"github.com/cert-manager/cert-manager/pkg/util"
 )
 
@@ -59,6 +67,9 @@ var (
 	defaultKubernetesAPIQPS   float32 = 20
 	defaultKubernetesAPIBurst int32   = 50
 
+	defaultSyncTimeout      = 2 * time.Minute
+	defaultProgressDeadline = 10 * time.Minute
+
 	defaultClusterResourceNamespace = "kube-system"
 	defaultNamespace                = ""
 
@@ -74,10 +85,30 @@ var (
 	defaultEnableCertificateOwnerRef = false
 	defaultEnableGatewayAPI          = false
 
+	defaultClockSkewTolerance = time.Minute
+	defaultMaxNotBeforeWait   = time.Hour
+
+	defaultVerifyIssuedCertificates     = true
+	defaultVerifyIssuedCertificateChain = false
+
+	defaultMigrateSecretAnnotations = false
+
+	defaultMaxIssuanceFailureEvents   int32 = 10
+	defaultIssuanceFailureEventWindow       = time.Hour
+
+	defaultIssuanceHistoryLimit int32 = 5
+
+	defaultMaxSecretSize int32 = 1 << 20
+
+	defaultACMEAccountVerificationInterval = 24 * time.Hour
+
 	defaultDNS01RecursiveNameserversOnly = false
 	defaultDNS01RecursiveNameservers     = []string{}
 	defaultDNS01CheckRetryPeriod         = 10 * time.Second
 
+	defaultDNS01OrphanedRecordSweepInterval = time.Duration(0)
+	defaultDNS01OrphanedRecordMinAge        = time.Hour
+
 	defaultNumberOfConcurrentWorkers int32 = 5
 	defaultMaxConcurrentChallenges   int32 = 60
 
@@ -96,6 +127,7 @@ var (
 	defaultACMEHTTP01SolverResourceLimitsCPU     = "100m"
 	defaultACMEHTTP01SolverResourceLimitsMemory  = "64Mi"
 	defaultACMEHTTP01SolverRunAsNonRoot          = true
+	defaultACMEHTTP01SolverSharedPods            = false
 	defaultACMEHTTP01SolverNameservers           = []string{}
 
 	defaultAutoCertificateAnnotations = []string{"kubernetes.io/tls-acme"}
@@ -103,6 +135,8 @@ var (
 	AllControllers = []string{
 		issuerscontroller.ControllerName,
 		clusterissuerscontroller.ControllerName,
+		cacrlcontroller.ControllerName,
+		carotationcontroller.ControllerName,
 		certificatesmetricscontroller.ControllerName,
 		shimingresscontroller.ControllerName,
 		shimgatewaycontroller.ControllerName,
@@ -110,7 +144,9 @@ var (
 		challengescontroller.ControllerName,
 		cracmecontroller.CRControllerName,
 		crapprovercontroller.ControllerName,
+		crdeliverycontroller.ControllerName,
 		crcacontroller.CRControllerName,
+		crmanualcontroller.CRControllerName,
 		crselfsignedcontroller.CRControllerName,
 		crvaultcontroller.CRControllerName,
 		crvenaficontroller.CRControllerName,
@@ -121,18 +157,26 @@ var (
 		requestmanager.ControllerName,
 		readiness.ControllerName,
 		revisionmanager.ControllerName,
+		revocation.ControllerName,
+		issuancepreviewscontroller.ControllerName,
+		certificatemigrationscontroller.ControllerName,
+		secretannotationmigrationcontroller.ControllerName,
 	}
 
 	DefaultEnabledControllers = []string{
 		issuerscontroller.ControllerName,
 		clusterissuerscontroller.ControllerName,
+		cacrlcontroller.ControllerName,
+		carotationcontroller.ControllerName,
 		certificatesmetricscontroller.ControllerName,
 		shimingresscontroller.ControllerName,
 		orderscontroller.ControllerName,
 		challengescontroller.ControllerName,
 		cracmecontroller.CRControllerName,
 		crapprovercontroller.ControllerName,
+		crdeliverycontroller.ControllerName,
 		crcacontroller.CRControllerName,
+		crmanualcontroller.CRControllerName,
 		crselfsignedcontroller.CRControllerName,
 		crvaultcontroller.CRControllerName,
 		crvenaficontroller.CRControllerName,
@@ -143,6 +187,7 @@ var (
 		requestmanager.ControllerName,
 		readiness.ControllerName,
 		revisionmanager.ControllerName,
+		revocation.ControllerName,
 	}
 
 	ExperimentalCertificateSigningRequestControllers = []string{
@@ -153,6 +198,23 @@ var (
 		csrvaultcontroller.CSRControllerName,
 	}
 
+	// ControllerDependencies maps a controller to the controllers it relies on
+	// to make progress. A controller can run without its dependencies, but the
+	// resources it creates will not be processed any further.
+	ControllerDependencies = map[string][]string{
+		orderscontroller.ControllerName:                {challengescontroller.ControllerName},
+		cracmecontroller.CRControllerName:              {orderscontroller.ControllerName},
+		csracmecontroller.CSRControllerName:            {orderscontroller.ControllerName},
+		shimingresscontroller.ControllerName:           {trigger.ControllerName},
+		shimgatewaycontroller.ControllerName:           {trigger.ControllerName},
+		certificatemigrationscontroller.ControllerName: {trigger.ControllerName},
+		trigger.ControllerName: {
+			keymanager.ControllerName,
+			requestmanager.ControllerName,
+			issuing.ControllerName,
+		},
+	}
+
 	// Annotations that will be copied from Certificate to CertificateRequest and to Order.
 	// By default, copy all annotations except for the ones applied by kubectl, fluxcd, argocd.
 	defaultCopiedAnnotationPrefixes = []string{
@@ -184,6 +246,14 @@ func SetDefaults_ControllerConfiguration(obj *v1alpha1.ControllerConfiguration)
 		obj.KubernetesAPIBurst = &defaultKubernetesAPIBurst
 	}
 
+	if obj.SyncTimeout == nil {
+		obj.SyncTimeout = sharedv1alpha1.DurationFromTime(defaultSyncTimeout)
+	}
+
+	if obj.ProgressDeadline == nil {
+		obj.ProgressDeadline = sharedv1alpha1.DurationFromTime(defaultProgressDeadline)
+	}
+
 	if obj.Namespace == "" {
 		obj.Namespace = defaultNamespace
 	}
@@ -216,6 +286,46 @@ func SetDefaults_ControllerConfiguration(obj *v1alpha1.ControllerConfiguration)
 		obj.CopiedAnnotationPrefixes = defaultCopiedAnnotationPrefixes
 	}
 
+	if obj.ClockSkewTolerance == nil {
+		obj.ClockSkewTolerance = sharedv1alpha1.DurationFromTime(defaultClockSkewTolerance)
+	}
+
+	if obj.MaxNotBeforeWait == nil {
+		obj.MaxNotBeforeWait = sharedv1alpha1.DurationFromTime(defaultMaxNotBeforeWait)
+	}
+
+	if obj.VerifyIssuedCertificates == nil {
+		obj.VerifyIssuedCertificates = &defaultVerifyIssuedCertificates
+	}
+
+	if obj.VerifyIssuedCertificateChain == nil {
+		obj.VerifyIssuedCertificateChain = &defaultVerifyIssuedCertificateChain
+	}
+
+	if obj.MigrateSecretAnnotations == nil {
+		obj.MigrateSecretAnnotations = &defaultMigrateSecretAnnotations
+	}
+
+	if obj.MaxIssuanceFailureEvents == nil {
+		obj.MaxIssuanceFailureEvents = &defaultMaxIssuanceFailureEvents
+	}
+
+	if obj.IssuanceFailureEventWindow == nil {
+		obj.IssuanceFailureEventWindow = sharedv1alpha1.DurationFromTime(defaultIssuanceFailureEventWindow)
+	}
+
+	if obj.IssuanceHistoryLimit == nil {
+		obj.IssuanceHistoryLimit = &defaultIssuanceHistoryLimit
+	}
+
+	if obj.MaxSecretSize == nil {
+		obj.MaxSecretSize = &defaultMaxSecretSize
+	}
+
+	if obj.ACMEAccountVerificationInterval == nil {
+		obj.ACMEAccountVerificationInterval = sharedv1alpha1.DurationFromTime(defaultACMEAccountVerificationInterval)
+	}
+
 	if obj.NumberOfConcurrentWorkers == nil {
 		obj.NumberOfConcurrentWorkers = &defaultNumberOfConcurrentWorkers
 	}
@@ -292,6 +402,10 @@ func SetDefaults_ACMEHTTP01Config(obj *v1alpha1.ACMEHTTP01Config) {
 		obj.SolverRunAsNonRoot = &defaultACMEHTTP01SolverRunAsNonRoot
 	}
 
+	if obj.SolverSharedPods == nil {
+		obj.SolverSharedPods = &defaultACMEHTTP01SolverSharedPods
+	}
+
 	if len(obj.SolverNameservers) == 0 {
 		obj.SolverNameservers = defaultACMEHTTP01SolverNameservers
 	}
@@ -310,4 +424,12 @@ func SetDefaults_ACMEDNS01Config(obj *v1alpha1.ACMEDNS01Config) {
 	if obj.CheckRetryPeriod.IsZero() {
 		obj.CheckRetryPeriod = sharedv1alpha1.DurationFromTime(defaultDNS01CheckRetryPeriod)
 	}
+
+	if obj.OrphanedRecordSweepInterval == nil {
+		obj.OrphanedRecordSweepInterval = sharedv1alpha1.DurationFromTime(defaultDNS01OrphanedRecordSweepInterval)
+	}
+
+	if obj.OrphanedRecordMinAge == nil {
+		obj.OrphanedRecordMinAge = sharedv1alpha1.DurationFromTime(defaultDNS01OrphanedRecordMinAge)
+	}
 }
@@ -89,6 +89,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.OutboundTLSConfig)(nil), (*controller.OutboundTLSConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_OutboundTLSConfig_To_controller_OutboundTLSConfig(a.(*v1alpha1.OutboundTLSConfig), b.(*controller.OutboundTLSConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*controller.OutboundTLSConfig)(nil), (*v1alpha1.OutboundTLSConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_controller_OutboundTLSConfig_To_v1alpha1_OutboundTLSConfig(a.(*controller.OutboundTLSConfig), b.(*v1alpha1.OutboundTLSConfig), scope)
+	}); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -100,6 +110,12 @@ func autoConvert_v1alpha1_ACMEDNS01Config_To_controller_ACMEDNS01Config(in *v1al
 	if err := sharedv1alpha1.Convert_Pointer_v1alpha1_Duration_To_time_Duration(&in.CheckRetryPeriod, &out.CheckRetryPeriod, s); err != nil {
 		return err
 	}
+	if err := sharedv1alpha1.Convert_Pointer_v1alpha1_Duration_To_time_Duration(&in.OrphanedRecordSweepInterval, &out.OrphanedRecordSweepInterval, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_Pointer_v1alpha1_Duration_To_time_Duration(&in.OrphanedRecordMinAge, &out.OrphanedRecordMinAge, s); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -116,6 +132,12 @@ func autoConvert_controller_ACMEDNS01Config_To_v1alpha1_ACMEDNS01Config(in *cont
 	if err := sharedv1alpha1.Convert_time_Duration_To_Pointer_v1alpha1_Duration(&in.CheckRetryPeriod, &out.CheckRetryPeriod, s); err != nil {
 		return err
 	}
+	if err := sharedv1alpha1.Convert_time_Duration_To_Pointer_v1alpha1_Duration(&in.OrphanedRecordSweepInterval, &out.OrphanedRecordSweepInterval, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_time_Duration_To_Pointer_v1alpha1_Duration(&in.OrphanedRecordMinAge, &out.OrphanedRecordMinAge, s); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -133,6 +155,9 @@ func autoConvert_v1alpha1_ACMEHTTP01Config_To_controller_ACMEHTTP01Config(in *v1
 	if err := v1.Convert_Pointer_bool_To_bool(&in.SolverRunAsNonRoot, &out.SolverRunAsNonRoot, s); err != nil {
 		return err
 	}
+	if err := v1.Convert_Pointer_bool_To_bool(&in.SolverSharedPods, &out.SolverSharedPods, s); err != nil {
+		return err
+	}
 	out.SolverNameservers = *(*[]string)(unsafe.Pointer(&in.SolverNameservers))
 	return nil
 }
@@ -151,6 +176,9 @@ func autoConvert_controller_ACMEHTTP01Config_To_v1alpha1_ACMEHTTP01Config(in *co
 	if err := v1.Convert_bool_To_Pointer_bool(&in.SolverRunAsNonRoot, &out.SolverRunAsNonRoot, s); err != nil {
 		return err
 	}
+	if err := v1.Convert_bool_To_Pointer_bool(&in.SolverSharedPods, &out.SolverSharedPods, s); err != nil {
+		return err
+	}
 	out.SolverNameservers = *(*[]string)(unsafe.Pointer(&in.SolverNameservers))
 	return nil
 }
@@ -169,6 +197,18 @@ func autoConvert_v1alpha1_ControllerConfiguration_To_controller_ControllerConfig
 	if err := sharedv1alpha1.Convert_Pointer_int32_To_int(&in.KubernetesAPIBurst, &out.KubernetesAPIBurst, s); err != nil {
 		return err
 	}
+	if err := sharedv1alpha1.Convert_Pointer_int32_To_int(&in.InformerListChunkSize, &out.InformerListChunkSize, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_Pointer_v1alpha1_Duration_To_time_Duration(&in.InformerSyncBudget, &out.InformerSyncBudget, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_Pointer_v1alpha1_Duration_To_time_Duration(&in.SyncTimeout, &out.SyncTimeout, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_Pointer_v1alpha1_Duration_To_time_Duration(&in.ProgressDeadline, &out.ProgressDeadline, s); err != nil {
+		return err
+	}
 	out.Namespace = in.Namespace
 	out.ClusterResourceNamespace = in.ClusterResourceNamespace
 	if err := Convert_v1alpha1_LeaderElectionConfig_To_controller_LeaderElectionConfig(&in.LeaderElectionConfig, &out.LeaderElectionConfig, s); err != nil {
@@ -188,9 +228,61 @@ func autoConvert_v1alpha1_ControllerConfiguration_To_controller_ControllerConfig
 		return err
 	}
 	out.CopiedAnnotationPrefixes = *(*[]string)(unsafe.Pointer(&in.CopiedAnnotationPrefixes))
+	out.CertificateRequestEncodings = *(*map[string]string)(unsafe.Pointer(&in.CertificateRequestEncodings))
+	if err := sharedv1alpha1.Convert_Pointer_v1alpha1_Duration_To_time_Duration(&in.IssuanceTimeout, &out.IssuanceTimeout, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_Pointer_v1alpha1_Duration_To_time_Duration(&in.ClockSkewTolerance, &out.ClockSkewTolerance, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_Pointer_v1alpha1_Duration_To_time_Duration(&in.MaxNotBeforeWait, &out.MaxNotBeforeWait, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_bool_To_bool(&in.VerifyIssuedCertificates, &out.VerifyIssuedCertificates, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_bool_To_bool(&in.VerifyIssuedCertificateChain, &out.VerifyIssuedCertificateChain, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_bool_To_bool(&in.MigrateSecretAnnotations, &out.MigrateSecretAnnotations, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_Pointer_int32_To_int(&in.MaxIssuanceFailureEvents, &out.MaxIssuanceFailureEvents, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_Pointer_v1alpha1_Duration_To_time_Duration(&in.IssuanceFailureEventWindow, &out.IssuanceFailureEventWindow, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_Pointer_int32_To_int(&in.IssuanceHistoryLimit, &out.IssuanceHistoryLimit, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_Pointer_int32_To_int(&in.MaxSecretSize, &out.MaxSecretSize, s); err != nil {
+		return err
+	}
+	out.DefaultPrivateKeyAlgorithm = in.DefaultPrivateKeyAlgorithm
+	if err := sharedv1alpha1.Convert_Pointer_int32_To_int(&in.DefaultPrivateKeySize, &out.DefaultPrivateKeySize, s); err != nil {
+		return err
+	}
+	out.DefaultPrivateKeyRotationPolicy = in.DefaultPrivateKeyRotationPolicy
+	out.ExternalPrivateKeySignerAddress = in.ExternalPrivateKeySignerAddress
+	if err := sharedv1alpha1.Convert_Pointer_v1alpha1_Duration_To_time_Duration(&in.ACMEAccountVerificationInterval, &out.ACMEAccountVerificationInterval, s); err != nil {
+		return err
+	}
 	if err := sharedv1alpha1.Convert_Pointer_int32_To_int(&in.NumberOfConcurrentWorkers, &out.NumberOfConcurrentWorkers, s); err != nil {
 		return err
 	}
+	if err := sharedv1alpha1.Convert_Pointer_int32_To_int(&in.ConcurrentCertificateSyncs, &out.ConcurrentCertificateSyncs, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_Pointer_int32_To_int(&in.ConcurrentCertificateRequestSyncs, &out.ConcurrentCertificateRequestSyncs, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_Pointer_int32_To_int(&in.ConcurrentOrderSyncs, &out.ConcurrentOrderSyncs, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_Pointer_int32_To_int(&in.ConcurrentChallengeSyncs, &out.ConcurrentChallengeSyncs, s); err != nil {
+		return err
+	}
 	if err := sharedv1alpha1.Convert_Pointer_int32_To_int(&in.MaxConcurrentChallenges, &out.MaxConcurrentChallenges, s); err != nil {
 		return err
 	}
@@ -214,6 +306,9 @@ func autoConvert_v1alpha1_ControllerConfiguration_To_controller_ControllerConfig
 	if err := Convert_v1alpha1_ACMEDNS01Config_To_controller_ACMEDNS01Config(&in.ACMEDNS01Config, &out.ACMEDNS01Config, s); err != nil {
 		return err
 	}
+	if err := Convert_v1alpha1_OutboundTLSConfig_To_controller_OutboundTLSConfig(&in.OutboundTLSConfig, &out.OutboundTLSConfig, s); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -231,6 +326,18 @@ func autoConvert_controller_ControllerConfiguration_To_v1alpha1_ControllerConfig
 	if err := sharedv1alpha1.Convert_int_To_Pointer_int32(&in.KubernetesAPIBurst, &out.KubernetesAPIBurst, s); err != nil {
 		return err
 	}
+	if err := sharedv1alpha1.Convert_int_To_Pointer_int32(&in.InformerListChunkSize, &out.InformerListChunkSize, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_time_Duration_To_Pointer_v1alpha1_Duration(&in.InformerSyncBudget, &out.InformerSyncBudget, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_time_Duration_To_Pointer_v1alpha1_Duration(&in.SyncTimeout, &out.SyncTimeout, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_time_Duration_To_Pointer_v1alpha1_Duration(&in.ProgressDeadline, &out.ProgressDeadline, s); err != nil {
+		return err
+	}
 	out.Namespace = in.Namespace
 	out.ClusterResourceNamespace = in.ClusterResourceNamespace
 	if err := Convert_controller_LeaderElectionConfig_To_v1alpha1_LeaderElectionConfig(&in.LeaderElectionConfig, &out.LeaderElectionConfig, s); err != nil {
@@ -250,9 +357,61 @@ func autoConvert_controller_ControllerConfiguration_To_v1alpha1_ControllerConfig
 		return err
 	}
 	out.CopiedAnnotationPrefixes = *(*[]string)(unsafe.Pointer(&in.CopiedAnnotationPrefixes))
+	out.CertificateRequestEncodings = *(*map[string]string)(unsafe.Pointer(&in.CertificateRequestEncodings))
+	if err := sharedv1alpha1.Convert_time_Duration_To_Pointer_v1alpha1_Duration(&in.IssuanceTimeout, &out.IssuanceTimeout, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_time_Duration_To_Pointer_v1alpha1_Duration(&in.ClockSkewTolerance, &out.ClockSkewTolerance, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_time_Duration_To_Pointer_v1alpha1_Duration(&in.MaxNotBeforeWait, &out.MaxNotBeforeWait, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_bool_To_Pointer_bool(&in.VerifyIssuedCertificates, &out.VerifyIssuedCertificates, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_bool_To_Pointer_bool(&in.VerifyIssuedCertificateChain, &out.VerifyIssuedCertificateChain, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_bool_To_Pointer_bool(&in.MigrateSecretAnnotations, &out.MigrateSecretAnnotations, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_int_To_Pointer_int32(&in.MaxIssuanceFailureEvents, &out.MaxIssuanceFailureEvents, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_time_Duration_To_Pointer_v1alpha1_Duration(&in.IssuanceFailureEventWindow, &out.IssuanceFailureEventWindow, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_int_To_Pointer_int32(&in.IssuanceHistoryLimit, &out.IssuanceHistoryLimit, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_int_To_Pointer_int32(&in.MaxSecretSize, &out.MaxSecretSize, s); err != nil {
+		return err
+	}
+	out.DefaultPrivateKeyAlgorithm = in.DefaultPrivateKeyAlgorithm
+	if err := sharedv1alpha1.Convert_int_To_Pointer_int32(&in.DefaultPrivateKeySize, &out.DefaultPrivateKeySize, s); err != nil {
+		return err
+	}
+	out.DefaultPrivateKeyRotationPolicy = in.DefaultPrivateKeyRotationPolicy
+	out.ExternalPrivateKeySignerAddress = in.ExternalPrivateKeySignerAddress
+	if err := sharedv1alpha1.Convert_time_Duration_To_Pointer_v1alpha1_Duration(&in.ACMEAccountVerificationInterval, &out.ACMEAccountVerificationInterval, s); err != nil {
+		return err
+	}
 	if err := sharedv1alpha1.Convert_int_To_Pointer_int32(&in.NumberOfConcurrentWorkers, &out.NumberOfConcurrentWorkers, s); err != nil {
 		return err
 	}
+	if err := sharedv1alpha1.Convert_int_To_Pointer_int32(&in.ConcurrentCertificateSyncs, &out.ConcurrentCertificateSyncs, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_int_To_Pointer_int32(&in.ConcurrentCertificateRequestSyncs, &out.ConcurrentCertificateRequestSyncs, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_int_To_Pointer_int32(&in.ConcurrentOrderSyncs, &out.ConcurrentOrderSyncs, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_int_To_Pointer_int32(&in.ConcurrentChallengeSyncs, &out.ConcurrentChallengeSyncs, s); err != nil {
+		return err
+	}
 	if err := sharedv1alpha1.Convert_int_To_Pointer_int32(&in.MaxConcurrentChallenges, &out.MaxConcurrentChallenges, s); err != nil {
 		return err
 	}
@@ -276,6 +435,9 @@ func autoConvert_controller_ControllerConfiguration_To_v1alpha1_ControllerConfig
 	if err := Convert_controller_ACMEDNS01Config_To_v1alpha1_ACMEDNS01Config(&in.ACMEDNS01Config, &out.ACMEDNS01Config, s); err != nil {
 		return err
 	}
+	if err := Convert_controller_OutboundTLSConfig_To_v1alpha1_OutboundTLSConfig(&in.OutboundTLSConfig, &out.OutboundTLSConfig, s); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -339,3 +501,25 @@ func autoConvert_controller_LeaderElectionConfig_To_v1alpha1_LeaderElectionConfi
 func Convert_controller_LeaderElectionConfig_To_v1alpha1_LeaderElectionConfig(in *controller.LeaderElectionConfig, out *v1alpha1.LeaderElectionConfig, s conversion.Scope) error {
 	return autoConvert_controller_LeaderElectionConfig_To_v1alpha1_LeaderElectionConfig(in, out, s)
 }
+
+func autoConvert_v1alpha1_OutboundTLSConfig_To_controller_OutboundTLSConfig(in *v1alpha1.OutboundTLSConfig, out *controller.OutboundTLSConfig, s conversion.Scope) error {
+	out.MinTLSVersion = in.MinTLSVersion
+	out.CipherSuites = *(*[]string)(unsafe.Pointer(&in.CipherSuites))
+	return nil
+}
+
+// Convert_v1alpha1_OutboundTLSConfig_To_controller_OutboundTLSConfig is an autogenerated conversion function.
+func Convert_v1alpha1_OutboundTLSConfig_To_controller_OutboundTLSConfig(in *v1alpha1.OutboundTLSConfig, out *controller.OutboundTLSConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_OutboundTLSConfig_To_controller_OutboundTLSConfig(in, out, s)
+}
+
+func autoConvert_controller_OutboundTLSConfig_To_v1alpha1_OutboundTLSConfig(in *controller.OutboundTLSConfig, out *v1alpha1.OutboundTLSConfig, s conversion.Scope) error {
+	out.MinTLSVersion = in.MinTLSVersion
+	out.CipherSuites = *(*[]string)(unsafe.Pointer(&in.CipherSuites))
+	return nil
+}
+
+// Convert_controller_OutboundTLSConfig_To_v1alpha1_OutboundTLSConfig is an autogenerated conversion function.
+func Convert_controller_OutboundTLSConfig_To_v1alpha1_OutboundTLSConfig(in *controller.OutboundTLSConfig, out *v1alpha1.OutboundTLSConfig, s conversion.Scope) error {
+	return autoConvert_controller_OutboundTLSConfig_To_v1alpha1_OutboundTLSConfig(in, out, s)
+}
@@ -19,6 +19,7 @@ package validation
 import (
 	"net"
 	"net/url"
+	"strconv"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -28,6 +29,9 @@ import (
 	config "github.com/cert-manager/cert-manager/internal/apis/config/controller"
 	defaults "github.com/cert-manager/cert-manager/internal/apis/config/controller/v1alpha1"
 	sharedvalidation "github.com/cert-manager/cert-manager/internal/apis/config/shared/validation"
+	"github.com/cert-manager/cert-manager/internal/tlsclient"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
 func ValidateControllerConfiguration(cfg *config.ControllerConfiguration, fldPath *field.Path) field.ErrorList {
@@ -45,6 +49,41 @@ func ValidateControllerConfiguration(cfg *config.ControllerConfiguration, fldPat
 		allErrors = append(allErrors, field.Required(fldPath.Child("ingressShimConfig").Child("defaultIssuerKind"), "must not be empty"))
 	}
 
+	if cfg.IssuanceTimeout < 0 {
+		allErrors = append(allErrors, field.Invalid(fldPath.Child("issuanceTimeout"), cfg.IssuanceTimeout, "must not be negative"))
+	}
+
+	if cfg.ClockSkewTolerance < 0 {
+		allErrors = append(allErrors, field.Invalid(fldPath.Child("clockSkewTolerance"), cfg.ClockSkewTolerance, "must not be negative"))
+	}
+
+	if cfg.MaxNotBeforeWait < 0 {
+		allErrors = append(allErrors, field.Invalid(fldPath.Child("maxNotBeforeWait"), cfg.MaxNotBeforeWait, "must not be negative"))
+	}
+
+	if cfg.MaxIssuanceFailureEvents < 0 {
+		allErrors = append(allErrors, field.Invalid(fldPath.Child("maxIssuanceFailureEvents"), cfg.MaxIssuanceFailureEvents, "must not be negative"))
+	}
+
+	if cfg.MaxIssuanceFailureEvents > 0 && cfg.IssuanceFailureEventWindow <= 0 {
+		allErrors = append(allErrors, field.Invalid(fldPath.Child("issuanceFailureEventWindow"), cfg.IssuanceFailureEventWindow, "must be higher than 0"))
+	}
+
+	if cfg.IssuanceHistoryLimit < 0 {
+		allErrors = append(allErrors, field.Invalid(fldPath.Child("issuanceHistoryLimit"), cfg.IssuanceHistoryLimit, "must not be negative"))
+	}
+
+	if cfg.MaxSecretSize < 0 {
+		allErrors = append(allErrors, field.Invalid(fldPath.Child("maxSecretSize"), cfg.MaxSecretSize, "must not be negative"))
+	}
+
+	if cfg.ACMEAccountVerificationInterval < 0 {
+		allErrors = append(allErrors, field.Invalid(fldPath.Child("acmeAccountVerificationInterval"), cfg.ACMEAccountVerificationInterval, "must not be negative"))
+	}
+
+	allErrors = append(allErrors, validateDefaultPrivateKey(cfg, fldPath)...)
+	allErrors = append(allErrors, validateCertificateRequestEncodings(cfg, fldPath)...)
+
 	if cfg.KubernetesAPIBurst <= 0 {
 		allErrors = append(allErrors, field.Invalid(fldPath.Child("kubernetesAPIBurst"), cfg.KubernetesAPIBurst, "must be higher than 0"))
 	}
@@ -57,6 +96,37 @@ func ValidateControllerConfiguration(cfg *config.ControllerConfiguration, fldPat
 		allErrors = append(allErrors, field.Invalid(fldPath.Child("kubernetesAPIBurst"), cfg.KubernetesAPIBurst, "must be higher or equal to kubernetesAPIQPS"))
 	}
 
+	if cfg.InformerListChunkSize < 0 {
+		allErrors = append(allErrors, field.Invalid(fldPath.Child("informerListChunkSize"), cfg.InformerListChunkSize, "must not be negative"))
+	}
+
+	if cfg.InformerSyncBudget < 0 {
+		allErrors = append(allErrors, field.Invalid(fldPath.Child("informerSyncBudget"), cfg.InformerSyncBudget, "must not be negative"))
+	}
+
+	if cfg.SyncTimeout < 0 {
+		allErrors = append(allErrors, field.Invalid(fldPath.Child("syncTimeout"), cfg.SyncTimeout, "must not be negative"))
+	}
+
+	if cfg.ProgressDeadline < 0 {
+		allErrors = append(allErrors, field.Invalid(fldPath.Child("progressDeadline"), cfg.ProgressDeadline, "must not be negative"))
+	}
+
+	for _, syncs := range []struct {
+		name  string
+		value int
+	}{
+		{"concurrentCertificateSyncs", cfg.ConcurrentCertificateSyncs},
+		{"concurrentCertificateRequestSyncs", cfg.ConcurrentCertificateRequestSyncs},
+		{"concurrentOrderSyncs", cfg.ConcurrentOrderSyncs},
+		{"concurrentChallengeSyncs", cfg.ConcurrentChallengeSyncs},
+	} {
+		// 0 means that numberOfConcurrentWorkers is used.
+		if syncs.value < 0 {
+			allErrors = append(allErrors, field.Invalid(fldPath.Child(syncs.name), syncs.value, "must be at least 1, or 0 to use numberOfConcurrentWorkers"))
+		}
+	}
+
 	for i, server := range cfg.ACMEHTTP01Config.SolverNameservers {
 		// ensure all servers have a port number
 		_, _, err := net.SplitHostPort(server)
@@ -81,6 +151,21 @@ func ValidateControllerConfiguration(cfg *config.ControllerConfiguration, fldPat
 		}
 	}
 
+	if cfg.ACMEDNS01Config.OrphanedRecordSweepInterval < 0 {
+		allErrors = append(allErrors, field.Invalid(fldPath.Child("acmeDNS01Config").Child("orphanedRecordSweepInterval"), cfg.ACMEDNS01Config.OrphanedRecordSweepInterval, "must not be negative"))
+	}
+
+	if cfg.ACMEDNS01Config.OrphanedRecordMinAge < 0 {
+		allErrors = append(allErrors, field.Invalid(fldPath.Child("acmeDNS01Config").Child("orphanedRecordMinAge"), cfg.ACMEDNS01Config.OrphanedRecordMinAge, "must not be negative"))
+	}
+
+	minTLSVersion, err := tlsclient.ParseMinTLSVersion(cfg.OutboundTLSConfig.MinTLSVersion)
+	if err != nil {
+		allErrors = append(allErrors, field.Invalid(fldPath.Child("outboundTLSConfig").Child("minTLSVersion"), cfg.OutboundTLSConfig.MinTLSVersion, err.Error()))
+	} else if _, err := tlsclient.ParseCipherSuites(cfg.OutboundTLSConfig.CipherSuites, minTLSVersion); err != nil {
+		allErrors = append(allErrors, field.Invalid(fldPath.Child("outboundTLSConfig").Child("cipherSuites"), cfg.OutboundTLSConfig.CipherSuites, err.Error()))
+	}
+
 	allControllersSet := sets.NewString(defaults.AllControllers...)
 	for i, controller := range cfg.Controllers {
 		if controller == "*" {
@@ -95,3 +180,67 @@ func ValidateControllerConfiguration(cfg *config.ControllerConfiguration, fldPat
 
 	return allErrors
 }
+
+func validateCertificateRequestEncodings(cfg *config.ControllerConfiguration, fldPath *field.Path) field.ErrorList {
+	var allErrors field.ErrorList
+
+	supported := make([]string, 0, len(pki.CertificateRequestEncodings))
+	for _, encoding := range pki.CertificateRequestEncodings {
+		supported = append(supported, string(encoding))
+	}
+
+	encodingsPath := fldPath.Child("certificateRequestEncodings")
+	for _, group := range sets.List(sets.KeySet(cfg.CertificateRequestEncodings)) {
+		encoding := cfg.CertificateRequestEncodings[group]
+		// The issuers built into cert-manager expect PEM encoded requests.
+		if group == "" || group == cmapi.SchemeGroupVersion.Group {
+			allErrors = append(allErrors, field.Invalid(encodingsPath.Key(group), encoding, "the encoding can only be configured for external issuer groups"))
+			continue
+		}
+		if !sets.New(supported...).Has(encoding) {
+			allErrors = append(allErrors, field.NotSupported(encodingsPath.Key(group), encoding, supported))
+		}
+	}
+
+	return allErrors
+}
+
+func validateDefaultPrivateKey(cfg *config.ControllerConfiguration, fldPath *field.Path) field.ErrorList {
+	var allErrors field.ErrorList
+
+	sizePath := fldPath.Child("defaultPrivateKeySize")
+	switch cmapi.PrivateKeyAlgorithm(cfg.DefaultPrivateKeyAlgorithm) {
+	case "":
+		if cfg.DefaultPrivateKeySize != 0 {
+			allErrors = append(allErrors, field.Invalid(sizePath, cfg.DefaultPrivateKeySize, "must not be set without defaultPrivateKeyAlgorithm"))
+		}
+	case cmapi.RSAKeyAlgorithm:
+		if cfg.DefaultPrivateKeySize != 0 && (cfg.DefaultPrivateKeySize < pki.MinRSAKeySize || cfg.DefaultPrivateKeySize > pki.MaxRSAKeySize) {
+			allErrors = append(allErrors, field.Invalid(sizePath, cfg.DefaultPrivateKeySize, "must be between 2048 & 8192 for the RSA algorithm"))
+		}
+	case cmapi.ECDSAKeyAlgorithm:
+		if cfg.DefaultPrivateKeySize != 0 && !pki.IsSupportedECDSAKeySize(cfg.DefaultPrivateKeySize) {
+			var sizes []string
+			for _, size := range pki.SupportedECDSAKeySizes() {
+				sizes = append(sizes, strconv.Itoa(size))
+			}
+			allErrors = append(allErrors, field.NotSupported(sizePath, cfg.DefaultPrivateKeySize, sizes))
+		}
+	case cmapi.Ed25519KeyAlgorithm:
+		if cfg.DefaultPrivateKeySize != 0 {
+			allErrors = append(allErrors, field.Invalid(sizePath, cfg.DefaultPrivateKeySize, "must not be set for the Ed25519 algorithm"))
+		}
+	default:
+		allErrors = append(allErrors, field.NotSupported(fldPath.Child("defaultPrivateKeyAlgorithm"), cfg.DefaultPrivateKeyAlgorithm,
+			[]string{string(cmapi.RSAKeyAlgorithm), string(cmapi.ECDSAKeyAlgorithm), string(cmapi.Ed25519KeyAlgorithm)}))
+	}
+
+	switch cmapi.PrivateKeyRotationPolicy(cfg.DefaultPrivateKeyRotationPolicy) {
+	case "", cmapi.RotationPolicyNever, cmapi.RotationPolicyAlways:
+	default:
+		allErrors = append(allErrors, field.NotSupported(fldPath.Child("defaultPrivateKeyRotationPolicy"), cfg.DefaultPrivateKeyRotationPolicy,
+			[]string{string(cmapi.RotationPolicyNever), string(cmapi.RotationPolicyAlways)}))
+	}
+
+	return allErrors
+}
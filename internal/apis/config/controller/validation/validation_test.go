@@ -218,6 +218,253 @@ func TestValidateControllerConfiguration(t *testing.T) {
 				}
 			},
 		},
+		{
+			"with negative issuance timeout",
+			&config.ControllerConfiguration{
+				Logging: logsapi.LoggingConfiguration{
+					Format: "text",
+				},
+				IngressShimConfig: config.IngressShimConfig{
+					DefaultIssuerKind: "Issuer",
+				},
+				KubernetesAPIBurst: 1,
+				KubernetesAPIQPS:   1,
+				IssuanceTimeout:    -time.Minute,
+			},
+			func(cc *config.ControllerConfiguration) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(field.NewPath("issuanceTimeout"), cc.IssuanceTimeout, "must not be negative"),
+				}
+			},
+		},
+		{
+			"with negative clock skew tolerance and not before wait",
+			&config.ControllerConfiguration{
+				Logging: logsapi.LoggingConfiguration{
+					Format: "text",
+				},
+				IngressShimConfig: config.IngressShimConfig{
+					DefaultIssuerKind: "Issuer",
+				},
+				KubernetesAPIBurst: 1,
+				KubernetesAPIQPS:   1,
+				ClockSkewTolerance: -time.Minute,
+				MaxNotBeforeWait:   -time.Minute,
+			},
+			func(cc *config.ControllerConfiguration) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(field.NewPath("clockSkewTolerance"), cc.ClockSkewTolerance, "must not be negative"),
+					field.Invalid(field.NewPath("maxNotBeforeWait"), cc.MaxNotBeforeWait, "must not be negative"),
+				}
+			},
+		},
+		{
+			"with issuance failure events aggregated over an empty window",
+			&config.ControllerConfiguration{
+				Logging: logsapi.LoggingConfiguration{
+					Format: "text",
+				},
+				IngressShimConfig: config.IngressShimConfig{
+					DefaultIssuerKind: "Issuer",
+				},
+				KubernetesAPIBurst:       1,
+				KubernetesAPIQPS:         1,
+				MaxIssuanceFailureEvents: 10,
+			},
+			func(cc *config.ControllerConfiguration) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(field.NewPath("issuanceFailureEventWindow"), cc.IssuanceFailureEventWindow, "must be higher than 0"),
+				}
+			},
+		},
+		{
+			"with negative issuance history limit",
+			&config.ControllerConfiguration{
+				Logging: logsapi.LoggingConfiguration{
+					Format: "text",
+				},
+				IngressShimConfig: config.IngressShimConfig{
+					DefaultIssuerKind: "Issuer",
+				},
+				KubernetesAPIBurst:   1,
+				KubernetesAPIQPS:     1,
+				IssuanceHistoryLimit: -1,
+			},
+			func(cc *config.ControllerConfiguration) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(field.NewPath("issuanceHistoryLimit"), cc.IssuanceHistoryLimit, "must not be negative"),
+				}
+			},
+		},
+		{
+			"with negative max secret size",
+			&config.ControllerConfiguration{
+				Logging: logsapi.LoggingConfiguration{
+					Format: "text",
+				},
+				IngressShimConfig: config.IngressShimConfig{
+					DefaultIssuerKind: "Issuer",
+				},
+				KubernetesAPIBurst: 1,
+				KubernetesAPIQPS:   1,
+				MaxSecretSize:      -1,
+			},
+			func(cc *config.ControllerConfiguration) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(field.NewPath("maxSecretSize"), cc.MaxSecretSize, "must not be negative"),
+				}
+			},
+		},
+		{
+			"with negative ACME account verification interval",
+			&config.ControllerConfiguration{
+				Logging: logsapi.LoggingConfiguration{
+					Format: "text",
+				},
+				IngressShimConfig: config.IngressShimConfig{
+					DefaultIssuerKind: "Issuer",
+				},
+				KubernetesAPIBurst:              1,
+				KubernetesAPIQPS:                1,
+				ACMEAccountVerificationInterval: -time.Hour,
+			},
+			func(cc *config.ControllerConfiguration) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(field.NewPath("acmeAccountVerificationInterval"), cc.ACMEAccountVerificationInterval, "must not be negative"),
+				}
+			},
+		},
+		{
+			"with negative informer list chunk size, sync budget, sync timeout and progress deadline",
+			&config.ControllerConfiguration{
+				Logging: logsapi.LoggingConfiguration{
+					Format: "text",
+				},
+				IngressShimConfig: config.IngressShimConfig{
+					DefaultIssuerKind: "Issuer",
+				},
+				KubernetesAPIBurst:    1,
+				KubernetesAPIQPS:      1,
+				InformerListChunkSize: -1,
+				InformerSyncBudget:    -time.Second,
+				SyncTimeout:           -time.Second,
+				ProgressDeadline:      -time.Second,
+			},
+			func(cc *config.ControllerConfiguration) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(field.NewPath("informerListChunkSize"), cc.InformerListChunkSize, "must not be negative"),
+					field.Invalid(field.NewPath("informerSyncBudget"), cc.InformerSyncBudget, "must not be negative"),
+					field.Invalid(field.NewPath("syncTimeout"), cc.SyncTimeout, "must not be negative"),
+					field.Invalid(field.NewPath("progressDeadline"), cc.ProgressDeadline, "must not be negative"),
+				}
+			},
+		},
+		{
+			"with negative concurrent syncs",
+			&config.ControllerConfiguration{
+				Logging: logsapi.LoggingConfiguration{
+					Format: "text",
+				},
+				IngressShimConfig: config.IngressShimConfig{
+					DefaultIssuerKind: "Issuer",
+				},
+				KubernetesAPIBurst:                1,
+				KubernetesAPIQPS:                  1,
+				ConcurrentCertificateSyncs:        10,
+				ConcurrentCertificateRequestSyncs: -1,
+				ConcurrentOrderSyncs:              -1,
+				ConcurrentChallengeSyncs:          -1,
+			},
+			func(cc *config.ControllerConfiguration) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(field.NewPath("concurrentCertificateRequestSyncs"), cc.ConcurrentCertificateRequestSyncs, "must be at least 1, or 0 to use numberOfConcurrentWorkers"),
+					field.Invalid(field.NewPath("concurrentOrderSyncs"), cc.ConcurrentOrderSyncs, "must be at least 1, or 0 to use numberOfConcurrentWorkers"),
+					field.Invalid(field.NewPath("concurrentChallengeSyncs"), cc.ConcurrentChallengeSyncs, "must be at least 1, or 0 to use numberOfConcurrentWorkers"),
+				}
+			},
+		},
+		{
+			"with invalid certificate request encodings",
+			&config.ControllerConfiguration{
+				Logging: logsapi.LoggingConfiguration{
+					Format: "text",
+				},
+				IngressShimConfig: config.IngressShimConfig{
+					DefaultIssuerKind: "Issuer",
+				},
+				KubernetesAPIBurst: 1,
+				KubernetesAPIQPS:   1,
+				CertificateRequestEncodings: map[string]string{
+					"example.com":     "LegacyPEM",
+					"bad.example.com": "PKCS7",
+					"cert-manager.io": "DER",
+				},
+			},
+			func(cc *config.ControllerConfiguration) field.ErrorList {
+				return field.ErrorList{
+					field.NotSupported(field.NewPath("certificateRequestEncodings").Key("bad.example.com"), "PKCS7", []string{"PEM", "LegacyPEM", "DER"}),
+					field.Invalid(field.NewPath("certificateRequestEncodings").Key("cert-manager.io"), "DER", "the encoding can only be configured for external issuer groups"),
+				}
+			},
+		},
+		{
+			"with valid private key defaults",
+			&config.ControllerConfiguration{
+				Logging: logsapi.LoggingConfiguration{
+					Format: "text",
+				},
+				IngressShimConfig: config.IngressShimConfig{
+					DefaultIssuerKind: "Issuer",
+				},
+				KubernetesAPIBurst:              1,
+				KubernetesAPIQPS:                1,
+				DefaultPrivateKeyAlgorithm:      "ECDSA",
+				DefaultPrivateKeySize:           384,
+				DefaultPrivateKeyRotationPolicy: "Always",
+			},
+			nil,
+		},
+		{
+			"with private key size without algorithm",
+			&config.ControllerConfiguration{
+				Logging: logsapi.LoggingConfiguration{
+					Format: "text",
+				},
+				IngressShimConfig: config.IngressShimConfig{
+					DefaultIssuerKind: "Issuer",
+				},
+				KubernetesAPIBurst:    1,
+				KubernetesAPIQPS:      1,
+				DefaultPrivateKeySize: 4096,
+			},
+			func(cc *config.ControllerConfiguration) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(field.NewPath("defaultPrivateKeySize"), cc.DefaultPrivateKeySize, "must not be set without defaultPrivateKeyAlgorithm"),
+				}
+			},
+		},
+		{
+			"with invalid private key defaults",
+			&config.ControllerConfiguration{
+				Logging: logsapi.LoggingConfiguration{
+					Format: "text",
+				},
+				IngressShimConfig: config.IngressShimConfig{
+					DefaultIssuerKind: "Issuer",
+				},
+				KubernetesAPIBurst:              1,
+				KubernetesAPIQPS:                1,
+				DefaultPrivateKeyAlgorithm:      "RSA",
+				DefaultPrivateKeySize:           1024,
+				DefaultPrivateKeyRotationPolicy: "Sometimes",
+			},
+			func(cc *config.ControllerConfiguration) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(field.NewPath("defaultPrivateKeySize"), cc.DefaultPrivateKeySize, "must be between 2048 & 8192 for the RSA algorithm"),
+					field.NotSupported(field.NewPath("defaultPrivateKeyRotationPolicy"), cc.DefaultPrivateKeyRotationPolicy, []string{"Never", "Always"}),
+				}
+			},
+		},
 		{
 			"with valid acme http solver nameservers",
 			&config.ControllerConfiguration{
@@ -330,6 +577,72 @@ func TestValidateControllerConfiguration(t *testing.T) {
 				}
 			},
 		},
+		{
+			"with negative acme dns orphaned record sweep settings",
+			&config.ControllerConfiguration{
+				Logging: logsapi.LoggingConfiguration{
+					Format: "text",
+				},
+				IngressShimConfig: config.IngressShimConfig{
+					DefaultIssuerKind: "Issuer",
+				},
+				KubernetesAPIBurst: 1,
+				KubernetesAPIQPS:   1,
+				ACMEDNS01Config: config.ACMEDNS01Config{
+					OrphanedRecordSweepInterval: -time.Minute,
+					OrphanedRecordMinAge:        -time.Hour,
+				},
+			},
+			func(cc *config.ControllerConfiguration) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(field.NewPath("acmeDNS01Config.orphanedRecordSweepInterval"), cc.ACMEDNS01Config.OrphanedRecordSweepInterval, "must not be negative"),
+					field.Invalid(field.NewPath("acmeDNS01Config.orphanedRecordMinAge"), cc.ACMEDNS01Config.OrphanedRecordMinAge, "must not be negative"),
+				}
+			},
+		},
+		{
+			"with invalid outbound minimum TLS version",
+			&config.ControllerConfiguration{
+				Logging: logsapi.LoggingConfiguration{
+					Format: "text",
+				},
+				IngressShimConfig: config.IngressShimConfig{
+					DefaultIssuerKind: "Issuer",
+				},
+				KubernetesAPIBurst: 1,
+				KubernetesAPIQPS:   1,
+				OutboundTLSConfig: config.OutboundTLSConfig{
+					MinTLSVersion: "VersionTLS14",
+				},
+			},
+			func(cc *config.ControllerConfiguration) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(field.NewPath("outboundTLSConfig.minTLSVersion"), cc.OutboundTLSConfig.MinTLSVersion, `invalid minimum TLS version: unknown tls version "VersionTLS14"`),
+				}
+			},
+		},
+		{
+			"with outbound cipher suites and a minimum TLS version of 1.3",
+			&config.ControllerConfiguration{
+				Logging: logsapi.LoggingConfiguration{
+					Format: "text",
+				},
+				IngressShimConfig: config.IngressShimConfig{
+					DefaultIssuerKind: "Issuer",
+				},
+				KubernetesAPIBurst: 1,
+				KubernetesAPIQPS:   1,
+				OutboundTLSConfig: config.OutboundTLSConfig{
+					MinTLSVersion: "VersionTLS13",
+					CipherSuites:  []string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"},
+				},
+			},
+			func(cc *config.ControllerConfiguration) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(field.NewPath("outboundTLSConfig.cipherSuites"), cc.OutboundTLSConfig.CipherSuites, "cipher suites cannot be configured when the minimum TLS version is VersionTLS13, as TLS 1.3 cipher suites are not configurable"),
+				}
+			},
+		},
 		{
 			"with valid controllers named",
 			&config.ControllerConfiguration{
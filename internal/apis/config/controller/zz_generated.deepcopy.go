@@ -82,6 +82,13 @@ func (in *ControllerConfiguration) DeepCopyInto(out *ControllerConfiguration) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.CertificateRequestEncodings != nil {
+		in, out := &in.CertificateRequestEncodings, &out.CertificateRequestEncodings
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	in.MetricsTLSConfig.DeepCopyInto(&out.MetricsTLSConfig)
 	in.Logging.DeepCopyInto(&out.Logging)
 	if in.FeatureGates != nil {
@@ -94,6 +101,7 @@ func (in *ControllerConfiguration) DeepCopyInto(out *ControllerConfiguration) {
 	in.IngressShimConfig.DeepCopyInto(&out.IngressShimConfig)
 	in.ACMEHTTP01Config.DeepCopyInto(&out.ACMEHTTP01Config)
 	in.ACMEDNS01Config.DeepCopyInto(&out.ACMEDNS01Config)
+	in.OutboundTLSConfig.DeepCopyInto(&out.OutboundTLSConfig)
 	return
 }
 
@@ -152,3 +160,24 @@ func (in *LeaderElectionConfig) DeepCopy() *LeaderElectionConfig {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutboundTLSConfig) DeepCopyInto(out *OutboundTLSConfig) {
+	*out = *in
+	if in.CipherSuites != nil {
+		in, out := &in.CipherSuites, &out.CipherSuites
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutboundTLSConfig.
+func (in *OutboundTLSConfig) DeepCopy() *OutboundTLSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OutboundTLSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
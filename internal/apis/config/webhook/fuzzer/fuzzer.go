@@ -34,6 +34,18 @@ var Funcs = func(codecs runtimeserializer.CodecFactory) []interface{} {
 				s.PprofAddress = "something:1234"
 			}
 
+			if s.DuplicateDNSNamesPolicy == "" {
+				s.DuplicateDNSNamesPolicy = "Warn"
+			}
+
+			if s.MissingReferencesPolicy == "" {
+				s.MissingReferencesPolicy = "Warn"
+			}
+
+			if s.ControllerUsername == "" {
+				s.ControllerUsername = "system:serviceaccount:test-roundtrip:cert-manager"
+			}
+
 			logsapi.SetRecommendedLoggingConfiguration(&s.Logging)
 		},
 	}
@@ -62,4 +62,58 @@ type WebhookConfiguration struct {
 	// featureGates is a map of feature names to bools that enable or disable experimental
 	// features.
 	FeatureGates map[string]bool
+
+	// certificateSANsWarningThreshold is the number of subject alternative
+	// names above which a warning is returned when a Certificate is created
+	// or updated. If 0, no warning is returned.
+	CertificateSANsWarningThreshold int32
+
+	// maxCertificateSANs is the maximum number of subject alternative names
+	// a Certificate may request. If 0, the number is not limited.
+	// Certificates which already request more names can still be updated as
+	// long as the number of names does not grow.
+	MaxCertificateSANs int32
+
+	// duplicateDNSNamesPolicy configures what happens when a Certificate is
+	// created with DNS names which overlap with those of another Certificate,
+	// in any namespace, issued by the same ClusterIssuer. One of Ignore, Warn
+	// or Strict.
+	DuplicateDNSNamesPolicy string
+
+	// missingReferencesPolicy configures what happens when a Certificate is
+	// created or updated referencing a Secret or an issuer which does not
+	// exist. One of Ignore, Warn or Strict.
+	MissingReferencesPolicy string
+
+	// controllerUsername is the username of the cert-manager controller, such
+	// as that of its service account. Only requests from this user may set or
+	// change the label marking CertificateRequests as created for a
+	// Certificate.
+	ControllerUsername string
 }
+
+const (
+	// DuplicateDNSNamesPolicyIgnore does not check Certificates for duplicate
+	// DNS names.
+	DuplicateDNSNamesPolicyIgnore = "Ignore"
+
+	// DuplicateDNSNamesPolicyWarn returns a warning naming the existing
+	// Certificate when a Certificate with duplicate DNS names is created.
+	DuplicateDNSNamesPolicyWarn = "Warn"
+
+	// DuplicateDNSNamesPolicyStrict rejects Certificates with duplicate DNS
+	// names.
+	DuplicateDNSNamesPolicyStrict = "Strict"
+
+	// MissingReferencesPolicyIgnore does not check whether the objects
+	// referenced by Certificates exist.
+	MissingReferencesPolicyIgnore = "Ignore"
+
+	// MissingReferencesPolicyWarn returns a warning naming each missing
+	// object referenced by a Certificate.
+	MissingReferencesPolicyWarn = "Warn"
+
+	// MissingReferencesPolicyStrict rejects Certificates which reference
+	// missing objects.
+	MissingReferencesPolicyStrict = "Strict"
+)
@@ -38,6 +38,21 @@ func SetDefaults_WebhookConfiguration(obj *v1alpha1.WebhookConfiguration) {
 	if obj.PprofAddress == "" {
 		obj.PprofAddress = "localhost:6060"
 	}
+	if obj.CertificateSANsWarningThreshold == nil {
+		obj.CertificateSANsWarningThreshold = ptr.To(int32(50))
+	}
+	if obj.MaxCertificateSANs == nil {
+		obj.MaxCertificateSANs = ptr.To(int32(100))
+	}
+	if obj.DuplicateDNSNamesPolicy == "" {
+		obj.DuplicateDNSNamesPolicy = "Ignore"
+	}
+	if obj.MissingReferencesPolicy == "" {
+		obj.MissingReferencesPolicy = "Ignore"
+	}
+	if obj.ControllerUsername == "" {
+		obj.ControllerUsername = "system:serviceaccount:cert-manager:cert-manager"
+	}
 
 	logsapi.SetRecommendedLoggingConfiguration(&obj.Logging)
 }
@@ -68,6 +68,15 @@ func autoConvert_v1alpha1_WebhookConfiguration_To_webhook_WebhookConfiguration(i
 	out.PprofAddress = in.PprofAddress
 	out.Logging = in.Logging
 	out.FeatureGates = *(*map[string]bool)(unsafe.Pointer(&in.FeatureGates))
+	if err := v1.Convert_Pointer_int32_To_int32(&in.CertificateSANsWarningThreshold, &out.CertificateSANsWarningThreshold, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_int32_To_int32(&in.MaxCertificateSANs, &out.MaxCertificateSANs, s); err != nil {
+		return err
+	}
+	out.DuplicateDNSNamesPolicy = in.DuplicateDNSNamesPolicy
+	out.MissingReferencesPolicy = in.MissingReferencesPolicy
+	out.ControllerUsername = in.ControllerUsername
 	return nil
 }
 
@@ -92,6 +101,15 @@ func autoConvert_webhook_WebhookConfiguration_To_v1alpha1_WebhookConfiguration(i
 	out.PprofAddress = in.PprofAddress
 	out.Logging = in.Logging
 	out.FeatureGates = *(*map[string]bool)(unsafe.Pointer(&in.FeatureGates))
+	if err := v1.Convert_int32_To_Pointer_int32(&in.CertificateSANsWarningThreshold, &out.CertificateSANsWarningThreshold, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_int32_To_Pointer_int32(&in.MaxCertificateSANs, &out.MaxCertificateSANs, s); err != nil {
+		return err
+	}
+	out.DuplicateDNSNamesPolicy = in.DuplicateDNSNamesPolicy
+	out.MissingReferencesPolicy = in.MissingReferencesPolicy
+	out.ControllerUsername = in.ControllerUsername
 	return nil
 }
 
@@ -36,6 +36,30 @@ func ValidateWebhookConfiguration(cfg *config.WebhookConfiguration, fldPath *fie
 	if cfg.SecurePort < 0 || cfg.SecurePort > 65535 {
 		allErrors = append(allErrors, field.Invalid(fldPath.Child("securePort"), cfg.SecurePort, "must be a valid port number"))
 	}
+	if cfg.CertificateSANsWarningThreshold < 0 {
+		allErrors = append(allErrors, field.Invalid(fldPath.Child("certificateSANsWarningThreshold"), cfg.CertificateSANsWarningThreshold, "must not be negative"))
+	}
+	if cfg.MaxCertificateSANs < 0 {
+		allErrors = append(allErrors, field.Invalid(fldPath.Child("maxCertificateSANs"), cfg.MaxCertificateSANs, "must not be negative"))
+	}
+	switch cfg.DuplicateDNSNamesPolicy {
+	case "", config.DuplicateDNSNamesPolicyIgnore, config.DuplicateDNSNamesPolicyWarn, config.DuplicateDNSNamesPolicyStrict:
+	default:
+		allErrors = append(allErrors, field.NotSupported(fldPath.Child("duplicateDNSNamesPolicy"), cfg.DuplicateDNSNamesPolicy, []string{
+			config.DuplicateDNSNamesPolicyIgnore,
+			config.DuplicateDNSNamesPolicyWarn,
+			config.DuplicateDNSNamesPolicyStrict,
+		}))
+	}
+	switch cfg.MissingReferencesPolicy {
+	case "", config.MissingReferencesPolicyIgnore, config.MissingReferencesPolicyWarn, config.MissingReferencesPolicyStrict:
+	default:
+		allErrors = append(allErrors, field.NotSupported(fldPath.Child("missingReferencesPolicy"), cfg.MissingReferencesPolicy, []string{
+			config.MissingReferencesPolicyIgnore,
+			config.MissingReferencesPolicyWarn,
+			config.MissingReferencesPolicyStrict,
+		}))
+	}
 
 	return allErrors
 }
@@ -127,6 +127,60 @@ func TestValidateWebhookConfiguration(t *testing.T) {
 				}
 			},
 		},
+		{
+			"with negative certificate SAN limits",
+			&config.WebhookConfiguration{
+				Logging: logsapi.LoggingConfiguration{
+					Format: "text",
+				},
+				CertificateSANsWarningThreshold: -1,
+				MaxCertificateSANs:              -1,
+			},
+			func(wc *config.WebhookConfiguration) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(field.NewPath("certificateSANsWarningThreshold"), wc.CertificateSANsWarningThreshold, "must not be negative"),
+					field.Invalid(field.NewPath("maxCertificateSANs"), wc.MaxCertificateSANs, "must not be negative"),
+				}
+			},
+		},
+		{
+			"with a valid duplicate DNS names policy",
+			&config.WebhookConfiguration{
+				Logging: logsapi.LoggingConfiguration{
+					Format: "text",
+				},
+				DuplicateDNSNamesPolicy: "Strict",
+			},
+			nil,
+		},
+		{
+			"with an unknown duplicate DNS names policy",
+			&config.WebhookConfiguration{
+				Logging: logsapi.LoggingConfiguration{
+					Format: "text",
+				},
+				DuplicateDNSNamesPolicy: "Deny",
+			},
+			func(wc *config.WebhookConfiguration) field.ErrorList {
+				return field.ErrorList{
+					field.NotSupported(field.NewPath("duplicateDNSNamesPolicy"), wc.DuplicateDNSNamesPolicy, []string{"Ignore", "Warn", "Strict"}),
+				}
+			},
+		},
+		{
+			"with an unknown missing references policy",
+			&config.WebhookConfiguration{
+				Logging: logsapi.LoggingConfiguration{
+					Format: "text",
+				},
+				MissingReferencesPolicy: "Deny",
+			},
+			func(wc *config.WebhookConfiguration) field.ErrorList {
+				return field.ErrorList{
+					field.NotSupported(field.NewPath("missingReferencesPolicy"), wc.MissingReferencesPolicy, []string{"Ignore", "Warn", "Strict"}),
+				}
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -69,6 +69,24 @@ type SecretKeySelector struct {
 	Key string
 }
 
+// ClientTLSConfig configures the TLS settings of the HTTP client used to
+// connect to an issuer's server.
+// Fields which are not set default to the values of the controller's
+// `--outbound-tls-min-version` and `--outbound-tls-cipher-suites` flags.
+type ClientTLSConfig struct {
+	// MinVersion is the minimum TLS version accepted when connecting to the
+	// server. Values are from tls package constants, e.g. `VersionTLS12` or
+	// `VersionTLS13` (https://golang.org/pkg/crypto/tls/#pkg-constants).
+	MinVersion string
+
+	// CipherSuites is the list of TLS 1.2 cipher suites which may be used when
+	// connecting to the server. Values are from tls package constants
+	// (https://golang.org/pkg/crypto/tls/#pkg-constants).
+	// TLS 1.3 cipher suites are not configurable, so this field cannot be set
+	// when minVersion is `VersionTLS13`.
+	CipherSuites []string
+}
+
 const (
 	// Used as a data key in Secret resources to store a CA certificate.
 	TLSCAKey = "ca.crt"
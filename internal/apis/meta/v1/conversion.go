@@ -58,3 +58,15 @@ func Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(in *meta.SecretKeySe
 func Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(in *cmmeta.SecretKeySelector, out *meta.SecretKeySelector, s conversion.Scope) error {
 	return autoConvert_v1_SecretKeySelector_To_meta_SecretKeySelector(in, out, s)
 }
+
+// Convert_meta_ClientTLSConfig_To_v1_ClientTLSConfig is explicitly defined to avoid issues in conversion-gen
+// when referencing types in other API groups.
+func Convert_meta_ClientTLSConfig_To_v1_ClientTLSConfig(in *meta.ClientTLSConfig, out *cmmeta.ClientTLSConfig, s conversion.Scope) error {
+	return autoConvert_meta_ClientTLSConfig_To_v1_ClientTLSConfig(in, out, s)
+}
+
+// Convert_v1_ClientTLSConfig_To_meta_ClientTLSConfig is explicitly defined to avoid issues in conversion-gen
+// when referencing types in other API groups.
+func Convert_v1_ClientTLSConfig_To_meta_ClientTLSConfig(in *cmmeta.ClientTLSConfig, out *meta.ClientTLSConfig, s conversion.Scope) error {
+	return autoConvert_v1_ClientTLSConfig_To_meta_ClientTLSConfig(in, out, s)
+}
@@ -22,6 +22,8 @@ limitations under the License.
 package v1
 
 import (
+	unsafe "unsafe"
+
 	meta "github.com/cert-manager/cert-manager/internal/apis/meta"
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
@@ -35,6 +37,11 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddConversionFunc((*meta.ClientTLSConfig)(nil), (*v1.ClientTLSConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_meta_ClientTLSConfig_To_v1_ClientTLSConfig(a.(*meta.ClientTLSConfig), b.(*v1.ClientTLSConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*meta.LocalObjectReference)(nil), (*v1.LocalObjectReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_meta_LocalObjectReference_To_v1_LocalObjectReference(a.(*meta.LocalObjectReference), b.(*v1.LocalObjectReference), scope)
 	}); err != nil {
@@ -50,6 +57,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1.ClientTLSConfig)(nil), (*meta.ClientTLSConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ClientTLSConfig_To_meta_ClientTLSConfig(a.(*v1.ClientTLSConfig), b.(*meta.ClientTLSConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1.LocalObjectReference)(nil), (*meta.LocalObjectReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_LocalObjectReference_To_meta_LocalObjectReference(a.(*v1.LocalObjectReference), b.(*meta.LocalObjectReference), scope)
 	}); err != nil {
@@ -68,6 +80,18 @@ func RegisterConversions(s *runtime.Scheme) error {
 	return nil
 }
 
+func autoConvert_v1_ClientTLSConfig_To_meta_ClientTLSConfig(in *v1.ClientTLSConfig, out *meta.ClientTLSConfig, s conversion.Scope) error {
+	out.MinVersion = in.MinVersion
+	out.CipherSuites = *(*[]string)(unsafe.Pointer(&in.CipherSuites))
+	return nil
+}
+
+func autoConvert_meta_ClientTLSConfig_To_v1_ClientTLSConfig(in *meta.ClientTLSConfig, out *v1.ClientTLSConfig, s conversion.Scope) error {
+	out.MinVersion = in.MinVersion
+	out.CipherSuites = *(*[]string)(unsafe.Pointer(&in.CipherSuites))
+	return nil
+}
+
 func autoConvert_v1_LocalObjectReference_To_meta_LocalObjectReference(in *v1.LocalObjectReference, out *meta.LocalObjectReference, s conversion.Scope) error {
 	out.Name = in.Name
 	return nil
@@ -18,6 +18,8 @@ package certificates
 
 import (
 	"context"
+	"crypto/x509"
+	"fmt"
 	"slices"
 	"strings"
 
@@ -27,6 +29,7 @@ import (
 	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
 // We determine whether a Certificate owns its Secret in order to prevent a CertificateRequest
@@ -111,3 +114,90 @@ func CertificateOwnsSecret(
 	})
 	return isOwner, otherCertificatesWithSameSecretName, nil
 }
+
+// shortFingerprintLength is the number of hex characters of the SHA-256
+// fingerprint recorded in status.shortSHA256Fingerprint, which is short
+// enough to fit in the kubectl output and long enough to tell certificates
+// apart.
+const shortFingerprintLength = 16
+
+// SetIssuedCertificateStatus records the serial number, SHA-256 fingerprint,
+// issuer DN and subject DN of the given issued certificate on the
+// Certificate's status. The fields are cleared if cert is nil.
+func SetIssuedCertificateStatus(crt *cmapi.Certificate, cert *x509.Certificate) {
+	if cert == nil {
+		crt.Status.SerialNumber = ""
+		crt.Status.SHA256Fingerprint = ""
+		crt.Status.ShortSHA256Fingerprint = ""
+		crt.Status.IssuerDN = ""
+		crt.Status.SubjectDN = ""
+		return
+	}
+
+	crt.Status.SerialNumber = utilpki.FormatSerialNumber(cert.SerialNumber)
+	crt.Status.SHA256Fingerprint = utilpki.FingerprintSHA256(cert)
+	crt.Status.ShortSHA256Fingerprint = crt.Status.SHA256Fingerprint[:shortFingerprintLength]
+	crt.Status.IssuerDN = cert.Issuer.String()
+	crt.Status.SubjectDN = cert.Subject.String()
+}
+
+// IssuedPublicKeyMismatch checks whether the given certificate, issued for
+// the given CertificateRequest, is for a different public key than the one
+// that was requested. The requested key is identified by the
+// CertificateRequest's public key fingerprint annotation, or by the public key
+// of its CSR if the annotation is not set. A message describing the mismatch
+// is returned if the keys differ.
+func IssuedPublicKeyMismatch(req *cmapi.CertificateRequest, cert *x509.Certificate) (string, bool, error) {
+	requested, ok := req.Annotations[cmapi.CertificateRequestPublicKeyFingerprintAnnotationKey]
+	if !ok {
+		csr, err := utilpki.DecodeX509CertificateRequestBytes(req.Spec.Request)
+		if err != nil {
+			return "", false, err
+		}
+		requested, err = utilpki.PublicKeyFingerprintSHA256(csr.PublicKey)
+		if err != nil {
+			return "", false, err
+		}
+	}
+
+	issued, err := utilpki.PublicKeyFingerprintSHA256(cert.PublicKey)
+	if err != nil {
+		return "", false, err
+	}
+	if issued == requested {
+		return "", false, nil
+	}
+
+	return fmt.Sprintf("The certificate issued for CertificateRequest %q is for public key %s, but public key %s was requested", req.Name, issued, requested), true, nil
+}
+
+// IssuerCAFingerprints returns the fingerprints of the public keys of the CA
+// certificates returned by the issuer of the given CertificateRequest, i.e.
+// the certificates following the issued certificate in status.certificate and
+// those in status.ca. The fingerprints are sorted and de-duplicated so that
+// they only change when the CAs do.
+func IssuerCAFingerprints(req *cmapi.CertificateRequest) ([]string, error) {
+	chain, err := utilpki.DecodeX509CertificateSetBytes(req.Status.Certificate)
+	if err != nil {
+		return nil, err
+	}
+	cas := chain[1:]
+	if len(req.Status.CA) > 0 {
+		caCerts, err := utilpki.DecodeX509CertificateSetBytes(req.Status.CA)
+		if err != nil {
+			return nil, err
+		}
+		cas = append(cas, caCerts...)
+	}
+
+	var fingerprints []string
+	for _, ca := range cas {
+		fingerprint, err := utilpki.PublicKeyFingerprintSHA256(ca.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		fingerprints = append(fingerprints, fingerprint)
+	}
+	slices.Sort(fingerprints)
+	return slices.Compact(fingerprints), nil
+}
@@ -18,18 +18,31 @@ package certificates
 
 import (
 	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"slices"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
+	fakeclock "k8s.io/utils/clock/testing"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmv1listers "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
 )
 
 func TestCertificateOwnsSecret(t *testing.T) {
@@ -202,3 +215,159 @@ func TestCertificateOwnsSecret(t *testing.T) {
 		})
 	}
 }
+
+func TestSetIssuedCertificateStatus(t *testing.T) {
+	cert := &x509.Certificate{
+		Raw:          []byte("certificate"),
+		SerialNumber: big.NewInt(0x1f029a),
+		Issuer:       pkix.Name{CommonName: "issuer", Organization: []string{"cert-manager"}},
+		Subject:      pkix.Name{CommonName: "example.com"},
+	}
+
+	crt := &cmapi.Certificate{}
+	SetIssuedCertificateStatus(crt, cert)
+	assert.Equal(t, cmapi.CertificateStatus{
+		SerialNumber:           "1f:02:9a",
+		SHA256Fingerprint:      "03d66dd08835c1ca3f128cceacd1f31ac94163096b20f445ae84285bc0832d72",
+		ShortSHA256Fingerprint: "03d66dd08835c1ca",
+		IssuerDN:               "CN=issuer,O=cert-manager",
+		SubjectDN:              "CN=example.com",
+	}, crt.Status)
+
+	SetIssuedCertificateStatus(crt, nil)
+	assert.Equal(t, cmapi.CertificateStatus{}, crt.Status)
+}
+
+func TestIssuedPublicKeyMismatch(t *testing.T) {
+	crt := gen.Certificate("test", gen.SetCertificateDNSNames("example.com"))
+	bundle := testcrypto.MustCreateCryptoBundle(t, crt, fakeclock.NewFakeClock(time.Now()))
+	otherBundle := testcrypto.MustCreateCryptoBundle(t, crt, fakeclock.NewFakeClock(time.Now()))
+
+	requested, err := utilpki.PublicKeyFingerprintSHA256(bundle.CSR.PublicKey)
+	require.NoError(t, err)
+	issued, err := utilpki.PublicKeyFingerprintSHA256(otherBundle.Cert.PublicKey)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		req         *cmapi.CertificateRequest
+		cert        *x509.Certificate
+		expMismatch bool
+		expMessage  string
+	}{
+		"certificate matching the CSR should not mismatch": {
+			req:  bundle.CertificateRequest,
+			cert: bundle.Cert,
+		},
+		"certificate for another key than the CSR should mismatch": {
+			req:         bundle.CertificateRequest,
+			cert:        otherBundle.Cert,
+			expMismatch: true,
+			expMessage:  fmt.Sprintf("The certificate issued for CertificateRequest %q is for public key %s, but public key %s was requested", bundle.CertificateRequest.Name, issued, requested),
+		},
+		"fingerprint annotation should be preferred over the CSR": {
+			req: gen.CertificateRequestFrom(bundle.CertificateRequest,
+				gen.AddCertificateRequestAnnotations(map[string]string{
+					cmapi.CertificateRequestPublicKeyFingerprintAnnotationKey: issued,
+				}),
+			),
+			cert: otherBundle.Cert,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			message, mismatch, err := IssuedPublicKeyMismatch(test.req, test.cert)
+			require.NoError(t, err)
+			assert.Equal(t, test.expMismatch, mismatch)
+			assert.Equal(t, test.expMessage, message)
+		})
+	}
+}
+
+func TestIssuerCAFingerprints(t *testing.T) {
+	type keyPair struct {
+		cert *x509.Certificate
+		key  crypto.Signer
+		pem  []byte
+	}
+	create := func(name string, isCA bool, parent *keyPair) *keyPair {
+		key, err := utilpki.GenerateECPrivateKey(256)
+		require.NoError(t, err)
+		tmpl := &x509.Certificate{
+			SerialNumber:          big.NewInt(time.Now().UnixNano()),
+			Subject:               pkix.Name{CommonName: name},
+			NotBefore:             time.Now().Add(-time.Hour),
+			NotAfter:              time.Now().Add(time.Hour),
+			BasicConstraintsValid: true,
+			IsCA:                  isCA,
+			KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		}
+		signerCert, signerKey := tmpl, crypto.Signer(key)
+		if parent != nil {
+			signerCert, signerKey = parent.cert, parent.key
+		}
+		der, err := x509.CreateCertificate(rand.Reader, tmpl, signerCert, key.Public(), signerKey)
+		require.NoError(t, err)
+		cert, err := x509.ParseCertificate(der)
+		require.NoError(t, err)
+		return &keyPair{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+	}
+	fingerprint := func(kp *keyPair) string {
+		f, err := utilpki.PublicKeyFingerprintSHA256(kp.cert.PublicKey)
+		require.NoError(t, err)
+		return f
+	}
+
+	root := create("root", true, nil)
+	intermediate := create("intermediate", true, root)
+	leaf := create("leaf", false, intermediate)
+	expected := []string{fingerprint(root), fingerprint(intermediate)}
+	slices.Sort(expected)
+
+	tests := map[string]struct {
+		certificate []byte
+		ca          []byte
+
+		expFingerprints []string
+		expErr          bool
+	}{
+		"the chain and the CA should be recorded": {
+			certificate:     append(append([]byte{}, leaf.pem...), intermediate.pem...),
+			ca:              root.pem,
+			expFingerprints: expected,
+		},
+		"CAs which are both in the chain and the CA should be recorded once": {
+			certificate:     append(append([]byte{}, leaf.pem...), intermediate.pem...),
+			ca:              append(append([]byte{}, intermediate.pem...), root.pem...),
+			expFingerprints: expected,
+		},
+		"a certificate without a chain or CA should not record any CA": {
+			certificate: leaf.pem,
+		},
+		"an invalid certificate should return an error": {
+			certificate: []byte("invalid"),
+			expErr:      true,
+		},
+		"an invalid CA should return an error": {
+			certificate: leaf.pem,
+			ca:          []byte("-----BEGIN CERTIFICATE-----\naW52YWxpZA==\n-----END CERTIFICATE-----\n"),
+			expErr:      true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := gen.CertificateRequest("test",
+				gen.SetCertificateRequestCertificate(test.certificate),
+				gen.SetCertificateRequestCA(test.ca),
+			)
+			fingerprints, err := IssuerCAFingerprints(req)
+			if test.expErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expFingerprints, fingerprints)
+		})
+	}
+}
@@ -0,0 +1,271 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package failureevents records Events for Certificates which fail to be
+// issued, without flooding the events API when many Certificates fail for
+// the same reason at once, such as when the credentials of a ClusterIssuer
+// used by thousands of Certificates expire.
+package failureevents
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/metadata"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+// Recorder records Warning Events for Certificates which fail to be issued.
+// Failures are grouped by the issuer referenced by the Certificate and the
+// reason of the failure. Within a window, Events are only recorded for the
+// first maxEvents Certificates of a group. Failures of the other Certificates
+// are counted, and summarised by an Event recorded on the issuer at most
+// once per window. The conditions of the Certificates still carry the
+// details of every failure.
+type Recorder struct {
+	recorder  record.EventRecorder
+	clock     clock.Clock
+	maxEvents int
+	window    time.Duration
+	issuers   Issuers
+
+	lock   sync.Mutex
+	groups map[groupKey]*group
+}
+
+// Issuers is used to look up the issuers referenced by Certificates, so that
+// summary Events are recorded on the issuer objects. Fields which are nil are
+// not used, in which case references are built from the issuerRef alone.
+type Issuers struct {
+	IssuerLister        cmlisters.IssuerLister
+	ClusterIssuerLister cmlisters.ClusterIssuerLister
+
+	// RESTMapper is used to find the version and scope of external issuer
+	// kinds, and MetadataClient to look up external issuers.
+	RESTMapper     meta.RESTMapper
+	MetadataClient metadata.Interface
+}
+
+// groupKey identifies the Certificates failing to be issued by the same
+// issuer with the same reason.
+type groupKey struct {
+	issuer corev1.ObjectReference
+	reason string
+}
+
+type group struct {
+	// failing is the time of the last failure of each Certificate, keyed by
+	// namespace and name.
+	failing map[string]time.Time
+	// detailed is the time at which each Certificate for which Events are
+	// recorded first failed, keyed by namespace and name.
+	detailed map[string]time.Time
+	// lastSummary is the time at which the last summary Event was recorded.
+	lastSummary time.Time
+	// lastFailure is the time of the last failure of any Certificate.
+	lastFailure time.Time
+}
+
+// NewRecorder returns a Recorder which records Events using recorder. If
+// maxEvents is 0, an Event is recorded for every failure.
+func NewRecorder(recorder record.EventRecorder, clock clock.Clock, maxEvents int, window time.Duration, issuers Issuers) *Recorder {
+	return &Recorder{
+		recorder:  recorder,
+		clock:     clock,
+		maxEvents: maxEvents,
+		window:    window,
+		issuers:   issuers,
+		groups:    make(map[groupKey]*group),
+	}
+}
+
+// Event records that the Certificate failed to be issued with the given
+// reason and message.
+func (r *Recorder) Event(ctx context.Context, crt *cmapi.Certificate, reason, message string) {
+	if r.maxEvents <= 0 {
+		r.recorder.Event(crt, corev1.EventTypeWarning, reason, message)
+		return
+	}
+
+	key := groupKey{issuer: r.issuers.reference(crt), reason: reason}
+	detailed, summary := r.count(crt, key, message)
+	if detailed {
+		r.recorder.Event(crt, corev1.EventTypeWarning, reason, message)
+	}
+	if summary == "" {
+		return
+	}
+
+	// The issuer is only looked up when a summary is recorded, which
+	// happens at most once per window.
+	issuer := key.issuer
+	r.issuers.lookup(ctx, &issuer)
+	r.recorder.Event(&issuer, corev1.EventTypeWarning, reason, summary)
+}
+
+// count records the failure of the Certificate in its group. It returns
+// whether an Event should be recorded for the Certificate, and the message of
+// the summary Event to record on the issuer, if one is due.
+func (r *Recorder) count(crt *cmapi.Certificate, key groupKey, message string) (bool, string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := r.clock.Now()
+	r.prune(now)
+
+	g, ok := r.groups[key]
+	if !ok {
+		g = &group{failing: make(map[string]time.Time), detailed: make(map[string]time.Time)}
+		r.groups[key] = g
+	}
+
+	name := crt.Namespace + "/" + crt.Name
+	g.failing[name] = now
+	g.lastFailure = now
+
+	if _, ok := g.detailed[name]; !ok && len(g.detailed) < r.maxEvents {
+		g.detailed[name] = now
+	}
+	if _, ok := g.detailed[name]; ok {
+		return true, ""
+	}
+
+	if !g.lastSummary.IsZero() && now.Sub(g.lastSummary) < r.window {
+		return false, ""
+	}
+	g.lastSummary = now
+	return false, fmt.Sprintf("%d Certificates failed to be issued with reason %q in the last %s, Events were only recorded for %d of them. Certificate %s failed with: %s",
+		len(g.failing), key.reason, r.window, len(g.detailed), name, message)
+}
+
+// prune forgets about Certificates which have not failed within the window,
+// and groups without any such Certificates.
+func (r *Recorder) prune(now time.Time) {
+	for key, g := range r.groups {
+		if now.Sub(g.lastFailure) >= r.window {
+			delete(r.groups, key)
+			continue
+		}
+		for name, t := range g.failing {
+			if now.Sub(t) >= r.window {
+				delete(g.failing, name)
+			}
+		}
+		for name, t := range g.detailed {
+			if now.Sub(t) >= r.window {
+				delete(g.detailed, name)
+			}
+		}
+	}
+}
+
+// reference returns a reference to the issuer of the Certificate, on which
+// summary Events are recorded, without looking up the issuer. The version
+// and scope of external issuers are taken from the RESTMapper. If their kind
+// is not known, the reference has no API version and is assumed to be
+// namespaced.
+func (i Issuers) reference(crt *cmapi.Certificate) corev1.ObjectReference {
+	group := crt.Spec.IssuerRef.Group
+	if group == "" {
+		group = certmanager.GroupName
+	}
+	kind := crt.Spec.IssuerRef.Kind
+	if kind == "" {
+		kind = cmapi.IssuerKind
+	}
+
+	ref := corev1.ObjectReference{
+		Kind:      kind,
+		Namespace: crt.Namespace,
+		Name:      crt.Spec.IssuerRef.Name,
+	}
+	if group == certmanager.GroupName {
+		ref.APIVersion = cmapi.SchemeGroupVersion.String()
+		if kind == cmapi.ClusterIssuerKind {
+			ref.Namespace = ""
+		}
+		return ref
+	}
+
+	if i.RESTMapper == nil {
+		return ref
+	}
+	mapping, err := i.RESTMapper.RESTMapping(schema.GroupKind{Group: group, Kind: kind})
+	if err != nil {
+		return ref
+	}
+	ref.APIVersion = mapping.GroupVersionKind.GroupVersion().String()
+	if mapping.Scope.Name() == meta.RESTScopeNameRoot {
+		ref.Namespace = ""
+	}
+	return ref
+}
+
+// lookup sets the UID of the referenced issuer, so that the Events recorded
+// on it are shown for that issuer object only. The reference is left
+// unchanged if the issuer cannot be found.
+func (i Issuers) lookup(ctx context.Context, ref *corev1.ObjectReference) {
+	var (
+		obj metav1.Object
+		err error
+	)
+	switch {
+	case ref.APIVersion == "":
+		return
+
+	case ref.APIVersion == cmapi.SchemeGroupVersion.String() && ref.Kind == cmapi.IssuerKind:
+		if i.IssuerLister == nil {
+			return
+		}
+		obj, err = i.IssuerLister.Issuers(ref.Namespace).Get(ref.Name)
+
+	case ref.APIVersion == cmapi.SchemeGroupVersion.String() && ref.Kind == cmapi.ClusterIssuerKind:
+		if i.ClusterIssuerLister == nil {
+			return
+		}
+		obj, err = i.ClusterIssuerLister.Get(ref.Name)
+
+	default:
+		if i.RESTMapper == nil || i.MetadataClient == nil {
+			return
+		}
+		gvk := schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind)
+		mapping, mapErr := i.RESTMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if mapErr != nil {
+			err = mapErr
+			break
+		}
+		obj, err = i.MetadataClient.Resource(mapping.Resource).Namespace(ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	}
+	if err != nil {
+		logf.FromContext(ctx).V(logf.DebugLevel).Info("failed to look up issuer for failure summary event", "issuer", ref.Name, "kind", ref.Kind, "error", err.Error())
+		return
+	}
+
+	ref.UID = obj.GetUID()
+}
@@ -0,0 +1,237 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package failureevents
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	metadatafake "k8s.io/client-go/metadata/fake"
+	"k8s.io/client-go/tools/cache"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+// recorder counts the Events recorded on Certificates and issuers.
+type recorder struct {
+	certificateEvents int
+	issuerEvents      []string
+}
+
+func (r *recorder) Event(object runtime.Object, eventtype, reason, message string) {
+	switch obj := object.(type) {
+	case *cmapi.Certificate:
+		r.certificateEvents++
+	case *corev1.ObjectReference:
+		r.issuerEvents = append(r.issuerEvents, fmt.Sprintf("%s %s/%s: %s %s %s", obj.Kind, obj.Namespace, obj.Name, eventtype, reason, message))
+	}
+}
+
+func (r *recorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.Event(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *recorder) AnnotatedEventf(object runtime.Object, _ map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.Eventf(object, eventtype, reason, messageFmt, args...)
+}
+
+func TestRecorder(t *testing.T) {
+	// 100 Certificates in 5 namespaces using the same ClusterIssuer.
+	var crts []*cmapi.Certificate
+	for i := 0; i < 100; i++ {
+		crts = append(crts, gen.Certificate(fmt.Sprintf("crt-%02d", i),
+			gen.SetCertificateNamespace(fmt.Sprintf("team-%d", i%5)),
+			gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "letsencrypt", Kind: cmapi.ClusterIssuerKind}),
+		))
+	}
+	failAll := func(r *Recorder) {
+		for _, crt := range crts {
+			r.Event(context.TODO(), crt, "Failed", "credentials expired")
+		}
+	}
+
+	t.Run("should only record Events for the first Certificates and summarise the others on the issuer", func(t *testing.T) {
+		events := &recorder{}
+		clock := fakeclock.NewFakeClock(time.Now())
+		r := NewRecorder(events, clock, 10, time.Hour, Issuers{})
+
+		failAll(r)
+		assert.Equal(t, 10, events.certificateEvents)
+		assert.Equal(t, []string{
+			`ClusterIssuer /letsencrypt: Warning Failed 11 Certificates failed to be issued with reason "Failed" in the last 1h0m0s, Events were only recorded for 10 of them. Certificate team-0/crt-10 failed with: credentials expired`,
+		}, events.issuerEvents)
+
+		// Events are still recorded for the same Certificates when they fail
+		// again, but the summary is not recorded again within the window.
+		clock.Step(10 * time.Minute)
+		failAll(r)
+		assert.Equal(t, 20, events.certificateEvents)
+		assert.Len(t, events.issuerEvents, 1)
+
+		// Once the window has passed, the summary counts every Certificate
+		// which failed within the window.
+		clock.Step(55 * time.Minute)
+		failAll(r)
+		assert.Equal(t, 30, events.certificateEvents)
+		assert.Equal(t, []string{
+			`ClusterIssuer /letsencrypt: Warning Failed 11 Certificates failed to be issued with reason "Failed" in the last 1h0m0s, Events were only recorded for 10 of them. Certificate team-0/crt-10 failed with: credentials expired`,
+			`ClusterIssuer /letsencrypt: Warning Failed 100 Certificates failed to be issued with reason "Failed" in the last 1h0m0s, Events were only recorded for 10 of them. Certificate team-0/crt-10 failed with: credentials expired`,
+		}, events.issuerEvents)
+	})
+
+	t.Run("should count failures with different reasons separately", func(t *testing.T) {
+		events := &recorder{}
+		r := NewRecorder(events, fakeclock.NewFakeClock(time.Now()), 10, time.Hour, Issuers{})
+
+		for i, crt := range crts[:20] {
+			r.Event(context.TODO(), crt, fmt.Sprintf("Reason%d", i%2), "failed")
+		}
+		assert.Equal(t, 20, events.certificateEvents)
+		assert.Empty(t, events.issuerEvents)
+	})
+
+	t.Run("should count failures of Certificates using different Issuers separately", func(t *testing.T) {
+		events := &recorder{}
+		r := NewRecorder(events, fakeclock.NewFakeClock(time.Now()), 10, time.Hour, Issuers{})
+
+		// Issuers with the same name in different namespaces are different.
+		for _, crt := range crts[:50] {
+			crt = gen.CertificateFrom(crt, gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca"}))
+			r.Event(context.TODO(), crt, "Failed", "failed")
+		}
+		assert.Equal(t, 50, events.certificateEvents)
+		assert.Empty(t, events.issuerEvents)
+
+		crt := gen.CertificateFrom(crts[50], gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca"}))
+		r.Event(context.TODO(), crt, "Failed", "failed")
+		assert.Equal(t, []string{
+			`Issuer team-0/ca: Warning Failed 11 Certificates failed to be issued with reason "Failed" in the last 1h0m0s, Events were only recorded for 10 of them. Certificate team-0/crt-50 failed with: failed`,
+		}, events.issuerEvents)
+	})
+
+	t.Run("should record an Event for every failure if the number of Events is not limited", func(t *testing.T) {
+		events := &recorder{}
+		r := NewRecorder(events, fakeclock.NewFakeClock(time.Now()), 0, time.Hour, Issuers{})
+
+		failAll(r)
+		assert.Equal(t, 100, events.certificateEvents)
+		assert.Empty(t, events.issuerEvents)
+	})
+}
+
+func TestIssuerReference(t *testing.T) {
+	crt := &cmapi.Certificate{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "crt"}}
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{{Group: "awspca.cert-manager.io", Version: "v1beta1"}})
+	mapper.Add(schema.GroupVersionKind{Group: "awspca.cert-manager.io", Version: "v1beta1", Kind: "AWSPCAIssuer"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Group: "awspca.cert-manager.io", Version: "v1beta1", Kind: "AWSPCAClusterIssuer"}, meta.RESTScopeRoot)
+
+	tests := map[string]struct {
+		issuerRef cmmeta.ObjectReference
+		expected  corev1.ObjectReference
+	}{
+		"an Issuer is namespaced": {
+			issuerRef: cmmeta.ObjectReference{Name: "ca"},
+			expected:  corev1.ObjectReference{APIVersion: "cert-manager.io/v1", Kind: "Issuer", Namespace: "team-a", Name: "ca"},
+		},
+		"a ClusterIssuer is cluster scoped": {
+			issuerRef: cmmeta.ObjectReference{Name: "letsencrypt", Kind: "ClusterIssuer", Group: "cert-manager.io"},
+			expected:  corev1.ObjectReference{APIVersion: "cert-manager.io/v1", Kind: "ClusterIssuer", Name: "letsencrypt"},
+		},
+		"a namespaced external issuer has the version served by the API server": {
+			issuerRef: cmmeta.ObjectReference{Name: "pca", Kind: "AWSPCAIssuer", Group: "awspca.cert-manager.io"},
+			expected:  corev1.ObjectReference{APIVersion: "awspca.cert-manager.io/v1beta1", Kind: "AWSPCAIssuer", Namespace: "team-a", Name: "pca"},
+		},
+		"a cluster scoped external issuer has no namespace": {
+			issuerRef: cmmeta.ObjectReference{Name: "pca", Kind: "AWSPCAClusterIssuer", Group: "awspca.cert-manager.io"},
+			expected:  corev1.ObjectReference{APIVersion: "awspca.cert-manager.io/v1beta1", Kind: "AWSPCAClusterIssuer", Name: "pca"},
+		},
+		"an unknown external issuer has no API version": {
+			issuerRef: cmmeta.ObjectReference{Name: "pca", Kind: "UnknownIssuer", Group: "example.com"},
+			expected:  corev1.ObjectReference{Kind: "UnknownIssuer", Namespace: "team-a", Name: "pca"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			crt := crt.DeepCopy()
+			crt.Spec.IssuerRef = test.issuerRef
+			assert.Equal(t, test.expected, Issuers{RESTMapper: mapper}.reference(crt))
+		})
+	}
+}
+
+func TestIssuerLookup(t *testing.T) {
+	clusterIssuers := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	if err := clusterIssuers.Add(&cmapi.ClusterIssuer{ObjectMeta: metav1.ObjectMeta{Name: "letsencrypt", UID: "letsencrypt-uid"}}); err != nil {
+		t.Fatal(err)
+	}
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{{Group: "awspca.cert-manager.io", Version: "v1beta1"}})
+	mapper.Add(schema.GroupVersionKind{Group: "awspca.cert-manager.io", Version: "v1beta1", Kind: "AWSPCAClusterIssuer"}, meta.RESTScopeRoot)
+	scheme := metadatafake.NewTestScheme()
+	metav1.AddMetaToScheme(scheme)
+	metadataClient := metadatafake.NewSimpleMetadataClient(scheme, &metav1.PartialObjectMetadata{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "awspca.cert-manager.io/v1beta1", Kind: "AWSPCAClusterIssuer"},
+		ObjectMeta: metav1.ObjectMeta{Name: "pca", UID: "pca-uid"},
+	})
+	issuers := Issuers{
+		IssuerLister:        cmlisters.NewIssuerLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})),
+		ClusterIssuerLister: cmlisters.NewClusterIssuerLister(clusterIssuers),
+		RESTMapper:          mapper,
+		MetadataClient:      metadataClient,
+	}
+
+	tests := map[string]struct {
+		ref         corev1.ObjectReference
+		expectedUID types.UID
+	}{
+		"a ClusterIssuer is looked up with its lister": {
+			ref:         corev1.ObjectReference{APIVersion: "cert-manager.io/v1", Kind: "ClusterIssuer", Name: "letsencrypt"},
+			expectedUID: "letsencrypt-uid",
+		},
+		"an external issuer is looked up with the metadata client": {
+			ref:         corev1.ObjectReference{APIVersion: "awspca.cert-manager.io/v1beta1", Kind: "AWSPCAClusterIssuer", Name: "pca"},
+			expectedUID: "pca-uid",
+		},
+		"an issuer which does not exist is left unchanged": {
+			ref: corev1.ObjectReference{APIVersion: "cert-manager.io/v1", Kind: "Issuer", Namespace: "team-a", Name: "ca"},
+		},
+		"an unknown external issuer is left unchanged": {
+			ref: corev1.ObjectReference{Kind: "UnknownIssuer", Namespace: "team-a", Name: "pca"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ref := test.ref
+			issuers.lookup(context.TODO(), &ref)
+			expected := test.ref
+			expected.UID = test.expectedUID
+			assert.Equal(t, expected, ref)
+		})
+	}
+}
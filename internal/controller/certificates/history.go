@@ -0,0 +1,79 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"slices"
+	"unicode/utf8"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// maxIssuanceAttemptMessageLength is the maximum length in bytes of the
+// message of an attempt recorded in the issuance history, which keeps the
+// size of the Certificate's status bounded.
+const maxIssuanceAttemptMessageLength = 512
+
+// truncatedSuffix is appended to messages which have been truncated.
+const truncatedSuffix = "... (truncated)"
+
+// RecordIssuanceAttempt appends attempt to the issuance history of the
+// Certificate, removing the oldest attempts so that at most limit attempts are
+// kept. Messages which are too long are truncated.
+// If the newest attempt in the history used the same CertificateRequest, it is
+// replaced rather than appended to, so that an attempt is only recorded once
+// if the Certificate is processed again before the updated status has been
+// observed. A limit of 0 clears the history.
+func RecordIssuanceAttempt(crt *cmapi.Certificate, attempt cmapi.CertificateIssuanceAttempt, limit int) {
+	if limit <= 0 {
+		crt.Status.IssuanceHistory = nil
+		return
+	}
+
+	attempt.Message = truncateMessage(attempt.Message, maxIssuanceAttemptMessageLength)
+
+	history := slices.Clone(crt.Status.IssuanceHistory)
+	if n := len(history); n > 0 && sameIssuanceAttempt(history[n-1], attempt) {
+		history = history[:n-1]
+	}
+	history = append(history, attempt)
+	if len(history) > limit {
+		history = history[len(history)-limit:]
+	}
+	crt.Status.IssuanceHistory = history
+}
+
+// sameIssuanceAttempt returns true if both attempts used the same
+// CertificateRequest. A CertificateRequest which has been re-created with the
+// same name is identified by its creation time.
+func sameIssuanceAttempt(a, b cmapi.CertificateIssuanceAttempt) bool {
+	return a.CertificateRequestName == b.CertificateRequestName &&
+		a.StartTime.Equal(b.StartTime)
+}
+
+// truncateMessage truncates message to at most maxLength bytes, without
+// splitting a multi-byte character.
+func truncateMessage(message string, maxLength int) string {
+	if len(message) <= maxLength {
+		return message
+	}
+	cut := maxLength - len(truncatedSuffix)
+	for cut > 0 && !utf8.RuneStart(message[cut]) {
+		cut--
+	}
+	return message[:cut] + truncatedSuffix
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+func Test_RecordIssuanceAttempt(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	attempt := func(revision int, outcome cmapi.CertificateIssuanceOutcome) cmapi.CertificateIssuanceAttempt {
+		startTime := metav1.NewTime(start.Add(time.Duration(revision) * time.Hour))
+		endTime := metav1.NewTime(startTime.Add(time.Minute))
+		return cmapi.CertificateIssuanceAttempt{
+			Revision:               revision,
+			CertificateRequestName: fmt.Sprintf("test-%d", revision),
+			StartTime:              &startTime,
+			EndTime:                &endTime,
+			Outcome:                outcome,
+		}
+	}
+	history := func(attempts ...cmapi.CertificateIssuanceAttempt) []cmapi.CertificateIssuanceAttempt {
+		return attempts
+	}
+
+	tests := map[string]struct {
+		history []cmapi.CertificateIssuanceAttempt
+		attempt cmapi.CertificateIssuanceAttempt
+		limit   int
+
+		expHistory []cmapi.CertificateIssuanceAttempt
+	}{
+		"the first attempt is recorded": {
+			attempt:    attempt(1, cmapi.CertificateIssuanceOutcomeIssued),
+			limit:      5,
+			expHistory: history(attempt(1, cmapi.CertificateIssuanceOutcomeIssued)),
+		},
+		"attempts are appended after older attempts": {
+			history:    history(attempt(1, cmapi.CertificateIssuanceOutcomeIssued), attempt(2, cmapi.CertificateIssuanceOutcomeFailed)),
+			attempt:    attempt(3, cmapi.CertificateIssuanceOutcomeIssued),
+			limit:      5,
+			expHistory: history(attempt(1, cmapi.CertificateIssuanceOutcomeIssued), attempt(2, cmapi.CertificateIssuanceOutcomeFailed), attempt(3, cmapi.CertificateIssuanceOutcomeIssued)),
+		},
+		"the oldest attempts are removed once the limit is reached": {
+			history:    history(attempt(1, cmapi.CertificateIssuanceOutcomeIssued), attempt(2, cmapi.CertificateIssuanceOutcomeFailed), attempt(3, cmapi.CertificateIssuanceOutcomeFailed)),
+			attempt:    attempt(4, cmapi.CertificateIssuanceOutcomeIssued),
+			limit:      2,
+			expHistory: history(attempt(3, cmapi.CertificateIssuanceOutcomeFailed), attempt(4, cmapi.CertificateIssuanceOutcomeIssued)),
+		},
+		"an attempt using the same CertificateRequest as the newest attempt replaces it": {
+			history:    history(attempt(1, cmapi.CertificateIssuanceOutcomeIssued), attempt(2, cmapi.CertificateIssuanceOutcomeFailed)),
+			attempt:    attempt(2, cmapi.CertificateIssuanceOutcomeFailed),
+			limit:      5,
+			expHistory: history(attempt(1, cmapi.CertificateIssuanceOutcomeIssued), attempt(2, cmapi.CertificateIssuanceOutcomeFailed)),
+		},
+		"an attempt using a re-created CertificateRequest with the same name is appended": {
+			history: history(attempt(1, cmapi.CertificateIssuanceOutcomeFailed)),
+			attempt: func() cmapi.CertificateIssuanceAttempt {
+				a := attempt(2, cmapi.CertificateIssuanceOutcomeIssued)
+				a.CertificateRequestName = "test-1"
+				return a
+			}(),
+			limit: 5,
+			expHistory: history(attempt(1, cmapi.CertificateIssuanceOutcomeFailed), func() cmapi.CertificateIssuanceAttempt {
+				a := attempt(2, cmapi.CertificateIssuanceOutcomeIssued)
+				a.CertificateRequestName = "test-1"
+				return a
+			}()),
+		},
+		"a limit of 0 clears the history": {
+			history: history(attempt(1, cmapi.CertificateIssuanceOutcomeIssued)),
+			attempt: attempt(2, cmapi.CertificateIssuanceOutcomeIssued),
+			limit:   0,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			crt := &cmapi.Certificate{Status: cmapi.CertificateStatus{IssuanceHistory: test.history}}
+			RecordIssuanceAttempt(crt, test.attempt, test.limit)
+			assert.Equal(t, test.expHistory, crt.Status.IssuanceHistory)
+		})
+	}
+}
+
+func Test_RecordIssuanceAttemptTruncatesMessage(t *testing.T) {
+	crt := &cmapi.Certificate{}
+	RecordIssuanceAttempt(crt, cmapi.CertificateIssuanceAttempt{
+		Outcome: cmapi.CertificateIssuanceOutcomeFailed,
+		Message: strings.Repeat("ü", 1000),
+	}, 5)
+
+	message := crt.Status.IssuanceHistory[0].Message
+	assert.LessOrEqual(t, len(message), maxIssuanceAttemptMessageLength)
+	assert.True(t, strings.HasSuffix(message, truncatedSuffix))
+	assert.Equal(t, strings.Repeat("ü", (maxIssuanceAttemptMessageLength-len(truncatedSuffix))/2)+truncatedSuffix, message)
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fake contains an in-memory key management service used to test
+// external private keys.
+package fake
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"fmt"
+	"sync"
+
+	"github.com/cert-manager/cert-manager/internal/controller/certificates/keyprovider"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// KMS is an in-memory implementation of keyprovider.SignerService.
+type KMS struct {
+	lock    sync.Mutex
+	keys    map[string]crypto.Signer
+	created int
+}
+
+var _ keyprovider.SignerService = &KMS{}
+
+func NewKMS() *KMS {
+	return &KMS{keys: make(map[string]crypto.Signer)}
+}
+
+// AddKey stores the given key under ref, so that tests can refer to keys
+// whose material they already know.
+func (k *KMS) AddKey(ref string, key crypto.Signer) {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	k.keys[ref] = key
+}
+
+// Key returns the key stored under ref, or nil if there is none.
+func (k *KMS) Key(ref string) crypto.Signer {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	return k.keys[ref]
+}
+
+// Refs returns the references of the keys held by the KMS.
+func (k *KMS) Refs() []string {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	refs := make([]string, 0, len(k.keys))
+	for ref := range k.keys {
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+func (k *KMS) CreateKey(_ context.Context, req *keyprovider.CreateKeyRequest) (*keyprovider.CreateKeyResponse, error) {
+	key, err := pki.GeneratePrivateKeyForCertificate(&cmapi.Certificate{
+		Spec: cmapi.CertificateSpec{
+			PrivateKey: &cmapi.CertificatePrivateKey{
+				Algorithm: cmapi.PrivateKeyAlgorithm(req.Algorithm),
+				Size:      req.Size,
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	publicKey, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	k.created++
+	ref := fmt.Sprintf("%s/keys/%d", req.KeyRing, k.created)
+	k.keys[ref] = key
+	return &keyprovider.CreateKeyResponse{KeyRef: ref, PublicKey: publicKey}, nil
+}
+
+func (k *KMS) GetPublicKey(_ context.Context, req *keyprovider.GetPublicKeyRequest) (*keyprovider.GetPublicKeyResponse, error) {
+	key := k.Key(req.KeyRef)
+	if key == nil {
+		return nil, fmt.Errorf("key %q not found", req.KeyRef)
+	}
+	publicKey, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		return nil, err
+	}
+	return &keyprovider.GetPublicKeyResponse{PublicKey: publicKey}, nil
+}
+
+func (k *KMS) DeleteKey(_ context.Context, req *keyprovider.DeleteKeyRequest) (*keyprovider.DeleteKeyResponse, error) {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	if _, ok := k.keys[req.KeyRef]; !ok {
+		return nil, fmt.Errorf("key %q not found", req.KeyRef)
+	}
+	delete(k.keys, req.KeyRef)
+	return &keyprovider.DeleteKeyResponse{}, nil
+}
+
+func (k *KMS) SignDigest(_ context.Context, req *keyprovider.SignDigestRequest) (*keyprovider.SignDigestResponse, error) {
+	key := k.Key(req.KeyRef)
+	if key == nil {
+		return nil, fmt.Errorf("key %q not found", req.KeyRef)
+	}
+	hash, err := keyprovider.ParseHash(req.Hash)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := key.Sign(rand.Reader, req.Digest, hash)
+	if err != nil {
+		return nil, err
+	}
+	return &keyprovider.SignDigestResponse{Signature: signature}, nil
+}
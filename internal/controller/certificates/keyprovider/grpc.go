@@ -0,0 +1,165 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyprovider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding"
+)
+
+// The signer service is small enough that its messages are encoded as JSON
+// rather than protocol buffers, so that plugins can be written without any
+// generated code. The codec is only used by the signer client and servers
+// created by NewServer, rather than being registered globally, so that it
+// does not replace the codec of other gRPC clients in the same process.
+const (
+	codecName   = "json"
+	serviceName = "certmanager.kms.v1alpha1.Signer"
+)
+
+var _ encoding.Codec = jsonCodec{}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonCodec) Name() string {
+	return codecName
+}
+
+// Dial returns a SignerService which calls the signer plugin listening on the
+// given address, such as unix:///run/kms/signer.sock. The plugin is expected
+// to run next to the controller, so the connection is not encrypted.
+func Dial(address string, opts ...grpc.DialOption) (SignerService, error) {
+	opts = append([]grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(jsonCodec{})),
+	}, opts...)
+	conn, err := grpc.NewClient(address, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signer client for %q: %w", address, err)
+	}
+	return &client{conn: conn}, nil
+}
+
+type client struct {
+	conn *grpc.ClientConn
+}
+
+func (c *client) CreateKey(ctx context.Context, req *CreateKeyRequest) (*CreateKeyResponse, error) {
+	resp := new(CreateKeyResponse)
+	if err := c.conn.Invoke(ctx, "/"+serviceName+"/CreateKey", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *client) GetPublicKey(ctx context.Context, req *GetPublicKeyRequest) (*GetPublicKeyResponse, error) {
+	resp := new(GetPublicKeyResponse)
+	if err := c.conn.Invoke(ctx, "/"+serviceName+"/GetPublicKey", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *client) DeleteKey(ctx context.Context, req *DeleteKeyRequest) (*DeleteKeyResponse, error) {
+	resp := new(DeleteKeyResponse)
+	if err := c.conn.Invoke(ctx, "/"+serviceName+"/DeleteKey", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *client) SignDigest(ctx context.Context, req *SignDigestRequest) (*SignDigestResponse, error) {
+	resp := new(SignDigestResponse)
+	if err := c.conn.Invoke(ctx, "/"+serviceName+"/SignDigest", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// NewServer returns a gRPC server serving the given implementation of the
+// signer service, which decodes requests using the signer service's codec.
+// It is used by signer plugins written in Go, and by tests.
+func NewServer(service SignerService, opts ...grpc.ServerOption) *grpc.Server {
+	s := grpc.NewServer(append([]grpc.ServerOption{grpc.ForceServerCodec(jsonCodec{})}, opts...)...)
+	s.RegisterService(&signerServiceDesc, service)
+	return s
+}
+
+var signerServiceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*SignerService)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateKey",
+			Handler: unaryHandler("CreateKey", func(ctx context.Context, srv SignerService, req *CreateKeyRequest) (any, error) {
+				return srv.CreateKey(ctx, req)
+			}),
+		},
+		{
+			MethodName: "GetPublicKey",
+			Handler: unaryHandler("GetPublicKey", func(ctx context.Context, srv SignerService, req *GetPublicKeyRequest) (any, error) {
+				return srv.GetPublicKey(ctx, req)
+			}),
+		},
+		{
+			MethodName: "DeleteKey",
+			Handler: unaryHandler("DeleteKey", func(ctx context.Context, srv SignerService, req *DeleteKeyRequest) (any, error) {
+				return srv.DeleteKey(ctx, req)
+			}),
+		},
+		{
+			MethodName: "SignDigest",
+			Handler: unaryHandler("SignDigest", func(ctx context.Context, srv SignerService, req *SignDigestRequest) (any, error) {
+				return srv.SignDigest(ctx, req)
+			}),
+		},
+	},
+}
+
+// unaryHandler adapts a SignerService method to the handler signature used
+// by grpc.MethodDesc.
+func unaryHandler[Req any](method string, call func(context.Context, SignerService, *Req) (any, error)) func(any, context.Context, func(any) error, grpc.UnaryServerInterceptor) (any, error) {
+	return func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+		req := new(Req)
+		if err := dec(req); err != nil {
+			return nil, err
+		}
+		if interceptor == nil {
+			return call(ctx, srv.(SignerService), req)
+		}
+		info := &grpc.UnaryServerInfo{
+			Server:     srv,
+			FullMethod: "/" + serviceName + "/" + method,
+		}
+		return interceptor(ctx, req, info, func(ctx context.Context, req any) (any, error) {
+			return call(ctx, srv.(SignerService), req.(*Req))
+		})
+	}
+}
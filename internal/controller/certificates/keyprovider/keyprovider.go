@@ -0,0 +1,217 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package keyprovider contains the interface used by the certificates
+// controllers to create and use private keys which are held by an external
+// key management service (KMS), along with a client for signer plugins
+// which implement that service over gRPC.
+package keyprovider
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// Provider creates and uses private keys which are held outside of
+// cert-manager. Only references to the keys are ever stored in the cluster.
+type Provider interface {
+	// CreateKey creates a new private key matching the private key spec of
+	// the Certificate, and returns a reference to it. Defaults must already
+	// have been applied to the Certificate's private key spec.
+	CreateKey(ctx context.Context, crt *cmapi.Certificate) (string, error)
+
+	// Signer returns a crypto.Signer for the private key with the given
+	// reference. Signing is performed by the key management service, so the
+	// returned Signer must only be used for the duration of ctx.
+	Signer(ctx context.Context, ref string) (crypto.Signer, error)
+
+	// DeleteKey deletes the private key with the given reference. It is used
+	// to clean up keys whose reference could not be stored, which would
+	// otherwise be left behind in the key management service.
+	DeleteKey(ctx context.Context, ref string) error
+}
+
+// SignerService is the service implemented by signer plugins. Keys are
+// identified by an opaque reference chosen by the plugin, and public keys are
+// exchanged as DER encoded PKIX structures.
+type SignerService interface {
+	CreateKey(context.Context, *CreateKeyRequest) (*CreateKeyResponse, error)
+	GetPublicKey(context.Context, *GetPublicKeyRequest) (*GetPublicKeyResponse, error)
+	DeleteKey(context.Context, *DeleteKeyRequest) (*DeleteKeyResponse, error)
+	SignDigest(context.Context, *SignDigestRequest) (*SignDigestResponse, error)
+}
+
+type CreateKeyRequest struct {
+	// KeyRing is the Certificate's spec.privateKey.external.keyRing.
+	KeyRing string `json:"keyRing"`
+	// Certificate is the namespaced name of the Certificate the key is
+	// created for, which plugins may use to label the key.
+	Certificate string `json:"certificate"`
+	// Algorithm is one of RSA, ECDSA or Ed25519.
+	Algorithm string `json:"algorithm"`
+	// Size is the RSA key size or ECDSA curve size. It is 0 for Ed25519.
+	Size int `json:"size,omitempty"`
+}
+
+type CreateKeyResponse struct {
+	KeyRef    string `json:"keyRef"`
+	PublicKey []byte `json:"publicKey"`
+}
+
+type GetPublicKeyRequest struct {
+	KeyRef string `json:"keyRef"`
+}
+
+type GetPublicKeyResponse struct {
+	PublicKey []byte `json:"publicKey"`
+}
+
+type DeleteKeyRequest struct {
+	KeyRef string `json:"keyRef"`
+}
+
+type DeleteKeyResponse struct{}
+
+type SignDigestRequest struct {
+	KeyRef string `json:"keyRef"`
+	// Digest is the digest to sign, or the whole message for Ed25519 keys.
+	Digest []byte `json:"digest"`
+	// Hash is the name of the hash function used to compute the digest, as
+	// returned by crypto.Hash.String, or empty for Ed25519 keys.
+	Hash string `json:"hash,omitempty"`
+}
+
+type SignDigestResponse struct {
+	Signature []byte `json:"signature"`
+}
+
+// NewProvider returns a Provider which creates and uses keys using the given
+// signer service.
+func NewProvider(service SignerService) Provider {
+	return &provider{service: service}
+}
+
+type provider struct {
+	service SignerService
+}
+
+func (p *provider) CreateKey(ctx context.Context, crt *cmapi.Certificate) (string, error) {
+	if crt.Spec.PrivateKey == nil || crt.Spec.PrivateKey.External == nil {
+		return "", fmt.Errorf("certificate %s/%s does not use an external private key", crt.Namespace, crt.Name)
+	}
+
+	req := &CreateKeyRequest{
+		KeyRing:     crt.Spec.PrivateKey.External.KeyRing,
+		Certificate: crt.Namespace + "/" + crt.Name,
+		Algorithm:   string(crt.Spec.PrivateKey.Algorithm),
+		Size:        crt.Spec.PrivateKey.Size,
+	}
+	// Signer plugins are always given an explicit algorithm and size.
+	switch crt.Spec.PrivateKey.Algorithm {
+	case "", cmapi.RSAKeyAlgorithm:
+		req.Algorithm = string(cmapi.RSAKeyAlgorithm)
+		if req.Size == 0 {
+			req.Size = pki.MinRSAKeySize
+		}
+	case cmapi.ECDSAKeyAlgorithm:
+		if req.Size == 0 {
+			req.Size = pki.ECCurve256
+		}
+	case cmapi.Ed25519KeyAlgorithm:
+		req.Size = 0
+	default:
+		return "", fmt.Errorf("unsupported private key algorithm %q", crt.Spec.PrivateKey.Algorithm)
+	}
+
+	resp, err := p.service.CreateKey(ctx, req)
+	if err != nil {
+		return "", fmt.Errorf("failed to create key in key ring %q: %w", req.KeyRing, err)
+	}
+	if resp.KeyRef == "" {
+		return "", errors.New("signer returned an empty key reference")
+	}
+	return resp.KeyRef, nil
+}
+
+func (p *provider) Signer(ctx context.Context, ref string) (crypto.Signer, error) {
+	resp, err := p.service.GetPublicKey(ctx, &GetPublicKeyRequest{KeyRef: ref})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get public key of %q: %w", ref, err)
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(resp.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("signer returned an invalid public key for %q: %w", ref, err)
+	}
+	return &signer{ctx: ctx, service: p.service, ref: ref, publicKey: publicKey}, nil
+}
+
+func (p *provider) DeleteKey(ctx context.Context, ref string) error {
+	if _, err := p.service.DeleteKey(ctx, &DeleteKeyRequest{KeyRef: ref}); err != nil {
+		return fmt.Errorf("failed to delete key %q: %w", ref, err)
+	}
+	return nil
+}
+
+// signer is a crypto.Signer which signs digests using the signer service.
+type signer struct {
+	ctx       context.Context
+	service   SignerService
+	ref       string
+	publicKey crypto.PublicKey
+}
+
+func (s *signer) Public() crypto.PublicKey {
+	return s.publicKey
+}
+
+func (s *signer) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	if _, ok := opts.(*rsa.PSSOptions); ok {
+		return nil, errors.New("RSA-PSS signatures are not supported by external private keys")
+	}
+
+	req := &SignDigestRequest{KeyRef: s.ref, Digest: digest}
+	if hash := opts.HashFunc(); hash != 0 {
+		req.Hash = hash.String()
+	}
+	resp, err := s.service.SignDigest(s.ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign using %q: %w", s.ref, err)
+	}
+	return resp.Signature, nil
+}
+
+// ParseHash returns the hash function with the given name, as sent in a
+// SignDigestRequest. An empty name returns the zero crypto.Hash, which is
+// used for Ed25519 keys.
+func ParseHash(name string) (crypto.Hash, error) {
+	for _, hash := range []crypto.Hash{crypto.SHA256, crypto.SHA384, crypto.SHA512} {
+		if hash.String() == name {
+			return hash, nil
+		}
+	}
+	if name == "" {
+		return 0, nil
+	}
+	return 0, fmt.Errorf("unsupported hash function %q", name)
+}
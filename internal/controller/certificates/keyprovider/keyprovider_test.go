@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyprovider_test
+
+import (
+	"context"
+	"crypto/x509"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/cert-manager/cert-manager/internal/controller/certificates/keyprovider"
+	"github.com/cert-manager/cert-manager/internal/controller/certificates/keyprovider/fake"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+func Test_ProviderOverGRPC(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	kms := fake.NewKMS()
+	lis := bufconn.Listen(1024 * 1024)
+	server := keyprovider.NewServer(kms)
+	go func() { _ = server.Serve(lis) }()
+	defer server.Stop()
+
+	service, err := keyprovider.Dial("passthrough:///bufnet", grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.DialContext(ctx)
+	}))
+	require.NoError(t, err)
+	provider := keyprovider.NewProvider(service)
+
+	tests := map[string]cmapi.CertificatePrivateKey{
+		"RSA":     {Algorithm: cmapi.RSAKeyAlgorithm, Size: 2048},
+		"ECDSA":   {Algorithm: cmapi.ECDSAKeyAlgorithm, Size: 384},
+		"Ed25519": {Algorithm: cmapi.Ed25519KeyAlgorithm},
+		"default": {},
+	}
+	for name, privateKey := range tests {
+		t.Run(name, func(t *testing.T) {
+			privateKey.External = &cmapi.CertificateExternalPrivateKey{KeyRing: "projects/test/keyRings/cert-manager"}
+			crt := &cmapi.Certificate{
+				Spec: cmapi.CertificateSpec{
+					CommonName: "example.com",
+					PrivateKey: &privateKey,
+				},
+			}
+
+			ref, err := provider.CreateKey(ctx, crt)
+			require.NoError(t, err)
+			assert.NotNil(t, kms.Key(ref))
+
+			signer, err := provider.Signer(ctx, ref)
+			require.NoError(t, err)
+			violations, err := pki.PrivateKeyMatchesSpec(signer, crt.Spec)
+			require.NoError(t, err)
+			assert.Empty(t, violations)
+
+			template, err := pki.GenerateCSR(crt)
+			require.NoError(t, err)
+			csrDER, err := pki.EncodeCSR(template, signer)
+			require.NoError(t, err)
+			csr, err := x509.ParseCertificateRequest(csrDER)
+			require.NoError(t, err)
+			assert.NoError(t, csr.CheckSignature())
+
+			require.NoError(t, provider.DeleteKey(ctx, ref))
+			assert.Nil(t, kms.Key(ref))
+		})
+	}
+
+	_, err = provider.Signer(ctx, "unknown")
+	assert.Error(t, err)
+}
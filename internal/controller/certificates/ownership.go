@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/pkg/util/predicate"
+)
+
+// CertificateRequestOwnedBy returns a predicate matching the
+// CertificateRequests which are owned by the given Certificate. If the
+// AdoptRestoredCertificateRequests feature gate is enabled, it also matches
+// CertificateRequests which have been restored together with the Certificate,
+// see IsRestoredCertificateRequest.
+func CertificateRequestOwnedBy(crt *cmapi.Certificate) predicate.Func {
+	ownedBy := predicate.ResourceOwnedBy(crt)
+	if !utilfeature.DefaultFeatureGate.Enabled(feature.AdoptRestoredCertificateRequests) {
+		return ownedBy
+	}
+	return func(obj runtime.Object) bool {
+		if ownedBy(obj) {
+			return true
+		}
+		req, ok := obj.(*cmapi.CertificateRequest)
+		return ok && IsRestoredCertificateRequest(crt, req)
+	}
+}
+
+// IsRestoredCertificateRequest returns true if the CertificateRequest is
+// controlled by a Certificate with the name of the given Certificate but a
+// different UID. Restoring a backup assigns new UIDs to the restored
+// resources, but keeps the owner references of their dependents pointing at
+// the old UIDs. To make sure that the CertificateRequest really belongs to the
+// Certificate, it must also be annotated with the name of the Certificate, and
+// match its spec.
+func IsRestoredCertificateRequest(crt *cmapi.Certificate, req *cmapi.CertificateRequest) bool {
+	ref := metav1.GetControllerOf(req)
+	if ref == nil || ref.UID == crt.UID || ref.Name != crt.Name || ref.Kind != cmapi.CertificateKind {
+		return false
+	}
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil || gv.Group != cmapi.SchemeGroupVersion.Group {
+		return false
+	}
+
+	if req.Annotations[cmapi.CertificateNameKey] != crt.Name {
+		return false
+	}
+
+	violations, err := utilpki.RequestMatchesSpec(req, crt.Spec)
+	return err == nil && len(violations) == 0
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	featuregatetesting "k8s.io/component-base/featuregate/testing"
+
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestIsRestoredCertificateRequest(t *testing.T) {
+	issuerRef := cmmeta.ObjectReference{Name: "ca-issuer", Kind: "Issuer"}
+	crt := gen.Certificate("crt",
+		gen.SetCertificateNamespace("ns"),
+		gen.SetCertificateUID("restored-uid"),
+		gen.SetCertificateDNSNames("example.com"),
+		gen.SetCertificateIssuer(issuerRef),
+	)
+	csr := testcrypto.MustGenerateCSRImpl(t, testcrypto.MustCreatePEMPrivateKey(t), crt)
+
+	// restoredCR returns a CertificateRequest as it is restored from a backup
+	// taken before the Certificate was restored with a new UID.
+	restoredCR := func(mods ...gen.CertificateRequestModifier) *cmapi.CertificateRequest {
+		return gen.CertificateRequest("crt-1", append([]gen.CertificateRequestModifier{
+			gen.SetCertificateRequestNamespace("ns"),
+			gen.SetCertificateRequestCSR(csr),
+			gen.SetCertificateRequestIssuer(issuerRef),
+			gen.AddCertificateRequestOwnerReferences(gen.CertificateRef("crt", "stale-uid")),
+			gen.AddCertificateRequestAnnotations(map[string]string{cmapi.CertificateNameKey: "crt"}),
+		}, mods...)...)
+	}
+
+	tests := map[string]struct {
+		req  *cmapi.CertificateRequest
+		want bool
+	}{
+		"a restored request matching the certificate": {
+			req:  restoredCR(),
+			want: true,
+		},
+		"a request owned by the certificate is not a restored request": {
+			req: restoredCR(func(req *cmapi.CertificateRequest) {
+				req.OwnerReferences = []metav1.OwnerReference{gen.CertificateRef("crt", "restored-uid")}
+			}),
+			want: false,
+		},
+		"a request owned by a certificate with another name": {
+			req: restoredCR(func(req *cmapi.CertificateRequest) {
+				req.OwnerReferences = []metav1.OwnerReference{gen.CertificateRef("other-crt", "stale-uid")}
+			}),
+			want: false,
+		},
+		"a request without an owner": {
+			req: restoredCR(func(req *cmapi.CertificateRequest) {
+				req.OwnerReferences = nil
+			}),
+			want: false,
+		},
+		"a request annotated with the name of another certificate": {
+			req:  restoredCR(gen.AddCertificateRequestAnnotations(map[string]string{cmapi.CertificateNameKey: "other-crt"})),
+			want: false,
+		},
+		"a request which does not match the spec of the certificate": {
+			req:  restoredCR(gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "other-issuer", Kind: "Issuer"})),
+			want: false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.want, IsRestoredCertificateRequest(crt, test.req))
+		})
+	}
+}
+
+func TestCertificateRequestOwnedBy(t *testing.T) {
+	crt := gen.Certificate("crt",
+		gen.SetCertificateNamespace("ns"),
+		gen.SetCertificateUID("restored-uid"),
+		gen.SetCertificateDNSNames("example.com"),
+	)
+	owned := gen.CertificateRequest("crt-1",
+		gen.SetCertificateRequestNamespace("ns"),
+		gen.AddCertificateRequestOwnerReferences(gen.CertificateRef("crt", "restored-uid")),
+	)
+	restored := gen.CertificateRequest("crt-2",
+		gen.SetCertificateRequestNamespace("ns"),
+		gen.SetCertificateRequestCSR(testcrypto.MustGenerateCSRImpl(t, testcrypto.MustCreatePEMPrivateKey(t), crt)),
+		gen.AddCertificateRequestOwnerReferences(gen.CertificateRef("crt", "stale-uid")),
+		gen.AddCertificateRequestAnnotations(map[string]string{cmapi.CertificateNameKey: "crt"}),
+	)
+
+	t.Run("restored requests are not matched by default", func(t *testing.T) {
+		ownedBy := CertificateRequestOwnedBy(crt)
+		assert.True(t, ownedBy(owned))
+		assert.False(t, ownedBy(restored))
+	})
+
+	t.Run("restored requests are matched if AdoptRestoredCertificateRequests is enabled", func(t *testing.T) {
+		defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, feature.AdoptRestoredCertificateRequests, true)()
+		ownedBy := CertificateRequestOwnedBy(crt)
+		assert.True(t, ownedBy(owned))
+		assert.True(t, ownedBy(restored))
+	})
+}
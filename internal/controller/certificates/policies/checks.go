@@ -19,7 +19,9 @@ package policies
 import (
 	"bytes"
 	"crypto/x509"
+	"encoding/pem"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -32,42 +34,90 @@ import (
 	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
 	"sigs.k8s.io/structured-merge-diff/v4/value"
 
-	cmmeta "github.com/cert-manager/cert-manager/internal/apis/meta"
 	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
 func SecretDoesNotExist(input Input) (string, string, bool) {
 	if input.Secret == nil {
-		return DoesNotExist, "Issuing certificate as Secret does not exist", true
+		return secretReason(SecretMissing, DoesNotExist), "Issuing certificate as Secret does not exist", true
 	}
 	return "", "", false
 }
 
 func SecretIsMissingData(input Input) (string, string, bool) {
-	if input.Secret.Data == nil {
-		return MissingData, "Issuing certificate as Secret does not contain any data", true
+	if secretIsEmpty(input.Secret) {
+		return secretReason(SecretEmpty, MissingData), "Issuing certificate as Secret does not contain any data", true
 	}
-	pkData := input.Secret.Data[corev1.TLSPrivateKeyKey]
-	certData := input.Secret.Data[corev1.TLSCertKey]
-	if len(pkData) == 0 {
-		return MissingData, "Issuing certificate as Secret does not contain a private key", true
+	if len(input.Secret.Data[corev1.TLSPrivateKeyKey]) == 0 && !privateKeyHeldExternally(input) {
+		return secretReason(SecretKeyMissing, MissingData), fmt.Sprintf("Issuing certificate as Secret does not contain a private key in %q", corev1.TLSPrivateKeyKey), true
 	}
-	if len(certData) == 0 {
-		return MissingData, "Issuing certificate as Secret does not contain a certificate", true
+	if input.Certificate != nil && internalcertificates.UsesExternalPrivateKey(input.Certificate.Spec) &&
+		len(input.Secret.Data[cmapi.PrivateKeyReferenceSecretKey]) == 0 {
+		return secretReason(SecretKeyMissing, MissingData), fmt.Sprintf("Issuing certificate as Secret does not contain a private key reference in %q", cmapi.PrivateKeyReferenceSecretKey), true
+	}
+	if len(input.Secret.Data[corev1.TLSCertKey]) == 0 {
+		return secretReason(SecretCertMissing, MissingData), fmt.Sprintf("Issuing certificate as Secret does not contain a certificate in %q", corev1.TLSCertKey), true
 	}
 	return "", "", false
 }
 
+// secretIsEmpty returns true if none of the Secret's keys hold any data.
+func secretIsEmpty(secret *corev1.Secret) bool {
+	for _, v := range secret.Data {
+		if len(v) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// secretReason returns the legacy reason instead of the given reason if the
+// LegacySecretPolicyReasons feature gate is enabled, so that users can migrate
+// alerts relying on the reasons reported before the Secret policy violation
+// reasons were made consistent.
+func secretReason(reason, legacyReason string) string {
+	if utilfeature.DefaultFeatureGate.Enabled(feature.LegacySecretPolicyReasons) {
+		return legacyReason
+	}
+	return reason
+}
+
+// invalidPrivateKey returns the policy violation for private key data in the
+// Secret which could not be decoded.
+func invalidPrivateKey(err error) (string, string, bool) {
+	return secretReason(SecretDataInvalid, InvalidKeyPair), fmt.Sprintf("Issuing certificate as Secret contains invalid private key data in %q: %v", corev1.TLSPrivateKeyKey, err), true
+}
+
+// invalidCertificate returns the policy violation for certificate data in the
+// Secret which could not be decoded.
+func invalidCertificate(err error) (string, string, bool) {
+	return secretReason(SecretDataInvalid, InvalidCertificate), fmt.Sprintf("Issuing certificate as Secret contains an invalid certificate in %q: %v", corev1.TLSCertKey, err), true
+}
+
 func SecretPublicKeysDiffer(input Input) (string, string, bool) {
-	pk, err := pki.DecodePrivateKeyBytes(input.Secret.Data[corev1.TLSPrivateKeyKey])
+	if privateKeyHeldExternally(input) {
+		// There is no private key to compare against, but the certificate
+		// must still be valid.
+		if _, err := input.secretCertificate(); err != nil {
+			return invalidCertificate(err)
+		}
+		return "", "", false
+	}
+
+	pk, err := input.secretPrivateKey()
 	if err != nil {
-		return InvalidKeyPair, fmt.Sprintf("Issuing certificate as Secret contains invalid private key data: %v", err), true
+		return invalidPrivateKey(err)
 	}
-	x509Cert, err := pki.DecodeX509CertificateBytes(input.Secret.Data[corev1.TLSCertKey])
+	x509Cert, err := input.secretCertificate()
 	if err != nil {
-		return InvalidCertificate, fmt.Sprintf("Issuing certificate as Secret contains an invalid certificate: %v", err), true
+		return invalidCertificate(err)
 	}
 
 	equal, err := pki.PublicKeysEqual(x509Cert.PublicKey, pk.Public())
@@ -81,20 +131,30 @@ func SecretPublicKeysDiffer(input Input) (string, string, bool) {
 	return "", "", false
 }
 
-func SecretPrivateKeyMismatchesSpec(input Input) (string, string, bool) {
-	pk, err := pki.DecodePrivateKeyBytes(input.Secret.Data[corev1.TLSPrivateKeyKey])
-	if err != nil {
-		return InvalidKeyPair, fmt.Sprintf("Issuing certificate as Secret contains invalid private key data: %v", err), true
-	}
+// SecretPrivateKeyMismatchesSpec returns a policy function which checks that
+// the Secret's private key matches the Certificate spec, applying the given
+// defaults to unset private key fields.
+func SecretPrivateKeyMismatchesSpec(defaults internalcertificates.PrivateKeyDefaults) Func {
+	return func(input Input) (string, string, bool) {
+		if privateKeyHeldExternally(input) {
+			// The private key is not managed by cert-manager.
+			return "", "", false
+		}
 
-	violations, err := pki.PrivateKeyMatchesSpec(pk, input.Certificate.Spec)
-	if err != nil {
-		return SecretMismatch, fmt.Sprintf("Failed to check private key is up to date: %v", err), true
-	}
-	if len(violations) > 0 {
-		return SecretMismatch, fmt.Sprintf("Existing private key is not up to date for spec: %v", violations), true
+		pk, err := input.secretPrivateKey()
+		if err != nil {
+			return invalidPrivateKey(err)
+		}
+
+		violations, err := defaults.PrivateKeyMatchesSpec(pk, input.Certificate.Spec, input.Secret)
+		if err != nil {
+			return SecretMismatch, fmt.Sprintf("Failed to check private key is up to date: %v", err), true
+		}
+		if len(violations) > 0 {
+			return SecretMismatch, fmt.Sprintf("Existing private key is not up to date for spec: %v", violations), true
+		}
+		return "", "", false
 	}
-	return "", "", false
 }
 
 // SecretKeystoreFormatMismatch - When the keystore is not defined, the keystore
@@ -106,6 +166,18 @@ func SecretPrivateKeyMismatchesSpec(input Input) (string, string, bool) {
 func SecretKeystoreFormatMismatch(input Input) (string, string, bool) {
 	_, issuerProvidesCA := input.Secret.Data[cmmeta.TLSCAKey]
 
+	// Keystores written to a separate Secret must not also be present in the
+	// Certificate's Secret. The separate Secret is not checked.
+	if input.Certificate.Spec.Keystores != nil && input.Certificate.Spec.Keystores.SecretName != "" {
+		if len(input.Secret.Data[cmapi.PKCS12SecretKey]) != 0 ||
+			len(input.Secret.Data[cmapi.PKCS12TruststoreKey]) != 0 ||
+			len(input.Secret.Data[cmapi.JKSSecretKey]) != 0 ||
+			len(input.Secret.Data[cmapi.JKSTruststoreKey]) != 0 {
+			return SecretMismatch, "Keystores are written to a separate Secret", true
+		}
+		return "", "", false
+	}
+
 	if input.Certificate.Spec.Keystores == nil {
 		if len(input.Secret.Data[cmapi.PKCS12SecretKey]) != 0 ||
 			len(input.Secret.Data[cmapi.PKCS12TruststoreKey]) != 0 ||
@@ -158,15 +230,27 @@ func SecretKeystoreFormatMismatch(input Input) (string, string, bool) {
 }
 
 // SecretIssuerAnnotationsMismatch - When the issuer annotations are defined,
-// it must match the issuer ref.
+// they must match the issuer ref.
+// Missing annotations, e.g. after the Secret has been restored from a backup
+// which dropped them, do not require a reissuance and are instead repaired by
+// SecretManagedAnnotationsMissing. Each annotation is checked on its own, so
+// that the remaining annotations are still compared if only some of them are
+// missing.
 func SecretIssuerAnnotationsMismatch(input Input) (string, string, bool) {
-	name, ok1 := input.Secret.Annotations[cmapi.IssuerNameAnnotationKey]
-	kind, ok2 := input.Secret.Annotations[cmapi.IssuerKindAnnotationKey]
-	group, ok3 := input.Secret.Annotations[cmapi.IssuerGroupAnnotationKey]
-	if (ok1 || ok2 || ok3) && // only check if an annotation is present
-		name != input.Certificate.Spec.IssuerRef.Name ||
-		!issuerKindsEqual(kind, input.Certificate.Spec.IssuerRef.Kind) ||
-		!issuerGroupsEqual(group, input.Certificate.Spec.IssuerRef.Group) {
+	ref := input.Certificate.Spec.IssuerRef
+	name, ok := input.Secret.Annotations[cmapi.IssuerNameAnnotationKey]
+	if !ok {
+		name = ref.Name
+	}
+	kind, ok := input.Secret.Annotations[cmapi.IssuerKindAnnotationKey]
+	if !ok {
+		kind = ref.Kind
+	}
+	group, ok := input.Secret.Annotations[cmapi.IssuerGroupAnnotationKey]
+	if !ok {
+		group = ref.Group
+	}
+	if !apiutil.IssuerRefsEqual(cmmeta.ObjectReference{Name: name, Kind: kind, Group: group}, ref) {
 		return IncorrectIssuer, fmt.Sprintf("Issuing certificate as Secret was previously issued by %q", formatIssuerRef(name, kind, group)), true
 	}
 	return "", "", false
@@ -183,20 +267,41 @@ func SecretCertificateNameAnnotationsMismatch(input Input) (string, string, bool
 	return "", "", false
 }
 
+// SecretAdoptionDisabled - A Secret which already exists before the
+// Certificate is first issued is adopted if its certificate satisfies the
+// other policies, unless adoption has been disabled using the
+// `cert-manager.io/adopt-existing-secret` annotation, in which case a
+// certificate is issued.
+func SecretAdoptionDisabled(input Input) (string, string, bool) {
+	if input.Certificate.Status.Revision != nil || internalcertificates.AdoptsExistingSecret(input.Certificate) {
+		return "", "", false
+	}
+	return SecretNotAdopted, fmt.Sprintf("Issuing certificate as the existing Secret is not adopted since the %q annotation is set to \"false\"", cmapi.AdoptExistingSecretAnnotationKey), true
+}
+
 // SecretPublicKeyDiffersFromCurrentCertificateRequest checks that the current CertificateRequest
 // contains a CSR that is signed by the key stored in the Secret. A failure is often caused by the
 // Secret being changed outside of the control of cert-manager, causing the current CertificateRequest
 // to no longer match what is stored in the Secret.
+//
+// For Certificates using an external CSR there is no private key in the
+// Secret, so the public key of the certificate is checked against the CSR
+// instead.
 func SecretPublicKeyDiffersFromCurrentCertificateRequest(input Input) (string, string, bool) {
 	if input.CurrentRevisionRequest == nil {
 		return "", "", false
 	}
-	pk, err := pki.DecodePrivateKeyBytes(input.Secret.Data[corev1.TLSPrivateKeyKey])
+
+	if privateKeyHeldExternally(input) {
+		return secretCertificateDiffersFromCurrentCertificateRequest(input)
+	}
+
+	pk, err := input.secretPrivateKey()
 	if err != nil {
 		return InvalidKeyPair, fmt.Sprintf("Issuing certificate as Secret contains invalid private key data: %v", err), true
 	}
 
-	csr, err := pki.DecodeX509CertificateRequestBytes(input.CurrentRevisionRequest.Spec.Request)
+	csr, err := input.currentRequest()
 	if err != nil {
 		return InvalidCertificateRequest, fmt.Sprintf("Failed to decode current CertificateRequest: %v", err), true
 	}
@@ -212,6 +317,64 @@ func SecretPublicKeyDiffersFromCurrentCertificateRequest(input Input) (string, s
 	return "", "", false
 }
 
+// secretCertificateDiffersFromCurrentCertificateRequest checks that the
+// certificate stored in the Secret was issued for the public key of the
+// current CertificateRequest's CSR.
+func secretCertificateDiffersFromCurrentCertificateRequest(input Input) (string, string, bool) {
+	x509Cert, err := input.secretCertificate()
+	if err != nil {
+		return InvalidCertificate, fmt.Sprintf("Issuing certificate as Secret contains an invalid certificate: %v", err), true
+	}
+
+	csr, err := input.currentRequest()
+	if err != nil {
+		return InvalidCertificateRequest, fmt.Sprintf("Failed to decode current CertificateRequest: %v", err), true
+	}
+
+	equal, err := pki.PublicKeysEqual(csr.PublicKey, x509Cert.PublicKey)
+	if err != nil {
+		return InvalidCertificateRequest, fmt.Sprintf("CertificateRequest's public key is invalid: %v", err), true
+	}
+	if !equal {
+		return SecretMismatch, "Secret contains a certificate that does not match the public key of the current CertificateRequest", true
+	}
+
+	return "", "", false
+}
+
+// SecretCertificateIssuedKeyMismatch checks that the certificate stored in the
+// Secret is for the public key recorded on the current CertificateRequest,
+// catching issuers which return a certificate for a different key than the
+// one that was requested.
+func SecretCertificateIssuedKeyMismatch(input Input) (string, string, bool) {
+	if input.CurrentRevisionRequest == nil {
+		return "", "", false
+	}
+
+	x509Cert, err := input.secretCertificate()
+	if err != nil {
+		return InvalidCertificate, fmt.Sprintf("Issuing certificate as Secret contains an invalid certificate: %v", err), true
+	}
+
+	message, mismatch, err := internalcertificates.IssuedPublicKeyMismatch(input.CurrentRevisionRequest, x509Cert)
+	if err != nil {
+		return InvalidCertificateRequest, fmt.Sprintf("Failed to check the public key of the current CertificateRequest: %v", err), true
+	}
+	if mismatch {
+		return IssuedKeyMismatch, message, true
+	}
+
+	return "", "", false
+}
+
+// privateKeyHeldExternally returns true if the Certificate is issued for an
+// externally provided CSR, or its private key is held by an external KMS, in
+// which case the Secret does not contain a private key.
+func privateKeyHeldExternally(input Input) bool {
+	return input.Certificate != nil &&
+		(input.Certificate.Spec.ExternalCSR != nil || internalcertificates.UsesExternalPrivateKey(input.Certificate.Spec))
+}
+
 func CurrentCertificateRequestMismatchesSpec(input Input) (string, string, bool) {
 	if input.CurrentRevisionRequest == nil {
 		// Fallback to comparing the Certificate spec with the issued certificate.
@@ -223,14 +386,22 @@ func CurrentCertificateRequestMismatchesSpec(input Input) (string, string, bool)
 		return currentSecretValidForSpec(input)
 	}
 
-	violations, err := pki.RequestMatchesSpec(input.CurrentRevisionRequest, input.Certificate.Spec)
+	x509req, err := input.currentRequest()
 	if err != nil {
 		// If parsing the request fails, we don't immediately trigger a re-issuance as
 		// the existing certificate stored in the Secret may still be valid/up to date.
 		return "", "", false
 	}
+	violations, err := pki.X509RequestMatchesSpec(input.CurrentRevisionRequest, x509req, input.Certificate.Spec)
+	if err != nil {
+		return "", "", false
+	}
 	if len(violations) > 0 {
-		return RequestChanged, fmt.Sprintf("Fields on existing CertificateRequest resource not up to date: %v", violations), true
+		message := fmt.Sprintf("Fields on existing CertificateRequest resource not up to date: %v", violations)
+		if sets.New(violations...).Has("spec.dnsNames") {
+			message += dnsNamesMismatchMessage(x509req.DNSNames, input.Certificate.Spec.DNSNames)
+		}
+		return RequestChanged, message, true
 	}
 
 	return "", "", false
@@ -240,7 +411,7 @@ func CurrentCertificateRequestMismatchesSpec(input Input) (string, string, bool)
 // and is instead called by currentCertificateRequestValidForSpec if no there
 // is no existing CertificateRequest resource.
 func currentSecretValidForSpec(input Input) (string, string, bool) {
-	violations, err := pki.SecretDataAltNamesMatchSpec(input.Secret, input.Certificate.Spec)
+	x509cert, err := input.secretCertificate()
 	if err != nil {
 		// This case should never be reached as we already check the certificate data can
 		// be parsed in an earlier policy check, but handle it anyway.
@@ -248,40 +419,94 @@ func currentSecretValidForSpec(input Input) (string, string, bool) {
 		return "", "", false
 	}
 
+	violations := pki.CertificateAltNamesMatchSpec(x509cert, input.Certificate.Spec)
 	if len(violations) > 0 {
-		return SecretMismatch, fmt.Sprintf("Issuing certificate as Existing issued Secret is not up to date for spec: %v", violations), true
+		message := fmt.Sprintf("Issuing certificate as Existing issued Secret is not up to date for spec: %v", violations)
+		if sets.New(violations...).Has("spec.dnsNames") {
+			// Names may move freely between the commonName and dnsNames,
+			// see pki.SecretDataAltNamesMatchSpec.
+			message += dnsNamesMismatchMessage(
+				append([]string{x509cert.Subject.CommonName}, x509cert.DNSNames...),
+				append([]string{pki.EffectiveCommonName(input.Certificate.Spec)}, input.Certificate.Spec.DNSNames...),
+			)
+		}
+		return SecretMismatch, message, true
 	}
 
 	return "", "", false
 }
 
+// maxNamesInMessage is the maximum number of names listed in a policy
+// violation message. Certificates may have hundreds of DNS names, and the
+// message is copied to the Certificate's conditions and Events.
+const maxNamesInMessage = 10
+
+// dnsNamesMismatchMessage describes the DNS names which are missing from, or
+// were not requested but are present in, the actual names. It returns an
+// empty string if the names match.
+func dnsNamesMismatchMessage(actual, expected []string) string {
+	actualSet := sets.New(pki.NormalizeDNSNames(actual)...).Delete("")
+	expectedSet := sets.New(pki.NormalizeDNSNames(expected)...).Delete("")
+
+	var parts []string
+	if missing := sets.List(expectedSet.Difference(actualSet)); len(missing) > 0 {
+		parts = append(parts, "missing DNS names: "+formatNames(missing))
+	}
+	if unexpected := sets.List(actualSet.Difference(expectedSet)); len(unexpected) > 0 {
+		parts = append(parts, "unexpected DNS names: "+formatNames(unexpected))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(parts, "; ") + ")"
+}
+
+// formatNames quotes and lists the given sorted names, truncating the list
+// to maxNamesInMessage names followed by "and N more".
+func formatNames(names []string) string {
+	quoted := make([]string, 0, min(len(names), maxNamesInMessage))
+	for _, name := range names[:min(len(names), maxNamesInMessage)] {
+		quoted = append(quoted, fmt.Sprintf("%q", name))
+	}
+	message := strings.Join(quoted, ", ")
+	if len(names) > maxNamesInMessage {
+		message += fmt.Sprintf(" and %d more", len(names)-maxNamesInMessage)
+	}
+	return message
+}
+
 // CurrentCertificateNearingExpiry returns a policy function that can be used to
 // check whether an X.509 cert currently issued for a Certificate should be
 // renewed.
 func CurrentCertificateNearingExpiry(c clock.Clock) Func {
 	return func(input Input) (string, string, bool) {
-		x509Cert, err := pki.DecodeX509CertificateBytes(input.Secret.Data[corev1.TLSCertKey])
+		x509Cert, err := input.secretCertificate()
 		if err != nil {
-			return InvalidCertificate, fmt.Sprintf("Issuing certificate as Secret contains an invalid certificate: %v", err), true
+			return secretReason(SecretDataInvalid, InvalidCertificate), fmt.Sprintf("Issuing certificate as Secret contains an invalid certificate: %v", err), true
 		}
 
 		// Determine if the certificate is nearing expiry solely by looking at
-		// the actual cert, if it exists. We assume that at this point we have
-		// called policy functions that check that input.Secret and
+		// the actual cert, if it exists, and its issuer chain if
+		// spec.renewBeforeChainExpiry is set. We assume that at this point we
+		// have called policy functions that check that input.Secret and
 		// input.Secret.Data exists (SecretDoesNotExist and SecretIsMissingData).
 
-		notBefore := metav1.NewTime(x509Cert.NotBefore)
-		notAfter := metav1.NewTime(x509Cert.NotAfter)
 		crt := input.Certificate
-		renewalTime := pki.RenewalTime(notBefore.Time, notAfter.Time, crt.Spec.RenewBefore)
+		renewalTime := pki.RenewalTime(x509Cert.NotBefore, renewalNotAfter(input, x509Cert), crt.Spec.RenewBefore)
 
-		renewIn := renewalTime.Time.Sub(c.Now())
+		renewIn := renewalTime.Time.Sub(evaluationTime(c, input))
 		if renewIn > 0 {
 			// renewal time is in future, no need to renew
 			return "", "", false
 		}
 
-		return Renewing, fmt.Sprintf("Renewing certificate as renewal was scheduled at %s", input.Certificate.Status.RenewalTime), true
+		// The renewal time has not been recorded yet for a certificate in a
+		// Secret which existed before the Certificate was created.
+		scheduledAt := input.Certificate.Status.RenewalTime
+		if scheduledAt == nil {
+			scheduledAt = renewalTime
+		}
+		return Renewing, fmt.Sprintf("Renewing certificate as renewal was scheduled at %s", scheduledAt), true
 	}
 }
 
@@ -289,49 +514,383 @@ func CurrentCertificateNearingExpiry(c clock.Clock) Func {
 // issued certificate has actually expired rather than just nearing expiry.
 func CurrentCertificateHasExpired(c clock.Clock) Func {
 	return func(input Input) (string, string, bool) {
-		x509Cert, err := pki.DecodeX509CertificateBytes(input.Secret.Data[corev1.TLSCertKey])
+		x509Cert, err := input.secretCertificate()
 		if err != nil {
-			return InvalidCertificate, fmt.Sprintf("Issuing certificate as Secret contains an invalid certificate: %v", err), true
+			return secretReason(SecretDataInvalid, InvalidCertificate), fmt.Sprintf("Issuing certificate as Secret contains an invalid certificate: %v", err), true
 		}
 
-		if c.Now().After(x509Cert.NotAfter) {
+		if evaluationTime(c, input).After(x509Cert.NotAfter) {
 			return Expired, fmt.Sprintf("Certificate expired on %s", x509Cert.NotAfter.Format(time.RFC1123)), true
 		}
 		return "", "", false
 	}
 }
 
-func formatIssuerRef(name, kind, group string) string {
-	if group == "" {
-		group = "cert-manager.io"
+// CurrentCertificateNotYetValid is used to check if the current issued
+// certificate is not yet valid, e.g. because the clock of its issuer is ahead
+// of the cluster's clock.
+func CurrentCertificateNotYetValid(c clock.Clock) Func {
+	return func(input Input) (string, string, bool) {
+		x509Cert, err := input.secretCertificate()
+		if err != nil {
+			return secretReason(SecretDataInvalid, InvalidCertificate), fmt.Sprintf("Issuing certificate as Secret contains an invalid certificate: %v", err), true
+		}
+
+		if evaluationTime(c, input).Before(x509Cert.NotBefore) {
+			return NotYetValid, fmt.Sprintf("Certificate is not valid until %s", x509Cert.NotBefore.Format(time.RFC1123)), true
+		}
+		return "", "", false
 	}
-	if kind == "" {
-		kind = "Issuer"
+}
+
+// SecretCertificateChainInvalid returns a policy function that checks that the
+// certificate chain stored in the Secret's tls.crt is well formed. The leaf
+// must be followed by its intermediates in order, none of the intermediates
+// may have expired, the file must not contain certificates that are not part
+// of the chain and, if a ca.crt is present, the chain must lead to it.
+func SecretCertificateChainInvalid(c clock.Clock) Func {
+	return func(input Input) (string, string, bool) {
+		certs, err := input.secretCertificateChain()
+		if err != nil {
+			return secretReason(SecretDataInvalid, InvalidCertificate), fmt.Sprintf("Secret contains an invalid certificate: %v", err), true
+		}
+
+		// A CA bundle chosen by the user through the SecretCAPolicy does not
+		// have to contain the issuer of the chain, e.g. when a root is pinned.
+		caPEM := input.Secret.Data[cmmeta.TLSCAKey]
+		if input.Certificate != nil && input.Certificate.Spec.SecretCAPolicy != nil &&
+			input.Certificate.Spec.SecretCAPolicy.Type == cmapi.CertificateSecretCAPolicyFromSecretRef {
+			caPEM = nil
+		}
+		var (
+			caCerts []*x509.Certificate
+			caErr   error
+		)
+		if len(bytes.TrimSpace(caPEM)) > 0 {
+			caCerts, caErr = input.secretCACertificates()
+		}
+
+		if msg := certificateChainProblem(evaluationTime(c, input), certs, caCerts, caErr); msg != "" {
+			return InvalidCertificateChain, fmt.Sprintf("Secret contains an invalid certificate chain: %s", msg), true
+		}
+		return "", "", false
 	}
-	return fmt.Sprintf("%s.%s/%s", kind, group, name)
 }
 
-const defaultIssuerKind = "Issuer"
-const defaultIssuerGroup = "cert-manager.io"
+// SecretIssuerChainExpiringSoon checks that no certificate in the issuer
+// chain stored in the Secret expires before the issued certificate is due to
+// be renewed. Once the chain has expired the issued certificate can no longer
+// be verified, even though it has not expired itself.
+func SecretIssuerChainExpiringSoon(input Input) (string, string, bool) {
+	certs, err := input.secretCertificateChain()
+	if err != nil {
+		return secretReason(SecretDataInvalid, InvalidCertificate), fmt.Sprintf("Secret contains an invalid certificate: %v", err), true
+	}
+
+	leaf := certs[0]
+	renewalTime := pki.RenewalTime(leaf.NotBefore, renewalNotAfter(input, leaf), input.Certificate.Spec.RenewBefore)
+	for _, cert := range issuerChain(input, certs) {
+		if chainExpiryIgnored(input.Certificate, leaf, cert) {
+			continue
+		}
+		if cert.NotAfter.Before(renewalTime.Time) {
+			return IssuerChainExpiringSoon, fmt.Sprintf("Issuer certificate %q expires on %s, before the certificate is due to be renewed on %s",
+				cert.Subject.String(), cert.NotAfter.Format(time.RFC1123), renewalTime.Time.Format(time.RFC1123)), true
+		}
+	}
+	return "", "", false
+}
+
+// SecretIssuerCAUnexpected checks that the certificate stored in the Secret
+// was signed by one of the CAs recorded on the Certificate's status upon
+// issuance. It only applies to Certificates which have CA fingerprints
+// recorded, i.e. which opted in with the verify-issuer-ca annotation.
+// The signer is looked up among the CA certificates in the Secret and in the
+// current CertificateRequest, and is identified by the fingerprint of its
+// public key, so that CA certificates which have been renewed with the same
+// key are still accepted. A CA which has been rotated to a new key is
+// recorded once the certificate is re-issued by it.
+// A certificate signed by the current CA of the Certificate's CA issuer, see
+// IssuerCAInput, is always accepted. If that CA could not be read, and the
+// certificate was not signed by any recorded CA, the check is inconclusive
+// and does not trigger a re-issuance.
+func SecretIssuerCAUnexpected(input Input) (string, string, bool) {
+	expected := input.Certificate.Status.IssuerCAFingerprints
+	if len(expected) == 0 {
+		return "", "", false
+	}
+
+	certs, err := input.secretCertificateChain()
+	if err != nil {
+		return invalidCertificate(err)
+	}
+	leaf := certs[0]
+
+	candidates := append([]*x509.Certificate(nil), certs[1:]...)
+	if caCerts, err := input.secretCACertificates(); err == nil {
+		candidates = append(candidates, caCerts...)
+	}
+	appendCerts := func(data []byte) {
+		if decoded, err := pki.DecodeX509CertificateSetBytes(data); err == nil {
+			candidates = append(candidates, decoded...)
+		}
+	}
+	if req := input.CurrentRevisionRequest; req != nil {
+		appendCerts(req.Status.Certificate)
+		appendCerts(req.Status.CA)
+	}
+
+	// The signature is checked with the candidate's public key only, as a
+	// self-signed certificate need not be a CA certificate.
+	signed := func(candidate *x509.Certificate) bool {
+		return candidate.CheckSignature(leaf.SignatureAlgorithm, leaf.RawTBSCertificate, leaf.Signature) == nil
+	}
+	for _, candidate := range candidates {
+		if !signed(candidate) {
+			continue
+		}
+		fingerprint, err := pki.PublicKeyFingerprintSHA256(candidate.PublicKey)
+		if err != nil {
+			continue
+		}
+		if slices.Contains(expected, fingerprint) {
+			return "", "", false
+		}
+	}
+
+	if secret := input.IssuerCASecret; secret != nil {
+		issuerCAs, _ := pki.DecodeX509CertificateSetBytes(secret.Data[corev1.TLSCertKey])
+		if slices.ContainsFunc(issuerCAs, signed) {
+			return "", "", false
+		}
+	}
+	if input.Unavailable(IssuerCAInput) != nil {
+		return "", "", false
+	}
+
+	return UnexpectedIssuerCA, fmt.Sprintf("Issuing certificate as the certificate in the Secret, issued by %q, was not signed by any of the CAs recorded when it was issued",
+		leaf.Issuer.String()), true
+}
+
+// IssuerChainNotAfter returns the earliest expiry time of the certificates in
+// the issuer chain stored in the Secret, i.e. the certificates following the
+// issued certificate in tls.crt and those in ca.crt. It returns false if the
+// Secret does not contain an issuer chain.
+func IssuerChainNotAfter(crt *cmapi.Certificate, secret *corev1.Secret) (time.Time, bool) {
+	input := Input{Certificate: crt, Secret: secret}
+	certs, err := input.secretCertificateChain()
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	var notAfter time.Time
+	found := false
+	for _, cert := range issuerChain(input, certs) {
+		if !found || cert.NotAfter.Before(notAfter) {
+			notAfter = cert.NotAfter
+			found = true
+		}
+	}
+	return notAfter, found
+}
+
+// RenewalNotAfter returns the expiry time from which the renewal time of the
+// issued certificate cert, stored in the Secret, is computed. This is the
+// expiry of cert itself unless spec.renewBeforeChainExpiry is set, in which
+// case it is the earliest expiry among cert and its issuer chain. Certificates
+// of the chain ignored by chainExpiryIgnored do not count.
+func RenewalNotAfter(crt *cmapi.Certificate, secret *corev1.Secret, cert *x509.Certificate) time.Time {
+	return renewalNotAfter(Input{Certificate: crt, Secret: secret}, cert)
+}
+
+// renewalNotAfter is RenewalNotAfter for the Certificate and Secret of the
+// given input.
+func renewalNotAfter(input Input, cert *x509.Certificate) time.Time {
+	crt := input.Certificate
+	notAfter := cert.NotAfter
+	if crt == nil || !crt.Spec.RenewBeforeChainExpiry || input.Secret == nil {
+		return notAfter
+	}
+
+	certs, err := input.secretCertificateChain()
+	if err != nil || !certs[0].Equal(cert) {
+		return notAfter
+	}
+	for _, chainCert := range issuerChain(input, certs) {
+		if chainExpiryIgnored(crt, cert, chainCert) {
+			continue
+		}
+		if chainCert.NotAfter.Before(notAfter) {
+			notAfter = chainCert.NotAfter
+		}
+	}
+	return notAfter
+}
+
+// chainExpiryIgnored returns true if the expiry of chainCert, a certificate of
+// the issuer chain of cert, is ignored when deciding when cert is renewed. This
+// is the case if it is listed in spec.chainExpiryExcludedFingerprints, or if it
+// was already due for renewal when cert was issued, i.e. it expires within the
+// renew-before window of cert counted from cert's NotBefore. The issuer
+// returned such a certificate along with the current certificate, so it would
+// most likely return it again, and re-issuing would trigger another issuance
+// straight away.
+func chainExpiryIgnored(crt *cmapi.Certificate, cert, chainCert *x509.Certificate) bool {
+	if chainExpiryExcluded(crt, chainCert) {
+		return true
+	}
+
+	var renewBefore *metav1.Duration
+	if crt != nil {
+		renewBefore = crt.Spec.RenewBefore
+	}
+	window := cert.NotAfter.Sub(pki.RenewalTime(cert.NotBefore, cert.NotAfter, renewBefore).Time)
+	return !chainCert.NotAfter.After(cert.NotBefore.Add(window))
+}
+
+// chainExpiryExcluded returns true if the expiry of the given certificate of
+// the issuer chain is ignored as its fingerprint is listed in
+// spec.chainExpiryExcludedFingerprints.
+func chainExpiryExcluded(crt *cmapi.Certificate, cert *x509.Certificate) bool {
+	if crt == nil || len(crt.Spec.ChainExpiryExcludedFingerprints) == 0 {
+		return false
+	}
+	fingerprint := pki.FingerprintSHA256(cert)
+	for _, excluded := range crt.Spec.ChainExpiryExcludedFingerprints {
+		if strings.EqualFold(excluded, fingerprint) {
+			return true
+		}
+	}
+	return false
+}
+
+// issuerChain returns the certificates in the issuer chain of the input's
+// Secret, given the decoded tls.crt bundle. A CA bundle chosen by the user
+// through the SecretCAPolicy is not part of the issuer chain.
+func issuerChain(input Input, certs []*x509.Certificate) []*x509.Certificate {
+	chain := append([]*x509.Certificate(nil), certs[1:]...)
+	if crt := input.Certificate; crt != nil && crt.Spec.SecretCAPolicy != nil &&
+		crt.Spec.SecretCAPolicy.Type == cmapi.CertificateSecretCAPolicyFromSecretRef {
+		return chain
+	}
+	// ca.crt of a self-signed certificate contains the certificate itself.
+	caCerts, err := input.secretCACertificates()
+	if err != nil {
+		return chain
+	}
+	for _, caCert := range caCerts {
+		if !caCert.Equal(certs[0]) {
+			chain = append(chain, caCert)
+		}
+	}
+	return chain
+}
 
-func issuerKindsEqual(l, r string) bool {
-	if l == "" {
-		l = defaultIssuerKind
+// decodeCertificateBundle decodes every PEM block in data. Unlike
+// pki.DecodeX509CertificateSetBytes it fails if a PEM block cannot be decoded
+// or is not a certificate, which catches bundles that have been truncated.
+func decodeCertificateBundle(data []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	rest := data
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("unexpected PEM block of type %q in certificate chain", block.Type)
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("certificate %d in chain could not be parsed: %w", len(certs), err)
+		}
+		certs = append(certs, cert)
 	}
-	if r == "" {
-		r = defaultIssuerKind
+	if bytes.Contains(rest, []byte("-----BEGIN")) {
+		return nil, fmt.Errorf("certificate chain contains a PEM block that could not be decoded, it may have been truncated")
 	}
-	return l == r
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no certificates found")
+	}
+	return certs, nil
 }
 
-func issuerGroupsEqual(l, r string) bool {
-	if l == "" {
-		l = defaultIssuerGroup
+// certificateChainProblem returns a description of the first problem found
+// with the given chain, or an empty string if the chain is valid. certs[0] is
+// the leaf certificate. caCerts are the certificates decoded from ca.crt, or
+// caErr the error with which it could not be decoded. The chain is not
+// checked against ca.crt if neither is set.
+func certificateChainProblem(now time.Time, certs []*x509.Certificate, caCerts []*x509.Certificate, caErr error) string {
+	// Every certificate after the leaf must have issued another certificate
+	// in the bundle, otherwise it is not part of the chain at all.
+	for i := 1; i < len(certs); i++ {
+		issuedAny := false
+		for j := range certs {
+			if i != j && certs[j].CheckSignatureFrom(certs[i]) == nil {
+				issuedAny = true
+				break
+			}
+		}
+		if !issuedAny {
+			return fmt.Sprintf("certificate %d (%q) is not part of the chain", i, certs[i].Subject.String())
+		}
+	}
+
+	for i := 1; i < len(certs); i++ {
+		if err := certs[i-1].CheckSignatureFrom(certs[i]); err != nil {
+			return fmt.Sprintf("certificate %d (%q) was not issued by certificate %d (%q), the chain is in the wrong order",
+				i-1, certs[i-1].Subject.String(), i, certs[i].Subject.String())
+		}
+		if now.After(certs[i].NotAfter) {
+			return fmt.Sprintf("intermediate certificate %q expired on %s", certs[i].Subject.String(), certs[i].NotAfter.Format(time.RFC1123))
+		}
+	}
+
+	if caErr != nil {
+		return fmt.Sprintf("ca.crt could not be decoded: %v", caErr)
+	}
+	if len(caCerts) == 0 {
+		return ""
+	}
+
+	top := certs[len(certs)-1]
+	if isSelfSignedCertificate(top) {
+		return ""
+	}
+	for _, ca := range caCerts {
+		if top.Equal(ca) || top.CheckSignatureFrom(ca) == nil {
+			return ""
+		}
 	}
-	if r == "" {
-		r = defaultIssuerGroup
+	return fmt.Sprintf("certificate %q was not issued by any certificate in ca.crt, the chain may be incomplete", top.Subject.String())
+}
+
+func isSelfSignedCertificate(cert *x509.Certificate) bool {
+	return bytes.Equal(cert.RawIssuer, cert.RawSubject) &&
+		cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature) == nil
+}
+
+// ClusterIssuerUnsupported returns a policy Func that fails when cert-manager
+// is scoped to the given namespace and the Certificate references a
+// ClusterIssuer, which cannot be used in that mode. An empty namespace means
+// cert-manager is not scoped and the check always passes.
+func ClusterIssuerUnsupported(namespace string) Func {
+	return func(input Input) (string, string, bool) {
+		if namespace == "" {
+			return "", "", false
+		}
+		kind, group := apiutil.NormalizeIssuerKindAndGroup(input.Certificate.Spec.IssuerRef.Kind, input.Certificate.Spec.IssuerRef.Group)
+		if kind != cmapi.ClusterIssuerKind || group != certmanager.GroupName {
+			return "", "", false
+		}
+		return ClusterIssuerNotSupported, fmt.Sprintf("ClusterIssuers are not supported as cert-manager is scoped to the namespace %q", namespace), true
 	}
-	return l == r
+}
+
+func formatIssuerRef(name, kind, group string) string {
+	kind, group = apiutil.NormalizeIssuerKindAndGroup(kind, group)
+	return fmt.Sprintf("%s.%s/%s", kind, group, name)
 }
 
 // SecretSecretTemplateMismatch will inspect the given Secret's Annotations
@@ -361,10 +920,22 @@ func SecretSecretTemplateMismatch(input Input) (string, string, bool) {
 	return "", "", false
 }
 
-func certificateDataAnnotationsForSecret(secret *corev1.Secret) (annotations map[string]string, err error) {
+// SecretImmutableMismatch returns true if the Secret is not marked immutable
+// although the Certificate's SecretTemplate requests it, or the other way
+// around, so that the Secret is made immutable, or re-created as mutable,
+// without waiting for the next issuance.
+func SecretImmutableMismatch(input Input) (string, string, bool) {
+	immutable := input.Secret.Immutable != nil && *input.Secret.Immutable
+	if immutable != internalcertificates.UsesImmutableSecret(input.Certificate.Spec) {
+		return SecretTemplateMismatch, "Certificate's SecretTemplate immutable value does not match Secret", true
+	}
+	return "", "", false
+}
+
+func certificateDataAnnotationsForSecret(input Input) (annotations map[string]string, err error) {
 	var certificate *x509.Certificate
-	if len(secret.Data[corev1.TLSCertKey]) > 0 {
-		certificate, err = pki.DecodeX509CertificateBytes(secret.Data[corev1.TLSCertKey])
+	if len(input.Secret.Data[corev1.TLSCertKey]) > 0 {
+		certificate, err = input.secretCertificate()
 		if err != nil {
 			return nil, err
 		}
@@ -442,12 +1013,13 @@ func SecretManagedLabelsAndAnnotationsManagedFieldsMismatch(fieldManager string)
 			delete(managedAnnotations, k)
 		}
 
-		// Ignore the CertificateName and IssuerRef annotations as these cannot be set by the postIssuance controller.
+		// Ignore the CertificateName, IssuerRef and PrivateKeyDefaulted annotations as these cannot be set by the postIssuance controller.
 		managedAnnotations.Delete(
-			cmapi.CertificateNameKey,       // SecretCertificateNameAnnotationMismatch checks the value
-			cmapi.IssuerNameAnnotationKey,  // SecretIssuerAnnotationsMismatch checks the value
-			cmapi.IssuerKindAnnotationKey,  // SecretIssuerAnnotationsMismatch checks the value
-			cmapi.IssuerGroupAnnotationKey, // SecretIssuerAnnotationsMismatch checks the value
+			cmapi.CertificateNameKey,               // SecretCertificateNameAnnotationMismatch checks the value
+			cmapi.IssuerNameAnnotationKey,          // SecretIssuerAnnotationsMismatch checks the value
+			cmapi.IssuerKindAnnotationKey,          // SecretIssuerAnnotationsMismatch checks the value
+			cmapi.IssuerGroupAnnotationKey,         // SecretIssuerAnnotationsMismatch checks the value
+			cmapi.PrivateKeyDefaultedAnnotationKey, // Only set at issuance
 		)
 
 		// Remove the non cert-manager labels from the managed labels so we can compare
@@ -461,7 +1033,7 @@ func SecretManagedLabelsAndAnnotationsManagedFieldsMismatch(fieldManager string)
 			delete(managedLabels, k)
 		}
 
-		expCertificateDataAnnotations, err := certificateDataAnnotationsForSecret(input.Secret)
+		expCertificateDataAnnotations, err := certificateDataAnnotationsForSecret(input)
 		if err != nil {
 			return InvalidCertificate, fmt.Sprintf("Failed getting secret annotations: %v", err), true
 		}
@@ -585,12 +1157,69 @@ func SecretBaseLabelsMismatch(input Input) (string, string, bool) {
 	return SecretManagedMetadataMismatch, fmt.Sprintf("wrong base label %s value %q, expected \"true\"", cmapi.PartOfCertManagerControllerLabelKey, value), true
 }
 
+// SecretManagedAnnotationsMissing - When any of the certificate name, issuer
+// or certificate details annotations are missing from the Secret, the Secret
+// is updated.
+// Backup and restore tools commonly drop these annotations. The certificate
+// data in the Secret is checked by the trigger policies, so the annotations
+// can be rewritten without reissuing the certificate.
+func SecretManagedAnnotationsMissing(input Input) (string, string, bool) {
+	dataAnnotations, err := certificateDataAnnotationsForSecret(input)
+	if err != nil {
+		return InvalidCertificate, fmt.Sprintf("Failed getting secret annotations: %v", err), true
+	}
+
+	expAnnotations := sets.New[string](
+		cmapi.CertificateNameKey,
+		cmapi.IssuerNameAnnotationKey,
+		cmapi.IssuerKindAnnotationKey,
+		cmapi.IssuerGroupAnnotationKey,
+	)
+	for k := range dataAnnotations {
+		expAnnotations.Insert(k)
+	}
+
+	missingAnnotations := sets.New[string]()
+	for k := range expAnnotations {
+		if _, ok := input.Secret.Annotations[k]; !ok {
+			missingAnnotations.Insert(k)
+		}
+	}
+	if len(missingAnnotations) > 0 {
+		return SecretManagedMetadataMismatch, fmt.Sprintf("Secret is missing these Managed Annotations: %v", sets.List(missingAnnotations)), true
+	}
+
+	return "", "", false
+}
+
+// SecretIssuerAnnotationsNotNormalized - When the issuer kind and group
+// annotations refer to the Certificate's issuer but are not in their
+// canonical form, e.g. an empty group written by older versions of
+// cert-manager, the Secret is updated to rewrite them in canonical form.
+// NOTE: Annotations which refer to a different issuer are checked by
+// SecretIssuerAnnotationsMismatch, which triggers a reissuance instead.
+func SecretIssuerAnnotationsNotNormalized(input Input) (string, string, bool) {
+	kind, ok1 := input.Secret.Annotations[cmapi.IssuerKindAnnotationKey]
+	group, ok2 := input.Secret.Annotations[cmapi.IssuerGroupAnnotationKey]
+	if !ok1 || !ok2 {
+		// Missing annotations are checked by SecretManagedAnnotationsMissing.
+		return "", "", false
+	}
+
+	expKind, expGroup := apiutil.NormalizeIssuerKindAndGroup(kind, group)
+	if kind != expKind || group != expGroup {
+		return SecretManagedMetadataMismatch, fmt.Sprintf("Secret issuer annotations kind %q and group %q are not in canonical form, expected kind %q and group %q", kind, group, expKind, expGroup), true
+	}
+
+	return "", "", false
+}
+
 // SecretCertificateDetailsAnnotationsMismatch - When the certificate details annotations are
 // not matching, the secret is updated.
 // NOTE: The presence of the certificate details annotations is checked
 // by the SecretManagedLabelsAndAnnotationsManagedFieldsMismatch function.
 func SecretCertificateDetailsAnnotationsMismatch(input Input) (string, string, bool) {
-	dataAnnotations, err := certificateDataAnnotationsForSecret(input.Secret)
+	dataAnnotations, err := certificateDataAnnotationsForSecret(input)
 	if err != nil {
 		return InvalidCertificate, fmt.Sprintf("Failed getting secret annotations: %v", err), true
 	}
@@ -637,6 +1266,16 @@ func SecretAdditionalOutputFormatsMismatch(input Input) (string, string, bool) {
 	return "", "", false
 }
 
+// SecretCAPolicyMismatch validates that the ca.crt key of the Secret contains
+// the data required by the Certificate's SecretCAPolicy, as given in
+// Input.SecretCA.
+func SecretCAPolicyMismatch(input Input) (string, string, bool) {
+	if !bytes.Equal(input.Secret.Data[cmmeta.TLSCAKey], input.SecretCA) {
+		return SecretCAMismatch, "Secret's ca.crt doesn't match the Certificate's SecretCAPolicy", true
+	}
+	return "", "", false
+}
+
 // SecretAdditionalOutputFormatsManagedFieldsMismatch validates that the field manager
 // owns the correct Certificate's AdditionalOutputFormats in the Secret.
 // Returns true (violation) if:
@@ -780,3 +1419,85 @@ func SecretOwnerReferenceMismatch(ownerRefEnabled bool) Func {
 		return "", "", false
 	}
 }
+
+// SecretManagedDataModifiedExternally inspects the given Secret's managed
+// fields to determine whether the data written by cert-manager has been
+// modified by a third party. The field manager that issued the Secret is
+// taken to be the one managing its `cert-manager.io/certificate-name`
+// annotation, since cert-manager applies the annotations and data of a Secret
+// in a single call. Returns true (violation) if any of the certificate, private
+// key, private key reference or CA keys written by cert-manager for the
+// Certificate is managed by another field manager, naming that field manager
+// in the message. Keys which are managed by both, as in a pre-existing Secret
+// adopted by a Certificate, have not been modified. Keys which cert-manager
+// does not write for the Certificate, such as the private key of a
+// Certificate using an external CSR, may be managed by anyone.
+// No violation is returned if no field manager manages the annotation, for
+// example if the Secret was not written using server-side apply.
+// A violation with the reason `ManagedFieldsParseError` should be considered a
+// non re-triable error.
+func SecretManagedDataModifiedExternally(input Input) (string, string, bool) {
+	annotationPath := fieldpath.MakePathOrDie("metadata", "annotations", cmapi.CertificateNameKey)
+	dataKeys := issuedDataKeys(input.Certificate)
+
+	issuingManagers := sets.New[string]()
+	fieldsets := make([]fieldpath.Set, len(input.Secret.ManagedFields))
+	for i, managedField := range input.Secret.ManagedFields {
+		if managedField.FieldsV1 == nil {
+			continue
+		}
+		if err := fieldsets[i].FromJSON(bytes.NewReader(managedField.FieldsV1.Raw)); err != nil {
+			return ManagedFieldsParseError, fmt.Sprintf("failed to decode managed fields on Secret: %s", err), true
+		}
+		if fieldsets[i].Has(annotationPath) {
+			issuingManagers.Insert(managedField.Manager)
+		}
+	}
+	if issuingManagers.Len() == 0 {
+		return "", "", false
+	}
+
+	// Keys which are also managed by the issuing field manager hold the value
+	// it last applied, e.g. in a Secret which existed before it was adopted.
+	issuedKeys := sets.New[string]()
+	for i, managedField := range input.Secret.ManagedFields {
+		if !issuingManagers.Has(managedField.Manager) {
+			continue
+		}
+		for _, key := range dataKeys {
+			if fieldsets[i].Has(fieldpath.MakePathOrDie("data", key)) {
+				issuedKeys.Insert(key)
+			}
+		}
+	}
+
+	for i, managedField := range input.Secret.ManagedFields {
+		if issuingManagers.Has(managedField.Manager) {
+			continue
+		}
+		for _, key := range dataKeys {
+			if !issuedKeys.Has(key) && fieldsets[i].Has(fieldpath.MakePathOrDie("data", key)) {
+				return SecretModifiedExternally,
+					fmt.Sprintf("Secret key %q has been modified by field manager %q", key, managedField.Manager), true
+			}
+		}
+	}
+
+	return "", "", false
+}
+
+// issuedDataKeys returns the keys of the Certificate's Secret holding data
+// which cert-manager writes on issuance: the certificate and the CA, as well
+// as either the private key or, if the private key is held by an external
+// KMS, the reference to it. No private key is written for Certificates using
+// an external CSR.
+func issuedDataKeys(crt *cmapi.Certificate) []string {
+	switch {
+	case internalcertificates.UsesExternalPrivateKey(crt.Spec):
+		return []string{corev1.TLSCertKey, cmapi.PrivateKeyReferenceSecretKey, cmmeta.TLSCAKey}
+	case crt.Spec.ExternalCSR != nil:
+		return []string{corev1.TLSCertKey, cmmeta.TLSCAKey}
+	default:
+		return []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey, cmmeta.TLSCAKey}
+	}
+}
@@ -17,7 +17,17 @@ limitations under the License.
 package policies
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
 	"testing"
 	"time"
 
@@ -25,11 +35,15 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	featuregatetesting "k8s.io/component-base/featuregate/testing"
 	fakeclock "k8s.io/utils/clock/testing"
 	"k8s.io/utils/ptr"
 
+	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
@@ -43,6 +57,27 @@ import (
 func Test_NewTriggerPolicyChain(t *testing.T) {
 	clock := &fakeclock.FakeClock{}
 	staticFixedPrivateKey := testcrypto.MustCreatePEMPrivateKey(t)
+	externalCSR := &cmapi.CertificateExternalCSR{
+		SecretRef: cmmeta.SecretKeySelector{
+			LocalObjectReference: cmmeta.LocalObjectReference{Name: "csr"},
+		},
+	}
+	// Certificates may have very large SAN lists, in which case the names
+	// listed in violation messages are truncated.
+	manyDNSNames := func(prefix string) []string {
+		names := make([]string, 150)
+		for i := range names {
+			names[i] = fmt.Sprintf("%s-%03d.example.com", prefix, i)
+		}
+		return names
+	}
+	truncatedDNSNames := func(prefix string) string {
+		quoted := make([]string, 10)
+		for i := range quoted {
+			quoted[i] = fmt.Sprintf("%q", fmt.Sprintf("%s-%03d.example.com", prefix, i))
+		}
+		return strings.Join(quoted, ", ") + " and 140 more"
+	}
 	tests := map[string]struct {
 		// policy inputs
 		certificate *cmapi.Certificate
@@ -55,14 +90,14 @@ func Test_NewTriggerPolicyChain(t *testing.T) {
 	}{
 		"trigger issuance if Secret is missing": {
 			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{SecretName: "something"}},
-			reason:      DoesNotExist,
+			reason:      SecretMissing,
 			message:     "Issuing certificate as Secret does not exist",
 			reissue:     true,
 		},
 		"trigger issuance as Secret does not contain any data": {
 			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{SecretName: "something"}},
 			secret:      &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "something"}},
-			reason:      MissingData,
+			reason:      SecretEmpty,
 			message:     "Issuing certificate as Secret does not contain any data",
 			reissue:     true,
 		},
@@ -71,8 +106,8 @@ func Test_NewTriggerPolicyChain(t *testing.T) {
 			secret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "something"},
 				Data: map[string][]byte{corev1.TLSCertKey: []byte("test")},
 			},
-			reason:  MissingData,
-			message: "Issuing certificate as Secret does not contain a private key",
+			reason:  SecretKeyMissing,
+			message: `Issuing certificate as Secret does not contain a private key in "tls.key"`,
 			reissue: true,
 		},
 		"trigger issuance as Secret is missing certificate": {
@@ -80,8 +115,8 @@ func Test_NewTriggerPolicyChain(t *testing.T) {
 			secret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "something"},
 				Data: map[string][]byte{corev1.TLSPrivateKeyKey: []byte("test")},
 			},
-			reason:  MissingData,
-			message: "Issuing certificate as Secret does not contain a certificate",
+			reason:  SecretCertMissing,
+			message: `Issuing certificate as Secret does not contain a certificate in "tls.crt"`,
 			reissue: true,
 		},
 		"trigger issuance as Secret contains corrupt private key and certificate data": {
@@ -92,8 +127,8 @@ func Test_NewTriggerPolicyChain(t *testing.T) {
 					corev1.TLSCertKey:       []byte("test"),
 				},
 			},
-			reason:  InvalidKeyPair,
-			message: "Issuing certificate as Secret contains invalid private key data: error decoding private key PEM block",
+			reason:  SecretDataInvalid,
+			message: `Issuing certificate as Secret contains invalid private key data in "tls.key": error decoding private key PEM block`,
 			reissue: true,
 		},
 		"trigger issuance as Secret contains corrupt certificate data": {
@@ -104,8 +139,8 @@ func Test_NewTriggerPolicyChain(t *testing.T) {
 					corev1.TLSCertKey:       []byte("test"),
 				},
 			},
-			reason:  InvalidCertificate,
-			message: "Issuing certificate as Secret contains an invalid certificate: error decoding certificate PEM block",
+			reason:  SecretDataInvalid,
+			message: `Issuing certificate as Secret contains an invalid certificate in "tls.crt": error decoding certificate PEM block`,
 			reissue: true,
 		},
 		"trigger issuance as Secret contains corrupt private key data": {
@@ -118,8 +153,8 @@ func Test_NewTriggerPolicyChain(t *testing.T) {
 					),
 				},
 			},
-			reason:  InvalidKeyPair,
-			message: "Issuing certificate as Secret contains invalid private key data: error decoding private key PEM block",
+			reason:  SecretDataInvalid,
+			message: `Issuing certificate as Secret contains invalid private key data in "tls.key": error decoding private key PEM block`,
 			reissue: true,
 		},
 		"trigger issuance as Secret contains a non-matching key-pair": {
@@ -214,6 +249,126 @@ func Test_NewTriggerPolicyChain(t *testing.T) {
 			message: "Issuing certificate as Secret was previously issued by \"IssuerKind.new.example.com/testissuer\"",
 			reissue: true,
 		},
+		"do not trigger issuance as Secret's issuer annotations have been removed, e.g. by a restore of the Secret": {
+			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				SecretName: "something",
+				CommonName: "example.com",
+				IssuerRef: cmmeta.ObjectReference{
+					Name: "testissuer",
+					Kind: "ClusterIssuer",
+				},
+			}},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "something"},
+				Data: map[string][]byte{
+					corev1.TLSPrivateKeyKey: staticFixedPrivateKey,
+					corev1.TLSCertKey: testcrypto.MustCreateCert(t, staticFixedPrivateKey,
+						&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.com"}},
+					),
+				},
+			},
+		},
+		"do not trigger issuance as only some of the Secret's issuer annotations have been removed": {
+			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				SecretName: "something",
+				CommonName: "example.com",
+				IssuerRef: cmmeta.ObjectReference{
+					Name:  "testissuer",
+					Kind:  "IssuerKind",
+					Group: "group.example.com",
+				},
+			}},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "something",
+					Annotations: map[string]string{
+						cmapi.IssuerNameAnnotationKey: "testissuer",
+					},
+				},
+				Data: map[string][]byte{
+					corev1.TLSPrivateKeyKey: staticFixedPrivateKey,
+					corev1.TLSCertKey: testcrypto.MustCreateCert(t, staticFixedPrivateKey,
+						&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.com"}},
+					),
+				},
+			},
+		},
+		"trigger issuance as the Secret's remaining issuer annotations do not match the issuerRef": {
+			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				SecretName: "something",
+				IssuerRef: cmmeta.ObjectReference{
+					Name:  "testissuer",
+					Kind:  "IssuerKind",
+					Group: "group.example.com",
+				},
+			}},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "something",
+					Annotations: map[string]string{
+						cmapi.IssuerNameAnnotationKey: "oldissuer",
+					},
+				},
+				Data: map[string][]byte{
+					corev1.TLSPrivateKeyKey: staticFixedPrivateKey,
+					corev1.TLSCertKey: testcrypto.MustCreateCert(t, staticFixedPrivateKey,
+						&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.com"}},
+					),
+				},
+			},
+			reason:  IncorrectIssuer,
+			message: "Issuing certificate as Secret was previously issued by \"IssuerKind.group.example.com/oldissuer\"",
+			reissue: true,
+		},
+		"do not trigger issuance as Secret's issuer annotations were written with an empty kind and group by an older version": {
+			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				SecretName: "something",
+				CommonName: "example.com",
+				IssuerRef: cmmeta.ObjectReference{
+					Name:  "testissuer",
+					Kind:  "Issuer",
+					Group: "cert-manager.io",
+				},
+			}},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "something",
+					Annotations: map[string]string{
+						cmapi.IssuerNameAnnotationKey:  "testissuer",
+						cmapi.IssuerKindAnnotationKey:  "",
+						cmapi.IssuerGroupAnnotationKey: "",
+					},
+				},
+				Data: map[string][]byte{
+					corev1.TLSPrivateKeyKey: staticFixedPrivateKey,
+					corev1.TLSCertKey: testcrypto.MustCreateCert(t, staticFixedPrivateKey,
+						&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.com"}},
+					),
+				},
+			},
+		},
+		"do not trigger issuance as Secret's issuer kind annotation is qualified with the issuer group": {
+			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				SecretName: "something",
+				CommonName: "example.com",
+				IssuerRef: cmmeta.ObjectReference{
+					Name: "testissuer",
+					Kind: "ClusterIssuer",
+				},
+			}},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "something",
+					Annotations: map[string]string{
+						cmapi.IssuerNameAnnotationKey:  "testissuer",
+						cmapi.IssuerKindAnnotationKey:  "ClusterIssuer.cert-manager.io",
+						cmapi.IssuerGroupAnnotationKey: "",
+					},
+				},
+				Data: map[string][]byte{
+					corev1.TLSPrivateKeyKey: staticFixedPrivateKey,
+					corev1.TLSCertKey: testcrypto.MustCreateCert(t, staticFixedPrivateKey,
+						&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.com"}},
+					),
+				},
+			},
+		},
 		"trigger issuance as private key properties do not meet the requested properties": {
 			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{SecretName: "something"}},
 			secret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "something"},
@@ -311,6 +466,45 @@ func Test_NewTriggerPolicyChain(t *testing.T) {
 			message: "Fields on existing CertificateRequest resource not up to date: [spec.commonName]",
 			reissue: true,
 		},
+		"trigger issuance listing a truncated set of DNS names when CertificateRequest has many mismatched DNS names": {
+			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				DNSNames: manyDNSNames("new"),
+				IssuerRef: cmmeta.ObjectReference{
+					Name:  "testissuer",
+					Kind:  "IssuerKind",
+					Group: "group.example.com",
+				},
+			}},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "something",
+					Annotations: map[string]string{
+						cmapi.IssuerNameAnnotationKey:  "testissuer",
+						cmapi.IssuerKindAnnotationKey:  "IssuerKind",
+						cmapi.IssuerGroupAnnotationKey: "group.example.com",
+					},
+				},
+				Data: map[string][]byte{
+					corev1.TLSPrivateKeyKey: staticFixedPrivateKey,
+					corev1.TLSCertKey: testcrypto.MustCreateCert(t, staticFixedPrivateKey,
+						&cmapi.Certificate{Spec: cmapi.CertificateSpec{DNSNames: manyDNSNames("old")}},
+					),
+				},
+			},
+			request: &cmapi.CertificateRequest{Spec: cmapi.CertificateRequestSpec{
+				IssuerRef: cmmeta.ObjectReference{
+					Name:  "testissuer",
+					Kind:  "IssuerKind",
+					Group: "group.example.com",
+				},
+				Request: testcrypto.MustGenerateCSRImpl(t, staticFixedPrivateKey, &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+					DNSNames: manyDNSNames("old"),
+				}}),
+			}},
+			reason: RequestChanged,
+			message: "Fields on existing CertificateRequest resource not up to date: [spec.dnsNames] " +
+				"(missing DNS names: " + truncatedDNSNames("new") + "; unexpected DNS names: " + truncatedDNSNames("old") + ")",
+			reissue: true,
+		},
 		"do nothing if CertificateRequest matches spec": {
 			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
 				CommonName: "example.com",
@@ -349,9 +543,10 @@ func Test_NewTriggerPolicyChain(t *testing.T) {
 				}}),
 			}},
 		},
-		"compare signed x509 certificate in Secret with spec if CertificateRequest does not exist": {
+		"do nothing if CertificateRequest was generated from dnsNames and a commonName differing only in case": {
 			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
-				CommonName: "new.example.com",
+				CommonName: "WWW.Example.com",
+				DNSNames:   []string{"www.example.com", "*.example.com", "*.EXAMPLE.com.", "API.example.com"},
 				IssuerRef: cmmeta.ObjectReference{
 					Name:  "testissuer",
 					Kind:  "IssuerKind",
@@ -369,15 +564,26 @@ func Test_NewTriggerPolicyChain(t *testing.T) {
 				Data: map[string][]byte{
 					corev1.TLSPrivateKeyKey: staticFixedPrivateKey,
 					corev1.TLSCertKey: testcrypto.MustCreateCert(t, staticFixedPrivateKey,
-						&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "old.example.com"}},
+						&cmapi.Certificate{Spec: cmapi.CertificateSpec{
+							CommonName: "www.example.com",
+							DNSNames:   []string{"www.example.com", "*.example.com", "api.example.com"},
+						}},
 					),
 				},
 			},
-			reason:  SecretMismatch,
-			message: "Issuing certificate as Existing issued Secret is not up to date for spec: [spec.commonName]",
-			reissue: true,
+			request: &cmapi.CertificateRequest{Spec: cmapi.CertificateRequestSpec{
+				IssuerRef: cmmeta.ObjectReference{
+					Name:  "testissuer",
+					Kind:  "IssuerKind",
+					Group: "group.example.com",
+				},
+				Request: testcrypto.MustGenerateCSRImpl(t, staticFixedPrivateKey, &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+					CommonName: "WWW.Example.com",
+					DNSNames:   []string{"www.example.com", "*.example.com", "*.EXAMPLE.com.", "API.example.com"},
+				}}),
+			}},
 		},
-		"do nothing if signed x509 certificate in Secret matches spec (when request does not exist)": {
+		"do nothing if Secret has no private key as the Certificate uses an external CSR": {
 			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
 				CommonName: "example.com",
 				IssuerRef: cmmeta.ObjectReference{
@@ -385,6 +591,8 @@ func Test_NewTriggerPolicyChain(t *testing.T) {
 					Kind:  "IssuerKind",
 					Group: "group.example.com",
 				},
+				PrivateKey:  &cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm},
+				ExternalCSR: externalCSR,
 			}},
 			secret: &corev1.Secret{
 				ObjectMeta: metav1.ObjectMeta{Name: "something",
@@ -395,28 +603,97 @@ func Test_NewTriggerPolicyChain(t *testing.T) {
 					},
 				},
 				Data: map[string][]byte{
-					corev1.TLSPrivateKeyKey: staticFixedPrivateKey,
+					corev1.TLSPrivateKeyKey: {},
 					corev1.TLSCertKey: testcrypto.MustCreateCert(t, staticFixedPrivateKey,
 						&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.com"}},
 					),
 				},
 			},
-		},
-		"trigger renewal if renewalTime is right now": {
-			certificate: &cmapi.Certificate{
-				Spec: cmapi.CertificateSpec{
+			request: &cmapi.CertificateRequest{Spec: cmapi.CertificateRequestSpec{
+				IssuerRef: cmmeta.ObjectReference{
+					Name:  "testissuer",
+					Kind:  "IssuerKind",
+					Group: "group.example.com",
+				},
+				Request: testcrypto.MustGenerateCSRImpl(t, staticFixedPrivateKey, &cmapi.Certificate{Spec: cmapi.CertificateSpec{
 					CommonName: "example.com",
-					IssuerRef: cmmeta.ObjectReference{
-						Name:  "testissuer",
-						Kind:  "IssuerKind",
-						Group: "group.example.com",
+				}}),
+			}},
+		},
+		"trigger issuance as Secret contains corrupt certificate data when the Certificate uses an external CSR": {
+			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{SecretName: "something", ExternalCSR: externalCSR}},
+			secret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "something"},
+				Data: map[string][]byte{
+					corev1.TLSPrivateKeyKey: {},
+					corev1.TLSCertKey:       []byte("test"),
+				},
+			},
+			reason:  SecretDataInvalid,
+			message: `Issuing certificate as Secret contains an invalid certificate in "tls.crt": error decoding certificate PEM block`,
+			reissue: true,
+		},
+		"trigger issuance as Secret does not contain a private key reference when the Certificate uses an external private key": {
+			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				SecretName: "something",
+				PrivateKey: &cmapi.CertificatePrivateKey{External: &cmapi.CertificateExternalPrivateKey{KeyRing: "projects/test"}},
+			}},
+			secret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "something"},
+				Data: map[string][]byte{
+					corev1.TLSPrivateKeyKey: {},
+					corev1.TLSCertKey:       []byte("test"),
+				},
+			},
+			reason:  SecretKeyMissing,
+			message: `Issuing certificate as Secret does not contain a private key reference in "tls.key.ref"`,
+			reissue: true,
+		},
+		"trigger issuance if the certificate does not match the public key of the external CSR": {
+			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				CommonName: "example.com",
+				IssuerRef: cmmeta.ObjectReference{
+					Name:  "testissuer",
+					Kind:  "IssuerKind",
+					Group: "group.example.com",
+				},
+				ExternalCSR: externalCSR,
+			}},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "something",
+					Annotations: map[string]string{
+						cmapi.IssuerNameAnnotationKey:  "testissuer",
+						cmapi.IssuerKindAnnotationKey:  "IssuerKind",
+						cmapi.IssuerGroupAnnotationKey: "group.example.com",
 					},
-					RenewBefore: &metav1.Duration{Duration: time.Minute * 5},
 				},
-				Status: cmapi.CertificateStatus{
-					RenewalTime: &metav1.Time{Time: clock.Now()},
+				Data: map[string][]byte{
+					corev1.TLSCertKey: testcrypto.MustCreateCert(t, staticFixedPrivateKey,
+						&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.com"}},
+					),
 				},
 			},
+			request: &cmapi.CertificateRequest{Spec: cmapi.CertificateRequestSpec{
+				IssuerRef: cmmeta.ObjectReference{
+					Name:  "testissuer",
+					Kind:  "IssuerKind",
+					Group: "group.example.com",
+				},
+				Request: testcrypto.MustGenerateCSRImpl(t, testcrypto.MustCreatePEMPrivateKey(t), &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+					CommonName: "example.com",
+				}}),
+			}},
+			reason:  SecretMismatch,
+			message: "Secret contains a certificate that does not match the public key of the current CertificateRequest",
+			reissue: true,
+		},
+		"compare signed x509 certificate in Secret with spec if CertificateRequest does not exist": {
+			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				CommonName: "new.example.com",
+				IssuerRef: cmmeta.ObjectReference{
+					Name:  "testissuer",
+					Kind:  "IssuerKind",
+					Group: "group.example.com",
+				},
+			}},
 			secret: &corev1.Secret{
 				ObjectMeta: metav1.ObjectMeta{Name: "something",
 					Annotations: map[string]string{
@@ -427,19 +704,16 @@ func Test_NewTriggerPolicyChain(t *testing.T) {
 				},
 				Data: map[string][]byte{
 					corev1.TLSPrivateKeyKey: staticFixedPrivateKey,
-					corev1.TLSCertKey: testcrypto.MustCreateCertWithNotBeforeAfter(t, staticFixedPrivateKey,
-						&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.com"}},
-						clock.Now().Add(time.Minute*-30),
-						// expires in 1 minute time
-						clock.Now().Add(time.Minute*1),
+					corev1.TLSCertKey: testcrypto.MustCreateCert(t, staticFixedPrivateKey,
+						&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "old.example.com"}},
 					),
 				},
 			},
-			reason:  Renewing,
-			message: "Renewing certificate as renewal was scheduled at 0001-01-01 00:00:00 +0000 UTC",
+			reason:  SecretMismatch,
+			message: "Issuing certificate as Existing issued Secret is not up to date for spec: [spec.commonName]",
 			reissue: true,
 		},
-		"trigger renewal if renewalTime is in the past": {
+		"do not trigger issuance if CertificateRequest does not exist, e.g. after a restore, and the signed x509 certificate in Secret matches the spec": {
 			certificate: &cmapi.Certificate{
 				Spec: cmapi.CertificateSpec{
 					CommonName: "example.com",
@@ -448,11 +722,8 @@ func Test_NewTriggerPolicyChain(t *testing.T) {
 						Kind:  "IssuerKind",
 						Group: "group.example.com",
 					},
-					RenewBefore: &metav1.Duration{Duration: time.Minute * 5},
-				},
-				Status: cmapi.CertificateStatus{
-					RenewalTime: &metav1.Time{Time: clock.Now().Add(-1 * time.Minute)},
 				},
+				Status: cmapi.CertificateStatus{Revision: ptr.To(12)},
 			},
 			secret: &corev1.Secret{
 				ObjectMeta: metav1.ObjectMeta{Name: "something",
@@ -464,7 +735,129 @@ func Test_NewTriggerPolicyChain(t *testing.T) {
 				},
 				Data: map[string][]byte{
 					corev1.TLSPrivateKeyKey: staticFixedPrivateKey,
-					corev1.TLSCertKey: testcrypto.MustCreateCertWithNotBeforeAfter(t, staticFixedPrivateKey,
+					corev1.TLSCertKey: testcrypto.MustCreateCert(t, staticFixedPrivateKey,
+						&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.com"}},
+					),
+				},
+			},
+		},
+		"trigger issuance listing a truncated set of DNS names when the signed x509 certificate in Secret has many mismatched DNS names": {
+			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				DNSNames: append(manyDNSNames("new"), "kept.example.com"),
+				IssuerRef: cmmeta.ObjectReference{
+					Name:  "testissuer",
+					Kind:  "IssuerKind",
+					Group: "group.example.com",
+				},
+			}},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "something",
+					Annotations: map[string]string{
+						cmapi.IssuerNameAnnotationKey:  "testissuer",
+						cmapi.IssuerKindAnnotationKey:  "IssuerKind",
+						cmapi.IssuerGroupAnnotationKey: "group.example.com",
+					},
+				},
+				Data: map[string][]byte{
+					corev1.TLSPrivateKeyKey: staticFixedPrivateKey,
+					corev1.TLSCertKey: testcrypto.MustCreateCert(t, staticFixedPrivateKey,
+						&cmapi.Certificate{Spec: cmapi.CertificateSpec{DNSNames: append(manyDNSNames("old"), "kept.example.com")}},
+					),
+				},
+			},
+			reason: SecretMismatch,
+			message: "Issuing certificate as Existing issued Secret is not up to date for spec: [spec.dnsNames] " +
+				"(missing DNS names: " + truncatedDNSNames("new") + "; unexpected DNS names: " + truncatedDNSNames("old") + ")",
+			reissue: true,
+		},
+		"do nothing if signed x509 certificate in Secret matches spec (when request does not exist)": {
+			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				CommonName: "example.com",
+				IssuerRef: cmmeta.ObjectReference{
+					Name:  "testissuer",
+					Kind:  "IssuerKind",
+					Group: "group.example.com",
+				},
+			}},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "something",
+					Annotations: map[string]string{
+						cmapi.IssuerNameAnnotationKey:  "testissuer",
+						cmapi.IssuerKindAnnotationKey:  "IssuerKind",
+						cmapi.IssuerGroupAnnotationKey: "group.example.com",
+					},
+				},
+				Data: map[string][]byte{
+					corev1.TLSPrivateKeyKey: staticFixedPrivateKey,
+					corev1.TLSCertKey: testcrypto.MustCreateCert(t, staticFixedPrivateKey,
+						&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.com"}},
+					),
+				},
+			},
+		},
+		"trigger renewal if renewalTime is right now": {
+			certificate: &cmapi.Certificate{
+				Spec: cmapi.CertificateSpec{
+					CommonName: "example.com",
+					IssuerRef: cmmeta.ObjectReference{
+						Name:  "testissuer",
+						Kind:  "IssuerKind",
+						Group: "group.example.com",
+					},
+					RenewBefore: &metav1.Duration{Duration: time.Minute * 5},
+				},
+				Status: cmapi.CertificateStatus{
+					RenewalTime: &metav1.Time{Time: clock.Now()},
+				},
+			},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "something",
+					Annotations: map[string]string{
+						cmapi.IssuerNameAnnotationKey:  "testissuer",
+						cmapi.IssuerKindAnnotationKey:  "IssuerKind",
+						cmapi.IssuerGroupAnnotationKey: "group.example.com",
+					},
+				},
+				Data: map[string][]byte{
+					corev1.TLSPrivateKeyKey: staticFixedPrivateKey,
+					corev1.TLSCertKey: testcrypto.MustCreateCertWithNotBeforeAfter(t, staticFixedPrivateKey,
+						&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.com"}},
+						clock.Now().Add(time.Minute*-30),
+						// expires in 1 minute time
+						clock.Now().Add(time.Minute*1),
+					),
+				},
+			},
+			reason:  Renewing,
+			message: "Renewing certificate as renewal was scheduled at 0001-01-01 00:00:00 +0000 UTC",
+			reissue: true,
+		},
+		"trigger renewal if renewalTime is in the past": {
+			certificate: &cmapi.Certificate{
+				Spec: cmapi.CertificateSpec{
+					CommonName: "example.com",
+					IssuerRef: cmmeta.ObjectReference{
+						Name:  "testissuer",
+						Kind:  "IssuerKind",
+						Group: "group.example.com",
+					},
+					RenewBefore: &metav1.Duration{Duration: time.Minute * 5},
+				},
+				Status: cmapi.CertificateStatus{
+					RenewalTime: &metav1.Time{Time: clock.Now().Add(-1 * time.Minute)},
+				},
+			},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "something",
+					Annotations: map[string]string{
+						cmapi.IssuerNameAnnotationKey:  "testissuer",
+						cmapi.IssuerKindAnnotationKey:  "IssuerKind",
+						cmapi.IssuerGroupAnnotationKey: "group.example.com",
+					},
+				},
+				Data: map[string][]byte{
+					corev1.TLSPrivateKeyKey: staticFixedPrivateKey,
+					corev1.TLSCertKey: testcrypto.MustCreateCertWithNotBeforeAfter(t, staticFixedPrivateKey,
 						&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.com"}},
 						clock.Now().Add(time.Minute*-30),
 						// expires in 1 minute time
@@ -545,7 +938,7 @@ func Test_NewTriggerPolicyChain(t *testing.T) {
 			},
 		},
 	}
-	policyChain := NewTriggerPolicyChain(clock)
+	policyChain := NewTriggerPolicyChain(clock, internalcertificates.PrivateKeyDefaults{})
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
 			reason, message, reissue := policyChain.Evaluate(Input{
@@ -567,6 +960,372 @@ func Test_NewTriggerPolicyChain(t *testing.T) {
 	}
 }
 
+// A Secret which already exists when a Certificate is created is adopted if
+// its certificate satisfies the Certificate's spec, rather than a certificate
+// being issued.
+func Test_NewTriggerPolicyChainAdoptsExistingSecret(t *testing.T) {
+	clock := &fakeclock.FakeClock{}
+	pk := testcrypto.MustCreatePEMPrivateKey(t)
+	existingSecret := func(notAfter time.Time) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "existing"},
+			Data: map[string][]byte{
+				corev1.TLSPrivateKeyKey: pk,
+				corev1.TLSCertKey: testcrypto.MustCreateCertWithNotBeforeAfter(t, pk,
+					&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.com", DNSNames: []string{"example.com"}}},
+					clock.Now().Add(-30*24*time.Hour), notAfter,
+				),
+			},
+		}
+	}
+	newCertificate := func(mods ...gen.CertificateModifier) *cmapi.Certificate {
+		return gen.Certificate("test", append([]gen.CertificateModifier{
+			gen.SetCertificateSecretName("existing"),
+			gen.SetCertificateCommonName("example.com"),
+			gen.SetCertificateDNSNames("example.com"),
+		}, mods...)...)
+	}
+
+	tests := map[string]struct {
+		certificate *cmapi.Certificate
+		secret      *corev1.Secret
+
+		reason  string
+		reissue bool
+	}{
+		"should adopt a Secret with a certificate matching the Certificate": {
+			certificate: newCertificate(),
+			secret:      existingSecret(clock.Now().Add(60 * 24 * time.Hour)),
+		},
+		"should issue a certificate if the Secret's certificate only partially matches the Certificate": {
+			certificate: newCertificate(gen.SetCertificateIPs("10.0.0.1")),
+			secret:      existingSecret(clock.Now().Add(60 * 24 * time.Hour)),
+			reason:      SecretMismatch,
+			reissue:     true,
+		},
+		"should issue a certificate if the Secret's certificate has expired": {
+			certificate: newCertificate(),
+			secret:      existingSecret(clock.Now().Add(-time.Hour)),
+			reason:      Renewing,
+			reissue:     true,
+		},
+		"should issue a certificate if adoption is disabled": {
+			certificate: newCertificate(gen.AddCertificateAnnotations(map[string]string{cmapi.AdoptExistingSecretAnnotationKey: "false"})),
+			secret:      existingSecret(clock.Now().Add(60 * 24 * time.Hour)),
+			reason:      SecretNotAdopted,
+			reissue:     true,
+		},
+		"should not re-issue an issued certificate if adoption is disabled": {
+			certificate: newCertificate(
+				gen.AddCertificateAnnotations(map[string]string{cmapi.AdoptExistingSecretAnnotationKey: "false"}),
+				gen.SetCertificateRevision(1),
+			),
+			secret: existingSecret(clock.Now().Add(60 * 24 * time.Hour)),
+		},
+	}
+	policyChain := NewTriggerPolicyChain(clock, internalcertificates.PrivateKeyDefaults{})
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			reason, _, reissue := policyChain.Evaluate(Input{
+				Certificate: test.certificate,
+				Secret:      test.secret,
+			})
+			assert.Equal(t, test.reason, reason)
+			assert.Equal(t, test.reissue, reissue)
+		})
+	}
+}
+
+// The trigger and readiness chains must report the same reason for each state
+// of the Secret's data, and the reasons used before they were made consistent
+// if the LegacySecretPolicyReasons feature gate is enabled.
+func Test_SecretDataPolicyReasons(t *testing.T) {
+	clock := &fakeclock.FakeClock{}
+	pk := testcrypto.MustCreatePEMPrivateKey(t)
+	crt := &cmapi.Certificate{Spec: cmapi.CertificateSpec{SecretName: "something"}}
+	externalKeyCrt := &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+		SecretName: "something",
+		PrivateKey: &cmapi.CertificatePrivateKey{External: &cmapi.CertificateExternalPrivateKey{KeyRing: "projects/test"}},
+	}}
+	secretWithData := func(data map[string][]byte) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "something"}, Data: data}
+	}
+
+	tests := map[string]struct {
+		certificate *cmapi.Certificate
+		secret      *corev1.Secret
+
+		reason, legacyReason, message string
+	}{
+		"Secret does not exist": {
+			certificate:  crt,
+			reason:       SecretMissing,
+			legacyReason: DoesNotExist,
+			message:      "Issuing certificate as Secret does not exist",
+		},
+		"Secret without data": {
+			certificate:  crt,
+			secret:       secretWithData(nil),
+			reason:       SecretEmpty,
+			legacyReason: MissingData,
+			message:      "Issuing certificate as Secret does not contain any data",
+		},
+		"Secret with an empty data map": {
+			certificate:  crt,
+			secret:       secretWithData(map[string][]byte{}),
+			reason:       SecretEmpty,
+			legacyReason: MissingData,
+			message:      "Issuing certificate as Secret does not contain any data",
+		},
+		"placeholder Secret whose keys are all empty": {
+			certificate: crt,
+			secret: secretWithData(map[string][]byte{
+				corev1.TLSCertKey:       {},
+				corev1.TLSPrivateKeyKey: {},
+				cmmeta.TLSCAKey:         {},
+			}),
+			reason:       SecretEmpty,
+			legacyReason: MissingData,
+			message:      "Issuing certificate as Secret does not contain any data",
+		},
+		"Secret only containing a CA": {
+			certificate:  crt,
+			secret:       secretWithData(map[string][]byte{cmmeta.TLSCAKey: []byte("ca")}),
+			reason:       SecretKeyMissing,
+			legacyReason: MissingData,
+			message:      `Issuing certificate as Secret does not contain a private key in "tls.key"`,
+		},
+		"Secret with an empty private key": {
+			certificate:  crt,
+			secret:       secretWithData(map[string][]byte{corev1.TLSCertKey: []byte("test"), corev1.TLSPrivateKeyKey: {}}),
+			reason:       SecretKeyMissing,
+			legacyReason: MissingData,
+			message:      `Issuing certificate as Secret does not contain a private key in "tls.key"`,
+		},
+		"Secret without a private key reference for an external private key": {
+			certificate:  externalKeyCrt,
+			secret:       secretWithData(map[string][]byte{corev1.TLSCertKey: []byte("test")}),
+			reason:       SecretKeyMissing,
+			legacyReason: MissingData,
+			message:      `Issuing certificate as Secret does not contain a private key reference in "tls.key.ref"`,
+		},
+		"Secret without a certificate": {
+			certificate:  crt,
+			secret:       secretWithData(map[string][]byte{corev1.TLSPrivateKeyKey: pk}),
+			reason:       SecretCertMissing,
+			legacyReason: MissingData,
+			message:      `Issuing certificate as Secret does not contain a certificate in "tls.crt"`,
+		},
+		"Secret with an invalid private key": {
+			certificate:  crt,
+			secret:       secretWithData(map[string][]byte{corev1.TLSPrivateKeyKey: []byte("test"), corev1.TLSCertKey: []byte("test")}),
+			reason:       SecretDataInvalid,
+			legacyReason: InvalidKeyPair,
+			message:      `Issuing certificate as Secret contains invalid private key data in "tls.key": error decoding private key PEM block`,
+		},
+		"Secret with an invalid certificate": {
+			certificate:  crt,
+			secret:       secretWithData(map[string][]byte{corev1.TLSPrivateKeyKey: pk, corev1.TLSCertKey: []byte("test")}),
+			reason:       SecretDataInvalid,
+			legacyReason: InvalidCertificate,
+			message:      `Issuing certificate as Secret contains an invalid certificate in "tls.crt": error decoding certificate PEM block`,
+		},
+		"Secret with an invalid certificate for an external private key": {
+			certificate: externalKeyCrt,
+			secret: secretWithData(map[string][]byte{
+				cmapi.PrivateKeyReferenceSecretKey: []byte("projects/test/keys/1"),
+				corev1.TLSCertKey:                  []byte("test"),
+			}),
+			reason:       SecretDataInvalid,
+			legacyReason: InvalidCertificate,
+			message:      `Issuing certificate as Secret contains an invalid certificate in "tls.crt": error decoding certificate PEM block`,
+		},
+	}
+
+	chains := map[string]Chain{
+		"trigger":   NewTriggerPolicyChain(clock, internalcertificates.PrivateKeyDefaults{}),
+		"readiness": NewReadinessPolicyChain(clock, internalcertificates.PrivateKeyDefaults{}),
+	}
+	for chainName, chain := range chains {
+		for name, test := range tests {
+			for _, legacy := range []bool{false, true} {
+				t.Run(fmt.Sprintf("%s/%s/legacy=%t", chainName, name, legacy), func(t *testing.T) {
+					defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, feature.LegacySecretPolicyReasons, legacy)()
+
+					reason, message, violation := chain.Evaluate(Input{
+						Certificate: test.certificate,
+						Secret:      test.secret,
+					})
+					expReason := test.reason
+					if legacy {
+						expReason = test.legacyReason
+					}
+					assert.True(t, violation)
+					assert.Equal(t, expReason, reason)
+					assert.Equal(t, test.message, message)
+				})
+			}
+		}
+	}
+}
+
+// Policies which decode the certificate in tls.crt report an invalid
+// certificate with the same reason as the Secret data policies.
+func Test_InvalidCertificatePolicyReasons(t *testing.T) {
+	clock := &fakeclock.FakeClock{}
+	input := Input{
+		Certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{SecretName: "something"}},
+		Secret: &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "something"},
+			Data:       map[string][]byte{corev1.TLSCertKey: []byte("test")},
+		},
+	}
+
+	policies := map[string]Func{
+		"CurrentCertificateNearingExpiry": CurrentCertificateNearingExpiry(clock),
+		"CurrentCertificateHasExpired":    CurrentCertificateHasExpired(clock),
+		"CurrentCertificateNotYetValid":   CurrentCertificateNotYetValid(clock),
+		"SecretCertificateChainInvalid":   SecretCertificateChainInvalid(clock),
+		"SecretIssuerChainExpiringSoon":   SecretIssuerChainExpiringSoon,
+	}
+	for name, policy := range policies {
+		for _, legacy := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s/legacy=%t", name, legacy), func(t *testing.T) {
+				defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, feature.LegacySecretPolicyReasons, legacy)()
+
+				expReason := SecretDataInvalid
+				if legacy {
+					expReason = InvalidCertificate
+				}
+				reason, _, violation := policy(input)
+				assert.True(t, violation)
+				assert.Equal(t, expReason, reason)
+			})
+		}
+	}
+}
+
+// Time based policies must make their decisions for the EvaluationTime of
+// the input, so that every policy in a chain sees the same instant even when
+// the clock moves on, and so that renewal boundaries can be tested exactly.
+func Test_TimeBasedPoliciesUseEvaluationTime(t *testing.T) {
+	pk := testcrypto.MustCreatePEMPrivateKey(t)
+	notBefore := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	notAfter := notBefore.Add(90 * 24 * time.Hour)
+	renewalTime := pki.RenewalTime(notBefore, notAfter, nil).Time
+
+	crt := &cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.com"}}
+	secret := &corev1.Secret{
+		Data: map[string][]byte{
+			corev1.TLSPrivateKeyKey: pk,
+			corev1.TLSCertKey:       testcrypto.MustCreateCertWithNotBeforeAfter(t, pk, crt, notBefore, notAfter),
+		},
+	}
+
+	// The clock is far past the expiry of the certificate, so that any
+	// policy using it instead of the EvaluationTime would fail.
+	clock := fakeclock.NewFakeClock(notAfter.Add(365 * 24 * time.Hour))
+
+	tests := map[string]struct {
+		evaluationTime time.Time
+		expRenewing    bool
+		expExpired     bool
+	}{
+		"just before the renewal time": {
+			evaluationTime: renewalTime.Add(-time.Nanosecond),
+		},
+		"exactly at the renewal time": {
+			evaluationTime: renewalTime,
+			expRenewing:    true,
+		},
+		"exactly at NotAfter": {
+			evaluationTime: notAfter,
+			expRenewing:    true,
+		},
+		"just after NotAfter": {
+			evaluationTime: notAfter.Add(time.Nanosecond),
+			expRenewing:    true,
+			expExpired:     true,
+		},
+		"falls back to the clock if no evaluation time is set": {
+			expRenewing: true,
+			expExpired:  true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			input := Input{Certificate: crt, Secret: secret, EvaluationTime: test.evaluationTime}
+
+			reason, _, renewing := CurrentCertificateNearingExpiry(clock)(input)
+			assert.Equal(t, test.expRenewing, renewing, "unexpected renewal decision")
+			if renewing {
+				assert.Equal(t, Renewing, reason)
+			}
+
+			reason, _, expired := CurrentCertificateHasExpired(clock)(input)
+			assert.Equal(t, test.expExpired, expired, "unexpected expiry decision")
+			if expired {
+				assert.Equal(t, Expired, reason)
+			}
+
+			// The chain of time based policies must agree with the
+			// individual policies.
+			reason, _, violated := Chain{CurrentCertificateHasExpired(clock), CurrentCertificateNearingExpiry(clock)}.Evaluate(input)
+			assert.Equal(t, test.expRenewing || test.expExpired, violated)
+			switch {
+			case test.expExpired:
+				assert.Equal(t, Expired, reason)
+			case test.expRenewing:
+				assert.Equal(t, Renewing, reason)
+			}
+		})
+	}
+}
+
+func Test_CurrentCertificateNotYetValid(t *testing.T) {
+	pk := testcrypto.MustCreatePEMPrivateKey(t)
+	notBefore := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	notAfter := notBefore.Add(time.Hour)
+
+	crt := &cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.com"}}
+	secret := &corev1.Secret{
+		Data: map[string][]byte{
+			corev1.TLSPrivateKeyKey: pk,
+			corev1.TLSCertKey:       testcrypto.MustCreateCertWithNotBeforeAfter(t, pk, crt, notBefore, notAfter),
+		},
+	}
+
+	tests := map[string]struct {
+		now            time.Time
+		expNotYetValid bool
+	}{
+		"issuer's clock is ahead of the cluster's clock": {
+			now:            notBefore.Add(-2 * time.Minute),
+			expNotYetValid: true,
+		},
+		"just before NotBefore": {
+			now:            notBefore.Add(-time.Nanosecond),
+			expNotYetValid: true,
+		},
+		"exactly at NotBefore": {
+			now: notBefore,
+		},
+		"after NotBefore": {
+			now: notBefore.Add(time.Minute),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			reason, message, notYetValid := CurrentCertificateNotYetValid(fakeclock.NewFakeClock(test.now))(Input{Certificate: crt, Secret: secret})
+			assert.Equal(t, test.expNotYetValid, notYetValid)
+			if test.expNotYetValid {
+				assert.Equal(t, NotYetValid, reason)
+				assert.Equal(t, "Certificate is not valid until "+notBefore.Format(time.RFC1123), message)
+			}
+		})
+	}
+}
+
 func Test_SecretManagedLabelsAndAnnotationsManagedFieldsMismatch(t *testing.T) {
 	const fieldManager = "cert-manager-unit-test"
 
@@ -832,6 +1591,50 @@ func Test_SecretSecretTemplateMismatch(t *testing.T) {
 	}
 }
 
+func Test_SecretImmutableMismatch(t *testing.T) {
+	tests := map[string]struct {
+		tmpl         *cmapi.CertificateSecretTemplate
+		immutable    *bool
+		expViolation bool
+	}{
+		"if SecretTemplate is nil and the Secret is not immutable, return false": {
+			tmpl:         nil,
+			immutable:    nil,
+			expViolation: false,
+		},
+		"if SecretTemplate is not immutable and the Secret is immutable, return true": {
+			tmpl:         &cmapi.CertificateSecretTemplate{},
+			immutable:    ptr.To(true),
+			expViolation: true,
+		},
+		"if SecretTemplate is immutable and the Secret is not immutable, return true": {
+			tmpl:         &cmapi.CertificateSecretTemplate{Immutable: true},
+			immutable:    ptr.To(false),
+			expViolation: true,
+		},
+		"if SecretTemplate is immutable and the Secret is immutable, return false": {
+			tmpl:         &cmapi.CertificateSecretTemplate{Immutable: true},
+			immutable:    ptr.To(true),
+			expViolation: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotReason, gotMessage, gotViolation := SecretImmutableMismatch(Input{
+				Certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{SecretTemplate: test.tmpl}},
+				Secret:      &corev1.Secret{Immutable: test.immutable},
+			})
+
+			assert.Equal(t, test.expViolation, gotViolation, "unexpected violation")
+			if test.expViolation {
+				assert.Equal(t, SecretTemplateMismatch, gotReason, "unexpected reason")
+				assert.Equal(t, "Certificate's SecretTemplate immutable value does not match Secret", gotMessage, "unexpected message")
+			}
+		})
+	}
+}
+
 func Test_SecretSecretTemplateManagedFieldsMismatch(t *testing.T) {
 	const fieldManager = "cert-manager-unit-test"
 
@@ -2338,3 +3141,1008 @@ func Test_SecretCertificateNameAnnotationsMismatch(t *testing.T) {
 		})
 	}
 }
+
+func Test_ClusterIssuerUnsupported(t *testing.T) {
+	tests := map[string]struct {
+		namespace string
+		issuerRef cmmeta.ObjectReference
+
+		expReason    string
+		expMessage   string
+		expViolation bool
+	}{
+		"not scoped to a namespace, referencing a ClusterIssuer, should return false": {
+			namespace:    "",
+			issuerRef:    cmmeta.ObjectReference{Name: "ca", Kind: cmapi.ClusterIssuerKind},
+			expViolation: false,
+		},
+		"scoped to a namespace, referencing an Issuer, should return false": {
+			namespace:    "testns",
+			issuerRef:    cmmeta.ObjectReference{Name: "ca", Kind: cmapi.IssuerKind},
+			expViolation: false,
+		},
+		"scoped to a namespace, referencing an external ClusterIssuer kind, should return false": {
+			namespace:    "testns",
+			issuerRef:    cmmeta.ObjectReference{Name: "ca", Kind: cmapi.ClusterIssuerKind, Group: "example.io"},
+			expViolation: false,
+		},
+		"scoped to a namespace, referencing a ClusterIssuer by its fully qualified kind, should return true": {
+			namespace:    "testns",
+			issuerRef:    cmmeta.ObjectReference{Name: "ca", Kind: "ClusterIssuer.cert-manager.io"},
+			expReason:    ClusterIssuerNotSupported,
+			expMessage:   `ClusterIssuers are not supported as cert-manager is scoped to the namespace "testns"`,
+			expViolation: true,
+		},
+		"scoped to a namespace, referencing a ClusterIssuer, should return true": {
+			namespace:    "testns",
+			issuerRef:    cmmeta.ObjectReference{Name: "ca", Kind: cmapi.ClusterIssuerKind, Group: "cert-manager.io"},
+			expReason:    ClusterIssuerNotSupported,
+			expMessage:   `ClusterIssuers are not supported as cert-manager is scoped to the namespace "testns"`,
+			expViolation: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			input := Input{
+				Certificate: gen.Certificate("test-certificate", gen.SetCertificateIssuer(test.issuerRef)),
+			}
+			gotReason, gotMessage, gotViolation := ClusterIssuerUnsupported(test.namespace)(input)
+			assert.Equal(t, test.expReason, gotReason)
+			assert.Equal(t, test.expMessage, gotMessage)
+			assert.Equal(t, test.expViolation, gotViolation)
+		})
+	}
+}
+
+func Test_SecretIssuerAnnotationsNotNormalized(t *testing.T) {
+	tests := map[string]struct {
+		annotations map[string]string
+
+		expReason    string
+		expMessage   string
+		expViolation bool
+	}{
+		"with canonical issuer annotations, should return false": {
+			annotations: map[string]string{
+				cmapi.IssuerNameAnnotationKey:  "testissuer",
+				cmapi.IssuerKindAnnotationKey:  "ClusterIssuer",
+				cmapi.IssuerGroupAnnotationKey: "cert-manager.io",
+			},
+		},
+		"with an external issuer, should return false": {
+			annotations: map[string]string{
+				cmapi.IssuerNameAnnotationKey:  "testissuer",
+				cmapi.IssuerKindAnnotationKey:  "AWSPCAIssuer",
+				cmapi.IssuerGroupAnnotationKey: "awspca.cert-manager.io",
+			},
+		},
+		"with missing issuer annotations, should return false": {
+			annotations: map[string]string{cmapi.IssuerNameAnnotationKey: "testissuer"},
+		},
+		"with an empty kind and group written by an older version, should return true": {
+			annotations: map[string]string{
+				cmapi.IssuerNameAnnotationKey:  "testissuer",
+				cmapi.IssuerKindAnnotationKey:  "",
+				cmapi.IssuerGroupAnnotationKey: "",
+			},
+			expReason:    SecretManagedMetadataMismatch,
+			expMessage:   `Secret issuer annotations kind "" and group "" are not in canonical form, expected kind "Issuer" and group "cert-manager.io"`,
+			expViolation: true,
+		},
+		"with a kind qualified with its group, should return true": {
+			annotations: map[string]string{
+				cmapi.IssuerNameAnnotationKey:  "testissuer",
+				cmapi.IssuerKindAnnotationKey:  "ClusterIssuer.cert-manager.io",
+				cmapi.IssuerGroupAnnotationKey: "cert-manager.io",
+			},
+			expReason:    SecretManagedMetadataMismatch,
+			expMessage:   `Secret issuer annotations kind "ClusterIssuer.cert-manager.io" and group "cert-manager.io" are not in canonical form, expected kind "ClusterIssuer" and group "cert-manager.io"`,
+			expViolation: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			input := Input{
+				Certificate: gen.Certificate("test-certificate"),
+				Secret:      &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Annotations: test.annotations}},
+			}
+			gotReason, gotMessage, gotViolation := SecretIssuerAnnotationsNotNormalized(input)
+			assert.Equal(t, test.expReason, gotReason)
+			assert.Equal(t, test.expMessage, gotMessage)
+			assert.Equal(t, test.expViolation, gotViolation)
+		})
+	}
+}
+
+func Test_SecretManagedAnnotationsMissing(t *testing.T) {
+	crt := gen.Certificate("test-certificate")
+	pk := testcrypto.MustCreatePEMPrivateKey(t)
+	certData := testcrypto.MustCreateCert(t, pk, &cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.com"}})
+
+	tests := map[string]struct {
+		input Input
+
+		expReason    string
+		expMessage   string
+		expViolation bool
+	}{
+		"with all managed annotations, should return false": {
+			input: Input{
+				Certificate: crt,
+				Secret: &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							cmapi.CertificateNameKey:       "test-certificate",
+							cmapi.IssuerNameAnnotationKey:  "testissuer",
+							cmapi.IssuerKindAnnotationKey:  "ClusterIssuer",
+							cmapi.IssuerGroupAnnotationKey: "cert-manager.io",
+							cmapi.CommonNameAnnotationKey:  "example.com",
+							cmapi.AltNamesAnnotationKey:    "",
+							cmapi.IPSANAnnotationKey:       "",
+							cmapi.URISANAnnotationKey:      "",
+						},
+					},
+					Data: map[string][]byte{corev1.TLSCertKey: certData},
+				},
+			},
+			expReason:    "",
+			expMessage:   "",
+			expViolation: false,
+		},
+		"with all annotations removed by a restore of the Secret, should return true": {
+			input: Input{
+				Certificate: crt,
+				Secret: &corev1.Secret{
+					Data: map[string][]byte{corev1.TLSCertKey: certData},
+				},
+			},
+			expReason:    SecretManagedMetadataMismatch,
+			expMessage:   "Secret is missing these Managed Annotations: [cert-manager.io/alt-names cert-manager.io/certificate-name cert-manager.io/common-name cert-manager.io/ip-sans cert-manager.io/issuer-group cert-manager.io/issuer-kind cert-manager.io/issuer-name cert-manager.io/uri-sans]",
+			expViolation: true,
+		},
+		"with only the issuer annotations removed, should return true": {
+			input: Input{
+				Certificate: crt,
+				Secret: &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							cmapi.CertificateNameKey:      "test-certificate",
+							cmapi.CommonNameAnnotationKey: "example.com",
+							cmapi.AltNamesAnnotationKey:   "",
+							cmapi.IPSANAnnotationKey:      "",
+							cmapi.URISANAnnotationKey:     "",
+						},
+					},
+					Data: map[string][]byte{corev1.TLSCertKey: certData},
+				},
+			},
+			expReason:    SecretManagedMetadataMismatch,
+			expMessage:   "Secret is missing these Managed Annotations: [cert-manager.io/issuer-group cert-manager.io/issuer-kind cert-manager.io/issuer-name]",
+			expViolation: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotReason, gotMessage, gotViolation := SecretManagedAnnotationsMissing(test.input)
+			assert.Equal(t, test.expReason, gotReason)
+			assert.Equal(t, test.expMessage, gotMessage)
+			assert.Equal(t, test.expViolation, gotViolation)
+		})
+	}
+}
+
+func Test_SecretCertificateChainInvalid(t *testing.T) {
+	now := time.Now()
+	clock := fakeclock.NewFakeClock(now)
+
+	mustCreate := func(name string, isCA bool, notAfter time.Time, parent *chainTestCert) *chainTestCert {
+		return mustCreateChainCert(t, now, name, isCA, notAfter, parent)
+	}
+	join := joinChainTestCerts
+
+	root := mustCreate("root", true, now.Add(time.Hour), nil)
+	intermediate := mustCreate("intermediate", true, now.Add(time.Hour), root)
+	leaf := mustCreate("leaf", false, now.Add(time.Hour), intermediate)
+	expiredIntermediate := mustCreate("expired-intermediate", true, now.Add(-time.Hour), root)
+	leafOfExpired := mustCreate("leaf", false, now.Add(time.Hour), expiredIntermediate)
+	otherRoot := mustCreate("other-root", true, now.Add(time.Hour), nil)
+	selfSigned := mustCreate("self-signed", false, now.Add(time.Hour), nil)
+
+	tests := map[string]struct {
+		tlsCrt []byte
+		caCrt  []byte
+		policy *cmapi.CertificateSecretCAPolicy
+
+		expReason    string
+		expMessage   string
+		expViolation bool
+	}{
+		"a self signed certificate is valid": {
+			tlsCrt: selfSigned.pem,
+			caCrt:  selfSigned.pem,
+		},
+		"a correctly ordered chain is valid": {
+			tlsCrt: join(leaf, intermediate),
+			caCrt:  root.pem,
+		},
+		"a correctly ordered chain including the root is valid": {
+			tlsCrt: join(leaf, intermediate, root),
+			caCrt:  root.pem,
+		},
+		"a chain without ca.crt is valid": {
+			tlsCrt: join(leaf, intermediate),
+		},
+		"a chain in the wrong order is invalid": {
+			tlsCrt:       join(leaf, root, intermediate),
+			caCrt:        root.pem,
+			expReason:    InvalidCertificateChain,
+			expMessage:   `Secret contains an invalid certificate chain: certificate 0 ("CN=leaf") was not issued by certificate 1 ("CN=root"), the chain is in the wrong order`,
+			expViolation: true,
+		},
+		"a chain with an unrelated certificate appended is invalid": {
+			tlsCrt:       join(leaf, intermediate, otherRoot),
+			caCrt:        root.pem,
+			expReason:    InvalidCertificateChain,
+			expMessage:   `Secret contains an invalid certificate chain: certificate 2 ("CN=other-root") is not part of the chain`,
+			expViolation: true,
+		},
+		"a chain with the leaf duplicated is invalid": {
+			tlsCrt:       join(leaf, intermediate, leaf),
+			caCrt:        root.pem,
+			expReason:    InvalidCertificateChain,
+			expMessage:   `Secret contains an invalid certificate chain: certificate 2 ("CN=leaf") is not part of the chain`,
+			expViolation: true,
+		},
+		"a chain with an expired intermediate is invalid": {
+			tlsCrt:       join(leafOfExpired, expiredIntermediate),
+			caCrt:        root.pem,
+			expReason:    InvalidCertificateChain,
+			expMessage:   `Secret contains an invalid certificate chain: intermediate certificate "CN=expired-intermediate" expired on ` + expiredIntermediate.cert.NotAfter.Format(time.RFC1123),
+			expViolation: true,
+		},
+		"a chain missing its intermediate is invalid when ca.crt is present": {
+			tlsCrt:       leaf.pem,
+			caCrt:        root.pem,
+			expReason:    InvalidCertificateChain,
+			expMessage:   `Secret contains an invalid certificate chain: certificate "CN=leaf" was not issued by any certificate in ca.crt, the chain may be incomplete`,
+			expViolation: true,
+		},
+		"a chain that does not lead to ca.crt is invalid": {
+			tlsCrt:       join(leaf, intermediate),
+			caCrt:        otherRoot.pem,
+			expReason:    InvalidCertificateChain,
+			expMessage:   `Secret contains an invalid certificate chain: certificate "CN=intermediate" was not issued by any certificate in ca.crt, the chain may be incomplete`,
+			expViolation: true,
+		},
+		"a chain that does not lead to a ca.crt taken from a fixed bundle is valid": {
+			tlsCrt: join(leaf, intermediate),
+			caCrt:  otherRoot.pem,
+			policy: &cmapi.CertificateSecretCAPolicy{
+				Type:      cmapi.CertificateSecretCAPolicyFromSecretRef,
+				BundleRef: &cmapi.CertificateCABundleReference{Kind: "ConfigMap", Name: "bundle", Key: "ca.crt"},
+			},
+			expViolation: false,
+		},
+		"a truncated chain is invalid": {
+			tlsCrt:       append(leaf.pem, intermediate.pem[:len(intermediate.pem)/2]...),
+			caCrt:        root.pem,
+			expReason:    SecretDataInvalid,
+			expMessage:   "Secret contains an invalid certificate: certificate chain contains a PEM block that could not be decoded, it may have been truncated",
+			expViolation: true,
+		},
+		"a chain containing a private key is invalid": {
+			tlsCrt:       append(join(leaf, intermediate), testcrypto.MustCreatePEMPrivateKey(t)...),
+			caCrt:        root.pem,
+			expReason:    SecretDataInvalid,
+			expMessage:   `Secret contains an invalid certificate: unexpected PEM block of type "PRIVATE KEY" in certificate chain`,
+			expViolation: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			input := Input{
+				Certificate: gen.Certificate("test", gen.SetCertificateSecretCAPolicy(test.policy)),
+				Secret: &corev1.Secret{
+					Data: map[string][]byte{
+						corev1.TLSCertKey: test.tlsCrt,
+						cmmeta.TLSCAKey:   test.caCrt,
+					},
+				},
+			}
+			gotReason, gotMessage, gotViolation := SecretCertificateChainInvalid(clock)(input)
+			assert.Equal(t, test.expReason, gotReason)
+			assert.Equal(t, test.expMessage, gotMessage)
+			assert.Equal(t, test.expViolation, gotViolation)
+		})
+	}
+}
+
+type chainTestCert struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+	pem  []byte
+}
+
+// mustCreateChainCert creates a certificate valid from 2 hours before now
+// until notAfter, signed by parent or self-signed if parent is nil.
+func mustCreateChainCert(t testing.TB, now time.Time, name string, isCA bool, notAfter time.Time, parent *chainTestCert) *chainTestCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(now.UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             now.Add(-2 * time.Hour),
+		NotAfter:              notAfter,
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+	}
+	signerCert, signerKey := tmpl, crypto.Signer(key)
+	if parent != nil {
+		signerCert, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signerCert, key.Public(), signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &chainTestCert{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+func joinChainTestCerts(certs ...*chainTestCert) []byte {
+	var out []byte
+	for _, c := range certs {
+		out = append(out, c.pem...)
+	}
+	return out
+}
+
+func Test_SecretIssuerChainExpiringSoon(t *testing.T) {
+	// Certificates are only accurate to the second.
+	now := time.Now().Truncate(time.Second)
+
+	// The leaf certificates are valid for 6 hours from 2 hours ago, so are
+	// due to be renewed in 2 hours.
+	renewalTime := now.Add(2 * time.Hour)
+	root := mustCreateChainCert(t, now, "root", true, now.Add(24*time.Hour), nil)
+	newChain := func(intermediateNotAfter time.Time) (*chainTestCert, *chainTestCert) {
+		intermediate := mustCreateChainCert(t, now, "intermediate", true, intermediateNotAfter, root)
+		leaf := mustCreateChainCert(t, now, "leaf", false, now.Add(4*time.Hour), intermediate)
+		return leaf, intermediate
+	}
+	leafBefore, intermediateBefore := newChain(renewalTime.Add(-time.Hour))
+	leafAt, intermediateAt := newChain(renewalTime)
+	leafAfter, intermediateAfter := newChain(renewalTime.Add(time.Hour))
+	expiringRoot := mustCreateChainCert(t, now, "expiring-root", true, renewalTime.Add(-time.Minute), nil)
+	leafOfExpiringRoot := mustCreateChainCert(t, now, "leaf", false, now.Add(4*time.Hour), expiringRoot)
+	selfSigned := mustCreateChainCert(t, now, "self-signed", false, now.Add(4*time.Hour), nil)
+	// A certificate issued just now, valid for 6 hours, along with an
+	// intermediate which was already due for renewal.
+	issuedLeafOfExpiring := mustCreateChainCert(t, now.Add(2*time.Hour), "leaf", false, now.Add(6*time.Hour), intermediateBefore)
+
+	tests := map[string]struct {
+		tlsCrt []byte
+		caCrt  []byte
+		policy *cmapi.CertificateSecretCAPolicy
+
+		expReason    string
+		expMessage   string
+		expViolation bool
+	}{
+		"an intermediate expiring before the renewal time is flagged": {
+			tlsCrt:       joinChainTestCerts(leafBefore, intermediateBefore),
+			caCrt:        root.pem,
+			expReason:    IssuerChainExpiringSoon,
+			expMessage:   `Issuer certificate "CN=intermediate" expires on ` + renewalTime.Add(-time.Hour).UTC().Format(time.RFC1123) + `, before the certificate is due to be renewed on ` + renewalTime.UTC().Format(time.RFC1123),
+			expViolation: true,
+		},
+		"an intermediate expiring at the renewal time is not flagged": {
+			tlsCrt: joinChainTestCerts(leafAt, intermediateAt),
+			caCrt:  root.pem,
+		},
+		"an intermediate expiring after the renewal time is not flagged": {
+			tlsCrt: joinChainTestCerts(leafAfter, intermediateAfter),
+			caCrt:  root.pem,
+		},
+		"a CA in ca.crt expiring before the renewal time is flagged": {
+			tlsCrt:       leafOfExpiringRoot.pem,
+			caCrt:        expiringRoot.pem,
+			expReason:    IssuerChainExpiringSoon,
+			expMessage:   `Issuer certificate "CN=expiring-root" expires on ` + renewalTime.Add(-time.Minute).UTC().Format(time.RFC1123) + `, before the certificate is due to be renewed on ` + renewalTime.UTC().Format(time.RFC1123),
+			expViolation: true,
+		},
+		"a CA bundle chosen through the SecretCAPolicy is not part of the issuer chain": {
+			tlsCrt: leafOfExpiringRoot.pem,
+			caCrt:  expiringRoot.pem,
+			policy: &cmapi.CertificateSecretCAPolicy{
+				Type:      cmapi.CertificateSecretCAPolicyFromSecretRef,
+				BundleRef: &cmapi.CertificateCABundleReference{Kind: "ConfigMap", Name: "bundle", Key: "ca.crt"},
+			},
+		},
+		"a self signed certificate is not flagged": {
+			tlsCrt: selfSigned.pem,
+			caCrt:  selfSigned.pem,
+		},
+		"an intermediate already due for renewal when the certificate was issued is not flagged": {
+			tlsCrt: joinChainTestCerts(issuedLeafOfExpiring, intermediateBefore),
+			caCrt:  root.pem,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			input := Input{
+				Certificate: gen.Certificate("test", gen.SetCertificateSecretCAPolicy(test.policy)),
+				Secret: &corev1.Secret{
+					Data: map[string][]byte{
+						corev1.TLSCertKey: test.tlsCrt,
+						cmmeta.TLSCAKey:   test.caCrt,
+					},
+				},
+			}
+			gotReason, gotMessage, gotViolation := SecretIssuerChainExpiringSoon(input)
+			assert.Equal(t, test.expReason, gotReason)
+			assert.Equal(t, test.expMessage, gotMessage)
+			assert.Equal(t, test.expViolation, gotViolation)
+		})
+	}
+
+	t.Run("the earliest expiry of the issuer chain is reported", func(t *testing.T) {
+		notAfter, ok := IssuerChainNotAfter(gen.Certificate("test"), &corev1.Secret{
+			Data: map[string][]byte{
+				corev1.TLSCertKey: joinChainTestCerts(leafBefore, intermediateBefore),
+				cmmeta.TLSCAKey:   root.pem,
+			},
+		})
+		assert.True(t, ok)
+		assert.True(t, notAfter.Equal(renewalTime.Add(-time.Hour)), "unexpected expiry %s", notAfter)
+
+		_, ok = IssuerChainNotAfter(gen.Certificate("test"), &corev1.Secret{
+			Data: map[string][]byte{
+				corev1.TLSCertKey: selfSigned.pem,
+				cmmeta.TLSCAKey:   selfSigned.pem,
+			},
+		})
+		assert.False(t, ok)
+	})
+}
+
+func Test_RenewalNotAfter(t *testing.T) {
+	// Certificates are only accurate to the second.
+	now := time.Now().Truncate(time.Second)
+	clock := fakeclock.NewFakeClock(now)
+
+	// The leaf certificates are valid for 6 hours from 2 hours ago, so are
+	// due to be renewed in 2 hours unless their chain expires first.
+	leafNotAfter := now.Add(4 * time.Hour)
+	root := mustCreateChainCert(t, now, "root", true, now.Add(24*time.Hour), nil)
+	newChain := func(intermediateNotAfter time.Time) []byte {
+		intermediate := mustCreateChainCert(t, now, "intermediate", true, intermediateNotAfter, root)
+		leaf := mustCreateChainCert(t, now, "leaf", false, leafNotAfter, intermediate)
+		return joinChainTestCerts(leaf, intermediate)
+	}
+	expiringSoon := mustCreateChainCert(t, now, "intermediate", true, now.Add(time.Hour), root)
+	leafOfExpiringSoon := mustCreateChainCert(t, now, "leaf", false, leafNotAfter, expiringSoon)
+	// The issuer returns a cross-signed root which had expired long before
+	// the certificate was issued.
+	expiredCrossSign := mustCreateChainCert(t, now, "cross-signed-root", true, now.Add(-30*24*time.Hour), nil)
+	// A certificate issued just now, valid for 6 hours, along with the
+	// intermediate expiring soon, e.g. because the issuer returned the same
+	// intermediate when the certificate was re-issued for its expiry.
+	issuedLeafOfExpiringSoon := mustCreateChainCert(t, now.Add(2*time.Hour), "leaf", false, now.Add(6*time.Hour), expiringSoon)
+
+	tests := map[string]struct {
+		tlsCrt       []byte
+		caCrt        []byte
+		modifiers    []gen.CertificateModifier
+		expNotAfter  time.Time
+		expRenewing  bool
+		expRenewalAt time.Time
+	}{
+		"the chain is ignored unless renewBeforeChainExpiry is set": {
+			tlsCrt:       joinChainTestCerts(leafOfExpiringSoon, expiringSoon),
+			caCrt:        root.pem,
+			expNotAfter:  leafNotAfter,
+			expRenewalAt: now.Add(2 * time.Hour),
+		},
+		"an intermediate expiring after the certificate does not change the renewal time": {
+			tlsCrt:       newChain(now.Add(12 * time.Hour)),
+			caCrt:        root.pem,
+			modifiers:    []gen.CertificateModifier{gen.SetCertificateRenewBeforeChainExpiry(true)},
+			expNotAfter:  leafNotAfter,
+			expRenewalAt: now.Add(2 * time.Hour),
+		},
+		"an intermediate expiring after the normal renewal time brings the renewal forward": {
+			tlsCrt:      newChain(now.Add(3 * time.Hour)),
+			caCrt:       root.pem,
+			modifiers:   []gen.CertificateModifier{gen.SetCertificateRenewBeforeChainExpiry(true)},
+			expNotAfter: now.Add(3 * time.Hour),
+			// 2/3 through the 5 hours between the certificate's NotBefore
+			// and the expiry of the intermediate.
+			expRenewalAt: now.Add(-2*time.Hour + 200*time.Minute),
+		},
+		"an intermediate expiring before the normal renewal time triggers renewal": {
+			tlsCrt:       joinChainTestCerts(leafOfExpiringSoon, expiringSoon),
+			caCrt:        root.pem,
+			modifiers:    []gen.CertificateModifier{gen.SetCertificateRenewBeforeChainExpiry(true)},
+			expNotAfter:  now.Add(time.Hour),
+			expRenewing:  true,
+			expRenewalAt: now,
+		},
+		"a CA in ca.crt expiring before the certificate brings the renewal forward": {
+			tlsCrt:       newChain(now.Add(12 * time.Hour)),
+			caCrt:        joinChainTestCerts(root, mustCreateChainCert(t, now, "other-root", true, now.Add(time.Hour), nil)),
+			modifiers:    []gen.CertificateModifier{gen.SetCertificateRenewBeforeChainExpiry(true)},
+			expNotAfter:  now.Add(time.Hour),
+			expRenewing:  true,
+			expRenewalAt: now,
+		},
+		"an excluded intermediate is ignored": {
+			tlsCrt: joinChainTestCerts(leafOfExpiringSoon, expiringSoon),
+			caCrt:  root.pem,
+			modifiers: []gen.CertificateModifier{
+				gen.SetCertificateRenewBeforeChainExpiry(true),
+				gen.SetCertificateChainExpiryExcludedFingerprints(strings.ToUpper(pki.FingerprintSHA256(expiringSoon.cert))),
+			},
+			expNotAfter:  leafNotAfter,
+			expRenewalAt: now.Add(2 * time.Hour),
+		},
+		"an intermediate already due for renewal when the certificate was issued is ignored": {
+			tlsCrt:       joinChainTestCerts(issuedLeafOfExpiringSoon, expiringSoon),
+			caCrt:        root.pem,
+			modifiers:    []gen.CertificateModifier{gen.SetCertificateRenewBeforeChainExpiry(true)},
+			expNotAfter:  now.Add(6 * time.Hour),
+			expRenewalAt: now.Add(4 * time.Hour),
+		},
+		"a certificate which had expired before the certificate was issued is ignored": {
+			tlsCrt:       newChain(now.Add(12 * time.Hour)),
+			caCrt:        joinChainTestCerts(root, expiredCrossSign),
+			modifiers:    []gen.CertificateModifier{gen.SetCertificateRenewBeforeChainExpiry(true)},
+			expNotAfter:  leafNotAfter,
+			expRenewalAt: now.Add(2 * time.Hour),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			crt := gen.Certificate("test", test.modifiers...)
+			secret := &corev1.Secret{
+				Data: map[string][]byte{
+					corev1.TLSCertKey: test.tlsCrt,
+					cmmeta.TLSCAKey:   test.caCrt,
+				},
+			}
+			leaf, err := pki.DecodeX509CertificateBytes(test.tlsCrt)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			notAfter := RenewalNotAfter(crt, secret, leaf)
+			assert.True(t, notAfter.Equal(test.expNotAfter), "expected %s, got %s", test.expNotAfter, notAfter)
+
+			renewalTime := pki.RenewalTime(leaf.NotBefore, notAfter, crt.Spec.RenewBefore)
+			assert.True(t, renewalTime.Time.Equal(test.expRenewalAt), "expected renewal at %s, got %s", test.expRenewalAt, renewalTime.Time)
+
+			reason, _, renewing := CurrentCertificateNearingExpiry(clock)(Input{Certificate: crt, Secret: secret})
+			assert.Equal(t, test.expRenewing, renewing)
+			if test.expRenewing {
+				assert.Equal(t, Renewing, reason)
+			}
+		})
+	}
+}
+
+func Test_SecretManagedDataModifiedExternally(t *testing.T) {
+	const (
+		fieldManager = "cert-manager-test"
+		issuedFields = `{"f:metadata": {"f:annotations": {"f:cert-manager.io/certificate-name": {}}},
+			"f:data": {"f:tls.crt": {}, "f:tls.key": {}, "f:ca.crt": {}}}`
+	)
+
+	tests := map[string]struct {
+		certificate   *cmapi.Certificate
+		managedFields []metav1.ManagedFieldsEntry
+
+		expReason    string
+		expMessage   string
+		expViolation bool
+	}{
+		"if the Secret has no managed fields, should return false": {
+			expViolation: false,
+		},
+		"if only cert-manager manages the Secret's data, should return false": {
+			managedFields: []metav1.ManagedFieldsEntry{
+				{Manager: fieldManager, FieldsV1: &metav1.FieldsV1{Raw: []byte(issuedFields)}},
+			},
+			expViolation: false,
+		},
+		"if another field manager manages an unrelated data key, should return false": {
+			managedFields: []metav1.ManagedFieldsEntry{
+				{Manager: fieldManager, FieldsV1: &metav1.FieldsV1{Raw: []byte(issuedFields)}},
+				{Manager: "kubectl-edit", FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:data": {"f:extra": {}}}`)}},
+			},
+			expViolation: false,
+		},
+		"if no field manager manages the certificate name annotation, should return false": {
+			managedFields: []metav1.ManagedFieldsEntry{
+				{Manager: "kubectl-edit", FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:data": {"f:tls.crt": {}}}`)}},
+			},
+			expViolation: false,
+		},
+		"if another field manager has modified the certificate, should return true": {
+			managedFields: []metav1.ManagedFieldsEntry{
+				{Manager: fieldManager, FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:metadata": {"f:annotations": {"f:cert-manager.io/certificate-name": {}}},
+					"f:data": {"f:tls.key": {}, "f:ca.crt": {}}}`)}},
+				{Manager: "kubectl-edit", FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:data": {"f:tls.crt": {}}}`)}},
+			},
+			expReason:    SecretModifiedExternally,
+			expMessage:   `Secret key "tls.crt" has been modified by field manager "kubectl-edit"`,
+			expViolation: true,
+		},
+		"if another field manager wrote data keys before the Secret was adopted, should return false": {
+			managedFields: []metav1.ManagedFieldsEntry{
+				{Manager: "kubectl-create", FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:data": {"f:tls.crt": {}, "f:tls.key": {}}}`)}},
+				{Manager: fieldManager, FieldsV1: &metav1.FieldsV1{Raw: []byte(issuedFields)}},
+			},
+			expViolation: false,
+		},
+		"if another field manager has modified the CA, should return true": {
+			managedFields: []metav1.ManagedFieldsEntry{
+				{Manager: fieldManager, FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:metadata": {"f:annotations": {"f:cert-manager.io/certificate-name": {}}},
+					"f:data": {"f:tls.crt": {}, "f:tls.key": {}}}`)}},
+				{Manager: "trust-operator", FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:data": {"f:ca.crt": {}}}`)}},
+			},
+			expReason:    SecretModifiedExternally,
+			expMessage:   `Secret key "ca.crt" has been modified by field manager "trust-operator"`,
+			expViolation: true,
+		},
+		"if another field manager manages the private key of a Certificate using an external CSR, should return false": {
+			certificate: gen.Certificate("test-certificate", gen.SetCertificateExternalCSR("csr", "tls.csr")),
+			managedFields: []metav1.ManagedFieldsEntry{
+				{Manager: fieldManager, FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:metadata": {"f:annotations": {"f:cert-manager.io/certificate-name": {}}},
+					"f:data": {"f:tls.crt": {}}}`)}},
+				{Manager: "key-operator", FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:data": {"f:tls.key": {}}}`)}},
+			},
+			expViolation: false,
+		},
+		"if another field manager has modified the private key reference of a Certificate using an external private key, should return true": {
+			certificate: gen.Certificate("test-certificate", gen.SetCertificateExternalPrivateKey("projects/p/locations/l/keyRings/r")),
+			managedFields: []metav1.ManagedFieldsEntry{
+				{Manager: fieldManager, FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:metadata": {"f:annotations": {"f:cert-manager.io/certificate-name": {}}},
+					"f:data": {"f:tls.crt": {}}}`)}},
+				{Manager: "key-operator", FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:data": {"f:tls.key": {}, "f:` + cmapi.PrivateKeyReferenceSecretKey + `": {}}}`)}},
+			},
+			expReason:    SecretModifiedExternally,
+			expMessage:   `Secret key "` + cmapi.PrivateKeyReferenceSecretKey + `" has been modified by field manager "key-operator"`,
+			expViolation: true,
+		},
+		"if the managed fields cannot be decoded, should return true": {
+			managedFields: []metav1.ManagedFieldsEntry{
+				{Manager: fieldManager, FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:data": `)}},
+			},
+			expReason:    ManagedFieldsParseError,
+			expMessage:   "failed to decode managed fields on Secret: ",
+			expViolation: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			crt := test.certificate
+			if crt == nil {
+				crt = gen.Certificate("test-certificate")
+			}
+			input := Input{
+				Certificate: crt,
+				Secret: &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{ManagedFields: test.managedFields},
+				},
+			}
+			gotReason, gotMessage, gotViolation := SecretManagedDataModifiedExternally(input)
+			assert.Equal(t, test.expReason, gotReason)
+			if test.expReason == ManagedFieldsParseError {
+				assert.Contains(t, gotMessage, test.expMessage)
+			} else {
+				assert.Equal(t, test.expMessage, gotMessage)
+			}
+			assert.Equal(t, test.expViolation, gotViolation)
+		})
+	}
+}
+
+func Test_SecretCertificateIssuedKeyMismatch(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Now())
+	crt := gen.Certificate("test-certificate", gen.SetCertificateDNSNames("example.com"))
+	bundle := testcrypto.MustCreateCryptoBundle(t, crt, clock)
+	otherBundle := testcrypto.MustCreateCryptoBundle(t, crt, clock)
+
+	tests := map[string]struct {
+		req  *cmapi.CertificateRequest
+		cert []byte
+
+		expReason    string
+		expViolation bool
+	}{
+		"if there is no current CertificateRequest, should return false": {
+			cert:         otherBundle.CertBytes,
+			expViolation: false,
+		},
+		"if the certificate was issued for the requested key, should return false": {
+			req:          bundle.CertificateRequestReady,
+			cert:         bundle.CertBytes,
+			expViolation: false,
+		},
+		"if the certificate was issued for another key, should return true": {
+			req:          bundle.CertificateRequestReady,
+			cert:         otherBundle.CertBytes,
+			expReason:    IssuedKeyMismatch,
+			expViolation: true,
+		},
+		"if the certificate cannot be decoded, should return true": {
+			req:          bundle.CertificateRequestReady,
+			cert:         []byte("invalid"),
+			expReason:    InvalidCertificate,
+			expViolation: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			input := Input{
+				Certificate:            crt,
+				CurrentRevisionRequest: test.req,
+				Secret: &corev1.Secret{
+					Data: map[string][]byte{corev1.TLSCertKey: test.cert},
+				},
+			}
+			gotReason, _, gotViolation := SecretCertificateIssuedKeyMismatch(input)
+			assert.Equal(t, test.expReason, gotReason)
+			assert.Equal(t, test.expViolation, gotViolation)
+		})
+	}
+}
+
+func Test_SecretCAPolicyMismatch(t *testing.T) {
+	tests := map[string]struct {
+		caCrt    []byte
+		secretCA []byte
+
+		expReason    string
+		expViolation bool
+	}{
+		"if ca.crt matches, should return false": {
+			caCrt:        []byte("ca"),
+			secretCA:     []byte("ca"),
+			expViolation: false,
+		},
+		"if ca.crt should be omitted and is not present, should return false": {
+			expViolation: false,
+		},
+		"if ca.crt should be omitted but is present, should return true": {
+			caCrt:        []byte("ca"),
+			expReason:    SecretCAMismatch,
+			expViolation: true,
+		},
+		"if ca.crt is missing, should return true": {
+			secretCA:     []byte("ca"),
+			expReason:    SecretCAMismatch,
+			expViolation: true,
+		},
+		"if ca.crt differs, should return true": {
+			caCrt:        []byte("issuer-ca"),
+			secretCA:     []byte("bundle"),
+			expReason:    SecretCAMismatch,
+			expViolation: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			input := Input{
+				Certificate: gen.Certificate("test-certificate"),
+				Secret: &corev1.Secret{
+					Data: map[string][]byte{cmmeta.TLSCAKey: test.caCrt},
+				},
+				SecretCA: test.secretCA,
+			}
+			gotReason, _, gotViolation := SecretCAPolicyMismatch(input)
+			assert.Equal(t, test.expReason, gotReason)
+			assert.Equal(t, test.expViolation, gotViolation)
+		})
+	}
+}
+
+func Test_SecretIssuerCAUnexpected(t *testing.T) {
+	// Certificates are only accurate to the second.
+	now := time.Now().Truncate(time.Second)
+
+	fingerprint := func(c *chainTestCert) string {
+		f, err := pki.PublicKeyFingerprintSHA256(c.cert.PublicKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return f
+	}
+
+	root := mustCreateChainCert(t, now, "root", true, now.Add(24*time.Hour), nil)
+	intermediate := mustCreateChainCert(t, now, "intermediate", true, now.Add(24*time.Hour), root)
+	leaf := mustCreateChainCert(t, now, "leaf", false, now.Add(4*time.Hour), intermediate)
+
+	// A CA which is not known to the issuer, with the same subject.
+	rogue := mustCreateChainCert(t, now, "intermediate", true, now.Add(24*time.Hour), root)
+	rogueLeaf := mustCreateChainCert(t, now, "leaf", false, now.Add(4*time.Hour), rogue)
+
+	// The CA issuer's CA certificate is renewed with the same key, and later
+	// rotated to a new key.
+	ca := mustCreateChainCert(t, now, "ca", true, now.Add(24*time.Hour), nil)
+	caLeaf := mustCreateChainCert(t, now, "leaf", false, now.Add(4*time.Hour), ca)
+	renewedCA := mustRenewChainCert(t, now, ca)
+	rotatedCA := mustCreateChainCert(t, now, "ca", true, now.Add(48*time.Hour), nil)
+	rotatedCALeaf := mustCreateChainCert(t, now, "leaf", false, now.Add(4*time.Hour), rotatedCA)
+
+	selfSigned := mustCreateChainCert(t, now, "self-signed", false, now.Add(4*time.Hour), nil)
+
+	currentRequest := func(certificate, ca *chainTestCert) *cmapi.CertificateRequest {
+		return gen.CertificateRequest("test", gen.SetCertificateRequestCertificate(certificate.pem), gen.SetCertificateRequestCA(ca.pem))
+	}
+
+	tests := map[string]struct {
+		fingerprints   []string
+		tlsCrt         []byte
+		caCrt          []byte
+		currentRequest *cmapi.CertificateRequest
+		// issuerCA is the tls.crt of the issuer's CA Secret, which could not
+		// be fetched if issuerCAUnavailable is set.
+		issuerCA            []byte
+		issuerCAUnavailable bool
+
+		expReason    string
+		expViolation bool
+	}{
+		"if no CA fingerprints are recorded, should return false": {
+			tlsCrt:       joinChainTestCerts(rogueLeaf, rogue),
+			caCrt:        root.pem,
+			expViolation: false,
+		},
+		"if the certificate was signed by the recorded intermediate, should return false": {
+			fingerprints: []string{fingerprint(intermediate), fingerprint(root)},
+			tlsCrt:       joinChainTestCerts(leaf, intermediate),
+			caCrt:        root.pem,
+			expViolation: false,
+		},
+		"if the certificate was signed by a CA with the same name but another key, should return true": {
+			fingerprints: []string{fingerprint(intermediate), fingerprint(root)},
+			tlsCrt:       joinChainTestCerts(rogueLeaf, rogue),
+			caCrt:        root.pem,
+			expReason:    UnexpectedIssuerCA,
+			expViolation: true,
+		},
+		"if the signer of the certificate is not in the Secret or the current CertificateRequest, should return true": {
+			fingerprints: []string{fingerprint(intermediate), fingerprint(root)},
+			tlsCrt:       joinChainTestCerts(leaf, rogue),
+			caCrt:        root.pem,
+			expReason:    UnexpectedIssuerCA,
+			expViolation: true,
+		},
+		"if the signer of the certificate is only in the current CertificateRequest, should return false": {
+			fingerprints:   []string{fingerprint(intermediate), fingerprint(root)},
+			tlsCrt:         joinChainTestCerts(leaf, rogue),
+			caCrt:          root.pem,
+			currentRequest: currentRequest(leaf, intermediate),
+			expViolation:   false,
+		},
+		"if ca.crt is omitted, the signer should be looked up in the current CertificateRequest": {
+			fingerprints:   []string{fingerprint(ca)},
+			tlsCrt:         caLeaf.pem,
+			currentRequest: currentRequest(caLeaf, ca),
+			expViolation:   false,
+		},
+		"if the CA certificate was renewed with the same key, should return false": {
+			fingerprints: []string{fingerprint(ca)},
+			tlsCrt:       caLeaf.pem,
+			caCrt:        renewedCA.pem,
+			expViolation: false,
+		},
+		"if the certificate was re-issued by a rotated CA which was recorded upon issuance, should return false": {
+			fingerprints:   []string{fingerprint(rotatedCA)},
+			tlsCrt:         rotatedCALeaf.pem,
+			caCrt:          rotatedCA.pem,
+			currentRequest: currentRequest(rotatedCALeaf, rotatedCA),
+			expViolation:   false,
+		},
+		"if the certificate was signed by the rotated CA but the previous CA is recorded, should return true": {
+			fingerprints: []string{fingerprint(ca)},
+			tlsCrt:       rotatedCALeaf.pem,
+			caCrt:        rotatedCA.pem,
+			expReason:    UnexpectedIssuerCA,
+			expViolation: true,
+		},
+		"if the certificate was signed by the rotated CA held by the issuer's CA Secret, should return false": {
+			fingerprints: []string{fingerprint(ca)},
+			tlsCrt:       rotatedCALeaf.pem,
+			caCrt:        rotatedCA.pem,
+			issuerCA:     rotatedCA.pem,
+			expViolation: false,
+		},
+		"if the certificate was not signed by the CA held by the issuer's CA Secret, should return true": {
+			fingerprints: []string{fingerprint(intermediate), fingerprint(root)},
+			tlsCrt:       joinChainTestCerts(rogueLeaf, rogue),
+			caCrt:        root.pem,
+			issuerCA:     intermediate.pem,
+			expReason:    UnexpectedIssuerCA,
+			expViolation: true,
+		},
+		"if the certificate was not signed by a recorded CA but the issuer's CA is unavailable, should return false": {
+			fingerprints:        []string{fingerprint(intermediate), fingerprint(root)},
+			tlsCrt:              joinChainTestCerts(rogueLeaf, rogue),
+			caCrt:               root.pem,
+			issuerCAUnavailable: true,
+			expViolation:        false,
+		},
+		"if a self-signed certificate is recorded as its own CA, should return false": {
+			fingerprints: []string{fingerprint(selfSigned)},
+			tlsCrt:       selfSigned.pem,
+			caCrt:        selfSigned.pem,
+			expViolation: false,
+		},
+		"if the certificate cannot be decoded, should return true": {
+			fingerprints: []string{fingerprint(ca)},
+			tlsCrt:       []byte("invalid"),
+			expReason:    SecretDataInvalid,
+			expViolation: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			crt := gen.Certificate("test-certificate")
+			crt.Status.IssuerCAFingerprints = test.fingerprints
+			input := Input{
+				Certificate: crt,
+				Secret: &corev1.Secret{
+					Data: map[string][]byte{
+						corev1.TLSCertKey: test.tlsCrt,
+						cmmeta.TLSCAKey:   test.caCrt,
+					},
+				},
+				CurrentRevisionRequest: test.currentRequest,
+			}
+			if test.issuerCA != nil {
+				input.IssuerCASecret = &corev1.Secret{Data: map[string][]byte{corev1.TLSCertKey: test.issuerCA}}
+			}
+			if test.issuerCAUnavailable {
+				input.setUnavailable(IssuerCAInput, errors.New("connection refused"))
+			}
+			gotReason, _, gotViolation := SecretIssuerCAUnexpected(input)
+			assert.Equal(t, test.expReason, gotReason)
+			assert.Equal(t, test.expViolation, gotViolation)
+		})
+	}
+}
+
+// mustRenewChainCert creates a new self-signed certificate for the key and
+// subject of the given certificate, as when a CA certificate is renewed
+// without rotating its key.
+func mustRenewChainCert(t *testing.T, now time.Time, c *chainTestCert) *chainTestCert {
+	tmpl := *c.cert
+	tmpl.SerialNumber = big.NewInt(now.UnixNano() + 1)
+	tmpl.NotAfter = c.cert.NotAfter.Add(24 * time.Hour)
+	der, err := x509.CreateCertificate(rand.Reader, &tmpl, &tmpl, c.key.Public(), c.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &chainTestCert{cert: cert, key: c.key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
@@ -16,19 +16,56 @@ limitations under the License.
 
 package policies
 
+// The Secret policy violation reasons are reported, in this order, when the
+// Certificate's spec.secretName Secret cannot be used as it is.
 const (
-	// DoesNotExist is a policy violation reason for a scenario where
-	// Certificate's spec.secretName secret does not exist.
+	// SecretMissing is a policy violation reason for a scenario where
+	// Certificate's spec.secretName Secret does not exist.
+	SecretMissing string = "SecretMissing"
+	// SecretEmpty is a policy violation reason for a scenario where
+	// Certificate's spec.secretName Secret exists but none of its keys hold
+	// any data, e.g. a placeholder Secret created by a Helm chart.
+	SecretEmpty string = "SecretEmpty"
+	// SecretKeyMissing is a policy violation reason for a scenario where
+	// Certificate's spec.secretName Secret holds data, but not the private key
+	// in tls.key or, for external private keys, the private key reference.
+	SecretKeyMissing string = "SecretKeyMissing"
+	// SecretCertMissing is a policy violation reason for a scenario where
+	// Certificate's spec.secretName Secret holds data, but not the signed
+	// certificate in tls.crt.
+	SecretCertMissing string = "SecretCertMissing"
+	// SecretDataInvalid is a policy violation reason for a scenario where the
+	// private key in tls.key or the certificate in tls.crt of Certificate's
+	// spec.secretName Secret could not be parsed or decoded.
+	SecretDataInvalid string = "SecretDataInvalid"
+)
+
+const (
+	// DoesNotExist is the reason reported instead of SecretMissing if the
+	// LegacySecretPolicyReasons feature gate is enabled.
+	//
+	// Deprecated: use SecretMissing.
 	DoesNotExist string = "DoesNotExist"
-	// MissingData is a policy violation reason for a scenario where
-	// Certificate's spec.secretName secret has missing data.
+	// MissingData is the reason reported instead of SecretEmpty,
+	// SecretKeyMissing and SecretCertMissing if the LegacySecretPolicyReasons
+	// feature gate is enabled.
+	//
+	// Deprecated: use SecretEmpty, SecretKeyMissing or SecretCertMissing.
 	MissingData string = "MissingData"
 	// InvalidKeyPair is a policy violation reason for a scenario where public
-	// key of certificate does not match private key.
+	// key of certificate does not match private key. It is also reported
+	// instead of SecretDataInvalid for an invalid private key if the
+	// LegacySecretPolicyReasons feature gate is enabled.
 	InvalidKeyPair string = "InvalidKeyPair"
 	// InvalidCertificate is a policy violation whereby the signed certificate in
-	// the Input Secret could not be parsed or decoded.
+	// the Input Secret could not be parsed or decoded. It is also reported
+	// instead of SecretDataInvalid for an invalid certificate if the
+	// LegacySecretPolicyReasons feature gate is enabled.
 	InvalidCertificate string = "InvalidCertificate"
+	// InvalidCertificateChain is a policy violation whereby the chain of
+	// certificates in the Secret is out of order, contains an expired or
+	// unrelated certificate, or does not lead to the CA in ca.crt.
+	InvalidCertificateChain string = "InvalidCertificateChain"
 	// InvalidCertificateRequest is a policy violation whereby the CSR in
 	// the Input CertificateRequest could not be parsed or decoded.
 	InvalidCertificateRequest string = "InvalidCertificateRequest"
@@ -53,6 +90,9 @@ const (
 	// Expired is a policy violation reason for a scenario where Certificate has
 	// expired.
 	Expired string = "Expired"
+	// NotYetValid is a policy violation reason for a scenario where the
+	// Certificate's NotBefore is in the future.
+	NotYetValid string = "NotYetValid"
 	// SecretTemplateMisMatch is a policy violation whereby the Certificate's
 	// SecretTemplate is not reflected on the target Secret, either by having
 	// extra, missing, or wrong Annotations or Labels.
@@ -72,4 +112,48 @@ const (
 	// a missing owner reference to the Certificate, or has an owner reference it
 	// shouldn't have.
 	SecretOwnerRefMismatch string = "SecretOwnerRefMismatch"
+	// ClusterIssuerNotSupported is a policy violation whereby the Certificate
+	// references a ClusterIssuer whilst cert-manager is scoped to a single
+	// namespace.
+	ClusterIssuerNotSupported string = "ClusterIssuerNotSupported"
+	// SecretModifiedExternally is a policy violation whereby the signed
+	// certificate, private key or CA in the Secret have been modified by a
+	// field manager other than cert-manager.
+	SecretModifiedExternally string = "SecretModifiedExternally"
+	// IssuedKeyMismatch is a policy violation whereby the certificate issued
+	// for a CertificateRequest is for a different public key than the one that
+	// was requested.
+	IssuedKeyMismatch string = "IssuedKeyMismatch"
+	// SecretCAMismatch is a policy violation whereby the ca.crt key of the
+	// Secret does not match the Certificate's SecretCAPolicy.
+	SecretCAMismatch string = "SecretCAMismatch"
+	// SecretNotAdopted is a policy violation whereby the Certificate has not
+	// been issued yet and its Secret already exists, but adoption of existing
+	// Secrets is disabled for the Certificate.
+	SecretNotAdopted string = "SecretNotAdopted"
+	// IssuerChainExpiringSoon is a policy violation whereby a certificate in
+	// the issuer chain stored in the Secret expires before the issued
+	// certificate is due to be renewed.
+	IssuerChainExpiringSoon string = "IssuerChainExpiringSoon"
+	// UnexpectedIssuerCA is a policy violation whereby the certificate in the
+	// Secret was not signed by any of the CAs recorded on the Certificate's
+	// status when it was issued.
+	UnexpectedIssuerCA string = "UnexpectedIssuerCA"
+)
+
+// The names of the trigger policies, which are reported in the
+// status.lastEvaluation of Certificates. They are read by tools outside of
+// cert-manager, so they must not be changed once released.
+const (
+	PolicySecretDoesNotExist                                  string = "SecretDoesNotExist"
+	PolicySecretIsMissingData                                 string = "SecretIsMissingData"
+	PolicySecretPublicKeysDiffer                              string = "SecretPublicKeysDiffer"
+	PolicySecretIssuerAnnotationsMismatch                     string = "SecretIssuerAnnotationsMismatch"
+	PolicySecretCertificateNameAnnotationsMismatch            string = "SecretCertificateNameAnnotationsMismatch"
+	PolicySecretAdoptionDisabled                              string = "SecretAdoptionDisabled"
+	PolicySecretPrivateKeyMismatchesSpec                      string = "SecretPrivateKeyMismatchesSpec"
+	PolicySecretPublicKeyDiffersFromCurrentCertificateRequest string = "SecretPublicKeyDiffersFromCurrentCertificateRequest"
+	PolicyCurrentCertificateRequestMismatchesSpec             string = "CurrentCertificateRequestMismatchesSpec"
+	PolicySecretIssuerCAUnexpected                            string = "SecretIssuerCAUnexpected"
+	PolicyCurrentCertificateNearingExpiry                     string = "CurrentCertificateNearingExpiry"
 )
@@ -0,0 +1,151 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fake contains an in-memory implementation of the policies Gatherer,
+// used to evaluate policies in tests without fake clientsets and informers.
+package fake
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+
+	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// Gatherer is an in-memory implementation of policies.Gatherer. It gathers
+// data from the CertificateRequests and Secrets it has been given, with the
+// same semantics as policies.Gatherer.DataForCertificate: missing objects are
+// left nil, and duplicate CertificateRequests for a revision are an error.
+type Gatherer struct {
+	clock clock.Clock
+
+	requests []*cmapi.CertificateRequest
+	secrets  []*corev1.Secret
+}
+
+// NewGatherer returns a Gatherer without any objects, which uses the given
+// clock to set the EvaluationTime of the gathered data.
+func NewGatherer(c clock.Clock) *Gatherer {
+	return &Gatherer{clock: c}
+}
+
+// WithCertificateRequests adds the given CertificateRequests to the Gatherer.
+func (g *Gatherer) WithCertificateRequests(reqs ...*cmapi.CertificateRequest) *Gatherer {
+	g.requests = append(g.requests, reqs...)
+	return g
+}
+
+// WithSecrets adds the given Secrets to the Gatherer.
+func (g *Gatherer) WithSecrets(secrets ...*corev1.Secret) *Gatherer {
+	g.secrets = append(g.secrets, secrets...)
+	return g
+}
+
+// DataForCertificate returns the Certificate's Secret and the "current" and
+// "next" CertificateRequests, as policies.Gatherer.DataForCertificate does.
+func (g *Gatherer) DataForCertificate(_ context.Context, crt *cmapi.Certificate) (policies.Input, error) {
+	input := policies.Input{
+		Certificate:    crt,
+		EvaluationTime: g.clock.Now(),
+	}
+
+	for _, secret := range g.secrets {
+		if secret.Namespace == crt.Namespace && secret.Name == crt.Spec.SecretName {
+			input.Secret = secret
+			break
+		}
+	}
+
+	nextRevision := 1
+	if crt.Status.Revision != nil {
+		var err error
+		input.CurrentRevisionRequest, err = g.requestForRevision(crt, *crt.Status.Revision, "current")
+		if err != nil {
+			return policies.Input{}, err
+		}
+		nextRevision = *crt.Status.Revision + 1
+	}
+
+	var err error
+	input.NextRevisionRequest, err = g.requestForRevision(crt, nextRevision, "next")
+	if err != nil {
+		return policies.Input{}, err
+	}
+	return input, nil
+}
+
+// requestForRevision returns the CertificateRequest controlled by the
+// Certificate for the given revision, or nil if there is none.
+func (g *Gatherer) requestForRevision(crt *cmapi.Certificate, revision int, name string) (*cmapi.CertificateRequest, error) {
+	var found []*cmapi.CertificateRequest
+	for _, req := range g.requests {
+		if req.Namespace == crt.Namespace &&
+			metav1.IsControlledBy(req, crt) &&
+			req.Annotations[cmapi.CertificateRequestRevisionAnnotationKey] == strconv.Itoa(revision) {
+			found = append(found, req)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return nil, nil
+	case 1:
+		return found[0], nil
+	default:
+		return nil, fmt.Errorf("multiple CertificateRequests were found for the '%s' revision %v, issuance is skipped until there are no more duplicates", name, revision)
+	}
+}
+
+// CertificateAtRevision returns a copy of the Certificate with the given
+// status.revision and status conditions. A revision of 0 leaves the revision
+// unset, as for a Certificate which has not been issued yet.
+func CertificateAtRevision(crt *cmapi.Certificate, revision int, conditions ...cmapi.CertificateCondition) *cmapi.Certificate {
+	crt = crt.DeepCopy()
+	crt.Status.Revision = nil
+	if revision > 0 {
+		crt.Status.Revision = &revision
+	}
+	crt.Status.Conditions = conditions
+	return crt
+}
+
+// CertificateRequestForRevision returns a CertificateRequest controlled by
+// the Certificate for the given revision, with the given status conditions.
+func CertificateRequestForRevision(crt *cmapi.Certificate, revision int, conditions ...cmapi.CertificateRequestCondition) *cmapi.CertificateRequest {
+	return &cmapi.CertificateRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: crt.Namespace,
+			Name:      fmt.Sprintf("%s-%d", crt.Name, revision),
+			Annotations: map[string]string{
+				cmapi.CertificateRequestRevisionAnnotationKey: strconv.Itoa(revision),
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(crt, cmapi.SchemeGroupVersion.WithKind(cmapi.CertificateKind)),
+			},
+		},
+		Spec: cmapi.CertificateRequestSpec{
+			IssuerRef: crt.Spec.IssuerRef,
+		},
+		Status: cmapi.CertificateRequestStatus{
+			Conditions: conditions,
+		},
+	}
+}
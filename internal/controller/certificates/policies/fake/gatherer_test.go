@@ -0,0 +1,193 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+// gathererFunc builds a gatherer from the given objects.
+type gathererFunc func(t *testing.T, c *fakeclock.FakeClock, reqs []*cmapi.CertificateRequest, secrets []*corev1.Secret) func(context.Context, *cmapi.Certificate) (policies.Input, error)
+
+func fakeGatherer(_ *testing.T, c *fakeclock.FakeClock, reqs []*cmapi.CertificateRequest, secrets []*corev1.Secret) func(context.Context, *cmapi.Certificate) (policies.Input, error) {
+	return NewGatherer(c).WithCertificateRequests(reqs...).WithSecrets(secrets...).DataForCertificate
+}
+
+func informerGatherer(t *testing.T, c *fakeclock.FakeClock, reqs []*cmapi.CertificateRequest, secrets []*corev1.Secret) func(context.Context, *cmapi.Certificate) (policies.Input, error) {
+	builder := &testpkg.Builder{T: t, Clock: c}
+	for _, req := range reqs {
+		builder.CertManagerObjects = append(builder.CertManagerObjects, req)
+	}
+	for _, secret := range secrets {
+		builder.KubeObjects = append(builder.KubeObjects, secret)
+	}
+	builder.Init()
+
+	// Listers only return objects once their informer has an event handler.
+	noop := cache.ResourceEventHandlerFuncs{AddFunc: func(obj interface{}) {}}
+	builder.SharedInformerFactory.Certmanager().V1().CertificateRequests().Informer().AddEventHandler(noop)
+	builder.KubeSharedInformerFactory.Secrets().Informer().AddEventHandler(noop)
+	builder.Start()
+	t.Cleanup(builder.Stop)
+
+	return (&policies.Gatherer{
+		CertificateRequestLister: builder.SharedInformerFactory.Certmanager().V1().CertificateRequests().Lister(),
+		SecretLister:             builder.KubeSharedInformerFactory.Secrets().Lister(),
+		Clock:                    c,
+	}).DataForCertificate
+}
+
+// TestGathererConformance checks that the fake Gatherer behaves like the
+// informer backed policies.Gatherer.
+func TestGathererConformance(t *testing.T) {
+	crt := gen.Certificate("crt",
+		gen.SetCertificateNamespace("ns"),
+		gen.SetCertificateUID("crt-uid"),
+		gen.SetCertificateSecretName("crt-tls"),
+	)
+	otherCrt := gen.CertificateFrom(crt, gen.SetCertificateName("other"), gen.SetCertificateUID("other-uid"))
+	secret := gen.Secret("crt-tls", gen.SetSecretNamespace("ns"))
+	ready := cmapi.CertificateRequestCondition{Type: cmapi.CertificateRequestConditionReady, Status: cmmeta.ConditionTrue}
+	pending := cmapi.CertificateRequestCondition{Type: cmapi.CertificateRequestConditionReady, Status: cmmeta.ConditionFalse}
+
+	duplicate := func(req *cmapi.CertificateRequest) *cmapi.CertificateRequest {
+		req = req.DeepCopy()
+		req.Name += "-duplicate"
+		return req
+	}
+	inNamespace := func(req *cmapi.CertificateRequest, namespace string) *cmapi.CertificateRequest {
+		req = req.DeepCopy()
+		req.Namespace = namespace
+		return req
+	}
+
+	tests := map[string]struct {
+		crt     *cmapi.Certificate
+		reqs    []*cmapi.CertificateRequest
+		secrets []*corev1.Secret
+
+		wantSecret *corev1.Secret
+		wantCurCR  *cmapi.CertificateRequest
+		wantNextCR *cmapi.CertificateRequest
+		wantErr    string
+	}{
+		"nothing is returned if no objects exist": {
+			crt: crt,
+		},
+		"the Secret is returned": {
+			crt:        crt,
+			secrets:    []*corev1.Secret{secret},
+			wantSecret: secret,
+		},
+		"a Secret in another namespace is not returned": {
+			crt:     crt,
+			secrets: []*corev1.Secret{gen.SecretFrom(secret, gen.SetSecretNamespace("other-ns"))},
+		},
+		"revision 1 is the next revision of a Certificate without a revision": {
+			crt:        crt,
+			reqs:       []*cmapi.CertificateRequest{CertificateRequestForRevision(crt, 1, pending)},
+			wantNextCR: CertificateRequestForRevision(crt, 1, pending),
+		},
+		"the current and next revisions are returned": {
+			crt: CertificateAtRevision(crt, 2),
+			reqs: []*cmapi.CertificateRequest{
+				CertificateRequestForRevision(crt, 1, ready),
+				CertificateRequestForRevision(crt, 2, ready),
+				CertificateRequestForRevision(crt, 3, pending),
+			},
+			wantCurCR:  CertificateRequestForRevision(crt, 2, ready),
+			wantNextCR: CertificateRequestForRevision(crt, 3, pending),
+		},
+		"requests of other Certificates or in other namespaces are ignored": {
+			crt: CertificateAtRevision(crt, 1),
+			reqs: []*cmapi.CertificateRequest{
+				CertificateRequestForRevision(otherCrt, 1, ready),
+				inNamespace(CertificateRequestForRevision(crt, 2, pending), "other-ns"),
+			},
+		},
+		"duplicate requests for the current revision are an error": {
+			crt: CertificateAtRevision(crt, 1),
+			reqs: []*cmapi.CertificateRequest{
+				CertificateRequestForRevision(crt, 1, ready),
+				duplicate(CertificateRequestForRevision(crt, 1, ready)),
+			},
+			wantErr: "multiple CertificateRequests were found for the 'current' revision 1, issuance is skipped until there are no more duplicates",
+		},
+		"duplicate requests for the next revision are an error": {
+			crt: CertificateAtRevision(crt, 1),
+			reqs: []*cmapi.CertificateRequest{
+				CertificateRequestForRevision(crt, 2, pending),
+				duplicate(CertificateRequestForRevision(crt, 2, pending)),
+			},
+			wantErr: "multiple CertificateRequests were found for the 'next' revision 2, issuance is skipped until there are no more duplicates",
+		},
+	}
+
+	gatherers := map[string]gathererFunc{
+		"fake":     fakeGatherer,
+		"informer": informerGatherer,
+	}
+	for gathererName, newGatherer := range gatherers {
+		for name, test := range tests {
+			t.Run(gathererName+"/"+name, func(t *testing.T) {
+				clock := fakeclock.NewFakeClock(time.Now())
+				dataForCertificate := newGatherer(t, clock, test.reqs, test.secrets)
+
+				got, err := dataForCertificate(context.Background(), test.crt)
+				if test.wantErr != "" {
+					require.EqualError(t, err, test.wantErr)
+					return
+				}
+				require.NoError(t, err)
+				assert.Equal(t, test.crt, got.Certificate)
+				assert.Equal(t, test.wantSecret, got.Secret)
+				assert.Equal(t, test.wantCurCR, got.CurrentRevisionRequest)
+				assert.Equal(t, test.wantNextCR, got.NextRevisionRequest)
+				assert.Equal(t, clock.Now(), got.EvaluationTime)
+			})
+		}
+	}
+}
+
+func TestCertificateAtRevision(t *testing.T) {
+	crt := gen.Certificate("crt", gen.SetCertificateRevision(3))
+	issuing := cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue}
+
+	got := CertificateAtRevision(crt, 0, issuing)
+	assert.Nil(t, got.Status.Revision)
+	assert.Equal(t, []cmapi.CertificateCondition{issuing}, got.Status.Conditions)
+	assert.Equal(t, 3, *crt.Status.Revision, "the given Certificate must not be modified")
+
+	got = CertificateAtRevision(crt, 5)
+	require.NotNil(t, got.Status.Revision)
+	assert.Equal(t, 5, *got.Status.Revision)
+	assert.Empty(t, got.Status.Conditions)
+}
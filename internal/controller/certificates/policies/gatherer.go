@@ -211,13 +211,17 @@ import (
 	"context"
 	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/clock"
 
+	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
 	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/util/predicate"
 )
@@ -227,6 +231,21 @@ import (
 type Gatherer struct {
 	CertificateRequestLister cmlisters.CertificateRequestLister
 	SecretLister             internalinformers.SecretLister
+
+	// Clock is used to capture the time at which the gathered data is
+	// evaluated.
+	Clock clock.Clock
+
+	// OptionalInputs are the optional inputs to gather along with the data
+	// above. They are fetched concurrently, as they may require Secrets to be
+	// read from the API server rather than from the informer cache.
+	OptionalInputs []OptionalInput
+
+	// IssuerHelper is used to read the issuer of a Certificate in order to
+	// gather the IssuerCAInput, and ClusterResourceNamespace is the namespace
+	// of the CA Secrets of ClusterIssuers.
+	IssuerHelper             issuer.Helper
+	ClusterResourceNamespace string
 }
 
 // DataForCertificate returns the secret as well as the "current" and "next"
@@ -239,14 +258,36 @@ type Gatherer struct {
 // "current" or the "next" revision. DataForCertificate does not return any
 // apierrors.NewNotFound; instead, if either of the objects (current CR, next CR
 // or secret) is not found, then the returned value of this object is left nil.
+// The time at which the data was gathered is returned as the EvaluationTime.
+//
+// The configured OptionalInputs are fetched concurrently with the rest of the
+// data. Failing to fetch one of them does not return an error; the input is
+// recorded in the UnavailableInputs of the returned Input instead.
 func (g *Gatherer) DataForCertificate(ctx context.Context, crt *cmapi.Certificate) (Input, error) {
 	log := logf.FromContext(ctx)
+	now := g.Clock.Now()
+
+	// Abandon the optional fetches which have not started yet if an error is
+	// returned before they are waited for.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	optionalInputs := g.startOptionalFetches(ctx, g.optionalFetches(crt))
+
 	// Attempt to fetch the Secret being managed but tolerate NotFound errors.
 	secret, err := g.SecretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
 	if err != nil && !apierrors.IsNotFound(err) {
 		return Input{}, err
 	}
-
+	secretNotFound := apierrors.IsNotFound(err)
+	if secretNotFound && internalcertificates.UsesImmutableSecret(crt.Spec) {
+		secret, err = g.rotationSecret(crt)
+		if err != nil {
+			return Input{}, err
+		}
+		if secret != nil {
+			log.V(logf.DebugLevel).Info("Secret is being re-created, using the data of its rotation Secret", "rotation_secret", secret.Name)
+		}
+	}
 	// Attempt to fetch the CertificateRequest for the current status.revision.
 	//
 	// We can skip looking for the current CR when the status.revision is nil
@@ -262,7 +303,7 @@ func (g *Gatherer) DataForCertificate(ctx context.Context, crt *cmapi.Certificat
 
 		reqs, err := certificates.ListCertificateRequestsMatchingPredicates(g.CertificateRequestLister.CertificateRequests(crt.Namespace),
 			labels.Everything(),
-			predicate.ResourceOwnedBy(crt),
+			internalcertificates.CertificateRequestOwnedBy(crt),
 			predicate.CertificateRequestRevision(*crt.Status.Revision),
 		)
 		if err != nil {
@@ -273,8 +314,23 @@ func (g *Gatherer) DataForCertificate(ctx context.Context, crt *cmapi.Certificat
 			return Input{}, fmt.Errorf("multiple CertificateRequests were found for the 'current' revision %v, issuance is skipped until there are no more duplicates", *crt.Status.Revision)
 		case len(reqs) == 1:
 			curCR = reqs[0]
+			logf.WithRelatedCertificateRequest(log, curCR).V(logf.DebugLevel).Info("Found CertificateRequest owned by this Certificate for the current revision")
 		case len(reqs) == 0:
-			log.V(logf.DebugLevel).Info("Found no CertificateRequest resources owned by this Certificate for the current revision", "revision", *crt.Status.Revision)
+			log.V(logf.DebugLevel).Info("Found no CertificateRequest resources owned by this Certificate for the current revision", logf.CertificateRequestRevisionKey, *crt.Status.Revision)
+		}
+	}
+
+	// If spec.secretName has been changed, the data of the previous Secret is
+	// evaluated until it has been moved to the new Secret, so that a
+	// certificate which is still valid is not re-issued. Only a Secret holding
+	// the current certificate is considered.
+	if secretNotFound && secret == nil {
+		secret, err = internalcertificates.PreviousSecret(g.SecretLister.Secrets(crt.Namespace), crt, curCR)
+		if err != nil {
+			return Input{}, err
+		}
+		if secret != nil {
+			log.V(logf.DebugLevel).Info("Secret does not exist, using the data of the Secret previously named by spec.secretName", "previous_secret", secret.Name)
 		}
 	}
 
@@ -289,7 +345,7 @@ func (g *Gatherer) DataForCertificate(ctx context.Context, crt *cmapi.Certificat
 	}
 	reqs, err := certificates.ListCertificateRequestsMatchingPredicates(g.CertificateRequestLister.CertificateRequests(crt.Namespace),
 		labels.Everything(),
-		predicate.ResourceOwnedBy(crt),
+		internalcertificates.CertificateRequestOwnedBy(crt),
 		predicate.CertificateRequestRevision(nextCRRevision),
 	)
 	if err != nil {
@@ -308,14 +364,40 @@ func (g *Gatherer) DataForCertificate(ctx context.Context, crt *cmapi.Certificat
 		return Input{}, fmt.Errorf("multiple CertificateRequests were found for the 'next' revision %v, issuance is skipped until there are no more duplicates", nextCRRevision)
 	case len(reqs) == 1:
 		nextCR = reqs[0]
+		logf.WithRelatedCertificateRequest(log, nextCR).V(logf.DebugLevel).Info("Found CertificateRequest owned by this Certificate for the next revision")
 	case len(reqs) == 0:
-		log.V(logf.DebugLevel).Info("Found no CertificateRequest resources owned by this Certificate for the next revision", "revision", nextCRRevision)
+		log.V(logf.DebugLevel).Info("Found no CertificateRequest resources owned by this Certificate for the next revision", logf.CertificateRequestRevisionKey, nextCRRevision)
 	}
 
-	return Input{
+	input := Input{
 		Certificate:            crt,
 		Secret:                 secret,
 		CurrentRevisionRequest: curCR,
 		NextRevisionRequest:    nextCR,
-	}, nil
+		EvaluationTime:         now,
+	}
+	optionalInputs.wait(ctx, &input)
+	for optionalInput, err := range input.UnavailableInputs {
+		log.V(logf.DebugLevel).Info("Optional input is unavailable, policies depending on it are inconclusive", "input", optionalInput, "error", err.Error())
+	}
+
+	return input, nil
+}
+
+// rotationSecret returns the temporary Secret which holds the data of an
+// immutable Certificate Secret whilst that Secret is deleted and re-created,
+// or nil if there is none. The Secret is briefly missing during that swap,
+// which must not be mistaken for a Secret that needs to be issued.
+func (g *Gatherer) rotationSecret(crt *cmapi.Certificate) (*corev1.Secret, error) {
+	secret, err := g.SecretLister.Secrets(crt.Namespace).Get(internalcertificates.RotationSecretName(crt.Spec.SecretName))
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if secret.Annotations[cmapi.CertificateNameKey] != crt.Name {
+		return nil, nil
+	}
+	return secret, nil
 }
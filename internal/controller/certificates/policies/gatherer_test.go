@@ -18,7 +18,10 @@ package policies
 
 import (
 	"context"
+	"errors"
 	"flag"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -26,32 +29,95 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/cache"
+	featuregatetesting "k8s.io/component-base/featuregate/testing"
 	"k8s.io/klog/v2"
 	fakeclock "k8s.io/utils/clock/testing"
 
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
 	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
 )
 
 func TestDataForCertificate(t *testing.T) {
+	rotationSecret := func(crtName string) *corev1.Secret {
+		return gen.Secret("secret-1-rotation", gen.SetSecretNamespace("ns-1"),
+			gen.SetSecretAnnotations(map[string]string{cmapi.CertificateNameKey: crtName}),
+			func(secret *corev1.Secret) {
+				secret.Labels = map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"}
+			},
+		)
+	}
+	// previousSecret was named by spec.secretName of cert-1 before it was
+	// changed to secret-1.
+	previousCertPEM := testcrypto.MustCreateCert(t, testcrypto.MustCreatePEMPrivateKey(t),
+		gen.Certificate("cert-1", gen.SetCertificateDNSNames("example.com")))
+	previousCert, err := pki.DecodeX509CertificateBytes(previousCertPEM)
+	require.NoError(t, err)
+	previousSecret := gen.Secret("previous-secret-1", gen.SetSecretNamespace("ns-1"),
+		gen.SetSecretAnnotations(map[string]string{cmapi.CertificateNameKey: "cert-1"}),
+		gen.SetSecretData(map[string][]byte{corev1.TLSCertKey: previousCertPEM}),
+		func(secret *corev1.Secret) {
+			secret.Labels = map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"}
+		},
+	)
+	// issuedFromPreviousSecret sets the fingerprint of the certificate held
+	// by previousSecret on the status, as it was last issued into it.
+	issuedFromPreviousSecret := func(crt *cmapi.Certificate) {
+		crt.Status.SHA256Fingerprint = pki.FingerprintSHA256(previousCert)
+	}
+	immutableSecretTemplate := func(crt *cmapi.Certificate) {
+		crt.Spec.SecretTemplate = &cmapi.CertificateSecretTemplate{Immutable: true}
+	}
 	cr := func(crName, ownerCertUID string, annot map[string]string) *cmapi.CertificateRequest {
 		return gen.CertificateRequest(crName, gen.SetCertificateRequestNamespace("ns-1"),
 			gen.AddCertificateRequestOwnerReferences(gen.CertificateRef("some-cert-name-that-does-not-matter", ownerCertUID)),
 			gen.AddCertificateRequestAnnotations(annot),
 		)
 	}
+	// restoredCert has been restored from a backup, and so was given a new
+	// UID. The restoredCR, which was restored with it, is still owned by the
+	// UID of the Certificate at the time of the backup.
+	restoredCert := gen.Certificate("cert-1", gen.SetCertificateNamespace("ns-1"),
+		gen.SetCertificateUID("cert-1-restored-uid"),
+		gen.SetCertificateDNSNames("example.com"),
+		gen.SetCertificateRevision(1),
+	)
+	restoredCSR := testcrypto.MustGenerateCSRImpl(t, testcrypto.MustCreatePEMPrivateKey(t), restoredCert)
+	restoredCR := func(mods ...gen.CertificateRequestModifier) *cmapi.CertificateRequest {
+		return gen.CertificateRequest("cr-1-rev1", append([]gen.CertificateRequestModifier{
+			gen.SetCertificateRequestNamespace("ns-1"),
+			gen.SetCertificateRequestCSR(restoredCSR),
+			gen.AddCertificateRequestOwnerReferences(gen.CertificateRef("cert-1", "cert-1-uid")),
+			gen.AddCertificateRequestAnnotations(map[string]string{
+				cmapi.CertificateNameKey:                      "cert-1",
+				cmapi.CertificateRequestRevisionAnnotationKey: "1",
+			}),
+		}, mods...)...)
+	}
 
 	tests := map[string]struct {
-		builder    *testpkg.Builder
-		givenCert  *cmapi.Certificate
-		wantCurCR  *cmapi.CertificateRequest
-		wantNextCR *cmapi.CertificateRequest
-		wantSecret *corev1.Secret
-		wantErr    string
+		builder   *testpkg.Builder
+		givenCert *cmapi.Certificate
+		// adoptRestored enables the AdoptRestoredCertificateRequests feature
+		// gate.
+		adoptRestored bool
+		wantCurCR     *cmapi.CertificateRequest
+		wantNextCR    *cmapi.CertificateRequest
+		wantSecret    *corev1.Secret
+		wantErr       string
 	}{
 		"when no secret is found, the returned secret is nil": {
 			givenCert: gen.Certificate("cert-1", gen.SetCertificateNamespace("default-unit-test-ns"),
@@ -61,6 +127,61 @@ func TestDataForCertificate(t *testing.T) {
 			builder:    &testpkg.Builder{},
 			wantSecret: nil,
 		},
+		"when an immutable secret is being re-created, the returned secret is its rotation secret": {
+			givenCert: gen.Certificate("cert-1", gen.SetCertificateNamespace("ns-1"),
+				gen.SetCertificateSecretName("secret-1"),
+				gen.SetCertificateUID("uid-1"),
+				immutableSecretTemplate,
+			),
+			builder:    &testpkg.Builder{KubeObjects: []runtime.Object{rotationSecret("cert-1")}},
+			wantSecret: rotationSecret("cert-1"),
+		},
+		"when the rotation secret belongs to another certificate, the returned secret is nil": {
+			givenCert: gen.Certificate("cert-1", gen.SetCertificateNamespace("ns-1"),
+				gen.SetCertificateSecretName("secret-1"),
+				gen.SetCertificateUID("uid-1"),
+				immutableSecretTemplate,
+			),
+			builder:    &testpkg.Builder{KubeObjects: []runtime.Object{rotationSecret("cert-2")}},
+			wantSecret: nil,
+		},
+		"when spec.secretName was changed, the returned secret is the previous secret": {
+			givenCert: gen.Certificate("cert-1", gen.SetCertificateNamespace("ns-1"),
+				gen.SetCertificateSecretName("secret-1"),
+				gen.SetCertificateUID("uid-1"),
+				issuedFromPreviousSecret,
+			),
+			builder:    &testpkg.Builder{KubeObjects: []runtime.Object{previousSecret}},
+			wantSecret: previousSecret,
+		},
+		"when the previous secret does not hold the current certificate, it is ignored": {
+			givenCert: gen.Certificate("cert-1", gen.SetCertificateNamespace("ns-1"),
+				gen.SetCertificateSecretName("secret-1"),
+				gen.SetCertificateUID("uid-1"),
+			),
+			builder:    &testpkg.Builder{KubeObjects: []runtime.Object{previousSecret}},
+			wantSecret: nil,
+		},
+		"when spec.secretName was changed and the new secret exists, the previous secret is ignored": {
+			givenCert: gen.Certificate("cert-1", gen.SetCertificateNamespace("ns-1"),
+				gen.SetCertificateSecretName("secret-1"),
+				gen.SetCertificateUID("uid-1"),
+				issuedFromPreviousSecret,
+			),
+			builder: &testpkg.Builder{KubeObjects: []runtime.Object{
+				previousSecret,
+				gen.Secret("secret-1", gen.SetSecretNamespace("ns-1")),
+			}},
+			wantSecret: gen.Secret("secret-1", gen.SetSecretNamespace("ns-1")),
+		},
+		"when the secret is not immutable, the rotation secret is ignored": {
+			givenCert: gen.Certificate("cert-1", gen.SetCertificateNamespace("ns-1"),
+				gen.SetCertificateSecretName("secret-1"),
+				gen.SetCertificateUID("uid-1"),
+			),
+			builder:    &testpkg.Builder{KubeObjects: []runtime.Object{rotationSecret("cert-1")}},
+			wantSecret: nil,
+		},
 		"when neither current nor next CRs exist, the returned cur and next CRs should be nil": {
 			givenCert: gen.Certificate("cert-1", gen.SetCertificateNamespace("default-unit-test-ns"),
 				gen.SetCertificateSecretName("secret-1"),
@@ -141,12 +262,32 @@ func TestDataForCertificate(t *testing.T) {
 			}},
 			wantErr: `multiple CertificateRequests were found for the 'next' revision 2, issuance is skipped until there are no more duplicates`,
 		},
+		"when a CR is owned by a stale UID of the cert, it is ignored by default": {
+			givenCert: restoredCert,
+			builder:   &testpkg.Builder{CertManagerObjects: []runtime.Object{restoredCR()}},
+			wantCurCR: nil,
+		},
+		"when a CR is owned by a stale UID of the cert and AdoptRestoredCertificateRequests is enabled, it is returned": {
+			givenCert:     restoredCert,
+			adoptRestored: true,
+			builder:       &testpkg.Builder{CertManagerObjects: []runtime.Object{restoredCR()}},
+			wantCurCR:     restoredCR(),
+		},
+		"when a CR owned by a stale UID of the cert does not match its spec, it is ignored even if AdoptRestoredCertificateRequests is enabled": {
+			givenCert:     restoredCert,
+			adoptRestored: true,
+			builder: &testpkg.Builder{CertManagerObjects: []runtime.Object{
+				restoredCR(gen.SetCertificateRequestIsCA(true)),
+			}},
+			wantCurCR: nil,
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
 			fakeClockStart, _ := time.Parse(time.RFC3339, "2021-01-02T15:04:05Z07:00")
 			log := logtesting.NewTestLogger(t)
 			turnOnKlogIfVerboseTest()
+			defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, feature.AdoptRestoredCertificateRequests, test.adoptRestored)()
 
 			test.builder.T = t
 			test.builder.Clock = fakeclock.NewFakeClock(fakeClockStart)
@@ -206,9 +347,11 @@ func TestDataForCertificate(t *testing.T) {
 			// actually made or not prevents us from knowing whether the
 			// input argument (i.e., the namespace) is checked or not.
 
+			fixedClock := fakeclock.NewFakeClock(time.Now())
 			g := &Gatherer{
 				CertificateRequestLister: test.builder.SharedInformerFactory.Certmanager().V1().CertificateRequests().Lister(),
 				SecretLister:             test.builder.KubeSharedInformerFactory.Secrets().Lister(),
+				Clock:                    fixedClock,
 			}
 
 			ctx := logf.NewContext(context.Background(), logf.WithResource(log, test.givenCert))
@@ -223,11 +366,184 @@ func TestDataForCertificate(t *testing.T) {
 				assert.Equal(t, test.wantCurCR, got.CurrentRevisionRequest)
 				assert.Equal(t, test.wantNextCR, got.NextRevisionRequest)
 				assert.Equal(t, test.wantSecret, got.Secret)
+				assert.Equal(t, fixedClock.Now(), got.EvaluationTime, "evaluation time should be captured from the clock")
 			}
 		})
 	}
 }
 
+func TestDataForCertificate_OptionalInputs(t *testing.T) {
+	crt := gen.Certificate("cert-1", gen.SetCertificateNamespace("ns-1"),
+		gen.SetCertificateSecretName("secret-1"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca-issuer"}),
+		func(crt *cmapi.Certificate) {
+			crt.Status.IssuerCAFingerprints = []string{"fingerprint"}
+		},
+	)
+	caSecret := gen.Secret("ca-secret", gen.SetSecretNamespace("ns-1"))
+
+	issuerIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	require.NoError(t, issuerIndexer.Add(gen.Issuer("ca-issuer", gen.SetIssuerNamespace("ns-1"), gen.SetIssuerCASecretName("ca-secret"))))
+	issuerHelper := issuer.NewHelper(cmlisters.NewIssuerLister(issuerIndexer), nil)
+
+	// gatherer returns a Gatherer whose Secret lister gets the Secrets with
+	// the given function. The Certificate's own Secret is got with
+	// getSecret, and does not exist if it is nil.
+	var getSecret func() error
+	gatherer := func(get func(name string) (*corev1.Secret, error)) *Gatherer {
+		return &Gatherer{
+			CertificateRequestLister: cmlisters.NewCertificateRequestLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})),
+			SecretLister: internalinformers.FakeSecretLister{
+				NamespaceLister: internalinformers.FakeSecretNamespaceLister{
+					FakeGet: func(name string) (*corev1.Secret, error) {
+						if name == crt.Spec.SecretName {
+							if getSecret != nil {
+								if err := getSecret(); err != nil {
+									return nil, err
+								}
+							}
+							return nil, apierrors.NewNotFound(corev1.Resource("secrets"), name)
+						}
+						return get(name)
+					},
+					FakeList: func(labels.Selector) ([]*corev1.Secret, error) { return nil, nil },
+				},
+			},
+			Clock:          fakeclock.NewFakeClock(time.Now()),
+			OptionalInputs: []OptionalInput{IssuerCAInput},
+			IssuerHelper:   issuerHelper,
+		}
+	}
+	getFrom := func(secrets ...*corev1.Secret) func(string) (*corev1.Secret, error) {
+		return func(name string) (*corev1.Secret, error) {
+			for _, secret := range secrets {
+				if secret.Name == name {
+					return secret, nil
+				}
+			}
+			return nil, apierrors.NewNotFound(corev1.Resource("secrets"), name)
+		}
+	}
+
+	t.Run("should attach the optional inputs", func(t *testing.T) {
+		got, err := gatherer(getFrom(caSecret)).DataForCertificate(context.Background(), crt)
+		require.NoError(t, err)
+		assert.Equal(t, caSecret, got.IssuerCASecret)
+		assert.Empty(t, got.UnavailableInputs)
+	})
+
+	t.Run("should not gather optional inputs which are not configured", func(t *testing.T) {
+		g := gatherer(getFrom(caSecret))
+		g.OptionalInputs = nil
+		got, err := g.DataForCertificate(context.Background(), crt)
+		require.NoError(t, err)
+		assert.Nil(t, got.IssuerCASecret)
+		assert.Empty(t, got.UnavailableInputs)
+	})
+
+	t.Run("should not gather the issuer CA if no CA fingerprints are recorded", func(t *testing.T) {
+		crt := crt.DeepCopy()
+		crt.Status.IssuerCAFingerprints = nil
+		got, err := gatherer(getFrom(caSecret)).DataForCertificate(context.Background(), crt)
+		require.NoError(t, err)
+		assert.Nil(t, got.IssuerCASecret)
+		assert.Empty(t, got.UnavailableInputs)
+	})
+
+	t.Run("should not gather the issuer CA of an issuer which is not a CA issuer", func(t *testing.T) {
+		crt := crt.DeepCopy()
+		crt.Spec.IssuerRef.Name = "unknown-issuer"
+		got, err := gatherer(getFrom(caSecret)).DataForCertificate(context.Background(), crt)
+		require.NoError(t, err)
+		assert.Nil(t, got.IssuerCASecret)
+		assert.Empty(t, got.UnavailableInputs)
+	})
+
+	t.Run("should leave missing optional inputs nil without marking them unavailable", func(t *testing.T) {
+		got, err := gatherer(getFrom()).DataForCertificate(context.Background(), crt)
+		require.NoError(t, err)
+		assert.Nil(t, got.IssuerCASecret)
+		assert.Empty(t, got.UnavailableInputs)
+	})
+
+	t.Run("should mark optional inputs which failed to be fetched as unavailable", func(t *testing.T) {
+		fetchErr := errors.New("connection refused")
+		got, err := gatherer(func(name string) (*corev1.Secret, error) {
+			return nil, fetchErr
+		}).DataForCertificate(context.Background(), crt)
+		require.NoError(t, err, "a failed optional input must not fail the evaluation")
+		assert.Nil(t, got.IssuerCASecret)
+		assert.Equal(t, map[OptionalInput]error{IssuerCAInput: fetchErr}, got.UnavailableInputs)
+		assert.Equal(t, fetchErr, got.Unavailable(IssuerCAInput))
+	})
+
+	t.Run("should fetch the optional inputs concurrently with the rest of the data", func(t *testing.T) {
+		// The Certificate's Secret and the issuer's CA Secret are each got
+		// once both are in flight, which only happens if they are fetched
+		// concurrently.
+		var inFlight sync.WaitGroup
+		inFlight.Add(2)
+		allInFlight := make(chan struct{})
+		go func() {
+			inFlight.Wait()
+			close(allInFlight)
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		waitForAll := func() error {
+			inFlight.Done()
+			select {
+			case <-allInFlight:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		getSecret = waitForAll
+		defer func() { getSecret = nil }()
+
+		got, err := gatherer(func(name string) (*corev1.Secret, error) {
+			if err := waitForAll(); err != nil {
+				return nil, err
+			}
+			return getFrom(caSecret)(name)
+		}).DataForCertificate(ctx, crt)
+		require.NoError(t, err)
+		assert.Equal(t, caSecret, got.IssuerCASecret)
+		assert.Empty(t, got.UnavailableInputs)
+	})
+
+	t.Run("should mark the optional inputs which are still being fetched as unavailable when the context is done", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		got, err := gatherer(func(name string) (*corev1.Secret, error) {
+			<-release
+			return getFrom(caSecret)(name)
+		}).DataForCertificate(ctx, crt)
+		require.NoError(t, err)
+		assert.Nil(t, got.IssuerCASecret)
+		assert.Equal(t, map[OptionalInput]error{IssuerCAInput: context.DeadlineExceeded}, got.UnavailableInputs)
+	})
+
+	t.Run("should not start fetching the optional inputs once the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var fetched atomic.Bool
+		got, err := gatherer(func(name string) (*corev1.Secret, error) {
+			fetched.Store(true)
+			return getFrom(caSecret)(name)
+		}).DataForCertificate(ctx, crt)
+		require.NoError(t, err)
+		assert.False(t, fetched.Load())
+		assert.Equal(t, map[OptionalInput]error{IssuerCAInput: context.Canceled}, got.UnavailableInputs)
+	})
+}
+
 // The logs are helpful for debugging client-go-related issues (informer
 // not starting...). This function passes the flag -v=4 to klog when the
 // tests are being run with -v. Otherwise, the default klog level is used.
@@ -0,0 +1,101 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policies
+
+// The Issuing reasons are the reasons of a Certificate's Issuing condition,
+// and categorise why an issuance was triggered. They are part of the API, and
+// may be relied upon by monitoring and alerting: they must not be changed.
+const (
+	// IssuingReasonRenewal is the Issuing reason of a routine renewal of a
+	// certificate which matches the Certificate's spec, because its renewal
+	// time has been reached or it has expired.
+	IssuingReasonRenewal string = "Renewal"
+	// IssuingReasonSpecChanged is the Issuing reason of a re-issuance caused
+	// by the certificate or private key no longer matching the Certificate's
+	// spec.
+	IssuingReasonSpecChanged string = "SpecChanged"
+	// IssuingReasonSecretInvalid is the Issuing reason of an issuance caused
+	// by the Certificate's Secret being missing, incomplete or invalid.
+	IssuingReasonSecretInvalid string = "SecretInvalid"
+	// IssuingReasonManuallyTriggered is the Issuing reason set by API
+	// consumers, such as `cmctl renew`, to trigger an issuance manually.
+	IssuingReasonManuallyTriggered string = "ManuallyTriggered"
+	// IssuingReasonIssuerChanged is the Issuing reason of a re-issuance
+	// caused by the certificate having been issued by another issuer than the
+	// Certificate's issuerRef.
+	IssuingReasonIssuerChanged string = "IssuerChanged"
+	// IssuingReasonCARotated is the Issuing reason set by CA issuers
+	// configured with `reissueOnCARotation` to re-issue the certificates they
+	// signed with their previous signing CA certificate.
+	IssuingReasonCARotated string = "CARotated"
+)
+
+// issuingReasons maps the reasons of the policy violations which trigger an
+// issuance to the Issuing reason of their category.
+var issuingReasons = map[string]string{
+	Renewing:    IssuingReasonRenewal,
+	Expired:     IssuingReasonRenewal,
+	NotYetValid: IssuingReasonRenewal,
+
+	SecretMismatch:            IssuingReasonSpecChanged,
+	RequestChanged:            IssuingReasonSpecChanged,
+	InvalidCertificateRequest: IssuingReasonSpecChanged,
+
+	IncorrectIssuer: IssuingReasonIssuerChanged,
+
+	SecretMissing:            IssuingReasonSecretInvalid,
+	SecretEmpty:              IssuingReasonSecretInvalid,
+	SecretKeyMissing:         IssuingReasonSecretInvalid,
+	SecretCertMissing:        IssuingReasonSecretInvalid,
+	SecretDataInvalid:        IssuingReasonSecretInvalid,
+	DoesNotExist:             IssuingReasonSecretInvalid,
+	MissingData:              IssuingReasonSecretInvalid,
+	InvalidKeyPair:           IssuingReasonSecretInvalid,
+	InvalidCertificate:       IssuingReasonSecretInvalid,
+	InvalidCertificateChain:  IssuingReasonSecretInvalid,
+	IncorrectCertificate:     IssuingReasonSecretInvalid,
+	SecretNotAdopted:         IssuingReasonSecretInvalid,
+	SecretModifiedExternally: IssuingReasonSecretInvalid,
+	IssuedKeyMismatch:        IssuingReasonSecretInvalid,
+	IssuerChainExpiringSoon:  IssuingReasonSecretInvalid,
+	UnexpectedIssuerCA:       IssuingReasonSecretInvalid,
+}
+
+// IssuingReason returns the Issuing reason of the category of the given
+// policy violation reason. Reasons which do not belong to a category, such
+// as those of policies injected in tests, are returned unchanged.
+func IssuingReason(policyReason string) string {
+	if reason, ok := issuingReasons[policyReason]; ok {
+		return reason
+	}
+	return policyReason
+}
+
+// IsTriggerPolicyReason returns true if the given reason of a Certificate's
+// Issuing condition was set by the trigger policies: either the Issuing
+// reason of one of their categories, or the reason of the violated policy
+// itself, as set by earlier versions of cert-manager. Reasons set by API
+// consumers or by other controllers, such as ManuallyTriggered or CARotated,
+// are not.
+func IsTriggerPolicyReason(reason string) bool {
+	switch reason {
+	case IssuingReasonRenewal, IssuingReasonSpecChanged, IssuingReasonSecretInvalid, IssuingReasonIssuerChanged:
+		return true
+	}
+	_, ok := issuingReasons[reason]
+	return ok
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policies
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIssuingReasonsAreStable(t *testing.T) {
+	// The Issuing reasons are part of the API and must never change.
+	assert.Equal(t, "Renewal", IssuingReasonRenewal)
+	assert.Equal(t, "SpecChanged", IssuingReasonSpecChanged)
+	assert.Equal(t, "SecretInvalid", IssuingReasonSecretInvalid)
+	assert.Equal(t, "ManuallyTriggered", IssuingReasonManuallyTriggered)
+	assert.Equal(t, "IssuerChanged", IssuingReasonIssuerChanged)
+	assert.Equal(t, "CARotated", IssuingReasonCARotated)
+}
+
+func TestIssuingReason(t *testing.T) {
+	tests := map[string]struct {
+		policyReasons []string
+		expected      string
+	}{
+		"renewals": {
+			policyReasons: []string{Renewing, Expired, NotYetValid},
+			expected:      IssuingReasonRenewal,
+		},
+		"changes of the spec": {
+			policyReasons: []string{SecretMismatch, RequestChanged, InvalidCertificateRequest},
+			expected:      IssuingReasonSpecChanged,
+		},
+		"changes of the issuer": {
+			policyReasons: []string{IncorrectIssuer},
+			expected:      IssuingReasonIssuerChanged,
+		},
+		"invalid Secrets": {
+			policyReasons: []string{
+				SecretMissing, SecretEmpty, SecretKeyMissing, SecretCertMissing, SecretDataInvalid,
+				DoesNotExist, MissingData, InvalidKeyPair, InvalidCertificate, InvalidCertificateChain,
+				IncorrectCertificate, SecretNotAdopted, SecretModifiedExternally, IssuedKeyMismatch,
+				IssuerChainExpiringSoon, UnexpectedIssuerCA,
+			},
+			expected: IssuingReasonSecretInvalid,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			for _, policyReason := range test.policyReasons {
+				assert.Equal(t, test.expected, IssuingReason(policyReason), "policy reason %q", policyReason)
+			}
+		})
+	}
+
+	t.Run("reasons without a category are returned unchanged", func(t *testing.T) {
+		assert.Equal(t, "ForceTriggered", IssuingReason("ForceTriggered"))
+	})
+}
+
+func TestIsTriggerPolicyReason(t *testing.T) {
+	for _, reason := range []string{IssuingReasonRenewal, IssuingReasonSpecChanged, IssuingReasonSecretInvalid, IssuingReasonIssuerChanged, Renewing, SecretMismatch} {
+		assert.True(t, IsTriggerPolicyReason(reason), "reason %q", reason)
+	}
+	for _, reason := range []string{IssuingReasonManuallyTriggered, IssuingReasonCARotated, "", "SetByAnotherController"} {
+		assert.False(t, IsTriggerPolicyReason(reason), "reason %q", reason)
+	}
+}
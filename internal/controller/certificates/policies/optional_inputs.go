@@ -0,0 +1,184 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
+	"github.com/cert-manager/cert-manager/pkg/issuer/ca"
+)
+
+// OptionalInput identifies an input which is only gathered for the policies
+// that need it. Fetching an optional input may fail without failing the
+// evaluation: the input is then recorded as unavailable, and the policies
+// which depend on it must treat their check as inconclusive.
+type OptionalInput string
+
+const (
+	// IssuerCAInput is the Secret holding the CA of the Certificate's issuer,
+	// if it is a CA issuer. It is used by SecretIssuerCAUnexpected.
+	IssuerCAInput OptionalInput = "IssuerCA"
+)
+
+// optionalFetch fetches a single optional input. A nil Secret without an
+// error means that the input does not exist.
+type optionalFetch struct {
+	input OptionalInput
+	fetch func(ctx context.Context) (*corev1.Secret, error)
+}
+
+type optionalResult struct {
+	input  OptionalInput
+	secret *corev1.Secret
+	err    error
+}
+
+// pendingInputs are the optional inputs which are being fetched in the
+// background by startOptionalFetches.
+type pendingInputs struct {
+	inputs  []OptionalInput
+	results chan optionalResult
+}
+
+// optionalFetches returns the fetches of the optional inputs that the
+// Gatherer has been configured to gather, and which apply to the given
+// Certificate.
+func (g *Gatherer) optionalFetches(crt *cmapi.Certificate) []optionalFetch {
+	var fetches []optionalFetch
+	for _, input := range g.OptionalInputs {
+		switch input {
+		case IssuerCAInput:
+			// The issuer's CA is only used to verify the CA fingerprints
+			// recorded upon issuance.
+			if len(crt.Status.IssuerCAFingerprints) == 0 {
+				continue
+			}
+			fetches = append(fetches, optionalFetch{input: input, fetch: func(ctx context.Context) (*corev1.Secret, error) {
+				return g.issuerCASecret(ctx, crt)
+			}})
+		}
+	}
+	return fetches
+}
+
+// startOptionalFetches starts fetching the given optional inputs in the
+// background, concurrently with the rest of the data. Fetches which have not
+// started when the context is cancelled are abandoned.
+func (g *Gatherer) startOptionalFetches(ctx context.Context, fetches []optionalFetch) *pendingInputs {
+	// The results channel is buffered so that fetches never block on
+	// sending their result, even once nobody is waiting for it anymore.
+	pending := &pendingInputs{results: make(chan optionalResult, len(fetches))}
+	for _, f := range fetches {
+		pending.inputs = append(pending.inputs, f.input)
+		go func() {
+			if err := ctx.Err(); err != nil {
+				pending.results <- optionalResult{input: f.input, err: err}
+				return
+			}
+			secret, err := f.fetch(ctx)
+			pending.results <- optionalResult{input: f.input, secret: secret, err: err}
+		}()
+	}
+	return pending
+}
+
+// wait waits for the pending optional inputs and attaches them to the given
+// input. Inputs which failed to be fetched, or which have not been fetched
+// by the time the context is cancelled, are recorded as unavailable.
+func (p *pendingInputs) wait(ctx context.Context, input *Input) {
+	received := make(map[OptionalInput]bool, len(p.inputs))
+	for range p.inputs {
+		select {
+		case r := <-p.results:
+			received[r.input] = true
+			if r.err != nil {
+				input.setUnavailable(r.input, r.err)
+				continue
+			}
+			input.setOptional(r.input, r.secret)
+		case <-ctx.Done():
+			for _, in := range p.inputs {
+				if !received[in] {
+					input.setUnavailable(in, ctx.Err())
+				}
+			}
+			return
+		}
+	}
+}
+
+// issuerCASecret returns the Secret holding the CA of the Certificate's
+// issuer. It is nil if the issuer does not exist, is not a CA issuer, or if
+// its Secret does not exist.
+func (g *Gatherer) issuerCASecret(ctx context.Context, crt *cmapi.Certificate) (*corev1.Secret, error) {
+	if g.IssuerHelper == nil {
+		return nil, errors.New("the gatherer cannot read issuers")
+	}
+
+	kind, group := apiutil.NormalizeIssuerKindAndGroup(crt.Spec.IssuerRef.Kind, crt.Spec.IssuerRef.Group)
+	if group != cmapi.SchemeGroupVersion.Group {
+		return nil, nil
+	}
+	ref := crt.Spec.IssuerRef
+	ref.Kind = kind
+	genericIssuer, err := g.IssuerHelper.GetGenericIssuer(ref, crt.Namespace)
+	if apierrors.IsNotFound(err) || errors.Is(err, issuer.ErrClusterIssuersDisabled) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the issuer of the Certificate: %w", err)
+	}
+	if genericIssuer.GetSpec().CA == nil {
+		return nil, nil
+	}
+
+	resourceNamespace := genericIssuer.GetObjectMeta().Namespace
+	if resourceNamespace == "" {
+		resourceNamespace = g.ClusterResourceNamespace
+	}
+	// The Secret may have to be read from the API server if it is not
+	// cached, so give up if the evaluation has been abandoned meanwhile.
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	namespace, name, err := ca.SigningSecret(g.SecretLister, genericIssuer, resourceNamespace)
+	if apierrors.IsNotFound(err) || ca.IsNotGranted(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		return nil, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	secret, err := g.SecretLister.Secrets(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	return secret, err
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policies
+
+import (
+	"crypto"
+	"crypto/x509"
+
+	corev1 "k8s.io/api/core/v1"
+
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// parsedInput holds the certificates, private key and certificate request
+// decoded from an Input. Several policies of a chain need the same decoded
+// data, and decoding a long certificate chain is costly, so each is decoded
+// at most once per evaluation of a chain. Errors are kept as well, so that
+// data which cannot be decoded is not decoded again by every policy.
+type parsedInput struct {
+	certificate memo[*x509.Certificate]
+	chain       memo[[]*x509.Certificate]
+	caCerts     memo[[]*x509.Certificate]
+	privateKey  memo[crypto.Signer]
+	request     memo[*x509.CertificateRequest]
+}
+
+// memo holds the result of decoding a single value.
+type memo[T any] struct {
+	done  bool
+	value T
+	err   error
+}
+
+// get returns the result of decode, which is only called the first time.
+func (m *memo[T]) get(decode func() (T, error)) (T, error) {
+	if !m.done {
+		m.value, m.err = decode()
+		m.done = true
+	}
+	return m.value, m.err
+}
+
+// withParsed returns a copy of the input with an empty parsedInput, which
+// is shared by every policy evaluated with the copy. Policies which are
+// called directly, outside of a chain, decode the data they need on each
+// call.
+func (i Input) withParsed() Input {
+	i.parsed = &parsedInput{}
+	return i
+}
+
+// secretCertificate returns the first certificate in the Secret's tls.crt.
+func (i Input) secretCertificate() (*x509.Certificate, error) {
+	decode := func() (*x509.Certificate, error) {
+		// A chain which could be decoded starts with the same certificate.
+		if i.parsed != nil && i.parsed.chain.done && i.parsed.chain.err == nil {
+			return i.parsed.chain.value[0], nil
+		}
+		return pki.DecodeX509CertificateBytes(i.Secret.Data[corev1.TLSCertKey])
+	}
+	if i.parsed == nil {
+		return decode()
+	}
+	return i.parsed.certificate.get(decode)
+}
+
+// secretCertificateChain returns every certificate in the Secret's tls.crt,
+// decoded with decodeCertificateBundle.
+func (i Input) secretCertificateChain() ([]*x509.Certificate, error) {
+	decode := func() ([]*x509.Certificate, error) {
+		return decodeCertificateBundle(i.Secret.Data[corev1.TLSCertKey])
+	}
+	if i.parsed == nil {
+		return decode()
+	}
+	return i.parsed.chain.get(decode)
+}
+
+// secretCACertificates returns the certificates in the Secret's ca.crt.
+func (i Input) secretCACertificates() ([]*x509.Certificate, error) {
+	decode := func() ([]*x509.Certificate, error) {
+		return pki.DecodeX509CertificateSetBytes(i.Secret.Data[cmmeta.TLSCAKey])
+	}
+	if i.parsed == nil {
+		return decode()
+	}
+	return i.parsed.caCerts.get(decode)
+}
+
+// secretPrivateKey returns the private key in the Secret's tls.key.
+func (i Input) secretPrivateKey() (crypto.Signer, error) {
+	decode := func() (crypto.Signer, error) {
+		return pki.DecodePrivateKeyBytes(i.Secret.Data[corev1.TLSPrivateKeyKey])
+	}
+	if i.parsed == nil {
+		return decode()
+	}
+	return i.parsed.privateKey.get(decode)
+}
+
+// currentRequest returns the x509 certificate request of the current
+// CertificateRequest, which must not be nil.
+func (i Input) currentRequest() (*x509.CertificateRequest, error) {
+	decode := func() (*x509.CertificateRequest, error) {
+		return pki.DecodeX509CertificateRequestBytes(i.CurrentRevisionRequest.Spec.Request)
+	}
+	if i.parsed == nil {
+		return decode()
+	}
+	return i.parsed.request.get(decode)
+}
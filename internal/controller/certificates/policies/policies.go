@@ -18,9 +18,12 @@ limitations under the License.
 package policies
 
 import (
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/clock"
 
+	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 )
 
@@ -40,6 +43,62 @@ type Input struct {
 	// Take a look at the gatherer package's documentation to see more about why
 	// we care about the "next" certificate request.
 	NextRevisionRequest *cmapi.CertificateRequest
+
+	// SecretCA is the data that the Certificate's SecretCAPolicy requires to
+	// be stored in the Secret's ca.crt key, nil meaning the key should not
+	// exist. It is resolved by the caller as it may be taken from another
+	// resource, and is only used by post issuance policy checks.
+	SecretCA []byte
+
+	// EvaluationTime is the time at which the input is evaluated. It is
+	// captured once when the input is gathered so that every time based
+	// policy in a chain makes its decision for the same instant. If it is not
+	// set, time based policies fall back to the current time of their clock.
+	EvaluationTime time.Time
+
+	// IssuerCASecret is an optional input, only gathered if the Gatherer has
+	// been configured to gather it. It is nil if the Secret does not exist,
+	// or if it could not be fetched, in which case it is recorded in
+	// UnavailableInputs.
+	IssuerCASecret *corev1.Secret
+
+	// UnavailableInputs records the error with which each optional input
+	// could not be fetched. Policies depending on an unavailable input should
+	// treat their check as inconclusive rather than as failed.
+	UnavailableInputs map[OptionalInput]error
+
+	// parsed holds the data decoded from the input whilst it is evaluated
+	// by a chain, see parsedInput.
+	parsed *parsedInput
+}
+
+// Unavailable returns the error with which the given optional input could
+// not be fetched, or nil if it is available.
+func (i Input) Unavailable(input OptionalInput) error {
+	return i.UnavailableInputs[input]
+}
+
+func (i *Input) setUnavailable(input OptionalInput, err error) {
+	if i.UnavailableInputs == nil {
+		i.UnavailableInputs = make(map[OptionalInput]error)
+	}
+	i.UnavailableInputs[input] = err
+}
+
+func (i *Input) setOptional(input OptionalInput, secret *corev1.Secret) {
+	switch input {
+	case IssuerCAInput:
+		i.IssuerCASecret = secret
+	}
+}
+
+// evaluationTime returns the time at which the given input should be
+// evaluated.
+func evaluationTime(c clock.Clock, input Input) time.Time {
+	if !input.EvaluationTime.IsZero() {
+		return input.EvaluationTime
+	}
+	return c.Now()
 }
 
 // A Func evaluates the given input data and decides whether a check has passed
@@ -54,6 +113,7 @@ type Chain []Func
 // As soon as it is discovered that the input violates one policy,
 // Evaluate will return and not evaluate the rest of the chain.
 func (c Chain) Evaluate(input Input) (string, string, bool) {
+	input = input.withParsed()
 	for _, policyFunc := range c {
 		reason, message, violationFound := policyFunc(input)
 		if violationFound {
@@ -63,39 +123,111 @@ func (c Chain) Evaluate(input Input) (string, string, bool) {
 	return "", "", false
 }
 
+// A Policy is a Func identified by a stable name.
+type Policy struct {
+	Name  string
+	Check Func
+}
+
+// A NamedChain of Policies to be evaluated in order. Unlike a Chain, it
+// reports the result of each evaluated policy.
+type NamedChain []Policy
+
+// Results will evaluate the policy chain using the provided input, and
+// returns the result of each evaluated policy. As soon as it is discovered
+// that the input violates one policy, the rest of the chain is not evaluated,
+// so only the last result may be a violation.
+func (c NamedChain) Results(input Input) []cmapi.CertificatePolicyResult {
+	input = input.withParsed()
+	results := make([]cmapi.CertificatePolicyResult, 0, len(c))
+	for _, policy := range c {
+		reason, message, violationFound := policy.Check(input)
+		results = append(results, cmapi.CertificatePolicyResult{
+			PolicyName: policy.Name,
+			Violated:   violationFound,
+			Reason:     reason,
+			Message:    message,
+		})
+		if violationFound {
+			break
+		}
+	}
+	return results
+}
+
+// Evaluate will evaluate the entire policy chain using the provided input,
+// with the same semantics as Chain.Evaluate.
+func (c NamedChain) Evaluate(input Input) (string, string, bool) {
+	return Violation(c.Results(input))
+}
+
+// Chain returns the policies of the NamedChain as a Chain.
+func (c NamedChain) Chain() Chain {
+	chain := make(Chain, 0, len(c))
+	for _, policy := range c {
+		chain = append(chain, policy.Check)
+	}
+	return chain
+}
+
+// Violation returns the reason and message of the violated policy in the
+// given results, as returned by NamedChain.Results, if any.
+func Violation(results []cmapi.CertificatePolicyResult) (string, string, bool) {
+	for _, result := range results {
+		if result.Violated {
+			return result.Reason, result.Message, true
+		}
+	}
+	return "", "", false
+}
+
 // NewTriggerPolicyChain includes trigger policy checks, which if return true,
 // should cause a Certificate to be marked for issuance.
-func NewTriggerPolicyChain(c clock.Clock) Chain {
-	return Chain{
-		SecretDoesNotExist,     // Make sure the Secret exists
-		SecretIsMissingData,    // Make sure the Secret has the required keys set
-		SecretPublicKeysDiffer, // Make sure the PrivateKey and PublicKey match in the Secret
+func NewTriggerPolicyChain(c clock.Clock, defaults internalcertificates.PrivateKeyDefaults) Chain {
+	return NewNamedTriggerPolicyChain(c, defaults).Chain()
+}
 
-		SecretIssuerAnnotationsMismatch,          // Make sure the Secret's IssuerRef annotations match the Certificate spec
-		SecretCertificateNameAnnotationsMismatch, // Make sure the Secret's CertificateName annotation matches the Certificate's name
+// NewNamedTriggerPolicyChain returns the trigger policy checks of
+// NewTriggerPolicyChain along with their names.
+func NewNamedTriggerPolicyChain(c clock.Clock, defaults internalcertificates.PrivateKeyDefaults) NamedChain {
+	return NamedChain{
+		{PolicySecretDoesNotExist, SecretDoesNotExist},         // Make sure the Secret exists
+		{PolicySecretIsMissingData, SecretIsMissingData},       // Make sure the Secret has the required keys set
+		{PolicySecretPublicKeysDiffer, SecretPublicKeysDiffer}, // Make sure the PrivateKey and PublicKey match in the Secret
 
-		SecretPrivateKeyMismatchesSpec,                      // Make sure the PrivateKey Type and Size match the Certificate spec
-		SecretPublicKeyDiffersFromCurrentCertificateRequest, // Make sure the Secret's PublicKey matches the current CertificateRequest
-		CurrentCertificateRequestMismatchesSpec,             // Make sure the current CertificateRequest matches the Certificate spec
-		CurrentCertificateNearingExpiry(c),                  // Make sure the Certificate in the Secret is not nearing expiry
+		{PolicySecretIssuerAnnotationsMismatch, SecretIssuerAnnotationsMismatch},                   // Make sure the Secret's IssuerRef annotations match the Certificate spec
+		{PolicySecretCertificateNameAnnotationsMismatch, SecretCertificateNameAnnotationsMismatch}, // Make sure the Secret's CertificateName annotation matches the Certificate's name
+		{PolicySecretAdoptionDisabled, SecretAdoptionDisabled},                                     // Make sure an existing Secret may be adopted if the Certificate has not been issued yet
+
+		{PolicySecretPrivateKeyMismatchesSpec, SecretPrivateKeyMismatchesSpec(defaults)},                                 // Make sure the PrivateKey Type and Size match the Certificate spec
+		{PolicySecretPublicKeyDiffersFromCurrentCertificateRequest, SecretPublicKeyDiffersFromCurrentCertificateRequest}, // Make sure the Secret's PublicKey matches the current CertificateRequest
+		{PolicyCurrentCertificateRequestMismatchesSpec, CurrentCertificateRequestMismatchesSpec},                         // Make sure the current CertificateRequest matches the Certificate spec
+		{PolicySecretIssuerCAUnexpected, SecretIssuerCAUnexpected},                                                       // Make sure the Secret's certificate was signed by a CA recorded upon issuance
+		{PolicyCurrentCertificateNearingExpiry, CurrentCertificateNearingExpiry(c)},                                      // Make sure the Certificate in the Secret is not nearing expiry
 	}
 }
 
 // NewReadinessPolicyChain includes readiness policy checks, which if return
 // true, would cause a Certificate to be marked as not ready.
-func NewReadinessPolicyChain(c clock.Clock) Chain {
+func NewReadinessPolicyChain(c clock.Clock, defaults internalcertificates.PrivateKeyDefaults) Chain {
 	return Chain{
-		SecretDoesNotExist,     // Make sure the Secret exists
-		SecretIsMissingData,    // Make sure the Secret has the required keys set
-		SecretPublicKeysDiffer, // Make sure the PrivateKey and PublicKey match in the Secret
+		SecretDoesNotExist,                  // Make sure the Secret exists
+		SecretManagedDataModifiedExternally, // Make sure the Secret's data has not been modified by a third party
+		SecretIsMissingData,                 // Make sure the Secret has the required keys set
+		SecretPublicKeysDiffer,              // Make sure the PrivateKey and PublicKey match in the Secret
 
 		SecretIssuerAnnotationsMismatch,          // Make sure the Secret's IssuerRef annotations match the Certificate spec
 		SecretCertificateNameAnnotationsMismatch, // Make sure the Secret's CertificateName annotation matches the Certificate's name
+		SecretAdoptionDisabled,                   // Make sure an existing Secret may be adopted if the Certificate has not been issued yet
 
-		SecretPrivateKeyMismatchesSpec,                      // Make sure the PrivateKey Type and Size match the Certificate spec
+		SecretPrivateKeyMismatchesSpec(defaults),            // Make sure the PrivateKey Type and Size match the Certificate spec
 		SecretPublicKeyDiffersFromCurrentCertificateRequest, // Make sure the Secret's PublicKey matches the current CertificateRequest
+		SecretCertificateIssuedKeyMismatch,                  // Make sure the Secret's certificate is for the key the current CertificateRequest requested
 		CurrentCertificateRequestMismatchesSpec,             // Make sure the current CertificateRequest matches the Certificate spec
 		CurrentCertificateHasExpired(c),                     // Make sure the Certificate in the Secret has not expired
+		CurrentCertificateNotYetValid(c),                    // Make sure the Certificate in the Secret is already valid
+		SecretCertificateChainInvalid(c),                    // Make sure the certificate chain in the Secret is well formed
+		SecretIssuerChainExpiringSoon,                       // Make sure the issuer chain in the Secret outlives the certificate's renewal time
 	}
 }
 
@@ -104,13 +236,17 @@ func NewReadinessPolicyChain(c clock.Clock) Chain {
 // correctness of metadata and output formats of Certificate's Secrets.
 func NewSecretPostIssuancePolicyChain(ownerRefEnabled bool, fieldManager string) Chain {
 	return Chain{
+		SecretManagedAnnotationsMissing,                                      // Make sure the managed annotations exist, e.g. after a restore of the Secret
+		SecretIssuerAnnotationsNotNormalized,                                 // Make sure the issuer annotations are written in canonical form
 		SecretBaseLabelsMismatch,                                             // Make sure the managed labels have the correct values
 		SecretCertificateDetailsAnnotationsMismatch,                          // Make sure the managed certificate details annotations have the correct values
 		SecretManagedLabelsAndAnnotationsManagedFieldsMismatch(fieldManager), // Make sure the only the expected managed labels and annotations exist
 		SecretSecretTemplateMismatch,                                         // Make sure the template label and annotation values match the secret
 		SecretSecretTemplateManagedFieldsMismatch(fieldManager),              // Make sure the only the expected template labels and annotations exist
+		SecretImmutableMismatch,                                              // Make sure the Secret is immutable if the SecretTemplate requests it
 		SecretAdditionalOutputFormatsMismatch,
 		SecretAdditionalOutputFormatsManagedFieldsMismatch(fieldManager),
+		SecretCAPolicyMismatch, // Make sure ca.crt matches the Certificate's SecretCAPolicy
 		SecretOwnerReferenceMismatch(ownerRefEnabled),
 		SecretOwnerReferenceManagedFieldMismatch(ownerRefEnabled, fieldManager),
 
@@ -0,0 +1,269 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policies
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+
+	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+func TestNamedChain(t *testing.T) {
+	pass := func(Input) (string, string, bool) { return "", "", false }
+	violate := func(reason string) Func {
+		return func(Input) (string, string, bool) { return reason, reason + " message", true }
+	}
+
+	tests := map[string]struct {
+		chain       NamedChain
+		wantResults []cmapi.CertificatePolicyResult
+	}{
+		"should report every policy if none is violated": {
+			chain: NamedChain{{"First", pass}, {"Second", pass}},
+			wantResults: []cmapi.CertificatePolicyResult{
+				{PolicyName: "First"},
+				{PolicyName: "Second"},
+			},
+		},
+		"should stop at the first violated policy": {
+			chain: NamedChain{{"First", pass}, {"Second", violate("Renewing")}, {"Third", violate("Expired")}},
+			wantResults: []cmapi.CertificatePolicyResult{
+				{PolicyName: "First"},
+				{PolicyName: "Second", Violated: true, Reason: "Renewing", Message: "Renewing message"},
+			},
+		},
+		"should report no results for an empty chain": {
+			chain:       NamedChain{},
+			wantResults: []cmapi.CertificatePolicyResult{},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			results := test.chain.Results(Input{})
+			assert.Equal(t, test.wantResults, results)
+
+			// The NamedChain and its Chain must agree with the results.
+			reason, message, violated := Violation(results)
+			gotReason, gotMessage, gotViolated := test.chain.Evaluate(Input{})
+			assert.Equal(t, []any{reason, message, violated}, []any{gotReason, gotMessage, gotViolated})
+			gotReason, gotMessage, gotViolated = test.chain.Chain().Evaluate(Input{})
+			assert.Equal(t, []any{reason, message, violated}, []any{gotReason, gotMessage, gotViolated})
+		})
+	}
+}
+
+func TestNewNamedTriggerPolicyChain(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Now())
+	chain := NewNamedTriggerPolicyChain(clock, internalcertificates.PrivateKeyDefaults{})
+	assert.Len(t, NewTriggerPolicyChain(clock, internalcertificates.PrivateKeyDefaults{}), len(chain))
+
+	names := map[string]bool{}
+	for _, policy := range chain {
+		assert.NotEmpty(t, policy.Name)
+		assert.False(t, names[policy.Name], "duplicate policy name %q", policy.Name)
+		names[policy.Name] = true
+	}
+}
+
+// newChainInput returns an input which passes the trigger and readiness
+// policy chains, whose Secret holds a chain of five certificates: the leaf
+// and three intermediates in tls.crt, and the root in ca.crt.
+func newChainInput(tb testing.TB, now time.Time) Input {
+	chainNotAfter := now.Add(365 * 24 * time.Hour)
+	root := mustCreateChainCert(tb, now, "root", true, chainNotAfter, nil)
+	intermediate1 := mustCreateChainCert(tb, now, "intermediate-1", true, chainNotAfter, root)
+	intermediate2 := mustCreateChainCert(tb, now, "intermediate-2", true, chainNotAfter, intermediate1)
+	intermediate3 := mustCreateChainCert(tb, now, "intermediate-3", true, chainNotAfter, intermediate2)
+	leaf := mustCreateChainCert(tb, now, "leaf", false, now.Add(90*24*time.Hour), intermediate3)
+
+	keyPEM, err := pki.EncodePKCS8PrivateKey(leaf.key)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{Subject: pkix.Name{CommonName: "leaf"}}, leaf.key)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	issuerCAFingerprint, err := pki.PublicKeyFingerprintSHA256(intermediate3.key.Public())
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	issuerRef := cmmeta.ObjectReference{Name: "testissuer", Kind: "IssuerKind", Group: "group.example.com"}
+	return Input{
+		Certificate: &cmapi.Certificate{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: cmapi.CertificateSpec{
+				CommonName:             "leaf",
+				IssuerRef:              issuerRef,
+				PrivateKey:             &cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm, Size: 256},
+				RenewBeforeChainExpiry: true,
+			},
+			Status: cmapi.CertificateStatus{
+				Revision:             ptr.To(1),
+				IssuerCAFingerprints: []string{issuerCAFingerprint},
+			},
+		},
+		Secret: &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test",
+				Annotations: map[string]string{
+					cmapi.IssuerNameAnnotationKey:  issuerRef.Name,
+					cmapi.IssuerKindAnnotationKey:  issuerRef.Kind,
+					cmapi.IssuerGroupAnnotationKey: issuerRef.Group,
+					cmapi.CertificateNameKey:       "test",
+				},
+			},
+			Data: map[string][]byte{
+				corev1.TLSPrivateKeyKey: keyPEM,
+				corev1.TLSCertKey:       joinChainTestCerts(leaf, intermediate3, intermediate2, intermediate1),
+				cmmeta.TLSCAKey:         root.pem,
+			},
+		},
+		CurrentRevisionRequest: &cmapi.CertificateRequest{Spec: cmapi.CertificateRequestSpec{
+			IssuerRef: issuerRef,
+			Request:   pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csr}),
+		}},
+		EvaluationTime: now,
+	}
+}
+
+// evaluateEachPolicy evaluates the chain by calling each of its policies
+// directly, so that every policy decodes the data it needs itself.
+func evaluateEachPolicy(chain Chain, input Input) (string, string, bool) {
+	for _, policyFunc := range chain {
+		if reason, message, violationFound := policyFunc(input); violationFound {
+			return reason, message, violationFound
+		}
+	}
+	return "", "", false
+}
+
+func TestChainEvaluateDecodesInputOnce(t *testing.T) {
+	now := time.Now()
+	clock := fakeclock.NewFakeClock(now)
+	chains := map[string]Chain{
+		"trigger":   NewTriggerPolicyChain(clock, internalcertificates.PrivateKeyDefaults{}),
+		"readiness": NewReadinessPolicyChain(clock, internalcertificates.PrivateKeyDefaults{}),
+	}
+
+	tests := map[string]func(input *Input){
+		"valid chain": func(*Input) {},
+		"invalid private key": func(input *Input) {
+			input.Secret.Data[corev1.TLSPrivateKeyKey] = []byte("invalid")
+		},
+		"invalid certificate": func(input *Input) {
+			input.Secret.Data[corev1.TLSCertKey] = []byte("invalid")
+		},
+		"truncated chain": func(input *Input) {
+			data := input.Secret.Data[corev1.TLSCertKey]
+			input.Secret.Data[corev1.TLSCertKey] = data[:len(data)-100]
+		},
+		"invalid ca.crt": func(input *Input) {
+			input.Secret.Data[cmmeta.TLSCAKey] = []byte("-----BEGIN CERTIFICATE-----\ninvalid\n-----END CERTIFICATE-----\n")
+		},
+		"invalid certificate request": func(input *Input) {
+			input.CurrentRevisionRequest.Spec.Request = []byte("invalid")
+		},
+		"unexpected issuer CA": func(input *Input) {
+			input.Certificate.Status.IssuerCAFingerprints = []string{"unknown"}
+		},
+	}
+	for name, modify := range tests {
+		t.Run(name, func(t *testing.T) {
+			input := newChainInput(t, now)
+			modify(&input)
+			for chainName, chain := range chains {
+				// Decoding the input once must not change the outcome of
+				// any policy.
+				reason, message, violated := chain.Evaluate(input)
+				expReason, expMessage, expViolated := evaluateEachPolicy(chain, input)
+				assert.Equal(t, []any{expReason, expMessage, expViolated}, []any{reason, message, violated}, "%s chain", chainName)
+			}
+		})
+	}
+
+	t.Run("valid chain passes every policy", func(t *testing.T) {
+		input := newChainInput(t, now)
+		for chainName, chain := range chains {
+			_, message, violated := chain.Evaluate(input)
+			assert.False(t, violated, "%s chain: %s", chainName, message)
+		}
+	})
+}
+
+func TestMemo(t *testing.T) {
+	var m memo[int]
+	calls := 0
+	decode := func() (int, error) {
+		calls++
+		return 0, assert.AnError
+	}
+	for range 3 {
+		_, err := m.get(decode)
+		assert.Equal(t, assert.AnError, err)
+	}
+	assert.Equal(t, 1, calls, "expected the error to be memoized")
+}
+
+// BenchmarkPolicyChains compares evaluating the policy chains, which decode
+// the certificates and private key of the Secret once, with calling each of
+// their policies directly, which decode them in every policy.
+func BenchmarkPolicyChains(b *testing.B) {
+	now := time.Now()
+	clock := fakeclock.NewFakeClock(now)
+	input := newChainInput(b, now)
+
+	for _, chain := range []struct {
+		name  string
+		chain Chain
+	}{
+		{"trigger", NewTriggerPolicyChain(clock, internalcertificates.PrivateKeyDefaults{})},
+		{"readiness", NewReadinessPolicyChain(clock, internalcertificates.PrivateKeyDefaults{})},
+	} {
+		if _, message, violated := chain.chain.Evaluate(input); violated {
+			b.Fatalf("expected the %s chain to pass: %s", chain.name, message)
+		}
+
+		b.Run(chain.name+"/decoded-once", func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				chain.chain.Evaluate(input)
+			}
+		})
+		b.Run(chain.name+"/decoded-per-policy", func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				evaluateEachPolicy(chain.chain, input)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+
+	corev1 "k8s.io/api/core/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// PrivateKeyDefaults are the controller wide defaults for the private key of
+// Certificates which leave it unspecified. The zero value results in the
+// built-in defaults: 2048 bit RSA keys which are never rotated.
+type PrivateKeyDefaults struct {
+	// Algorithm and Size are used for Certificates which set neither
+	// spec.privateKey.algorithm nor spec.privateKey.size.
+	Algorithm cmapi.PrivateKeyAlgorithm
+	Size      int
+
+	// RotationPolicy is used for Certificates which do not set
+	// spec.privateKey.rotationPolicy.
+	RotationPolicy cmapi.PrivateKeyRotationPolicy
+}
+
+// PrivateKeyDefaulted returns true if the Certificate spec leaves the
+// algorithm and size of its private key to the defaults. A size without an
+// algorithm has always meant an RSA key, so is not subject to the defaults.
+func PrivateKeyDefaulted(spec cmapi.CertificateSpec) bool {
+	return spec.PrivateKey == nil || (spec.PrivateKey.Algorithm == "" && spec.PrivateKey.Size == 0)
+}
+
+// UsesExternalPrivateKey returns true if the Certificate's private key is
+// held by an external KMS, in which case only a reference to the key is
+// stored in the cluster.
+func UsesExternalPrivateKey(spec cmapi.CertificateSpec) bool {
+	return spec.PrivateKey != nil && spec.PrivateKey.External != nil
+}
+
+// Apply returns a copy of the Certificate with the defaults set on its
+// unset private key fields.
+func (d PrivateKeyDefaults) Apply(crt *cmapi.Certificate) *cmapi.Certificate {
+	defaulted := PrivateKeyDefaulted(crt.Spec)
+	crt = crt.DeepCopy()
+	if crt.Spec.PrivateKey == nil {
+		crt.Spec.PrivateKey = &cmapi.CertificatePrivateKey{}
+	}
+	if defaulted {
+		crt.Spec.PrivateKey.Algorithm = d.Algorithm
+		crt.Spec.PrivateKey.Size = d.Size
+	}
+	if crt.Spec.PrivateKey.RotationPolicy == "" {
+		crt.Spec.PrivateKey.RotationPolicy = d.RotationPolicy
+	}
+	return crt
+}
+
+// PrivateKeyMatchesSpec is like pki.PrivateKeyMatchesSpec, but compares the
+// private key against the spec with the defaults applied. The secret is the
+// Certificate's Secret, and may be nil if it does not exist.
+// Changing the defaults must not replace the keys of existing certificates,
+// so if the Secret's key was issued whilst the spec was defaulted, any key is
+// accepted for as long as the spec remains defaulted. Secrets issued before
+// this was recorded on them also accept keys matching the built-in defaults.
+func (d PrivateKeyDefaults) PrivateKeyMatchesSpec(pk crypto.PrivateKey, spec cmapi.CertificateSpec, secret *corev1.Secret) ([]string, error) {
+	if !PrivateKeyDefaulted(spec) {
+		return utilpki.PrivateKeyMatchesSpec(pk, spec)
+	}
+
+	if secret != nil && secret.Annotations[cmapi.PrivateKeyDefaultedAnnotationKey] == "true" {
+		return nil, nil
+	}
+
+	violations, err := utilpki.PrivateKeyMatchesSpec(pk, d.Apply(&cmapi.Certificate{Spec: spec}).Spec)
+	if err != nil || len(violations) == 0 || secret == nil {
+		return violations, err
+	}
+
+	if builtinViolations, err := utilpki.PrivateKeyMatchesSpec(pk, spec); err == nil && len(builtinViolations) == 0 {
+		return nil, nil
+	}
+
+	return violations, nil
+}
+
+// WithPrivateKeyParameters returns a copy of the Certificate with the
+// algorithm and size of its private key set to those of the given key, if
+// the Certificate leaves them to the defaults. The key may have been
+// generated using earlier defaults, and the CSR for it must be encoded with a
+// matching signature algorithm.
+func WithPrivateKeyParameters(crt *cmapi.Certificate, pk crypto.PrivateKey) *cmapi.Certificate {
+	if !PrivateKeyDefaulted(crt.Spec) {
+		return crt
+	}
+
+	crt = crt.DeepCopy()
+	if crt.Spec.PrivateKey == nil {
+		crt.Spec.PrivateKey = &cmapi.CertificatePrivateKey{}
+	}
+	// The key may be held by a KMS, so only its public half is inspected.
+	var publicKey crypto.PublicKey
+	if signer, ok := pk.(crypto.Signer); ok {
+		publicKey = signer.Public()
+	}
+	switch k := publicKey.(type) {
+	case *rsa.PublicKey:
+		crt.Spec.PrivateKey.Algorithm = cmapi.RSAKeyAlgorithm
+		crt.Spec.PrivateKey.Size = k.N.BitLen()
+	case *ecdsa.PublicKey:
+		crt.Spec.PrivateKey.Algorithm = cmapi.ECDSAKeyAlgorithm
+		crt.Spec.PrivateKey.Size = k.Curve.Params().BitSize
+	case ed25519.PublicKey:
+		crt.Spec.PrivateKey.Algorithm = cmapi.Ed25519KeyAlgorithm
+	}
+	return crt
+}
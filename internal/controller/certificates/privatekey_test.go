@@ -0,0 +1,175 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"crypto"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+func mustGenerateRSA(t *testing.T, keySize int) crypto.Signer {
+	pk, err := utilpki.GenerateRSAPrivateKey(keySize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pk
+}
+
+func mustGenerateECDSA(t *testing.T, keySize int) crypto.Signer {
+	pk, err := utilpki.GenerateECPrivateKey(keySize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pk
+}
+
+func Test_PrivateKeyDefaultsApply(t *testing.T) {
+	defaults := PrivateKeyDefaults{
+		Algorithm:      cmapi.ECDSAKeyAlgorithm,
+		Size:           384,
+		RotationPolicy: cmapi.RotationPolicyAlways,
+	}
+
+	tests := map[string]struct {
+		privateKey *cmapi.CertificatePrivateKey
+		exp        cmapi.CertificatePrivateKey
+	}{
+		"unset private key is defaulted": {
+			privateKey: nil,
+			exp:        cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm, Size: 384, RotationPolicy: cmapi.RotationPolicyAlways},
+		},
+		"explicit algorithm is not defaulted": {
+			privateKey: &cmapi.CertificatePrivateKey{Algorithm: cmapi.RSAKeyAlgorithm},
+			exp:        cmapi.CertificatePrivateKey{Algorithm: cmapi.RSAKeyAlgorithm, RotationPolicy: cmapi.RotationPolicyAlways},
+		},
+		"explicit size without an algorithm is not defaulted": {
+			privateKey: &cmapi.CertificatePrivateKey{Size: 4096},
+			exp:        cmapi.CertificatePrivateKey{Size: 4096, RotationPolicy: cmapi.RotationPolicyAlways},
+		},
+		"explicit rotation policy is not defaulted": {
+			privateKey: &cmapi.CertificatePrivateKey{RotationPolicy: cmapi.RotationPolicyNever},
+			exp:        cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm, Size: 384, RotationPolicy: cmapi.RotationPolicyNever},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			crt := &cmapi.Certificate{Spec: cmapi.CertificateSpec{PrivateKey: test.privateKey}}
+			got := defaults.Apply(crt)
+			assert.Equal(t, test.exp, *got.Spec.PrivateKey)
+			assert.Equal(t, test.privateKey, crt.Spec.PrivateKey, "input Certificate must not be modified")
+		})
+	}
+}
+
+// Simulates the controller being restarted with different private key
+// defaults whilst existing Secrets are kept.
+func Test_PrivateKeyDefaultsPrivateKeyMatchesSpec(t *testing.T) {
+	rsa2048 := mustGenerateRSA(t, 2048)
+	ecdsa256 := mustGenerateECDSA(t, utilpki.ECCurve256)
+	ecdsa384 := mustGenerateECDSA(t, utilpki.ECCurve384)
+
+	defaultedSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Annotations: map[string]string{cmapi.PrivateKeyDefaultedAnnotationKey: "true"},
+	}}
+	legacySecret := &corev1.Secret{}
+
+	ecdsaDefaults := PrivateKeyDefaults{Algorithm: cmapi.ECDSAKeyAlgorithm, Size: 384}
+
+	tests := map[string]struct {
+		defaults      PrivateKeyDefaults
+		spec          cmapi.CertificateSpec
+		pk            crypto.PrivateKey
+		secret        *corev1.Secret
+		expViolations []string
+	}{
+		"new key must match the current defaults": {
+			defaults:      ecdsaDefaults,
+			pk:            rsa2048,
+			expViolations: []string{"spec.privateKey.algorithm"},
+		},
+		"new key matching the current defaults is accepted": {
+			defaults: ecdsaDefaults,
+			pk:       ecdsa384,
+		},
+		"key issued under previous defaults is accepted": {
+			defaults: ecdsaDefaults,
+			pk:       rsa2048,
+			secret:   defaultedSecret,
+		},
+		"key issued under previous defaults is accepted after the defaults are reset": {
+			defaults: PrivateKeyDefaults{},
+			pk:       ecdsa384,
+			secret:   defaultedSecret,
+		},
+		"key issued under previous defaults must match an explicit spec": {
+			defaults:      ecdsaDefaults,
+			spec:          cmapi.CertificateSpec{PrivateKey: &cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm, Size: 256}},
+			pk:            rsa2048,
+			secret:        defaultedSecret,
+			expViolations: []string{"spec.privateKey.algorithm"},
+		},
+		"key in a Secret issued before defaults were recorded is accepted if it matches the built-in defaults": {
+			defaults: ecdsaDefaults,
+			pk:       rsa2048,
+			secret:   legacySecret,
+		},
+		"key in a Secret issued before defaults were recorded is accepted if it matches the current defaults": {
+			defaults: ecdsaDefaults,
+			pk:       ecdsa384,
+			secret:   legacySecret,
+		},
+		"key in a Secret issued before defaults were recorded must match either defaults": {
+			defaults:      ecdsaDefaults,
+			pk:            ecdsa256,
+			secret:        legacySecret,
+			expViolations: []string{"spec.privateKey.size"},
+		},
+		"explicit spec is not subject to the defaults": {
+			defaults: ecdsaDefaults,
+			spec:     cmapi.CertificateSpec{PrivateKey: &cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm}},
+			pk:       ecdsa256,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			violations, err := test.defaults.PrivateKeyMatchesSpec(test.pk, test.spec, test.secret)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expViolations, violations)
+		})
+	}
+}
+
+func Test_WithPrivateKeyParameters(t *testing.T) {
+	ecdsa384 := mustGenerateECDSA(t, utilpki.ECCurve384)
+
+	defaulted := &cmapi.Certificate{}
+	got := WithPrivateKeyParameters(defaulted, ecdsa384)
+	assert.Equal(t, &cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm, Size: 384}, got.Spec.PrivateKey)
+	assert.Nil(t, defaulted.Spec.PrivateKey, "input Certificate must not be modified")
+
+	explicit := &cmapi.Certificate{Spec: cmapi.CertificateSpec{PrivateKey: &cmapi.CertificatePrivateKey{Algorithm: cmapi.RSAKeyAlgorithm}}}
+	assert.Equal(t, explicit, WithPrivateKeyParameters(explicit, ecdsa384))
+}
@@ -20,6 +20,14 @@ import (
 	"bytes"
 	"crypto/x509"
 	"encoding/pem"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmutil "github.com/cert-manager/cert-manager/pkg/util"
@@ -96,3 +104,120 @@ func OutputFormatDER(privateKey []byte) []byte {
 func OutputFormatCombinedPEM(privateKey, certificate []byte) []byte {
 	return bytes.Join([][]byte{privateKey, certificate}, []byte("\n"))
 }
+
+// AdoptsExistingSecret returns true if a Secret which already exists before
+// the Certificate is first issued may be adopted, i.e. used as the Certificate's
+// Secret without issuing a certificate. Adoption is disabled by setting the
+// AdoptExistingSecretAnnotationKey annotation to "false".
+func AdoptsExistingSecret(crt *cmapi.Certificate) bool {
+	return crt.Annotations[cmapi.AdoptExistingSecretAnnotationKey] != "false"
+}
+
+// DeletesPreviousSecrets returns true if the Secrets which held the
+// Certificate's data before spec.secretName was changed are to be deleted,
+// either because owner references are enabled for Certificate Secrets or
+// because the Certificate opted in using the DeletePreviousSecretAnnotationKey
+// annotation.
+func DeletesPreviousSecrets(crt *cmapi.Certificate, ownerRefEnabled bool) bool {
+	return ownerRefEnabled || crt.Annotations[cmapi.DeletePreviousSecretAnnotationKey] == "true"
+}
+
+// PreviousSecrets returns the Secrets which held the Certificate's data under
+// another name before spec.secretName was changed. These are the Secrets
+// managed by cert-manager which are annotated with the name of the
+// Certificate and hold a certificate, other than the Secret named by
+// spec.secretName and its rotation Secret. The Secret whose certificate
+// expires last is returned first, so that the most recent data is preferred
+// if spec.secretName was changed more than once. Secrets whose certificate
+// cannot be decoded are returned last, and ties are broken by name.
+func PreviousSecrets(secrets corev1listers.SecretNamespaceLister, crt *cmapi.Certificate) ([]*corev1.Secret, error) {
+	managed, err := secrets.List(labels.SelectorFromSet(labels.Set{cmapi.PartOfCertManagerControllerLabelKey: "true"}))
+	if err != nil {
+		return nil, err
+	}
+
+	var previous []*corev1.Secret
+	notAfter := make(map[string]time.Time)
+	for _, secret := range managed {
+		if secret.Name == crt.Spec.SecretName ||
+			secret.Name == RotationSecretName(crt.Spec.SecretName) ||
+			secret.Annotations[cmapi.CertificateNameKey] != crt.Name ||
+			secret.Labels[cmapi.IsNextPrivateKeySecretLabelKey] == "true" ||
+			len(secret.Data[corev1.TLSCertKey]) == 0 {
+			continue
+		}
+		previous = append(previous, secret)
+		if cert, err := utilpki.DecodeX509CertificateBytes(secret.Data[corev1.TLSCertKey]); err == nil {
+			notAfter[secret.Name] = cert.NotAfter
+		}
+	}
+
+	sort.Slice(previous, func(i, j int) bool {
+		a, aOK := notAfter[previous[i].Name]
+		b, bOK := notAfter[previous[j].Name]
+		if aOK != bOK {
+			return aOK
+		}
+		if !a.Equal(b) {
+			return a.After(b)
+		}
+		return previous[i].Name < previous[j].Name
+	})
+	return previous, nil
+}
+
+// PreviousSecret returns the Secret which held the Certificate's current
+// certificate before spec.secretName was changed, or nil if there is none. See
+// PreviousSecrets.
+// A previous Secret is only returned while the rename is in progress, i.e. if
+// it holds the certificate whose fingerprint is recorded in the Certificate's
+// status, or the certificate issued for req, the CertificateRequest of the
+// current revision, which may be nil. Secrets holding any other certificate,
+// such as copies of the Certificate's Secret or Secrets left behind by an
+// earlier rename, are never returned.
+func PreviousSecret(secrets corev1listers.SecretNamespaceLister, crt *cmapi.Certificate, req *cmapi.CertificateRequest) (*corev1.Secret, error) {
+	previous, err := PreviousSecrets(secrets, crt)
+	if err != nil {
+		return nil, err
+	}
+
+	var issued *x509.Certificate
+	if req != nil {
+		issued, _ = utilpki.DecodeX509CertificateBytes(req.Status.Certificate)
+	}
+	for _, secret := range previous {
+		cert, err := utilpki.DecodeX509CertificateBytes(secret.Data[corev1.TLSCertKey])
+		if err != nil {
+			continue
+		}
+		if crt.Status.SHA256Fingerprint != "" && strings.EqualFold(utilpki.FingerprintSHA256(cert), crt.Status.SHA256Fingerprint) {
+			return secret, nil
+		}
+		if issued != nil && cert.Equal(issued) {
+			return secret, nil
+		}
+	}
+	return nil, nil
+}
+
+// rotationSecretSuffix is appended to the name of an immutable Certificate
+// Secret to name the temporary Secret used whilst it is re-created.
+const rotationSecretSuffix = "-rotation"
+
+// UsesImmutableSecret returns true if the Certificate's Secret is marked
+// immutable, in which case it is re-created rather than updated when the
+// certificate is issued.
+func UsesImmutableSecret(spec cmapi.CertificateSpec) bool {
+	return spec.SecretTemplate != nil && spec.SecretTemplate.Immutable
+}
+
+// RotationSecretName returns the name of the temporary Secret which holds
+// the next version of the immutable Secret secretName whilst that Secret is
+// deleted and re-created. The name is truncated to remain a valid Secret
+// name.
+func RotationSecretName(secretName string) string {
+	if maxLen := validation.DNS1123SubdomainMaxLength - len(rotationSecretSuffix); len(secretName) > maxLen {
+		secretName = strings.TrimRight(secretName[:maxLen], ".-")
+	}
+	return secretName + rotationSecretSuffix
+}
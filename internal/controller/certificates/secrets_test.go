@@ -21,9 +21,20 @@ import (
 	"crypto/x509/pkix"
 	"net"
 	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
 )
 
 func Test_AnnotationsForCertificateSecret(t *testing.T) {
@@ -137,3 +148,140 @@ func Test_AnnotationsForCertificateSecret(t *testing.T) {
 		})
 	}
 }
+
+func Test_RotationSecretName(t *testing.T) {
+	tests := map[string]struct {
+		secretName string
+		expName    string
+	}{
+		"the suffix is appended to the Secret name": {
+			secretName: "example-tls",
+			expName:    "example-tls-rotation",
+		},
+		"a long Secret name is truncated": {
+			secretName: strings.Repeat("a", 253),
+			expName:    strings.Repeat("a", 244) + "-rotation",
+		},
+		"a truncated Secret name does not end in a separator": {
+			secretName: strings.Repeat("a", 243) + ".example.com",
+			expName:    strings.Repeat("a", 243) + "-rotation",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expName, RotationSecretName(test.secretName))
+		})
+	}
+}
+
+func Test_PreviousSecrets(t *testing.T) {
+	crt := &cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "crt"},
+		Spec:       cmapi.CertificateSpec{CommonName: "example.com", SecretName: "current"},
+	}
+	pk := testcrypto.MustCreatePEMPrivateKey(t)
+	now := time.Now()
+	secret := func(name string, notAfter time.Time, mods ...func(*corev1.Secret)) *corev1.Secret {
+		s := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name,
+				Annotations: map[string]string{cmapi.CertificateNameKey: "crt"},
+				Labels:      map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"},
+			},
+			Data: map[string][]byte{
+				corev1.TLSPrivateKeyKey: pk,
+				corev1.TLSCertKey:       testcrypto.MustCreateCertWithNotBeforeAfter(t, pk, crt, now.Add(-time.Hour), notAfter),
+			},
+		}
+		for _, mod := range mods {
+			mod(s)
+		}
+		return s
+	}
+
+	renamedOnce := secret("renamed-once", now.Add(time.Hour*24*30))
+	renamedTwice := secret("renamed-twice", now.Add(time.Hour*24*60))
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, s := range []*corev1.Secret{
+		secret("current", now.Add(time.Hour*24*90)),
+		secret(RotationSecretName("current"), now.Add(time.Hour*24*90)),
+		renamedOnce,
+		renamedTwice,
+		secret("also-renamed-twice", now.Add(time.Hour*24*60)),
+		secret("invalid", now, func(s *corev1.Secret) {
+			s.Data[corev1.TLSCertKey] = []byte("invalid")
+		}),
+		secret("other-certificate", now.Add(time.Hour*24*90), func(s *corev1.Secret) {
+			s.Annotations[cmapi.CertificateNameKey] = "other-crt"
+		}),
+		secret("next-private-key", now.Add(time.Hour*24*90), func(s *corev1.Secret) {
+			s.Labels[cmapi.IsNextPrivateKeySecretLabelKey] = "true"
+		}),
+		secret("no-certificate", now.Add(time.Hour*24*90), func(s *corev1.Secret) {
+			delete(s.Data, corev1.TLSCertKey)
+		}),
+		secret("other-namespace", now.Add(time.Hour*24*90), func(s *corev1.Secret) {
+			s.Namespace = "other-ns"
+		}),
+	} {
+		require.NoError(t, indexer.Add(s))
+	}
+	lister := corev1listers.NewSecretLister(indexer).Secrets("ns")
+
+	previous, err := PreviousSecrets(lister, crt)
+	require.NoError(t, err)
+	var names []string
+	for _, s := range previous {
+		names = append(names, s.Name)
+	}
+	assert.Equal(t, []string{"also-renamed-twice", "renamed-twice", "renamed-once", "invalid"}, names,
+		"expected the Secrets whose certificate expires last first")
+
+	t.Run("no previous Secret is returned if none holds the current certificate", func(t *testing.T) {
+		latest, err := PreviousSecret(lister, crt, nil)
+		require.NoError(t, err)
+		assert.Nil(t, latest)
+	})
+
+	t.Run("the previous Secret holding the certificate recorded on the status is returned", func(t *testing.T) {
+		cert, err := utilpki.DecodeX509CertificateBytes(renamedOnce.Data[corev1.TLSCertKey])
+		require.NoError(t, err)
+		crt := crt.DeepCopy()
+		crt.Status.SHA256Fingerprint = strings.ToUpper(utilpki.FingerprintSHA256(cert))
+
+		latest, err := PreviousSecret(lister, crt, nil)
+		require.NoError(t, err)
+		require.NotNil(t, latest)
+		assert.Equal(t, "renamed-once", latest.Name)
+	})
+
+	t.Run("the previous Secret holding the certificate of the current CertificateRequest is returned", func(t *testing.T) {
+		req := &cmapi.CertificateRequest{Status: cmapi.CertificateRequestStatus{Certificate: renamedTwice.Data[corev1.TLSCertKey]}}
+
+		latest, err := PreviousSecret(lister, crt, req)
+		require.NoError(t, err)
+		require.NotNil(t, latest)
+		assert.Equal(t, "renamed-twice", latest.Name)
+	})
+
+	t.Run("no previous Secret is returned for another Certificate", func(t *testing.T) {
+		req := &cmapi.CertificateRequest{Status: cmapi.CertificateRequestStatus{Certificate: renamedTwice.Data[corev1.TLSCertKey]}}
+
+		latest, err := PreviousSecret(lister, &cmapi.Certificate{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "unknown"},
+			Spec:       cmapi.CertificateSpec{SecretName: "current"},
+		}, req)
+		require.NoError(t, err)
+		assert.Nil(t, latest)
+	})
+}
+
+func Test_DeletesPreviousSecrets(t *testing.T) {
+	crt := &cmapi.Certificate{}
+	assert.False(t, DeletesPreviousSecrets(crt, false))
+	assert.True(t, DeletesPreviousSecrets(crt, true))
+
+	crt.Annotations = map[string]string{cmapi.DeletePreviousSecretAnnotationKey: "true"}
+	assert.True(t, DeletesPreviousSecrets(crt, false))
+}
@@ -137,6 +137,49 @@ const (
 	// Certificate resources.
 	// Github Issue: https://github.com/cert-manager/cert-manager/issues/6393
 	OtherNames featuregate.Feature = "OtherNames"
+
+	// Owner: N/A
+	// Alpha: v1.16
+	//
+	// ExternalPrivateKeys allows Certificates to set spec.privateKey.external,
+	// in which case their private keys are created and held by an external
+	// key management service, and CSRs are signed using the service.
+	ExternalPrivateKeys featuregate.Feature = "ExternalPrivateKeys"
+
+	// Owner: N/A
+	// Deprecated: v1.16
+	//
+	// LegacySecretPolicyReasons makes the trigger and readiness policies report
+	// the reasons used before v1.16 for Secrets which are missing, empty or
+	// contain invalid data: DoesNotExist, MissingData, InvalidKeyPair and
+	// InvalidCertificate instead of SecretMissing, SecretEmpty,
+	// SecretKeyMissing, SecretCertMissing and SecretDataInvalid. It allows
+	// alerts relying on the old reasons to be migrated, and will be removed in
+	// v1.17.
+	LegacySecretPolicyReasons featuregate.Feature = "LegacySecretPolicyReasons"
+
+	// Owner: N/A
+	// Alpha: v1.16
+	//
+	// AdoptRestoredCertificateRequests makes cert-manager treat a
+	// CertificateRequest as owned by a Certificate if its owner reference
+	// points at a Certificate of the same name with a different UID, as is the
+	// case after the Certificate has been restored from a backup, provided that
+	// the CertificateRequest is annotated with the name of the Certificate and
+	// still matches its spec. The owner reference of such a CertificateRequest
+	// is then repaired, so that restoring a backup does not cause every
+	// Certificate to be re-issued.
+	AdoptRestoredCertificateRequests featuregate.Feature = "AdoptRestoredCertificateRequests"
+
+	// Owner: N/A
+	// Alpha: v1.16
+	//
+	// CertificatePolicyEvaluationStatus makes the trigger controller record
+	// the result of each policy evaluated to decide whether a Certificate must
+	// be re-issued in the Certificate's status.lastEvaluation, so that tools
+	// can read why a Certificate is re-issued without parsing the message of
+	// its conditions. The status is only written when the outcome changes.
+	CertificatePolicyEvaluationStatus featuregate.Feature = "CertificatePolicyEvaluationStatus"
 )
 
 func init() {
@@ -160,4 +203,8 @@ var defaultCertManagerFeatureGates = map[featuregate.Feature]featuregate.Feature
 	UseCertificateRequestBasicConstraints:            {Default: false, PreRelease: featuregate.Alpha},
 	NameConstraints:                                  {Default: false, PreRelease: featuregate.Alpha},
 	OtherNames:                                       {Default: false, PreRelease: featuregate.Alpha},
+	ExternalPrivateKeys:                              {Default: false, PreRelease: featuregate.Alpha},
+	LegacySecretPolicyReasons:                        {Default: false, PreRelease: featuregate.Deprecated},
+	AdoptRestoredCertificateRequests:                 {Default: false, PreRelease: featuregate.Alpha},
+	CertificatePolicyEvaluationStatus:                {Default: false, PreRelease: featuregate.Alpha},
 }
@@ -0,0 +1,208 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuerhealth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	authnv1 "k8s.io/api/authentication/v1"
+	authzv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilcache "k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+)
+
+// Path is the path at which the issuer health summary is served.
+const Path = "/issuers/health"
+
+const (
+	// decisionCacheTTL is the time for which the outcome of authenticating
+	// and authorizing a token is cached, as by the delegated authentication
+	// and authorization of Kubernetes API servers.
+	decisionCacheTTL = time.Second * 10
+	// decisionCacheSize is the maximum number of tokens whose outcome is
+	// cached.
+	decisionCacheSize = 1024
+)
+
+// Handler serves the issuer health Summary as JSON.
+//
+// Requests must be made over TLS and present a bearer token, which is
+// authenticated using a TokenReview. The authenticated user must be allowed
+// to `get` the non-resource URL Path, which is checked using a
+// SubjectAccessReview. The outcome of both reviews is cached for a short
+// time, so that frequent scrapes do not each create two reviews.
+type Handler struct {
+	log     logr.Logger
+	tracker *Tracker
+	client  kubernetes.Interface
+
+	// decisions caches the HTTP status to respond with for a token, keyed by
+	// the hash of the token.
+	decisions *utilcache.LRUExpireCache
+
+	issuerLister cmlisters.IssuerLister
+	// clusterIssuerLister is nil if cert-manager is scoped to a single
+	// namespace, as ClusterIssuers are not supported.
+	clusterIssuerLister cmlisters.ClusterIssuerLister
+	mustSync            []cache.InformerSynced
+}
+
+// NewHandler returns a Handler reporting the Issuers and ClusterIssuers in the
+// given informer factory. The informers are registered with the factory, so
+// NewHandler must be called before the factory is started.
+func NewHandler(log logr.Logger, tracker *Tracker, client kubernetes.Interface, factory cminformers.SharedInformerFactory, namespace string) *Handler {
+	issuerInformer := factory.Certmanager().V1().Issuers()
+	h := &Handler{
+		log:          log.WithName("issuer-health"),
+		tracker:      tracker,
+		client:       client,
+		decisions:    utilcache.NewLRUExpireCacheWithClock(decisionCacheSize, tracker.clock),
+		issuerLister: issuerInformer.Lister(),
+		mustSync:     []cache.InformerSynced{issuerInformer.Informer().HasSynced},
+	}
+	if namespace == "" {
+		clusterIssuerInformer := factory.Certmanager().V1().ClusterIssuers()
+		h.clusterIssuerLister = clusterIssuerInformer.Lister()
+		h.mustSync = append(h.mustSync, clusterIssuerInformer.Informer().HasSynced)
+	}
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	// Bearer tokens must not be sent in the clear, so the summary is only
+	// served if the metrics server is configured to serve TLS.
+	if r.TLS == nil {
+		http.Error(w, "the issuer health summary is only served over TLS", http.StatusForbidden)
+		return
+	}
+	if status := h.authorize(r); status != http.StatusOK {
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+
+	for _, synced := range h.mustSync {
+		if !synced() {
+			http.Error(w, "issuer caches have not synced", http.StatusServiceUnavailable)
+			return
+		}
+	}
+
+	issuers, err := h.issuerLister.List(labels.Everything())
+	if err != nil {
+		h.log.Error(err, "failed to list Issuers")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	var clusterIssuers []*cmapi.ClusterIssuer
+	if h.clusterIssuerLister != nil {
+		clusterIssuers, err = h.clusterIssuerLister.List(labels.Everything())
+		if err != nil {
+			h.log.Error(err, "failed to list ClusterIssuers")
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(h.tracker.Summarize(issuers, clusterIssuers)); err != nil {
+		h.log.Error(err, "failed to write issuer health summary")
+	}
+}
+
+// authorize authenticates the request's bearer token and checks that the
+// user may read the summary. It returns the HTTP status to respond with if
+// the request may not be served, or http.StatusOK. The outcome is cached
+// for decisionCacheTTL, unless the reviews could not be created.
+func (h *Handler) authorize(r *http.Request) int {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return http.StatusUnauthorized
+	}
+
+	hash := sha256.Sum256([]byte(token))
+	key := hex.EncodeToString(hash[:])
+	if status, ok := h.decisions.Get(key); ok {
+		return status.(int)
+	}
+
+	status, err := h.review(r.Context(), token)
+	if err != nil {
+		h.log.Error(err, "failed to authorize request")
+		return http.StatusInternalServerError
+	}
+	h.decisions.Add(key, status, decisionCacheTTL)
+	return status
+}
+
+// review authenticates the token using a TokenReview, and checks that the
+// user may read the summary using a SubjectAccessReview.
+func (h *Handler) review(ctx context.Context, token string) (int, error) {
+	review, err := h.client.AuthenticationV1().TokenReviews().Create(ctx, &authnv1.TokenReview{
+		Spec: authnv1.TokenReviewSpec{Token: token},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to create TokenReview: %w", err)
+	}
+	if !review.Status.Authenticated {
+		return http.StatusUnauthorized, nil
+	}
+
+	user := review.Status.User
+	extra := make(map[string]authzv1.ExtraValue)
+	for k, v := range user.Extra {
+		extra[k] = authzv1.ExtraValue(v)
+	}
+	sar, err := h.client.AuthorizationV1().SubjectAccessReviews().Create(ctx, &authzv1.SubjectAccessReview{
+		Spec: authzv1.SubjectAccessReviewSpec{
+			User:   user.Username,
+			Groups: user.Groups,
+			Extra:  extra,
+			UID:    user.UID,
+
+			NonResourceAttributes: &authzv1.NonResourceAttributes{
+				Path: Path,
+				Verb: "get",
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to create SubjectAccessReview: %w", err)
+	}
+	if !sar.Status.Allowed {
+		return http.StatusForbidden, nil
+	}
+	return http.StatusOK, nil
+}
@@ -0,0 +1,239 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuerhealth
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authnv1 "k8s.io/api/authentication/v1"
+	authzv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	coretesting "k8s.io/client-go/testing"
+	"k8s.io/utils/clock"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestHandler(t *testing.T) {
+	const validToken = "valid-token"
+
+	tests := map[string]struct {
+		method        string
+		insecure      bool
+		authorization string
+		allowed       bool
+		namespace     string
+
+		expectedStatus  int
+		expectedIssuers []string
+	}{
+		"reject requests without a bearer token": {
+			expectedStatus: http.StatusUnauthorized,
+		},
+		"reject requests with a token which cannot be authenticated": {
+			authorization:  "Bearer invalid-token",
+			allowed:        true,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		"reject requests from users who may not read the summary": {
+			authorization:  "Bearer " + validToken,
+			allowed:        false,
+			expectedStatus: http.StatusForbidden,
+		},
+		"reject requests which are not made over TLS": {
+			insecure:       true,
+			authorization:  "Bearer " + validToken,
+			allowed:        true,
+			expectedStatus: http.StatusForbidden,
+		},
+		"reject requests which are not GET requests": {
+			method:         http.MethodPost,
+			authorization:  "Bearer " + validToken,
+			allowed:        true,
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+		"report Issuers and ClusterIssuers to authorized users": {
+			authorization:   "Bearer " + validToken,
+			allowed:         true,
+			expectedStatus:  http.StatusOK,
+			expectedIssuers: []string{"ClusterIssuer//cluster-ca", "Issuer/ns/acme", "Issuer/ns/ca"},
+		},
+		"only report Issuers if scoped to a single namespace": {
+			authorization:   "Bearer " + validToken,
+			allowed:         true,
+			namespace:       "ns",
+			expectedStatus:  http.StatusOK,
+			expectedIssuers: []string{"Issuer/ns/acme", "Issuer/ns/ca"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			kubeClient := kubefake.NewSimpleClientset()
+			kubeClient.PrependReactor("create", "tokenreviews", func(action coretesting.Action) (bool, runtime.Object, error) {
+				review := action.(coretesting.CreateAction).GetObject().(*authnv1.TokenReview)
+				if review.Spec.Token == validToken {
+					review.Status = authnv1.TokenReviewStatus{
+						Authenticated: true,
+						User:          authnv1.UserInfo{Username: "platform-team", Groups: []string{"system:authenticated"}},
+					}
+				}
+				return true, review, nil
+			})
+			kubeClient.PrependReactor("create", "subjectaccessreviews", func(action coretesting.Action) (bool, runtime.Object, error) {
+				sar := action.(coretesting.CreateAction).GetObject().(*authzv1.SubjectAccessReview)
+				assert.Equal(t, "platform-team", sar.Spec.User)
+				assert.Equal(t, &authzv1.NonResourceAttributes{Path: Path, Verb: "get"}, sar.Spec.NonResourceAttributes)
+				sar.Status.Allowed = test.allowed
+				return true, sar, nil
+			})
+
+			cmClient := cmfake.NewSimpleClientset(
+				gen.Issuer("ca", gen.SetIssuerNamespace("ns"), gen.SetIssuerCA(cmapi.CAIssuer{})),
+				gen.Issuer("acme", gen.SetIssuerNamespace("ns"), gen.SetIssuerACMEAccountURL("https://acme.example.com/acct/1")),
+				gen.ClusterIssuer("cluster-ca", gen.SetIssuerCA(cmapi.CAIssuer{})),
+			)
+			factory := cminformers.NewSharedInformerFactoryWithOptions(cmClient, 0, cminformers.WithNamespace(test.namespace))
+			tracker := NewTracker(clock.RealClock{})
+			tracker.ObserveFailed("ns", cmmeta.ObjectReference{Name: "ca"})
+			handler := NewHandler(logr.Discard(), tracker, kubeClient, factory, test.namespace)
+
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+			factory.Start(stopCh)
+			factory.WaitForCacheSync(stopCh)
+
+			method := test.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			req := httptest.NewRequest(method, Path, nil)
+			if !test.insecure {
+				req.TLS = &tls.ConnectionState{}
+			}
+			if test.authorization != "" {
+				req.Header.Set("Authorization", test.authorization)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			require.Equal(t, test.expectedStatus, rec.Code, rec.Body.String())
+			if test.expectedStatus != http.StatusOK {
+				return
+			}
+
+			assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+			var summary Summary
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &summary))
+			var issuers []string
+			for _, iss := range summary.Issuers {
+				issuers = append(issuers, iss.Kind+"/"+iss.Namespace+"/"+iss.Name)
+				if iss.Name == "ca" {
+					assert.Equal(t, 1, iss.ConsecutiveFailures)
+				}
+			}
+			assert.Equal(t, test.expectedIssuers, issuers)
+		})
+	}
+}
+
+func TestHandlerCachesNotSynced(t *testing.T) {
+	kubeClient := kubefake.NewSimpleClientset()
+	kubeClient.PrependReactor("create", "tokenreviews", func(action coretesting.Action) (bool, runtime.Object, error) {
+		review := action.(coretesting.CreateAction).GetObject().(*authnv1.TokenReview)
+		review.Status.Authenticated = true
+		return true, review, nil
+	})
+	kubeClient.PrependReactor("create", "subjectaccessreviews", func(action coretesting.Action) (bool, runtime.Object, error) {
+		sar := action.(coretesting.CreateAction).GetObject().(*authzv1.SubjectAccessReview)
+		sar.Status.Allowed = true
+		return true, sar, nil
+	})
+	factory := cminformers.NewSharedInformerFactory(cmfake.NewSimpleClientset(), 0)
+	// The factory is never started, so its caches never sync.
+	handler := NewHandler(logr.Discard(), NewTracker(clock.RealClock{}), kubeClient, factory, "")
+
+	req := httptest.NewRequest(http.MethodGet, Path, nil)
+	req.TLS = &tls.ConnectionState{}
+	req.Header.Set("Authorization", "Bearer token")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
+
+func TestHandlerCachesDecisions(t *testing.T) {
+	var tokenReviews, accessReviews int
+	kubeClient := kubefake.NewSimpleClientset()
+	kubeClient.PrependReactor("create", "tokenreviews", func(action coretesting.Action) (bool, runtime.Object, error) {
+		tokenReviews++
+		review := action.(coretesting.CreateAction).GetObject().(*authnv1.TokenReview)
+		review.Status.Authenticated = review.Spec.Token != "invalid"
+		return true, review, nil
+	})
+	kubeClient.PrependReactor("create", "subjectaccessreviews", func(action coretesting.Action) (bool, runtime.Object, error) {
+		accessReviews++
+		sar := action.(coretesting.CreateAction).GetObject().(*authzv1.SubjectAccessReview)
+		sar.Status.Allowed = true
+		return true, sar, nil
+	})
+	factory := cminformers.NewSharedInformerFactory(cmfake.NewSimpleClientset(), 0)
+	fakeClock := fakeclock.NewFakeClock(time.Now())
+	handler := NewHandler(logr.Discard(), NewTracker(fakeClock), kubeClient, factory, "")
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	factory.Start(stopCh)
+	factory.WaitForCacheSync(stopCh)
+
+	get := func(token string) int {
+		req := httptest.NewRequest(http.MethodGet, Path, nil)
+		req.TLS = &tls.ConnectionState{}
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// Both allowed and rejected tokens are only reviewed once whilst their
+	// outcome is cached.
+	for range 3 {
+		assert.Equal(t, http.StatusOK, get("valid"))
+		assert.Equal(t, http.StatusUnauthorized, get("invalid"))
+	}
+	assert.Equal(t, 2, tokenReviews)
+	assert.Equal(t, 1, accessReviews)
+
+	// They are reviewed again once the outcome has expired.
+	fakeClock.Step(decisionCacheTTL + time.Second)
+	assert.Equal(t, http.StatusOK, get("valid"))
+	assert.Equal(t, 3, tokenReviews)
+	assert.Equal(t, 2, accessReviews)
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package issuerhealth aggregates the health of all Issuers and
+// ClusterIssuers into a single summary, combining their status from the
+// informer caches with counters maintained by the CertificateRequest
+// controllers.
+package issuerhealth
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+// Summary is the health of all Issuers and ClusterIssuers.
+type Summary struct {
+	Issuers []IssuerSummary `json:"issuers"`
+}
+
+// IssuerSummary is the health of a single Issuer or ClusterIssuer.
+type IssuerSummary struct {
+	Kind string `json:"kind"`
+
+	// Namespace is empty for ClusterIssuers.
+	Namespace string `json:"namespace,omitempty"`
+
+	Name string `json:"name"`
+
+	// Type is the type of the issuer, such as `acme` or `ca`. It is empty if
+	// the issuer does not have a type configured.
+	Type string `json:"type,omitempty"`
+
+	// Ready is the status of the issuer's Ready condition, or `Unknown` if
+	// the condition has not been set.
+	Ready        cmmeta.ConditionStatus `json:"ready"`
+	ReadyReason  string                 `json:"readyReason,omitempty"`
+	ReadyMessage string                 `json:"readyMessage,omitempty"`
+
+	// LastSuccessfulIssuanceTime is the time at which a CertificateRequest
+	// was last issued by the issuer since the controller started.
+	LastSuccessfulIssuanceTime *time.Time `json:"lastSuccessfulIssuanceTime,omitempty"`
+
+	// ConsecutiveFailures is the number of CertificateRequests that have
+	// failed since the last successful issuance.
+	ConsecutiveFailures int `json:"consecutiveFailures"`
+
+	// ACMEAccount is only set for ACME issuers.
+	ACMEAccount *ACMEAccountSummary `json:"acmeAccount,omitempty"`
+}
+
+// ACMEAccountSummary is the registration state of an ACME issuer's account.
+type ACMEAccountSummary struct {
+	Registered bool   `json:"registered"`
+	URI        string `json:"uri,omitempty"`
+	Email      string `json:"email,omitempty"`
+}
+
+// issuerKey identifies an Issuer or ClusterIssuer.
+type issuerKey struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+type counters struct {
+	lastSuccessfulIssuance time.Time
+	consecutiveFailures    int
+}
+
+// Tracker counts the outcomes of CertificateRequests for each issuer. It is
+// safe for concurrent use, and all of its methods may be called on a nil
+// Tracker, in which case nothing is recorded.
+type Tracker struct {
+	clock clock.Clock
+
+	lock     sync.Mutex
+	counters map[issuerKey]*counters
+}
+
+// NewTracker returns a Tracker which uses the given clock to record the time
+// of successful issuances.
+func NewTracker(c clock.Clock) *Tracker {
+	return &Tracker{
+		clock:    c,
+		counters: make(map[issuerKey]*counters),
+	}
+}
+
+// ObserveIssued records that a CertificateRequest in the given namespace has
+// been issued by the referenced issuer.
+func (t *Tracker) ObserveIssued(namespace string, ref cmmeta.ObjectReference) {
+	if t == nil {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	c := t.countersFor(keyForRef(namespace, ref))
+	c.lastSuccessfulIssuance = t.clock.Now()
+	c.consecutiveFailures = 0
+}
+
+// ObserveFailed records that a CertificateRequest in the given namespace has
+// failed to be issued by the referenced issuer.
+func (t *Tracker) ObserveFailed(namespace string, ref cmmeta.ObjectReference) {
+	if t == nil {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.countersFor(keyForRef(namespace, ref)).consecutiveFailures++
+}
+
+func (t *Tracker) countersFor(key issuerKey) *counters {
+	c, ok := t.counters[key]
+	if !ok {
+		c = &counters{}
+		t.counters[key] = c
+	}
+	return c
+}
+
+func keyForRef(namespace string, ref cmmeta.ObjectReference) issuerKey {
+	kind := apiutil.IssuerKind(ref)
+	if kind == cmapi.ClusterIssuerKind {
+		namespace = ""
+	}
+	return issuerKey{kind: kind, namespace: namespace, name: ref.Name}
+}
+
+// Summarize returns the health of the given Issuers and ClusterIssuers,
+// sorted by kind, namespace and name. Counters recorded for issuers which
+// are not given are not reported.
+func (t *Tracker) Summarize(issuers []*cmapi.Issuer, clusterIssuers []*cmapi.ClusterIssuer) Summary {
+	generic := make([]cmapi.GenericIssuer, 0, len(issuers)+len(clusterIssuers))
+	for _, iss := range issuers {
+		generic = append(generic, iss)
+	}
+	for _, iss := range clusterIssuers {
+		generic = append(generic, iss)
+	}
+
+	summary := Summary{Issuers: make([]IssuerSummary, 0, len(generic))}
+	for _, iss := range generic {
+		summary.Issuers = append(summary.Issuers, t.summarizeIssuer(iss))
+	}
+	sort.Slice(summary.Issuers, func(i, j int) bool {
+		a, b := summary.Issuers[i], summary.Issuers[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	return summary
+}
+
+func (t *Tracker) summarizeIssuer(iss cmapi.GenericIssuer) IssuerSummary {
+	kind := cmapi.IssuerKind
+	if _, ok := iss.(*cmapi.ClusterIssuer); ok {
+		kind = cmapi.ClusterIssuerKind
+	}
+	s := IssuerSummary{
+		Kind:      kind,
+		Namespace: iss.GetNamespace(),
+		Name:      iss.GetName(),
+		Ready:     cmmeta.ConditionUnknown,
+	}
+	if issuerType, err := apiutil.NameForIssuer(iss); err == nil {
+		s.Type = issuerType
+	}
+	for _, cond := range iss.GetStatus().Conditions {
+		if cond.Type == cmapi.IssuerConditionReady {
+			s.Ready = cond.Status
+			s.ReadyReason = cond.Reason
+			s.ReadyMessage = cond.Message
+		}
+	}
+	if iss.GetSpec().ACME != nil {
+		s.ACMEAccount = &ACMEAccountSummary{}
+		if status := iss.GetStatus().ACME; status != nil {
+			s.ACMEAccount.Registered = status.URI != ""
+			s.ACMEAccount.URI = status.URI
+			s.ACMEAccount.Email = status.LastRegisteredEmail
+		}
+	}
+
+	if t == nil {
+		return s
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if c, ok := t.counters[issuerKey{kind: kind, namespace: s.Namespace, name: s.Name}]; ok {
+		if !c.lastSuccessfulIssuance.IsZero() {
+			lastSuccessfulIssuance := c.lastSuccessfulIssuance
+			s.LastSuccessfulIssuanceTime = &lastSuccessfulIssuance
+		}
+		s.ConsecutiveFailures = c.consecutiveFailures
+	}
+	return s
+}
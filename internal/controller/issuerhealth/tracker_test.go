@@ -0,0 +1,165 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuerhealth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestSummarize(t *testing.T) {
+	fixedNow := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	readyCondition := func(status cmmeta.ConditionStatus, reason, message string) gen.IssuerModifier {
+		return gen.AddIssuerCondition(cmapi.IssuerCondition{
+			Type:    cmapi.IssuerConditionReady,
+			Status:  status,
+			Reason:  reason,
+			Message: message,
+		})
+	}
+
+	issuers := []*cmapi.Issuer{
+		gen.Issuer("vault",
+			gen.SetIssuerNamespace("ns-b"),
+			gen.SetIssuerVault(cmapi.VaultIssuer{}),
+			readyCondition(cmmeta.ConditionFalse, "VaultError", "Failed to initialize Vault client"),
+		),
+		gen.Issuer("acme",
+			gen.SetIssuerNamespace("ns-a"),
+			gen.SetIssuerACME(cmacme.ACMEIssuer{}),
+			gen.SetIssuerACMEAccountURL("https://acme.example.com/acct/1"),
+			gen.SetIssuerACMELastRegisteredEmail("admin@example.com"),
+			readyCondition(cmmeta.ConditionTrue, "ACMEAccountRegistered", "The ACME account was registered with the ACME server"),
+		),
+		gen.Issuer("ca",
+			gen.SetIssuerNamespace("ns-a"),
+			gen.SetIssuerCA(cmapi.CAIssuer{}),
+			readyCondition(cmmeta.ConditionTrue, "KeyPairVerified", "Signing CA verified"),
+		),
+		// An issuer without a type or Ready condition.
+		gen.Issuer("unconfigured",
+			gen.SetIssuerNamespace("ns-a"),
+		),
+	}
+	clusterIssuers := []*cmapi.ClusterIssuer{
+		gen.ClusterIssuer("selfsigned",
+			gen.SetIssuerSelfSigned(cmapi.SelfSignedIssuer{}),
+			readyCondition(cmmeta.ConditionTrue, "IsReady", ""),
+		),
+		gen.ClusterIssuer("acme",
+			gen.SetIssuerACME(cmacme.ACMEIssuer{}),
+			readyCondition(cmmeta.ConditionFalse, "ErrRegisterACMEAccount", "Failed to register ACME account"),
+		),
+	}
+
+	tracker := NewTracker(fakeclock.NewFakeClock(fixedNow))
+	// The namespaced "acme" Issuer has recovered from a failure.
+	tracker.ObserveFailed("ns-a", cmmeta.ObjectReference{Name: "acme"})
+	tracker.ObserveIssued("ns-a", cmmeta.ObjectReference{Name: "acme", Kind: cmapi.IssuerKind})
+	// The Vault Issuer has never issued a certificate.
+	tracker.ObserveFailed("ns-b", cmmeta.ObjectReference{Name: "vault"})
+	tracker.ObserveFailed("ns-b", cmmeta.ObjectReference{Name: "vault"})
+	// The "acme" ClusterIssuer is referenced from multiple namespaces, and
+	// must not be confused with the "acme" Issuer.
+	tracker.ObserveFailed("ns-a", cmmeta.ObjectReference{Name: "acme", Kind: cmapi.ClusterIssuerKind})
+	tracker.ObserveFailed("ns-b", cmmeta.ObjectReference{Name: "acme", Kind: cmapi.ClusterIssuerKind})
+	tracker.ObserveFailed("ns-c", cmmeta.ObjectReference{Name: "acme", Kind: cmapi.ClusterIssuerKind})
+	// Counters for issuers which do not exist are not reported.
+	tracker.ObserveFailed("ns-a", cmmeta.ObjectReference{Name: "deleted"})
+
+	assert.Equal(t, Summary{Issuers: []IssuerSummary{
+		{
+			Kind:                cmapi.ClusterIssuerKind,
+			Name:                "acme",
+			Type:                "acme",
+			Ready:               cmmeta.ConditionFalse,
+			ReadyReason:         "ErrRegisterACMEAccount",
+			ReadyMessage:        "Failed to register ACME account",
+			ConsecutiveFailures: 3,
+			ACMEAccount:         &ACMEAccountSummary{Registered: false},
+		},
+		{
+			Kind:        cmapi.ClusterIssuerKind,
+			Name:        "selfsigned",
+			Type:        "selfsigned",
+			Ready:       cmmeta.ConditionTrue,
+			ReadyReason: "IsReady",
+		},
+		{
+			Kind:                       cmapi.IssuerKind,
+			Namespace:                  "ns-a",
+			Name:                       "acme",
+			Type:                       "acme",
+			Ready:                      cmmeta.ConditionTrue,
+			ReadyReason:                "ACMEAccountRegistered",
+			ReadyMessage:               "The ACME account was registered with the ACME server",
+			LastSuccessfulIssuanceTime: &fixedNow,
+			ConsecutiveFailures:        0,
+			ACMEAccount: &ACMEAccountSummary{
+				Registered: true,
+				URI:        "https://acme.example.com/acct/1",
+				Email:      "admin@example.com",
+			},
+		},
+		{
+			Kind:         cmapi.IssuerKind,
+			Namespace:    "ns-a",
+			Name:         "ca",
+			Type:         "ca",
+			Ready:        cmmeta.ConditionTrue,
+			ReadyReason:  "KeyPairVerified",
+			ReadyMessage: "Signing CA verified",
+		},
+		{
+			Kind:      cmapi.IssuerKind,
+			Namespace: "ns-a",
+			Name:      "unconfigured",
+			Ready:     cmmeta.ConditionUnknown,
+		},
+		{
+			Kind:                cmapi.IssuerKind,
+			Namespace:           "ns-b",
+			Name:                "vault",
+			Type:                "vault",
+			Ready:               cmmeta.ConditionFalse,
+			ReadyReason:         "VaultError",
+			ReadyMessage:        "Failed to initialize Vault client",
+			ConsecutiveFailures: 2,
+		},
+	}}, tracker.Summarize(issuers, clusterIssuers))
+}
+
+func TestNilTracker(t *testing.T) {
+	var tracker *Tracker
+	tracker.ObserveIssued("ns", cmmeta.ObjectReference{Name: "ca"})
+	tracker.ObserveFailed("ns", cmmeta.ObjectReference{Name: "ca"})
+
+	summary := tracker.Summarize([]*cmapi.Issuer{
+		gen.Issuer("ca", gen.SetIssuerNamespace("ns"), gen.SetIssuerCA(cmapi.CAIssuer{})),
+	}, nil)
+	assert.Equal(t, Summary{Issuers: []IssuerSummary{
+		{Kind: cmapi.IssuerKind, Namespace: "ns", Name: "ca", Type: "ca", Ready: cmmeta.ConditionUnknown},
+	}}, summary)
+}
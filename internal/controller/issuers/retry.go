@@ -0,0 +1,128 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	acmeapi "golang.org/x/crypto/acme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+)
+
+// RetryPendingRequested returns the updated issuer if the update of an Issuer
+// or ClusterIssuer from oldObj to newObj means that its pending Orders and
+// Challenges should be retried immediately, bypassing their backoff. That is
+// the case if the ACME server has become available again after an outage, or
+// if the retry-pending annotation has been set to a new value.
+func RetryPendingRequested(oldObj, newObj interface{}) (cmapi.GenericIssuer, bool) {
+	oldIssuer, oldOK := oldObj.(cmapi.GenericIssuer)
+	newIssuer, newOK := newObj.(cmapi.GenericIssuer)
+	if !oldOK || !newOK || newIssuer.GetSpec().ACME == nil {
+		return nil, false
+	}
+
+	if ACMEServerUnavailable(oldIssuer) && !ACMEServerUnavailable(newIssuer) {
+		return newIssuer, true
+	}
+
+	retryPending := newIssuer.GetObjectMeta().Annotations[cmacme.RetryPendingAnnotationKey]
+	if retryPending != "" && retryPending != oldIssuer.GetObjectMeta().Annotations[cmacme.RetryPendingAnnotationKey] {
+		return newIssuer, true
+	}
+
+	return nil, false
+}
+
+// ACMEServerProbeInterval is the interval at which an ACME issuer is re-synced
+// while its ACME server is unavailable, so that the recovery of the server is
+// noticed soon and pending Orders and Challenges are retried.
+const ACMEServerProbeInterval = 30 * time.Second
+
+const (
+	reasonACMEServerUnavailable  = "ACMEServerUnavailable"
+	messageACMEServerUnavailable = "The ACME server failed to respond to a request for an Order or Challenge, pending Orders and Challenges are retried once it responds: "
+)
+
+// ACMEServerUnavailable returns true if the issuer has the
+// ACMEServerUnavailable condition.
+func ACMEServerUnavailable(iss cmapi.GenericIssuer) bool {
+	return apiutil.IssuerHasCondition(iss, cmapi.IssuerCondition{
+		Type:   cmapi.IssuerConditionACMEServerUnavailable,
+		Status: cmmeta.ConditionTrue,
+	})
+}
+
+// IsACMEServerUnavailableError returns true if err was returned because the
+// ACME server responded with a 5xx status code, or could not be reached.
+func IsACMEServerUnavailableError(err error) bool {
+	var acmeErr *acmeapi.Error
+	if errors.As(err, &acmeErr) {
+		return acmeErr.StatusCode >= 500
+	}
+	var opErr *net.OpError
+	var dnsErr *net.DNSError
+	return errors.As(err, &opErr) || errors.As(err, &dnsErr)
+}
+
+// MarkACMEServerUnavailable sets the ACMEServerUnavailable condition on an
+// ACME issuer after its ACME server failed to respond to a request made for one
+// of its Orders or Challenges. The issuers controllers then probe the ACME
+// server every ACMEServerProbeInterval until it responds again, at which point
+// the condition is removed.
+func MarkACMEServerUnavailable(ctx context.Context, cl cmclient.Interface, fieldManager string, iss cmapi.GenericIssuer, cause error) error {
+	if iss.GetSpec().ACME == nil || ACMEServerUnavailable(iss) {
+		return nil
+	}
+
+	iss = iss.DeepCopyObject().(cmapi.GenericIssuer)
+	apiutil.SetIssuerCondition(iss,
+		iss.GetGeneration(),
+		cmapi.IssuerConditionACMEServerUnavailable,
+		cmmeta.ConditionTrue,
+		reasonACMEServerUnavailable,
+		messageACMEServerUnavailable+cause.Error())
+
+	ssa := utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply)
+	switch iss := iss.(type) {
+	case *cmapi.Issuer:
+		if ssa {
+			return ApplyIssuerStatus(ctx, cl, fieldManager, iss)
+		}
+		_, err := cl.CertmanagerV1().Issuers(iss.Namespace).UpdateStatus(ctx, iss, metav1.UpdateOptions{})
+		return err
+	case *cmapi.ClusterIssuer:
+		if ssa {
+			return ApplyClusterIssuerStatus(ctx, cl, fieldManager, iss)
+		}
+		_, err := cl.CertmanagerV1().ClusterIssuers().UpdateStatus(ctx, iss, metav1.UpdateOptions{})
+		return err
+	default:
+		return fmt.Errorf("unsupported issuer type %T", iss)
+	}
+}
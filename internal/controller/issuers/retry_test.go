@@ -0,0 +1,66 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuers
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	acmeapi "golang.org/x/crypto/acme"
+)
+
+func TestIsACMEServerUnavailableError(t *testing.T) {
+	tests := map[string]struct {
+		err  error
+		want bool
+	}{
+		"no error": {
+			err: nil,
+		},
+		"5xx response": {
+			err:  &acmeapi.Error{StatusCode: http.StatusServiceUnavailable},
+			want: true,
+		},
+		"wrapped 5xx response": {
+			err:  fmt.Errorf("failed to get order: %w", &acmeapi.Error{StatusCode: http.StatusBadGateway}),
+			want: true,
+		},
+		"4xx response": {
+			err: &acmeapi.Error{StatusCode: http.StatusTooManyRequests},
+		},
+		"connection refused": {
+			err:  &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")},
+			want: true,
+		},
+		"DNS lookup failure": {
+			err:  fmt.Errorf("request failed: %w", &net.DNSError{Err: "no such host", Name: "acme.example.com"}),
+			want: true,
+		},
+		"other error": {
+			err: errors.New("order not found"),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.want, IsACMEServerUnavailableError(test.err))
+		})
+	}
+}
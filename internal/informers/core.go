@@ -19,6 +19,7 @@ package informers
 import (
 	corev1 "k8s.io/api/core/v1"
 	certificatesv1 "k8s.io/client-go/informers/certificates/v1"
+	corev1informers "k8s.io/client-go/informers/core/v1"
 	networkingv1informers "k8s.io/client-go/informers/networking/v1"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
@@ -46,6 +47,7 @@ type KubeInformerFactory interface {
 	WaitForCacheSync(<-chan struct{}) map[string]bool
 	Ingresses() networkingv1informers.IngressInformer
 	Secrets() SecretInformer
+	ConfigMaps() corev1informers.ConfigMapInformer
 	CertificateSigningRequests() certificatesv1.CertificateSigningRequestInformer
 }
 
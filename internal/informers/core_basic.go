@@ -20,6 +20,7 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kubeinformers "k8s.io/client-go/informers"
 	certificatesv1 "k8s.io/client-go/informers/certificates/v1"
 	corev1informers "k8s.io/client-go/informers/core/v1"
@@ -39,15 +40,22 @@ type baseFactory struct {
 	// namespace is set if cert-manager controller is scoped to a single
 	// namespace
 	namespace string
+	// tweakListOptions is applied to the list and watch requests of the
+	// Secrets informer, which is not created by the factory
+	tweakListOptions func(*metav1.ListOptions)
 }
 
-func NewBaseKubeInformerFactory(client kubernetes.Interface, resync time.Duration, namespace string) KubeInformerFactory {
+// NewBaseKubeInformerFactory returns a KubeInformerFactory for the given
+// namespace. tweakListOptions, if set, is applied to the list and watch
+// requests of all informers, e.g. to paginate their lists.
+func NewBaseKubeInformerFactory(client kubernetes.Interface, resync time.Duration, namespace string, tweakListOptions func(*metav1.ListOptions)) KubeInformerFactory {
 	return &baseFactory{
-		f: kubeinformers.NewSharedInformerFactoryWithOptions(client, resync, kubeinformers.WithNamespace(namespace)),
+		f: kubeinformers.NewSharedInformerFactoryWithOptions(client, resync, kubeinformers.WithNamespace(namespace), kubeinformers.WithTweakListOptions(tweakListOptions)),
 		// namespace is set to a non-empty value if cert-manager
 		// controller is scoped to a single namespace via --namespace
 		// flag
-		namespace: namespace,
+		namespace:        namespace,
+		tweakListOptions: tweakListOptions,
 	}
 }
 
@@ -70,11 +78,16 @@ func (bf *baseFactory) Ingresses() networkingv1informers.IngressInformer {
 
 func (bf *baseFactory) Secrets() SecretInformer {
 	return &baseSecretInformer{
-		f:         bf.f,
-		namespace: bf.namespace,
+		f:                bf.f,
+		namespace:        bf.namespace,
+		tweakListOptions: bf.tweakListOptions,
 	}
 }
 
+func (bf *baseFactory) ConfigMaps() corev1informers.ConfigMapInformer {
+	return bf.f.Core().V1().ConfigMaps()
+}
+
 func (bf *baseFactory) CertificateSigningRequests() certificatesv1.CertificateSigningRequestInformer {
 	return bf.f.Certificates().V1().CertificateSigningRequests()
 }
@@ -84,9 +97,10 @@ var _ SecretInformer = &baseSecretInformer{}
 // baseSecretInformer is an implementation of SecretInformer that only uses
 // upstream client-go functionality
 type baseSecretInformer struct {
-	f         kubeinformers.SharedInformerFactory
-	informer  cache.SharedIndexInformer
-	namespace string
+	f                kubeinformers.SharedInformerFactory
+	informer         cache.SharedIndexInformer
+	namespace        string
+	tweakListOptions func(*metav1.ListOptions)
 }
 
 func (bsi *baseSecretInformer) Informer() Informer {
@@ -99,5 +113,5 @@ func (bsi *baseSecretInformer) Lister() SecretLister {
 }
 
 func (bsi *baseSecretInformer) new(client kubernetes.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
-	return corev1informers.NewSecretInformer(client, bsi.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	return corev1informers.NewFilteredSecretInformer(client, bsi.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, bsi.tweakListOptions)
 }
@@ -71,21 +71,26 @@ type filteredSecretsFactory struct {
 	metadataInformerFactory metadatainformer.SharedInformerFactory
 	client                  kubernetes.Interface
 	namespace               string
+	tweakListOptions        func(*metav1.ListOptions)
 	ctx                     context.Context
 }
 
-func NewFilteredSecretsKubeInformerFactory(ctx context.Context, typedClient kubernetes.Interface, metadataClient metadata.Interface, resync time.Duration, namespace string) KubeInformerFactory {
+// NewFilteredSecretsKubeInformerFactory returns a KubeInformerFactory which
+// only caches Secrets labelled as part of cert-manager in full.
+// tweakListOptions, if set, is applied to the list and watch requests of all
+// informers, e.g. to paginate their lists.
+func NewFilteredSecretsKubeInformerFactory(ctx context.Context, typedClient kubernetes.Interface, metadataClient metadata.Interface, resync time.Duration, namespace string, tweakListOptions func(*metav1.ListOptions)) KubeInformerFactory {
 	return &filteredSecretsFactory{
-		typedInformerFactory: kubeinformers.NewSharedInformerFactoryWithOptions(typedClient, resync, kubeinformers.WithNamespace(namespace)),
-		metadataInformerFactory: metadatainformer.NewFilteredSharedInformerFactory(metadataClient, resync, namespace, func(listOptions *metav1.ListOptions) {
+		typedInformerFactory: kubeinformers.NewSharedInformerFactoryWithOptions(typedClient, resync, kubeinformers.WithNamespace(namespace), kubeinformers.WithTweakListOptions(tweakListOptions)),
+		metadataInformerFactory: metadatainformer.NewFilteredSharedInformerFactory(metadataClient, resync, namespace, withListOptions(tweakListOptions, func(listOptions *metav1.ListOptions) {
 			listOptions.LabelSelector = isNotCertManagerSecretLabelSelector.String()
-
-		}),
+		})),
 		// namespace is set to a non-empty value if cert-manager
 		// controller is scoped to a single namespace via --namespace
 		// flag
-		namespace: namespace,
-		client:    typedClient,
+		namespace:        namespace,
+		client:           typedClient,
+		tweakListOptions: tweakListOptions,
 		// Go recommends to not store context in
 		// structs, but here we have no other way as we need to use root context inside
 		// Get whose signature is defined upstream and does not accept context
@@ -119,15 +124,19 @@ func (bf *filteredSecretsFactory) Ingresses() networkingv1informers.IngressInfor
 	return bf.typedInformerFactory.Networking().V1().Ingresses()
 }
 
+func (bf *filteredSecretsFactory) ConfigMaps() corev1informers.ConfigMapInformer {
+	return bf.typedInformerFactory.Core().V1().ConfigMaps()
+}
+
 func (bf *filteredSecretsFactory) CertificateSigningRequests() certificatesv1.CertificateSigningRequestInformer {
 	return bf.typedInformerFactory.Certificates().V1().CertificateSigningRequests()
 }
 
 func (bf *filteredSecretsFactory) Secrets() SecretInformer {
 	f := func(client kubernetes.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
-		return corev1informers.NewFilteredSecretInformer(client, bf.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, func(listOptions *metav1.ListOptions) {
+		return corev1informers.NewFilteredSecretInformer(client, bf.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, withListOptions(bf.tweakListOptions, func(listOptions *metav1.ListOptions) {
 			listOptions.LabelSelector = isCertManageSecretLabelSelector.String()
-		})
+		}))
 	}
 	return &filteredSecretInformer{
 		typedInformerFactory:    bf.typedInformerFactory,
@@ -0,0 +1,61 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package informers
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ChunkedListOptions returns a function which makes the LIST calls of
+// informers fetch their objects in chunks of at most chunkSize objects, using
+// the limit and continue parameters. It returns nil if chunkSize is not
+// positive, in which case the informers' default behaviour is kept.
+//
+// Informers request their initial list with resourceVersion=0, for which the
+// API server ignores the limit and returns every object from its watch cache
+// at once. For such lists the resourceVersion is cleared so that the API
+// server pages through the objects instead.
+//
+// Only requests for which the informer's pager has already set a limit are
+// changed. Watch requests, and the full lists an informer falls back to if a
+// paginated list expires, never set a limit, so the steady state watch
+// behaviour is unchanged.
+func ChunkedListOptions(chunkSize int64) func(*metav1.ListOptions) {
+	if chunkSize <= 0 {
+		return nil
+	}
+	return func(opts *metav1.ListOptions) {
+		if opts.Limit == 0 {
+			return
+		}
+		opts.Limit = chunkSize
+		if opts.ResourceVersion == "0" {
+			opts.ResourceVersion = ""
+		}
+	}
+}
+
+// withListOptions returns a function which applies both the given tweak and
+// tweakListOptions, if it is set.
+func withListOptions(tweakListOptions func(*metav1.ListOptions), tweak func(*metav1.ListOptions)) func(*metav1.ListOptions) {
+	return func(opts *metav1.ListOptions) {
+		tweak(opts)
+		if tweakListOptions != nil {
+			tweakListOptions(opts)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package informers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+func TestChunkedListOptions(t *testing.T) {
+	tests := map[string]struct {
+		chunkSize int64
+		options   metav1.ListOptions
+		expected  metav1.ListOptions
+	}{
+		"the initial list is paginated instead of being served from the watch cache": {
+			chunkSize: 100,
+			options:   metav1.ListOptions{ResourceVersion: "0", Limit: 500},
+			expected:  metav1.ListOptions{Limit: 100},
+		},
+		"the next page keeps its continue token": {
+			chunkSize: 100,
+			options:   metav1.ListOptions{Continue: "token", Limit: 500},
+			expected:  metav1.ListOptions{Continue: "token", Limit: 100},
+		},
+		"a paginated relist keeps its resource version": {
+			chunkSize: 100,
+			options:   metav1.ListOptions{ResourceVersion: "10", Limit: 500},
+			expected:  metav1.ListOptions{ResourceVersion: "10", Limit: 100},
+		},
+		"requests without a limit, such as watches, are not changed": {
+			chunkSize: 100,
+			options:   metav1.ListOptions{ResourceVersion: "0", AllowWatchBookmarks: true},
+			expected:  metav1.ListOptions{ResourceVersion: "0", AllowWatchBookmarks: true},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			opts := test.options
+			ChunkedListOptions(test.chunkSize)(&opts)
+			assert.Equal(t, test.expected, opts)
+		})
+	}
+
+	assert.Nil(t, ChunkedListOptions(0))
+}
+
+// fakeSecretsAPIServer serves paginated lists of Secrets, and watches which
+// never send any events.
+type fakeSecretsAPIServer struct {
+	secrets []corev1.Secret
+	done    chan struct{}
+
+	lock    sync.Mutex
+	lists   []url.Values
+	watches []url.Values
+}
+
+func (s *fakeSecretsAPIServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/api/v1/secrets" {
+		http.NotFound(w, r)
+		return
+	}
+	query := r.URL.Query()
+	w.Header().Set("Content-Type", "application/json")
+
+	if query.Get("watch") == "true" {
+		s.lock.Lock()
+		s.watches = append(s.watches, query)
+		s.lock.Unlock()
+
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		select {
+		case <-r.Context().Done():
+		case <-s.done:
+		}
+		return
+	}
+
+	s.lock.Lock()
+	s.lists = append(s.lists, query)
+	s.lock.Unlock()
+
+	start := 0
+	if c := query.Get("continue"); c != "" {
+		start, _ = strconv.Atoi(c)
+	}
+	end := len(s.secrets)
+	if limit, _ := strconv.Atoi(query.Get("limit")); limit > 0 && start+limit < end {
+		end = start + limit
+	}
+	list := corev1.SecretList{
+		TypeMeta: metav1.TypeMeta{Kind: "SecretList", APIVersion: "v1"},
+		ListMeta: metav1.ListMeta{ResourceVersion: "10"},
+		Items:    s.secrets[start:end],
+	}
+	if end < len(s.secrets) {
+		list.Continue = strconv.Itoa(end)
+	}
+	if err := json.NewEncoder(w).Encode(list); err != nil {
+		panic(err)
+	}
+}
+
+func TestChunkedListOptionsInformerSync(t *testing.T) {
+	server := &fakeSecretsAPIServer{done: make(chan struct{})}
+	for i := 0; i < 5; i++ {
+		server.secrets = append(server.secrets, corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: fmt.Sprintf("secret-%d", i), ResourceVersion: "10"},
+		})
+	}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+	defer close(server.done)
+
+	client, err := kubernetes.NewForConfig(&rest.Config{Host: httpServer.URL})
+	require.NoError(t, err)
+
+	factory := NewBaseKubeInformerFactory(client, 0, "", ChunkedListOptions(2))
+	secrets := factory.Secrets()
+	secrets.Informer()
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	factory.Start(stopCh)
+
+	waitCh := make(chan struct{})
+	timeout := time.AfterFunc(wait.ForeverTestTimeout, func() { close(waitCh) })
+	defer timeout.Stop()
+	for _, synced := range factory.WaitForCacheSync(waitCh) {
+		require.True(t, synced, "informer caches did not sync")
+	}
+
+	cached, err := secrets.Lister().Secrets("ns").List(labels.Everything())
+	require.NoError(t, err)
+	assert.Len(t, cached, 5)
+
+	server.lock.Lock()
+	defer server.lock.Unlock()
+	require.Len(t, server.lists, 3)
+	for i, expectedContinue := range []string{"", "2", "4"} {
+		assert.Equal(t, "2", server.lists[i].Get("limit"), "list %d", i)
+		assert.Equal(t, expectedContinue, server.lists[i].Get("continue"), "list %d", i)
+		assert.Empty(t, server.lists[i].Get("resourceVersion"), "list %d", i)
+	}
+	// The watch is unchanged by the chunked list options.
+	for _, watch := range server.watches {
+		assert.Empty(t, watch.Get("limit"))
+		assert.Equal(t, "10", watch.Get("resourceVersion"))
+	}
+}
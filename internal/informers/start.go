@@ -0,0 +1,95 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package informers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+// Factory is the subset of the methods of an informer factory used to start
+// its informers and wait for their caches to sync.
+type Factory[K comparable] interface {
+	Start(stopCh <-chan struct{})
+	WaitForCacheSync(stopCh <-chan struct{}) map[K]bool
+}
+
+// Startable is an informer factory which can be started by StartStaggered.
+type Startable struct {
+	// Name of the factory, used in log messages.
+	Name string
+
+	start            func(stopCh <-chan struct{})
+	waitForCacheSync func(stopCh <-chan struct{}) (synced bool)
+}
+
+// NewStartable returns a Startable for the given informer factory.
+func NewStartable[K comparable](name string, f Factory[K]) Startable {
+	return Startable{
+		Name:  name,
+		start: f.Start,
+		waitForCacheSync: func(stopCh <-chan struct{}) bool {
+			for _, synced := range f.WaitForCacheSync(stopCh) {
+				if !synced {
+					return false
+				}
+			}
+			return true
+		},
+	}
+}
+
+// StartStaggered starts the informers of the given factories until ctx is
+// done.
+//
+// If budget is zero, all the factories are started at once. Otherwise the
+// factories are started one after the other: the next factory is started once
+// the caches of the previous one have synced, or after the previous factory
+// has been given budget to sync its caches. This spreads the initial LIST
+// calls of the informers over time rather than sending them to the API server
+// simultaneously. The informers of a factory which did not sync within budget
+// keep syncing in the background.
+func StartStaggered(ctx context.Context, budget time.Duration, factories ...Startable) {
+	log := logf.FromContext(ctx)
+
+	for _, f := range factories {
+		f.start(ctx.Done())
+		if budget <= 0 {
+			continue
+		}
+		waitForCacheSync(ctx, log, budget, f)
+	}
+}
+
+func waitForCacheSync(ctx context.Context, log logr.Logger, budget time.Duration, f Startable) {
+	budgetCtx, cancel := context.WithTimeout(ctx, budget)
+	defer cancel()
+
+	start := time.Now()
+	if f.waitForCacheSync(budgetCtx.Done()) {
+		log.V(logf.DebugLevel).Info("informer caches synced", "factory", f.Name, "duration", time.Since(start))
+		return
+	}
+	if ctx.Err() != nil {
+		return
+	}
+	log.V(logf.InfoLevel).Info("informer caches did not sync within the startup budget, starting the next informers", "factory", f.Name, "budget", budget)
+}
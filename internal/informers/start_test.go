@@ -0,0 +1,126 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package informers
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeFactory records when it is started, and reports its caches as synced
+// once it has been running for syncAfter, or never if syncAfter is negative.
+type fakeFactory struct {
+	name      string
+	syncAfter time.Duration
+	events    *[]string
+	lock      *sync.Mutex
+}
+
+func (f *fakeFactory) Start(<-chan struct{}) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	*f.events = append(*f.events, "start "+f.name)
+}
+
+func (f *fakeFactory) WaitForCacheSync(stopCh <-chan struct{}) map[string]bool {
+	synced := false
+	if f.syncAfter >= 0 {
+		select {
+		case <-time.After(f.syncAfter):
+			synced = true
+		case <-stopCh:
+		}
+	} else {
+		<-stopCh
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if synced {
+		*f.events = append(*f.events, "synced "+f.name)
+	}
+	return map[string]bool{f.name: synced}
+}
+
+func TestStartStaggered(t *testing.T) {
+	tests := map[string]struct {
+		budget    time.Duration
+		syncAfter map[string]time.Duration
+
+		expectedEvents []string
+	}{
+		"start all factories at once without a budget": {
+			syncAfter:      map[string]time.Duration{"a": -1, "b": -1},
+			expectedEvents: []string{"start a", "start b"},
+		},
+		"start each factory once the previous one has synced": {
+			budget:         time.Minute,
+			syncAfter:      map[string]time.Duration{"a": 0, "b": 0},
+			expectedEvents: []string{"start a", "synced a", "start b", "synced b"},
+		},
+		"start the next factory once the budget of a factory which does not sync is spent": {
+			budget:         10 * time.Millisecond,
+			syncAfter:      map[string]time.Duration{"a": -1, "b": 0},
+			expectedEvents: []string{"start a", "start b", "synced b"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var (
+				events []string
+				lock   sync.Mutex
+			)
+			var factories []Startable
+			for _, name := range []string{"a", "b"} {
+				factories = append(factories, NewStartable[string](name, &fakeFactory{
+					name:      name,
+					syncAfter: test.syncAfter[name],
+					events:    &events,
+					lock:      &lock,
+				}))
+			}
+
+			StartStaggered(context.Background(), test.budget, factories...)
+
+			lock.Lock()
+			defer lock.Unlock()
+			assert.Equal(t, test.expectedEvents, events)
+		})
+	}
+}
+
+func TestStartStaggeredStopsWaitingWhenContextIsDone(t *testing.T) {
+	var (
+		events []string
+		lock   sync.Mutex
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	StartStaggered(ctx, time.Hour,
+		NewStartable[string]("a", &fakeFactory{name: "a", syncAfter: -1, events: &events, lock: &lock}),
+		NewStartable[string]("b", &fakeFactory{name: "b", syncAfter: -1, events: &events, lock: &lock}),
+	)
+
+	lock.Lock()
+	defer lock.Unlock()
+	assert.Equal(t, []string{"start a", "start b"}, events)
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tlsclient builds the TLS configuration used by the controller for
+// outbound connections, e.g. to ACME servers, Vault or Venafi.
+// Controller-wide defaults are set once using SetDefaults, and can be
+// overridden per issuer.
+package tlsclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	ciphers "k8s.io/component-base/cli/flag"
+
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+// Options are the TLS settings applied to outbound connections.
+type Options struct {
+	// MinTLSVersion is the name of the minimum TLS version, e.g.
+	// `VersionTLS13`. If empty, the Go default is used.
+	MinTLSVersion string
+
+	// CipherSuites are the names of the TLS 1.2 cipher suites which may be
+	// negotiated. If empty, the Go defaults are used.
+	// The TLS 1.3 cipher suites are not configurable.
+	CipherSuites []string
+}
+
+var (
+	defaultsLock sync.RWMutex
+	defaults     Options
+)
+
+// SetDefaults validates and sets the controller-wide default Options.
+// The defaults are also applied to http.DefaultTransport, so that clients
+// which are not built by cert-manager itself (such as those of most DNS01
+// provider SDKs) use them too.
+func SetDefaults(opts Options) error {
+	transport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return errors.New("http.DefaultTransport is not an *http.Transport")
+	}
+
+	tlsConfig := transport.TLSClientConfig.Clone()
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{}
+	}
+	if err := opts.Apply(tlsConfig); err != nil {
+		return err
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	defaultsLock.Lock()
+	defer defaultsLock.Unlock()
+	defaults = opts
+
+	return nil
+}
+
+// Defaults returns the controller-wide default Options.
+func Defaults() Options {
+	defaultsLock.RLock()
+	defer defaultsLock.RUnlock()
+	return defaults
+}
+
+// ForIssuer returns the controller-wide default Options, overridden by the
+// fields set on the given issuer TLS configuration.
+func ForIssuer(override *cmmeta.ClientTLSConfig) Options {
+	opts := Defaults()
+	if override == nil {
+		return opts
+	}
+	if override.MinVersion != "" {
+		opts.MinTLSVersion = override.MinVersion
+	}
+	if len(override.CipherSuites) > 0 {
+		opts.CipherSuites = override.CipherSuites
+	}
+	return opts
+}
+
+// Config returns a new TLS configuration for connecting to an issuer's
+// server, using the given issuer TLS configuration and optional PEM encoded
+// CA bundle.
+func Config(override *cmmeta.ClientTLSConfig, caBundle []byte) (*tls.Config, error) {
+	tlsConfig := &tls.Config{}
+	if err := ForIssuer(override).Apply(tlsConfig); err != nil {
+		return nil, err
+	}
+	if err := SetRootCAs(tlsConfig, caBundle); err != nil {
+		return nil, err
+	}
+	return tlsConfig, nil
+}
+
+// Apply sets the minimum TLS version and cipher suites of the given TLS
+// configuration. Fields which are empty in the Options are left unchanged.
+func (o Options) Apply(tlsConfig *tls.Config) error {
+	minVersion, err := ParseMinTLSVersion(o.MinTLSVersion)
+	if err != nil {
+		return err
+	}
+	cipherSuites, err := ParseCipherSuites(o.CipherSuites, minVersion)
+	if err != nil {
+		return err
+	}
+
+	if minVersion != 0 {
+		tlsConfig.MinVersion = minVersion
+	}
+	if len(cipherSuites) > 0 {
+		tlsConfig.CipherSuites = cipherSuites
+	}
+	return nil
+}
+
+// Validate returns an error if the minimum TLS version or cipher suites are
+// invalid.
+func (o Options) Validate() error {
+	return o.Apply(&tls.Config{})
+}
+
+// SetRootCAs sets the root CAs of the given TLS configuration to the
+// certificates in the PEM encoded CA bundle. The system roots are left in
+// place if the bundle is empty.
+func SetRootCAs(tlsConfig *tls.Config, caBundle []byte) error {
+	if len(caBundle) == 0 {
+		return nil
+	}
+
+	pool := x509.NewCertPool()
+	if ok := pool.AppendCertsFromPEM(caBundle); !ok {
+		return errors.New("CA bundle does not contain any valid certificates")
+	}
+	tlsConfig.RootCAs = pool
+	return nil
+}
+
+// ParseMinTLSVersion returns the TLS version with the given name, or 0 if the
+// name is empty.
+func ParseMinTLSVersion(name string) (uint16, error) {
+	if name == "" {
+		return 0, nil
+	}
+	version, err := ciphers.TLSVersion(name)
+	if err != nil {
+		return 0, fmt.Errorf("invalid minimum TLS version: %w", err)
+	}
+	return version, nil
+}
+
+// ParseCipherSuites returns the IDs of the named cipher suites. As only TLS
+// 1.2 cipher suites can be configured, an error is returned for TLS 1.3
+// cipher suites, or if any are given while the minimum TLS version is 1.3.
+func ParseCipherSuites(names []string, minVersion uint16) ([]uint16, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	if minVersion >= tls.VersionTLS13 {
+		return nil, errors.New("cipher suites cannot be configured when the minimum TLS version is VersionTLS13, as TLS 1.3 cipher suites are not configurable")
+	}
+
+	ids, err := ciphers.TLSCipherSuites(names)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cipher suites: %w", err)
+	}
+	for i, id := range ids {
+		if isTLS13CipherSuite(id) {
+			return nil, fmt.Errorf("invalid cipher suites: %s is a TLS 1.3 cipher suite, which is not configurable", names[i])
+		}
+	}
+	return ids, nil
+}
+
+func isTLS13CipherSuite(id uint16) bool {
+	switch id {
+	case tls.TLS_AES_128_GCM_SHA256, tls.TLS_AES_256_GCM_SHA384, tls.TLS_CHACHA20_POLY1305_SHA256:
+		return true
+	}
+	return false
+}
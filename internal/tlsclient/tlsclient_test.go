@@ -0,0 +1,157 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tlsclient
+
+import (
+	"crypto/tls"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+func TestParseCipherSuites(t *testing.T) {
+	tests := map[string]struct {
+		names      []string
+		minVersion uint16
+		expIDs     []uint16
+		expErr     string
+	}{
+		"no cipher suites": {},
+		"TLS 1.2 cipher suites": {
+			names:      []string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"},
+			minVersion: tls.VersionTLS12,
+			expIDs:     []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256, tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384},
+		},
+		"unknown cipher suite": {
+			names:  []string{"TLS_UNKNOWN"},
+			expErr: "invalid cipher suites: Cipher suite TLS_UNKNOWN not supported or doesn't exist",
+		},
+		"TLS 1.3 cipher suite": {
+			names:  []string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256", "TLS_CHACHA20_POLY1305_SHA256"},
+			expErr: "invalid cipher suites: TLS_CHACHA20_POLY1305_SHA256 is a TLS 1.3 cipher suite, which is not configurable",
+		},
+		"cipher suites with a minimum version of TLS 1.3": {
+			names:      []string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"},
+			minVersion: tls.VersionTLS13,
+			expErr:     "cipher suites cannot be configured when the minimum TLS version is VersionTLS13, as TLS 1.3 cipher suites are not configurable",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ids, err := ParseCipherSuites(test.names, test.minVersion)
+			if test.expErr != "" {
+				assert.EqualError(t, err, test.expErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expIDs, ids)
+		})
+	}
+}
+
+func TestForIssuer(t *testing.T) {
+	t.Cleanup(resetDefaults())
+
+	require.NoError(t, SetDefaults(Options{
+		MinTLSVersion: "VersionTLS12",
+		CipherSuites:  []string{"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"},
+	}))
+	assert.Equal(t, uint16(tls.VersionTLS12), http.DefaultTransport.(*http.Transport).TLSClientConfig.MinVersion)
+
+	assert.Equal(t, Defaults(), ForIssuer(nil))
+	assert.Equal(t, Options{
+		MinTLSVersion: "VersionTLS13",
+		CipherSuites:  []string{"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"},
+	}, ForIssuer(&cmmeta.ClientTLSConfig{MinVersion: "VersionTLS13"}))
+
+	assert.Error(t, SetDefaults(Options{MinTLSVersion: "VersionTLS14"}))
+	assert.Equal(t, "VersionTLS12", Defaults().MinTLSVersion, "invalid defaults must not be stored")
+}
+
+func TestConfig(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.TLS = &tls.Config{MaxVersion: tls.VersionTLS12}
+	server.StartTLS()
+	defer server.Close()
+
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	tests := map[string]struct {
+		override *cmmeta.ClientTLSConfig
+		caBundle []byte
+		expErr   string
+		expGetOK bool
+	}{
+		"default TLS settings can connect to a TLS 1.2 server": {
+			caBundle: caBundle,
+			expGetOK: true,
+		},
+		"a minimum version of TLS 1.3 cannot connect to a TLS 1.2 server": {
+			override: &cmmeta.ClientTLSConfig{MinVersion: "VersionTLS13"},
+			caBundle: caBundle,
+		},
+		"an invalid CA bundle is rejected": {
+			caBundle: []byte("not a certificate"),
+			expErr:   "CA bundle does not contain any valid certificates",
+		},
+		"an invalid minimum version is rejected": {
+			override: &cmmeta.ClientTLSConfig{MinVersion: "VersionTLS14"},
+			expErr:   `invalid minimum TLS version: unknown tls version "VersionTLS14"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			tlsConfig, err := Config(test.override, test.caBundle)
+			if test.expErr != "" {
+				assert.EqualError(t, err, test.expErr)
+				return
+			}
+			require.NoError(t, err)
+
+			client := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+			resp, err := client.Get(server.URL)
+			if !test.expGetOK {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			resp.Body.Close()
+		})
+	}
+}
+
+// resetDefaults returns a function restoring the defaults and
+// http.DefaultTransport, which are modified by SetDefaults.
+func resetDefaults() func() {
+	transport := http.DefaultTransport.(*http.Transport)
+	tlsConfig := transport.TLSClientConfig
+	opts := Defaults()
+	return func() {
+		transport.TLSClientConfig = tlsConfig
+		defaultsLock.Lock()
+		defer defaultsLock.Unlock()
+		defaults = opts
+	}
+}
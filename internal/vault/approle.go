@@ -0,0 +1,182 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// appRoleLogins caches App Role logins across Vault clients, which are
+// created for every signing request.
+var appRoleLogins = &appRoleLoginCache{
+	logins: make(map[appRoleLoginKey]*appRoleLogin),
+}
+
+// maxAppRoleLogins is the maximum number of cached App Role logins. Once it is
+// reached, the least recently used login is evicted. Evicted logins only cost
+// a new login, as unwrapped secret IDs are persisted in their Secret.
+const maxAppRoleLogins = 256
+
+// appRoleLoginKey identifies an App Role login. Issuers with the same Vault
+// server, App Role and secret reference share a login.
+type appRoleLoginKey struct {
+	server          string
+	vaultNamespace  string
+	authPath        string
+	roleId          string
+	secretNamespace string
+	secretName      string
+	secretKey       string
+}
+
+type appRoleLoginCache struct {
+	lock   sync.Mutex
+	logins map[appRoleLoginKey]*appRoleLogin
+}
+
+// get returns the login for the given key. If the App Role Secret has been
+// changed since the login was cached, for example because the secret ID was
+// rotated, a new empty login is returned.
+func (c *appRoleLoginCache) get(key appRoleLoginKey, secret *corev1.Secret, now time.Time) *appRoleLogin {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	login, ok := c.logins[key]
+	if !ok || login.secretUID != secret.UID || login.secretResourceVersion != secret.ResourceVersion {
+		if !ok && len(c.logins) >= maxAppRoleLogins {
+			c.evictLeastRecentlyUsed()
+		}
+		login = &appRoleLogin{
+			secretUID:             secret.UID,
+			secretResourceVersion: secret.ResourceVersion,
+		}
+		c.logins[key] = login
+	}
+	login.lastUsed = now
+
+	return login
+}
+
+func (c *appRoleLoginCache) evictLeastRecentlyUsed() {
+	var oldestKey appRoleLoginKey
+	var oldest time.Time
+	for key, login := range c.logins {
+		if oldest.IsZero() || login.lastUsed.Before(oldest) {
+			oldestKey, oldest = key, login.lastUsed
+		}
+	}
+	delete(c.logins, oldestKey)
+}
+
+// appRoleLogin holds the Vault token obtained with an App Role, along with
+// the unwrapped secret ID if the Secret contains a response-wrapping token.
+type appRoleLogin struct {
+	sync.Mutex
+
+	secretUID             types.UID
+	secretResourceVersion string
+
+	// lastUsed is only accessed with the lock of the cache held.
+	lastUsed time.Time
+
+	// secretId is the secret ID read from the Secret, or unwrapped from
+	// the response-wrapping token in the Secret. persisted is false if an
+	// unwrapped secret ID has not been persisted in the Secret yet.
+	secretId  string
+	persisted bool
+
+	token  string
+	expiry time.Time
+}
+
+// validToken returns the cached token if it has not expired. Tokens without
+// an expiry are considered valid until Vault rejects them.
+func (l *appRoleLogin) validToken(now time.Time) (string, bool) {
+	if l.token == "" {
+		return "", false
+	}
+	if !l.expiry.IsZero() && !now.Before(l.expiry) {
+		return "", false
+	}
+	return l.token, true
+}
+
+// setToken caches the token. The token is considered expired once 90% of its
+// TTL has passed, so that it is not used right before Vault expires it.
+func (l *appRoleLogin) setToken(token string, ttl time.Duration, now time.Time) {
+	l.token = token
+	l.expiry = time.Time{}
+	if ttl > 0 {
+		l.expiry = now.Add(ttl * 9 / 10)
+	}
+}
+
+// isUnwrappedSecretID returns true if the value in the App Role Secret is a
+// secret ID which has been unwrapped by cert-manager, rather than a
+// response-wrapping token.
+func isUnwrappedSecretID(secret *corev1.Secret, value string) bool {
+	return unwrappedSecretIDHashes(secret).Has(secretIDHash(value))
+}
+
+// unwrappedSecretIDHashes returns the hashes of the unwrapped secret IDs
+// recorded on the Secret which are still stored in it.
+func unwrappedSecretIDHashes(secret *corev1.Secret) sets.Set[string] {
+	recorded := sets.New(strings.Split(secret.Annotations[v1.VaultAppRoleUnwrappedSecretIDsAnnotationKey], ",")...)
+	hashes := sets.New[string]()
+	for _, value := range secret.Data {
+		if hash := secretIDHash(strings.TrimSpace(string(value))); recorded.Has(hash) {
+			hashes.Insert(hash)
+		}
+	}
+	return hashes
+}
+
+func secretIDHash(secretId string) string {
+	hash := sha256.Sum256([]byte(secretId))
+	return hex.EncodeToString(hash[:])
+}
+
+// persistUnwrappedSecretID replaces the response-wrapping token in the App
+// Role Secret, which can only be used once, with the secret ID it wrapped. The
+// hash of the secret ID is recorded on the Secret, so that the secret ID is
+// not unwrapped again, for example after cert-manager has been restarted.
+func (v *Vault) persistUnwrappedSecretID(ctx context.Context, secret *corev1.Secret, key, secretId string) error {
+	if v.updateSecret == nil {
+		return errors.New("no client to update Secrets")
+	}
+
+	secret = secret.DeepCopy()
+	secret.Data[key] = []byte(secretId)
+	hashes := unwrappedSecretIDHashes(secret).Insert(secretIDHash(secretId))
+	metav1.SetMetaDataAnnotation(&secret.ObjectMeta, v1.VaultAppRoleUnwrappedSecretIDsAnnotationKey, strings.Join(sets.List(hashes), ","))
+
+	_, err := v.updateSecret(ctx, secret, metav1.UpdateOptions{})
+	return err
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package fake
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -66,6 +67,11 @@ func (c *FakeClient) SetToken(v string) {
 	c.GotToken = v
 }
 
-func (c *FakeClient) RawRequest(r *vault.Request) (*vault.Response, error) {
+// RawRequestWithContext returns the error of the context if it is already
+// done, and otherwise calls RawRequestFn.
+func (c *FakeClient) RawRequestWithContext(ctx context.Context, r *vault.Request) (*vault.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	return c.RawRequestFn(r)
 }
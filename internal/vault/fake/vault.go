@@ -18,6 +18,7 @@ limitations under the License.
 package fake
 
 import (
+	"context"
 	"time"
 
 	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
@@ -27,17 +28,21 @@ import (
 // Vault is a mock implementation of the Vault interface
 type Vault struct {
 	NewFn                           func(string, internalinformers.SecretLister, cmapi.GenericIssuer) (*Vault, error)
-	SignFn                          func([]byte, time.Duration) ([]byte, []byte, error)
-	IsVaultInitializedAndUnsealedFn func() error
+	SignFn                          func(context.Context, []byte, time.Duration) ([]byte, []byte, error)
+	RevokeFn                        func(context.Context, string) error
+	IsVaultInitializedAndUnsealedFn func(context.Context) error
 }
 
 // New returns a new fake Vault
 func New() *Vault {
 	v := &Vault{
-		SignFn: func([]byte, time.Duration) ([]byte, []byte, error) {
+		SignFn: func(context.Context, []byte, time.Duration) ([]byte, []byte, error) {
 			return nil, nil, nil
 		},
-		IsVaultInitializedAndUnsealedFn: func() error {
+		RevokeFn: func(context.Context, string) error {
+			return nil
+		},
+		IsVaultInitializedAndUnsealedFn: func(context.Context) error {
 			return nil
 		},
 	}
@@ -50,18 +55,31 @@ func New() *Vault {
 }
 
 // Sign implements `vault.Interface`.
-func (v *Vault) Sign(csrPEM []byte, duration time.Duration) ([]byte, []byte, error) {
-	return v.SignFn(csrPEM, duration)
+func (v *Vault) Sign(ctx context.Context, csrPEM []byte, duration time.Duration) ([]byte, []byte, error) {
+	return v.SignFn(ctx, csrPEM, duration)
 }
 
 // WithSign sets the fake Vault's Sign function.
 func (v *Vault) WithSign(certPEM, caPEM []byte, err error) *Vault {
-	v.SignFn = func([]byte, time.Duration) ([]byte, []byte, error) {
+	v.SignFn = func(context.Context, []byte, time.Duration) ([]byte, []byte, error) {
 		return certPEM, caPEM, err
 	}
 	return v
 }
 
+// Revoke implements `vault.Interface`.
+func (v *Vault) Revoke(ctx context.Context, serialNumber string) error {
+	return v.RevokeFn(ctx, serialNumber)
+}
+
+// WithRevoke sets the fake Vault's Revoke function.
+func (v *Vault) WithRevoke(err error) *Vault {
+	v.RevokeFn = func(context.Context, string) error {
+		return err
+	}
+	return v
+}
+
 // WithNew sets the fake Vault's New function.
 func (v *Vault) WithNew(f func(string, internalinformers.SecretLister, cmapi.GenericIssuer) (*Vault, error)) *Vault {
 	v.NewFn = f
@@ -79,6 +97,6 @@ func (v *Vault) New(ns string, sl internalinformers.SecretLister, iss cmapi.Gene
 }
 
 // IsVaultInitializedAndUnsealed always returns nil
-func (v *Vault) IsVaultInitializedAndUnsealed() error {
+func (v *Vault) IsVaultInitializedAndUnsealed(context.Context) error {
 	return nil
 }
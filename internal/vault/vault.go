@@ -19,12 +19,13 @@ package vault
 import (
 	"context"
 	"crypto/tls"
-	"crypto/x509"
 	"errors"
 	"fmt"
 	"net/http"
 	"path"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -36,8 +37,10 @@ import (
 	"k8s.io/utils/ptr"
 
 	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
+	"github.com/cert-manager/cert-manager/internal/tlsclient"
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
@@ -45,30 +48,64 @@ var _ Interface = &Vault{}
 
 // ClientBuilder is a function type that returns a new Interface.
 // Can be used in tests to create a mock signer of Vault certificate requests.
-type ClientBuilder func(ctx context.Context, namespace string, _ func(ns string) CreateToken, _ internalinformers.SecretLister, _ v1.GenericIssuer) (Interface, error)
+type ClientBuilder func(ctx context.Context, namespace string, _ func(ns string) CreateToken, _ func(ns string) UpdateSecret, _ internalinformers.SecretLister, _ v1.GenericIssuer) (Interface, error)
 
 // Interface implements various high level functionality related to connecting
 // with a Vault server, verifying its status and signing certificate request for
 // Vault's certificate.
 type Interface interface {
-	Sign(csrPEM []byte, duration time.Duration) (certPEM []byte, caPEM []byte, err error)
-	IsVaultInitializedAndUnsealed() error
+	Sign(ctx context.Context, csrPEM []byte, duration time.Duration) (certPEM []byte, caPEM []byte, err error)
+	Revoke(ctx context.Context, serialNumber string) error
+	IsVaultInitializedAndUnsealed(ctx context.Context) error
 }
 
 // Client implements functionality to talk to a Vault server.
 type Client interface {
 	NewRequest(method, requestPath string) *vault.Request
-	RawRequest(r *vault.Request) (*vault.Response, error)
+	RawRequestWithContext(ctx context.Context, r *vault.Request) (*vault.Response, error)
 	SetToken(v string)
 }
 
+// LoginError is returned when authenticating to Vault with the configured
+// auth method fails. It only marks the error as a login failure: errors
+// reading the credentials from the cluster, such as a missing Secret, are not
+// LoginErrors.
+type LoginError struct {
+	Err error
+}
+
+func (e *LoginError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *LoginError) Unwrap() error {
+	return e.Err
+}
+
+// RejectedError is returned when Vault rejects a request to sign a
+// certificate, for example because the role does not allow one of the
+// requested names.
+type RejectedError struct {
+	// Errors are the errors returned by Vault.
+	Errors []string
+}
+
+func (e *RejectedError) Error() string {
+	return fmt.Sprintf("vault rejected the request to sign the certificate: %s", strings.Join(e.Errors, "; "))
+}
+
 // For mocking purposes.
 type CreateToken func(ctx context.Context, saName string, req *authv1.TokenRequest, opts metav1.CreateOptions) (*authv1.TokenRequest, error)
 
+// UpdateSecret updates a Secret. It is used to persist unwrapped App Role
+// secret IDs. For mocking purposes.
+type UpdateSecret func(ctx context.Context, secret *corev1.Secret, opts metav1.UpdateOptions) (*corev1.Secret, error)
+
 // Vault implements Interface and holds a Vault issuer, secrets lister and a
 // Vault client.
 type Vault struct {
-	createToken   CreateToken // Uses the same namespace as below.
+	createToken   CreateToken  // Uses the same namespace as below.
+	updateSecret  UpdateSecret // Uses the same namespace as below.
 	secretsLister internalinformers.SecretLister
 	issuer        v1.GenericIssuer
 	namespace     string
@@ -95,9 +132,10 @@ type Vault struct {
 // secrets lister.
 // Returned errors may be network failures and should be considered for
 // retrying.
-func New(ctx context.Context, namespace string, createTokenFn func(ns string) CreateToken, secretsLister internalinformers.SecretLister, issuer v1.GenericIssuer) (Interface, error) {
+func New(ctx context.Context, namespace string, createTokenFn func(ns string) CreateToken, updateSecretFn func(ns string) UpdateSecret, secretsLister internalinformers.SecretLister, issuer v1.GenericIssuer) (Interface, error) {
 	v := &Vault{
 		createToken:   createTokenFn(namespace),
+		updateSecret:  updateSecretFn(namespace),
 		secretsLister: secretsLister,
 		namespace:     namespace,
 		issuer:        issuer,
@@ -138,15 +176,24 @@ func New(ctx context.Context, namespace string, createTokenFn func(ns string) Cr
 }
 
 // Sign will connect to a Vault instance to sign a certificate signing request.
-func (v *Vault) Sign(csrPEM []byte, duration time.Duration) (cert []byte, ca []byte, err error) {
+func (v *Vault) Sign(ctx context.Context, csrPEM []byte, duration time.Duration) (cert []byte, ca []byte, err error) {
 	csr, err := pki.DecodeX509CertificateRequestBytes(csrPEM)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to decode CSR for signing: %s", err)
 	}
 
+	vaultIssuer := v.issuer.GetSpec().Vault
+
+	altNames := csr.DNSNames
+	if vaultIssuer.SignMode == v1.VaultSignModeRole {
+		// The role decides whether the email addresses of the CSR are
+		// allowed, which it can only do if they are requested.
+		altNames = append(slices.Clone(altNames), csr.EmailAddresses...)
+	}
+
 	parameters := map[string]string{
 		"common_name": csr.Subject.CommonName,
-		"alt_names":   strings.Join(csr.DNSNames, ","),
+		"alt_names":   strings.Join(altNames, ","),
 		"ip_sans":     strings.Join(pki.IPAddressesToString(csr.IPAddresses), ","),
 		"uri_sans":    strings.Join(pki.URLsToString(csr.URIs), ","),
 		"ttl":         duration.String(),
@@ -154,19 +201,67 @@ func (v *Vault) Sign(csrPEM []byte, duration time.Duration) (cert []byte, ca []b
 
 		"exclude_cn_from_sans": "true",
 	}
+	// Vault takes the other subject fields from the role, but the serial
+	// number is taken from the request.
+	if csr.Subject.SerialNumber != "" {
+		parameters["serial_number"] = csr.Subject.SerialNumber
+	}
 
-	vaultIssuer := v.issuer.GetSpec().Vault
-	url := path.Join("/v1", vaultIssuer.Path)
+	for name, value := range vaultIssuer.ExtraParameters {
+		// csr and format are validated by the webhook, but are skipped here
+		// too as cert-manager relies on them to decode the response.
+		if name == "csr" || name == "format" {
+			continue
+		}
+		parameters[name] = value
+	}
+	if vaultIssuer.IssuerRef != "" {
+		parameters["issuer_ref"] = vaultIssuer.IssuerRef
+	}
+	if vaultIssuer.ExcludeCNFromSANs != nil {
+		parameters["exclude_cn_from_sans"] = strconv.FormatBool(*vaultIssuer.ExcludeCNFromSANs)
+	}
 
-	request := v.client.NewRequest("POST", url)
+	signPath, err := signEndpointPath(vaultIssuer.Path, vaultIssuer.SignMode)
+	if err != nil {
+		return nil, nil, err
+	}
+	url := path.Join("/v1", signPath)
+
+	// The request must be built again to be retried, as it holds a copy of
+	// the client token.
+	sign := func() (*vault.Response, error) {
+		request := v.client.NewRequest("POST", url)
 
-	if err := request.SetJSONBody(parameters); err != nil {
-		return nil, nil, fmt.Errorf("failed to build vault request: %s", err)
+		if err := request.SetJSONBody(parameters); err != nil {
+			return nil, fmt.Errorf("failed to build vault request: %s", err)
+		}
+
+		resp, err := v.client.RawRequestWithContext(ctx, request)
+		var respErr *vault.ResponseError
+		if errors.As(err, &respErr) && respErr.StatusCode == http.StatusBadRequest {
+			return nil, &RejectedError{Errors: respErr.Errors}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign certificate by vault: %w", err)
+		}
+		return resp, nil
 	}
 
-	resp, err := v.client.RawRequest(request)
+	resp, err := sign()
+	if err != nil && v.isAppRoleTokenRejected(err) {
+		// A cached App Role token may have been revoked before it expired,
+		// in which case we log in again and retry once.
+		token, loginErr := v.requestTokenWithAppRoleRef(ctx, v.client, vaultIssuer.Auth.AppRole, true)
+		if loginErr != nil {
+			return nil, nil, loginErr
+		}
+		v.client.SetToken(token)
+
+		resp, err = sign()
+	}
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to sign certificate by vault: %s", err)
+		return nil, nil, err
 	}
 
 	defer resp.Body.Close()
@@ -180,6 +275,79 @@ func (v *Vault) Sign(csrPEM []byte, duration time.Duration) (cert []byte, ca []b
 	return extractCertificatesFromVaultCertificateSecret(&vaultResult)
 }
 
+// Revoke revokes the certificate with the given serial number using the
+// revoke endpoint of the PKI secrets engine the issuer signs with. The serial
+// number must be a colon or hyphen separated hex string.
+func (v *Vault) Revoke(ctx context.Context, serialNumber string) error {
+	url := path.Join("/v1", pkiMountPath(v.issuer.GetSpec().Vault.Path), "revoke")
+
+	request := v.client.NewRequest("POST", url)
+	if err := request.SetJSONBody(map[string]string{"serial_number": serialNumber}); err != nil {
+		return fmt.Errorf("failed to build vault request: %s", err)
+	}
+
+	resp, err := v.client.RawRequestWithContext(ctx, request)
+	if err != nil {
+		return fmt.Errorf("failed to revoke certificate by vault: %w", err)
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// signEndpointPath returns the path of the endpoint used to sign certificates
+// in the given sign mode. The path configured for the issuer is used as it is
+// if no sign mode is set. Otherwise, it must be the path of the `sign` or
+// `sign-verbatim` endpoint of a role, such as "pki/sign/my-role", and the
+// endpoint of the same role selected by the sign mode is returned.
+func signEndpointPath(signPath string, mode v1.VaultSignMode) (string, error) {
+	if mode == "" {
+		return signPath, nil
+	}
+
+	segments := strings.Split(strings.Trim(signPath, "/"), "/")
+	endpoint := len(segments) - 2
+	if endpoint < 1 || (segments[endpoint] != "sign" && segments[endpoint] != "sign-verbatim") {
+		return "", fmt.Errorf("signMode %q requires the path to be the sign or sign-verbatim endpoint of a role, but got %q", mode, signPath)
+	}
+
+	switch mode {
+	case v1.VaultSignModeRole:
+		segments[endpoint] = "sign"
+	case v1.VaultSignModeVerbatim:
+		segments[endpoint] = "sign-verbatim"
+	default:
+		return "", fmt.Errorf("unknown signMode %q", mode)
+	}
+	return strings.Join(segments, "/"), nil
+}
+
+// pkiMountPath returns the mount path of the PKI secrets engine from the path
+// of one of its signing endpoints, such as "pki/sign/my-role" or
+// "pki/issuer/my-issuer/sign/my-role".
+func pkiMountPath(signPath string) string {
+	segments := strings.Split(strings.Trim(signPath, "/"), "/")
+	for i, segment := range segments {
+		switch segment {
+		case "sign", "sign-verbatim", "issuer", "root":
+			return strings.Join(segments[:i], "/")
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// isAppRoleTokenRejected returns true if the error was caused by Vault
+// rejecting a token obtained with App Role authentication.
+func (v *Vault) isAppRoleTokenRejected(err error) bool {
+	auth := v.issuer.GetSpec().Vault.Auth
+	if auth.TokenSecretRef != nil || auth.AppRole == nil {
+		return false
+	}
+
+	var respErr *vault.ResponseError
+	return errors.As(err, &respErr) && respErr.StatusCode == http.StatusForbidden
+}
+
 func (v *Vault) setToken(ctx context.Context, client Client) error {
 	// IMPORTANT: Because of backwards compatibility with older versions that
 	// incorrectly allowed multiple authentication methods to be specified at
@@ -201,7 +369,7 @@ func (v *Vault) setToken(ctx context.Context, client Client) error {
 
 	appRole := v.issuer.GetSpec().Vault.Auth.AppRole
 	if appRole != nil {
-		token, err := v.requestTokenWithAppRoleRef(client, appRole)
+		token, err := v.requestTokenWithAppRoleRef(ctx, client, appRole, false)
 		if err != nil {
 			return err
 		}
@@ -232,14 +400,13 @@ func (v *Vault) newConfig() (*vault.Config, error) {
 		return nil, fmt.Errorf("failed to load vault CA bundle: %w", err)
 	}
 
-	if len(caBundle) != 0 {
-		caCertPool := x509.NewCertPool()
-		ok := caCertPool.AppendCertsFromPEM(caBundle)
-		if !ok {
-			return nil, fmt.Errorf("no Vault CA bundles loaded, check bundle contents")
-		}
+	tlsConfig := cfg.HttpClient.Transport.(*http.Transport).TLSClientConfig
+	if err := tlsclient.ForIssuer(v.issuer.GetSpec().Vault.TLS).Apply(tlsConfig); err != nil {
+		return nil, fmt.Errorf("invalid vault TLS configuration: %w", err)
+	}
 
-		cfg.HttpClient.Transport.(*http.Transport).TLSClientConfig.RootCAs = caCertPool
+	if err := tlsclient.SetRootCAs(tlsConfig, caBundle); err != nil {
+		return nil, fmt.Errorf("no Vault CA bundles loaded, check bundle contents")
 	}
 
 	clientCertificate, err := v.clientCertificate()
@@ -248,7 +415,7 @@ func (v *Vault) newConfig() (*vault.Config, error) {
 	}
 
 	if clientCertificate != nil {
-		cfg.HttpClient.Transport.(*http.Transport).TLSClientConfig.Certificates = []tls.Certificate{*clientCertificate}
+		tlsConfig.Certificates = []tls.Certificate{*clientCertificate}
 	}
 
 	return cfg, nil
@@ -359,39 +526,90 @@ func (v *Vault) tokenRef(name, namespace, key string) (string, error) {
 	return token, nil
 }
 
-func (v *Vault) appRoleRef(appRole *v1.VaultAppRole) (roleId, secretId string, err error) {
-	roleId = strings.TrimSpace(appRole.RoleId)
-
-	secret, err := v.secretsLister.Secrets(v.namespace).Get(appRole.SecretRef.Name)
-	if err != nil {
-		return "", "", err
-	}
-
+func (v *Vault) appRoleSecretID(secret *corev1.Secret, appRole *v1.VaultAppRole) (string, error) {
 	key := appRole.SecretRef.Key
 
 	keyBytes, ok := secret.Data[key]
 	if !ok {
-		return "", "", fmt.Errorf("no data for %q in secret '%s/%s'", key, v.namespace, appRole.SecretRef.Name)
+		return "", fmt.Errorf("no data for %q in secret '%s/%s'", key, v.namespace, appRole.SecretRef.Name)
 	}
 
-	secretId = string(keyBytes)
-	secretId = strings.TrimSpace(secretId)
-
-	return roleId, secretId, nil
+	return strings.TrimSpace(string(keyBytes)), nil
 }
 
-func (v *Vault) requestTokenWithAppRoleRef(client Client, appRole *v1.VaultAppRole) (string, error) {
-	roleId, secretId, err := v.appRoleRef(appRole)
+// requestTokenWithAppRoleRef returns a Vault token for the given App Role.
+// Tokens are cached until they expire or the App Role Secret changes, unless
+// forceLogin is true, in which case a new token is always requested.
+// Errors from Vault while unwrapping the secret ID or logging in are
+// returned as LoginErrors.
+func (v *Vault) requestTokenWithAppRoleRef(ctx context.Context, client Client, appRole *v1.VaultAppRole, forceLogin bool) (string, error) {
+	roleId := strings.TrimSpace(appRole.RoleId)
+
+	secret, err := v.secretsLister.Secrets(v.namespace).Get(appRole.SecretRef.Name)
 	if err != nil {
 		return "", err
 	}
 
+	login := appRoleLogins.get(appRoleLoginKey{
+		server:          v.issuer.GetSpec().Vault.Server,
+		vaultNamespace:  v.issuer.GetSpec().Vault.Namespace,
+		authPath:        appRole.Path,
+		roleId:          roleId,
+		secretNamespace: v.namespace,
+		secretName:      appRole.SecretRef.Name,
+		secretKey:       appRole.SecretRef.Key,
+	}, secret, time.Now())
+	login.Lock()
+	defer login.Unlock()
+
+	if !forceLogin {
+		if token, ok := login.validToken(time.Now()); ok {
+			return token, nil
+		}
+	}
+
+	if login.secretId == "" {
+		secretId, err := v.appRoleSecretID(secret, appRole)
+		if err != nil {
+			return "", err
+		}
+		persisted := true
+		if appRole.ResponseWrapped && !isUnwrappedSecretID(secret, secretId) {
+			secretId, err = unwrapAppRoleSecretID(ctx, client, secretId)
+			if err != nil {
+				return "", &LoginError{Err: err}
+			}
+			persisted = false
+		}
+		login.secretId, login.persisted = secretId, persisted
+	}
+
+	// Wrapping tokens can only be used once, so the unwrapped secret ID is
+	// kept in memory until it has been persisted in the Secret.
+	if !login.persisted {
+		if err := v.persistUnwrappedSecretID(ctx, secret, appRole.SecretRef.Key, login.secretId); err != nil {
+			logf.FromContext(ctx).Error(err, "failed to persist the unwrapped App Role secret ID, retrying on the next login", "secret", appRole.SecretRef.Name)
+		} else {
+			login.persisted = true
+		}
+	}
+
+	token, ttl, err := loginWithAppRole(ctx, client, appRole.Path, roleId, login.secretId)
+	if err != nil {
+		login.token = ""
+		return "", &LoginError{Err: err}
+	}
+	login.setToken(token, ttl, time.Now())
+
+	return token, nil
+}
+
+func loginWithAppRole(ctx context.Context, client Client, authPath, roleId, secretId string) (string, time.Duration, error) {
 	parameters := map[string]string{
 		"role_id":   roleId,
 		"secret_id": secretId,
 	}
 
-	authPath := appRole.Path
 	if authPath == "" {
 		authPath = "approle"
 	}
@@ -400,33 +618,64 @@ func (v *Vault) requestTokenWithAppRoleRef(client Client, appRole *v1.VaultAppRo
 
 	request := client.NewRequest("POST", url)
 
-	err = request.SetJSONBody(parameters)
+	err := request.SetJSONBody(parameters)
 	if err != nil {
-		return "", fmt.Errorf("error encoding Vault parameters: %s", err.Error())
+		return "", 0, fmt.Errorf("error encoding Vault parameters: %s", err.Error())
 	}
 
-	resp, err := client.RawRequest(request)
+	resp, err := client.RawRequestWithContext(ctx, request)
 	if err != nil {
-		return "", fmt.Errorf("error logging in to Vault server: %s", err.Error())
+		return "", 0, fmt.Errorf("error logging in to Vault server: %s", err.Error())
 	}
 
 	defer resp.Body.Close()
 
 	vaultResult := vault.Secret{}
 	if err := resp.DecodeJSON(&vaultResult); err != nil {
-		return "", fmt.Errorf("unable to decode JSON payload: %s", err.Error())
+		return "", 0, fmt.Errorf("unable to decode JSON payload: %s", err.Error())
 	}
 
 	token, err := vaultResult.TokenID()
 	if err != nil {
-		return "", fmt.Errorf("unable to read token: %s", err.Error())
+		return "", 0, fmt.Errorf("unable to read token: %s", err.Error())
 	}
 
 	if token == "" {
-		return "", errors.New("no token returned")
+		return "", 0, errors.New("no token returned")
 	}
 
-	return token, nil
+	ttl, err := vaultResult.TokenTTL()
+	if err != nil {
+		return "", 0, fmt.Errorf("unable to read token TTL: %s", err.Error())
+	}
+
+	return token, ttl, nil
+}
+
+// unwrapAppRoleSecretID exchanges a response-wrapping token for the App Role
+// secret ID it wraps.
+func unwrapAppRoleSecretID(ctx context.Context, client Client, wrappingToken string) (string, error) {
+	request := client.NewRequest("POST", "/v1/sys/wrapping/unwrap")
+	request.ClientToken = wrappingToken
+
+	resp, err := client.RawRequestWithContext(ctx, request)
+	if err != nil {
+		return "", fmt.Errorf("error unwrapping App Role secret ID: %s", err.Error())
+	}
+
+	defer resp.Body.Close()
+
+	vaultResult := vault.Secret{}
+	if err := resp.DecodeJSON(&vaultResult); err != nil {
+		return "", fmt.Errorf("unable to decode JSON payload: %s", err.Error())
+	}
+
+	secretId, ok := vaultResult.Data["secret_id"].(string)
+	if !ok || secretId == "" {
+		return "", errors.New("no secret_id found in the unwrapped response")
+	}
+
+	return secretId, nil
 }
 
 func (v *Vault) requestTokenWithKubernetesAuth(ctx context.Context, client Client, kubernetesAuth *v1.VaultKubernetesAuth) (string, error) {
@@ -510,7 +759,7 @@ func (v *Vault) requestTokenWithKubernetesAuth(ctx context.Context, client Clien
 		return "", fmt.Errorf("error encoding Vault parameters: %s", err.Error())
 	}
 
-	resp, err := client.RawRequest(request)
+	resp, err := client.RawRequestWithContext(ctx, request)
 	if err != nil {
 		return "", fmt.Errorf("error calling Vault server: %s", err.Error())
 	}
@@ -554,10 +803,10 @@ func extractCertificatesFromVaultCertificateSecret(secret *certutil.Secret) ([]b
 	return bundle.ChainPEM, bundle.CAPEM, nil
 }
 
-func (v *Vault) IsVaultInitializedAndUnsealed() error {
+func (v *Vault) IsVaultInitializedAndUnsealed(ctx context.Context) error {
 	healthURL := path.Join("/v1", "sys", "health")
 	healthRequest := v.clientSys.NewRequest("GET", healthURL)
-	healthResp, err := v.clientSys.RawRequest(healthRequest)
+	healthResp, err := v.clientSys.RawRequestWithContext(ctx, healthRequest)
 
 	if healthResp != nil {
 		defer healthResp.Body.Close()
@@ -21,13 +21,17 @@ import (
 	"context"
 	"crypto"
 	"crypto/rsa"
+	"crypto/tls"
 	"crypto/x509"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -39,7 +43,9 @@ import (
 	authv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	clientcorev1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/utils/ptr"
 
 	vaultfake "github.com/cert-manager/cert-manager/internal/vault/fake"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
@@ -345,7 +351,7 @@ func TestSign(t *testing.T) {
 			client:        test.fakeClient,
 		}
 
-		cert, ca, err := v.Sign(test.csrPEM, time.Minute)
+		cert, ca, err := v.Sign(context.TODO(), test.csrPEM, time.Minute)
 		if ((test.expectedErr == nil) != (err == nil)) &&
 			test.expectedErr != nil &&
 			test.expectedErr.Error() != err.Error() {
@@ -887,77 +893,39 @@ func TestSetToken(t *testing.T) {
 	}
 }
 
-type testAppRoleRefT struct {
-	expectedRoleID   string
-	expectedSecretID string
-	expectedErr      error
-
-	appRole *cmapi.VaultAppRole
-
-	fakeLister *listers.FakeSecretLister
-}
-
-func TestAppRoleRef(t *testing.T) {
-	errSecretGet := errors.New("no secret found")
-
-	basicAppRoleRef := &cmapi.VaultAppRole{
+func TestAppRoleSecretID(t *testing.T) {
+	appRole := &cmapi.VaultAppRole{
 		RoleId: "my-role-id",
-	}
-
-	tests := map[string]testAppRoleRefT{
-		"failing to get secret should error": {
-			appRole: basicAppRoleRef,
-			fakeLister: listers.FakeSecretListerFrom(listers.NewFakeSecretLister(),
-				listers.SetFakeSecretNamespaceListerGet(nil, errSecretGet),
-			),
-			expectedRoleID:   "",
-			expectedSecretID: "",
-			expectedErr:      errSecretGet,
+		SecretRef: cmmeta.SecretKeySelector{
+			LocalObjectReference: cmmeta.LocalObjectReference{
+				Name: "secret-name",
+			},
+			Key: "my-key",
 		},
+	}
 
+	tests := map[string]struct {
+		secret           *corev1.Secret
+		expectedSecretID string
+		expectedErr      error
+	}{
 		"no data in key should fail": {
-			appRole: &cmapi.VaultAppRole{
-				RoleId: "",
-				SecretRef: cmmeta.SecretKeySelector{
-					LocalObjectReference: cmmeta.LocalObjectReference{
-						Name: "secret-name",
-					},
-					Key: "my-key",
+			secret: &corev1.Secret{
+				Data: map[string][]byte{
+					"foo": []byte("bar"),
 				},
 			},
-			fakeLister: listers.FakeSecretListerFrom(listers.NewFakeSecretLister(),
-				listers.SetFakeSecretNamespaceListerGet(
-					&corev1.Secret{
-						Data: map[string][]byte{
-							"foo": []byte("bar"),
-						},
-					}, nil),
-			),
-			expectedRoleID:   "",
 			expectedSecretID: "",
 			expectedErr:      errors.New(`no data for "my-key" in secret 'test-namespace/secret-name'`),
 		},
 
-		"should return roleID and secretID with trimmed space": {
-			appRole: &cmapi.VaultAppRole{
-				RoleId: "    my-role-id  ",
-				SecretRef: cmmeta.SecretKeySelector{
-					LocalObjectReference: cmmeta.LocalObjectReference{
-						Name: "secret-name",
-					},
-					Key: "my-key",
+		"should return secretID with trimmed space": {
+			secret: &corev1.Secret{
+				Data: map[string][]byte{
+					"foo":    []byte("bar"),
+					"my-key": []byte("    my-key-data   "),
 				},
 			},
-			fakeLister: listers.FakeSecretListerFrom(listers.NewFakeSecretLister(),
-				listers.SetFakeSecretNamespaceListerGet(
-					&corev1.Secret{
-						Data: map[string][]byte{
-							"foo":    []byte("bar"),
-							"my-key": []byte("    my-key-data   "),
-						},
-					}, nil),
-			),
-			expectedRoleID:   "my-role-id",
 			expectedSecretID: "my-key-data",
 			expectedErr:      nil,
 		},
@@ -966,24 +934,16 @@ func TestAppRoleRef(t *testing.T) {
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
 			v := &Vault{
-				namespace:     "test-namespace",
-				secretsLister: test.fakeLister,
-				issuer:        nil,
+				namespace: "test-namespace",
 			}
 
-			roleID, secretID, err := v.appRoleRef(test.appRole)
-			if ((test.expectedErr == nil) != (err == nil)) &&
-				test.expectedErr != nil &&
-				test.expectedErr.Error() != err.Error() {
+			secretID, err := v.appRoleSecretID(test.secret, appRole)
+			if ((test.expectedErr == nil) != (err == nil)) ||
+				(test.expectedErr != nil && test.expectedErr.Error() != err.Error()) {
 				t.Errorf("unexpected error, exp=%v got=%v",
 					test.expectedErr, err)
 			}
 
-			if test.expectedRoleID != roleID {
-				t.Errorf("got unexpected roleID, exp=%s got=%s",
-					test.expectedRoleID, roleID)
-			}
-
 			if test.expectedSecretID != secretID {
 				t.Errorf("got unexpected secretID, exp=%s got=%s",
 					test.expectedSecretID, secretID)
@@ -1312,6 +1272,39 @@ func TestNewConfig(t *testing.T) {
 			},
 			fakeLister: clientCertificateSecretRefFakeSecretLister("test-namespace", "bundle", "ca.crt", testLeafCertificate, "tls.crt", testClientCertificate, "tls.key", testClientCertificatePrivateKey),
 		},
+		"TLS settings should be applied to the config": {
+			issuer: gen.Issuer("vault-issuer",
+				gen.SetIssuerVault(cmapi.VaultIssuer{
+					Server: "https://vault.example.com",
+					TLS: &cmmeta.ClientTLSConfig{
+						MinVersion:   "VersionTLS12",
+						CipherSuites: []string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"},
+					},
+				}),
+			),
+			expectedErr: nil,
+			checkFunc: func(cfg *vault.Config, err error) error {
+				tlsConfig := cfg.HttpClient.Transport.(*http.Transport).TLSClientConfig
+				if tlsConfig.MinVersion != tls.VersionTLS12 {
+					return fmt.Errorf("got unexpected minimum TLS version, exp=%d got=%d", tls.VersionTLS12, tlsConfig.MinVersion)
+				}
+				if !reflect.DeepEqual(tlsConfig.CipherSuites, []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256}) {
+					return fmt.Errorf("got unexpected cipher suites: %v", tlsConfig.CipherSuites)
+				}
+				return nil
+			},
+		},
+		"invalid TLS settings should error": {
+			issuer: gen.Issuer("vault-issuer",
+				gen.SetIssuerVault(cmapi.VaultIssuer{
+					Server: "https://vault.example.com",
+					TLS: &cmmeta.ClientTLSConfig{
+						MinVersion: "VersionTLS14",
+					},
+				}),
+			),
+			expectedErr: errors.New(`invalid vault TLS configuration: invalid minimum TLS version: unknown tls version "VersionTLS14"`),
+		},
 		"a bad client certificate should error": {
 			issuer: gen.Issuer("vault-issuer",
 				gen.SetIssuerVault(cmapi.VaultIssuer{
@@ -1468,10 +1461,11 @@ func TestRequestTokenWithAppRoleRef(t *testing.T) {
 				secretsLister: test.fakeLister,
 				issuer: gen.Issuer("vault-issuer",
 					gen.SetIssuerNamespace("namespace"),
+					gen.SetIssuerVault(cmapi.VaultIssuer{}),
 				),
 			}
 
-			token, err := v.requestTokenWithAppRoleRef(test.client, test.appRole)
+			token, err := v.requestTokenWithAppRoleRef(context.TODO(), test.client, test.appRole, true)
 			if ((test.expectedErr == nil) != (err == nil)) &&
 				test.expectedErr != nil &&
 				test.expectedErr.Error() != err.Error() {
@@ -1514,6 +1508,7 @@ func TestNewWithVaultNamespaces(t *testing.T) {
 				context.TODO(),
 				"k8s-ns1",
 				func(ns string) CreateToken { return nil },
+				func(ns string) UpdateSecret { return nil },
 				listers.FakeSecretListerFrom(listers.NewFakeSecretLister(),
 					listers.SetFakeSecretNamespaceListerGet(
 						&corev1.Secret{
@@ -1571,6 +1566,7 @@ func TestIsVaultInitiatedAndUnsealedIntegration(t *testing.T) {
 		context.TODO(),
 		"k8s-ns1",
 		func(ns string) CreateToken { return nil },
+		func(ns string) UpdateSecret { return nil },
 		listers.FakeSecretListerFrom(listers.NewFakeSecretLister(),
 			listers.SetFakeSecretNamespaceListerGet(
 				&corev1.Secret{
@@ -1603,7 +1599,7 @@ func TestIsVaultInitiatedAndUnsealedIntegration(t *testing.T) {
 		})
 	require.NoError(t, err)
 
-	err = v.IsVaultInitializedAndUnsealed()
+	err = v.IsVaultInitializedAndUnsealed(context.TODO())
 	require.NoError(t, err)
 }
 
@@ -1637,6 +1633,7 @@ func TestSignIntegration(t *testing.T) {
 		context.TODO(),
 		"k8s-ns1",
 		func(ns string) CreateToken { return nil },
+		func(ns string) UpdateSecret { return nil },
 		listers.FakeSecretListerFrom(listers.NewFakeSecretLister(),
 			listers.SetFakeSecretNamespaceListerGet(
 				&corev1.Secret{
@@ -1670,8 +1667,738 @@ func TestSignIntegration(t *testing.T) {
 		})
 	require.NoError(t, err)
 
-	certPEM, caPEM, err := v.Sign(csrPEM, time.Hour)
+	certPEM, caPEM, err := v.Sign(context.TODO(), csrPEM, time.Hour)
 	require.NoError(t, err)
 	require.NotEmpty(t, certPEM)
 	require.NotEmpty(t, caPEM)
 }
+
+// TestSignReturnsWhenContextIsDone demonstrates that a Vault server which never
+// responds does not block Sign beyond the deadline of its context.
+func TestSignReturnsWhenContextIsDone(t *testing.T) {
+	const vaultPath = "my_pki_mount/sign/my-role-name"
+
+	privatekey := generateRSAPrivateKey(t)
+	csrPEM := generateCSR(t, privatekey)
+
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+		select {
+		case <-request.Context().Done():
+		case <-done:
+		}
+	}))
+	defer server.Close()
+	defer close(done)
+
+	v, err := New(
+		context.TODO(),
+		"k8s-ns1",
+		func(ns string) CreateToken { return nil },
+		func(ns string) UpdateSecret { return nil },
+		listers.FakeSecretListerFrom(listers.NewFakeSecretLister(),
+			listers.SetFakeSecretNamespaceListerGet(
+				&corev1.Secret{
+					Data: map[string][]byte{
+						"key1": []byte("token1"),
+					},
+				}, nil),
+		),
+		&cmapi.Issuer{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "issuer1",
+				Namespace: "k8s-ns1",
+			},
+			Spec: v1.IssuerSpec{
+				IssuerConfig: v1.IssuerConfig{
+					Vault: &v1.VaultIssuer{
+						Server: server.URL,
+						Path:   vaultPath,
+						Auth: cmapi.VaultAuth{
+							TokenSecretRef: &cmmeta.SecretKeySelector{
+								LocalObjectReference: cmmeta.LocalObjectReference{
+									Name: "secret1",
+								},
+								Key: "key1",
+							},
+						},
+					},
+				},
+			},
+		})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, _, err = v.Sign(ctx, csrPEM, time.Hour)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 10*time.Second)
+}
+
+func TestSignParameters(t *testing.T) {
+	const vaultPath = "my_pki_mount/sign/my-role-name"
+
+	privatekey := generateRSAPrivateKey(t)
+	csrPEM := generateCSR(t, privatekey)
+
+	rootBundleData, err := bundlePEM(testIntermediateCa, testRootCa)
+	require.NoError(t, err)
+
+	defaultParameters := func(mods map[string]string) map[string]string {
+		params := map[string]string{
+			"common_name":          "test",
+			"alt_names":            "",
+			"ip_sans":              "",
+			"uri_sans":             "",
+			"ttl":                  "1h0m0s",
+			"csr":                  string(csrPEM),
+			"exclude_cn_from_sans": "true",
+		}
+		for k, v := range mods {
+			params[k] = v
+		}
+		return params
+	}
+
+	serialNumberCSRPEM, err := gen.CSRWithSigner(privatekey,
+		gen.SetCSRCommonName("test"),
+		func(c *x509.CertificateRequest) error {
+			c.Subject.SerialNumber = "CSM01-4242"
+			return nil
+		},
+	)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		vaultIssuer        cmapi.VaultIssuer
+		csrPEM             []byte
+		expectedParameters map[string]string
+	}{
+		"default parameters are sent if no parameters are configured": {
+			expectedParameters: defaultParameters(nil),
+		},
+		"the subject serial number of the CSR is sent": {
+			csrPEM: serialNumberCSRPEM,
+			expectedParameters: defaultParameters(map[string]string{
+				"csr":           string(serialNumberCSRPEM),
+				"serial_number": "CSM01-4242",
+			}),
+		},
+		"issuerRef and excludeCNFromSANs are sent": {
+			vaultIssuer: cmapi.VaultIssuer{
+				IssuerRef:         "intermediate-2024",
+				ExcludeCNFromSANs: ptr.To(false),
+			},
+			expectedParameters: defaultParameters(map[string]string{
+				"issuer_ref":           "intermediate-2024",
+				"exclude_cn_from_sans": "false",
+			}),
+		},
+		"extra parameters are sent and override computed parameters": {
+			vaultIssuer: cmapi.VaultIssuer{
+				ExtraParameters: map[string]string{
+					"ttl":                     "720h",
+					"remove_roots_from_chain": "true",
+				},
+			},
+			expectedParameters: defaultParameters(map[string]string{
+				"ttl":                     "720h",
+				"remove_roots_from_chain": "true",
+			}),
+		},
+		"extra parameters cannot override csr or format": {
+			vaultIssuer: cmapi.VaultIssuer{
+				ExtraParameters: map[string]string{
+					"csr":    "not a csr",
+					"format": "der",
+				},
+			},
+			expectedParameters: defaultParameters(nil),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var gotParameters map[string]string
+			mux := http.NewServeMux()
+			mux.HandleFunc(fmt.Sprintf("/v1/%s", vaultPath), func(response http.ResponseWriter, request *http.Request) {
+				assert.NoError(t, jsonutil.DecodeJSONFromReader(request.Body, &gotParameters))
+				_, err := response.Write(rootBundleData)
+				assert.NoError(t, err)
+			})
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			vaultIssuer := test.vaultIssuer
+			vaultIssuer.Server = server.URL
+			vaultIssuer.Path = vaultPath
+			vaultIssuer.Auth = cmapi.VaultAuth{
+				TokenSecretRef: &cmmeta.SecretKeySelector{
+					LocalObjectReference: cmmeta.LocalObjectReference{
+						Name: "secret1",
+					},
+					Key: "key1",
+				},
+			}
+
+			v, err := New(
+				context.TODO(),
+				"k8s-ns1",
+				func(ns string) CreateToken { return nil },
+				func(ns string) UpdateSecret { return nil },
+				listers.FakeSecretListerFrom(listers.NewFakeSecretLister(),
+					listers.SetFakeSecretNamespaceListerGet(
+						&corev1.Secret{
+							Data: map[string][]byte{
+								"key1": []byte("token1"),
+							},
+						}, nil),
+				),
+				gen.Issuer("issuer1",
+					gen.SetIssuerNamespace("k8s-ns1"),
+					gen.SetIssuerVault(vaultIssuer),
+				))
+			require.NoError(t, err)
+
+			reqCSRPEM := csrPEM
+			if test.csrPEM != nil {
+				reqCSRPEM = test.csrPEM
+			}
+			_, _, err = v.Sign(context.TODO(), reqCSRPEM, time.Hour)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedParameters, gotParameters)
+		})
+	}
+}
+
+func TestAppRoleLoginCacheEviction(t *testing.T) {
+	cache := &appRoleLoginCache{logins: make(map[appRoleLoginKey]*appRoleLogin)}
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{UID: "uid", ResourceVersion: "1"}}
+	start := time.Now()
+
+	for i := 0; i < maxAppRoleLogins; i++ {
+		cache.get(appRoleLoginKey{secretName: strconv.Itoa(i)}, secret, start.Add(time.Duration(i)*time.Second))
+	}
+	// Using the first login makes the second one the least recently used.
+	first := cache.get(appRoleLoginKey{secretName: "0"}, secret, start.Add(time.Hour))
+
+	cache.get(appRoleLoginKey{secretName: "new"}, secret, start.Add(2*time.Hour))
+
+	assert.Len(t, cache.logins, maxAppRoleLogins)
+	assert.NotContains(t, cache.logins, appRoleLoginKey{secretName: "1"})
+	assert.Same(t, first, cache.logins[appRoleLoginKey{secretName: "0"}])
+}
+
+// fakeAppRoleVault is a Vault server which supports App Role login,
+// response-wrapped secret IDs and signing with the issued tokens.
+type fakeAppRoleVault struct {
+	lock sync.Mutex
+
+	wrappingTokens map[string]string
+	secretIDs      sets.Set[string]
+	tokens         sets.Set[string]
+
+	unwraps int
+	logins  int
+}
+
+func newFakeAppRoleVault(t *testing.T, signPath string, signResponse []byte, secretIDs ...string) (*fakeAppRoleVault, *httptest.Server) {
+	f := &fakeAppRoleVault{
+		wrappingTokens: make(map[string]string),
+		secretIDs:      sets.New(secretIDs...),
+		tokens:         sets.New[string](),
+	}
+
+	writeError := func(response http.ResponseWriter, code int, msg string) {
+		response.WriteHeader(code)
+		_, err := fmt.Fprintf(response, `{"errors":[%q]}`, msg)
+		assert.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/sys/wrapping/unwrap", func(response http.ResponseWriter, request *http.Request) {
+		f.lock.Lock()
+		defer f.lock.Unlock()
+
+		wrappingToken := request.Header.Get("X-Vault-Token")
+		secretID, ok := f.wrappingTokens[wrappingToken]
+		if !ok {
+			writeError(response, http.StatusBadRequest, "wrapping token is not valid or does not exist")
+			return
+		}
+		delete(f.wrappingTokens, wrappingToken)
+		f.unwraps++
+
+		_, err := fmt.Fprintf(response, `{"data":{"secret_id":%q}}`, secretID)
+		assert.NoError(t, err)
+	})
+	mux.HandleFunc("/v1/auth/approle/login", func(response http.ResponseWriter, request *http.Request) {
+		f.lock.Lock()
+		defer f.lock.Unlock()
+
+		var parameters map[string]string
+		assert.NoError(t, jsonutil.DecodeJSONFromReader(request.Body, &parameters))
+		if !f.secretIDs.Has(parameters["secret_id"]) {
+			writeError(response, http.StatusBadRequest, "invalid secret id")
+			return
+		}
+		f.logins++
+
+		token := fmt.Sprintf("token-%d", f.logins)
+		f.tokens.Insert(token)
+		_, err := fmt.Fprintf(response, `{"auth":{"client_token":%q,"lease_duration":3600}}`, token)
+		assert.NoError(t, err)
+	})
+	mux.HandleFunc(fmt.Sprintf("/v1/%s", signPath), func(response http.ResponseWriter, request *http.Request) {
+		f.lock.Lock()
+		defer f.lock.Unlock()
+
+		if !f.tokens.Has(request.Header.Get("X-Vault-Token")) {
+			writeError(response, http.StatusForbidden, "permission denied")
+			return
+		}
+
+		_, err := response.Write(signResponse)
+		assert.NoError(t, err)
+	})
+
+	return f, httptest.NewServer(mux)
+}
+
+// rotate replaces all valid secret IDs and revokes all issued tokens.
+func (f *fakeAppRoleVault) rotate(secretIDs ...string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.secretIDs = sets.New(secretIDs...)
+	f.tokens = sets.New[string]()
+}
+
+// revokeTokens revokes all issued tokens.
+func (f *fakeAppRoleVault) revokeTokens() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.tokens = sets.New[string]()
+}
+
+// wrap returns a single use wrapping token for the secret ID.
+func (f *fakeAppRoleVault) wrap(secretID string) string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	wrappingToken := fmt.Sprintf("wrapped-%s", secretID)
+	f.wrappingTokens[wrappingToken] = secretID
+	return wrappingToken
+}
+
+// TestSignWithAppRole demonstrates that App Role tokens are reused across
+// Vault clients, and that a new token is requested with the current secret
+// ID when the App Role Secret changes or Vault rejects the cached token.
+func TestSignWithAppRole(t *testing.T) {
+	const vaultPath = "my_pki_mount/sign/my-role-name"
+
+	privatekey := generateRSAPrivateKey(t)
+	csrPEM := generateCSR(t, privatekey)
+
+	rootBundleData, err := bundlePEM(testIntermediateCa, testRootCa)
+	require.NoError(t, err)
+
+	setup := func(t *testing.T, responseWrapped bool, secretIDs ...string) (*fakeAppRoleVault, *corev1.Secret, func() (Interface, error)) {
+		f, server := newFakeAppRoleVault(t, vaultPath, rootBundleData, secretIDs...)
+		t.Cleanup(server.Close)
+
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "approle",
+				Namespace:       "k8s-ns1",
+				UID:             "approle-uid",
+				ResourceVersion: "1",
+			},
+			Data: map[string][]byte{},
+		}
+		issuer := gen.Issuer("issuer1",
+			gen.SetIssuerNamespace("k8s-ns1"),
+			gen.SetIssuerVault(cmapi.VaultIssuer{
+				Server: server.URL,
+				Path:   vaultPath,
+				Auth: cmapi.VaultAuth{
+					AppRole: &cmapi.VaultAppRole{
+						Path:   "approle",
+						RoleId: "my-role-id",
+						SecretRef: cmmeta.SecretKeySelector{
+							LocalObjectReference: cmmeta.LocalObjectReference{
+								Name: "approle",
+							},
+							Key: "secret-id",
+						},
+						ResponseWrapped: responseWrapped,
+					},
+				},
+			}),
+		)
+
+		// Updates of the Secret are immediately visible to the lister.
+		updateSecret := func(_ context.Context, updated *corev1.Secret, _ metav1.UpdateOptions) (*corev1.Secret, error) {
+			resourceVersion, err := strconv.Atoi(secret.ResourceVersion)
+			require.NoError(t, err)
+			*secret = *updated.DeepCopy()
+			secret.ResourceVersion = strconv.Itoa(resourceVersion + 1)
+			return secret, nil
+		}
+
+		newClient := func() (Interface, error) {
+			return New(
+				context.TODO(),
+				"k8s-ns1",
+				func(ns string) CreateToken { return nil },
+				func(ns string) UpdateSecret { return updateSecret },
+				listers.FakeSecretListerFrom(listers.NewFakeSecretLister(),
+					listers.SetFakeSecretNamespaceListerGet(secret, nil),
+				),
+				issuer,
+			)
+		}
+
+		return f, secret, newClient
+	}
+
+	sign := func(t *testing.T, newClient func() (Interface, error)) {
+		v, err := newClient()
+		require.NoError(t, err)
+
+		_, _, err = v.Sign(context.TODO(), csrPEM, time.Hour)
+		require.NoError(t, err)
+	}
+
+	t.Run("a token obtained with a plain secret ID is reused", func(t *testing.T) {
+		f, secret, newClient := setup(t, false, "secret-id-1")
+		secret.Data["secret-id"] = []byte("secret-id-1")
+
+		sign(t, newClient)
+		sign(t, newClient)
+
+		assert.Equal(t, 1, f.logins)
+		assert.Equal(t, 0, f.unwraps)
+	})
+
+	t.Run("a wrapped secret ID is only unwrapped once", func(t *testing.T) {
+		f, secret, newClient := setup(t, true, "secret-id-1")
+		secret.Data["secret-id"] = []byte(f.wrap("secret-id-1"))
+
+		sign(t, newClient)
+		f.revokeTokens()
+		sign(t, newClient)
+
+		assert.Equal(t, 2, f.logins)
+		assert.Equal(t, 1, f.unwraps)
+	})
+
+	t.Run("a rotated wrapped secret ID is unwrapped after the Secret changes", func(t *testing.T) {
+		f, secret, newClient := setup(t, true, "secret-id-1")
+		secret.Data["secret-id"] = []byte(f.wrap("secret-id-1"))
+
+		sign(t, newClient)
+		f.rotate("secret-id-2")
+		secret.Data["secret-id"] = []byte(f.wrap("secret-id-2"))
+		secret.ResourceVersion = "3"
+		sign(t, newClient)
+
+		assert.Equal(t, 2, f.logins)
+		assert.Equal(t, 2, f.unwraps)
+	})
+
+	t.Run("an unwrapped secret ID is persisted in the Secret and used after a restart", func(t *testing.T) {
+		f, secret, newClient := setup(t, true, "secret-id-1")
+		secret.Data["secret-id"] = []byte(f.wrap("secret-id-1"))
+
+		sign(t, newClient)
+		assert.Equal(t, "secret-id-1", string(secret.Data["secret-id"]))
+		assert.Equal(t, secretIDHash("secret-id-1"), secret.Annotations[cmapi.VaultAppRoleUnwrappedSecretIDsAnnotationKey])
+
+		// Forget all cached logins, as after a restart of cert-manager.
+		appRoleLogins.lock.Lock()
+		appRoleLogins.logins = make(map[appRoleLoginKey]*appRoleLogin)
+		appRoleLogins.lock.Unlock()
+
+		sign(t, newClient)
+		assert.Equal(t, 2, f.logins)
+		assert.Equal(t, 1, f.unwraps)
+	})
+
+	t.Run("a secret ID rotated during issuance is used to log in again", func(t *testing.T) {
+		f, secret, newClient := setup(t, false, "secret-id-1")
+		secret.Data["secret-id"] = []byte("secret-id-1")
+
+		v, err := newClient()
+		require.NoError(t, err)
+
+		f.rotate("secret-id-2")
+		secret.Data["secret-id"] = []byte("secret-id-2")
+		secret.ResourceVersion = "2"
+
+		_, _, err = v.Sign(context.TODO(), csrPEM, time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, 2, f.logins)
+	})
+
+	t.Run("a revoked token is replaced during issuance", func(t *testing.T) {
+		f, secret, newClient := setup(t, false, "secret-id-1")
+		secret.Data["secret-id"] = []byte("secret-id-1")
+
+		v, err := newClient()
+		require.NoError(t, err)
+
+		f.revokeTokens()
+
+		_, _, err = v.Sign(context.TODO(), csrPEM, time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, 2, f.logins)
+	})
+
+	t.Run("an invalid secret ID returns a LoginError", func(t *testing.T) {
+		_, secret, newClient := setup(t, false, "secret-id-1")
+		secret.Data["secret-id"] = []byte("not-a-secret-id")
+
+		_, err := newClient()
+		var loginErr *LoginError
+		assert.ErrorAs(t, err, &loginErr)
+	})
+}
+
+func TestRevoke(t *testing.T) {
+	var gotParameters map[string]string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/my_pki_mount/revoke", func(response http.ResponseWriter, request *http.Request) {
+		assert.NoError(t, jsonutil.DecodeJSONFromReader(request.Body, &gotParameters))
+		if gotParameters["serial_number"] == "00:00" {
+			response.WriteHeader(http.StatusBadRequest)
+			_, err := response.Write([]byte(`{"errors":["certificate with serial 00:00 not found"]}`))
+			assert.NoError(t, err)
+			return
+		}
+		_, err := response.Write([]byte(`{"data":{"revocation_time":1700000000}}`))
+		assert.NoError(t, err)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	v, err := New(
+		context.TODO(),
+		"k8s-ns1",
+		func(ns string) CreateToken { return nil },
+		func(ns string) UpdateSecret { return nil },
+		listers.FakeSecretListerFrom(listers.NewFakeSecretLister(),
+			listers.SetFakeSecretNamespaceListerGet(
+				&corev1.Secret{
+					Data: map[string][]byte{
+						"key1": []byte("token1"),
+					},
+				}, nil),
+		),
+		gen.Issuer("issuer1",
+			gen.SetIssuerNamespace("k8s-ns1"),
+			gen.SetIssuerVault(cmapi.VaultIssuer{
+				Server: server.URL,
+				Path:   "my_pki_mount/sign/my-role-name",
+				Auth: cmapi.VaultAuth{
+					TokenSecretRef: &cmmeta.SecretKeySelector{
+						LocalObjectReference: cmmeta.LocalObjectReference{
+							Name: "secret1",
+						},
+						Key: "key1",
+					},
+				},
+			}),
+		))
+	require.NoError(t, err)
+
+	require.NoError(t, v.Revoke(context.TODO(), "1f:02:9a"))
+	assert.Equal(t, map[string]string{"serial_number": "1f:02:9a"}, gotParameters)
+
+	err = v.Revoke(context.TODO(), "00:00")
+	assert.Error(t, err, "expected an error when Vault fails to revoke the certificate")
+}
+
+func TestPKIMountPath(t *testing.T) {
+	tests := map[string]string{
+		"pki/sign/my-role":                  "pki",
+		"/pki/sign/my-role":                 "pki",
+		"my/nested/pki/sign/my-role":        "my/nested/pki",
+		"pki/sign-verbatim/my-role":         "pki",
+		"pki/issuer/my-issuer/sign/my-role": "pki",
+		"pki/root/sign-intermediate":        "pki",
+		"pki":                               "pki",
+	}
+
+	for signPath, exp := range tests {
+		t.Run(signPath, func(t *testing.T) {
+			assert.Equal(t, exp, pkiMountPath(signPath))
+		})
+	}
+}
+
+func TestSignEndpointPath(t *testing.T) {
+	tests := map[string]struct {
+		path     string
+		mode     cmapi.VaultSignMode
+		expected string
+		err      string
+	}{
+		"the path is used as it is without a sign mode": {
+			path:     "pki/root/sign-intermediate",
+			expected: "pki/root/sign-intermediate",
+		},
+		"the sign endpoint is used in Role mode": {
+			path:     "pki/sign-verbatim/my-role",
+			mode:     cmapi.VaultSignModeRole,
+			expected: "pki/sign/my-role",
+		},
+		"the sign-verbatim endpoint is used in Verbatim mode": {
+			path:     "/pki/sign/my-role",
+			mode:     cmapi.VaultSignModeVerbatim,
+			expected: "pki/sign-verbatim/my-role",
+		},
+		"the issuer of a role endpoint is kept": {
+			path:     "pki/issuer/my-issuer/sign/my-role",
+			mode:     cmapi.VaultSignModeVerbatim,
+			expected: "pki/issuer/my-issuer/sign-verbatim/my-role",
+		},
+		"a sign mode requires the endpoint of a role": {
+			path: "pki/root/sign-intermediate",
+			mode: cmapi.VaultSignModeRole,
+			err:  `signMode "Role" requires the path to be the sign or sign-verbatim endpoint of a role, but got "pki/root/sign-intermediate"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			signPath, err := signEndpointPath(test.path, test.mode)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, signPath)
+		})
+	}
+}
+
+// TestSignModes demonstrates that the sign mode selects the endpoint used to
+// sign certificates, and that the rejection of a request by the constraints
+// of a role is returned as a RejectedError.
+func TestSignModes(t *testing.T) {
+	privatekey := generateRSAPrivateKey(t)
+	allowedCSRPEM, err := gen.CSRWithSigner(privatekey,
+		gen.SetCSRCommonName("www.example.com"),
+		gen.SetCSRDNSNames("www.example.com", "api.example.com"),
+		gen.SetCSREmails([]string{"admin@example.com"}),
+	)
+	require.NoError(t, err)
+	disallowedCSRPEM, err := gen.CSRWithSigner(privatekey,
+		gen.SetCSRCommonName("www.example.org"),
+		gen.SetCSRDNSNames("www.example.org"),
+	)
+	require.NoError(t, err)
+
+	rootBundleData, err := bundlePEM(testIntermediateCa, testRootCa)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		mode   cmapi.VaultSignMode
+		csrPEM []byte
+
+		expectedEndpoint string
+		expectedAltNames string
+		expectedErr      string
+	}{
+		"Role mode signs using the role, which allows the requested names": {
+			mode:             cmapi.VaultSignModeRole,
+			csrPEM:           allowedCSRPEM,
+			expectedEndpoint: "sign",
+			expectedAltNames: "www.example.com,api.example.com,admin@example.com",
+		},
+		"Role mode returns the rejection of a name by the role": {
+			mode:             cmapi.VaultSignModeRole,
+			csrPEM:           disallowedCSRPEM,
+			expectedEndpoint: "sign",
+			expectedAltNames: "www.example.org",
+			expectedErr:      "vault rejected the request to sign the certificate: common name www.example.org not allowed by this role",
+		},
+		"Verbatim mode signs the CSR as it is, bypassing the role": {
+			mode:             cmapi.VaultSignModeVerbatim,
+			csrPEM:           disallowedCSRPEM,
+			expectedEndpoint: "sign-verbatim",
+			expectedAltNames: "www.example.org",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var gotEndpoint string
+			var gotParameters map[string]string
+			mux := http.NewServeMux()
+			// The fake role only allows subdomains of example.com, which is
+			// only enforced by the sign endpoint.
+			mux.HandleFunc("/v1/pki/{endpoint}/my-role", func(response http.ResponseWriter, request *http.Request) {
+				gotEndpoint = request.PathValue("endpoint")
+				assert.NoError(t, jsonutil.DecodeJSONFromReader(request.Body, &gotParameters))
+				if gotEndpoint == "sign" && !strings.HasSuffix(gotParameters["common_name"], ".example.com") {
+					response.WriteHeader(http.StatusBadRequest)
+					_, err := fmt.Fprintf(response, `{"errors":["common name %s not allowed by this role"]}`, gotParameters["common_name"])
+					assert.NoError(t, err)
+					return
+				}
+				_, err := response.Write(rootBundleData)
+				assert.NoError(t, err)
+			})
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			v, err := New(
+				context.TODO(),
+				"k8s-ns1",
+				func(ns string) CreateToken { return nil },
+				func(ns string) UpdateSecret { return nil },
+				listers.FakeSecretListerFrom(listers.NewFakeSecretLister(),
+					listers.SetFakeSecretNamespaceListerGet(
+						&corev1.Secret{
+							Data: map[string][]byte{
+								"key1": []byte("token1"),
+							},
+						}, nil),
+				),
+				gen.Issuer("issuer1",
+					gen.SetIssuerNamespace("k8s-ns1"),
+					gen.SetIssuerVault(cmapi.VaultIssuer{
+						Server:   server.URL,
+						Path:     "pki/sign/my-role",
+						SignMode: test.mode,
+						Auth: cmapi.VaultAuth{
+							TokenSecretRef: &cmmeta.SecretKeySelector{
+								LocalObjectReference: cmmeta.LocalObjectReference{
+									Name: "secret1",
+								},
+								Key: "key1",
+							},
+						},
+					}),
+				))
+			require.NoError(t, err)
+
+			certPEM, _, err := v.Sign(context.TODO(), test.csrPEM, time.Hour)
+			assert.Equal(t, test.expectedEndpoint, gotEndpoint)
+			assert.Equal(t, test.expectedAltNames, gotParameters["alt_names"])
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+				var rejectedErr *RejectedError
+				assert.ErrorAs(t, err, &rejectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotEmpty(t, certPEM)
+		})
+	}
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package commonname implements an admission plugin which defaults the
+// common name of Certificates which set `spec.commonNameFromFirstDNSName` to
+// their first DNS name.
+package commonname
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/pkg/webhook/admission"
+)
+
+type commonNameFromFirstDNSName struct {
+	*admission.Handler
+}
+
+var _ admission.ValidationInterface = &commonNameFromFirstDNSName{}
+var _ admission.MutationInterface = &commonNameFromFirstDNSName{}
+
+func NewPlugin() admission.Interface {
+	return &commonNameFromFirstDNSName{
+		Handler: admission.NewHandler(admissionv1.Create, admissionv1.Update),
+	}
+}
+
+func isCertificate(request admissionv1.AdmissionRequest) bool {
+	return request.RequestResource.Group == "cert-manager.io" &&
+		request.RequestResource.Resource == "certificates" &&
+		request.SubResource == ""
+}
+
+// Mutate sets `spec.commonName` to the effective common name of the
+// Certificate, so that the common name defaulted from the first DNS name is
+// visible on the resource.
+// On update, a common name which was defaulted and is not changed by the
+// update is defaulted again, so that it follows changes of the DNS names and
+// is removed when `spec.commonNameFromFirstDNSName` is unset. A common name
+// which was set to the first DNS name explicitly cannot be told apart from a
+// defaulted one, and is treated the same way.
+func (p *commonNameFromFirstDNSName) Mutate(ctx context.Context, request admissionv1.AdmissionRequest, obj *unstructured.Unstructured) error {
+	if !isCertificate(request) {
+		return nil
+	}
+
+	var crt cmapi.Certificate
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &crt); err != nil {
+		return err
+	}
+
+	if request.Operation == admissionv1.Update && crt.Spec.CommonName != "" && len(request.OldObject.Raw) > 0 {
+		var oldCrt cmapi.Certificate
+		if err := json.Unmarshal(request.OldObject.Raw, &oldCrt); err != nil {
+			return err
+		}
+		if crt.Spec.CommonName == oldCrt.Spec.CommonName && oldCrt.Spec.CommonName == defaultCommonName(oldCrt.Spec) {
+			crt.Spec.CommonName = ""
+			unstructured.RemoveNestedField(obj.Object, "spec", "commonName")
+		}
+	}
+
+	if crt.Spec.CommonName != "" {
+		return nil
+	}
+
+	commonName := defaultCommonName(crt.Spec)
+	if commonName == "" {
+		return nil
+	}
+	return unstructured.SetNestedField(obj.Object, commonName, "spec", "commonName")
+}
+
+// defaultCommonName returns the common name defaulted for the Certificate
+// spec, ignoring its `commonName`.
+func defaultCommonName(spec cmapi.CertificateSpec) string {
+	spec.CommonName = ""
+	return pki.EffectiveCommonName(spec)
+}
+
+// Validate warns when `spec.commonNameFromFirstDNSName` is set but the common
+// name could not be defaulted.
+func (p *commonNameFromFirstDNSName) Validate(ctx context.Context, request admissionv1.AdmissionRequest, oldObj, obj runtime.Object) ([]string, error) {
+	if !isCertificate(request) {
+		return nil, nil
+	}
+
+	crt, ok := obj.(*certmanager.Certificate)
+	if !ok {
+		return nil, fmt.Errorf("internal error: object in admission request is not of type *certmanager.Certificate")
+	}
+
+	spec := crt.Spec
+	if !spec.CommonNameFromFirstDNSName || spec.CommonName != "" {
+		return nil, nil
+	}
+	switch {
+	case spec.LiteralSubject != "":
+		return []string{"spec.commonNameFromFirstDNSName has no effect when spec.literalSubject is set"}, nil
+	case len(spec.DNSNames) == 0:
+		return []string{"spec.commonNameFromFirstDNSName has no effect as spec.dnsNames is empty"}, nil
+	case len(spec.DNSNames[0]) > pki.MaxCommonNameLength:
+		return []string{fmt.Sprintf("spec.commonName was not defaulted from spec.dnsNames[0] as it is longer than %d characters", pki.MaxCommonNameLength)}, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commonname
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+var certificatesResource = &metav1.GroupVersionResource{
+	Group:    "cert-manager.io",
+	Version:  "v1",
+	Resource: "certificates",
+}
+
+// longName is a DNS name which is longer than the 64 characters allowed in a
+// common name.
+var longName = strings.Repeat("a", 60) + ".example.com"
+
+func TestMutate(t *testing.T) {
+	tests := map[string]struct {
+		resource    *metav1.GroupVersionResource
+		subResource string
+		spec        cmapi.CertificateSpec
+
+		expectedCommonName string
+	}{
+		"the common name is not defaulted if commonNameFromFirstDNSName is not set": {
+			spec: cmapi.CertificateSpec{DNSNames: []string{"example.com"}},
+		},
+		"the common name is defaulted to the first dnsName": {
+			spec:               cmapi.CertificateSpec{CommonNameFromFirstDNSName: true, DNSNames: []string{"example.com", "www.example.com"}},
+			expectedCommonName: "example.com",
+		},
+		"the common name is defaulted to a wildcard first dnsName": {
+			spec:               cmapi.CertificateSpec{CommonNameFromFirstDNSName: true, DNSNames: []string{"*.example.com", "example.com"}},
+			expectedCommonName: "*.example.com",
+		},
+		"a common name which is set is not changed": {
+			spec:               cmapi.CertificateSpec{CommonName: "cn", CommonNameFromFirstDNSName: true, DNSNames: []string{"example.com"}},
+			expectedCommonName: "cn",
+		},
+		"the common name is not defaulted to a first dnsName longer than 64 characters": {
+			spec: cmapi.CertificateSpec{CommonNameFromFirstDNSName: true, DNSNames: []string{longName, "example.com"}},
+		},
+		"the common name is not defaulted if literalSubject is set": {
+			spec: cmapi.CertificateSpec{CommonNameFromFirstDNSName: true, LiteralSubject: "O=example", DNSNames: []string{"example.com"}},
+		},
+		"the status sub-resource is ignored": {
+			subResource: "status",
+			spec:        cmapi.CertificateSpec{CommonNameFromFirstDNSName: true, DNSNames: []string{"example.com"}},
+		},
+		"other resources are ignored": {
+			resource: &metav1.GroupVersionResource{Group: "cert-manager.io", Version: "v1", Resource: "certificaterequests"},
+			spec:     cmapi.CertificateSpec{CommonNameFromFirstDNSName: true, DNSNames: []string{"example.com"}},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			resource := test.resource
+			if resource == nil {
+				resource = certificatesResource
+			}
+
+			unstr, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&cmapi.Certificate{Spec: test.spec})
+			require.NoError(t, err)
+			obj := &unstructured.Unstructured{Object: unstr}
+
+			err = NewPlugin().(*commonNameFromFirstDNSName).Mutate(context.Background(), admissionv1.AdmissionRequest{
+				Operation:       admissionv1.Create,
+				RequestResource: resource,
+				SubResource:     test.subResource,
+			}, obj)
+			require.NoError(t, err)
+
+			commonName, _, err := unstructured.NestedString(obj.Object, "spec", "commonName")
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedCommonName, commonName)
+		})
+	}
+}
+
+func TestMutateUpdate(t *testing.T) {
+	tests := map[string]struct {
+		oldSpec cmapi.CertificateSpec
+		spec    cmapi.CertificateSpec
+
+		expectedCommonName string
+	}{
+		"a defaulted common name follows the first dnsName": {
+			oldSpec:            cmapi.CertificateSpec{CommonName: "example.com", CommonNameFromFirstDNSName: true, DNSNames: []string{"example.com"}},
+			spec:               cmapi.CertificateSpec{CommonName: "example.com", CommonNameFromFirstDNSName: true, DNSNames: []string{"www.example.com", "example.com"}},
+			expectedCommonName: "www.example.com",
+		},
+		"a defaulted common name is removed when commonNameFromFirstDNSName is unset": {
+			oldSpec: cmapi.CertificateSpec{CommonName: "example.com", CommonNameFromFirstDNSName: true, DNSNames: []string{"example.com"}},
+			spec:    cmapi.CertificateSpec{CommonName: "example.com", DNSNames: []string{"example.com"}},
+		},
+		"a defaulted common name is removed when the first dnsName is longer than 64 characters": {
+			oldSpec: cmapi.CertificateSpec{CommonName: "example.com", CommonNameFromFirstDNSName: true, DNSNames: []string{"example.com"}},
+			spec:    cmapi.CertificateSpec{CommonName: "example.com", CommonNameFromFirstDNSName: true, DNSNames: []string{longName}},
+		},
+		"a common name changed by the update is not changed": {
+			oldSpec:            cmapi.CertificateSpec{CommonName: "example.com", CommonNameFromFirstDNSName: true, DNSNames: []string{"example.com"}},
+			spec:               cmapi.CertificateSpec{CommonName: "cn", CommonNameFromFirstDNSName: true, DNSNames: []string{"www.example.com"}},
+			expectedCommonName: "cn",
+		},
+		"a common name which was not defaulted is not changed": {
+			oldSpec:            cmapi.CertificateSpec{CommonName: "cn", CommonNameFromFirstDNSName: true, DNSNames: []string{"example.com"}},
+			spec:               cmapi.CertificateSpec{CommonName: "cn", CommonNameFromFirstDNSName: true, DNSNames: []string{"www.example.com"}},
+			expectedCommonName: "cn",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			oldRaw, err := json.Marshal(&cmapi.Certificate{Spec: test.oldSpec})
+			require.NoError(t, err)
+
+			unstr, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&cmapi.Certificate{Spec: test.spec})
+			require.NoError(t, err)
+			obj := &unstructured.Unstructured{Object: unstr}
+
+			err = NewPlugin().(*commonNameFromFirstDNSName).Mutate(context.Background(), admissionv1.AdmissionRequest{
+				Operation:       admissionv1.Update,
+				RequestResource: certificatesResource,
+				OldObject:       runtime.RawExtension{Raw: oldRaw},
+			}, obj)
+			require.NoError(t, err)
+
+			commonName, _, err := unstructured.NestedString(obj.Object, "spec", "commonName")
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedCommonName, commonName)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		spec certmanager.CertificateSpec
+
+		expectedWarnings []string
+	}{
+		"no warnings are returned if commonNameFromFirstDNSName is not set": {
+			spec: certmanager.CertificateSpec{DNSNames: []string{longName}},
+		},
+		"no warnings are returned if the common name was defaulted": {
+			spec: certmanager.CertificateSpec{CommonName: "*.example.com", CommonNameFromFirstDNSName: true, DNSNames: []string{"*.example.com"}},
+		},
+		"a warning is returned if the first dnsName is longer than 64 characters": {
+			spec:             certmanager.CertificateSpec{CommonNameFromFirstDNSName: true, DNSNames: []string{longName, "example.com"}},
+			expectedWarnings: []string{"spec.commonName was not defaulted from spec.dnsNames[0] as it is longer than 64 characters"},
+		},
+		"a warning is returned if literalSubject is set": {
+			spec:             certmanager.CertificateSpec{CommonNameFromFirstDNSName: true, LiteralSubject: "O=example", DNSNames: []string{"example.com"}},
+			expectedWarnings: []string{"spec.commonNameFromFirstDNSName has no effect when spec.literalSubject is set"},
+		},
+		"a warning is returned if there are no dnsNames": {
+			spec:             certmanager.CertificateSpec{CommonNameFromFirstDNSName: true, URIs: []string{"spiffe://example.com/foo"}},
+			expectedWarnings: []string{"spec.commonNameFromFirstDNSName has no effect as spec.dnsNames is empty"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			warnings, err := NewPlugin().(*commonNameFromFirstDNSName).Validate(context.Background(), admissionv1.AdmissionRequest{
+				Operation:       admissionv1.Create,
+				RequestResource: certificatesResource,
+			}, nil, &certmanager.Certificate{Spec: test.spec})
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedWarnings, warnings)
+		})
+	}
+}
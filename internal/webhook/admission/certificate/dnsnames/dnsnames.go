@@ -0,0 +1,71 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dnsnames implements an admission plugin which removes DNS names
+// from Certificates which are equal to an earlier DNS name once normalized,
+// that is ignoring case, trailing dots and the form of internationalized
+// names. This is the same normalization used when generating CSRs and when
+// deciding whether a Certificate must be re-issued.
+package dnsnames
+
+import (
+	"context"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/pkg/webhook/admission"
+)
+
+type deduplicateDNSNames struct {
+	*admission.Handler
+}
+
+var _ admission.MutationInterface = &deduplicateDNSNames{}
+
+func NewPlugin() admission.Interface {
+	return &deduplicateDNSNames{
+		Handler: admission.NewHandler(admissionv1.Create, admissionv1.Update),
+	}
+}
+
+func isCertificate(request admissionv1.AdmissionRequest) bool {
+	return request.RequestResource.Group == "cert-manager.io" &&
+		request.RequestResource.Resource == "certificates" &&
+		request.SubResource == ""
+}
+
+// Mutate removes duplicate DNS names from `spec.dnsNames`, keeping the first
+// occurrence of each name in the form it was given.
+func (p *deduplicateDNSNames) Mutate(ctx context.Context, request admissionv1.AdmissionRequest, obj *unstructured.Unstructured) error {
+	if !isCertificate(request) {
+		return nil
+	}
+
+	var crt cmapi.Certificate
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &crt); err != nil {
+		return err
+	}
+
+	dnsNames := pki.DeduplicateDNSNames(crt.Spec.DNSNames)
+	if len(dnsNames) == len(crt.Spec.DNSNames) {
+		return nil
+	}
+	return unstructured.SetNestedStringSlice(obj.Object, dnsNames, "spec", "dnsNames")
+}
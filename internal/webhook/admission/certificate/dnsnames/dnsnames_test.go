@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dnsnames
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+var certificatesResource = &metav1.GroupVersionResource{
+	Group:    "cert-manager.io",
+	Version:  "v1",
+	Resource: "certificates",
+}
+
+func TestMutate(t *testing.T) {
+	tests := map[string]struct {
+		resource    *metav1.GroupVersionResource
+		subResource string
+		dnsNames    []string
+
+		expectedDNSNames []string
+	}{
+		"no dnsNames": {},
+		"distinct dnsNames are not changed": {
+			dnsNames:         []string{"www.example.com", "example.com"},
+			expectedDNSNames: []string{"www.example.com", "example.com"},
+		},
+		"dnsNames differing only in case are removed, keeping the first": {
+			dnsNames:         []string{"WWW.example.com", "www.example.com", "example.com", "www.EXAMPLE.com"},
+			expectedDNSNames: []string{"WWW.example.com", "example.com"},
+		},
+		"dnsNames differing only by a trailing dot are removed": {
+			dnsNames:         []string{"example.com", "example.com."},
+			expectedDNSNames: []string{"example.com"},
+		},
+		"U-label and A-label forms of a dnsName are removed": {
+			dnsNames:         []string{"xn--bcher-kva.example.com", "bücher.example.com"},
+			expectedDNSNames: []string{"xn--bcher-kva.example.com"},
+		},
+		"dnsNames covered by a wildcard are kept": {
+			dnsNames:         []string{"*.example.com", "*.Example.com", "www.example.com"},
+			expectedDNSNames: []string{"*.example.com", "www.example.com"},
+		},
+		"the status sub-resource is ignored": {
+			subResource:      "status",
+			dnsNames:         []string{"example.com", "EXAMPLE.com"},
+			expectedDNSNames: []string{"example.com", "EXAMPLE.com"},
+		},
+		"other resources are ignored": {
+			resource:         &metav1.GroupVersionResource{Group: "cert-manager.io", Version: "v1", Resource: "certificaterequests"},
+			dnsNames:         []string{"example.com", "EXAMPLE.com"},
+			expectedDNSNames: []string{"example.com", "EXAMPLE.com"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			resource := test.resource
+			if resource == nil {
+				resource = certificatesResource
+			}
+
+			unstr, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&cmapi.Certificate{Spec: cmapi.CertificateSpec{DNSNames: test.dnsNames}})
+			require.NoError(t, err)
+			obj := &unstructured.Unstructured{Object: unstr}
+
+			err = NewPlugin().(*deduplicateDNSNames).Mutate(context.Background(), admissionv1.AdmissionRequest{
+				Operation:       admissionv1.Update,
+				RequestResource: resource,
+				SubResource:     test.subResource,
+			}, obj)
+			require.NoError(t, err)
+
+			dnsNames, _, err := unstructured.NestedStringSlice(obj.Object, "spec", "dnsNames")
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedDNSNames, dnsNames)
+		})
+	}
+}
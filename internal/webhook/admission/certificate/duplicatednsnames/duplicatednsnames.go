@@ -0,0 +1,158 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package duplicatednsnames implements an admission plugin which detects
+// Certificates created with DNS names that are already requested by another
+// Certificate, in any namespace, from the same ClusterIssuer.
+// Such duplicates are easily created by different teams sharing a
+// ClusterIssuer, and count towards the duplicate certificate rate limits of
+// public ACME servers.
+package duplicatednsnames
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/pkg/webhook/admission"
+)
+
+type duplicateDNSNames struct {
+	*admission.Handler
+
+	// strict rejects Certificates with duplicate DNS names instead of
+	// returning a warning.
+	strict bool
+
+	certificates cmlisters.CertificateLister
+	hasSynced    cache.InformerSynced
+}
+
+var _ admission.ValidationInterface = &duplicateDNSNames{}
+
+// NewPlugin returns a plugin which warns about, or in strict mode rejects,
+// Certificates created with DNS names that overlap with those of another
+// Certificate, in any namespace, issued by the same ClusterIssuer.
+// Certificates are not checked until hasSynced returns true.
+func NewPlugin(strict bool, certificates cmlisters.CertificateLister, hasSynced cache.InformerSynced) admission.Interface {
+	return &duplicateDNSNames{
+		Handler:      admission.NewHandler(admissionv1.Create),
+		strict:       strict,
+		certificates: certificates,
+		hasSynced:    hasSynced,
+	}
+}
+
+func (p *duplicateDNSNames) Validate(ctx context.Context, request admissionv1.AdmissionRequest, oldObj, obj runtime.Object) ([]string, error) {
+	if request.RequestResource.Group != "cert-manager.io" ||
+		request.RequestResource.Resource != "certificates" ||
+		request.SubResource != "" {
+		return nil, nil
+	}
+
+	crt, ok := obj.(*certmanager.Certificate)
+	if !ok {
+		return nil, fmt.Errorf("internal error: object in admission request is not of type *certmanager.Certificate")
+	}
+
+	issuerRef := crt.Spec.IssuerRef
+	if issuerRef.Kind != cmapi.ClusterIssuerKind || !isCertManagerGroup(issuerRef.Group) || len(crt.Spec.DNSNames) == 0 {
+		return nil, nil
+	}
+
+	// Until the cache is synced, a duplicate could be missed, so no
+	// Certificate is rejected by mistake.
+	if !p.hasSynced() {
+		return nil, nil
+	}
+
+	existing, err := p.certificates.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(existing, func(i, j int) bool {
+		if existing[i].Namespace != existing[j].Namespace {
+			return existing[i].Namespace < existing[j].Namespace
+		}
+		return existing[i].Name < existing[j].Name
+	})
+
+	var (
+		warnings []string
+		errs     field.ErrorList
+	)
+	fldPath := field.NewPath("spec", "dnsNames")
+	for i, dnsName := range crt.Spec.DNSNames {
+		for _, other := range existing {
+			// A Certificate which is being re-created may still be cached.
+			if (other.Namespace == request.Namespace && other.Name == crt.Name) ||
+				other.Spec.IssuerRef.Kind != cmapi.ClusterIssuerKind ||
+				!isCertManagerGroup(other.Spec.IssuerRef.Group) ||
+				other.Spec.IssuerRef.Name != issuerRef.Name {
+				continue
+			}
+
+			otherDNSName, ok := overlappingDNSName(dnsName, other.Spec.DNSNames)
+			if !ok {
+				continue
+			}
+
+			msg := fmt.Sprintf("%q overlaps with %q requested by Certificate %s/%s from the same ClusterIssuer %q",
+				dnsName, otherDNSName, other.Namespace, other.Name, issuerRef.Name)
+			if p.strict {
+				errs = append(errs, field.Forbidden(fldPath.Index(i), msg))
+			} else {
+				warnings = append(warnings, fmt.Sprintf("%s: %s", fldPath.Index(i), msg))
+			}
+			// Naming a single existing Certificate is enough to find the
+			// duplicate.
+			break
+		}
+	}
+
+	return warnings, errs.ToAggregate()
+}
+
+func isCertManagerGroup(group string) bool {
+	return group == "" || group == cmapi.SchemeGroupVersion.Group
+}
+
+// overlappingDNSName returns the first of dnsNames which overlaps with
+// dnsName, see dnsNamesOverlap.
+func overlappingDNSName(dnsName string, dnsNames []string) (string, bool) {
+	for _, other := range dnsNames {
+		if dnsNamesOverlap(dnsName, other) {
+			return other, true
+		}
+	}
+	return "", false
+}
+
+// dnsNamesOverlap returns true if a and b are the same DNS name once
+// normalized, or if one of them is a wildcard DNS name which covers the other.
+func dnsNamesOverlap(a, b string) bool {
+	return pki.NormalizeDNSName(a) == pki.NormalizeDNSName(b) || pki.WildcardDNSNameCovers(a, b) || pki.WildcardDNSNameCovers(b, a)
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package duplicatednsnames
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	internalcmmeta "github.com/cert-manager/cert-manager/internal/apis/meta"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestDNSNamesOverlap(t *testing.T) {
+	tests := map[string]struct {
+		a, b     string
+		expected bool
+	}{
+		"the same DNS names overlap":                        {a: "example.com", b: "example.com", expected: true},
+		"DNS names are compared case insensitively":         {a: "Example.com", b: "example.COM", expected: true},
+		"a trailing dot is ignored":                         {a: "example.com.", b: "example.com", expected: true},
+		"different DNS names do not overlap":                {a: "example.com", b: "example.org", expected: false},
+		"a wildcard overlaps with a name it covers":         {a: "*.example.com", b: "www.example.com", expected: true},
+		"a name overlaps with a wildcard covering it":       {a: "www.example.com", b: "*.example.com", expected: true},
+		"the same wildcards overlap":                        {a: "*.example.com", b: "*.example.com", expected: true},
+		"a wildcard does not cover the apex":                {a: "*.example.com", b: "example.com", expected: false},
+		"a wildcard does not cover more than one label":     {a: "*.example.com", b: "a.www.example.com", expected: false},
+		"a wildcard does not cover a wildcard a level down": {a: "*.example.com", b: "*.www.example.com", expected: false},
+		"U-label and A-label forms of a name overlap":       {a: "bücher.example.com", b: "xn--bcher-kva.example.com", expected: true},
+		"a wildcard covers a name differing in case":        {a: "*.Example.com", b: "WWW.example.com", expected: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, dnsNamesOverlap(test.a, test.b))
+		})
+	}
+}
+
+var certificatesResource = &metav1.GroupVersionResource{
+	Group:    "cert-manager.io",
+	Version:  "v1",
+	Resource: "certificates",
+}
+
+func TestValidate(t *testing.T) {
+	clusterIssuer := func(name string) cmmeta.ObjectReference {
+		return cmmeta.ObjectReference{Name: name, Kind: cmapi.ClusterIssuerKind, Group: "cert-manager.io"}
+	}
+	existing := []*cmapi.Certificate{
+		gen.Certificate("team-a-www", gen.SetCertificateNamespace("team-a"),
+			gen.SetCertificateDNSNames("www.example.com"),
+			gen.SetCertificateIssuer(clusterIssuer("letsencrypt"))),
+		gen.Certificate("team-b-wildcard", gen.SetCertificateNamespace("team-b"),
+			gen.SetCertificateDNSNames("*.example.org"),
+			gen.SetCertificateIssuer(clusterIssuer("letsencrypt"))),
+		gen.Certificate("team-c-api", gen.SetCertificateNamespace("team-c"),
+			gen.SetCertificateDNSNames("api.example.net"),
+			gen.SetCertificateIssuer(clusterIssuer("other"))),
+		gen.Certificate("team-d-shop", gen.SetCertificateNamespace("team-d"),
+			gen.SetCertificateDNSNames("shop.example.net"),
+			gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "letsencrypt", Kind: cmapi.IssuerKind})),
+	}
+
+	tests := map[string]struct {
+		strict    bool
+		notSynced bool
+		namespace string
+		name      string
+		issuerRef internalcmmeta.ObjectReference
+		dnsNames  []string
+
+		expectedWarnings []string
+		expectedErr      string
+	}{
+		"a duplicate DNS name in another namespace is reported": {
+			dnsNames: []string{"example.com", "www.example.com"},
+			expectedWarnings: []string{
+				`spec.dnsNames[1]: "www.example.com" overlaps with "www.example.com" requested by Certificate team-a/team-a-www from the same ClusterIssuer "letsencrypt"`,
+			},
+		},
+		"a DNS name covered by an existing wildcard is reported": {
+			dnsNames: []string{"shop.example.org"},
+			expectedWarnings: []string{
+				`spec.dnsNames[0]: "shop.example.org" overlaps with "*.example.org" requested by Certificate team-b/team-b-wildcard from the same ClusterIssuer "letsencrypt"`,
+			},
+		},
+		"a wildcard covering an existing DNS name is reported": {
+			dnsNames: []string{"*.example.com"},
+			expectedWarnings: []string{
+				`spec.dnsNames[0]: "*.example.com" overlaps with "www.example.com" requested by Certificate team-a/team-a-www from the same ClusterIssuer "letsencrypt"`,
+			},
+		},
+		"a duplicate DNS name is rejected in strict mode": {
+			strict:      true,
+			dnsNames:    []string{"www.example.com"},
+			expectedErr: `spec.dnsNames[0]: Forbidden: "www.example.com" overlaps with "www.example.com" requested by Certificate team-a/team-a-www from the same ClusterIssuer "letsencrypt"`,
+		},
+		"DNS names requested from another ClusterIssuer are not reported": {
+			dnsNames: []string{"api.example.net"},
+		},
+		"DNS names requested from an Issuer are not reported": {
+			dnsNames: []string{"shop.example.net"},
+		},
+		"Certificates using an Issuer are not checked": {
+			issuerRef: internalcmmeta.ObjectReference{Name: "other", Kind: cmapi.IssuerKind},
+			dnsNames:  []string{"www.example.com"},
+		},
+		"a Certificate being re-created is not reported as its own duplicate": {
+			namespace: "team-a",
+			name:      "team-a-www",
+			dnsNames:  []string{"www.example.com"},
+		},
+		"Certificates are not checked until the cache has synced": {
+			strict:    true,
+			notSynced: true,
+			dnsNames:  []string{"www.example.com"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			for _, crt := range existing {
+				require.NoError(t, indexer.Add(crt))
+			}
+
+			namespace := test.namespace
+			if namespace == "" {
+				namespace = "team-z"
+			}
+			issuerRef := test.issuerRef
+			if issuerRef.Name == "" {
+				issuerRef = internalcmmeta.ObjectReference{Name: "letsencrypt", Kind: cmapi.ClusterIssuerKind}
+			}
+
+			plugin := NewPlugin(test.strict, cmlisters.NewCertificateLister(indexer), func() bool { return !test.notSynced })
+			warnings, err := plugin.(*duplicateDNSNames).Validate(context.Background(), admissionv1.AdmissionRequest{
+				Operation:       admissionv1.Create,
+				RequestResource: certificatesResource,
+				Namespace:       namespace,
+			}, nil, &certmanager.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: test.name},
+				Spec: certmanager.CertificateSpec{
+					DNSNames:  test.dnsNames,
+					IssuerRef: issuerRef,
+				},
+			})
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expectedWarnings, warnings)
+		})
+	}
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package missingreferences implements an admission plugin which detects
+// Certificates referencing objects which do not exist: the Secrets holding
+// keystore passwords or an external CSR, and the issuer. Such Certificates
+// otherwise only fail once they are issued, with an error which is easily
+// missed.
+package missingreferences
+
+import (
+	"context"
+	"fmt"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/webhook/admission"
+)
+
+type missingReferences struct {
+	*admission.Handler
+
+	// strict rejects Certificates referencing missing objects instead of
+	// returning a warning.
+	strict bool
+
+	client   kubernetes.Interface
+	cmClient cmclient.Interface
+}
+
+var _ admission.ValidationInterface = &missingReferences{}
+
+// NewPlugin returns a plugin which warns about, or in strict mode rejects,
+// Certificates referencing Secrets or an issuer which do not exist. On
+// update, only the references which changed are checked, so that existing
+// Certificates can still be updated.
+// The referenced objects are read using the given clients. References which
+// cannot be read, for example because the webhook is not allowed to, are not
+// checked.
+func NewPlugin(strict bool, client kubernetes.Interface, cmClient cmclient.Interface) admission.Interface {
+	return &missingReferences{
+		Handler:  admission.NewHandler(admissionv1.Create, admissionv1.Update),
+		strict:   strict,
+		client:   client,
+		cmClient: cmClient,
+	}
+}
+
+func (p *missingReferences) Validate(ctx context.Context, request admissionv1.AdmissionRequest, oldObj, obj runtime.Object) ([]string, error) {
+	if request.RequestResource.Group != "cert-manager.io" ||
+		request.RequestResource.Resource != "certificates" ||
+		request.SubResource != "" {
+		return nil, nil
+	}
+
+	crt, ok := obj.(*certmanager.Certificate)
+	if !ok {
+		return nil, fmt.Errorf("internal error: object in admission request is not of type *certmanager.Certificate")
+	}
+	var oldRefs []reference
+	if request.Operation == admissionv1.Update {
+		oldCrt, ok := oldObj.(*certmanager.Certificate)
+		if !ok {
+			return nil, fmt.Errorf("internal error: oldObject in admission request is not of type *certmanager.Certificate")
+		}
+		oldRefs = references(oldCrt)
+	}
+
+	log := logf.FromContext(ctx, "missingreferences").WithValues("namespace", request.Namespace, "name", request.Name)
+
+	var (
+		warnings []string
+		errs     field.ErrorList
+	)
+	for _, ref := range references(crt) {
+		if containsReference(oldRefs, ref) {
+			continue
+		}
+
+		exists, err := p.exists(ctx, request.Namespace, ref)
+		if err != nil {
+			// Failing to check a reference must not block Certificates, for
+			// example if the webhook has not been granted access to Secrets.
+			log.V(logf.InfoLevel).Info("unable to check whether a referenced object exists, not checking the reference",
+				"field", ref.path.String(), "kind", ref.kind, "object", ref.name, "error", err.Error())
+			continue
+		}
+		if exists {
+			continue
+		}
+
+		msg := fmt.Sprintf("%s %q does not exist", ref.kind, ref.name)
+		if ref.namespaced {
+			msg = fmt.Sprintf("%s %q does not exist in namespace %q", ref.kind, ref.name, request.Namespace)
+		}
+		if p.strict {
+			errs = append(errs, field.Invalid(ref.path, ref.name, msg))
+		} else {
+			warnings = append(warnings, fmt.Sprintf("%s: %s", ref.path, msg))
+		}
+	}
+
+	return warnings, errs.ToAggregate()
+}
+
+// exists returns true if the referenced object exists in the namespace. An
+// error is returned if it cannot be read.
+func (p *missingReferences) exists(ctx context.Context, namespace string, ref reference) (bool, error) {
+	var err error
+	switch ref.kind {
+	case "Secret":
+		_, err = p.client.CoreV1().Secrets(namespace).Get(ctx, ref.name, metav1.GetOptions{})
+	case cmapi.IssuerKind:
+		_, err = p.cmClient.CertmanagerV1().Issuers(namespace).Get(ctx, ref.name, metav1.GetOptions{})
+	case cmapi.ClusterIssuerKind:
+		_, err = p.cmClient.CertmanagerV1().ClusterIssuers().Get(ctx, ref.name, metav1.GetOptions{})
+	default:
+		return false, fmt.Errorf("unknown kind %q", ref.kind)
+	}
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// reference is an object referenced by a Certificate.
+type reference struct {
+	path       *field.Path
+	kind       string
+	name       string
+	namespaced bool
+}
+
+// references returns the objects referenced by the Certificate which can be
+// checked. Issuers of other API groups, i.e. external issuers, are not
+// checked as their kinds are not known to the webhook.
+func references(crt *certmanager.Certificate) []reference {
+	var refs []reference
+
+	specPath := field.NewPath("spec")
+	issuerRef := crt.Spec.IssuerRef
+	kind, group := apiutil.NormalizeIssuerKindAndGroup(issuerRef.Kind, issuerRef.Group)
+	if issuerRef.Name != "" && group == cmapi.SchemeGroupVersion.Group {
+		switch kind {
+		case cmapi.IssuerKind:
+			refs = append(refs, reference{path: specPath.Child("issuerRef", "name"), kind: cmapi.IssuerKind, name: issuerRef.Name, namespaced: true})
+		case cmapi.ClusterIssuerKind:
+			refs = append(refs, reference{path: specPath.Child("issuerRef", "name"), kind: cmapi.ClusterIssuerKind, name: issuerRef.Name})
+		}
+	}
+
+	secretRef := func(path *field.Path, name string) {
+		if name != "" {
+			refs = append(refs, reference{path: path, kind: "Secret", name: name, namespaced: true})
+		}
+	}
+	if keystores := crt.Spec.Keystores; keystores != nil {
+		if keystores.JKS != nil && keystores.JKS.Create {
+			secretRef(specPath.Child("keystores", "jks", "passwordSecretRef", "name"), keystores.JKS.PasswordSecretRef.Name)
+		}
+		if keystores.PKCS12 != nil && keystores.PKCS12.Create {
+			secretRef(specPath.Child("keystores", "pkcs12", "passwordSecretRef", "name"), keystores.PKCS12.PasswordSecretRef.Name)
+		}
+	}
+	if crt.Spec.ExternalCSR != nil {
+		secretRef(specPath.Child("externalCSR", "secretRef", "name"), crt.Spec.ExternalCSR.SecretRef.Name)
+	}
+
+	return refs
+}
+
+// containsReference returns true if refs contains the same reference.
+func containsReference(refs []reference, ref reference) bool {
+	for _, r := range refs {
+		if r.path.String() == ref.path.String() && r.kind == ref.kind && r.name == ref.name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package missingreferences
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	coretesting "k8s.io/client-go/testing"
+
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	internalcmmeta "github.com/cert-manager/cert-manager/internal/apis/meta"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+)
+
+var certificatesResource = &metav1.GroupVersionResource{
+	Group:    "cert-manager.io",
+	Version:  "v1",
+	Resource: "certificates",
+}
+
+func TestValidate(t *testing.T) {
+	secret := func(name string) runtime.Object {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: name}}
+	}
+	issuer := &cmapi.Issuer{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "ca"}}
+	clusterIssuer := &cmapi.ClusterIssuer{ObjectMeta: metav1.ObjectMeta{Name: "letsencrypt"}}
+
+	keystores := func(jks, pkcs12 string) *certmanager.CertificateKeystores {
+		return &certmanager.CertificateKeystores{
+			JKS: &certmanager.JKSKeystore{
+				Create:            true,
+				PasswordSecretRef: internalcmmeta.SecretKeySelector{LocalObjectReference: internalcmmeta.LocalObjectReference{Name: jks}},
+			},
+			PKCS12: &certmanager.PKCS12Keystore{
+				Create:            true,
+				PasswordSecretRef: internalcmmeta.SecretKeySelector{LocalObjectReference: internalcmmeta.LocalObjectReference{Name: pkcs12}},
+			},
+		}
+	}
+	externalCSR := func(name string) *certmanager.CertificateExternalCSR {
+		return &certmanager.CertificateExternalCSR{
+			SecretRef: internalcmmeta.SecretKeySelector{LocalObjectReference: internalcmmeta.LocalObjectReference{Name: name}},
+		}
+	}
+
+	tests := map[string]struct {
+		strict bool
+		// forbidden makes the webhook's requests to get Secrets forbidden,
+		// as if it had not been granted access.
+		forbidden bool
+		oldSpec   *certmanager.CertificateSpec
+		spec      certmanager.CertificateSpec
+
+		expectedWarnings []string
+		expectedErr      string
+	}{
+		"no warning is returned if every referenced object exists": {
+			spec: certmanager.CertificateSpec{
+				IssuerRef:   internalcmmeta.ObjectReference{Name: "ca"},
+				Keystores:   keystores("jks-password", "pkcs12-password"),
+				ExternalCSR: externalCSR("csr"),
+			},
+		},
+		"a ClusterIssuer which exists is found": {
+			spec: certmanager.CertificateSpec{
+				IssuerRef: internalcmmeta.ObjectReference{Name: "letsencrypt", Kind: cmapi.ClusterIssuerKind, Group: "cert-manager.io"},
+			},
+		},
+		"every missing object is named": {
+			spec: certmanager.CertificateSpec{
+				IssuerRef:   internalcmmeta.ObjectReference{Name: "letsencrypt", Kind: cmapi.IssuerKind},
+				Keystores:   keystores("missing-password", "pkcs12-password"),
+				ExternalCSR: externalCSR("missing-csr"),
+			},
+			expectedWarnings: []string{
+				`spec.issuerRef.name: Issuer "letsencrypt" does not exist in namespace "team-a"`,
+				`spec.keystores.jks.passwordSecretRef.name: Secret "missing-password" does not exist in namespace "team-a"`,
+				`spec.externalCSR.secretRef.name: Secret "missing-csr" does not exist in namespace "team-a"`,
+			},
+		},
+		"a missing ClusterIssuer is named": {
+			spec: certmanager.CertificateSpec{
+				IssuerRef: internalcmmeta.ObjectReference{Name: "ca", Kind: cmapi.ClusterIssuerKind},
+			},
+			expectedWarnings: []string{
+				`spec.issuerRef.name: ClusterIssuer "ca" does not exist`,
+			},
+		},
+		"missing objects are rejected in strict mode": {
+			strict: true,
+			spec: certmanager.CertificateSpec{
+				IssuerRef: internalcmmeta.ObjectReference{Name: "ca"},
+				Keystores: keystores("jks-password", "missing-password"),
+			},
+			expectedErr: `spec.keystores.pkcs12.passwordSecretRef.name: Invalid value: "missing-password": Secret "missing-password" does not exist in namespace "team-a"`,
+		},
+		"keystores which are not created are not checked": {
+			spec: certmanager.CertificateSpec{
+				IssuerRef: internalcmmeta.ObjectReference{Name: "ca"},
+				Keystores: &certmanager.CertificateKeystores{
+					JKS: &certmanager.JKSKeystore{
+						PasswordSecretRef: internalcmmeta.SecretKeySelector{LocalObjectReference: internalcmmeta.LocalObjectReference{Name: "missing-password"}},
+					},
+				},
+			},
+		},
+		"external issuers are not checked": {
+			spec: certmanager.CertificateSpec{
+				IssuerRef: internalcmmeta.ObjectReference{Name: "missing", Kind: "AWSPCAClusterIssuer", Group: "awspca.cert-manager.io"},
+			},
+		},
+		"references which the webhook is not allowed to get are not checked": {
+			strict:    true,
+			forbidden: true,
+			spec: certmanager.CertificateSpec{
+				IssuerRef:   internalcmmeta.ObjectReference{Name: "missing"},
+				Keystores:   keystores("missing-password", "missing-password"),
+				ExternalCSR: externalCSR("missing-csr"),
+			},
+			expectedErr: `spec.issuerRef.name: Invalid value: "missing": Issuer "missing" does not exist in namespace "team-a"`,
+		},
+		"references which are unchanged by an update are not checked": {
+			strict: true,
+			oldSpec: &certmanager.CertificateSpec{
+				IssuerRef: internalcmmeta.ObjectReference{Name: "missing"},
+				Keystores: keystores("missing-password", "pkcs12-password"),
+			},
+			spec: certmanager.CertificateSpec{
+				IssuerRef:   internalcmmeta.ObjectReference{Name: "missing"},
+				Keystores:   keystores("missing-password", "pkcs12-password"),
+				ExternalCSR: externalCSR("missing-csr"),
+			},
+			expectedErr: `spec.externalCSR.secretRef.name: Invalid value: "missing-csr": Secret "missing-csr" does not exist in namespace "team-a"`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := kubefake.NewSimpleClientset(secret("jks-password"), secret("pkcs12-password"), secret("csr"))
+			if test.forbidden {
+				client.PrependReactor("get", "secrets", func(action coretesting.Action) (bool, runtime.Object, error) {
+					return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "secrets"}, action.(coretesting.GetAction).GetName(), nil)
+				})
+			}
+			cmClient := cmfake.NewSimpleClientset(issuer, clusterIssuer)
+
+			request := admissionv1.AdmissionRequest{
+				Operation:       admissionv1.Create,
+				RequestResource: certificatesResource,
+				Namespace:       "team-a",
+				Name:            "crt",
+			}
+			var oldObj runtime.Object
+			if test.oldSpec != nil {
+				request.Operation = admissionv1.Update
+				oldObj = &certmanager.Certificate{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "crt"},
+					Spec:       *test.oldSpec,
+				}
+			}
+
+			plugin := NewPlugin(test.strict, client, cmClient)
+			warnings, err := plugin.(*missingReferences).Validate(context.Background(), request, oldObj, &certmanager.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "crt"},
+				Spec:       test.spec,
+			})
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expectedWarnings, warnings)
+		})
+	}
+}
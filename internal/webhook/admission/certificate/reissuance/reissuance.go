@@ -0,0 +1,217 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package reissuance implements an admission plugin which protects
+// Certificates in protected namespaces from updates which would cause them to
+// be re-issued straight away, such as a change of the private key algorithm
+// of a widely used certificate, unless the update is explicitly confirmed.
+package reissuance
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/clock"
+
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	cmapiconversion "github.com/cert-manager/cert-manager/internal/apis/certmanager/v1"
+	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
+	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/webhook/admission"
+)
+
+type reissuance struct {
+	*admission.Handler
+
+	client kubernetes.Interface
+
+	namespaces corelisters.NamespaceLister
+	hasSynced  cache.InformerSynced
+
+	// controllerUsername is the username of the cert-manager controller,
+	// whose updates are never rejected as they are made on behalf of users,
+	// e.g. by ingress-shim or when migrating Certificates.
+	controllerUsername string
+
+	// triggerPolicies are the policies which the controller uses to decide
+	// whether a Certificate must be issued.
+	triggerPolicies policies.Chain
+}
+
+var _ admission.ValidationInterface = &reissuance{}
+
+// NewPlugin returns a plugin which rejects updates to Certificates in
+// namespaces labelled as protected which would cause the Certificate to be
+// re-issued, unless the update sets the confirm-reissue annotation to the
+// generation of the Certificate being updated.
+// Whether an update causes re-issuance is decided by evaluating the trigger
+// policies against the Certificate's Secret, read using client. If the Secret
+// cannot be read, or the namespaces are not synced yet, a warning is returned
+// instead. Updates made by the controller, identified by controllerUsername,
+// are always allowed.
+func NewPlugin(controllerUsername string, client kubernetes.Interface, namespaces corelisters.NamespaceLister, hasSynced cache.InformerSynced, clock clock.Clock) admission.Interface {
+	return &reissuance{
+		Handler:            admission.NewHandler(admissionv1.Update),
+		client:             client,
+		namespaces:         namespaces,
+		hasSynced:          hasSynced,
+		controllerUsername: controllerUsername,
+		// The private key defaults of the controller are not known to the
+		// webhook, but are the same for the old and the new Certificate.
+		triggerPolicies: policies.NewTriggerPolicyChain(clock, internalcertificates.PrivateKeyDefaults{}),
+	}
+}
+
+func (p *reissuance) Validate(ctx context.Context, request admissionv1.AdmissionRequest, oldObj, obj runtime.Object) ([]string, error) {
+	if request.RequestResource.Group != "cert-manager.io" ||
+		request.RequestResource.Resource != "certificates" ||
+		request.SubResource != "" {
+		return nil, nil
+	}
+
+	crt, ok := obj.(*certmanager.Certificate)
+	if !ok {
+		return nil, fmt.Errorf("internal error: object in admission request is not of type *certmanager.Certificate")
+	}
+	oldCrt, ok := oldObj.(*certmanager.Certificate)
+	if !ok {
+		return nil, fmt.Errorf("internal error: oldObject in admission request is not of type *certmanager.Certificate")
+	}
+
+	// An empty controller username never matches, so that the exemption
+	// cannot be used if the webhook has not been told who the controller is.
+	if p.controllerUsername != "" && request.UserInfo.Username == p.controllerUsername {
+		return nil, nil
+	}
+
+	// The generation being confirmed is that of the Certificate as it was
+	// read by the user, so that a confirmation cannot apply to a later
+	// update. The annotation must also be set by the update itself, so that
+	// a confirmation left behind by an earlier update is never reused.
+	confirmation := strconv.FormatInt(oldCrt.Generation, 10)
+	if crt.Annotations[cmapi.ConfirmReissueAnnotationKey] == confirmation &&
+		oldCrt.Annotations[cmapi.ConfirmReissueAnnotationKey] != confirmation {
+		return nil, nil
+	}
+
+	protected, err := p.namespaceProtected(request.Namespace)
+	if err != nil {
+		return []string{fmt.Sprintf("unable to check whether namespace %q is protected from Certificate re-issuance: %v", request.Namespace, err)}, nil
+	}
+	if !protected {
+		return nil, nil
+	}
+
+	reasons, err := p.reissuanceReasons(ctx, request.Namespace, oldCrt, crt)
+	if err != nil {
+		return []string{fmt.Sprintf("unable to check whether this update causes the Certificate to be re-issued, it may be re-issued immediately: %v", err)}, nil
+	}
+	if len(reasons) == 0 {
+		return nil, nil
+	}
+
+	fldPath := field.NewPath("metadata", "annotations").Key(cmapi.ConfirmReissueAnnotationKey)
+	return nil, field.ErrorList{
+		field.Forbidden(fldPath, fmt.Sprintf("this update causes the Certificate to be re-issued immediately (%s); namespace %q is protected, so the update must set this annotation to %q to confirm it",
+			strings.Join(reasons, "; "), request.Namespace, confirmation)),
+	}.ToAggregate()
+}
+
+// namespaceProtected returns true if the namespace has the protected label.
+func (p *reissuance) namespaceProtected(name string) (bool, error) {
+	if !p.hasSynced() {
+		return false, fmt.Errorf("namespaces have not been synced yet")
+	}
+	ns, err := p.namespaces.Get(name)
+	if err != nil {
+		return false, err
+	}
+	return ns.Labels[cmapi.ProtectedNamespaceLabelKey] == "true", nil
+}
+
+// reissuanceReasons returns the reasons and messages of the trigger policies
+// which are violated by the updated Certificate, i.e. the reasons for which
+// the update causes the Certificate to be re-issued. If the old Certificate
+// already violates a policy, it is re-issued regardless of the update and no
+// reasons are returned.
+// The CertificateRequest for the current revision of the Certificate is not
+// looked up, in which case the policies compare the Certificate's spec with
+// the certificate stored in the Secret.
+func (p *reissuance) reissuanceReasons(ctx context.Context, namespace string, oldCrt, crt *certmanager.Certificate) ([]string, error) {
+	oldInput := policies.Input{Certificate: &cmapi.Certificate{}}
+	if err := cmapiconversion.Convert_certmanager_Certificate_To_v1_Certificate(oldCrt, oldInput.Certificate, nil); err != nil {
+		return nil, err
+	}
+	input := policies.Input{Certificate: &cmapi.Certificate{}}
+	if err := cmapiconversion.Convert_certmanager_Certificate_To_v1_Certificate(crt, input.Certificate, nil); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if oldInput.Secret, err = p.secret(ctx, namespace, oldCrt.Spec.SecretName); err != nil {
+		return nil, err
+	}
+	input.Secret = oldInput.Secret
+	if crt.Spec.SecretName != oldCrt.Spec.SecretName {
+		if input.Secret, err = p.secret(ctx, namespace, crt.Spec.SecretName); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, _, violated := p.triggerPolicies.Evaluate(oldInput); violated {
+		return nil, nil
+	}
+
+	// Unlike the controller, every violated policy is listed so that all of
+	// the reasons are known before confirming the update.
+	var reasons []string
+	for _, policy := range p.triggerPolicies {
+		reason, message, violated := policy(input)
+		if !violated {
+			continue
+		}
+		reasons = append(reasons, fmt.Sprintf("%s: %s", reason, message))
+		// The policies after the first assume that the Secret exists.
+		if input.Secret == nil {
+			break
+		}
+	}
+	return reasons, nil
+}
+
+// secret returns the named Secret, or nil if it does not exist.
+func (p *reissuance) secret(ctx context.Context, namespace, name string) (*corev1.Secret, error) {
+	secret, err := p.client.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read Secret %q: %w", name, err)
+	}
+	return secret, nil
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reissuance
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	coretesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	cmapiconversion "github.com/cert-manager/cert-manager/internal/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+const controllerUsername = "system:serviceaccount:cert-manager:cert-manager"
+
+var certificatesResource = &metav1.GroupVersionResource{
+	Group:    "cert-manager.io",
+	Version:  "v1",
+	Resource: "certificates",
+}
+
+func TestValidate(t *testing.T) {
+	fixedClock := fakeclock.NewFakeClock(time.Now())
+
+	crt := gen.Certificate("wildcard",
+		gen.SetCertificateNamespace("shared"),
+		gen.SetCertificateGeneration(3),
+		gen.SetCertificateSecretName("wildcard-tls"),
+		gen.SetCertificateDNSNames("*.example.com"),
+		gen.SetCertificateKeyAlgorithm(cmapi.RSAKeyAlgorithm),
+		gen.SetCertificateKeySize(2048),
+	)
+	bundle := testcrypto.MustCreateCryptoBundle(t, crt.DeepCopy(), fixedClock)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shared", Name: "wildcard-tls"},
+		Data: map[string][]byte{
+			corev1.TLSPrivateKeyKey: bundle.PrivateKeyBytes,
+			corev1.TLSCertKey:       bundle.CertBytes,
+		},
+	}
+
+	ecdsaCrt := gen.CertificateFrom(crt,
+		gen.SetCertificateKeyAlgorithm(cmapi.ECDSAKeyAlgorithm),
+		gen.SetCertificateKeySize(256),
+	)
+	keyMismatch := policies.SecretMismatch + ": Existing private key is not up to date for spec: [spec.privateKey.algorithm]"
+	secretReason, secretMessage, _ := policies.SecretDoesNotExist(policies.Input{})
+	secretMissing := secretReason + ": " + secretMessage
+
+	confirmedCrt := gen.CertificateFrom(crt, gen.AddCertificateAnnotations(map[string]string{cmapi.ConfirmReissueAnnotationKey: "3"}))
+
+	tests := map[string]struct {
+		unprotected     bool
+		unsynced        bool
+		secretForbidden bool
+		username        string
+		oldCrt          *cmapi.Certificate
+		crt             *cmapi.Certificate
+
+		expectedWarnings []string
+		expectedErr      string
+	}{
+		"an update which causes re-issuance is allowed in a namespace which is not protected": {
+			unprotected: true,
+			crt:         ecdsaCrt,
+		},
+		"an unconfirmed update which causes re-issuance is rejected": {
+			crt:         ecdsaCrt,
+			expectedErr: fmt.Sprintf(`metadata.annotations[cert-manager.io/confirm-reissue]: Forbidden: this update causes the Certificate to be re-issued immediately (%s); namespace "shared" is protected, so the update must set this annotation to "3" to confirm it`, keyMismatch),
+		},
+		"a confirmed update which causes re-issuance is allowed": {
+			crt: gen.CertificateFrom(ecdsaCrt, gen.AddCertificateAnnotations(map[string]string{cmapi.ConfirmReissueAnnotationKey: "3"})),
+		},
+		"an update confirmed for another generation is rejected": {
+			crt:         gen.CertificateFrom(ecdsaCrt, gen.AddCertificateAnnotations(map[string]string{cmapi.ConfirmReissueAnnotationKey: "2"})),
+			expectedErr: fmt.Sprintf(`metadata.annotations[cert-manager.io/confirm-reissue]: Forbidden: this update causes the Certificate to be re-issued immediately (%s); namespace "shared" is protected, so the update must set this annotation to "3" to confirm it`, keyMismatch),
+		},
+		"an unconfirmed update to a Secret which does not exist is rejected": {
+			crt:         gen.CertificateFrom(crt, gen.SetCertificateSecretName("other-tls")),
+			expectedErr: fmt.Sprintf(`metadata.annotations[cert-manager.io/confirm-reissue]: Forbidden: this update causes the Certificate to be re-issued immediately (%s); namespace "shared" is protected, so the update must set this annotation to "3" to confirm it`, secretMissing),
+		},
+		"an update keeping a confirmation set by an earlier update is rejected": {
+			oldCrt:      confirmedCrt,
+			crt:         gen.CertificateFrom(ecdsaCrt, gen.AddCertificateAnnotations(map[string]string{cmapi.ConfirmReissueAnnotationKey: "3"})),
+			expectedErr: fmt.Sprintf(`metadata.annotations[cert-manager.io/confirm-reissue]: Forbidden: this update causes the Certificate to be re-issued immediately (%s); namespace "shared" is protected, so the update must set this annotation to "3" to confirm it`, keyMismatch),
+		},
+		"an unconfirmed update by the controller is allowed": {
+			username: controllerUsername,
+			crt:      ecdsaCrt,
+		},
+		"a warning is returned if the namespaces have not been synced": {
+			unsynced: true,
+			crt:      ecdsaCrt,
+			expectedWarnings: []string{
+				`unable to check whether namespace "shared" is protected from Certificate re-issuance: namespaces have not been synced yet`,
+			},
+		},
+		"an update which does not cause re-issuance is allowed": {
+			crt: gen.CertificateFrom(crt, gen.SetCertificateRevisionHistoryLimit(5)),
+		},
+		"a warning is returned if the Secret cannot be read": {
+			secretForbidden: true,
+			crt:             ecdsaCrt,
+			expectedWarnings: []string{
+				`unable to check whether this update causes the Certificate to be re-issued, it may be re-issued immediately: unable to read Secret "wildcard-tls": secrets "wildcard-tls" is forbidden: not allowed`,
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shared"}}
+			if !test.unprotected {
+				ns.Labels = map[string]string{cmapi.ProtectedNamespaceLabelKey: "true"}
+			}
+			client := fake.NewSimpleClientset(secret)
+			namespaces := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			require.NoError(t, namespaces.Add(ns))
+			if test.secretForbidden {
+				client.PrependReactor("get", "secrets", func(action coretesting.Action) (bool, runtime.Object, error) {
+					return true, nil, apierrors.NewForbidden(corev1.Resource("secrets"), action.(coretesting.GetAction).GetName(), fmt.Errorf("not allowed"))
+				})
+			}
+
+			oldCrt := crt
+			if test.oldCrt != nil {
+				oldCrt = test.oldCrt
+			}
+
+			hasSynced := func() bool { return !test.unsynced }
+			plugin := NewPlugin(controllerUsername, client, corelisters.NewNamespaceLister(namespaces), hasSynced, fixedClock)
+			warnings, err := plugin.(*reissuance).Validate(context.Background(), admissionv1.AdmissionRequest{
+				Operation:       admissionv1.Update,
+				RequestResource: certificatesResource,
+				Namespace:       "shared",
+				UserInfo:        authenticationv1.UserInfo{Username: test.username},
+			}, toInternal(t, oldCrt), toInternal(t, test.crt))
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expectedWarnings, warnings)
+		})
+	}
+}
+
+func toInternal(t *testing.T, crt *cmapi.Certificate) *certmanager.Certificate {
+	out := &certmanager.Certificate{}
+	require.NoError(t, cmapiconversion.Convert_v1_Certificate_To_certmanager_Certificate(crt, out, nil))
+	return out
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package createdby implements an admission plugin which protects the label
+// marking CertificateRequests as created by cert-manager for a Certificate,
+// so that CertificateRequests created directly cannot pass for managed ones.
+package createdby
+
+import (
+	"context"
+	"fmt"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/webhook/admission"
+)
+
+type certificateRequestCreatedBy struct {
+	*admission.Handler
+
+	// controllerUsername is the username of the cert-manager controller,
+	// the only user which may set or change the label.
+	controllerUsername string
+}
+
+var _ admission.ValidationInterface = &certificateRequestCreatedBy{}
+
+// NewPlugin returns a plugin which rejects CertificateRequests on which the
+// created-by label is set or changed by any user other than the cert-manager
+// controller, identified by controllerUsername.
+func NewPlugin(controllerUsername string) admission.Interface {
+	return &certificateRequestCreatedBy{
+		Handler:            admission.NewHandler(admissionv1.Create, admissionv1.Update),
+		controllerUsername: controllerUsername,
+	}
+}
+
+func (p *certificateRequestCreatedBy) Validate(ctx context.Context, request admissionv1.AdmissionRequest, oldObj, obj runtime.Object) ([]string, error) {
+	if request.RequestResource.Group != "cert-manager.io" ||
+		request.RequestResource.Resource != "certificaterequests" {
+		return nil, nil
+	}
+
+	cr, ok := obj.(*certmanager.CertificateRequest)
+	if !ok {
+		return nil, fmt.Errorf("internal error: object in admission request is not of type *certmanager.CertificateRequest")
+	}
+
+	value, ok := cr.Labels[cmapi.CertificateRequestCreatedByLabelKey]
+	if request.Operation == admissionv1.Update {
+		oldCR, isCR := oldObj.(*certmanager.CertificateRequest)
+		if !isCR {
+			return nil, fmt.Errorf("internal error: oldObject in admission request is not of type *certmanager.CertificateRequest")
+		}
+		oldValue, oldOK := oldCR.Labels[cmapi.CertificateRequestCreatedByLabelKey]
+		if value == oldValue && ok == oldOK {
+			return nil, nil
+		}
+	} else if !ok {
+		return nil, nil
+	}
+
+	// An empty controller username never matches, so that the label cannot
+	// be spoofed if the webhook has not been told who the controller is.
+	if p.controllerUsername != "" && request.UserInfo.Username == p.controllerUsername {
+		return nil, nil
+	}
+
+	fldPath := field.NewPath("metadata", "labels").Key(cmapi.CertificateRequestCreatedByLabelKey)
+	return nil, field.ErrorList{
+		field.Forbidden(fldPath, "may only be set or changed by the cert-manager controller"),
+	}.ToAggregate()
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package createdby
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+const controllerUsername = "system:serviceaccount:cert-manager:cert-manager"
+
+var certificateRequestsResource = &metav1.GroupVersionResource{
+	Group:    "cert-manager.io",
+	Version:  "v1",
+	Resource: "certificaterequests",
+}
+
+func TestValidate(t *testing.T) {
+	withLabel := func(value string) *certmanager.CertificateRequest {
+		return &certmanager.CertificateRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{cmapi.CertificateRequestCreatedByLabelKey: value, "app": "web"},
+			},
+		}
+	}
+	withoutLabel := &certmanager.CertificateRequest{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "web"}},
+	}
+	const forbidden = `metadata.labels[cert-manager.io/created-by]: Forbidden: may only be set or changed by the cert-manager controller`
+
+	tests := map[string]struct {
+		noControllerUsername bool
+		username             string
+		op                   admissionv1.Operation
+		oldCR, cr            *certmanager.CertificateRequest
+
+		expectedErr string
+	}{
+		"the controller may create a CertificateRequest with the label": {
+			username: controllerUsername,
+			op:       admissionv1.Create,
+			cr:       withLabel("certificates-request-manager"),
+		},
+		"a user may create a CertificateRequest without the label": {
+			username: "user",
+			op:       admissionv1.Create,
+			cr:       withoutLabel,
+		},
+		"a user may not create a CertificateRequest with the label": {
+			username:    "user",
+			op:          admissionv1.Create,
+			cr:          withLabel("certificates-request-manager"),
+			expectedErr: forbidden,
+		},
+		"a user may update a CertificateRequest without changing the label": {
+			username: "user",
+			op:       admissionv1.Update,
+			oldCR:    withLabel("certificates-request-manager"),
+			cr:       withLabel("certificates-request-manager"),
+		},
+		"a user may not add the label to a CertificateRequest": {
+			username:    "user",
+			op:          admissionv1.Update,
+			oldCR:       withoutLabel,
+			cr:          withLabel("certificates-request-manager"),
+			expectedErr: forbidden,
+		},
+		"a user may not change the label of a CertificateRequest": {
+			username:    "user",
+			op:          admissionv1.Update,
+			oldCR:       withLabel("certificates-request-manager"),
+			cr:          withLabel("other"),
+			expectedErr: forbidden,
+		},
+		"a user may not remove the label from a CertificateRequest": {
+			username:    "user",
+			op:          admissionv1.Update,
+			oldCR:       withLabel("certificates-request-manager"),
+			cr:          withoutLabel,
+			expectedErr: forbidden,
+		},
+		"the controller may change the label of a CertificateRequest": {
+			username: controllerUsername,
+			op:       admissionv1.Update,
+			oldCR:    withLabel("certificates-request-manager"),
+			cr:       withLabel("other"),
+		},
+		"nobody may set the label if the controller username is not configured": {
+			noControllerUsername: true,
+			username:             "",
+			op:                   admissionv1.Create,
+			cr:                   withLabel("certificates-request-manager"),
+			expectedErr:          forbidden,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			username := controllerUsername
+			if test.noControllerUsername {
+				username = ""
+			}
+
+			request := admissionv1.AdmissionRequest{
+				Operation:       test.op,
+				RequestResource: certificateRequestsResource,
+				UserInfo:        authenticationv1.UserInfo{Username: test.username},
+			}
+			warnings, err := NewPlugin(username).(*certificateRequestCreatedBy).Validate(context.Background(), request, test.oldCR, test.cr)
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Empty(t, warnings)
+		})
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	acmevalidation "github.com/cert-manager/cert-manager/internal/apis/acme/validation"
+	internalcmapi "github.com/cert-manager/cert-manager/internal/apis/certmanager"
 	cmvalidation "github.com/cert-manager/cert-manager/internal/apis/certmanager/validation"
 	acmev1 "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
@@ -67,9 +68,37 @@ var validationMapping = map[schema.GroupVersionResource]validationPair{
 	challengeGVR:          newValidationPair(acmevalidation.ValidateChallenge, acmevalidation.ValidateChallengeUpdate),
 }
 
-func NewPlugin() admission.Interface {
+// NewPlugin returns a plugin validating cert-manager resources. Certificates
+// are additionally checked not to request too many subject alternative names
+// when they are created, or when the number of names they request grows, see
+// cmvalidation.ValidateCertificateSANCount.
+func NewPlugin(certificateSANsWarningThreshold, maxCertificateSANs int) admission.Interface {
+	validationMappings := make(map[schema.GroupVersionResource]validationPair, len(validationMapping))
+	for gvr, pair := range validationMapping {
+		validationMappings[gvr] = pair
+	}
+	validationMappings[certificateGVR] = newValidationPair(
+		func(a *admissionv1.AdmissionRequest, obj runtime.Object) (field.ErrorList, []string) {
+			errs, warnings := cmvalidation.ValidateCertificate(a, obj)
+			crt := obj.(*internalcmapi.Certificate)
+			sanErrs, sanWarnings := cmvalidation.ValidateCertificateSANCount(&crt.Spec, field.NewPath("spec"), certificateSANsWarningThreshold, maxCertificateSANs)
+			return append(errs, sanErrs...), append(warnings, sanWarnings...)
+		},
+		func(a *admissionv1.AdmissionRequest, oldObj, obj runtime.Object) (field.ErrorList, []string) {
+			errs, warnings := cmvalidation.ValidateUpdateCertificate(a, oldObj, obj)
+			// The spec cannot be changed through the status subresource.
+			if a.SubResource != "" {
+				return errs, warnings
+			}
+			oldCrt, crt := oldObj.(*internalcmapi.Certificate), obj.(*internalcmapi.Certificate)
+			sanErrs, sanWarnings := cmvalidation.ValidateUpdateCertificateSANCount(&oldCrt.Spec, &crt.Spec, field.NewPath("spec"), certificateSANsWarningThreshold, maxCertificateSANs)
+			return append(errs, sanErrs...), append(warnings, sanWarnings...)
+		},
+	)
+
 	return &resourceValidation{
-		Handler: admission.NewHandler(admissionv1.Create, admissionv1.Update),
+		Handler:            admission.NewHandler(admissionv1.Create, admissionv1.Update),
+		validationMappings: validationMappings,
 	}
 }
 
@@ -80,7 +109,7 @@ func (p resourceValidation) Validate(_ context.Context, request admissionv1.Admi
 		Resource: request.RequestResource.Resource,
 	}
 
-	pair, ok := validationMapping[requestResource]
+	pair, ok := p.validationMappings[requestResource]
 	if !ok {
 		return nil, nil
 	}
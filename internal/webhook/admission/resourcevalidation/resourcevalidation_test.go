@@ -18,6 +18,7 @@ package resourcevalidation
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 	"testing"
 
@@ -26,6 +27,9 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	internalcmapi "github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	cmmeta "github.com/cert-manager/cert-manager/internal/apis/meta"
 )
 
 var (
@@ -81,7 +85,7 @@ func TestResourceValidation(t *testing.T) {
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			p := NewPlugin().(*resourceValidation)
+			p := NewPlugin(0, 0).(*resourceValidation)
 			p.validationMappings = test.mapping
 			warnings, err := p.Validate(context.Background(), test.req, test.oldObj, test.obj)
 			compareErrors(t, test.expectedError, err)
@@ -92,6 +96,88 @@ func TestResourceValidation(t *testing.T) {
 	}
 }
 
+func TestResourceValidationCertificateSANCount(t *testing.T) {
+	certificateMetaGVR := metav1.GroupVersionResource{
+		Group:    certificateGVR.Group,
+		Version:  certificateGVR.Version,
+		Resource: certificateGVR.Resource,
+	}
+	certificateWithDNSNames := func(n int) *internalcmapi.Certificate {
+		crt := &internalcmapi.Certificate{
+			Spec: internalcmapi.CertificateSpec{
+				SecretName: "abc",
+				IssuerRef:  cmmeta.ObjectReference{Name: "issuer"},
+			},
+		}
+		for i := 0; i < n; i++ {
+			crt.Spec.DNSNames = append(crt.Spec.DNSNames, fmt.Sprintf("test-%03d.example.com", i))
+		}
+		return crt
+	}
+
+	tests := map[string]struct {
+		operation   admissionv1.Operation
+		subResource string
+		oldObj, obj runtime.Object
+
+		expectedWarnings []string
+		expectedError    error
+	}{
+		"should accept a Certificate with few DNS names": {
+			operation: admissionv1.Create,
+			obj:       certificateWithDNSNames(10),
+		},
+		"should warn about a Certificate with many DNS names": {
+			operation:        admissionv1.Create,
+			obj:              certificateWithDNSNames(75),
+			expectedWarnings: []string{"spec requests 75 subject alternative names, which may be slow to issue or be rejected by the issuer"},
+		},
+		"should reject the creation of a Certificate with too many DNS names": {
+			operation:     admissionv1.Create,
+			obj:           certificateWithDNSNames(150),
+			expectedError: field.ErrorList{field.Forbidden(field.NewPath("spec"), "requests 150 subject alternative names, but at most 100 are allowed")}.ToAggregate(),
+		},
+		"should reject the update of a Certificate to too many DNS names": {
+			operation:     admissionv1.Update,
+			oldObj:        certificateWithDNSNames(1),
+			obj:           certificateWithDNSNames(150),
+			expectedError: field.ErrorList{field.Forbidden(field.NewPath("spec"), "requests 150 subject alternative names, but at most 100 are allowed")}.ToAggregate(),
+		},
+		"should reject the update of a Certificate with too many DNS names which adds more": {
+			operation:     admissionv1.Update,
+			oldObj:        certificateWithDNSNames(150),
+			obj:           certificateWithDNSNames(151),
+			expectedError: field.ErrorList{field.Forbidden(field.NewPath("spec"), "requests 151 subject alternative names, but at most 100 are allowed")}.ToAggregate(),
+		},
+		"should only warn about the update of a Certificate with too many DNS names which does not add more": {
+			operation:        admissionv1.Update,
+			oldObj:           certificateWithDNSNames(150),
+			obj:              certificateWithDNSNames(120),
+			expectedWarnings: []string{"spec requests 120 subject alternative names, but at most 100 are allowed; the number of names may not be increased"},
+		},
+		"should not check the DNS names of a Certificate when its status is updated": {
+			operation:   admissionv1.Update,
+			subResource: "status",
+			oldObj:      certificateWithDNSNames(150),
+			obj:         certificateWithDNSNames(150),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := NewPlugin(50, 100)
+			warnings, err := p.(*resourceValidation).Validate(context.Background(), admissionv1.AdmissionRequest{
+				Operation:       test.operation,
+				SubResource:     test.subResource,
+				RequestResource: &certificateMetaGVR,
+			}, test.oldObj, test.obj)
+			compareErrors(t, test.expectedError, err)
+			if !reflect.DeepEqual(test.expectedWarnings, warnings) {
+				t.Errorf("unexpected warnings. exp=%v, got=%v", test.expectedWarnings, warnings)
+			}
+		})
+	}
+}
+
 func compareErrors(t *testing.T, exp, act error) {
 	if exp == nil && act == nil {
 		return
@@ -79,6 +79,14 @@ const (
 	// Certificate resources.
 	// Github Issue: https://github.com/cert-manager/cert-manager/issues/6393
 	OtherNames featuregate.Feature = "OtherNames"
+
+	// Owner: N/A
+	// Alpha: v1.16
+	//
+	// ExternalPrivateKeys allows Certificates to set spec.privateKey.external,
+	// in which case their private keys are created and held by an external
+	// key management service, and CSRs are signed using the service.
+	ExternalPrivateKeys featuregate.Feature = "ExternalPrivateKeys"
 )
 
 func init() {
@@ -99,4 +107,5 @@ var webhookFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
 	LiteralCertificateSubject:          {Default: true, PreRelease: featuregate.Beta},
 	NameConstraints:                    {Default: false, PreRelease: featuregate.Alpha},
 	OtherNames:                         {Default: false, PreRelease: featuregate.Alpha},
+	ExternalPrivateKeys:                {Default: false, PreRelease: featuregate.Alpha},
 }
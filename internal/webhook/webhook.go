@@ -17,6 +17,7 @@ limitations under the License.
 package webhook
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -24,19 +25,31 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apiserver/pkg/authorization/authorizerfactory"
+	kubeinformers "k8s.io/client-go/informers"
+	coreinformers "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/utils/clock"
 	crlog "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	acmeinstall "github.com/cert-manager/cert-manager/internal/apis/acme/install"
 	cminstall "github.com/cert-manager/cert-manager/internal/apis/certmanager/install"
 	"github.com/cert-manager/cert-manager/internal/apis/config/shared"
 	config "github.com/cert-manager/cert-manager/internal/apis/config/webhook"
 	metainstall "github.com/cert-manager/cert-manager/internal/apis/meta/install"
+	crtcommonname "github.com/cert-manager/cert-manager/internal/webhook/admission/certificate/commonname"
+	crtdnsnames "github.com/cert-manager/cert-manager/internal/webhook/admission/certificate/dnsnames"
+	crtduplicatednsnames "github.com/cert-manager/cert-manager/internal/webhook/admission/certificate/duplicatednsnames"
+	crtmissingreferences "github.com/cert-manager/cert-manager/internal/webhook/admission/certificate/missingreferences"
+	crtreissuance "github.com/cert-manager/cert-manager/internal/webhook/admission/certificate/reissuance"
 	crapproval "github.com/cert-manager/cert-manager/internal/webhook/admission/certificaterequest/approval"
+	crcreatedby "github.com/cert-manager/cert-manager/internal/webhook/admission/certificaterequest/createdby"
 	cridentity "github.com/cert-manager/cert-manager/internal/webhook/admission/certificaterequest/identity"
 	"github.com/cert-manager/cert-manager/internal/webhook/admission/resourcevalidation"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/server/tls"
 	"github.com/cert-manager/cert-manager/pkg/server/tls/authority"
@@ -59,8 +72,51 @@ func NewCertManagerWebhookServer(log logr.Logger, opts config.WebhookConfigurati
 		return nil, fmt.Errorf("error creating kubernetes client: %s", err)
 	}
 
+	cmcl, err := cmclient.NewForConfig(restcfg)
+	if err != nil {
+		return nil, fmt.Errorf("error creating cert-manager client: %s", err)
+	}
+
+	var runnables []manager.Runnable
+
 	// Set up the admission chain
-	admissionHandler, err := buildAdmissionChain(cl)
+	var plugins []admission.Interface
+	if opts.DuplicateDNSNamesPolicy == config.DuplicateDNSNamesPolicyWarn || opts.DuplicateDNSNamesPolicy == config.DuplicateDNSNamesPolicyStrict {
+		factory := cminformers.NewSharedInformerFactory(cmcl, 0)
+		certificates := factory.Certmanager().V1().Certificates()
+		plugins = append(plugins, crtduplicatednsnames.NewPlugin(
+			opts.DuplicateDNSNamesPolicy == config.DuplicateDNSNamesPolicyStrict,
+			certificates.Lister(),
+			certificates.Informer().HasSynced,
+		))
+		runnables = append(runnables, manager.RunnableFunc(func(ctx context.Context) error {
+			factory.Start(ctx.Done())
+			<-ctx.Done()
+			factory.Shutdown()
+			return nil
+		}))
+	}
+	if opts.MissingReferencesPolicy == config.MissingReferencesPolicyWarn || opts.MissingReferencesPolicy == config.MissingReferencesPolicyStrict {
+		plugins = append(plugins, crtmissingreferences.NewPlugin(
+			opts.MissingReferencesPolicy == config.MissingReferencesPolicyStrict,
+			cl,
+			cmcl,
+		))
+	}
+
+	// The namespaces are watched to find out which of them are protected
+	// from Certificate re-issuance.
+	kubeFactory := kubeinformers.NewSharedInformerFactory(cl, 0)
+	namespaces := kubeFactory.Core().V1().Namespaces()
+	namespaces.Informer()
+	runnables = append(runnables, manager.RunnableFunc(func(ctx context.Context) error {
+		kubeFactory.Start(ctx.Done())
+		<-ctx.Done()
+		kubeFactory.Shutdown()
+		return nil
+	}))
+
+	admissionHandler, err := buildAdmissionChain(cl, namespaces, opts, plugins...)
 	if err != nil {
 		return nil, err
 	}
@@ -81,6 +137,7 @@ func NewCertManagerWebhookServer(log logr.Logger, opts config.WebhookConfigurati
 		MinTLSVersion:     opts.TLSConfig.MinTLSVersion,
 		ValidationWebhook: admissionHandler,
 		MutationWebhook:   admissionHandler,
+		Runnables:         runnables,
 	}
 	for _, fn := range optionFunctions {
 		fn(s)
@@ -88,7 +145,7 @@ func NewCertManagerWebhookServer(log logr.Logger, opts config.WebhookConfigurati
 	return s, nil
 }
 
-func buildAdmissionChain(client kubernetes.Interface) (admission.PluginChain, error) {
+func buildAdmissionChain(client kubernetes.Interface, namespaces coreinformers.NamespaceInformer, opts config.WebhookConfiguration, plugins ...admission.Interface) (admission.PluginChain, error) {
 	authorizer, err := authorizerfactory.DelegatingAuthorizerConfig{
 		SubjectAccessReviewClient: client.AuthorizationV1(),
 		// cache responses for 1 second
@@ -106,11 +163,15 @@ func buildAdmissionChain(client kubernetes.Interface) (admission.PluginChain, er
 		return nil, fmt.Errorf("error creating authorization handler: %v", err)
 	}
 
-	pluginChain := admission.PluginChain([]admission.Interface{
+	pluginChain := admission.PluginChain(append([]admission.Interface{
 		cridentity.NewPlugin(),
+		crcreatedby.NewPlugin(opts.ControllerUsername),
+		crtdnsnames.NewPlugin(),
+		crtcommonname.NewPlugin(),
+		crtreissuance.NewPlugin(opts.ControllerUsername, client, namespaces.Lister(), namespaces.Informer().HasSynced, clock.RealClock{}),
 		crapproval.NewPlugin(authorizer, client.Discovery()),
-		resourcevalidation.NewPlugin(),
-	})
+		resourcevalidation.NewPlugin(int(opts.CertificateSANsWarningThreshold), int(opts.MaxCertificateSANs)),
+	}, plugins...))
 
 	return pluginChain, nil
 }
@@ -26,6 +26,7 @@ import (
 
 	acmeapi "golang.org/x/crypto/acme"
 
+	"github.com/cert-manager/cert-manager/internal/tlsclient"
 	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
 	"github.com/cert-manager/cert-manager/pkg/acme/client/middleware"
 	acmeutil "github.com/cert-manager/cert-manager/pkg/acme/util"
@@ -46,14 +47,22 @@ type NewClientFunc func(*http.Client, cmacme.ACMEIssuer, *rsa.PrivateKey, string
 var _ NewClientFunc = NewClient
 
 // NewClient is an implementation of NewClientFunc that returns a real ACME client.
+// The returned client re-discovers the ACME directory when it appears to be
+// out of date, or when it is older than the Issuer's directoryMaxAge.
 func NewClient(client *http.Client, config cmacme.ACMEIssuer, privateKey *rsa.PrivateKey, userAgent string) acmecl.Interface {
-	return middleware.NewLogger(&acmeapi.Client{
-		Key:          privateKey,
-		HTTPClient:   client,
-		DirectoryURL: config.Server,
-		UserAgent:    userAgent,
-		RetryBackoff: acmeutil.RetryBackoff,
-	})
+	var maxDirectoryAge time.Duration
+	if config.DirectoryMaxAge != nil {
+		maxDirectoryAge = config.DirectoryMaxAge.Duration
+	}
+	return middleware.NewLogger(middleware.NewDirectoryRefresher(client, config.Server, maxDirectoryAge, func(client *http.Client) acmecl.Interface {
+		return &acmeapi.Client{
+			Key:          privateKey,
+			HTTPClient:   client,
+			DirectoryURL: config.Server,
+			UserAgent:    userAgent,
+			RetryBackoff: acmeutil.RetryBackoff,
+		}
+	}))
 }
 
 // BuildHTTPClient returns a instrumented HTTP client to be used by an ACME client.
@@ -84,6 +93,32 @@ func BuildHTTPClientWithCABundle(metrics *metrics.Metrics, skipTLSVerify bool, c
 		}
 	}
 
+	return buildHTTPClient(metrics, tlsConfig)
+}
+
+// BuildHTTPClientForIssuer returns a instrumented HTTP client to be used by an
+// ACME client, using the TLS settings of the given ACME issuer: the CA bundle,
+// 'skipTLSVerify' flag, and the minimum TLS version and cipher suites, which
+// default to the controller-wide settings.
+// The CAs in secretCABundle, loaded from the issuer's caBundleSecretRef, are
+// trusted in addition to those of the CA bundle.
+// An error is returned if the TLS settings are invalid.
+func BuildHTTPClientForIssuer(metrics *metrics.Metrics, config *cmacme.ACMEIssuer, secretCABundle []byte) (*http.Client, error) {
+	caBundle := config.CABundle
+	if len(secretCABundle) > 0 {
+		caBundle = append(append(append([]byte(nil), caBundle...), '\n'), secretCABundle...)
+	}
+
+	tlsConfig, err := tlsclient.Config(config.TLS, caBundle)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig.InsecureSkipVerify = config.SkipTLSVerify
+
+	return buildHTTPClient(metrics, tlsConfig), nil
+}
+
+func buildHTTPClient(metrics *metrics.Metrics, tlsConfig *tls.Config) *http.Client {
 	return acmecl.NewInstrumentedClient(
 		metrics,
 		&http.Client{
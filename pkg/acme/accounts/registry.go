@@ -91,6 +91,9 @@ type stableOptions struct {
 	exponent      int
 	caBundle      string
 	keyChecksum   [sha256.Size]byte
+	// directoryMaxAge is the string form of the Issuer's directoryMaxAge,
+	// which is empty if it is unset
+	directoryMaxAge string
 }
 
 func (c stableOptions) equalTo(c2 stableOptions) bool {
@@ -101,6 +104,10 @@ func newStableOptions(uid string, config cmacme.ACMEIssuer, privateKey *rsa.Priv
 	// Encoding a big.Int cannot fail
 	publicNBytes, _ := privateKey.PublicKey.N.GobEncode()
 	checksum := sha256.Sum256(x509.MarshalPKCS1PrivateKey(privateKey))
+	var directoryMaxAge string
+	if config.DirectoryMaxAge != nil {
+		directoryMaxAge = config.DirectoryMaxAge.Duration.String()
+	}
 
 	return stableOptions{
 		serverURL:     config.Server,
@@ -110,6 +117,8 @@ func newStableOptions(uid string, config cmacme.ACMEIssuer, privateKey *rsa.Priv
 		exponent:      privateKey.PublicKey.E,
 		caBundle:      string(config.CABundle),
 		keyChecksum:   checksum,
+
+		directoryMaxAge: directoryMaxAge,
 	}
 }
 
@@ -0,0 +1,255 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/crypto/acme"
+	"k8s.io/utils/clock"
+
+	"github.com/cert-manager/cert-manager/pkg/acme/client"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+// maxDirectorySize is the max size of a directory response body that will be
+// inspected for endpoints. Directories are small, so this is generous.
+const maxDirectorySize = 1024 * 1024 // 1mb
+
+// NewDirectoryRefresher returns an ACME client which builds its underlying
+// client using newClient, and replaces it with a new one, so that the ACME
+// directory is discovered again, when either:
+//   - a request to one of the endpoints listed in the directory returns a 404
+//     Not Found or 410 Gone response, or
+//   - maxAge is non-zero and the current client was built more than maxAge
+//     ago.
+//
+// The underlying ACME library caches the directory for the lifetime of a
+// client, so without this an ACME server which relocates its endpoints would
+// cause every request to fail until the controller is restarted.
+func NewDirectoryRefresher(httpClient *http.Client, directoryURL string, maxAge time.Duration, newClient func(*http.Client) client.Interface) client.Interface {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	wrapped := httpClient.Transport
+	if wrapped == nil {
+		wrapped = http.DefaultTransport
+	}
+
+	r := &DirectoryRefresher{
+		newClient:    newClient,
+		directoryURL: directoryURL,
+		maxAge:       maxAge,
+		clock:        clock.RealClock{},
+		log:          logf.Log.WithName("acme-middleware"),
+	}
+
+	// Copy the HTTP client so that other users of it are not affected.
+	cl := *httpClient
+	cl.Transport = &directoryTransport{wrapped: wrapped, refresher: r}
+	r.httpClient = &cl
+
+	return r
+}
+
+// DirectoryRefresher is an ACME client middleware which discards its cached
+// ACME directory when it appears to be out of date.
+type DirectoryRefresher struct {
+	newClient    func(*http.Client) client.Interface
+	httpClient   *http.Client
+	directoryURL string
+	maxAge       time.Duration
+	clock        clock.Clock
+	log          logr.Logger
+
+	lock      sync.Mutex
+	baseCl    client.Interface
+	createdAt time.Time
+	// endpoints is the set of URLs listed in the most recently discovered
+	// directory
+	endpoints map[string]struct{}
+	stale     bool
+}
+
+var _ client.Interface = &DirectoryRefresher{}
+
+// client returns the current underlying ACME client, building a new one if
+// there is none or if the cached directory should be refreshed.
+func (r *DirectoryRefresher) client() client.Interface {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	expired := r.maxAge > 0 && r.clock.Since(r.createdAt) >= r.maxAge
+	if r.baseCl != nil && !r.stale && !expired {
+		return r.baseCl
+	}
+	if r.baseCl != nil {
+		r.log.V(logf.DebugLevel).Info("refreshing ACME directory", "directory_url", r.directoryURL, "stale", r.stale, "expired", expired)
+	}
+
+	r.baseCl = r.newClient(r.httpClient)
+	r.createdAt = r.clock.Now()
+	r.endpoints = nil
+	r.stale = false
+	return r.baseCl
+}
+
+// setEndpoints records the endpoints listed in a discovered directory.
+func (r *DirectoryRefresher) setEndpoints(urls ...string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.endpoints = make(map[string]struct{}, len(urls))
+	for _, u := range urls {
+		if u != "" {
+			r.endpoints[u] = struct{}{}
+		}
+	}
+}
+
+// invalidate marks the cached directory as stale if url is one of its
+// endpoints.
+func (r *DirectoryRefresher) invalidate(url string, statusCode int) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if _, ok := r.endpoints[url]; !ok {
+		return
+	}
+	r.log.V(logf.InfoLevel).Info("ACME directory endpoint no longer exists, the directory will be refreshed", "url", url, "status_code", statusCode)
+	r.stale = true
+}
+
+// directoryTransport is a http.RoundTripper which observes responses to keep
+// track of the endpoints in the ACME directory, and marks the directory as
+// stale when one of them is no longer found.
+type directoryTransport struct {
+	wrapped   http.RoundTripper
+	refresher *DirectoryRefresher
+}
+
+func (t *directoryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.wrapped.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	url := req.URL.String()
+	switch {
+	case req.Method == http.MethodGet && url == t.refresher.directoryURL && resp.StatusCode == http.StatusOK:
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxDirectorySize))
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+
+		var dir struct {
+			NewNonce   string `json:"newNonce"`
+			NewAccount string `json:"newAccount"`
+			NewOrder   string `json:"newOrder"`
+			NewAuthz   string `json:"newAuthz"`
+			RevokeCert string `json:"revokeCert"`
+			KeyChange  string `json:"keyChange"`
+		}
+		// Leave reporting of malformed directories to the ACME client.
+		if json.Unmarshal(body, &dir) == nil {
+			t.refresher.setEndpoints(dir.NewNonce, dir.NewAccount, dir.NewOrder, dir.NewAuthz, dir.RevokeCert, dir.KeyChange)
+		}
+
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		t.refresher.invalidate(url, resp.StatusCode)
+	}
+
+	return resp, nil
+}
+
+func (r *DirectoryRefresher) AuthorizeOrder(ctx context.Context, id []acme.AuthzID, opt ...acme.OrderOption) (*acme.Order, error) {
+	return r.client().AuthorizeOrder(ctx, id, opt...)
+}
+
+func (r *DirectoryRefresher) GetOrder(ctx context.Context, url string) (*acme.Order, error) {
+	return r.client().GetOrder(ctx, url)
+}
+
+func (r *DirectoryRefresher) FetchCert(ctx context.Context, url string, bundle bool) ([][]byte, error) {
+	return r.client().FetchCert(ctx, url, bundle)
+}
+
+func (r *DirectoryRefresher) ListCertAlternates(ctx context.Context, url string) ([]string, error) {
+	return r.client().ListCertAlternates(ctx, url)
+}
+
+func (r *DirectoryRefresher) WaitOrder(ctx context.Context, url string) (*acme.Order, error) {
+	return r.client().WaitOrder(ctx, url)
+}
+
+func (r *DirectoryRefresher) CreateOrderCert(ctx context.Context, finalizeURL string, csr []byte, bundle bool) (der [][]byte, certURL string, err error) {
+	return r.client().CreateOrderCert(ctx, finalizeURL, csr, bundle)
+}
+
+func (r *DirectoryRefresher) Accept(ctx context.Context, chal *acme.Challenge) (*acme.Challenge, error) {
+	return r.client().Accept(ctx, chal)
+}
+
+func (r *DirectoryRefresher) GetChallenge(ctx context.Context, url string) (*acme.Challenge, error) {
+	return r.client().GetChallenge(ctx, url)
+}
+
+func (r *DirectoryRefresher) GetAuthorization(ctx context.Context, url string) (*acme.Authorization, error) {
+	return r.client().GetAuthorization(ctx, url)
+}
+
+func (r *DirectoryRefresher) WaitAuthorization(ctx context.Context, url string) (*acme.Authorization, error) {
+	return r.client().WaitAuthorization(ctx, url)
+}
+
+func (r *DirectoryRefresher) Register(ctx context.Context, a *acme.Account, prompt func(tosURL string) bool) (*acme.Account, error) {
+	return r.client().Register(ctx, a, prompt)
+}
+
+func (r *DirectoryRefresher) GetReg(ctx context.Context, url string) (*acme.Account, error) {
+	return r.client().GetReg(ctx, url)
+}
+
+func (r *DirectoryRefresher) HTTP01ChallengeResponse(token string) (string, error) {
+	return r.client().HTTP01ChallengeResponse(token)
+}
+
+func (r *DirectoryRefresher) DNS01ChallengeRecord(token string) (string, error) {
+	return r.client().DNS01ChallengeRecord(token)
+}
+
+func (r *DirectoryRefresher) Discover(ctx context.Context) (acme.Directory, error) {
+	return r.client().Discover(ctx)
+}
+
+func (r *DirectoryRefresher) UpdateReg(ctx context.Context, a *acme.Account) (*acme.Account, error) {
+	return r.client().UpdateReg(ctx, a)
+}
+
+func (r *DirectoryRefresher) RevokeCert(ctx context.Context, key crypto.Signer, cert []byte, reason acme.CRLReasonCode) error {
+	return r.client().RevokeCert(ctx, key, cert, reason)
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package middleware
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/acme"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	"github.com/cert-manager/cert-manager/pkg/acme/client"
+)
+
+// fakeACMEServer is a minimal ACME server whose newNonce endpoint can be
+// moved while it is running.
+type fakeACMEServer struct {
+	*httptest.Server
+
+	lock          sync.Mutex
+	noncePath     string
+	directoryHits int
+	nonceCount    int
+}
+
+func newFakeACMEServer(t *testing.T) *fakeACMEServer {
+	s := &fakeACMEServer{noncePath: "/nonce-a"}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/directory", func(w http.ResponseWriter, r *http.Request) {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		s.directoryHits++
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"newNonce":   s.URL + s.noncePath,
+			"newAccount": s.URL + "/new-account",
+			"newOrder":   s.URL + "/new-order",
+		})
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		if r.URL.Path != s.noncePath {
+			http.NotFound(w, r)
+			return
+		}
+		s.nonceCount++
+		w.Header().Set("Replay-Nonce", fmt.Sprintf("nonce-%d", s.nonceCount))
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/new-account", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", s.URL+"/account/1")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"status":"valid"}`))
+	})
+	mux.HandleFunc("/new-order", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", s.URL+"/order/1")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"status":"pending","identifiers":[{"type":"dns","value":"example.com"}],"authorizations":[],"finalize":"` + s.URL + `/finalize/1"}`))
+	})
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *fakeACMEServer) relocateNonce(path string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.noncePath = path
+}
+
+func (s *fakeACMEServer) getDirectoryHits() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.directoryHits
+}
+
+func newTestDirectoryRefresher(t *testing.T, s *fakeACMEServer, maxAge time.Duration) *DirectoryRefresher {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	directoryURL := s.URL + "/directory"
+	return NewDirectoryRefresher(s.Client(), directoryURL, maxAge, func(httpClient *http.Client) client.Interface {
+		return &acme.Client{
+			Key:          key,
+			HTTPClient:   httpClient,
+			DirectoryURL: directoryURL,
+		}
+	}).(*DirectoryRefresher)
+}
+
+func TestDirectoryRefresher_RefreshesWhenEndpointIsNotFound(t *testing.T) {
+	ctx := context.Background()
+	s := newFakeACMEServer(t)
+	cl := newTestDirectoryRefresher(t, s, 0)
+	ids := acme.DomainIDs("example.com")
+
+	_, err := cl.AuthorizeOrder(ctx, ids)
+	require.NoError(t, err)
+	assert.Equal(t, 1, s.getDirectoryHits())
+
+	// Move the newNonce endpoint. The cached directory still points to the
+	// old endpoint, so the next request fails.
+	s.relocateNonce("/nonce-b")
+	_, err = cl.AuthorizeOrder(ctx, ids)
+	require.Error(t, err)
+	assert.Equal(t, 1, s.getDirectoryHits())
+
+	// The 404 from the old endpoint should have caused the directory to be
+	// discovered again.
+	_, err = cl.AuthorizeOrder(ctx, ids)
+	require.NoError(t, err)
+	assert.Equal(t, 2, s.getDirectoryHits())
+
+	dir, err := cl.Discover(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, s.URL+"/nonce-b", dir.NonceURL)
+}
+
+func TestDirectoryRefresher_IgnoresNotFoundForOtherURLs(t *testing.T) {
+	ctx := context.Background()
+	s := newFakeACMEServer(t)
+	cl := newTestDirectoryRefresher(t, s, 0)
+
+	_, err := cl.Discover(ctx)
+	require.NoError(t, err)
+
+	// A 404 for a URL which isn't listed in the directory, such as a
+	// deleted order, must not cause the directory to be refreshed.
+	_, err = cl.GetOrder(ctx, s.URL+"/order/does-not-exist")
+	require.Error(t, err)
+
+	_, err = cl.Discover(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, s.getDirectoryHits())
+}
+
+func TestDirectoryRefresher_MaxAge(t *testing.T) {
+	ctx := context.Background()
+	s := newFakeACMEServer(t)
+	cl := newTestDirectoryRefresher(t, s, time.Hour)
+	fakeClock := fakeclock.NewFakeClock(time.Now())
+	cl.clock = fakeClock
+
+	_, err := cl.Discover(ctx)
+	require.NoError(t, err)
+	fakeClock.Step(time.Hour - time.Second)
+	_, err = cl.Discover(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, s.getDirectoryHits())
+
+	fakeClock.Step(time.Second)
+	_, err = cl.Discover(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, s.getDirectoryHits())
+}
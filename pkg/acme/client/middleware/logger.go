@@ -18,6 +18,7 @@ package middleware
 
 import (
 	"context"
+	"crypto"
 
 	"github.com/go-logr/logr"
 	"golang.org/x/crypto/acme"
@@ -136,3 +137,9 @@ func (l *Logger) UpdateReg(ctx context.Context, a *acme.Account) (*acme.Account,
 
 	return l.baseCl.UpdateReg(ctx, a)
 }
+
+func (l *Logger) RevokeCert(ctx context.Context, key crypto.Signer, cert []byte, reason acme.CRLReasonCode) error {
+	l.log.V(logf.TraceLevel).Info("Calling RevokeCert")
+
+	return l.baseCl.RevokeCert(ctx, key, cert, reason)
+}
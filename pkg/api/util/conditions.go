@@ -96,6 +96,22 @@ func SetIssuerCondition(i cmapi.GenericIssuer, observedGeneration int64, conditi
 	logf.V(logf.InfoLevel).Infof("Setting lastTransitionTime for Issuer %q condition %q to %v", i.GetObjectMeta().Name, conditionType, nowTime.Time)
 }
 
+// RemoveIssuerCondition will remove any condition with this condition type
+// from the given GenericIssuer.
+func RemoveIssuerCondition(i cmapi.GenericIssuer, conditionType cmapi.IssuerConditionType) {
+	var updatedConditions []cmapi.IssuerCondition
+
+	// Search through existing conditions
+	for _, cond := range i.GetStatus().Conditions {
+		// Only add unrelated conditions
+		if cond.Type != conditionType {
+			updatedConditions = append(updatedConditions, cond)
+		}
+	}
+
+	i.GetStatus().Conditions = updatedConditions
+}
+
 // CertificateHasCondition will return true if the given Certificate has a
 // condition matching the provided CertificateCondition.
 // Only the Type and Status field will be used in the comparison, meaning that
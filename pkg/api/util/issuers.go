@@ -18,7 +18,9 @@ package util
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 )
@@ -34,6 +36,8 @@ const (
 	IssuerSelfSigned string = "selfsigned"
 	// IssuerVenafi uses Venafi Trust Protection Platform and Venafi Cloud
 	IssuerVenafi string = "venafi"
+	// IssuerManual delivers certificates which are provided by an operator
+	IssuerManual string = "manual"
 )
 
 // NameForIssuer determines the name of the Issuer implementation given an
@@ -50,6 +54,8 @@ func NameForIssuer(i cmapi.GenericIssuer) (string, error) {
 		return IssuerSelfSigned, nil
 	case i.GetSpec().Venafi != nil:
 		return IssuerVenafi, nil
+	case i.GetSpec().Manual != nil:
+		return IssuerManual, nil
 	}
 	return "", fmt.Errorf("no issuer specified for Issuer '%s/%s'", i.GetObjectMeta().Namespace, i.GetObjectMeta().Name)
 }
@@ -61,3 +67,30 @@ func IssuerKind(ref cmmeta.ObjectReference) string {
 	}
 	return ref.Kind
 }
+
+// NormalizeIssuerKindAndGroup returns the kind and group of an issuer
+// reference in their canonical form, so that references to the same issuer
+// can be compared and are always written in the same way. An empty kind
+// defaults to Issuer and an empty group defaults to cert-manager.io. A kind
+// qualified with its group, e.g. "ClusterIssuer.cert-manager.io", is split
+// into its kind and group, unless it conflicts with the given group.
+func NormalizeIssuerKindAndGroup(kind, group string) (string, string) {
+	if k, g, ok := strings.Cut(kind, "."); ok && (group == "" || group == g) {
+		kind, group = k, g
+	}
+	if kind == "" {
+		kind = cmapi.IssuerKind
+	}
+	if group == "" {
+		group = certmanager.GroupName
+	}
+	return kind, group
+}
+
+// IssuerRefsEqual returns true if both references refer to the same issuer,
+// after normalizing their kind and group with NormalizeIssuerKindAndGroup.
+func IssuerRefsEqual(a, b cmmeta.ObjectReference) bool {
+	aKind, aGroup := NormalizeIssuerKindAndGroup(a.Kind, a.Group)
+	bKind, bGroup := NormalizeIssuerKindAndGroup(b.Kind, b.Group)
+	return a.Name == b.Name && aKind == bKind && aGroup == bGroup
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+func TestNormalizeIssuerKindAndGroup(t *testing.T) {
+	tests := map[string]struct {
+		kind, group       string
+		expKind, expGroup string
+	}{
+		"empty kind and group are defaulted": {
+			expKind: "Issuer", expGroup: "cert-manager.io",
+		},
+		"empty group is defaulted": {
+			kind:    "ClusterIssuer",
+			expKind: "ClusterIssuer", expGroup: "cert-manager.io",
+		},
+		"canonical kind and group are unchanged": {
+			kind: "ClusterIssuer", group: "cert-manager.io",
+			expKind: "ClusterIssuer", expGroup: "cert-manager.io",
+		},
+		"external issuer kind and group are unchanged": {
+			kind: "AWSPCAIssuer", group: "awspca.cert-manager.io",
+			expKind: "AWSPCAIssuer", expGroup: "awspca.cert-manager.io",
+		},
+		"fully qualified kind without group is split": {
+			kind:    "ClusterIssuer.cert-manager.io",
+			expKind: "ClusterIssuer", expGroup: "cert-manager.io",
+		},
+		"fully qualified kind with the same group is split": {
+			kind: "AWSPCAIssuer.awspca.cert-manager.io", group: "awspca.cert-manager.io",
+			expKind: "AWSPCAIssuer", expGroup: "awspca.cert-manager.io",
+		},
+		"fully qualified kind with a different group is unchanged": {
+			kind: "Issuer.example.com", group: "cert-manager.io",
+			expKind: "Issuer.example.com", expGroup: "cert-manager.io",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			kind, group := NormalizeIssuerKindAndGroup(test.kind, test.group)
+			if kind != test.expKind || group != test.expGroup {
+				t.Errorf("expected kind %q and group %q, got kind %q and group %q", test.expKind, test.expGroup, kind, group)
+			}
+		})
+	}
+}
+
+func TestIssuerRefsEqual(t *testing.T) {
+	tests := map[string]struct {
+		a, b cmmeta.ObjectReference
+		exp  bool
+	}{
+		"identical references are equal": {
+			a:   cmmeta.ObjectReference{Name: "ca", Kind: "Issuer", Group: "cert-manager.io"},
+			b:   cmmeta.ObjectReference{Name: "ca", Kind: "Issuer", Group: "cert-manager.io"},
+			exp: true,
+		},
+		"defaulted kind and group are equal to the explicit defaults": {
+			a:   cmmeta.ObjectReference{Name: "ca"},
+			b:   cmmeta.ObjectReference{Name: "ca", Kind: "Issuer", Group: "cert-manager.io"},
+			exp: true,
+		},
+		"fully qualified kind is equal to the kind and group": {
+			a:   cmmeta.ObjectReference{Name: "ca", Kind: "ClusterIssuer.cert-manager.io"},
+			b:   cmmeta.ObjectReference{Name: "ca", Kind: "ClusterIssuer"},
+			exp: true,
+		},
+		"different names are not equal": {
+			a: cmmeta.ObjectReference{Name: "ca"},
+			b: cmmeta.ObjectReference{Name: "other"},
+		},
+		"different kinds are not equal": {
+			a: cmmeta.ObjectReference{Name: "ca"},
+			b: cmmeta.ObjectReference{Name: "ca", Kind: "ClusterIssuer"},
+		},
+		"different groups are not equal": {
+			a: cmmeta.ObjectReference{Name: "ca", Kind: "Issuer"},
+			b: cmmeta.ObjectReference{Name: "ca", Kind: "Issuer", Group: "example.com"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := IssuerRefsEqual(test.a, test.b); got != test.exp {
+				t.Errorf("expected %t, got %t", test.exp, got)
+			}
+		})
+	}
+}
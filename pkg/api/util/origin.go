@@ -0,0 +1,42 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+const (
+	// CertificateRequestOriginManaged is the origin of CertificateRequests
+	// created by cert-manager for a Certificate.
+	CertificateRequestOriginManaged = "managed"
+
+	// CertificateRequestOriginDirect is the origin of CertificateRequests
+	// created directly, outside of a Certificate.
+	CertificateRequestOriginDirect = "direct"
+)
+
+// CertificateRequestOrigin returns whether the CertificateRequest was created
+// by cert-manager for a Certificate, or directly. The created-by label which
+// marks managed CertificateRequests can only be set by cert-manager, as
+// enforced by the webhook.
+func CertificateRequestOrigin(cr *cmapi.CertificateRequest) string {
+	if cr.Labels[cmapi.CertificateRequestCreatedByLabelKey] != "" {
+		return CertificateRequestOriginManaged
+	}
+	return CertificateRequestOriginDirect
+}
@@ -49,6 +49,40 @@ const (
 	// SolverIdentificationLabelKey is added to the labels of a Pod serving an ACME challenge.
 	// Its value will be the "true" if the Pod is an HTTP-01 solver.
 	SolverIdentificationLabelKey = "acme.cert-manager.io/http01-solver"
+
+	// SolverGroupLabelKey is added to the labels of a Pod, and of the
+	// ConfigMap it serves key authorizations from, when the HTTP-01
+	// challenges of an Order are solved by a single shared Pod.
+	// Its value identifies the group of challenges served by the Pod.
+	SolverGroupLabelKey = "acme.cert-manager.io/http01-solver-group"
+
+	// SolverSelectionAnnotationKey is added to Challenge resources created by
+	// the Order controller. Its value explains which of the issuer's solvers
+	// was selected for the challenge and why.
+	SolverSelectionAnnotationKey = "acme.cert-manager.io/solver-selection"
+
+	// SolverSelectionAmbiguousAnnotationKey is added to Challenge resources
+	// with the value "true" if more than one of the issuer's solvers matched
+	// the challenge equally well, and the solver was therefore chosen based
+	// on the order the solvers are listed in.
+	SolverSelectionAmbiguousAnnotationKey = "acme.cert-manager.io/solver-selection-ambiguous"
+
+	// OrderURLAnnotationKey is added to CertificateRequest resources issued by
+	// an ACME issuer. Its value is the URL of the ACME order created for the
+	// request, which CAs typically ask for when debugging failed issuance.
+	OrderURLAnnotationKey = "acme.cert-manager.io/order-url"
+
+	// SkipAllowedZonesCheckAnnotationKey can be set to "true" on a Certificate
+	// to skip checking its DNS names against the allowedZones of its ACME
+	// issuer, for intentional issuance for names outside of those zones.
+	// The annotation is copied to the Certificate's CertificateRequests.
+	SkipAllowedZonesCheckAnnotationKey = "acme.cert-manager.io/skip-allowed-zones-check"
+
+	// RetryPendingAnnotationKey can be set on an ACME Issuer or ClusterIssuer
+	// to retry its pending Orders and Challenges immediately, without waiting
+	// for their backoff to expire. They are retried each time the value of
+	// the annotation changes, which is typically the current time.
+	RetryPendingAnnotationKey = "acme.cert-manager.io/retry-pending"
 )
 
 const (
@@ -30,8 +30,34 @@ const (
 	// See https://github.com/cert-manager/cert-manager/blob/master/design/20221205-memory-management.md#risks-and-mitigations
 	PartOfCertManagerControllerLabelKey = "controller.cert-manager.io/fao"
 
+	// Label key set on CertificateRequests created by cert-manager for a
+	// Certificate, with the name of the controller which created them as its
+	// value. Only cert-manager may set this label, so that CertificateRequests
+	// created outside of a Certificate can be told apart.
+	CertificateRequestCreatedByLabelKey = "cert-manager.io/created-by"
+
+	// Label key set on CertificateRequests created by cert-manager for a
+	// Certificate, with the name of that Certificate as its value, so that
+	// the CertificateRequests of a Certificate can be listed with a label
+	// selector. The controller keeps this label up to date on existing
+	// CertificateRequests. It is not set if the name of the Certificate is
+	// longer than 63 characters, which is not a valid label value.
+	CertificateNameLabelKey = "cert-manager.io/certificate-name"
+
+	// Label key which, set to "true" on a namespace, makes the webhook reject
+	// updates to Certificates in the namespace which would cause them to be
+	// re-issued, unless the update is confirmed using the
+	// ConfirmReissueAnnotationKey annotation.
+	ProtectedNamespaceLabelKey = "cert-manager.io/protected"
+
 	// Common annotation keys added to resources
 
+	// Annotation key used to confirm an update to a Certificate in a
+	// protected namespace which causes it to be re-issued. Its value must be
+	// the metadata.generation of the Certificate being updated, and it must be
+	// set by the update being confirmed.
+	ConfirmReissueAnnotationKey = "cert-manager.io/confirm-reissue"
+
 	// Annotation key for DNS subjectAltNames.
 	AltNamesAnnotationKey = "cert-manager.io/alt-names"
 
@@ -92,6 +118,13 @@ const (
 	// Annotation key for the name of the certificate that a resource is related to.
 	CertificateNameKey = "cert-manager.io/certificate-name"
 
+	// Annotation key set to "true" on a Certificate's Secret if the private
+	// key was issued whilst the Certificate did not set
+	// spec.privateKey.algorithm, meaning the key was subject to the
+	// controller's private key defaults. Such keys are not replaced when the
+	// defaults change.
+	PrivateKeyDefaultedAnnotationKey = "cert-manager.io/private-key-defaulted"
+
 	// Annotation key used to denote whether a Secret is named on a Certificate
 	// as a 'next private key' Secret resource.
 	IsNextPrivateKeySecretLabelKey = "cert-manager.io/next-private-key"
@@ -127,6 +160,14 @@ const (
 	// Annotation key used to set the PrivateKeyRotationPolicy for a Certificate.
 	// If unset a policy `Never` will be used.
 	PrivateKeyRotationPolicyAnnotationKey = "cert-manager.io/private-key-rotation-policy"
+
+	// VaultAppRoleUnwrappedSecretIDsAnnotationKey is set by cert-manager on
+	// Secrets referenced by a Vault App Role with `responseWrapped` set. Once
+	// a response-wrapping token has been unwrapped, it is replaced in the
+	// Secret by the secret ID it wrapped, and the SHA-256 hash of the secret
+	// ID is added to this comma-separated list, so that it is not unwrapped
+	// again.
+	VaultAppRoleUnwrappedSecretIDsAnnotationKey = "cert-manager.io/vault-approle-unwrapped-secret-ids"
 )
 
 const (
@@ -149,6 +190,15 @@ const (
 	// IngressSecretTemplate can be used to set the secretTemplate field in the generated Certificate.
 	// The value is a JSON representation of secretTemplate and must not have any unknown fields.
 	IngressSecretTemplate = "cert-manager.io/secret-template"
+
+	// GatewayListenerAnnotationDomain is the domain of the Gateway annotations
+	// which override an annotation for the Certificates of a single listener.
+	// For example, `https.listener.cert-manager.io/duration` sets the duration
+	// of the Certificate of the listener named "https", overriding the
+	// `cert-manager.io/duration` annotation of the Gateway.
+	// Only the duration, renew-before, usages, revision-history-limit,
+	// private-key-algorithm and private-key-size annotations can be overridden.
+	GatewayListenerAnnotationDomain = "listener.cert-manager.io"
 )
 
 // Annotation names for CertificateRequests
@@ -162,6 +212,22 @@ const (
 
 	// Annotation to declare the CertificateRequest "revision", belonging to a Certificate Resource
 	CertificateRequestRevisionAnnotationKey = "cert-manager.io/certificate-revision"
+
+	// Annotation added to CertificateRequest resources created for a
+	// Certificate to record the lowercase hex encoded SHA-256 fingerprint of
+	// the public key in the CSR. It is used to audit that private keys are
+	// re-used across revisions, and to verify that the issued certificate is
+	// for the requested key.
+	CertificateRequestPublicKeyFingerprintAnnotationKey = "cert-manager.io/public-key-fingerprint"
+
+	// CertificateRequestDeliverToSecretAnnotationKey can be added to
+	// CertificateRequest resources which are not created for a Certificate.
+	// Once the CertificateRequest is Ready, the signed certificate and CA are
+	// written to the Secret with this name in the same namespace. The private
+	// key is never written, as it is held by the user who created the request.
+	// An existing Secret is only written to if it is a kubernetes.io/tls Secret
+	// which does not hold a certificate yet, and is not owned by the request.
+	CertificateRequestDeliverToSecretAnnotationKey = "cert-manager.io/deliver-to-secret"
 )
 
 const (
@@ -171,14 +237,74 @@ const (
 	// stored in the target Secret resource whilst the real Issuer is processing
 	// the certificate request.
 	IssueTemporaryCertificateAnnotation = "cert-manager.io/issue-temporary-certificate"
+
+	// IssuanceTimeoutAnnotationKey is an annotation that can be added to
+	// Certificate resources to override the controller's issuance timeout.
+	// Its value must be a Go duration string, for example "30m". If the
+	// current CertificateRequest shows no status progress for this long, the
+	// issuance attempt is failed and retried with the usual backoff. A value
+	// of "0s" disables the timeout for the Certificate.
+	IssuanceTimeoutAnnotationKey = "cert-manager.io/issuance-timeout"
+
+	// AdoptExistingSecretAnnotationKey is an annotation that can be added to
+	// Certificate resources to control whether a Secret which already exists
+	// before the Certificate is first issued is adopted. By default, a Secret
+	// holding a certificate and private key which satisfy the Certificate's
+	// spec is adopted, and the certificate is only re-issued at its renewal
+	// time. If set to "false", a certificate is always issued for a
+	// Certificate which has not been issued yet.
+	AdoptExistingSecretAnnotationKey = "cert-manager.io/adopt-existing-secret"
+
+	// DeletePreviousSecretAnnotationKey is an annotation that can be added to
+	// Certificate resources. If set to "true", the Secret which held the
+	// certificate before spec.secretName was changed is deleted once its
+	// certificate has been moved to the Secret named by spec.secretName.
+	// Previous Secrets holding any other certificate are kept. The previous
+	// Secret is always deleted if owner references on Secrets are enabled.
+	DeletePreviousSecretAnnotationKey = "cert-manager.io/delete-previous-secret"
+
+	// VerifyIssuerCAAnnotationKey is an annotation that can be added to
+	// Certificate, Issuer or ClusterIssuer resources. If set to "true" on a
+	// Certificate or on its issuer, the public keys of the CA certificates
+	// returned by the issuer are recorded on the Certificate's status upon
+	// issuance, and a certificate in the Secret which was not signed by one of
+	// these keys is re-issued.
+	VerifyIssuerCAAnnotationKey = "cert-manager.io/verify-issuer-ca"
+
+	// RequireEmbeddedSCTsAnnotationKey is an annotation that can be added to
+	// Issuer or ClusterIssuer resources which are not ACME issuers. Its value
+	// is the minimum number of Signed Certificate Timestamps which must be
+	// embedded in certificates issued by the issuer, as with the
+	// `requireEmbeddedSCTs` field of ACME issuers.
+	RequireEmbeddedSCTsAnnotationKey = "cert-manager.io/require-embedded-scts"
+
+	// CertificateRevocationFinalizer is added to Certificate resources that
+	// have `spec.revokeOnDelete` set, so that the certificate can be revoked
+	// before the Certificate is deleted.
+	CertificateRevocationFinalizer = "cert-manager.io/revoke-on-delete"
+
+	// CertificateMigrationAnnotationKey is added to Certificate resources by
+	// the CertificateMigration controller to record the name of the
+	// CertificateMigration that changed the Certificate's issuerRef.
+	CertificateMigrationAnnotationKey = "cert-manager.io/certificate-migration"
+
+	// AllowedIssuerNamespacesAnnotationKey is an annotation that can be added
+	// to a Secret containing a signing CA keypair to grant CA issuers in other
+	// namespaces access to it. Its value is a comma separated list of the
+	// namespaces of the Issuers which may reference the Secret, or the cluster
+	// resource namespace for ClusterIssuers. Removing a namespace from the list
+	// stops new issuance by the Issuers in that namespace.
+	AllowedIssuerNamespacesAnnotationKey = "cert-manager.io/allowed-issuer-namespaces"
 )
 
 // Common/known resource kinds.
 const (
-	ClusterIssuerKind      = "ClusterIssuer"
-	IssuerKind             = "Issuer"
-	CertificateKind        = "Certificate"
-	CertificateRequestKind = "CertificateRequest"
+	ClusterIssuerKind        = "ClusterIssuer"
+	IssuerKind               = "Issuer"
+	CertificateKind          = "Certificate"
+	CertificateRequestKind   = "CertificateRequest"
+	IssuancePreviewKind      = "IssuancePreview"
+	CertificateMigrationKind = "CertificateMigration"
 )
 
 const (
@@ -293,6 +419,17 @@ const (
 	JKSTruststoreKey = "truststore.jks"
 )
 
+// ExternalCSRSecretKey is the default key of the entry in the Secret
+// referenced by a Certificate's `spec.externalCSR.secretRef` that contains
+// the PEM encoded CSR.
+const ExternalCSRSecretKey = "tls.csr"
+
+// PrivateKeyReferenceSecretKey is the key of the entry in a Certificate's
+// 'next private key' Secret and in its Secret that contains the reference to
+// a private key held by an external KMS, used when the Certificate sets
+// `spec.privateKey.external`. The key material itself is never stored.
+const PrivateKeyReferenceSecretKey = "tls.key.ref"
+
 // DefaultKeyUsages contains the default list of key usages
 func DefaultKeyUsages() []KeyUsage {
 	// The serverAuth EKU is required as of Mac OS Catalina: https://support.apple.com/en-us/HT210176
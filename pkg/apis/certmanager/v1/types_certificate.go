@@ -27,6 +27,9 @@ import (
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +kubebuilder:storageversion
+// +kubebuilder:selectablefield:JSONPath=`.spec.secretName`
+// +kubebuilder:selectablefield:JSONPath=`.spec.issuerRef.name`
+// +kubebuilder:selectablefield:JSONPath=`.spec.issuerRef.kind`
 
 // A Certificate resource should be created to ensure an up to date and signed
 // X.509 certificate is stored in the Kubernetes Secret resource named in `spec.secretName`.
@@ -141,12 +144,22 @@ type CertificateSpec struct {
 	// +optional
 	CommonName string `json:"commonName,omitempty"`
 
+	// CommonNameFromFirstDNSName defaults `commonName` to the first entry of
+	// `dnsNames` when `commonName` is not set, for issuers which require the
+	// common name to be present. The defaulted value is written to
+	// `commonName` by the webhook so that the effective common name is
+	// visible on the Certificate.
+	// The common name is not defaulted if the first DNS name is longer than 64
+	// characters, or if the `literalSubject` field is set.
+	// +optional
+	CommonNameFromFirstDNSName bool `json:"commonNameFromFirstDNSName,omitempty"`
+
 	// Requested 'duration' (i.e. lifetime) of the Certificate. Note that the
 	// issuer may choose to ignore the requested duration, just like any other
 	// requested attribute.
 	//
 	// If unset, this defaults to 90 days.
-	// Minimum accepted duration is 1 hour.
+	// Minimum accepted duration is 5 minutes.
 	// Value must be in units accepted by Go time.ParseDuration https://golang.org/pkg/time/#ParseDuration.
 	// +optional
 	Duration *metav1.Duration `json:"duration,omitempty"`
@@ -167,6 +180,42 @@ type CertificateSpec struct {
 	// +optional
 	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
 
+	// RenewBeforeChainExpiry makes the renewal time of the certificate take the
+	// issuer chain stored in the Secret into account: the intermediate
+	// certificates in `tls.crt` and, unless `secretCAPolicy` copies it from
+	// another Secret, the certificates in `ca.crt`. If one of them expires
+	// before the issued certificate, the renewal time is computed
+	// as if the issued certificate expired at the same time, so that the
+	// certificate is re-issued with a fresh chain before the chain expires.
+	// This is useful for bundles such as PKCS#12 keystores, whose clients
+	// reject the whole bundle once any certificate in it has expired.
+	// +optional
+	RenewBeforeChainExpiry bool `json:"renewBeforeChainExpiry,omitempty"`
+
+	// ChainExpiryExcludedFingerprints lists the SHA-256 fingerprints, hex
+	// encoded as in `status.sha256Fingerprint`, of certificates in the issuer
+	// chain whose expiry is ignored by `renewBeforeChainExpiry`, for example
+	// an expired cross-signed certificate which the issuer always returns.
+	// May only be set if `renewBeforeChainExpiry` is true.
+	// +optional
+	// +listType=atomic
+	ChainExpiryExcludedFingerprints []string `json:"chainExpiryExcludedFingerprints,omitempty"`
+
+	// RolloutDelay is how long a renewed certificate is held back once it has
+	// been issued before it is written to the Secret named in `secretName`, for
+	// clients which cache the previous certificate and break if it changes
+	// while still in use. The issued certificate is held in the
+	// CertificateRequest in the meantime, and the `Issuing` condition has the
+	// reason `PendingRollout`.
+	// The certificate is written before the delay has elapsed if the
+	// certificate stored in the Secret would otherwise expire within 10 minutes.
+	// The first certificate issued for a Certificate is never held back, nor
+	// is a certificate issued for another reason than a renewal, e.g. because
+	// the spec changed or the issuance was triggered manually.
+	// Value must be in units accepted by Go time.ParseDuration https://golang.org/pkg/time/#ParseDuration.
+	// +optional
+	RolloutDelay *metav1.Duration `json:"rolloutDelay,omitempty"`
+
 	// Requested DNS subject alternative names.
 	// +optional
 	DNSNames []string `json:"dnsNames,omitempty"`
@@ -276,6 +325,119 @@ type CertificateSpec struct {
 	// the controller and webhook components.
 	// +optional
 	NameConstraints *NameConstraints `json:"nameConstraints,omitempty"`
+
+	// PolicyOIDs are the object identifiers of the certificate policies
+	// requested for the certificate, expressed as dotted strings, for example
+	// "2.23.140.1.2.1". They are encoded in the certificatePolicies extension
+	// of the CSR. Issuers may ignore the requested policies; the CA issuer
+	// only includes the policies listed in its `allowedPolicyOIDs`.
+	// More Info: https://datatracker.ietf.org/doc/html/rfc5280#section-4.2.1.4
+	// +optional
+	PolicyOIDs []string `json:"policyOIDs,omitempty"`
+
+	// RevokeOnDelete, if true, will cause the certificate to be revoked with
+	// the issuer when this Certificate is deleted. Deletion is blocked by a
+	// finalizer until the certificate has been revoked, or until revocation
+	// has failed a number of times, in which case a Warning event is
+	// recorded and the Certificate is deleted anyway.
+	// Only the ACME, Vault and Venafi issuers, and CA issuers generating a CRL,
+	// support revocation.
+	// +optional
+	RevokeOnDelete *bool `json:"revokeOnDelete,omitempty"`
+
+	// ExternalCSR configures the Certificate to be issued for a CSR that is
+	// provided by the user, for example when the private key is held in a
+	// hardware security module and never stored in the cluster.
+	// When set, cert-manager does not generate or store a private key: the
+	// provided CSR is used verbatim for every CertificateRequest, and only
+	// `tls.crt` and `ca.crt` are written to the Secret named in
+	// `spec.secretName`. The CSR only needs to be rotated when a new key
+	// should be used.
+	// `spec.privateKey` is ignored, and `spec.keystores` and
+	// `spec.additionalOutputFormats` may not be set.
+	// +optional
+	ExternalCSR *CertificateExternalCSR `json:"externalCSR,omitempty"`
+
+	// SecretCAPolicy controls what is stored in the `ca.crt` key of the
+	// Certificate's Secret. Changing the policy updates the Secret without
+	// re-issuing the certificate.
+	// If unset, the CA provided by the issuer is stored.
+	// +optional
+	SecretCAPolicy *CertificateSecretCAPolicy `json:"secretCAPolicy,omitempty"`
+
+	// Defines annotations and labels to be set on each CertificateRequest
+	// created for this Certificate. They are applied again whenever a new
+	// CertificateRequest revision is created, so changes take effect on the
+	// next issuance. Annotations and labels in the `cert-manager.io` domain
+	// are reserved and may not be set.
+	// +optional
+	CertificateRequestTemplate *CertificateRequestTemplate `json:"certificateRequestTemplate,omitempty"`
+
+	// IssuanceWindow restricts the times at which the Certificate is
+	// re-issued, e.g. to the maintenance windows of the workloads using it.
+	// Re-issuances required outside of the window are deferred until it
+	// opens, unless the certificate would expire before, or less than a third
+	// of its duration (at most 24 hours) after the window opens, in which case
+	// it is re-issued immediately and a warning event is recorded.
+	// Certificates which have not been issued yet, or whose Secret is missing
+	// or invalid, are issued immediately.
+	// +optional
+	IssuanceWindow *CertificateIssuanceWindow `json:"issuanceWindow,omitempty"`
+}
+
+// CertificateExternalCSR references a user provided CSR to be used to request
+// certificates for a Certificate.
+type CertificateExternalCSR struct {
+	// SecretRef is a reference to a key in a Secret in the Certificate's
+	// namespace containing the PEM encoded CSR. The key defaults to
+	// `tls.csr`.
+	SecretRef cmmeta.SecretKeySelector `json:"secretRef"`
+}
+
+// CertificateSecretCAPolicy controls the contents of the `ca.crt` key of a
+// Certificate's Secret.
+type CertificateSecretCAPolicy struct {
+	// Type of the policy. One of `IssuerProvided`, `Omit` or `FromSecretRef`.
+	// `IssuerProvided` stores the CA returned by the issuer, `Omit` never
+	// stores a `ca.crt` key, and `FromSecretRef` stores the fixed bundle
+	// referenced by `bundleRef`.
+	// +kubebuilder:validation:Enum=IssuerProvided;Omit;FromSecretRef
+	Type CertificateSecretCAPolicyType `json:"type"`
+
+	// BundleRef references the PEM encoded CA bundle to store in `ca.crt`.
+	// Required when, and only allowed when, `type` is `FromSecretRef`.
+	// +optional
+	BundleRef *CertificateCABundleReference `json:"bundleRef,omitempty"`
+}
+
+// CertificateSecretCAPolicyType is the type of a CertificateSecretCAPolicy.
+type CertificateSecretCAPolicyType string
+
+const (
+	// CertificateSecretCAPolicyIssuerProvided stores the CA returned by the
+	// issuer in `ca.crt`. This is the default.
+	CertificateSecretCAPolicyIssuerProvided CertificateSecretCAPolicyType = "IssuerProvided"
+
+	// CertificateSecretCAPolicyOmit never stores a `ca.crt` key.
+	CertificateSecretCAPolicyOmit CertificateSecretCAPolicyType = "Omit"
+
+	// CertificateSecretCAPolicyFromSecretRef stores the bundle referenced by
+	// the policy's `bundleRef` in `ca.crt`.
+	CertificateSecretCAPolicyFromSecretRef CertificateSecretCAPolicyType = "FromSecretRef"
+)
+
+// CertificateCABundleReference references a key of a ConfigMap or Secret in
+// the Certificate's namespace which contains a PEM encoded CA bundle.
+type CertificateCABundleReference struct {
+	// Kind of the referenced resource, either `ConfigMap` or `Secret`.
+	// +kubebuilder:validation:Enum=ConfigMap;Secret
+	Kind string `json:"kind"`
+
+	// Name of the referenced resource.
+	Name string `json:"name"`
+
+	// Key of the entry in the resource's data which contains the bundle.
+	Key string `json:"key"`
 }
 
 type OtherName struct {
@@ -337,6 +499,26 @@ type CertificatePrivateKey struct {
 	// No other values are allowed.
 	// +optional
 	Size int `json:"size,omitempty"`
+
+	// External configures the private key to be created and held by an
+	// external key management service (KMS), rather than being generated by
+	// cert-manager. Only a reference to the key is stored in the cluster, and
+	// the CSR is signed by the KMS.
+	// The private key is not written to the Secret, so `keystores` and
+	// `additionalOutputFormats` cannot be used.
+	// Requires the ExternalPrivateKeys feature gate to be enabled on both the
+	// controller and the webhook.
+	// +optional
+	External *CertificateExternalPrivateKey `json:"external,omitempty"`
+}
+
+// CertificateExternalPrivateKey configures a private key which is created and
+// held by an external key management service.
+type CertificateExternalPrivateKey struct {
+	// KeyRing identifies where the key is created in the key management
+	// service, for example the resource name of a Cloud KMS key ring. It is
+	// passed as-is to the external signer.
+	KeyRing string `json:"keyRing"`
 }
 
 // Denotes how private keys should be generated or sourced when a Certificate
@@ -438,6 +620,13 @@ type CertificateKeystores struct {
 	// `spec.secretName` Secret resource.
 	// +optional
 	PKCS12 *PKCS12Keystore `json:"pkcs12,omitempty"`
+
+	// SecretName is the name of a Secret resource that keystores should be
+	// written to instead of the `spec.secretName` Secret resource. This can
+	// be used to keep the `spec.secretName` Secret below the maximum size of
+	// a Secret when keystores and long certificate chains are both stored.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
 }
 
 // JKS configures options for storing a JKS keystore in the `spec.secretName`
@@ -542,6 +731,12 @@ type CertificateStatus struct {
 	// +optional
 	RenewalTime *metav1.Time `json:"renewalTime,omitempty"`
 
+	// NextIssuanceWindowTime is the time at which the issuance window of the
+	// Certificate next opens. It is only set while a re-issuance is deferred
+	// until the window opens.
+	// +optional
+	NextIssuanceWindowTime *metav1.Time `json:"nextIssuanceWindowTime,omitempty"`
+
 	// The current 'revision' of the certificate as issued.
 	//
 	// When a CertificateRequest resource is created, it will have the
@@ -575,8 +770,137 @@ type CertificateStatus struct {
 	// time.Hour * 2 ^ (failedIssuanceAttempts - 1).
 	// +optional
 	FailedIssuanceAttempts *int `json:"failedIssuanceAttempts,omitempty"`
+
+	// The serial number of the certificate stored in the secret named by this
+	// resource in `spec.secretName`, as a colon separated hex string.
+	// It is recorded upon issuance so that the certificate can be revoked
+	// when `spec.revokeOnDelete` is set.
+	// +optional
+	SerialNumber string `json:"serialNumber,omitempty"`
+
+	// The hex encoded SHA-256 fingerprint of the certificate stored in the
+	// secret named by this resource in `spec.secretName`.
+	// +optional
+	SHA256Fingerprint string `json:"sha256Fingerprint,omitempty"`
+
+	// The first 16 characters of `status.sha256Fingerprint`, displayed by
+	// kubectl in place of the full fingerprint.
+	// +optional
+	ShortSHA256Fingerprint string `json:"shortSHA256Fingerprint,omitempty"`
+
+	// The distinguished name of the issuer of the certificate stored in the
+	// secret named by this resource in `spec.secretName`.
+	// +optional
+	IssuerDN string `json:"issuerDN,omitempty"`
+
+	// The distinguished name of the subject of the certificate stored in the
+	// secret named by this resource in `spec.secretName`.
+	// +optional
+	SubjectDN string `json:"subjectDN,omitempty"`
+
+	// The lowercase hex encoded SHA-256 fingerprints of the public keys of the
+	// CA certificates returned by the issuer along with the certificate
+	// stored in the secret named by this resource in `spec.secretName`.
+	// They are only recorded if the `cert-manager.io/verify-issuer-ca`
+	// annotation is set to "true" on this resource or on its issuer, in which
+	// case the certificate is re-issued if it is not signed by one of these
+	// keys.
+	// +listType=atomic
+	// +optional
+	IssuerCAFingerprints []string `json:"issuerCAFingerprints,omitempty"`
+
+	// IssuanceHistory records the most recent attempts to issue the
+	// certificate, ordered from the oldest to the newest. The number of
+	// attempts which are kept is configured on the controller, and older
+	// attempts are removed.
+	// +listType=atomic
+	// +optional
+	IssuanceHistory []CertificateIssuanceAttempt `json:"issuanceHistory,omitempty"`
+
+	// LastEvaluation is the outcome of the last evaluation of the policies
+	// which decide whether the certificate must be re-issued. It is only
+	// updated when the outcome of the evaluation changes, and only recorded if
+	// the CertificatePolicyEvaluationStatus feature gate is enabled.
+	// +optional
+	LastEvaluation *CertificatePolicyEvaluation `json:"lastEvaluation,omitempty"`
 }
 
+// CertificatePolicyEvaluation is the outcome of an evaluation of the policies
+// which decide whether a Certificate must be re-issued.
+type CertificatePolicyEvaluation struct {
+	// EvaluationTime is the time of the evaluation at which the outcome last
+	// changed.
+	EvaluationTime metav1.Time `json:"evaluationTime"`
+
+	// Policies are the results of the evaluated policies, in the order in
+	// which they were evaluated. The evaluation stops at the first violated
+	// policy, so only the last policy may be violated.
+	// +listType=atomic
+	Policies []CertificatePolicyResult `json:"policies"`
+}
+
+// CertificatePolicyResult is the result of the evaluation of a single policy.
+type CertificatePolicyResult struct {
+	// PolicyName is the stable name of the policy.
+	PolicyName string `json:"policyName"`
+
+	// Violated is true if the Certificate violates the policy.
+	Violated bool `json:"violated"`
+
+	// Reason is a brief machine readable explanation of the violation.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message is a human readable description of the violation.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// CertificateIssuanceAttempt records the outcome of an attempt to issue a
+// Certificate.
+type CertificateIssuanceAttempt struct {
+	// The revision of the Certificate which was being issued.
+	Revision int `json:"revision"`
+
+	// The name of the CertificateRequest used for the attempt.
+	CertificateRequestName string `json:"certificateRequestName"`
+
+	// StartTime is the time at which the CertificateRequest was created.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// EndTime is the time at which the attempt completed.
+	// +optional
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+
+	// Outcome of the attempt, one of (`Issued`, `Failed`).
+	Outcome CertificateIssuanceOutcome `json:"outcome"`
+
+	// Reason is a brief machine readable explanation of the outcome.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message is a human readable description of the outcome. Long messages
+	// are truncated.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// CertificateIssuanceOutcome is the outcome of an attempt to issue a
+// Certificate.
+// +kubebuilder:validation:Enum=Issued;Failed
+type CertificateIssuanceOutcome string
+
+const (
+	// CertificateIssuanceOutcomeIssued means that the certificate was issued
+	// and stored in the Secret.
+	CertificateIssuanceOutcomeIssued CertificateIssuanceOutcome = "Issued"
+
+	// CertificateIssuanceOutcomeFailed means that the certificate could not
+	// be issued, and that the issuance will be retried.
+	CertificateIssuanceOutcomeFailed CertificateIssuanceOutcome = "Failed"
+)
+
 // CertificateCondition contains condition information for an Certificate.
 type CertificateCondition struct {
 	// Type of the condition, known values are (`Ready`, `Issuing`).
@@ -636,8 +960,65 @@ const (
 	// This condition may also be added by external API consumers to trigger
 	// a re-issuance manually for any other reason.
 	//
+	// The reason of the condition categorises why the issuance was triggered:
+	// `Renewal`, `SpecChanged`, `SecretInvalid`, `IssuerChanged`,
+	// `ManuallyTriggered` for re-issuances triggered by API consumers, or
+	// `CARotated` for re-issuances triggered by the rotation of the signing CA
+	// certificate of a CA issuer.
+	//
 	// It will be removed by the 'issuing' controller upon completing issuance.
 	CertificateConditionIssuing CertificateConditionType = "Issuing"
+
+	// CertificateConditionIssuerKindNotFound indicates that the group and kind
+	// of the Certificate's issuerRef are not served by the API server, for
+	// example because the external issuer's CRDs are not installed or the
+	// group is misspelled. It is set to `False` once the kind is found.
+	CertificateConditionIssuerKindNotFound CertificateConditionType = "IssuerKindNotFound"
+
+	// CertificateConditionSecretTypeConflict indicates that the Certificate's
+	// Secret is of type `Opaque` and contains data unrelated to the
+	// Certificate, so cannot be converted to a `kubernetes.io/tls` Secret.
+	// It is removed by the 'issuing' controller upon completing issuance.
+	CertificateConditionSecretTypeConflict CertificateConditionType = "SecretTypeConflict"
+
+	// CertificateConditionInvalidCABundle indicates that the CA bundle
+	// referenced by the Certificate's `secretCAPolicy.bundleRef` does not exist
+	// or does not contain PEM encoded certificates, so the `ca.crt` key of the
+	// Certificate's Secret is left untouched. It is removed by the 'issuing'
+	// controller once the bundle can be used.
+	CertificateConditionInvalidCABundle CertificateConditionType = "InvalidCABundle"
+
+	// CertificateConditionExternalCSRInvalid indicates that the CSR referenced
+	// by the Certificate's `externalCSR.secretRef` does not exist, cannot be
+	// decoded or does not match the Certificate's spec, so no
+	// CertificateRequest can be created for it. It is removed by the
+	// 'requestmanager' controller once the CSR can be used.
+	CertificateConditionExternalCSRInvalid CertificateConditionType = "ExternalCSRInvalid"
+
+	// CertificateConditionWaitingForApproval indicates that the
+	// CertificateRequest for the next revision of the Certificate has been
+	// neither approved nor denied, e.g. by an approval plugin. It is set to
+	// `False` once the CertificateRequest is approved, and removed by the
+	// 'issuing' controller once the issuance completes or fails.
+	CertificateConditionWaitingForApproval CertificateConditionType = "WaitingForApproval"
+)
+
+const (
+	// CertificateReasonWaitingForApproval is the reason of the
+	// WaitingForApproval condition while the CertificateRequest for the next
+	// revision has been neither approved nor denied.
+	CertificateReasonWaitingForApproval = "WaitingForApproval"
+
+	// CertificateReasonApproved is the reason of the WaitingForApproval
+	// condition once the CertificateRequest for the next revision, which was
+	// waiting for approval, has been approved.
+	CertificateReasonApproved = "Approved"
+
+	// CertificateReasonPendingRollout is the reason of the Issuing condition
+	// once the certificate for the next revision has been issued, while it is
+	// held back from the Secret until the Certificate's rolloutDelay has
+	// elapsed.
+	CertificateReasonPendingRollout = "PendingRollout"
 )
 
 // CertificateSecretTemplate defines the default labels and annotations
@@ -650,6 +1031,80 @@ type CertificateSecretTemplate struct {
 	// Labels is a key value map to be copied to the target Kubernetes Secret.
 	// +optional
 	Labels map[string]string `json:"labels,omitempty"`
+
+	// Immutable marks the target Kubernetes Secret as immutable, which
+	// reduces the load on the kube-apiserver as kubelets stop watching it.
+	// As the data of an immutable Secret cannot be changed, the Secret is
+	// deleted and re-created each time the certificate is issued. The new
+	// data is staged in a temporary Secret named `<secretName>-rotation`
+	// beforehand, so the Secret is only missing for the short time between
+	// the delete and the create, during which pods mounting it cannot start.
+	// Running pods keep the data they have mounted, and must be restarted to
+	// use the renewed certificate.
+	// Unsetting this field also re-creates the Secret.
+	// +optional
+	Immutable bool `json:"immutable,omitempty"`
+}
+
+// CertificateRequestTemplate defines the labels and annotations to be set on
+// the CertificateRequest resources created for a Certificate.
+type CertificateRequestTemplate struct {
+	// Annotations is a key value map to be copied to the CertificateRequest.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Labels is a key value map to be copied to the CertificateRequest.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// CertificateIssuanceWindow defines the daily time ranges during which a
+// Certificate may be re-issued.
+type CertificateIssuanceWindow struct {
+	// TimeZone is the IANA name of the time zone in which the ranges are
+	// interpreted, e.g. `Europe/London`. Daylight saving time transitions are
+	// taken into account. Defaults to `UTC`.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// Ranges are the daily time ranges during which the Certificate may be
+	// re-issued.
+	Ranges []CertificateIssuanceWindowRange `json:"ranges"`
+}
+
+// CertificateIssuanceWindowRange is a daily time range.
+type CertificateIssuanceWindowRange struct {
+	// Start is the time of day at which the range starts, in the 24-hour
+	// `HH:MM` format.
+	Start string `json:"start"`
+
+	// End is the time of day at which the range ends, in the 24-hour `HH:MM`
+	// format. If it is before Start, the range ends on the next day.
+	End string `json:"end"`
+}
+
+// CertificateReadinessGate is an external endpoint which must accept the
+// certificates issued by an issuer before the Certificates referencing it are
+// marked as Ready.
+type CertificateReadinessGate struct {
+	// Name identifies the gate in the Ready condition of Certificates.
+	Name string `json:"name"`
+
+	// URL is the HTTPS endpoint of the gate. The PEM encoded leaf certificate
+	// is sent to it in the `certificate` field of a JSON POST request, and it
+	// must respond with a JSON object whose boolean `allowed` field is the
+	// verdict of the gate, and whose optional `message` field explains it.
+	URL string `json:"url"`
+
+	// CABundle is a PEM encoded CA bundle used to verify the certificate of
+	// the endpoint. Defaults to the system trust store.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+
+	// Timeout of each request to the gate. Defaults to 10 seconds, and may not
+	// be more than 30 seconds.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
 }
 
 // NameConstraints is a type to represent x509 NameConstraints
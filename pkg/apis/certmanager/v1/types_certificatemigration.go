@@ -0,0 +1,144 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// A CertificateMigration is a campaign which moves a set of Certificates to a
+// new issuer at a controlled rate.
+//
+// The CertificateMigration controller updates the `spec.issuerRef` of each
+// selected Certificate, no more than `spec.maxReissuancesPerHour` times per
+// hour. Each update causes the Certificate to be re-issued by the new issuer,
+// as its Secret no longer matches the Certificate's issuer. Progress is
+// recorded in the status.
+//
+// A CertificateMigration can be paused and resumed by setting `spec.paused`.
+// +k8s:openapi-gen=true
+type CertificateMigration struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Desired state of the CertificateMigration.
+	// https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#spec-and-status
+	Spec CertificateMigrationSpec `json:"spec"`
+
+	// Status of the CertificateMigration.
+	// This is set and managed automatically.
+	// Read-only.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#spec-and-status
+	// +optional
+	Status CertificateMigrationStatus `json:"status"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CertificateMigrationList is a list of CertificateMigrations.
+type CertificateMigrationList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard list metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// List of CertificateMigrations
+	Items []CertificateMigration `json:"items"`
+}
+
+// CertificateMigrationSpec defines the Certificates to migrate, the issuer to
+// migrate them to, and the rate at which to do so.
+type CertificateMigrationSpec struct {
+	// Namespaces is the list of namespaces in which Certificates are
+	// selected. If empty, Certificates in all namespaces are selected.
+	// +optional
+	// +listType=set
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Selector is a label selector for the Certificates to migrate. If not
+	// set, all Certificates in the selected namespaces are migrated.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// IssuerRef is the issuer that the selected Certificates are migrated to.
+	// It replaces the `spec.issuerRef` of each selected Certificate.
+	IssuerRef cmmeta.ObjectReference `json:"issuerRef"`
+
+	// MaxReissuancesPerHour is the maximum number of Certificates that are
+	// migrated, and therefore re-issued, per hour. Migrations are spread
+	// evenly across the hour.
+	// +kubebuilder:validation:Minimum=1
+	MaxReissuancesPerHour int32 `json:"maxReissuancesPerHour"`
+
+	// Paused stops any further Certificates from being migrated while true.
+	// Certificates that have already been migrated are not reverted, and
+	// progress continues to be reported in the status.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+}
+
+// CertificateMigrationStatus defines the observed state of a
+// CertificateMigration.
+type CertificateMigrationStatus struct {
+	// Total is the number of Certificates selected by the
+	// CertificateMigration.
+	// +optional
+	Total int32 `json:"total"`
+
+	// Migrated is the number of selected Certificates that reference the
+	// new issuer and have been re-issued by it.
+	// +optional
+	Migrated int32 `json:"migrated"`
+
+	// Pending is the number of selected Certificates that have not been
+	// migrated yet, or are being re-issued by the new issuer.
+	// +optional
+	Pending int32 `json:"pending"`
+
+	// Failed is the number of selected Certificates that reference the new
+	// issuer, but whose last issuance attempt failed, or that could not be
+	// updated to reference the new issuer. cert-manager keeps retrying failed
+	// issuances with its usual backoff, and skips Certificates that could not
+	// be updated until they are retried with a backoff of their own.
+	// +optional
+	Failed int32 `json:"failed"`
+
+	// LastMigrationTime is the time at which a Certificate was last migrated
+	// to the new issuer.
+	// +optional
+	LastMigrationTime *metav1.Time `json:"lastMigrationTime,omitempty"`
+
+	// CompletionTime is the time at which every selected Certificate was
+	// first observed to be migrated.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// ObservedGeneration is the `metadata.generation` of the
+	// CertificateMigration that the status was computed for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
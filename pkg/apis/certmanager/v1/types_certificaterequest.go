@@ -246,4 +246,10 @@ const (
 	// `False`, and cannot be modified once set. Cannot be set alongside
 	// `Approved`.
 	CertificateRequestConditionDenied CertificateRequestConditionType = "Denied"
+
+	// CertificateRequestConditionIssuerKindNotFound indicates that the group
+	// and kind of the request's issuerRef are not served by the API server, so
+	// no issuer is able to sign the request. It is set to `False` once the
+	// kind is found.
+	CertificateRequestConditionIssuerKindNotFound CertificateRequestConditionType = "IssuerKindNotFound"
 )
@@ -0,0 +1,165 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// An IssuancePreview is used to check whether a Certificate would be issued
+// successfully by an issuer, without issuing it.
+//
+// The IssuancePreview controller evaluates the Certificate spec against the
+// same validation, issuer readiness, ACME solver selection and issuance
+// trigger logic used when issuing a Certificate, and records the result of
+// each check in the status. No private keys, CertificateRequests or ACME
+// Orders are created.
+//
+// IssuancePreviews are deleted automatically once
+// `spec.ttlSecondsAfterFinished` has elapsed after they were evaluated.
+// +k8s:openapi-gen=true
+type IssuancePreview struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Specification of the Certificate to evaluate.
+	// https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#spec-and-status
+	Spec IssuancePreviewSpec `json:"spec"`
+
+	// Status of the IssuancePreview.
+	// This is set and managed automatically.
+	// Read-only.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#spec-and-status
+	// +optional
+	Status IssuancePreviewStatus `json:"status"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// IssuancePreviewList is a list of IssuancePreviews.
+type IssuancePreviewList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard list metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// List of IssuancePreviews
+	Items []IssuancePreview `json:"items"`
+}
+
+// IssuancePreviewSpec defines the Certificate to evaluate.
+type IssuancePreviewSpec struct {
+	// CertificateSpec is the spec of the Certificate to evaluate, as it
+	// would be written in a Certificate resource in the same namespace as
+	// this IssuancePreview.
+	CertificateSpec CertificateSpec `json:"certificateSpec"`
+
+	// IssuerRef, if set, overrides `certificateSpec.issuerRef`. This allows
+	// the same Certificate spec to be checked against several issuers.
+	// +optional
+	IssuerRef *cmmeta.ObjectReference `json:"issuerRef,omitempty"`
+
+	// TTLSecondsAfterFinished is the number of seconds after the
+	// IssuancePreview has been evaluated before it is deleted.
+	// If not set, the IssuancePreview is deleted after one hour.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+}
+
+// IssuancePreviewVerdict is the overall result of an IssuancePreview.
+// +kubebuilder:validation:Enum=Pass;Fail
+type IssuancePreviewVerdict string
+
+const (
+	// IssuancePreviewVerdictPass means all checks passed and the Certificate
+	// is expected to be issued successfully.
+	IssuancePreviewVerdictPass IssuancePreviewVerdict = "Pass"
+
+	// IssuancePreviewVerdictFail means at least one check failed and the
+	// Certificate is not expected to be issued successfully.
+	IssuancePreviewVerdictFail IssuancePreviewVerdict = "Fail"
+)
+
+// Names of the checks performed by the IssuancePreview controller.
+const (
+	// IssuancePreviewCheckValidation checks that the Certificate spec would
+	// be accepted by the cert-manager webhook.
+	IssuancePreviewCheckValidation = "Validation"
+
+	// IssuancePreviewCheckIssuerReady checks that the referenced issuer
+	// exists and is Ready.
+	IssuancePreviewCheckIssuerReady = "IssuerReady"
+
+	// IssuancePreviewCheckSolverSelection checks that an ACME issuer has a
+	// solver that can be used for every DNS name in the Certificate.
+	// It is only performed for ACME issuers.
+	IssuancePreviewCheckSolverSelection = "SolverSelection"
+
+	// IssuancePreviewCheckTriggerPolicy evaluates the issuance trigger
+	// policies against the existing Secret, reporting whether issuance
+	// would be triggered.
+	IssuancePreviewCheckTriggerPolicy = "TriggerPolicy"
+)
+
+// IssuancePreviewStatus defines the observed state of an IssuancePreview.
+type IssuancePreviewStatus struct {
+	// Verdict is `Pass` if every check passed, and `Fail` otherwise.
+	// +optional
+	Verdict IssuancePreviewVerdict `json:"verdict,omitempty"`
+
+	// Checks contains the result of each check that was performed.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Checks []IssuancePreviewCheck `json:"checks,omitempty"`
+
+	// ObservedGeneration is the `metadata.generation` of the
+	// IssuancePreview that was evaluated.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// CompletionTime is the time at which the IssuancePreview was evaluated.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// IssuancePreviewCheck is the result of a single check.
+type IssuancePreviewCheck struct {
+	// Name of the check.
+	Name string `json:"name"`
+
+	// Passed is true if the check passed.
+	Passed bool `json:"passed"`
+
+	// Reason is a brief machine readable explanation of the result.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message is a human readable description of the result.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
@@ -44,6 +44,16 @@ func (in *ACMEDNS01Config) DeepCopyInto(out *ACMEDNS01Config) {
 		*out = new(sharedv1alpha1.Duration)
 		**out = **in
 	}
+	if in.OrphanedRecordSweepInterval != nil {
+		in, out := &in.OrphanedRecordSweepInterval, &out.OrphanedRecordSweepInterval
+		*out = new(sharedv1alpha1.Duration)
+		**out = **in
+	}
+	if in.OrphanedRecordMinAge != nil {
+		in, out := &in.OrphanedRecordMinAge, &out.OrphanedRecordMinAge
+		*out = new(sharedv1alpha1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -65,6 +75,11 @@ func (in *ACMEHTTP01Config) DeepCopyInto(out *ACMEHTTP01Config) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.SolverSharedPods != nil {
+		in, out := &in.SolverSharedPods, &out.SolverSharedPods
+		*out = new(bool)
+		**out = **in
+	}
 	if in.SolverNameservers != nil {
 		in, out := &in.SolverNameservers, &out.SolverNameservers
 		*out = make([]string, len(*in))
@@ -97,6 +112,26 @@ func (in *ControllerConfiguration) DeepCopyInto(out *ControllerConfiguration) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.InformerListChunkSize != nil {
+		in, out := &in.InformerListChunkSize, &out.InformerListChunkSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.InformerSyncBudget != nil {
+		in, out := &in.InformerSyncBudget, &out.InformerSyncBudget
+		*out = new(sharedv1alpha1.Duration)
+		**out = **in
+	}
+	if in.SyncTimeout != nil {
+		in, out := &in.SyncTimeout, &out.SyncTimeout
+		*out = new(sharedv1alpha1.Duration)
+		**out = **in
+	}
+	if in.ProgressDeadline != nil {
+		in, out := &in.ProgressDeadline, &out.ProgressDeadline
+		*out = new(sharedv1alpha1.Duration)
+		**out = **in
+	}
 	in.LeaderElectionConfig.DeepCopyInto(&out.LeaderElectionConfig)
 	if in.Controllers != nil {
 		in, out := &in.Controllers, &out.Controllers
@@ -128,11 +163,98 @@ func (in *ControllerConfiguration) DeepCopyInto(out *ControllerConfiguration) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.CertificateRequestEncodings != nil {
+		in, out := &in.CertificateRequestEncodings, &out.CertificateRequestEncodings
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.IssuanceTimeout != nil {
+		in, out := &in.IssuanceTimeout, &out.IssuanceTimeout
+		*out = new(sharedv1alpha1.Duration)
+		**out = **in
+	}
+	if in.ClockSkewTolerance != nil {
+		in, out := &in.ClockSkewTolerance, &out.ClockSkewTolerance
+		*out = new(sharedv1alpha1.Duration)
+		**out = **in
+	}
+	if in.MaxNotBeforeWait != nil {
+		in, out := &in.MaxNotBeforeWait, &out.MaxNotBeforeWait
+		*out = new(sharedv1alpha1.Duration)
+		**out = **in
+	}
+	if in.VerifyIssuedCertificates != nil {
+		in, out := &in.VerifyIssuedCertificates, &out.VerifyIssuedCertificates
+		*out = new(bool)
+		**out = **in
+	}
+	if in.VerifyIssuedCertificateChain != nil {
+		in, out := &in.VerifyIssuedCertificateChain, &out.VerifyIssuedCertificateChain
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MigrateSecretAnnotations != nil {
+		in, out := &in.MigrateSecretAnnotations, &out.MigrateSecretAnnotations
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxIssuanceFailureEvents != nil {
+		in, out := &in.MaxIssuanceFailureEvents, &out.MaxIssuanceFailureEvents
+		*out = new(int32)
+		**out = **in
+	}
+	if in.IssuanceFailureEventWindow != nil {
+		in, out := &in.IssuanceFailureEventWindow, &out.IssuanceFailureEventWindow
+		*out = new(sharedv1alpha1.Duration)
+		**out = **in
+	}
+	if in.IssuanceHistoryLimit != nil {
+		in, out := &in.IssuanceHistoryLimit, &out.IssuanceHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxSecretSize != nil {
+		in, out := &in.MaxSecretSize, &out.MaxSecretSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DefaultPrivateKeySize != nil {
+		in, out := &in.DefaultPrivateKeySize, &out.DefaultPrivateKeySize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ACMEAccountVerificationInterval != nil {
+		in, out := &in.ACMEAccountVerificationInterval, &out.ACMEAccountVerificationInterval
+		*out = new(sharedv1alpha1.Duration)
+		**out = **in
+	}
 	if in.NumberOfConcurrentWorkers != nil {
 		in, out := &in.NumberOfConcurrentWorkers, &out.NumberOfConcurrentWorkers
 		*out = new(int32)
 		**out = **in
 	}
+	if in.ConcurrentCertificateSyncs != nil {
+		in, out := &in.ConcurrentCertificateSyncs, &out.ConcurrentCertificateSyncs
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ConcurrentCertificateRequestSyncs != nil {
+		in, out := &in.ConcurrentCertificateRequestSyncs, &out.ConcurrentCertificateRequestSyncs
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ConcurrentOrderSyncs != nil {
+		in, out := &in.ConcurrentOrderSyncs, &out.ConcurrentOrderSyncs
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ConcurrentChallengeSyncs != nil {
+		in, out := &in.ConcurrentChallengeSyncs, &out.ConcurrentChallengeSyncs
+		*out = new(int32)
+		**out = **in
+	}
 	if in.MaxConcurrentChallenges != nil {
 		in, out := &in.MaxConcurrentChallenges, &out.MaxConcurrentChallenges
 		*out = new(int32)
@@ -155,6 +277,7 @@ func (in *ControllerConfiguration) DeepCopyInto(out *ControllerConfiguration) {
 	in.IngressShimConfig.DeepCopyInto(&out.IngressShimConfig)
 	in.ACMEHTTP01Config.DeepCopyInto(&out.ACMEHTTP01Config)
 	in.ACMEDNS01Config.DeepCopyInto(&out.ACMEDNS01Config)
+	in.OutboundTLSConfig.DeepCopyInto(&out.OutboundTLSConfig)
 	return
 }
 
@@ -218,3 +341,24 @@ func (in *LeaderElectionConfig) DeepCopy() *LeaderElectionConfig {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutboundTLSConfig) DeepCopyInto(out *OutboundTLSConfig) {
+	*out = *in
+	if in.CipherSuites != nil {
+		in, out := &in.CipherSuites, &out.CipherSuites
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutboundTLSConfig.
+func (in *OutboundTLSConfig) DeepCopy() *OutboundTLSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OutboundTLSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -64,4 +64,49 @@ type WebhookConfiguration struct {
 	// features.
 	// +optional
 	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+
+	// certificateSANsWarningThreshold is the number of subject alternative
+	// names above which a warning is returned when a Certificate is created
+	// or updated. If 0, no warning is returned.
+	// Defaults to 50.
+	CertificateSANsWarningThreshold *int32 `json:"certificateSANsWarningThreshold,omitempty"`
+
+	// maxCertificateSANs is the maximum number of subject alternative names
+	// a Certificate may request. If 0, the number is not limited.
+	// Certificates which already request more names can still be updated as
+	// long as the number of names does not grow.
+	// Defaults to 100, the limit of most public ACME servers.
+	MaxCertificateSANs *int32 `json:"maxCertificateSANs,omitempty"`
+
+	// duplicateDNSNamesPolicy configures what happens when a Certificate is
+	// created with DNS names which overlap with those of another Certificate,
+	// in any namespace, issued by the same ClusterIssuer, such as a wildcard
+	// DNS name covering a DNS name of the other Certificate. Duplicate
+	// Certificates count towards the duplicate certificate rate limits of
+	// public ACME servers.
+	// One of Ignore, Warn, which returns a warning naming the existing
+	// Certificate, or Strict, which rejects the Certificate.
+	// Defaults to Ignore.
+	DuplicateDNSNamesPolicy string `json:"duplicateDNSNamesPolicy,omitempty"`
+
+	// missingReferencesPolicy configures what happens when a Certificate is
+	// created or updated referencing an object which does not exist: the
+	// Secrets referenced by `spec.keystores` and `spec.externalCSR`, or the
+	// Issuer or ClusterIssuer referenced by `spec.issuerRef`. Such
+	// Certificates otherwise only fail when they are issued.
+	// Checking the references requires the webhook to be allowed to get
+	// Secrets, Issuers and ClusterIssuers. References which the webhook is
+	// not allowed to get are not checked.
+	// One of Ignore, Warn, which returns a warning naming each missing
+	// object, or Strict, which rejects the Certificate.
+	// Defaults to Ignore.
+	MissingReferencesPolicy string `json:"missingReferencesPolicy,omitempty"`
+
+	// controllerUsername is the username of the cert-manager controller, such
+	// as "system:serviceaccount:<namespace>:<name>" for its service account.
+	// Only requests from this user may set or change the
+	// cert-manager.io/created-by label, which marks CertificateRequests as
+	// created for a Certificate, so that it cannot be spoofed.
+	// Defaults to "system:serviceaccount:cert-manager:cert-manager".
+	ControllerUsername string `json:"controllerUsername,omitempty"`
 }
@@ -48,6 +48,16 @@ func (in *WebhookConfiguration) DeepCopyInto(out *WebhookConfiguration) {
 			(*out)[key] = val
 		}
 	}
+	if in.CertificateSANsWarningThreshold != nil {
+		in, out := &in.CertificateSANsWarningThreshold, &out.CertificateSANsWarningThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxCertificateSANs != nil {
+		in, out := &in.MaxCertificateSANs, &out.MaxCertificateSANs
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
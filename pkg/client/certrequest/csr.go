@@ -0,0 +1,124 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package certrequest helps programs obtain certificates from cert-manager
+// by creating CertificateRequest resources directly: it builds and signs the
+// CSR, creates the CertificateRequest, waits for it to be issued, denied or
+// failed, and returns the signed certificate chain and CA.
+package certrequest
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"fmt"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// Options describes the certificate to request.
+type Options struct {
+	// CommonName is the common name of the certificate's subject. ACME
+	// issuers require it to also be one of the DNSNames.
+	CommonName string
+	// Subject holds the other attributes of the certificate's subject.
+	Subject *cmapi.X509Subject
+
+	DNSNames       []string
+	IPAddresses    []string
+	URIs           []string
+	EmailAddresses []string
+
+	// IsCA requests a CA certificate.
+	IsCA bool
+	// Usages are the key usages requested. The issuer defaults them to
+	// digital signature and key encipherment if empty.
+	Usages []cmapi.KeyUsage
+
+	// Signer is the private key the CSR is signed with, for example a key
+	// held in an HSM. It must be an RSA, ECDSA or Ed25519 key. If nil, a
+	// private key is generated as described by PrivateKey.
+	Signer crypto.Signer
+	// PrivateKey describes the algorithm and size of the private key which is
+	// generated if Signer is nil. An RSA 2048 bit key is generated by default.
+	PrivateKey *cmapi.CertificatePrivateKey
+}
+
+// GenerateCSR builds a PEM encoded CSR for the given options and signs it
+// with the options' Signer, or with a newly generated private key. The
+// private key which signed the CSR is returned.
+func GenerateCSR(opts Options) ([]byte, crypto.Signer, error) {
+	crt := &cmapi.Certificate{
+		Spec: cmapi.CertificateSpec{
+			CommonName:     opts.CommonName,
+			Subject:        opts.Subject,
+			DNSNames:       opts.DNSNames,
+			IPAddresses:    opts.IPAddresses,
+			URIs:           opts.URIs,
+			EmailAddresses: opts.EmailAddresses,
+			IsCA:           opts.IsCA,
+			Usages:         opts.Usages,
+			PrivateKey:     opts.PrivateKey,
+		},
+	}
+
+	signer := opts.Signer
+	if signer == nil {
+		var err error
+		signer, err = pki.GeneratePrivateKeyForCertificate(crt)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error generating private key: %w", err)
+		}
+	} else {
+		// The signature algorithm of the CSR is chosen based on the
+		// algorithm and size of the private key.
+		privateKey, err := privateKeyForSigner(signer)
+		if err != nil {
+			return nil, nil, err
+		}
+		crt.Spec.PrivateKey = privateKey
+	}
+
+	template, err := pki.GenerateCSR(crt)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error building CSR: %w", err)
+	}
+	csrDER, err := pki.EncodeCSR(template, signer)
+	if err != nil {
+		return nil, nil, err
+	}
+	csrPEM, err := pki.EncodeCertificateRequest(csrDER, pki.CertificateRequestEncodingPEM)
+	if err != nil {
+		return nil, nil, err
+	}
+	return csrPEM, signer, nil
+}
+
+// privateKeyForSigner describes the algorithm and size of the signer's key.
+func privateKeyForSigner(signer crypto.Signer) (*cmapi.CertificatePrivateKey, error) {
+	switch pub := signer.Public().(type) {
+	case *rsa.PublicKey:
+		return &cmapi.CertificatePrivateKey{Algorithm: cmapi.RSAKeyAlgorithm, Size: pub.N.BitLen()}, nil
+	case *ecdsa.PublicKey:
+		return &cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm, Size: pub.Curve.Params().BitSize}, nil
+	case ed25519.PublicKey:
+		return &cmapi.CertificatePrivateKey{Algorithm: cmapi.Ed25519KeyAlgorithm}, nil
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", pub)
+	}
+}
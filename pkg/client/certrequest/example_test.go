@@ -0,0 +1,98 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certrequest_test
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s.io/client-go/rest"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/client/certrequest"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+)
+
+// This example obtains a certificate for a service at startup, using a newly
+// generated ECDSA private key.
+func ExampleDo() {
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		panic(err)
+	}
+	client := cmclient.NewForConfigOrDie(config)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute*5)
+	defer cancel()
+
+	result, err := certrequest.Do(ctx, client, certrequest.Request{
+		Options: certrequest.Options{
+			CommonName: "my-service.my-namespace.svc",
+			DNSNames:   []string{"my-service.my-namespace.svc"},
+			Usages:     []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageServerAuth},
+			PrivateKey: &cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm, Size: 256},
+		},
+		Namespace:    "my-namespace",
+		GenerateName: "my-service-",
+		IssuerRef:    cmmeta.ObjectReference{Name: "internal-ca", Kind: cmapi.ClusterIssuerKind},
+		Duration:     time.Hour * 24,
+	})
+	switch {
+	case errors.Is(err, certrequest.ErrDenied):
+		panic(fmt.Sprintf("the request was not approved: %v", err))
+	case err != nil:
+		panic(err)
+	}
+
+	fmt.Printf("obtained certificate chain of %d bytes\n", len(result.ChainPEM))
+}
+
+// This example requests a certificate for a private key which never leaves
+// an HSM, by supplying the crypto.Signer backed by the HSM.
+func ExampleCreate() {
+	var hsmKey crypto.Signer // e.g. obtained from a PKCS#11 library
+
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		panic(err)
+	}
+	client := cmclient.NewForConfigOrDie(config)
+	ctx := context.Background()
+
+	cr, _, err := certrequest.Create(ctx, client, certrequest.Request{
+		Options: certrequest.Options{
+			DNSNames: []string{"hsm.example.com"},
+			Signer:   hsmKey,
+		},
+		Namespace: "my-namespace",
+		Name:      "hsm-example-com",
+		IssuerRef: cmmeta.ObjectReference{Name: "internal-ca", Kind: cmapi.IssuerKind},
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	cr, err = certrequest.Wait(ctx, client, cr.Namespace, cr.Name, time.Second*5)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("CA certificate: %s\n", cr.Status.CA)
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certrequest
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// DefaultPollInterval is the interval at which a CertificateRequest is
+// fetched while waiting for it to complete, if no interval is given.
+const DefaultPollInterval = time.Second * 2
+
+var (
+	// ErrDenied is returned if the CertificateRequest is denied by an
+	// approver.
+	ErrDenied = errors.New("certificate request was denied")
+	// ErrFailed is returned if the issuer fails to sign the
+	// CertificateRequest, or the CertificateRequest is invalid.
+	ErrFailed = errors.New("certificate request failed")
+)
+
+// Request describes the CertificateRequest to create.
+type Request struct {
+	Options
+
+	// Namespace is the namespace the CertificateRequest is created in.
+	Namespace string
+	// Name is the name of the CertificateRequest. If empty, a name is
+	// generated by the API server from GenerateName.
+	Name string
+	// GenerateName is the prefix of the generated name of the
+	// CertificateRequest, used if Name is empty.
+	GenerateName string
+
+	// IssuerRef references the issuer which signs the certificate.
+	IssuerRef cmmeta.ObjectReference
+	// Duration is the requested duration of the certificate. The issuer's
+	// default is used if zero.
+	Duration time.Duration
+
+	Labels      map[string]string
+	Annotations map[string]string
+
+	// PollInterval is the interval at which the CertificateRequest is fetched
+	// while waiting for it to complete. DefaultPollInterval is used if zero.
+	PollInterval time.Duration
+}
+
+// Result is a signed certificate obtained from a CertificateRequest.
+type Result struct {
+	// CertificateRequest is the completed CertificateRequest.
+	CertificateRequest *cmapi.CertificateRequest
+	// PrivateKey is the private key of the certificate: the Signer given in
+	// the request's options, or the private key which was generated.
+	PrivateKey crypto.Signer
+	// ChainPEM is the PEM encoded signed certificate, followed by any
+	// intermediate certificates returned by the issuer.
+	ChainPEM []byte
+	// CAPEM is the PEM encoded CA certificate returned by the issuer, if any.
+	CAPEM []byte
+}
+
+// Do creates a CertificateRequest for the request, waits for it to be
+// signed, denied or failed, and returns the signed certificate. The waiting
+// is cancelled with the context; the CertificateRequest is not deleted.
+func Do(ctx context.Context, client cmclient.Interface, req Request) (*Result, error) {
+	cr, signer, err := Create(ctx, client, req)
+	if err != nil {
+		return nil, err
+	}
+
+	cr, err = Wait(ctx, client, cr.Namespace, cr.Name, req.PollInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := pki.DecodeX509CertificateBytes(cr.Status.Certificate)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding signed certificate: %w", err)
+	}
+	ok, err := pki.PublicKeyMatchesCertificate(signer.Public(), cert)
+	if err != nil {
+		return nil, fmt.Errorf("error checking the signed certificate against the private key of the request: %w", err)
+	}
+	if !ok {
+		return nil, errors.New("the signed certificate does not match the private key of the request")
+	}
+
+	return &Result{
+		CertificateRequest: cr,
+		PrivateKey:         signer,
+		ChainPEM:           cr.Status.Certificate,
+		CAPEM:              cr.Status.CA,
+	}, nil
+}
+
+// Create builds and signs a CSR for the request, and creates the
+// CertificateRequest. It returns the created CertificateRequest and the
+// private key which signed the CSR. The public key fingerprint annotation is
+// set so that the issued certificate can be matched to the private key.
+func Create(ctx context.Context, client cmclient.Interface, req Request) (*cmapi.CertificateRequest, crypto.Signer, error) {
+	if req.Name == "" && req.GenerateName == "" {
+		return nil, nil, errors.New("either a name or a generateName must be given")
+	}
+
+	csrPEM, signer, err := GenerateCSR(req.Options)
+	if err != nil {
+		return nil, nil, err
+	}
+	fingerprint, err := pki.PublicKeyFingerprintSHA256(signer.Public())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	annotations := make(map[string]string, len(req.Annotations)+1)
+	for k, v := range req.Annotations {
+		annotations[k] = v
+	}
+	annotations[cmapi.CertificateRequestPublicKeyFingerprintAnnotationKey] = fingerprint
+
+	cr := &cmapi.CertificateRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:    req.Namespace,
+			Name:         req.Name,
+			GenerateName: req.GenerateName,
+			Labels:       req.Labels,
+			Annotations:  annotations,
+		},
+		Spec: cmapi.CertificateRequestSpec{
+			IssuerRef: req.IssuerRef,
+			Request:   csrPEM,
+			IsCA:      req.IsCA,
+			Usages:    req.Usages,
+		},
+	}
+	if req.Duration > 0 {
+		cr.Spec.Duration = &metav1.Duration{Duration: req.Duration}
+	}
+
+	cr, err = client.CertmanagerV1().CertificateRequests(req.Namespace).Create(ctx, cr, metav1.CreateOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating CertificateRequest: %w", err)
+	}
+	return cr, signer, nil
+}
+
+// Wait fetches the named CertificateRequest at the given interval until it
+// has been signed, and returns it. An error wrapping ErrDenied or ErrFailed
+// is returned if the CertificateRequest is denied, invalid or failed. Errors
+// fetching the CertificateRequest which may be transient, such as the API
+// server being unavailable, are retried at the same interval. The waiting is
+// cancelled with the context; the returned error then includes the last
+// transient error, if the last attempt failed.
+func Wait(ctx context.Context, client cmclient.Interface, namespace, name string, interval time.Duration) (*cmapi.CertificateRequest, error) {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	var (
+		cr      *cmapi.CertificateRequest
+		lastErr error
+	)
+	err := wait.PollUntilContextCancel(ctx, interval, true, func(ctx context.Context) (bool, error) {
+		var err error
+		cr, err = client.CertmanagerV1().CertificateRequests(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			if !transientError(err) {
+				return false, err
+			}
+			lastErr = err
+			return false, nil
+		}
+		lastErr = nil
+		return completed(cr)
+	})
+	if err != nil {
+		if lastErr != nil && ctx.Err() != nil {
+			return nil, fmt.Errorf("%w: last error fetching CertificateRequest: %v", err, lastErr)
+		}
+		return nil, err
+	}
+	return cr, nil
+}
+
+// transientError returns true if fetching the CertificateRequest failed with
+// an error which may not occur when retried, for example because the API
+// server was unavailable or rate limited the request, or because the
+// connection was dropped.
+func transientError(err error) bool {
+	switch {
+	case apierrors.IsServerTimeout(err),
+		apierrors.IsTimeout(err),
+		apierrors.IsTooManyRequests(err),
+		apierrors.IsInternalError(err),
+		apierrors.IsServiceUnavailable(err),
+		apierrors.IsUnexpectedServerError(err):
+		return true
+	}
+	// Errors which do not come from the API server, such as network
+	// errors, are retried.
+	var status apierrors.APIStatus
+	return !errors.As(err, &status)
+}
+
+// completed returns true if the CertificateRequest has been signed, and an
+// error if it has been denied, is invalid or failed.
+func completed(cr *cmapi.CertificateRequest) (bool, error) {
+	if apiutil.CertificateRequestIsDenied(cr) {
+		return false, fmt.Errorf("%w: %s", ErrDenied, conditionMessage(cr, cmapi.CertificateRequestConditionDenied))
+	}
+	if apiutil.CertificateRequestHasInvalidRequest(cr) {
+		return false, fmt.Errorf("%w: %s", ErrFailed, apiutil.CertificateRequestInvalidRequestMessage(cr))
+	}
+	if apiutil.CertificateRequestReadyReason(cr) == cmapi.CertificateRequestReasonFailed {
+		return false, fmt.Errorf("%w: %s", ErrFailed, conditionMessage(cr, cmapi.CertificateRequestConditionReady))
+	}
+	ready := apiutil.CertificateRequestHasCondition(cr, cmapi.CertificateRequestCondition{
+		Type:   cmapi.CertificateRequestConditionReady,
+		Status: cmmeta.ConditionTrue,
+	})
+	return ready && len(cr.Status.Certificate) > 0, nil
+}
+
+func conditionMessage(cr *cmapi.CertificateRequest, conditionType cmapi.CertificateRequestConditionType) string {
+	for _, cond := range cr.Status.Conditions {
+		if cond.Type == conditionType {
+			return cond.Message
+		}
+	}
+	return ""
+}
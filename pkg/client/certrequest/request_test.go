@@ -0,0 +1,369 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certrequest
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"io"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// fakeSigner is a crypto.Signer which records its use, standing in for a
+// private key held in an HSM.
+type fakeSigner struct {
+	crypto.Signer
+	signed atomic.Int32
+}
+
+func (s *fakeSigner) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	s.signed.Add(1)
+	return s.Signer.Sign(rand, digest, opts)
+}
+
+// testCA signs the CSRs of CertificateRequests.
+type testCA struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	_, cert, err := pki.SignCertificate(template, template, key.Public(), key)
+	require.NoError(t, err)
+	return &testCA{cert: cert, key: key}
+}
+
+// sign marks the CertificateRequest as Ready with a certificate signed by
+// the CA.
+func (ca *testCA) sign(t *testing.T, cr *cmapi.CertificateRequest) {
+	template, err := pki.CertificateTemplateFromCertificateRequest(cr)
+	require.NoError(t, err)
+	bundle, err := pki.SignCSRTemplate([]*x509.Certificate{ca.cert}, ca.key, template)
+	require.NoError(t, err)
+	cr.Status.Certificate = bundle.ChainPEM
+	cr.Status.CA = bundle.CAPEM
+	apiutil.SetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionReady, cmmeta.ConditionTrue, cmapi.CertificateRequestReasonIssued, "issued")
+}
+
+// signOnCreate returns a fake clientset which signs CertificateRequests as
+// they are created.
+func signOnCreate(t *testing.T, ca *testCA) *cmfake.Clientset {
+	client := cmfake.NewSimpleClientset()
+	client.PrependReactor("create", "certificaterequests", func(action coretesting.Action) (bool, runtime.Object, error) {
+		ca.sign(t, action.(coretesting.CreateAction).GetObject().(*cmapi.CertificateRequest))
+		return false, nil, nil
+	})
+	return client
+}
+
+func TestGenerateCSR(t *testing.T) {
+	ecKey, err := pki.GenerateECPrivateKey(384)
+	require.NoError(t, err)
+	rsaKey, err := pki.GenerateRSAPrivateKey(3072)
+	require.NoError(t, err)
+	edKey, err := pki.GenerateEd25519PrivateKey()
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		opts Options
+
+		expSigAlg x509.SignatureAlgorithm
+		expErr    bool
+	}{
+		"an RSA key is generated by default": {
+			opts:      Options{DNSNames: []string{"example.com"}},
+			expSigAlg: x509.SHA256WithRSA,
+		},
+		"the generated key follows the private key options": {
+			opts:      Options{DNSNames: []string{"example.com"}, PrivateKey: &cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm, Size: 256}},
+			expSigAlg: x509.ECDSAWithSHA256,
+		},
+		"an ECDSA signer is used": {
+			opts:      Options{DNSNames: []string{"example.com"}, Signer: ecKey},
+			expSigAlg: x509.ECDSAWithSHA384,
+		},
+		"an RSA signer is used": {
+			opts:      Options{DNSNames: []string{"example.com"}, Signer: rsaKey},
+			expSigAlg: x509.SHA384WithRSA,
+		},
+		"an Ed25519 signer is used": {
+			opts:      Options{DNSNames: []string{"example.com"}, Signer: edKey},
+			expSigAlg: x509.PureEd25519,
+		},
+		"a subject or SAN is required": {
+			opts:   Options{},
+			expErr: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			csrPEM, signer, err := GenerateCSR(test.opts)
+			if test.expErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if test.opts.Signer != nil {
+				assert.Equal(t, test.opts.Signer, signer)
+			}
+
+			csr, err := pki.DecodeX509CertificateRequestBytes(csrPEM)
+			require.NoError(t, err)
+			require.NoError(t, csr.CheckSignature())
+			assert.Equal(t, test.expSigAlg, csr.SignatureAlgorithm)
+			ok, err := pki.PublicKeyMatchesCSR(signer.Public(), csr)
+			require.NoError(t, err)
+			assert.True(t, ok)
+		})
+	}
+}
+
+func TestDo(t *testing.T) {
+	ca := newTestCA(t)
+	ecKey, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+	signer := &fakeSigner{Signer: ecKey}
+	client := signOnCreate(t, ca)
+
+	result, err := Do(context.Background(), client, Request{
+		Options: Options{
+			CommonName: "example.com",
+			DNSNames:   []string{"example.com", "www.example.com"},
+			IPAddresses: []string{
+				"10.0.0.1",
+			},
+			Usages: []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageServerAuth},
+			Signer: signer,
+		},
+		Namespace:    "default",
+		Name:         "test",
+		IssuerRef:    cmmeta.ObjectReference{Name: "ca", Kind: cmapi.IssuerKind},
+		Duration:     time.Hour,
+		Annotations:  map[string]string{"example.com/owner": "service"},
+		PollInterval: time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	// The CSR is signed with the supplied signer, and no key is generated.
+	assert.Equal(t, int32(1), signer.signed.Load())
+	assert.Equal(t, crypto.Signer(signer), result.PrivateKey)
+
+	cert, err := pki.DecodeX509CertificateBytes(result.ChainPEM)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"example.com", "www.example.com"}, cert.DNSNames)
+	assert.NoError(t, cert.CheckSignatureFrom(ca.cert))
+	caCert, err := pki.DecodeX509CertificateBytes(result.CAPEM)
+	require.NoError(t, err)
+	assert.True(t, caCert.Equal(ca.cert))
+
+	cr := result.CertificateRequest
+	assert.Equal(t, cmmeta.ObjectReference{Name: "ca", Kind: cmapi.IssuerKind}, cr.Spec.IssuerRef)
+	assert.Equal(t, &metav1.Duration{Duration: time.Hour}, cr.Spec.Duration)
+	assert.Equal(t, []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageServerAuth}, cr.Spec.Usages)
+	fingerprint, err := pki.PublicKeyFingerprintSHA256(ecKey.Public())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"example.com/owner": "service",
+		cmapi.CertificateRequestPublicKeyFingerprintAnnotationKey: fingerprint,
+	}, cr.Annotations)
+}
+
+func TestDoRejectsCertificateForAnotherKey(t *testing.T) {
+	ca := newTestCA(t)
+	otherKey, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+	otherCSR, _, err := GenerateCSR(Options{DNSNames: []string{"example.com"}, Signer: otherKey})
+	require.NoError(t, err)
+
+	client := cmfake.NewSimpleClientset()
+	client.PrependReactor("create", "certificaterequests", func(action coretesting.Action) (bool, runtime.Object, error) {
+		// Sign a CSR for another private key.
+		cr := action.(coretesting.CreateAction).GetObject().(*cmapi.CertificateRequest)
+		other := cr.DeepCopy()
+		other.Spec.Request = otherCSR
+		ca.sign(t, other)
+		cr.Status = other.Status
+		return false, nil, nil
+	})
+
+	_, err = Do(context.Background(), client, Request{
+		Options:      Options{DNSNames: []string{"example.com"}},
+		Namespace:    "default",
+		Name:         "test",
+		PollInterval: time.Millisecond,
+	})
+	assert.EqualError(t, err, "the signed certificate does not match the private key of the request")
+}
+
+func TestCreateRequiresName(t *testing.T) {
+	_, _, err := Create(context.Background(), cmfake.NewSimpleClientset(), Request{
+		Options:   Options{DNSNames: []string{"example.com"}},
+		Namespace: "default",
+	})
+	assert.EqualError(t, err, "either a name or a generateName must be given")
+}
+
+func TestWait(t *testing.T) {
+	ca := newTestCA(t)
+	csrPEM, _, err := GenerateCSR(Options{DNSNames: []string{"example.com"}, PrivateKey: &cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm}})
+	require.NoError(t, err)
+
+	pending := func() *cmapi.CertificateRequest {
+		cr := &cmapi.CertificateRequest{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+			Spec:       cmapi.CertificateRequestSpec{Request: csrPEM},
+		}
+		apiutil.SetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionReady, cmmeta.ConditionFalse, cmapi.CertificateRequestReasonPending, "pending")
+		return cr
+	}
+	withCondition := func(conditionType cmapi.CertificateRequestConditionType, status cmmeta.ConditionStatus, reason, message string) *cmapi.CertificateRequest {
+		cr := pending()
+		apiutil.SetCertificateRequestCondition(cr, conditionType, status, reason, message)
+		return cr
+	}
+
+	tests := map[string]struct {
+		cr *cmapi.CertificateRequest
+
+		expErr    string
+		expSentry error
+	}{
+		"a denied request returns ErrDenied": {
+			cr:        withCondition(cmapi.CertificateRequestConditionDenied, cmmeta.ConditionTrue, "Policy", "not allowed"),
+			expErr:    "certificate request was denied: not allowed",
+			expSentry: ErrDenied,
+		},
+		"a failed request returns ErrFailed": {
+			cr:        withCondition(cmapi.CertificateRequestConditionReady, cmmeta.ConditionFalse, cmapi.CertificateRequestReasonFailed, "issuer error"),
+			expErr:    "certificate request failed: issuer error",
+			expSentry: ErrFailed,
+		},
+		"an invalid request returns ErrFailed": {
+			cr:        withCondition(cmapi.CertificateRequestConditionInvalidRequest, cmmeta.ConditionTrue, "BadConfig", "unsupported usages"),
+			expErr:    "certificate request failed: unsupported usages",
+			expSentry: ErrFailed,
+		},
+		"a pending request is waited for until the context is cancelled": {
+			cr:        pending(),
+			expErr:    context.DeadlineExceeded.Error(),
+			expSentry: context.DeadlineExceeded,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := cmfake.NewSimpleClientset(test.cr)
+			ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+			defer cancel()
+
+			_, err := Wait(ctx, client, "default", "test", time.Millisecond)
+			assert.EqualError(t, err, test.expErr)
+			assert.True(t, errors.Is(err, test.expSentry))
+		})
+	}
+
+	t.Run("transient errors fetching the request are retried", func(t *testing.T) {
+		signed := pending()
+		ca.sign(t, signed)
+		client := cmfake.NewSimpleClientset(signed)
+		var gets atomic.Int32
+		client.PrependReactor("get", "certificaterequests", func(coretesting.Action) (bool, runtime.Object, error) {
+			if gets.Add(1) <= 3 {
+				return true, nil, apierrors.NewServiceUnavailable("apiserver is restarting")
+			}
+			return false, nil, nil
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+		defer cancel()
+		cr, err := Wait(ctx, client, "default", "test", time.Millisecond)
+		require.NoError(t, err)
+		assert.NotEmpty(t, cr.Status.Certificate)
+		assert.EqualValues(t, 4, gets.Load())
+	})
+
+	t.Run("other errors fetching the request are returned", func(t *testing.T) {
+		client := cmfake.NewSimpleClientset(pending())
+		client.PrependReactor("get", "certificaterequests", func(coretesting.Action) (bool, runtime.Object, error) {
+			return true, nil, apierrors.NewForbidden(cmapi.Resource("certificaterequests"), "test", errors.New("denied by RBAC"))
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+		defer cancel()
+		_, err := Wait(ctx, client, "default", "test", time.Millisecond)
+		assert.True(t, apierrors.IsForbidden(err), "expected a Forbidden error, got: %v", err)
+	})
+
+	t.Run("the last transient error is returned once the context is cancelled", func(t *testing.T) {
+		client := cmfake.NewSimpleClientset(pending())
+		client.PrependReactor("get", "certificaterequests", func(coretesting.Action) (bool, runtime.Object, error) {
+			return true, nil, apierrors.NewServiceUnavailable("apiserver is restarting")
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+		defer cancel()
+		_, err := Wait(ctx, client, "default", "test", time.Millisecond)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+		assert.ErrorContains(t, err, "apiserver is restarting")
+	})
+
+	t.Run("a request is waited for until it is signed", func(t *testing.T) {
+		client := cmfake.NewSimpleClientset(pending())
+		signed := pending()
+		ca.sign(t, signed)
+		go func() {
+			time.Sleep(time.Millisecond * 20)
+			_, err := client.CertmanagerV1().CertificateRequests("default").UpdateStatus(context.Background(), signed, metav1.UpdateOptions{})
+			assert.NoError(t, err)
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+		defer cancel()
+		cr, err := Wait(ctx, client, "default", "test", time.Millisecond)
+		require.NoError(t, err)
+		assert.NotEmpty(t, cr.Status.Certificate)
+	})
+}
@@ -29,8 +29,10 @@ import (
 type CertmanagerV1Interface interface {
 	RESTClient() rest.Interface
 	CertificatesGetter
+	CertificateMigrationsGetter
 	CertificateRequestsGetter
 	ClusterIssuersGetter
+	IssuancePreviewsGetter
 	IssuersGetter
 }
 
@@ -43,6 +45,10 @@ func (c *CertmanagerV1Client) Certificates(namespace string) CertificateInterfac
 	return newCertificates(c, namespace)
 }
 
+func (c *CertmanagerV1Client) CertificateMigrations() CertificateMigrationInterface {
+	return newCertificateMigrations(c)
+}
+
 func (c *CertmanagerV1Client) CertificateRequests(namespace string) CertificateRequestInterface {
 	return newCertificateRequests(c, namespace)
 }
@@ -51,6 +57,10 @@ func (c *CertmanagerV1Client) ClusterIssuers() ClusterIssuerInterface {
 	return newClusterIssuers(c)
 }
 
+func (c *CertmanagerV1Client) IssuancePreviews(namespace string) IssuancePreviewInterface {
+	return newIssuancePreviews(c, namespace)
+}
+
 func (c *CertmanagerV1Client) Issuers(namespace string) IssuerInterface {
 	return newIssuers(c, namespace)
 }
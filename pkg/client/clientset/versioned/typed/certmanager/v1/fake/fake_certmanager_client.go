@@ -32,6 +32,10 @@ func (c *FakeCertmanagerV1) Certificates(namespace string) v1.CertificateInterfa
 	return &FakeCertificates{c, namespace}
 }
 
+func (c *FakeCertmanagerV1) CertificateMigrations() v1.CertificateMigrationInterface {
+	return &FakeCertificateMigrations{c}
+}
+
 func (c *FakeCertmanagerV1) CertificateRequests(namespace string) v1.CertificateRequestInterface {
 	return &FakeCertificateRequests{c, namespace}
 }
@@ -40,6 +44,10 @@ func (c *FakeCertmanagerV1) ClusterIssuers() v1.ClusterIssuerInterface {
 	return &FakeClusterIssuers{c}
 }
 
+func (c *FakeCertmanagerV1) IssuancePreviews(namespace string) v1.IssuancePreviewInterface {
+	return &FakeIssuancePreviews{c, namespace}
+}
+
 func (c *FakeCertmanagerV1) Issuers(namespace string) v1.IssuerInterface {
 	return &FakeIssuers{c, namespace}
 }
@@ -20,8 +20,12 @@ package v1
 
 type CertificateExpansion interface{}
 
+type CertificateMigrationExpansion interface{}
+
 type CertificateRequestExpansion interface{}
 
 type ClusterIssuerExpansion interface{}
 
+type IssuancePreviewExpansion interface{}
+
 type IssuerExpansion interface{}
@@ -26,10 +26,14 @@ import (
 type Interface interface {
 	// Certificates returns a CertificateInformer.
 	Certificates() CertificateInformer
+	// CertificateMigrations returns a CertificateMigrationInformer.
+	CertificateMigrations() CertificateMigrationInformer
 	// CertificateRequests returns a CertificateRequestInformer.
 	CertificateRequests() CertificateRequestInformer
 	// ClusterIssuers returns a ClusterIssuerInformer.
 	ClusterIssuers() ClusterIssuerInformer
+	// IssuancePreviews returns a IssuancePreviewInformer.
+	IssuancePreviews() IssuancePreviewInformer
 	// Issuers returns a IssuerInformer.
 	Issuers() IssuerInformer
 }
@@ -50,6 +54,11 @@ func (v *version) Certificates() CertificateInformer {
 	return &certificateInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// CertificateMigrations returns a CertificateMigrationInformer.
+func (v *version) CertificateMigrations() CertificateMigrationInformer {
+	return &certificateMigrationInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // CertificateRequests returns a CertificateRequestInformer.
 func (v *version) CertificateRequests() CertificateRequestInformer {
 	return &certificateRequestInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -60,6 +69,11 @@ func (v *version) ClusterIssuers() ClusterIssuerInformer {
 	return &clusterIssuerInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// IssuancePreviews returns a IssuancePreviewInformer.
+func (v *version) IssuancePreviews() IssuancePreviewInformer {
+	return &issuancePreviewInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // Issuers returns a IssuerInformer.
 func (v *version) Issuers() IssuerInformer {
 	return &issuerInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -26,6 +26,10 @@ type CertificateListerExpansion interface{}
 // CertificateNamespaceLister.
 type CertificateNamespaceListerExpansion interface{}
 
+// CertificateMigrationListerExpansion allows custom methods to be added to
+// CertificateMigrationLister.
+type CertificateMigrationListerExpansion interface{}
+
 // CertificateRequestListerExpansion allows custom methods to be added to
 // CertificateRequestLister.
 type CertificateRequestListerExpansion interface{}
@@ -38,6 +42,14 @@ type CertificateRequestNamespaceListerExpansion interface{}
 // ClusterIssuerLister.
 type ClusterIssuerListerExpansion interface{}
 
+// IssuancePreviewListerExpansion allows custom methods to be added to
+// IssuancePreviewLister.
+type IssuancePreviewListerExpansion interface{}
+
+// IssuancePreviewNamespaceListerExpansion allows custom methods to be added to
+// IssuancePreviewNamespaceLister.
+type IssuancePreviewNamespaceListerExpansion interface{}
+
 // IssuerListerExpansion allows custom methods to be added to
 // IssuerLister.
 type IssuerListerExpansion interface{}
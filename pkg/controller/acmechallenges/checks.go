@@ -16,4 +16,77 @@ limitations under the License.
 
 package acmechallenges
 
-// no checks for the acme orders controller yet
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/util/workqueue"
+
+	internalissuers "github.com/cert-manager/cert-manager/internal/controller/issuers"
+	"github.com/cert-manager/cert-manager/pkg/acme"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmacmelisters "github.com/cert-manager/cert-manager/pkg/client/listers/acme/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+)
+
+// handleRetryPendingFunc returns an update handler for Issuers and
+// ClusterIssuers which retries their pending Challenges once the ACME server
+// has become available again, or when requested with the retry-pending
+// annotation. The backoff of each Challenge is reset, so that it is processed
+// immediately rather than once its backoff expires.
+func handleRetryPendingFunc(
+	queue workqueue.RateLimitingInterface,
+	challengeLister cmacmelisters.ChallengeLister,
+) func(interface{}, interface{}) {
+	return func(oldObj, newObj interface{}) {
+		iss, ok := internalissuers.RetryPendingRequested(oldObj, newObj)
+		if !ok {
+			return
+		}
+
+		challenges, err := challengesForGenericIssuer(iss, challengeLister)
+		if err != nil {
+			runtime.HandleError(fmt.Errorf("error looking up Challenges observing Issuer/ClusterIssuer: %s/%s", iss.GetObjectMeta().Namespace, iss.GetObjectMeta().Name))
+			return
+		}
+		for _, ch := range challenges {
+			if acme.IsFinalState(ch.Status.State) {
+				continue
+			}
+			key, err := controllerpkg.KeyFunc(ch)
+			if err != nil {
+				runtime.HandleError(err)
+				continue
+			}
+			queue.Forget(key)
+			queue.Add(key)
+		}
+	}
+}
+
+func challengesForGenericIssuer(iss cmapi.GenericIssuer, challengeLister cmacmelisters.ChallengeLister) ([]*cmacme.Challenge, error) {
+	challenges, err := challengeLister.List(labels.NewSelector())
+	if err != nil {
+		return nil, fmt.Errorf("error listing challenges: %s", err.Error())
+	}
+
+	_, isClusterIssuer := iss.(*cmapi.ClusterIssuer)
+
+	var affected []*cmacme.Challenge
+	for _, ch := range challenges {
+		if isClusterIssuer && ch.Spec.IssuerRef.Kind != cmapi.ClusterIssuerKind {
+			continue
+		}
+		if !isClusterIssuer && ch.Namespace != iss.GetObjectMeta().Namespace {
+			continue
+		}
+		if ch.Spec.IssuerRef.Name != iss.GetObjectMeta().Name {
+			continue
+		}
+		affected = append(affected, ch)
+	}
+
+	return affected, nil
+}
@@ -26,9 +26,13 @@ import (
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
 
+	internalissuers "github.com/cert-manager/cert-manager/internal/controller/issuers"
 	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
 	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
 	cmacmelisters "github.com/cert-manager/cert-manager/pkg/client/listers/acme/v1"
 	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
@@ -61,10 +65,21 @@ type controller struct {
 	// for processing. This job runs periodically every N seconds, so it cannot
 	// be constructed as a traditional controller.
 	scheduler *scheduler.Scheduler
+	// dns01Sweeper deletes DNS01 challenge records which were never cleaned
+	// up. It is nil if the sweeper is disabled, and runs at most once every
+	// dns01SweepInterval.
+	dns01Sweeper       *dns.Sweeper
+	dns01SweepInterval time.Duration
+	lastDNS01Sweep     time.Time
+	clock              clock.Clock
 
 	// used to record Events about resources to the API
 	recorder record.EventRecorder
 
+	// used to mark the ACME server of issuers unavailable
+	cmClient     cmclient.Interface
+	fieldManager string
+
 	// maintain a reference to the workqueue for this controller
 	// so the handleOwnedResource method can enqueue resources
 	queue workqueue.RateLimitingInterface
@@ -125,14 +140,22 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 		clusterIssuerInformer := ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers()
 		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
 		c.clusterIssuerLister = clusterIssuerInformer.Lister()
+		clusterIssuerInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			UpdateFunc: handleRetryPendingFunc(c.queue, c.challengeLister),
+		})
 	}
 
 	// register handler functions
 	challengeInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: c.queue})
+	issuerInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: handleRetryPendingFunc(c.queue, c.challengeLister),
+	})
 
 	c.helper = issuer.NewHelper(c.issuerLister, c.clusterIssuerLister)
 	c.scheduler = scheduler.New(logf.NewContext(ctx.RootContext, c.log), c.challengeLister, ctx.SchedulerOptions.MaxConcurrentChallenges)
 	c.recorder = ctx.Recorder
+	c.cmClient = ctx.CMClient
+	c.fieldManager = ctx.FieldManager
 	c.accountRegistry = ctx.ACMEOptions.AccountRegistry
 
 	var err error
@@ -140,10 +163,17 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	if err != nil {
 		return nil, nil, err
 	}
-	c.dnsSolver, err = dns.NewSolver(ctx)
+	dnsSolver, err := dns.NewSolver(ctx)
 	if err != nil {
 		return nil, nil, err
 	}
+	c.dnsSolver = dnsSolver
+
+	if ctx.ACMEOptions.DNS01OrphanedRecordSweepInterval > 0 {
+		c.dns01Sweeper = dns.NewSweeper(dnsSolver, c.challengeLister, c.issuerLister, c.clusterIssuerLister, ctx.ACMEOptions.DNS01OrphanedRecordMinAge)
+		c.dns01SweepInterval = ctx.ACMEOptions.DNS01OrphanedRecordSweepInterval
+	}
+	c.clock = ctx.Clock
 
 	// read options from context
 	c.dns01Nameservers = ctx.ACMEOptions.DNS01Nameservers
@@ -193,6 +223,19 @@ func (c *controller) runScheduler(ctx context.Context) {
 	}
 }
 
+// runDNS01Sweeper deletes the DNS01 challenge records which were never cleaned
+// up, if the sweeper is enabled and has not run within its interval.
+func (c *controller) runDNS01Sweeper(ctx context.Context) {
+	if c.dns01Sweeper == nil || c.clock.Since(c.lastDNS01Sweep) < c.dns01SweepInterval {
+		return
+	}
+	c.lastDNS01Sweep = c.clock.Now()
+
+	if err := c.dns01Sweeper.Sweep(ctx); err != nil {
+		logf.FromContext(ctx, "dns01Sweeper").Error(err, "error sweeping orphaned DNS01 challenge records")
+	}
+}
+
 func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	log := logf.FromContext(ctx)
 	namespace, name, err := cache.SplitMetaNamespaceKey(key)
@@ -213,7 +256,26 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	}
 
 	ctx = logf.NewContext(ctx, logf.WithResource(log, ch))
-	return c.Sync(ctx, ch)
+	err = c.Sync(ctx, ch)
+	if internalissuers.IsACMEServerUnavailableError(err) {
+		c.markACMEServerUnavailable(ctx, ch.Spec.IssuerRef, ch.Namespace, err)
+	}
+	return err
+}
+
+// markACMEServerUnavailable sets the ACMEServerUnavailable condition on the
+// issuer of a Challenge whose ACME server failed to respond, so that the issuers
+// controllers probe the ACME server until it recovers.
+func (c *controller) markACMEServerUnavailable(ctx context.Context, ref cmmeta.ObjectReference, namespace string, cause error) {
+	log := logf.FromContext(ctx)
+	iss, err := c.helper.GetGenericIssuer(ref, namespace)
+	if err != nil {
+		log.Error(err, "failed to look up the issuer to mark its ACME server unavailable")
+		return
+	}
+	if err := internalissuers.MarkACMEServerUnavailable(ctx, c.cmClient, c.fieldManager, iss, cause); err != nil {
+		log.Error(err, "failed to mark the ACME server of the issuer unavailable")
+	}
 }
 
 const (
@@ -226,6 +288,7 @@ func init() {
 		return controllerpkg.NewBuilder(ctx, ControllerName).
 			For(c).
 			With(c.runScheduler, time.Second).
+			With(c.runDNS01Sweeper, time.Minute).
 			Complete()
 	})
 }
@@ -186,6 +186,28 @@ func (c *controller) Sync(ctx context.Context, chOriginal *cmacme.Challenge) (er
 	}
 
 	err = solver.Check(ctx, genericIssuer, ch)
+	if errors.Is(err, dnsutil.ErrPropagationTimeout) {
+		log.Error(err, "propagation check timed out")
+		ch.Status.State = cmacme.Errored
+		ch.Status.Reason = fmt.Sprintf("Error waiting for %s challenge propagation: %s", ch.Spec.Type, err)
+		c.recorder.Eventf(ch, corev1.EventTypeWarning, reasonFailed, "Timed out waiting for challenge propagation: %v", err)
+		return nil
+	}
+	var delayErr *dnsutil.InitialDelayError
+	if errors.As(err, &delayErr) {
+		log.V(logf.DebugLevel).Info("waiting for the initial delay before accepting the challenge", "retryAfter", delayErr.RetryAfter)
+		ch.Status.Reason = fmt.Sprintf("Waiting for %s challenge propagation: %s", ch.Spec.Type, err)
+
+		key, err := controllerpkg.KeyFunc(ch)
+		// This is an unexpected edge case and should never occur
+		if err != nil {
+			return err
+		}
+
+		c.queue.AddAfter(key, delayErr.RetryAfter)
+
+		return nil
+	}
 	if err != nil {
 		log.Error(err, "propagation check failed")
 		ch.Status.Reason = fmt.Sprintf("Waiting for %s challenge propagation: %s", ch.Spec.Type, err)
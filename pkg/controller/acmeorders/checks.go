@@ -23,6 +23,8 @@ import (
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/util/workqueue"
 
+	internalissuers "github.com/cert-manager/cert-manager/internal/controller/issuers"
+	"github.com/cert-manager/cert-manager/pkg/acme"
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmacmelisters "github.com/cert-manager/cert-manager/pkg/client/listers/acme/v1"
@@ -55,6 +57,50 @@ func handleGenericIssuerFunc(
 	}
 }
 
+// handleRetryPendingFunc returns an update handler for Issuers and
+// ClusterIssuers which retries their pending Orders once the ACME server has
+// become available again, or when requested with the retry-pending
+// annotation. The backoff of each Order is reset, so that it is processed
+// immediately rather than once its backoff expires.
+func handleRetryPendingFunc(
+	queue workqueue.RateLimitingInterface,
+	orderLister cmacmelisters.OrderLister,
+) func(interface{}, interface{}) {
+	return func(oldObj, newObj interface{}) {
+		iss, ok := internalissuers.RetryPendingRequested(oldObj, newObj)
+		if !ok {
+			return
+		}
+
+		orders, err := ordersForGenericIssuer(iss, orderLister)
+		if err != nil {
+			runtime.HandleError(fmt.Errorf("error looking up Orders observing Issuer/ClusterIssuer: %s/%s", iss.GetObjectMeta().Namespace, iss.GetObjectMeta().Name))
+			return
+		}
+		for _, o := range orders {
+			if !orderPending(o) {
+				continue
+			}
+			key, err := keyFunc(o)
+			if err != nil {
+				runtime.HandleError(err)
+				continue
+			}
+			queue.Forget(key)
+			queue.Add(key)
+		}
+	}
+}
+
+// orderPending returns true if the Order has not failed and its certificate
+// has not been fetched from the ACME server yet.
+func orderPending(o *cmacme.Order) bool {
+	if acme.IsFailureState(o.Status.State) {
+		return false
+	}
+	return o.Status.State != cmacme.Valid || len(o.Status.Certificate) == 0
+}
+
 func ordersForGenericIssuer(iss cmapi.GenericIssuer, orderLister cmacmelisters.OrderLister) ([]*cmacme.Order, error) {
 	orders, err := orderLister.List(labels.NewSelector())
 
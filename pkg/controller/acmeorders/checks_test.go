@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acmeorders
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmacmelisters "github.com/cert-manager/cert-manager/pkg/client/listers/acme/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestHandleRetryPendingFunc(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Now())
+	queue := workqueue.NewRateLimitingQueueWithConfig(
+		workqueue.NewItemExponentialFailureRateLimiter(time.Second*5, time.Minute*30),
+		workqueue.RateLimitingQueueConfig{Name: "test", Clock: clock},
+	)
+	defer queue.ShutDown()
+
+	issuerRef := cmmeta.ObjectReference{Name: "acme", Kind: cmapi.IssuerKind}
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, o := range []*cmacme.Order{
+		gen.Order("pending", gen.SetOrderNamespace("ns"), gen.SetOrderIssuer(issuerRef), gen.SetOrderState(cmacme.Pending)),
+		gen.Order("new", gen.SetOrderNamespace("ns"), gen.SetOrderIssuer(issuerRef)),
+		gen.Order("valid", gen.SetOrderNamespace("ns"), gen.SetOrderIssuer(issuerRef), gen.SetOrderState(cmacme.Valid), gen.SetOrderCertificate([]byte("cert"))),
+		gen.Order("other-issuer", gen.SetOrderNamespace("ns"), gen.SetOrderIssuer(cmmeta.ObjectReference{Name: "other"}), gen.SetOrderState(cmacme.Pending)),
+	} {
+		require.NoError(t, indexer.Add(o))
+	}
+	handler := handleRetryPendingFunc(queue, cmacmelisters.NewOrderLister(indexer))
+
+	keys := []string{"ns/pending", "ns/new", "ns/valid", "ns/other-issuer"}
+	// outage fails every Order repeatedly during an ACME server outage, so
+	// that they are backed off. As the clock does not advance, none of them
+	// is processed again unless it is retried.
+	outage := func() {
+		for range 10 {
+			for _, key := range keys {
+				queue.AddRateLimited(key)
+			}
+		}
+		require.Equal(t, 0, queue.Len(), "expected Orders to be backed off")
+	}
+	// processQueued returns the keys queued for processing straight away.
+	processQueued := func() []string {
+		var processed []string
+		for queue.Len() > 0 {
+			key, _ := queue.Get()
+			processed = append(processed, key.(string))
+			queue.Done(key)
+		}
+		return processed
+	}
+
+	unavailable := gen.Issuer("acme",
+		gen.SetIssuerNamespace("ns"),
+		gen.SetIssuerACME(cmacme.ACMEIssuer{}),
+		gen.AddIssuerCondition(cmapi.IssuerCondition{
+			Type:   cmapi.IssuerConditionACMEServerUnavailable,
+			Status: cmmeta.ConditionTrue,
+		}),
+	)
+	recovered := gen.IssuerFrom(unavailable)
+	recovered.Status.Conditions = nil
+
+	outage()
+
+	// Updates whilst the ACME server remains unavailable do not retry.
+	handler(unavailable, unavailable)
+	assert.Empty(t, processQueued())
+
+	// Once the ACME server responds again, the pending Orders of the issuer
+	// are processed immediately and their backoff is reset.
+	handler(unavailable, recovered)
+	assert.ElementsMatch(t, []string{"ns/pending", "ns/new"}, processQueued())
+	assert.Equal(t, 0, queue.NumRequeues("ns/pending"))
+	assert.Equal(t, 0, queue.NumRequeues("ns/new"))
+	assert.Equal(t, 10, queue.NumRequeues("ns/valid"), "expected the backoff of Orders in a final state to be kept")
+	assert.Equal(t, 10, queue.NumRequeues("ns/other-issuer"), "expected the backoff of Orders of other issuers to be kept")
+
+	// The retry-pending annotation retries the pending Orders each time its
+	// value changes.
+	outage()
+	requested := gen.IssuerFrom(recovered)
+	requested.Annotations = map[string]string{cmacme.RetryPendingAnnotationKey: "2024-01-01T00:00:00Z"}
+	handler(recovered, requested)
+	assert.ElementsMatch(t, []string{"ns/pending", "ns/new"}, processQueued())
+	handler(requested, requested)
+	assert.Empty(t, processQueued())
+}
@@ -27,8 +27,10 @@ import (
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/clock"
 
+	internalissuers "github.com/cert-manager/cert-manager/internal/controller/issuers"
 	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
 	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
 	cmacmelisters "github.com/cert-manager/cert-manager/pkg/client/listers/acme/v1"
 	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
@@ -120,6 +122,9 @@ func NewController(
 		clusterIssuerInformer.Informer().AddEventHandler(
 			&controllerpkg.BlockingEventHandler{WorkFunc: handleGenericIssuerFunc(queue, orderLister)},
 		)
+		clusterIssuerInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			UpdateFunc: handleRetryPendingFunc(queue, orderLister),
+		})
 	}
 
 	// register handler functions
@@ -129,6 +134,9 @@ func NewController(
 	issuerInformer.Informer().AddEventHandler(
 		&controllerpkg.BlockingEventHandler{WorkFunc: handleGenericIssuerFunc(queue, orderLister)},
 	)
+	issuerInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: handleRetryPendingFunc(queue, orderLister),
+	})
 	challengeInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
 		WorkFunc: controllerpkg.HandleOwnedResourceNamespacedFunc(log, queue, orderGvk, orderGetterFunc(orderLister)),
 	})
@@ -170,7 +178,26 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	}
 
 	ctx = logf.NewContext(ctx, logf.WithResource(log, order))
-	return c.Sync(ctx, order)
+	err = c.Sync(ctx, order)
+	if internalissuers.IsACMEServerUnavailableError(err) {
+		c.markACMEServerUnavailable(ctx, order.Spec.IssuerRef, order.Namespace, err)
+	}
+	return err
+}
+
+// markACMEServerUnavailable sets the ACMEServerUnavailable condition on the
+// issuer of an Order whose ACME server failed to respond, so that the issuers
+// controllers probe the ACME server until it recovers.
+func (c *controller) markACMEServerUnavailable(ctx context.Context, ref cmmeta.ObjectReference, namespace string, cause error) {
+	log := logf.FromContext(ctx)
+	iss, err := c.helper.GetGenericIssuer(ref, namespace)
+	if err != nil {
+		log.Error(err, "failed to look up the issuer to mark its ACME server unavailable")
+		return
+	}
+	if err := internalissuers.MarkACMEServerUnavailable(ctx, c.cmClient, c.fieldManager, iss, cause); err != nil {
+		log.Error(err, "failed to mark the ACME server of the issuer unavailable")
+	}
 }
 
 // Returns a function that finds a named Order in a particular namespace.
@@ -0,0 +1,194 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package selectors
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+)
+
+// Score describes how specifically a solver's selector matches a given
+// object and DNS name. Scores are compared field by field in the order
+// DNSNames, DNSZones and then Labels, so a solver that lists the DNS name
+// explicitly always takes precedence over one that only matches its zone.
+type Score struct {
+	// DNSNames is 1 if the DNS name is listed in the selector's dnsNames,
+	// and 0 otherwise. Multiple dnsNames do not add extra weight.
+	DNSNames int
+	// DNSZones is the number of DNS labels in the longest matching dnsZone.
+	DNSZones int
+	// Labels is the number of matchLabels that matched.
+	Labels int
+}
+
+// Compare returns 1 if s is a more specific match than o, -1 if it is less
+// specific and 0 if the two scores are equal.
+func (s Score) Compare(o Score) int {
+	for _, d := range [...]int{s.DNSNames - o.DNSNames, s.DNSZones - o.DNSZones, s.Labels - o.Labels} {
+		switch {
+		case d > 0:
+			return 1
+		case d < 0:
+			return -1
+		}
+	}
+	return 0
+}
+
+func (s Score) String() string {
+	return fmt.Sprintf("dnsNames=%d, dnsZones=%d, matchLabels=%d", s.DNSNames, s.DNSZones, s.Labels)
+}
+
+// Candidate is the result of evaluating a single solver during solver
+// selection.
+type Candidate struct {
+	// Index is the position of the solver in the issuer's list of solvers.
+	Index int
+	// Matches is true if the solver could be used for the DNS name.
+	Matches bool
+	// Score is the specificity of the match. It is only meaningful if
+	// Matches is true.
+	Score Score
+	// Reason is a human readable explanation of why the solver did or did
+	// not match.
+	Reason string
+}
+
+// SolverSelection is the result of SelectSolver.
+type SolverSelection struct {
+	// Solver is the selected solver, or nil if no solver matched.
+	Solver *cmacme.ACMEChallengeSolver
+	// Index is the position of the selected solver in the list of solvers,
+	// or -1 if no solver matched.
+	Index int
+	// Candidates contains an entry for every solver that was evaluated, in
+	// the order they were configured.
+	Candidates []Candidate
+	// Ambiguous is true if another matching solver had exactly the same
+	// score as the selected one, meaning the selection was decided only by
+	// the order the solvers are listed in.
+	Ambiguous bool
+}
+
+// Explanation returns a human readable, deterministic description of why
+// the selected solver was chosen.
+func (s *SolverSelection) Explanation() string {
+	if s.Solver == nil {
+		return "no configured challenge solvers matched"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "selected solver %d (%s)", s.Index, s.Candidates[s.candidate()].Score)
+	var others []string
+	for _, c := range s.Candidates {
+		if c.Index == s.Index {
+			continue
+		}
+		others = append(others, fmt.Sprintf("solver %d: %s", c.Index, c.Reason))
+	}
+	if len(others) > 0 {
+		fmt.Fprintf(&b, "; %s", strings.Join(others, "; "))
+	}
+	if s.Ambiguous {
+		b.WriteString("; selection is ambiguous, the first listed solver with the highest score was chosen")
+	}
+	return b.String()
+}
+
+func (s *SolverSelection) candidate() int {
+	for i, c := range s.Candidates {
+		if c.Index == s.Index {
+			return i
+		}
+	}
+	return -1
+}
+
+// SelectSolver chooses the most specific solver for the given object
+// metadata and DNS name. Solvers for which usable returns false are skipped,
+// which allows callers to exclude solver types not offered by the ACME
+// server. If several solvers have the same highest score, the first one
+// listed is selected and the selection is marked as ambiguous.
+func SelectSolver(meta metav1.ObjectMeta, dnsName string, solvers []cmacme.ACMEChallengeSolver, usable func(*cmacme.ACMEChallengeSolver) bool) *SolverSelection {
+	sel := &SolverSelection{Index: -1}
+	var best Score
+
+	for i := range solvers {
+		cfg := &solvers[i]
+		c := Candidate{Index: i}
+		if usable != nil && !usable(cfg) {
+			c.Reason = "solver type cannot be used for this domain"
+			sel.Candidates = append(sel.Candidates, c)
+			continue
+		}
+
+		if cfg.Selector == nil {
+			c.Matches = true
+			c.Reason = "matches all domains (no selector)"
+		} else {
+			labelsMatch, numLabels := Labels(*cfg.Selector).Matches(meta, dnsName)
+			dnsNamesMatch, numDNSNames := DNSNames(*cfg.Selector).Matches(meta, dnsName)
+			dnsZonesMatch, numDNSZones := DNSZones(*cfg.Selector).Matches(meta, dnsName)
+			c.Matches = labelsMatch && dnsNamesMatch && dnsZonesMatch
+			if numDNSNames > 0 {
+				numDNSNames = 1
+			}
+			c.Score = Score{DNSNames: numDNSNames, DNSZones: numDNSZones, Labels: numLabels}
+
+			var mismatches []string
+			if !dnsNamesMatch {
+				mismatches = append(mismatches, "dnsNames")
+			}
+			if !dnsZonesMatch {
+				mismatches = append(mismatches, "dnsZones")
+			}
+			if !labelsMatch {
+				mismatches = append(mismatches, "matchLabels")
+			}
+			if c.Matches {
+				c.Reason = fmt.Sprintf("matches (%s)", c.Score)
+			} else {
+				c.Reason = fmt.Sprintf("does not match %s", strings.Join(mismatches, ", "))
+			}
+		}
+		sel.Candidates = append(sel.Candidates, c)
+
+		if !c.Matches {
+			continue
+		}
+		if sel.Solver == nil {
+			sel.Solver, sel.Index, best = cfg, i, c.Score
+			continue
+		}
+		switch c.Score.Compare(best) {
+		case 1:
+			sel.Solver, sel.Index, best = cfg, i, c.Score
+			sel.Ambiguous = false
+		case 0:
+			sel.Ambiguous = true
+		}
+	}
+
+	if sel.Solver != nil {
+		sel.Solver = sel.Solver.DeepCopy()
+	}
+	return sel
+}
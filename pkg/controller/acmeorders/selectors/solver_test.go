@@ -0,0 +1,183 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package selectors
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+)
+
+func TestSelectSolver(t *testing.T) {
+	http01 := &cmacme.ACMEChallengeSolverHTTP01{}
+	dns01 := &cmacme.ACMEChallengeSolverDNS01{}
+	meta := metav1.ObjectMeta{Labels: map[string]string{"a": "b", "c": "d"}}
+
+	tests := []struct {
+		name            string
+		solvers         []cmacme.ACMEChallengeSolver
+		usable          func(*cmacme.ACMEChallengeSolver) bool
+		dnsName         string
+		expectedIndex   int
+		expectAmbiguous bool
+		expectedExplain string
+	}{
+		{
+			name:            "no solvers",
+			dnsName:         "www.example.com",
+			expectedIndex:   -1,
+			expectedExplain: "no configured challenge solvers matched",
+		},
+		{
+			name: "single solver without a selector",
+			solvers: []cmacme.ACMEChallengeSolver{
+				{HTTP01: http01},
+			},
+			dnsName:         "www.example.com",
+			expectedIndex:   0,
+			expectedExplain: "selected solver 0 (dnsNames=0, dnsZones=0, matchLabels=0)",
+		},
+		{
+			name: "two solvers without selectors are ambiguous and the first is chosen",
+			solvers: []cmacme.ACMEChallengeSolver{
+				{HTTP01: http01},
+				{DNS01: dns01},
+			},
+			dnsName:         "www.example.com",
+			expectedIndex:   0,
+			expectAmbiguous: true,
+			expectedExplain: "selected solver 0 (dnsNames=0, dnsZones=0, matchLabels=0); solver 1: matches all domains (no selector); selection is ambiguous, the first listed solver with the highest score was chosen",
+		},
+		{
+			name: "dnsNames take precedence over dnsZones and labels",
+			solvers: []cmacme.ACMEChallengeSolver{
+				{HTTP01: http01, Selector: &cmacme.CertificateDNSNameSelector{DNSZones: []string{"www.example.com"}, MatchLabels: map[string]string{"a": "b", "c": "d"}}},
+				{DNS01: dns01, Selector: &cmacme.CertificateDNSNameSelector{DNSNames: []string{"www.example.com"}}},
+			},
+			dnsName:         "www.example.com",
+			expectedIndex:   1,
+			expectedExplain: "selected solver 1 (dnsNames=1, dnsZones=0, matchLabels=0); solver 0: matches (dnsNames=0, dnsZones=3, matchLabels=2)",
+		},
+		{
+			name: "the most specific dnsZone is chosen",
+			solvers: []cmacme.ACMEChallengeSolver{
+				{HTTP01: http01, Selector: &cmacme.CertificateDNSNameSelector{DNSZones: []string{"example.com"}}},
+				{DNS01: dns01, Selector: &cmacme.CertificateDNSNameSelector{DNSZones: []string{"www.example.com"}}},
+			},
+			dnsName:       "a.www.example.com",
+			expectedIndex: 1,
+		},
+		{
+			name: "dnsZones take precedence over labels",
+			solvers: []cmacme.ACMEChallengeSolver{
+				{HTTP01: http01, Selector: &cmacme.CertificateDNSNameSelector{MatchLabels: map[string]string{"a": "b", "c": "d"}}},
+				{DNS01: dns01, Selector: &cmacme.CertificateDNSNameSelector{DNSZones: []string{"example.com"}}},
+			},
+			dnsName:       "www.example.com",
+			expectedIndex: 1,
+		},
+		{
+			name: "the solver with more matching labels is chosen",
+			solvers: []cmacme.ACMEChallengeSolver{
+				{HTTP01: http01, Selector: &cmacme.CertificateDNSNameSelector{MatchLabels: map[string]string{"a": "b"}}},
+				{DNS01: dns01, Selector: &cmacme.CertificateDNSNameSelector{MatchLabels: map[string]string{"a": "b", "c": "d"}}},
+			},
+			dnsName:       "www.example.com",
+			expectedIndex: 1,
+		},
+		{
+			name: "a tie that is later beaten is not ambiguous",
+			solvers: []cmacme.ACMEChallengeSolver{
+				{HTTP01: http01},
+				{HTTP01: http01},
+				{DNS01: dns01, Selector: &cmacme.CertificateDNSNameSelector{DNSZones: []string{"example.com"}}},
+			},
+			dnsName:       "www.example.com",
+			expectedIndex: 2,
+		},
+		{
+			name: "equally specific selectors are ambiguous",
+			solvers: []cmacme.ACMEChallengeSolver{
+				{HTTP01: http01, Selector: &cmacme.CertificateDNSNameSelector{DNSZones: []string{"example.com"}}},
+				{DNS01: dns01, Selector: &cmacme.CertificateDNSNameSelector{DNSZones: []string{"example.com"}}},
+			},
+			dnsName:         "www.example.com",
+			expectedIndex:   0,
+			expectAmbiguous: true,
+		},
+		{
+			name: "non-matching solvers are reported",
+			solvers: []cmacme.ACMEChallengeSolver{
+				{HTTP01: http01, Selector: &cmacme.CertificateDNSNameSelector{DNSNames: []string{"other.com"}, MatchLabels: map[string]string{"x": "y"}}},
+				{DNS01: dns01},
+			},
+			dnsName:         "www.example.com",
+			expectedIndex:   1,
+			expectedExplain: "selected solver 1 (dnsNames=0, dnsZones=0, matchLabels=0); solver 0: does not match dnsNames, matchLabels",
+		},
+		{
+			name: "unusable solvers are skipped",
+			solvers: []cmacme.ACMEChallengeSolver{
+				{HTTP01: http01, Selector: &cmacme.CertificateDNSNameSelector{DNSNames: []string{"www.example.com"}}},
+				{DNS01: dns01},
+			},
+			usable: func(s *cmacme.ACMEChallengeSolver) bool {
+				return s.DNS01 != nil
+			},
+			dnsName:         "www.example.com",
+			expectedIndex:   1,
+			expectedExplain: "selected solver 1 (dnsNames=0, dnsZones=0, matchLabels=0); solver 0: solver type cannot be used for this domain",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sel := SelectSolver(meta, test.dnsName, test.solvers, test.usable)
+			if sel.Index != test.expectedIndex {
+				t.Errorf("expected solver %d to be selected, got %d", test.expectedIndex, sel.Index)
+			}
+			if (sel.Solver == nil) != (test.expectedIndex == -1) {
+				t.Errorf("unexpected selected solver %v for index %d", sel.Solver, sel.Index)
+			}
+			if sel.Ambiguous != test.expectAmbiguous {
+				t.Errorf("expected ambiguous=%t, got %t", test.expectAmbiguous, sel.Ambiguous)
+			}
+			if test.expectedExplain != "" && sel.Explanation() != test.expectedExplain {
+				t.Errorf("unexpected explanation\nexpected: %s\ngot:      %s", test.expectedExplain, sel.Explanation())
+			}
+		})
+	}
+}
+
+func TestScoreCompare(t *testing.T) {
+	tests := []struct {
+		a, b     Score
+		expected int
+	}{
+		{Score{}, Score{}, 0},
+		{Score{DNSNames: 1}, Score{DNSZones: 5, Labels: 5}, 1},
+		{Score{DNSZones: 2, Labels: 1}, Score{DNSZones: 3}, -1},
+		{Score{DNSZones: 2, Labels: 2}, Score{DNSZones: 2, Labels: 1}, 1},
+	}
+	for _, test := range tests {
+		if got := test.a.Compare(test.b); got != test.expected {
+			t.Errorf("%v.Compare(%v): expected %d, got %d", test.a, test.b, test.expected, got)
+		}
+	}
+}
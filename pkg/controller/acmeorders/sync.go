@@ -22,6 +22,8 @@ import (
 	"crypto/x509"
 	"encoding/pem"
 	"fmt"
+	"net/http"
+	"strconv"
 	"time"
 
 	acmeapi "golang.org/x/crypto/acme"
@@ -45,8 +47,10 @@ import (
 )
 
 const (
-	reasonSolver  = "Solver"
-	reasonCreated = "Created"
+	reasonSolver          = "Solver"
+	reasonCreated         = "Created"
+	reasonSolverSelected  = "SolverSelected"
+	reasonSolverAmbiguous = "AmbiguousSolver"
 )
 
 var (
@@ -55,6 +59,12 @@ var (
 	RequeuePeriod = time.Second * 5
 )
 
+// maxChallengesCreatedPerSync is the maximum number of Challenges created for
+// an Order in a single sync. The Challenges of Orders for Certificates with
+// many DNS names are created in batches, every RequeuePeriod, so that the
+// API server and the challenges controller are not flooded at once.
+const maxChallengesCreatedPerSync = 10
+
 func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 	log := logf.FromContext(ctx)
 	dbg := log.V(logf.DebugLevel)
@@ -98,6 +108,7 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 		log.V(logf.DebugLevel).Info("Updating Order status as status.finalizeURL is not set")
 		_, err := c.updateOrderStatus(ctx, cl, o)
 		if acmeErr, ok := err.(*acmeapi.Error); ok {
+			c.setOrderProblem(&o.Status, acmeErr)
 			if acmeErr.StatusCode >= 400 && acmeErr.StatusCode < 500 {
 				log.Error(err, "failed to update Order status due to a 4xx error, marking Order as failed")
 				c.setOrderState(&o.Status, string(cmacme.Errored))
@@ -142,11 +153,7 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 	switch {
 	case needToCreateChallenges:
 		log.V(logf.DebugLevel).Info("Creating additional Challenge resources to complete Order")
-		requiredChallenges, err = ensureKeysForChallenges(cl, requiredChallenges)
-		if err != nil {
-			return err
-		}
-		return c.createRequiredChallenges(ctx, o, requiredChallenges)
+		return c.createRequiredChallenges(ctx, cl, o, requiredChallenges)
 	case needToDeleteChallenges:
 		log.V(logf.DebugLevel).Info("Deleting leftover Challenge resources no longer required by Order")
 		return c.deleteLeftoverChallenges(ctx, o, requiredChallenges)
@@ -180,6 +187,7 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 	acmeOrder, err := getACMEOrder(ctx, cl, o)
 	// Order probably has been deleted, we cannot recover here.
 	if acmeErr, ok := err.(*acmeapi.Error); ok {
+		c.setOrderProblem(&o.Status, acmeErr)
 		if acmeErr.StatusCode >= 400 && acmeErr.StatusCode < 500 {
 			log.Error(err, "failed to retrieve the ACME order (4xx error) marking Order as failed")
 			c.setOrderState(&o.Status, string(cmacme.Errored))
@@ -204,6 +212,7 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 		log.V(logf.DebugLevel).Info("Update Order status as at least one Challenge has failed")
 		_, err := c.updateOrderStatusFromACMEOrder(o, acmeOrder)
 		if acmeErr, ok := err.(*acmeapi.Error); ok {
+			c.setOrderProblem(&o.Status, acmeErr)
 			if acmeErr.StatusCode >= 400 && acmeErr.StatusCode < 500 {
 				log.Error(err, "failed to update Order status due to a 4xx error, marking Order as failed")
 				c.setOrderState(&o.Status, string(cmacme.Errored))
@@ -244,6 +253,7 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 		log.V(logf.DebugLevel).Info("All challenges are in a final state, updating order state")
 		_, err := c.updateOrderStatusFromACMEOrder(o, acmeOrder)
 		if acmeErr, ok := err.(*acmeapi.Error); ok {
+			c.setOrderProblem(&o.Status, acmeErr)
 			if acmeErr.StatusCode >= 400 && acmeErr.StatusCode < 500 {
 				log.Error(err, "failed to update Order status due to a 4xx error, marking Order as failed")
 				c.setOrderState(&o.Status, string(cmacme.Errored))
@@ -286,6 +296,7 @@ func (c *controller) createOrder(ctx context.Context, cl acmecl.Interface, o *cm
 	}
 	acmeOrder, err := cl.AuthorizeOrder(ctx, authzIDs, options...)
 	if acmeErr, ok := err.(*acmeapi.Error); ok {
+		c.setOrderProblem(&o.Status, acmeErr)
 		if acmeErr.StatusCode >= 400 && acmeErr.StatusCode < 500 {
 			log.Error(err, "failed to create Order resource due to bad request, marking Order as failed")
 			c.setOrderState(&o.Status, string(cmacme.Errored))
@@ -323,6 +334,9 @@ func (c *controller) updateOrderStatusFromACMEOrder(o *cmacme.Order, acmeOrder *
 		o.Status.URL = acmeOrder.URI
 	}
 	o.Status.FinalizeURL = acmeOrder.FinalizeURL
+	if acmeOrder.CertURL != "" {
+		o.Status.CertificateURL = acmeOrder.CertURL
+	}
 	c.setOrderState(&o.Status, acmeOrder.Status)
 	// once the 'authorizations' slice contains at least one item, it cannot be
 	// updated. If it does not contain any items, update it containing the list
@@ -336,14 +350,51 @@ func (c *controller) updateOrderStatusFromACMEOrder(o *cmacme.Order, acmeOrder *
 
 // setOrderState will set the 'State' field of the given Order to 's'.
 // It will set the Orders failureTime field if the state provided is classed as
-// a failure state.
+// a failure state, and clear any previously recorded ACME problem otherwise.
 func (c *controller) setOrderState(o *cmacme.OrderStatus, s string) {
 	o.State = cmacme.State(s)
 	// if the order is in a failure state, we should set the `failureTime` field
 	if acme.IsFailureState(o.State) {
 		t := metav1.NewTime(c.clock.Now())
 		o.FailureTime = &t
+		return
 	}
+	o.RetryAfter = nil
+	o.LastProblemType = ""
+}
+
+// setOrderProblem records the problem type and Retry-After time of an error
+// returned by the ACME server on the given Order status.
+func (c *controller) setOrderProblem(o *cmacme.OrderStatus, acmeErr *acmeapi.Error) {
+	o.LastProblemType = acmeErr.ProblemType
+	o.RetryAfter = nil
+	if d, ok := retryAfter(acmeErr.Header.Get("Retry-After"), c.clock.Now()); ok {
+		t := metav1.NewTime(c.clock.Now().Add(d).Truncate(time.Second))
+		o.RetryAfter = &t
+	}
+}
+
+// retryAfter parses the value of a Retry-After header, which is either a
+// number of seconds or an HTTP date, into the duration to wait from now.
+// See https://www.rfc-editor.org/rfc/rfc9110#field.retry-after
+func retryAfter(v string, now time.Time) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if sec, err := strconv.Atoi(v); err == nil {
+		if sec < 0 {
+			return 0, false
+		}
+		return time.Duration(sec) * time.Second, true
+	}
+	t, err := http.ParseTime(v)
+	if err != nil {
+		return 0, false
+	}
+	if d := t.Sub(now); d > 0 {
+		return d, true
+	}
+	return 0, true
 }
 
 // constructAuthorizations will construct a slice of ACMEAuthorizations must be
@@ -378,6 +429,7 @@ func (c *controller) fetchMetadataForAuthorizations(ctx context.Context, o *cmac
 
 		acmeAuthz, err := cl.GetAuthorization(ctx, authz.URL)
 		if acmeErr, ok := err.(*acmeapi.Error); ok {
+			c.setOrderProblem(&o.Status, acmeErr)
 			if acmeErr.StatusCode >= 400 && acmeErr.StatusCode < 500 {
 				log.Error(err, "failed to fetch authorization metadata from acme server")
 				c.setOrderState(&o.Status, string(cmacme.Errored))
@@ -416,9 +468,37 @@ func (c *controller) anyRequiredChallengesDoNotExist(requiredChallenges []*cmacm
 	return false, nil
 }
 
-func (c *controller) createRequiredChallenges(ctx context.Context, o *cmacme.Order, requiredChallenges []*cmacme.Challenge) error {
+func (c *controller) createRequiredChallenges(ctx context.Context, cl acmecl.Interface, o *cmacme.Order, requiredChallenges []*cmacme.Challenge) error {
+	var missingChallenges []*cmacme.Challenge
 	for _, ch := range requiredChallenges {
-		_, err := c.cmClient.AcmeV1().Challenges(ch.Namespace).Create(ctx, ch, metav1.CreateOptions{})
+		_, err := c.challengeLister.Challenges(ch.Namespace).Get(ch.Name)
+		if apierrors.IsNotFound(err) {
+			missingChallenges = append(missingChallenges, ch)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(missingChallenges) > maxChallengesCreatedPerSync {
+		key, err := cache.MetaNamespaceKeyFunc(o)
+		if err != nil {
+			return err
+		}
+		logf.FromContext(ctx).V(logf.DebugLevel).Info("Creating Challenge resources in batches", "missing", len(missingChallenges), "batchSize", maxChallengesCreatedPerSync)
+		// Re-queue the Order to create the next batch of Challenges.
+		c.scheduledWorkQueue.Add(key, RequeuePeriod)
+		missingChallenges = missingChallenges[:maxChallengesCreatedPerSync]
+	}
+
+	missingChallenges, err := ensureKeysForChallenges(cl, missingChallenges)
+	if err != nil {
+		return err
+	}
+
+	for _, ch := range missingChallenges {
+		created, err := c.cmClient.AcmeV1().Challenges(ch.Namespace).Create(ctx, ch, metav1.CreateOptions{})
 		if apierrors.IsAlreadyExists(err) {
 			continue
 		}
@@ -426,6 +506,12 @@ func (c *controller) createRequiredChallenges(ctx context.Context, o *cmacme.Ord
 			return err
 		}
 		c.recorder.Eventf(o, corev1.EventTypeNormal, reasonCreated, "Created Challenge resource %q for domain %q", ch.Name, ch.Spec.DNSName)
+		if explanation, ok := ch.Annotations[cmacme.SolverSelectionAnnotationKey]; ok {
+			c.recorder.Event(created, corev1.EventTypeNormal, reasonSolverSelected, explanation)
+		}
+		if ch.Annotations[cmacme.SolverSelectionAmbiguousAnnotationKey] == "true" {
+			c.recorder.Eventf(created, corev1.EventTypeWarning, reasonSolverAmbiguous, "Multiple solvers matched domain %q equally well, the first one listed was selected", ch.Spec.DNSName)
+		}
 	}
 	return nil
 }
@@ -542,6 +628,7 @@ func (c *controller) finalizeOrder(ctx context.Context, cl acmecl.Interface, o *
 		acmeGetOrderErr, ok := getOrderErr.(*acmeapi.Error)
 		if ok && acmeGetOrderErr.StatusCode >= 400 && acmeGetOrderErr.StatusCode < 500 {
 			log.Error(err, "failed to retrieve the ACME order (4xx error) marking Order as failed")
+			c.setOrderProblem(&o.Status, acmeGetOrderErr)
 			c.setOrderState(&o.Status, string(cmacme.Errored))
 			o.Status.Reason = fmt.Sprintf("Failed to retrieve Order resource: %v", err)
 			return nil
@@ -560,6 +647,7 @@ func (c *controller) finalizeOrder(ctx context.Context, cl acmecl.Interface, o *
 	// Any other ACME 4xx error means that the Order can be considered failed.
 	if ok && acmeErr.StatusCode >= 400 && acmeErr.StatusCode < 500 {
 		log.Error(err, "failed to finalize Order resource due to bad request, marking Order as failed")
+		c.setOrderProblem(&o.Status, acmeErr)
 		c.setOrderState(&o.Status, string(cmacme.Errored))
 		o.Status.Reason = fmt.Sprintf("Failed to finalize Order: %v", err)
 		return nil
@@ -569,6 +657,7 @@ func (c *controller) finalizeOrder(ctx context.Context, cl acmecl.Interface, o *
 	// non-4xx error, ensure the order status is up-to-date.
 	_, errUpdate := c.updateOrderStatus(ctx, cl, o)
 	if acmeErr, ok := errUpdate.(*acmeapi.Error); ok {
+		c.setOrderProblem(&o.Status, acmeErr)
 		if acmeErr.StatusCode >= 400 && acmeErr.StatusCode < 500 {
 			log.Error(err, "failed to update Order status due to a 4xx error, marking Order as failed")
 			c.setOrderState(&o.Status, string(cmacme.Errored))
@@ -581,8 +670,16 @@ func (c *controller) finalizeOrder(ctx context.Context, cl acmecl.Interface, o *
 	}
 	// Check for non-4xx errors from CreateOrderCert
 	if err != nil {
+		if ok {
+			// Record the problem after syncing the order status, as that
+			// would otherwise clear it again.
+			c.setOrderProblem(&o.Status, acmeErr)
+		}
 		return fmt.Errorf("error finalizing order: %v", err)
 	}
+	if certURL != "" {
+		o.Status.CertificateURL = certURL
+	}
 
 	if issuer.GetSpec().ACME != nil && issuer.GetSpec().ACME.PreferredChain != "" {
 		preferredChainName := issuer.GetSpec().ACME.PreferredChain
@@ -627,6 +724,7 @@ func (c *controller) syncCertificateData(ctx context.Context, cl acmecl.Interfac
 	log := logf.FromContext(ctx)
 	acmeOrder, err := c.updateOrderStatus(ctx, cl, o)
 	if acmeErr, ok := err.(*acmeapi.Error); ok {
+		c.setOrderProblem(&o.Status, acmeErr)
 		if acmeErr.StatusCode >= 400 && acmeErr.StatusCode < 500 {
 			log.Error(err, "failed to update Order status due to a 4xx error, marking Order as failed")
 			c.setOrderState(&o.Status, string(cmacme.Errored))
@@ -651,9 +749,13 @@ func (c *controller) syncCertificateDataWithOrder(ctx context.Context, cl acmecl
 	if acmeOrder.Status != acmeapi.StatusValid {
 		return nil
 	}
+	if acmeOrder.CertURL != "" {
+		o.Status.CertificateURL = acmeOrder.CertURL
+	}
 
 	certs, err := cl.FetchCert(ctx, acmeOrder.CertURL, true)
 	if acmeErr, ok := err.(*acmeapi.Error); ok {
+		c.setOrderProblem(&o.Status, acmeErr)
 		if acmeErr.StatusCode >= 400 && acmeErr.StatusCode < 500 {
 			log.Error(err, "failed to retrieve issued certificate from ACME server")
 			c.setOrderState(&o.Status, string(cmacme.Errored))
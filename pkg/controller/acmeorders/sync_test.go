@@ -21,6 +21,7 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"net/http"
 	"testing"
 	"time"
 
@@ -144,6 +145,18 @@ func TestSync(t *testing.T) {
 		StatusCode: 403,
 		Detail:     "some error",
 	}
+	acmeErrorRateLimited := acmeapi.Error{
+		StatusCode:  429,
+		ProblemType: "urn:ietf:params:acme:error:rateLimited",
+		Detail:      "too many certificates",
+		Header:      http.Header{"Retry-After": []string{"3600"}},
+	}
+	acmeErrorServerInternal := acmeapi.Error{
+		StatusCode:  503,
+		ProblemType: "urn:ietf:params:acme:error:serverInternal",
+		Detail:      "service unavailable",
+		Header:      http.Header{"Retry-After": []string{"120"}},
+	}
 
 	// testCert is using the following Let's Encrypt chain (X1 is not included):
 	//   leaf -> R3 -> ISRG Root X1
@@ -274,12 +287,26 @@ Dfvp7OOGAN6dEOM4+qR9sdjoSYKEBpsr6GtPAQw4dy753ec5
 	testOrderValid.Status.State = cmacme.Valid
 	// pem encoded word 'test'
 	testOrderValid.Status.Certificate = []byte(testCert)
+	testOrderValid.Status.CertificateURL = "http://testurl"
 	testOrderReady := testOrderPending.DeepCopy()
 	testOrderReady.Status.State = cmacme.Ready
+	testOrderRateLimited := testOrderPending.DeepCopy()
+	testOrderRateLimited.Status.State = cmacme.Errored
+	testOrderRateLimited.Status.FailureTime = &nowMetaTime
+	testOrderRateLimited.Status.Reason = "Failed to finalize Order: 429 urn:ietf:params:acme:error:rateLimited: too many certificates"
+	testOrderRateLimited.Status.RetryAfter = &metav1.Time{Time: nowTime.Add(time.Hour).Truncate(time.Second)}
+	testOrderRateLimited.Status.LastProblemType = "urn:ietf:params:acme:error:rateLimited"
+	testOrderReadyServerInternal := testOrderReady.DeepCopy()
+	testOrderReadyServerInternal.Status.RetryAfter = &metav1.Time{Time: nowTime.Add(2 * time.Minute).Truncate(time.Second)}
+	testOrderReadyServerInternal.Status.LastProblemType = "urn:ietf:params:acme:error:serverInternal"
+	testOrderPendingServerInternal := testOrderPending.DeepCopy()
+	testOrderPendingServerInternal.Status.RetryAfter = testOrderReadyServerInternal.Status.RetryAfter
+	testOrderPendingServerInternal.Status.LastProblemType = testOrderReadyServerInternal.Status.LastProblemType
 
 	testOrderValidAltCert := gen.OrderFrom(testOrder, gen.SetOrderStatus(pendingStatus))
 	testOrderValidAltCert.Status.State = cmacme.Valid
 	testOrderValidAltCert.Status.Certificate = []byte(testAltCert)
+	testOrderValidAltCert.Status.CertificateURL = "http://testurl"
 
 	fakeHTTP01ACMECl := &acmecl.FakeACME{
 		FakeHTTP01ChallengeResponse: func(s string) (string, error) {
@@ -428,6 +455,7 @@ Dfvp7OOGAN6dEOM4+qR9sdjoSYKEBpsr6GtPAQw4dy753ec5
 				ExpectedEvents: []string{
 					//nolint: dupword
 					`Normal Created Created Challenge resource "testorder-756011405" for domain "test.com"`,
+					`Normal SolverSelected selected solver 0 (dnsNames=1, dnsZones=0, matchLabels=0)`,
 				},
 			},
 			acmeClient: &acmecl.FakeACME{
@@ -674,6 +702,73 @@ Dfvp7OOGAN6dEOM4+qR9sdjoSYKEBpsr6GtPAQw4dy753ec5
 			},
 			expectErr: true,
 		},
+		"call FinalizeOrder and record the ACME problem type and Retry-After if finalize is rate limited": {
+			order: testOrderReady,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrderReady, testAuthorizationChallengeValid},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						"status",
+						testOrderRateLimited.Namespace, testOrderRateLimited)),
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeGetOrder: func(_ context.Context, url string) (*acmeapi.Order, error) {
+					return testACMEOrderReady, nil
+				},
+				FakeCreateOrderCert: func(_ context.Context, url string, csr []byte, bundle bool) ([][]byte, string, error) {
+					return nil, "", &acmeErrorRateLimited
+				},
+				FakeHTTP01ChallengeResponse: func(s string) (string, error) {
+					// TODO: assert s = "token"
+					return "key", nil
+				},
+			},
+		},
+		"call FinalizeOrder, record the ACME problem type and Retry-After and return error if finalize fails with a 5xx ACME error": {
+			order: testOrderReady,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrderReady, testAuthorizationChallengeValid},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						"status",
+						testOrderReadyServerInternal.Namespace, testOrderReadyServerInternal)),
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeGetOrder: func(_ context.Context, url string) (*acmeapi.Order, error) {
+					return testACMEOrderReady, nil
+				},
+				FakeCreateOrderCert: func(_ context.Context, url string, csr []byte, bundle bool) ([][]byte, string, error) {
+					return nil, "", &acmeErrorServerInternal
+				},
+				FakeHTTP01ChallengeResponse: func(s string) (string, error) {
+					// TODO: assert s = "token"
+					return "key", nil
+				},
+			},
+			expectErr: true,
+		},
+		"call GetOrder, update the order state to 'ready' and clear a previously recorded ACME problem": {
+			order: testOrderPendingServerInternal,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrderPendingServerInternal, testAuthorizationChallengeValid},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						"status",
+						testOrderReady.Namespace, testOrderReady)),
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeGetOrder: func(_ context.Context, url string) (*acmeapi.Order, error) {
+					return testACMEOrderReady, nil
+				},
+				FakeHTTP01ChallengeResponse: func(s string) (string, error) {
+					// TODO: assert s = "token"
+					return "key", nil
+				},
+			},
+		},
 		"call FinalizeOrder, recover if finalize fails because order is already finalized": {
 			order: testOrderReady,
 			builder: &testpkg.Builder{
@@ -954,3 +1049,233 @@ func runTest(t *testing.T, test testT) {
 
 	test.builder.CheckAndFinish(err)
 }
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		value  string
+		expD   time.Duration
+		expSet bool
+	}{
+		"no header": {},
+		"delay in seconds": {
+			value:  "120",
+			expD:   2 * time.Minute,
+			expSet: true,
+		},
+		"negative delay": {
+			value: "-1",
+		},
+		"HTTP date": {
+			value:  "Mon, 01 Jan 2024 13:00:00 GMT",
+			expD:   time.Hour,
+			expSet: true,
+		},
+		"HTTP date in the past": {
+			value:  "Mon, 01 Jan 2024 11:00:00 GMT",
+			expSet: true,
+		},
+		"invalid value": {
+			value: "soon",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			d, ok := retryAfter(test.value, now)
+			if d != test.expD || ok != test.expSet {
+				t.Errorf("unexpected result, exp=(%s, %t) got=(%s, %t)", test.expD, test.expSet, d, ok)
+			}
+		})
+	}
+}
+
+func TestSync_CreatesChallengesInBatches(t *testing.T) {
+	testIssuer := gen.Issuer("testissuer", gen.SetIssuerACME(cmacme.ACMEIssuer{
+		Solvers: []cmacme.ACMEChallengeSolver{
+			{
+				HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+					Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{},
+				},
+			},
+		},
+	}))
+
+	// An Order for a Certificate with 150 DNS names.
+	dnsNames := make([]string, 150)
+	authorizations := make([]cmacme.ACMEAuthorization, len(dnsNames))
+	for i := range dnsNames {
+		dnsNames[i] = fmt.Sprintf("test-%03d.example.com", i)
+		authorizations[i] = cmacme.ACMEAuthorization{
+			URL:        fmt.Sprintf("http://authzurl/%d", i),
+			Identifier: dnsNames[i],
+			Challenges: []cmacme.ACMEChallenge{
+				{
+					URL:   fmt.Sprintf("http://chalurl/%d", i),
+					Token: fmt.Sprintf("token-%d", i),
+					Type:  "http-01",
+				},
+			},
+		}
+	}
+	testOrder := gen.Order("testorder",
+		gen.SetOrderIssuer(cmmeta.ObjectReference{Name: testIssuer.Name}),
+		gen.SetOrderDNSNames(dnsNames...),
+		gen.SetOrderStatus(cmacme.OrderStatus{
+			State:          cmacme.Pending,
+			URL:            "http://testurl.com/abcde",
+			FinalizeURL:    "http://testurl.com/abcde/finalize",
+			Authorizations: authorizations,
+		}),
+	)
+
+	testChallenges, err := buildPartialRequiredChallenges(context.TODO(), testIssuer, testOrder)
+	if err != nil {
+		t.Fatalf("error building Challenge resource test fixtures: %v", err)
+	}
+	for _, ch := range testChallenges {
+		ch.Spec.Key = "key"
+	}
+
+	tests := map[string]struct {
+		existingChallenges int
+		createdChallenges  int
+		shouldSchedule     bool
+	}{
+		"should create the first batch of Challenges and re-queue the Order": {
+			existingChallenges: 0,
+			createdChallenges:  maxChallengesCreatedPerSync,
+			shouldSchedule:     true,
+		},
+		"should create the next batch of Challenges and re-queue the Order": {
+			existingChallenges: maxChallengesCreatedPerSync,
+			createdChallenges:  maxChallengesCreatedPerSync,
+			shouldSchedule:     true,
+		},
+		"should create the last Challenges without re-queueing the Order": {
+			existingChallenges: 145,
+			createdChallenges:  5,
+			shouldSchedule:     false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cmObjects := []runtime.Object{testIssuer, testOrder}
+			for _, ch := range testChallenges[:test.existingChallenges] {
+				cmObjects = append(cmObjects, ch)
+			}
+
+			var expectedActions []testpkg.Action
+			var expectedEvents []string
+			for _, ch := range testChallenges[test.existingChallenges : test.existingChallenges+test.createdChallenges] {
+				expectedActions = append(expectedActions, testpkg.NewAction(coretesting.NewCreateAction(cmacme.SchemeGroupVersion.WithResource("challenges"), ch.Namespace, ch)))
+				expectedEvents = append(expectedEvents, fmt.Sprintf("Normal Created Created Challenge resource %q for domain %q", ch.Name, ch.Spec.DNSName))
+				if explanation, ok := ch.Annotations[cmacme.SolverSelectionAnnotationKey]; ok {
+					expectedEvents = append(expectedEvents, "Normal SolverSelected "+explanation)
+				}
+			}
+
+			runTest(t, testT{
+				order: testOrder,
+				builder: &testpkg.Builder{
+					CertManagerObjects: cmObjects,
+					ExpectedActions:    expectedActions,
+					ExpectedEvents:     expectedEvents,
+				},
+				acmeClient: &acmecl.FakeACME{
+					FakeHTTP01ChallengeResponse: func(string) (string, error) {
+						return "key", nil
+					},
+				},
+				shouldSchedule: test.shouldSchedule,
+			})
+		})
+	}
+}
+
+func TestSync_ReusesValidAuthorizations(t *testing.T) {
+	testIssuer := gen.Issuer("testissuer", gen.SetIssuerACME(cmacme.ACMEIssuer{
+		Solvers: []cmacme.ACMEChallengeSolver{
+			{
+				DNS01: &cmacme.ACMEChallengeSolverDNS01{
+					Cloudflare: &cmacme.ACMEIssuerDNS01ProviderCloudflare{},
+				},
+			},
+		},
+	}))
+
+	// An Order renewing a Certificate with 20 DNS names, for which the ACME
+	// server still has valid authorizations for every other DNS name.
+	dnsNames := make([]string, 20)
+	authorizations := make([]cmacme.ACMEAuthorization, len(dnsNames))
+	var pendingAuthorizations []cmacme.ACMEAuthorization
+	for i := range dnsNames {
+		dnsNames[i] = fmt.Sprintf("test-%02d.example.com", i)
+		authorizations[i] = cmacme.ACMEAuthorization{
+			URL:          fmt.Sprintf("http://authzurl/%d", i),
+			Identifier:   dnsNames[i],
+			InitialState: cmacme.Valid,
+			Challenges: []cmacme.ACMEChallenge{
+				{
+					URL:   fmt.Sprintf("http://chalurl/%d", i),
+					Token: fmt.Sprintf("token-%d", i),
+					Type:  "dns-01",
+				},
+			},
+		}
+		if i%2 == 1 {
+			authorizations[i].InitialState = cmacme.Pending
+			pendingAuthorizations = append(pendingAuthorizations, authorizations[i])
+		}
+	}
+	orderStatus := cmacme.OrderStatus{
+		State:          cmacme.Pending,
+		URL:            "http://testurl.com/abcde",
+		FinalizeURL:    "http://testurl.com/abcde/finalize",
+		Authorizations: authorizations,
+	}
+	testOrder := gen.Order("testorder",
+		gen.SetOrderIssuer(cmmeta.ObjectReference{Name: testIssuer.Name}),
+		gen.SetOrderDNSNames(dnsNames...),
+		gen.SetOrderStatus(orderStatus),
+	)
+
+	// The Challenges expected to be created are built from the pending
+	// authorizations only.
+	pendingOrder := testOrder.DeepCopy()
+	pendingOrder.Status.Authorizations = pendingAuthorizations
+	pendingChallenges, err := buildPartialRequiredChallenges(context.TODO(), testIssuer, pendingOrder)
+	if err != nil {
+		t.Fatalf("error building Challenge resource test fixtures: %v", err)
+	}
+	if len(pendingChallenges) != 10 {
+		t.Fatalf("expected a Challenge for each of the 10 pending authorizations, got %d", len(pendingChallenges))
+	}
+
+	var expectedActions []testpkg.Action
+	var expectedEvents []string
+	for _, ch := range pendingChallenges {
+		ch.Spec.Key = "key"
+		expectedActions = append(expectedActions, testpkg.NewAction(coretesting.NewCreateAction(cmacme.SchemeGroupVersion.WithResource("challenges"), ch.Namespace, ch)))
+		expectedEvents = append(expectedEvents, fmt.Sprintf("Normal Created Created Challenge resource %q for domain %q", ch.Name, ch.Spec.DNSName))
+		if explanation, ok := ch.Annotations[cmacme.SolverSelectionAnnotationKey]; ok {
+			expectedEvents = append(expectedEvents, "Normal SolverSelected "+explanation)
+		}
+	}
+
+	runTest(t, testT{
+		order: testOrder,
+		builder: &testpkg.Builder{
+			CertManagerObjects: []runtime.Object{testIssuer, testOrder},
+			ExpectedActions:    expectedActions,
+			ExpectedEvents:     expectedEvents,
+		},
+		acmeClient: &acmecl.FakeACME{
+			FakeDNS01ChallengeRecord: func(string) (string, error) {
+				return "key", nil
+			},
+		},
+	})
+}
@@ -38,15 +38,19 @@ var (
 // buildPartialRequiredChallenges builds partial required ACME challenges by
 // looking at authorization on order spec and related issuer. It does not call
 // ACME. ensureKeysForChallenge must be called before creating the Challenge.
+// Challenges are only built for authorizations which are pending, so that
+// authorizations which are still valid from a previous Order are re-used
+// rather than solved again. Authorizations without an initial state are
+// assumed to be pending.
 func buildPartialRequiredChallenges(ctx context.Context, issuer cmapi.GenericIssuer, o *cmacme.Order) ([]*cmacme.Challenge, error) {
 	chs := make([]*cmacme.Challenge, 0)
 	for _, a := range o.Status.Authorizations {
-		if a.InitialState == cmacme.Valid {
+		if a.InitialState != "" && a.InitialState != cmacme.Pending {
 			wc := false
 			if a.Wildcard != nil {
 				wc = *a.Wildcard
 			}
-			logf.FromContext(ctx).V(logf.DebugLevel).Info("Authorization already valid, not creating Challenge resource", "identifier", a.Identifier, "is_wildcard", wc)
+			logf.FromContext(ctx).V(logf.DebugLevel).Info("Authorization not pending, not creating Challenge resource", "identifier", a.Identifier, "is_wildcard", wc, "state", a.InitialState)
 			continue
 		}
 		ch, err := buildPartialChallenge(ctx, issuer, o, a)
@@ -62,7 +66,7 @@ func buildPartialRequiredChallenges(ctx context.Context, issuer cmapi.GenericIss
 // The spec will be populated with fields that can be determined by looking at
 // the ACME Authorization object returned in Order.
 func buildPartialChallenge(ctx context.Context, issuer cmapi.GenericIssuer, o *cmacme.Order, authz cmacme.ACMEAuthorization) (*cmacme.Challenge, error) {
-	chSpec, err := partialChallengeSpecForAuthorization(ctx, issuer, o, authz)
+	chSpec, selection, err := partialChallengeSpecForAuthorization(ctx, issuer, o, authz)
 	if err != nil {
 		// TODO: in this case, we should probably not return the error as it's
 		//  unlikely we can make it succeed by retrying.
@@ -74,10 +78,18 @@ func buildPartialChallenge(ctx context.Context, issuer cmapi.GenericIssuer, o *c
 		return nil, err
 	}
 
+	annotations := map[string]string{
+		cmacme.SolverSelectionAnnotationKey: selection.Explanation(),
+	}
+	if selection.Ambiguous {
+		annotations[cmacme.SolverSelectionAmbiguousAnnotationKey] = "true"
+	}
+
 	return &cmacme.Challenge{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:            chName,
 			Namespace:       o.Namespace,
+			Annotations:     annotations,
 			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(o, orderGvk)},
 		},
 		Spec: *chSpec,
@@ -86,8 +98,9 @@ func buildPartialChallenge(ctx context.Context, issuer cmapi.GenericIssuer, o *c
 
 // partialChallengeSpecForAuthorization builds a partial challenge spec by
 // looking at the ACME authorization object and issuer. It does not make any
-// ACME calls.
-func partialChallengeSpecForAuthorization(ctx context.Context, issuer cmapi.GenericIssuer, o *cmacme.Order, authz cmacme.ACMEAuthorization) (*cmacme.ChallengeSpec, error) {
+// ACME calls. The returned SolverSelection describes how the solver was
+// chosen.
+func partialChallengeSpecForAuthorization(ctx context.Context, issuer cmapi.GenericIssuer, o *cmacme.Order, authz cmacme.ACMEAuthorization) (*cmacme.ChallengeSpec, *selectors.SolverSelection, error) {
 	log := logf.FromContext(ctx, "challengeSpecForAuthorization")
 	dbg := log.V(logf.DebugLevel)
 
@@ -103,12 +116,6 @@ func partialChallengeSpecForAuthorization(ctx context.Context, issuer cmapi.Gene
 		domainToFind = "*." + domainToFind
 	}
 
-	var selectedSolver *cmacme.ACMEChallengeSolver
-	var selectedChallenge *cmacme.ACMEChallenge
-	selectedNumLabelsMatch := 0
-	selectedNumDNSNamesMatch := 0
-	selectedNumDNSZonesMatch := 0
-
 	challengeForSolver := func(solver *cmacme.ACMEChallengeSolver) *cmacme.ACMEChallenge {
 		for _, ch := range authz.Challenges {
 			switch {
@@ -121,156 +128,30 @@ func partialChallengeSpecForAuthorization(ctx context.Context, issuer cmapi.Gene
 		return nil
 	}
 
-	// 2. filter solvers to only those that matchLabels
-	for _, cfg := range solvers {
-		acmech := challengeForSolver(&cfg) // #nosec G601 -- False positive. See https://github.com/golang/go/discussions/56010
-		if acmech == nil {
-			dbg.Info("cannot use solver as the ACME authorization does not allow solvers of this type")
-			continue
-		}
-
-		if cfg.Selector == nil {
-			if selectedSolver != nil {
-				dbg.Info("not selecting solver as previously selected solver has a just as or more specific selector")
-				continue
-			}
-			dbg.Info("selecting solver due to match all selector and no previously selected solver")
-			selectedSolver = cfg.DeepCopy()
-			selectedChallenge = acmech
-			continue
-		}
-
-		labelsMatch, numLabelsMatch := selectors.Labels(*cfg.Selector).Matches(o.ObjectMeta, domainToFind)
-		dnsNamesMatch, numDNSNamesMatch := selectors.DNSNames(*cfg.Selector).Matches(o.ObjectMeta, domainToFind)
-		dnsZonesMatch, numDNSZonesMatch := selectors.DNSZones(*cfg.Selector).Matches(o.ObjectMeta, domainToFind)
-
-		if !labelsMatch || !dnsNamesMatch || !dnsZonesMatch {
-			dbg.Info("not selecting solver", "labels_match", labelsMatch, "dnsnames_match", dnsNamesMatch, "dnszones_match", dnsZonesMatch)
-			continue
-		}
-
-		dbg.Info("selector matches")
-
-		selectSolver := func() {
-			selectedSolver = cfg.DeepCopy()
-			selectedChallenge = acmech
-			selectedNumLabelsMatch = numLabelsMatch
-			selectedNumDNSNamesMatch = numDNSNamesMatch
-			selectedNumDNSZonesMatch = numDNSZonesMatch
-		}
-
-		if selectedSolver == nil {
-			dbg.Info("selecting solver as there is no previously selected solver")
-			selectSolver()
-			continue
-		}
-
-		dbg.Info("determining whether this match is more significant than last")
-
-		// because we don't count multiple dnsName matches as extra 'weight'
-		// in the selection process, we normalize the numDNSNamesMatch vars
-		// to be either 1 or 0 (i.e. true or false)
-		selectedHasMatchingDNSNames := selectedNumDNSNamesMatch > 0
-		hasMatchingDNSNames := numDNSNamesMatch > 0
-
-		// dnsName selectors have the highest precedence, so check them first
-		switch {
-		case !selectedHasMatchingDNSNames && hasMatchingDNSNames:
-			dbg.Info("selecting solver as this solver has matching DNS names and the previous one does not")
-			selectSolver()
-			continue
-		case selectedHasMatchingDNSNames && !hasMatchingDNSNames:
-			dbg.Info("not selecting solver as the previous one has matching DNS names and this one does not")
-			continue
-		case !selectedHasMatchingDNSNames && !hasMatchingDNSNames:
-			dbg.Info("solver does not have any matching DNS names, checking dnsZones")
-			// check zones
-		case selectedHasMatchingDNSNames && hasMatchingDNSNames:
-			dbg.Info("both this solver and the previously selected one matches dnsNames, comparing zones")
-			if numDNSZonesMatch > selectedNumDNSZonesMatch {
-				dbg.Info("selecting solver as this one has a more specific dnsZone match than the previously selected one")
-				selectSolver()
-				continue
-			}
-			if selectedNumDNSZonesMatch > numDNSZonesMatch {
-				dbg.Info("not selecting this solver as the previously selected one has a more specific dnsZone match")
-				continue
-			}
-			dbg.Info("both this solver and the previously selected one match dnsZones, comparing labels")
-			// choose the one with the most labels
-			if numLabelsMatch > selectedNumLabelsMatch {
-				dbg.Info("selecting solver as this one has more labels than the previously selected one")
-				selectSolver()
-				continue
-			}
-			dbg.Info("not selecting this solver as previous one has either the same number of or more labels")
-			continue
-		}
-
-		selectedHasMatchingDNSZones := selectedNumDNSZonesMatch > 0
-		hasMatchingDNSZones := numDNSZonesMatch > 0
-
-		switch {
-		case !selectedHasMatchingDNSZones && hasMatchingDNSZones:
-			dbg.Info("selecting solver as this solver has matching DNS zones and the previous one does not")
-			selectSolver()
-			continue
-		case selectedHasMatchingDNSZones && !hasMatchingDNSZones:
-			dbg.Info("not selecting solver as the previous one has matching DNS zones and this one does not")
-			continue
-		case !selectedHasMatchingDNSZones && !hasMatchingDNSZones:
-			dbg.Info("solver does not have any matching DNS zones, checking labels")
-			// check labels
-		case selectedHasMatchingDNSZones && hasMatchingDNSZones:
-			dbg.Info("both this solver and the previously selected one matches dnsZones")
-			dbg.Info("comparing number of matching domain segments")
-			// choose the one with the most matching DNS zone segments
-			if numDNSZonesMatch > selectedNumDNSZonesMatch {
-				dbg.Info("selecting solver because this one has more matching DNS zone segments")
-				selectSolver()
-				continue
-			}
-			if selectedNumDNSZonesMatch > numDNSZonesMatch {
-				dbg.Info("not selecting solver because previous one has more matching DNS zone segments")
-				continue
-			}
-			// choose the one with the most labels
-			if numLabelsMatch > selectedNumLabelsMatch {
-				dbg.Info("selecting solver because this one has more labels than the previous one")
-				selectSolver()
-				continue
-			}
-			dbg.Info("not selecting solver as this one's number of matching labels is equal to or less than the last one")
-			continue
-		}
-
-		if numLabelsMatch > selectedNumLabelsMatch {
-			dbg.Info("selecting solver as this one has more labels than the last one")
-			selectSolver()
-			continue
-		}
-
-		dbg.Info("not selecting solver as this one's number of matching labels is equal to or less than the last one (reached end of loop)")
-		// if we get here, the number of matches is less than or equal so we
-		// fallback to choosing the first in the list
-	}
-
-	if selectedSolver == nil || selectedChallenge == nil {
-		return nil, fmt.Errorf("no configured challenge solvers can be used for this challenge")
+	// 2. select the most specific solver, skipping solvers of a type not
+	//    offered by the ACME authorization
+	selection := selectors.SelectSolver(o.ObjectMeta, domainToFind, solvers, func(s *cmacme.ACMEChallengeSolver) bool {
+		return challengeForSolver(s) != nil
+	})
+	dbg.Info("evaluated solvers", "selection", selection.Explanation())
+	if selection.Solver == nil {
+		return nil, selection, fmt.Errorf("no configured challenge solvers can be used for this challenge")
 	}
+	selectedSolver := selection.Solver
+	selectedChallenge := challengeForSolver(selectedSolver)
 
 	// It should never be possible for this case to be hit as earlier in this
 	// method we already assert that the challenge type is one of 'http-01'
 	// or 'dns-01'.
 	chType, err := challengeType(selectedChallenge.Type)
 	if err != nil {
-		return nil, err
+		return nil, selection, err
 	}
 
 	// 4. handle overriding the HTTP01 ingress class and name fields using the
 	//    ACMECertificateHTTP01IngressNameOverride & Class annotations
 	if err := applyIngressParameterAnnotationOverrides(o, selectedSolver); err != nil {
-		return nil, err
+		return nil, selection, err
 	}
 
 	// 5. construct Challenge resource with spec.solver field set
@@ -284,7 +165,7 @@ func partialChallengeSpecForAuthorization(ctx context.Context, issuer cmapi.Gene
 		Solver:    *selectedSolver,
 		Wildcard:  wc,
 		IssuerRef: o.Spec.IssuerRef,
-	}, nil
+	}, selection, nil
 }
 
 func challengeType(t string) (cmacme.ACMEChallengeType, error) {
@@ -1238,7 +1238,7 @@ func TestChallengeSpecForAuthorization(t *testing.T) {
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
 			ctx := context.Background()
-			cs, err := partialChallengeSpecForAuthorization(ctx, test.issuer, test.order, *test.authz)
+			cs, _, err := partialChallengeSpecForAuthorization(ctx, test.issuer, test.order, *test.authz)
 			if err != nil && !test.expectedError {
 				t.Errorf("expected to not get an error, but got: %v", err)
 				t.Fail()
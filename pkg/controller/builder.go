@@ -78,5 +78,8 @@ func (b *Builder) Complete() (Interface, error) {
 		return nil, fmt.Errorf("error registering controller: %v", err)
 	}
 
-	return NewController(b.name, controllerctx.Metrics, b.impl.ProcessItem, mustSync, b.runDurationFuncs, queue), nil
+	ctrl := NewController(b.name, controllerctx.Metrics, b.impl.ProcessItem, mustSync, b.runDurationFuncs, queue).(*controller)
+	ctrl.syncTimeout = controllerctx.SyncTimeout
+	ctrl.progressDeadline = controllerctx.ProgressDeadline
+	return ctrl, nil
 }
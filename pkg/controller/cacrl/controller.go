@@ -0,0 +1,424 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cacrl
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/issuer/ca"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/util/errors"
+	"github.com/cert-manager/cert-manager/pkg/util/kube"
+)
+
+const (
+	ControllerName = "ca-crl"
+)
+
+type controller struct {
+	issuerLister        cmlisters.IssuerLister
+	clusterIssuerLister cmlisters.ClusterIssuerLister
+	secretLister        internalinformers.SecretLister
+	client              kubernetes.Interface
+	cmClient            cmclient.Interface
+	issuerOptions       controllerpkg.IssuerOptions
+
+	queue workqueue.RateLimitingInterface
+	clock clock.Clock
+}
+
+func NewController(log logr.Logger, ctx *controllerpkg.Context) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
+	// create a queue used to queue up items to be processed
+	queue := workqueue.NewNamedRateLimitingQueue(controllerpkg.DefaultItemBasedRateLimiter(), ControllerName)
+
+	// obtain references to all the informers used by this controller
+	issuerInformer := ctx.SharedInformerFactory.Certmanager().V1().Issuers()
+	secretInformer := ctx.KubeSharedInformerFactory.Secrets()
+
+	c := &controller{
+		issuerLister:  issuerInformer.Lister(),
+		secretLister:  secretInformer.Lister(),
+		client:        ctx.Client,
+		cmClient:      ctx.CMClient,
+		issuerOptions: ctx.IssuerOptions,
+		queue:         queue,
+		clock:         ctx.Clock,
+	}
+
+	// Issuers are keyed by namespace and name, and ClusterIssuers by name.
+	issuerInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+	mustSync := []cache.InformerSynced{
+		issuerInformer.Informer().HasSynced,
+		secretInformer.Informer().HasSynced,
+	}
+
+	// ClusterIssuers are not supported if cert-manager is scoped to a single
+	// namespace.
+	if ctx.Namespace == "" {
+		clusterIssuerInformer := ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers()
+		clusterIssuerInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
+		c.clusterIssuerLister = clusterIssuerInformer.Lister()
+	}
+
+	// The CRL is signed again when the signing CA keypair changes, and
+	// restored when its Secret is modified or deleted.
+	secretInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: c.secretChanged(log),
+	})
+
+	return c, queue, mustSync
+}
+
+// secretChanged enqueues the CA issuers whose signing CA keypair or CRL is
+// stored in the given Secret.
+func (c *controller) secretChanged(log logr.Logger) func(obj interface{}) {
+	return func(obj interface{}) {
+		secret, ok := controllerpkg.ToSecret(obj)
+		if !ok {
+			log.Error(nil, "object is not a secret", "object", obj)
+			return
+		}
+
+		log := logf.WithResource(log, secret)
+		var issuers []cmapi.GenericIssuer
+		list, err := c.issuerLister.List(labels.Everything())
+		if err != nil {
+			log.Error(err, "error listing issuers")
+			return
+		}
+		for _, iss := range list {
+			issuers = append(issuers, iss)
+		}
+		if c.clusterIssuerLister != nil {
+			list, err := c.clusterIssuerLister.List(labels.Everything())
+			if err != nil {
+				log.Error(err, "error listing clusterissuers")
+				return
+			}
+			for _, iss := range list {
+				issuers = append(issuers, iss)
+			}
+		}
+
+		for _, iss := range issuers {
+			if !c.usesSecret(iss, secret) {
+				continue
+			}
+			key, err := controllerpkg.KeyFunc(iss)
+			if err != nil {
+				log.Error(err, "error computing key for resource")
+				continue
+			}
+			c.queue.Add(key)
+		}
+	}
+}
+
+// usesSecret returns true if the given issuer generates a CRL, and stores
+// either its signing CA keypair or its CRL in the given Secret.
+func (c *controller) usesSecret(iss cmapi.GenericIssuer, secret *corev1.Secret) bool {
+	spec := iss.GetSpec().CA
+	if spec == nil || spec.CRL == nil {
+		return false
+	}
+
+	resourceNamespace := c.issuerOptions.ResourceNamespace(iss)
+	if secret.Namespace == resourceNamespace && secret.Name == spec.CRL.SecretName {
+		return true
+	}
+
+	namespace, name := resourceNamespace, spec.SecretName
+	if spec.SecretRef != nil {
+		name = spec.SecretRef.Name
+		if spec.SecretRef.Namespace != "" {
+			namespace = spec.SecretRef.Namespace
+		}
+	}
+	return secret.Namespace == namespace && secret.Name == name
+}
+
+// ProcessItem signs the CRL of a CA issuer again if it is missing, outdated,
+// or was not signed by the issuer's current signing CA, and stores it in the
+// configured Secret and ConfigMap. The issuer is requeued for when the CRL is
+// due to be signed again.
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx).WithValues("key", key)
+
+	ctx = logf.NewContext(ctx, log)
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key passed to ProcessItem")
+		return nil
+	}
+
+	var iss cmapi.GenericIssuer
+	if namespace == "" {
+		if c.clusterIssuerLister == nil {
+			return nil
+		}
+		iss, err = c.clusterIssuerLister.Get(name)
+	} else {
+		iss, err = c.issuerLister.Issuers(namespace).Get(name)
+	}
+	if apierrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("issuer not found for key", "error", err.Error())
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	spec := iss.GetSpec().CA
+	if spec == nil || spec.CRL == nil {
+		return nil
+	}
+
+	log = logf.WithResource(log, iss)
+	ctx = logf.NewContext(ctx, log)
+
+	resourceNamespace := c.issuerOptions.ResourceNamespace(iss)
+	secretNamespace, secretName, err := ca.SigningSecret(c.secretLister, iss, resourceNamespace)
+	if err != nil {
+		return ignoreInvalidKeyPair(log, err)
+	}
+	certs, caKey, err := kube.SecretTLSKeyPair(ctx, c.secretLister, secretNamespace, secretName)
+	if err != nil {
+		return ignoreInvalidKeyPair(log, err)
+	}
+
+	resignAt, err := c.sync(ctx, iss, resourceNamespace, certs[0], caKey)
+	if err != nil {
+		return err
+	}
+
+	log.V(logf.DebugLevel).Info("scheduling CRL to be signed again", "at", resignAt)
+	c.queue.AddAfter(key, resignAt.Sub(c.clock.Now()))
+	return nil
+}
+
+// ignoreInvalidKeyPair returns nil if the error is caused by a missing or
+// invalid signing CA keypair: the issuer is requeued once the Secret
+// containing it changes, and reports the error in its Ready condition.
+func ignoreInvalidKeyPair(log logr.Logger, err error) error {
+	if ca.IsNotGranted(err) || apierrors.IsNotFound(err) || errors.IsInvalidData(err) {
+		log.V(logf.DebugLevel).Info("cannot sign CRL without a valid signing CA keypair", "error", err.Error())
+		return nil
+	}
+	return err
+}
+
+// sync signs the CRL of the issuer again if needed, and stores it in the
+// configured Secret and ConfigMap. Revoked certificates which have expired are
+// removed from the status of the issuer and from the CRL. It returns the time
+// at which the CRL is due to be signed again.
+func (c *controller) sync(ctx context.Context, iss cmapi.GenericIssuer, namespace string, caCert *x509.Certificate, caKey crypto.Signer) (time.Time, error) {
+	log := logf.FromContext(ctx)
+	spec := iss.GetSpec().CA.CRL
+	interval := ca.CRLUpdateInterval(spec)
+	now := c.clock.Now()
+
+	var revoked []cmapi.CAIssuerRevokedCertificate
+	if status := iss.GetStatus().CA; status != nil {
+		revoked = ca.UnexpiredRevokedCertificates(status.RevokedCertificates, now)
+		if len(revoked) != len(status.RevokedCertificates) {
+			if err := c.pruneRevokedCertificates(ctx, iss, revoked); err != nil {
+				return time.Time{}, err
+			}
+			log.V(logf.InfoLevel).Info("removed expired certificates from the revoked certificates", "expired", len(status.RevokedCertificates)-len(revoked))
+		}
+	}
+
+	secret, err := c.secretLister.Secrets(namespace).Get(spec.SecretName)
+	if apierrors.IsNotFound(err) {
+		secret = nil
+	} else if err != nil {
+		return time.Time{}, err
+	}
+
+	var crlPEM []byte
+	var crl *x509.RevocationList
+	if secret != nil {
+		crlPEM = secret.Data[ca.CRLKey]
+		// A CRL which cannot be parsed is replaced.
+		if parsed, err := ca.ParseCRL(crlPEM); err == nil {
+			crl = parsed
+		}
+	}
+
+	resignAt := now.Add(interval)
+	if ca.CRLNeedsUpdate(crl, caCert, revoked, now, interval) {
+		crlPEM, err = ca.SignCRL(caCert, caKey, revoked, ca.NextCRLNumber(crl, now), now, interval)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if err := c.updateSecret(ctx, iss, namespace, secret, crlPEM); err != nil {
+			return time.Time{}, err
+		}
+		log.V(logf.InfoLevel).Info("signed CRL", "revokedCertificates", len(revoked))
+	} else {
+		resignAt = crl.ThisUpdate.Add(interval)
+	}
+	// The CRL is signed again without the revoked certificates which expire
+	// before then.
+	if expiry, ok := ca.NextRevokedCertificateExpiry(revoked, now); ok && expiry.Before(resignAt) {
+		resignAt = expiry
+	}
+
+	if spec.ConfigMapName != "" {
+		if err := c.updateConfigMap(ctx, iss, namespace, spec.ConfigMapName, crlPEM); err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	return resignAt, nil
+}
+
+// pruneRevokedCertificates replaces the revoked certificates in the status of
+// the issuer with the given ones. A conflict is returned if the issuer has
+// been updated meanwhile, e.g. because another certificate was revoked, so
+// that it is processed again.
+func (c *controller) pruneRevokedCertificates(ctx context.Context, iss cmapi.GenericIssuer, revoked []cmapi.CAIssuerRevokedCertificate) error {
+	var err error
+	switch iss := iss.(type) {
+	case *cmapi.Issuer:
+		iss = iss.DeepCopy()
+		iss.Status.CA.RevokedCertificates = revoked
+		_, err = c.cmClient.CertmanagerV1().Issuers(iss.Namespace).UpdateStatus(ctx, iss, metav1.UpdateOptions{})
+	case *cmapi.ClusterIssuer:
+		iss = iss.DeepCopy()
+		iss.Status.CA.RevokedCertificates = revoked
+		_, err = c.cmClient.CertmanagerV1().ClusterIssuers().UpdateStatus(ctx, iss, metav1.UpdateOptions{})
+	default:
+		err = fmt.Errorf("unexpected issuer type %T", iss)
+	}
+	return err
+}
+
+// updateSecret stores the CRL in the given Secret, or creates it, owned by
+// the issuer, if it does not exist.
+func (c *controller) updateSecret(ctx context.Context, iss cmapi.GenericIssuer, namespace string, secret *corev1.Secret, crlPEM []byte) error {
+	if secret == nil {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            iss.GetSpec().CA.CRL.SecretName,
+				Namespace:       namespace,
+				Labels:          map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"},
+				OwnerReferences: []metav1.OwnerReference{ownerReference(iss)},
+			},
+			Data: map[string][]byte{ca.CRLKey: crlPEM},
+		}
+		_, err := c.client.CoreV1().Secrets(namespace).Create(ctx, secret, metav1.CreateOptions{})
+		return err
+	}
+
+	secret = secret.DeepCopy()
+	if secret.Labels == nil {
+		secret.Labels = make(map[string]string)
+	}
+	secret.Labels[cmapi.PartOfCertManagerControllerLabelKey] = "true"
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte)
+	}
+	secret.Data[ca.CRLKey] = crlPEM
+	_, err := c.client.CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{})
+	return err
+}
+
+// updateConfigMap stores the CRL in the named ConfigMap if it does not hold it
+// already, or creates it, owned by the issuer, if it does not exist.
+// ConfigMaps are not watched, so the ConfigMap is read from the API server.
+func (c *controller) updateConfigMap(ctx context.Context, iss cmapi.GenericIssuer, namespace, name string, crlPEM []byte) error {
+	configMap, err := c.client.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       namespace,
+				OwnerReferences: []metav1.OwnerReference{ownerReference(iss)},
+			},
+			Data: map[string]string{ca.CRLKey: string(crlPEM)},
+		}
+		_, err := c.client.CoreV1().ConfigMaps(namespace).Create(ctx, configMap, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	if configMap.Data[ca.CRLKey] == string(crlPEM) {
+		return nil
+	}
+	configMap = configMap.DeepCopy()
+	if configMap.Data == nil {
+		configMap.Data = make(map[string]string)
+	}
+	configMap.Data[ca.CRLKey] = string(crlPEM)
+	_, err = c.client.CoreV1().ConfigMaps(namespace).Update(ctx, configMap, metav1.UpdateOptions{})
+	return err
+}
+
+func ownerReference(iss cmapi.GenericIssuer) metav1.OwnerReference {
+	kind := cmapi.IssuerKind
+	if _, ok := iss.(*cmapi.ClusterIssuer); ok {
+		kind = cmapi.ClusterIssuerKind
+	}
+	return *metav1.NewControllerRef(iss, cmapi.SchemeGroupVersion.WithKind(kind))
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+
+	ctrl, queue, mustSync := NewController(log, ctx)
+	c.controller = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controllerWrapper{}).
+			Complete()
+	})
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cacrl
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/issuer/ca"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func mustCASecret(t *testing.T, namespace, name string) *corev1.Secret {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour * 24 * 365),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}),
+			corev1.TLSPrivateKeyKey: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}),
+		},
+	}
+}
+
+func TestCRLIsManaged(t *testing.T) {
+	issuer := gen.Issuer("ca",
+		gen.SetIssuerNamespace("default"),
+		gen.SetIssuerUID("issuer-uid"),
+		gen.SetIssuerCA(cmapi.CAIssuer{
+			SecretName: "ca-keypair",
+			CRL: &cmapi.CAIssuerCRL{
+				SecretName:     "ca-crl",
+				ConfigMapName:  "ca-crl",
+				UpdateInterval: &metav1.Duration{Duration: time.Hour},
+			},
+		}),
+	)
+
+	clock := fakeclock.NewFakeClock(time.Now().Truncate(time.Second))
+	builder := &testpkg.Builder{
+		T:                  t,
+		Clock:              clock,
+		KubeObjects:        []runtime.Object{mustCASecret(t, "default", "ca-keypair")},
+		CertManagerObjects: []runtime.Object{issuer},
+	}
+	builder.Init()
+	w := &controllerWrapper{}
+	if _, _, err := w.Register(builder.Context); err != nil {
+		t.Fatal(err)
+	}
+	builder.Start()
+	defer builder.Stop()
+
+	ctx := context.Background()
+	secrets := builder.Client.CoreV1().Secrets("default")
+
+	// process reconciles the Issuer, and waits for the informer caches to
+	// observe the resulting CRL Secret, returning the CRL.
+	process := func() *x509.RevocationList {
+		t.Helper()
+		if err := w.controller.ProcessItem(ctx, "default/ca"); err != nil {
+			t.Fatal(err)
+		}
+		secret, err := secrets.Get(ctx, "ca-crl", metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := wait.PollUntilContextTimeout(ctx, time.Millisecond*50, time.Second*5, true, func(context.Context) (bool, error) {
+			cached, err := w.controller.secretLister.Secrets("default").Get("ca-crl")
+			return err == nil && string(cached.Data[ca.CRLKey]) == string(secret.Data[ca.CRLKey]), nil
+		}); err != nil {
+			t.Fatalf("CRL Secret was not observed: %v", err)
+		}
+
+		configMap, err := builder.Client.CoreV1().ConfigMaps("default").Get(ctx, "ca-crl", metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if configMap.Data[ca.CRLKey] != string(secret.Data[ca.CRLKey]) {
+			t.Errorf("expected the ConfigMap to hold the same CRL as the Secret")
+		}
+
+		crl, err := ca.ParseCRL(secret.Data[ca.CRLKey])
+		if err != nil {
+			t.Fatal(err)
+		}
+		return crl
+	}
+	// revoke records a revoked certificate in the Issuer's status, and waits
+	// for the informer cache to observe it.
+	revoke := func(serialNumber string, notAfter *metav1.Time) {
+		t.Helper()
+		iss, err := builder.CMClient.CertmanagerV1().Issuers("default").Get(ctx, "ca", metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if iss.Status.CA == nil {
+			iss.Status.CA = &cmapi.CAIssuerStatus{}
+		}
+		iss.Status.CA.RevokedCertificates = append(iss.Status.CA.RevokedCertificates, cmapi.CAIssuerRevokedCertificate{
+			SerialNumber:   serialNumber,
+			RevocationTime: metav1.NewTime(clock.Now()),
+			NotAfter:       notAfter,
+		})
+		if _, err := builder.CMClient.CertmanagerV1().Issuers("default").UpdateStatus(ctx, iss, metav1.UpdateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		if err := wait.PollUntilContextTimeout(ctx, time.Millisecond*50, time.Second*5, true, func(context.Context) (bool, error) {
+			cached, err := w.controller.issuerLister.Issuers("default").Get("ca")
+			return err == nil && cached.Status.CA != nil && len(cached.Status.CA.RevokedCertificates) == len(iss.Status.CA.RevokedCertificates), nil
+		}); err != nil {
+			t.Fatalf("Issuer was not updated: %v", err)
+		}
+	}
+
+	// The CRL is signed straight away, without any revoked certificates.
+	crl := process()
+	if len(crl.RevokedCertificateEntries) != 0 {
+		t.Errorf("expected no revoked certificates, got %d", len(crl.RevokedCertificateEntries))
+	}
+	if !crl.NextUpdate.Equal(clock.Now().Add(2 * time.Hour)) {
+		t.Errorf("expected nextUpdate to be twice the update interval from now, got %v", crl.NextUpdate)
+	}
+	secret, err := secrets.Get(ctx, "ca-crl", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(secret.OwnerReferences) != 1 || secret.OwnerReferences[0].UID != "issuer-uid" {
+		t.Errorf("expected the CRL Secret to be owned by the Issuer, got %v", secret.OwnerReferences)
+	}
+
+	// An up to date CRL is not signed again.
+	clock.Step(time.Minute)
+	if again := process(); !again.ThisUpdate.Equal(crl.ThisUpdate) {
+		t.Errorf("expected the CRL not to be signed again, got thisUpdate %v", again.ThisUpdate)
+	}
+
+	// The CRL is signed again once a certificate has been revoked.
+	revoke("1f:02:9a", nil)
+	crl = process()
+	if len(crl.RevokedCertificateEntries) != 1 || crl.RevokedCertificateEntries[0].SerialNumber.Cmp(big.NewInt(0x1f029a)) != 0 {
+		t.Errorf("expected the revoked certificate to be listed, got %v", crl.RevokedCertificateEntries)
+	}
+	if !crl.ThisUpdate.Equal(clock.Now()) {
+		t.Errorf("expected the CRL to be signed again, got thisUpdate %v", crl.ThisUpdate)
+	}
+
+	// The CRL is signed again once the update interval has elapsed.
+	clock.Step(time.Hour)
+	again := process()
+	if !again.ThisUpdate.Equal(clock.Now()) {
+		t.Errorf("expected the CRL to be signed again after the update interval, got thisUpdate %v", again.ThisUpdate)
+	}
+	if again.Number.Cmp(crl.Number) <= 0 {
+		t.Errorf("expected the CRL number to increase, got %v after %v", again.Number, crl.Number)
+	}
+	if len(again.RevokedCertificateEntries) != 1 {
+		t.Errorf("expected the revoked certificate to remain listed, got %v", again.RevokedCertificateEntries)
+	}
+
+	// A revoked certificate is listed until it expires, and the CRL is
+	// signed again without it as soon as it has.
+	notAfter := metav1.NewTime(clock.Now().Add(30 * time.Minute))
+	revoke("0a", &notAfter)
+	if crl = process(); len(crl.RevokedCertificateEntries) != 2 {
+		t.Errorf("expected both revoked certificates to be listed, got %v", crl.RevokedCertificateEntries)
+	}
+	clock.Step(30 * time.Minute)
+	crl = process()
+	if len(crl.RevokedCertificateEntries) != 1 || crl.RevokedCertificateEntries[0].SerialNumber.Cmp(big.NewInt(0x1f029a)) != 0 {
+		t.Errorf("expected the expired certificate to be removed from the CRL, got %v", crl.RevokedCertificateEntries)
+	}
+	iss, err := builder.CMClient.CertmanagerV1().Issuers("default").Get(ctx, "ca", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if revoked := iss.Status.CA.RevokedCertificates; len(revoked) != 1 || revoked[0].SerialNumber != "1f:02:9a" {
+		t.Errorf("expected the expired certificate to be removed from the Issuer's status, got %v", revoked)
+	}
+}
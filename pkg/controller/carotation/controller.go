@@ -0,0 +1,488 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package carotation
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
+	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
+	"github.com/cert-manager/cert-manager/pkg/issuer/ca"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/util/errors"
+	"github.com/cert-manager/cert-manager/pkg/util/kube"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+const (
+	ControllerName = "ca-rotation"
+
+	// DefaultSpreadDuration is the period over which the Certificates of a
+	// CA issuer are re-issued if the issuer does not configure one.
+	DefaultSpreadDuration = time.Hour * 24
+
+	// FailureBackoff is the period after which a Certificate whose
+	// re-issuance failed is marked for re-issuance again.
+	FailureBackoff = time.Hour
+)
+
+type controller struct {
+	issuerLister        cmlisters.IssuerLister
+	clusterIssuerLister cmlisters.ClusterIssuerLister
+	secretLister        internalinformers.SecretLister
+	certificateIndexer  cache.Indexer
+	client              cmclient.Interface
+	issuerOptions       controllerpkg.IssuerOptions
+
+	queue workqueue.RateLimitingInterface
+	clock clock.Clock
+}
+
+func NewController(log logr.Logger, ctx *controllerpkg.Context) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// create a queue used to queue up items to be processed
+	queue := workqueue.NewNamedRateLimitingQueue(controllerpkg.DefaultItemBasedRateLimiter(), ControllerName)
+
+	// obtain references to all the informers used by this controller
+	issuerInformer := ctx.SharedInformerFactory.Certmanager().V1().Issuers()
+	certificateInformer := ctx.SharedInformerFactory.Certmanager().V1().Certificates()
+	secretInformer := ctx.KubeSharedInformerFactory.Secrets()
+
+	// The Certificates of an issuer are looked up on every sync, so they are
+	// indexed by the issuer they reference.
+	if err := certificates.AddCertificateIssuerIndex(certificateInformer.Informer()); err != nil {
+		return nil, nil, nil, fmt.Errorf("error adding Certificate issuer index: %w", err)
+	}
+
+	c := &controller{
+		issuerLister:       issuerInformer.Lister(),
+		secretLister:       secretInformer.Lister(),
+		certificateIndexer: certificateInformer.Informer().GetIndexer(),
+		client:             ctx.CMClient,
+		issuerOptions:      ctx.IssuerOptions,
+		queue:              queue,
+		clock:              ctx.Clock,
+	}
+
+	// Issuers are keyed by namespace and name, and ClusterIssuers by name.
+	issuerInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+	mustSync := []cache.InformerSynced{
+		issuerInformer.Informer().HasSynced,
+		certificateInformer.Informer().HasSynced,
+		secretInformer.Informer().HasSynced,
+	}
+
+	// ClusterIssuers are not supported if cert-manager is scoped to a single
+	// namespace.
+	if ctx.Namespace == "" {
+		clusterIssuerInformer := ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers()
+		clusterIssuerInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
+		c.clusterIssuerLister = clusterIssuerInformer.Lister()
+	}
+
+	// A rotation is detected when the Secret containing the signing CA
+	// keypair changes, and its progress is recorded as the issuer's
+	// Certificates are re-issued.
+	secretInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: c.secretChanged(log),
+	})
+	certificateInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: c.certificateChanged(log),
+	})
+
+	return c, queue, mustSync, nil
+}
+
+// secretChanged enqueues the CA issuers which re-issue their Certificates on
+// rotation and whose signing CA keypair is stored in the given Secret.
+func (c *controller) secretChanged(log logr.Logger) func(obj interface{}) {
+	return func(obj interface{}) {
+		secret, ok := controllerpkg.ToSecret(obj)
+		if !ok {
+			log.Error(nil, "object is not a secret", "object", obj)
+			return
+		}
+
+		log := logf.WithResource(log, secret)
+		var issuers []cmapi.GenericIssuer
+		list, err := c.issuerLister.List(labels.Everything())
+		if err != nil {
+			log.Error(err, "error listing issuers")
+			return
+		}
+		for _, iss := range list {
+			issuers = append(issuers, iss)
+		}
+		if c.clusterIssuerLister != nil {
+			list, err := c.clusterIssuerLister.List(labels.Everything())
+			if err != nil {
+				log.Error(err, "error listing clusterissuers")
+				return
+			}
+			for _, iss := range list {
+				issuers = append(issuers, iss)
+			}
+		}
+
+		for _, iss := range issuers {
+			spec := iss.GetSpec().CA
+			if spec == nil || !spec.ReissueOnCARotation {
+				continue
+			}
+			namespace, name := c.issuerOptions.ResourceNamespace(iss), spec.SecretName
+			if spec.SecretRef != nil {
+				name = spec.SecretRef.Name
+				if spec.SecretRef.Namespace != "" {
+					namespace = spec.SecretRef.Namespace
+				}
+			}
+			if secret.Namespace != namespace || secret.Name != name {
+				continue
+			}
+			key, err := controllerpkg.KeyFunc(iss)
+			if err != nil {
+				log.Error(err, "error computing key for resource")
+				continue
+			}
+			c.queue.Add(key)
+		}
+	}
+}
+
+// certificateChanged enqueues the cert-manager issuer referenced by the given
+// Certificate.
+func (c *controller) certificateChanged(log logr.Logger) func(obj interface{}) {
+	return func(obj interface{}) {
+		crt, ok := obj.(*cmapi.Certificate)
+		if !ok {
+			if tombstone, isTombstone := obj.(cache.DeletedFinalStateUnknown); isTombstone {
+				crt, ok = tombstone.Obj.(*cmapi.Certificate)
+			}
+		}
+		if !ok {
+			log.Error(nil, "object is not a certificate", "object", obj)
+			return
+		}
+
+		ref := crt.Spec.IssuerRef
+		if ref.Group != "" && ref.Group != certmanager.GroupName {
+			return
+		}
+		switch ref.Kind {
+		case "", cmapi.IssuerKind:
+			c.queue.Add(crt.Namespace + "/" + ref.Name)
+		case cmapi.ClusterIssuerKind:
+			c.queue.Add(ref.Name)
+		}
+	}
+}
+
+// ProcessItem records the fingerprint of the signing CA certificate of a CA
+// issuer which re-issues its Certificates on rotation. When the fingerprint
+// changes, a rotation is started, during which the Certificates issued by the
+// previous CA certificate are marked for re-issuance gradually over the
+// issuer's spread duration. The issuer is requeued for when the next
+// Certificate can be marked.
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx).WithValues("key", key)
+
+	ctx = logf.NewContext(ctx, log)
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key passed to ProcessItem")
+		return nil
+	}
+
+	var iss cmapi.GenericIssuer
+	if namespace == "" {
+		if c.clusterIssuerLister == nil {
+			return nil
+		}
+		iss, err = c.clusterIssuerLister.Get(name)
+	} else {
+		iss, err = c.issuerLister.Issuers(namespace).Get(name)
+	}
+	if apierrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("issuer not found for key", "error", err.Error())
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	spec := iss.GetSpec().CA
+	if spec == nil || !spec.ReissueOnCARotation {
+		return nil
+	}
+
+	log = logf.WithResource(log, iss)
+	ctx = logf.NewContext(ctx, log)
+
+	secretNamespace, secretName, err := ca.SigningSecret(c.secretLister, iss, c.issuerOptions.ResourceNamespace(iss))
+	if err != nil {
+		return ignoreInvalidCertificate(log, err)
+	}
+	caCert, err := kube.SecretTLSCert(ctx, c.secretLister, secretNamespace, secretName)
+	if err != nil {
+		return ignoreInvalidCertificate(log, err)
+	}
+
+	crts, err := certificates.CertificatesForIssuer(c.certificateIndexer, iss)
+	if err != nil {
+		return err
+	}
+
+	status := &cmapi.CAIssuerStatus{}
+	if iss.GetStatus().CA != nil {
+		status = iss.GetStatus().CA.DeepCopy()
+	}
+
+	now := c.clock.Now()
+	fingerprint := pki.FingerprintSHA256(caCert)
+	switch status.SigningCertificateFingerprint {
+	case fingerprint:
+	case "":
+		// The first signing CA certificate observed is not a rotation.
+		status.SigningCertificateFingerprint = fingerprint
+	default:
+		log.V(logf.InfoLevel).Info("signing CA certificate has been rotated, re-issuing certificates")
+		status.SigningCertificateFingerprint = fingerprint
+		status.Rotation = &cmapi.CAIssuerRotationStatus{
+			StartTime: metav1.NewTime(now),
+			Total:     int32(len(outdated(crts, now))),
+		}
+	}
+
+	var next *time.Time
+	if status.Rotation != nil && status.Rotation.CompletionTime == nil {
+		next, err = c.reissue(ctx, status.Rotation, crts, spreadDuration(spec), now)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !apiequality.Semantic.DeepEqual(iss.GetStatus().CA, status) {
+		if err := c.updateIssuerStatus(ctx, iss, status); err != nil {
+			return err
+		}
+	}
+
+	if next != nil {
+		log.V(logf.DebugLevel).Info("scheduling next certificates to be re-issued", "at", *next)
+		c.queue.AddAfter(key, next.Sub(now))
+	}
+	return nil
+}
+
+// reissue marks as many of the Certificates issued before the rotation
+// started for re-issuance as the spread duration allows, and records the
+// progress of the rotation. Certificates whose re-issuance failed are marked
+// again once FailureBackoff has elapsed since the failure. It returns the
+// time at which the next Certificate can be marked, or nil if none is left
+// to be marked.
+func (c *controller) reissue(ctx context.Context, rotation *cmapi.CAIssuerRotationStatus, crts []*cmapi.Certificate, spread time.Duration, now time.Time) (*time.Time, error) {
+	log := logf.FromContext(ctx)
+
+	outdatedCrts := outdated(crts, rotation.StartTime.Time)
+	rotation.Reissued = max(rotation.Total-int32(len(outdatedCrts)), 0)
+	if len(outdatedCrts) == 0 {
+		completionTime := metav1.NewTime(now)
+		rotation.CompletionTime = &completionTime
+		log.V(logf.InfoLevel).Info("all certificates have been re-issued by the rotated signing CA certificate")
+		return nil, nil
+	}
+
+	// Certificates which are being issued need not be marked, and those
+	// whose re-issuance failed recently are marked again once they have
+	// backed off. Both count as triggered.
+	var candidates []*cmapi.Certificate
+	var retryAt *time.Time
+	for _, crt := range outdatedCrts {
+		if apiutil.CertificateHasCondition(crt, cmapi.CertificateCondition{
+			Type:   cmapi.CertificateConditionIssuing,
+			Status: cmmeta.ConditionTrue,
+		}) {
+			continue
+		}
+		if crt.Status.LastFailureTime != nil && crt.Status.LastFailureTime.Time.After(rotation.StartTime.Time) {
+			if at := crt.Status.LastFailureTime.Add(FailureBackoff); at.After(now) {
+				if retryAt == nil || at.Before(*retryAt) {
+					retryAt = &at
+				}
+				continue
+			}
+		}
+		candidates = append(candidates, crt)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Namespace != candidates[j].Namespace {
+			return candidates[i].Namespace < candidates[j].Namespace
+		}
+		return candidates[i].Name < candidates[j].Name
+	})
+
+	// Progress is computed from the Certificates which are still waiting to
+	// be marked, so that Certificates which failed, or which were created or
+	// deleted since the rotation started, are accounted for.
+	rotation.Triggered = max(rotation.Total-int32(len(candidates)), 0)
+	allowed := allowedAt(rotation, spread, now) - rotation.Triggered
+	marked := 0
+	for ; marked < int(allowed) && marked < len(candidates); marked++ {
+		if err := c.markForReissuance(ctx, candidates[marked]); err != nil {
+			return nil, err
+		}
+		rotation.Triggered++
+	}
+
+	next := retryAt
+	if marked < len(candidates) && spread != 0 && rotation.Triggered < rotation.Total {
+		// The next Certificate can be marked once the elapsed fraction of
+		// the spread duration allows one more Certificate than has been
+		// marked.
+		at := rotation.StartTime.Add(time.Duration(math.Ceil(float64(spread) * float64(rotation.Triggered) / float64(rotation.Total))))
+		if next == nil || at.Before(*next) {
+			next = &at
+		}
+	}
+	return next, nil
+}
+
+// markForReissuance sets the Issuing condition of the Certificate, which
+// causes it to be re-issued by the issuer's current signing CA certificate.
+func (c *controller) markForReissuance(ctx context.Context, crt *cmapi.Certificate) error {
+	crt = crt.DeepCopy()
+	apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionIssuing, cmmeta.ConditionTrue,
+		policies.IssuingReasonCARotated, "Re-issuing certificate as the signing CA certificate of its issuer has been rotated")
+	_, err := c.client.CertmanagerV1().Certificates(crt.Namespace).UpdateStatus(ctx, crt, metav1.UpdateOptions{})
+	return err
+}
+
+// updateIssuerStatus records the given CA status of the issuer. The status is
+// written using the resource version of the cached issuer, so that progress
+// computed from an outdated cache is not recorded.
+func (c *controller) updateIssuerStatus(ctx context.Context, iss cmapi.GenericIssuer, status *cmapi.CAIssuerStatus) error {
+	var err error
+	switch iss := iss.(type) {
+	case *cmapi.Issuer:
+		iss = iss.DeepCopy()
+		iss.Status.CA = status
+		_, err = c.client.CertmanagerV1().Issuers(iss.Namespace).UpdateStatus(ctx, iss, metav1.UpdateOptions{})
+	case *cmapi.ClusterIssuer:
+		iss = iss.DeepCopy()
+		iss.Status.CA = status
+		_, err = c.client.CertmanagerV1().ClusterIssuers().UpdateStatus(ctx, iss, metav1.UpdateOptions{})
+	default:
+		err = fmt.Errorf("unexpected issuer type %T", iss)
+	}
+	return err
+}
+
+// outdated returns the Certificates which were last issued before the given
+// time. Certificates which have never been issued are issued by the current
+// signing CA certificate, so are not outdated.
+func outdated(crts []*cmapi.Certificate, before time.Time) []*cmapi.Certificate {
+	var out []*cmapi.Certificate
+	for _, crt := range crts {
+		if crt.Status.NotBefore != nil && crt.Status.NotBefore.Time.Before(before) {
+			out = append(out, crt)
+		}
+	}
+	return out
+}
+
+// allowedAt returns the number of Certificates that may have been marked for
+// re-issuance at the given time: the Certificates are spread evenly over the
+// spread duration, starting with one as soon as the rotation starts.
+func allowedAt(rotation *cmapi.CAIssuerRotationStatus, spread time.Duration, now time.Time) int32 {
+	if spread == 0 {
+		return rotation.Total
+	}
+	elapsed := now.Sub(rotation.StartTime.Time)
+	allowed := int32(float64(elapsed)/float64(spread)*float64(rotation.Total)) + 1
+	if allowed > rotation.Total {
+		return rotation.Total
+	}
+	return allowed
+}
+
+// spreadDuration returns the period over which the Certificates of the CA
+// issuer are re-issued, or DefaultSpreadDuration if none is configured.
+func spreadDuration(spec *cmapi.CAIssuer) time.Duration {
+	if spec.SpreadDuration == nil {
+		return DefaultSpreadDuration
+	}
+	return spec.SpreadDuration.Duration
+}
+
+// ignoreInvalidCertificate returns nil if the error is caused by a missing or
+// invalid signing CA certificate: the issuer is requeued once the Secret
+// containing it changes, and reports the error in its Ready condition.
+func ignoreInvalidCertificate(log logr.Logger, err error) error {
+	if ca.IsNotGranted(err) || apierrors.IsNotFound(err) || errors.IsInvalidData(err) {
+		log.V(logf.DebugLevel).Info("cannot detect rotation without a valid signing CA certificate", "error", err.Error())
+		return nil
+	}
+	return err
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+
+	ctrl, queue, mustSync, err := NewController(log, ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	c.controller = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controllerWrapper{}).
+			Complete()
+	})
+}
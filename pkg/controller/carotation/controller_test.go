@@ -0,0 +1,282 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package carotation
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func mustCASecret(t *testing.T, namespace, name string) *corev1.Secret {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour * 24 * 365),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}),
+			corev1.TLSPrivateKeyKey: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}),
+		},
+	}
+}
+
+func TestCertificatesAreReissuedGradually(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Now().Truncate(time.Second))
+	issuedAt := metav1.NewTime(clock.Now().Add(-time.Hour * 24 * 30))
+
+	issuer := gen.Issuer("ca",
+		gen.SetIssuerNamespace("default"),
+		gen.SetIssuerCA(cmapi.CAIssuer{
+			SecretName:          "ca-keypair",
+			ReissueOnCARotation: true,
+			SpreadDuration:      &metav1.Duration{Duration: time.Hour},
+		}),
+		func(iss cmapi.GenericIssuer) {
+			// The fingerprint of the CA certificate before it was rotated.
+			iss.GetStatus().CA = &cmapi.CAIssuerStatus{SigningCertificateFingerprint: "previous"}
+		},
+	)
+
+	cmObjects := []runtime.Object{issuer}
+	for i := 0; i < 4; i++ {
+		cmObjects = append(cmObjects, gen.Certificate(fmt.Sprintf("crt-%d", i),
+			gen.SetCertificateNamespace("default"),
+			gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca"}),
+			gen.SetCertificateNotBefore(issuedAt),
+		))
+	}
+	cmObjects = append(cmObjects,
+		// Certificates which have not been issued yet are not re-issued.
+		gen.Certificate("not-issued",
+			gen.SetCertificateNamespace("default"),
+			gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca"}),
+		),
+		// Certificates of other issuers are not re-issued.
+		gen.Certificate("other-issuer",
+			gen.SetCertificateNamespace("default"),
+			gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "other"}),
+			gen.SetCertificateNotBefore(issuedAt),
+		),
+	)
+
+	builder := &testpkg.Builder{
+		T:                  t,
+		Clock:              clock,
+		KubeObjects:        []runtime.Object{mustCASecret(t, "default", "ca-keypair")},
+		CertManagerObjects: cmObjects,
+	}
+	builder.Init()
+	w := &controllerWrapper{}
+	if _, _, err := w.Register(builder.Context); err != nil {
+		t.Fatal(err)
+	}
+	builder.Start()
+	defer builder.Stop()
+
+	ctx := context.Background()
+	certificates := builder.CMClient.CertmanagerV1().Certificates("default")
+
+	// waitForCaches waits for the informer caches to observe the current
+	// state of the Issuer and its Certificates.
+	waitForCaches := func() {
+		t.Helper()
+		if err := wait.PollUntilContextTimeout(ctx, time.Millisecond*50, time.Second*5, true, func(context.Context) (bool, error) {
+			iss, err := builder.CMClient.CertmanagerV1().Issuers("default").Get(ctx, "ca", metav1.GetOptions{})
+			if err != nil {
+				return false, err
+			}
+			cached, err := w.controller.issuerLister.Issuers("default").Get("ca")
+			if err != nil || !apiequality.Semantic.DeepEqual(cached.Status, iss.Status) {
+				return false, nil
+			}
+			crts, err := certificates.List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return false, err
+			}
+			for _, crt := range crts.Items {
+				obj, exists, err := w.controller.certificateIndexer.GetByKey(crt.Namespace + "/" + crt.Name)
+				if err != nil || !exists || !apiequality.Semantic.DeepEqual(obj.(*cmapi.Certificate).Status, crt.Status) {
+					return false, nil
+				}
+			}
+			return true, nil
+		}); err != nil {
+			t.Fatalf("informer caches were not synced: %v", err)
+		}
+	}
+	// process reconciles the Issuer, and returns its rotation status.
+	process := func() *cmapi.CAIssuerRotationStatus {
+		t.Helper()
+		waitForCaches()
+		if err := w.controller.ProcessItem(ctx, "default/ca"); err != nil {
+			t.Fatal(err)
+		}
+		iss, err := builder.CMClient.CertmanagerV1().Issuers("default").Get(ctx, "ca", metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if iss.Status.CA == nil || iss.Status.CA.Rotation == nil {
+			t.Fatalf("expected a rotation to be recorded, got %v", iss.Status.CA)
+		}
+		if iss.Status.CA.SigningCertificateFingerprint == "previous" {
+			t.Errorf("expected the fingerprint of the rotated CA certificate to be recorded")
+		}
+		return iss.Status.CA.Rotation
+	}
+	// marked returns the names of the Certificates marked for re-issuance.
+	marked := func() []string {
+		t.Helper()
+		crts, err := certificates.List(ctx, metav1.ListOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var names []string
+		for _, crt := range crts.Items {
+			cond := apiutil.GetCertificateCondition(&crt, cmapi.CertificateConditionIssuing)
+			if cond != nil && cond.Status == cmmeta.ConditionTrue {
+				if cond.Reason != policies.IssuingReasonCARotated {
+					t.Errorf("expected %s to be marked with reason %q, got %q", crt.Name, policies.IssuingReasonCARotated, cond.Reason)
+				}
+				names = append(names, crt.Name)
+			}
+		}
+		return names
+	}
+	// reissue simulates the re-issuance of the named Certificate by the
+	// rotated CA certificate.
+	reissue := func(name string) {
+		t.Helper()
+		crt, err := certificates.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		notBefore := metav1.NewTime(clock.Now())
+		crt.Status.NotBefore = &notBefore
+		apiutil.RemoveCertificateCondition(crt, cmapi.CertificateConditionIssuing)
+		if _, err := certificates.UpdateStatus(ctx, crt, metav1.UpdateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expect := func(rotation *cmapi.CAIssuerRotationStatus, total, triggered, reissued int32, complete bool) {
+		t.Helper()
+		if rotation.Total != total || rotation.Triggered != triggered || rotation.Reissued != reissued {
+			t.Errorf("expected total=%d triggered=%d reissued=%d, got total=%d triggered=%d reissued=%d",
+				total, triggered, reissued, rotation.Total, rotation.Triggered, rotation.Reissued)
+		}
+		if complete != (rotation.CompletionTime != nil) {
+			t.Errorf("expected the rotation to be complete=%t, got completionTime %v", complete, rotation.CompletionTime)
+		}
+	}
+
+	// A single Certificate is marked as soon as the rotation starts.
+	rotation := process()
+	expect(rotation, 4, 1, 0, false)
+	if got := marked(); len(got) != 1 || got[0] != "crt-0" {
+		t.Errorf("expected only crt-0 to be marked, got %v", got)
+	}
+
+	// No further Certificate is marked until its share of the spread
+	// duration has elapsed.
+	clock.Step(time.Minute * 14)
+	expect(process(), 4, 1, 0, false)
+
+	clock.Step(time.Minute)
+	expect(process(), 4, 2, 0, false)
+	reissue("crt-0")
+	expect(process(), 4, 2, 1, false)
+
+	// The remaining Certificates are marked once the spread duration has
+	// elapsed.
+	clock.Step(time.Hour)
+	expect(process(), 4, 4, 1, false)
+	if got := marked(); len(got) != 3 {
+		t.Errorf("expected the remaining 3 certificates to be marked, got %v", got)
+	}
+
+	// A Certificate whose re-issuance failed is marked again once it has
+	// backed off.
+	crt, err := certificates.Get(ctx, "crt-3", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	failedAt := metav1.NewTime(clock.Now())
+	crt.Status.LastFailureTime = &failedAt
+	apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionIssuing, cmmeta.ConditionFalse, "Failed", "The certificate request has failed to complete")
+	if _, err := certificates.UpdateStatus(ctx, crt, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	expect(process(), 4, 4, 1, false)
+	if got := marked(); len(got) != 2 {
+		t.Errorf("expected the failed certificate not to be marked before it has backed off, got %v", got)
+	}
+	clock.Step(FailureBackoff)
+	expect(process(), 4, 4, 1, false)
+	if got := marked(); len(got) != 3 {
+		t.Errorf("expected the failed certificate to be marked again, got %v", got)
+	}
+
+	// The rotation completes once every Certificate has been re-issued.
+	for _, name := range []string{"crt-1", "crt-2", "crt-3"} {
+		reissue(name)
+	}
+	rotation = process()
+	expect(rotation, 4, 4, 4, true)
+	if !rotation.StartTime.Equal(&metav1.Time{Time: clock.Now().Add(-time.Minute*75 - FailureBackoff)}) {
+		t.Errorf("expected the start time of the rotation to be kept, got %v", rotation.StartTime)
+	}
+}
@@ -27,10 +27,12 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
+	gwapi "sigs.k8s.io/gateway-api/apis/v1"
 
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/cert-manager/cert-manager/pkg/util"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
 var (
@@ -240,10 +242,7 @@ func translateAnnotations(crt *cmapi.Certificate, ingLikeAnnotations map[string]
 				return fmt.Errorf("%w %q: invalid private key size for RSA algorithm %q", errInvalidIngressAnnotation, cmapi.PrivateKeySizeAnnotationKey, privateKeySize)
 			}
 		case cmapi.ECDSAKeyAlgorithm:
-			switch size {
-			case 256, 384, 521:
-				// ok
-			default:
+			if !pki.IsSupportedECDSAKeySize(size) {
 				return fmt.Errorf("%w %q: invalid private key size for ECDSA algorithm %q", errInvalidIngressAnnotation, cmapi.PrivateKeySizeAnnotationKey, privateKeySize)
 			}
 		}
@@ -289,3 +288,54 @@ func translateAnnotations(crt *cmapi.Certificate, ingLikeAnnotations map[string]
 
 	return nil
 }
+
+// listenerOverridableAnnotations are the annotations of a Gateway which can
+// be overridden for the Certificates of a single listener.
+var listenerOverridableAnnotations = []string{
+	cmapi.DurationAnnotationKey,
+	cmapi.RenewBeforeAnnotationKey,
+	cmapi.UsagesAnnotationKey,
+	cmapi.RevisionHistoryLimitAnnotationKey,
+	cmapi.PrivateKeyAlgorithmAnnotationKey,
+	cmapi.PrivateKeySizeAnnotationKey,
+}
+
+// listenerAnnotationKey returns the key of the Gateway annotation which
+// overrides the given annotation for a listener. For example, the annotation
+// cert-manager.io/duration is overridden for the listener "https" by:
+//
+//	https.listener.cert-manager.io/duration
+func listenerAnnotationKey(listener gwapi.SectionName, annotation string) string {
+	_, name, _ := strings.Cut(annotation, "/")
+	return fmt.Sprintf("%s.%s/%s", listener, cmapi.GatewayListenerAnnotationDomain, name)
+}
+
+// gatewayAnnotationsForListeners returns the annotations of the Gateway which
+// apply to the Certificate of the given listeners, with the annotations
+// overridden for those listeners taking precedence over the Gateway's. A
+// Certificate is shared by every listener which references its Secret, so
+// the listeners must not override an annotation with different values.
+func gatewayAnnotationsForListeners(gw *gwapi.Gateway, listeners []gwapi.SectionName) (map[string]string, error) {
+	annotations := make(map[string]string, len(gw.Annotations))
+	for k, v := range gw.Annotations {
+		annotations[k] = v
+	}
+
+	for _, key := range listenerOverridableAnnotations {
+		var overriddenBy gwapi.SectionName
+		for _, listener := range listeners {
+			value, found := gw.Annotations[listenerAnnotationKey(listener, key)]
+			if !found {
+				continue
+			}
+			if overriddenBy != "" && annotations[key] != value {
+				return nil, fmt.Errorf("%w %q: listeners %q and %q share a Certificate but override it with different values",
+					errInvalidIngressAnnotation, key, overriddenBy, listener)
+			}
+			annotations[key] = value
+			overriddenBy = listener
+		}
+	}
+
+	return annotations, nil
+}
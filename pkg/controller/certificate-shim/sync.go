@@ -35,11 +35,13 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	gwapi "sigs.k8s.io/gateway-api/apis/v1"
 
 	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	clientset "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
@@ -77,6 +79,7 @@ func SyncFnFor(
 	cmLister cmlisters.CertificateLister,
 	defaults controller.IngressShimOptions,
 	fieldManager string,
+	namespace string,
 ) SyncFn {
 	return func(ctx context.Context, ingLike metav1.Object) error {
 		log := logf.WithResource(log, ingLike)
@@ -115,6 +118,13 @@ func SyncFnFor(
 			return nil
 		}
 
+		// ClusterIssuers are not supported when cert-manager is scoped to a
+		// single namespace.
+		if namespace != "" && issuerKind == cmapi.ClusterIssuerKind && (issuerGroup == "" || issuerGroup == certmanager.GroupName) {
+			rec.Eventf(ingLikeObj, corev1.EventTypeWarning, reasonBadConfig, "ClusterIssuer %q cannot be used as cert-manager is scoped to the namespace %q", issuerName, namespace)
+			return nil
+		}
+
 		err = validateIngressLike(ingLike).ToAggregate()
 		if err != nil {
 			rec.Eventf(ingLikeObj, corev1.EventTypeWarning, reasonBadConfig, err.Error())
@@ -145,11 +155,15 @@ func SyncFnFor(
 						OwnerReferences: crt.OwnerReferences,
 					},
 					Spec: cmapi.CertificateSpec{
-						DNSNames:    crt.Spec.DNSNames,
-						IPAddresses: crt.Spec.IPAddresses,
-						SecretName:  crt.Spec.SecretName,
-						IssuerRef:   crt.Spec.IssuerRef,
-						Usages:      crt.Spec.Usages,
+						DNSNames:             crt.Spec.DNSNames,
+						IPAddresses:          crt.Spec.IPAddresses,
+						SecretName:           crt.Spec.SecretName,
+						IssuerRef:            crt.Spec.IssuerRef,
+						Usages:               crt.Spec.Usages,
+						Duration:             crt.Spec.Duration,
+						RenewBefore:          crt.Spec.RenewBefore,
+						RevisionHistoryLimit: crt.Spec.RevisionHistoryLimit,
+						PrivateKey:           crt.Spec.PrivateKey,
 					},
 				})
 			} else {
@@ -299,6 +313,7 @@ func buildCertificates(
 	issuerName, issuerKind, issuerGroup string,
 ) (newCrts, updateCrts []*cmapi.Certificate, _ error) {
 	tlsHosts := make(map[corev1.ObjectReference][]string)
+	tlsListeners := make(map[corev1.ObjectReference][]gwapi.SectionName)
 	switch ingLike := ingLike.(type) {
 	case *networkingv1.Ingress:
 		for i, tls := range ingLike.Spec.TLS {
@@ -338,6 +353,7 @@ func buildCertificates(
 				// Gateway API hostname explicitly disallows IP addresses, so this
 				// should be OK.
 				tlsHosts[secretRef] = append(tlsHosts[secretRef], string(*l.Hostname))
+				tlsListeners[secretRef] = append(tlsListeners[secretRef], l.Name)
 			}
 		}
 	default:
@@ -397,8 +413,24 @@ func buildCertificates(
 		}
 		setIssuerSpecificConfig(crt, ingLike)
 
-		if err := translateAnnotations(crt, ingLike.GetAnnotations()); err != nil {
-			return nil, nil, err
+		var annotationsErr error
+		annotations := ingLike.GetAnnotations()
+		gateway, isGateway := ingLike.(*gwapi.Gateway)
+		if isGateway {
+			annotations, annotationsErr = gatewayAnnotationsForListeners(gateway, tlsListeners[secretRef])
+		}
+		if annotationsErr == nil {
+			annotationsErr = translateAnnotations(crt, annotations)
+		}
+		if annotationsErr != nil {
+			if !isGateway {
+				return nil, nil, annotationsErr
+			}
+			// An invalid annotation on a Gateway only affects the
+			// Certificates it applies to, which are left as they are until
+			// the annotation is fixed.
+			rec.Eventf(gateway, corev1.EventTypeWarning, reasonBadConfig, "Skipped Certificate %q: %s", crt.Name, annotationsErr)
+			continue
 		}
 
 		// check if a Certificate for this TLS entry already exists, and if it
@@ -515,7 +547,19 @@ func certNeedsUpdate(a, b *cmapi.Certificate) bool {
 		return true
 	}
 
-	if a.Spec.RevisionHistoryLimit != b.Spec.RevisionHistoryLimit {
+	if !ptr.Equal(a.Spec.RevisionHistoryLimit, b.Spec.RevisionHistoryLimit) {
+		return true
+	}
+
+	if !reflect.DeepEqual(a.Spec.Duration, b.Spec.Duration) {
+		return true
+	}
+
+	if !reflect.DeepEqual(a.Spec.RenewBefore, b.Spec.RenewBefore) {
+		return true
+	}
+
+	if !reflect.DeepEqual(a.Spec.Usages, b.Spec.Usages) {
 		return true
 	}
 
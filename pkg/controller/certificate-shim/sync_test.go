@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
@@ -91,8 +92,34 @@ func TestSync(t *testing.T) {
 		ExpectedUpdate      []*cmapi.Certificate
 		ExpectedDelete      []*cmapi.Certificate
 		ExpectedEvents      []string
+		// Namespace is the single namespace cert-manager is scoped to, if any.
+		Namespace string
 	}
 	testIngressShim := []testT{
+		{
+			Name:   "should not create a Certificate for an ingress referencing a ClusterIssuer when scoped to a single namespace",
+			Issuer: acmeClusterIssuer,
+			IngressLike: &networkingv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ingress-name",
+					Namespace: gen.DefaultTestNamespace,
+					Annotations: map[string]string{
+						cmapi.IngressClusterIssuerNameAnnotationKey: "issuer-name",
+					},
+					UID: types.UID("ingress-name"),
+				},
+				Spec: networkingv1.IngressSpec{
+					TLS: []networkingv1.IngressTLS{
+						{
+							Hosts:      []string{"example.com"},
+							SecretName: "example-com-tls",
+						},
+					},
+				},
+			},
+			Namespace:      gen.DefaultTestNamespace,
+			ExpectedEvents: []string{`Warning BadConfig ClusterIssuer "issuer-name" cannot be used as cert-manager is scoped to the namespace "default-unit-test-ns"`},
+		},
 		{
 			Name:   "return a single Certificate for an ingress with a single valid TLS entry and common-name annotation",
 			Issuer: acmeClusterIssuer,
@@ -3073,7 +3100,7 @@ func TestSync(t *testing.T) {
 			},
 		},
 		{
-			Name:   "Failure to translate the Gateway annotations",
+			Name:   "should record an Event and skip the Certificate if the Gateway annotations cannot be translated",
 			Issuer: acmeIssuer,
 			IngressLike: &gwapi.Gateway{
 				ObjectMeta: metav1.ObjectMeta{
@@ -3106,7 +3133,9 @@ func TestSync(t *testing.T) {
 					}},
 				},
 			},
-			Err: true,
+			ExpectedEvents: []string{
+				`Warning BadConfig Skipped Certificate "example-com-tls": invalid ingress annotation "cert-manager.io/renew-before": time: invalid duration "invalid renew before value"`,
+			},
 		},
 		{
 			Name:   "return a single Certificate for a Gateway with a single valid TLS entry with common-name and keyusage annotation",
@@ -3173,6 +3202,234 @@ func TestSync(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name:         "should apply the annotations overridden for a listener to its Certificate only",
+			Issuer:       acmeIssuer,
+			IssuerLister: []runtime.Object{acmeIssuer},
+			IngressLike: &gwapi.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "gateway-name",
+					Namespace: gen.DefaultTestNamespace,
+					Annotations: map[string]string{
+						cmapi.IngressIssuerNameAnnotationKey:                   "issuer-name",
+						cmapi.DurationAnnotationKey:                            "2160h",
+						cmapi.RenewBeforeAnnotationKey:                         "360h",
+						"https.listener.cert-manager.io/duration":              "720h",
+						"https.listener.cert-manager.io/usages":                "digital signature,server auth",
+						"https.listener.cert-manager.io/private-key-algorithm": "ECDSA",
+					},
+					UID: types.UID("gateway-name"),
+				},
+				Spec: gwapi.GatewaySpec{
+					GatewayClassName: "test-gateway",
+					Listeners: []gwapi.Listener{
+						buildGatewayListener("https", "foo.example.com", "foo-example-com-tls"),
+						buildGatewayListener("other", "bar.example.com", "bar-example-com-tls"),
+					},
+				},
+			},
+			DefaultIssuerKind: "Issuer",
+			ExpectedEvents: []string{
+				`Normal CreateCertificate Successfully created Certificate "foo-example-com-tls"`,
+				`Normal CreateCertificate Successfully created Certificate "bar-example-com-tls"`,
+			},
+			ExpectedCreate: []*cmapi.Certificate{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "foo-example-com-tls",
+						Namespace:       gen.DefaultTestNamespace,
+						OwnerReferences: buildGatewayOwnerReferences("gateway-name"),
+					},
+					Spec: cmapi.CertificateSpec{
+						DNSNames:   []string{"foo.example.com"},
+						SecretName: "foo-example-com-tls",
+						IssuerRef: cmmeta.ObjectReference{
+							Name: "issuer-name",
+							Kind: "Issuer",
+						},
+						Duration:    &metav1.Duration{Duration: 720 * time.Hour},
+						RenewBefore: &metav1.Duration{Duration: 360 * time.Hour},
+						Usages:      []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageServerAuth},
+						PrivateKey:  &cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "bar-example-com-tls",
+						Namespace:       gen.DefaultTestNamespace,
+						OwnerReferences: buildGatewayOwnerReferences("gateway-name"),
+					},
+					Spec: cmapi.CertificateSpec{
+						DNSNames:   []string{"bar.example.com"},
+						SecretName: "bar-example-com-tls",
+						IssuerRef: cmmeta.ObjectReference{
+							Name: "issuer-name",
+							Kind: "Issuer",
+						},
+						Duration:    &metav1.Duration{Duration: 2160 * time.Hour},
+						RenewBefore: &metav1.Duration{Duration: 360 * time.Hour},
+						Usages:      cmapi.DefaultKeyUsages(),
+					},
+				},
+			},
+		},
+		{
+			Name:         "should update an existing Certificate if the annotations overridden for its listener change",
+			Issuer:       acmeIssuer,
+			IssuerLister: []runtime.Object{acmeIssuer},
+			IngressLike: &gwapi.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "gateway-name",
+					Namespace: gen.DefaultTestNamespace,
+					Annotations: map[string]string{
+						cmapi.IngressIssuerNameAnnotationKey:                    "issuer-name",
+						cmapi.DurationAnnotationKey:                             "2160h",
+						"https.listener.cert-manager.io/duration":               "720h",
+						"https.listener.cert-manager.io/revision-history-limit": "3",
+					},
+					UID: types.UID("gateway-name"),
+				},
+				Spec: gwapi.GatewaySpec{
+					GatewayClassName: "test-gateway",
+					Listeners: []gwapi.Listener{
+						buildGatewayListener("https", "example.com", "example-com-tls"),
+					},
+				},
+			},
+			DefaultIssuerKind: "Issuer",
+			CertificateLister: []runtime.Object{
+				&cmapi.Certificate{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "example-com-tls",
+						Namespace:       gen.DefaultTestNamespace,
+						OwnerReferences: buildGatewayOwnerReferences("gateway-name"),
+					},
+					Spec: cmapi.CertificateSpec{
+						DNSNames:   []string{"example.com"},
+						SecretName: "example-com-tls",
+						IssuerRef: cmmeta.ObjectReference{
+							Name: "issuer-name",
+							Kind: "Issuer",
+						},
+						Duration: &metav1.Duration{Duration: 2160 * time.Hour},
+						Usages:   cmapi.DefaultKeyUsages(),
+					},
+				},
+			},
+			ExpectedEvents: []string{`Normal UpdateCertificate Successfully updated Certificate "example-com-tls"`},
+			ExpectedUpdate: []*cmapi.Certificate{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "example-com-tls",
+						Namespace:       gen.DefaultTestNamespace,
+						OwnerReferences: buildGatewayOwnerReferences("gateway-name"),
+					},
+					Spec: cmapi.CertificateSpec{
+						DNSNames:   []string{"example.com"},
+						SecretName: "example-com-tls",
+						IssuerRef: cmmeta.ObjectReference{
+							Name: "issuer-name",
+							Kind: "Issuer",
+						},
+						Duration:             &metav1.Duration{Duration: 720 * time.Hour},
+						RevisionHistoryLimit: ptr.To(int32(3)),
+						Usages:               cmapi.DefaultKeyUsages(),
+					},
+				},
+			},
+		},
+		{
+			Name:         "should record an Event and leave the Certificate untouched if listeners sharing it override an annotation with different values",
+			Issuer:       acmeIssuer,
+			IssuerLister: []runtime.Object{acmeIssuer},
+			IngressLike: &gwapi.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "gateway-name",
+					Namespace: gen.DefaultTestNamespace,
+					Annotations: map[string]string{
+						cmapi.IngressIssuerNameAnnotationKey:  "issuer-name",
+						"a.listener.cert-manager.io/duration": "720h",
+						"b.listener.cert-manager.io/duration": "1440h",
+					},
+					UID: types.UID("gateway-name"),
+				},
+				Spec: gwapi.GatewaySpec{
+					GatewayClassName: "test-gateway",
+					Listeners: []gwapi.Listener{
+						buildGatewayListener("a", "example.com", "example-com-tls"),
+						buildGatewayListener("b", "www.example.com", "example-com-tls"),
+					},
+				},
+			},
+			DefaultIssuerKind: "Issuer",
+			CertificateLister: []runtime.Object{
+				&cmapi.Certificate{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "example-com-tls",
+						Namespace:       gen.DefaultTestNamespace,
+						OwnerReferences: buildGatewayOwnerReferences("gateway-name"),
+					},
+					Spec: cmapi.CertificateSpec{
+						DNSNames:   []string{"example.com"},
+						SecretName: "example-com-tls",
+						IssuerRef: cmmeta.ObjectReference{
+							Name: "issuer-name",
+							Kind: "Issuer",
+						},
+						Usages: cmapi.DefaultKeyUsages(),
+					},
+				},
+			},
+			ExpectedEvents: []string{
+				`Warning BadConfig Skipped Certificate "example-com-tls": invalid ingress annotation "cert-manager.io/duration": listeners "a" and "b" share a Certificate but override it with different values`,
+			},
+		},
+		{
+			Name:         "should record an Event for an invalid listener override and still create the Certificates of the other listeners",
+			Issuer:       acmeIssuer,
+			IssuerLister: []runtime.Object{acmeIssuer},
+			IngressLike: &gwapi.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "gateway-name",
+					Namespace: gen.DefaultTestNamespace,
+					Annotations: map[string]string{
+						cmapi.IngressIssuerNameAnnotationKey:          "issuer-name",
+						"https.listener.cert-manager.io/renew-before": "soon",
+					},
+					UID: types.UID("gateway-name"),
+				},
+				Spec: gwapi.GatewaySpec{
+					GatewayClassName: "test-gateway",
+					Listeners: []gwapi.Listener{
+						buildGatewayListener("https", "foo.example.com", "foo-example-com-tls"),
+						buildGatewayListener("other", "bar.example.com", "bar-example-com-tls"),
+					},
+				},
+			},
+			DefaultIssuerKind: "Issuer",
+			ExpectedEvents: []string{
+				`Warning BadConfig Skipped Certificate "foo-example-com-tls": invalid ingress annotation "cert-manager.io/renew-before": time: invalid duration "soon"`,
+				`Normal CreateCertificate Successfully created Certificate "bar-example-com-tls"`,
+			},
+			ExpectedCreate: []*cmapi.Certificate{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "bar-example-com-tls",
+						Namespace:       gen.DefaultTestNamespace,
+						OwnerReferences: buildGatewayOwnerReferences("gateway-name"),
+					},
+					Spec: cmapi.CertificateSpec{
+						DNSNames:   []string{"bar.example.com"},
+						SecretName: "bar-example-com-tls",
+						IssuerRef: cmmeta.ObjectReference{
+							Name: "issuer-name",
+							Kind: "Issuer",
+						},
+						Usages: cmapi.DefaultKeyUsages(),
+					},
+				},
+			},
+		},
 		{
 			Name:                "should not trigger a gateway sync if deleted in foreground",
 			Issuer:              clusterIssuer,
@@ -3222,6 +3479,10 @@ func TestSync(t *testing.T) {
 				CertManagerObjects: allCMObjects,
 				ExpectedActions:    expectedActions,
 				ExpectedEvents:     test.ExpectedEvents,
+				Context: &controllerpkg.Context{
+					RootContext:    context.Background(),
+					ContextOptions: controllerpkg.ContextOptions{Namespace: test.Namespace},
+				},
 			}
 			b.Init()
 			defer b.Stop()
@@ -3230,7 +3491,7 @@ func TestSync(t *testing.T) {
 				DefaultIssuerKind:                 test.DefaultIssuerKind,
 				DefaultIssuerGroup:                test.DefaultIssuerGroup,
 				DefaultAutoCertificateAnnotations: []string{"kubernetes.io/tls-acme"},
-			}, "cert-manager-test")
+			}, "cert-manager-test", test.Namespace)
 			b.Start()
 
 			err := sync(context.Background(), test.IngressLike)
@@ -3398,6 +3659,27 @@ func buildGatewayOwnerReferences(name string) []metav1.OwnerReference {
 	}
 }
 
+// buildGatewayListener returns a valid HTTPS listener which terminates TLS
+// with the given Secret.
+func buildGatewayListener(name, hostname, secretName string) gwapi.Listener {
+	return gwapi.Listener{
+		Name:     gwapi.SectionName(name),
+		Hostname: ptrHostname(hostname),
+		Port:     443,
+		Protocol: gwapi.HTTPSProtocolType,
+		TLS: &gwapi.GatewayTLSConfig{
+			Mode: ptrMode(gwapi.TLSModeTerminate),
+			CertificateRefs: []gwapi.SecretObjectReference{
+				{
+					Group: func() *gwapi.Group { g := gwapi.Group("core"); return &g }(),
+					Kind:  func() *gwapi.Kind { k := gwapi.Kind("Secret"); return &k }(),
+					Name:  gwapi.ObjectName(secretName),
+				},
+			},
+		},
+	}
+}
+
 func ptrHostname(hostname string) *gwapi.Hostname {
 	h := gwapi.Hostname(hostname)
 	return &h
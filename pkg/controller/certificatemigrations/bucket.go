@@ -0,0 +1,190 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificatemigrations
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// tokenBucket paces the migrations of a single CertificateMigration. The
+// bucket holds at most one token, which is refilled once every interval, so
+// that migrations are spread evenly across the hour rather than happening in
+// bursts.
+type tokenBucket struct {
+	uid      types.UID
+	interval time.Duration
+
+	// credit is the time accumulated towards the next token. A token is
+	// available once credit reaches interval, and credit never exceeds it.
+	credit time.Duration
+	last   time.Time
+
+	// failures holds the Certificates which could not be migrated, keyed by
+	// UID, so that they are skipped until they are due to be retried.
+	failures map[types.UID]migrationFailure
+}
+
+// migrationFailure records the failed attempts to migrate a Certificate.
+type migrationFailure struct {
+	count int
+	last  time.Time
+}
+
+const (
+	// migrationRetryInitialBackoff and migrationRetryMaxBackoff bound the
+	// time for which a Certificate that could not be migrated is skipped.
+	// The backoff doubles with every failed attempt.
+	migrationRetryInitialBackoff = time.Minute * 5
+	migrationRetryMaxBackoff     = time.Hour * 6
+)
+
+// retryIn returns the time until the Certificate can be migrated again.
+func (f migrationFailure) retryIn(now time.Time) time.Duration {
+	backoff := migrationRetryInitialBackoff
+	for i := 1; i < f.count && backoff < migrationRetryMaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, migrationRetryMaxBackoff) - now.Sub(f.last)
+}
+
+// take removes a token from the bucket if one is available. If not, it
+// returns the time until the next token becomes available.
+func (b *tokenBucket) take(now time.Time) (bool, time.Duration) {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.credit += elapsed
+	}
+	if b.credit > b.interval {
+		b.credit = b.interval
+	}
+	b.last = now
+
+	if b.credit < b.interval {
+		return false, b.interval - b.credit
+	}
+	b.credit = 0
+	return true, 0
+}
+
+// tokenBuckets holds a tokenBucket for each CertificateMigration, keyed by
+// name.
+type tokenBuckets struct {
+	lock    sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+func newTokenBuckets() *tokenBuckets {
+	return &tokenBuckets{buckets: make(map[string]*tokenBucket)}
+}
+
+// take removes a token from the CertificateMigration's bucket if one is
+// available, or returns the time until the next token becomes available.
+// A new bucket is created when the CertificateMigration is first seen, is
+// re-created, or changes its rate. A new bucket is full, unless the
+// CertificateMigration has already migrated a Certificate, in which case it
+// is refilled from the last migration time so that pacing is kept across
+// restarts.
+func (b *tokenBuckets) take(migration *cmapi.CertificateMigration, now time.Time) (bool, time.Duration) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	// The rate is validated to be at least 1 by the API server.
+	rate := migration.Spec.MaxReissuancesPerHour
+	if rate < 1 {
+		rate = 1
+	}
+	interval := time.Hour / time.Duration(rate)
+	bucket, ok := b.buckets[migration.Name]
+	if !ok || bucket.uid != migration.UID || bucket.interval != interval {
+		var failures map[types.UID]migrationFailure
+		if ok && bucket.uid == migration.UID {
+			// Failures are kept when the rate of the CertificateMigration
+			// changes.
+			failures = bucket.failures
+		}
+		bucket = &tokenBucket{uid: migration.UID, interval: interval, credit: interval, last: now, failures: failures}
+		if last := migration.Status.LastMigrationTime; last != nil {
+			bucket.credit, bucket.last = 0, last.Time
+		}
+		b.buckets[migration.Name] = bucket
+	}
+
+	return bucket.take(now)
+}
+
+// retryIn returns the time until a Certificate that the CertificateMigration
+// failed to migrate can be migrated again, or 0 if it can be migrated now.
+func (b *tokenBuckets) retryIn(migration *cmapi.CertificateMigration, crt *cmapi.Certificate, now time.Time) time.Duration {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	bucket, ok := b.buckets[migration.Name]
+	if !ok || bucket.uid != migration.UID {
+		return 0
+	}
+	failure, ok := bucket.failures[crt.UID]
+	if !ok {
+		return 0
+	}
+	return max(failure.retryIn(now), 0)
+}
+
+// recordFailure records a failed attempt to migrate the Certificate, which is
+// then skipped by the CertificateMigration until it is due to be retried.
+func (b *tokenBuckets) recordFailure(migration *cmapi.CertificateMigration, crt *cmapi.Certificate, now time.Time) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	bucket, ok := b.buckets[migration.Name]
+	if !ok || bucket.uid != migration.UID {
+		return
+	}
+	if bucket.failures == nil {
+		bucket.failures = make(map[types.UID]migrationFailure)
+	}
+	failure := bucket.failures[crt.UID]
+	bucket.failures[crt.UID] = migrationFailure{count: failure.count + 1, last: now}
+}
+
+// forgetFailures drops the failures of the Certificates which are no longer
+// waiting to be migrated by the CertificateMigration.
+func (b *tokenBuckets) forgetFailures(migration *cmapi.CertificateMigration, unmigrated sets.Set[types.UID]) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	bucket, ok := b.buckets[migration.Name]
+	if !ok || bucket.uid != migration.UID {
+		return
+	}
+	for uid := range bucket.failures {
+		if !unmigrated.Has(uid) {
+			delete(bucket.failures, uid)
+		}
+	}
+}
+
+// forget drops the bucket of a CertificateMigration that has been deleted.
+func (b *tokenBuckets) forget(name string) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	delete(b.buckets, name)
+}
@@ -0,0 +1,374 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificatemigrations
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+const (
+	ControllerName = "certificatemigrations"
+)
+
+type controller struct {
+	migrationLister   cmlisters.CertificateMigrationLister
+	certificateLister cmlisters.CertificateLister
+	client            cmclient.Interface
+
+	queue   workqueue.RateLimitingInterface
+	clock   clock.Clock
+	buckets *tokenBuckets
+}
+
+func NewController(log logr.Logger, ctx *controllerpkg.Context) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
+	// create a queue used to queue up items to be processed
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Second*1, time.Second*30), ControllerName)
+
+	// obtain references to all the informers used by this controller
+	migrationInformer := ctx.SharedInformerFactory.Certmanager().V1().CertificateMigrations()
+	certificateInformer := ctx.SharedInformerFactory.Certmanager().V1().Certificates()
+
+	migrationInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+	// Re-count the CertificateMigrations selecting a Certificate whenever it
+	// changes, so that progress is reported as Certificates are re-issued.
+	certificateInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: enqueueMigrationsForCertificate(log, queue, migrationInformer.Lister()),
+	})
+
+	// build a list of InformerSynced functions that will be returned by the Register method.
+	// the controller will only begin processing items once all of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		migrationInformer.Informer().HasSynced,
+		certificateInformer.Informer().HasSynced,
+	}
+
+	return &controller{
+		migrationLister:   migrationInformer.Lister(),
+		certificateLister: certificateInformer.Lister(),
+		client:            ctx.CMClient,
+		queue:             queue,
+		clock:             ctx.Clock,
+		buckets:           newTokenBuckets(),
+	}, queue, mustSync
+}
+
+// ProcessItem migrates the next Certificate selected by a
+// CertificateMigration to the new issuer if the CertificateMigration's rate
+// allows it, and records the progress of the CertificateMigration in its
+// status. The CertificateMigration is requeued for when the next Certificate
+// can be migrated. A Certificate which cannot be updated is skipped, retried
+// with a backoff, and counted as failed in the meantime.
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx).WithValues("key", key)
+
+	ctx = logf.NewContext(ctx, log)
+	_, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key passed to ProcessItem")
+		return nil
+	}
+
+	migration, err := c.migrationLister.Get(name)
+	if apierrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("certificatemigration not found for key", "error", err.Error())
+		c.buckets.forget(name)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	log = logf.WithResource(log, migration)
+	ctx = logf.NewContext(ctx, log)
+
+	crts, err := c.selectCertificates(migration)
+	if err != nil {
+		return err
+	}
+
+	status := migration.Status.DeepCopy()
+	status.ObservedGeneration = migration.Generation
+
+	// Certificates which could not be migrated are skipped until they are due
+	// to be retried, so that a single Certificate cannot hold up the
+	// CertificateMigration.
+	now := c.clock.Now()
+	var unmigrated []*cmapi.Certificate
+	var retry time.Duration
+	unmigratedUIDs := sets.New[types.UID]()
+	for _, crt := range crts {
+		if issuerRefsEqual(crt.Spec.IssuerRef, migration.Spec.IssuerRef) {
+			continue
+		}
+		unmigratedUIDs.Insert(crt.UID)
+		if wait := c.buckets.retryIn(migration, crt, now); wait > 0 {
+			retry = earliest(retry, wait)
+			continue
+		}
+		unmigrated = append(unmigrated, crt)
+	}
+	c.buckets.forgetFailures(migration, unmigratedUIDs)
+
+	// Migrate the next Certificate if the CertificateMigration's rate allows
+	// it, otherwise requeue the CertificateMigration for when it does.
+	if !migration.Spec.Paused {
+		if len(unmigrated) > 0 {
+			ok, wait := c.buckets.take(migration, now)
+			if ok {
+				crt := unmigrated[0]
+				unmigrated = unmigrated[1:]
+				if err := c.migrate(ctx, migration, crt); err != nil {
+					log.Error(err, "failed to migrate certificate to new issuer, skipping it", "certificate", crt.Namespace+"/"+crt.Name)
+					c.buckets.recordFailure(migration, crt, now)
+					retry = earliest(retry, c.buckets.retryIn(migration, crt, now))
+				} else {
+					log.V(logf.InfoLevel).Info("migrated certificate to new issuer", "certificate", crt.Namespace+"/"+crt.Name)
+					status.LastMigrationTime = &metav1.Time{Time: now}
+				}
+				_, wait = c.buckets.take(migration, now)
+			}
+			if len(unmigrated) > 0 {
+				log.V(logf.DebugLevel).Info("rate limit reached, requeueing", "after", wait, "remaining", len(unmigrated))
+				retry = earliest(retry, wait)
+			}
+		}
+		if retry > 0 {
+			c.queue.AddAfter(key, retry)
+		}
+	}
+
+	// Migrated Certificates are counted from the informer cache, which does
+	// not yet reflect a Certificate that was migrated above. It will be
+	// counted when the resulting update event requeues the
+	// CertificateMigration.
+	status.Total, status.Migrated, status.Pending, status.Failed = 0, 0, 0, 0
+	for _, crt := range crts {
+		status.Total++
+		switch {
+		case !issuerRefsEqual(crt.Spec.IssuerRef, migration.Spec.IssuerRef):
+			if c.buckets.retryIn(migration, crt, now) > 0 {
+				status.Failed++
+			} else {
+				status.Pending++
+			}
+		case apiutil.CertificateHasConditionWithObservedGeneration(crt, cmapi.CertificateCondition{
+			Type:               cmapi.CertificateConditionReady,
+			Status:             cmmeta.ConditionTrue,
+			ObservedGeneration: crt.Generation,
+		}) && !apiutil.CertificateHasCondition(crt, cmapi.CertificateCondition{
+			Type:   cmapi.CertificateConditionIssuing,
+			Status: cmmeta.ConditionTrue,
+		}):
+			status.Migrated++
+		case crt.Status.LastFailureTime != nil:
+			status.Failed++
+		default:
+			status.Pending++
+		}
+	}
+
+	if status.Total == status.Migrated {
+		if status.CompletionTime == nil {
+			status.CompletionTime = &metav1.Time{Time: now}
+		}
+	} else {
+		status.CompletionTime = nil
+	}
+
+	if apiequality.Semantic.DeepEqual(&migration.Status, status) {
+		return nil
+	}
+
+	migration = migration.DeepCopy()
+	migration.Status = *status
+	_, err = c.client.CertmanagerV1().CertificateMigrations().UpdateStatus(ctx, migration, metav1.UpdateOptions{})
+	return err
+}
+
+// selectCertificates returns the Certificates selected by the
+// CertificateMigration, sorted by namespace and name so that they are
+// migrated in a predictable order.
+func (c *controller) selectCertificates(migration *cmapi.CertificateMigration) ([]*cmapi.Certificate, error) {
+	selector := labels.Everything()
+	if migration.Spec.Selector != nil {
+		var err error
+		selector, err = metav1.LabelSelectorAsSelector(migration.Spec.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selector: %w", err)
+		}
+	}
+
+	var crts []*cmapi.Certificate
+	if len(migration.Spec.Namespaces) == 0 {
+		var err error
+		crts, err = c.certificateLister.List(selector)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		for _, namespace := range sets.List(sets.New(migration.Spec.Namespaces...)) {
+			namespaced, err := c.certificateLister.Certificates(namespace).List(selector)
+			if err != nil {
+				return nil, err
+			}
+			crts = append(crts, namespaced...)
+		}
+	}
+
+	sort.Slice(crts, func(i, j int) bool {
+		if crts[i].Namespace != crts[j].Namespace {
+			return crts[i].Namespace < crts[j].Namespace
+		}
+		return crts[i].Name < crts[j].Name
+	})
+	return crts, nil
+}
+
+// migrate updates the Certificate's issuerRef to the CertificateMigration's
+// issuer. The Certificate is then re-issued by the new issuer, as its Secret's
+// issuer annotations no longer match.
+func (c *controller) migrate(ctx context.Context, migration *cmapi.CertificateMigration, crt *cmapi.Certificate) error {
+	crt = crt.DeepCopy()
+	crt.Spec.IssuerRef = migration.Spec.IssuerRef
+	if crt.Annotations == nil {
+		crt.Annotations = make(map[string]string)
+	}
+	crt.Annotations[cmapi.CertificateMigrationAnnotationKey] = migration.Name
+	_, err := c.client.CertmanagerV1().Certificates(crt.Namespace).Update(ctx, crt, metav1.UpdateOptions{})
+	return err
+}
+
+// earliest returns the shorter of the two durations, ignoring a zero
+// duration.
+func earliest(a, b time.Duration) time.Duration {
+	if a == 0 || (b > 0 && b < a) {
+		return b
+	}
+	return a
+}
+
+// selects returns true if the CertificateMigration selects the Certificate.
+func selects(migration *cmapi.CertificateMigration, crt *cmapi.Certificate) (bool, error) {
+	if len(migration.Spec.Namespaces) > 0 && !sets.New(migration.Spec.Namespaces...).Has(crt.Namespace) {
+		return false, nil
+	}
+	if migration.Spec.Selector == nil {
+		return true, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(migration.Spec.Selector)
+	if err != nil {
+		return false, err
+	}
+	return selector.Matches(labels.Set(crt.Labels)), nil
+}
+
+// issuerRefsEqual returns true if both references refer to the same issuer,
+// taking the defaults for the kind and group into account.
+func issuerRefsEqual(a, b cmmeta.ObjectReference) bool {
+	defaulted := func(ref cmmeta.ObjectReference) cmmeta.ObjectReference {
+		if ref.Kind == "" {
+			ref.Kind = cmapi.IssuerKind
+		}
+		if ref.Group == "" {
+			ref.Group = certmanager.GroupName
+		}
+		return ref
+	}
+	return defaulted(a) == defaulted(b)
+}
+
+// enqueueMigrationsForCertificate returns a WorkFunc for Certificate
+// informers which enqueues every CertificateMigration selecting the changed
+// Certificate.
+func enqueueMigrationsForCertificate(log logr.Logger, queue workqueue.Interface, lister cmlisters.CertificateMigrationLister) func(obj interface{}) {
+	return func(obj interface{}) {
+		crt, ok := obj.(*cmapi.Certificate)
+		if !ok {
+			log.V(logf.ErrorLevel).Info("Certificate informer returned a non-Certificate object", "object", obj)
+			return
+		}
+
+		migrations, err := lister.List(labels.Everything())
+		if err != nil {
+			log.Error(err, "failed listing CertificateMigration resources")
+			return
+		}
+		for _, migration := range migrations {
+			ok, err := selects(migration, crt)
+			if err != nil {
+				log.Error(err, "invalid selector", "certificatemigration", migration.Name)
+				continue
+			}
+			if !ok {
+				continue
+			}
+			key, err := controllerpkg.KeyFunc(migration)
+			if err != nil {
+				log.Error(err, "error computing key for resource")
+				continue
+			}
+			queue.Add(key)
+		}
+	}
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+
+	ctrl, queue, mustSync := NewController(log, ctx)
+	c.controller = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controllerWrapper{}).
+			Complete()
+	})
+}
@@ -0,0 +1,365 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificatemigrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+var (
+	oldIssuer = cmmeta.ObjectReference{Name: "old-ca", Kind: "ClusterIssuer"}
+	newIssuer = cmmeta.ObjectReference{Name: "new-ca", Kind: "ClusterIssuer", Group: "cert-manager.io"}
+)
+
+func TestMigrationIsPaced(t *testing.T) {
+	// The population is made up of 10 selected Certificates, as well as
+	// Certificates that are not selected because of their labels or
+	// namespace.
+	var objects []runtime.Object
+	for i := 0; i < 10; i++ {
+		objects = append(objects, gen.Certificate(fmt.Sprintf("crt-%02d", i),
+			gen.SetCertificateNamespace("team-a"),
+			gen.AddCertificateLabels(map[string]string{"migrate": "true"}),
+			gen.SetCertificateIssuer(oldIssuer),
+		))
+	}
+	objects = append(objects,
+		gen.Certificate("unlabelled", gen.SetCertificateNamespace("team-a"), gen.SetCertificateIssuer(oldIssuer)),
+		gen.Certificate("other-namespace", gen.SetCertificateNamespace("team-b"),
+			gen.AddCertificateLabels(map[string]string{"migrate": "true"}),
+			gen.SetCertificateIssuer(oldIssuer),
+		),
+		&cmapi.CertificateMigration{
+			ObjectMeta: metav1.ObjectMeta{Name: "to-new-ca", UID: "migration-uid", Generation: 1},
+			Spec: cmapi.CertificateMigrationSpec{
+				Namespaces:            []string{"team-a"},
+				Selector:              &metav1.LabelSelector{MatchLabels: map[string]string{"migrate": "true"}},
+				IssuerRef:             newIssuer,
+				MaxReissuancesPerHour: 6,
+			},
+		},
+	)
+
+	clock := fakeclock.NewFakeClock(time.Now())
+	builder := &testpkg.Builder{
+		T:                  t,
+		Clock:              clock,
+		CertManagerObjects: objects,
+	}
+	builder.Init()
+	w := &controllerWrapper{}
+	if _, _, err := w.Register(builder.Context); err != nil {
+		t.Fatal(err)
+	}
+	builder.Start()
+	defer builder.Stop()
+
+	ctx := context.Background()
+	client := builder.CMClient.CertmanagerV1()
+
+	// process reconciles the CertificateMigration, and waits for the
+	// informer cache to observe the expected number of migrated Certificates.
+	process := func(expectMigrated int) {
+		t.Helper()
+		if err := w.controller.ProcessItem(ctx, "to-new-ca"); err != nil {
+			t.Fatal(err)
+		}
+		var migrated []string
+		if err := wait.PollUntilContextTimeout(ctx, time.Millisecond*50, time.Second*5, true, func(context.Context) (bool, error) {
+			crts, err := w.controller.certificateLister.List(labels.Everything())
+			if err != nil {
+				return false, err
+			}
+			migrated = nil
+			for _, crt := range crts {
+				if crt.Spec.IssuerRef == newIssuer {
+					migrated = append(migrated, crt.Namespace+"/"+crt.Name)
+				}
+			}
+			return len(migrated) == expectMigrated, nil
+		}); err != nil {
+			t.Fatalf("expected %d migrated Certificates, got %v", expectMigrated, migrated)
+		}
+	}
+	// setPaused updates the CertificateMigration, and waits for the informer
+	// cache to observe the change.
+	setPaused := func(paused bool) {
+		t.Helper()
+		migration, err := client.CertificateMigrations().Get(ctx, "to-new-ca", metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		migration.Spec.Paused = paused
+		if _, err := client.CertificateMigrations().Update(ctx, migration, metav1.UpdateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		if err := wait.PollUntilContextTimeout(ctx, time.Millisecond*50, time.Second*5, true, func(context.Context) (bool, error) {
+			migration, err := w.controller.migrationLister.Get("to-new-ca")
+			return err == nil && migration.Spec.Paused == paused, nil
+		}); err != nil {
+			t.Fatalf("CertificateMigration was not updated: %v", err)
+		}
+	}
+
+	// The first Certificate is migrated straight away.
+	process(1)
+	crt, err := client.Certificates("team-a").Get(ctx, "crt-00", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if crt.Spec.IssuerRef != newIssuer {
+		t.Errorf("expected the first Certificate in order to be migrated, got issuerRef %v", crt.Spec.IssuerRef)
+	}
+	if got := crt.Annotations[cmapi.CertificateMigrationAnnotationKey]; got != "to-new-ca" {
+		t.Errorf("expected migrated Certificate to be annotated with the CertificateMigration, got %q", got)
+	}
+
+	// No further Certificates are migrated until a sixth of an hour has
+	// passed.
+	process(1)
+	clock.Step(time.Minute * 9)
+	process(1)
+	clock.Step(time.Minute)
+	process(2)
+	clock.Step(time.Minute * 10)
+	process(3)
+
+	// While paused, no Certificates are migrated no matter how much time
+	// passes.
+	setPaused(true)
+	clock.Step(time.Hour)
+	process(3)
+
+	// Progress is still reported while paused.
+	migration, err := client.CertificateMigrations().Get(ctx, "to-new-ca", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if migration.Status.Total != 10 || migration.Status.Pending != 10 {
+		t.Errorf("expected 10 selected and pending Certificates, got total=%d pending=%d", migration.Status.Total, migration.Status.Pending)
+	}
+
+	// Once resumed, Certificates are migrated at the same pace rather than
+	// catching up on the time spent paused.
+	setPaused(false)
+	process(4)
+	process(4)
+	clock.Step(time.Minute * 10)
+	process(5)
+
+	// Certificates that have been re-issued by the new issuer are reported as
+	// migrated, and those whose re-issuance failed are reported as failed.
+	for name, mod := range map[string]gen.CertificateModifier{
+		"crt-00": gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue}),
+		"crt-01": gen.SetCertificateLastFailureTime(metav1.Time{Time: clock.Now()}),
+	} {
+		crt, err := client.Certificates("team-a").Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := client.Certificates("team-a").UpdateStatus(ctx, gen.CertificateFrom(crt, mod), metav1.UpdateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := wait.PollUntilContextTimeout(ctx, time.Millisecond*50, time.Second*5, true, func(context.Context) (bool, error) {
+		ready, err := w.controller.certificateLister.Certificates("team-a").Get("crt-00")
+		if err != nil {
+			return false, err
+		}
+		failed, err := w.controller.certificateLister.Certificates("team-a").Get("crt-01")
+		if err != nil {
+			return false, err
+		}
+		return len(ready.Status.Conditions) > 0 && failed.Status.LastFailureTime != nil, nil
+	}); err != nil {
+		t.Fatalf("Certificate status was not observed: %v", err)
+	}
+	process(5)
+
+	migration, err = client.CertificateMigrations().Get(ctx, "to-new-ca", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := cmapi.CertificateMigrationStatus{Total: 10, Migrated: 1, Pending: 8, Failed: 1}
+	got := migration.Status
+	if got.Total != expected.Total || got.Migrated != expected.Migrated || got.Pending != expected.Pending || got.Failed != expected.Failed {
+		t.Errorf("expected status counts %+v, got total=%d migrated=%d pending=%d failed=%d",
+			expected, got.Total, got.Migrated, got.Pending, got.Failed)
+	}
+	if got.LastMigrationTime == nil || !got.LastMigrationTime.Time.Equal(clock.Now()) {
+		t.Errorf("expected lastMigrationTime to be %v, got %v", clock.Now(), got.LastMigrationTime)
+	}
+	if got.CompletionTime != nil {
+		t.Errorf("expected completionTime to be unset, got %v", got.CompletionTime)
+	}
+
+	// Certificates outside of the selection are never migrated.
+	for _, key := range []struct{ namespace, name string }{{"team-a", "unlabelled"}, {"team-b", "other-namespace"}} {
+		crt, err := client.Certificates(key.namespace).Get(ctx, key.name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if crt.Spec.IssuerRef != oldIssuer {
+			t.Errorf("expected %s/%s not to be migrated, got issuerRef %v", key.namespace, key.name, crt.Spec.IssuerRef)
+		}
+	}
+}
+
+func TestFailingCertificateIsSkipped(t *testing.T) {
+	var objects []runtime.Object
+	for i := 0; i < 3; i++ {
+		objects = append(objects, gen.Certificate(fmt.Sprintf("crt-%02d", i),
+			gen.SetCertificateNamespace("team-a"),
+			gen.SetCertificateUID(types.UID(fmt.Sprintf("crt-%02d", i))),
+			gen.SetCertificateIssuer(oldIssuer),
+		))
+	}
+	objects = append(objects, &cmapi.CertificateMigration{
+		ObjectMeta: metav1.ObjectMeta{Name: "to-new-ca", UID: "migration-uid", Generation: 1},
+		Spec: cmapi.CertificateMigrationSpec{
+			IssuerRef:             newIssuer,
+			MaxReissuancesPerHour: 60,
+		},
+	})
+
+	clock := fakeclock.NewFakeClock(time.Now())
+	builder := &testpkg.Builder{
+		T:                  t,
+		Clock:              clock,
+		CertManagerObjects: objects,
+	}
+	builder.Init()
+
+	// Updates of the first Certificate are rejected until failing is unset.
+	var failing atomic.Bool
+	failing.Store(true)
+	builder.FakeCMClient().PrependReactor("update", "certificates", func(action coretesting.Action) (bool, runtime.Object, error) {
+		update := action.(coretesting.UpdateAction)
+		if update.GetSubresource() != "" || update.GetObject().(*cmapi.Certificate).Name != "crt-00" || !failing.Load() {
+			return false, nil, nil
+		}
+		return true, nil, errors.New("admission webhook denied the request")
+	})
+
+	w := &controllerWrapper{}
+	if _, _, err := w.Register(builder.Context); err != nil {
+		t.Fatal(err)
+	}
+	builder.Start()
+	defer builder.Stop()
+
+	ctx := context.Background()
+	client := builder.CMClient.CertmanagerV1()
+
+	process := func(expectMigrated []string, expectFailed int32) {
+		t.Helper()
+		if err := w.controller.ProcessItem(ctx, "to-new-ca"); err != nil {
+			t.Fatalf("expected a failing Certificate not to fail the CertificateMigration, got %v", err)
+		}
+		var migrated []string
+		if err := wait.PollUntilContextTimeout(ctx, time.Millisecond*50, time.Second*5, true, func(context.Context) (bool, error) {
+			crts, err := w.controller.certificateLister.List(labels.Everything())
+			if err != nil {
+				return false, err
+			}
+			migrated = nil
+			for _, crt := range crts {
+				if crt.Spec.IssuerRef == newIssuer {
+					migrated = append(migrated, crt.Name)
+				}
+			}
+			sort.Strings(migrated)
+			return len(migrated) == len(expectMigrated), nil
+		}); err != nil || !reflect.DeepEqual(migrated, expectMigrated) {
+			t.Fatalf("expected migrated Certificates %v, got %v", expectMigrated, migrated)
+		}
+		migration, err := client.CertificateMigrations().Get(ctx, "to-new-ca", metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if migration.Status.Failed != expectFailed {
+			t.Errorf("expected %d failed Certificates, got %d", expectFailed, migration.Status.Failed)
+		}
+	}
+
+	// The first Certificate cannot be updated, and is counted as failed.
+	process(nil, 1)
+
+	// It does not hold up the other Certificates, which are migrated at the
+	// usual pace.
+	clock.Step(time.Minute)
+	process([]string{"crt-01"}, 1)
+	clock.Step(time.Minute)
+	process([]string{"crt-01", "crt-02"}, 1)
+
+	// It is retried once its backoff has passed, and fails again.
+	clock.Step(time.Minute * 3)
+	process([]string{"crt-01", "crt-02"}, 1)
+
+	// The backoff doubles with every failure.
+	failing.Store(false)
+	clock.Step(time.Minute * 5)
+	process([]string{"crt-01", "crt-02"}, 1)
+	clock.Step(time.Minute * 5)
+	process([]string{"crt-00", "crt-01", "crt-02"}, 0)
+}
+
+func TestTokenBucketsKeepPacingAcrossRestarts(t *testing.T) {
+	now := time.Now()
+	migration := &cmapi.CertificateMigration{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", UID: "test"},
+		Spec:       cmapi.CertificateMigrationSpec{MaxReissuancesPerHour: 4},
+		Status:     cmapi.CertificateMigrationStatus{LastMigrationTime: &metav1.Time{Time: now.Add(-time.Minute * 5)}},
+	}
+
+	buckets := newTokenBuckets()
+	ok, wait := buckets.take(migration, now)
+	if ok {
+		t.Fatal("expected no token to be available 5 minutes after the last migration")
+	}
+	if wait != time.Minute*10 {
+		t.Errorf("expected to wait 10 minutes, got %v", wait)
+	}
+
+	// A re-created CertificateMigration starts with a full bucket.
+	recreated := migration.DeepCopy()
+	recreated.UID = "recreated"
+	recreated.Status = cmapi.CertificateMigrationStatus{}
+	if ok, _ := buckets.take(recreated, now); !ok {
+		t.Error("expected a token to be available for a re-created CertificateMigration")
+	}
+}
@@ -19,6 +19,7 @@ package acme
 import (
 	"context"
 	"crypto/x509"
+	"errors"
 	"fmt"
 	"slices"
 
@@ -39,6 +40,7 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/controller/certificaterequests"
 	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
 	issuerpkg "github.com/cert-manager/cert-manager/pkg/issuer"
+	dnsutil "github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
@@ -63,6 +65,13 @@ type ACME struct {
 
 	// fieldManager is the manager name used for Create and Apply operations.
 	fieldManager string
+
+	// dns01Nameservers are used to look up the zones of DNS names when
+	// checking them against an issuer's allowed zones.
+	dns01Nameservers []string
+	// findZoneByFqdn looks up the zone of a DNS name. It is a field so that it
+	// can be faked in tests.
+	findZoneByFqdn func(ctx context.Context, fqdn string, nameservers []string) (string, error)
 }
 
 func init() {
@@ -103,6 +112,9 @@ func NewACME(ctx *controllerpkg.Context) certificaterequests.Issuer {
 		acmeClientV:   ctx.CMClient.AcmeV1(),
 		reporter:      crutil.NewReporter(ctx.Clock, ctx.Recorder),
 		fieldManager:  ctx.FieldManager,
+
+		dns01Nameservers: ctx.ACMEOptions.DNS01Nameservers,
+		findZoneByFqdn:   dnsutil.FindZoneByFqdn,
 	}
 }
 
@@ -151,6 +163,44 @@ func (a *ACME) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuer cm
 
 	order, err := a.orderLister.Orders(expectedOrder.Namespace).Get(expectedOrder.Name)
 	if k8sErrors.IsNotFound(err) {
+		// Refuse to create an Order for DNS names outside of the issuer's
+		// allowed zones, as its challenges could never succeed.
+		if allowedZones := issuer.GetSpec().ACME.AllowedZones; len(allowedZones) > 0 && cr.Annotations[cmacme.SkipAllowedZonesCheckAnnotationKey] != "true" {
+			err := a.checkAllowedZones(ctx, expectedOrder.Spec.DNSNames, allowedZones)
+			var notAllowedErr *errDomainNotAllowed
+			switch {
+			case errors.As(err, &notAllowedErr):
+				message := "Refusing to create an Order for a DNS name outside of the issuer's allowed zones"
+
+				a.reporter.Failed(cr, err, "DomainNotAllowed", message)
+				log.V(logf.DebugLevel).Info(fmt.Sprintf("%s: %s", message, err))
+
+				return nil, nil
+			case err != nil:
+				message := "Failed to check DNS names against the issuer's allowed zones"
+
+				a.reporter.Pending(cr, err, "AllowedZonesCheckError", message)
+				log.Error(err, message)
+
+				return nil, err
+			}
+		}
+
+		// Refuse to create an Order for DNS names which none of the issuer's
+		// solvers can be selected for, as their challenges could never be
+		// created. Issuers without solvers rely on authorizations which are
+		// already valid, so are not checked.
+		if solvers := issuer.GetSpec().ACME.Solvers; len(solvers) > 0 {
+			if err := checkSolversCoverDNSNames(expectedOrder.ObjectMeta, expectedOrder.Spec.DNSNames, solvers); err != nil {
+				message := "Refusing to create an Order for DNS names which none of the issuer's solvers match"
+
+				a.reporter.Failed(cr, err, "NoMatchingSolver", message)
+				log.V(logf.DebugLevel).Info(fmt.Sprintf("%s: %s", message, err))
+
+				return nil, nil
+			}
+		}
+
 		// Failing to create the order here is most likely network related.
 		// We should backoff and keep trying.
 		_, err = a.acmeClientV.Orders(expectedOrder.Namespace).Create(ctx, expectedOrder, metav1.CreateOptions{FieldManager: a.fieldManager})
@@ -191,6 +241,13 @@ func (a *ACME) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuer cm
 
 	log = logf.WithRelatedResource(log, order)
 
+	// Record the URL of the ACME order so that it can be given to the CA when
+	// debugging issuance. The annotation is persisted by the calling
+	// controller.
+	if order.Status.URL != "" {
+		metav1.SetMetaDataAnnotation(&cr.ObjectMeta, cmacme.OrderURLAnnotationKey, order.Status.URL)
+	}
+
 	// If the acme order has failed then so too does the CertificateRequest meet the same fate.
 	if acme.IsFailureState(order.Status.State) {
 		message := fmt.Sprintf("Failed to wait for order resource %q to become ready", expectedOrder.Name)
@@ -22,6 +22,7 @@ import (
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"errors"
+	"fmt"
 	"math/big"
 	"reflect"
 	"testing"
@@ -199,6 +200,50 @@ func TestSign(t *testing.T) {
 		t.Fatalf("failed to build order during testing: %s", err)
 	}
 
+	allowedZonesIssuer := gen.IssuerFrom(baseIssuer,
+		gen.SetIssuerACME(cmacme.ACMEIssuer{AllowedZones: []string{"example.com", "f*.com"}}),
+	)
+	notAllowedZonesIssuer := gen.IssuerFrom(baseIssuer,
+		gen.SetIssuerACME(cmacme.ACMEIssuer{AllowedZones: []string{"example.com"}}),
+	)
+	partialSolversIssuer := gen.IssuerFrom(baseIssuer,
+		gen.SetIssuerACME(cmacme.ACMEIssuer{Solvers: []cmacme.ACMEChallengeSolver{{
+			Selector: &cmacme.CertificateDNSNameSelector{DNSZones: []string{"example.com"}},
+			DNS01:    &cmacme.ACMEChallengeSolverDNS01{},
+		}}}),
+	)
+	skipAllowedZonesCR := gen.CertificateRequestFrom(baseCR,
+		gen.AddCertificateRequestAnnotations(map[string]string{cmacme.SkipAllowedZonesCheckAnnotationKey: "true"}),
+	)
+	skipAllowedZonesOrder, err := buildOrder(skipAllowedZonesCR, csr, false)
+	if err != nil {
+		t.Fatalf("failed to build order during testing: %s", err)
+	}
+	wildcardCSRPEM := generateCSR(t, sk, "", "*.example.com")
+	wildcardCSR, err := pki.DecodeX509CertificateRequestBytes(wildcardCSRPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wildcardCR := gen.CertificateRequestFrom(baseCR, gen.SetCertificateRequestCSR(wildcardCSRPEM))
+	wildcardOrder, err := buildOrder(wildcardCR, wildcardCSR, false)
+	if err != nil {
+		t.Fatalf("failed to build order during testing: %s", err)
+	}
+	// fakeZones is a fake resolver which knows the zones of the DNS names
+	// used in these tests.
+	fakeZones := func(_ context.Context, fqdn string, _ []string) (string, error) {
+		switch fqdn {
+		case "example.com.":
+			return "example.com.", nil
+		case "foo.com.":
+			return "foo.com.", nil
+		}
+		return "", fmt.Errorf("unexpected lookup of %q", fqdn)
+	}
+	failingZones := func(_ context.Context, _ string, _ []string) (string, error) {
+		return "", errors.New("simulated lookup failure")
+	}
+
 	tests := map[string]testT{
 		"a CertificateRequest without an approved condition should do nothing": {
 			certificateRequest: baseCRNotApproved.DeepCopy(),
@@ -206,7 +251,7 @@ func TestSign(t *testing.T) {
 				KubeObjects:        []runtime.Object{},
 				CertManagerObjects: []runtime.Object{baseCRNotApproved.DeepCopy(), baseIssuer.DeepCopy()},
 				ExpectedEvents: []string{
-					"Normal WaitingForApproval Not signing CertificateRequest until it is Approved",
+					"Normal WaitingForApproval Not signing CertificateRequest until it is Approved (origin: direct)",
 				},
 			},
 		},
@@ -392,6 +437,185 @@ func TestSign(t *testing.T) {
 			},
 		},
 
+		"if all DNS names are within the issuer's allowed zones then create an order": {
+			certificateRequest: baseCR.DeepCopy(),
+			findZoneByFqdn:     fakeZones,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), allowedZonesIssuer},
+				ExpectedEvents: []string{
+					"Normal OrderCreated Created Order resource default-unit-test-ns/test-cr-1733622556",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewCreateAction(
+						cmacme.SchemeGroupVersion.WithResource("orders"),
+						gen.DefaultTestNamespace,
+						baseOrder,
+					)),
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            "Created Order resource default-unit-test-ns/test-cr-1733622556",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+						),
+					)),
+				},
+			},
+		},
+
+		"if a DNS name is outside of the issuer's allowed zones then fail with DomainNotAllowed": {
+			certificateRequest: baseCR.DeepCopy(),
+			findZoneByFqdn:     fakeZones,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), notAllowedZonesIssuer},
+				ExpectedEvents: []string{
+					`Warning DomainNotAllowed Refusing to create an Order for a DNS name outside of the issuer's allowed zones: zone "foo.com" of DNS name "foo.com" does not match any of the allowed zones [example.com]`,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonFailed,
+								Message:            `Refusing to create an Order for a DNS name outside of the issuer's allowed zones: zone "foo.com" of DNS name "foo.com" does not match any of the allowed zones [example.com]`,
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+						),
+					)),
+				},
+			},
+		},
+
+		"if no solver of the issuer matches a DNS name then fail with NoMatchingSolver": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), partialSolversIssuer},
+				ExpectedEvents: []string{
+					`Warning NoMatchingSolver Refusing to create an Order for DNS names which none of the issuer's solvers match: no configured challenge solver matches the DNS names "foo.com" ("foo.com": solver 0: does not match dnsZones)`,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonFailed,
+								Message:            `Refusing to create an Order for DNS names which none of the issuer's solvers match: no configured challenge solver matches the DNS names "foo.com" ("foo.com": solver 0: does not match dnsZones)`,
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+						),
+					)),
+				},
+			},
+		},
+
+		"if the allowed zones check is skipped by annotation then create an order": {
+			certificateRequest: skipAllowedZonesCR.DeepCopy(),
+			findZoneByFqdn:     failingZones,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{skipAllowedZonesCR.DeepCopy(), notAllowedZonesIssuer},
+				ExpectedEvents: []string{
+					"Normal OrderCreated Created Order resource default-unit-test-ns/test-cr-1733622556",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewCreateAction(
+						cmacme.SchemeGroupVersion.WithResource("orders"),
+						gen.DefaultTestNamespace,
+						skipAllowedZonesOrder,
+					)),
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(skipAllowedZonesCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            "Created Order resource default-unit-test-ns/test-cr-1733622556",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+						),
+					)),
+				},
+			},
+		},
+
+		"wildcard DNS names should be checked against their base domain": {
+			certificateRequest: wildcardCR.DeepCopy(),
+			findZoneByFqdn:     fakeZones,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{wildcardCR.DeepCopy(), notAllowedZonesIssuer},
+				ExpectedEvents: []string{
+					fmt.Sprintf("Normal OrderCreated Created Order resource default-unit-test-ns/%s", wildcardOrder.Name),
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewCreateAction(
+						cmacme.SchemeGroupVersion.WithResource("orders"),
+						gen.DefaultTestNamespace,
+						wildcardOrder,
+					)),
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(wildcardCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            fmt.Sprintf("Created Order resource default-unit-test-ns/%s", wildcardOrder.Name),
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+						),
+					)),
+				},
+			},
+		},
+
+		"if the zone of a DNS name cannot be looked up then report pending and return an error to retry": {
+			certificateRequest: baseCR.DeepCopy(),
+			findZoneByFqdn:     failingZones,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), notAllowedZonesIssuer},
+				ExpectedEvents: []string{
+					`Normal AllowedZonesCheckError Failed to check DNS names against the issuer's allowed zones: failed to find zone of DNS name "example.com": simulated lookup failure`,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            `Failed to check DNS names against the issuer's allowed zones: failed to find zone of DNS name "example.com": simulated lookup failure`,
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+						),
+					)),
+				},
+			},
+			expectedErr: true,
+		},
+
 		"should exit nil and set status pending if referenced issuer is not ready": {
 			certificateRequest: baseCR.DeepCopy(),
 			builder: &testpkg.Builder{
@@ -519,6 +743,38 @@ func TestSign(t *testing.T) {
 			},
 		},
 
+		"if the order has a URL then it should be recorded as an annotation": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				ExpectedEvents: []string{
+					`Normal OrderPending Waiting on certificate issuance from order default-unit-test-ns/test-cr-1733622556: "pending"`,
+				},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy(),
+					gen.OrderFrom(baseOrder,
+						gen.SetOrderState(cmacme.Pending),
+						gen.SetOrderURL("https://acme.example.com/order/1"),
+					),
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            `Waiting on certificate issuance from order default-unit-test-ns/test-cr-1733622556: "pending"`,
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.AddCertificateRequestAnnotations(map[string]string{cmacme.OrderURLAnnotationKey: "https://acme.example.com/order/1"}),
+						),
+					)),
+				},
+			},
+		},
+
 		"if the order is in Valid state but Certificate has not yet been populated": {
 			certificateRequest: baseCR.DeepCopy(),
 			builder: &testpkg.Builder{
@@ -628,6 +884,7 @@ type testT struct {
 	expectedErr bool
 
 	fakeOrderLister *testlisters.FakeOrderLister
+	findZoneByFqdn  func(ctx context.Context, fqdn string, nameservers []string) (string, error)
 }
 
 func runTest(t *testing.T, test testT) {
@@ -639,6 +896,9 @@ func runTest(t *testing.T, test testT) {
 	if test.fakeOrderLister != nil {
 		ac.orderLister = test.fakeOrderLister
 	}
+	if test.findZoneByFqdn != nil {
+		ac.findZoneByFqdn = test.findZoneByFqdn
+	}
 
 	controller := certificaterequests.New(
 		apiutil.IssuerACME,
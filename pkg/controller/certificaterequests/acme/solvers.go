@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller/acmeorders/selectors"
+)
+
+// uncoveredDNSName is a DNS name for which no solver could be selected,
+// together with the reasons each solver was rejected.
+type uncoveredDNSName struct {
+	dnsName    string
+	candidates []selectors.Candidate
+}
+
+// errNoMatchingSolver is returned by checkSolversCoverDNSNames if no solver
+// can be selected for one or more of the DNS names.
+type errNoMatchingSolver struct {
+	uncovered []uncoveredDNSName
+}
+
+func (e *errNoMatchingSolver) Error() string {
+	names := make([]string, 0, len(e.uncovered))
+	details := make([]string, 0, len(e.uncovered))
+	for _, u := range e.uncovered {
+		names = append(names, fmt.Sprintf("%q", u.dnsName))
+		reasons := make([]string, 0, len(u.candidates))
+		for _, c := range u.candidates {
+			reasons = append(reasons, fmt.Sprintf("solver %d: %s", c.Index, c.Reason))
+		}
+		details = append(details, fmt.Sprintf("%q: %s", u.dnsName, strings.Join(reasons, ", ")))
+	}
+	return fmt.Sprintf("no configured challenge solver matches the DNS names %s (%s)", strings.Join(names, ", "), strings.Join(details, "; "))
+}
+
+// checkSolversCoverDNSNames runs solver selection for each of the DNS names
+// the same way the orders controller does, and returns an errNoMatchingSolver
+// listing the names for which no solver could be selected. Wildcard names
+// can only be solved by DNS01 solvers. The challenge types offered by the
+// ACME server are not known before the Order is created, so this check can
+// only reject names which no solver could ever be selected for.
+func checkSolversCoverDNSNames(meta metav1.ObjectMeta, dnsNames []string, solvers []cmacme.ACMEChallengeSolver) error {
+	var uncovered []uncoveredDNSName
+	for _, dnsName := range dnsNames {
+		wildcard := strings.HasPrefix(dnsName, "*.")
+		selection := selectors.SelectSolver(meta, dnsName, solvers, func(s *cmacme.ACMEChallengeSolver) bool {
+			return s.DNS01 != nil || (s.HTTP01 != nil && !wildcard)
+		})
+		if selection.Solver == nil {
+			uncovered = append(uncovered, uncoveredDNSName{dnsName: dnsName, candidates: selection.Candidates})
+		}
+	}
+	if len(uncovered) > 0 {
+		return &errNoMatchingSolver{uncovered: uncovered}
+	}
+	return nil
+}
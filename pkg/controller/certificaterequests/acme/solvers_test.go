@@ -0,0 +1,101 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+)
+
+func Test_checkSolversCoverDNSNames(t *testing.T) {
+	dns01 := func(sel *cmacme.CertificateDNSNameSelector) cmacme.ACMEChallengeSolver {
+		return cmacme.ACMEChallengeSolver{Selector: sel, DNS01: &cmacme.ACMEChallengeSolverDNS01{}}
+	}
+	http01 := func(sel *cmacme.CertificateDNSNameSelector) cmacme.ACMEChallengeSolver {
+		return cmacme.ACMEChallengeSolver{Selector: sel, HTTP01: &cmacme.ACMEChallengeSolverHTTP01{}}
+	}
+
+	tests := map[string]struct {
+		meta     metav1.ObjectMeta
+		dnsNames []string
+		solvers  []cmacme.ACMEChallengeSolver
+		expErr   string
+	}{
+		"every name is covered by one of several solvers": {
+			dnsNames: []string{"www.example.com", "api.example.org", "other.net"},
+			solvers: []cmacme.ACMEChallengeSolver{
+				dns01(&cmacme.CertificateDNSNameSelector{DNSZones: []string{"example.com"}}),
+				dns01(&cmacme.CertificateDNSNameSelector{DNSZones: []string{"example.org"}}),
+				http01(&cmacme.CertificateDNSNameSelector{DNSNames: []string{"other.net"}}),
+			},
+		},
+		"a solver without a selector covers every name": {
+			dnsNames: []string{"www.example.com", "*.example.org"},
+			solvers: []cmacme.ACMEChallengeSolver{
+				dns01(&cmacme.CertificateDNSNameSelector{DNSZones: []string{"example.com"}}),
+				dns01(nil),
+			},
+		},
+		"a name outside of every solver's dnsZones is not covered": {
+			dnsNames: []string{"www.example.com", "www.example.org"},
+			solvers: []cmacme.ACMEChallengeSolver{
+				dns01(&cmacme.CertificateDNSNameSelector{DNSZones: []string{"example.com"}}),
+				http01(&cmacme.CertificateDNSNameSelector{DNSNames: []string{"www.example.net"}}),
+			},
+			expErr: `no configured challenge solver matches the DNS names "www.example.org" ("www.example.org": solver 0: does not match dnsZones, solver 1: does not match dnsNames)`,
+		},
+		"every uncovered name is listed": {
+			dnsNames: []string{"a.example.org", "www.example.com", "b.example.org"},
+			solvers: []cmacme.ACMEChallengeSolver{
+				dns01(&cmacme.CertificateDNSNameSelector{DNSZones: []string{"example.com"}}),
+			},
+			expErr: `no configured challenge solver matches the DNS names "a.example.org", "b.example.org" ("a.example.org": solver 0: does not match dnsZones; "b.example.org": solver 0: does not match dnsZones)`,
+		},
+		"wildcard names cannot be solved by HTTP01 solvers": {
+			dnsNames: []string{"example.com", "*.example.com"},
+			solvers: []cmacme.ACMEChallengeSolver{
+				http01(nil),
+			},
+			expErr: `no configured challenge solver matches the DNS names "*.example.com" ("*.example.com": solver 0: solver type cannot be used for this domain)`,
+		},
+		"labels of the Order are taken into account": {
+			meta:     metav1.ObjectMeta{Labels: map[string]string{"team": "a"}},
+			dnsNames: []string{"www.example.com"},
+			solvers: []cmacme.ACMEChallengeSolver{
+				dns01(&cmacme.CertificateDNSNameSelector{MatchLabels: map[string]string{"team": "b"}}),
+			},
+			expErr: `no configured challenge solver matches the DNS names "www.example.com" ("www.example.com": solver 0: does not match matchLabels)`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := checkSolversCoverDNSNames(test.meta, test.dnsNames, test.solvers)
+			if test.expErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != test.expErr {
+				t.Errorf("unexpected error, exp=%q, got=%v", test.expErr, err)
+			}
+		})
+	}
+}
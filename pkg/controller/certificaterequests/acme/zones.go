@@ -0,0 +1,69 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	dnsutil "github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
+)
+
+// errDomainNotAllowed is returned by checkAllowedZones if the zone of a DNS
+// name does not match any of the allowed zones.
+type errDomainNotAllowed struct {
+	dnsName, zone string
+	allowedZones  []string
+}
+
+func (e *errDomainNotAllowed) Error() string {
+	return fmt.Sprintf("zone %q of DNS name %q does not match any of the allowed zones %v", e.zone, e.dnsName, e.allowedZones)
+}
+
+// checkAllowedZones looks up the zone of each of the DNS names and returns an
+// errDomainNotAllowed if it does not match any of the allowed zones. Wildcard
+// names are checked against their base domain. Other errors are returned if
+// the zone of a name could not be looked up.
+func (a *ACME) checkAllowedZones(ctx context.Context, dnsNames []string, allowedZones []string) error {
+	for _, dnsName := range dnsNames {
+		domain := strings.TrimPrefix(dnsName, "*.")
+		zone, err := a.findZoneByFqdn(ctx, dnsutil.ToFqdn(domain), a.dns01Nameservers)
+		if err != nil {
+			return fmt.Errorf("failed to find zone of DNS name %q: %w", dnsName, err)
+		}
+		if !zoneAllowed(zone, allowedZones) {
+			return &errDomainNotAllowed{dnsName: dnsName, zone: dnsutil.UnFqdn(zone), allowedZones: allowedZones}
+		}
+	}
+	return nil
+}
+
+// zoneAllowed returns true if the zone matches one of the allowed zones.
+// Allowed zones may contain '*' wildcards, and are compared to the zone
+// without case sensitivity or trailing dots.
+func zoneAllowed(zone string, allowedZones []string) bool {
+	zone = strings.ToLower(dnsutil.UnFqdn(zone))
+	for _, allowed := range allowedZones {
+		allowed = strings.ToLower(dnsutil.UnFqdn(allowed))
+		if matched, err := path.Match(allowed, zone); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
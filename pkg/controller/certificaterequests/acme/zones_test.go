@@ -0,0 +1,80 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import "testing"
+
+func Test_zoneAllowed(t *testing.T) {
+	tests := map[string]struct {
+		zone         string
+		allowedZones []string
+		exp          bool
+	}{
+		"exact match": {
+			zone:         "example.com.",
+			allowedZones: []string{"example.com"},
+			exp:          true,
+		},
+		"match is not case sensitive and ignores trailing dots": {
+			zone:         "Example.COM.",
+			allowedZones: []string{"example.com."},
+			exp:          true,
+		},
+		"parent zone does not match": {
+			zone:         "example.com.",
+			allowedZones: []string{"sub.example.com"},
+			exp:          false,
+		},
+		"subdomain glob matches child zone": {
+			zone:         "team.example.com.",
+			allowedZones: []string{"*.example.com"},
+			exp:          true,
+		},
+		"subdomain glob does not match the zone itself": {
+			zone:         "example.com.",
+			allowedZones: []string{"*.example.com"},
+			exp:          false,
+		},
+		"glob in the top level domain": {
+			zone:         "example.co.uk.",
+			allowedZones: []string{"example.*"},
+			exp:          true,
+		},
+		"typo'd zone does not match": {
+			zone:         "exmaple.com.",
+			allowedZones: []string{"example.com", "*.example.com"},
+			exp:          false,
+		},
+		"any of the allowed zones may match": {
+			zone:         "example.org.",
+			allowedZones: []string{"example.com", "example.org"},
+			exp:          true,
+		},
+		"invalid patterns never match": {
+			zone:         "example.com.",
+			allowedZones: []string{"[example.com"},
+			exp:          false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := zoneAllowed(test.zone, test.allowedZones); got != test.exp {
+				t.Errorf("unexpected result, exp=%t, got=%t", test.exp, got)
+			}
+		})
+	}
+}
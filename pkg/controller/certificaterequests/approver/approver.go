@@ -30,6 +30,7 @@ import (
 	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
 )
 
 const (
@@ -51,6 +52,9 @@ type Controller struct {
 
 	recorder record.EventRecorder
 
+	// metrics is used to count approved CertificateRequests by origin
+	metrics *metrics.Metrics
+
 	queue workqueue.RateLimitingInterface
 }
 
@@ -77,6 +81,7 @@ func (c *Controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	c.cmClient = ctx.CMClient
 	c.fieldManager = ctx.FieldManager
 	c.recorder = ctx.Recorder
+	c.metrics = ctx.Metrics
 
 	c.log.V(logf.DebugLevel).Info("certificate request approver controller registered")
 
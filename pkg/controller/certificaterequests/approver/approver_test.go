@@ -134,7 +134,25 @@ func TestProcessItem(t *testing.T) {
 					LastTransitionTime: &metaNow,
 				},
 			},
-			expectedEvent: "Normal cert-manager.io Certificate request has been approved by cert-manager.io",
+			expectedEvent: "Normal cert-manager.io Certificate request has been approved by cert-manager.io (origin: direct)",
+		},
+		"approve CertificateRequest created for a Certificate": {
+			request: &cmapi.CertificateRequest{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "testns", Name: "test",
+					Labels: map[string]string{cmapi.CertificateRequestCreatedByLabelKey: "certificates-request-manager"},
+				},
+			},
+			expectedConditions: []cmapi.CertificateRequestCondition{
+				{
+					Type:               cmapi.CertificateRequestConditionApproved,
+					Status:             cmmeta.ConditionTrue,
+					Reason:             "cert-manager.io",
+					Message:            ApprovedMessage,
+					LastTransitionTime: &metaNow,
+				},
+			},
+			expectedEvent: "Normal cert-manager.io Certificate request has been approved by cert-manager.io (origin: managed)",
 		},
 		"approve CertificateRequest has 'Ready' Pending condition": {
 			request: &cmapi.CertificateRequest{
@@ -163,7 +181,7 @@ func TestProcessItem(t *testing.T) {
 					LastTransitionTime: &metaNow,
 				},
 			},
-			expectedEvent: "Normal cert-manager.io Certificate request has been approved by cert-manager.io",
+			expectedEvent: "Normal cert-manager.io Certificate request has been approved by cert-manager.io (origin: direct)",
 		},
 	}
 	for name, test := range tests {
@@ -18,6 +18,7 @@ package approver
 
 import (
 	"context"
+	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -68,9 +69,14 @@ func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (er
 	if err := c.updateStatusOrApply(ctx, cr); err != nil {
 		return err
 	}
-	c.recorder.Event(cr, corev1.EventTypeNormal, "cert-manager.io", ApprovedMessage)
-
-	log.V(logf.DebugLevel).Info("approved certificate request")
+	// Every CertificateRequest is only approved once, so counting approvals
+	// counts CertificateRequests by whether they were created for a
+	// Certificate or directly.
+	origin := apiutil.CertificateRequestOrigin(cr)
+	c.metrics.IncrementCertificateRequestApprovedCount(origin)
+	c.recorder.Event(cr, corev1.EventTypeNormal, "cert-manager.io", fmt.Sprintf("%s (origin: %s)", ApprovedMessage, origin))
+
+	log.V(logf.DebugLevel).Info("approved certificate request", "origin", origin)
 
 	return nil
 }
@@ -31,6 +31,7 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/controller/certificaterequests"
 	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
 	issuerpkg "github.com/cert-manager/cert-manager/pkg/issuer"
+	issuerca "github.com/cert-manager/cert-manager/pkg/issuer/ca"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	cmerrors "github.com/cert-manager/cert-manager/pkg/util/errors"
 	"github.com/cert-manager/cert-manager/pkg/util/kube"
@@ -80,13 +81,28 @@ func NewCA(ctx *controllerpkg.Context) certificaterequests.Issuer {
 func (c *CA) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerObj cmapi.GenericIssuer) (*issuerpkg.IssueResponse, error) {
 	log := logf.FromContext(ctx, "sign")
 
-	secretName := issuerObj.GetSpec().CA.SecretName
 	resourceNamespace := c.issuerOptions.ResourceNamespace(issuerObj)
 
+	// The grant of a Secret in another namespace is checked on every sign, so
+	// that revoking it stops new issuance immediately.
+	secretNamespace, secretName, err := issuerca.SigningSecret(c.secretsLister, issuerObj, resourceNamespace)
+	if issuerca.IsNotGranted(err) {
+		message := fmt.Sprintf("Referenced secret %s/%s may not be used by this issuer", secretNamespace, secretName)
+
+		c.reporter.Pending(cr, err, "SecretNotGranted", message)
+		log.Error(err, message)
+
+		return nil, nil
+	}
+
 	// get a copy of the CA certificate named on the Issuer
-	caCerts, caKey, err := kube.SecretTLSKeyPairAndCA(ctx, c.secretsLister, resourceNamespace, issuerObj.GetSpec().CA.SecretName)
+	var caCerts []*x509.Certificate
+	var caKey crypto.Signer
+	if err == nil {
+		caCerts, caKey, err = kube.SecretTLSKeyPairAndCA(ctx, c.secretsLister, secretNamespace, secretName)
+	}
 	if k8sErrors.IsNotFound(err) {
-		message := fmt.Sprintf("Referenced secret %s/%s not found", resourceNamespace, secretName)
+		message := fmt.Sprintf("Referenced secret %s/%s not found", secretNamespace, secretName)
 
 		c.reporter.Pending(cr, err, "SecretMissing", message)
 		log.Error(err, message)
@@ -95,7 +111,7 @@ func (c *CA) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerObj c
 	}
 
 	if cmerrors.IsInvalidData(err) {
-		message := fmt.Sprintf("Failed to parse signing CA keypair from secret %s/%s", resourceNamespace, secretName)
+		message := fmt.Sprintf("Failed to parse signing CA keypair from secret %s/%s", secretNamespace, secretName)
 
 		c.reporter.Pending(cr, err, "SecretInvalidData", message)
 		log.Error(err, message)
@@ -104,7 +120,7 @@ func (c *CA) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerObj c
 
 	if err != nil {
 		// We are probably in a network error here so we should backoff and retry
-		message := fmt.Sprintf("Failed to get certificate key pair from secret %s/%s", resourceNamespace, secretName)
+		message := fmt.Sprintf("Failed to get certificate key pair from secret %s/%s", secretNamespace, secretName)
 		c.reporter.Pending(cr, err, "SecretGetError", message)
 		log.Error(err, message)
 		return nil, err
@@ -122,6 +138,12 @@ func (c *CA) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerObj c
 	template.OCSPServer = issuerObj.GetSpec().CA.OCSPServers
 	template.IssuingCertificateURL = issuerObj.GetSpec().CA.IssuingCertificateURLs
 
+	// Only the certificate policies allowed by the issuer are included in the
+	// signed certificate, the others are dropped.
+	if removed := pki.FilterCertificatePolicies(template, issuerObj.GetSpec().CA.AllowedPolicyOIDs); len(removed) > 0 {
+		log.V(logf.DebugLevel).Info("removed certificate policies not allowed by the issuer", "policies", removed)
+	}
+
 	bundle, err := c.signingFn(caCerts, caKey, template)
 	if err != nil {
 		message := "Error signing certificate"
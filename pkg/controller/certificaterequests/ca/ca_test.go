@@ -23,6 +23,7 @@ import (
 	"crypto/rand"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/asn1"
 	"errors"
 	"math"
 	"math/big"
@@ -49,6 +50,7 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
 	testlisters "github.com/cert-manager/cert-manager/test/unit/listers"
+	"github.com/cert-manager/cert-manager/test/unit/signer"
 )
 
 var (
@@ -158,6 +160,19 @@ func TestSign(t *testing.T) {
 		},
 	}
 
+	// an issuer signing with a CA key pair in another namespace, which grants
+	// access to the namespace of the issuer
+	crossNamespaceIssuer := gen.IssuerFrom(baseIssuer,
+		gen.SetIssuerCA(cmapi.CAIssuer{SecretRef: &cmapi.CAIssuerSecretReference{Name: "root-ca-secret", Namespace: "pki"}}),
+	)
+	grantedCASecret := rsaCASecret.DeepCopy()
+	grantedCASecret.Namespace = "pki"
+	grantedCASecret.Annotations = map[string]string{
+		cmapi.AllowedIssuerNamespacesAnnotationKey: "other, " + gen.DefaultTestNamespace,
+	}
+	notGrantedCASecret := grantedCASecret.DeepCopy()
+	notGrantedCASecret.Annotations[cmapi.AllowedIssuerNamespacesAnnotationKey] = "other"
+
 	badDataSecret := rsaCASecret.DeepCopy()
 	badDataSecret.Data[corev1.TLSPrivateKeyKey] = []byte("bad key")
 
@@ -177,7 +192,7 @@ func TestSign(t *testing.T) {
 				KubeObjects:        []runtime.Object{},
 				CertManagerObjects: []runtime.Object{baseCRNotApproved.DeepCopy(), baseIssuer.DeepCopy()},
 				ExpectedEvents: []string{
-					"Normal WaitingForApproval Not signing CertificateRequest until it is Approved",
+					"Normal WaitingForApproval Not signing CertificateRequest until it is Approved (origin: direct)",
 				},
 			},
 		},
@@ -232,6 +247,58 @@ func TestSign(t *testing.T) {
 				},
 			},
 		},
+		"a missing CA key pair in another namespace should set the condition to pending and wait for a re-sync": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), crossNamespaceIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					`Normal SecretMissing Referenced secret pki/root-ca-secret not found: secret "root-ca-secret" not found`,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR.DeepCopy(),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            `Referenced secret pki/root-ca-secret not found: secret "root-ca-secret" not found`,
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+						),
+					)),
+				},
+			},
+		},
+		"a CA key pair in another namespace which does not grant access should set the condition to pending and wait for a re-sync": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{notGrantedCASecret},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), crossNamespaceIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					`Normal SecretNotGranted Referenced secret pki/root-ca-secret may not be used by this issuer: secret pki/root-ca-secret does not grant access to namespace "default-unit-test-ns" in its "cert-manager.io/allowed-issuer-namespaces" annotation`,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR.DeepCopy(),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            `Referenced secret pki/root-ca-secret may not be used by this issuer: secret pki/root-ca-secret does not grant access to namespace "default-unit-test-ns" in its "cert-manager.io/allowed-issuer-namespaces" annotation`,
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+						),
+					)),
+				},
+			},
+		},
 		"a secret with invalid data should set condition to pending and wait for re-sync": {
 			certificateRequest: baseCR.DeepCopy(),
 			builder: &testpkg.Builder{
@@ -398,6 +465,17 @@ func TestSign(t *testing.T) {
 		},
 	}
 
+	// signing with a CA key pair in another namespace which grants access
+	// succeeds in the same way as with one in the namespace of the issuer
+	grantedTest := tests["a successful signing should set condition to Ready"]
+	grantedTest.builder = &testpkg.Builder{
+		KubeObjects:        []runtime.Object{grantedCASecret},
+		CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), crossNamespaceIssuer.DeepCopy()},
+		ExpectedEvents:     grantedTest.builder.ExpectedEvents,
+		ExpectedActions:    grantedTest.builder.ExpectedActions,
+	}
+	tests["a successful signing with a CA key pair in another namespace which grants access should set condition to Ready"] = grantedTest
+
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
 			fixedClock.SetTime(fixedClockStart)
@@ -467,6 +545,13 @@ func TestCA_Sign(t *testing.T) {
 		t.Fatal(err)
 	}
 	testCSR := generateCSR(t, testpk)
+	testCSRWithPolicies, err := gen.CSRWithSigner(testpk,
+		gen.SetCSRCommonName("test"),
+		gen.SetCSRPolicyOIDs("2.23.140.1.2.1", "1.3.6.1.4.1.44947.1.1.1"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	tests := map[string]struct {
 		givenCASecret    *corev1.Secret
@@ -589,6 +674,59 @@ func TestCA_Sign(t *testing.T) {
 				assert.Equal(t, []string{"http://www.example.com/crl/test.crl"}, gotCA.CRLDistributionPoints)
 			},
 		},
+		"when the CertificateRequest requests policies allowed by the Issuer, they should appear on the signed certificate": {
+			givenCASecret: gen.SecretFrom(gen.Secret("secret-1"), gen.SetSecretNamespace("default"), gen.SetSecretData(secretDataFor(t, rootPK, rootCert))),
+			givenCAIssuer: gen.Issuer("issuer-1", gen.SetIssuerCA(cmapi.CAIssuer{
+				SecretName:        "secret-1",
+				AllowedPolicyOIDs: []string{"2.23.140.1.2.1", "1.3.6.1.4.1.44947.1.1.1"},
+			})),
+			givenCR: gen.CertificateRequest("cr-1",
+				gen.SetCertificateRequestCSR(testCSRWithPolicies),
+				gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+					Name:  "issuer-1",
+					Group: certmanager.GroupName,
+					Kind:  "Issuer",
+				}),
+			),
+			assertSignedCert: func(t *testing.T, got *x509.Certificate) {
+				assert.Equal(t, []asn1.ObjectIdentifier{{2, 23, 140, 1, 2, 1}, {1, 3, 6, 1, 4, 1, 44947, 1, 1, 1}}, got.PolicyIdentifiers)
+			},
+		},
+		"when the CertificateRequest requests policies not allowed by the Issuer, they should be removed from the signed certificate": {
+			givenCASecret: gen.SecretFrom(gen.Secret("secret-1"), gen.SetSecretNamespace("default"), gen.SetSecretData(secretDataFor(t, rootPK, rootCert))),
+			givenCAIssuer: gen.Issuer("issuer-1", gen.SetIssuerCA(cmapi.CAIssuer{
+				SecretName:        "secret-1",
+				AllowedPolicyOIDs: []string{"2.23.140.1.2.1"},
+			})),
+			givenCR: gen.CertificateRequest("cr-1",
+				gen.SetCertificateRequestCSR(testCSRWithPolicies),
+				gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+					Name:  "issuer-1",
+					Group: certmanager.GroupName,
+					Kind:  "Issuer",
+				}),
+			),
+			assertSignedCert: func(t *testing.T, got *x509.Certificate) {
+				assert.Equal(t, []asn1.ObjectIdentifier{{2, 23, 140, 1, 2, 1}}, got.PolicyIdentifiers)
+			},
+		},
+		"when the Issuer allows no policies, requested policies should not appear on the signed certificate": {
+			givenCASecret: gen.SecretFrom(gen.Secret("secret-1"), gen.SetSecretNamespace("default"), gen.SetSecretData(secretDataFor(t, rootPK, rootCert))),
+			givenCAIssuer: gen.Issuer("issuer-1", gen.SetIssuerCA(cmapi.CAIssuer{
+				SecretName: "secret-1",
+			})),
+			givenCR: gen.CertificateRequest("cr-1",
+				gen.SetCertificateRequestCSR(testCSRWithPolicies),
+				gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+					Name:  "issuer-1",
+					Group: certmanager.GroupName,
+					Kind:  "Issuer",
+				}),
+			),
+			assertSignedCert: func(t *testing.T, got *x509.Certificate) {
+				assert.Empty(t, got.PolicyIdentifiers)
+			},
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -624,6 +762,33 @@ func TestCA_Sign(t *testing.T) {
 	}
 }
 
+func TestCA_SignConformance(t *testing.T) {
+	rootPK, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+	rootCert, _ := generateSelfSignedCACert(t, rootPK, "root")
+
+	caSecret := gen.Secret("secret-1", gen.SetSecretNamespace(gen.DefaultTestNamespace), gen.SetSecretData(secretDataFor(t, rootPK, rootCert)))
+	caIssuer := gen.Issuer("issuer-1", gen.SetIssuerNamespace(gen.DefaultTestNamespace), gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "secret-1"}))
+
+	signer.RunConformance(t, func(t *testing.T, _ crypto.Signer, cr *cmapi.CertificateRequest) []byte {
+		c := &CA{
+			reporter: util.NewReporter(fixedClock, &testpkg.FakeRecorder{}),
+			secretsLister: testlisters.FakeSecretListerFrom(testlisters.NewFakeSecretLister(),
+				testlisters.SetFakeSecretNamespaceListerGet(caSecret, nil),
+			),
+			templateGenerator: pki.CertificateTemplateFromCertificateRequest,
+			signingFn:         pki.SignCSRTemplate,
+		}
+
+		resp, err := c.Sign(context.Background(), cr, caIssuer)
+		require.NoError(t, err)
+		if resp == nil {
+			return nil
+		}
+		return resp.Certificate
+	})
+}
+
 // Returns a map that is meant to be used for creating a certificate Secret
 // that contains the fields "tls.crt" and "tls.key".
 func secretDataFor(t *testing.T, caKey *ecdsa.PrivateKey, caCrt *x509.Certificate) (secretData map[string][]byte) {
@@ -27,6 +27,7 @@ import (
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/clock"
 
+	"github.com/cert-manager/cert-manager/internal/controller/issuerhealth"
 	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
@@ -45,6 +46,15 @@ type Issuer interface {
 	Sign(context.Context, *v1.CertificateRequest, v1.GenericIssuer) (*issuer.IssueResponse, error)
 }
 
+// StatusCertificateVerifier is implemented by Issuers whose certificates may
+// be set on the status of a CertificateRequest by a third party, rather than
+// being returned by Sign. Sign is then also called for CertificateRequests
+// which have a certificate in their status but are not yet Ready, so that the
+// certificate can be verified before the request is marked as Ready.
+type StatusCertificateVerifier interface {
+	VerifiesStatusCertificate() bool
+}
+
 // Issuer Contractor builds a Issuer instance using the given controller
 // context.
 type IssuerConstructor func(*controllerpkg.Context) Issuer
@@ -100,6 +110,10 @@ type Controller struct {
 	clock clock.Clock
 
 	reporter *util.Reporter
+
+	// issuerHealth records the outcome of CertificateRequests for the
+	// issuer health summary.
+	issuerHealth *issuerhealth.Tracker
 }
 
 // New will construct a new certificaterequest controller using the given
@@ -182,6 +196,7 @@ func (c *Controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	// recorder records events about resources to the Kubernetes api
 	c.recorder = ctx.Recorder
 	c.reporter = util.NewReporter(c.clock, c.recorder)
+	c.issuerHealth = ctx.IssuerHealth
 	c.cmClient = ctx.CMClient
 	c.fieldManager = ctx.FieldManager
 
@@ -0,0 +1,286 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package delivery
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+const (
+	ControllerName = "certificaterequests-delivery"
+
+	// reasonDelivered is the reason used when the signed certificate has been
+	// written to the CertificateRequest's Secret.
+	reasonDelivered = "Delivered"
+
+	// reasonSecretConflict is the reason used when the CertificateRequest's
+	// Secret is managed by a Certificate or another CertificateRequest.
+	reasonSecretConflict = "SecretConflict"
+)
+
+var certificateRequestGvk = cmapi.SchemeGroupVersion.WithKind(cmapi.CertificateRequestKind)
+
+// Controller is a CertificateRequest controller which delivers the signed
+// certificate of CertificateRequests created directly by users to the Secret
+// named in their `cert-manager.io/deliver-to-secret` annotation.
+//
+// Only the certificate and CA are written to the Secret. The Secret is
+// created if it does not exist, and is owned by the CertificateRequest so that
+// it is garbage collected when the CertificateRequest is deleted. An existing
+// kubernetes.io/tls Secret which does not hold a certificate yet is written to
+// but not owned. Secrets managed by a Certificate, controlled by another
+// resource, or holding another certificate are never written.
+type Controller struct {
+	// logger to be used by this controller
+	log logr.Logger
+
+	certificateRequestLister cmlisters.CertificateRequestLister
+	certificateLister        cmlisters.CertificateLister
+	secretLister             internalinformers.SecretLister
+	kubeClient               kubernetes.Interface
+
+	recorder record.EventRecorder
+
+	queue workqueue.RateLimitingInterface
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(new(Controller)).Complete()
+	})
+}
+
+// Register registers and constructs the controller using the provided context.
+// It returns the workqueue to be used to enqueue items, a list of
+// InformerSynced functions that must be synced, or an error.
+func (c *Controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	c.log = logf.FromContext(ctx.RootContext, ControllerName)
+	c.queue = workqueue.NewNamedRateLimitingQueue(controllerpkg.DefaultItemBasedRateLimiter(), ControllerName)
+
+	certificateRequestInformer := ctx.SharedInformerFactory.Certmanager().V1().CertificateRequests()
+	certificateInformer := ctx.SharedInformerFactory.Certmanager().V1().Certificates()
+	secretsInformer := ctx.KubeSharedInformerFactory.Secrets()
+
+	certificateRequestInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: c.queue})
+	// Restore the delivered certificate if the Secret is changed.
+	secretsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: controllerpkg.HandleOwnedResourceNamespacedFunc(c.log, c.queue, certificateRequestGvk, func(namespace, name string) (interface{}, error) {
+			return certificateRequestInformer.Lister().CertificateRequests(namespace).Get(name)
+		}),
+	})
+
+	mustSync := []cache.InformerSynced{
+		certificateRequestInformer.Informer().HasSynced,
+		certificateInformer.Informer().HasSynced,
+		secretsInformer.Informer().HasSynced,
+	}
+
+	c.certificateRequestLister = certificateRequestInformer.Lister()
+	c.certificateLister = certificateInformer.Lister()
+	c.secretLister = secretsInformer.Lister()
+	c.kubeClient = ctx.Client
+	c.recorder = ctx.Recorder
+
+	c.log.V(logf.DebugLevel).Info("certificate request delivery controller registered")
+
+	return c.queue, mustSync, nil
+}
+
+func (c *Controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx)
+	dbg := log.V(logf.DebugLevel)
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key")
+		return nil
+	}
+
+	cr, err := c.certificateRequestLister.CertificateRequests(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		// The delivered Secret is garbage collected through its owner
+		// reference.
+		dbg.Info(fmt.Sprintf("certificate request in work queue no longer exists: %s", err))
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	ctx = logf.NewContext(ctx, logf.WithResource(log, cr))
+	return c.Sync(ctx, cr)
+}
+
+// Sync writes the signed certificate and CA of a Ready CertificateRequest to
+// the Secret named in its `cert-manager.io/deliver-to-secret` annotation.
+func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) error {
+	log := logf.FromContext(ctx)
+	dbg := log.V(logf.DebugLevel)
+
+	secretName := cr.Annotations[cmapi.CertificateRequestDeliverToSecretAnnotationKey]
+	if secretName == "" || cr.DeletionTimestamp != nil {
+		return nil
+	}
+	if _, ok := cr.Annotations[cmapi.CertificateRequestRevisionAnnotationKey]; ok {
+		dbg.Info("certificate request was created for a certificate, not delivering")
+		return nil
+	}
+	if !apiutil.CertificateRequestHasCondition(cr, cmapi.CertificateRequestCondition{
+		Type:   cmapi.CertificateRequestConditionReady,
+		Status: cmmeta.ConditionTrue,
+	}) || len(cr.Status.Certificate) == 0 {
+		dbg.Info("certificate request is not ready, waiting to deliver")
+		return nil
+	}
+
+	log = log.WithValues("secret", secretName)
+
+	// A Certificate may not have created its Secret yet, so the Certificates
+	// in the namespace are checked as well as the Secret's annotations.
+	crts, err := c.certificateLister.Certificates(cr.Namespace).List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, crt := range crts {
+		if crt.Spec.SecretName == secretName {
+			c.recorder.Eventf(cr, corev1.EventTypeWarning, reasonSecretConflict,
+				"Not delivering the signed certificate: Secret %q is managed by Certificate %q", secretName, crt.Name)
+			return nil
+		}
+	}
+
+	secret, err := c.secretLister.Secrets(cr.Namespace).Get(secretName)
+	if apierrors.IsNotFound(err) {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       cr.Namespace,
+				Name:            secretName,
+				OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(cr, certificateRequestGvk)},
+				Labels: map[string]string{
+					cmapi.PartOfCertManagerControllerLabelKey: "true",
+				},
+			},
+			Data: secretData(cr, nil),
+		}
+		if _, err := c.kubeClient.CoreV1().Secrets(cr.Namespace).Create(ctx, secret, metav1.CreateOptions{}); err != nil {
+			return err
+		}
+		log.V(logf.InfoLevel).Info("delivered signed certificate to new secret")
+		c.recorder.Eventf(cr, corev1.EventTypeNormal, reasonDelivered, "Delivered the signed certificate to Secret %q", secretName)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if msg := secretConflict(cr, secret); msg != "" {
+		c.recorder.Eventf(cr, corev1.EventTypeWarning, reasonSecretConflict, "Not delivering the signed certificate: Secret %q %s", secretName, msg)
+		return nil
+	}
+
+	data := secretData(cr, secret.Data)
+	if dataEqual(secret.Data, data) {
+		dbg.Info("secret is up to date")
+		return nil
+	}
+
+	// A Secret which existed before the CertificateRequest, such as one
+	// holding the user's private key, is written to but never owned, so that
+	// deleting the CertificateRequest does not garbage collect it.
+	secret = secret.DeepCopy()
+	secret.Data = data
+	if _, err := c.kubeClient.CoreV1().Secrets(cr.Namespace).Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+	log.V(logf.InfoLevel).Info("delivered signed certificate to existing secret")
+	c.recorder.Eventf(cr, corev1.EventTypeNormal, reasonDelivered, "Delivered the signed certificate to Secret %q", secretName)
+	return nil
+}
+
+// secretConflict returns a description of why the CertificateRequest may not
+// write to the Secret, or an empty string if it may.
+func secretConflict(cr *cmapi.CertificateRequest, secret *corev1.Secret) string {
+	if crtName, ok := secret.Annotations[cmapi.CertificateNameKey]; ok {
+		return fmt.Sprintf("is managed by Certificate %q", crtName)
+	}
+	if ref := metav1.GetControllerOf(secret); ref != nil {
+		if ref.UID != cr.UID {
+			return fmt.Sprintf("is controlled by %s %q", ref.Kind, ref.Name)
+		}
+		return ""
+	}
+	// Secrets created by the user are only adopted if they are TLS Secrets
+	// which do not hold a certificate yet, or which already hold the signed
+	// certificate of this CertificateRequest.
+	if secret.Type != corev1.SecretTypeTLS {
+		return fmt.Sprintf("has type %q, not %q", secret.Type, corev1.SecretTypeTLS)
+	}
+	if crt := secret.Data[corev1.TLSCertKey]; len(crt) > 0 && !bytes.Equal(crt, cr.Status.Certificate) {
+		return "already holds a certificate"
+	}
+	return ""
+}
+
+// secretData returns a copy of the existing Secret data with the
+// CertificateRequest's signed certificate and CA. Any other keys, such as a
+// private key stored by the user, are left untouched.
+func secretData(cr *cmapi.CertificateRequest, existing map[string][]byte) map[string][]byte {
+	data := make(map[string][]byte, len(existing)+2)
+	for k, v := range existing {
+		data[k] = v
+	}
+	data[corev1.TLSCertKey] = cr.Status.Certificate
+	if len(cr.Status.CA) > 0 {
+		data[cmmeta.TLSCAKey] = cr.Status.CA
+	} else {
+		delete(data, cmmeta.TLSCAKey)
+	}
+	return data
+}
+
+func dataEqual(a, b map[string][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if w, ok := b[k]; !ok || !bytes.Equal(v, w) {
+			return false
+		}
+	}
+	return true
+}
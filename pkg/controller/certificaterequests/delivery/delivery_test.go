@@ -0,0 +1,257 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package delivery
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestProcessItem(t *testing.T) {
+	var (
+		certPEM = []byte("signed-certificate")
+		caPEM   = []byte("ca-certificate")
+		keyPEM  = []byte("users-private-key")
+	)
+
+	baseCR := gen.CertificateRequest("cr",
+		func(cr *cmapi.CertificateRequest) { cr.UID = "cr-uid" },
+		gen.AddCertificateRequestAnnotations(map[string]string{
+			cmapi.CertificateRequestDeliverToSecretAnnotationKey: "my-tls",
+		}),
+	)
+	readyCR := gen.CertificateRequestFrom(baseCR,
+		gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+			Type:   cmapi.CertificateRequestConditionReady,
+			Status: cmmeta.ConditionTrue,
+			Reason: cmapi.CertificateRequestReasonIssued,
+		}),
+		gen.SetCertificateRequestCertificate(certPEM),
+		gen.SetCertificateRequestCA(caPEM),
+	)
+	ownerRef := *metav1.NewControllerRef(readyCR, certificateRequestGvk)
+	deliveredSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       gen.DefaultTestNamespace,
+			Name:            "my-tls",
+			OwnerReferences: []metav1.OwnerReference{ownerRef},
+			Labels:          map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"},
+		},
+		Data: map[string][]byte{
+			corev1.TLSCertKey: certPEM,
+			cmmeta.TLSCAKey:   caPEM,
+		},
+	}
+	userSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: gen.DefaultTestNamespace,
+			Name:      "my-tls",
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSPrivateKeyKey: keyPEM,
+		},
+	}
+	secretsGVR := corev1.SchemeGroupVersion.WithResource("secrets")
+
+	tests := map[string]struct {
+		// key that should be passed to ProcessItem.
+		// if not set, the 'namespace/name' of the 'CertificateRequest' field will be used.
+		key string
+
+		request         *cmapi.CertificateRequest
+		existingObjects []runtime.Object
+		existingCMObjs  []runtime.Object
+
+		expectedActions []testpkg.Action
+		expectedEvents  []string
+	}{
+		"do nothing if the CertificateRequest no longer exists": {
+			key: gen.DefaultTestNamespace + "/cr",
+		},
+		"do nothing if the CertificateRequest does not have the annotation": {
+			request: gen.CertificateRequestFrom(readyCR, gen.DeleteCertificateRequestAnnotation(cmapi.CertificateRequestDeliverToSecretAnnotationKey)),
+		},
+		"do nothing if the CertificateRequest is not ready": {
+			request: baseCR,
+		},
+		"do nothing if the CertificateRequest was created for a Certificate": {
+			request: gen.CertificateRequestFrom(readyCR, gen.SetCertificateRequestRevision("1")),
+		},
+		"do nothing if the CertificateRequest is being deleted": {
+			request: gen.CertificateRequestFrom(readyCR, func(cr *cmapi.CertificateRequest) {
+				cr.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+				cr.Finalizers = []string{"example.com/finalizer"}
+			}),
+		},
+		"create a Secret owned by the CertificateRequest if it does not exist": {
+			request: readyCR,
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewCreateAction(secretsGVR, gen.DefaultTestNamespace, deliveredSecret)),
+			},
+			expectedEvents: []string{`Normal Delivered Delivered the signed certificate to Secret "my-tls"`},
+		},
+		"omit the CA if the CertificateRequest does not have one": {
+			request: gen.CertificateRequestFrom(readyCR, gen.SetCertificateRequestCA(nil)),
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewCreateAction(secretsGVR, gen.DefaultTestNamespace, func() *corev1.Secret {
+					secret := deliveredSecret.DeepCopy()
+					delete(secret.Data, cmmeta.TLSCAKey)
+					return secret
+				}())),
+			},
+			expectedEvents: []string{`Normal Delivered Delivered the signed certificate to Secret "my-tls"`},
+		},
+		"write to an existing Secret holding the user's private key without owning it or changing the key": {
+			request:         readyCR,
+			existingObjects: []runtime.Object{userSecret},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(secretsGVR, gen.DefaultTestNamespace, func() *corev1.Secret {
+					secret := userSecret.DeepCopy()
+					secret.Data[corev1.TLSCertKey] = certPEM
+					secret.Data[cmmeta.TLSCAKey] = caPEM
+					return secret
+				}())),
+			},
+			expectedEvents: []string{`Normal Delivered Delivered the signed certificate to Secret "my-tls"`},
+		},
+		"do nothing if an existing Secret already holds the signed certificate": {
+			request: readyCR,
+			existingObjects: []runtime.Object{func() *corev1.Secret {
+				secret := userSecret.DeepCopy()
+				secret.Data[corev1.TLSCertKey] = certPEM
+				secret.Data[cmmeta.TLSCAKey] = caPEM
+				return secret
+			}()},
+		},
+		"refuse to deliver to an existing Secret which is not a TLS Secret": {
+			request: readyCR,
+			existingObjects: []runtime.Object{func() *corev1.Secret {
+				secret := userSecret.DeepCopy()
+				secret.Type = corev1.SecretTypeOpaque
+				return secret
+			}()},
+			expectedEvents: []string{`Warning SecretConflict Not delivering the signed certificate: Secret "my-tls" has type "Opaque", not "kubernetes.io/tls"`},
+		},
+		"refuse to deliver to an existing Secret which holds another certificate": {
+			request: readyCR,
+			existingObjects: []runtime.Object{func() *corev1.Secret {
+				secret := userSecret.DeepCopy()
+				secret.Data[corev1.TLSCertKey] = []byte("other-certificate")
+				return secret
+			}()},
+			expectedEvents: []string{`Warning SecretConflict Not delivering the signed certificate: Secret "my-tls" already holds a certificate`},
+		},
+		"update a delivered Secret which has been changed": {
+			request: readyCR,
+			existingObjects: []runtime.Object{func() *corev1.Secret {
+				secret := deliveredSecret.DeepCopy()
+				secret.Data[corev1.TLSCertKey] = []byte("changed")
+				return secret
+			}()},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(secretsGVR, gen.DefaultTestNamespace, deliveredSecret)),
+			},
+			expectedEvents: []string{`Normal Delivered Delivered the signed certificate to Secret "my-tls"`},
+		},
+		"do nothing if the delivered Secret is up to date": {
+			request:         readyCR,
+			existingObjects: []runtime.Object{deliveredSecret},
+		},
+		"refuse to deliver to the Secret of a Certificate which has not been issued yet": {
+			request: readyCR,
+			existingCMObjs: []runtime.Object{
+				gen.Certificate("crt", gen.SetCertificateSecretName("my-tls")),
+			},
+			expectedEvents: []string{`Warning SecretConflict Not delivering the signed certificate: Secret "my-tls" is managed by Certificate "crt"`},
+		},
+		"refuse to deliver to a Secret issued for a Certificate": {
+			request: readyCR,
+			existingObjects: []runtime.Object{func() *corev1.Secret {
+				secret := userSecret.DeepCopy()
+				secret.Annotations = map[string]string{cmapi.CertificateNameKey: "deleted-crt"}
+				return secret
+			}()},
+			expectedEvents: []string{`Warning SecretConflict Not delivering the signed certificate: Secret "my-tls" is managed by Certificate "deleted-crt"`},
+		},
+		"refuse to deliver to a Secret controlled by another CertificateRequest": {
+			request: readyCR,
+			existingObjects: []runtime.Object{func() *corev1.Secret {
+				secret := deliveredSecret.DeepCopy()
+				secret.OwnerReferences[0].Name = "other-cr"
+				secret.OwnerReferences[0].UID = "other-cr-uid"
+				return secret
+			}()},
+			expectedEvents: []string{`Warning SecretConflict Not delivering the signed certificate: Secret "my-tls" is controlled by CertificateRequest "other-cr"`},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fakeclock.NewFakeClock(time.Now()),
+				KubeObjects:        test.existingObjects,
+				CertManagerObjects: test.existingCMObjs,
+				ExpectedActions:    test.expectedActions,
+				ExpectedEvents:     test.expectedEvents,
+			}
+			if test.request != nil {
+				builder.CertManagerObjects = append(builder.CertManagerObjects, test.request)
+			}
+			builder.Init()
+			c := new(Controller)
+			if _, _, err := c.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+			builder.Start()
+			defer builder.Stop()
+
+			key := test.key
+			if key == "" && test.request != nil {
+				var err error
+				key, err = controllerpkg.KeyFunc(test.request)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := c.ProcessItem(context.Background(), key); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if err := builder.AllEventsCalled(); err != nil {
+				builder.T.Error(err)
+			}
+			if err := builder.AllActionsExecuted(); err != nil {
+				builder.T.Error(err)
+			}
+		})
+	}
+}
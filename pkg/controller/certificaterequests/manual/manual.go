@@ -0,0 +1,293 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manual
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificaterequests"
+	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+const (
+	CRControllerName = "certificaterequests-issuer-manual"
+
+	// stagingSecretSuffix is appended to the name of the Certificate of a
+	// CertificateRequest to get the name of the Secret in which an operator
+	// provides the certificate.
+	stagingSecretSuffix = "-staging"
+)
+
+// Manual delivers certificates which are signed outside of cert-manager. The
+// certificate is either read from a staging Secret, or set on the status of
+// the CertificateRequest by an operator. Until then, the CertificateRequest
+// is left pending.
+type Manual struct {
+	secretsLister     internalinformers.SecretLister
+	certificateLister cmlisters.CertificateLister
+
+	reporter *crutil.Reporter
+	clock    clock.PassiveClock
+}
+
+var _ certificaterequests.StatusCertificateVerifier = &Manual{}
+
+func init() {
+	// create certificate request controller for manual issuer
+	controllerpkg.Register(CRControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, CRControllerName).
+			For(certificaterequests.New(
+				apiutil.IssuerManual,
+				NewManual,
+
+				// Handle informed Secrets, so that CertificateRequests are
+				// synced as soon as their staging Secret is created or
+				// updated by an operator.
+				func(ctx *controllerpkg.Context, log logr.Logger, queue workqueue.RateLimitingInterface) ([]cache.InformerSynced, error) {
+					secretInformer := ctx.KubeSharedInformerFactory.Secrets().Informer()
+					certificateRequestLister := ctx.SharedInformerFactory.Certmanager().V1().CertificateRequests().Lister()
+					mustSync := []cache.InformerSynced{
+						secretInformer.HasSynced,
+						ctx.SharedInformerFactory.Certmanager().V1().Certificates().Informer().HasSynced,
+						ctx.SharedInformerFactory.Certmanager().V1().Issuers().Informer().HasSynced,
+					}
+
+					// ClusterIssuers are only informed if cert-manager is not
+					// scoped to a single namespace.
+					var clusterIssuerLister cmlisters.ClusterIssuerLister
+					if ctx.Namespace == "" {
+						clusterIssuerLister = ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers().Lister()
+						mustSync = append(mustSync, ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers().Informer().HasSynced)
+					}
+
+					helper := issuer.NewHelper(
+						ctx.SharedInformerFactory.Certmanager().V1().Issuers().Lister(),
+						clusterIssuerLister,
+					)
+					secretInformer.AddEventHandler(&controllerpkg.BlockingEventHandler{
+						WorkFunc: handleStagingSecretWorkFunc(log, certificateRequestLister, helper, queue),
+					})
+					return mustSync, nil
+				},
+			)).
+			Complete()
+	})
+}
+
+func NewManual(ctx *controllerpkg.Context) certificaterequests.Issuer {
+	return &Manual{
+		secretsLister:     ctx.KubeSharedInformerFactory.Secrets().Lister(),
+		certificateLister: ctx.SharedInformerFactory.Certmanager().V1().Certificates().Lister(),
+		reporter:          crutil.NewReporter(ctx.Clock, ctx.Recorder),
+		clock:             ctx.Clock,
+	}
+}
+
+// VerifiesStatusCertificate returns true, as operators may fulfil a
+// CertificateRequest by setting the signed certificate on its status.
+func (m *Manual) VerifiesStatusCertificate() bool {
+	return true
+}
+
+// Sign returns the certificate which an operator has provided for the
+// CertificateRequest, either on its status or in its staging Secret. If no
+// certificate has been provided yet, the CertificateRequest is marked as
+// pending and an Event asks an operator to fulfil it.
+func (m *Manual) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerObj cmapi.GenericIssuer) (*issuer.IssueResponse, error) {
+	log := logf.FromContext(ctx, "sign")
+
+	csr, err := pki.DecodeX509CertificateRequestBytes(cr.Spec.Request)
+	if err != nil {
+		message := "Failed to decode CSR in spec.request"
+		m.reporter.Failed(cr, err, "ErrorParsingCSR", message)
+		log.Error(err, message)
+		return nil, nil
+	}
+
+	secretName := stagingSecretName(cr)
+
+	if len(cr.Status.Certificate) > 0 {
+		if _, err := verifyCertificate(cr.Status.Certificate, csr, m.clock.Now()); err != nil {
+			message := "Certificate set in status.certificate cannot be used"
+			m.reporter.Pending(cr, err, "InvalidCertificate", message)
+			log.Error(err, message)
+			return nil, nil
+		}
+
+		log.V(logf.DebugLevel).Info("certificate provided in status by an operator")
+		return &issuer.IssueResponse{
+			Certificate: cr.Status.Certificate,
+			CA:          cr.Status.CA,
+		}, nil
+	}
+
+	secret, err := m.secretsLister.Secrets(cr.Namespace).Get(secretName)
+	if k8sErrors.IsNotFound(err) {
+		m.reporter.Pending(cr, nil, "WaitingForCertificate", waitingForCertificateMessage(secretName))
+		return nil, nil
+	}
+	if err != nil {
+		message := fmt.Sprintf("Failed to get staging secret %s/%s", cr.Namespace, secretName)
+		m.reporter.Pending(cr, err, "ErrorGettingSecret", message)
+		log.Error(err, message)
+		return nil, err
+	}
+
+	// A staging Secret which does not hold a certificate for this request
+	// most likely still holds the certificate of a previous request, so keep
+	// waiting for an operator to replace it.
+	bundle, err := verifyCertificate(secret.Data[corev1.TLSCertKey], csr, m.clock.Now())
+	if err != nil {
+		m.reporter.Pending(cr, fmt.Errorf("certificate in staging Secret cannot be used: %w", err),
+			"WaitingForCertificate", waitingForCertificateMessage(secretName))
+		return nil, nil
+	}
+
+	delivered, err := m.deliveredToCertificate(cr, bundle.ChainPEM)
+	if err != nil {
+		message := "Failed to compare the staged certificate with the current certificate"
+		m.reporter.Pending(cr, err, "ErrorGettingSecret", message)
+		log.Error(err, message)
+		return nil, err
+	}
+	if delivered {
+		m.reporter.Pending(cr, errors.New("certificate in staging Secret has already been delivered to the Certificate"),
+			"WaitingForCertificate", waitingForCertificateMessage(secretName))
+		return nil, nil
+	}
+
+	ca := bundle.CAPEM
+	if len(secret.Data[cmmeta.TLSCAKey]) > 0 {
+		ca = secret.Data[cmmeta.TLSCAKey]
+	}
+
+	log.V(logf.DebugLevel).Info("certificate provided in staging secret by an operator", "secret", secretName)
+	return &issuer.IssueResponse{
+		Certificate: bundle.ChainPEM,
+		CA:          ca,
+	}, nil
+}
+
+// stagingSecretName returns the name of the Secret in which an operator
+// provides the certificate for the given CertificateRequest. It is named
+// after the Certificate of the request, so that the same Secret is used for
+// each renewal, or after the request itself if it has no Certificate.
+func stagingSecretName(cr *cmapi.CertificateRequest) string {
+	name := cr.Annotations[cmapi.CertificateNameKey]
+	if name == "" {
+		name = cr.Name
+	}
+	return name + stagingSecretSuffix
+}
+
+func waitingForCertificateMessage(secretName string) string {
+	return fmt.Sprintf("Waiting for an operator to sign the CSR in spec.request outside of cert-manager, "+
+		"and to provide the certificate either in the %q key of Secret %q, or in status.certificate", corev1.TLSCertKey, secretName)
+}
+
+// verifyCertificate checks that the given PEM encoded certificate chain has
+// been issued for the public key of the CSR and has not expired yet.
+func verifyCertificate(certPEM []byte, csr *x509.CertificateRequest, now time.Time) (pki.PEMBundle, error) {
+	if len(certPEM) == 0 {
+		return pki.PEMBundle{}, errors.New("no certificate provided")
+	}
+
+	bundle, err := pki.ParseSingleCertificateChainPEM(certPEM)
+	if err != nil {
+		return pki.PEMBundle{}, fmt.Errorf("failed to parse certificate chain: %w", err)
+	}
+
+	cert, err := pki.DecodeX509CertificateBytes(bundle.ChainPEM)
+	if err != nil {
+		return pki.PEMBundle{}, fmt.Errorf("failed to decode certificate: %w", err)
+	}
+
+	equal, err := pki.PublicKeysEqual(cert.PublicKey, csr.PublicKey)
+	if err != nil {
+		return pki.PEMBundle{}, fmt.Errorf("failed to compare public keys: %w", err)
+	}
+	if !equal {
+		return pki.PEMBundle{}, errors.New("certificate was not issued for the private key of the CSR")
+	}
+
+	if !now.Before(cert.NotAfter) {
+		return pki.PEMBundle{}, fmt.Errorf("certificate expired at %s", cert.NotAfter.UTC().Format(time.RFC3339))
+	}
+
+	return bundle, nil
+}
+
+// deliveredToCertificate returns true if the given certificate is already
+// stored in the Secret of the Certificate of the CertificateRequest. With a
+// private key rotation policy of Never, a renewal reuses the private key, so
+// the certificate of the previous request still matches the CSR.
+func (m *Manual) deliveredToCertificate(cr *cmapi.CertificateRequest, certPEM []byte) (bool, error) {
+	crtName := cr.Annotations[cmapi.CertificateNameKey]
+	if crtName == "" {
+		return false, nil
+	}
+
+	crt, err := m.certificateLister.Certificates(cr.Namespace).Get(crtName)
+	if k8sErrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	secret, err := m.secretsLister.Secrets(cr.Namespace).Get(crt.Spec.SecretName)
+	if k8sErrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	current, err := pki.DecodeX509CertificateBytes(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		// The current certificate cannot have been delivered by this issuer
+		// if it cannot be decoded.
+		return false, nil
+	}
+
+	cert, err := pki.DecodeX509CertificateBytes(certPEM)
+	if err != nil {
+		return false, err
+	}
+
+	return current.Equal(cert), nil
+}
@@ -0,0 +1,390 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manual
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificaterequests"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+var (
+	fixedClockStart = time.Now()
+	fixedClock      = fakeclock.NewFakeClock(fixedClockStart)
+)
+
+// signManually signs the CSR of the given CertificateRequest with the given
+// CA, as an operator would do outside of cert-manager.
+func signManually(t *testing.T, cr *cmapi.CertificateRequest, publicKey crypto.PublicKey, caCert *x509.Certificate, caKey crypto.Signer) []byte {
+	template, err := pki.CertificateTemplateFromCertificateRequest(cr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM, _, err := pki.SignCertificate(template, caCert, publicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return certPEM
+}
+
+func TestSign(t *testing.T) {
+	metaFixedClockStart := metav1.NewTime(fixedClockStart)
+
+	baseIssuer := gen.Issuer("test-issuer",
+		gen.SetIssuerManual(cmapi.ManualIssuer{}),
+		gen.AddIssuerCondition(cmapi.IssuerCondition{
+			Type:   cmapi.IssuerConditionReady,
+			Status: cmmeta.ConditionTrue,
+		}),
+	)
+
+	caKey, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "manual-ca"},
+		NotBefore:             fixedClockStart.Add(-time.Hour),
+		NotAfter:              fixedClockStart.Add(time.Hour * 24 * 365),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caPEM, caCert, err := pki.SignCertificate(caTemplate, caTemplate, caKey.Public(), caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sk, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csrPEM, err := gen.CSRWithSigner(sk, gen.SetCSRCommonName("example.com"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherSK, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	baseCR := gen.CertificateRequest("test-cr",
+		gen.SetCertificateRequestAnnotations(map[string]string{
+			cmapi.CertificateNameKey: "test-crt",
+		}),
+		gen.SetCertificateRequestCSR(csrPEM),
+		gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+			Name:  baseIssuer.Name,
+			Group: certmanager.GroupName,
+			Kind:  "Issuer",
+		}),
+		gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+			Type:               cmapi.CertificateRequestConditionApproved,
+			Status:             cmmeta.ConditionTrue,
+			Reason:             "cert-manager.io",
+			Message:            "Certificate request has been approved by cert-manager.io",
+			LastTransitionTime: &metaFixedClockStart,
+		}),
+	)
+
+	certPEM := signManually(t, baseCR, sk.Public(), caCert, caKey)
+	otherCertPEM := signManually(t, baseCR, otherSK.Public(), caCert, caKey)
+
+	stagingSecret := func(data []byte) *corev1.Secret {
+		return gen.Secret("test-crt-staging",
+			gen.SetSecretNamespace(gen.DefaultTestNamespace),
+			gen.SetSecretData(map[string][]byte{corev1.TLSCertKey: data}),
+		)
+	}
+	certificate := gen.Certificate("test-crt",
+		gen.SetCertificateNamespace(gen.DefaultTestNamespace),
+		gen.SetCertificateSecretName("test-tls"),
+	)
+	pendingCR := func(message string) *cmapi.CertificateRequest {
+		return gen.CertificateRequestFrom(baseCR,
+			gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+				Type:               cmapi.CertificateRequestConditionReady,
+				Status:             cmmeta.ConditionFalse,
+				Reason:             cmapi.CertificateRequestReasonPending,
+				Message:            message,
+				LastTransitionTime: &metaFixedClockStart,
+			}),
+		)
+	}
+	waiting := waitingForCertificateMessage("test-crt-staging")
+
+	tests := map[string]testT{
+		"a request without a staging Secret should be pending and ask an operator to fulfil it": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy(), certificate},
+				ExpectedEvents: []string{
+					"Normal WaitingForCertificate " + waiting,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						pendingCR(waiting),
+					)),
+				},
+			},
+		},
+		"a request should be fulfilled from its staging Secret": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{stagingSecret(append(append([]byte{}, certPEM...), caPEM...))},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy(), certificate},
+				ExpectedEvents: []string{
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             cmapi.CertificateRequestReasonIssued,
+								Message:            "Certificate fetched from issuer successfully",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestCertificate(certPEM),
+							gen.SetCertificateRequestCA(caPEM),
+						),
+					)),
+				},
+			},
+		},
+		"a staging Secret with a certificate for another private key should leave the request pending": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{stagingSecret(otherCertPEM)},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy(), certificate},
+				ExpectedEvents: []string{
+					"Normal WaitingForCertificate " + waiting + ": certificate in staging Secret cannot be used: certificate was not issued for the private key of the CSR",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						pendingCR(waiting+": certificate in staging Secret cannot be used: certificate was not issued for the private key of the CSR"),
+					)),
+				},
+			},
+		},
+		"a staged certificate which has already been delivered to the Certificate should leave the request pending": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{
+					stagingSecret(certPEM),
+					gen.Secret("test-tls",
+						gen.SetSecretNamespace(gen.DefaultTestNamespace),
+						gen.SetSecretData(map[string][]byte{corev1.TLSCertKey: certPEM}),
+					),
+				},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy(), certificate},
+				ExpectedEvents: []string{
+					"Normal WaitingForCertificate " + waiting + ": certificate in staging Secret has already been delivered to the Certificate",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						pendingCR(waiting+": certificate in staging Secret has already been delivered to the Certificate"),
+					)),
+				},
+			},
+		},
+		"a request should be fulfilled by an operator patching its status": {
+			certificateRequest: gen.CertificateRequestFrom(pendingCR(waiting),
+				gen.SetCertificateRequestCertificate(certPEM),
+				gen.SetCertificateRequestCA(caPEM),
+			),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					gen.CertificateRequestFrom(pendingCR(waiting),
+						gen.SetCertificateRequestCertificate(certPEM),
+						gen.SetCertificateRequestCA(caPEM),
+					),
+					baseIssuer.DeepCopy(),
+					certificate,
+				},
+				ExpectedEvents: []string{
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             cmapi.CertificateRequestReasonIssued,
+								Message:            "Certificate fetched from issuer successfully",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestCertificate(certPEM),
+							gen.SetCertificateRequestCA(caPEM),
+						),
+					)),
+				},
+			},
+		},
+		"a certificate patched into the status for another private key should leave the request pending": {
+			certificateRequest: gen.CertificateRequestFrom(baseCR,
+				gen.SetCertificateRequestCertificate(otherCertPEM),
+			),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					gen.CertificateRequestFrom(baseCR,
+						gen.SetCertificateRequestCertificate(otherCertPEM),
+					),
+					baseIssuer.DeepCopy(),
+					certificate,
+				},
+				ExpectedEvents: []string{
+					"Normal InvalidCertificate Certificate set in status.certificate cannot be used: certificate was not issued for the private key of the CSR",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(pendingCR("Certificate set in status.certificate cannot be used: certificate was not issued for the private key of the CSR"),
+							gen.SetCertificateRequestCertificate(otherCertPEM),
+						),
+					)),
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fixedClock.SetTime(fixedClockStart)
+			test.builder.Clock = fixedClock
+			runTest(t, test)
+		})
+	}
+}
+
+type testT struct {
+	builder            *testpkg.Builder
+	certificateRequest *cmapi.CertificateRequest
+
+	expectedErr bool
+}
+
+func runTest(t *testing.T, test testT) {
+	test.builder.T = t
+	test.builder.Init()
+	defer test.builder.Stop()
+
+	manual := NewManual(test.builder.Context).(*Manual)
+
+	controller := certificaterequests.New(
+		apiutil.IssuerManual,
+		func(*controller.Context) certificaterequests.Issuer { return manual },
+	)
+	controller.Register(test.builder.Context)
+	test.builder.Start()
+
+	err := controller.Sync(context.Background(), test.certificateRequest)
+	if err != nil && !test.expectedErr {
+		t.Errorf("expected to not get an error, but got: %v", err)
+	}
+	if err == nil && test.expectedErr {
+		t.Errorf("expected to get an error but did not get one")
+	}
+
+	test.builder.CheckAndFinish(err)
+}
+
+func TestVerifyCertificate(t *testing.T) {
+	sk, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csrPEM, err := gen.CSRWithSigner(sk, gen.SetCSRCommonName("example.com"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	csr, err := pki.DecodeX509CertificateRequestBytes(csrPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(time.Hour),
+	}
+	certPEM, _, err := pki.SignCertificate(template, template, sk.Public(), sk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bundle, err := verifyCertificate(certPEM, csr, now)
+	if err != nil {
+		t.Fatalf("expected certificate to be valid, got: %v", err)
+	}
+	if !bytes.Equal(bundle.ChainPEM, certPEM) {
+		t.Errorf("expected chain to be the certificate, got: %s", bundle.ChainPEM)
+	}
+
+	if _, err := verifyCertificate(certPEM, csr, now.Add(time.Hour)); err == nil {
+		t.Errorf("expected an expired certificate to be rejected")
+	}
+	if _, err := verifyCertificate(nil, csr, now); err == nil {
+		t.Errorf("expected a missing certificate to be rejected")
+	}
+	if _, err := verifyCertificate([]byte("not a certificate"), csr, now); err == nil {
+		t.Errorf("expected an invalid certificate to be rejected")
+	}
+}
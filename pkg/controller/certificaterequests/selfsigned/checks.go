@@ -17,6 +17,7 @@ limitations under the License.
 package selfsigned
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/go-logr/logr"
@@ -97,6 +98,11 @@ func certificateRequestsForSecret(log logr.Logger,
 			continue
 		}
 
+		if errors.Is(err, issuer.ErrClusterIssuersDisabled) {
+			dbg.Info("ClusterIssuers are disabled, skipping")
+			continue
+		}
+
 		if err != nil {
 			log.Error(err, "failed to get issuer")
 			return nil, err
@@ -33,6 +33,7 @@ import (
 	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificaterequests"
 	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
@@ -48,6 +49,7 @@ const (
 	emptyDNMessage   = "Certificate will be issued with an empty Issuer DN, which contravenes RFC 5280 and could break some strict clients"
 )
 
+type templateGenerator func(*cmapi.CertificateRequest) (*x509.Certificate, error)
 type signingFn func(*x509.Certificate, *x509.Certificate, crypto.PublicKey, interface{}) ([]byte, *x509.Certificate, error)
 
 type SelfSigned struct {
@@ -58,7 +60,8 @@ type SelfSigned struct {
 	recorder record.EventRecorder
 
 	// Used for testing to get reproducible resulting certificates
-	signingFn signingFn
+	templateGenerator templateGenerator
+	signingFn         signingFn
 }
 
 func init() {
@@ -74,18 +77,27 @@ func init() {
 				func(ctx *controllerpkg.Context, log logr.Logger, queue workqueue.RateLimitingInterface) ([]cache.InformerSynced, error) {
 					secretInformer := ctx.KubeSharedInformerFactory.Secrets().Informer()
 					certificateRequestLister := ctx.SharedInformerFactory.Certmanager().V1().CertificateRequests().Lister()
+					mustSync := []cache.InformerSynced{
+						secretInformer.HasSynced,
+						ctx.SharedInformerFactory.Certmanager().V1().Issuers().Informer().HasSynced,
+					}
+
+					// ClusterIssuers are only informed if cert-manager is not
+					// scoped to a single namespace.
+					var clusterIssuerLister cmlisters.ClusterIssuerLister
+					if ctx.Namespace == "" {
+						clusterIssuerLister = ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers().Lister()
+						mustSync = append(mustSync, ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers().Informer().HasSynced)
+					}
+
 					helper := issuer.NewHelper(
 						ctx.SharedInformerFactory.Certmanager().V1().Issuers().Lister(),
-						ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers().Lister(),
+						clusterIssuerLister,
 					)
 					secretInformer.AddEventHandler(&controllerpkg.BlockingEventHandler{
 						WorkFunc: handleSecretReferenceWorkFunc(log, certificateRequestLister, helper, queue),
 					})
-					return []cache.InformerSynced{
-						secretInformer.HasSynced,
-						ctx.SharedInformerFactory.Certmanager().V1().Issuers().Informer().HasSynced,
-						ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers().Informer().HasSynced,
-					}, nil
+					return mustSync, nil
 				},
 			)).
 			Complete()
@@ -94,11 +106,12 @@ func init() {
 
 func NewSelfSigned(ctx *controllerpkg.Context) certificaterequests.Issuer {
 	return &SelfSigned{
-		issuerOptions: ctx.IssuerOptions,
-		secretsLister: ctx.KubeSharedInformerFactory.Secrets().Lister(),
-		reporter:      crutil.NewReporter(ctx.Clock, ctx.Recorder),
-		recorder:      ctx.Recorder,
-		signingFn:     pki.SignCertificate,
+		issuerOptions:     ctx.IssuerOptions,
+		secretsLister:     ctx.KubeSharedInformerFactory.Secrets().Lister(),
+		reporter:          crutil.NewReporter(ctx.Clock, ctx.Recorder),
+		recorder:          ctx.Recorder,
+		templateGenerator: pki.CertificateTemplateFromCertificateRequest,
+		signingFn:         pki.SignCertificate,
 	}
 }
 
@@ -147,8 +160,9 @@ func (s *SelfSigned) Sign(ctx context.Context, cr *cmapi.CertificateRequest, iss
 		return nil, err
 	}
 
-	var template *x509.Certificate
-	template, err = pki.CertificateTemplateFromCertificateRequest(cr)
+	// The template is generated in the same way as for the CA issuer, so the
+	// requested duration, usages, isCA and name constraints are all honored.
+	template, err := s.templateGenerator(cr)
 	if err != nil {
 		message := "Error generating certificate template"
 		s.reporter.Failed(cr, err, "ErrorGenerating", message)
@@ -156,6 +170,13 @@ func (s *SelfSigned) Sign(ctx context.Context, cr *cmapi.CertificateRequest, iss
 		return nil, nil
 	}
 
+	if err := validateSelfSignedTemplate(template); err != nil {
+		message := "Request cannot be self-signed"
+		s.reporter.Failed(cr, err, "ErrorUnsupportedRequest", message)
+		log.Error(err, message)
+		return nil, nil
+	}
+
 	template.CRLDistributionPoints = issuerObj.GetSpec().SelfSigned.CRLDistributionPoints
 
 	if template.Subject.String() == "" {
@@ -207,3 +228,19 @@ func (s *SelfSigned) Sign(ctx context.Context, cr *cmapi.CertificateRequest, iss
 		CA:          certPem,
 	}, nil
 }
+
+// validateSelfSignedTemplate returns an error if the template requests
+// something that cannot be expressed in a self-signed certificate.
+func validateSelfSignedTemplate(template *x509.Certificate) error {
+	// RFC 5280, 4.2.1.10: the name constraints extension MUST only be used
+	// in a CA certificate.
+	hasNameConstraints := len(template.PermittedDNSDomains) > 0 || len(template.ExcludedDNSDomains) > 0 ||
+		len(template.PermittedIPRanges) > 0 || len(template.ExcludedIPRanges) > 0 ||
+		len(template.PermittedEmailAddresses) > 0 || len(template.ExcludedEmailAddresses) > 0 ||
+		len(template.PermittedURIDomains) > 0 || len(template.ExcludedURIDomains) > 0
+	if hasNameConstraints && !template.IsCA {
+		return errors.New("name constraints can only be set on a CA certificate")
+	}
+
+	return nil
+}
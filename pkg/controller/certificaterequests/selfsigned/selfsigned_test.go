@@ -20,6 +20,7 @@ import (
 	"context"
 	"crypto"
 	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"testing"
@@ -38,10 +39,12 @@ import (
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	"github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificaterequests"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
 	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
 	listersfake "github.com/cert-manager/cert-manager/test/unit/listers"
+	"github.com/cert-manager/cert-manager/test/unit/signer"
 )
 
 var (
@@ -199,7 +202,7 @@ func TestSign(t *testing.T) {
 				KubeObjects:        []runtime.Object{},
 				CertManagerObjects: []runtime.Object{baseCRNotApproved.DeepCopy(), baseIssuer.DeepCopy()},
 				ExpectedEvents: []string{
-					"Normal WaitingForApproval Not signing CertificateRequest until it is Approved",
+					"Normal WaitingForApproval Not signing CertificateRequest until it is Approved (origin: direct)",
 				},
 			},
 		},
@@ -643,3 +646,87 @@ func runTest(t *testing.T, test testT) {
 
 	test.builder.CheckAndFinish(err)
 }
+
+func newTestSelfSigned(t *testing.T, key crypto.Signer) *SelfSigned {
+	keyPEM, err := pki.EncodePKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keySecret := gen.Secret("test-key",
+		gen.SetSecretNamespace(gen.DefaultTestNamespace),
+		gen.SetSecretData(map[string][]byte{corev1.TLSPrivateKeyKey: keyPEM}),
+	)
+
+	return &SelfSigned{
+		secretsLister: listersfake.FakeSecretListerFrom(listersfake.NewFakeSecretLister(),
+			listersfake.SetFakeSecretNamespaceListerGet(keySecret, nil),
+		),
+		reporter:          util.NewReporter(fixedClock, &testpkg.FakeRecorder{}),
+		recorder:          &testpkg.FakeRecorder{},
+		templateGenerator: pki.CertificateTemplateFromCertificateRequest,
+		signingFn:         pki.SignCertificate,
+	}
+}
+
+func TestSelfSigned_SignConformance(t *testing.T) {
+	selfSignedIssuer := gen.Issuer("issuer-1", gen.SetIssuerSelfSigned(cmapi.SelfSignedIssuer{}))
+
+	signer.RunConformance(t, func(t *testing.T, key crypto.Signer, cr *cmapi.CertificateRequest) []byte {
+		cr.Annotations = map[string]string{cmapi.CertificateRequestPrivateKeyAnnotationKey: "test-key"}
+
+		resp, err := newTestSelfSigned(t, key).Sign(context.Background(), cr, selfSignedIssuer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp == nil {
+			return nil
+		}
+		return resp.Certificate
+	})
+}
+
+func TestSelfSigned_SignUnsupportedRequest(t *testing.T) {
+	key, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Name constraints can only be set on CA certificates, so requesting them
+	// for a non-CA certificate cannot be self-signed.
+	crt := gen.Certificate("test",
+		gen.SetCertificateCommonName("example.com"),
+		gen.SetCertificateKeyAlgorithm(cmapi.ECDSAKeyAlgorithm),
+		gen.SetCertificateKeySize(256),
+	)
+	crt.Spec.NameConstraints = &cmapi.NameConstraints{
+		Permitted: &cmapi.NameConstraintItem{DNSDomains: []string{"example.com"}},
+	}
+	template, err := pki.GenerateCSR(crt, pki.WithNameConstraints(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	csrDER, err := pki.EncodeCSR(template, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cr := gen.CertificateRequest("test",
+		gen.SetCertificateRequestNamespace(gen.DefaultTestNamespace),
+		gen.SetCertificateRequestCSR(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER})),
+		gen.AddCertificateRequestAnnotations(map[string]string{cmapi.CertificateRequestPrivateKeyAnnotationKey: "test-key"}),
+	)
+
+	resp, err := newTestSelfSigned(t, key).Sign(context.Background(), cr, gen.Issuer("issuer-1", gen.SetIssuerSelfSigned(cmapi.SelfSignedIssuer{})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp != nil {
+		t.Errorf("expected the request not to be signed")
+	}
+
+	cond := apiutil.GetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionReady)
+	if cond == nil || cond.Reason != cmapi.CertificateRequestReasonFailed ||
+		cond.Message != "Request cannot be self-signed: name constraints can only be set on a CA certificate" {
+		t.Errorf("unexpected Ready condition: %+v", cond)
+	}
+}
@@ -18,6 +18,7 @@ package certificaterequests
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 
@@ -34,6 +35,7 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
@@ -53,7 +55,9 @@ func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (er
 	defer func() {
 		if saveErr := c.updateCertificateRequestStatusAndAnnotations(ctx, cr, crCopy); saveErr != nil {
 			err = utilerrors.NewAggregate([]error{saveErr, err})
+			return
 		}
+		c.observeIssuerHealth(cr, crCopy)
 	}()
 
 	// If CertificateRequest has been denied, mark the CertificateRequest as
@@ -72,7 +76,7 @@ func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (er
 	// If CertificateRequest has not been approved, exit early.
 	if !apiutil.CertificateRequestIsApproved(cr) {
 		dbg.Info("certificate request has not been approved")
-		c.recorder.Event(cr, corev1.EventTypeNormal, "WaitingForApproval", "Not signing CertificateRequest until it is Approved")
+		c.recorder.Eventf(cr, corev1.EventTypeNormal, "WaitingForApproval", "Not signing CertificateRequest until it is Approved (origin: %s)", apiutil.CertificateRequestOrigin(cr))
 		return nil
 	}
 
@@ -95,6 +99,13 @@ func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (er
 		return nil
 	}
 
+	// ClusterIssuers can never be referenced while cert-manager is scoped to
+	// a single namespace, so there is no point in retrying.
+	if errors.Is(err, issuer.ErrClusterIssuersDisabled) {
+		c.reporter.Pending(crCopy, err, "ClusterIssuerNotSupported", fmt.Sprintf("Referenced %q cannot be used", cmapi.ClusterIssuerKind))
+		return nil
+	}
+
 	if err != nil {
 		log.Error(err, "failed to get issuer")
 		return err
@@ -123,14 +134,13 @@ func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (er
 		Type:   cmapi.IssuerConditionReady,
 		Status: cmmeta.ConditionTrue,
 	}) {
-		c.reporter.Pending(crCopy, nil, "IssuerNotReady",
-			"Referenced issuer does not have a Ready status condition")
+		c.reporter.Pending(crCopy, nil, "IssuerNotReady", issuerNotReadyMessage(issuerObj))
 		return nil
 	}
 
 	dbg.Info("validating CertificateRequest resource object")
 
-	if len(crCopy.Status.Certificate) > 0 {
+	if len(crCopy.Status.Certificate) > 0 && !c.verifiesStatusCertificate() {
 		dbg.Info("certificate field is already set in status so skipping processing")
 		return nil
 	}
@@ -168,6 +178,42 @@ func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (er
 	return nil
 }
 
+// verifiesStatusCertificate returns true if the issuer of this controller
+// verifies certificates which have been set on the status of a
+// CertificateRequest by a third party.
+func (c *Controller) verifiesStatusCertificate() bool {
+	v, ok := c.issuer.(StatusCertificateVerifier)
+	return ok && v.VerifiesStatusCertificate()
+}
+
+// issuerNotReadyMessage returns the message set on CertificateRequests whose
+// issuer is not Ready. It includes the message of the issuer's Ready
+// condition, so that it is clear why issuance is paused, for example because
+// the ACME account of the issuer has been deactivated.
+func issuerNotReadyMessage(iss cmapi.GenericIssuer) string {
+	for _, cond := range iss.GetStatus().Conditions {
+		if cond.Type == cmapi.IssuerConditionReady && cond.Message != "" {
+			return fmt.Sprintf("Referenced issuer is not ready: %s", cond.Message)
+		}
+	}
+	return "Referenced issuer does not have a Ready status condition"
+}
+
+// observeIssuerHealth records the outcome of a CertificateRequest for the
+// issuer health summary if it has been issued or has failed during this sync.
+func (c *Controller) observeIssuerHealth(oldCR, newCR *cmapi.CertificateRequest) {
+	reason := apiutil.CertificateRequestReadyReason(newCR)
+	if reason == apiutil.CertificateRequestReadyReason(oldCR) {
+		return
+	}
+	switch reason {
+	case cmapi.CertificateRequestReasonIssued:
+		c.issuerHealth.ObserveIssued(newCR.Namespace, newCR.Spec.IssuerRef)
+	case cmapi.CertificateRequestReasonFailed:
+		c.issuerHealth.ObserveFailed(newCR.Namespace, newCR.Spec.IssuerRef)
+	}
+}
+
 func (c *Controller) updateCertificateRequestStatusAndAnnotations(ctx context.Context, oldCR, newCR *cmapi.CertificateRequest) error {
 	log := logf.FromContext(ctx, "updateStatus")
 
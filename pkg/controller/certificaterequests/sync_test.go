@@ -24,6 +24,7 @@ import (
 	"crypto/x509"
 	"encoding/pem"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -176,7 +177,7 @@ func TestSync(t *testing.T) {
 			builder: &testpkg.Builder{
 				CertManagerObjects: []runtime.Object{baseIssuer, baseCR},
 				ExpectedEvents: []string{
-					"Normal WaitingForApproval Not signing CertificateRequest until it is Approved",
+					"Normal WaitingForApproval Not signing CertificateRequest until it is Approved (origin: direct)",
 				},
 				ExpectedActions: []testpkg.Action{},
 			},
@@ -368,7 +369,19 @@ func TestSync(t *testing.T) {
 			builder: &testpkg.Builder{
 				CertManagerObjects: []runtime.Object{baseIssuer, baseCR},
 				ExpectedEvents: []string{
-					"Normal WaitingForApproval Not signing CertificateRequest until it is Approved",
+					"Normal WaitingForApproval Not signing CertificateRequest until it is Approved (origin: direct)",
+				},
+				ExpectedActions: []testpkg.Action{},
+			},
+		},
+		"should include the origin of a certificate request created for a Certificate in the event if it is not approved": {
+			certificateRequest: gen.CertificateRequestFrom(baseCRNotApproved,
+				gen.SetCertificateRequestLabels(map[string]string{cmapi.CertificateRequestCreatedByLabelKey: "certificates-request-manager"}),
+			),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseIssuer, baseCR},
+				ExpectedEvents: []string{
+					"Normal WaitingForApproval Not signing CertificateRequest until it is Approved (origin: managed)",
 				},
 				ExpectedActions: []testpkg.Action{},
 			},
@@ -445,6 +458,36 @@ func TestSync(t *testing.T) {
 				},
 			},
 		},
+		"should report pending without retrying if ClusterIssuers are disabled": {
+			certificateRequest: baseCR.DeepCopy(),
+			helper: &issuerfake.Helper{
+				GetGenericIssuerFunc: func(cmmeta.ObjectReference, string) (cmapi.GenericIssuer, error) {
+					return nil, fmt.Errorf("cannot get ClusterIssuer named %q: %w", "test-issuer", issuer.ErrClusterIssuersDisabled)
+				},
+			},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR},
+				ExpectedEvents: []string{
+					`Normal ClusterIssuerNotSupported Referenced "ClusterIssuer" cannot be used: cannot get ClusterIssuer named "test-issuer": ClusterIssuers are not supported as cert-manager is scoped to a single namespace`,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             "Pending",
+								Message:            `Referenced "ClusterIssuer" cannot be used: cannot get ClusterIssuer named "test-issuer": ClusterIssuers are not supported as cert-manager is scoped to a single namespace`,
+								LastTransitionTime: &nowMetaTime,
+							}),
+						),
+					)),
+				},
+			},
+		},
 		"should return error to try again if there was a error getting issuer wasn't a not found error": {
 			certificateRequest: baseCR.DeepCopy(),
 			helper: &issuerfake.Helper{
@@ -516,6 +559,41 @@ func TestSync(t *testing.T) {
 				},
 			},
 		},
+		"should exit nil and set status pending with the reason if referenced issuer is not ready": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR,
+					gen.Issuer(baseIssuer.Name,
+						gen.SetIssuerSelfSigned(cmapi.SelfSignedIssuer{}),
+						gen.AddIssuerCondition(cmapi.IssuerCondition{
+							Type:    cmapi.IssuerConditionReady,
+							Status:  cmmeta.ConditionFalse,
+							Reason:  "ACMEAccountDeactivated",
+							Message: "The ACME account has been deactivated by the ACME server",
+						}),
+					),
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             "Pending",
+								Message:            "Referenced issuer is not ready: The ACME account has been deactivated by the ACME server",
+								LastTransitionTime: &nowMetaTime,
+							}),
+						),
+					)),
+				},
+				ExpectedEvents: []string{
+					"Normal IssuerNotReady Referenced issuer is not ready: The ACME account has been deactivated by the ACME server",
+				},
+			},
+		},
 		"exit nil and no action if the issuer type does not match ours (its not meant for us)": {
 			certificateRequest: baseCR.DeepCopy(),
 			builder: &testpkg.Builder{
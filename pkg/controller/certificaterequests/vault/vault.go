@@ -18,6 +18,7 @@ package vault
 
 import (
 	"context"
+	"errors"
 
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 
@@ -35,15 +36,21 @@ import (
 const (
 	// CRControllerName is the name of Vault certificate requests controller.
 	CRControllerName = "certificaterequests-issuer-vault"
+
+	// reasonRequestRejected is the reason of the Event recorded when Vault
+	// rejects a request, for example because the role does not allow one of
+	// the requested names.
+	reasonRequestRejected = "VaultRequestRejected"
 )
 
 // Vault is a Vault-specific implementation of
 // pkg/controller/certificaterequests.Issuer interface.
 type Vault struct {
-	issuerOptions controllerpkg.IssuerOptions
-	createTokenFn func(ns string) vaultinternal.CreateToken
-	secretsLister internalinformers.SecretLister
-	reporter      *crutil.Reporter
+	issuerOptions  controllerpkg.IssuerOptions
+	createTokenFn  func(ns string) vaultinternal.CreateToken
+	updateSecretFn func(ns string) vaultinternal.UpdateSecret
+	secretsLister  internalinformers.SecretLister
+	reporter       *crutil.Reporter
 
 	vaultClientBuilder vaultinternal.ClientBuilder
 }
@@ -64,6 +71,9 @@ func NewVault(ctx *controllerpkg.Context) certificaterequests.Issuer {
 		createTokenFn: func(ns string) vaultinternal.CreateToken {
 			return ctx.Client.CoreV1().ServiceAccounts(ns).CreateToken
 		},
+		updateSecretFn: func(ns string) vaultinternal.UpdateSecret {
+			return ctx.Client.CoreV1().Secrets(ns).Update
+		},
 		secretsLister:      ctx.KubeSharedInformerFactory.Secrets().Lister(),
 		reporter:           crutil.NewReporter(ctx.Clock, ctx.Recorder),
 		vaultClientBuilder: vaultinternal.New,
@@ -78,7 +88,7 @@ func (v *Vault) Sign(ctx context.Context, cr *v1.CertificateRequest, issuerObj v
 
 	resourceNamespace := v.issuerOptions.ResourceNamespace(issuerObj)
 
-	client, err := v.vaultClientBuilder(ctx, resourceNamespace, v.createTokenFn, v.secretsLister, issuerObj)
+	client, err := v.vaultClientBuilder(ctx, resourceNamespace, v.createTokenFn, v.updateSecretFn, v.secretsLister, issuerObj)
 	if k8sErrors.IsNotFound(err) {
 		message := "Required secret resource not found"
 
@@ -96,7 +106,16 @@ func (v *Vault) Sign(ctx context.Context, cr *v1.CertificateRequest, issuerObj v
 	}
 
 	certDuration := apiutil.DefaultCertDuration(cr.Spec.Duration)
-	certPem, caPem, err := client.Sign(cr.Spec.Request, certDuration)
+	certPem, caPem, err := client.Sign(ctx, cr.Spec.Request, certDuration)
+	var rejectedErr *vaultinternal.RejectedError
+	if errors.As(err, &rejectedErr) {
+		message := "Vault refused to sign certificate"
+
+		v.reporter.Failed(cr, err, reasonRequestRejected, message)
+		log.Error(err, message)
+
+		return nil, nil
+	}
 	if err != nil {
 		message := "Vault failed to sign certificate"
 
@@ -0,0 +1,200 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/util/predicate"
+)
+
+// CertificateSecretNameIndex is the name of the Certificate informer index
+// that maps a Secret's namespace and name to the Certificates that name it as
+// their spec.secretName.
+const CertificateSecretNameIndex = "certificate-secret-name"
+
+// certificateSecretNameIndexFunc indexes Certificates by the namespaced name
+// of their spec.secretName.
+func certificateSecretNameIndexFunc(obj interface{}) ([]string, error) {
+	crt, ok := obj.(*cmapi.Certificate)
+	if !ok {
+		return nil, fmt.Errorf("expected a Certificate, got %T", obj)
+	}
+	if crt.Spec.SecretName == "" {
+		return nil, nil
+	}
+	return []string{secretIndexKey(crt.Namespace, crt.Spec.SecretName)}, nil
+}
+
+func secretIndexKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// AddCertificateSecretNameIndex adds the CertificateSecretNameIndex to the
+// given Certificate informer. The index is maintained incrementally by the
+// informer as Certificates are added, updated and deleted. It is safe to
+// call this function multiple times for the same informer.
+func AddCertificateSecretNameIndex(informer cache.SharedIndexInformer) error {
+	if _, ok := informer.GetIndexer().GetIndexers()[CertificateSecretNameIndex]; ok {
+		return nil
+	}
+	return informer.AddIndexers(cache.Indexers{
+		CertificateSecretNameIndex: certificateSecretNameIndexFunc,
+	})
+}
+
+// CertificatesForSecret returns the Certificates in the given namespace that
+// name the given Secret as their spec.secretName. The indexer must have the
+// CertificateSecretNameIndex, see AddCertificateSecretNameIndex.
+func CertificatesForSecret(indexer cache.Indexer, namespace, secretName string) ([]*cmapi.Certificate, error) {
+	objs, err := indexer.ByIndex(CertificateSecretNameIndex, secretIndexKey(namespace, secretName))
+	if err != nil {
+		return nil, err
+	}
+
+	crts := make([]*cmapi.Certificate, 0, len(objs))
+	for _, obj := range objs {
+		crt, ok := obj.(*cmapi.Certificate)
+		if !ok {
+			return nil, fmt.Errorf("expected a Certificate in the indexer, got %T", obj)
+		}
+		crts = append(crts, crt)
+	}
+	return crts, nil
+}
+
+// CertificateIssuerIndex is the name of the Certificate informer index that
+// maps a cert-manager Issuer or ClusterIssuer to the Certificates that
+// reference it in their spec.issuerRef.
+const CertificateIssuerIndex = "certificate-issuer"
+
+// certificateIssuerIndexFunc indexes Certificates by the kind, namespace and
+// name of the issuer they reference. Certificates referencing external
+// issuers are not indexed.
+func certificateIssuerIndexFunc(obj interface{}) ([]string, error) {
+	crt, ok := obj.(*cmapi.Certificate)
+	if !ok {
+		return nil, fmt.Errorf("expected a Certificate, got %T", obj)
+	}
+	ref := crt.Spec.IssuerRef
+	if ref.Group != "" && ref.Group != certmanager.GroupName {
+		return nil, nil
+	}
+	switch ref.Kind {
+	case "", cmapi.IssuerKind:
+		return []string{issuerIndexKey(cmapi.IssuerKind, crt.Namespace, ref.Name)}, nil
+	case cmapi.ClusterIssuerKind:
+		return []string{issuerIndexKey(cmapi.ClusterIssuerKind, "", ref.Name)}, nil
+	default:
+		return nil, nil
+	}
+}
+
+func issuerIndexKey(kind, namespace, name string) string {
+	return kind + "/" + namespace + "/" + name
+}
+
+// AddCertificateIssuerIndex adds the CertificateIssuerIndex to the given
+// Certificate informer. It is safe to call this function multiple times for
+// the same informer.
+func AddCertificateIssuerIndex(informer cache.SharedIndexInformer) error {
+	if _, ok := informer.GetIndexer().GetIndexers()[CertificateIssuerIndex]; ok {
+		return nil
+	}
+	return informer.AddIndexers(cache.Indexers{
+		CertificateIssuerIndex: certificateIssuerIndexFunc,
+	})
+}
+
+// CertificatesForIssuer returns the Certificates that reference the given
+// Issuer or ClusterIssuer. The indexer must have the CertificateIssuerIndex,
+// see AddCertificateIssuerIndex.
+func CertificatesForIssuer(indexer cache.Indexer, iss cmapi.GenericIssuer) ([]*cmapi.Certificate, error) {
+	var key string
+	switch iss.(type) {
+	case *cmapi.Issuer:
+		key = issuerIndexKey(cmapi.IssuerKind, iss.GetNamespace(), iss.GetName())
+	case *cmapi.ClusterIssuer:
+		key = issuerIndexKey(cmapi.ClusterIssuerKind, "", iss.GetName())
+	default:
+		return nil, fmt.Errorf("unexpected issuer type %T", iss)
+	}
+
+	objs, err := indexer.ByIndex(CertificateIssuerIndex, key)
+	if err != nil {
+		return nil, err
+	}
+
+	crts := make([]*cmapi.Certificate, 0, len(objs))
+	for _, obj := range objs {
+		crt, ok := obj.(*cmapi.Certificate)
+		if !ok {
+			return nil, fmt.Errorf("expected a Certificate in the indexer, got %T", obj)
+		}
+		crts = append(crts, crt)
+	}
+	return crts, nil
+}
+
+// EnqueueCertificatesForSecret will return a function that can be used as an
+// OnAdd handler for a Secret SharedIndexInformer. It enqueues the Certificates
+// that name the Secret as their spec.secretName, using the
+// CertificateSecretNameIndex rather than listing all Certificates in the
+// Secret's namespace. If the index cannot be added to the Certificate
+// informer, the Certificates are listed instead.
+func EnqueueCertificatesForSecret(log logr.Logger, queue workqueue.Interface, certificateInformer cminformers.CertificateInformer) func(obj interface{}) {
+	if err := AddCertificateSecretNameIndex(certificateInformer.Informer()); err != nil {
+		log.Error(err, "Failed adding Certificate index, falling back to listing Certificates")
+		return EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
+			predicate.ExtractResourceName(predicate.CertificateSecretName))
+	}
+
+	indexer := certificateInformer.Informer().GetIndexer()
+	return func(obj interface{}) {
+		s, ok := obj.(metav1.Object)
+		if !ok {
+			log.V(logf.ErrorLevel).Info("Non-Object type resource passed to EnqueueCertificatesForSecret")
+			return
+		}
+
+		crts, err := CertificatesForSecret(indexer, s.GetNamespace(), s.GetName())
+		if err != nil {
+			log.Error(err, "Failed looking up Certificate resources for Secret")
+			return
+		}
+
+		for _, crt := range crts {
+			key, err := controllerpkg.KeyFunc(crt)
+			if err != nil {
+				log.Error(err, "Error determining 'key' for resource")
+				continue
+			}
+			queue.Add(key)
+		}
+	}
+}
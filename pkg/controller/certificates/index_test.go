@@ -0,0 +1,157 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func names(crts []*cmapi.Certificate) []string {
+	var out []string
+	for _, crt := range crts {
+		out = append(out, crt.Name)
+	}
+	return out
+}
+
+func TestCertificatesForSecret(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{
+		CertificateSecretNameIndex: certificateSecretNameIndexFunc,
+	})
+
+	crt1 := gen.Certificate("crt-1", gen.SetCertificateNamespace("ns-1"), gen.SetCertificateSecretName("secret-1"))
+	crt2 := gen.Certificate("crt-2", gen.SetCertificateNamespace("ns-1"), gen.SetCertificateSecretName("secret-1"))
+	crt3 := gen.Certificate("crt-3", gen.SetCertificateNamespace("ns-2"), gen.SetCertificateSecretName("secret-1"))
+	for _, crt := range []*cmapi.Certificate{crt1, crt2, crt3} {
+		require.NoError(t, indexer.Add(crt))
+	}
+
+	got, err := CertificatesForSecret(indexer, "ns-1", "secret-1")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"crt-1", "crt-2"}, names(got))
+
+	got, err = CertificatesForSecret(indexer, "ns-2", "secret-1")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"crt-3"}, names(got))
+
+	// Changing the secretName of a Certificate should move it in the index.
+	require.NoError(t, indexer.Update(gen.CertificateFrom(crt2, gen.SetCertificateSecretName("secret-2"))))
+
+	got, err = CertificatesForSecret(indexer, "ns-1", "secret-1")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"crt-1"}, names(got))
+
+	got, err = CertificatesForSecret(indexer, "ns-1", "secret-2")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"crt-2"}, names(got))
+
+	// Deleting a Certificate should remove it from the index.
+	require.NoError(t, indexer.Delete(crt1))
+
+	got, err = CertificatesForSecret(indexer, "ns-1", "secret-1")
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestCertificatesForIssuer(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{
+		CertificateIssuerIndex: certificateIssuerIndexFunc,
+	})
+
+	for _, crt := range []*cmapi.Certificate{
+		gen.Certificate("defaulted-kind", gen.SetCertificateNamespace("ns-1"), gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca"})),
+		gen.Certificate("issuer", gen.SetCertificateNamespace("ns-1"), gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca", Kind: "Issuer", Group: "cert-manager.io"})),
+		gen.Certificate("other-namespace", gen.SetCertificateNamespace("ns-2"), gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca"})),
+		gen.Certificate("cluster-issuer-1", gen.SetCertificateNamespace("ns-1"), gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca", Kind: "ClusterIssuer"})),
+		gen.Certificate("cluster-issuer-2", gen.SetCertificateNamespace("ns-2"), gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca", Kind: "ClusterIssuer"})),
+		gen.Certificate("external", gen.SetCertificateNamespace("ns-1"), gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca", Kind: "Issuer", Group: "example.com"})),
+	} {
+		require.NoError(t, indexer.Add(crt))
+	}
+
+	got, err := CertificatesForIssuer(indexer, gen.Issuer("ca", gen.SetIssuerNamespace("ns-1")))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"defaulted-kind", "issuer"}, names(got))
+
+	got, err = CertificatesForIssuer(indexer, gen.Issuer("ca", gen.SetIssuerNamespace("ns-2")))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"other-namespace"}, names(got))
+
+	got, err = CertificatesForIssuer(indexer, gen.ClusterIssuer("ca"))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"cluster-issuer-1", "cluster-issuer-2"}, names(got))
+}
+
+func TestEnqueueCertificatesForSecret(t *testing.T) {
+	factory := cminformers.NewSharedInformerFactory(cmfake.NewSimpleClientset(), time.Second)
+	certificateInformer := factory.Certmanager().V1().Certificates()
+	queue := workqueue.New()
+	defer queue.ShutDown()
+
+	handler := EnqueueCertificatesForSecret(logr.Discard(), queue, certificateInformer)
+
+	// Adding the index a second time should be a no-op.
+	require.NoError(t, AddCertificateSecretNameIndex(certificateInformer.Informer()))
+
+	indexer := certificateInformer.Informer().GetIndexer()
+	require.NoError(t, indexer.Add(gen.Certificate("crt-1", gen.SetCertificateNamespace("ns-1"), gen.SetCertificateSecretName("secret-1"))))
+	require.NoError(t, indexer.Add(gen.Certificate("crt-2", gen.SetCertificateNamespace("ns-1"), gen.SetCertificateSecretName("secret-2"))))
+
+	handler(gen.Secret("secret-1", gen.SetSecretNamespace("ns-1")))
+
+	require.Equal(t, 1, queue.Len())
+	key, _ := queue.Get()
+	assert.Equal(t, "ns-1/crt-1", key)
+}
+
+func BenchmarkCertificatesForSecret(b *testing.B) {
+	for _, n := range []int{100, 1000, 10000} {
+		b.Run(fmt.Sprintf("certificates=%d", n), func(b *testing.B) {
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{
+				CertificateSecretNameIndex: certificateSecretNameIndexFunc,
+			})
+			for i := 0; i < n; i++ {
+				name := fmt.Sprintf("crt-%d", i)
+				if err := indexer.Add(gen.Certificate(name, gen.SetCertificateNamespace("ns"), gen.SetCertificateSecretName(name))); err != nil {
+					b.Fatal(err)
+				}
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				crts, err := CertificatesForSecret(indexer, "ns", "crt-0")
+				if err != nil || len(crts) != 1 {
+					b.Fatalf("unexpected result: %v, %v", crts, err)
+				}
+			}
+		})
+	}
+}
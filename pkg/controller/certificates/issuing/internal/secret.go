@@ -17,9 +17,13 @@ limitations under the License.
 package internal
 
 import (
+	"bytes"
 	"context"
 	"crypto/x509"
 	"fmt"
+	"maps"
+	"sort"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -27,10 +31,12 @@ import (
 	applycorev1 "k8s.io/client-go/applyconfigurations/core/v1"
 	applymetav1 "k8s.io/client-go/applyconfigurations/meta/v1"
 	coreclient "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/util/retry"
 
 	"github.com/cert-manager/cert-manager/internal/controller/certificates"
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
@@ -55,6 +61,10 @@ type SecretsManager struct {
 	// Secret resource will be automatically deleted.
 	// This option is disabled by default.
 	enableSecretOwnerReferences bool
+
+	// maxSecretSize is the maximum size in bytes of the data written to a
+	// Secret. A zero value disables the check.
+	maxSecretSize int
 }
 
 // SecretData is a structure wrapping private key, Certificate and CA data
@@ -62,22 +72,82 @@ type SecretData struct {
 	PrivateKey, Certificate, CA         []byte
 	CertificateName                     string
 	IssuerName, IssuerKind, IssuerGroup string
+
+	// PrivateKeyReference is the reference to the private key held by an
+	// external KMS, for Certificates which set spec.privateKey.external.
+	PrivateKeyReference []byte
+
+	// PrivateKeyDefaulted records that the private key was issued whilst
+	// the Certificate left it to the controller's defaults.
+	PrivateKeyDefaulted bool
+
+	// ConvertOpaqueSecret allows an existing Secret of type Opaque to be
+	// re-created as a `kubernetes.io/tls` Secret, provided that it is empty
+	// or holds a prior version of the Certificate.
+	ConvertOpaqueSecret bool
+}
+
+// SecretTypeConflictError is returned by UpdateData if the Certificate's
+// Secret is of type Opaque and contains data unrelated to the Certificate, so
+// cannot be converted to a `kubernetes.io/tls` Secret.
+type SecretTypeConflictError struct {
+	Name string
+}
+
+func (e *SecretTypeConflictError) Error() string {
+	return fmt.Sprintf("Secret %q is of type %q and contains data unrelated to this Certificate, so it cannot be converted to a %q Secret. Delete the Secret, or set spec.secretName to a different Secret",
+		e.Name, corev1.SecretTypeOpaque, corev1.SecretTypeTLS)
+}
+
+// SecretTooLargeError is returned by UpdateData if the data of a Secret would
+// exceed the maximum Secret size, so that it would be rejected by the
+// apiserver.
+type SecretTooLargeError struct {
+	Name          string
+	Size, MaxSize int
+	// KeySizes holds the size in bytes of each data key, largest first.
+	KeySizes []SecretKeySize
+	// HasKeystores is true if the Secret holds keystores which could be
+	// moved to a separate Secret with spec.keystores.secretName.
+	HasKeystores bool
+}
+
+// SecretKeySize is the size in bytes of the value of a Secret data key.
+type SecretKeySize struct {
+	Key  string
+	Size int
+}
+
+func (e *SecretTooLargeError) Error() string {
+	sizes := make([]string, 0, len(e.KeySizes))
+	for _, ks := range e.KeySizes {
+		sizes = append(sizes, fmt.Sprintf("%s=%d", ks.Key, ks.Size))
+	}
+	msg := fmt.Sprintf("Secret %q would be %d bytes, which exceeds the maximum Secret size of %d bytes (%s)",
+		e.Name, e.Size, e.MaxSize, strings.Join(sizes, ", "))
+	if e.HasKeystores {
+		msg += ". Set spec.keystores.secretName to write the keystores to a separate Secret"
+	}
+	return msg
 }
 
 // NewSecretsManager returns a new SecretsManager. Setting
 // enableSecretOwnerReferences to true will mean that secrets will be deleted
-// when the corresponding Certificate is deleted.
+// when the corresponding Certificate is deleted. Secrets whose data would
+// exceed maxSecretSize bytes are not written, unless maxSecretSize is zero.
 func NewSecretsManager(
 	secretClient coreclient.SecretsGetter,
 	secretLister internalinformers.SecretLister,
 	fieldManager string,
 	enableSecretOwnerReferences bool,
+	maxSecretSize int,
 ) *SecretsManager {
 	return &SecretsManager{
 		secretClient:                secretClient,
 		secretLister:                secretLister,
 		fieldManager:                fieldManager,
 		enableSecretOwnerReferences: enableSecretOwnerReferences,
+		maxSecretSize:               maxSecretSize,
 	}
 }
 
@@ -98,22 +168,57 @@ func (s *SecretsManager) UpdateData(ctx context.Context, crt *cmapi.Certificate,
 		return err
 	}
 
+	// Keystores are written to a separate Secret if one is configured, which
+	// is applied before the Certificate's Secret.
+	var keystoresApplyCnf *applycorev1.SecretApplyConfiguration
+	if usesKeystoresSecret(crt) {
+		keystores, err := s.keystoresSecret(crt, data)
+		if err != nil {
+			return err
+		}
+		if err := s.checkSecretSize(keystores); err != nil {
+			return err
+		}
+		keystoresApplyCnf = s.withOwnerReference(crt, applycorev1.Secret(keystores.Name, keystores.Namespace).
+			WithAnnotations(keystores.Annotations).WithLabels(keystores.Labels).
+			WithData(keystores.Data).WithType(keystores.Type))
+	}
+
+	if err := s.checkSecretSize(secret); err != nil {
+		return err
+	}
+
+	if data.ConvertOpaqueSecret {
+		if err := s.convertOpaqueSecret(ctx, crt, secret); err != nil {
+			return err
+		}
+	}
+
 	// Build Secret apply configuration and options.
 	applyOpts := metav1.ApplyOptions{FieldManager: s.fieldManager, Force: true}
-	applyCnf := applycorev1.Secret(secret.Name, secret.Namespace).
+	applyCnf := s.withOwnerReference(crt, applycorev1.Secret(secret.Name, secret.Namespace).
 		WithAnnotations(secret.Annotations).WithLabels(secret.Labels).
-		WithData(secret.Data).WithType(secret.Type)
+		WithData(secret.Data).WithType(secret.Type))
 
-	// If Secret owner reference is enabled, set it on the Secret. This results
-	// in a no-op if the Secret already exists and has the owner reference set,
-	// and visa-versa.
-	if s.enableSecretOwnerReferences {
-		ref := *metav1.NewControllerRef(crt, certificateGvk)
-		applyCnf = applyCnf.WithOwnerReferences(&applymetav1.OwnerReferenceApplyConfiguration{
-			APIVersion: &ref.APIVersion, Kind: &ref.Kind,
-			Name: &ref.Name, UID: &ref.UID,
-			Controller: ref.Controller, BlockOwnerDeletion: ref.BlockOwnerDeletion,
-		})
+	if certificates.UsesImmutableSecret(crt.Spec) {
+		applyCnf = applyCnf.WithImmutable(true)
+	}
+
+	rotate, err := s.immutableSecretNeedsRotation(crt, secret)
+	if err != nil {
+		return err
+	}
+
+	if keystoresApplyCnf != nil {
+		log.V(logf.DebugLevel).Info("applying keystores secret", "keystores_secret", crt.Spec.Keystores.SecretName)
+		if _, err := s.secretClient.Secrets(secret.Namespace).Apply(ctx, keystoresApplyCnf, applyOpts); err != nil {
+			return fmt.Errorf("failed to apply keystores secret %s/%s: %w", secret.Namespace, crt.Spec.Keystores.SecretName, err)
+		}
+	}
+
+	if rotate {
+		log.V(logf.DebugLevel).Info("re-creating immutable secret")
+		return s.rotateImmutableSecret(ctx, crt, secret, applyCnf, applyOpts)
 	}
 
 	log.V(logf.DebugLevel).Info("applying secret")
@@ -126,6 +231,89 @@ func (s *SecretsManager) UpdateData(ctx context.Context, crt *cmapi.Certificate,
 	return nil
 }
 
+// withOwnerReference sets the Certificate as the owner of the Secret if Secret
+// owner references are enabled. This results in a no-op if the Secret already
+// exists and has the owner reference set, and visa-versa.
+func (s *SecretsManager) withOwnerReference(crt *cmapi.Certificate, applyCnf *applycorev1.SecretApplyConfiguration) *applycorev1.SecretApplyConfiguration {
+	if !s.enableSecretOwnerReferences {
+		return applyCnf
+	}
+	ref := *metav1.NewControllerRef(crt, certificateGvk)
+	return applyCnf.WithOwnerReferences(&applymetav1.OwnerReferenceApplyConfiguration{
+		APIVersion: &ref.APIVersion, Kind: &ref.Kind,
+		Name: &ref.Name, UID: &ref.UID,
+		Controller: ref.Controller, BlockOwnerDeletion: ref.BlockOwnerDeletion,
+	})
+}
+
+// checkSecretSize returns a SecretTooLargeError if the data of the Secret
+// exceeds the maximum Secret size. As in the apiserver, only the size of the
+// data values is counted.
+func (s *SecretsManager) checkSecretSize(secret *corev1.Secret) error {
+	if s.maxSecretSize <= 0 {
+		return nil
+	}
+
+	size := 0
+	keySizes := make([]SecretKeySize, 0, len(secret.Data))
+	for k, v := range secret.Data {
+		size += len(v)
+		keySizes = append(keySizes, SecretKeySize{Key: k, Size: len(v)})
+	}
+	if size <= s.maxSecretSize {
+		return nil
+	}
+
+	sort.Slice(keySizes, func(i, j int) bool {
+		if keySizes[i].Size != keySizes[j].Size {
+			return keySizes[i].Size > keySizes[j].Size
+		}
+		return keySizes[i].Key < keySizes[j].Key
+	})
+	hasKeystores := false
+	for _, k := range []string{cmapi.PKCS12SecretKey, cmapi.PKCS12TruststoreKey, cmapi.JKSSecretKey, cmapi.JKSTruststoreKey} {
+		if _, ok := secret.Data[k]; ok {
+			hasKeystores = true
+		}
+	}
+	return &SecretTooLargeError{
+		Name:         secret.Name,
+		Size:         size,
+		MaxSize:      s.maxSecretSize,
+		KeySizes:     keySizes,
+		HasKeystores: hasKeystores,
+	}
+}
+
+// usesKeystoresSecret returns true if the Certificate's keystores are written
+// to a Secret other than the Certificate's Secret.
+func usesKeystoresSecret(crt *cmapi.Certificate) bool {
+	return crt.Spec.Keystores != nil && crt.Spec.Keystores.SecretName != ""
+}
+
+// keystoresSecret returns the Secret holding the Certificate's keystores when
+// they are written to a separate Secret, ready to be applied.
+func (s *SecretsManager) keystoresSecret(crt *cmapi.Certificate, data SecretData) (*corev1.Secret, error) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      crt.Spec.Keystores.SecretName,
+			Namespace: crt.Namespace,
+			Annotations: map[string]string{
+				cmapi.CertificateNameKey: crt.Name,
+			},
+			Labels: map[string]string{
+				cmapi.PartOfCertManagerControllerLabelKey: "true",
+			},
+		},
+		Data: make(map[string][]byte),
+		Type: corev1.SecretTypeOpaque,
+	}
+	if err := s.setKeystores(crt, secret, data); err != nil {
+		return nil, fmt.Errorf("failed to add keystores to Secret: %w", err)
+	}
+	return secret, nil
+}
+
 // setValues will update the Secret resource 'secret' with the data contained
 // in the given secretData.
 // It will update labels and annotations on the Secret resource appropriately.
@@ -135,8 +323,11 @@ func (s *SecretsManager) UpdateData(ctx context.Context, crt *cmapi.Certificate,
 // It will also update depreciated issuer name and kind annotations if they
 // exist.
 func (s *SecretsManager) setValues(crt *cmapi.Certificate, secret *corev1.Secret, data SecretData) error {
-	if err := s.setKeystores(crt, secret, data); err != nil {
-		return fmt.Errorf("failed to add keystores to Secret: %w", err)
+	// Keystores written to a separate Secret are set by UpdateData.
+	if !usesKeystoresSecret(crt) {
+		if err := s.setKeystores(crt, secret, data); err != nil {
+			return fmt.Errorf("failed to add keystores to Secret: %w", err)
+		}
 	}
 
 	// Add additional output formats if feature enabled.
@@ -146,7 +337,18 @@ func (s *SecretsManager) setValues(crt *cmapi.Certificate, secret *corev1.Secret
 		}
 	}
 
-	secret.Data[corev1.TLSPrivateKeyKey] = data.PrivateKey
+	switch {
+	case crt.Spec.ExternalCSR != nil:
+		// The private key is held outside of the cluster. The key is still
+		// written, empty, as it is required by kubernetes.io/tls Secrets.
+		secret.Data[corev1.TLSPrivateKeyKey] = []byte{}
+	case certificates.UsesExternalPrivateKey(crt.Spec):
+		// Only the reference to the private key held by the KMS is stored.
+		secret.Data[corev1.TLSPrivateKeyKey] = []byte{}
+		secret.Data[cmapi.PrivateKeyReferenceSecretKey] = data.PrivateKeyReference
+	default:
+		secret.Data[corev1.TLSPrivateKeyKey] = data.PrivateKey
+	}
 	secret.Data[corev1.TLSCertKey] = data.Certificate
 	if len(data.CA) > 0 {
 		secret.Data[cmmeta.TLSCAKey] = data.CA
@@ -194,10 +396,17 @@ func (s *SecretsManager) setValues(crt *cmapi.Certificate, secret *corev1.Secret
 	if data.CertificateName != "" {
 		secret.Annotations[cmapi.CertificateNameKey] = data.CertificateName
 	}
+	// The issuer kind and group are always written in canonical form, which
+	// also repairs annotations written by older versions, e.g. with an empty
+	// group.
 	if data.IssuerName != "" || data.IssuerKind != "" || data.IssuerGroup != "" {
+		issuerKind, issuerGroup := apiutil.NormalizeIssuerKindAndGroup(data.IssuerKind, data.IssuerGroup)
 		secret.Annotations[cmapi.IssuerNameAnnotationKey] = data.IssuerName
-		secret.Annotations[cmapi.IssuerKindAnnotationKey] = data.IssuerKind
-		secret.Annotations[cmapi.IssuerGroupAnnotationKey] = data.IssuerGroup
+		secret.Annotations[cmapi.IssuerKindAnnotationKey] = issuerKind
+		secret.Annotations[cmapi.IssuerGroupAnnotationKey] = issuerGroup
+	}
+	if data.PrivateKeyDefaulted {
+		secret.Annotations[cmapi.PrivateKeyDefaultedAnnotationKey] = "true"
 	}
 
 	secret.Labels[cmapi.PartOfCertManagerControllerLabelKey] = "true"
@@ -244,6 +453,253 @@ func (s *SecretsManager) getCertificateSecret(crt *cmapi.Certificate) (*corev1.S
 	}, nil
 }
 
+// convertOpaqueSecret re-creates the Certificate's existing Opaque Secret as a
+// `kubernetes.io/tls` Secret, as the type of a Secret is immutable. Labels,
+// annotations, owner references and data keys of the existing Secret are
+// carried over, with the data in 'secret' taking precedence.
+// The converted Secret is staged in the rotation Secret before the existing
+// Secret is deleted, so that nothing is lost if it cannot be re-created, and
+// policies do not mistake the missing Secret for one that needs to be issued.
+// A conversion that failed after the deletion is completed from the rotation
+// Secret on the next attempt. The existing Secret is only deleted if it has
+// not changed since it was read, so that no concurrent write is lost.
+// A SecretTypeConflictError is returned if the existing Secret contains data
+// unrelated to the Certificate.
+func (s *SecretsManager) convertOpaqueSecret(ctx context.Context, crt *cmapi.Certificate, secret *corev1.Secret) error {
+	rotationName := certificates.RotationSecretName(crt.Spec.SecretName)
+	existing, err := s.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
+	if apierrors.IsNotFound(err) {
+		return s.restoreConvertedSecret(ctx, crt, rotationName)
+	}
+	if err != nil {
+		return err
+	}
+	if existing.Type != corev1.SecretTypeOpaque {
+		return nil
+	}
+
+	if !opaqueSecretConvertible(crt, existing) {
+		return &SecretTypeConflictError{Name: existing.Name}
+	}
+
+	data := make(map[string][]byte, len(existing.Data)+len(secret.Data))
+	for k, v := range existing.Data {
+		data[k] = v
+	}
+	for k, v := range secret.Data {
+		data[k] = v
+	}
+	// The Certificate name annotation marks the rotation Secret as belonging
+	// to the Certificate.
+	annotations := make(map[string]string, len(existing.Annotations)+1)
+	for k, v := range existing.Annotations {
+		annotations[k] = v
+	}
+	annotations[cmapi.CertificateNameKey] = crt.Name
+	converted := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            existing.Name,
+			Namespace:       existing.Namespace,
+			Labels:          existing.Labels,
+			Annotations:     annotations,
+			OwnerReferences: existing.OwnerReferences,
+		},
+		Data: data,
+		Type: corev1.SecretTypeTLS,
+	}
+
+	if err := s.createRotationSecret(ctx, crt, converted, rotationName); err != nil {
+		return err
+	}
+
+	err = s.secretClient.Secrets(existing.Namespace).Delete(ctx, existing.Name, metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{UID: &existing.UID, ResourceVersion: &existing.ResourceVersion},
+	})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete Opaque secret %s/%s: %w", existing.Namespace, existing.Name, err)
+	}
+	// The Secret is re-created rather than applied, so that the keys and
+	// metadata carried over from the Opaque Secret are not owned by the
+	// Apply operations of the field manager, and are not removed by the
+	// next Apply which no longer sets them. cert-manager's data is applied
+	// right after by UpdateData.
+	if _, err := s.secretClient.Secrets(existing.Namespace).Create(ctx, converted, metav1.CreateOptions{FieldManager: s.fieldManager}); err != nil {
+		return fmt.Errorf("failed to re-create secret %s/%s as type %s: %w", existing.Namespace, existing.Name, corev1.SecretTypeTLS, err)
+	}
+
+	err = s.secretClient.Secrets(existing.Namespace).Delete(ctx, rotationName, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete rotation secret %s/%s: %w", existing.Namespace, rotationName, err)
+	}
+
+	secret.Type = corev1.SecretTypeTLS
+	return nil
+}
+
+// restoreConvertedSecret re-creates the Certificate's missing Secret from a
+// rotation Secret belonging to the Certificate, which is left behind if an
+// Opaque Secret was deleted but could not be re-created. The rotation Secret
+// is deleted once the Secret exists.
+func (s *SecretsManager) restoreConvertedSecret(ctx context.Context, crt *cmapi.Certificate, rotationName string) error {
+	rotation, err := s.secretLister.Secrets(crt.Namespace).Get(rotationName)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if rotation.Annotations[cmapi.CertificateNameKey] != crt.Name {
+		return nil
+	}
+
+	restored := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            crt.Spec.SecretName,
+			Namespace:       crt.Namespace,
+			Labels:          rotation.Labels,
+			Annotations:     rotation.Annotations,
+			OwnerReferences: rotation.OwnerReferences,
+		},
+		Data: rotation.Data,
+		Type: rotation.Type,
+	}
+	// Created rather than applied for the same reason as in
+	// convertOpaqueSecret.
+	_, err = s.secretClient.Secrets(crt.Namespace).Create(ctx, restored, metav1.CreateOptions{FieldManager: s.fieldManager})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to restore secret %s/%s from rotation secret: %w", crt.Namespace, crt.Spec.SecretName, err)
+	}
+
+	err = s.secretClient.Secrets(crt.Namespace).Delete(ctx, rotationName, metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{UID: &rotation.UID},
+	})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete rotation secret %s/%s: %w", crt.Namespace, rotationName, err)
+	}
+	return nil
+}
+
+// immutableSecretNeedsRotation returns true if the Certificate's existing
+// Secret is immutable, so that it cannot be updated to hold the data of
+// 'secret', or to no longer be immutable, and has to be re-created instead.
+func (s *SecretsManager) immutableSecretNeedsRotation(crt *cmapi.Certificate, secret *corev1.Secret) (bool, error) {
+	existing, err := s.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if existing.Immutable == nil || !*existing.Immutable {
+		return false, nil
+	}
+	return !certificates.UsesImmutableSecret(crt.Spec) || !maps.EqualFunc(existing.Data, secret.Data, bytes.Equal), nil
+}
+
+// rotateImmutableSecret replaces the Certificate's immutable Secret with one
+// built from applyCnf. The data of 'secret' is first staged in a rotation
+// Secret, which policies evaluate in place of the Secret whilst it is
+// missing, so that the swap does not trigger another issuance. The Secret is
+// then deleted and re-created, retrying if it is modified concurrently.
+// Finally the rotation Secret is deleted. If the swap fails, the rotation
+// Secret is left in place and replaced on the next attempt.
+func (s *SecretsManager) rotateImmutableSecret(ctx context.Context, crt *cmapi.Certificate, secret *corev1.Secret, applyCnf *applycorev1.SecretApplyConfiguration, applyOpts metav1.ApplyOptions) error {
+	rotationName := certificates.RotationSecretName(secret.Name)
+	if err := s.createRotationSecret(ctx, crt, secret, rotationName); err != nil {
+		return err
+	}
+
+	err := retry.OnError(retry.DefaultRetry, apierrors.IsConflict, func() error {
+		existing, err := s.secretClient.Secrets(secret.Namespace).Get(ctx, secret.Name, metav1.GetOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		if err == nil {
+			// Only delete the Secret if it has not changed since it was read,
+			// so that a concurrent write is not lost. A Conflict is returned
+			// otherwise, and the Secret is read again.
+			err = s.secretClient.Secrets(secret.Namespace).Delete(ctx, secret.Name, metav1.DeleteOptions{
+				Preconditions: &metav1.Preconditions{UID: &existing.UID, ResourceVersion: &existing.ResourceVersion},
+			})
+			if err != nil && !apierrors.IsNotFound(err) {
+				return err
+			}
+		}
+		_, err = s.secretClient.Secrets(secret.Namespace).Apply(ctx, applyCnf, applyOpts)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to re-create immutable secret %s/%s: %w", secret.Namespace, secret.Name, err)
+	}
+
+	err = s.secretClient.Secrets(secret.Namespace).Delete(ctx, rotationName, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete rotation secret %s/%s: %w", secret.Namespace, rotationName, err)
+	}
+
+	return nil
+}
+
+// createRotationSecret applies the rotation Secret holding the labels,
+// annotations, owner references and data of 'secret'. A rotation Secret left
+// behind by a failed swap is deleted first, provided that it belongs to the
+// Certificate, so that none of its data is carried over.
+func (s *SecretsManager) createRotationSecret(ctx context.Context, crt *cmapi.Certificate, secret *corev1.Secret, name string) error {
+	existing, err := s.secretClient.Secrets(secret.Namespace).Get(ctx, name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+	case err != nil:
+		return fmt.Errorf("failed to get rotation secret %s/%s: %w", secret.Namespace, name, err)
+	case existing.Annotations[cmapi.CertificateNameKey] != crt.Name:
+		return fmt.Errorf("rotation secret %s/%s already exists and does not belong to Certificate %q", secret.Namespace, name, crt.Name)
+	default:
+		err = s.secretClient.Secrets(secret.Namespace).Delete(ctx, name, metav1.DeleteOptions{
+			Preconditions: &metav1.Preconditions{UID: &existing.UID, ResourceVersion: &existing.ResourceVersion},
+		})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete stale rotation secret %s/%s: %w", secret.Namespace, name, err)
+		}
+	}
+
+	applyCnf := applycorev1.Secret(name, secret.Namespace).
+		WithAnnotations(secret.Annotations).WithLabels(secret.Labels).
+		WithData(secret.Data).WithType(secret.Type)
+	for i := range secret.OwnerReferences {
+		ref := secret.OwnerReferences[i]
+		applyCnf = applyCnf.WithOwnerReferences(&applymetav1.OwnerReferenceApplyConfiguration{
+			APIVersion: &ref.APIVersion, Kind: &ref.Kind, Name: &ref.Name, UID: &ref.UID,
+			Controller: ref.Controller, BlockOwnerDeletion: ref.BlockOwnerDeletion,
+		})
+	}
+	if metav1.GetControllerOfNoCopy(secret) == nil {
+		applyCnf = s.withOwnerReference(crt, applyCnf)
+	}
+
+	_, err = s.secretClient.Secrets(secret.Namespace).Apply(ctx, applyCnf, metav1.ApplyOptions{FieldManager: s.fieldManager, Force: true})
+	if err != nil {
+		return fmt.Errorf("failed to apply rotation secret %s/%s: %w", secret.Namespace, name, err)
+	}
+	return nil
+}
+
+// opaqueSecretConvertible returns true if the Opaque Secret can be taken over
+// by the Certificate. That is the case if the Secret is empty, was written by
+// cert-manager for this Certificate, or holds a certificate for the
+// Certificate's names, for example from a previous manual process.
+func opaqueSecretConvertible(crt *cmapi.Certificate, secret *corev1.Secret) bool {
+	if len(secret.Data) == 0 {
+		return true
+	}
+	if secret.Annotations[cmapi.CertificateNameKey] == crt.Name {
+		return true
+	}
+	if len(secret.Data[corev1.TLSCertKey]) == 0 {
+		return false
+	}
+	violations, err := utilpki.SecretDataAltNamesMatchSpec(secret, crt.Spec)
+	return err == nil && len(violations) == 0
+}
+
 // setKeystores will set extra Secret Data keys according to any Keystores
 // which have been configured.
 func (s *SecretsManager) setKeystores(crt *cmapi.Certificate, secret *corev1.Secret, data SecretData) error {
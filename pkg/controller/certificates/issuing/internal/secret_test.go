@@ -17,10 +17,15 @@ limitations under the License.
 package internal
 
 import (
+	"bytes"
 	"context"
 	"encoding/pem"
 	"errors"
+	"reflect"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -31,6 +36,7 @@ import (
 	apitypes "k8s.io/apimachinery/pkg/types"
 	applycorev1 "k8s.io/client-go/applyconfigurations/core/v1"
 	applymetav1 "k8s.io/client-go/applyconfigurations/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
 	fakeclock "k8s.io/utils/clock/testing"
 	"k8s.io/utils/ptr"
 
@@ -155,6 +161,155 @@ func Test_SecretsManager(t *testing.T) {
 			expectedErr: false,
 		},
 
+		"if secret does not exist and the private key was defaulted, create new Secret with the private key defaulted annotation": {
+			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: false},
+			certificate:        baseCertBundle.Certificate,
+			existingSecret:     nil,
+			secretData: SecretData{
+				Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKey: []byte("test-key"),
+				CertificateName: "test", IssuerName: "ca-issuer", IssuerKind: "Issuer", IssuerGroup: "foo.io",
+				PrivateKeyDefaulted: true,
+			},
+			applyFn: func(t *testing.T) testcoreclients.ApplyFn {
+				return func(_ context.Context, gotCnf *applycorev1.SecretApplyConfiguration, gotOpts metav1.ApplyOptions) (*corev1.Secret, error) {
+					expCnf := applycorev1.Secret("output", gen.DefaultTestNamespace).
+						WithAnnotations(
+							map[string]string{
+								cmapi.CertificateNameKey: "test", cmapi.IssuerGroupAnnotationKey: "foo.io",
+								cmapi.IssuerKindAnnotationKey: "Issuer", cmapi.IssuerNameAnnotationKey: "ca-issuer",
+								cmapi.PrivateKeyDefaultedAnnotationKey: "true",
+
+								cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName, cmapi.AltNamesAnnotationKey: strings.Join(baseCertBundle.Cert.DNSNames, ","),
+								cmapi.IPSANAnnotationKey:  strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+								cmapi.URISANAnnotationKey: strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+							}).
+						WithLabels(map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"}).
+						WithData(map[string][]byte{
+							corev1.TLSCertKey:       baseCertBundle.CertBytes,
+							corev1.TLSPrivateKeyKey: []byte("test-key"),
+							cmmeta.TLSCAKey:         []byte("test-ca"),
+						}).
+						WithType(corev1.SecretTypeTLS)
+					assert.Equal(t, expCnf, gotCnf)
+
+					expOpts := metav1.ApplyOptions{FieldManager: "cert-manager-test", Force: true}
+					assert.Equal(t, expOpts, gotOpts)
+
+					return nil, nil
+				}
+			},
+			expectedErr: false,
+		},
+
+		"if the issuer annotations are in the format of older versions, write them in canonical form": {
+			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: false},
+			certificate:        baseCertBundle.Certificate,
+			existingSecret:     nil,
+			secretData: SecretData{
+				Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKey: []byte("test-key"),
+				CertificateName: "test", IssuerName: "ca-issuer", IssuerKind: "ClusterIssuer.cert-manager.io", IssuerGroup: "",
+			},
+			applyFn: func(t *testing.T) testcoreclients.ApplyFn {
+				return func(_ context.Context, gotCnf *applycorev1.SecretApplyConfiguration, gotOpts metav1.ApplyOptions) (*corev1.Secret, error) {
+					expCnf := applycorev1.Secret("output", gen.DefaultTestNamespace).
+						WithAnnotations(
+							map[string]string{
+								cmapi.CertificateNameKey: "test", cmapi.IssuerGroupAnnotationKey: "cert-manager.io",
+								cmapi.IssuerKindAnnotationKey: "ClusterIssuer", cmapi.IssuerNameAnnotationKey: "ca-issuer",
+
+								cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName, cmapi.AltNamesAnnotationKey: strings.Join(baseCertBundle.Cert.DNSNames, ","),
+								cmapi.IPSANAnnotationKey:  strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+								cmapi.URISANAnnotationKey: strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+							}).
+						WithLabels(map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"}).
+						WithData(map[string][]byte{
+							corev1.TLSCertKey:       baseCertBundle.CertBytes,
+							corev1.TLSPrivateKeyKey: []byte("test-key"),
+							cmmeta.TLSCAKey:         []byte("test-ca"),
+						}).
+						WithType(corev1.SecretTypeTLS)
+					assert.Equal(t, expCnf, gotCnf)
+
+					expOpts := metav1.ApplyOptions{FieldManager: "cert-manager-test", Force: true}
+					assert.Equal(t, expOpts, gotOpts)
+
+					return nil, nil
+				}
+			},
+			expectedErr: false,
+		},
+
+		"if secret does not exist and the Certificate uses an external CSR, create new Secret with an empty private key": {
+			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: false},
+			certificate:        gen.CertificateFrom(baseCertBundle.Certificate, gen.SetCertificateExternalCSR("csr", "")),
+			existingSecret:     nil,
+			secretData: SecretData{
+				Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"),
+				CertificateName: "test", IssuerName: "ca-issuer", IssuerKind: "Issuer", IssuerGroup: "foo.io",
+			},
+			applyFn: func(t *testing.T) testcoreclients.ApplyFn {
+				return func(_ context.Context, gotCnf *applycorev1.SecretApplyConfiguration, gotOpts metav1.ApplyOptions) (*corev1.Secret, error) {
+					expCnf := applycorev1.Secret("output", gen.DefaultTestNamespace).
+						WithAnnotations(
+							map[string]string{
+								cmapi.CertificateNameKey: "test", cmapi.IssuerGroupAnnotationKey: "foo.io",
+								cmapi.IssuerKindAnnotationKey: "Issuer", cmapi.IssuerNameAnnotationKey: "ca-issuer",
+
+								cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName, cmapi.AltNamesAnnotationKey: strings.Join(baseCertBundle.Cert.DNSNames, ","),
+								cmapi.IPSANAnnotationKey:  strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+								cmapi.URISANAnnotationKey: strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+							}).
+						WithLabels(map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"}).
+						WithData(map[string][]byte{
+							corev1.TLSCertKey:       baseCertBundle.CertBytes,
+							corev1.TLSPrivateKeyKey: {},
+							cmmeta.TLSCAKey:         []byte("test-ca"),
+						}).
+						WithType(corev1.SecretTypeTLS)
+					assert.Equal(t, expCnf, gotCnf)
+
+					return nil, nil
+				}
+			},
+			expectedErr: false,
+		},
+
+		"if secret does not exist and the Certificate uses an external private key, create new Secret with only the private key reference": {
+			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: false},
+			certificate:        gen.CertificateFrom(baseCertBundle.Certificate, gen.SetCertificateExternalPrivateKey("projects/test")),
+			existingSecret:     nil,
+			secretData: SecretData{
+				Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKeyReference: []byte("projects/test/keys/1"),
+				CertificateName: "test", IssuerName: "ca-issuer", IssuerKind: "Issuer", IssuerGroup: "foo.io",
+			},
+			applyFn: func(t *testing.T) testcoreclients.ApplyFn {
+				return func(_ context.Context, gotCnf *applycorev1.SecretApplyConfiguration, gotOpts metav1.ApplyOptions) (*corev1.Secret, error) {
+					expCnf := applycorev1.Secret("output", gen.DefaultTestNamespace).
+						WithAnnotations(
+							map[string]string{
+								cmapi.CertificateNameKey: "test", cmapi.IssuerGroupAnnotationKey: "foo.io",
+								cmapi.IssuerKindAnnotationKey: "Issuer", cmapi.IssuerNameAnnotationKey: "ca-issuer",
+
+								cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName, cmapi.AltNamesAnnotationKey: strings.Join(baseCertBundle.Cert.DNSNames, ","),
+								cmapi.IPSANAnnotationKey:  strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+								cmapi.URISANAnnotationKey: strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+							}).
+						WithLabels(map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"}).
+						WithData(map[string][]byte{
+							corev1.TLSCertKey:                  baseCertBundle.CertBytes,
+							corev1.TLSPrivateKeyKey:            {},
+							cmapi.PrivateKeyReferenceSecretKey: []byte("projects/test/keys/1"),
+							cmmeta.TLSCAKey:                    []byte("test-ca"),
+						}).
+						WithType(corev1.SecretTypeTLS)
+					assert.Equal(t, expCnf, gotCnf)
+
+					return nil, nil
+				}
+			},
+			expectedErr: false,
+		},
+
 		"if secret does not exist, create new Secret, with owner enabled": {
 			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: true},
 			certificate:        baseCertBundle.Certificate,
@@ -776,6 +931,7 @@ func Test_SecretsManager(t *testing.T) {
 				secretClient, secretLister,
 				"cert-manager-test",
 				test.certificateOptions.EnableOwnerRef,
+				test.certificateOptions.MaxSecretSize,
 			)
 
 			err := testManager.UpdateData(context.Background(), test.certificate, test.secretData)
@@ -789,6 +945,661 @@ func Test_SecretsManager(t *testing.T) {
 	}
 }
 
+func Test_SecretsManager_ConvertOpaqueSecret(t *testing.T) {
+	crt := gen.Certificate("test",
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca-issuer", Kind: "Issuer", Group: "foo.io"}),
+		gen.SetCertificateSecretName("output"),
+		gen.SetCertificateDNSNames("example.com"),
+	)
+	bundle := testcrypto.MustCreateCryptoBundle(t, crt, fixedClock)
+	secretData := SecretData{
+		Certificate: bundle.CertBytes, PrivateKey: bundle.PrivateKeyBytes,
+		CertificateName: "test", IssuerName: "ca-issuer", IssuerKind: "Issuer", IssuerGroup: "foo.io",
+		ConvertOpaqueSecret: true,
+	}
+	ownerRefs := []metav1.OwnerReference{{APIVersion: "v1", Kind: "ConfigMap", Name: "owner", UID: "owner-uid"}}
+	opaqueSecret := func(data map[string][]byte) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "output", Namespace: gen.DefaultTestNamespace,
+				Labels:          map[string]string{"team": "a"},
+				Annotations:     map[string]string{"owner": "manual"},
+				OwnerReferences: ownerRefs,
+			},
+			Data: data,
+			Type: corev1.SecretTypeOpaque,
+		}
+	}
+	convertedMeta := metav1.ObjectMeta{
+		Name: "output", Namespace: gen.DefaultTestNamespace,
+		Labels:          map[string]string{"team": "a"},
+		Annotations:     map[string]string{"owner": "manual", cmapi.CertificateNameKey: "test"},
+		OwnerReferences: ownerRefs,
+	}
+
+	tests := map[string]struct {
+		existingSecret *corev1.Secret
+
+		// expConverted is nil if the Secret is not expected to be
+		// converted.
+		expConverted *corev1.Secret
+		expConflict  bool
+	}{
+		"empty Opaque Secret is re-created as a kubernetes.io/tls Secret": {
+			existingSecret: opaqueSecret(nil),
+			expConverted: &corev1.Secret{
+				ObjectMeta: convertedMeta,
+				Data: map[string][]byte{
+					corev1.TLSCertKey:       bundle.CertBytes,
+					corev1.TLSPrivateKeyKey: bundle.PrivateKeyBytes,
+				},
+				Type: corev1.SecretTypeTLS,
+			},
+		},
+		"Opaque Secret holding a prior version of the certificate is re-created, keeping unrelated keys": {
+			existingSecret: opaqueSecret(map[string][]byte{
+				corev1.TLSCertKey:       bundle.CertBytes,
+				corev1.TLSPrivateKeyKey: []byte("old-key"),
+				"notes":                 []byte("issued by hand"),
+			}),
+			expConverted: &corev1.Secret{
+				ObjectMeta: convertedMeta,
+				Data: map[string][]byte{
+					corev1.TLSCertKey:       bundle.CertBytes,
+					corev1.TLSPrivateKeyKey: bundle.PrivateKeyBytes,
+					"notes":                 []byte("issued by hand"),
+				},
+				Type: corev1.SecretTypeTLS,
+			},
+		},
+		"Opaque Secret with unrelated data is not converted": {
+			existingSecret: opaqueSecret(map[string][]byte{
+				"password": []byte("hunter2"),
+			}),
+			expConflict: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			store := &fakeSecretStore{secrets: make(map[string]*corev1.Secret)}
+			if _, err := store.create(test.existingSecret); err != nil {
+				t.Fatal(err)
+			}
+
+			var created *corev1.Secret
+			secretClient := testcoreclients.NewFakeSecretsGetter(
+				testcoreclients.SetFakeSecretsGetterGetFn(store.get),
+				testcoreclients.SetFakeSecretsGetterDeleteFn(store.delete),
+				testcoreclients.SetFakeSecretsGetterCreateFn(func(secret *corev1.Secret) (*corev1.Secret, error) {
+					if secret.Name == "output" {
+						created = secret
+						if _, err := store.get("output"); !apierrors.IsNotFound(err) {
+							t.Error("expected the Opaque Secret to be deleted before it is re-created")
+						}
+						rotation, err := store.get("output-rotation")
+						if err != nil {
+							t.Errorf("expected the converted Secret to be staged before the Opaque Secret is deleted: %v", err)
+						} else {
+							assert.Equal(t, secret.Data, rotation.Data)
+						}
+					}
+					return store.create(secret)
+				}),
+				testcoreclients.SetFakeSecretsGetterApplyFn(func(_ context.Context, cnf *applycorev1.SecretApplyConfiguration, _ metav1.ApplyOptions) (*corev1.Secret, error) {
+					assert.Equal(t, corev1.SecretTypeTLS, *cnf.Type)
+					return store.apply(cnf)
+				}),
+			)
+			secretLister := testcorelisters.NewFakeSecretLister(testcorelisters.SetFakeSecretListerSecret(func(string) corelisters.SecretNamespaceLister {
+				return &testcorelisters.FakeSecretNamespaceLister{GetFn: store.get}
+			}))
+			testManager := NewSecretsManager(secretClient, secretLister, "cert-manager-test", false, 0)
+
+			err := testManager.UpdateData(context.Background(), crt, secretData)
+
+			var conflictErr *SecretTypeConflictError
+			if test.expConflict != errors.As(err, &conflictErr) {
+				t.Fatalf("expected SecretTypeConflictError=%t, got error: %v", test.expConflict, err)
+			}
+			if !test.expConflict && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assert.Equal(t, test.expConverted, created)
+			_, err = store.get("output-rotation")
+			assert.True(t, apierrors.IsNotFound(err), "expected no rotation Secret to be left behind")
+			secret, err := store.get("output")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if test.expConflict {
+				assert.Equal(t, corev1.SecretTypeOpaque, secret.Type)
+			} else {
+				assert.Equal(t, corev1.SecretTypeTLS, secret.Type)
+			}
+		})
+	}
+}
+
+func Test_SecretsManager_ConvertOpaqueSecretIsCompletedAfterFailure(t *testing.T) {
+	crt := gen.Certificate("test",
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca-issuer", Kind: "Issuer", Group: "foo.io"}),
+		gen.SetCertificateSecretName("output"),
+		gen.SetCertificateDNSNames("example.com"),
+	)
+	bundle := testcrypto.MustCreateCryptoBundle(t, crt, fixedClock)
+	secretData := SecretData{
+		Certificate: bundle.CertBytes, PrivateKey: bundle.PrivateKeyBytes,
+		CertificateName: "test", IssuerName: "ca-issuer", IssuerKind: "Issuer", IssuerGroup: "foo.io",
+		ConvertOpaqueSecret: true,
+	}
+	ownerRefs := []metav1.OwnerReference{{APIVersion: "v1", Kind: "ConfigMap", Name: "owner", UID: "owner-uid"}}
+
+	store := &fakeSecretStore{secrets: make(map[string]*corev1.Secret)}
+	if _, err := store.create(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "output", Namespace: gen.DefaultTestNamespace,
+			Labels:          map[string]string{"team": "a"},
+			OwnerReferences: ownerRefs,
+		},
+		Data: map[string][]byte{
+			corev1.TLSCertKey: bundle.CertBytes,
+			"notes":           []byte("issued by hand"),
+		},
+		Type: corev1.SecretTypeOpaque,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Re-creating the Secret fails once, after the Opaque Secret has been
+	// deleted.
+	failCreate := true
+	var restored *corev1.Secret
+	secretClient := testcoreclients.NewFakeSecretsGetter(
+		testcoreclients.SetFakeSecretsGetterGetFn(store.get),
+		testcoreclients.SetFakeSecretsGetterDeleteFn(store.delete),
+		testcoreclients.SetFakeSecretsGetterCreateFn(func(secret *corev1.Secret) (*corev1.Secret, error) {
+			if secret.Name == "output" {
+				if failCreate {
+					return nil, errors.New("simulated failure")
+				}
+				restored = secret
+			}
+			return store.create(secret)
+		}),
+		testcoreclients.SetFakeSecretsGetterApplyFn(func(_ context.Context, cnf *applycorev1.SecretApplyConfiguration, _ metav1.ApplyOptions) (*corev1.Secret, error) {
+			return store.apply(cnf)
+		}),
+	)
+	secretLister := testcorelisters.NewFakeSecretLister(testcorelisters.SetFakeSecretListerSecret(func(string) corelisters.SecretNamespaceLister {
+		return &testcorelisters.FakeSecretNamespaceLister{GetFn: store.get}
+	}))
+	testManager := NewSecretsManager(secretClient, secretLister, "cert-manager-test", false, 0)
+
+	if err := testManager.UpdateData(context.Background(), crt, secretData); err == nil {
+		t.Fatal("expected an error when the Secret cannot be re-created")
+	}
+	rotation, err := store.get("output-rotation")
+	if err != nil {
+		t.Fatalf("expected the converted Secret to be kept in the rotation Secret: %v", err)
+	}
+	assert.Equal(t, bundle.CertBytes, rotation.Data[corev1.TLSCertKey], "expected the policies to see the new certificate whilst the Secret is missing")
+
+	// The next attempt re-creates the Secret from the rotation Secret, so
+	// that its metadata and unrelated keys are not lost.
+	failCreate = false
+	if err := testManager.UpdateData(context.Background(), crt, secretData); err != nil {
+		t.Fatal(err)
+	}
+	if restored == nil {
+		t.Fatal("expected the Secret to be restored from the rotation Secret")
+	}
+	assert.Equal(t, map[string]string{"team": "a"}, restored.Labels)
+	assert.Equal(t, ownerRefs, restored.OwnerReferences)
+	assert.Contains(t, restored.Data, "notes")
+	assert.Equal(t, corev1.SecretTypeTLS, restored.Type)
+
+	_, err = store.get("output-rotation")
+	assert.True(t, apierrors.IsNotFound(err), "expected the rotation Secret to be deleted")
+	secret, err := store.get("output")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, bundle.CertBytes, secret.Data[corev1.TLSCertKey])
+}
+
+// fakeSecretStore is an in-memory Secrets API backing the fake Secrets client
+// of Test_SecretsManager_RotateImmutableSecret. Every call is serialised, as
+// by the apiserver, so that concurrent readers observe the Secrets as they
+// would be seen by informers between two calls.
+type fakeSecretStore struct {
+	mu              sync.Mutex
+	secrets         map[string]*corev1.Secret
+	resourceVersion int
+
+	// conflicts is the number of Deletes of the Secret named 'conflictOn'
+	// which fail with a Conflict, as if the Secret was modified concurrently.
+	conflicts  int
+	conflictOn string
+
+	deletes int
+}
+
+func (f *fakeSecretStore) nextResourceVersion() string {
+	f.resourceVersion++
+	return strconv.Itoa(f.resourceVersion)
+}
+
+func (f *fakeSecretStore) get(name string) (*corev1.Secret, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	secret, ok := f.secrets[name]
+	if !ok {
+		return nil, apierrors.NewNotFound(corev1.Resource("secrets"), name)
+	}
+	return secret.DeepCopy(), nil
+}
+
+func (f *fakeSecretStore) create(secret *corev1.Secret) (*corev1.Secret, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.secrets[secret.Name]; ok {
+		return nil, apierrors.NewAlreadyExists(corev1.Resource("secrets"), secret.Name)
+	}
+	secret = secret.DeepCopy()
+	secret.UID = apitypes.UID("uid-" + f.nextResourceVersion())
+	secret.ResourceVersion = f.nextResourceVersion()
+	f.secrets[secret.Name] = secret
+	return secret.DeepCopy(), nil
+}
+
+func (f *fakeSecretStore) delete(name string, opts metav1.DeleteOptions) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	secret, ok := f.secrets[name]
+	if !ok {
+		return apierrors.NewNotFound(corev1.Resource("secrets"), name)
+	}
+	if name == f.conflictOn && f.conflicts > 0 {
+		f.conflicts--
+		secret.ResourceVersion = f.nextResourceVersion()
+	}
+	if p := opts.Preconditions; p != nil {
+		if (p.UID != nil && *p.UID != secret.UID) || (p.ResourceVersion != nil && *p.ResourceVersion != secret.ResourceVersion) {
+			return apierrors.NewConflict(corev1.Resource("secrets"), name, errors.New("the object has been modified"))
+		}
+	}
+	delete(f.secrets, name)
+	f.deletes++
+	return nil
+}
+
+func (f *fakeSecretStore) apply(cnf *applycorev1.SecretApplyConfiguration) (*corev1.Secret, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: *cnf.Name, Namespace: *cnf.Namespace,
+			Labels: cnf.Labels, Annotations: cnf.Annotations,
+		},
+		Data:      cnf.Data,
+		Type:      *cnf.Type,
+		Immutable: cnf.Immutable,
+	}
+	for _, ref := range cnf.OwnerReferences {
+		secret.OwnerReferences = append(secret.OwnerReferences, metav1.OwnerReference{
+			APIVersion: *ref.APIVersion, Kind: *ref.Kind, Name: *ref.Name, UID: *ref.UID,
+			Controller: ref.Controller, BlockOwnerDeletion: ref.BlockOwnerDeletion,
+		})
+	}
+	existing, ok := f.secrets[secret.Name]
+	switch {
+	case !ok:
+		secret.UID = apitypes.UID("uid-" + f.nextResourceVersion())
+	case ptr.Deref(existing.Immutable, false) && !reflect.DeepEqual(existing.Data, secret.Data):
+		return nil, apierrors.NewInvalid(corev1.SchemeGroupVersion.WithKind("Secret").GroupKind(), secret.Name, nil)
+	default:
+		secret.UID = existing.UID
+	}
+	secret.ResourceVersion = f.nextResourceVersion()
+	f.secrets[secret.Name] = secret
+	return secret.DeepCopy(), nil
+}
+
+// visibleData returns the certificate data that the policies would evaluate
+// for the Secret, falling back to the rotation Secret whilst the Secret is
+// missing. It returns nil if neither exists.
+func (f *fakeSecretStore) visibleData(name, rotationName, crtName string) []byte {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if secret, ok := f.secrets[name]; ok {
+		return secret.Data[corev1.TLSCertKey]
+	}
+	if secret, ok := f.secrets[rotationName]; ok && secret.Annotations[cmapi.CertificateNameKey] == crtName {
+		return secret.Data[corev1.TLSCertKey]
+	}
+	return nil
+}
+
+func Test_SecretsManager_RotateImmutableSecret(t *testing.T) {
+	crt := gen.Certificate("test",
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca-issuer", Kind: "Issuer", Group: "foo.io"}),
+		gen.SetCertificateSecretName("output"),
+		gen.SetCertificateDNSNames("example.com"),
+	)
+	immutableCrt := gen.CertificateFrom(crt, func(crt *cmapi.Certificate) {
+		crt.Spec.SecretTemplate = &cmapi.CertificateSecretTemplate{Immutable: true}
+	})
+	oldBundle := testcrypto.MustCreateCryptoBundle(t, crt, fixedClock)
+	newBundle := testcrypto.MustCreateCryptoBundle(t, crt, fixedClock)
+	secretData := SecretData{
+		Certificate: newBundle.CertBytes, PrivateKey: newBundle.PrivateKeyBytes,
+		CertificateName: "test", IssuerName: "ca-issuer", IssuerKind: "Issuer", IssuerGroup: "foo.io",
+	}
+	existingSecret := func(bundle testcrypto.CryptoBundle, immutable bool) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "output", Namespace: gen.DefaultTestNamespace,
+				Annotations: map[string]string{cmapi.CertificateNameKey: "test"},
+			},
+			Data: map[string][]byte{
+				corev1.TLSCertKey:       bundle.CertBytes,
+				corev1.TLSPrivateKeyKey: bundle.PrivateKeyBytes,
+			},
+			Type:      corev1.SecretTypeTLS,
+			Immutable: ptr.To(immutable),
+		}
+	}
+	rotationSecret := func(crtName string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "output-rotation", Namespace: gen.DefaultTestNamespace,
+				Annotations: map[string]string{cmapi.CertificateNameKey: crtName},
+			},
+			Data: map[string][]byte{corev1.TLSCertKey: oldBundle.CertBytes},
+			Type: corev1.SecretTypeTLS,
+		}
+	}
+
+	tests := map[string]struct {
+		certificate     *cmapi.Certificate
+		existingSecrets []*corev1.Secret
+		conflicts       int
+
+		expErr       bool
+		expImmutable bool
+		expData      []byte
+		expDeletes   int
+	}{
+		"a mutable Secret is made immutable in place": {
+			certificate:     immutableCrt,
+			existingSecrets: []*corev1.Secret{existingSecret(oldBundle, false)},
+			expImmutable:    true,
+			expData:         newBundle.CertBytes,
+		},
+		"an immutable Secret holding the same data is applied in place": {
+			certificate:     immutableCrt,
+			existingSecrets: []*corev1.Secret{existingSecret(newBundle, true)},
+			expImmutable:    true,
+			expData:         newBundle.CertBytes,
+		},
+		"an immutable Secret is re-created with the new data": {
+			certificate:     immutableCrt,
+			existingSecrets: []*corev1.Secret{existingSecret(oldBundle, true)},
+			expImmutable:    true,
+			expData:         newBundle.CertBytes,
+			// The Secret and the rotation Secret are deleted.
+			expDeletes: 2,
+		},
+		"an immutable Secret which is modified concurrently is re-created once it is read again": {
+			certificate:     immutableCrt,
+			existingSecrets: []*corev1.Secret{existingSecret(oldBundle, true)},
+			conflicts:       3,
+			expImmutable:    true,
+			expData:         newBundle.CertBytes,
+			expDeletes:      2,
+		},
+		"an immutable Secret which keeps being modified is not deleted": {
+			certificate:     immutableCrt,
+			existingSecrets: []*corev1.Secret{existingSecret(oldBundle, true)},
+			conflicts:       100,
+			expErr:          true,
+			expImmutable:    true,
+			expData:         oldBundle.CertBytes,
+		},
+		"an immutable Secret is re-created as mutable once immutable is unset": {
+			certificate:     crt,
+			existingSecrets: []*corev1.Secret{existingSecret(newBundle, true)},
+			expData:         newBundle.CertBytes,
+			expDeletes:      2,
+		},
+		"a rotation Secret left behind by a failed swap is replaced": {
+			certificate:     immutableCrt,
+			existingSecrets: []*corev1.Secret{existingSecret(oldBundle, true), rotationSecret("test")},
+			expImmutable:    true,
+			expData:         newBundle.CertBytes,
+			expDeletes:      3,
+		},
+		"a rotation Secret belonging to another Certificate is not replaced": {
+			certificate:     immutableCrt,
+			existingSecrets: []*corev1.Secret{existingSecret(oldBundle, true), rotationSecret("other")},
+			expErr:          true,
+			expImmutable:    true,
+			expData:         oldBundle.CertBytes,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			store := &fakeSecretStore{
+				secrets:    make(map[string]*corev1.Secret),
+				conflicts:  test.conflicts,
+				conflictOn: "output",
+			}
+			for _, secret := range test.existingSecrets {
+				if _, err := store.create(secret); err != nil {
+					t.Fatal(err)
+				}
+			}
+			existing, err := store.get("output")
+			if err != nil {
+				t.Fatal(err)
+			}
+			store.deletes = 0
+
+			secretClient := testcoreclients.NewFakeSecretsGetter(
+				testcoreclients.SetFakeSecretsGetterGetFn(store.get),
+				testcoreclients.SetFakeSecretsGetterCreateFn(store.create),
+				testcoreclients.SetFakeSecretsGetterDeleteFn(store.delete),
+				testcoreclients.SetFakeSecretsGetterApplyFn(func(_ context.Context, cnf *applycorev1.SecretApplyConfiguration, _ metav1.ApplyOptions) (*corev1.Secret, error) {
+					return store.apply(cnf)
+				}),
+			)
+			secretLister := testcorelisters.NewFakeSecretLister(testcorelisters.SetFakeSecretNamespaceListerGet(existing, nil))
+			testManager := NewSecretsManager(secretClient, secretLister, "cert-manager-test", false, 0)
+
+			// Readers evaluating the Secret whilst it is being re-created must
+			// always see either the old or the new certificate.
+			var (
+				done = make(chan struct{})
+				wg   sync.WaitGroup
+				gaps atomic.Int32
+			)
+			for range 4 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for {
+						select {
+						case <-done:
+							return
+						default:
+						}
+						data := store.visibleData("output", "output-rotation", "test")
+						if !bytes.Equal(data, oldBundle.CertBytes) && !bytes.Equal(data, newBundle.CertBytes) {
+							gaps.Add(1)
+						}
+					}
+				}()
+			}
+
+			err = testManager.UpdateData(context.Background(), test.certificate, secretData)
+			close(done)
+			wg.Wait()
+
+			if test.expErr != (err != nil) {
+				t.Fatalf("expected error=%t, got: %v", test.expErr, err)
+			}
+			assert.Zero(t, gaps.Load(), "expected readers to always see the old or the new certificate")
+
+			secret, err := store.get("output")
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, test.expImmutable, ptr.Deref(secret.Immutable, false))
+			assert.Equal(t, test.expData, secret.Data[corev1.TLSCertKey])
+			assert.Equal(t, test.expDeletes, store.deletes)
+			if !test.expErr {
+				_, err := store.get("output-rotation")
+				assert.True(t, apierrors.IsNotFound(err), "expected the rotation Secret to be deleted")
+			}
+		})
+	}
+}
+
+func Test_SecretsManager_MaxSecretSize(t *testing.T) {
+	crt := gen.Certificate("test",
+		gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca-issuer", Kind: "Issuer", Group: "foo.io"}),
+		gen.SetCertificateSecretName("output"),
+		gen.SetCertificateDNSNames("example.com"),
+	)
+	bundle := testcrypto.MustCreateCryptoBundle(t, crt, fixedClock)
+	secretData := SecretData{
+		Certificate: bundle.CertBytes, PrivateKey: bundle.PrivateKeyBytes, CA: bundle.CertBytes,
+		CertificateName: "test", IssuerName: "ca-issuer", IssuerKind: "Issuer", IssuerGroup: "foo.io",
+	}
+	dataSize := len(secretData.Certificate) + len(secretData.PrivateKey) + len(secretData.CA)
+	// The PKCS12 keystore and truststore are together about as large as the
+	// PEM data, so fit within this size on their own but not alongside it.
+	keystoresMaxSize := dataSize * 3 / 2
+
+	// The keystore password is read through the lister, which returns the
+	// same Secret for every name.
+	passwordSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "keystore-password"},
+		Data:       map[string][]byte{"password": []byte("changeit")},
+		Type:       corev1.SecretTypeOpaque,
+	}
+	withKeystores := func(secretName string) *cmapi.Certificate {
+		crt := crt.DeepCopy()
+		crt.Spec.Keystores = &cmapi.CertificateKeystores{
+			PKCS12: &cmapi.PKCS12Keystore{
+				Create: true,
+				PasswordSecretRef: cmmeta.SecretKeySelector{
+					LocalObjectReference: cmmeta.LocalObjectReference{Name: "keystore-password"},
+					Key:                  "password",
+				},
+			},
+			SecretName: secretName,
+		}
+		return crt
+	}
+
+	tests := map[string]struct {
+		certificate   *cmapi.Certificate
+		maxSecretSize int
+
+		expErr          bool
+		expHasKeystores bool
+		// expApplied is the data keys of each applied Secret, by name.
+		expApplied map[string][]string
+	}{
+		"a Secret at the maximum size is written": {
+			certificate:   crt,
+			maxSecretSize: dataSize,
+			expApplied: map[string][]string{
+				"output": {cmmeta.TLSCAKey, corev1.TLSCertKey, corev1.TLSPrivateKeyKey},
+			},
+		},
+		"a Secret over the maximum size is not written": {
+			certificate:   crt,
+			maxSecretSize: dataSize - 1,
+			expErr:        true,
+			expApplied:    map[string][]string{},
+		},
+		"a zero maximum size disables the check": {
+			certificate:   crt,
+			maxSecretSize: 0,
+			expApplied: map[string][]string{
+				"output": {cmmeta.TLSCAKey, corev1.TLSCertKey, corev1.TLSPrivateKeyKey},
+			},
+		},
+		"a Secret over the maximum size due to keystores suggests moving them to a separate Secret": {
+			certificate:     withKeystores(""),
+			maxSecretSize:   keystoresMaxSize,
+			expErr:          true,
+			expHasKeystores: true,
+			expApplied:      map[string][]string{},
+		},
+		"keystores written to a separate Secret do not count towards the Certificate's Secret": {
+			certificate:   withKeystores("output-keystores"),
+			maxSecretSize: keystoresMaxSize,
+			expApplied: map[string][]string{
+				"output-keystores": {cmapi.PKCS12SecretKey, cmapi.PKCS12TruststoreKey},
+				"output":           {cmmeta.TLSCAKey, corev1.TLSCertKey, corev1.TLSPrivateKeyKey},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			applied := make(map[string][]string)
+			var applyOrder []string
+			secretClient := testcoreclients.NewFakeSecretsGetter(testcoreclients.SetFakeSecretsGetterApplyFn(
+				func(_ context.Context, cnf *applycorev1.SecretApplyConfiguration, _ metav1.ApplyOptions) (*corev1.Secret, error) {
+					var keys []string
+					for k := range cnf.Data {
+						keys = append(keys, k)
+					}
+					applied[*cnf.Name] = keys
+					applyOrder = append(applyOrder, *cnf.Name)
+					return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: *cnf.Name, Namespace: *cnf.Namespace}}, nil
+				},
+			))
+			secretLister := testcorelisters.NewFakeSecretLister(testcorelisters.SetFakeSecretNamespaceListerGet(passwordSecret, nil))
+			testManager := NewSecretsManager(secretClient, secretLister, "cert-manager-test", false, test.maxSecretSize)
+
+			err := testManager.UpdateData(context.Background(), test.certificate, secretData)
+			if test.expErr {
+				var tooLargeErr *SecretTooLargeError
+				if !errors.As(err, &tooLargeErr) {
+					t.Fatalf("expected a SecretTooLargeError, got: %v", err)
+				}
+				assert.Equal(t, "output", tooLargeErr.Name)
+				assert.Equal(t, test.maxSecretSize, tooLargeErr.MaxSize)
+				assert.Greater(t, tooLargeErr.Size, test.maxSecretSize)
+				assert.Equal(t, test.expHasKeystores, tooLargeErr.HasKeystores)
+				assert.Equal(t, test.expHasKeystores, strings.Contains(err.Error(), "spec.keystores.secretName"))
+				for i := 1; i < len(tooLargeErr.KeySizes); i++ {
+					assert.GreaterOrEqual(t, tooLargeErr.KeySizes[i-1].Size, tooLargeErr.KeySizes[i].Size, "expected key sizes to be sorted largest first")
+				}
+			} else if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+
+			assert.Len(t, applied, len(test.expApplied))
+			for name, expKeys := range test.expApplied {
+				assert.ElementsMatch(t, expKeys, applied[name], "unexpected data keys in Secret %q", name)
+			}
+			if len(applyOrder) > 1 {
+				assert.Equal(t, "output", applyOrder[len(applyOrder)-1], "expected the Certificate's Secret to be applied last")
+			}
+		})
+	}
+}
+
 func Test_getCertificateSecret(t *testing.T) {
 	crt := &cmapi.Certificate{
 		ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-certificate"},
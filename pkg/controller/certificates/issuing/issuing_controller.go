@@ -19,7 +19,13 @@ package issuing
 import (
 	"context"
 	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
 	"fmt"
+	"net/url"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -27,16 +33,24 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/discovery/cached/memory"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/restmapper"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/clock"
 
+	internalcertificaterequests "github.com/cert-manager/cert-manager/internal/controller/certificaterequests"
 	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
+	"github.com/cert-manager/cert-manager/internal/controller/certificates/failureevents"
+	"github.com/cert-manager/cert-manager/internal/controller/certificates/keyprovider"
 	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
@@ -44,7 +58,9 @@ import (
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/issuing/internal"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/scheduler"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	utilkube "github.com/cert-manager/cert-manager/pkg/util/kube"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
@@ -54,6 +70,53 @@ import (
 
 const (
 	ControllerName = "certificates-issuing"
+
+	// reasonIssuanceTimedOut is the reason used when a CertificateRequest has
+	// made no progress within the issuance timeout.
+	reasonIssuanceTimedOut = "IssuanceTimedOut"
+
+	// reasonInvalidCABundle is the reason used when the CA bundle referenced
+	// by a Certificate's SecretCAPolicy cannot be used.
+	reasonInvalidCABundle = "InvalidCABundle"
+
+	// reasonSecretTypeConflict is the reason used when the Certificate's
+	// Opaque Secret cannot be converted to a `kubernetes.io/tls` Secret.
+	reasonSecretTypeConflict = "SecretTypeConflict"
+
+	// reasonSecretTooLarge is the reason used when the data of the
+	// Certificate's Secret would exceed the maximum Secret size.
+	reasonSecretTooLarge = "SecretTooLarge"
+
+	// reasonSecretConverted is the reason used when the Certificate's Opaque
+	// Secret has been converted to a `kubernetes.io/tls` Secret.
+	reasonSecretConverted = "SecretConverted"
+
+	// reasonSecretAdopted is the reason used when a Secret which existed
+	// before the Certificate was first issued has been adopted.
+	reasonSecretAdopted = "SecretAdopted"
+
+	// reasonSecretMigrated is the reason used when the data of the Secret
+	// previously named by spec.secretName has been moved to the new Secret.
+	reasonSecretMigrated = "SecretMigrated"
+
+	// reasonPreviousSecretDeleted is the reason used when the Secret
+	// previously named by spec.secretName has been deleted.
+	reasonPreviousSecretDeleted = "PreviousSecretDeleted"
+
+	// reasonIssuedCertificateInvalid is the reason used when the certificate
+	// issued for a CertificateRequest fails verification, and so is not
+	// stored.
+	reasonIssuedCertificateInvalid = "IssuedCertificateInvalid"
+
+	// reasonMissingSCTs is the reason used when the certificate issued for a
+	// CertificateRequest embeds fewer Signed Certificate Timestamps than its
+	// issuer requires, and so is not stored.
+	reasonMissingSCTs = "MissingSCTs"
+
+	// rolloutExpiryMargin is how long before the certificate stored in the
+	// Secret expires a renewed certificate which is held back by the
+	// Certificate's rolloutDelay is stored anyway.
+	rolloutExpiryMargin = 10 * time.Minute
 )
 
 type localTemporarySignerFn func(crt *cmapi.Certificate, pk []byte) ([]byte, error)
@@ -65,9 +128,19 @@ type controller struct {
 	certificateLister        cmlisters.CertificateLister
 	certificateRequestLister cmlisters.CertificateRequestLister
 	secretLister             internalinformers.SecretLister
+	configMapLister          corelisters.ConfigMapLister
+	secretsGetter            corev1client.SecretsGetter
 	recorder                 record.EventRecorder
 	clock                    clock.Clock
 
+	// failureEvents records the Events for failed issuances, so that the
+	// events API is not flooded when many Certificates fail at once.
+	failureEvents *failureevents.Recorder
+
+	// issuanceHistoryLimit is the number of the most recent issuance attempts
+	// recorded in the status of each Certificate.
+	issuanceHistoryLimit int
+
 	client cmclient.Interface
 
 	// secretsUpdateData is used by the SecretTemplate controller for
@@ -79,6 +152,16 @@ type controller struct {
 	// metadata and output formats are kept are present and correct.
 	postIssuancePolicyChain policies.Chain
 
+	// migrationPolicyChain is the policies chain which the data of the Secret
+	// previously named by spec.secretName must pass to be moved to the new
+	// Secret rather than issuing a certificate.
+	migrationPolicyChain policies.Chain
+
+	// enableOwnerRef is whether owner references are set on Certificate
+	// Secrets, in which case previous Secrets are deleted once their data has
+	// been moved.
+	enableOwnerRef bool
+
 	// fieldManager is the string which will be used as the Field Manager on
 	// fields created or edited by the cert-manager Kubernetes client during
 	// Apply API calls.
@@ -86,6 +169,48 @@ type controller struct {
 
 	// localTemporarySigner signs a certificate that is stored temporarily
 	localTemporarySigner localTemporarySignerFn
+
+	// issuanceTimeout is the default maximum amount of time a
+	// CertificateRequest may go without status progress before the issuance
+	// attempt is failed. Zero disables the timeout.
+	issuanceTimeout time.Duration
+
+	// clockSkewTolerance is the maximum amount of time by which an issued
+	// certificate's NotBefore may be in the future when it is stored.
+	clockSkewTolerance time.Duration
+
+	// maxNotBeforeWait is the longest the controller waits for an issued
+	// certificate to become valid before storing it. Certificates which are
+	// not valid for longer than this, beyond the clock skew tolerance, fail
+	// the issuance.
+	maxNotBeforeWait time.Duration
+
+	// verifyIssuedCertificates is whether issued certificates are verified
+	// before they are stored, see checkIssuedCertificate.
+	verifyIssuedCertificates bool
+
+	// verifyIssuedCertificateChain is whether the chain of issued
+	// certificates is also verified against the CA certificate which is
+	// stored, see checkIssuedCertificateChain.
+	verifyIssuedCertificateChain bool
+
+	// privateKeyDefaults are applied to Certificates which leave their
+	// private key unspecified.
+	privateKeyDefaults internalcertificates.PrivateKeyDefaults
+
+	// keyProvider is used to access the private keys of Certificates which
+	// set spec.privateKey.external. It is nil if no signer plugin is
+	// configured.
+	keyProvider keyprovider.Provider
+
+	// scheduledWorkQueue is used to re-sync Certificates once the issuance
+	// timeout of their CertificateRequest may have elapsed, or once the
+	// issued certificate becomes valid.
+	scheduledWorkQueue scheduler.ScheduledWorkQueue
+
+	// helper is used to read the issuer of a Certificate, to determine
+	// whether it opted into verifying the CA of issued certificates.
+	helper issuer.Helper
 }
 
 func NewController(
@@ -93,13 +218,16 @@ func NewController(
 	ctx *controllerpkg.Context,
 ) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
 
-	// create a queue used to queue up items to be processed
-	queue := workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Second*1, time.Second*30), ControllerName)
-
 	// obtain references to all the informers used by this controller
 	certificateInformer := ctx.SharedInformerFactory.Certmanager().V1().Certificates()
 	certificateRequestInformer := ctx.SharedInformerFactory.Certmanager().V1().CertificateRequests()
 	secretsInformer := ctx.KubeSharedInformerFactory.Secrets()
+	configMapsInformer := ctx.KubeSharedInformerFactory.ConfigMaps()
+	issuerInformer := ctx.SharedInformerFactory.Certmanager().V1().Issuers()
+
+	// create a queue used to queue up items to be processed, in which
+	// Certificates which are about to expire are processed first
+	queue := certificates.NewIssuanceQueue(ControllerName, ctx, certificateInformer.Lister(), secretsInformer.Lister())
 
 	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
 	certificateRequestInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
@@ -113,8 +241,17 @@ func NewController(
 	})
 	secretsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
 		// Issuer reconciles on changes to the Secret named `spec.secretName`
+		WorkFunc: certificates.EnqueueCertificatesForSecret(log, queue, certificateInformer),
+	})
+	// Issuer reconciles on changes to the CA bundle referenced by
+	// `spec.secretCAPolicy.bundleRef`
+	secretsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
+			predicate.ExtractResourceName(predicate.CertificateCABundleSecretName)),
+	})
+	configMapsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
 		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
-			predicate.ExtractResourceName(predicate.CertificateSecretName)),
+			predicate.ExtractResourceName(predicate.CertificateCABundleConfigMapName)),
 	})
 
 	// build a list of InformerSynced functions that will be returned by the Register method.
@@ -122,18 +259,32 @@ func NewController(
 	mustSync := []cache.InformerSynced{
 		certificateRequestInformer.Informer().HasSynced,
 		secretsInformer.Informer().HasSynced,
+		configMapsInformer.Informer().HasSynced,
 		certificateInformer.Informer().HasSynced,
+		issuerInformer.Informer().HasSynced,
+	}
+
+	// If we are running in non-namespaced mode, we also obtain a lister for
+	// ClusterIssuers.
+	var clusterIssuerLister cmlisters.ClusterIssuerLister
+	if ctx.Namespace == "" {
+		clusterIssuerInformer := ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers()
+		clusterIssuerLister = clusterIssuerInformer.Lister()
+		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
 	}
 
 	secretsManager := internal.NewSecretsManager(
 		ctx.Client.CoreV1(), secretsInformer.Lister(),
 		ctx.FieldManager, ctx.CertificateOptions.EnableOwnerRef,
+		ctx.CertificateOptions.MaxSecretSize,
 	)
 
 	return &controller{
 		certificateLister:        certificateInformer.Lister(),
 		certificateRequestLister: certificateRequestInformer.Lister(),
 		secretLister:             secretsInformer.Lister(),
+		configMapLister:          configMapsInformer.Lister(),
+		secretsGetter:            ctx.Client.CoreV1(),
 		client:                   ctx.CMClient,
 		recorder:                 ctx.Recorder,
 		clock:                    ctx.Clock,
@@ -142,17 +293,34 @@ func NewController(
 			ctx.CertificateOptions.EnableOwnerRef,
 			ctx.FieldManager,
 		),
-		fieldManager:         ctx.FieldManager,
-		localTemporarySigner: pki.GenerateLocallySignedTemporaryCertificate,
+		migrationPolicyChain:         policies.NewTriggerPolicyChain(ctx.Clock, ctx.CertificateOptions.PrivateKeyDefaults),
+		enableOwnerRef:               ctx.CertificateOptions.EnableOwnerRef,
+		fieldManager:                 ctx.FieldManager,
+		localTemporarySigner:         pki.GenerateLocallySignedTemporaryCertificate,
+		issuanceTimeout:              ctx.CertificateOptions.IssuanceTimeout,
+		clockSkewTolerance:           ctx.CertificateOptions.ClockSkewTolerance,
+		maxNotBeforeWait:             ctx.CertificateOptions.MaxNotBeforeWait,
+		verifyIssuedCertificates:     ctx.CertificateOptions.VerifyIssuedCertificates,
+		verifyIssuedCertificateChain: ctx.CertificateOptions.VerifyIssuedCertificateChain,
+		privateKeyDefaults:           ctx.CertificateOptions.PrivateKeyDefaults,
+		keyProvider:                  ctx.CertificateOptions.KeyProvider,
+		scheduledWorkQueue:           scheduler.NewScheduledWorkQueue(ctx.Clock, queue.Add),
+		failureEvents: failureevents.NewRecorder(ctx.Recorder, ctx.Clock,
+			ctx.CertificateOptions.MaxIssuanceFailureEvents,
+			ctx.CertificateOptions.IssuanceFailureEventWindow,
+			failureevents.Issuers{
+				IssuerLister:        issuerInformer.Lister(),
+				ClusterIssuerLister: clusterIssuerLister,
+				RESTMapper:          restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(ctx.DiscoveryClient)),
+				MetadataClient:      ctx.MetadataClient,
+			},
+		),
+		issuanceHistoryLimit: ctx.CertificateOptions.IssuanceHistoryLimit,
+		helper:               issuer.NewHelper(issuerInformer.Lister(), clusterIssuerLister),
 	}, queue, mustSync
 }
 
 func (c *controller) ProcessItem(ctx context.Context, key string) error {
-	// TODO: Change to globals.DefaultControllerContextTimeout as part of a wider effort to ensure we have
-	// failsafe timeouts in every controller
-	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
-	defer cancel()
-
 	log := logf.FromContext(ctx).WithValues("key", key)
 
 	namespace, name, err := cache.SplitMetaNamespaceKey(key)
@@ -169,7 +337,7 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return err
 	}
 
-	log = logf.WithResource(log, crt)
+	log = logf.WithCertificate(log, crt)
 	ctx = logf.NewContext(ctx, log)
 
 	if !apiutil.CertificateHasCondition(crt, cmapi.CertificateCondition{
@@ -182,39 +350,18 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return c.ensureSecretData(ctx, log, crt)
 	}
 
-	if crt.Status.NextPrivateKeySecretName == nil ||
-		len(*crt.Status.NextPrivateKeySecretName) == 0 {
-		// Do nothing if the next private key secret name is not set
-		return nil
-	}
-
-	// Fetch and parse the 'next private key secret'
-	nextPrivateKeySecret, err := c.secretLister.Secrets(crt.Namespace).Get(*crt.Status.NextPrivateKeySecretName)
-	if apierrors.IsNotFound(err) {
-		log.V(logf.DebugLevel).Info("Next private key secret does not exist, waiting for keymanager controller")
-		// If secret does not exist, do nothing (keymanager will handle this).
-		return nil
-	}
-	if err != nil {
-		return err
-	}
-	if nextPrivateKeySecret.Data == nil || len(nextPrivateKeySecret.Data[corev1.TLSPrivateKeyKey]) == 0 {
-		logf.WithResource(log, nextPrivateKeySecret).Info("Next private key secret does not contain any private key data, waiting for keymanager controller")
-		return nil
-	}
-	pk, _, err := utilkube.ParseTLSKeyFromSecret(nextPrivateKeySecret, corev1.TLSPrivateKeyKey)
-	if err != nil {
-		// If the private key cannot be parsed here, do nothing as the key manager will handle this.
-		logf.WithResource(log, nextPrivateKeySecret).Error(err, "failed to parse next private key, waiting for keymanager controller")
-		return nil
-	}
-	pkViolations, err := pki.PrivateKeyMatchesSpec(pk, crt.Spec)
-	if err != nil {
-		return err
-	}
-	if len(pkViolations) > 0 {
-		logf.WithResource(log, nextPrivateKeySecret).Info("stored next private key does not match requirements on Certificate resource, waiting for keymanager controller", "violations", pkViolations)
-		return nil
+	// The private key of a Certificate using an external CSR is not available
+	// to cert-manager, in which case pk is left nil. keyRef is only set if
+	// the private key is held by an external KMS.
+	var (
+		pk     crypto.Signer
+		keyRef []byte
+	)
+	if crt.Spec.ExternalCSR == nil {
+		pk, keyRef, err = c.nextPrivateKey(ctx, crt)
+		if err != nil || pk == nil {
+			return err
+		}
 	}
 
 	// CertificateRequest revisions begin from 1. If no revision is set on the
@@ -227,7 +374,7 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	reqs, err := certificates.ListCertificateRequestsMatchingPredicates(c.certificateRequestLister.CertificateRequests(crt.Namespace),
 		labels.Everything(),
 		predicate.CertificateRequestRevision(nextRevision),
-		predicate.ResourceOwnedBy(crt),
+		internalcertificates.CertificateRequestOwnedBy(crt),
 	)
 	if err != nil || len(reqs) != 1 {
 		// If error return.
@@ -238,7 +385,7 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	}
 
 	req := reqs[0]
-	log = logf.WithResource(log, req)
+	log = logf.WithRelatedCertificateRequest(log, req)
 
 	// Verify the CSR options match what is requested in certificate.spec.
 	// If there are violations in the spec, then the requestmanager will handle this.
@@ -289,65 +436,639 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	// now, bump the issuance attempts and set the Issuing status condition
 	// to False.
 	if apiutil.CertificateRequestIsDenied(req) {
-		return c.failIssueCertificate(ctx, log, crt, apiutil.GetCertificateRequestCondition(req, cmapi.CertificateRequestConditionDenied))
+		return c.failIssueCertificate(ctx, log, crt, req, apiutil.GetCertificateRequestCondition(req, cmapi.CertificateRequestConditionDenied))
 	}
 
 	// If the certificate request is invalid, set the last failure time to
 	// now, bump the issuance attempts and set the Issuing status condition
 	// to False.
 	if apiutil.CertificateRequestHasInvalidRequest(req) {
-		return c.failIssueCertificate(ctx, log, crt, apiutil.GetCertificateRequestCondition(req, cmapi.CertificateRequestConditionInvalidRequest))
+		return c.failIssueCertificate(ctx, log, crt, req, apiutil.GetCertificateRequestCondition(req, cmapi.CertificateRequestConditionInvalidRequest))
+	}
+
+	// If the CertificateRequest has made no progress within the issuance
+	// timeout, mark it as failed and fail the issuance so that it is retried
+	// with a new CertificateRequest after the usual backoff.
+	timedOutCond, err := c.timeoutCertificateRequest(ctx, key, crt, req)
+	if err != nil {
+		return err
+	}
+	if timedOutCond != nil {
+		return c.failIssueCertificate(ctx, log, crt, req, timedOutCond)
 	}
 
 	if crReadyCond == nil {
 		log.V(logf.DebugLevel).Info("CertificateRequest does not have Ready condition, waiting...")
-		return nil
+		return c.updateApprovalStatus(ctx, crt, req)
 	}
 
 	// If the certificate request has failed, set the last failure time to
 	// now, bump the issuance attempts and set the Issuing status condition
 	// to False.
 	if crReadyCond.Reason == cmapi.CertificateRequestReasonFailed {
-		return c.failIssueCertificate(ctx, log, crt, apiutil.GetCertificateRequestCondition(req, cmapi.CertificateRequestConditionReady))
+		return c.failIssueCertificate(ctx, log, crt, req, apiutil.GetCertificateRequestCondition(req, cmapi.CertificateRequestConditionReady))
 	}
 
 	// If public key does not match, do nothing (requestmanager will handle this).
-	csr, err := utilpki.DecodeX509CertificateRequestBytes(req.Spec.Request)
-	if err != nil {
-		return err
-	}
-	publicKeyMatchesCSR, err := utilpki.PublicKeyMatchesCSR(pk.Public(), csr)
-	if err != nil {
-		return err
-	}
-	if !publicKeyMatchesCSR {
-		logf.WithResource(log, nextPrivateKeySecret).Info("next private key does not match CSR public key, waiting for requestmanager controller")
-		return nil
+	if pk != nil {
+		csr, err := utilpki.DecodeX509CertificateRequestBytes(req.Spec.Request)
+		if err != nil {
+			return err
+		}
+		publicKeyMatchesCSR, err := utilpki.PublicKeyMatchesCSR(pk.Public(), csr)
+		if err != nil {
+			return err
+		}
+		if !publicKeyMatchesCSR {
+			log.Info("next private key does not match CSR public key, waiting for requestmanager controller")
+			return nil
+		}
 	}
 
 	// If the CertificateRequest is valid and ready, verify its status and issue
 	// accordingly.
 	if crReadyCond.Reason == cmapi.CertificateRequestReasonIssued {
-		return c.issueCertificate(ctx, nextRevision, crt, req, pk)
+		// Never overwrite the Secret with a certificate which is broken,
+		// as the certificate it holds may still be in use.
+		if c.verifyIssuedCertificates {
+			invalidCond, err := c.checkIssuedCertificate(ctx, req)
+			if err != nil {
+				return err
+			}
+			if invalidCond != nil {
+				return c.failIssueCertificate(ctx, log, crt, req, invalidCond)
+			}
+		}
+
+		// Never store a certificate which was issued for a different key
+		// than the one that was requested.
+		mismatchCond, err := c.checkIssuedPublicKey(ctx, req)
+		if err != nil {
+			return err
+		}
+		if mismatchCond != nil {
+			return c.failIssueCertificate(ctx, log, crt, req, mismatchCond)
+		}
+
+		// Never store a certificate which embeds fewer SCTs than its issuer
+		// requires, as it would be rejected by clients enforcing Certificate
+		// Transparency.
+		missingSCTsCond, err := c.checkEmbeddedSCTs(ctx, crt, req)
+		if err != nil {
+			return err
+		}
+		if missingSCTsCond != nil {
+			return c.failIssueCertificate(ctx, log, crt, req, missingSCTsCond)
+		}
+
+		// Don't store a certificate which is not yet valid, as it would be
+		// used straight away and fail TLS handshakes until it is.
+		waiting, notYetValidCond, err := c.checkNotBefore(ctx, key, crt, req)
+		if err != nil || waiting {
+			return err
+		}
+		if notYetValidCond != nil {
+			return c.failIssueCertificate(ctx, log, crt, req, notYetValidCond)
+		}
+
+		ca, err := c.secretCA(crt, req.Status.CA)
+		if errors.Is(err, errInvalidCABundle) {
+			return c.failIssueCertificate(ctx, log, crt, req, &cmapi.CertificateRequestCondition{
+				Reason:  reasonInvalidCABundle,
+				Message: err.Error(),
+			})
+		}
+		if err != nil {
+			return err
+		}
+
+		if c.verifyIssuedCertificates && c.verifyIssuedCertificateChain {
+			invalidCond, err := c.checkIssuedCertificateChain(ctx, req, ca)
+			if err != nil {
+				return err
+			}
+			if invalidCond != nil {
+				return c.failIssueCertificate(ctx, log, crt, req, invalidCond)
+			}
+		}
+
+		// Hold back a renewed certificate until the Certificate's rollout
+		// delay has elapsed, for clients caching the stored certificate.
+		pending, err := c.holdForRollout(ctx, key, crt, req)
+		if err != nil || pending {
+			return err
+		}
+
+		return c.issueCertificate(ctx, nextRevision, crt, req, pk, keyRef, ca)
 	}
 
 	// Issue temporary certificate if needed. If a certificate was issued, then
 	// return early - we will sync again since the target Secret has been
-	// updated.
-	if issued, err := c.ensureTemporaryCertificate(ctx, crt, pk); err != nil || issued {
-		return err
+	// updated. A temporary certificate cannot be issued without the private
+	// key material, so is never issued for a key held by an external KMS.
+	if pk != nil && keyRef == nil {
+		if issued, err := c.ensureTemporaryCertificate(ctx, crt, pk); err != nil || issued {
+			return err
+		}
 	}
 
 	// CertificateRequest is not in a final state so do nothing.
 	log.V(logf.DebugLevel).Info("CertificateRequest not in final state, waiting...", "reason", crReadyCond.Reason)
-	return nil
+	return c.updateApprovalStatus(ctx, crt, req)
+}
+
+// nextPrivateKey returns the private key stored in the Secret named in
+// 'status.nextPrivateKeySecretName'. A nil private key is returned if the
+// keymanager has not yet stored a private key matching the Certificate's spec.
+// If the private key is held by an external KMS, the returned key signs using
+// the KMS and the reference to the key is also returned.
+func (c *controller) nextPrivateKey(ctx context.Context, crt *cmapi.Certificate) (crypto.Signer, []byte, error) {
+	log := logf.FromContext(ctx)
+
+	if crt.Status.NextPrivateKeySecretName == nil ||
+		len(*crt.Status.NextPrivateKeySecretName) == 0 {
+		// Do nothing if the next private key secret name is not set
+		return nil, nil, nil
+	}
+
+	// Fetch and parse the 'next private key secret'
+	nextPrivateKeySecret, err := c.secretLister.Secrets(crt.Namespace).Get(*crt.Status.NextPrivateKeySecretName)
+	if apierrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("Next private key secret does not exist, waiting for keymanager controller")
+		// If secret does not exist, do nothing (keymanager will handle this).
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	log = logf.WithResource(log, nextPrivateKeySecret)
+
+	var (
+		pk     crypto.Signer
+		keyRef []byte
+	)
+	if internalcertificates.UsesExternalPrivateKey(crt.Spec) {
+		keyRef = nextPrivateKeySecret.Data[cmapi.PrivateKeyReferenceSecretKey]
+		if len(keyRef) == 0 {
+			log.Info("Next private key secret does not contain a private key reference, waiting for keymanager controller")
+			return nil, nil, nil
+		}
+		if c.keyProvider == nil {
+			log.Info("Certificate uses an external private key but no signer plugin is configured, waiting for keymanager controller")
+			return nil, nil, nil
+		}
+		pk, err = c.keyProvider.Signer(ctx, string(keyRef))
+		if err != nil {
+			return nil, nil, err
+		}
+	} else {
+		if nextPrivateKeySecret.Data == nil || len(nextPrivateKeySecret.Data[corev1.TLSPrivateKeyKey]) == 0 {
+			log.Info("Next private key secret does not contain any private key data, waiting for keymanager controller")
+			return nil, nil, nil
+		}
+		pk, _, err = utilkube.ParseTLSKeyFromSecret(nextPrivateKeySecret, corev1.TLSPrivateKeyKey)
+		if err != nil {
+			// If the private key cannot be parsed here, do nothing as the key manager will handle this.
+			log.Error(err, "failed to parse next private key, waiting for keymanager controller")
+			return nil, nil, nil
+		}
+	}
+	secret, err := c.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
+	if apierrors.IsNotFound(err) {
+		secret = nil
+	} else if err != nil {
+		return nil, nil, err
+	}
+	pkViolations, err := c.privateKeyDefaults.PrivateKeyMatchesSpec(pk, crt.Spec, secret)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(pkViolations) > 0 {
+		log.Info("stored next private key does not match requirements on Certificate resource, waiting for keymanager controller", "violations", pkViolations)
+		return nil, nil, nil
+	}
+
+	return pk, keyRef, nil
+}
+
+// issuanceTimeoutFor returns the issuance timeout for the given Certificate.
+// The controller default is used unless the Certificate overrides it with a
+// valid IssuanceTimeoutAnnotationKey annotation.
+func (c *controller) issuanceTimeoutFor(log logr.Logger, crt *cmapi.Certificate) time.Duration {
+	value, ok := crt.Annotations[cmapi.IssuanceTimeoutAnnotationKey]
+	if !ok {
+		return c.issuanceTimeout
+	}
+
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout < 0 {
+		log.V(logf.WarnLevel).Info("ignoring invalid issuance timeout annotation, using the controller default",
+			"annotation", cmapi.IssuanceTimeoutAnnotationKey, "value", value)
+		return c.issuanceTimeout
+	}
+
+	return timeout
+}
+
+// lastProgressTime returns the last time the given CertificateRequest made
+// progress. This is the latest of its creation, any of its condition
+// transitions, and any update made to its status.
+func lastProgressTime(req *cmapi.CertificateRequest) time.Time {
+	last := req.CreationTimestamp.Time
+	for _, cond := range req.Status.Conditions {
+		if cond.LastTransitionTime != nil && cond.LastTransitionTime.After(last) {
+			last = cond.LastTransitionTime.Time
+		}
+	}
+	for _, entry := range req.ManagedFields {
+		if entry.Subresource == "status" && entry.Time != nil && entry.Time.After(last) {
+			last = entry.Time.Time
+		}
+	}
+	return last
+}
+
+// timeoutCertificateRequest checks whether the given CertificateRequest has
+// made no progress within the Certificate's issuance timeout. If so, the
+// CertificateRequest is marked as failed so that it is no longer processed
+// by its issuer, and the condition with which the issuance should be failed
+// is returned. Otherwise, the Certificate is re-queued for when the timeout
+// would elapse and nil is returned.
+func (c *controller) timeoutCertificateRequest(ctx context.Context, key string, crt *cmapi.Certificate, req *cmapi.CertificateRequest) (*cmapi.CertificateRequestCondition, error) {
+	log := logf.FromContext(ctx)
+
+	switch apiutil.CertificateRequestReadyReason(req) {
+	case cmapi.CertificateRequestReasonFailed, cmapi.CertificateRequestReasonIssued:
+		// The CertificateRequest is already in a final state.
+		return nil, nil
+	}
+
+	timeout := c.issuanceTimeoutFor(log, crt)
+	if timeout <= 0 {
+		return nil, nil
+	}
+
+	sinceProgress := c.clock.Since(lastProgressTime(req))
+	if sinceProgress < timeout {
+		c.scheduledWorkQueue.Add(key, timeout-sinceProgress)
+		return nil, nil
+	}
+
+	message := fmt.Sprintf("CertificateRequest %q made no progress for %s", req.Name, timeout)
+	log.V(logf.InfoLevel).Info("CertificateRequest timed out, failing issuance", "timeout", timeout)
+
+	if err := c.failCertificateRequest(ctx, req, message); err != nil {
+		return nil, err
+	}
+
+	return &cmapi.CertificateRequestCondition{
+		Reason:  reasonIssuanceTimedOut,
+		Message: message,
+	}, nil
+}
+
+// checkIssuedPublicKey checks that the certificate issued for the given
+// CertificateRequest is for the public key that was requested. If it is not,
+// the CertificateRequest is marked as failed so that a new one is created on
+// the next issuance, and the condition with which the issuance should be
+// failed is returned.
+func (c *controller) checkIssuedPublicKey(ctx context.Context, req *cmapi.CertificateRequest) (*cmapi.CertificateRequestCondition, error) {
+	log := logf.FromContext(ctx)
+
+	x509Cert, err := utilpki.DecodeX509CertificateBytes(req.Status.Certificate)
+	if err != nil {
+		// Certificates which cannot be decoded are reported by the
+		// readiness policy checks once stored.
+		return nil, nil
+	}
+
+	message, mismatch, err := internalcertificates.IssuedPublicKeyMismatch(req, x509Cert)
+	if err != nil {
+		return nil, err
+	}
+	if !mismatch {
+		return nil, nil
+	}
+
+	log.V(logf.InfoLevel).Info("CertificateRequest was issued a certificate for the wrong public key, failing issuance")
+
+	if err := c.failCertificateRequest(ctx, req, message); err != nil {
+		return nil, err
+	}
+
+	return &cmapi.CertificateRequestCondition{
+		Reason:  policies.IssuedKeyMismatch,
+		Message: message,
+	}, nil
+}
+
+// checkNotBefore checks that the certificate issued for the given
+// CertificateRequest is valid, allowing for the clock skew tolerance. If it is
+// not yet valid, an Event is emitted, the Certificate is re-queued for when
+// it will be and true is returned. If it will not be valid within the
+// maximum wait, the CertificateRequest is marked as failed and the
+// condition with which the issuance should be failed is returned.
+func (c *controller) checkNotBefore(ctx context.Context, key string, crt *cmapi.Certificate, req *cmapi.CertificateRequest) (bool, *cmapi.CertificateRequestCondition, error) {
+	log := logf.FromContext(ctx)
+
+	x509Cert, err := utilpki.DecodeX509CertificateBytes(req.Status.Certificate)
+	if err != nil {
+		// Certificates which cannot be decoded are reported by the
+		// readiness policy checks once stored.
+		return false, nil, nil
+	}
+
+	untilValid := x509Cert.NotBefore.Sub(c.clock.Now()) - c.clockSkewTolerance
+	if untilValid <= 0 {
+		return false, nil, nil
+	}
+
+	notBefore := x509Cert.NotBefore.Format(time.RFC1123)
+	if untilValid > c.maxNotBeforeWait {
+		message := fmt.Sprintf("The certificate issued for CertificateRequest %q is not valid until %s, which is too far in the future", req.Name, notBefore)
+		log.V(logf.InfoLevel).Info("CertificateRequest was issued a certificate which is not valid until too far in the future, failing issuance", "notBefore", notBefore)
+
+		if err := c.failCertificateRequest(ctx, req, message); err != nil {
+			return false, nil, err
+		}
+
+		return false, &cmapi.CertificateRequestCondition{
+			Reason:  policies.NotYetValid,
+			Message: message,
+		}, nil
+	}
+
+	message := fmt.Sprintf("The certificate issued for CertificateRequest %q is not valid until %s, it will be stored once it is", req.Name, notBefore)
+	log.V(logf.InfoLevel).Info("CertificateRequest was issued a certificate which is not yet valid, waiting", "notBefore", notBefore)
+	c.recorder.Event(crt, corev1.EventTypeNormal, policies.NotYetValid, message)
+	c.scheduledWorkQueue.Add(key, untilValid)
+
+	return true, nil, nil
+}
+
+// holdForRollout checks whether the certificate issued for the given
+// CertificateRequest must be held back from the Secret as the Certificate's
+// rolloutDelay, counted from when the CertificateRequest was issued, has not
+// yet elapsed. Only routine renewals are held back: a certificate issued
+// because the Secret is invalid, the spec or the issuer changed, or which was
+// triggered manually, is stored straight away. The first certificate issued
+// for a Certificate is not held back either, nor is a certificate replacing
+// one which expires within rolloutExpiryMargin. While the certificate is held
+// back, the Issuing condition reports that it is pending rollout, and the
+// Certificate is re-queued for when it is to be stored.
+func (c *controller) holdForRollout(ctx context.Context, key string, crt *cmapi.Certificate, req *cmapi.CertificateRequest) (bool, error) {
+	log := logf.FromContext(ctx)
+
+	if crt.Spec.RolloutDelay == nil || crt.Spec.RolloutDelay.Duration <= 0 || crt.Status.Revision == nil {
+		return false, nil
+	}
+	// The Issuing reason of a renewal is replaced by PendingRollout once the
+	// certificate is held back.
+	issuingCond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing)
+	if issuingCond == nil || (issuingCond.Reason != policies.IssuingReasonRenewal && issuingCond.Reason != cmapi.CertificateReasonPendingRollout) {
+		return false, nil
+	}
+	readyCond := apiutil.GetCertificateRequestCondition(req, cmapi.CertificateRequestConditionReady)
+	if readyCond == nil || readyCond.LastTransitionTime == nil {
+		return false, nil
+	}
+	rolloutTime := readyCond.LastTransitionTime.Add(crt.Spec.RolloutDelay.Duration)
+
+	secret, err := c.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	stored, err := utilpki.DecodeX509CertificateBytes(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		// There is no certificate in use which clients could have cached.
+		return false, nil
+	}
+	if expiryRolloutTime := stored.NotAfter.Add(-rolloutExpiryMargin); expiryRolloutTime.Before(rolloutTime) {
+		rolloutTime = expiryRolloutTime
+	}
+
+	untilRollout := rolloutTime.Sub(c.clock.Now())
+	if untilRollout <= 0 {
+		return false, nil
+	}
+	c.scheduledWorkQueue.Add(key, untilRollout)
+
+	message := fmt.Sprintf("The certificate issued for CertificateRequest %q is pending rollout, it will be stored in the Secret at %s",
+		req.Name, rolloutTime.UTC().Format(time.RFC3339))
+	if issuingCond.Reason == cmapi.CertificateReasonPendingRollout && issuingCond.Message == message {
+		return true, nil
+	}
+	log.V(logf.InfoLevel).Info("Certificate has been issued, holding it back until the rollout delay has elapsed", "rolloutTime", rolloutTime)
+
+	crt = crt.DeepCopy()
+	apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionIssuing, cmmeta.ConditionTrue, cmapi.CertificateReasonPendingRollout, message)
+	if err := c.updateOrApplyStatus(ctx, crt, false); err != nil {
+		return false, err
+	}
+	c.recorder.Event(crt, corev1.EventTypeNormal, cmapi.CertificateReasonPendingRollout, message)
+
+	return true, nil
+}
+
+// checkIssuedCertificate checks that every certificate issued for the given
+// CertificateRequest can be parsed, that the leaf certificate includes all of
+// the subject alternative names which were requested, and that it has not
+// expired. If it does not, the CertificateRequest is marked as failed and the
+// condition with which the issuance should be failed is returned.
+// The public key of the leaf certificate is checked by checkIssuedPublicKey.
+func (c *controller) checkIssuedCertificate(ctx context.Context, req *cmapi.CertificateRequest) (*cmapi.CertificateRequestCondition, error) {
+	chain, err := decodeIssuedCertificateChain(req.Status.Certificate)
+	if err != nil {
+		return c.invalidIssuedCertificate(ctx, req, fmt.Sprintf("The certificate issued for CertificateRequest %q could not be parsed: %v", req.Name, err))
+	}
+	leaf := chain[0]
+
+	csr, err := utilpki.DecodeX509CertificateRequestBytes(req.Spec.Request)
+	if err != nil {
+		return nil, err
+	}
+	if missing := missingSubjectAltName(csr, leaf); missing != "" {
+		return c.invalidIssuedCertificate(ctx, req, fmt.Sprintf("The certificate issued for CertificateRequest %q does not include the requested %s", req.Name, missing))
+	}
+
+	if !leaf.NotAfter.After(c.clock.Now()) {
+		return c.invalidIssuedCertificate(ctx, req, fmt.Sprintf("The certificate issued for CertificateRequest %q expired at %s", req.Name, leaf.NotAfter.Format(time.RFC1123)))
+	}
+
+	return nil, nil
+}
+
+// checkIssuedCertificateChain checks that the chain of certificates issued
+// for the given CertificateRequest verifies against the given CA certificates,
+// which will be stored alongside it. Nothing is checked if there are no CA
+// certificates. If the chain does not verify, the CertificateRequest is marked
+// as failed and the condition with which the issuance should be failed is
+// returned.
+func (c *controller) checkIssuedCertificateChain(ctx context.Context, req *cmapi.CertificateRequest, ca []byte) (*cmapi.CertificateRequestCondition, error) {
+	if len(ca) == 0 {
+		return nil, nil
+	}
+
+	chain, err := decodeIssuedCertificateChain(req.Status.Certificate)
+	if err != nil {
+		return c.invalidIssuedCertificate(ctx, req, fmt.Sprintf("The certificate issued for CertificateRequest %q could not be parsed: %v", req.Name, err))
+	}
+
+	roots, err := utilpki.DecodeX509CertificateSetBytes(ca)
+	if err != nil {
+		return c.invalidIssuedCertificate(ctx, req, fmt.Sprintf("The CA certificate for CertificateRequest %q could not be parsed: %v", req.Name, err))
+	}
+
+	opts := x509.VerifyOptions{
+		Roots:         x509.NewCertPool(),
+		Intermediates: x509.NewCertPool(),
+		CurrentTime:   c.clock.Now(),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}
+	for _, root := range roots {
+		opts.Roots.AddCert(root)
+	}
+	for _, intermediate := range chain[1:] {
+		opts.Intermediates.AddCert(intermediate)
+	}
+	if _, err := chain[0].Verify(opts); err != nil {
+		return c.invalidIssuedCertificate(ctx, req, fmt.Sprintf("The certificate issued for CertificateRequest %q does not verify against its CA certificate: %v", req.Name, err))
+	}
+
+	return nil, nil
+}
+
+// invalidIssuedCertificate marks the given CertificateRequest as failed with
+// the given message, and returns the condition with which the issuance should
+// be failed.
+func (c *controller) invalidIssuedCertificate(ctx context.Context, req *cmapi.CertificateRequest, message string) (*cmapi.CertificateRequestCondition, error) {
+	return c.rejectIssuedCertificate(ctx, req, reasonIssuedCertificateInvalid, message)
+}
+
+// rejectIssuedCertificate fails the CertificateRequest whose issued
+// certificate is not stored, and returns the condition with which the
+// issuance is failed.
+func (c *controller) rejectIssuedCertificate(ctx context.Context, req *cmapi.CertificateRequest, reason, message string) (*cmapi.CertificateRequestCondition, error) {
+	logf.FromContext(ctx).V(logf.InfoLevel).Info("CertificateRequest was issued an invalid certificate, failing issuance", "reason", reason, "message", message)
+
+	if err := c.failCertificateRequest(ctx, req, message); err != nil {
+		return nil, err
+	}
+
+	return &cmapi.CertificateRequestCondition{
+		Reason:  reason,
+		Message: message,
+	}, nil
+}
+
+// decodeIssuedCertificateChain decodes the PEM encoded chain of certificates
+// issued for a CertificateRequest. Unlike DecodeX509CertificateChainBytes, a
+// chain which is truncated or followed by anything other than whitespace is
+// an error.
+func decodeIssuedCertificateChain(certBytes []byte) ([]*x509.Certificate, error) {
+	var chain []*x509.Certificate
+	rest := certBytes
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("unexpected PEM block of type %q", block.Type)
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, cert)
+	}
+
+	if len(chain) == 0 {
+		return nil, errors.New("no PEM encoded certificate found")
+	}
+	if len(strings.TrimSpace(string(rest))) > 0 {
+		return nil, fmt.Errorf("%d bytes could not be decoded after certificate %d of the chain", len(rest), len(chain))
+	}
+	return chain, nil
+}
+
+// missingSubjectAltName returns the first subject alternative name requested
+// in csr which is not included in cert, or an empty string if they all are.
+func missingSubjectAltName(csr *x509.CertificateRequest, cert *x509.Certificate) string {
+	for _, dnsName := range csr.DNSNames {
+		if !slices.ContainsFunc(cert.DNSNames, func(s string) bool { return strings.EqualFold(s, dnsName) }) {
+			return fmt.Sprintf("DNS name %q", dnsName)
+		}
+	}
+	for _, ip := range csr.IPAddresses {
+		if !slices.ContainsFunc(cert.IPAddresses, ip.Equal) {
+			return fmt.Sprintf("IP address %q", ip)
+		}
+	}
+	for _, uri := range csr.URIs {
+		if !slices.ContainsFunc(cert.URIs, func(u *url.URL) bool { return u.String() == uri.String() }) {
+			return fmt.Sprintf("URI %q", uri)
+		}
+	}
+	for _, email := range csr.EmailAddresses {
+		if !slices.ContainsFunc(cert.EmailAddresses, func(s string) bool { return strings.EqualFold(s, email) }) {
+			return fmt.Sprintf("email address %q", email)
+		}
+	}
+	return ""
+}
+
+// verifyIssuerCA returns true if the Certificate or its issuer have the
+// verify-issuer-ca annotation set to "true". External issuers, and issuers
+// which cannot be read, are treated as not having opted in.
+func (c *controller) verifyIssuerCA(crt *cmapi.Certificate) bool {
+	if crt.Annotations[cmapi.VerifyIssuerCAAnnotationKey] == "true" {
+		return true
+	}
+
+	genericIssuer := c.certificateIssuer(crt)
+	if genericIssuer == nil {
+		return false
+	}
+	return genericIssuer.GetObjectMeta().Annotations[cmapi.VerifyIssuerCAAnnotationKey] == "true"
+}
+
+// certificateIssuer returns the cert-manager Issuer or ClusterIssuer
+// referenced by the Certificate, or nil if it does not reference one or the
+// issuer cannot be found.
+func (c *controller) certificateIssuer(crt *cmapi.Certificate) cmapi.GenericIssuer {
+	kind, group := apiutil.NormalizeIssuerKindAndGroup(crt.Spec.IssuerRef.Kind, crt.Spec.IssuerRef.Group)
+	if group != cmapi.SchemeGroupVersion.Group {
+		return nil
+	}
+	ref := crt.Spec.IssuerRef
+	ref.Kind = kind
+	genericIssuer, err := c.helper.GetGenericIssuer(ref, crt.Namespace)
+	if err != nil {
+		return nil
+	}
+	return genericIssuer
+}
+
+// failCertificateRequest marks the given CertificateRequest as failed with the
+// given message.
+func (c *controller) failCertificateRequest(ctx context.Context, req *cmapi.CertificateRequest, message string) error {
+	req = req.DeepCopy()
+	apiutil.SetCertificateRequestCondition(req, cmapi.CertificateRequestConditionReady, cmmeta.ConditionFalse, cmapi.CertificateRequestReasonFailed, message)
+	nowTime := metav1.NewTime(c.clock.Now())
+	req.Status.FailureTime = &nowTime
+	return c.updateOrApplyRequestStatus(ctx, req)
 }
 
 // failIssueCertificate will mark the Issuing condition of this Certificate as
 // false, set the Certificate's last failure time and issuance attempts, and log
 // an appropriate event. The reason and message of the Issuing condition will be that of
 // the CertificateRequest condition passed.
-func (c *controller) failIssueCertificate(ctx context.Context, log logr.Logger, crt *cmapi.Certificate, condition *cmapi.CertificateRequestCondition) error {
+func (c *controller) failIssueCertificate(ctx context.Context, log logr.Logger, crt *cmapi.Certificate, req *cmapi.CertificateRequest, condition *cmapi.CertificateRequestCondition) error {
 	nowTime := metav1.NewTime(c.clock.Now())
 	crt.Status.LastFailureTime = &nowTime
 
@@ -366,50 +1087,122 @@ func (c *controller) failIssueCertificate(ctx context.Context, log logr.Logger,
 
 	crt = crt.DeepCopy()
 	apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionIssuing, cmmeta.ConditionFalse, reason, message)
+	apiutil.RemoveCertificateCondition(crt, cmapi.CertificateConditionWaitingForApproval)
+
+	nextRevision := 1
+	if crt.Status.Revision != nil {
+		nextRevision = *crt.Status.Revision + 1
+	}
+	c.recordIssuanceAttempt(crt, req, nextRevision, cmapi.CertificateIssuanceOutcomeFailed, reason, condition.Message)
 
 	if err := c.updateOrApplyStatus(ctx, crt, false); err != nil {
 		return err
 	}
 
-	c.recorder.Event(crt, corev1.EventTypeWarning, reason, message)
+	// Include the URL of the ACME order in the event, as it is needed when
+	// escalating a failed issuance to the CA.
+	if orderURL := req.Annotations[cmacme.OrderURLAnnotationKey]; orderURL != "" {
+		message = fmt.Sprintf("%s (ACME order: %s)", message, orderURL)
+	}
+	c.failureEvents.Event(ctx, crt, reason, message)
 
 	return nil
 }
 
 // issueCertificate will ensure the public key of the CSR matches the signed
 // certificate, and then store the certificate, CA and private key into the
-// Secret in the appropriate format type.
-func (c *controller) issueCertificate(ctx context.Context, nextRevision int, crt *cmapi.Certificate, req *cmapi.CertificateRequest, pk crypto.Signer) error {
+// Secret in the appropriate format type. ca is stored in place of the CA
+// provided by the issuer, as determined by the Certificate's SecretCAPolicy.
+func (c *controller) issueCertificate(ctx context.Context, nextRevision int, crt *cmapi.Certificate, req *cmapi.CertificateRequest, pk crypto.Signer, keyRef []byte, ca []byte) error {
 	crt = crt.DeepCopy()
 	if crt.Spec.PrivateKey == nil {
 		crt.Spec.PrivateKey = &cmapi.CertificatePrivateKey{}
 	}
 
-	pkData, err := utilpki.EncodePrivateKey(pk, crt.Spec.PrivateKey.Encoding)
-	if err != nil {
-		return err
+	// pk is nil if the Certificate uses an external CSR, in which case no
+	// private key is written to the Secret. Only the reference is written
+	// for a private key held by an external KMS.
+	var pkData []byte
+	if pk != nil && keyRef == nil {
+		var err error
+		pkData, err = utilpki.EncodePrivateKey(pk, crt.Spec.PrivateKey.Encoding)
+		if err != nil {
+			return err
+		}
 	}
 	secretData := internal.SecretData{
-		PrivateKey:      pkData,
-		Certificate:     req.Status.Certificate,
-		CA:              req.Status.CA,
-		CertificateName: crt.Name,
-		IssuerName:      req.Spec.IssuerRef.Name,
-		IssuerKind:      req.Spec.IssuerRef.Kind,
-		IssuerGroup:     req.Spec.IssuerRef.Group,
+		PrivateKey:          pkData,
+		PrivateKeyReference: keyRef,
+		Certificate:         req.Status.Certificate,
+		CA:                  ca,
+		CertificateName:     crt.Name,
+		IssuerName:          req.Spec.IssuerRef.Name,
+		IssuerKind:          req.Spec.IssuerRef.Kind,
+		IssuerGroup:         req.Spec.IssuerRef.Group,
+		PrivateKeyDefaulted: pk != nil && internalcertificates.PrivateKeyDefaulted(crt.Spec),
+		ConvertOpaqueSecret: true,
 	}
 
+	// Secrets of type Opaque, for example created by a previous manual
+	// process, are converted to `kubernetes.io/tls` Secrets when written.
+	existingSecret, err := c.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	convertingSecret := err == nil && existingSecret.Type == corev1.SecretTypeOpaque
+
 	if err := c.secretsUpdateData(ctx, crt, secretData); err != nil {
+		var conflictErr *internal.SecretTypeConflictError
+		if errors.As(err, &conflictErr) {
+			return c.setSecretTypeConflict(ctx, crt, conflictErr.Error())
+		}
+		// The Secret is not written, rather than being rejected by the
+		// apiserver on every attempt, and issuance is retried with backoff.
+		var tooLargeErr *internal.SecretTooLargeError
+		if errors.As(err, &tooLargeErr) {
+			return c.failIssueCertificate(ctx, logf.FromContext(ctx), crt, req, &cmapi.CertificateRequestCondition{
+				Reason:  reasonSecretTooLarge,
+				Message: tooLargeErr.Error(),
+			})
+		}
 		return err
 	}
 
+	if convertingSecret {
+		message := fmt.Sprintf("Converted Secret %q from type %q to %q, keeping its existing labels, annotations and data keys",
+			crt.Spec.SecretName, corev1.SecretTypeOpaque, corev1.SecretTypeTLS)
+		c.recorder.Event(crt, corev1.EventTypeNormal, reasonSecretConverted, message)
+	}
+
 	// Set status.revision to revision of the CertificateRequest
 	crt.Status.Revision = &nextRevision
 
+	// Record the serial number, fingerprint and DNs of the issued certificate
+	// alongside status.revision. The serial number is used to revoke the
+	// certificate once the Certificate is deleted. The fields are cleared if
+	// the certificate cannot be decoded.
+	x509Cert, _ := utilpki.DecodeX509CertificateBytes(req.Status.Certificate)
+	internalcertificates.SetIssuedCertificateStatus(crt, x509Cert)
+
+	// Record the CAs returned by the issuer if the Certificate or its issuer
+	// opted into verifying them, so that a certificate in the Secret which is
+	// signed by another CA is re-issued.
+	crt.Status.IssuerCAFingerprints = nil
+	if c.verifyIssuerCA(crt) {
+		fingerprints, err := internalcertificates.IssuerCAFingerprints(req)
+		if err != nil {
+			logf.FromContext(ctx).V(logf.WarnLevel).Info("failed to record the CAs of the issued certificate, they will not be verified", "error", err)
+		}
+		crt.Status.IssuerCAFingerprints = fingerprints
+	}
+
 	// Remove Issuing status condition
 	// TODO @joshvanl: Once we move to only server-side apply API calls, this
 	// should be changed to setting the Issuing condition to False.
 	apiutil.RemoveCertificateCondition(crt, cmapi.CertificateConditionIssuing)
+	apiutil.RemoveCertificateCondition(crt, cmapi.CertificateConditionSecretTypeConflict)
+	apiutil.RemoveCertificateCondition(crt, cmapi.CertificateConditionInvalidCABundle)
+	apiutil.RemoveCertificateCondition(crt, cmapi.CertificateConditionWaitingForApproval)
 
 	// Clear status.failedIssuanceAttempts (if set)
 	crt.Status.FailedIssuanceAttempts = nil
@@ -417,17 +1210,103 @@ func (c *controller) issueCertificate(ctx context.Context, nextRevision int, crt
 	// Clear status.lastFailureTime (if set)
 	crt.Status.LastFailureTime = nil
 
+	message := "The certificate has been successfully issued"
+	c.recordIssuanceAttempt(crt, req, nextRevision, cmapi.CertificateIssuanceOutcomeIssued, "Issued", message)
+
 	if err := c.updateOrApplyStatus(ctx, crt, true); err != nil {
 		return err
 	}
 
-	message := "The certificate has been successfully issued"
 	c.recorder.Event(crt, corev1.EventTypeNormal, "Issuing", message)
 
 	return nil
 
 }
 
+// recordIssuanceAttempt records the outcome of the attempt to issue the given
+// revision of the Certificate using req in the Certificate's issuance history.
+// The attempt started when req was created.
+func (c *controller) recordIssuanceAttempt(crt *cmapi.Certificate, req *cmapi.CertificateRequest, revision int, outcome cmapi.CertificateIssuanceOutcome, reason, message string) {
+	endTime := metav1.NewTime(c.clock.Now())
+	attempt := cmapi.CertificateIssuanceAttempt{
+		Revision:               revision,
+		CertificateRequestName: req.Name,
+		EndTime:                &endTime,
+		Outcome:                outcome,
+		Reason:                 reason,
+		Message:                message,
+	}
+	if !req.CreationTimestamp.IsZero() {
+		startTime := req.CreationTimestamp
+		attempt.StartTime = &startTime
+	}
+	internalcertificates.RecordIssuanceAttempt(crt, attempt, c.issuanceHistoryLimit)
+}
+
+// updateApprovalStatus is called while the CertificateRequest for the next
+// revision is not yet in a final state. It sets the WaitingForApproval
+// condition of the Certificate while the CertificateRequest has not been
+// approved, e.g. by an approval plugin, so that a stuck issuance is visible on
+// the Certificate, and sets it to False once it has been. The Issuing
+// condition is left untouched, as its reason records why the issuance was
+// triggered.
+func (c *controller) updateApprovalStatus(ctx context.Context, crt *cmapi.Certificate, req *cmapi.CertificateRequest) error {
+	log := logf.FromContext(ctx)
+
+	if !apiutil.CertificateHasCondition(crt, cmapi.CertificateCondition{
+		Type:   cmapi.CertificateConditionIssuing,
+		Status: cmmeta.ConditionTrue,
+	}) {
+		return nil
+	}
+	approved := apiutil.CertificateRequestIsApproved(req)
+	waiting := apiutil.CertificateHasCondition(crt, cmapi.CertificateCondition{
+		Type:   cmapi.CertificateConditionWaitingForApproval,
+		Status: cmmeta.ConditionTrue,
+	})
+	if approved != waiting {
+		return nil
+	}
+
+	status, reason := cmmeta.ConditionTrue, cmapi.CertificateReasonWaitingForApproval
+	message := fmt.Sprintf("Waiting for CertificateRequest %q to be approved", req.Name)
+	if approved {
+		status, reason = cmmeta.ConditionFalse, cmapi.CertificateReasonApproved
+		message = fmt.Sprintf("CertificateRequest %q has been approved, waiting for it to be issued", req.Name)
+	}
+	log.V(logf.DebugLevel).Info(message)
+
+	crt = crt.DeepCopy()
+	apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionWaitingForApproval, status, reason, message)
+	if err := c.updateOrApplyStatus(ctx, crt, false); err != nil {
+		return err
+	}
+	c.recorder.Event(crt, corev1.EventTypeNormal, reason, message)
+
+	return nil
+}
+
+// setSecretTypeConflict sets the SecretTypeConflict condition on the
+// Certificate, rather than retrying to write to a Secret which cannot be
+// converted. The Certificate is processed again once the Secret changes.
+func (c *controller) setSecretTypeConflict(ctx context.Context, crt *cmapi.Certificate, message string) error {
+	if apiutil.CertificateHasCondition(crt, cmapi.CertificateCondition{
+		Type:   cmapi.CertificateConditionSecretTypeConflict,
+		Status: cmmeta.ConditionTrue,
+	}) {
+		return nil
+	}
+
+	apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionSecretTypeConflict, cmmeta.ConditionTrue, reasonSecretTypeConflict, message)
+	if err := c.updateOrApplyStatus(ctx, crt, false); err != nil {
+		return err
+	}
+
+	c.recorder.Event(crt, corev1.EventTypeWarning, reasonSecretTypeConflict, message)
+
+	return nil
+}
+
 // updateOrApplyStatus will update the controller status. If the
 // ServerSideApply feature is enabled, the managed fields will instead get
 // applied using the relevant Patch API call.
@@ -446,16 +1325,25 @@ func (c *controller) updateOrApplyStatus(ctx context.Context, crt *cmapi.Certifi
 		}
 
 		var conditions []cmapi.CertificateCondition
-		if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing); cond != nil {
-			conditions = []cmapi.CertificateCondition{*cond}
+		for _, condType := range []cmapi.CertificateConditionType{cmapi.CertificateConditionIssuing, cmapi.CertificateConditionSecretTypeConflict, cmapi.CertificateConditionInvalidCABundle, cmapi.CertificateConditionWaitingForApproval} {
+			if cond := apiutil.GetCertificateCondition(crt, condType); cond != nil {
+				conditions = append(conditions, *cond)
+			}
 		}
 
 		return internalcertificates.ApplyStatus(ctx, c.client, c.fieldManager, &cmapi.Certificate{
 			ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace, Name: crt.Name},
 			Status: cmapi.CertificateStatus{
-				Revision:        crt.Status.Revision,
-				LastFailureTime: crt.Status.LastFailureTime,
-				Conditions:      conditions,
+				Revision:               crt.Status.Revision,
+				LastFailureTime:        crt.Status.LastFailureTime,
+				SerialNumber:           crt.Status.SerialNumber,
+				SHA256Fingerprint:      crt.Status.SHA256Fingerprint,
+				ShortSHA256Fingerprint: crt.Status.ShortSHA256Fingerprint,
+				IssuerDN:               crt.Status.IssuerDN,
+				SubjectDN:              crt.Status.SubjectDN,
+				IssuerCAFingerprints:   crt.Status.IssuerCAFingerprints,
+				IssuanceHistory:        crt.Status.IssuanceHistory,
+				Conditions:             conditions,
 			},
 		})
 	} else {
@@ -464,6 +1352,18 @@ func (c *controller) updateOrApplyStatus(ctx context.Context, crt *cmapi.Certifi
 	}
 }
 
+// updateOrApplyRequestStatus will update the status of the given
+// CertificateRequest. If the ServerSideApply feature is enabled, the status
+// will instead get applied using the relevant Patch API call.
+func (c *controller) updateOrApplyRequestStatus(ctx context.Context, req *cmapi.CertificateRequest) error {
+	if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
+		return internalcertificaterequests.ApplyStatus(ctx, c.client, c.fieldManager, req)
+	} else {
+		_, err := c.client.CertmanagerV1().CertificateRequests(req.Namespace).UpdateStatus(ctx, req, metav1.UpdateOptions{})
+		return err
+	}
+}
+
 // controllerWrapper wraps the `controller` structure to make it implement
 // the controllerpkg.queueingController interface
 type controllerWrapper struct {
@@ -32,10 +32,16 @@ import (
 	fakeclock "k8s.io/utils/clock/testing"
 	"k8s.io/utils/ptr"
 
+	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
+	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/issuing/internal"
 	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	schedulertest "github.com/cert-manager/cert-manager/pkg/scheduler/test"
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
 	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
 )
@@ -86,6 +92,25 @@ func TestIssuingController(t *testing.T) {
 		}),
 	)
 
+	exampleIssuedStatus := func(crt *cmapi.Certificate) {
+		internalcertificates.SetIssuedCertificateStatus(crt, exampleBundle.Cert)
+	}
+
+	nextPrivateKeySecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      nextPrivateKeySecretName,
+			Namespace: exampleBundle.Certificate.Namespace,
+		},
+		Data: map[string][]byte{
+			corev1.TLSPrivateKeyKey: exampleBundle.PrivateKeyBytes,
+		},
+	}
+	omitCAPolicy := &cmapi.CertificateSecretCAPolicy{Type: cmapi.CertificateSecretCAPolicyOmit}
+	bundleCAPolicy := &cmapi.CertificateSecretCAPolicy{
+		Type:      cmapi.CertificateSecretCAPolicyFromSecretRef,
+		BundleRef: &cmapi.CertificateCABundleReference{Kind: "ConfigMap", Name: "ca-bundle", Key: "ca.crt"},
+	}
+
 	tests := map[string]testT{
 		"if certificate is not in Issuing state, then do nothing": {
 			certificate: exampleBundle.Certificate,
@@ -169,6 +194,11 @@ func TestIssuingController(t *testing.T) {
 						gen.AddCertificateRequestAnnotations(map[string]string{
 							cmapi.CertificateRequestRevisionAnnotationKey: "2", // Current Certificate revision=1
 						}),
+						gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+							Type:   cmapi.CertificateRequestConditionApproved,
+							Status: cmmeta.ConditionTrue,
+							Reason: "cert-manager.io",
+						}),
 						gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
 							Type:   cmapi.CertificateRequestConditionReady,
 							Status: cmmeta.ConditionFalse,
@@ -301,6 +331,59 @@ func TestIssuingController(t *testing.T) {
 			},
 			expectedErr: false,
 		},
+		"if certificate is in Issuing state, one CertificateRequest, and was issued by an ACME issuer and has failed, include the order URL in the event": {
+			certificate: exampleBundle.Certificate,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					gen.CertificateFrom(issuingCert),
+					gen.CertificateRequestFrom(exampleBundle.CertificateRequestFailed,
+						gen.AddCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestRevisionAnnotationKey: "2", // Current Certificate revision=1
+							cmacme.OrderURLAnnotationKey:                  "https://acme.example.com/order/1",
+						}),
+						gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+							Type:    cmapi.CertificateRequestConditionReady,
+							Status:  cmmeta.ConditionFalse,
+							Reason:  cmapi.CertificateRequestReasonFailed,
+							Message: "The certificate request failed because of reasons",
+						}),
+					)},
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      nextPrivateKeySecretName,
+							Namespace: exampleBundle.Certificate.Namespace,
+						},
+						Data: map[string][]byte{
+							corev1.TLSPrivateKeyKey: exampleBundle.PrivateKeyBytes,
+						},
+					},
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						exampleBundle.Certificate.Namespace,
+						gen.CertificateFrom(exampleBundle.Certificate,
+							gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+								Type:               cmapi.CertificateConditionIssuing,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             "Failed",
+								Message:            "The certificate request has failed to complete and will be retried: The certificate request failed because of reasons",
+								LastTransitionTime: &metaFixedClockStart,
+								ObservedGeneration: 3,
+							}),
+							gen.SetCertificateLastFailureTime(metaFixedClockStart),
+							gen.SetCertificateIssuanceAttempts(ptr.To(1)),
+						),
+					)),
+				},
+				ExpectedEvents: []string{
+					"Warning Failed The certificate request has failed to complete and will be retried: The certificate request failed because of reasons (ACME order: https://acme.example.com/order/1)",
+				},
+			},
+			expectedErr: false,
+		},
 		"if certificate is in Issuing state, one CertificateRequest, and has failed for the fifth time during this series of attempts, set failed state with five issuance attempts and log event": {
 			certificate: exampleBundle.Certificate,
 			builder: &testpkg.Builder{
@@ -499,6 +582,138 @@ func TestIssuingController(t *testing.T) {
 						exampleBundle.Certificate.Namespace,
 						gen.CertificateFrom(exampleBundle.Certificate,
 							gen.SetCertificateRevision(2),
+							exampleIssuedStatus,
+						),
+					)),
+				},
+				ExpectedEvents: []string{
+					"Normal Issuing The certificate has been successfully issued",
+				},
+			},
+			expSecretUpdateDataCall: &internal.SecretData{
+				Certificate:         exampleBundle.CertificateRequestReady.Status.Certificate,
+				PrivateKey:          exampleBundle.PrivateKeyBytes,
+				CA:                  nil,
+				CertificateName:     "test",
+				IssuerName:          "ca-issuer",
+				IssuerKind:          "Issuer",
+				IssuerGroup:         "foo.io",
+				PrivateKeyDefaulted: true,
+				ConvertOpaqueSecret: true,
+			},
+			expectedErr: false,
+		},
+
+		"if certificate uses an external CSR and is in Issuing state, one CertificateRequest, and is ready, store the signed certificate and ca without a private key, and log an event": {
+			certificate: exampleBundle.Certificate,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					gen.CertificateFrom(issuingCert,
+						gen.SetCertificateExternalCSR("csr", ""),
+					),
+					gen.CertificateRequestFrom(exampleBundle.CertificateRequestReady,
+						gen.AddCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestRevisionAnnotationKey: "2", // Current Certificate revision=1
+						}),
+					)},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						exampleBundle.Certificate.Namespace,
+						gen.CertificateFrom(exampleBundle.Certificate,
+							gen.SetCertificateExternalCSR("csr", ""),
+							gen.SetCertificateRevision(2),
+							exampleIssuedStatus,
+						),
+					)),
+				},
+				ExpectedEvents: []string{
+					"Normal Issuing The certificate has been successfully issued",
+				},
+			},
+			expSecretUpdateDataCall: &internal.SecretData{
+				Certificate:         exampleBundle.CertificateRequestReady.Status.Certificate,
+				PrivateKey:          nil,
+				CA:                  nil,
+				CertificateName:     "test",
+				IssuerName:          "ca-issuer",
+				IssuerKind:          "Issuer",
+				IssuerGroup:         "foo.io",
+				ConvertOpaqueSecret: true,
+			},
+			expectedErr: false,
+		},
+
+		"if certificate has the Omit SecretCAPolicy and one CertificateRequest is ready, store the signed certificate and private key without the issuer's ca": {
+			certificate: exampleBundle.Certificate,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					gen.CertificateFrom(issuingCert, gen.SetCertificateSecretCAPolicy(omitCAPolicy)),
+					gen.CertificateRequestFrom(exampleBundle.CertificateRequestReady,
+						gen.AddCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestRevisionAnnotationKey: "2", // Current Certificate revision=1
+						}),
+						gen.SetCertificateRequestCA(exampleBundle.CertBytes),
+					)},
+				KubeObjects: []runtime.Object{nextPrivateKeySecret},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						exampleBundle.Certificate.Namespace,
+						gen.CertificateFrom(exampleBundle.Certificate,
+							gen.SetCertificateSecretCAPolicy(omitCAPolicy),
+							gen.SetCertificateRevision(2),
+							exampleIssuedStatus,
+						),
+					)),
+				},
+				ExpectedEvents: []string{
+					"Normal Issuing The certificate has been successfully issued",
+				},
+			},
+			expSecretUpdateDataCall: &internal.SecretData{
+				Certificate:         exampleBundle.CertificateRequestReady.Status.Certificate,
+				PrivateKey:          exampleBundle.PrivateKeyBytes,
+				CA:                  nil,
+				CertificateName:     "test",
+				IssuerName:          "ca-issuer",
+				IssuerKind:          "Issuer",
+				IssuerGroup:         "foo.io",
+				PrivateKeyDefaulted: true,
+				ConvertOpaqueSecret: true,
+			},
+			expectedErr: false,
+		},
+
+		"if certificate has the FromSecretRef SecretCAPolicy and one CertificateRequest is ready, store the signed certificate and private key with the referenced bundle as ca": {
+			certificate: exampleBundle.Certificate,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					gen.CertificateFrom(issuingCert, gen.SetCertificateSecretCAPolicy(bundleCAPolicy)),
+					gen.CertificateRequestFrom(exampleBundle.CertificateRequestReady,
+						gen.AddCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestRevisionAnnotationKey: "2", // Current Certificate revision=1
+						}),
+						gen.SetCertificateRequestCA(exampleBundle.CertBytes),
+					)},
+				KubeObjects: []runtime.Object{
+					nextPrivateKeySecret,
+					&corev1.ConfigMap{
+						ObjectMeta: metav1.ObjectMeta{Name: "ca-bundle", Namespace: exampleBundle.Certificate.Namespace},
+						Data:       map[string]string{"ca.crt": string(exampleBundleAlt.CertBytes)},
+					},
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						exampleBundle.Certificate.Namespace,
+						gen.CertificateFrom(exampleBundle.Certificate,
+							gen.SetCertificateSecretCAPolicy(bundleCAPolicy),
+							gen.SetCertificateRevision(2),
+							exampleIssuedStatus,
 						),
 					)),
 				},
@@ -507,13 +722,53 @@ func TestIssuingController(t *testing.T) {
 				},
 			},
 			expSecretUpdateDataCall: &internal.SecretData{
-				Certificate:     exampleBundle.CertificateRequestReady.Status.Certificate,
-				PrivateKey:      exampleBundle.PrivateKeyBytes,
-				CA:              nil,
-				CertificateName: "test",
-				IssuerName:      "ca-issuer",
-				IssuerKind:      "Issuer",
-				IssuerGroup:     "foo.io",
+				Certificate:         exampleBundle.CertificateRequestReady.Status.Certificate,
+				PrivateKey:          exampleBundle.PrivateKeyBytes,
+				CA:                  exampleBundleAlt.CertBytes,
+				CertificateName:     "test",
+				IssuerName:          "ca-issuer",
+				IssuerKind:          "Issuer",
+				IssuerGroup:         "foo.io",
+				PrivateKeyDefaulted: true,
+				ConvertOpaqueSecret: true,
+			},
+			expectedErr: false,
+		},
+
+		"if certificate has the FromSecretRef SecretCAPolicy but the referenced bundle does not exist, fail the issuance with a clear reason": {
+			certificate: exampleBundle.Certificate,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					gen.CertificateFrom(issuingCert, gen.SetCertificateSecretCAPolicy(bundleCAPolicy)),
+					gen.CertificateRequestFrom(exampleBundle.CertificateRequestReady,
+						gen.AddCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestRevisionAnnotationKey: "2", // Current Certificate revision=1
+						}),
+					)},
+				KubeObjects: []runtime.Object{nextPrivateKeySecret},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						exampleBundle.Certificate.Namespace,
+						gen.CertificateFrom(exampleBundle.Certificate,
+							gen.SetCertificateSecretCAPolicy(bundleCAPolicy),
+							gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+								Type:               cmapi.CertificateConditionIssuing,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             "InvalidCABundle",
+								Message:            `The certificate request has failed to complete and will be retried: invalid CA bundle: ConfigMap "ca-bundle" not found`,
+								LastTransitionTime: &metaFixedClockStart,
+								ObservedGeneration: 3,
+							}),
+							gen.SetCertificateLastFailureTime(metaFixedClockStart),
+							gen.SetCertificateIssuanceAttempts(ptr.To(1)),
+						),
+					)),
+				},
+				ExpectedEvents: []string{
+					`Warning InvalidCABundle The certificate request has failed to complete and will be retried: invalid CA bundle: ConfigMap "ca-bundle" not found`,
+				},
 			},
 			expectedErr: false,
 		},
@@ -557,6 +812,7 @@ func TestIssuingController(t *testing.T) {
 						exampleBundle.Certificate.Namespace,
 						gen.CertificateFrom(exampleBundle.Certificate,
 							gen.SetCertificateRevision(2),
+							exampleIssuedStatus,
 						),
 					)),
 				},
@@ -565,13 +821,15 @@ func TestIssuingController(t *testing.T) {
 				},
 			},
 			expSecretUpdateDataCall: &internal.SecretData{
-				Certificate:     exampleBundle.CertificateRequestReady.Status.Certificate,
-				PrivateKey:      exampleBundle.PrivateKeyBytes,
-				CA:              nil,
-				CertificateName: "test",
-				IssuerName:      "ca-issuer",
-				IssuerKind:      "Issuer",
-				IssuerGroup:     "foo.io",
+				Certificate:         exampleBundle.CertificateRequestReady.Status.Certificate,
+				PrivateKey:          exampleBundle.PrivateKeyBytes,
+				CA:                  nil,
+				CertificateName:     "test",
+				IssuerName:          "ca-issuer",
+				IssuerKind:          "Issuer",
+				IssuerGroup:         "foo.io",
+				PrivateKeyDefaulted: true,
+				ConvertOpaqueSecret: true,
 			},
 			expectedErr: false,
 		},
@@ -614,6 +872,7 @@ func TestIssuingController(t *testing.T) {
 						exampleBundle.Certificate.Namespace,
 						gen.CertificateFrom(exampleBundle.Certificate,
 							gen.SetCertificateRevision(2),
+							exampleIssuedStatus,
 						),
 					)),
 				},
@@ -622,13 +881,15 @@ func TestIssuingController(t *testing.T) {
 				},
 			},
 			expSecretUpdateDataCall: &internal.SecretData{
-				Certificate:     exampleBundle.CertificateRequestReady.Status.Certificate,
-				PrivateKey:      exampleBundle.PrivateKeyBytes,
-				CA:              nil,
-				CertificateName: "test",
-				IssuerName:      "ca-issuer",
-				IssuerKind:      "Issuer",
-				IssuerGroup:     "foo.io",
+				Certificate:         exampleBundle.CertificateRequestReady.Status.Certificate,
+				PrivateKey:          exampleBundle.PrivateKeyBytes,
+				CA:                  nil,
+				CertificateName:     "test",
+				IssuerName:          "ca-issuer",
+				IssuerKind:          "Issuer",
+				IssuerGroup:         "foo.io",
+				PrivateKeyDefaulted: true,
+				ConvertOpaqueSecret: true,
 			},
 			expectedErr: false,
 		},
@@ -672,6 +933,7 @@ func TestIssuingController(t *testing.T) {
 						exampleBundle.Certificate.Namespace,
 						gen.CertificateFrom(exampleBundle.Certificate,
 							gen.SetCertificateRevision(2),
+							exampleIssuedStatus,
 						),
 					)),
 				},
@@ -680,13 +942,15 @@ func TestIssuingController(t *testing.T) {
 				},
 			},
 			expSecretUpdateDataCall: &internal.SecretData{
-				Certificate:     exampleBundle.CertificateRequestReady.Status.Certificate,
-				PrivateKey:      exampleBundle.PrivateKeyBytes,
-				CA:              nil,
-				CertificateName: "test",
-				IssuerName:      "ca-issuer",
-				IssuerKind:      "Issuer",
-				IssuerGroup:     "foo.io",
+				Certificate:         exampleBundle.CertificateRequestReady.Status.Certificate,
+				PrivateKey:          exampleBundle.PrivateKeyBytes,
+				CA:                  nil,
+				CertificateName:     "test",
+				IssuerName:          "ca-issuer",
+				IssuerKind:          "Issuer",
+				IssuerGroup:         "foo.io",
+				PrivateKeyDefaulted: true,
+				ConvertOpaqueSecret: true,
 			},
 			expectedErr: false,
 		},
@@ -721,10 +985,11 @@ func TestIssuingController(t *testing.T) {
 				},
 			},
 			expSecretUpdateDataCall: &internal.SecretData{
-				Certificate:     exampleBundle.LocalTemporaryCertificateBytes,
-				PrivateKey:      exampleBundle.PrivateKeyBytes,
-				CA:              nil,
-				CertificateName: "test",
+				Certificate:         exampleBundle.LocalTemporaryCertificateBytes,
+				PrivateKey:          exampleBundle.PrivateKeyBytes,
+				CA:                  nil,
+				CertificateName:     "test",
+				PrivateKeyDefaulted: true,
 			},
 			expectedErr: false,
 		},
@@ -770,10 +1035,11 @@ func TestIssuingController(t *testing.T) {
 				},
 			},
 			expSecretUpdateDataCall: &internal.SecretData{
-				Certificate:     exampleBundle.LocalTemporaryCertificateBytes,
-				PrivateKey:      exampleBundle.PrivateKeyBytes,
-				CA:              nil,
-				CertificateName: "test",
+				Certificate:         exampleBundle.LocalTemporaryCertificateBytes,
+				PrivateKey:          exampleBundle.PrivateKeyBytes,
+				CA:                  nil,
+				CertificateName:     "test",
+				PrivateKeyDefaulted: true,
 			},
 			expectedErr: false,
 		},
@@ -791,6 +1057,11 @@ func TestIssuingController(t *testing.T) {
 						gen.AddCertificateRequestAnnotations(map[string]string{
 							cmapi.CertificateRequestRevisionAnnotationKey: "2", // Current Certificate revision=1
 						}),
+						gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+							Type:   cmapi.CertificateRequestConditionApproved,
+							Status: cmmeta.ConditionTrue,
+							Reason: "cert-manager.io",
+						}),
 					)},
 				KubeObjects: []runtime.Object{
 					&corev1.Secret{
@@ -868,10 +1139,11 @@ func TestIssuingController(t *testing.T) {
 				},
 			},
 			expSecretUpdateDataCall: &internal.SecretData{
-				Certificate:     exampleBundle.LocalTemporaryCertificateBytes,
-				PrivateKey:      exampleBundle.PrivateKeyBytes,
-				CA:              nil,
-				CertificateName: "test",
+				Certificate:         exampleBundle.LocalTemporaryCertificateBytes,
+				PrivateKey:          exampleBundle.PrivateKeyBytes,
+				CA:                  nil,
+				CertificateName:     "test",
+				PrivateKeyDefaulted: true,
 			},
 			expectedErr: false,
 		},
@@ -889,6 +1161,11 @@ func TestIssuingController(t *testing.T) {
 						gen.AddCertificateRequestAnnotations(map[string]string{
 							cmapi.CertificateRequestRevisionAnnotationKey: "2", // Current Certificate revision=1
 						}),
+						gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+							Type:   cmapi.CertificateRequestConditionApproved,
+							Status: cmmeta.ConditionTrue,
+							Reason: "cert-manager.io",
+						}),
 					)},
 				KubeObjects: []runtime.Object{
 					&corev1.Secret{
@@ -956,6 +1233,7 @@ func TestIssuingController(t *testing.T) {
 								cmapi.IssueTemporaryCertificateAnnotation: "true",
 							}),
 							gen.SetCertificateRevision(2),
+							exampleIssuedStatus,
 						),
 					)),
 				},
@@ -964,13 +1242,15 @@ func TestIssuingController(t *testing.T) {
 				},
 			},
 			expSecretUpdateDataCall: &internal.SecretData{
-				Certificate:     exampleBundle.CertificateRequestReady.Status.Certificate,
-				PrivateKey:      exampleBundle.PrivateKeyBytes,
-				CA:              nil,
-				CertificateName: "test",
-				IssuerName:      "ca-issuer",
-				IssuerKind:      "Issuer",
-				IssuerGroup:     "foo.io",
+				Certificate:         exampleBundle.CertificateRequestReady.Status.Certificate,
+				PrivateKey:          exampleBundle.PrivateKeyBytes,
+				CA:                  nil,
+				CertificateName:     "test",
+				IssuerName:          "ca-issuer",
+				IssuerKind:          "Issuer",
+				IssuerGroup:         "foo.io",
+				PrivateKeyDefaulted: true,
+				ConvertOpaqueSecret: true,
 			},
 			expectedErr: false,
 		},
@@ -1378,6 +1658,10 @@ func TestIssuingController(t *testing.T) {
 			test.builder.T = t
 			test.builder.InitWithRESTConfig()
 			defer test.builder.Stop()
+			// The certificates in the crypto bundles are signed using the
+			// real clock, so may be valid from up to a second after
+			// fixedClockStart.
+			test.builder.Context.CertificateOptions.ClockSkewTolerance = time.Minute
 
 			w := controllerWrapper{}
 			_, _, err := w.Register(test.builder.Context)
@@ -1414,3 +1698,1505 @@ func TestIssuingController(t *testing.T) {
 		})
 	}
 }
+
+func TestIssuingController_IssuanceTimeout(t *testing.T) {
+	nextPrivateKeySecretName := "next-private-key"
+	metaFixedClockStart := metav1.NewTime(fixedClockStart)
+
+	baseCert := gen.Certificate("test",
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca-issuer", Kind: "Issuer", Group: "foo.io"}),
+		gen.SetCertificateGeneration(3),
+		gen.SetCertificateSecretName("output"),
+		gen.SetCertificateDNSNames("example.com"),
+		gen.SetCertificateRevision(1),
+		gen.SetCertificateNextPrivateKeySecretName(nextPrivateKeySecretName),
+		gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+			Type:               cmapi.CertificateConditionIssuing,
+			Status:             cmmeta.ConditionTrue,
+			ObservedGeneration: 3,
+			LastTransitionTime: &metaFixedClockStart,
+		}),
+	)
+	bundle := testcrypto.MustCreateCryptoBundle(t, baseCert.DeepCopy(), fixedClock)
+
+	// pendingReq is a CertificateRequest created at fixedClockStart for which
+	// the issuer never completes.
+	pendingReq := gen.CertificateRequestFrom(bundle.CertificateRequest,
+		gen.AddCertificateRequestAnnotations(map[string]string{
+			cmapi.CertificateRequestRevisionAnnotationKey: "2", // Current Certificate revision=1
+		}),
+		gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+			Type:               cmapi.CertificateRequestConditionReady,
+			Status:             cmmeta.ConditionFalse,
+			Reason:             cmapi.CertificateRequestReasonPending,
+			Message:            "Waiting on certificate issuance",
+			LastTransitionTime: &metaFixedClockStart,
+		}),
+		gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+			Type:               cmapi.CertificateRequestConditionApproved,
+			Status:             cmmeta.ConditionTrue,
+			Reason:             "cert-manager.io",
+			LastTransitionTime: &metaFixedClockStart,
+		}),
+		func(req *cmapi.CertificateRequest) {
+			req.CreationTimestamp = metaFixedClockStart
+		},
+	)
+
+	tests := map[string]struct {
+		// issuanceTimeout is the controller default issuance timeout.
+		issuanceTimeout time.Duration
+		crtMods         []gen.CertificateModifier
+		reqMods         []gen.CertificateRequestModifier
+
+		// expTimeout is the timeout after which the issuance is expected to
+		// be failed, or zero if it is not expected to time out.
+		expTimeout time.Duration
+	}{
+		"should time out a CertificateRequest that made no progress for the full timeout": {
+			issuanceTimeout: time.Hour,
+			expTimeout:      time.Hour,
+		},
+		"should not time out a CertificateRequest whose status was updated within the timeout": {
+			issuanceTimeout: time.Hour,
+			reqMods: []gen.CertificateRequestModifier{
+				func(req *cmapi.CertificateRequest) {
+					req.ManagedFields = []metav1.ManagedFieldsEntry{{
+						Manager:     "cert-manager-certificaterequests-issuer-acme",
+						Subresource: "status",
+						Time:        ptr.To(metav1.NewTime(fixedClockStart.Add(30 * time.Minute))),
+					}}
+				},
+			},
+		},
+		"should not time out if the issuance timeout is disabled": {
+			issuanceTimeout: 0,
+		},
+		"should use a longer issuance timeout from the Certificate annotation": {
+			issuanceTimeout: time.Hour,
+			crtMods: []gen.CertificateModifier{
+				gen.AddCertificateAnnotations(map[string]string{cmapi.IssuanceTimeoutAnnotationKey: "2h"}),
+			},
+		},
+		"should use a shorter issuance timeout from the Certificate annotation": {
+			issuanceTimeout: 0,
+			crtMods: []gen.CertificateModifier{
+				gen.AddCertificateAnnotations(map[string]string{cmapi.IssuanceTimeoutAnnotationKey: "30m"}),
+			},
+			expTimeout: 30 * time.Minute,
+		},
+		"should not time out if the Certificate annotation disables the issuance timeout": {
+			issuanceTimeout: time.Hour,
+			crtMods: []gen.CertificateModifier{
+				gen.AddCertificateAnnotations(map[string]string{cmapi.IssuanceTimeoutAnnotationKey: "0s"}),
+			},
+		},
+		"should use the controller issuance timeout if the Certificate annotation is invalid": {
+			issuanceTimeout: time.Hour,
+			crtMods: []gen.CertificateModifier{
+				gen.AddCertificateAnnotations(map[string]string{cmapi.IssuanceTimeoutAnnotationKey: "not-a-duration"}),
+			},
+			expTimeout: time.Hour,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fixedClock.SetTime(fixedClockStart)
+			crt := gen.CertificateFrom(baseCert, test.crtMods...)
+			req := gen.CertificateRequestFrom(pendingReq, test.reqMods...)
+
+			// The Certificate is synced when the CertificateRequest is created,
+			// and again an hour later.
+			failedAt := metav1.NewTime(fixedClockStart.Add(time.Hour))
+			var expectedActions []testpkg.Action
+			var expectedEvents []string
+			if test.expTimeout > 0 {
+				message := fmt.Sprintf("CertificateRequest %q made no progress for %s", req.Name, test.expTimeout)
+				crtMessage := "The certificate request has failed to complete and will be retried: " + message
+				expectedActions = []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						req.Namespace,
+						gen.CertificateRequestFrom(req,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonFailed,
+								Message:            message,
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestFailureTime(failedAt),
+						),
+					)),
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						crt.Namespace,
+						gen.CertificateFrom(crt,
+							gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+								Type:               cmapi.CertificateConditionIssuing,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             "IssuanceTimedOut",
+								Message:            crtMessage,
+								LastTransitionTime: &failedAt,
+								ObservedGeneration: 3,
+							}),
+							gen.SetCertificateLastFailureTime(failedAt),
+							gen.SetCertificateIssuanceAttempts(ptr.To(1)),
+						),
+					)),
+				}
+				expectedEvents = []string{"Warning IssuanceTimedOut " + crtMessage}
+			}
+
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fixedClock,
+				CertManagerObjects: []runtime.Object{crt, req},
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      nextPrivateKeySecretName,
+							Namespace: crt.Namespace,
+						},
+						Data: map[string][]byte{
+							corev1.TLSPrivateKeyKey: bundle.PrivateKeyBytes,
+						},
+					},
+				},
+				ExpectedActions: expectedActions,
+				ExpectedEvents:  expectedEvents,
+			}
+			builder.InitWithRESTConfig()
+			defer builder.Stop()
+			builder.Context.CertificateOptions.IssuanceTimeout = test.issuanceTimeout
+
+			w := controllerWrapper{}
+			_, _, err := w.Register(builder.Context)
+			require.NoError(t, err)
+			builder.Start()
+
+			key, err := cache.MetaNamespaceKeyFunc(crt)
+			require.NoError(t, err)
+
+			// The CertificateRequest has just been created, so it must never
+			// be timed out straight away.
+			require.NoError(t, w.controller.ProcessItem(context.Background(), key))
+
+			fixedClock.Step(time.Hour)
+			err = w.controller.ProcessItem(context.Background(), key)
+			require.NoError(t, err)
+			builder.CheckAndFinish(err)
+		})
+	}
+}
+
+func TestIssuingController_WaitingForApproval(t *testing.T) {
+	nextPrivateKeySecretName := "next-private-key"
+	metaFixedClockStart := metav1.NewTime(fixedClockStart)
+
+	baseCert := gen.Certificate("test",
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca-issuer", Kind: "Issuer", Group: "foo.io"}),
+		gen.SetCertificateGeneration(3),
+		gen.SetCertificateSecretName("output"),
+		gen.SetCertificateDNSNames("example.com"),
+		gen.SetCertificateRevision(1),
+		gen.SetCertificateNextPrivateKeySecretName(nextPrivateKeySecretName),
+	)
+	bundle := testcrypto.MustCreateCryptoBundle(t, baseCert.DeepCopy(), fixedClock)
+
+	issuing := gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+		Type:               cmapi.CertificateConditionIssuing,
+		Status:             cmmeta.ConditionTrue,
+		Reason:             "ManuallyTriggered",
+		Message:            "Certificate re-issuance manually triggered",
+		ObservedGeneration: 3,
+		LastTransitionTime: &metaFixedClockStart,
+	})
+	waitingCondition := func(status cmmeta.ConditionStatus, reason, message string) gen.CertificateModifier {
+		return gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+			Type:               cmapi.CertificateConditionWaitingForApproval,
+			Status:             status,
+			Reason:             reason,
+			Message:            message,
+			ObservedGeneration: 3,
+			LastTransitionTime: &metaFixedClockStart,
+		})
+	}
+	pending := gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+		Type:   cmapi.CertificateRequestConditionReady,
+		Status: cmmeta.ConditionFalse,
+		Reason: cmapi.CertificateRequestReasonPending,
+	})
+	approved := gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+		Type:   cmapi.CertificateRequestConditionApproved,
+		Status: cmmeta.ConditionTrue,
+		Reason: "policy.cert-manager.io",
+	})
+
+	req := gen.CertificateRequestFrom(bundle.CertificateRequest,
+		gen.AddCertificateRequestAnnotations(map[string]string{
+			cmapi.CertificateRequestRevisionAnnotationKey: "2", // Current Certificate revision=1
+		}),
+	)
+	waitingMessage := fmt.Sprintf("Waiting for CertificateRequest %q to be approved", req.Name)
+	approvedMessage := fmt.Sprintf("CertificateRequest %q has been approved, waiting for it to be issued", req.Name)
+
+	// The reason of the Issuing condition is never changed, as it records
+	// why the issuance was triggered.
+	tests := map[string]struct {
+		crtMods []gen.CertificateModifier
+		reqMods []gen.CertificateRequestModifier
+
+		// expCondition is the WaitingForApproval condition which is expected
+		// to be set on the Certificate, if any.
+		expCondition gen.CertificateModifier
+		expEvent     string
+	}{
+		"should report a pending CertificateRequest which has not been approved": {
+			crtMods:      []gen.CertificateModifier{issuing},
+			reqMods:      []gen.CertificateRequestModifier{pending},
+			expCondition: waitingCondition(cmmeta.ConditionTrue, "WaitingForApproval", waitingMessage),
+			expEvent:     "Normal WaitingForApproval " + waitingMessage,
+		},
+		"should report a CertificateRequest without a Ready condition which has not been approved": {
+			crtMods:      []gen.CertificateModifier{issuing},
+			expCondition: waitingCondition(cmmeta.ConditionTrue, "WaitingForApproval", waitingMessage),
+			expEvent:     "Normal WaitingForApproval " + waitingMessage,
+		},
+		"should do nothing if the CertificateRequest is still waiting for approval": {
+			crtMods: []gen.CertificateModifier{issuing, waitingCondition(cmmeta.ConditionTrue, "WaitingForApproval", waitingMessage)},
+			reqMods: []gen.CertificateRequestModifier{pending},
+		},
+		"should report once a CertificateRequest waiting for approval has been approved": {
+			crtMods:      []gen.CertificateModifier{issuing, waitingCondition(cmmeta.ConditionTrue, "WaitingForApproval", waitingMessage)},
+			reqMods:      []gen.CertificateRequestModifier{approved, pending},
+			expCondition: waitingCondition(cmmeta.ConditionFalse, "Approved", approvedMessage),
+			expEvent:     "Normal Approved " + approvedMessage,
+		},
+		"should do nothing if the CertificateRequest was approved without waiting": {
+			crtMods: []gen.CertificateModifier{issuing},
+			reqMods: []gen.CertificateRequestModifier{approved, pending},
+		},
+		"should do nothing if the Certificate is not being issued": {
+			reqMods: []gen.CertificateRequestModifier{pending},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fixedClock.SetTime(fixedClockStart)
+			crt := gen.CertificateFrom(baseCert, test.crtMods...)
+			req := gen.CertificateRequestFrom(req, test.reqMods...)
+
+			var expectedActions []testpkg.Action
+			var expectedEvents []string
+			if test.expCondition != nil {
+				expectedActions = append(expectedActions, testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					cmapi.SchemeGroupVersion.WithResource("certificates"),
+					"status",
+					crt.Namespace,
+					gen.CertificateFrom(crt, test.expCondition),
+				)))
+				expectedEvents = []string{test.expEvent}
+			}
+
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fixedClock,
+				CertManagerObjects: []runtime.Object{crt, req},
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      nextPrivateKeySecretName,
+							Namespace: crt.Namespace,
+						},
+						Data: map[string][]byte{
+							corev1.TLSPrivateKeyKey: bundle.PrivateKeyBytes,
+						},
+					},
+				},
+				ExpectedActions: expectedActions,
+				ExpectedEvents:  expectedEvents,
+			}
+			builder.InitWithRESTConfig()
+			defer builder.Stop()
+
+			w := controllerWrapper{}
+			_, _, err := w.Register(builder.Context)
+			require.NoError(t, err)
+			builder.Start()
+
+			key, err := cache.MetaNamespaceKeyFunc(crt)
+			require.NoError(t, err)
+
+			err = w.controller.ProcessItem(context.Background(), key)
+			require.NoError(t, err)
+			builder.CheckAndFinish(err)
+		})
+	}
+}
+
+func TestIssuingController_IssuedKeyMismatch(t *testing.T) {
+	nextPrivateKeySecretName := "next-private-key"
+	metaFixedClockStart := metav1.NewTime(fixedClockStart)
+	fixedClock.SetTime(fixedClockStart)
+
+	crt := gen.Certificate("test",
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca-issuer", Kind: "Issuer", Group: "foo.io"}),
+		gen.SetCertificateGeneration(3),
+		gen.SetCertificateSecretName("output"),
+		gen.SetCertificateDNSNames("example.com"),
+		gen.SetCertificateRevision(1),
+		gen.SetCertificateNextPrivateKeySecretName(nextPrivateKeySecretName),
+		gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+			Type:               cmapi.CertificateConditionIssuing,
+			Status:             cmmeta.ConditionTrue,
+			ObservedGeneration: 3,
+			LastTransitionTime: &metaFixedClockStart,
+		}),
+	)
+	bundle := testcrypto.MustCreateCryptoBundle(t, crt.DeepCopy(), fixedClock)
+	// maliciousBundle contains a certificate for the same Certificate, but
+	// for a different private key.
+	maliciousBundle := testcrypto.MustCreateCryptoBundle(t, crt.DeepCopy(), fixedClock)
+
+	// req is a CertificateRequest which a misbehaving issuer has completed
+	// with a certificate for a different key than the one in its CSR.
+	req := gen.CertificateRequestFrom(bundle.CertificateRequestReady,
+		gen.AddCertificateRequestAnnotations(map[string]string{
+			cmapi.CertificateRequestRevisionAnnotationKey: "2", // Current Certificate revision=1
+		}),
+		gen.SetCertificateRequestCertificate(maliciousBundle.CertBytes),
+	)
+
+	requested, err := utilpki.PublicKeyFingerprintSHA256(bundle.CSR.PublicKey)
+	require.NoError(t, err)
+	issued, err := utilpki.PublicKeyFingerprintSHA256(maliciousBundle.Cert.PublicKey)
+	require.NoError(t, err)
+	message := fmt.Sprintf("The certificate issued for CertificateRequest %q is for public key %s, but public key %s was requested", req.Name, issued, requested)
+	crtMessage := "The certificate request has failed to complete and will be retried: " + message
+
+	// The Secret must not be written to; the CertificateRequest is failed so
+	// that a new one is created for the next issuance.
+	builder := &testpkg.Builder{
+		T:                  t,
+		Clock:              fixedClock,
+		CertManagerObjects: []runtime.Object{crt, req},
+		KubeObjects: []runtime.Object{
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      nextPrivateKeySecretName,
+					Namespace: crt.Namespace,
+				},
+				Data: map[string][]byte{
+					corev1.TLSPrivateKeyKey: bundle.PrivateKeyBytes,
+				},
+			},
+		},
+		ExpectedActions: []testpkg.Action{
+			testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+				cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+				"status",
+				req.Namespace,
+				gen.CertificateRequestFrom(req,
+					gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:               cmapi.CertificateRequestConditionReady,
+						Status:             cmmeta.ConditionFalse,
+						Reason:             cmapi.CertificateRequestReasonFailed,
+						Message:            message,
+						LastTransitionTime: &metaFixedClockStart,
+					}),
+					gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+				),
+			)),
+			testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+				cmapi.SchemeGroupVersion.WithResource("certificates"),
+				"status",
+				crt.Namespace,
+				gen.CertificateFrom(crt,
+					gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+						Type:               cmapi.CertificateConditionIssuing,
+						Status:             cmmeta.ConditionFalse,
+						Reason:             "IssuedKeyMismatch",
+						Message:            crtMessage,
+						LastTransitionTime: &metaFixedClockStart,
+						ObservedGeneration: 3,
+					}),
+					gen.SetCertificateLastFailureTime(metaFixedClockStart),
+					gen.SetCertificateIssuanceAttempts(ptr.To(1)),
+				),
+			)),
+		},
+		ExpectedEvents: []string{"Warning IssuedKeyMismatch " + crtMessage},
+	}
+	builder.InitWithRESTConfig()
+	defer builder.Stop()
+
+	w := controllerWrapper{}
+	_, _, err = w.Register(builder.Context)
+	require.NoError(t, err)
+	builder.Start()
+
+	key, err := cache.MetaNamespaceKeyFunc(crt)
+	require.NoError(t, err)
+
+	err = w.controller.ProcessItem(context.Background(), key)
+	require.NoError(t, err)
+	builder.CheckAndFinish(err)
+}
+
+func TestIssuingController_NotYetValid(t *testing.T) {
+	nextPrivateKeySecretName := "next-private-key"
+	metaFixedClockStart := metav1.NewTime(fixedClockStart)
+
+	crt := gen.Certificate("test",
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca-issuer", Kind: "Issuer", Group: "foo.io"}),
+		gen.SetCertificateGeneration(3),
+		gen.SetCertificateSecretName("output"),
+		gen.SetCertificateDNSNames("example.com"),
+		gen.SetCertificateRevision(1),
+		gen.SetCertificateNextPrivateKeySecretName(nextPrivateKeySecretName),
+		gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+			Type:               cmapi.CertificateConditionIssuing,
+			Status:             cmmeta.ConditionTrue,
+			ObservedGeneration: 3,
+			LastTransitionTime: &metaFixedClockStart,
+		}),
+	)
+	bundle := testcrypto.MustCreateCryptoBundle(t, crt.DeepCopy(), fixedClock)
+
+	tests := map[string]struct {
+		// issuerClockAhead is how far the clock of the issuer is ahead of the
+		// controller's clock, i.e. how far in the future the issued
+		// certificate's NotBefore is.
+		issuerClockAhead   time.Duration
+		clockSkewTolerance time.Duration
+
+		expWait time.Duration
+		expFail bool
+	}{
+		"should store a certificate which is valid from now": {
+			issuerClockAhead: 0,
+		},
+		"should store a certificate which is not yet valid, but within the clock skew tolerance": {
+			issuerClockAhead:   time.Minute,
+			clockSkewTolerance: 2 * time.Minute,
+		},
+		"should wait for a certificate which is not yet valid to become valid": {
+			issuerClockAhead: 5 * time.Minute,
+			expWait:          5 * time.Minute,
+		},
+		"should wait for a certificate to become valid within the clock skew tolerance": {
+			issuerClockAhead:   5 * time.Minute,
+			clockSkewTolerance: 2 * time.Minute,
+			expWait:            3 * time.Minute,
+		},
+		"should fail the issuance if the certificate is not valid until too far in the future": {
+			issuerClockAhead: 24 * time.Hour,
+			expFail:          true,
+		},
+		"should fail the issuance if the certificate is not valid until too far in the future, beyond the clock skew tolerance": {
+			issuerClockAhead:   time.Hour + 2*time.Minute,
+			clockSkewTolerance: time.Minute,
+			expFail:            true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fixedClock.SetTime(fixedClockStart)
+
+			// The issuer signs the certificate using its own clock.
+			notBefore := fixedClockStart.Add(test.issuerClockAhead).Truncate(time.Second)
+			certBytes := testcrypto.MustCreateCertWithNotBeforeAfter(t, bundle.PrivateKeyBytes, crt, notBefore, notBefore.Add(time.Hour*24))
+			req := gen.CertificateRequestFrom(bundle.CertificateRequestReady,
+				gen.AddCertificateRequestAnnotations(map[string]string{
+					cmapi.CertificateRequestRevisionAnnotationKey: "2", // Current Certificate revision=1
+				}),
+				gen.SetCertificateRequestCertificate(certBytes),
+			)
+			x509Cert, err := utilpki.DecodeX509CertificateBytes(certBytes)
+			require.NoError(t, err)
+
+			var expectedActions []testpkg.Action
+			var expectedEvents []string
+			switch {
+			case test.expFail:
+				message := fmt.Sprintf("The certificate issued for CertificateRequest %q is not valid until %s, which is too far in the future", req.Name, x509Cert.NotBefore.Format(time.RFC1123))
+				crtMessage := "The certificate request has failed to complete and will be retried: " + message
+				expectedActions = []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						req.Namespace,
+						gen.CertificateRequestFrom(req,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonFailed,
+								Message:            message,
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+						),
+					)),
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						crt.Namespace,
+						gen.CertificateFrom(crt,
+							gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+								Type:               cmapi.CertificateConditionIssuing,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             "NotYetValid",
+								Message:            crtMessage,
+								LastTransitionTime: &metaFixedClockStart,
+								ObservedGeneration: 3,
+							}),
+							gen.SetCertificateLastFailureTime(metaFixedClockStart),
+							gen.SetCertificateIssuanceAttempts(ptr.To(1)),
+						),
+					)),
+				}
+				expectedEvents = []string{"Warning NotYetValid " + crtMessage}
+			case test.expWait > 0:
+				expectedEvents = []string{fmt.Sprintf("Normal NotYetValid The certificate issued for CertificateRequest %q is not valid until %s, it will be stored once it is",
+					req.Name, x509Cert.NotBefore.Format(time.RFC1123))}
+			default:
+				expectedActions = []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						crt.Namespace,
+						gen.CertificateFrom(crt,
+							gen.SetCertificateRevision(2),
+							func(crt *cmapi.Certificate) {
+								crt.Status.Conditions = nil
+								internalcertificates.SetIssuedCertificateStatus(crt, x509Cert)
+							},
+						),
+					)),
+				}
+				expectedEvents = []string{"Normal Issuing The certificate has been successfully issued"}
+			}
+
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fixedClock,
+				CertManagerObjects: []runtime.Object{crt, req},
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      nextPrivateKeySecretName,
+							Namespace: crt.Namespace,
+						},
+						Data: map[string][]byte{
+							corev1.TLSPrivateKeyKey: bundle.PrivateKeyBytes,
+						},
+					},
+				},
+				ExpectedActions: expectedActions,
+				ExpectedEvents:  expectedEvents,
+			}
+			builder.InitWithRESTConfig()
+			defer builder.Stop()
+			builder.Context.CertificateOptions.ClockSkewTolerance = test.clockSkewTolerance
+			builder.Context.CertificateOptions.MaxNotBeforeWait = time.Hour
+
+			w := controllerWrapper{}
+			_, _, err = w.Register(builder.Context)
+			require.NoError(t, err)
+
+			var gotWait time.Duration
+			w.controller.scheduledWorkQueue = &schedulertest.FakeScheduler{
+				AddFunc: func(_ interface{}, d time.Duration) {
+					gotWait = d
+				},
+			}
+			var secretsUpdateDataCalled bool
+			w.controller.secretsUpdateData = func(_ context.Context, _ *cmapi.Certificate, secretData internal.SecretData) error {
+				secretsUpdateDataCalled = true
+				assert.Equal(t, certBytes, secretData.Certificate)
+				return nil
+			}
+			builder.Start()
+
+			key, err := cache.MetaNamespaceKeyFunc(crt)
+			require.NoError(t, err)
+
+			err = w.controller.ProcessItem(context.Background(), key)
+			require.NoError(t, err)
+			builder.CheckAndFinish(err)
+
+			assert.Equal(t, !test.expFail && test.expWait == 0, secretsUpdateDataCalled, "secretsUpdateData func call")
+			// The certificate's NotBefore is truncated to the second.
+			assert.InDelta(t, test.expWait, gotWait, float64(time.Second), "re-queue delay")
+		})
+	}
+}
+
+func TestIssuingController_IssuedCertificateInvalid(t *testing.T) {
+	nextPrivateKeySecretName := "next-private-key"
+	metaFixedClockStart := metav1.NewTime(fixedClockStart)
+	fixedClock.SetTime(fixedClockStart)
+
+	crt := gen.Certificate("test",
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca-issuer", Kind: "Issuer", Group: "foo.io"}),
+		gen.SetCertificateGeneration(3),
+		gen.SetCertificateSecretName("output"),
+		gen.SetCertificateDNSNames("example.com"),
+		gen.SetCertificateRevision(1),
+		gen.SetCertificateNextPrivateKeySecretName(nextPrivateKeySecretName),
+		gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+			Type:               cmapi.CertificateConditionIssuing,
+			Status:             cmmeta.ConditionTrue,
+			ObservedGeneration: 3,
+			LastTransitionTime: &metaFixedClockStart,
+		}),
+	)
+	bundle := testcrypto.MustCreateCryptoBundle(t, crt.DeepCopy(), fixedClock)
+	otherBundle := testcrypto.MustCreateCryptoBundle(t, crt.DeepCopy(), fixedClock)
+
+	notBefore := fixedClockStart.Add(-time.Hour).Truncate(time.Second)
+	validCert := testcrypto.MustCreateCertWithNotBeforeAfter(t, bundle.PrivateKeyBytes, crt, notBefore, notBefore.Add(24*time.Hour))
+	expiredCert := testcrypto.MustCreateCertWithNotBeforeAfter(t, bundle.PrivateKeyBytes, crt, notBefore.Add(-24*time.Hour), notBefore)
+	wrongSANCert := testcrypto.MustCreateCertWithNotBeforeAfter(t, bundle.PrivateKeyBytes,
+		gen.CertificateFrom(crt, gen.SetCertificateDNSNames("example.org")), notBefore, notBefore.Add(24*time.Hour))
+	wrongKeyCert := testcrypto.MustCreateCertWithNotBeforeAfter(t, otherBundle.PrivateKeyBytes, crt, notBefore, notBefore.Add(24*time.Hour))
+	otherCA := testcrypto.MustCreateCert(t, otherBundle.PrivateKeyBytes, gen.CertificateFrom(crt, gen.SetCertificateCommonName("other-ca")))
+
+	requested, err := utilpki.PublicKeyFingerprintSHA256(bundle.CSR.PublicKey)
+	require.NoError(t, err)
+	issued, err := utilpki.PublicKeyFingerprintSHA256(otherBundle.PrivateKey.Public())
+	require.NoError(t, err)
+
+	reqName := bundle.CertificateRequestReady.Name
+	tests := map[string]struct {
+		certificate       []byte
+		ca                []byte
+		disableVerify     bool
+		verifyChain       bool
+		expFailureReason  string
+		expFailureMessage string
+	}{
+		"should store a certificate which verifies": {
+			certificate: validCert,
+		},
+		"should fail the issuance if the certificate chain is truncated": {
+			certificate:      append(append([]byte{}, validCert...), validCert[:len(validCert)/2]...),
+			expFailureReason: "IssuedCertificateInvalid",
+			expFailureMessage: fmt.Sprintf("The certificate issued for CertificateRequest %q could not be parsed: %d bytes could not be decoded after certificate 1 of the chain",
+				reqName, len(validCert)/2),
+		},
+		"should fail the issuance if the certificate is for a different public key": {
+			certificate:       wrongKeyCert,
+			expFailureReason:  "IssuedKeyMismatch",
+			expFailureMessage: fmt.Sprintf("The certificate issued for CertificateRequest %q is for public key %s, but public key %s was requested", reqName, issued, requested),
+		},
+		"should fail the issuance if the certificate has expired": {
+			certificate:       expiredCert,
+			expFailureReason:  "IssuedCertificateInvalid",
+			expFailureMessage: fmt.Sprintf("The certificate issued for CertificateRequest %q expired at %s", reqName, notBefore.UTC().Format(time.RFC1123)),
+		},
+		"should fail the issuance if the certificate does not include the requested DNS names": {
+			certificate:       wrongSANCert,
+			expFailureReason:  "IssuedCertificateInvalid",
+			expFailureMessage: fmt.Sprintf("The certificate issued for CertificateRequest %q does not include the requested DNS name %q", reqName, "example.com"),
+		},
+		"should store a certificate which has expired if verification is disabled": {
+			certificate:   expiredCert,
+			disableVerify: true,
+		},
+		"should store a certificate whose chain verifies against the CA certificate": {
+			certificate: validCert,
+			ca:          validCert,
+			verifyChain: true,
+		},
+		"should not verify the chain against the CA certificate unless enabled": {
+			certificate: validCert,
+			ca:          otherCA,
+		},
+		"should fail the issuance if the certificate chain does not verify against the CA certificate": {
+			certificate:       validCert,
+			ca:                otherCA,
+			verifyChain:       true,
+			expFailureReason:  "IssuedCertificateInvalid",
+			expFailureMessage: fmt.Sprintf("The certificate issued for CertificateRequest %q does not verify against its CA certificate: x509: certificate signed by unknown authority", reqName),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fixedClock.SetTime(fixedClockStart)
+
+			req := gen.CertificateRequestFrom(bundle.CertificateRequestReady,
+				gen.AddCertificateRequestAnnotations(map[string]string{
+					cmapi.CertificateRequestRevisionAnnotationKey: "2", // Current Certificate revision=1
+				}),
+				gen.SetCertificateRequestCertificate(test.certificate),
+				gen.SetCertificateRequestCA(test.ca),
+			)
+
+			var expectedActions []testpkg.Action
+			var expectedEvents []string
+			if test.expFailureReason != "" {
+				crtMessage := "The certificate request has failed to complete and will be retried: " + test.expFailureMessage
+				// The Secret must not be written to; the CertificateRequest
+				// is failed so that a new one is created for the next
+				// issuance.
+				expectedActions = []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						req.Namespace,
+						gen.CertificateRequestFrom(req,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonFailed,
+								Message:            test.expFailureMessage,
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+						),
+					)),
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						crt.Namespace,
+						gen.CertificateFrom(crt,
+							gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+								Type:               cmapi.CertificateConditionIssuing,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             test.expFailureReason,
+								Message:            crtMessage,
+								LastTransitionTime: &metaFixedClockStart,
+								ObservedGeneration: 3,
+							}),
+							gen.SetCertificateLastFailureTime(metaFixedClockStart),
+							gen.SetCertificateIssuanceAttempts(ptr.To(1)),
+						),
+					)),
+				}
+				expectedEvents = []string{"Warning " + test.expFailureReason + " " + crtMessage}
+			} else {
+				x509Cert, err := utilpki.DecodeX509CertificateBytes(test.certificate)
+				require.NoError(t, err)
+				expectedActions = []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						crt.Namespace,
+						gen.CertificateFrom(crt,
+							gen.SetCertificateRevision(2),
+							func(crt *cmapi.Certificate) {
+								crt.Status.Conditions = nil
+								internalcertificates.SetIssuedCertificateStatus(crt, x509Cert)
+							},
+						),
+					)),
+				}
+				expectedEvents = []string{"Normal Issuing The certificate has been successfully issued"}
+			}
+
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fixedClock,
+				CertManagerObjects: []runtime.Object{crt, req},
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      nextPrivateKeySecretName,
+							Namespace: crt.Namespace,
+						},
+						Data: map[string][]byte{
+							corev1.TLSPrivateKeyKey: bundle.PrivateKeyBytes,
+						},
+					},
+				},
+				ExpectedActions: expectedActions,
+				ExpectedEvents:  expectedEvents,
+			}
+			builder.InitWithRESTConfig()
+			defer builder.Stop()
+			builder.Context.CertificateOptions.VerifyIssuedCertificates = !test.disableVerify
+			builder.Context.CertificateOptions.VerifyIssuedCertificateChain = test.verifyChain
+
+			w := controllerWrapper{}
+			_, _, err := w.Register(builder.Context)
+			require.NoError(t, err)
+
+			var secretsUpdateDataCalled bool
+			w.controller.secretsUpdateData = func(_ context.Context, _ *cmapi.Certificate, secretData internal.SecretData) error {
+				secretsUpdateDataCalled = true
+				assert.Equal(t, test.certificate, secretData.Certificate)
+				return nil
+			}
+			builder.Start()
+
+			key, err := cache.MetaNamespaceKeyFunc(crt)
+			require.NoError(t, err)
+
+			err = w.controller.ProcessItem(context.Background(), key)
+			require.NoError(t, err)
+			builder.CheckAndFinish(err)
+
+			assert.Equal(t, test.expFailureReason == "", secretsUpdateDataCalled, "secretsUpdateData func call")
+		})
+	}
+}
+
+func TestIssuingController_IssuanceHistory(t *testing.T) {
+	nextPrivateKeySecretName := "next-private-key"
+	metaFixedClockStart := metav1.NewTime(fixedClockStart)
+
+	// The Certificate was issued at revision 1 after a failed attempt.
+	previousAttempt := func(hoursAgo int, outcome cmapi.CertificateIssuanceOutcome, reason string) cmapi.CertificateIssuanceAttempt {
+		startTime := metav1.NewTime(fixedClockStart.Add(time.Duration(-hoursAgo) * time.Hour))
+		endTime := metav1.NewTime(startTime.Add(time.Minute))
+		return cmapi.CertificateIssuanceAttempt{
+			Revision:               1,
+			CertificateRequestName: "test-1",
+			StartTime:              &startTime,
+			EndTime:                &endTime,
+			Outcome:                outcome,
+			Reason:                 reason,
+		}
+	}
+	history := []cmapi.CertificateIssuanceAttempt{
+		previousAttempt(48, cmapi.CertificateIssuanceOutcomeFailed, cmapi.CertificateRequestReasonFailed),
+		previousAttempt(24, cmapi.CertificateIssuanceOutcomeIssued, "Issued"),
+	}
+
+	baseCert := gen.Certificate("test",
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca-issuer", Kind: "Issuer", Group: "foo.io"}),
+		gen.SetCertificateGeneration(3),
+		gen.SetCertificateSecretName("output"),
+		gen.SetCertificateDNSNames("example.com"),
+		gen.SetCertificateRevision(1),
+		gen.SetCertificateNextPrivateKeySecretName(nextPrivateKeySecretName),
+		gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+			Type:               cmapi.CertificateConditionIssuing,
+			Status:             cmmeta.ConditionTrue,
+			ObservedGeneration: 3,
+			LastTransitionTime: &metaFixedClockStart,
+		}),
+		func(crt *cmapi.Certificate) {
+			crt.Status.IssuanceHistory = history
+		},
+	)
+	bundle := testcrypto.MustCreateCryptoBundle(t, baseCert.DeepCopy(), fixedClock)
+
+	reqMods := []gen.CertificateRequestModifier{
+		gen.AddCertificateRequestAnnotations(map[string]string{
+			cmapi.CertificateRequestRevisionAnnotationKey: "2", // Current Certificate revision=1
+		}),
+		gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+			Type:               cmapi.CertificateRequestConditionApproved,
+			Status:             cmmeta.ConditionTrue,
+			Reason:             "cert-manager.io",
+			LastTransitionTime: &metaFixedClockStart,
+		}),
+		func(req *cmapi.CertificateRequest) {
+			req.CreationTimestamp = metaFixedClockStart
+		},
+	}
+
+	tests := map[string]struct {
+		req *cmapi.CertificateRequest
+		// processAfter is the time after the CertificateRequest was created
+		// at which the Certificate is processed.
+		processAfter time.Duration
+
+		expOutcome cmapi.CertificateIssuanceOutcome
+		expReason  string
+		expMessage string
+	}{
+		"should record an issued attempt": {
+			req:          gen.CertificateRequestFrom(bundle.CertificateRequestReady, reqMods...),
+			processAfter: 10 * time.Minute,
+			expOutcome:   cmapi.CertificateIssuanceOutcomeIssued,
+			expReason:    "Issued",
+			expMessage:   "The certificate has been successfully issued",
+		},
+		"should record a failed attempt": {
+			req: gen.CertificateRequestFrom(bundle.CertificateRequestFailed, append(reqMods,
+				gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+					Type:    cmapi.CertificateRequestConditionReady,
+					Status:  cmmeta.ConditionFalse,
+					Reason:  cmapi.CertificateRequestReasonFailed,
+					Message: "issuer refused to sign",
+				}),
+			)...),
+			processAfter: 10 * time.Minute,
+			expOutcome:   cmapi.CertificateIssuanceOutcomeFailed,
+			expReason:    cmapi.CertificateRequestReasonFailed,
+			expMessage:   "issuer refused to sign",
+		},
+		"should record a timed out attempt": {
+			req:          gen.CertificateRequestFrom(bundle.CertificateRequestPending, reqMods...),
+			processAfter: time.Hour,
+			expOutcome:   cmapi.CertificateIssuanceOutcomeFailed,
+			expReason:    reasonIssuanceTimedOut,
+			expMessage:   fmt.Sprintf("CertificateRequest %q made no progress for %s", bundle.CertificateRequest.Name, time.Hour),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fixedClock.SetTime(fixedClockStart)
+
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fixedClock,
+				CertManagerObjects: []runtime.Object{baseCert, test.req},
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      nextPrivateKeySecretName,
+							Namespace: baseCert.Namespace,
+						},
+						Data: map[string][]byte{
+							corev1.TLSPrivateKeyKey: bundle.PrivateKeyBytes,
+						},
+					},
+				},
+			}
+			builder.InitWithRESTConfig()
+			defer builder.Stop()
+			builder.Context.CertificateOptions.IssuanceTimeout = time.Hour
+			builder.Context.CertificateOptions.ClockSkewTolerance = time.Minute
+			builder.Context.CertificateOptions.IssuanceHistoryLimit = 2
+
+			w := controllerWrapper{}
+			_, _, err := w.Register(builder.Context)
+			require.NoError(t, err)
+			w.controller.secretsUpdateData = func(context.Context, *cmapi.Certificate, internal.SecretData) error {
+				return nil
+			}
+			builder.Start()
+
+			key, err := cache.MetaNamespaceKeyFunc(baseCert)
+			require.NoError(t, err)
+
+			fixedClock.Step(test.processAfter)
+			require.NoError(t, w.controller.ProcessItem(context.Background(), key))
+
+			crt, err := builder.CMClient.CertmanagerV1().Certificates(baseCert.Namespace).Get(context.Background(), baseCert.Name, metav1.GetOptions{})
+			require.NoError(t, err)
+
+			// The oldest attempt is removed, and the new attempt is recorded
+			// after the remaining one.
+			endTime := metav1.NewTime(fixedClockStart.Add(test.processAfter))
+			assert.Equal(t, []cmapi.CertificateIssuanceAttempt{
+				history[1],
+				{
+					Revision:               2,
+					CertificateRequestName: test.req.Name,
+					StartTime:              &metaFixedClockStart,
+					EndTime:                &endTime,
+					Outcome:                test.expOutcome,
+					Reason:                 test.expReason,
+					Message:                test.expMessage,
+				},
+			}, crt.Status.IssuanceHistory)
+		})
+	}
+}
+
+func TestIssuingController_RolloutDelay(t *testing.T) {
+	nextPrivateKeySecretName := "next-private-key"
+	// Use a clock of our own, truncated to the second precision of the
+	// certificate validity times, so that other tests stepping the shared
+	// clock do not affect this one.
+	clockStart := time.Now().Truncate(time.Second)
+	fakeClock := fakeclock.NewFakeClock(clockStart)
+	metaClockStart := metav1.NewTime(clockStart)
+	rolloutDelay := time.Hour
+
+	baseCrt := gen.Certificate("test",
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca-issuer", Kind: "Issuer", Group: "foo.io"}),
+		gen.SetCertificateGeneration(3),
+		gen.SetCertificateSecretName("output"),
+		gen.SetCertificateDNSNames("example.com"),
+		gen.SetCertificateRolloutDelay(metav1.Duration{Duration: rolloutDelay}),
+		gen.SetCertificateNextPrivateKeySecretName(nextPrivateKeySecretName),
+		gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+			Type:               cmapi.CertificateConditionIssuing,
+			Status:             cmmeta.ConditionTrue,
+			Reason:             policies.IssuingReasonRenewal,
+			ObservedGeneration: 3,
+			LastTransitionTime: &metaClockStart,
+		}),
+	)
+	bundle := testcrypto.MustCreateCryptoBundle(t, baseCrt.DeepCopy(), fakeClock)
+	x509Cert, err := utilpki.DecodeX509CertificateBytes(bundle.CertBytes)
+	require.NoError(t, err)
+
+	pendingRolloutCondition := func(req *cmapi.CertificateRequest, rolloutTime time.Time) cmapi.CertificateCondition {
+		return cmapi.CertificateCondition{
+			Type:               cmapi.CertificateConditionIssuing,
+			Status:             cmmeta.ConditionTrue,
+			Reason:             cmapi.CertificateReasonPendingRollout,
+			Message:            fmt.Sprintf("The certificate issued for CertificateRequest %q is pending rollout, it will be stored in the Secret at %s", req.Name, rolloutTime.UTC().Format(time.RFC3339)),
+			ObservedGeneration: 3,
+			LastTransitionTime: &metaClockStart,
+		}
+	}
+
+	tests := map[string]struct {
+		// sinceIssued is how long ago the CertificateRequest was issued.
+		sinceIssued time.Duration
+		// storedExpiresIn is how long until the certificate stored in the
+		// Secret expires. No certificate is stored if zero.
+		storedExpiresIn time.Duration
+		// firstIssuance is true if the Certificate has not been issued
+		// before.
+		firstIssuance bool
+		// alreadyPending is true if the Certificate already reports that
+		// the certificate is pending rollout.
+		alreadyPending bool
+		// issuingReason is the reason of the Issuing condition, if the
+		// issuance is not a renewal.
+		issuingReason string
+
+		// expRolloutTime is when the certificate is expected to be stored,
+		// relative to when the CertificateRequest was issued. The
+		// certificate is expected to be stored straight away if zero.
+		expRolloutTime time.Duration
+	}{
+		"should hold back a renewed certificate until the rollout delay has elapsed": {
+			storedExpiresIn: 30 * 24 * time.Hour,
+			expRolloutTime:  rolloutDelay,
+		},
+		"should not update the status again while the certificate is pending rollout": {
+			storedExpiresIn: 30 * 24 * time.Hour,
+			alreadyPending:  true,
+			expRolloutTime:  rolloutDelay,
+		},
+		"should store the certificate once the rollout delay has elapsed": {
+			sinceIssued:     rolloutDelay,
+			storedExpiresIn: 30 * 24 * time.Hour,
+		},
+		"should only hold back the certificate until the stored certificate is about to expire": {
+			storedExpiresIn: 30 * time.Minute,
+			expRolloutTime:  30*time.Minute - rolloutExpiryMargin,
+		},
+		"should store the certificate straight away if the stored certificate is about to expire": {
+			sinceIssued:     time.Minute,
+			storedExpiresIn: rolloutExpiryMargin,
+		},
+		"should not hold back the certificate if the Secret holds no certificate": {},
+		"should not hold back a certificate re-issued because the spec changed": {
+			storedExpiresIn: 30 * 24 * time.Hour,
+			issuingReason:   policies.IssuingReasonSpecChanged,
+		},
+		"should not hold back a certificate issued because the Secret is invalid": {
+			storedExpiresIn: 30 * 24 * time.Hour,
+			issuingReason:   policies.IssuingReasonSecretInvalid,
+		},
+		"should not hold back a manually triggered certificate": {
+			storedExpiresIn: 30 * 24 * time.Hour,
+			issuingReason:   policies.IssuingReasonManuallyTriggered,
+		},
+		"should not hold back the first certificate issued for a Certificate": {
+			storedExpiresIn: 30 * 24 * time.Hour,
+			firstIssuance:   true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			issuedAt := clockStart.Add(-test.sinceIssued)
+			fakeClock.SetTime(clockStart)
+
+			crt := baseCrt.DeepCopy()
+			revision, nextRevision := ptr.To(1), 2
+			if test.firstIssuance {
+				revision, nextRevision = nil, 1
+			}
+			crt.Status.Revision = revision
+
+			req := gen.CertificateRequestFrom(bundle.CertificateRequestReady,
+				gen.AddCertificateRequestAnnotations(map[string]string{
+					cmapi.CertificateRequestRevisionAnnotationKey: fmt.Sprint(nextRevision),
+				}),
+				gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+					Type:               cmapi.CertificateRequestConditionReady,
+					Status:             cmmeta.ConditionTrue,
+					Reason:             cmapi.CertificateRequestReasonIssued,
+					LastTransitionTime: ptr.To(metav1.NewTime(issuedAt)),
+				}),
+			)
+			if test.alreadyPending {
+				gen.SetCertificateStatusCondition(pendingRolloutCondition(req, issuedAt.Add(test.expRolloutTime)))(crt)
+			}
+			if test.issuingReason != "" {
+				crt.Status.Conditions[0].Reason = test.issuingReason
+			}
+
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "output", Namespace: crt.Namespace},
+			}
+			if test.storedExpiresIn > 0 {
+				notAfter := clockStart.Add(test.storedExpiresIn)
+				secret.Data = map[string][]byte{
+					corev1.TLSCertKey:       testcrypto.MustCreateCertWithNotBeforeAfter(t, bundle.PrivateKeyBytes, crt, notAfter.Add(-90*24*time.Hour), notAfter),
+					corev1.TLSPrivateKeyKey: bundle.PrivateKeyBytes,
+				}
+			}
+
+			var expectedActions []testpkg.Action
+			var expectedEvents []string
+			switch {
+			case test.expRolloutTime == 0:
+				expectedActions = []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						crt.Namespace,
+						gen.CertificateFrom(crt,
+							gen.SetCertificateRevision(nextRevision),
+							func(crt *cmapi.Certificate) {
+								crt.Status.Conditions = nil
+								internalcertificates.SetIssuedCertificateStatus(crt, x509Cert)
+							},
+						),
+					)),
+				}
+				expectedEvents = []string{"Normal Issuing The certificate has been successfully issued"}
+			case !test.alreadyPending:
+				cond := pendingRolloutCondition(req, issuedAt.Add(test.expRolloutTime))
+				expectedActions = []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						crt.Namespace,
+						gen.CertificateFrom(crt, gen.SetCertificateStatusCondition(cond)),
+					)),
+				}
+				expectedEvents = []string{"Normal PendingRollout " + cond.Message}
+			}
+
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fakeClock,
+				CertManagerObjects: []runtime.Object{crt, req},
+				KubeObjects: []runtime.Object{
+					secret,
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      nextPrivateKeySecretName,
+							Namespace: crt.Namespace,
+						},
+						Data: map[string][]byte{
+							corev1.TLSPrivateKeyKey: bundle.PrivateKeyBytes,
+						},
+					},
+				},
+				ExpectedActions: expectedActions,
+				ExpectedEvents:  expectedEvents,
+			}
+			builder.InitWithRESTConfig()
+			defer builder.Stop()
+			// The certificates in the crypto bundles are signed using the
+			// real clock, so may be valid from up to a second after
+			// clockStart.
+			builder.Context.CertificateOptions.ClockSkewTolerance = time.Minute
+
+			w := controllerWrapper{}
+			_, _, err := w.Register(builder.Context)
+			require.NoError(t, err)
+
+			var gotWait time.Duration
+			w.controller.scheduledWorkQueue = &schedulertest.FakeScheduler{
+				AddFunc: func(_ interface{}, d time.Duration) {
+					gotWait = d
+				},
+			}
+			var secretsUpdateDataCalled bool
+			w.controller.secretsUpdateData = func(_ context.Context, _ *cmapi.Certificate, secretData internal.SecretData) error {
+				secretsUpdateDataCalled = true
+				assert.Equal(t, bundle.CertBytes, secretData.Certificate)
+				return nil
+			}
+			builder.Start()
+
+			key, err := cache.MetaNamespaceKeyFunc(crt)
+			require.NoError(t, err)
+
+			err = w.controller.ProcessItem(context.Background(), key)
+			require.NoError(t, err)
+			builder.CheckAndFinish(err)
+
+			assert.Equal(t, test.expRolloutTime == 0, secretsUpdateDataCalled, "secretsUpdateData func call")
+			var expWait time.Duration
+			if test.expRolloutTime > 0 {
+				expWait = test.expRolloutTime - test.sinceIssued
+			}
+			assert.Equal(t, expWait, gotWait, "re-queue delay")
+		})
+	}
+}
+
+func TestIssuingController_IssuerCAFingerprints(t *testing.T) {
+	nextPrivateKeySecretName := "next-private-key"
+	// The shared clock may have been stepped by other tests, which would
+	// leave the certificates signed below not yet valid.
+	clockStart := time.Now().Truncate(time.Second)
+	fakeClock := fakeclock.NewFakeClock(clockStart)
+	metaClockStart := metav1.NewTime(clockStart)
+
+	verifyIssuerCA := map[string]string{cmapi.VerifyIssuerCAAnnotationKey: "true"}
+	baseCert := gen.Certificate("test",
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca-issuer", Kind: "Issuer", Group: "cert-manager.io"}),
+		gen.SetCertificateGeneration(3),
+		gen.SetCertificateSecretName("output"),
+		gen.SetCertificateDNSNames("example.com"),
+		gen.SetCertificateRevision(1),
+		gen.SetCertificateNextPrivateKeySecretName(nextPrivateKeySecretName),
+		gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+			Type:               cmapi.CertificateConditionIssuing,
+			Status:             cmmeta.ConditionTrue,
+			ObservedGeneration: 3,
+			LastTransitionTime: &metaClockStart,
+		}),
+	)
+	bundle := testcrypto.MustCreateCryptoBundle(t, baseCert.DeepCopy(), fakeClock)
+	// The certificate of caBundle stands in for the CA returned by the
+	// issuer.
+	caBundle := testcrypto.MustCreateCryptoBundle(t, baseCert.DeepCopy(), fakeClock)
+	caFingerprint, err := utilpki.PublicKeyFingerprintSHA256(caBundle.Cert.PublicKey)
+	require.NoError(t, err)
+
+	req := gen.CertificateRequestFrom(bundle.CertificateRequestReady,
+		gen.AddCertificateRequestAnnotations(map[string]string{
+			cmapi.CertificateRequestRevisionAnnotationKey: "2", // Current Certificate revision=1
+		}),
+		gen.SetCertificateRequestCA(caBundle.CertBytes),
+	)
+	issuer := func(annotations map[string]string) *cmapi.Issuer {
+		return gen.Issuer("ca-issuer", gen.SetIssuerNamespace(baseCert.Namespace), func(iss cmapi.GenericIssuer) {
+			iss.GetObjectMeta().Annotations = annotations
+		})
+	}
+
+	tests := map[string]struct {
+		crt    *cmapi.Certificate
+		issuer *cmapi.Issuer
+
+		expFingerprints []string
+	}{
+		"should not record the CAs if verification is not enabled": {
+			crt:    baseCert,
+			issuer: issuer(nil),
+		},
+		"should record the CAs if enabled on the Certificate": {
+			crt:             gen.CertificateFrom(baseCert, gen.AddCertificateAnnotations(verifyIssuerCA)),
+			issuer:          issuer(nil),
+			expFingerprints: []string{caFingerprint},
+		},
+		"should record the CAs if enabled on the issuer": {
+			crt:             baseCert,
+			issuer:          issuer(verifyIssuerCA),
+			expFingerprints: []string{caFingerprint},
+		},
+		"should clear the CAs recorded for the previous certificate if verification has been disabled": {
+			crt: gen.CertificateFrom(baseCert, func(crt *cmapi.Certificate) {
+				crt.Status.IssuerCAFingerprints = []string{"previous"}
+			}),
+			issuer: issuer(nil),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fakeClock,
+				CertManagerObjects: []runtime.Object{test.crt, test.issuer, req},
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      nextPrivateKeySecretName,
+							Namespace: baseCert.Namespace,
+						},
+						Data: map[string][]byte{
+							corev1.TLSPrivateKeyKey: bundle.PrivateKeyBytes,
+						},
+					},
+				},
+			}
+			builder.InitWithRESTConfig()
+			defer builder.Stop()
+
+			w := controllerWrapper{}
+			_, _, err := w.Register(builder.Context)
+			require.NoError(t, err)
+			w.controller.secretsUpdateData = func(context.Context, *cmapi.Certificate, internal.SecretData) error {
+				return nil
+			}
+			builder.Start()
+
+			key, err := cache.MetaNamespaceKeyFunc(test.crt)
+			require.NoError(t, err)
+			require.NoError(t, w.controller.ProcessItem(context.Background(), key))
+
+			crt, err := builder.CMClient.CertmanagerV1().Certificates(baseCert.Namespace).Get(context.Background(), baseCert.Name, metav1.GetOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, test.expFingerprints, crt.Status.IssuerCAFingerprints)
+		})
+	}
+}
+
+func TestIssuingController_MissingSCTs(t *testing.T) {
+	nextPrivateKeySecretName := "next-private-key"
+	metaFixedClockStart := metav1.NewTime(fixedClockStart)
+	fixedClock.SetTime(fixedClockStart)
+
+	crt := func(issuerName string) *cmapi.Certificate {
+		return gen.Certificate("test",
+			gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: issuerName, Kind: "Issuer", Group: "cert-manager.io"}),
+			gen.SetCertificateGeneration(3),
+			gen.SetCertificateSecretName("output"),
+			gen.SetCertificateDNSNames("example.com"),
+			gen.SetCertificateRevision(1),
+			gen.SetCertificateNextPrivateKeySecretName(nextPrivateKeySecretName),
+			gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+				Type:               cmapi.CertificateConditionIssuing,
+				Status:             cmmeta.ConditionTrue,
+				ObservedGeneration: 3,
+				LastTransitionTime: &metaFixedClockStart,
+			}),
+		)
+	}
+	bundle := testcrypto.MustCreateCryptoBundle(t, crt("acme-issuer"), fixedClock)
+	leafWithSCTs := map[int][]byte{}
+	for _, n := range []int{0, 1, 2} {
+		leafWithSCTs[n] = testcrypto.MustCreateCertWithSCTs(t, bundle.PrivateKey, crt("acme-issuer"), n)
+	}
+	// The leaves are valid from the time at which they were created.
+	fixedClock.SetTime(time.Now())
+
+	acmeIssuer := func(requireEmbeddedSCTs int) *cmapi.Issuer {
+		return gen.Issuer("acme-issuer", gen.SetIssuerNamespace(bundle.Certificate.Namespace),
+			gen.SetIssuerACME(cmacme.ACMEIssuer{RequireEmbeddedSCTs: requireEmbeddedSCTs}))
+	}
+	caIssuer := func(annotations map[string]string) *cmapi.Issuer {
+		return gen.Issuer("ca-issuer", gen.SetIssuerNamespace(bundle.Certificate.Namespace),
+			gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca"}),
+			func(iss cmapi.GenericIssuer) {
+				iss.GetObjectMeta().Annotations = annotations
+			})
+	}
+	requireTwoSCTs := map[string]string{cmapi.RequireEmbeddedSCTsAnnotationKey: "2"}
+
+	tests := map[string]struct {
+		crt    *cmapi.Certificate
+		issuer *cmapi.Issuer
+		scts   int
+
+		// expMessage is the message with which the issuance is failed, if
+		// the certificate is not stored.
+		expMessage string
+	}{
+		"should store a certificate without SCTs if the ACME issuer requires none": {
+			crt:    crt("acme-issuer"),
+			issuer: acmeIssuer(0),
+			scts:   0,
+		},
+		"should fail the issuance if the certificate embeds no SCTs": {
+			crt:        crt("acme-issuer"),
+			issuer:     acmeIssuer(2),
+			scts:       0,
+			expMessage: "embeds 0 Signed Certificate Timestamps, but its issuer requires at least 2",
+		},
+		"should fail the issuance if the certificate embeds fewer SCTs than required": {
+			crt:        crt("acme-issuer"),
+			issuer:     acmeIssuer(2),
+			scts:       1,
+			expMessage: "embeds 1 Signed Certificate Timestamps, but its issuer requires at least 2",
+		},
+		"should store a certificate which embeds as many SCTs as required": {
+			crt:    crt("acme-issuer"),
+			issuer: acmeIssuer(2),
+			scts:   2,
+		},
+		"should not check the SCTs of certificates issued by other issuers by default": {
+			crt:    crt("ca-issuer"),
+			issuer: caIssuer(nil),
+			scts:   0,
+		},
+		"should fail the issuance if the check is enabled on another issuer": {
+			crt:        crt("ca-issuer"),
+			issuer:     caIssuer(requireTwoSCTs),
+			scts:       1,
+			expMessage: "embeds 1 Signed Certificate Timestamps, but its issuer requires at least 2",
+		},
+		"should store a certificate if the check is enabled on another issuer and enough SCTs are embedded": {
+			crt:    crt("ca-issuer"),
+			issuer: caIssuer(requireTwoSCTs),
+			scts:   2,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := gen.CertificateRequestFrom(bundle.CertificateRequestReady,
+				gen.AddCertificateRequestAnnotations(map[string]string{
+					cmapi.CertificateRequestRevisionAnnotationKey: "2", // Current Certificate revision=1
+				}),
+				gen.SetCertificateRequestIssuer(test.crt.Spec.IssuerRef),
+				gen.SetCertificateRequestCertificate(leafWithSCTs[test.scts]),
+			)
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fixedClock,
+				CertManagerObjects: []runtime.Object{test.crt, test.issuer, req},
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      nextPrivateKeySecretName,
+							Namespace: test.crt.Namespace,
+						},
+						Data: map[string][]byte{
+							corev1.TLSPrivateKeyKey: bundle.PrivateKeyBytes,
+						},
+					},
+				},
+			}
+			builder.InitWithRESTConfig()
+			defer builder.Stop()
+
+			w := controllerWrapper{}
+			_, _, err := w.Register(builder.Context)
+			require.NoError(t, err)
+			stored := false
+			w.controller.secretsUpdateData = func(context.Context, *cmapi.Certificate, internal.SecretData) error {
+				stored = true
+				return nil
+			}
+			builder.Start()
+
+			key, err := cache.MetaNamespaceKeyFunc(test.crt)
+			require.NoError(t, err)
+			require.NoError(t, w.controller.ProcessItem(context.Background(), key))
+
+			got, err := builder.CMClient.CertmanagerV1().Certificates(test.crt.Namespace).Get(context.Background(), test.crt.Name, metav1.GetOptions{})
+			require.NoError(t, err)
+			if test.expMessage == "" {
+				assert.True(t, stored, "expected the certificate to be stored")
+				return
+			}
+			assert.False(t, stored, "expected the Secret to be left in place")
+			issuing := apiutil.GetCertificateCondition(got, cmapi.CertificateConditionIssuing)
+			require.NotNil(t, issuing)
+			assert.Equal(t, cmmeta.ConditionFalse, issuing.Status)
+			assert.Equal(t, "MissingSCTs", issuing.Reason)
+			assert.Contains(t, issuing.Message, test.expMessage)
+		})
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuing
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
+	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+// migratePreviousSecret moves the data of the Secret previously named by
+// spec.secretName to the Secret now named by spec.secretName, which does not
+// exist yet. Only a previous Secret holding the Certificate's current
+// certificate is moved, see internalcertificates.PreviousSecret. The data is
+// only moved if it passes the same policies which would otherwise trigger an
+// issuance, so that a certificate which is still valid is not re-issued only
+// because spec.secretName was changed.
+func (c *controller) migratePreviousSecret(ctx context.Context, log logr.Logger, crt *cmapi.Certificate) error {
+	currentReq, err := c.currentCertificateRequest(crt)
+	if err != nil {
+		return err
+	}
+
+	previous, err := internalcertificates.PreviousSecret(c.secretLister.Secrets(crt.Namespace), crt, currentReq)
+	if err != nil || previous == nil {
+		return err
+	}
+	log = log.WithValues("previous_secret", previous.Name)
+	if reason, message, violation := c.migrationPolicyChain.Evaluate(policies.Input{
+		Certificate:            crt,
+		Secret:                 previous,
+		CurrentRevisionRequest: currentReq,
+	}); violation {
+		log.V(logf.DebugLevel).Info("not moving the data of the previous secret as it is not valid for the certificate", "reason", reason, "message", message)
+		return nil
+	}
+
+	log.Info("moving the data of the previous secret to the secret named by spec.secretName")
+	if err := c.secretsUpdateData(ctx, crt, secretDataFromSecret(previous)); err != nil {
+		return err
+	}
+	c.recorder.Eventf(crt, corev1.EventTypeNormal, reasonSecretMigrated,
+		"Moved the certificate from the Secret %q to the Secret %q as spec.secretName was changed", previous.Name, crt.Spec.SecretName)
+	return nil
+}
+
+// deletePreviousSecrets deletes the Secrets previously named by
+// spec.secretName whose data has been moved to the given Secret, named by
+// spec.secretName, if the Certificate's previous Secrets are to be deleted,
+// see internalcertificates.DeletesPreviousSecrets. Previous Secrets holding
+// another certificate are left alone, as they are not known to have been
+// replaced by the given Secret. It must only be called once the given Secret
+// holds a certificate.
+func (c *controller) deletePreviousSecrets(ctx context.Context, log logr.Logger, crt *cmapi.Certificate, current *corev1.Secret) error {
+	if !internalcertificates.DeletesPreviousSecrets(crt, c.enableOwnerRef) {
+		return nil
+	}
+
+	previous, err := internalcertificates.PreviousSecrets(c.secretLister.Secrets(crt.Namespace), crt)
+	if err != nil {
+		return err
+	}
+	for _, secret := range previous {
+		if !bytes.Equal(secret.Data[corev1.TLSCertKey], current.Data[corev1.TLSCertKey]) {
+			continue
+		}
+		// The UID precondition makes sure that a Secret which has since been
+		// re-created, for example by another Certificate, is not deleted.
+		err := c.secretsGetter.Secrets(crt.Namespace).Delete(ctx, secret.Name, metav1.DeleteOptions{
+			Preconditions: metav1.NewUIDPreconditions(string(secret.UID)),
+		})
+		if apierrors.IsNotFound(err) || apierrors.IsConflict(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		log.Info("deleted the secret previously named by spec.secretName", "previous_secret", secret.Name)
+		c.recorder.Eventf(crt, corev1.EventTypeNormal, reasonPreviousSecretDeleted,
+			"Deleted the Secret %q which was previously named by spec.secretName", secret.Name)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuing
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// checkEmbeddedSCTs returns a condition with which the issuance must be
+// failed if the certificate issued for the CertificateRequest embeds fewer
+// Signed Certificate Timestamps than its issuer requires, in which case the
+// CertificateRequest is also failed. A nil condition is returned if the
+// issuer requires no SCTs.
+func (c *controller) checkEmbeddedSCTs(ctx context.Context, crt *cmapi.Certificate, req *cmapi.CertificateRequest) (*cmapi.CertificateRequestCondition, error) {
+	required := c.requiredEmbeddedSCTs(ctx, crt)
+	if required == 0 {
+		return nil, nil
+	}
+
+	chain, err := decodeIssuedCertificateChain(req.Status.Certificate)
+	if err != nil {
+		return c.invalidIssuedCertificate(ctx, req, fmt.Sprintf("The certificate issued for CertificateRequest %q could not be parsed: %v", req.Name, err))
+	}
+
+	embedded, err := utilpki.EmbeddedSCTCount(chain[0])
+	if err != nil {
+		return c.rejectIssuedCertificate(ctx, req, reasonMissingSCTs,
+			fmt.Sprintf("The certificate issued for CertificateRequest %q has a malformed list of Signed Certificate Timestamps: %v", req.Name, err))
+	}
+	if embedded < required {
+		return c.rejectIssuedCertificate(ctx, req, reasonMissingSCTs,
+			fmt.Sprintf("The certificate issued for CertificateRequest %q embeds %d Signed Certificate Timestamps, but its issuer requires at least %d", req.Name, embedded, required))
+	}
+
+	return nil, nil
+}
+
+// requiredEmbeddedSCTs returns the minimum number of Signed Certificate
+// Timestamps which must be embedded in certificates issued for the
+// Certificate. It is set by the requireEmbeddedSCTs field of ACME issuers,
+// and by the require-embedded-scts annotation of other issuers. External
+// issuers, and issuers which cannot be read, require no SCTs.
+func (c *controller) requiredEmbeddedSCTs(ctx context.Context, crt *cmapi.Certificate) int {
+	genericIssuer := c.certificateIssuer(crt)
+	if genericIssuer == nil {
+		return 0
+	}
+	if acme := genericIssuer.GetSpec().ACME; acme != nil {
+		return acme.RequireEmbeddedSCTs
+	}
+
+	value, ok := genericIssuer.GetObjectMeta().Annotations[cmapi.RequireEmbeddedSCTsAnnotationKey]
+	if !ok {
+		return 0
+	}
+	required, err := strconv.Atoi(value)
+	if err != nil || required < 0 {
+		logf.FromContext(ctx).V(logf.WarnLevel).Info("ignoring invalid annotation on issuer, it must be a number of SCTs", "annotation", cmapi.RequireEmbeddedSCTsAnnotationKey, "value", value)
+		return 0
+	}
+	return required
+}
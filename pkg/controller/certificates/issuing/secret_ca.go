@@ -0,0 +1,162 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+
+	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/pkg/util/predicate"
+)
+
+// errInvalidCABundle is wrapped by errors returned when the CA bundle
+// referenced by a Certificate's SecretCAPolicy cannot be used.
+var errInvalidCABundle = errors.New("invalid CA bundle")
+
+// secretCA returns the data to store in the `ca.crt` key of the Certificate's
+// Secret according to its SecretCAPolicy, given the CA provided by the
+// issuer. A nil result means that no `ca.crt` key should be stored.
+func (c *controller) secretCA(crt *cmapi.Certificate, issuerCA []byte) ([]byte, error) {
+	policy := crt.Spec.SecretCAPolicy
+	if policy == nil {
+		return issuerCA, nil
+	}
+
+	switch policy.Type {
+	case cmapi.CertificateSecretCAPolicyOmit:
+		return nil, nil
+	case cmapi.CertificateSecretCAPolicyFromSecretRef:
+		return c.caBundle(crt.Namespace, policy.BundleRef)
+	default:
+		return issuerCA, nil
+	}
+}
+
+// caBundle returns the CA bundle referenced by ref. An error wrapping
+// errInvalidCABundle is returned if the bundle does not exist or does not
+// contain PEM encoded certificates.
+func (c *controller) caBundle(namespace string, ref *cmapi.CertificateCABundleReference) ([]byte, error) {
+	if ref == nil {
+		return nil, fmt.Errorf("%w: no bundleRef is set", errInvalidCABundle)
+	}
+
+	var (
+		data  []byte
+		found bool
+	)
+	switch ref.Kind {
+	case "ConfigMap":
+		cm, err := c.configMapLister.ConfigMaps(namespace).Get(ref.Name)
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("%w: ConfigMap %q not found", errInvalidCABundle, ref.Name)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if s, ok := cm.Data[ref.Key]; ok {
+			data, found = []byte(s), true
+		} else {
+			data, found = cm.BinaryData[ref.Key]
+		}
+
+	case "Secret":
+		secret, err := c.secretLister.Secrets(namespace).Get(ref.Name)
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("%w: Secret %q not found", errInvalidCABundle, ref.Name)
+		}
+		if err != nil {
+			return nil, err
+		}
+		data, found = secret.Data[ref.Key]
+
+	default:
+		return nil, fmt.Errorf("%w: unsupported bundleRef kind %q", errInvalidCABundle, ref.Kind)
+	}
+
+	if !found {
+		return nil, fmt.Errorf("%w: %s %q has no key %q", errInvalidCABundle, ref.Kind, ref.Name, ref.Key)
+	}
+	if _, err := utilpki.DecodeX509CertificateSetBytes(data); err != nil {
+		return nil, fmt.Errorf("%w: key %q of %s %q does not contain PEM encoded certificates: %v", errInvalidCABundle, ref.Key, ref.Kind, ref.Name, err)
+	}
+
+	return data, nil
+}
+
+// setInvalidCABundle sets the InvalidCABundle condition on the Certificate.
+// The Warning event is only recorded when the condition is first set, rather
+// than every time the Certificate is processed until the bundle is fixed.
+func (c *controller) setInvalidCABundle(ctx context.Context, crt *cmapi.Certificate, message string) error {
+	cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionInvalidCABundle)
+	if cond != nil && cond.Status == cmmeta.ConditionTrue && cond.Message == message {
+		return nil
+	}
+
+	crt = crt.DeepCopy()
+	apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionInvalidCABundle, cmmeta.ConditionTrue, reasonInvalidCABundle, message)
+	if err := c.updateOrApplyStatus(ctx, crt, false); err != nil {
+		return err
+	}
+
+	if cond == nil || cond.Status != cmmeta.ConditionTrue {
+		c.recorder.Event(crt, corev1.EventTypeWarning, reasonInvalidCABundle, message)
+	}
+
+	return nil
+}
+
+// removeInvalidCABundle removes the InvalidCABundle condition from the
+// Certificate once its CA bundle can be used.
+func (c *controller) removeInvalidCABundle(ctx context.Context, crt *cmapi.Certificate) error {
+	if apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionInvalidCABundle) == nil {
+		return nil
+	}
+
+	crt = crt.DeepCopy()
+	apiutil.RemoveCertificateCondition(crt, cmapi.CertificateConditionInvalidCABundle)
+	return c.updateOrApplyStatus(ctx, crt, false)
+}
+
+// currentCertificateRequest returns the CertificateRequest which issued the
+// current revision of the Certificate, or nil if it cannot be found.
+func (c *controller) currentCertificateRequest(crt *cmapi.Certificate) (*cmapi.CertificateRequest, error) {
+	if crt.Status.Revision == nil {
+		return nil, nil
+	}
+
+	reqs, err := certificates.ListCertificateRequestsMatchingPredicates(c.certificateRequestLister.CertificateRequests(crt.Namespace),
+		labels.Everything(),
+		predicate.CertificateRequestRevision(*crt.Status.Revision),
+		internalcertificates.CertificateRequestOwnedBy(crt),
+	)
+	if err != nil || len(reqs) != 1 {
+		return nil, err
+	}
+
+	return reqs[0], nil
+}
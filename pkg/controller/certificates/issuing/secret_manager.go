@@ -24,6 +24,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 
+	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
 	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
@@ -33,17 +34,19 @@ import (
 
 // ensureSecretData ensures that the Certificate's Secret is up to date with
 // non-issuing condition related data.
-// Reconciles over the Certificate's SecretTemplate, and
-// AdditionalOutputFormats.
+// Reconciles over the Certificate's SecretTemplate, AdditionalOutputFormats
+// and SecretCAPolicy.
 func (c *controller) ensureSecretData(ctx context.Context, log logr.Logger, crt *cmapi.Certificate) error {
 	// Retrieve the Secret which is associated with this Certificate.
 	secret, err := c.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
 
-	// Secret doesn't exist so we can't do anything. The Certificate will be
-	// marked for a re-issuance and the resulting Secret will be evaluated again.
+	// Secret doesn't exist, so the data of the Secret previously named by
+	// spec.secretName is moved to it if there is one. Otherwise, the
+	// Certificate will be marked for a re-issuance and the resulting Secret
+	// will be evaluated again.
 	if apierrors.IsNotFound(err) {
 		log.V(logf.DebugLevel).Info("secret not found", "error", err.Error())
-		return nil
+		return c.migratePreviousSecret(ctx, log, crt)
 	}
 
 	// This error is transient, return error to be retried on the rate limiting
@@ -57,29 +60,86 @@ func (c *controller) ensureSecretData(ctx context.Context, log logr.Logger, crt
 	// If there is no certificate or private key data available at the target
 	// Secret then exit early. The absence of these keys should cause an issuance
 	// of the Certificate, so there is no need to run post issuance checks.
+	// Certificates using an external CSR or an external private key never
+	// have private key data.
 	if secret.Data == nil ||
 		len(secret.Data[corev1.TLSCertKey]) == 0 ||
-		len(secret.Data[corev1.TLSPrivateKeyKey]) == 0 {
+		(len(secret.Data[corev1.TLSPrivateKeyKey]) == 0 && crt.Spec.ExternalCSR == nil && !internalcertificates.UsesExternalPrivateKey(crt.Spec)) {
 		log.V(logf.DebugLevel).Info("secret doesn't contain both certificate and private key data",
 			"cert_data_len", len(secret.Data[corev1.TLSCertKey]), "key_data_len", len(secret.Data[corev1.TLSPrivateKeyKey]))
 		return nil
 	}
 
-	data := internal.SecretData{
-		PrivateKey:      secret.Data[corev1.TLSPrivateKeyKey],
-		Certificate:     secret.Data[corev1.TLSCertKey],
-		CA:              secret.Data[cmmeta.TLSCAKey],
-		CertificateName: secret.Annotations[cmapi.CertificateNameKey],
-		IssuerName:      secret.Annotations[cmapi.IssuerNameAnnotationKey],
-		IssuerKind:      secret.Annotations[cmapi.IssuerKindAnnotationKey],
-		IssuerGroup:     secret.Annotations[cmapi.IssuerGroupAnnotationKey],
+	// The Secret holds a certificate, so the Secrets previously named by
+	// spec.secretName are no longer needed.
+	if err := c.deletePreviousSecrets(ctx, log, crt, secret); err != nil {
+		return err
+	}
+
+	// A Secret which existed before the Certificate was first issued is only
+	// adopted if the Certificate allows it, otherwise it is left untouched
+	// until a certificate has been issued.
+	if crt.Status.Revision == nil && !internalcertificates.AdoptsExistingSecret(crt) {
+		log.V(logf.DebugLevel).Info("not adopting existing secret as adoption is disabled for the certificate")
+		return nil
+	}
+
+	data := secretDataFromSecret(secret)
+
+	// If the certificate name or issuer annotations are missing, e.g. because
+	// the Secret was restored from a backup which dropped them, restore them
+	// from the Certificate. Each issuer annotation is restored on its own, as
+	// the ones which are present were checked against the issuerRef. Had the
+	// certificate data or the issuer annotations not matched the Certificate's
+	// spec, a reissuance would have been triggered instead.
+	adopting := false
+	if data.CertificateName == "" {
+		data.CertificateName = crt.Name
+		adopting = crt.Status.Revision == nil
+	}
+	if _, ok := secret.Annotations[cmapi.IssuerNameAnnotationKey]; !ok {
+		data.IssuerName = crt.Spec.IssuerRef.Name
+	}
+	if _, ok := secret.Annotations[cmapi.IssuerKindAnnotationKey]; !ok {
+		data.IssuerKind = crt.Spec.IssuerRef.Kind
+	}
+	if _, ok := secret.Annotations[cmapi.IssuerGroupAnnotationKey]; !ok {
+		data.IssuerGroup = crt.Spec.IssuerRef.Group
+	}
+
+	// The CA provided by the issuer is taken from the CertificateRequest of
+	// the current revision, so that it can be restored if the SecretCAPolicy
+	// is changed back to IssuerProvided without re-issuing the certificate.
+	// If the issuer provided no CA, the Secret's CA is kept as is.
+	currentReq, err := c.currentCertificateRequest(crt)
+	if err != nil {
+		return err
+	}
+	issuerCA := data.CA
+	if currentReq != nil && len(currentReq.Status.CA) > 0 {
+		issuerCA = currentReq.Status.CA
+	}
+	data.CA, err = c.secretCA(crt, issuerCA)
+	if errors.Is(err, errInvalidCABundle) {
+		// Leave the Secret untouched until the bundle has been fixed, at
+		// which point the Certificate is processed again.
+		log.V(logf.DebugLevel).Info("failed to determine the CA to store in the Secret", "error", err.Error())
+		return c.setInvalidCABundle(ctx, crt, err.Error())
+	}
+	if err != nil {
+		return err
+	}
+	if err := c.removeInvalidCABundle(ctx, crt); err != nil {
+		return err
 	}
 
 	// Check whether the Certificate's Secret has correct output format and
 	// metadata.
 	reason, message, isViolation := c.postIssuancePolicyChain.Evaluate(policies.Input{
-		Certificate: crt,
-		Secret:      secret,
+		Certificate:            crt,
+		Secret:                 secret,
+		CurrentRevisionRequest: currentReq,
+		SecretCA:               data.CA,
 	})
 
 	if isViolation {
@@ -96,7 +156,14 @@ func (c *controller) ensureSecretData(ctx context.Context, log logr.Logger, crt
 
 			// Here the Certificate need to be re-reconciled.
 			log.Info("applying Secret data", "message", message)
-			return c.secretsUpdateData(ctx, crt, data)
+			if err := c.secretsUpdateData(ctx, crt, data); err != nil {
+				return err
+			}
+			if adopting {
+				c.recorder.Eventf(crt, corev1.EventTypeNormal, reasonSecretAdopted,
+					"Adopted the existing Secret %q, its certificate will be renewed at its renewal time", crt.Spec.SecretName)
+			}
+			return nil
 		}
 	}
 
@@ -104,3 +171,19 @@ func (c *controller) ensureSecretData(ctx context.Context, log logr.Logger, crt
 
 	return nil
 }
+
+// secretDataFromSecret returns the data stored in a Certificate's Secret.
+func secretDataFromSecret(secret *corev1.Secret) internal.SecretData {
+	return internal.SecretData{
+		PrivateKey:          secret.Data[corev1.TLSPrivateKeyKey],
+		PrivateKeyReference: secret.Data[cmapi.PrivateKeyReferenceSecretKey],
+		Certificate:         secret.Data[corev1.TLSCertKey],
+		CA:                  secret.Data[cmmeta.TLSCAKey],
+		CertificateName:     secret.Annotations[cmapi.CertificateNameKey],
+		IssuerName:          secret.Annotations[cmapi.IssuerNameAnnotationKey],
+		IssuerKind:          secret.Annotations[cmapi.IssuerKindAnnotationKey],
+		IssuerGroup:         secret.Annotations[cmapi.IssuerGroupAnnotationKey],
+
+		PrivateKeyDefaulted: secret.Annotations[cmapi.PrivateKeyDefaultedAnnotationKey] == "true",
+	}
+}
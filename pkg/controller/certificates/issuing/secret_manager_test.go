@@ -24,7 +24,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	coretesting "k8s.io/client-go/testing"
 	"k8s.io/utils/ptr"
 
 	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
@@ -32,7 +34,9 @@ import (
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/issuing/internal"
 	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
 )
 
 func Test_ensureSecretData(t *testing.T) {
@@ -43,6 +47,103 @@ func Test_ensureSecretData(t *testing.T) {
 	block, _ := pem.Decode(pk)
 	pkDER := block.Bytes
 	combinedPEM := append(append(pk, '\n'), cert...)
+	issuerCA := testcrypto.MustCreateCert(t, pk, &cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "issuer-ca", IsCA: true}})
+	bundleCA := testcrypto.MustCreateCert(t, pk, &cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "bundle-ca", IsCA: true}})
+	bundleRef := &cmapi.CertificateCABundleReference{Kind: "Secret", Name: "ca-bundle", Key: "bundle.pem"}
+
+	// bundleRefCert is a Certificate storing the bundle referenced by
+	// bundleRef in its Secret.
+	bundleRefCert := func() *cmapi.Certificate {
+		return &cmapi.Certificate{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-name"},
+			Spec: cmapi.CertificateSpec{
+				CommonName: "test",
+				SecretName: "test-secret",
+				SecretCAPolicy: &cmapi.CertificateSecretCAPolicy{
+					Type:      cmapi.CertificateSecretCAPolicyFromSecretRef,
+					BundleRef: bundleRef,
+				},
+			},
+		}
+	}
+	bundleRefSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-secret",
+			Annotations: map[string]string{cmapi.CertificateNameKey: "test-name"},
+		},
+		Data: map[string][]byte{"tls.crt": cert, "tls.key": pk, "ca.crt": issuerCA},
+	}
+	invalidBundle := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "ca-bundle"},
+		Data:       map[string][]byte{"bundle.pem": []byte("not a certificate")},
+	}
+	invalidBundleMessage := `invalid CA bundle: key "bundle.pem" of Secret "ca-bundle" does not contain PEM encoded certificates: error decoding certificate PEM block`
+	metaFixedClockStart := metav1.NewTime(fixedClockStart)
+	withInvalidCABundle := func(crt *cmapi.Certificate) *cmapi.Certificate {
+		return gen.CertificateFrom(crt, gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+			Type:               cmapi.CertificateConditionInvalidCABundle,
+			Status:             cmmeta.ConditionTrue,
+			Reason:             "InvalidCABundle",
+			Message:            invalidBundleMessage,
+			LastTransitionTime: &metaFixedClockStart,
+		}))
+	}
+
+	// renamedCert is a Certificate whose spec.secretName has been changed
+	// from "old-secret" to "new-secret".
+	renamedCert := func(mods ...func(*cmapi.Certificate)) *cmapi.Certificate {
+		crt := &cmapi.Certificate{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-name"},
+			Spec: cmapi.CertificateSpec{
+				CommonName: "example.com",
+				IssuerRef:  cmmeta.ObjectReference{Name: "testissuer", Kind: "Issuer"},
+				SecretName: "new-secret",
+			},
+			Status: cmapi.CertificateStatus{Revision: ptr.To(1)},
+		}
+		for _, mod := range mods {
+			mod(crt)
+		}
+		return crt
+	}
+	// issuedSecret returns a Secret holding a certificate issued for the
+	// Certificate with the given common name.
+	issuedSecret := func(name, commonName string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: name,
+				Annotations: map[string]string{
+					cmapi.CertificateNameKey:      "test-name",
+					cmapi.IssuerNameAnnotationKey: "testissuer",
+					cmapi.IssuerKindAnnotationKey: "Issuer",
+				},
+				Labels: map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"},
+				UID:    types.UID(name + "-uid"),
+			},
+			Data: map[string][]byte{
+				corev1.TLSPrivateKeyKey: pk,
+				corev1.TLSCertKey: testcrypto.MustCreateCert(t, pk,
+					&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: commonName}},
+				),
+			},
+		}
+	}
+	oldSecret := issuedSecret("old-secret", "example.com")
+	// issuedInto records the certificate held by the given Secret as the
+	// Certificate's current certificate.
+	issuedInto := func(secret *corev1.Secret) func(*cmapi.Certificate) {
+		return func(crt *cmapi.Certificate) {
+			cert, err := pki.DecodeX509CertificateBytes(secret.Data[corev1.TLSCertKey])
+			if err != nil {
+				t.Fatal(err)
+			}
+			crt.Status.SHA256Fingerprint = pki.FingerprintSHA256(cert)
+		}
+	}
+	otherSecret := issuedSecret("old-secret", "other.example.com")
+	// movedSecret holds the same data as oldSecret under the name
+	// "new-secret".
+	movedSecret := oldSecret.DeepCopy()
+	movedSecret.Name = "new-secret"
+	movedSecret.UID = "new-secret-uid"
 
 	tests := map[string]struct {
 		// key that should be passed to ProcessItem.
@@ -56,12 +157,28 @@ func Test_ensureSecretData(t *testing.T) {
 		// secret is the optional secret to be loaded into the fake clientset.
 		secret *corev1.Secret
 
+		// objects are optional additional objects to be loaded into the fake
+		// clientsets.
+		certManagerObjects, kubeObjects []runtime.Object
+
 		// expectedAction is true if the test expects that the controller should
 		// reconcile the Secret.
 		expectedAction bool
 
 		// enableOwnerRef is passed to the post issuance policy checks.
 		enableOwnerRef bool
+
+		// expectedSecretData, if set, is the data the Secret is expected to
+		// be reconciled with.
+		expectedSecretData *internal.SecretData
+
+		// expectedActions, if set, are the actions the controller is
+		// expected to perform using the fake clientsets.
+		expectedActions []testpkg.Action
+
+		// expectedEvents, if set, are the events the controller is expected
+		// to record.
+		expectedEvents []string
 	}{
 		"if 'key' is empty, should do nothing and not error": {
 			expectedAction: false,
@@ -283,8 +400,18 @@ func Test_ensureSecretData(t *testing.T) {
 			secret: &corev1.Secret{
 				ObjectMeta: metav1.ObjectMeta{
 					Namespace: "test-namespace", Name: "test-secret",
-					Annotations: map[string]string{"foo": "bar"},
-					Labels:      map[string]string{"abc": "123", cmapi.PartOfCertManagerControllerLabelKey: "true"},
+					Annotations: map[string]string{
+						"foo":                          "bar",
+						cmapi.CertificateNameKey:       "test-name",
+						cmapi.IssuerNameAnnotationKey:  "",
+						cmapi.IssuerKindAnnotationKey:  "Issuer",
+						cmapi.IssuerGroupAnnotationKey: "cert-manager.io",
+						cmapi.CommonNameAnnotationKey:  "test",
+						cmapi.AltNamesAnnotationKey:    "",
+						cmapi.IPSANAnnotationKey:       "",
+						cmapi.URISANAnnotationKey:      "",
+					},
+					Labels: map[string]string{"abc": "123", cmapi.PartOfCertManagerControllerLabelKey: "true"},
 					ManagedFields: []metav1.ManagedFieldsEntry{{
 						Manager: fieldManager,
 						FieldsV1: &metav1.FieldsV1{
@@ -463,6 +590,16 @@ func Test_ensureSecretData(t *testing.T) {
 			},
 			secret: &corev1.Secret{
 				ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-secret",
+					Annotations: map[string]string{
+						cmapi.CertificateNameKey:       "test-name",
+						cmapi.IssuerNameAnnotationKey:  "",
+						cmapi.IssuerKindAnnotationKey:  "Issuer",
+						cmapi.IssuerGroupAnnotationKey: "cert-manager.io",
+						cmapi.CommonNameAnnotationKey:  "test",
+						cmapi.AltNamesAnnotationKey:    "",
+						cmapi.IPSANAnnotationKey:       "",
+						cmapi.URISANAnnotationKey:      "",
+					},
 					Labels: map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"},
 					ManagedFields: []metav1.ManagedFieldsEntry{{
 						Manager: fieldManager,
@@ -592,6 +729,16 @@ func Test_ensureSecretData(t *testing.T) {
 			},
 			secret: &corev1.Secret{
 				ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-secret",
+					Annotations: map[string]string{
+						cmapi.CertificateNameKey:       "test-name",
+						cmapi.IssuerNameAnnotationKey:  "",
+						cmapi.IssuerKindAnnotationKey:  "Issuer",
+						cmapi.IssuerGroupAnnotationKey: "cert-manager.io",
+						cmapi.CommonNameAnnotationKey:  "test",
+						cmapi.AltNamesAnnotationKey:    "",
+						cmapi.IPSANAnnotationKey:       "",
+						cmapi.URISANAnnotationKey:      "",
+					},
 					Labels: map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"},
 					OwnerReferences: []metav1.OwnerReference{
 						{APIVersion: "cert-manager.io/v1", Kind: "Certificate", Name: "test-name", UID: types.UID("uid-123"), Controller: ptr.To(true), BlockOwnerDeletion: ptr.To(true)},
@@ -620,6 +767,313 @@ func Test_ensureSecretData(t *testing.T) {
 			},
 			expectedAction: false,
 		},
+		"if the Secret was restored without its annotations, e.g. by Velero, should restore the annotations from the Certificate without reissuing": {
+			key: "test-namespace/test-name",
+			cert: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-name"},
+				Spec: cmapi.CertificateSpec{
+					CommonName: "test",
+					IssuerRef: cmmeta.ObjectReference{
+						Name:  "testissuer",
+						Kind:  "ClusterIssuer",
+						Group: "cert-manager.io",
+					},
+					SecretName: "test-secret",
+				},
+				Status: cmapi.CertificateStatus{
+					Conditions: []cmapi.CertificateCondition{{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue}},
+				},
+			},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-secret",
+					Labels: map[string]string{"velero.io/backup-name": "backup", "velero.io/restore-name": "restore"},
+					ManagedFields: []metav1.ManagedFieldsEntry{{
+						Manager: "velero-server",
+						FieldsV1: &metav1.FieldsV1{
+							Raw: []byte(`{"f:data": {"f:tls.crt": {}, "f:tls.key": {}}, "f:type": {}}`),
+						}},
+					},
+				},
+				Data: map[string][]byte{"tls.crt": cert, "tls.key": pk},
+			},
+			expectedAction: true,
+			expectedSecretData: &internal.SecretData{
+				PrivateKey:      pk,
+				Certificate:     cert,
+				CertificateName: "test-name",
+				IssuerName:      "testissuer",
+				IssuerKind:      "ClusterIssuer",
+				IssuerGroup:     "cert-manager.io",
+			},
+		},
+		"if the Secret's issuer annotations were written with an empty kind and group by an older version, should rewrite them without reissuing": {
+			key: "test-namespace/test-name",
+			cert: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-name"},
+				Spec: cmapi.CertificateSpec{
+					CommonName: "test",
+					IssuerRef:  cmmeta.ObjectReference{Name: "testissuer", Kind: "Issuer", Group: "cert-manager.io"},
+					SecretName: "test-secret",
+				},
+				Status: cmapi.CertificateStatus{
+					Conditions: []cmapi.CertificateCondition{{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue}},
+				},
+			},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-secret",
+					Annotations: map[string]string{
+						cmapi.CertificateNameKey:       "test-name",
+						cmapi.IssuerNameAnnotationKey:  "testissuer",
+						cmapi.IssuerKindAnnotationKey:  "",
+						cmapi.IssuerGroupAnnotationKey: "",
+						cmapi.CommonNameAnnotationKey:  "test",
+						cmapi.AltNamesAnnotationKey:    "",
+						cmapi.IPSANAnnotationKey:       "",
+						cmapi.URISANAnnotationKey:      "",
+					},
+					Labels: map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"},
+				},
+				Data: map[string][]byte{"tls.crt": cert, "tls.key": pk},
+			},
+			expectedAction: true,
+			expectedSecretData: &internal.SecretData{
+				PrivateKey:      pk,
+				Certificate:     cert,
+				CertificateName: "test-name",
+				IssuerName:      "testissuer",
+			},
+		},
+		"if a Certificate which has not been issued yet references a pre-existing Secret, should adopt the Secret": {
+			key: "test-namespace/test-name",
+			cert: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-name"},
+				Spec: cmapi.CertificateSpec{
+					CommonName: "test",
+					IssuerRef:  cmmeta.ObjectReference{Name: "testissuer", Kind: "Issuer", Group: "cert-manager.io"},
+					SecretName: "test-secret",
+				},
+			},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-secret"},
+				Type:       corev1.SecretTypeTLS,
+				Data:       map[string][]byte{"tls.crt": cert, "tls.key": pk},
+			},
+			expectedAction: true,
+			expectedSecretData: &internal.SecretData{
+				PrivateKey:      pk,
+				Certificate:     cert,
+				CertificateName: "test-name",
+				IssuerName:      "testissuer",
+				IssuerKind:      "Issuer",
+				IssuerGroup:     "cert-manager.io",
+			},
+		},
+		"if a Certificate which has not been issued yet disables adoption, should not touch the pre-existing Secret": {
+			key: "test-namespace/test-name",
+			cert: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-name",
+					Annotations: map[string]string{cmapi.AdoptExistingSecretAnnotationKey: "false"}},
+				Spec: cmapi.CertificateSpec{
+					CommonName: "test",
+					IssuerRef:  cmmeta.ObjectReference{Name: "testissuer", Kind: "Issuer", Group: "cert-manager.io"},
+					SecretName: "test-secret",
+				},
+			},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-secret"},
+				Type:       corev1.SecretTypeTLS,
+				Data:       map[string][]byte{"tls.crt": cert, "tls.key": pk},
+			},
+			expectedAction: false,
+		},
+		"if the SecretCAPolicy is Omit, should remove ca.crt from the Secret without reissuing": {
+			key: "test-namespace/test-name",
+			cert: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-name"},
+				Spec: cmapi.CertificateSpec{
+					CommonName:     "test",
+					SecretName:     "test-secret",
+					SecretCAPolicy: &cmapi.CertificateSecretCAPolicy{Type: cmapi.CertificateSecretCAPolicyOmit},
+				},
+			},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-secret",
+					Annotations: map[string]string{cmapi.CertificateNameKey: "test-name"},
+				},
+				Data: map[string][]byte{"tls.crt": cert, "tls.key": pk, "ca.crt": issuerCA},
+			},
+			expectedAction: true,
+			expectedSecretData: &internal.SecretData{
+				PrivateKey:      pk,
+				Certificate:     cert,
+				CertificateName: "test-name",
+			},
+		},
+		"if the SecretCAPolicy is FromSecretRef, should store the referenced bundle in ca.crt": {
+			key: "test-namespace/test-name",
+			cert: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-name"},
+				Spec: cmapi.CertificateSpec{
+					CommonName: "test",
+					SecretName: "test-secret",
+					SecretCAPolicy: &cmapi.CertificateSecretCAPolicy{
+						Type:      cmapi.CertificateSecretCAPolicyFromSecretRef,
+						BundleRef: bundleRef,
+					},
+				},
+			},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-secret",
+					Annotations: map[string]string{cmapi.CertificateNameKey: "test-name"},
+				},
+				Data: map[string][]byte{"tls.crt": cert, "tls.key": pk, "ca.crt": issuerCA},
+			},
+			kubeObjects: []runtime.Object{&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "ca-bundle"},
+				Data:       map[string][]byte{"bundle.pem": bundleCA},
+			}},
+			expectedAction: true,
+			expectedSecretData: &internal.SecretData{
+				PrivateKey:      pk,
+				Certificate:     cert,
+				CA:              bundleCA,
+				CertificateName: "test-name",
+			},
+		},
+		"if the SecretCAPolicy is FromSecretRef but the bundle is not PEM encoded, should not update the Secret": {
+			key: "test-namespace/test-name",
+			cert: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-name"},
+				Spec: cmapi.CertificateSpec{
+					CommonName: "test",
+					SecretName: "test-secret",
+					SecretCAPolicy: &cmapi.CertificateSecretCAPolicy{
+						Type:      cmapi.CertificateSecretCAPolicyFromSecretRef,
+						BundleRef: bundleRef,
+					},
+				},
+			},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-secret",
+					Annotations: map[string]string{cmapi.CertificateNameKey: "test-name"},
+				},
+				Data: map[string][]byte{"tls.crt": cert, "tls.key": pk, "ca.crt": issuerCA},
+			},
+			kubeObjects:    []runtime.Object{invalidBundle},
+			expectedAction: false,
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					cmapi.SchemeGroupVersion.WithResource("certificates"),
+					"status",
+					"test-namespace",
+					withInvalidCABundle(bundleRefCert()),
+				)),
+			},
+			expectedEvents: []string{"Warning InvalidCABundle " + invalidBundleMessage},
+		},
+		"if the bundle is still invalid and the InvalidCABundle condition is set, should do nothing": {
+			key:            "test-namespace/test-name",
+			cert:           withInvalidCABundle(bundleRefCert()),
+			secret:         bundleRefSecret,
+			kubeObjects:    []runtime.Object{invalidBundle},
+			expectedAction: false,
+			expectedEvents: []string{},
+		},
+		"if the bundle has been fixed, should remove the InvalidCABundle condition": {
+			key:    "test-namespace/test-name",
+			cert:   withInvalidCABundle(bundleRefCert()),
+			secret: bundleRefSecret,
+			kubeObjects: []runtime.Object{&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "ca-bundle"},
+				Data:       map[string][]byte{"bundle.pem": bundleCA},
+			}},
+			expectedAction: true,
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					cmapi.SchemeGroupVersion.WithResource("certificates"),
+					"status",
+					"test-namespace",
+					bundleRefCert(),
+				)),
+			},
+		},
+		"if the SecretCAPolicy is changed back to IssuerProvided, should restore the CA of the current CertificateRequest": {
+			key: "test-namespace/test-name",
+			cert: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-name", UID: types.UID("uid-123")},
+				Spec: cmapi.CertificateSpec{
+					CommonName:     "test",
+					SecretName:     "test-secret",
+					SecretCAPolicy: &cmapi.CertificateSecretCAPolicy{Type: cmapi.CertificateSecretCAPolicyIssuerProvided},
+				},
+				Status: cmapi.CertificateStatus{Revision: ptr.To(1)},
+			},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-secret",
+					Annotations: map[string]string{cmapi.CertificateNameKey: "test-name"},
+				},
+				Data: map[string][]byte{"tls.crt": cert, "tls.key": pk, "ca.crt": bundleCA},
+			},
+			certManagerObjects: []runtime.Object{&cmapi.CertificateRequest{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-name-1",
+					Annotations: map[string]string{cmapi.CertificateRequestRevisionAnnotationKey: "1"},
+					OwnerReferences: []metav1.OwnerReference{
+						{APIVersion: "cert-manager.io/v1", Kind: "Certificate", Name: "test-name", UID: types.UID("uid-123"), Controller: ptr.To(true)},
+					},
+				},
+				Status: cmapi.CertificateRequestStatus{Certificate: cert, CA: issuerCA},
+			}},
+			expectedAction: true,
+			expectedSecretData: &internal.SecretData{
+				PrivateKey:      pk,
+				Certificate:     cert,
+				CA:              issuerCA,
+				CertificateName: "test-name",
+			},
+		},
+		"if only some of the issuer annotations are missing, should restore each of them from the Certificate": {
+			key: "test-namespace/test-name",
+			cert: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-name"},
+				Spec: cmapi.CertificateSpec{
+					CommonName: "test",
+					IssuerRef: cmmeta.ObjectReference{
+						Name:  "testissuer",
+						Kind:  "ClusterIssuer",
+						Group: "cert-manager.io",
+					},
+					SecretName: "test-secret",
+				},
+				Status: cmapi.CertificateStatus{
+					Conditions: []cmapi.CertificateCondition{{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue}},
+				},
+			},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-secret",
+					Annotations: map[string]string{
+						cmapi.CertificateNameKey:      "test-name",
+						cmapi.IssuerNameAnnotationKey: "testissuer",
+						cmapi.IssuerKindAnnotationKey: "ClusterIssuer",
+					},
+					ManagedFields: []metav1.ManagedFieldsEntry{{
+						Manager: "velero-server",
+						FieldsV1: &metav1.FieldsV1{
+							Raw: []byte(`{"f:data": {"f:tls.crt": {}, "f:tls.key": {}}, "f:type": {}}`),
+						}},
+					},
+				},
+				Data: map[string][]byte{"tls.crt": cert, "tls.key": pk},
+			},
+			expectedAction: true,
+			expectedSecretData: &internal.SecretData{
+				PrivateKey:      pk,
+				Certificate:     cert,
+				CertificateName: "test-name",
+				IssuerName:      "testissuer",
+				IssuerKind:      "ClusterIssuer",
+				IssuerGroup:     "cert-manager.io",
+			},
+		},
 		"refresh secrets when keystore is not defined and the secret has keystore/truststore fields": {
 			key:            "test-namespace/test-name",
 			enableOwnerRef: true,
@@ -874,9 +1328,14 @@ func Test_ensureSecretData(t *testing.T) {
 			secret: &corev1.Secret{
 				ObjectMeta: metav1.ObjectMeta{Name: "something", Namespace: "test-namespace",
 					Annotations: map[string]string{
+						cmapi.CertificateNameKey:       "test-name",
 						cmapi.IssuerNameAnnotationKey:  "testissuer",
 						cmapi.IssuerKindAnnotationKey:  "IssuerKind",
 						cmapi.IssuerGroupAnnotationKey: "group.example.com",
+						cmapi.CommonNameAnnotationKey:  "example.com",
+						cmapi.AltNamesAnnotationKey:    "",
+						cmapi.IPSANAnnotationKey:       "",
+						cmapi.URISANAnnotationKey:      "",
 					},
 					Labels: map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"},
 					OwnerReferences: []metav1.OwnerReference{
@@ -1110,9 +1569,14 @@ func Test_ensureSecretData(t *testing.T) {
 			secret: &corev1.Secret{
 				ObjectMeta: metav1.ObjectMeta{Name: "something", Namespace: "test-namespace",
 					Annotations: map[string]string{
+						cmapi.CertificateNameKey:       "test-name",
 						cmapi.IssuerNameAnnotationKey:  "testissuer",
 						cmapi.IssuerKindAnnotationKey:  "IssuerKind",
 						cmapi.IssuerGroupAnnotationKey: "group.example.com",
+						cmapi.CommonNameAnnotationKey:  "example.com",
+						cmapi.AltNamesAnnotationKey:    "",
+						cmapi.IPSANAnnotationKey:       "",
+						cmapi.URISANAnnotationKey:      "",
 					},
 					Labels: map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"},
 					OwnerReferences: []metav1.OwnerReference{
@@ -1147,13 +1611,89 @@ func Test_ensureSecretData(t *testing.T) {
 			},
 			expectedAction: false,
 		},
+		"if spec.secretName was changed and the previous Secret holds a valid certificate, move its data to the new Secret": {
+			key:            "test-namespace/test-name",
+			cert:           renamedCert(issuedInto(oldSecret)),
+			secret:         oldSecret,
+			expectedAction: true,
+			expectedSecretData: &internal.SecretData{
+				PrivateKey:      pk,
+				Certificate:     oldSecret.Data[corev1.TLSCertKey],
+				CertificateName: "test-name",
+				IssuerName:      "testissuer",
+				IssuerKind:      "Issuer",
+			},
+			expectedEvents: []string{`Normal SecretMigrated Moved the certificate from the Secret "old-secret" to the Secret "new-secret" as spec.secretName was changed`},
+		},
+		"if spec.secretName was changed but the previous Secret does not hold the current certificate, do nothing": {
+			key:            "test-namespace/test-name",
+			cert:           renamedCert(),
+			secret:         oldSecret,
+			expectedAction: false,
+		},
+		"if spec.secretName was changed but the previous Secret holds a certificate which does not match the Certificate, do nothing": {
+			key:            "test-namespace/test-name",
+			cert:           renamedCert(issuedInto(otherSecret)),
+			secret:         otherSecret,
+			expectedAction: false,
+		},
+		"if spec.secretName was changed but the previous Secret was issued for another Certificate, do nothing": {
+			key:  "test-namespace/test-name",
+			cert: renamedCert(issuedInto(oldSecret)),
+			secret: func() *corev1.Secret {
+				secret := issuedSecret("old-secret", "example.com")
+				secret.Annotations[cmapi.CertificateNameKey] = "other-name"
+				return secret
+			}(),
+			expectedAction: false,
+		},
+		"if spec.secretName was changed back and both Secrets exist, keep the previous Secret by default": {
+			key: "test-namespace/test-name",
+			cert: renamedCert(func(crt *cmapi.Certificate) {
+				crt.Spec.SecretName = "old-secret"
+			}),
+			secret:         oldSecret,
+			kubeObjects:    []runtime.Object{issuedSecret("new-secret", "example.com")},
+			expectedAction: true,
+		},
+		"if spec.secretName was changed back and the previous Secret is to be deleted, delete it": {
+			key: "test-namespace/test-name",
+			cert: renamedCert(func(crt *cmapi.Certificate) {
+				crt.Spec.SecretName = "old-secret"
+				crt.Annotations = map[string]string{cmapi.DeletePreviousSecretAnnotationKey: "true"}
+			}),
+			secret:         oldSecret,
+			kubeObjects:    []runtime.Object{movedSecret},
+			expectedAction: true,
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewDeleteAction(corev1.SchemeGroupVersion.WithResource("secrets"), "test-namespace", "new-secret")),
+			},
+			expectedEvents: []string{`Normal PreviousSecretDeleted Deleted the Secret "new-secret" which was previously named by spec.secretName`},
+		},
+		"if the previous Secret is to be deleted but holds another certificate, keep it": {
+			key: "test-namespace/test-name",
+			cert: renamedCert(func(crt *cmapi.Certificate) {
+				crt.Spec.SecretName = "old-secret"
+				crt.Annotations = map[string]string{cmapi.DeletePreviousSecretAnnotationKey: "true"}
+			}),
+			secret:         oldSecret,
+			kubeObjects:    []runtime.Object{issuedSecret("new-secret", "example.com")},
+			expectedAction: true,
+		},
 	}
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
+			// Other tests in the package step the shared clock, and the
+			// expected conditions are stamped with fixedClockStart.
+			fixedClock.SetTime(fixedClockStart)
+
 			// Create and initialise a new unit test builder.
 			builder := &testpkg.Builder{
-				T: t,
+				T:               t,
+				Clock:           fixedClock,
+				ExpectedActions: test.expectedActions,
+				ExpectedEvents:  test.expectedEvents,
 			}
 			if test.cert != nil {
 				// Ensures cert is loaded into the builder's fake clientset.
@@ -1163,6 +1703,8 @@ func Test_ensureSecretData(t *testing.T) {
 				// Ensures secret is loaded into the builder's fake clientset.
 				builder.KubeObjects = append(builder.KubeObjects, test.secret)
 			}
+			builder.CertManagerObjects = append(builder.CertManagerObjects, test.certManagerObjects...)
+			builder.KubeObjects = append(builder.KubeObjects, test.kubeObjects...)
 
 			// Initialise with RESTConfig which is used to discover the User Agent.
 			builder.InitWithRESTConfig()
@@ -1174,8 +1716,11 @@ func Test_ensureSecretData(t *testing.T) {
 			assert.NoError(t, err)
 
 			var actionCalled bool
-			w.secretsUpdateData = func(_ context.Context, _ *cmapi.Certificate, _ internal.SecretData) error {
+			w.secretsUpdateData = func(_ context.Context, _ *cmapi.Certificate, data internal.SecretData) error {
 				actionCalled = true
+				if test.expectedSecretData != nil {
+					assert.Equal(t, *test.expectedSecretData, data)
+				}
 				return nil
 			}
 			w.postIssuancePolicyChain = policies.NewSecretPostIssuancePolicyChain(test.enableOwnerRef, fieldManager)
@@ -1193,6 +1738,11 @@ func Test_ensureSecretData(t *testing.T) {
 			if err := builder.AllActionsExecuted(); err != nil {
 				builder.T.Error(err)
 			}
+			if test.expectedEvents != nil {
+				if err := builder.AllEventsCalled(); err != nil {
+					builder.T.Error(err)
+				}
+			}
 
 			assert.Equal(t, test.expectedAction, actionCalled, "unexpected Secret reconcile called")
 		})
@@ -23,6 +23,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 
+	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
 	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/issuing/internal"
@@ -68,9 +69,10 @@ func (c *controller) ensureTemporaryCertificate(ctx context.Context, crt *cmapi.
 		return false, err
 	}
 	secretData := internal.SecretData{
-		Certificate:     certData,
-		PrivateKey:      pkData,
-		CertificateName: crt.Name,
+		Certificate:         certData,
+		PrivateKey:          pkData,
+		CertificateName:     crt.Name,
+		PrivateKeyDefaulted: internalcertificates.PrivateKeyDefaulted(crt.Spec),
 	}
 	if err := c.secretsUpdateData(ctx, crt, secretData); err != nil {
 		return false, err
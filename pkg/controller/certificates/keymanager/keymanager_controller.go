@@ -20,6 +20,7 @@ import (
 	"context"
 	"crypto"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -28,12 +29,14 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 
 	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
+	"github.com/cert-manager/cert-manager/internal/controller/certificates/keyprovider"
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
@@ -54,6 +57,9 @@ const (
 	reasonDecodeFailed        = "DecodeFailed"
 	reasonCannotRegenerateKey = "CannotRegenerateKey"
 	reasonDeleted             = "Deleted"
+	reasonNoKeyProvider       = "NoKeyProvider"
+
+	cannotRegenerateKeyMessage = "User intervention required: existing private key in Secret %q does not match requirements on Certificate resource, mismatching fields: %v, but cert-manager cannot create new private key as the Certificate's .spec.privateKey.rotationPolicy is unset or set to Never. To allow cert-manager to create a new private key you can set .spec.privateKey.rotationPolicy to 'Always' (this will result in the private key being regenerated every time a cert is renewed) "
 )
 
 var (
@@ -71,6 +77,21 @@ type controller struct {
 	// fields created or edited by the cert-manager Kubernetes client during
 	// Apply API calls.
 	fieldManager string
+
+	// privateKeyDefaults are applied to Certificates which leave their
+	// private key unspecified.
+	privateKeyDefaults internalcertificates.PrivateKeyDefaults
+
+	// keyProvider creates private keys for Certificates which set
+	// spec.privateKey.external. It is nil if no signer plugin is configured.
+	keyProvider keyprovider.Provider
+
+	// noKeyProviderReported holds the keys of the Certificates which use an
+	// external private key and have already been reported as such whilst no
+	// signer plugin is configured, so that the event is only emitted once
+	// for each.
+	noKeyProviderLock     sync.Mutex
+	noKeyProviderReported sets.Set[string]
 }
 
 func NewController(
@@ -93,9 +114,7 @@ func NewController(
 	})
 	secretsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
 		// Trigger reconciles on changes to certificates named as spec.secretName
-		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
-			predicate.ExtractResourceName(predicate.CertificateSecretName),
-		),
+		WorkFunc: certificates.EnqueueCertificatesForSecret(log, queue, certificateInformer),
 	})
 
 	// build a list of InformerSynced functions that will be returned by the Register method.
@@ -112,6 +131,10 @@ func NewController(
 		coreClient:        ctx.Client,
 		recorder:          ctx.Recorder,
 		fieldManager:      ctx.FieldManager,
+
+		privateKeyDefaults:    ctx.CertificateOptions.PrivateKeyDefaults,
+		keyProvider:           ctx.CertificateOptions.KeyProvider,
+		noKeyProviderReported: sets.New[string](),
 	}, queue, mustSync
 }
 
@@ -140,12 +163,16 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	crt, err := c.certificateLister.Certificates(namespace).Get(name)
 	if apierrors.IsNotFound(err) {
 		log.V(logf.DebugLevel).Info("certificate not found for key", "error", err.Error())
+		c.forgetNoKeyProvider(key)
 		return nil
 	}
 	if err != nil {
 		return err
 	}
 
+	log = logf.WithCertificate(log, crt)
+	ctx = logf.NewContext(ctx, log)
+
 	// Discover all 'owned' secrets that have the `next-private-key` label
 	secrets, err := certificates.ListSecretsMatchingPredicates(c.secretLister.Secrets(crt.Namespace), isNextPrivateKeyLabelSelector, predicate.ResourceOwnedBy(crt))
 	if err != nil {
@@ -163,11 +190,30 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return c.setNextPrivateKeySecretName(ctx, crt, nil)
 	}
 
+	// The private key for a Certificate using an externally provided CSR is
+	// held outside of cert-manager, so there is no next private key to manage.
+	if crt.Spec.ExternalCSR != nil {
+		log.V(logf.DebugLevel).Info("Cleaning up Secret resources and unsetting nextPrivateKeySecretName as certificate uses an external CSR")
+		if err := c.deleteSecretResources(ctx, secrets); err != nil {
+			return err
+		}
+		return c.setNextPrivateKeySecretName(ctx, crt, nil)
+	}
+
+	// Private keys held by an external KMS must never be generated in-process.
+	external := internalcertificates.UsesExternalPrivateKey(crt.Spec)
+	if external && c.keyProvider == nil {
+		if c.reportNoKeyProvider(key) {
+			c.recorder.Event(crt, corev1.EventTypeWarning, reasonNoKeyProvider, "Certificate uses an external private key, but no signer plugin is configured using --external-private-key-signer-address")
+		}
+		return nil
+	}
+
 	// if there is no existing Secret resource, create a new one
 	if len(secrets) == 0 {
 		rotationPolicy := cmapi.RotationPolicyNever
-		if crt.Spec.PrivateKey != nil && crt.Spec.PrivateKey.RotationPolicy != "" {
-			rotationPolicy = crt.Spec.PrivateKey.RotationPolicy
+		if policy := c.privateKeyDefaults.Apply(crt).Spec.PrivateKey.RotationPolicy; policy != "" {
+			rotationPolicy = policy
 		}
 		switch rotationPolicy {
 		case cmapi.RotationPolicyNever:
@@ -201,18 +247,38 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return c.deleteSecretResources(ctx, secrets)
 	}
 
-	if secret.Data == nil || len(secret.Data[corev1.TLSPrivateKeyKey]) == 0 {
+	// A private key held by an external KMS is stored as a reference.
+	dataKey := corev1.TLSPrivateKeyKey
+	if external {
+		dataKey = cmapi.PrivateKeyReferenceSecretKey
+	}
+	if secret.Data == nil || len(secret.Data[dataKey]) == 0 {
 		log.V(logf.DebugLevel).Info("Deleting Secret resource as it contains no data")
 		return c.deleteSecretResources(ctx, secrets)
 	}
-	pkData := secret.Data[corev1.TLSPrivateKeyKey]
-	pk, err := pki.DecodePrivateKeyBytes(pkData)
-	if err != nil {
-		log.Error(err, "Deleting existing private key secret due to error decoding data")
-		return c.deleteSecretResources(ctx, secrets)
+	var pk crypto.Signer
+	if external {
+		pk, err = c.keyProvider.Signer(ctx, string(secret.Data[dataKey]))
+		if err != nil {
+			return err
+		}
+	} else {
+		pk, err = pki.DecodePrivateKeyBytes(secret.Data[dataKey])
+		if err != nil {
+			log.Error(err, "Deleting existing private key secret due to error decoding data")
+			return c.deleteSecretResources(ctx, secrets)
+		}
 	}
 
-	violations, err := pki.PrivateKeyMatchesSpec(pk, crt.Spec)
+	// The Certificate's Secret records whether its key was issued using the
+	// defaults, in which case a key re-used from it remains acceptable.
+	crtSecret, err := c.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
+	if apierrors.IsNotFound(err) {
+		crtSecret = nil
+	} else if err != nil {
+		return err
+	}
+	violations, err := c.privateKeyDefaults.PrivateKeyMatchesSpec(pk, crt.Spec, crtSecret)
 	if err != nil {
 		log.Error(err, "Internal error verifying if private key matches spec - please open an issue.")
 		return nil
@@ -236,6 +302,9 @@ func (c *controller) createNextPrivateKeyRotationPolicyNever(ctx context.Context
 	if err != nil {
 		return err
 	}
+	if internalcertificates.UsesExternalPrivateKey(crt.Spec) {
+		return c.reuseExternalPrivateKey(ctx, crt, s)
+	}
 	if s.Data == nil || len(s.Data[corev1.TLSPrivateKeyKey]) == 0 {
 		log.V(logf.DebugLevel).Info("Creating new nextPrivateKeySecretName Secret because existing Secret contains empty data and rotation policy is Never")
 		return c.createAndSetNextPrivateKey(ctx, crt)
@@ -246,13 +315,13 @@ func (c *controller) createNextPrivateKeyRotationPolicyNever(ctx context.Context
 		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonDecodeFailed, "Failed to decode private key stored in Secret %q - generating new key", crt.Spec.SecretName)
 		return c.createAndSetNextPrivateKey(ctx, crt)
 	}
-	violations, err := pki.PrivateKeyMatchesSpec(pk, crt.Spec)
+	violations, err := c.privateKeyDefaults.PrivateKeyMatchesSpec(pk, crt.Spec, s)
 	if err != nil {
 		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonDecodeFailed, "Failed to check if private key stored in Secret %q is up to date - generating new key", crt.Spec.SecretName)
 		return c.createAndSetNextPrivateKey(ctx, crt)
 	}
 	if len(violations) > 0 {
-		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonCannotRegenerateKey, "User intervention required: existing private key in Secret %q does not match requirements on Certificate resource, mismatching fields: %v, but cert-manager cannot create new private key as the Certificate's .spec.privateKey.rotationPolicy is unset or set to Never. To allow cert-manager to create a new private key you can set .spec.privateKey.rotationPolicy to 'Always' (this will result in the private key being regenerated every time a cert is renewed) ", crt.Spec.SecretName, violations)
+		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonCannotRegenerateKey, cannotRegenerateKeyMessage, crt.Spec.SecretName, violations)
 		return nil
 	}
 
@@ -266,8 +335,63 @@ func (c *controller) createNextPrivateKeyRotationPolicyNever(ctx context.Context
 	return c.setNextPrivateKeySecretName(ctx, crt, &nextPkSecret.Name)
 }
 
+// reuseExternalPrivateKey stores the reference to the private key held by an
+// external KMS, which is stored in the Certificate's existing Secret, as the
+// next private key.
+func (c *controller) reuseExternalPrivateKey(ctx context.Context, crt *cmapi.Certificate, s *corev1.Secret) error {
+	log := logf.FromContext(ctx)
+	ref := string(s.Data[cmapi.PrivateKeyReferenceSecretKey])
+	if ref == "" {
+		log.V(logf.DebugLevel).Info("Creating new nextPrivateKeySecretName Secret because existing Secret contains no private key reference and rotation policy is Never")
+		return c.createAndSetNextPrivateKey(ctx, crt)
+	}
+	signer, err := c.keyProvider.Signer(ctx, ref)
+	if err != nil {
+		return err
+	}
+	violations, err := c.privateKeyDefaults.PrivateKeyMatchesSpec(signer, crt.Spec, s)
+	if err != nil {
+		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonDecodeFailed, "Failed to check if private key referenced in Secret %q is up to date - creating new key", crt.Spec.SecretName)
+		return c.createAndSetNextPrivateKey(ctx, crt)
+	}
+	if len(violations) > 0 {
+		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonCannotRegenerateKey, cannotRegenerateKeyMessage, crt.Spec.SecretName, violations)
+		return nil
+	}
+
+	nextPkSecret, err := c.createNewPrivateKeyReferenceSecret(ctx, crt, ref)
+	if err != nil {
+		return err
+	}
+
+	c.recorder.Event(crt, corev1.EventTypeNormal, "Reused", fmt.Sprintf("Reusing private key referenced in existing Secret resource %q", s.Name))
+
+	return c.setNextPrivateKeySecretName(ctx, crt, &nextPkSecret.Name)
+}
+
 func (c *controller) createAndSetNextPrivateKey(ctx context.Context, crt *cmapi.Certificate) error {
-	pk, err := pki.GeneratePrivateKeyForCertificate(crt)
+	if internalcertificates.UsesExternalPrivateKey(crt.Spec) {
+		ref, err := c.keyProvider.CreateKey(ctx, c.privateKeyDefaults.Apply(crt))
+		if err != nil {
+			return err
+		}
+
+		s, err := c.createNewPrivateKeyReferenceSecret(ctx, crt, ref)
+		if err != nil {
+			// The key is not referenced anywhere else, so it would be left
+			// behind in the KMS, and a new one created on the next attempt.
+			if deleteErr := c.keyProvider.DeleteKey(ctx, ref); deleteErr != nil {
+				logf.FromContext(ctx).Error(deleteErr, "failed to delete private key from external KMS after failing to store its reference", "key_ref", ref)
+			}
+			return err
+		}
+
+		c.recorder.Event(crt, corev1.EventTypeNormal, "Generated", fmt.Sprintf("Created new private key in external KMS and stored its reference in temporary Secret resource %q", s.Name))
+
+		return c.setNextPrivateKeySecretName(ctx, crt, &s.Name)
+	}
+
+	pk, err := pki.GeneratePrivateKeyForCertificate(c.privateKeyDefaults.Apply(crt))
 	if err != nil {
 		return err
 	}
@@ -282,6 +406,28 @@ func (c *controller) createAndSetNextPrivateKey(ctx context.Context, crt *cmapi.
 	return c.setNextPrivateKeySecretName(ctx, crt, &s.Name)
 }
 
+// reportNoKeyProvider returns true if the Certificate has not yet been
+// reported as using an external private key whilst no signer plugin is
+// configured. The signer plugin is configured for the lifetime of the
+// controller, so there is nothing new to report on later syncs.
+func (c *controller) reportNoKeyProvider(key string) bool {
+	c.noKeyProviderLock.Lock()
+	defer c.noKeyProviderLock.Unlock()
+	if c.noKeyProviderReported.Has(key) {
+		return false
+	}
+	c.noKeyProviderReported.Insert(key)
+	return true
+}
+
+// forgetNoKeyProvider forgets that a Certificate which has been deleted was
+// reported.
+func (c *controller) forgetNoKeyProvider(key string) {
+	c.noKeyProviderLock.Lock()
+	defer c.noKeyProviderLock.Unlock()
+	c.noKeyProviderReported.Delete(key)
+}
+
 // deleteSecretResources will delete the given secret resources
 func (c *controller) deleteSecretResources(ctx context.Context, secrets []*corev1.Secret) error {
 	log := logf.FromContext(ctx)
@@ -325,6 +471,24 @@ func (c *controller) updateOrApplyStatus(ctx context.Context, crt *cmapi.Certifi
 }
 
 func (c *controller) createNewPrivateKeySecret(ctx context.Context, crt *cmapi.Certificate, pk crypto.Signer) (*corev1.Secret, error) {
+	pkData, err := pki.EncodePrivateKey(pk, cmapi.PKCS8)
+	if err != nil {
+		return nil, err
+	}
+	return c.createNextPrivateKeySecret(ctx, crt, map[string][]byte{
+		corev1.TLSPrivateKeyKey: pkData,
+	})
+}
+
+// createNewPrivateKeyReferenceSecret stores only the reference to a private
+// key held by an external KMS, never the key material.
+func (c *controller) createNewPrivateKeyReferenceSecret(ctx context.Context, crt *cmapi.Certificate, ref string) (*corev1.Secret, error) {
+	return c.createNextPrivateKeySecret(ctx, crt, map[string][]byte{
+		cmapi.PrivateKeyReferenceSecretKey: []byte(ref),
+	})
+}
+
+func (c *controller) createNextPrivateKeySecret(ctx context.Context, crt *cmapi.Certificate, data map[string][]byte) (*corev1.Secret, error) {
 	// if the 'nextPrivateKeySecretName' field is already set, use this as the
 	// name of the Secret resource.
 	name := ""
@@ -332,11 +496,6 @@ func (c *controller) createNewPrivateKeySecret(ctx context.Context, crt *cmapi.C
 		name = *crt.Status.NextPrivateKeySecretName
 	}
 
-	pkData, err := pki.EncodePrivateKey(pk, cmapi.PKCS8)
-	if err != nil {
-		return nil, err
-	}
-
 	s := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace:       crt.Namespace,
@@ -347,15 +506,13 @@ func (c *controller) createNewPrivateKeySecret(ctx context.Context, crt *cmapi.C
 				cmapi.PartOfCertManagerControllerLabelKey: "true",
 			},
 		},
-		Data: map[string][]byte{
-			corev1.TLSPrivateKeyKey: pkData,
-		},
+		Data: data,
 	}
 	if s.Name == "" {
 		// TODO: handle certificate resources that have especially long names
 		s.GenerateName = crt.Name + "-"
 	}
-	s, err = c.coreClient.CoreV1().Secrets(s.Namespace).Create(ctx, s, metav1.CreateOptions{})
+	s, err := c.coreClient.CoreV1().Secrets(s.Namespace).Create(ctx, s, metav1.CreateOptions{})
 	if err != nil {
 		return nil, err
 	}
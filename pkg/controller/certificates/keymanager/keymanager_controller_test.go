@@ -18,11 +18,13 @@ package keymanager
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"testing"
 
 	"github.com/kr/pretty"
+	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -30,6 +32,9 @@ import (
 	coretesting "k8s.io/client-go/testing"
 	"k8s.io/utils/ptr"
 
+	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
+	"github.com/cert-manager/cert-manager/internal/controller/certificates/keyprovider"
+	"github.com/cert-manager/cert-manager/internal/controller/certificates/keyprovider/fake"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
@@ -77,6 +82,40 @@ func relaxedSecretMatcher(l coretesting.Action, r coretesting.Action) error {
 }
 
 func TestProcessItem(t *testing.T) {
+	externalKeyRSA, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kmsWithKey := func() *fake.KMS {
+		kms := fake.NewKMS()
+		kms.AddKey("projects/test/keys/existing", externalKeyRSA)
+		return kms
+	}
+	externalCertificate := func(rotationPolicy cmapi.PrivateKeyRotationPolicy) *cmapi.Certificate {
+		return &cmapi.Certificate{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test", UID: types.UID("test")},
+			Spec: cmapi.CertificateSpec{
+				SecretName: "output",
+				PrivateKey: &cmapi.CertificatePrivateKey{
+					RotationPolicy: rotationPolicy,
+					External:       &cmapi.CertificateExternalPrivateKey{KeyRing: "projects/test"},
+				},
+			},
+			Status: cmapi.CertificateStatus{
+				Conditions: []cmapi.CertificateCondition{
+					{
+						Type:   cmapi.CertificateConditionIssuing,
+						Status: cmmeta.ConditionTrue,
+					},
+				},
+			},
+		}
+	}
+	withNextPrivateKeySecretName := func(crt *cmapi.Certificate, name string) *cmapi.Certificate {
+		crt.Status.NextPrivateKeySecretName = ptr.To(name)
+		return crt
+	}
+
 	ownedSecretWithName := func(namespace, name, owner string, data map[string][]byte) *corev1.Secret {
 		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
 			Namespace: namespace,
@@ -109,6 +148,24 @@ func TestProcessItem(t *testing.T) {
 		// Request, if set, will exist in the apiserver before the test is run.
 		requests []*cmapi.CertificateRequest
 
+		// privateKeyDefaults are the controller's private key defaults.
+		privateKeyDefaults internalcertificates.PrivateKeyDefaults
+
+		// kms, if set, is the key management service used for external
+		// private keys.
+		kms *fake.KMS
+
+		// secretCreateErr, if set, is returned when creating a Secret.
+		secretCreateErr error
+
+		// syncs is the number of times the Certificate is synced, if more
+		// than once.
+		syncs int
+
+		// expectedKMSKeys, if set, are the references of the keys expected
+		// to be held by the KMS once the Certificate is synced.
+		expectedKMSKeys []string
+
 		expectedActions []testpkg.Action
 
 		expectedEvents []string
@@ -445,6 +502,60 @@ func TestProcessItem(t *testing.T) {
 				)),
 			},
 		},
+		"if the Certificate uses an external CSR, delete owned secrets and unset nextPrivateKeySecretName": {
+			certificate: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test", UID: types.UID("test")},
+				Spec: cmapi.CertificateSpec{
+					ExternalCSR: &cmapi.CertificateExternalCSR{
+						SecretRef: cmmeta.SecretKeySelector{
+							LocalObjectReference: cmmeta.LocalObjectReference{Name: "csr"},
+						},
+					},
+				},
+				Status: cmapi.CertificateStatus{
+					NextPrivateKeySecretName: ptr.To("fixed-name"),
+					Conditions: []cmapi.CertificateCondition{
+						{
+							Type:   cmapi.CertificateConditionIssuing,
+							Status: cmmeta.ConditionTrue,
+						},
+					},
+				},
+			},
+			secrets: []runtime.Object{
+				ownedSecretWithName("testns", "fixed-name", "test", map[string][]byte{"tls.key": mustGenerateRSA(t, 2048)}),
+			},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewDeleteAction(
+					corev1.SchemeGroupVersion.WithResource("secrets"),
+					"testns",
+					"fixed-name",
+				)),
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					cmapi.SchemeGroupVersion.WithResource("certificates"),
+					"status",
+					"testns",
+					&cmapi.Certificate{
+						ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test", UID: types.UID("test")},
+						Spec: cmapi.CertificateSpec{
+							ExternalCSR: &cmapi.CertificateExternalCSR{
+								SecretRef: cmmeta.SecretKeySelector{
+									LocalObjectReference: cmmeta.LocalObjectReference{Name: "csr"},
+								},
+							},
+						},
+						Status: cmapi.CertificateStatus{
+							Conditions: []cmapi.CertificateCondition{
+								{
+									Type:   cmapi.CertificateConditionIssuing,
+									Status: cmmeta.ConditionTrue,
+								},
+							},
+						},
+					},
+				)),
+			},
+		},
 		"if an owned secret exists and contains data valid for the spec, do nothing'": {
 			certificate: &cmapi.Certificate{
 				ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test", UID: types.UID("test")},
@@ -462,6 +573,187 @@ func TestProcessItem(t *testing.T) {
 				ownedSecretWithName("testns", "fixed-name", "test", map[string][]byte{"tls.key": mustGenerateRSA(t, 2048)}),
 			},
 		},
+		"if an owned secret exists and contains data valid for the spec with the private key defaults applied, do nothing": {
+			certificate: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test", UID: types.UID("test")},
+				Spec:       cmapi.CertificateSpec{SecretName: "output"},
+				Status: cmapi.CertificateStatus{
+					NextPrivateKeySecretName: ptr.To("fixed-name"),
+					Conditions: []cmapi.CertificateCondition{
+						{
+							Type:   cmapi.CertificateConditionIssuing,
+							Status: cmmeta.ConditionTrue,
+						},
+					},
+				},
+			},
+			secrets: []runtime.Object{
+				ownedSecretWithName("testns", "fixed-name", "test", map[string][]byte{"tls.key": mustGenerateECDSA(t, pki.ECCurve384)}),
+			},
+			privateKeyDefaults: internalcertificates.PrivateKeyDefaults{Algorithm: cmapi.ECDSAKeyAlgorithm, Size: 384},
+		},
+		"if an owned secret exists but does not match the private key defaults, delete it": {
+			certificate: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test", UID: types.UID("test")},
+				Spec:       cmapi.CertificateSpec{SecretName: "output"},
+				Status: cmapi.CertificateStatus{
+					NextPrivateKeySecretName: ptr.To("fixed-name"),
+					Conditions: []cmapi.CertificateCondition{
+						{
+							Type:   cmapi.CertificateConditionIssuing,
+							Status: cmmeta.ConditionTrue,
+						},
+					},
+				},
+			},
+			secrets: []runtime.Object{
+				ownedSecretWithName("testns", "fixed-name", "test", map[string][]byte{"tls.key": mustGenerateECDSA(t, pki.ECCurve256)}),
+			},
+			privateKeyDefaults: internalcertificates.PrivateKeyDefaults{Algorithm: cmapi.ECDSAKeyAlgorithm, Size: 384},
+			expectedEvents:     []string{"Normal Deleted Regenerating private key due to change in fields: [spec.privateKey.size]"},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewDeleteAction(
+					corev1.SchemeGroupVersion.WithResource("secrets"),
+					"testns",
+					"fixed-name",
+				)),
+			},
+		},
+		"if an owned secret contains a key re-used from a Secret issued under previous private key defaults, do nothing": {
+			certificate: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test", UID: types.UID("test")},
+				Spec:       cmapi.CertificateSpec{SecretName: "output"},
+				Status: cmapi.CertificateStatus{
+					NextPrivateKeySecretName: ptr.To("fixed-name"),
+					Conditions: []cmapi.CertificateCondition{
+						{
+							Type:   cmapi.CertificateConditionIssuing,
+							Status: cmmeta.ConditionTrue,
+						},
+					},
+				},
+			},
+			secrets: []runtime.Object{
+				ownedSecretWithName("testns", "fixed-name", "test", map[string][]byte{"tls.key": mustGenerateECDSA(t, pki.ECCurve256)}),
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   "testns",
+						Name:        "output",
+						Annotations: map[string]string{cmapi.PrivateKeyDefaultedAnnotationKey: "true"},
+					},
+				},
+			},
+			privateKeyDefaults: internalcertificates.PrivateKeyDefaults{Algorithm: cmapi.RSAKeyAlgorithm, Size: 4096},
+		},
+		"never generate a private key in-process for an external private key if no signer plugin is configured, reporting it once": {
+			certificate:    externalCertificate(cmapi.RotationPolicyAlways),
+			syncs:          3,
+			expectedEvents: []string{"Warning NoKeyProvider Certificate uses an external private key, but no signer plugin is configured using --external-private-key-signer-address"},
+		},
+		"delete the key created in the KMS if its reference cannot be stored": {
+			certificate:     externalCertificate(cmapi.RotationPolicyAlways),
+			kms:             kmsWithKey(),
+			secretCreateErr: errors.New("simulated error"),
+			expectedKMSKeys: []string{"projects/test/keys/existing"},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewCreateAction(
+					corev1.SchemeGroupVersion.WithResource("secrets"),
+					"testns",
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace:       "testns",
+							GenerateName:    "test-",
+							Labels:          map[string]string{cmapi.IsNextPrivateKeySecretLabelKey: "true", cmapi.PartOfCertManagerControllerLabelKey: "true"},
+							OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(externalCertificate(cmapi.RotationPolicyAlways), certificateGvk)},
+						},
+						Data: map[string][]byte{cmapi.PrivateKeyReferenceSecretKey: []byte("projects/test/keys/1")},
+					},
+				)),
+			},
+			err: "simulated error",
+		},
+		"create a key in the KMS and store only its reference for an external private key": {
+			certificate:    externalCertificate(cmapi.RotationPolicyAlways),
+			kms:            fake.NewKMS(),
+			expectedEvents: []string{`Normal Generated Created new private key in external KMS and stored its reference in temporary Secret resource "test-notrandom"`},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					cmapi.SchemeGroupVersion.WithResource("certificates"),
+					"status",
+					"testns",
+					withNextPrivateKeySecretName(externalCertificate(cmapi.RotationPolicyAlways), "test-notrandom"),
+				)),
+				testpkg.NewAction(coretesting.NewCreateAction(
+					corev1.SchemeGroupVersion.WithResource("secrets"),
+					"testns",
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace:       "testns",
+							GenerateName:    "test-",
+							Labels:          map[string]string{cmapi.IsNextPrivateKeySecretLabelKey: "true", cmapi.PartOfCertManagerControllerLabelKey: "true"},
+							OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(externalCertificate(cmapi.RotationPolicyAlways), certificateGvk)},
+						},
+						Data: map[string][]byte{cmapi.PrivateKeyReferenceSecretKey: []byte("projects/test/keys/1")},
+					},
+				)),
+			},
+		},
+		"reuse the key referenced in the Certificate's Secret for an external private key with rotation policy Never": {
+			certificate: externalCertificate(cmapi.RotationPolicyNever),
+			kms:         kmsWithKey(),
+			secrets: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "output"},
+					Data: map[string][]byte{
+						corev1.TLSPrivateKeyKey:            {},
+						cmapi.PrivateKeyReferenceSecretKey: []byte("projects/test/keys/existing"),
+					},
+				},
+			},
+			expectedEvents: []string{`Normal Reused Reusing private key referenced in existing Secret resource "output"`},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					cmapi.SchemeGroupVersion.WithResource("certificates"),
+					"status",
+					"testns",
+					withNextPrivateKeySecretName(externalCertificate(cmapi.RotationPolicyNever), "test-notrandom"),
+				)),
+				testpkg.NewAction(coretesting.NewCreateAction(
+					corev1.SchemeGroupVersion.WithResource("secrets"),
+					"testns",
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace:       "testns",
+							GenerateName:    "test-",
+							Labels:          map[string]string{cmapi.IsNextPrivateKeySecretLabelKey: "true", cmapi.PartOfCertManagerControllerLabelKey: "true"},
+							OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(externalCertificate(cmapi.RotationPolicyNever), certificateGvk)},
+						},
+						Data: map[string][]byte{cmapi.PrivateKeyReferenceSecretKey: []byte("projects/test/keys/existing")},
+					},
+				)),
+			},
+		},
+		"if an owned secret references an external private key matching the spec, do nothing": {
+			certificate: withNextPrivateKeySecretName(externalCertificate(cmapi.RotationPolicyAlways), "fixed-name"),
+			kms:         kmsWithKey(),
+			secrets: []runtime.Object{
+				ownedSecretWithName("testns", "fixed-name", "test", map[string][]byte{cmapi.PrivateKeyReferenceSecretKey: []byte("projects/test/keys/existing")}),
+			},
+		},
+		"if an owned secret holds private key material for an external private key, delete it": {
+			certificate: withNextPrivateKeySecretName(externalCertificate(cmapi.RotationPolicyAlways), "fixed-name"),
+			kms:         kmsWithKey(),
+			secrets: []runtime.Object{
+				ownedSecretWithName("testns", "fixed-name", "test", map[string][]byte{"tls.key": mustGenerateRSA(t, 2048)}),
+			},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewDeleteAction(
+					corev1.SchemeGroupVersion.WithResource("secrets"),
+					"testns",
+					"fixed-name",
+				)),
+			},
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -482,6 +774,15 @@ func TestProcessItem(t *testing.T) {
 				builder.CertManagerObjects = append(builder.CertManagerObjects, req)
 			}
 			builder.Init()
+			builder.Context.CertificateOptions.PrivateKeyDefaults = test.privateKeyDefaults
+			if test.kms != nil {
+				builder.Context.CertificateOptions.KeyProvider = keyprovider.NewProvider(test.kms)
+			}
+			if test.secretCreateErr != nil {
+				builder.FakeKubeClient().PrependReactor("create", "secrets", func(coretesting.Action) (bool, runtime.Object, error) {
+					return true, nil, test.secretCreateErr
+				})
+			}
 
 			// Register informers used by the controller using the registration wrapper
 			w := &controllerWrapper{}
@@ -502,6 +803,11 @@ func TestProcessItem(t *testing.T) {
 			}
 
 			// Call ProcessItem
+			for range test.syncs - 1 {
+				if err := w.controller.ProcessItem(context.Background(), key); err != nil {
+					t.Fatal(err)
+				}
+			}
 			err = w.controller.ProcessItem(context.Background(), key)
 			switch {
 			case err != nil:
@@ -520,6 +826,9 @@ func TestProcessItem(t *testing.T) {
 			if err := builder.AllActionsExecuted(); err != nil {
 				builder.T.Error(err)
 			}
+			if test.expectedKMSKeys != nil {
+				assert.ElementsMatch(t, test.expectedKMSKeys, test.kms.Refs())
+			}
 		})
 	}
 }
@@ -0,0 +1,90 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"time"
+
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+)
+
+// expiringSoonFraction is the fraction of its lifetime which remains of a
+// certificate which is expiring soon. Certificates are renewed once a third
+// of their lifetime remains by default, so only certificates whose renewal
+// has been delayed, for example by an outage, are expiring soon.
+const expiringSoonFraction = 10
+
+// IssuancePriority returns a PriorityFunc for the keys of Certificates which
+// prioritizes them by the time to expiry of the certificate stored in their
+// Secret, as recorded in the Certificate's status, so that certificates which
+// are about to expire are issued before others during a backlog:
+//   - Certificates whose certificate has expired, or which have less than a
+//     tenth of their lifetime remaining, have a high priority.
+//   - Certificates whose Secret does not exist, or whose certificate is not
+//     known, such as new Certificates, have a medium priority.
+//   - All other Certificates have a low priority.
+func IssuancePriority(certificateLister cmlisters.CertificateLister, secretLister internalinformers.SecretLister, clock clock.PassiveClock) controllerpkg.PriorityFunc {
+	return func(item interface{}) controllerpkg.Priority {
+		key, ok := item.(string)
+		if !ok {
+			return controllerpkg.PriorityMedium
+		}
+		namespace, name, err := cache.SplitMetaNamespaceKey(key)
+		if err != nil {
+			return controllerpkg.PriorityMedium
+		}
+		crt, err := certificateLister.Certificates(namespace).Get(name)
+		if err != nil {
+			return controllerpkg.PriorityMedium
+		}
+		notAfter := crt.Status.NotAfter
+		if notAfter == nil {
+			return controllerpkg.PriorityMedium
+		}
+		if _, err := secretLister.Secrets(namespace).Get(crt.Spec.SecretName); err != nil {
+			return controllerpkg.PriorityMedium
+		}
+		remaining := notAfter.Sub(clock.Now())
+		if remaining <= 0 {
+			return controllerpkg.PriorityHigh
+		}
+		if notBefore := crt.Status.NotBefore; notBefore != nil {
+			if lifetime := notAfter.Sub(notBefore.Time); remaining <= lifetime/expiringSoonFraction {
+				return controllerpkg.PriorityHigh
+			}
+		}
+		return controllerpkg.PriorityLow
+	}
+}
+
+// NewIssuanceQueue returns the workqueue of a controller which issues
+// Certificates, in which Certificates are prioritized by IssuancePriority.
+// Low priority Certificates are delayed by at most twice the default aging
+// period compared to a FIFO queue.
+func NewIssuanceQueue(name string, ctx *controllerpkg.Context, certificateLister cmlisters.CertificateLister, secretLister internalinformers.SecretLister) workqueue.RateLimitingInterface {
+	return controllerpkg.NewPriorityRateLimitingQueue(name,
+		workqueue.NewItemExponentialFailureRateLimiter(time.Second*1, time.Second*30),
+		IssuancePriority(certificateLister, secretLister, ctx.Clock),
+		controllerpkg.PriorityQueueOptions{Metrics: ctx.Metrics},
+	)
+}
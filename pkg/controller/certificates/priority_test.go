@@ -0,0 +1,118 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestIssuancePriority(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Now())
+	now := clock.Now()
+	issued := func(notBefore, notAfter time.Time) gen.CertificateModifier {
+		return func(crt *cmapi.Certificate) {
+			gen.SetCertificateNotBefore(metav1.NewTime(notBefore))(crt)
+			gen.SetCertificateNotAfter(metav1.NewTime(notAfter))(crt)
+		}
+	}
+
+	tests := map[string]struct {
+		certificate *cmapi.Certificate
+		secret      bool
+		item        interface{}
+
+		expected controllerpkg.Priority
+	}{
+		"a new Certificate without a Secret has a medium priority": {
+			certificate: gen.Certificate("crt"),
+			expected:    controllerpkg.PriorityMedium,
+		},
+		"a Certificate whose Secret was deleted has a medium priority": {
+			certificate: gen.Certificate("crt", issued(now.Add(-time.Hour), now.Add(time.Hour))),
+			expected:    controllerpkg.PriorityMedium,
+		},
+		"a Certificate whose certificate is not known has a medium priority": {
+			certificate: gen.Certificate("crt"),
+			secret:      true,
+			expected:    controllerpkg.PriorityMedium,
+		},
+		"a Certificate whose certificate expired has a high priority": {
+			certificate: gen.Certificate("crt", issued(now.Add(-90*24*time.Hour), now.Add(-time.Minute))),
+			secret:      true,
+			expected:    controllerpkg.PriorityHigh,
+		},
+		"a Certificate with less than a tenth of its lifetime remaining has a high priority": {
+			certificate: gen.Certificate("crt", issued(now.Add(-82*24*time.Hour), now.Add(8*24*time.Hour))),
+			secret:      true,
+			expected:    controllerpkg.PriorityHigh,
+		},
+		"a short-lived Certificate with less than a tenth of its lifetime remaining has a high priority": {
+			certificate: gen.Certificate("crt", issued(now.Add(-55*time.Minute), now.Add(5*time.Minute))),
+			secret:      true,
+			expected:    controllerpkg.PriorityHigh,
+		},
+		"a Certificate due for renewal has a low priority": {
+			certificate: gen.Certificate("crt", issued(now.Add(-60*24*time.Hour), now.Add(30*24*time.Hour))),
+			secret:      true,
+			expected:    controllerpkg.PriorityLow,
+		},
+		"a Certificate whose lifetime is not known has a low priority until it expires": {
+			certificate: gen.Certificate("crt", gen.SetCertificateNotAfter(metav1.NewTime(now.Add(time.Minute)))),
+			secret:      true,
+			expected:    controllerpkg.PriorityLow,
+		},
+		"a Certificate which does not exist has a medium priority": {
+			item:     "ns/does-not-exist",
+			expected: controllerpkg.PriorityMedium,
+		},
+		"an invalid key has a medium priority": {
+			item:     "a/b/c",
+			expected: controllerpkg.PriorityMedium,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			certificates := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			secrets := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			item := test.item
+			if test.certificate != nil {
+				crt := gen.CertificateFrom(test.certificate, gen.SetCertificateNamespace("ns"), gen.SetCertificateSecretName("crt-tls"))
+				require.NoError(t, certificates.Add(crt))
+				item = "ns/crt"
+			}
+			if test.secret {
+				require.NoError(t, secrets.Add(gen.Secret("crt-tls", gen.SetSecretNamespace("ns"))))
+			}
+
+			prioritize := IssuancePriority(cmlisters.NewCertificateLister(certificates), corelisters.NewSecretLister(secrets), clock)
+			assert.Equal(t, test.expected, prioritize(item))
+		})
+	}
+}
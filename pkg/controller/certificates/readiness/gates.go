@@ -0,0 +1,247 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package readiness
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cert-manager/cert-manager/internal/tlsclient"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+const (
+	// ReadinessGateRejectedReason is the 'Ready' reason of a Certificate whose
+	// certificate was rejected by one of its readiness gates.
+	ReadinessGateRejectedReason = "ReadinessGateRejected"
+	// ReadinessGateTimeoutReason is the 'Ready' reason of a Certificate when
+	// one of its readiness gates did not respond in time.
+	ReadinessGateTimeoutReason = "ReadinessGateTimeout"
+	// ReadinessGateFailedReason is the 'Ready' reason of a Certificate when
+	// one of its readiness gates could not be called, or returned an invalid
+	// response.
+	ReadinessGateFailedReason = "ReadinessGateFailed"
+
+	// defaultReadinessGateTimeout is the timeout of the requests to readiness
+	// gates which do not set one.
+	defaultReadinessGateTimeout = 10 * time.Second
+	// maxReadinessGateTimeout caps the timeout of the requests to readiness
+	// gates, which are called while the Certificate is being processed.
+	maxReadinessGateTimeout = 30 * time.Second
+
+	// readinessGateRetryInterval is how long a readiness gate which did not
+	// accept a certificate is not called again for the same certificate.
+	readinessGateRetryInterval = 30 * time.Second
+
+	// maxReadinessGateMessageLength is the maximum length of the messages of
+	// readiness gates which are copied to the Ready condition.
+	maxReadinessGateMessageLength = 256
+
+	// maxReadinessGateResponseSize is the maximum size of the responses read
+	// from readiness gates.
+	maxReadinessGateResponseSize = 1 << 20
+)
+
+// readinessGateRequest is the body of the requests sent to readiness gates.
+type readinessGateRequest struct {
+	Namespace   string `json:"namespace"`
+	Name        string `json:"name"`
+	Certificate string `json:"certificate"`
+}
+
+// readinessGateResponse is the body of the responses of readiness gates.
+type readinessGateResponse struct {
+	Allowed *bool  `json:"allowed"`
+	Message string `json:"message,omitempty"`
+}
+
+// readinessGateResult is the outcome of calling a readiness gate. The reason
+// is empty if the gate accepted the certificate.
+type readinessGateResult struct {
+	Reason  string
+	Message string
+}
+
+// readinessGateCheckerFunc calls a readiness gate of the Certificate's issuer
+// with its PEM encoded leaf certificate.
+type readinessGateCheckerFunc func(ctx context.Context, crt *cmapi.Certificate, gate cmapi.CertificateReadinessGate, leafPEM []byte) readinessGateResult
+
+// checkReadinessGate sends the PEM encoded leaf certificate of the
+// Certificate to the readiness gate, and returns its verdict. Timeouts and
+// failures to call the gate are reported with different reasons from
+// negative verdicts.
+func checkReadinessGate(ctx context.Context, crt *cmapi.Certificate, gate cmapi.CertificateReadinessGate, leafPEM []byte) readinessGateResult {
+	timeout := defaultReadinessGateTimeout
+	if gate.Timeout != nil {
+		timeout = gate.Timeout.Duration
+	}
+	if timeout > maxReadinessGateTimeout {
+		timeout = maxReadinessGateTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	failed := func(err error) readinessGateResult {
+		if errors.Is(err, context.DeadlineExceeded) || isTimeout(err) {
+			return readinessGateResult{
+				Reason:  ReadinessGateTimeoutReason,
+				Message: fmt.Sprintf("Readiness gate %q did not respond within %s", gate.Name, timeout),
+			}
+		}
+		return readinessGateResult{
+			Reason:  ReadinessGateFailedReason,
+			Message: fmt.Sprintf("Failed to call readiness gate %q: %v", gate.Name, err),
+		}
+	}
+
+	tlsConfig, err := tlsclient.Config(nil, gate.CABundle)
+	if err != nil {
+		return failed(fmt.Errorf("invalid CA bundle: %w", err))
+	}
+	client := &http.Client{
+		Transport: &http.Transport{
+			Proxy:             http.ProxyFromEnvironment,
+			TLSClientConfig:   tlsConfig,
+			DisableKeepAlives: true,
+		},
+	}
+
+	body, err := json.Marshal(readinessGateRequest{
+		Namespace:   crt.Namespace,
+		Name:        crt.Name,
+		Certificate: string(leafPEM),
+	})
+	if err != nil {
+		return failed(err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, gate.URL, bytes.NewReader(body))
+	if err != nil {
+		return failed(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return failed(err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxReadinessGateResponseSize))
+	if err != nil {
+		return failed(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return failed(fmt.Errorf("unexpected status code %d", resp.StatusCode))
+	}
+
+	var verdict readinessGateResponse
+	if err := json.Unmarshal(respBody, &verdict); err != nil {
+		return failed(fmt.Errorf("invalid response: %w", err))
+	}
+	if verdict.Allowed == nil {
+		return failed(errors.New("invalid response: missing allowed field"))
+	}
+
+	if !*verdict.Allowed {
+		message := fmt.Sprintf("Readiness gate %q rejected the certificate", gate.Name)
+		if verdict.Message != "" {
+			if len(verdict.Message) > maxReadinessGateMessageLength {
+				verdict.Message = strings.ToValidUTF8(verdict.Message[:maxReadinessGateMessageLength], "") + "..."
+			}
+			message += ": " + verdict.Message
+		}
+		return readinessGateResult{Reason: ReadinessGateRejectedReason, Message: message}
+	}
+
+	return readinessGateResult{}
+}
+
+// readinessGateVerdict is the cached outcome of calling the readiness gates of
+// a Certificate's issuer with the certificate with the given serial number.
+type readinessGateVerdict struct {
+	serialNumber string
+	gates        string
+	result       readinessGateResult
+	// expires is when the gates are called again for a certificate which was
+	// not accepted. It is zero if the certificate was accepted.
+	expires time.Time
+}
+
+// readinessGateCache caches the verdicts of readiness gates for each
+// Certificate, so that the gates are only called once for each certificate
+// they accept, and at most once per readinessGateRetryInterval otherwise.
+type readinessGateCache struct {
+	lock     sync.Mutex
+	verdicts map[string]readinessGateVerdict
+}
+
+func newReadinessGateCache() *readinessGateCache {
+	return &readinessGateCache{verdicts: make(map[string]readinessGateVerdict)}
+}
+
+// get returns the cached verdict for the certificate with the given serial
+// number and gates, if any.
+func (c *readinessGateCache) get(key, serialNumber, gates string, now time.Time) (readinessGateResult, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	verdict, ok := c.verdicts[key]
+	if !ok || verdict.serialNumber != serialNumber || verdict.gates != gates {
+		return readinessGateResult{}, false
+	}
+	if !verdict.expires.IsZero() && !now.Before(verdict.expires) {
+		return readinessGateResult{}, false
+	}
+	return verdict.result, true
+}
+
+func (c *readinessGateCache) set(key string, verdict readinessGateVerdict) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.verdicts[key] = verdict
+}
+
+func (c *readinessGateCache) forget(key string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.verdicts, key)
+}
+
+// readinessGatesKey identifies the configuration of readiness gates, so that
+// cached verdicts are discarded when the gates of the issuer are changed.
+func readinessGatesKey(gates []cmapi.CertificateReadinessGate) string {
+	var b strings.Builder
+	for _, gate := range gates {
+		fmt.Fprintf(&b, "%s\x00%s\x00%x\x00", gate.Name, gate.URL, sha256.Sum256(gate.CABundle))
+	}
+	return b.String()
+}
+
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
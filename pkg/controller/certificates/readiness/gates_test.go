@@ -0,0 +1,151 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package readiness
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestCheckReadinessGate(t *testing.T) {
+	const leafPEM = "-----BEGIN CERTIFICATE-----\nleaf\n-----END CERTIFICATE-----\n"
+	crt := gen.Certificate("test", gen.SetCertificateNamespace("testns"))
+
+	tests := map[string]struct {
+		handler http.HandlerFunc
+		timeout time.Duration
+
+		expected readinessGateResult
+	}{
+		"a gate accepting the certificate": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"allowed": true}`))
+			},
+			expected: readinessGateResult{},
+		},
+		"a gate rejecting the certificate": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"allowed": false, "message": "certificate has been revoked"}`))
+			},
+			expected: readinessGateResult{
+				Reason:  ReadinessGateRejectedReason,
+				Message: `Readiness gate "ocsp" rejected the certificate: certificate has been revoked`,
+			},
+		},
+		"a gate rejecting the certificate without a message": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"allowed": false}`))
+			},
+			expected: readinessGateResult{
+				Reason:  ReadinessGateRejectedReason,
+				Message: `Readiness gate "ocsp" rejected the certificate`,
+			},
+		},
+		"a gate rejecting the certificate with a long message": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"allowed": false, "message": "` + strings.Repeat("a", 300) + `"}`))
+			},
+			expected: readinessGateResult{
+				Reason:  ReadinessGateRejectedReason,
+				Message: `Readiness gate "ocsp" rejected the certificate: ` + strings.Repeat("a", 256) + "...",
+			},
+		},
+		"a gate which does not respond in time": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				<-r.Context().Done()
+			},
+			timeout: 100 * time.Millisecond,
+			expected: readinessGateResult{
+				Reason:  ReadinessGateTimeoutReason,
+				Message: `Readiness gate "ocsp" did not respond within 100ms`,
+			},
+		},
+		"a gate responding with an error status": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			},
+			expected: readinessGateResult{
+				Reason:  ReadinessGateFailedReason,
+				Message: `Failed to call readiness gate "ocsp": unexpected status code 503`,
+			},
+		},
+		"a gate responding without a verdict": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"message": "ok"}`))
+			},
+			expected: readinessGateResult{
+				Reason:  ReadinessGateFailedReason,
+				Message: `Failed to call readiness gate "ocsp": invalid response: missing allowed field`,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+				var req readinessGateRequest
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+				assert.Equal(t, readinessGateRequest{Namespace: "testns", Name: "test", Certificate: leafPEM}, req)
+
+				test.handler(w, r)
+			}))
+			defer server.Close()
+
+			gate := cmapi.CertificateReadinessGate{
+				Name:     "ocsp",
+				URL:      server.URL,
+				CABundle: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}),
+			}
+			if test.timeout > 0 {
+				gate.Timeout = &metav1.Duration{Duration: test.timeout}
+			}
+
+			result := checkReadinessGate(context.Background(), crt, gate, []byte(leafPEM))
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestCheckReadinessGateUntrustedEndpoint(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"allowed": true}`))
+	}))
+	defer server.Close()
+
+	result := checkReadinessGate(context.Background(), gen.Certificate("test"), cmapi.CertificateReadinessGate{
+		Name: "ocsp",
+		URL:  server.URL,
+	}, []byte("leaf"))
+	require.Equal(t, ReadinessGateFailedReason, result.Reason)
+	assert.Contains(t, result.Message, `Failed to call readiness gate "ocsp": `)
+}
@@ -18,6 +18,9 @@ package readiness
 
 import (
 	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -27,20 +30,26 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
 
 	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
 	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
 	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+	"github.com/cert-manager/cert-manager/pkg/scheduler"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	"github.com/cert-manager/cert-manager/pkg/util/predicate"
@@ -60,16 +69,33 @@ type controller struct {
 	certificateRequestLister cmlisters.CertificateRequestLister
 	secretLister             internalinformers.SecretLister
 	client                   cmclient.Interface
+	recorder                 record.EventRecorder
 	gatherer                 *policies.Gatherer
 	// policyEvaluator builds Ready condition of a Certificate based on policy evaluation
 	policyEvaluator policyEvaluatorFunc
 	// renewalTimeCalculator calculates renewal time of a certificate
 	renewalTimeCalculator pki.RenewalTimeFunc
+	// readinessGateChecker calls the readiness gates of a Certificate's issuer
+	readinessGateChecker readinessGateCheckerFunc
+	// readinessGateVerdicts caches the verdicts of readiness gates for the
+	// certificate stored in each Certificate's Secret
+	readinessGateVerdicts *readinessGateCache
+	// issuerHelper is used to read the readiness gates of the issuers
+	issuerHelper issuer.Helper
+
+	clock clock.Clock
+	// scheduledWorkQueue is used to re-sync Certificates once the certificate
+	// stored in their Secret becomes valid.
+	scheduledWorkQueue scheduler.ScheduledWorkQueue
 
 	// fieldManager is the string which will be used as the Field Manager on
 	// fields created or edited by the cert-manager Kubernetes client during
 	// Apply API calls.
 	fieldManager string
+
+	// metrics is used to report the expiry of the issuer chain stored in the
+	// Certificate's Secret.
+	metrics *metrics.Metrics
 }
 
 // readyConditionFunc is custom function type that builds certificate's Ready condition
@@ -90,6 +116,7 @@ func NewController(
 	certificateInformer := ctx.SharedInformerFactory.Certmanager().V1().Certificates()
 	certificateRequestInformer := ctx.SharedInformerFactory.Certmanager().V1().CertificateRequests()
 	secretsInformer := ctx.KubeSharedInformerFactory.Secrets()
+	issuerInformer := ctx.SharedInformerFactory.Certmanager().V1().Issuers()
 
 	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
 
@@ -100,8 +127,7 @@ func NewController(
 	// When a Secret resource changes, enqueue any Certificate resources that name it as spec.secretName.
 	secretsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
 		// Trigger reconciles on changes to the Secret named `spec.secretName`
-		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
-			predicate.ExtractResourceName(predicate.CertificateSecretName)),
+		WorkFunc: certificates.EnqueueCertificatesForSecret(log, queue, certificateInformer),
 	})
 
 	// build a list of InformerSynced functions that will be returned by the Register method.
@@ -110,6 +136,16 @@ func NewController(
 		certificateRequestInformer.Informer().HasSynced,
 		secretsInformer.Informer().HasSynced,
 		certificateInformer.Informer().HasSynced,
+		issuerInformer.Informer().HasSynced,
+	}
+
+	// If we are running in non-namespaced mode, we also obtain a lister for
+	// ClusterIssuers.
+	var clusterIssuerLister cmlisters.ClusterIssuerLister
+	if ctx.Namespace == "" {
+		clusterIssuerInformer := ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers()
+		clusterIssuerLister = clusterIssuerInformer.Lister()
+		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
 	}
 
 	return &controller{
@@ -118,13 +154,21 @@ func NewController(
 		certificateRequestLister: certificateRequestInformer.Lister(),
 		secretLister:             secretsInformer.Lister(),
 		client:                   ctx.CMClient,
+		recorder:                 ctx.Recorder,
 		gatherer: &policies.Gatherer{
 			CertificateRequestLister: certificateRequestInformer.Lister(),
 			SecretLister:             secretsInformer.Lister(),
+			Clock:                    ctx.Clock,
 		},
 		policyEvaluator:       policyEvaluator,
 		renewalTimeCalculator: renewalTimeCalculator,
+		readinessGateChecker:  checkReadinessGate,
+		readinessGateVerdicts: newReadinessGateCache(),
+		issuerHelper:          issuer.NewHelper(issuerInformer.Lister(), clusterIssuerLister),
 		fieldManager:          ctx.FieldManager,
+		metrics:               ctx.Metrics,
+		clock:                 ctx.Clock,
+		scheduledWorkQueue:    scheduler.NewScheduledWorkQueue(ctx.Clock, queue.Add),
 	}, queue, mustSync
 }
 
@@ -144,22 +188,56 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	crt, err := c.certificateLister.Certificates(namespace).Get(name)
 	if apierrors.IsNotFound(err) {
 		log.V(logf.DebugLevel).Info("certificate not found for key", "error", err.Error())
+		c.readinessGateVerdicts.forget(key)
 		return nil
 	}
 	if err != nil {
 		return err
 	}
 
+	log = logf.WithCertificate(log, crt)
+	ctx = logf.NewContext(ctx, log)
+
 	input, err := c.gatherer.DataForCertificate(ctx, crt)
 	if err != nil {
 		return err
 	}
 
 	condition := c.policyEvaluator(c.policyChain, input)
+
+	// A Certificate which is otherwise Ready is only marked as Ready once the
+	// readiness gates of its issuer have accepted the certificate.
+	var gateErr error
+	if condition.Status == cmmeta.ConditionTrue {
+		gates, err := c.readinessGates(crt)
+		if err != nil {
+			return err
+		}
+		if len(gates) > 0 {
+			condition, gateErr = c.evaluateReadinessGates(ctx, key, crt, gates, input.Secret, condition)
+		}
+	}
+
 	oldCrt := crt
+
+	// Name the conflicting field manager in an Event when the Secret is first
+	// found to have been modified by a third party.
+	if condition.Reason == policies.SecretModifiedExternally {
+		if oldCond := apiutil.GetCertificateCondition(oldCrt, cmapi.CertificateConditionReady); oldCond == nil ||
+			oldCond.Reason != condition.Reason || oldCond.Message != condition.Message {
+			c.recorder.Event(oldCrt, corev1.EventTypeWarning, condition.Reason, condition.Message)
+		}
+	}
+
 	crt = crt.DeepCopy()
 	apiutil.SetCertificateCondition(crt, crt.Generation, condition.Type, condition.Status, condition.Reason, condition.Message)
 
+	var issuerChainNotAfter time.Time
+	if input.Secret != nil {
+		issuerChainNotAfter, _ = policies.IssuerChainNotAfter(crt, input.Secret)
+	}
+	c.metrics.UpdateCertificateIssuerChainExpiry(crt, issuerChainNotAfter)
+
 	switch {
 	case input.Secret != nil && input.Secret.Data != nil:
 		x509cert, err := pki.DecodeX509CertificateBytes(input.Secret.Data[corev1.TLSCertKey])
@@ -174,13 +252,29 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		notBefore := metav1.NewTime(x509cert.NotBefore)
 		notAfter := metav1.NewTime(x509cert.NotAfter)
 		renewBeforeHint := crt.Spec.RenewBefore
-		renewalTime := c.renewalTimeCalculator(x509cert.NotBefore, x509cert.NotAfter, renewBeforeHint)
+		// The renewal time is brought forward if the issuer chain expires
+		// first and spec.renewBeforeChainExpiry is set.
+		renewalTime := c.renewalTimeCalculator(x509cert.NotBefore, policies.RenewalNotAfter(crt, input.Secret, x509cert), renewBeforeHint)
+
+		// A certificate which is not yet valid is not Ready, so re-check
+		// the Certificate once it becomes valid.
+		if untilValid := x509cert.NotBefore.Sub(c.clock.Now()); untilValid > 0 {
+			c.scheduledWorkQueue.Add(key, untilValid)
+		}
 
 		// update Certificate's Status
 		crt.Status.NotBefore = &notBefore
 		crt.Status.NotAfter = &notAfter
 		crt.Status.RenewalTime = renewalTime
 
+		// Repair the issued certificate's serial number, fingerprint and DNs
+		// if they have been lost, e.g. after restoring the Certificate from a
+		// backup without its status. Fields recorded for a different
+		// certificate are left for the issuing controller to manage.
+		if crt.Status.SerialNumber == "" || crt.Status.SerialNumber == pki.FormatSerialNumber(x509cert.SerialNumber) {
+			internalcertificates.SetIssuedCertificateStatus(crt, x509cert)
+		}
+
 	default:
 		// clear status fields if the secret does not have any data
 		crt.Status.NotAfter = nil
@@ -191,9 +285,96 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		log.V(logf.DebugLevel).Info("updating status fields", "notAfter",
 			crt.Status.NotAfter, "notBefore", crt.Status.NotBefore, "renewalTime",
 			crt.Status.RenewalTime)
-		return c.updateOrApplyStatus(ctx, crt)
+		if err := c.updateOrApplyStatus(ctx, crt); err != nil {
+			return err
+		}
 	}
-	return nil
+
+	// Returning an error re-queues the Certificate with backoff, so that the
+	// readiness gates are called again until they accept the certificate.
+	return gateErr
+}
+
+// readinessGates returns the readiness gates of the cert-manager Issuer or
+// ClusterIssuer referenced by the Certificate. Readiness gates can only be
+// configured on issuers, so that the endpoints called by the controller are
+// chosen by the administrators of the issuers rather than by anyone who can
+// create a Certificate.
+func (c *controller) readinessGates(crt *cmapi.Certificate) ([]cmapi.CertificateReadinessGate, error) {
+	if crt.Spec.IssuerRef.Group != "" && crt.Spec.IssuerRef.Group != certmanager.GroupName {
+		return nil, nil
+	}
+
+	genericIssuer, err := c.issuerHelper.GetGenericIssuer(crt.Spec.IssuerRef, crt.Namespace)
+	switch {
+	case apierrors.IsNotFound(err), errors.Is(err, issuer.ErrClusterIssuersDisabled):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+
+	return genericIssuer.GetSpec().ReadinessGates, nil
+}
+
+// evaluateReadinessGates calls the readiness gates in order with the
+// certificate stored in the Certificate's Secret. If a gate does not accept
+// the certificate, a not Ready condition explaining why is returned along with
+// an error. Otherwise the given Ready condition is returned.
+// Verdicts are cached for the serial number of the certificate: the gates are
+// not called again once they have accepted a certificate, and are called at
+// most once per readinessGateRetryInterval until they do.
+func (c *controller) evaluateReadinessGates(ctx context.Context, key string, crt *cmapi.Certificate, gates []cmapi.CertificateReadinessGate, secret *corev1.Secret, ready cmapi.CertificateCondition) (cmapi.CertificateCondition, error) {
+	log := logf.FromContext(ctx)
+
+	notReady := func(reason, message string) (cmapi.CertificateCondition, error) {
+		return cmapi.CertificateCondition{
+			Type:    cmapi.CertificateConditionReady,
+			Status:  cmmeta.ConditionFalse,
+			Reason:  reason,
+			Message: message,
+		}, errors.New(message)
+	}
+
+	var x509cert *x509.Certificate
+	if secret != nil {
+		x509cert, _ = pki.DecodeX509CertificateBytes(secret.Data[corev1.TLSCertKey])
+	}
+	if x509cert == nil {
+		return notReady(ReadinessGateFailedReason, "Failed to read the certificate for the readiness gates from the Secret")
+	}
+
+	serialNumber := pki.FormatSerialNumber(x509cert.SerialNumber)
+	gatesKey := readinessGatesKey(gates)
+	if result, ok := c.readinessGateVerdicts.get(key, serialNumber, gatesKey, c.clock.Now()); ok {
+		if result.Reason == "" {
+			return ready, nil
+		}
+		return notReady(result.Reason, result.Message)
+	}
+
+	leafPEM, err := pki.EncodeX509(x509cert)
+	if err != nil {
+		return notReady(ReadinessGateFailedReason, fmt.Sprintf("Failed to encode the certificate for the readiness gates: %v", err))
+	}
+
+	for _, gate := range gates {
+		result := c.readinessGateChecker(ctx, crt, gate, leafPEM)
+		if result.Reason == "" {
+			continue
+		}
+
+		log.V(logf.InfoLevel).Info("certificate was not accepted by a readiness gate", "gate", gate.Name, "reason", result.Reason, "message", result.Message)
+		c.readinessGateVerdicts.set(key, readinessGateVerdict{
+			serialNumber: serialNumber,
+			gates:        gatesKey,
+			result:       result,
+			expires:      c.clock.Now().Add(readinessGateRetryInterval),
+		})
+		return notReady(result.Reason, result.Message)
+	}
+
+	c.readinessGateVerdicts.set(key, readinessGateVerdict{serialNumber: serialNumber, gates: gatesKey})
+	return ready, nil
 }
 
 // updateOrApplyStatus will update the controller status. If the
@@ -208,10 +389,16 @@ func (c *controller) updateOrApplyStatus(ctx context.Context, crt *cmapi.Certifi
 		return internalcertificates.ApplyStatus(ctx, c.client, c.fieldManager, &cmapi.Certificate{
 			ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace, Name: crt.Name},
 			Status: cmapi.CertificateStatus{
-				NotAfter:    crt.Status.NotAfter,
-				NotBefore:   crt.Status.NotBefore,
-				RenewalTime: crt.Status.RenewalTime,
-				Conditions:  conditions,
+				NotAfter:               crt.Status.NotAfter,
+				NotBefore:              crt.Status.NotBefore,
+				RenewalTime:            crt.Status.RenewalTime,
+				SerialNumber:           crt.Status.SerialNumber,
+				SHA256Fingerprint:      crt.Status.SHA256Fingerprint,
+				ShortSHA256Fingerprint: crt.Status.ShortSHA256Fingerprint,
+				IssuerDN:               crt.Status.IssuerDN,
+				SubjectDN:              crt.Status.SubjectDN,
+				IssuerCAFingerprints:   crt.Status.IssuerCAFingerprints,
+				Conditions:             conditions,
 			},
 		})
 	} else {
@@ -249,9 +436,16 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 	// construct a new named logger to be reused throughout the controller
 	log := logf.FromContext(ctx.RootContext, ControllerName)
 
+	chain := policies.NewReadinessPolicyChain(ctx.Clock, ctx.CertificateOptions.PrivateKeyDefaults)
+	if ctx.Namespace != "" {
+		// Report Certificates referencing ClusterIssuers as not ready, since
+		// ClusterIssuers are disabled when scoped to a single namespace.
+		chain = append(policies.Chain{policies.ClusterIssuerUnsupported(ctx.Namespace)}, chain...)
+	}
+
 	ctrl, queue, mustSync := NewController(log,
 		ctx,
-		policies.NewReadinessPolicyChain(ctx.Clock),
+		chain,
 		pki.RenewalTime,
 		BuildReadyConditionFromChain,
 	)
@@ -18,15 +18,20 @@ package readiness
 
 import (
 	"context"
+	"fmt"
+	"reflect"
 	"testing"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	coretesting "k8s.io/client-go/testing"
 	fakeclock "k8s.io/utils/clock/testing"
 
+	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
 	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
@@ -249,11 +254,12 @@ func TestProcessItem(t *testing.T) {
 				builder.CertManagerObjects = append(builder.CertManagerObjects, test.cert)
 			}
 
+			var x509Bytes []byte
 			if test.secretShouldExist {
 				mods := make([]gen.SecretModifier, 0)
 				// If the test scenario needs a secret with a valid X509 cert.
 				if test.notBefore != nil && test.notAfter != nil {
-					x509Bytes := testcrypto.MustCreateCertWithNotBeforeAfter(t, privKey, cert, test.notBefore.Time, test.notAfter.Time)
+					x509Bytes = testcrypto.MustCreateCertWithNotBeforeAfter(t, privKey, cert, test.notBefore.Time, test.notAfter.Time)
 					mods = append(mods,
 						gen.SetSecretData(map[string][]byte{
 							"tls.crt": x509Bytes,
@@ -289,6 +295,13 @@ func TestProcessItem(t *testing.T) {
 				c.Status.NotAfter = test.notAfter
 				c.Status.NotBefore = test.notBefore
 				c.Status.RenewalTime = test.renewalTime
+				if x509Bytes != nil {
+					x509Cert, err := pki.DecodeX509CertificateBytes(x509Bytes)
+					if err != nil {
+						t.Fatal(err)
+					}
+					internalcertificates.SetIssuedCertificateStatus(c, x509Cert)
+				}
 
 				builder.ExpectedActions = append(builder.ExpectedActions,
 					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
@@ -323,6 +336,28 @@ func TestProcessItem(t *testing.T) {
 	}
 }
 
+// thirdPartyManagedFields are the managed fields of a Secret issued by
+// cert-manager whose certificate has since been edited with kubectl.
+var thirdPartyManagedFields = []metav1.ManagedFieldsEntry{
+	{
+		Manager:   "cert-manager-certificates-issuing",
+		Operation: metav1.ManagedFieldsOperationApply,
+		FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:metadata": {"f:annotations": {"f:cert-manager.io/certificate-name": {}}},
+			"f:data": {"f:tls.key": {}}}`)},
+	},
+	{
+		Manager:   "kubectl-edit",
+		Operation: metav1.ManagedFieldsOperationUpdate,
+		FieldsV1:  &metav1.FieldsV1{Raw: []byte(`{"f:data": {"f:tls.crt": {}}}`)},
+	},
+}
+
+func withSecretManagedFields(managedFields []metav1.ManagedFieldsEntry) gen.SecretModifier {
+	return func(secret *corev1.Secret) {
+		secret.ManagedFields = managedFields
+	}
+}
+
 // Test the evaluation of the ordered policy chain as a whole.
 func TestNewReadinessPolicyChain(t *testing.T) {
 	clock := &fakeclock.FakeClock{}
@@ -339,29 +374,29 @@ func TestNewReadinessPolicyChain(t *testing.T) {
 	}{
 		"Certificate not Ready if Secret is missing": {
 			cert:           gen.Certificate("test", gen.SetCertificateSecretName("something")),
-			reason:         policies.DoesNotExist,
+			reason:         policies.SecretMissing,
 			message:        "Issuing certificate as Secret does not exist",
 			violationFound: true,
 		},
 		"Certificate not Ready as Secret does not contain any data": {
 			cert:           gen.Certificate("test", gen.SetCertificateSecretName("something")),
 			secret:         gen.Secret("something"),
-			reason:         policies.MissingData,
+			reason:         policies.SecretEmpty,
 			message:        "Issuing certificate as Secret does not contain any data",
 			violationFound: true,
 		},
 		"Certificate not Ready as Secret is missing private key": {
 			cert:           gen.Certificate("test", gen.SetCertificateSecretName("something")),
 			secret:         gen.Secret("something", gen.SetSecretData(map[string][]byte{corev1.TLSCertKey: []byte("test")})),
-			reason:         policies.MissingData,
-			message:        "Issuing certificate as Secret does not contain a private key",
+			reason:         policies.SecretKeyMissing,
+			message:        `Issuing certificate as Secret does not contain a private key in "tls.key"`,
 			violationFound: true,
 		},
 		"Certificate not Ready as Secret is missing certificate": {
 			cert:           gen.Certificate("test", gen.SetCertificateSecretName("something")),
 			secret:         gen.Secret("something", gen.SetSecretData(map[string][]byte{corev1.TLSPrivateKeyKey: []byte("test")})),
-			reason:         policies.MissingData,
-			message:        "Issuing certificate as Secret does not contain a certificate",
+			reason:         policies.SecretCertMissing,
+			message:        `Issuing certificate as Secret does not contain a certificate in "tls.crt"`,
 			violationFound: true,
 		},
 		"Certificate not Ready as Secret contains corrupt private key and certificate data": {
@@ -371,8 +406,8 @@ func TestNewReadinessPolicyChain(t *testing.T) {
 					corev1.TLSPrivateKeyKey: []byte("test"),
 					corev1.TLSCertKey:       []byte("test"),
 				})),
-			reason:         policies.InvalidKeyPair,
-			message:        "Issuing certificate as Secret contains invalid private key data: error decoding private key PEM block",
+			reason:         policies.SecretDataInvalid,
+			message:        `Issuing certificate as Secret contains invalid private key data in "tls.key": error decoding private key PEM block`,
 			violationFound: true,
 		},
 		"Certificate not Ready as Secret contains corrupt certificate data": {
@@ -382,8 +417,21 @@ func TestNewReadinessPolicyChain(t *testing.T) {
 					corev1.TLSPrivateKeyKey: privKey,
 					corev1.TLSCertKey:       []byte("test"),
 				})),
-			reason:         policies.InvalidCertificate,
-			message:        "Issuing certificate as Secret contains an invalid certificate: error decoding certificate PEM block",
+			reason:         policies.SecretDataInvalid,
+			message:        `Issuing certificate as Secret contains an invalid certificate in "tls.crt": error decoding certificate PEM block`,
+			violationFound: true,
+		},
+		"Certificate not Ready as Secret data has been modified by a third party": {
+			cert: &cmapi.Certificate{Spec: cmapi.CertificateSpec{SecretName: "something"}},
+			secret: gen.Secret("something",
+				gen.SetSecretData(map[string][]byte{
+					corev1.TLSPrivateKeyKey: privKey,
+					corev1.TLSCertKey:       []byte("garbage"),
+				}),
+				withSecretManagedFields(thirdPartyManagedFields),
+			),
+			reason:         policies.SecretModifiedExternally,
+			message:        `Secret key "tls.crt" has been modified by field manager "kubectl-edit"`,
 			violationFound: true,
 		},
 		"Certificate not Ready as Secret contains a non-matching key-pair": {
@@ -473,6 +521,89 @@ func TestNewReadinessPolicyChain(t *testing.T) {
 			message:        "Certificate expired on Sun, 31 Dec 0000 23:00:00 UTC",
 			violationFound: true,
 		},
+		"Certificate is not Ready when the Secret contains an unrelated certificate after the leaf": {
+			cert: gen.Certificate("something",
+				gen.SetCertificateCommonName("new.example.com"),
+				gen.SetCertificateIssuer(cmmeta.ObjectReference{
+					Name:  "testissuer",
+					Kind:  "IssuerKind",
+					Group: "group.example.com",
+				})),
+			secret: gen.Secret("something",
+				gen.SetSecretAnnotations(map[string]string{
+					cmapi.IssuerNameAnnotationKey:  "testissuer",
+					cmapi.IssuerKindAnnotationKey:  "IssuerKind",
+					cmapi.IssuerGroupAnnotationKey: "group.example.com",
+				}),
+				gen.SetSecretData(
+					map[string][]byte{
+						corev1.TLSPrivateKeyKey: privKey,
+						corev1.TLSCertKey: append(
+							testcrypto.MustCreateCertWithNotBeforeAfter(t, privKey,
+								gen.Certificate("something", gen.SetCertificateCommonName("new.example.com")),
+								clock.Now(), clock.Now().Add(time.Hour*3),
+							),
+							testcrypto.MustCreateCertWithNotBeforeAfter(t, testcrypto.MustCreatePEMPrivateKey(t),
+								gen.Certificate("unrelated", gen.SetCertificateCommonName("unrelated.example.com")),
+								clock.Now(), clock.Now().Add(time.Hour*3),
+							)...,
+						),
+					},
+				)),
+			cr: gen.CertificateRequest("something",
+				gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+					Name:  "testissuer",
+					Kind:  "IssuerKind",
+					Group: "group.example.com",
+				}),
+				gen.SetCertificateRequestCSR(testcrypto.MustGenerateCSRImpl(t, privKey,
+					gen.Certificate("something",
+						gen.SetCertificateCommonName("new.example.com")))),
+			),
+			reason:         policies.InvalidCertificateChain,
+			message:        `Secret contains an invalid certificate chain: certificate 1 ("CN=unrelated.example.com") is not part of the chain`,
+			violationFound: true,
+		},
+		"Certificate not Ready as the issued certificate is not yet valid": {
+			cert: gen.Certificate("something",
+				gen.SetCertificateCommonName("new.example.com"),
+				gen.SetCertificateIssuer(cmmeta.ObjectReference{
+					Name:  "testissuer",
+					Kind:  "IssuerKind",
+					Group: "group.example.com",
+				})),
+			secret: gen.Secret("something",
+				gen.SetSecretAnnotations(
+					map[string]string{
+						cmapi.IssuerNameAnnotationKey:  "testissuer",
+						cmapi.IssuerKindAnnotationKey:  "IssuerKind",
+						cmapi.IssuerGroupAnnotationKey: "group.example.com",
+					}),
+				gen.SetSecretData(
+					map[string][]byte{
+						corev1.TLSPrivateKeyKey: privKey,
+						corev1.TLSCertKey: testcrypto.MustCreateCertWithNotBeforeAfter(t, privKey,
+							&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "new.example.com"}},
+							clock.Now().Add(time.Minute*5), clock.Now().Add(time.Hour*3),
+						),
+					},
+				)),
+			cr: gen.CertificateRequest("something",
+				gen.SetCertificateRequestIssuer(
+					cmmeta.ObjectReference{
+						Name:  "testissuer",
+						Kind:  "IssuerKind",
+						Group: "group.example.com",
+					},
+				),
+				gen.SetCertificateRequestCSR(testcrypto.MustGenerateCSRImpl(t, privKey,
+					gen.Certificate("something",
+						gen.SetCertificateCommonName("new.example.com")))),
+			),
+			reason:         policies.NotYetValid,
+			message:        "Certificate is not valid until " + clock.Now().Add(time.Minute*5).Format(time.RFC1123),
+			violationFound: true,
+		},
 		"Certificate is Ready, no policy violations found": {
 			cert: gen.Certificate("something",
 				gen.SetCertificateCommonName("new.example.com"),
@@ -513,7 +644,7 @@ func TestNewReadinessPolicyChain(t *testing.T) {
 			message: "",
 		},
 	}
-	policyChain := policies.NewReadinessPolicyChain(clock)
+	policyChain := policies.NewReadinessPolicyChain(clock, internalcertificates.PrivateKeyDefaults{})
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
 			reason, message, violationFound := policyChain.Evaluate(policies.Input{
@@ -533,3 +664,506 @@ func TestNewReadinessPolicyChain(t *testing.T) {
 		})
 	}
 }
+
+// Test that the issued certificate's serial number, fingerprint and DNs are
+// reconstructed from the Secret if they are missing from the Certificate's
+// status, e.g. after the Certificate has been restored from a backup.
+func TestProcessItemRepairsIssuedCertificateStatus(t *testing.T) {
+	now := time.Now().UTC()
+	privKey := testcrypto.MustCreatePEMPrivateKey(t)
+	cert := &cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"},
+		Spec: cmapi.CertificateSpec{
+			SecretName: "test-secret",
+			DNSNames:   []string{"example.com"},
+			CommonName: "example.com",
+		},
+	}
+	certBytes := testcrypto.MustCreateCertWithNotBeforeAfter(t, privKey, cert, now, now.Add(time.Hour))
+	x509Cert, err := pki.DecodeX509CertificateBytes(certBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret := gen.Secret("test-secret",
+		gen.SetSecretNamespace("testns"),
+		gen.SetSecretData(map[string][]byte{corev1.TLSCertKey: certBytes}),
+	)
+
+	metaNow := metav1.NewTime(now)
+	renewalTime := metav1.NewTime(x509Cert.NotBefore.Add(40 * time.Minute))
+	readyCondition := cmapi.CertificateCondition{
+		Type:   cmapi.CertificateConditionReady,
+		Status: cmmeta.ConditionTrue,
+		Reason: ReadyReason,
+	}
+
+	// readyStatus is the status the controller writes for every
+	// Certificate below, regardless of the issued certificate fields.
+	readyStatus := func(crt *cmapi.Certificate) {
+		cond := readyCondition
+		cond.LastTransitionTime = &metaNow
+		gen.SetCertificateStatusCondition(cond)(crt)
+		crt.Status.NotBefore = &metav1.Time{Time: x509Cert.NotBefore}
+		crt.Status.NotAfter = &metav1.Time{Time: x509Cert.NotAfter}
+		crt.Status.RenewalTime = &renewalTime
+	}
+	issuedStatus := func(crt *cmapi.Certificate) {
+		internalcertificates.SetIssuedCertificateStatus(crt, x509Cert)
+	}
+	withIssuanceHistory := func(crt *cmapi.Certificate) {
+		crt.Status.IssuanceHistory = []cmapi.CertificateIssuanceAttempt{{
+			Revision:               1,
+			CertificateRequestName: "test-1",
+			Outcome:                cmapi.CertificateIssuanceOutcomeIssued,
+			Reason:                 "Issued",
+		}}
+	}
+
+	tests := map[string]struct {
+		cert     *cmapi.Certificate
+		expected *cmapi.Certificate
+	}{
+		"reconstructs the status fields if the status has been wiped": {
+			cert:     cert,
+			expected: gen.CertificateFrom(cert, readyStatus, issuedStatus),
+		},
+		"fills in missing fields if the recorded serial number matches the Secret": {
+			cert:     gen.CertificateFrom(cert, gen.SetCertificateSerialNumber(pki.FormatSerialNumber(x509Cert.SerialNumber))),
+			expected: gen.CertificateFrom(cert, readyStatus, issuedStatus),
+		},
+		"does not overwrite fields recorded for a different certificate": {
+			cert:     gen.CertificateFrom(cert, gen.SetCertificateSerialNumber("01")),
+			expected: gen.CertificateFrom(cert, readyStatus, gen.SetCertificateSerialNumber("01")),
+		},
+		"keeps the issuance history recorded by the issuing controller": {
+			cert:     gen.CertificateFrom(cert, withIssuanceHistory),
+			expected: gen.CertificateFrom(cert, withIssuanceHistory, readyStatus, issuedStatus),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fakeclock.NewFakeClock(now),
+				CertManagerObjects: []runtime.Object{test.cert},
+				KubeObjects:        []runtime.Object{secret},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						test.cert.Namespace,
+						test.expected)),
+				},
+			}
+			builder.Init()
+
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+			w.controller.policyEvaluator = policyEvaluatorBuilder(readyCondition)
+			w.controller.renewalTimeCalculator = renewalTimeBuilder(&renewalTime)
+
+			builder.Start()
+			defer builder.Stop()
+
+			key, err := controllerpkg.KeyFunc(test.cert)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := w.controller.ProcessItem(context.Background(), key); err != nil {
+				t.Fatal(err)
+			}
+			if err := builder.AllActionsExecuted(); err != nil {
+				t.Error(err)
+			}
+
+			got, err := builder.CMClient.CertmanagerV1().Certificates(test.cert.Namespace).Get(context.Background(), test.cert.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Status.SerialNumber != test.expected.Status.SerialNumber ||
+				got.Status.SHA256Fingerprint != test.expected.Status.SHA256Fingerprint ||
+				got.Status.ShortSHA256Fingerprint != test.expected.Status.ShortSHA256Fingerprint ||
+				got.Status.IssuerDN != test.expected.Status.IssuerDN ||
+				got.Status.SubjectDN != test.expected.Status.SubjectDN ||
+				!reflect.DeepEqual(got.Status.IssuanceHistory, test.expected.Status.IssuanceHistory) {
+				t.Errorf("unexpected issued certificate status, exp=%+v got=%+v", test.expected.Status, got.Status)
+			}
+		})
+	}
+}
+
+func TestProcessItemNamespaceScoped(t *testing.T) {
+	now := time.Now().UTC()
+	cert := gen.Certificate("test",
+		gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateSecretName("test-secret"),
+		gen.SetCertificateDNSNames("example.com"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca", Kind: cmapi.ClusterIssuerKind}),
+	)
+
+	builder := &testpkg.Builder{
+		T:     t,
+		Clock: fakeclock.NewFakeClock(now),
+		Context: &controllerpkg.Context{
+			RootContext:    context.Background(),
+			ContextOptions: controllerpkg.ContextOptions{Namespace: "testns"},
+		},
+		CertManagerObjects: []runtime.Object{cert},
+		ExpectedActions: []testpkg.Action{
+			testpkg.NewCustomMatch(coretesting.NewUpdateSubresourceAction(
+				cmapi.SchemeGroupVersion.WithResource("certificates"),
+				"status",
+				cert.Namespace,
+				cert,
+			), func(exp, got coretesting.Action) error {
+				crt := got.(coretesting.UpdateAction).GetObject().(*cmapi.Certificate)
+				cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionReady)
+				if cond == nil || cond.Status != cmmeta.ConditionFalse || cond.Reason != policies.ClusterIssuerNotSupported {
+					return fmt.Errorf("expected Ready=False with reason %q, got %+v", policies.ClusterIssuerNotSupported, cond)
+				}
+				return nil
+			}),
+		},
+	}
+	builder.Init()
+
+	w := &controllerWrapper{}
+	if _, _, err := w.Register(builder.Context); err != nil {
+		t.Fatal(err)
+	}
+
+	builder.Start()
+	defer builder.Stop()
+
+	key, err := controllerpkg.KeyFunc(cert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.controller.ProcessItem(context.Background(), key); err != nil {
+		t.Fatal(err)
+	}
+	if err := builder.AllActionsExecuted(); err != nil {
+		t.Error(err)
+	}
+	if actions := builder.ClusterScopedActions(); len(actions) > 0 {
+		t.Errorf("expected no cluster-scoped calls, got %v", actions)
+	}
+}
+
+// Test that a Certificate is marked as not Ready, and an Event naming the
+// field manager is recorded, when its Secret's data is modified by a third
+// party.
+func TestProcessItemSecretModifiedExternally(t *testing.T) {
+	now := time.Now().UTC()
+	metaNow := metav1.NewTime(now)
+	const message = `Secret key "tls.crt" has been modified by field manager "kubectl-edit"`
+
+	cert := gen.Certificate("test",
+		gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateSecretName("test-secret"),
+		gen.SetCertificateDNSNames("example.com"),
+	)
+	secret := gen.Secret("test-secret",
+		gen.SetSecretNamespace("testns"),
+		gen.SetSecretData(map[string][]byte{
+			corev1.TLSPrivateKeyKey: testcrypto.MustCreatePEMPrivateKey(t),
+			corev1.TLSCertKey:       []byte("garbage"),
+		}),
+		withSecretManagedFields(thirdPartyManagedFields),
+	)
+
+	tests := map[string]struct {
+		cert *cmapi.Certificate
+
+		expectedActions []testpkg.Action
+		expectedEvents  []string
+	}{
+		"should mark the Certificate as not Ready and record an Event": {
+			cert: cert,
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					cmapi.SchemeGroupVersion.WithResource("certificates"),
+					"status",
+					cert.Namespace,
+					gen.CertificateFrom(cert, gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+						Type:               cmapi.CertificateConditionReady,
+						Status:             cmmeta.ConditionFalse,
+						Reason:             policies.SecretModifiedExternally,
+						Message:            message,
+						LastTransitionTime: &metaNow,
+					})),
+				)),
+			},
+			expectedEvents: []string{"Warning SecretModifiedExternally " + message},
+		},
+		"should not record another Event if the Certificate is already not Ready for the same reason": {
+			cert: gen.CertificateFrom(cert, gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+				Type:               cmapi.CertificateConditionReady,
+				Status:             cmmeta.ConditionFalse,
+				Reason:             policies.SecretModifiedExternally,
+				Message:            message,
+				LastTransitionTime: &metaNow,
+			})),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fakeclock.NewFakeClock(now),
+				CertManagerObjects: []runtime.Object{test.cert},
+				KubeObjects:        []runtime.Object{secret},
+				ExpectedActions:    test.expectedActions,
+				ExpectedEvents:     test.expectedEvents,
+			}
+			builder.Init()
+
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+
+			builder.Start()
+
+			key, err := controllerpkg.KeyFunc(test.cert)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = w.controller.ProcessItem(context.Background(), key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			builder.CheckAndFinish(err)
+		})
+	}
+}
+
+func TestProcessItemReadinessGates(t *testing.T) {
+	now := time.Now().UTC()
+	privKey := testcrypto.MustCreatePEMPrivateKey(t)
+	gates := []cmapi.CertificateReadinessGate{
+		{Name: "ocsp", URL: "https://ocsp-checker.internal/check"},
+		{Name: "ct", URL: "https://ct-checker.internal/check"},
+	}
+	issuer := gen.Issuer("ca-issuer",
+		gen.SetIssuerNamespace("testns"),
+		gen.SetIssuerReadinessGates(gates...),
+	)
+	cert := gen.Certificate("test",
+		gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateSecretName("test-secret"),
+		gen.SetCertificateDNSNames("example.com"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca-issuer", Kind: cmapi.IssuerKind}),
+	)
+	certBytes := testcrypto.MustCreateCertWithNotBeforeAfter(t, privKey, cert, now, now.Add(time.Hour))
+	secret := gen.Secret("test-secret",
+		gen.SetSecretNamespace("testns"),
+		gen.SetSecretData(map[string][]byte{corev1.TLSCertKey: certBytes}),
+	)
+	x509cert, err := pki.DecodeX509CertificateBytes(certBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serialNumber := pki.FormatSerialNumber(x509cert.SerialNumber)
+	metaNow := metav1.NewTime(now)
+	renewalTime := metav1.NewTime(x509cert.NotBefore.Add(40 * time.Minute))
+
+	tests := map[string]struct {
+		issuer  *cmapi.Issuer
+		cached  *readinessGateVerdict
+		results map[string]readinessGateResult
+
+		expectedCalls     []string
+		expectedCondition cmapi.CertificateCondition
+		expectedErr       bool
+	}{
+		"marks the Certificate as Ready if all gates accept the certificate": {
+			issuer:        issuer,
+			expectedCalls: []string{"ocsp", "ct"},
+			expectedCondition: cmapi.CertificateCondition{
+				Type:   cmapi.CertificateConditionReady,
+				Status: cmmeta.ConditionTrue,
+				Reason: ReadyReason,
+			},
+		},
+		"marks the Certificate as not Ready and retries if a gate rejects the certificate": {
+			issuer: issuer,
+			results: map[string]readinessGateResult{
+				"ocsp": {Reason: ReadinessGateRejectedReason, Message: `Readiness gate "ocsp" rejected the certificate: revoked`},
+			},
+			expectedCalls: []string{"ocsp"},
+			expectedCondition: cmapi.CertificateCondition{
+				Type:    cmapi.CertificateConditionReady,
+				Status:  cmmeta.ConditionFalse,
+				Reason:  ReadinessGateRejectedReason,
+				Message: `Readiness gate "ocsp" rejected the certificate: revoked`,
+			},
+			expectedErr: true,
+		},
+		"marks the Certificate as not Ready and retries if a gate times out": {
+			issuer: issuer,
+			results: map[string]readinessGateResult{
+				"ct": {Reason: ReadinessGateTimeoutReason, Message: `Readiness gate "ct" did not respond within 10s`},
+			},
+			expectedCalls: []string{"ocsp", "ct"},
+			expectedCondition: cmapi.CertificateCondition{
+				Type:    cmapi.CertificateConditionReady,
+				Status:  cmmeta.ConditionFalse,
+				Reason:  ReadinessGateTimeoutReason,
+				Message: `Readiness gate "ct" did not respond within 10s`,
+			},
+			expectedErr: true,
+		},
+		"marks the Certificate as Ready without calling any gate if the issuer has none": {
+			issuer: gen.IssuerFrom(issuer, gen.SetIssuerReadinessGates()),
+			expectedCondition: cmapi.CertificateCondition{
+				Type:   cmapi.CertificateConditionReady,
+				Status: cmmeta.ConditionTrue,
+				Reason: ReadyReason,
+			},
+		},
+		"marks the Certificate as Ready without calling any gate if the issuer does not exist": {
+			expectedCondition: cmapi.CertificateCondition{
+				Type:   cmapi.CertificateConditionReady,
+				Status: cmmeta.ConditionTrue,
+				Reason: ReadyReason,
+			},
+		},
+		"does not call the gates again for a certificate they accepted": {
+			issuer: issuer,
+			cached: &readinessGateVerdict{serialNumber: serialNumber, gates: readinessGatesKey(gates)},
+			expectedCondition: cmapi.CertificateCondition{
+				Type:   cmapi.CertificateConditionReady,
+				Status: cmmeta.ConditionTrue,
+				Reason: ReadyReason,
+			},
+		},
+		"does not call the gates again before the retry interval for a certificate they rejected": {
+			issuer: issuer,
+			cached: &readinessGateVerdict{
+				serialNumber: serialNumber,
+				gates:        readinessGatesKey(gates),
+				result:       readinessGateResult{Reason: ReadinessGateRejectedReason, Message: `Readiness gate "ocsp" rejected the certificate: revoked`},
+				expires:      now.Add(time.Second),
+			},
+			expectedCondition: cmapi.CertificateCondition{
+				Type:    cmapi.CertificateConditionReady,
+				Status:  cmmeta.ConditionFalse,
+				Reason:  ReadinessGateRejectedReason,
+				Message: `Readiness gate "ocsp" rejected the certificate: revoked`,
+			},
+			expectedErr: true,
+		},
+		"calls the gates again once the retry interval for a certificate they rejected has passed": {
+			issuer: issuer,
+			cached: &readinessGateVerdict{
+				serialNumber: serialNumber,
+				gates:        readinessGatesKey(gates),
+				result:       readinessGateResult{Reason: ReadinessGateRejectedReason, Message: `Readiness gate "ocsp" rejected the certificate: revoked`},
+				expires:      now,
+			},
+			expectedCalls: []string{"ocsp", "ct"},
+			expectedCondition: cmapi.CertificateCondition{
+				Type:   cmapi.CertificateConditionReady,
+				Status: cmmeta.ConditionTrue,
+				Reason: ReadyReason,
+			},
+		},
+		"calls the gates for a certificate accepted by gates which have since been changed": {
+			issuer:        issuer,
+			cached:        &readinessGateVerdict{serialNumber: serialNumber, gates: readinessGatesKey(gates[:1])},
+			expectedCalls: []string{"ocsp", "ct"},
+			expectedCondition: cmapi.CertificateCondition{
+				Type:   cmapi.CertificateConditionReady,
+				Status: cmmeta.ConditionTrue,
+				Reason: ReadyReason,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cmObjects := []runtime.Object{cert}
+			if test.issuer != nil {
+				cmObjects = append(cmObjects, test.issuer)
+			}
+			expectedCondition := test.expectedCondition
+			expectedCondition.LastTransitionTime = &metaNow
+			expected := gen.CertificateFrom(cert,
+				gen.SetCertificateStatusCondition(expectedCondition),
+				gen.SetCertificateNotBefore(metav1.NewTime(x509cert.NotBefore)),
+				gen.SetCertificateNotAfter(metav1.NewTime(x509cert.NotAfter)),
+				gen.SetCertificateRenewalTime(renewalTime),
+				func(crt *cmapi.Certificate) {
+					internalcertificates.SetIssuedCertificateStatus(crt, x509cert)
+				},
+			)
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fakeclock.NewFakeClock(now),
+				CertManagerObjects: cmObjects,
+				KubeObjects:        []runtime.Object{secret},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						cert.Namespace,
+						expected)),
+				},
+			}
+			builder.Init()
+
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+			w.controller.policyEvaluator = policyEvaluatorBuilder(cmapi.CertificateCondition{
+				Type:   cmapi.CertificateConditionReady,
+				Status: cmmeta.ConditionTrue,
+				Reason: ReadyReason,
+			})
+			w.controller.renewalTimeCalculator = renewalTimeBuilder(&renewalTime)
+			var calls []string
+			w.controller.readinessGateChecker = func(_ context.Context, _ *cmapi.Certificate, gate cmapi.CertificateReadinessGate, leafPEM []byte) readinessGateResult {
+				calls = append(calls, gate.Name)
+				if _, err := pki.DecodeX509CertificateBytes(leafPEM); err != nil {
+					t.Errorf("gate %q called with an invalid certificate: %v", gate.Name, err)
+				}
+				return test.results[gate.Name]
+			}
+
+			builder.Start()
+			defer builder.Stop()
+
+			key, err := controllerpkg.KeyFunc(cert)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if test.cached != nil {
+				w.controller.readinessGateVerdicts.set(key, *test.cached)
+			}
+			err = w.controller.ProcessItem(context.Background(), key)
+			if test.expectedErr != (err != nil) {
+				t.Errorf("unexpected error, exp=%t got=%v", test.expectedErr, err)
+			}
+			if err := builder.AllActionsExecuted(); err != nil {
+				t.Error(err)
+			}
+			if !reflect.DeepEqual(calls, test.expectedCalls) {
+				t.Errorf("unexpected readiness gate calls, exp=%v got=%v", test.expectedCalls, calls)
+			}
+
+			got, err := builder.CMClient.CertmanagerV1().Certificates(cert.Namespace).Get(context.Background(), cert.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			cond := apiutil.GetCertificateCondition(got, cmapi.CertificateConditionReady)
+			if cond == nil || cond.Status != test.expectedCondition.Status || cond.Reason != test.expectedCondition.Reason || cond.Message != test.expectedCondition.Message {
+				t.Errorf("unexpected Ready condition, exp=%+v got=%+v", test.expectedCondition, cond)
+			}
+		})
+	}
+}
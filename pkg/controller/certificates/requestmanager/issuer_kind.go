@@ -0,0 +1,315 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestmanager
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	internalcertificaterequests "github.com/cert-manager/cert-manager/internal/controller/certificaterequests"
+	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+)
+
+const (
+	reasonIssuerKindNotFound = "IssuerKindNotFound"
+	reasonIssuerKindFound    = "IssuerKindFound"
+)
+
+// issuerKindNotServedTTL is the time for which an issuerRef kind which is not
+// served by the API server is cached. Installing a CustomResourceDefinition
+// resets the cache, so this only bounds the delay for kinds served by an
+// aggregated API server.
+const issuerKindNotServedTTL = time.Minute
+
+// issuerKindChecker uses discovery to check whether the group and kind of an
+// issuerRef are served by the API server. Kinds that are found are cached
+// until reset, which happens whenever a CustomResourceDefinition changes.
+// Kinds that are not found are cached for issuerKindNotServedTTL, or until
+// reset, so that a Certificate referring to a misspelled kind does not cause
+// discovery calls on every sync.
+type issuerKindChecker struct {
+	discoveryClient discovery.DiscoveryInterface
+	clock           clock.Clock
+
+	lock      sync.Mutex
+	served    map[schema.GroupKind]struct{}
+	notServed map[schema.GroupKind]time.Time
+	// generation is incremented on every reset, so that the result of a
+	// discovery which was started before a reset is not cached.
+	generation uint64
+}
+
+func newIssuerKindChecker(discoveryClient discovery.DiscoveryInterface, clock clock.Clock) *issuerKindChecker {
+	return &issuerKindChecker{
+		discoveryClient: discoveryClient,
+		clock:           clock,
+		served:          make(map[schema.GroupKind]struct{}),
+		notServed:       make(map[schema.GroupKind]time.Time),
+	}
+}
+
+// reset drops all cached results.
+func (c *issuerKindChecker) reset() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.served = make(map[schema.GroupKind]struct{})
+	c.notServed = make(map[schema.GroupKind]time.Time)
+	c.generation++
+}
+
+// isServed returns true if the issuerRef's group and kind are served by the
+// API server. The built-in cert-manager.io kinds are always considered to be
+// served. The lock is not held during discovery, so that a slow API server
+// does not block the Certificates whose kind is cached.
+func (c *issuerKindChecker) isServed(ref cmmeta.ObjectReference) (bool, error) {
+	if ref.Group == "" || ref.Group == certmanager.GroupName {
+		return true, nil
+	}
+	gk := schema.GroupKind{Group: ref.Group, Kind: ref.Kind}
+
+	c.lock.Lock()
+	if _, ok := c.served[gk]; ok {
+		c.lock.Unlock()
+		return true, nil
+	}
+	if expiry, ok := c.notServed[gk]; ok && c.clock.Now().Before(expiry) {
+		c.lock.Unlock()
+		return false, nil
+	}
+	generation := c.generation
+	c.lock.Unlock()
+
+	served, err := c.discover(gk)
+	if err != nil {
+		return false, err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.generation != generation {
+		return served, nil
+	}
+	if served {
+		c.served[gk] = struct{}{}
+		delete(c.notServed, gk)
+	} else {
+		c.notServed[gk] = c.clock.Now().Add(issuerKindNotServedTTL)
+	}
+	return served, nil
+}
+
+// discover uses discovery to determine whether the group and kind are served
+// by the API server.
+func (c *issuerKindChecker) discover(gk schema.GroupKind) (bool, error) {
+	groups, err := c.discoveryClient.ServerGroups()
+	if err != nil {
+		return false, fmt.Errorf("failed to discover API groups: %w", err)
+	}
+	if groups == nil {
+		return false, nil
+	}
+	for _, group := range groups.Groups {
+		if group.Name != gk.Group {
+			continue
+		}
+		for _, version := range group.Versions {
+			resources, err := c.discoveryClient.ServerResourcesForGroupVersion(version.GroupVersion)
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return false, fmt.Errorf("failed to discover resources for %q: %w", version.GroupVersion, err)
+			}
+			if resources == nil {
+				continue
+			}
+			for _, resource := range resources.APIResources {
+				// Skip sub-resources such as "status", which share the
+				// kind of their parent resource.
+				if resource.Kind == gk.Kind && !strings.Contains(resource.Name, "/") {
+					return true, nil
+				}
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// enqueueCertificatesForCRD returns a WorkFunc for CustomResourceDefinition
+// metadata informers. It resets the cached discovery results, and enqueues
+// every Certificate whose issuerRef refers to the group of the changed
+// CustomResourceDefinition so that its IssuerKindNotFound condition is
+// re-evaluated.
+func enqueueCertificatesForCRD(log logr.Logger, queue workqueue.Interface, lister cmlisters.CertificateLister, checker *issuerKindChecker) func(obj interface{}) {
+	return func(obj interface{}) {
+		crd, ok := obj.(metav1.Object)
+		if !ok {
+			log.V(logf.ErrorLevel).Info("CustomResourceDefinition informer returned a non-object", "object", obj)
+			return
+		}
+
+		checker.reset()
+
+		// CustomResourceDefinitions are always named <plural>.<group>
+		_, group, ok := strings.Cut(crd.GetName(), ".")
+		if !ok {
+			return
+		}
+
+		crts, err := lister.List(labels.Everything())
+		if err != nil {
+			log.Error(err, "failed listing Certificate resources")
+			return
+		}
+		for _, crt := range crts {
+			if crt.Spec.IssuerRef.Group != group {
+				continue
+			}
+			key, err := controllerpkg.KeyFunc(crt)
+			if err != nil {
+				log.Error(err, "error computing key for resource")
+				continue
+			}
+			queue.Add(key)
+		}
+	}
+}
+
+// updateIssuerKindConditions sets the IssuerKindNotFound condition to True on
+// both the Certificate and the CertificateRequest if the issuerRef's group and
+// kind are not served by the API server. The conditions are set to False once
+// the kind is found. The conditions are never added if the kind is found to
+// begin with.
+func (c *controller) updateIssuerKindConditions(ctx context.Context, crt *cmapi.Certificate, req *cmapi.CertificateRequest) error {
+	served, err := c.issuerKinds.isServed(crt.Spec.IssuerRef)
+	if err != nil {
+		return err
+	}
+
+	ref := crt.Spec.IssuerRef
+	status, reason := cmmeta.ConditionFalse, reasonIssuerKindFound
+	message := fmt.Sprintf("Issuer kind %q in group %q is served by the API server", ref.Kind, ref.Group)
+	if !served {
+		status, reason = cmmeta.ConditionTrue, reasonIssuerKindNotFound
+		message = fmt.Sprintf("Issuer kind %q in group %q is not served by the API server, check that the issuer's CustomResourceDefinitions are installed and that the issuerRef group and kind are spelled correctly", ref.Kind, ref.Group)
+	}
+
+	var oldReqStatus cmmeta.ConditionStatus
+	if cond := apiutil.GetCertificateRequestCondition(req, cmapi.CertificateRequestConditionIssuerKindNotFound); cond != nil {
+		oldReqStatus = cond.Status
+	}
+	if needsIssuerKindCondition(oldReqStatus, status) {
+		req = req.DeepCopy()
+		apiutil.SetCertificateRequestCondition(req, cmapi.CertificateRequestConditionIssuerKindNotFound, status, reason, message)
+		if err := c.updateOrApplyRequestStatus(ctx, req); err != nil {
+			return err
+		}
+	}
+
+	var oldCrtStatus cmmeta.ConditionStatus
+	if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuerKindNotFound); cond != nil {
+		oldCrtStatus = cond.Status
+	}
+	if needsIssuerKindCondition(oldCrtStatus, status) {
+		crt = crt.DeepCopy()
+		apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionIssuerKindNotFound, status, reason, message)
+		if err := c.updateOrApplyStatus(ctx, crt); err != nil {
+			return err
+		}
+		if status == cmmeta.ConditionTrue {
+			c.recorder.Event(crt, corev1.EventTypeWarning, reasonIssuerKindNotFound, message)
+		}
+	}
+
+	return nil
+}
+
+// needsIssuerKindCondition returns true if an IssuerKindNotFound condition
+// with the current status needs to be updated to the given status. A missing
+// condition only needs to be added if the kind was not found.
+func needsIssuerKindCondition(current, status cmmeta.ConditionStatus) bool {
+	if current == "" {
+		return status == cmmeta.ConditionTrue
+	}
+	return current != status
+}
+
+// updateOrApplyStatus will update the Certificate's IssuerKindNotFound and
+// ExternalCSRInvalid conditions. If the ServerSideApply feature is enabled,
+// the conditions will instead get applied using the relevant Patch API call.
+func (c *controller) updateOrApplyStatus(ctx context.Context, crt *cmapi.Certificate) error {
+	if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
+		var conditions []cmapi.CertificateCondition
+		for _, condType := range []cmapi.CertificateConditionType{
+			cmapi.CertificateConditionIssuerKindNotFound,
+			cmapi.CertificateConditionExternalCSRInvalid,
+		} {
+			if cond := apiutil.GetCertificateCondition(crt, condType); cond != nil {
+				conditions = append(conditions, *cond)
+			}
+		}
+		return internalcertificates.ApplyStatus(ctx, c.client, c.fieldManager, &cmapi.Certificate{
+			ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace, Name: crt.Name},
+			Status:     cmapi.CertificateStatus{Conditions: conditions},
+		})
+	} else {
+		_, err := c.client.CertmanagerV1().Certificates(crt.Namespace).UpdateStatus(ctx, crt, metav1.UpdateOptions{})
+		return err
+	}
+}
+
+// updateOrApplyRequestStatus will update the CertificateRequest's
+// IssuerKindNotFound condition. If the ServerSideApply feature is enabled, the
+// condition will instead get applied using the relevant Patch API call.
+func (c *controller) updateOrApplyRequestStatus(ctx context.Context, req *cmapi.CertificateRequest) error {
+	if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
+		var conditions []cmapi.CertificateRequestCondition
+		if cond := apiutil.GetCertificateRequestCondition(req, cmapi.CertificateRequestConditionIssuerKindNotFound); cond != nil {
+			conditions = []cmapi.CertificateRequestCondition{*cond}
+		}
+		return internalcertificaterequests.ApplyStatus(ctx, c.client, c.fieldManager, &cmapi.CertificateRequest{
+			ObjectMeta: metav1.ObjectMeta{Namespace: req.Namespace, Name: req.Name},
+			Status:     cmapi.CertificateRequestStatus{Conditions: conditions},
+		})
+	} else {
+		_, err := c.client.CertmanagerV1().CertificateRequests(req.Namespace).UpdateStatus(ctx, req, metav1.UpdateOptions{})
+		return err
+	}
+}
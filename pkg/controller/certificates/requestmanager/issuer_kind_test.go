@@ -0,0 +1,328 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestmanager
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	metadatafake "k8s.io/client-go/metadata/fake"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestIssuerKindChecker(t *testing.T) {
+	discoveryCalls := 0
+	builder := &testpkg.Builder{T: t}
+	builder.Init()
+	builder.FakeDiscoveryClient().WithServerGroups(func() (*metav1.APIGroupList, error) {
+		discoveryCalls++
+		return &metav1.APIGroupList{Groups: []metav1.APIGroup{{
+			Name:     "example.io",
+			Versions: []metav1.GroupVersionForDiscovery{{GroupVersion: "example.io/v1", Version: "v1"}},
+		}}}, nil
+	}).WithServerResourcesForGroupVersion(func(groupVersion string) (*metav1.APIResourceList, error) {
+		return &metav1.APIResourceList{
+			GroupVersion: groupVersion,
+			APIResources: []metav1.APIResource{
+				{Name: "exampleissuers", Kind: "ExampleIssuer"},
+				{Name: "exampleclusterissuers/status", Kind: "ExampleClusterIssuer"},
+			},
+		}, nil
+	})
+	clock := fakeclock.NewFakeClock(time.Now())
+	checker := newIssuerKindChecker(builder.DiscoveryClient, clock)
+
+	tests := map[string]struct {
+		ref    cmmeta.ObjectReference
+		served bool
+	}{
+		"built-in kinds without a group are always served": {
+			ref:    cmmeta.ObjectReference{Name: "ca", Kind: "Issuer"},
+			served: true,
+		},
+		"built-in kinds in the cert-manager.io group are always served": {
+			ref:    cmmeta.ObjectReference{Name: "ca", Kind: "ClusterIssuer", Group: "cert-manager.io"},
+			served: true,
+		},
+		"kinds served in the group are found": {
+			ref:    cmmeta.ObjectReference{Name: "ca", Kind: "ExampleIssuer", Group: "example.io"},
+			served: true,
+		},
+		"kinds only served as a sub-resource are not found": {
+			ref:    cmmeta.ObjectReference{Name: "ca", Kind: "ExampleClusterIssuer", Group: "example.io"},
+			served: false,
+		},
+		"kinds in a misspelled group are not found": {
+			ref:    cmmeta.ObjectReference{Name: "ca", Kind: "ExampleIssuer", Group: "exmaple.io"},
+			served: false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			served, err := checker.isServed(test.ref)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if served != test.served {
+				t.Errorf("expected served=%t, got=%t", test.served, served)
+			}
+		})
+	}
+
+	// Kinds that were found are cached until the checker is reset.
+	discoveryCalls = 0
+	ref := cmmeta.ObjectReference{Name: "ca", Kind: "ExampleIssuer", Group: "example.io"}
+	if _, err := checker.isServed(ref); err != nil {
+		t.Fatal(err)
+	}
+	if discoveryCalls != 0 {
+		t.Errorf("expected the served kind to be cached, got %d discovery calls", discoveryCalls)
+	}
+	checker.reset()
+	if _, err := checker.isServed(ref); err != nil {
+		t.Fatal(err)
+	}
+	if discoveryCalls != 1 {
+		t.Errorf("expected discovery to be called once after reset, got %d discovery calls", discoveryCalls)
+	}
+
+	// Kinds that were not found are cached until the TTL expires, or until
+	// the checker is reset.
+	discoveryCalls = 0
+	misspelled := cmmeta.ObjectReference{Name: "ca", Kind: "ExampleIssuer", Group: "exmaple.io"}
+	for range 2 {
+		if _, err := checker.isServed(misspelled); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if discoveryCalls != 1 {
+		t.Errorf("expected the kind that was not found to be cached, got %d discovery calls", discoveryCalls)
+	}
+	clock.Step(issuerKindNotServedTTL)
+	if _, err := checker.isServed(misspelled); err != nil {
+		t.Fatal(err)
+	}
+	if discoveryCalls != 2 {
+		t.Errorf("expected discovery to be called again once the TTL expired, got %d discovery calls", discoveryCalls)
+	}
+	checker.reset()
+	if _, err := checker.isServed(misspelled); err != nil {
+		t.Fatal(err)
+	}
+	if discoveryCalls != 3 {
+		t.Errorf("expected discovery to be called again after reset, got %d discovery calls", discoveryCalls)
+	}
+}
+
+func TestIssuerKindCheckerDoesNotBlockOnDiscovery(t *testing.T) {
+	builder := &testpkg.Builder{T: t}
+	builder.Init()
+
+	// Discovery of the slow.io group blocks until it is released.
+	entered, release := make(chan struct{}), make(chan struct{})
+	builder.FakeDiscoveryClient().WithServerGroups(func() (*metav1.APIGroupList, error) {
+		return &metav1.APIGroupList{Groups: []metav1.APIGroup{
+			{Name: "example.io", Versions: []metav1.GroupVersionForDiscovery{{GroupVersion: "example.io/v1", Version: "v1"}}},
+			{Name: "slow.io", Versions: []metav1.GroupVersionForDiscovery{{GroupVersion: "slow.io/v1", Version: "v1"}}},
+		}}, nil
+	}).WithServerResourcesForGroupVersion(func(groupVersion string) (*metav1.APIResourceList, error) {
+		if groupVersion == "slow.io/v1" {
+			close(entered)
+			<-release
+		}
+		return &metav1.APIResourceList{
+			GroupVersion: groupVersion,
+			APIResources: []metav1.APIResource{{Name: "exampleissuers", Kind: "ExampleIssuer"}},
+		}, nil
+	})
+	checker := newIssuerKindChecker(builder.DiscoveryClient, fakeclock.NewFakeClock(time.Now()))
+
+	cached := cmmeta.ObjectReference{Name: "ca", Kind: "ExampleIssuer", Group: "example.io"}
+	if _, err := checker.isServed(cached); err != nil {
+		t.Fatal(err)
+	}
+
+	slowDone := make(chan struct{})
+	go func() {
+		defer close(slowDone)
+		if _, err := checker.isServed(cmmeta.ObjectReference{Name: "ca", Kind: "ExampleIssuer", Group: "slow.io"}); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	// The cached kind is returned whilst the discovery of slow.io is
+	// still in progress.
+	<-entered
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if served, err := checker.isServed(cached); err != nil || !served {
+			t.Errorf("expected the cached kind to be served, got served=%t err=%v", served, err)
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Error("isServed blocked on the discovery of another group")
+	}
+
+	close(release)
+	<-slowDone
+}
+
+func TestIssuerKindNotFoundUntilCRDInstalled(t *testing.T) {
+	ref := cmmeta.ObjectReference{Name: "ca", Kind: "ExampleIssuer", Group: "example.io"}
+	bundle := mustCreateCryptoBundle(t, &cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test", UID: "test"},
+		Spec:       cmapi.CertificateSpec{CommonName: "test-bundle", IssuerRef: ref},
+	})
+	crt := gen.CertificateFrom(bundle.certificate,
+		gen.SetCertificateNextPrivateKeySecretName("exists"),
+		gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue}),
+	)
+	notFoundMessage := `Issuer kind "ExampleIssuer" in group "example.io" is not served by the API server, check that the issuer's CustomResourceDefinitions are installed and that the issuerRef group and kind are spelled correctly`
+
+	builder := &testpkg.Builder{
+		T:                  t,
+		Clock:              fakeclock.NewFakeClock(time.Now()),
+		CertManagerObjects: []runtime.Object{crt},
+		KubeObjects: []runtime.Object{&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "exists"},
+			Data:       map[string][]byte{corev1.TLSPrivateKeyKey: bundle.privateKeyBytes},
+		}},
+		ExpectedEvents: []string{
+			`Normal Requested Created new CertificateRequest resource "test-1"`,
+			"Warning IssuerKindNotFound " + notFoundMessage,
+		},
+	}
+	builder.Init()
+
+	// The example.io group is only served once its CRD is "installed".
+	var installed atomic.Bool
+	builder.FakeDiscoveryClient().WithServerGroups(func() (*metav1.APIGroupList, error) {
+		if !installed.Load() {
+			return &metav1.APIGroupList{}, nil
+		}
+		return &metav1.APIGroupList{Groups: []metav1.APIGroup{{
+			Name:     "example.io",
+			Versions: []metav1.GroupVersionForDiscovery{{GroupVersion: "example.io/v1", Version: "v1"}},
+		}}}, nil
+	}).WithServerResourcesForGroupVersion(func(groupVersion string) (*metav1.APIResourceList, error) {
+		return &metav1.APIResourceList{
+			GroupVersion: groupVersion,
+			APIResources: []metav1.APIResource{{Name: "exampleissuers", Kind: "ExampleIssuer"}},
+		}, nil
+	})
+
+	w := &controllerWrapper{}
+	queue, _, err := w.Register(builder.Context)
+	if err != nil {
+		t.Fatal(err)
+	}
+	builder.Start()
+	defer builder.Stop()
+
+	key, err := controllerpkg.KeyFunc(crt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertConditions := func(status cmmeta.ConditionStatus, reason string) {
+		t.Helper()
+		req, err := builder.CMClient.CertmanagerV1().CertificateRequests("testns").Get(context.Background(), "test-1", metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !apiutil.CertificateRequestHasCondition(req, cmapi.CertificateRequestCondition{
+			Type:   cmapi.CertificateRequestConditionIssuerKindNotFound,
+			Status: status,
+			Reason: reason,
+		}) {
+			t.Errorf("expected CertificateRequest to have IssuerKindNotFound condition with status %q and reason %q, got %v", status, reason, req.Status.Conditions)
+		}
+		crt, err := builder.CMClient.CertmanagerV1().Certificates("testns").Get(context.Background(), "test", metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !apiutil.CertificateHasCondition(crt, cmapi.CertificateCondition{
+			Type:   cmapi.CertificateConditionIssuerKindNotFound,
+			Status: status,
+			Reason: reason,
+		}) {
+			t.Errorf("expected Certificate to have IssuerKindNotFound condition with status %q and reason %q, got %v", status, reason, crt.Status.Conditions)
+		}
+	}
+
+	// The CertificateRequest is created, but both it and the Certificate
+	// report that the issuer kind could not be found.
+	if err := w.controller.ProcessItem(context.Background(), key); err != nil {
+		t.Fatal(err)
+	}
+	assertConditions(cmmeta.ConditionTrue, reasonIssuerKindNotFound)
+
+	// Wait for the status updates to be observed, and drain the queue of any
+	// keys added by them.
+	builder.Sync()
+	for queue.Len() > 0 {
+		item, _ := queue.Get()
+		queue.Done(item)
+	}
+
+	// Installing the CRD re-queues the Certificate.
+	installed.Store(true)
+	crdClient := builder.FakeMetadataClient().Resource(apiextensionsv1.SchemeGroupVersion.WithResource("customresourcedefinitions")).(metadatafake.MetadataClient)
+	if _, err := crdClient.CreateFake(&metav1.PartialObjectMetadata{
+		TypeMeta:   metav1.TypeMeta{APIVersion: apiextensionsv1.SchemeGroupVersion.String(), Kind: "CustomResourceDefinition"},
+		ObjectMeta: metav1.ObjectMeta{Name: "exampleissuers.example.io"},
+	}, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := wait.PollUntilContextTimeout(context.Background(), time.Millisecond*100, time.Second*5, true, func(context.Context) (bool, error) {
+		return queue.Len() > 0, nil
+	}); err != nil {
+		t.Fatalf("Certificate was not re-queued after the CRD was installed: %v", err)
+	}
+	item, _ := queue.Get()
+	queue.Done(item)
+	if item != key {
+		t.Errorf("expected %q to be re-queued, got %q", key, item)
+	}
+
+	// Once re-processed, both conditions are resolved.
+	if err := w.controller.ProcessItem(context.Background(), key); err != nil {
+		t.Fatal(err)
+	}
+	assertConditions(cmmeta.ConditionFalse, reasonIssuerKindFound)
+
+	if err := builder.AllEventsCalled(); err != nil {
+		t.Error(err)
+	}
+}
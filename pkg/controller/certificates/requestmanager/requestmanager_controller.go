@@ -20,22 +20,33 @@ import (
 	"bytes"
 	"context"
 	"crypto"
+	"crypto/x509"
+	"encoding/json"
 	"encoding/pem"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/restmapper"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/clock"
 
+	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
+	"github.com/cert-manager/cert-manager/internal/controller/certificates/failureevents"
+	"github.com/cert-manager/cert-manager/internal/controller/certificates/keyprovider"
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
@@ -52,9 +63,11 @@ import (
 )
 
 const (
-	ControllerName      = "certificates-request-manager"
-	reasonRequestFailed = "RequestFailed"
-	reasonRequested     = "Requested"
+	ControllerName           = "certificates-request-manager"
+	reasonRequestFailed      = "RequestFailed"
+	reasonRequested          = "Requested"
+	reasonExternalCSRInvalid = "ExternalCSRInvalid"
+	reasonAdopted            = "Adopted"
 )
 
 var (
@@ -70,10 +83,28 @@ type controller struct {
 	clock                    clock.Clock
 	copiedAnnotationPrefixes []string
 
+	// failureEvents records the Events for CertificateRequests which could
+	// not be created, so that the events API is not flooded when many
+	// Certificates fail at once.
+	failureEvents *failureevents.Recorder
+
+	// certificateRequestEncodings is the encoding of spec.request on the
+	// CertificateRequests created for Certificates referencing an issuer in
+	// a given group. CertificateRequests for other groups are PEM encoded.
+	certificateRequestEncodings map[string]pki.CertificateRequestEncoding
+
+	// issuerKinds is used to check that the group and kind referenced by a
+	// Certificate's issuerRef are served by the API server.
+	issuerKinds *issuerKindChecker
+
 	// fieldManager is the string which will be used as the Field Manager on
 	// fields created or edited by the cert-manager Kubernetes client during
 	// Create or Apply API calls.
 	fieldManager string
+
+	// keyProvider is used to sign CSRs for Certificates which set
+	// spec.privateKey.external. It is nil if no signer plugin is configured.
+	keyProvider keyprovider.Provider
 }
 
 func NewController(
@@ -85,6 +116,7 @@ func NewController(
 	certificateInformer := ctx.SharedInformerFactory.Certmanager().V1().Certificates()
 	certificateRequestInformer := ctx.SharedInformerFactory.Certmanager().V1().CertificateRequests()
 	secretsInformer := ctx.KubeSharedInformerFactory.Secrets()
+	issuerInformer := ctx.SharedInformerFactory.Certmanager().V1().Issuers()
 
 	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
 	certificateRequestInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
@@ -99,6 +131,12 @@ func NewController(
 			predicate.ResourceOwnerOf,
 		),
 	})
+	secretsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		// Trigger reconciles on changes to Secrets holding an external CSR
+		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
+			predicate.ExtractResourceName(predicate.CertificateExternalCSRSecretName),
+		),
+	})
 
 	// build a list of InformerSynced functions that will be returned by the Register method.
 	// the controller will only begin processing items once all of these informers have synced.
@@ -106,17 +144,56 @@ func NewController(
 		secretsInformer.Informer().HasSynced,
 		certificateRequestInformer.Informer().HasSynced,
 		certificateInformer.Informer().HasSynced,
+		issuerInformer.Informer().HasSynced,
+	}
+
+	// The issuers of failing Certificates are looked up to record summary
+	// Events on them, see failureevents.Recorder. ClusterIssuers can only be
+	// listed when cert-manager is not limited to a single namespace.
+	var clusterIssuerLister cmlisters.ClusterIssuerLister
+	if ctx.Namespace == "" {
+		clusterIssuerInformer := ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers()
+		clusterIssuerLister = clusterIssuerInformer.Lister()
+		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
+	}
+
+	issuerKinds := newIssuerKindChecker(ctx.DiscoveryClient, ctx.Clock)
+	// CustomResourceDefinitions are cluster scoped, so they can only be
+	// watched when cert-manager is not limited to a single namespace. When
+	// they are not watched, kinds that were not found are re-checked the next
+	// time the Certificate is reconciled.
+	if ctx.Namespace == "" {
+		crdInformer := ctx.MetadataInformersFactory.ForResource(apiextensionsv1.SchemeGroupVersion.WithResource("customresourcedefinitions"))
+		crdInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+			// Trigger reconciles of Certificates referencing the group of
+			// any CustomResourceDefinition that is installed or changed
+			WorkFunc: enqueueCertificatesForCRD(log, queue, certificateInformer.Lister(), issuerKinds),
+		})
+		mustSync = append(mustSync, crdInformer.Informer().HasSynced)
 	}
 
 	return &controller{
-		certificateLister:        certificateInformer.Lister(),
-		certificateRequestLister: certificateRequestInformer.Lister(),
-		secretLister:             secretsInformer.Lister(),
-		client:                   ctx.CMClient,
-		recorder:                 ctx.Recorder,
-		clock:                    ctx.Clock,
-		copiedAnnotationPrefixes: ctx.CertificateOptions.CopiedAnnotationPrefixes,
-		fieldManager:             ctx.FieldManager,
+		certificateLister:           certificateInformer.Lister(),
+		certificateRequestLister:    certificateRequestInformer.Lister(),
+		secretLister:                secretsInformer.Lister(),
+		client:                      ctx.CMClient,
+		recorder:                    ctx.Recorder,
+		clock:                       ctx.Clock,
+		copiedAnnotationPrefixes:    ctx.CertificateOptions.CopiedAnnotationPrefixes,
+		certificateRequestEncodings: ctx.CertificateOptions.CertificateRequestEncodings,
+		issuerKinds:                 issuerKinds,
+		fieldManager:                ctx.FieldManager,
+		keyProvider:                 ctx.CertificateOptions.KeyProvider,
+		failureEvents: failureevents.NewRecorder(ctx.Recorder, ctx.Clock,
+			ctx.CertificateOptions.MaxIssuanceFailureEvents,
+			ctx.CertificateOptions.IssuanceFailureEventWindow,
+			failureevents.Issuers{
+				IssuerLister:        issuerInformer.Lister(),
+				ClusterIssuerLister: clusterIssuerLister,
+				RESTMapper:          restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(ctx.DiscoveryClient)),
+				MetadataClient:      ctx.MetadataClient,
+			},
+		),
 	}, queue, mustSync
 }
 
@@ -139,40 +216,69 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return err
 	}
 
-	if !apiutil.CertificateHasCondition(crt, cmapi.CertificateCondition{
-		Type:   cmapi.CertificateConditionIssuing,
-		Status: cmmeta.ConditionTrue,
-	}) {
-		return nil
-	}
+	log = logf.WithCertificate(log, crt)
+	ctx = logf.NewContext(ctx, log)
 
-	// Check for and fetch the 'status.nextPrivateKeySecretName' secret
-	if crt.Status.NextPrivateKeySecretName == nil {
-		log.V(logf.DebugLevel).Info("status.nextPrivateKeySecretName not yet set, waiting for keymanager before processing certificate")
-		return nil
+	// Discover all 'owned' CertificateRequests
+	requests, err := certificates.ListCertificateRequestsMatchingPredicates(c.certificateRequestLister.CertificateRequests(crt.Namespace), labels.Everything(), internalcertificates.CertificateRequestOwnedBy(crt))
+	if err != nil {
+		return err
 	}
-	nextPrivateKeySecret, err := c.secretLister.Secrets(crt.Namespace).Get(*crt.Status.NextPrivateKeySecretName)
-	if apierrors.IsNotFound(err) {
-		log.V(logf.DebugLevel).Info("nextPrivateKeySecretName Secret resource does not exist, waiting for keymanager to create it before continuing")
-		return nil
+
+	// Point CertificateRequests restored from a backup at the restored
+	// Certificate again. They are only listed above if the
+	// AdoptRestoredCertificateRequests feature gate is enabled.
+	if err := c.adoptRestoredRequests(ctx, crt, requests...); err != nil {
+		return err
 	}
-	if err != nil {
+
+	// Keep the certificate name label of all owned CertificateRequests up to
+	// date, whether or not the Certificate is being issued, so that they can
+	// be selected by the name of their Certificate.
+	if err := c.labelRequestsWithCertificateName(ctx, crt, requests...); err != nil {
 		return err
 	}
-	if nextPrivateKeySecret.Data == nil || len(nextPrivateKeySecret.Data[corev1.TLSPrivateKeyKey]) == 0 {
-		log.V(logf.DebugLevel).Info("Next private key secret does not contain any valid data, waiting for keymanager before processing certificate")
-		return nil
+
+	// Clean up the ExternalCSRInvalid condition if an external CSR is no
+	// longer used. The Certificate will be re-synced once its status is
+	// updated.
+	if crt.Spec.ExternalCSR == nil && apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionExternalCSRInvalid) != nil {
+		return c.removeExternalCSRInvalid(ctx, crt)
 	}
-	pk, err := pki.DecodePrivateKeyBytes(nextPrivateKeySecret.Data[corev1.TLSPrivateKeyKey])
-	if err != nil {
-		log.Error(err, "Failed to decode next private key secret data, waiting for keymanager before processing certificate")
+
+	if !apiutil.CertificateHasCondition(crt, cmapi.CertificateCondition{
+		Type:   cmapi.CertificateConditionIssuing,
+		Status: cmmeta.ConditionTrue,
+	}) {
 		return nil
 	}
 
-	// Discover all 'owned' CertificateRequests
-	requests, err := certificates.ListCertificateRequestsMatchingPredicates(c.certificateRequestLister.CertificateRequests(crt.Namespace), labels.Everything(), predicate.ResourceOwnedBy(crt))
-	if err != nil {
-		return err
+	var (
+		// pk is the next private key, it is nil if an external CSR is used.
+		pk                       crypto.Signer
+		publicKey                crypto.PublicKey
+		externalCSR              []byte
+		nextPrivateKeySecretName string
+	)
+	if crt.Spec.ExternalCSR != nil {
+		var x509CSR *x509.CertificateRequest
+		externalCSR, x509CSR, err = c.readExternalCSR(ctx, crt)
+		if err != nil {
+			return err
+		}
+		if externalCSR == nil {
+			return nil
+		}
+		publicKey = x509CSR.PublicKey
+	} else {
+		pk, nextPrivateKeySecretName, err = c.readNextPrivateKey(ctx, crt)
+		if err != nil {
+			return err
+		}
+		if pk == nil {
+			return nil
+		}
+		publicKey = pk.Public()
 	}
 
 	// delete any existing CertificateRequest resources that do not have a
@@ -192,11 +298,18 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return err
 	}
 
-	requests, err = c.deleteRequestsNotMatchingSpec(ctx, crt, pk.Public(), requests...)
+	requests, err = c.deleteRequestsNotMatchingSpec(ctx, crt, publicKey, requests...)
 	if err != nil {
 		return err
 	}
 
+	if externalCSR != nil {
+		requests, err = c.deleteRequestsNotMatchingCSR(ctx, externalCSR, requests...)
+		if err != nil {
+			return err
+		}
+	}
+
 	requests, err = c.deleteCurrentFailedRequests(ctx, crt, requests...)
 	if err != nil {
 		return err
@@ -211,19 +324,190 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	}
 
 	if len(requests) == 1 {
-		// Nothing to do as we've already verified that the CertificateRequest
-		// is up to date above.
+		// We've already verified that the CertificateRequest is up to date
+		// above, so only check whether its issuer kind has since been
+		// installed.
+		return c.updateIssuerKindConditions(ctx, crt, requests[0])
+	}
+
+	csrPEM := externalCSR
+	if csrPEM == nil {
+		csrPEM, err = generateCSR(ctx, crt, pk)
+		if err != nil {
+			return err
+		}
+		if csrPEM == nil {
+			return nil
+		}
+	}
+
+	req, err := c.createNewCertificateRequest(ctx, crt, csrPEM, nextRevision, nextPrivateKeySecretName)
+	if err != nil || req == nil {
+		return err
+	}
+
+	return c.updateIssuerKindConditions(ctx, crt, req)
+}
+
+// readNextPrivateKey returns the private key stored in the Secret named in
+// 'status.nextPrivateKeySecretName', along with the name of that Secret. For
+// a private key held by an external KMS, the Secret only stores a reference
+// to the key.
+// A nil private key is returned if the keymanager has not yet created a
+// valid private key.
+func (c *controller) readNextPrivateKey(ctx context.Context, crt *cmapi.Certificate) (crypto.Signer, string, error) {
+	log := logf.FromContext(ctx)
+
+	// Check for and fetch the 'status.nextPrivateKeySecretName' secret
+	if crt.Status.NextPrivateKeySecretName == nil {
+		log.V(logf.DebugLevel).Info("status.nextPrivateKeySecretName not yet set, waiting for keymanager before processing certificate")
+		return nil, "", nil
+	}
+	nextPrivateKeySecret, err := c.secretLister.Secrets(crt.Namespace).Get(*crt.Status.NextPrivateKeySecretName)
+	if apierrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("nextPrivateKeySecretName Secret resource does not exist, waiting for keymanager to create it before continuing")
+		return nil, "", nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	if internalcertificates.UsesExternalPrivateKey(crt.Spec) {
+		ref := nextPrivateKeySecret.Data[cmapi.PrivateKeyReferenceSecretKey]
+		if len(ref) == 0 || c.keyProvider == nil {
+			log.V(logf.DebugLevel).Info("Next private key secret does not contain a private key reference, waiting for keymanager before processing certificate")
+			return nil, "", nil
+		}
+		// The returned signer signs the CSR using the KMS.
+		pk, err := c.keyProvider.Signer(ctx, string(ref))
+		if err != nil {
+			return nil, "", err
+		}
+		return pk, nextPrivateKeySecret.Name, nil
+	}
+	if nextPrivateKeySecret.Data == nil || len(nextPrivateKeySecret.Data[corev1.TLSPrivateKeyKey]) == 0 {
+		log.V(logf.DebugLevel).Info("Next private key secret does not contain any valid data, waiting for keymanager before processing certificate")
+		return nil, "", nil
+	}
+	pk, err := pki.DecodePrivateKeyBytes(nextPrivateKeySecret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		log.Error(err, "Failed to decode next private key secret data, waiting for keymanager before processing certificate")
+		return nil, "", nil
+	}
+
+	return pk, nextPrivateKeySecret.Name, nil
+}
+
+// readExternalCSR returns the PEM encoded CSR referenced by
+// 'spec.externalCSR.secretRef', along with its decoded form. A nil CSR is
+// returned, and the ExternalCSRInvalid condition set, if the CSR does not
+// exist, is invalid or does not match the Certificate's spec. The Certificate
+// will be re-synced once the Secret changes.
+// A nil CSR is also returned while a stale ExternalCSRInvalid condition is
+// removed, as the Certificate will be re-synced once its status is updated.
+func (c *controller) readExternalCSR(ctx context.Context, crt *cmapi.Certificate) ([]byte, *x509.CertificateRequest, error) {
+	csrPEM, x509CSR, message, err := c.decodeExternalCSR(ctx, crt)
+	if err != nil {
+		return nil, nil, err
+	}
+	if message != "" {
+		return nil, nil, c.setExternalCSRInvalid(ctx, crt, message)
+	}
+	if apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionExternalCSRInvalid) != nil {
+		return nil, nil, c.removeExternalCSRInvalid(ctx, crt)
+	}
+	return csrPEM, x509CSR, nil
+}
+
+// decodeExternalCSR reads and decodes the CSR referenced by
+// 'spec.externalCSR.secretRef'. If the CSR cannot be used, a message
+// explaining why is returned instead.
+func (c *controller) decodeExternalCSR(ctx context.Context, crt *cmapi.Certificate) ([]byte, *x509.CertificateRequest, string, error) {
+	log := logf.FromContext(ctx)
+
+	ref := crt.Spec.ExternalCSR.SecretRef
+	key := ref.Key
+	if key == "" {
+		key = cmapi.ExternalCSRSecretKey
+	}
+
+	secret, err := c.secretLister.Secrets(crt.Namespace).Get(ref.Name)
+	if apierrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("External CSR Secret resource does not exist, waiting for it to be created before continuing", "secret", ref.Name)
+		return nil, nil, fmt.Sprintf("External CSR Secret %q does not exist", ref.Name), nil
+	}
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	csrPEM := secret.Data[key]
+	if len(csrPEM) == 0 {
+		return nil, nil, fmt.Sprintf("External CSR Secret %q does not contain any data for key %q", ref.Name, key), nil
+	}
+
+	x509CSR, err := pki.DecodeX509CertificateRequestBytes(csrPEM)
+	if err != nil {
+		return nil, nil, fmt.Sprintf("External CSR in Secret %q is invalid: %v", ref.Name, err), nil
+	}
+	if err := x509CSR.CheckSignature(); err != nil {
+		return nil, nil, fmt.Sprintf("External CSR in Secret %q has an invalid signature: %v", ref.Name, err), nil
+	}
+
+	// The external CSR is used verbatim, so refuse to request a certificate
+	// that would not match the Certificate's spec. The fields of the
+	// CertificateRequest which are not part of the CSR are copied from the
+	// spec, so only the CSR itself is compared.
+	violations, err := pki.X509RequestMatchesSpec(&cmapi.CertificateRequest{
+		Spec: cmapi.CertificateRequestSpec{
+			Duration: crt.Spec.Duration,
+			IsCA:     crt.Spec.IsCA,
+			Usages:   crt.Spec.Usages,
+		},
+	}, x509CSR, crt.Spec)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if len(violations) > 0 {
+		return nil, nil, fmt.Sprintf("External CSR does not match the Certificate's spec: %v", violations), nil
+	}
+
+	return csrPEM, x509CSR, "", nil
+}
+
+// setExternalCSRInvalid sets the ExternalCSRInvalid condition with the given
+// message. The Warning event is only recorded when the condition changes, so
+// that a CSR which stays invalid does not record an event on every sync.
+func (c *controller) setExternalCSRInvalid(ctx context.Context, crt *cmapi.Certificate, message string) error {
+	if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionExternalCSRInvalid); cond != nil &&
+		cond.Status == cmmeta.ConditionTrue && cond.Message == message {
 		return nil
 	}
 
-	return c.createNewCertificateRequest(ctx, crt, pk, nextRevision, nextPrivateKeySecret.Name)
+	crt = crt.DeepCopy()
+	apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionExternalCSRInvalid, cmmeta.ConditionTrue, reasonExternalCSRInvalid, message)
+	if err := c.updateOrApplyStatus(ctx, crt); err != nil {
+		return err
+	}
+	c.recorder.Event(crt, corev1.EventTypeWarning, reasonExternalCSRInvalid, message)
+	return nil
+}
+
+// removeExternalCSRInvalid removes the ExternalCSRInvalid condition, if
+// present.
+func (c *controller) removeExternalCSRInvalid(ctx context.Context, crt *cmapi.Certificate) error {
+	if apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionExternalCSRInvalid) == nil {
+		return nil
+	}
+
+	crt = crt.DeepCopy()
+	apiutil.RemoveCertificateCondition(crt, cmapi.CertificateConditionExternalCSRInvalid)
+	return c.updateOrApplyStatus(ctx, crt)
 }
 
 func (c *controller) deleteCurrentFailedRequests(ctx context.Context, crt *cmapi.Certificate, reqs ...*cmapi.CertificateRequest) ([]*cmapi.CertificateRequest, error) {
-	log := logf.FromContext(ctx).WithValues("Certificate", crt.Name)
+	log := logf.FromContext(ctx)
 	var remaining []*cmapi.CertificateRequest
 	for _, req := range reqs {
-		log = logf.WithRelatedResource(log, req)
+		log := logf.WithRelatedCertificateRequest(log, req)
 
 		// Check if there are any 'current' CertificateRequests that
 		// failed during the previous issuance cycle. Those should be
@@ -260,11 +544,99 @@ func (c *controller) deleteCurrentFailedRequests(ctx context.Context, crt *cmapi
 	return remaining, nil
 }
 
+// adoptRestoredRequests repairs the controller owner reference of
+// CertificateRequests which have been restored together with the Certificate
+// from a backup, and so still reference the UID the Certificate had before it
+// was restored.
+func (c *controller) adoptRestoredRequests(ctx context.Context, crt *cmapi.Certificate, reqs ...*cmapi.CertificateRequest) error {
+	log := logf.FromContext(ctx)
+	for _, req := range reqs {
+		if !internalcertificates.IsRestoredCertificateRequest(crt, req) {
+			continue
+		}
+
+		ownerRefs := make([]metav1.OwnerReference, 0, len(req.OwnerReferences))
+		for _, ref := range req.OwnerReferences {
+			if ref.Controller != nil && *ref.Controller {
+				ref = *metav1.NewControllerRef(crt, certificateGvk)
+			}
+			ownerRefs = append(ownerRefs, ref)
+		}
+		patch, err := json.Marshal(map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"ownerReferences": ownerRefs,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		logf.WithRelatedCertificateRequest(log, req).Info("Repairing the owner reference of restored CertificateRequest", "stale_uid", metav1.GetControllerOf(req).UID)
+		_, err = c.client.CertmanagerV1().CertificateRequests(req.Namespace).Patch(ctx, req.Name, types.MergePatchType, patch, metav1.PatchOptions{FieldManager: c.fieldManager})
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		c.recorder.Eventf(crt, corev1.EventTypeNormal, reasonAdopted, "Repaired the owner reference of restored CertificateRequest %q", req.Name)
+	}
+	return nil
+}
+
+// labelRequestsWithCertificateName sets the certificate name label on the
+// given CertificateRequests of the Certificate which do not have it, or which
+// have it set to another value, such as those created by older versions of
+// cert-manager.
+func (c *controller) labelRequestsWithCertificateName(ctx context.Context, crt *cmapi.Certificate, reqs ...*cmapi.CertificateRequest) error {
+	value, ok := certificateNameLabelValue(crt)
+	if !ok {
+		return nil
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": map[string]string{
+				cmapi.CertificateNameLabelKey: value,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	log := logf.FromContext(ctx)
+	for _, req := range reqs {
+		if current, ok := req.Labels[cmapi.CertificateNameLabelKey]; ok && current == value {
+			continue
+		}
+		logf.WithRelatedCertificateRequest(log, req).V(logf.DebugLevel).Info("Setting the certificate name label on CertificateRequest")
+		_, err := c.client.CertmanagerV1().CertificateRequests(req.Namespace).Patch(ctx, req.Name, types.MergePatchType, patch, metav1.PatchOptions{FieldManager: c.fieldManager})
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// certificateNameLabelValue returns the value of the certificate name label
+// of the CertificateRequests of the Certificate, and false if the name of the
+// Certificate is not a valid label value.
+func certificateNameLabelValue(crt *cmapi.Certificate) (string, bool) {
+	if len(validation.IsValidLabelValue(crt.Name)) > 0 {
+		return "", false
+	}
+	return crt.Name, true
+}
+
 func (c *controller) deleteRequestsWithoutRevision(ctx context.Context, reqs ...*cmapi.CertificateRequest) ([]*cmapi.CertificateRequest, error) {
 	log := logf.FromContext(ctx)
 	var remaining []*cmapi.CertificateRequest
 	for _, req := range reqs {
-		log := logf.WithRelatedResource(log, req)
+		log := logf.WithRelatedCertificateRequest(log, req)
 		if req.Annotations == nil || req.Annotations[cmapi.CertificateRequestRevisionAnnotationKey] == "" {
 			log.V(logf.DebugLevel).Info("Deleting CertificateRequest as it does not contain a revision annotation")
 			if err := c.client.CertmanagerV1().CertificateRequests(req.Namespace).Delete(ctx, req.Name, metav1.DeleteOptions{}); err != nil {
@@ -310,7 +682,7 @@ func (c *controller) deleteRequestsNotMatchingSpec(ctx context.Context, crt *cma
 	log := logf.FromContext(ctx)
 	var remaining []*cmapi.CertificateRequest
 	for _, req := range reqs {
-		log := logf.WithRelatedResource(log, req)
+		log := logf.WithRelatedCertificateRequest(log, req)
 		violations, err := pki.RequestMatchesSpec(req, crt.Spec)
 		if err != nil {
 			log.Error(err, "Failed to check if CertificateRequest matches spec, deleting CertificateRequest")
@@ -347,11 +719,40 @@ func (c *controller) deleteRequestsNotMatchingSpec(ctx context.Context, crt *cma
 	return remaining, nil
 }
 
-func (c *controller) createNewCertificateRequest(ctx context.Context, crt *cmapi.Certificate, pk crypto.Signer, nextRevision int, nextPrivateKeySecretName string) error {
+// deleteRequestsNotMatchingCSR deletes any CertificateRequests that do not
+// contain the given external CSR, for example because the CSR has been
+// rotated since they were created. The DER bytes of the CSRs are compared,
+// as the CertificateRequests may use another encoding than the external CSR.
+func (c *controller) deleteRequestsNotMatchingCSR(ctx context.Context, csrPEM []byte, reqs ...*cmapi.CertificateRequest) ([]*cmapi.CertificateRequest, error) {
+	log := logf.FromContext(ctx)
+	csr, err := pki.DecodeX509CertificateRequestBytes(csrPEM)
+	if err != nil {
+		return nil, err
+	}
+	var remaining []*cmapi.CertificateRequest
+	for _, req := range reqs {
+		log := logf.WithRelatedCertificateRequest(log, req)
+		reqCSR, err := pki.DecodeX509CertificateRequestBytes(req.Spec.Request)
+		if err != nil || !bytes.Equal(reqCSR.Raw, csr.Raw) {
+			log.V(logf.DebugLevel).Info("CertificateRequest does not contain the current external CSR, deleting CertificateRequest")
+			if err := c.client.CertmanagerV1().CertificateRequests(req.Namespace).Delete(ctx, req.Name, metav1.DeleteOptions{}); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		remaining = append(remaining, req)
+	}
+	return remaining, nil
+}
+
+// generateCSR generates a PEM encoded CSR for the Certificate, signed by the
+// given private key. A nil CSR is returned if the Certificate's spec cannot
+// be encoded as a CSR, as retrying will not help.
+func generateCSR(ctx context.Context, crt *cmapi.Certificate, pk crypto.Signer) ([]byte, error) {
 	log := logf.FromContext(ctx)
 
 	x509CSR, err := pki.GenerateCSR(
-		crt,
+		internalcertificates.WithPrivateKeyParameters(crt, pk),
 		pki.WithUseLiteralSubject(utilfeature.DefaultMutableFeatureGate.Enabled(feature.LiteralCertificateSubject)),
 		pki.WithEncodeBasicConstraintsInRequest(utilfeature.DefaultMutableFeatureGate.Enabled(feature.UseCertificateRequestBasicConstraints)),
 		pki.WithNameConstraints(utilfeature.DefaultMutableFeatureGate.Enabled(feature.NameConstraints)),
@@ -359,23 +760,81 @@ func (c *controller) createNewCertificateRequest(ctx context.Context, crt *cmapi
 	)
 	if err != nil {
 		log.Error(err, "Failed to generate CSR - will not retry")
-		return nil
+		return nil, nil
 	}
 	csrDER, err := pki.EncodeCSR(x509CSR, pk)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	csrPEM := bytes.NewBuffer([]byte{})
 	err = pem.Encode(csrPEM, &pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	return csrPEM.Bytes(), nil
+}
+
+// createNewCertificateRequest creates a CertificateRequest for the next
+// revision of the Certificate containing the given CSR. The private key
+// annotation is only set if nextPrivateKeySecretName is not empty, as there
+// is no private key Secret when an external CSR is used. The created
+// CertificateRequest is returned, or nil if none was created.
+func (c *controller) createNewCertificateRequest(ctx context.Context, crt *cmapi.Certificate, csrPEM []byte, nextRevision int, nextPrivateKeySecretName string) (*cmapi.CertificateRequest, error) {
 	annotations := controllerpkg.BuildAnnotationsToCopy(crt.Annotations, c.copiedAnnotationPrefixes)
+	crLabels := make(map[string]string, len(crt.Labels)+2)
+	for k, v := range crt.Labels {
+		crLabels[k] = v
+	}
+	if tmpl := crt.Spec.CertificateRequestTemplate; tmpl != nil {
+		// Values from the template take precedence over those copied from
+		// the Certificate, but never over the reserved annotations set below.
+		for k, v := range tmpl.Annotations {
+			if !isReservedKey(k) {
+				annotations[k] = v
+			}
+		}
+		for k, v := range tmpl.Labels {
+			if !isReservedKey(k) {
+				crLabels[k] = v
+			}
+		}
+	}
 	annotations[cmapi.CertificateRequestRevisionAnnotationKey] = strconv.Itoa(nextRevision)
-	annotations[cmapi.CertificateRequestPrivateKeyAnnotationKey] = nextPrivateKeySecretName
+	if nextPrivateKeySecretName != "" {
+		annotations[cmapi.CertificateRequestPrivateKeyAnnotationKey] = nextPrivateKeySecretName
+	}
 	annotations[cmapi.CertificateNameKey] = crt.Name
+	// Mark the CertificateRequest as created for a Certificate, as opposed
+	// to one created directly.
+	crLabels[cmapi.CertificateRequestCreatedByLabelKey] = ControllerName
+	if value, ok := certificateNameLabelValue(crt); ok {
+		crLabels[cmapi.CertificateNameLabelKey] = value
+	}
+
+	// Record the fingerprint of the requested public key so that key re-use
+	// across revisions can be audited, and so that the issued certificate can
+	// be checked against it.
+	csr, err := pki.DecodeX509CertificateRequestBytes(csrPEM)
+	if err != nil {
+		return nil, err
+	}
+	annotations[cmapi.CertificateRequestPublicKeyFingerprintAnnotationKey], err = pki.PublicKeyFingerprintSHA256(csr.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// The CSR is only re-encoded for issuer groups which are configured to
+	// use another encoding, so that an external CSR is otherwise stored
+	// verbatim.
+	request := csrPEM
+	if encoding, ok := c.certificateRequestEncodings[crt.Spec.IssuerRef.Group]; ok {
+		request, err = pki.EncodeCertificateRequest(csr.Raw, encoding)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	cr := &cmapi.CertificateRequest{
 		ObjectMeta: metav1.ObjectMeta{
@@ -385,13 +844,13 @@ func (c *controller) createNewCertificateRequest(ctx context.Context, crt *cmapi
 			// see https://github.com/kubernetes/apiserver/blob/696768606f546f71a1e90546613be37d1aa37f64/pkg/storage/names/generate.go
 			GenerateName:    apiutil.DNSSafeShortenTo52Characters(crt.Name) + "-",
 			Annotations:     annotations,
-			Labels:          crt.Labels,
+			Labels:          crLabels,
 			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(crt, certificateGvk)},
 		},
 		Spec: cmapi.CertificateRequestSpec{
 			Duration:  crt.Spec.Duration,
 			IssuerRef: crt.Spec.IssuerRef,
-			Request:   csrPEM.Bytes(),
+			Request:   request,
 			IsCA:      crt.Spec.IsCA,
 			Usages:    crt.Spec.Usages,
 		},
@@ -409,7 +868,7 @@ func (c *controller) createNewCertificateRequest(ctx context.Context, crt *cmapi
 		// name as follows: <first-168-chars-of-certificate-name>-<64-char-hash>-<19-char-nextRevision>
 		crName, err := apiutil.ComputeSecureUniqueDeterministicNameFromData(crt.Name, 233)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		cr.ObjectMeta.Name = fmt.Sprintf("%s-%d", crName, nextRevision)
@@ -417,8 +876,8 @@ func (c *controller) createNewCertificateRequest(ctx context.Context, crt *cmapi
 
 	cr, err = c.client.CertmanagerV1().CertificateRequests(cr.Namespace).Create(ctx, cr, metav1.CreateOptions{FieldManager: c.fieldManager})
 	if err != nil {
-		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonRequestFailed, "Failed to create CertificateRequest: "+err.Error())
-		return err
+		c.failureEvents.Event(ctx, crt, reasonRequestFailed, "Failed to create CertificateRequest: "+err.Error())
+		return nil, err
 	}
 
 	c.recorder.Eventf(crt, corev1.EventTypeNormal, reasonRequested, "Created new CertificateRequest resource %q", cr.Name)
@@ -427,13 +886,13 @@ func (c *controller) createNewCertificateRequest(ctx context.Context, crt *cmapi
 	// observe the creation event and instead rely on an AlreadyExists error being returned if we do attempt a
 	// CREATE for the same CertificateRequest name again early.
 	if utilfeature.DefaultFeatureGate.Enabled(feature.StableCertificateRequestName) {
-		return nil
+		return cr, nil
 	}
 
 	if err := c.waitForCertificateRequestToExist(ctx, cr.Namespace, cr.Name); err != nil {
-		return fmt.Errorf("failed whilst waiting for CertificateRequest to exist - this may indicate an apiserver running slowly. Request will be retried. %w", err)
+		return nil, fmt.Errorf("failed whilst waiting for CertificateRequest to exist - this may indicate an apiserver running slowly. Request will be retried. %w", err)
 	}
-	return nil
+	return cr, nil
 }
 
 func (c *controller) waitForCertificateRequestToExist(ctx context.Context, namespace, name string) error {
@@ -472,3 +931,12 @@ func init() {
 			Complete()
 	})
 }
+
+// isReservedKey returns true if the annotation or label key is in the
+// cert-manager.io domain, or one of its subdomains. These keys are managed by
+// cert-manager and are never taken from a Certificate's
+// certificateRequestTemplate.
+func isReservedKey(key string) bool {
+	prefix, _, found := strings.Cut(key, "/")
+	return found && (prefix == "cert-manager.io" || strings.HasSuffix(prefix, ".cert-manager.io"))
+}
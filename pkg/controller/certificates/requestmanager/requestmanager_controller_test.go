@@ -18,16 +18,22 @@ package requestmanager
 
 import (
 	"context"
+	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"reflect"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/go-logr/logr/funcr"
 	"github.com/kr/pretty"
+	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	coretesting "k8s.io/client-go/testing"
 	"k8s.io/component-base/featuregate"
 	featuregatetesting "k8s.io/component-base/featuregate/testing"
@@ -38,6 +44,7 @@ import (
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
@@ -58,6 +65,21 @@ func mustGenerateRSA(t *testing.T) []byte {
 func relaxedCertificateRequestMatcher(l coretesting.Action, r coretesting.Action) error {
 	objL := l.(coretesting.CreateAction).GetObject().(*cmapi.CertificateRequest).DeepCopy()
 	objR := r.(coretesting.CreateAction).GetObject().(*cmapi.CertificateRequest).DeepCopy()
+	// The public key fingerprint depends on the CSR, so check that it matches
+	// the CSR of the created request rather than comparing it.
+	csr, err := pki.DecodeX509CertificateRequestBytes(objR.Spec.Request)
+	if err != nil {
+		return err
+	}
+	fingerprint, err := pki.PublicKeyFingerprintSHA256(csr.PublicKey)
+	if err != nil {
+		return err
+	}
+	if got := objR.Annotations[cmapi.CertificateRequestPublicKeyFingerprintAnnotationKey]; got != fingerprint {
+		return fmt.Errorf("expected public key fingerprint annotation %q, got %q", fingerprint, got)
+	}
+	delete(objL.Annotations, cmapi.CertificateRequestPublicKeyFingerprintAnnotationKey)
+	delete(objR.Annotations, cmapi.CertificateRequestPublicKeyFingerprintAnnotationKey)
 	objL.Spec.Request = nil
 	objR.Spec.Request = nil
 	if !reflect.DeepEqual(objL, objR) {
@@ -66,6 +88,15 @@ func relaxedCertificateRequestMatcher(l coretesting.Action, r coretesting.Action
 	return nil
 }
 
+func certificateRequestMatcher(l coretesting.Action, r coretesting.Action) error {
+	objL := l.(coretesting.CreateAction).GetObject().(*cmapi.CertificateRequest)
+	objR := r.(coretesting.CreateAction).GetObject().(*cmapi.CertificateRequest)
+	if !reflect.DeepEqual(objL, objR) {
+		return fmt.Errorf("unexpected difference between actions: %s", pretty.Diff(objL, objR))
+	}
+	return nil
+}
+
 func TestProcessItem(t *testing.T) {
 	bundle1 := mustCreateCryptoBundle(t, &cmapi.Certificate{
 		ObjectMeta: metav1.ObjectMeta{
@@ -99,8 +130,43 @@ func TestProcessItem(t *testing.T) {
 		},
 		Spec: cmapi.CertificateSpec{CommonName: "test-bundle-4"}},
 	)
+	// rotatedBundle1 has the same spec as bundle1, but a different key.
+	rotatedBundle1 := mustCreateCryptoBundle(t, bundle1.certificate)
+	externalCSRSecret := func(csr []byte) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "csr"},
+			Data:       map[string][]byte{cmapi.ExternalCSRSecretKey: csr},
+		}
+	}
+	externalCSRRequest := func(b cryptoBundle, revision string) *cmapi.CertificateRequest {
+		return gen.CertificateRequestFrom(b.certificateRequest,
+			gen.SetCertificateRequestName("test-"+revision),
+			gen.DeleteCertificateRequestAnnotation(cmapi.CertificateRequestPrivateKeyAnnotationKey),
+			gen.SetCertificateRequestAnnotations(map[string]string{
+				cmapi.CertificateRequestRevisionAnnotationKey: revision,
+			}),
+		)
+	}
 	fixedNow := metav1.NewTime(time.Now())
 	fixedClock := fakeclock.NewFakeClock(fixedNow.Time)
+	externalCSRCertificate := gen.CertificateFrom(bundle1.certificate,
+		gen.SetCertificateExternalCSR("csr", ""),
+		gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue}),
+	)
+	withExternalCSRInvalid := func(message string) gen.CertificateModifier {
+		return gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+			Type:               cmapi.CertificateConditionExternalCSRInvalid,
+			Status:             cmmeta.ConditionTrue,
+			Reason:             "ExternalCSRInvalid",
+			Message:            message,
+			LastTransitionTime: &fixedNow,
+		})
+	}
+	externalCSRInvalidStatusUpdate := func(message string) testpkg.Action {
+		return testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmapi.SchemeGroupVersion.WithResource("certificates"), "status", "testns",
+			gen.CertificateFrom(externalCSRCertificate, withExternalCSRInvalid(message)),
+		))
+	}
 	failedCRConditionPreviousIssuance := cmapi.CertificateRequestCondition{
 		Type:               cmapi.CertificateRequestConditionReady,
 		Status:             cmmeta.ConditionFalse,
@@ -122,6 +188,10 @@ func TestProcessItem(t *testing.T) {
 		// Featuregates to set for a particular test.
 		featuresFlags map[featuregate.Feature]bool
 
+		// Prefixes of the Certificate's annotations which are copied to
+		// created CertificateRequests.
+		copiedAnnotationPrefixes []string
+
 		// Certificate to be synced for the test.
 		// if not set, the 'key' will be passed to ProcessItem instead.
 		certificate *cmapi.Certificate
@@ -153,6 +223,63 @@ func TestProcessItem(t *testing.T) {
 		"do nothing if Certificate has no 'Issuing' condition": {
 			certificate: bundle1.certificate,
 		},
+		"set the certificate name label on owned CertificateRequests which are missing it, even if the Certificate is not issuing": {
+			certificate: bundle1.certificate,
+			requests: []runtime.Object{
+				gen.CertificateRequestFrom(bundle1.certificateRequest,
+					gen.SetCertificateRequestName("test-1"),
+					gen.SetCertificateRequestLabels(map[string]string{cmapi.CertificateRequestCreatedByLabelKey: ControllerName}),
+				),
+				gen.CertificateRequestFrom(bundle1.certificateRequest,
+					gen.SetCertificateRequestName("test-2"),
+					gen.SetCertificateRequestLabels(map[string]string{cmapi.CertificateNameLabelKey: "other"}),
+				),
+				gen.CertificateRequestFrom(bundle1.certificateRequest,
+					gen.SetCertificateRequestName("test-3"),
+				),
+			},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewPatchAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns", "test-1",
+					types.MergePatchType, []byte(`{"metadata":{"labels":{"cert-manager.io/certificate-name":"test"}}}`))),
+				testpkg.NewAction(coretesting.NewPatchAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns", "test-2",
+					types.MergePatchType, []byte(`{"metadata":{"labels":{"cert-manager.io/certificate-name":"test"}}}`))),
+			},
+		},
+		"do not repair the owner reference of a restored CertificateRequest if AdoptRestoredCertificateRequests is disabled": {
+			certificate: bundle1.certificate,
+			requests: []runtime.Object{
+				gen.CertificateRequestFrom(bundle1.certificateRequest,
+					gen.SetCertificateRequestName("test-1"),
+					gen.SetCertificateRequestOwnerReferences(gen.CertificateRef("test", "stale")),
+				),
+			},
+		},
+		"repair the owner reference of a restored CertificateRequest if AdoptRestoredCertificateRequests is enabled": {
+			featuresFlags: map[featuregate.Feature]bool{
+				feature.AdoptRestoredCertificateRequests: true,
+			},
+			certificate: bundle1.certificate,
+			requests: []runtime.Object{
+				gen.CertificateRequestFrom(bundle1.certificateRequest,
+					gen.SetCertificateRequestName("test-1"),
+					gen.SetCertificateRequestOwnerReferences(gen.CertificateRef("test", "stale")),
+				),
+			},
+			expectedEvents: []string{`Normal Adopted Repaired the owner reference of restored CertificateRequest "test-1"`},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewPatchAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns", "test-1",
+					types.MergePatchType, []byte(`{"metadata":{"ownerReferences":[{"apiVersion":"cert-manager.io/v1","kind":"Certificate","name":"test","uid":"test","controller":true,"blockOwnerDeletion":true}]}}`))),
+			},
+		},
+		"do not set the certificate name label if the name of the Certificate is not a valid label value": {
+			certificate: bundle4.certificate,
+			requests: []runtime.Object{
+				gen.CertificateRequestFrom(bundle4.certificateRequest,
+					gen.SetCertificateRequestName("test-1"),
+					gen.SetCertificateRequestLabels(map[string]string{cmapi.CertificateRequestCreatedByLabelKey: ControllerName}),
+				),
+			},
+		},
 		"do nothing if status.nextPrivateKeySecretName is not set": {
 			certificate: gen.CertificateFrom(bundle1.certificate,
 				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue}),
@@ -237,6 +364,123 @@ func TestProcessItem(t *testing.T) {
 					)), relaxedCertificateRequestMatcher),
 			},
 		},
+		"create a CertificateRequest with the annotations and labels from the certificateRequestTemplate": {
+			copiedAnnotationPrefixes: []string{"*"},
+			secrets: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: bundle3.certificate.Namespace, Name: "exists"},
+					Data:       map[string][]byte{corev1.TLSPrivateKeyKey: bundle3.privateKeyBytes},
+				},
+			},
+			certificate: gen.CertificateFrom(bundle3.certificate,
+				gen.SetCertificateNextPrivateKeySecretName("exists"),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue}),
+				func(crt *cmapi.Certificate) {
+					crt.Annotations = map[string]string{
+						"my-ca.example.com/profile": "client",
+						"example.com/copied":        "copied",
+					}
+					crt.Labels = map[string]string{"app": "web", "tier": "frontend"}
+					crt.Spec.CertificateRequestTemplate = &cmapi.CertificateRequestTemplate{
+						// The template takes precedence over the Certificate's annotations and labels.
+						Annotations: map[string]string{"my-ca.example.com/profile": "server"},
+						Labels:      map[string]string{"tier": "backend"},
+					}
+				},
+			),
+			expectedEvents: []string{`Normal Requested Created new CertificateRequest resource "test-1"`},
+			expectedActions: []testpkg.Action{
+				testpkg.NewCustomMatch(coretesting.NewCreateAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns",
+					gen.CertificateRequestFrom(bundle3.certificateRequest,
+						gen.SetCertificateRequestName("test-1"),
+						gen.SetCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestPrivateKeyAnnotationKey: "exists",
+							cmapi.CertificateRequestRevisionAnnotationKey:   "1",
+							"my-ca.example.com/profile":                     "server",
+							"example.com/copied":                            "copied",
+						}),
+						gen.SetCertificateRequestLabels(map[string]string{"app": "web", "tier": "backend", cmapi.CertificateRequestCreatedByLabelKey: ControllerName, cmapi.CertificateNameLabelKey: "test"}),
+					)), relaxedCertificateRequestMatcher),
+			},
+		},
+		"create a CertificateRequest without overriding reserved annotations and labels from the certificateRequestTemplate": {
+			secrets: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: bundle3.certificate.Namespace, Name: "exists"},
+					Data:       map[string][]byte{corev1.TLSPrivateKeyKey: bundle3.privateKeyBytes},
+				},
+			},
+			certificate: gen.CertificateFrom(bundle3.certificate,
+				gen.SetCertificateNextPrivateKeySecretName("exists"),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue}),
+				func(crt *cmapi.Certificate) {
+					crt.Spec.CertificateRequestTemplate = &cmapi.CertificateRequestTemplate{
+						Annotations: map[string]string{
+							cmapi.CertificateRequestRevisionAnnotationKey: "99",
+							cmapi.CertificateNameKey:                      "other",
+							"acme.cert-manager.io/order-url":              "https://example.com",
+							"my-ca.example.com/profile":                   "server",
+						},
+						Labels: map[string]string{
+							"cert-manager.io/foo": "bar",
+							"app":                 "web",
+						},
+					}
+				},
+			),
+			expectedEvents: []string{`Normal Requested Created new CertificateRequest resource "test-1"`},
+			expectedActions: []testpkg.Action{
+				testpkg.NewCustomMatch(coretesting.NewCreateAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns",
+					gen.CertificateRequestFrom(bundle3.certificateRequest,
+						gen.SetCertificateRequestName("test-1"),
+						gen.SetCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestPrivateKeyAnnotationKey: "exists",
+							cmapi.CertificateRequestRevisionAnnotationKey:   "1",
+							"my-ca.example.com/profile":                     "server",
+						}),
+						gen.SetCertificateRequestLabels(map[string]string{"app": "web", cmapi.CertificateRequestCreatedByLabelKey: ControllerName, cmapi.CertificateNameLabelKey: "test"}),
+					)), relaxedCertificateRequestMatcher),
+			},
+		},
+		"create the next CertificateRequest revision with the current certificateRequestTemplate": {
+			secrets: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: bundle3.certificate.Namespace, Name: "exists"},
+					Data:       map[string][]byte{corev1.TLSPrivateKeyKey: bundle3.privateKeyBytes},
+				},
+			},
+			certificate: gen.CertificateFrom(bundle3.certificate,
+				gen.SetCertificateNextPrivateKeySecretName("exists"),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue}),
+				gen.SetCertificateRevision(1),
+				func(crt *cmapi.Certificate) {
+					crt.Spec.CertificateRequestTemplate = &cmapi.CertificateRequestTemplate{
+						Annotations: map[string]string{"my-ca.example.com/profile": "server"},
+					}
+				},
+			),
+			requests: []runtime.Object{
+				gen.CertificateRequestFrom(bundle3.certificateRequest,
+					gen.SetCertificateRequestName("test-1"),
+					gen.SetCertificateRequestAnnotations(map[string]string{
+						cmapi.CertificateRequestRevisionAnnotationKey: "1",
+						"my-ca.example.com/profile":                   "client",
+					}),
+				),
+			},
+			expectedEvents: []string{`Normal Requested Created new CertificateRequest resource "test-2"`},
+			expectedActions: []testpkg.Action{
+				testpkg.NewCustomMatch(coretesting.NewCreateAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns",
+					gen.CertificateRequestFrom(bundle3.certificateRequest,
+						gen.SetCertificateRequestName("test-2"),
+						gen.SetCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestPrivateKeyAnnotationKey: "exists",
+							cmapi.CertificateRequestRevisionAnnotationKey:   "2",
+							"my-ca.example.com/profile":                     "server",
+						}),
+					)), relaxedCertificateRequestMatcher),
+			},
+		},
 		"create a CertificateRequest if none exists (with long name)": {
 			secrets: []runtime.Object{
 				&corev1.Secret{
@@ -714,6 +958,103 @@ func TestProcessItem(t *testing.T) {
 				),
 			},
 		},
+		"set the ExternalCSRInvalid condition and fire an event if the external CSR Secret does not exist": {
+			certificate:     externalCSRCertificate,
+			expectedEvents:  []string{`Warning ExternalCSRInvalid External CSR Secret "csr" does not exist`},
+			expectedActions: []testpkg.Action{externalCSRInvalidStatusUpdate(`External CSR Secret "csr" does not exist`)},
+		},
+		"set the ExternalCSRInvalid condition and fire an event if the external CSR is invalid": {
+			secrets:         []runtime.Object{externalCSRSecret([]byte("invalid"))},
+			certificate:     externalCSRCertificate,
+			expectedEvents:  []string{`Warning ExternalCSRInvalid External CSR in Secret "csr" is invalid: error decoding certificate request PEM block`},
+			expectedActions: []testpkg.Action{externalCSRInvalidStatusUpdate(`External CSR in Secret "csr" is invalid: error decoding certificate request PEM block`)},
+		},
+		"set the ExternalCSRInvalid condition and fire an event if the external CSR does not match the spec": {
+			secrets:         []runtime.Object{externalCSRSecret(bundle2.csrBytes)},
+			certificate:     externalCSRCertificate,
+			expectedEvents:  []string{`Warning ExternalCSRInvalid External CSR does not match the Certificate's spec: [spec.commonName]`},
+			expectedActions: []testpkg.Action{externalCSRInvalidStatusUpdate(`External CSR does not match the Certificate's spec: [spec.commonName]`)},
+		},
+		"do nothing if the ExternalCSRInvalid condition is already set for the same reason": {
+			certificate: gen.CertificateFrom(externalCSRCertificate, withExternalCSRInvalid(`External CSR Secret "csr" does not exist`)),
+		},
+		"update the ExternalCSRInvalid condition and fire an event if the external CSR becomes invalid for another reason": {
+			secrets:         []runtime.Object{externalCSRSecret(bundle2.csrBytes)},
+			certificate:     gen.CertificateFrom(externalCSRCertificate, withExternalCSRInvalid(`External CSR Secret "csr" does not exist`)),
+			expectedEvents:  []string{`Warning ExternalCSRInvalid External CSR does not match the Certificate's spec: [spec.commonName]`},
+			expectedActions: []testpkg.Action{externalCSRInvalidStatusUpdate(`External CSR does not match the Certificate's spec: [spec.commonName]`)},
+		},
+		"remove the ExternalCSRInvalid condition once the external CSR is valid": {
+			secrets:     []runtime.Object{externalCSRSecret(bundle1.csrBytes)},
+			certificate: gen.CertificateFrom(externalCSRCertificate, withExternalCSRInvalid(`External CSR Secret "csr" does not exist`)),
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmapi.SchemeGroupVersion.WithResource("certificates"), "status", "testns", externalCSRCertificate)),
+			},
+		},
+		"remove the ExternalCSRInvalid condition once an external CSR is no longer used": {
+			certificate: gen.CertificateFrom(bundle1.certificate,
+				withExternalCSRInvalid(`External CSR Secret "csr" does not exist`),
+			),
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmapi.SchemeGroupVersion.WithResource("certificates"), "status", "testns", bundle1.certificate)),
+			},
+		},
+		"create a CertificateRequest containing the external CSR without waiting for a private key": {
+			secrets: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "csr"},
+					Data:       map[string][]byte{"custom.csr": bundle1.csrBytes},
+				},
+			},
+			certificate: gen.CertificateFrom(bundle1.certificate,
+				gen.SetCertificateExternalCSR("csr", "custom.csr"),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue}),
+			),
+			expectedEvents: []string{`Normal Requested Created new CertificateRequest resource "test-1"`},
+			expectedActions: []testpkg.Action{
+				testpkg.NewCustomMatch(coretesting.NewCreateAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns",
+					externalCSRRequest(bundle1, "1"),
+				), certificateRequestMatcher),
+			},
+		},
+		"do nothing if a CertificateRequest containing the external CSR already exists": {
+			secrets: []runtime.Object{externalCSRSecret(bundle1.csrBytes)},
+			certificate: gen.CertificateFrom(bundle1.certificate,
+				gen.SetCertificateExternalCSR("csr", ""),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue}),
+			),
+			requests: []runtime.Object{externalCSRRequest(bundle1, "1")},
+		},
+		"delete the CertificateRequest and create a new one if the external CSR is updated mid-issuance": {
+			secrets: []runtime.Object{externalCSRSecret(rotatedBundle1.csrBytes)},
+			certificate: gen.CertificateFrom(bundle1.certificate,
+				gen.SetCertificateExternalCSR("csr", ""),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue}),
+			),
+			requests:       []runtime.Object{externalCSRRequest(bundle1, "1")},
+			expectedEvents: []string{`Normal Requested Created new CertificateRequest resource "test-1"`},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewDeleteAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns", "test-1")),
+				testpkg.NewCustomMatch(coretesting.NewCreateAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns",
+					externalCSRRequest(rotatedBundle1, "1"),
+				), certificateRequestMatcher),
+			},
+		},
+		"use the updated external CSR when requesting the next revision": {
+			secrets: []runtime.Object{externalCSRSecret(rotatedBundle1.csrBytes)},
+			certificate: gen.CertificateFrom(bundle1.certificate,
+				gen.SetCertificateExternalCSR("csr", ""),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue}),
+				gen.SetCertificateRevision(1),
+			),
+			requests:       []runtime.Object{externalCSRRequest(bundle1, "1")},
+			expectedEvents: []string{`Normal Requested Created new CertificateRequest resource "test-2"`},
+			expectedActions: []testpkg.Action{
+				testpkg.NewCustomMatch(coretesting.NewCreateAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns",
+					externalCSRRequest(rotatedBundle1, "2"),
+				), certificateRequestMatcher),
+			},
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -733,6 +1074,7 @@ func TestProcessItem(t *testing.T) {
 			}
 			builder.CertManagerObjects = append(builder.CertManagerObjects, test.requests...)
 			builder.Init()
+			builder.Context.CertificateOptions.CopiedAnnotationPrefixes = test.copiedAnnotationPrefixes
 
 			// Register informers used by the controller using the registration wrapper
 			w := &controllerWrapper{}
@@ -780,3 +1122,228 @@ func TestProcessItem(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateNewCertificateRequestEncoding(t *testing.T) {
+	bundle := mustCreateCryptoBundle(t, &cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "testns",
+			Name:      "test",
+			UID:       "test",
+		},
+		Spec: cmapi.CertificateSpec{
+			CommonName: "test-encoding",
+			IssuerRef:  cmmeta.ObjectReference{Name: "issuer", Kind: "Issuer", Group: "example.com"},
+		}},
+	)
+	csrPEM := bundle.certificateRequest.Spec.Request
+	csr, err := pki.DecodeX509CertificateRequestBytes(csrPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		encodings map[string]pki.CertificateRequestEncoding
+
+		expectedRequest []byte
+	}{
+		"the request is unchanged if no encoding is configured": {
+			expectedRequest: csrPEM,
+		},
+		"the request is unchanged if no encoding is configured for the issuer group": {
+			encodings:       map[string]pki.CertificateRequestEncoding{"other.example.com": pki.CertificateRequestEncodingDER},
+			expectedRequest: csrPEM,
+		},
+		"the request is PEM encoded": {
+			encodings:       map[string]pki.CertificateRequestEncoding{"example.com": pki.CertificateRequestEncodingPEM},
+			expectedRequest: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csr.Raw}),
+		},
+		"the request is PEM encoded with the legacy block type": {
+			encodings:       map[string]pki.CertificateRequestEncoding{"example.com": pki.CertificateRequestEncodingLegacyPEM},
+			expectedRequest: pem.EncodeToMemory(&pem.Block{Type: "NEW CERTIFICATE REQUEST", Bytes: csr.Raw}),
+		},
+		"the request is DER encoded": {
+			encodings:       map[string]pki.CertificateRequestEncoding{"example.com": pki.CertificateRequestEncodingDER},
+			expectedRequest: csr.Raw,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{T: t}
+			builder.Init()
+			c := &controller{
+				client:                      builder.CMClient,
+				recorder:                    builder.Recorder,
+				certificateRequestEncodings: test.encodings,
+			}
+
+			req, err := c.createNewCertificateRequest(context.Background(), bundle.certificate, csrPEM, 1, "exists")
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, test.expectedRequest, req.Spec.Request)
+
+			// The CSR must round trip to the same DER bytes whatever the encoding.
+			decoded, err := pki.DecodeX509CertificateRequestBytes(req.Spec.Request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, csr.Raw, decoded.Raw)
+		})
+	}
+}
+
+// TestCertificateNameLabelSelector ensures that once the controller has synced
+// the Certificates, a label selector on the certificate name label returns
+// exactly the CertificateRequests of a Certificate.
+func TestCertificateNameLabelSelector(t *testing.T) {
+	web := mustCreateCryptoBundle(t, &cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "web", UID: "web"},
+		Spec:       cmapi.CertificateSpec{CommonName: "web.example.com"},
+	})
+	webAPI := mustCreateCryptoBundle(t, &cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "web-api", UID: "web-api"},
+		Spec:       cmapi.CertificateSpec{CommonName: "api.example.com"},
+	})
+	// unlabelled returns a CertificateRequest of the bundle as created by an
+	// older version of cert-manager, without the certificate name label.
+	unlabelled := func(b cryptoBundle, name string) *cmapi.CertificateRequest {
+		return gen.CertificateRequestFrom(b.certificateRequest,
+			gen.SetCertificateRequestName(name),
+			gen.SetCertificateRequestLabels(map[string]string{cmapi.CertificateRequestCreatedByLabelKey: ControllerName}),
+		)
+	}
+
+	builder := &testpkg.Builder{
+		T:     t,
+		Clock: fakeclock.NewFakeClock(time.Now()),
+		CertManagerObjects: []runtime.Object{
+			web.certificate, webAPI.certificate,
+			unlabelled(web, "web-1"), unlabelled(web, "web-2"), unlabelled(webAPI, "web-api-1"),
+			// A CertificateRequest which is not owned by a Certificate is
+			// never labelled, even if it is annotated with a Certificate name.
+			gen.CertificateRequest("standalone",
+				gen.SetCertificateRequestNamespace("testns"),
+				gen.SetCertificateRequestAnnotations(map[string]string{cmapi.CertificateNameKey: "web"}),
+			),
+		},
+	}
+	builder.Init()
+	w := &controllerWrapper{}
+	if _, _, err := w.Register(builder.Context); err != nil {
+		t.Fatal(err)
+	}
+	builder.Start()
+	defer builder.Stop()
+
+	for _, crt := range []*cmapi.Certificate{web.certificate, webAPI.certificate} {
+		key, err := controllerpkg.KeyFunc(crt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := w.controller.ProcessItem(context.Background(), key); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for certificateName, expected := range map[string][]string{
+		"web":     {"web-1", "web-2"},
+		"web-api": {"web-api-1"},
+		"other":   nil,
+	} {
+		selector := labels.SelectorFromSet(labels.Set{cmapi.CertificateNameLabelKey: certificateName})
+		list, err := builder.CMClient.CertmanagerV1().CertificateRequests("testns").List(context.Background(), metav1.ListOptions{LabelSelector: selector.String()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var names []string
+		for _, req := range list.Items {
+			names = append(names, req.Name)
+		}
+		assert.ElementsMatch(t, expected, names, "unexpected CertificateRequests selected for Certificate %q", certificateName)
+	}
+}
+
+// TestProcessItemLogKeys runs ProcessItem with a log sink which captures all
+// log lines, and checks that they identify the Certificate and the
+// CertificateRequest they relate to using the standard keys.
+func TestProcessItemLogKeys(t *testing.T) {
+	bundle := mustCreateCryptoBundle(t, &cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test", UID: "test"},
+		Spec:       cmapi.CertificateSpec{CommonName: "test-bundle"},
+	})
+	now := metav1.NewTime(time.Now())
+	crt := gen.CertificateFrom(bundle.certificate,
+		gen.SetCertificateNextPrivateKeySecretName("exists"),
+		gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue, LastTransitionTime: &now}),
+		gen.SetCertificateRevision(5),
+	)
+	// A CertificateRequest which failed during the previous issuance of the
+	// revision, so is deleted and logged as a related resource.
+	failedReq := gen.CertificateRequestFrom(bundle.certificateRequest,
+		gen.SetCertificateRequestName("test-6"),
+		gen.SetCertificateRequestAnnotations(map[string]string{
+			cmapi.CertificateRequestPrivateKeyAnnotationKey: "exists",
+			cmapi.CertificateRequestRevisionAnnotationKey:   "6",
+		}),
+		gen.AddCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+			Type:   cmapi.CertificateRequestConditionReady,
+			Status: cmmeta.ConditionFalse,
+			Reason: cmapi.CertificateRequestReasonFailed,
+		}),
+		gen.SetCertificateRequestFailureTime(metav1.NewTime(now.Add(-time.Hour))),
+	)
+
+	builder := &testpkg.Builder{
+		T:     t,
+		Clock: fakeclock.NewFakeClock(now.Time),
+		KubeObjects: []runtime.Object{
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "exists"},
+				Data:       map[string][]byte{corev1.TLSPrivateKeyKey: bundle.privateKeyBytes},
+			},
+		},
+		CertManagerObjects: []runtime.Object{crt, failedReq},
+	}
+	builder.Init()
+	w := &controllerWrapper{}
+	if _, _, err := w.Register(builder.Context); err != nil {
+		t.Fatal(err)
+	}
+	builder.Start()
+	defer builder.Stop()
+
+	var lines []map[string]interface{}
+	sink := funcr.NewJSON(func(obj string) {
+		var line map[string]interface{}
+		if err := json.Unmarshal([]byte(obj), &line); err != nil {
+			t.Errorf("invalid log line %q: %v", obj, err)
+		}
+		lines = append(lines, line)
+	}, funcr.Options{Verbosity: logf.TraceLevel})
+
+	key, err := controllerpkg.KeyFunc(crt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.controller.ProcessItem(logf.NewContext(context.Background(), sink), key); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(lines) == 0 {
+		t.Fatal("expected ProcessItem to log")
+	}
+	var relatedLogged bool
+	for _, line := range lines {
+		assert.Equal(t, "test", line[logf.ResourceNameKey], "unexpected Certificate name in %v", line)
+		assert.Equal(t, "testns", line[logf.ResourceNamespaceKey], "unexpected Certificate namespace in %v", line)
+		assert.Equal(t, "Certificate", line[logf.ResourceKindKey], "unexpected kind in %v", line)
+		assert.Equal(t, float64(5), line[logf.CertificateRevisionKey], "unexpected Certificate revision in %v", line)
+
+		if line[logf.RelatedResourceNameKey] == "test-6" {
+			relatedLogged = true
+			assert.Equal(t, "CertificateRequest", line[logf.RelatedResourceKindKey], "unexpected related kind in %v", line)
+			assert.Equal(t, "6", line[logf.CertificateRequestRevisionKey], "unexpected CertificateRequest revision in %v", line)
+		}
+	}
+	assert.True(t, relatedLogged, "expected the failed CertificateRequest to be logged as a related resource")
+}
@@ -105,15 +105,24 @@ func createCryptoBundle(originalCert *cmapi.Certificate) (*cryptoBundle, error)
 	annotations[cmapi.CertificateRequestRevisionAnnotationKey] = "NOT SET"
 	annotations[cmapi.CertificateRequestPrivateKeyAnnotationKey] = crt.Spec.SecretName
 	annotations[cmapi.CertificateNameKey] = crt.Name
+	annotations[cmapi.CertificateRequestPublicKeyFingerprintAnnotationKey], err = pki.PublicKeyFingerprintSHA256(csr.PublicKey)
+	if err != nil {
+		return nil, err
+	}
 	if crt.Status.NextPrivateKeySecretName != nil {
 		annotations[cmapi.CertificateRequestPrivateKeyAnnotationKey] = *crt.Status.NextPrivateKeySecretName
 	}
+	crLabels := map[string]string{cmapi.CertificateRequestCreatedByLabelKey: ControllerName}
+	if value, ok := certificateNameLabelValue(crt); ok {
+		crLabels[cmapi.CertificateNameLabelKey] = value
+	}
 	certificateRequest := &cmapi.CertificateRequest{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:            "NOT SET",
 			Namespace:       crt.Namespace,
 			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(crt, certificateGvk)},
 			Annotations:     annotations,
+			Labels:          crLabels,
 		},
 		Spec: cmapi.CertificateRequestSpec{
 			Request:   csrPEM,
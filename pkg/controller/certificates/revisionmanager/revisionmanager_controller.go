@@ -109,7 +109,7 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return err
 	}
 
-	log = logf.WithResource(log, crt)
+	log = logf.WithCertificate(log, crt)
 
 	// If RevisionHistoryLimit is nil, don't attempt to garbage collect old
 	// CertificateRequests
@@ -138,7 +138,7 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 
 	for _, req := range toDelete {
 		logf.WithRelatedResourceName(log, req.Name, req.Namespace, cmapi.CertificateRequestKind).
-			WithValues("revision", req.rev).Info("garbage collecting old certificate request revsion")
+			WithValues(logf.CertificateRequestRevisionKey, req.rev).Info("garbage collecting old certificate request revsion")
 		err = c.client.CertmanagerV1().CertificateRequests(req.Namespace).Delete(ctx, req.Name, metav1.DeleteOptions{})
 		if apierrors.IsNotFound(err) {
 			continue
@@ -0,0 +1,293 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package revocation
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+const (
+	ControllerName = "certificates-revocation"
+
+	// revocationRetryWindow is how long after a Certificate has been deleted
+	// revocation of its certificate is retried before the finalizer is removed
+	// regardless, so that a broken issuer cannot block deletion forever while
+	// an issuer outage of a few hours does not leave the certificate valid.
+	revocationRetryWindow = 24 * time.Hour
+
+	reasonRevoked                = "Revoked"
+	reasonRevocationFailed       = "RevocationFailed"
+	reasonRevocationNotSupported = "RevocationNotSupported"
+)
+
+type controller struct {
+	certificateLister cmlisters.CertificateLister
+	secretLister      internalinformers.SecretLister
+	helper            issuer.Helper
+	issuerFactory     issuer.Factory
+	client            cmclient.Interface
+	recorder          record.EventRecorder
+	clock             clock.Clock
+
+	queue workqueue.RateLimitingInterface
+}
+
+func NewController(log logr.Logger, ctx *controllerpkg.Context) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
+	// create a queue used to queue up items to be processed
+	// failed revocations are retried with a backoff of up to 10 minutes for
+	// the whole revocationRetryWindow
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Second*5, time.Minute*10), ControllerName)
+
+	// obtain references to all the informers used by this controller
+	certificateInformer := ctx.SharedInformerFactory.Certmanager().V1().Certificates()
+	issuerInformer := ctx.SharedInformerFactory.Certmanager().V1().Issuers()
+	secretsInformer := ctx.KubeSharedInformerFactory.Secrets()
+
+	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+
+	// build a list of InformerSynced functions that will be returned by the Register method.
+	// the controller will only begin processing items once all of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		certificateInformer.Informer().HasSynced,
+		issuerInformer.Informer().HasSynced,
+		secretsInformer.Informer().HasSynced,
+	}
+
+	// If we are running in non-namespaced mode, we also obtain a lister for
+	// ClusterIssuers.
+	var clusterIssuerLister cmlisters.ClusterIssuerLister
+	if ctx.Namespace == "" {
+		clusterIssuerInformer := ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers()
+		clusterIssuerLister = clusterIssuerInformer.Lister()
+		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
+	}
+
+	return &controller{
+		certificateLister: certificateInformer.Lister(),
+		secretLister:      secretsInformer.Lister(),
+		helper:            issuer.NewHelper(issuerInformer.Lister(), clusterIssuerLister),
+		issuerFactory:     issuer.NewFactory(ctx),
+		client:            ctx.CMClient,
+		recorder:          ctx.Recorder,
+		clock:             ctx.Clock,
+		queue:             queue,
+	}, queue, mustSync
+}
+
+// ProcessItem keeps the revocation finalizer in sync with
+// `spec.revokeOnDelete`, and revokes the certificate with its issuer once a
+// Certificate carrying the finalizer has been deleted.
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx).WithValues("key", key)
+
+	ctx = logf.NewContext(ctx, log)
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key passed to ProcessItem")
+		return nil
+	}
+
+	crt, err := c.certificateLister.Certificates(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("certificate not found for key", "error", err.Error())
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	log = logf.WithCertificate(log, crt)
+	ctx = logf.NewContext(ctx, log)
+
+	hasFinalizer := hasRevocationFinalizer(crt)
+
+	if crt.DeletionTimestamp == nil {
+		revokeOnDelete := crt.Spec.RevokeOnDelete != nil && *crt.Spec.RevokeOnDelete
+		switch {
+		case revokeOnDelete && !hasFinalizer:
+			log.V(logf.DebugLevel).Info("adding revocation finalizer")
+			crt = crt.DeepCopy()
+			crt.Finalizers = append(crt.Finalizers, cmapi.CertificateRevocationFinalizer)
+			_, err := c.client.CertmanagerV1().Certificates(crt.Namespace).Update(ctx, crt, metav1.UpdateOptions{})
+			return err
+		case !revokeOnDelete && hasFinalizer:
+			log.V(logf.DebugLevel).Info("removing revocation finalizer as revokeOnDelete is not set")
+			return c.removeFinalizer(ctx, crt)
+		}
+		return nil
+	}
+
+	if !hasFinalizer {
+		return nil
+	}
+
+	if err := c.revoke(ctx, crt); err != nil {
+		if giveUpAt := crt.DeletionTimestamp.Add(revocationRetryWindow); c.clock.Now().Before(giveUpAt) {
+			log.Error(err, "failed to revoke certificate, will retry", "attempts", c.queue.NumRequeues(key)+1, "giveUpAt", giveUpAt)
+			return err
+		}
+		log.Error(err, "failed to revoke certificate, giving up")
+		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonRevocationFailed,
+			"Failed to revoke certificate within %s of its deletion, it will remain valid until it expires: %v", revocationRetryWindow, err)
+	}
+
+	return c.removeFinalizer(ctx, crt)
+}
+
+// revoke revokes the certificate issued for the given Certificate with its
+// issuer. An Event is recorded if the issuer does not support revocation, or
+// once the certificate has been revoked.
+func (c *controller) revoke(ctx context.Context, crt *cmapi.Certificate) error {
+	genericIssuer, err := c.helper.GetGenericIssuer(crt.Spec.IssuerRef, crt.Namespace)
+	if err != nil {
+		return fmt.Errorf("error reading (cluster)issuer %q: %v", crt.Spec.IssuerRef.Name, err)
+	}
+
+	issuerObj, err := c.issuerFactory.IssuerFor(genericIssuer)
+	if err != nil {
+		return err
+	}
+
+	revoker, ok := issuerObj.(issuer.Revoker)
+	if !ok {
+		c.recordRevocationNotSupported(crt)
+		return nil
+	}
+
+	cert, err := c.issuedCertificate(crt)
+	if err != nil {
+		return err
+	}
+
+	if err := revoker.Revoke(ctx, crt, cert); err != nil {
+		if errors.Is(err, issuer.ErrRevocationNotSupported) {
+			c.recordRevocationNotSupported(crt)
+			return nil
+		}
+		return err
+	}
+
+	c.recorder.Event(crt, corev1.EventTypeNormal, reasonRevoked, "Certificate has been revoked")
+	return nil
+}
+
+func (c *controller) recordRevocationNotSupported(crt *cmapi.Certificate) {
+	c.recorder.Event(crt, corev1.EventTypeWarning, reasonRevocationNotSupported,
+		"Issuer does not support revocation, certificate will remain valid until it expires")
+}
+
+// issuedCertificate returns the certificate stored in the Certificate's
+// Secret. nil is returned if the Secret does not exist, or if it holds an
+// invalid certificate or one other than that recorded in the Certificate's
+// status.
+func (c *controller) issuedCertificate(crt *cmapi.Certificate) (*x509.Certificate, error) {
+	secret, err := c.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	certBytes := secret.Data[corev1.TLSCertKey]
+	if len(certBytes) == 0 {
+		return nil, nil
+	}
+
+	cert, err := utilpki.DecodeX509CertificateBytes(certBytes)
+	if err != nil {
+		return nil, nil
+	}
+
+	if crt.Status.SerialNumber != "" && utilpki.FormatSerialNumber(cert.SerialNumber) != crt.Status.SerialNumber {
+		return nil, nil
+	}
+
+	return cert, nil
+}
+
+func (c *controller) removeFinalizer(ctx context.Context, crt *cmapi.Certificate) error {
+	crt = crt.DeepCopy()
+	finalizers := crt.Finalizers[:0]
+	for _, f := range crt.Finalizers {
+		if f != cmapi.CertificateRevocationFinalizer {
+			finalizers = append(finalizers, f)
+		}
+	}
+	crt.Finalizers = finalizers
+
+	_, err := c.client.CertmanagerV1().Certificates(crt.Namespace).Update(ctx, crt, metav1.UpdateOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func hasRevocationFinalizer(crt *cmapi.Certificate) bool {
+	for _, f := range crt.Finalizers {
+		if f == cmapi.CertificateRevocationFinalizer {
+			return true
+		}
+	}
+	return false
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+
+	ctrl, queue, mustSync := NewController(log, ctx)
+	c.controller = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controllerWrapper{}).
+			Complete()
+	})
+}
@@ -0,0 +1,353 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package revocation
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/funcr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	issuerpkg "github.com/cert-manager/cert-manager/pkg/issuer"
+	issuerfake "github.com/cert-manager/cert-manager/pkg/issuer/fake"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+var (
+	fixedClockStart = time.Now()
+	fixedClock      = fakeclock.NewFakeClock(fixedClockStart)
+)
+
+// fakeIssuer is an issuer that does not support revocation.
+type fakeIssuer struct{}
+
+func (*fakeIssuer) Setup(context.Context) error { return nil }
+
+// fakeRevoker is an issuer that supports revocation.
+type fakeRevoker struct {
+	fakeIssuer
+	revokeFn func(context.Context, *cmapi.Certificate, *x509.Certificate) error
+}
+
+func (r *fakeRevoker) Revoke(ctx context.Context, crt *cmapi.Certificate, cert *x509.Certificate) error {
+	return r.revokeFn(ctx, crt, cert)
+}
+
+func TestProcessItem(t *testing.T) {
+	baseIssuer := gen.Issuer("test-issuer", gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca"}))
+	baseCrt := gen.Certificate("test",
+		gen.SetCertificateNamespace(baseIssuer.Namespace),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "test-issuer", Kind: "Issuer"}),
+		gen.SetCertificateSecretName("output"),
+		gen.SetCertificateDNSNames("example.com"),
+	)
+	bundle := testcrypto.MustCreateCryptoBundle(t, baseCrt, fixedClock)
+	serial := utilpki.FormatSerialNumber(bundle.Cert.SerialNumber)
+
+	secret := gen.Secret("output",
+		gen.SetSecretNamespace(baseCrt.Namespace),
+		gen.SetSecretData(map[string][]byte{corev1.TLSCertKey: bundle.CertBytes}),
+	)
+
+	deleted := gen.CertificateFrom(baseCrt,
+		gen.SetCertificateRevokeOnDelete(true),
+		gen.SetCertificateSerialNumber(serial),
+		gen.SetCertificateFinalizers(cmapi.CertificateRevocationFinalizer),
+		gen.SetCertificateDeletionTimestamp(metav1.NewTime(fixedClockStart)),
+	)
+	// The controller filters the finalizers in place, leaving an empty
+	// rather than a nil slice.
+	noFinalizers := func(crt *cmapi.Certificate) {
+		crt.Finalizers = []string{}
+	}
+	finalizerRemoved := gen.CertificateFrom(deleted, noFinalizers)
+	deletedLongAgo := gen.CertificateFrom(deleted,
+		gen.SetCertificateDeletionTimestamp(metav1.NewTime(fixedClockStart.Add(-revocationRetryWindow))),
+	)
+
+	revokeSerial := func(t *testing.T, expSerial string) func(context.Context, *cmapi.Certificate, *x509.Certificate) error {
+		return func(_ context.Context, _ *cmapi.Certificate, cert *x509.Certificate) error {
+			var got string
+			if cert != nil {
+				got = utilpki.FormatSerialNumber(cert.SerialNumber)
+			}
+			if got != expSerial {
+				t.Errorf("unexpected certificate passed to Revoke, exp serial=%q got=%q", expSerial, got)
+			}
+			return nil
+		}
+	}
+
+	tests := map[string]struct {
+		certificate     *cmapi.Certificate
+		secret          *corev1.Secret
+		issuer          func(t *testing.T) issuerpkg.Interface
+		expectedActions []testpkg.Action
+		expectedEvents  []string
+		expectedErr     bool
+	}{
+		"does nothing if revokeOnDelete is not set": {
+			certificate: baseCrt,
+		},
+		"adds the finalizer if revokeOnDelete is true": {
+			certificate: gen.CertificateFrom(baseCrt, gen.SetCertificateRevokeOnDelete(true)),
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(cmapi.SchemeGroupVersion.WithResource("certificates"), baseCrt.Namespace,
+					gen.CertificateFrom(baseCrt,
+						gen.SetCertificateRevokeOnDelete(true),
+						gen.SetCertificateFinalizers(cmapi.CertificateRevocationFinalizer),
+					),
+				)),
+			},
+		},
+		"removes the finalizer if revokeOnDelete is set to false": {
+			certificate: gen.CertificateFrom(baseCrt,
+				gen.SetCertificateRevokeOnDelete(false),
+				gen.SetCertificateFinalizers(cmapi.CertificateRevocationFinalizer),
+			),
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(cmapi.SchemeGroupVersion.WithResource("certificates"), baseCrt.Namespace,
+					gen.CertificateFrom(baseCrt, gen.SetCertificateRevokeOnDelete(false), noFinalizers),
+				)),
+			},
+		},
+		"does nothing if a deleted Certificate does not have the finalizer": {
+			certificate: gen.CertificateFrom(deleted, gen.SetCertificateFinalizers("example.com/other")),
+		},
+		"revokes the certificate stored in the Secret and removes the finalizer": {
+			certificate: deleted,
+			secret:      secret,
+			issuer: func(t *testing.T) issuerpkg.Interface {
+				return &fakeRevoker{revokeFn: revokeSerial(t, serial)}
+			},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(cmapi.SchemeGroupVersion.WithResource("certificates"), baseCrt.Namespace, finalizerRemoved)),
+			},
+			expectedEvents: []string{"Normal Revoked Certificate has been revoked"},
+		},
+		"passes no certificate to the issuer if the Secret does not exist": {
+			certificate: deleted,
+			issuer: func(t *testing.T) issuerpkg.Interface {
+				return &fakeRevoker{revokeFn: revokeSerial(t, "")}
+			},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(cmapi.SchemeGroupVersion.WithResource("certificates"), baseCrt.Namespace, finalizerRemoved)),
+			},
+			expectedEvents: []string{"Normal Revoked Certificate has been revoked"},
+		},
+		"passes no certificate to the issuer if the Secret holds a different certificate": {
+			certificate: gen.CertificateFrom(deleted, gen.SetCertificateSerialNumber("01")),
+			secret:      secret,
+			issuer: func(t *testing.T) issuerpkg.Interface {
+				return &fakeRevoker{revokeFn: revokeSerial(t, "")}
+			},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(cmapi.SchemeGroupVersion.WithResource("certificates"), baseCrt.Namespace,
+					gen.CertificateFrom(finalizerRemoved, gen.SetCertificateSerialNumber("01")),
+				)),
+			},
+			expectedEvents: []string{"Normal Revoked Certificate has been revoked"},
+		},
+		"removes the finalizer if the issuer does not support revocation": {
+			certificate: deleted,
+			secret:      secret,
+			issuer: func(t *testing.T) issuerpkg.Interface {
+				return &fakeIssuer{}
+			},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(cmapi.SchemeGroupVersion.WithResource("certificates"), baseCrt.Namespace, finalizerRemoved)),
+			},
+			expectedEvents: []string{"Warning RevocationNotSupported Issuer does not support revocation, certificate will remain valid until it expires"},
+		},
+		"removes the finalizer if the issuer is not configured to support revocation": {
+			certificate: deleted,
+			secret:      secret,
+			issuer: func(t *testing.T) issuerpkg.Interface {
+				return &fakeRevoker{revokeFn: func(context.Context, *cmapi.Certificate, *x509.Certificate) error {
+					return fmt.Errorf("no CRL is configured: %w", issuerpkg.ErrRevocationNotSupported)
+				}}
+			},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(cmapi.SchemeGroupVersion.WithResource("certificates"), baseCrt.Namespace, finalizerRemoved)),
+			},
+			expectedEvents: []string{"Warning RevocationNotSupported Issuer does not support revocation, certificate will remain valid until it expires"},
+		},
+		"retries if revocation fails": {
+			certificate: deleted,
+			secret:      secret,
+			issuer: func(t *testing.T) issuerpkg.Interface {
+				return &fakeRevoker{revokeFn: func(context.Context, *cmapi.Certificate, *x509.Certificate) error {
+					return errors.New("this is an error")
+				}}
+			},
+			expectedErr: true,
+		},
+		"removes the finalizer once revocation has failed for too long": {
+			certificate: deletedLongAgo,
+			secret:      secret,
+			issuer: func(t *testing.T) issuerpkg.Interface {
+				return &fakeRevoker{revokeFn: func(context.Context, *cmapi.Certificate, *x509.Certificate) error {
+					return errors.New("this is an error")
+				}}
+			},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(cmapi.SchemeGroupVersion.WithResource("certificates"), baseCrt.Namespace, gen.CertificateFrom(deletedLongAgo, noFinalizers))),
+			},
+			expectedEvents: []string{"Warning RevocationFailed Failed to revoke certificate within 24h0m0s of its deletion, it will remain valid until it expires: this is an error"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var kubeObjects []runtime.Object
+			if test.secret != nil {
+				kubeObjects = append(kubeObjects, test.secret)
+			}
+
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fixedClock,
+				KubeObjects:        kubeObjects,
+				CertManagerObjects: []runtime.Object{test.certificate, baseIssuer},
+				ExpectedActions:    test.expectedActions,
+				ExpectedEvents:     test.expectedEvents,
+			}
+			builder.Init()
+
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+			w.controller.issuerFactory = &issuerfake.Factory{
+				IssuerForFunc: func(cmapi.GenericIssuer) (issuerpkg.Interface, error) {
+					if test.issuer == nil {
+						t.Fatal("unexpected call to IssuerFor")
+					}
+					return test.issuer(t), nil
+				},
+			}
+			builder.Start()
+			defer builder.Stop()
+
+			key, err := controllerpkg.KeyFunc(test.certificate)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = w.controller.ProcessItem(context.Background(), key)
+			if (err != nil) != test.expectedErr {
+				t.Errorf("unexpected error, exp=%t got=%v", test.expectedErr, err)
+			}
+
+			if err := builder.AllEventsCalled(); err != nil {
+				t.Error(err)
+			}
+			if err := builder.AllActionsExecuted(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// TestProcessItemLogKeys runs ProcessItem with a log sink which captures all
+// log lines, and checks that they identify the Certificate using the standard
+// keys.
+func TestProcessItemLogKeys(t *testing.T) {
+	issuer := gen.Issuer("test-issuer", gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca"}))
+	crt := gen.Certificate("test",
+		gen.SetCertificateNamespace(issuer.Namespace),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "test-issuer", Kind: "Issuer"}),
+		gen.SetCertificateSecretName("output"),
+		gen.SetCertificateDNSNames("example.com"),
+		gen.SetCertificateRevokeOnDelete(true),
+		gen.SetCertificateFinalizers(cmapi.CertificateRevocationFinalizer),
+		gen.SetCertificateDeletionTimestamp(metav1.NewTime(fixedClockStart)),
+		gen.SetCertificateRevision(2),
+	)
+	bundle := testcrypto.MustCreateCryptoBundle(t, crt, fixedClock)
+	secret := gen.Secret("output",
+		gen.SetSecretNamespace(crt.Namespace),
+		gen.SetSecretData(map[string][]byte{corev1.TLSCertKey: bundle.CertBytes}),
+	)
+
+	builder := &testpkg.Builder{
+		T:                  t,
+		Clock:              fixedClock,
+		KubeObjects:        []runtime.Object{secret},
+		CertManagerObjects: []runtime.Object{crt, issuer},
+	}
+	builder.Init()
+	w := &controllerWrapper{}
+	if _, _, err := w.Register(builder.Context); err != nil {
+		t.Fatal(err)
+	}
+	w.controller.issuerFactory = &issuerfake.Factory{
+		IssuerForFunc: func(cmapi.GenericIssuer) (issuerpkg.Interface, error) {
+			return &fakeRevoker{revokeFn: func(context.Context, *cmapi.Certificate, *x509.Certificate) error {
+				return errors.New("this is an error")
+			}}, nil
+		},
+	}
+	builder.Start()
+	defer builder.Stop()
+
+	var lines []map[string]interface{}
+	sink := funcr.NewJSON(func(obj string) {
+		var line map[string]interface{}
+		if err := json.Unmarshal([]byte(obj), &line); err != nil {
+			t.Errorf("invalid log line %q: %v", obj, err)
+		}
+		lines = append(lines, line)
+	}, funcr.Options{Verbosity: logf.TraceLevel})
+
+	key, err := controllerpkg.KeyFunc(crt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.controller.ProcessItem(logf.NewContext(context.Background(), sink), key); err == nil {
+		t.Fatal("expected revocation to fail")
+	}
+
+	var errorLogged bool
+	for _, line := range lines {
+		assert.Equal(t, "test", line[logf.ResourceNameKey], "unexpected Certificate name in %v", line)
+		assert.Equal(t, crt.Namespace, line[logf.ResourceNamespaceKey], "unexpected Certificate namespace in %v", line)
+		assert.Equal(t, "Certificate", line[logf.ResourceKindKey], "unexpected kind in %v", line)
+		assert.Equal(t, float64(2), line[logf.CertificateRevisionKey], "unexpected Certificate revision in %v", line)
+		if line["error"] == "this is an error" {
+			errorLogged = true
+		}
+	}
+	assert.True(t, errorLogged, "expected the revocation error to be logged")
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trigger
+
+import (
+	"fmt"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// issuanceWindowTimeLayout is the layout of the start and end times of the
+// ranges of an issuance window.
+const issuanceWindowTimeLayout = "15:04"
+
+// issuanceWindowOpensAt returns now if the issuance window is open, otherwise
+// the time at which it next opens, in the time zone of the window.
+// The ranges of the window are wall clock times in its time zone, so that they
+// follow daylight saving time transitions: a range is open whenever the wall
+// clock shows a time within it, and a range starting at a time which does not
+// exist on the day of a transition is skipped on that day.
+func issuanceWindowOpensAt(window *cmapi.CertificateIssuanceWindow, now time.Time) (time.Time, error) {
+	loc := time.UTC
+	if window.TimeZone != "" {
+		var err error
+		if loc, err = time.LoadLocation(window.TimeZone); err != nil {
+			return time.Time{}, fmt.Errorf("invalid issuance window time zone %q: %w", window.TimeZone, err)
+		}
+	}
+
+	local := now.In(loc)
+	var opensAt time.Time
+	for _, r := range window.Ranges {
+		start, err := time.Parse(issuanceWindowTimeLayout, r.Start)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid issuance window start time %q: %w", r.Start, err)
+		}
+		end, err := time.Parse(issuanceWindowTimeLayout, r.End)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid issuance window end time %q: %w", r.End, err)
+		}
+
+		if inDailyRange(local, start, end) {
+			return local, nil
+		}
+
+		// A range opens again within a day, but as a day may be 23 or 25
+		// hours long the day after is checked too.
+		for days := 0; days <= 2; days++ {
+			t := time.Date(local.Year(), local.Month(), local.Day()+days, start.Hour(), start.Minute(), 0, 0, loc)
+			if !t.After(now) || minuteOfDay(t) != minuteOfDay(start) {
+				continue
+			}
+			if opensAt.IsZero() || t.Before(opensAt) {
+				opensAt = t
+			}
+			break
+		}
+	}
+
+	if opensAt.IsZero() {
+		return time.Time{}, fmt.Errorf("issuance window has no ranges")
+	}
+	return opensAt, nil
+}
+
+// inDailyRange returns true if the time of day of t is at or after the time of
+// day of start and before that of end. If end is before start, the range ends
+// on the next day.
+func inDailyRange(t, start, end time.Time) bool {
+	m, s, e := minuteOfDay(t), minuteOfDay(start), minuteOfDay(end)
+	if s < e {
+		return s <= m && m < e
+	}
+	return m >= s || m < e
+}
+
+func minuteOfDay(t time.Time) int {
+	return t.Hour()*60 + t.Minute()
+}
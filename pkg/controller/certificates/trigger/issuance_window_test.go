@@ -0,0 +1,141 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trigger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+func Test_issuanceWindowOpensAt(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	require.NoError(t, err)
+
+	window := func(timeZone string, ranges ...string) *cmapi.CertificateIssuanceWindow {
+		w := &cmapi.CertificateIssuanceWindow{TimeZone: timeZone}
+		for i := 0; i < len(ranges); i += 2 {
+			w.Ranges = append(w.Ranges, cmapi.CertificateIssuanceWindowRange{Start: ranges[i], End: ranges[i+1]})
+		}
+		return w
+	}
+
+	tests := map[string]struct {
+		window *cmapi.CertificateIssuanceWindow
+		now    time.Time
+
+		expOpensAt time.Time
+		expErr     string
+	}{
+		"should return now if the window is open": {
+			window:     window("", "09:00", "17:00"),
+			now:        time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC),
+			expOpensAt: time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC),
+		},
+		"should include the start of a range": {
+			window:     window("", "09:00", "17:00"),
+			now:        time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC),
+			expOpensAt: time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC),
+		},
+		"should exclude the end of a range": {
+			window:     window("", "09:00", "17:00"),
+			now:        time.Date(2026, 6, 1, 17, 0, 0, 0, time.UTC),
+			expOpensAt: time.Date(2026, 6, 2, 9, 0, 0, 0, time.UTC),
+		},
+		"should return the start of the range later on the same day": {
+			window:     window("", "09:00", "17:00"),
+			now:        time.Date(2026, 6, 1, 7, 30, 0, 0, time.UTC),
+			expOpensAt: time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC),
+		},
+		"should return the earliest start of multiple ranges": {
+			window:     window("", "20:00", "21:00", "18:00", "19:00"),
+			now:        time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC),
+			expOpensAt: time.Date(2026, 6, 1, 18, 0, 0, 0, time.UTC),
+		},
+		"should be open after midnight in a range ending on the next day": {
+			window:     window("", "22:00", "02:00"),
+			now:        time.Date(2026, 6, 1, 1, 0, 0, 0, time.UTC),
+			expOpensAt: time.Date(2026, 6, 1, 1, 0, 0, 0, time.UTC),
+		},
+		"should return the start of a range ending on the next day": {
+			window:     window("", "22:00", "02:00"),
+			now:        time.Date(2026, 6, 1, 3, 0, 0, 0, time.UTC),
+			expOpensAt: time.Date(2026, 6, 1, 22, 0, 0, 0, time.UTC),
+		},
+		"should interpret the ranges in the time zone of the window": {
+			window:     window("Europe/London", "09:00", "17:00"),
+			now:        time.Date(2026, 6, 1, 16, 30, 0, 0, time.UTC),
+			expOpensAt: time.Date(2026, 6, 2, 9, 0, 0, 0, london),
+		},
+		"should open at the same wall clock time after the clocks go forward": {
+			window:     window("Europe/London", "09:00", "17:00"),
+			now:        time.Date(2026, 3, 28, 18, 0, 0, 0, time.UTC),
+			expOpensAt: time.Date(2026, 3, 29, 8, 0, 0, 0, time.UTC),
+		},
+		"should open at the same wall clock time after the clocks go back": {
+			window:     window("Europe/London", "09:00", "17:00"),
+			now:        time.Date(2026, 10, 24, 17, 0, 0, 0, time.UTC),
+			expOpensAt: time.Date(2026, 10, 25, 9, 0, 0, 0, time.UTC),
+		},
+		"should be open at a wall clock time in the range just after the clocks go forward": {
+			window:     window("Europe/London", "02:00", "03:00"),
+			now:        time.Date(2026, 3, 29, 1, 15, 0, 0, time.UTC),
+			expOpensAt: time.Date(2026, 3, 29, 2, 15, 0, 0, london),
+		},
+		"should be open during the repeated hour when the clocks go back": {
+			window:     window("Europe/London", "01:00", "02:00"),
+			now:        time.Date(2026, 10, 25, 1, 30, 0, 0, time.UTC),
+			expOpensAt: time.Date(2026, 10, 25, 1, 30, 0, 0, time.UTC),
+		},
+		"should skip a range starting at a time which does not exist when the clocks go forward": {
+			window:     window("Europe/London", "01:30", "01:45"),
+			now:        time.Date(2026, 3, 28, 12, 0, 0, 0, time.UTC),
+			expOpensAt: time.Date(2026, 3, 30, 1, 30, 0, 0, london),
+		},
+		"should return an error for an unknown time zone": {
+			window: window("Europe/Nowhere", "09:00", "17:00"),
+			now:    time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC),
+			expErr: `invalid issuance window time zone "Europe/Nowhere": unknown time zone Europe/Nowhere`,
+		},
+		"should return an error for an invalid time": {
+			window: window("", "9am", "17:00"),
+			now:    time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC),
+			expErr: `invalid issuance window start time "9am": parsing time "9am" as "15:04": cannot parse "am" as ":"`,
+		},
+		"should return an error if there are no ranges": {
+			window: window(""),
+			now:    time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC),
+			expErr: "issuance window has no ranges",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			opensAt, err := issuanceWindowOpensAt(test.window, test.now)
+			if test.expErr != "" {
+				assert.EqualError(t, err, test.expErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, test.expOpensAt.Equal(opensAt), "expected the window to open at %s, got %s", test.expOpensAt, opensAt)
+		})
+	}
+}
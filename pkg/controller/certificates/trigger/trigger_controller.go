@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"slices"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -43,6 +44,7 @@ import (
 	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/scheduler"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
@@ -56,6 +58,23 @@ const (
 	stopIncreaseBackoff = 6 // 2 ^ (6 - 1) = 32 = maxDelay
 	// maxDelay is the maximum backoff period
 	maxDelay = 32 * time.Hour
+
+	// reasonIssuanceCancelled is the reason of the Issuing condition of a
+	// Certificate whose restored issuance was cancelled.
+	reasonIssuanceCancelled = "IssuanceCancelled"
+	// reasonIssuanceDeferred is the reason of the event recorded when a
+	// re-issuance is deferred until the issuance window opens.
+	reasonIssuanceDeferred = "IssuanceDeferred"
+	// reasonIssuanceWindowOverridden is the reason of the event recorded
+	// when a Certificate is re-issued outside of its issuance window, as it
+	// would otherwise expire.
+	reasonIssuanceWindowOverridden = "IssuanceWindowOverridden"
+
+	// maxIssuanceWindowMargin is the longest period before the expiry of a
+	// certificate within which its re-issuance is never deferred until the
+	// issuance window opens, so that there is time left to retry a failed
+	// issuance once the window has opened.
+	maxIssuanceWindowMargin = 24 * time.Hour
 )
 
 // This controller observes the state of the certificate's currently
@@ -76,24 +95,30 @@ type controller struct {
 	// Apply API calls.
 	fieldManager string
 
+	// namespace is the single namespace cert-manager is scoped to, or empty
+	// if cert-manager is watching all namespaces.
+	namespace string
+
 	// The following are used for testing purposes.
 	clock              clock.Clock
-	shouldReissue      policies.Func
+	triggerPolicies    policies.NamedChain
 	dataForCertificate func(context.Context, *cmapi.Certificate) (policies.Input, error)
 }
 
 func NewController(
 	log logr.Logger,
 	ctx *controllerpkg.Context,
-	shouldReissue policies.Func,
+	triggerPolicies policies.NamedChain,
 ) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
-	// create a queue used to queue up items to be processed
-	queue := workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Second*1, time.Second*30), ControllerName)
-
 	// obtain references to all the informers used by this controller
 	certificateInformer := ctx.SharedInformerFactory.Certmanager().V1().Certificates()
 	certificateRequestInformer := ctx.SharedInformerFactory.Certmanager().V1().CertificateRequests()
 	secretsInformer := ctx.KubeSharedInformerFactory.Secrets()
+	issuerInformer := ctx.SharedInformerFactory.Certmanager().V1().Issuers()
+
+	// create a queue used to queue up items to be processed, in which
+	// Certificates which are about to expire are processed first
+	queue := certificates.NewIssuanceQueue(ControllerName, ctx, certificateInformer.Lister(), secretsInformer.Lister())
 
 	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
 
@@ -104,8 +129,7 @@ func NewController(
 	// When a Secret resource changes, enqueue any Certificate resources that name it as spec.secretName.
 	secretsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
 		// Trigger reconciles on changes to the Secret named `spec.secretName`
-		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
-			predicate.ExtractResourceName(predicate.CertificateSecretName)),
+		WorkFunc: certificates.EnqueueCertificatesForSecret(log, queue, certificateInformer),
 	})
 
 	// build a list of InformerSynced functions that will be returned by the Register method.
@@ -114,6 +138,16 @@ func NewController(
 		certificateRequestInformer.Informer().HasSynced,
 		secretsInformer.Informer().HasSynced,
 		certificateInformer.Informer().HasSynced,
+		issuerInformer.Informer().HasSynced,
+	}
+
+	// If we are running in non-namespaced mode, we also obtain a lister for
+	// ClusterIssuers.
+	var clusterIssuerLister cmlisters.ClusterIssuerLister
+	if ctx.Namespace == "" {
+		clusterIssuerInformer := ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers()
+		clusterIssuerLister = clusterIssuerInformer.Lister()
+		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
 	}
 
 	return &controller{
@@ -124,13 +158,20 @@ func NewController(
 		recorder:                 ctx.Recorder,
 		scheduledWorkQueue:       scheduler.NewScheduledWorkQueue(ctx.Clock, queue.Add),
 		fieldManager:             ctx.FieldManager,
+		namespace:                ctx.Namespace,
 
 		// The following are used for testing purposes.
-		clock:         ctx.Clock,
-		shouldReissue: shouldReissue,
+		clock:           ctx.Clock,
+		triggerPolicies: triggerPolicies,
 		dataForCertificate: (&policies.Gatherer{
 			CertificateRequestLister: certificateRequestInformer.Lister(),
 			SecretLister:             secretsInformer.Lister(),
+			Clock:                    ctx.Clock,
+			// The issuer's CA is used to verify the CA which signed the
+			// certificate, see policies.SecretIssuerCAUnexpected.
+			OptionalInputs:           []policies.OptionalInput{policies.IssuerCAInput},
+			IssuerHelper:             issuer.NewHelper(issuerInformer.Lister(), clusterIssuerLister),
+			ClusterResourceNamespace: ctx.IssuerOptions.ClusterResourceNamespace,
 		}).DataForCertificate,
 	}, queue, mustSync
 }
@@ -152,11 +193,24 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	if err != nil {
 		return err
 	}
+
+	log = logf.WithCertificate(log, crt)
+	ctx = logf.NewContext(ctx, log)
+
 	if apiutil.CertificateHasCondition(crt, cmapi.CertificateCondition{
 		Type:   cmapi.CertificateConditionIssuing,
 		Status: cmmeta.ConditionTrue,
 	}) {
-		// Do nothing if an issuance is already in progress.
+		// Do nothing if an issuance is already in progress, unless it was
+		// restored from a backup and is not required.
+		return c.cancelRestoredIssuance(ctx, crt)
+	}
+
+	// ClusterIssuers cannot be used when cert-manager is scoped to a single
+	// namespace, so an issuance would never succeed. The readiness controller
+	// reports this on the Certificate's Ready condition.
+	if _, message, unsupported := policies.ClusterIssuerUnsupported(c.namespace)(policies.Input{Certificate: crt}); unsupported {
+		log.V(logf.InfoLevel).Info("Not triggering issuance: " + message)
 		return nil
 	}
 
@@ -202,20 +256,56 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		c.scheduleRecheckOfCertificateIfRequired(log, key, crt.Status.RenewalTime.Time.Sub(c.clock.Now()))
 	}
 
-	reason, message, reissue := c.shouldReissue(input)
+	results := c.triggerPolicies.Results(input)
+	reason, message, reissue := policies.Violation(results)
+
+	// The outcome of the evaluation is recorded along with any other change
+	// to the status below, or on its own if there is none.
+	evaluationChanged := false
+	if evaluation := c.lastEvaluation(crt, results); evaluation != nil {
+		crt = crt.DeepCopy()
+		crt.Status.LastEvaluation = evaluation
+		evaluationChanged = true
+	}
+
 	if !reissue {
+		// A re-issuance which was deferred until the issuance window opens
+		// may no longer be required.
+		if crt.Status.NextIssuanceWindowTime != nil {
+			crt = crt.DeepCopy()
+			crt.Status.NextIssuanceWindowTime = nil
+			return c.updateOrApplyStatus(ctx, crt)
+		}
+		if evaluationChanged {
+			return c.updateOrApplyStatus(ctx, crt)
+		}
 		// no re-issuance required, return early
 		return nil
 	}
 
+	// Certificates which have not been issued yet are never deferred, as
+	// there is no certificate to rotate. Neither are Certificates whose
+	// Secret is missing or invalid, as their certificate cannot be used
+	// until it is re-issued.
+	if crt.Spec.IssuanceWindow != nil && crt.Status.NotAfter != nil && policies.IssuingReason(reason) != policies.IssuingReasonSecretInvalid {
+		deferred, err := c.deferToIssuanceWindow(ctx, key, crt, evaluationChanged)
+		if err != nil || deferred {
+			return err
+		}
+	}
+
 	// Although the below recorder.Event already logs the event, the log
 	// line is quite unreadable (very long). Since this information is very
 	// important for the user and the operator, we log the following
 	// message.
 	log.V(logf.InfoLevel).Info("Certificate must be re-issued", "reason", reason, "message", message)
 
+	// The Issuing reason is the category of the violated policy, so that
+	// e.g. routine renewals can be told apart from re-issuances caused by a
+	// change of the Certificate's spec.
 	crt = crt.DeepCopy()
-	apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionIssuing, cmmeta.ConditionTrue, reason, message)
+	crt.Status.NextIssuanceWindowTime = nil
+	apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionIssuing, cmmeta.ConditionTrue, policies.IssuingReason(reason), message)
 	if err := c.updateOrApplyStatus(ctx, crt); err != nil {
 		return err
 	}
@@ -224,9 +314,148 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	return nil
 }
 
+// deferToIssuanceWindow returns true if the re-issuance of the Certificate
+// must be deferred as its issuance window is closed. The time at which the
+// window next opens is then recorded in the Certificate's status, and the
+// Certificate is re-queued for that time.
+// The re-issuance is not deferred if the certificate expires before the
+// window opens, or too soon after to leave time to retry a failed issuance,
+// in which case a warning event is recorded instead.
+// statusChanged is true if the status of the given Certificate must be
+// written even if the deferral has already been recorded.
+func (c *controller) deferToIssuanceWindow(ctx context.Context, key string, crt *cmapi.Certificate, statusChanged bool) (bool, error) {
+	log := logf.FromContext(ctx)
+
+	now := c.clock.Now()
+	opensAt, err := issuanceWindowOpensAt(crt.Spec.IssuanceWindow, now)
+	if err != nil {
+		return false, err
+	}
+	if !opensAt.After(now) {
+		return false, nil
+	}
+
+	if margin := issuanceWindowMargin(crt); !crt.Status.NotAfter.Time.After(opensAt.Add(margin)) {
+		message := fmt.Sprintf("Re-issuing outside of the issuance window as the certificate expires at %s, less than %s after the window opens at %s",
+			crt.Status.NotAfter.Time.In(opensAt.Location()).Format(time.RFC3339), margin, opensAt.Format(time.RFC3339))
+		log.V(logf.InfoLevel).Info(message)
+		c.recorder.Event(crt, corev1.EventTypeWarning, reasonIssuanceWindowOverridden, message)
+		return false, nil
+	}
+
+	c.scheduleRecheckOfCertificateIfRequired(log, key, opensAt.Sub(now))
+
+	if crt.Status.NextIssuanceWindowTime != nil && crt.Status.NextIssuanceWindowTime.Time.Equal(opensAt) {
+		if statusChanged {
+			return true, c.updateOrApplyStatus(ctx, crt)
+		}
+		return true, nil
+	}
+
+	message := fmt.Sprintf("Re-issuance deferred until the issuance window opens at %s", opensAt.Format(time.RFC3339))
+	log.V(logf.InfoLevel).Info(message)
+
+	crt = crt.DeepCopy()
+	crt.Status.NextIssuanceWindowTime = &metav1.Time{Time: opensAt.UTC()}
+	if err := c.updateOrApplyStatus(ctx, crt); err != nil {
+		return false, err
+	}
+	c.recorder.Event(crt, corev1.EventTypeNormal, reasonIssuanceDeferred, message)
+
+	return true, nil
+}
+
+// cancelRestoredIssuance marks the issuance of a restored Certificate as no
+// longer in progress if no CertificateRequest exists for either its current or
+// its next revision, and the trigger policies find that its Secret does not
+// need to be reissued.
+// This happens when a Certificate is restored from a backup, along with its
+// Secret and its Issuing condition, but without its CertificateRequests.
+// Continuing the issuance would cause a pointless reissuance of every such
+// Certificate, although the restored Secrets are still valid.
+// A Certificate is only considered restored if its Issuing condition was set
+// before the Certificate was created. Only issuances triggered by the trigger
+// policies are cancelled: other issuances, e.g. triggered manually or by the
+// rotation of a CA issuer's signing CA certificate, are never cancelled, as
+// the trigger policies are not the reason for them.
+func (c *controller) cancelRestoredIssuance(ctx context.Context, crt *cmapi.Certificate) error {
+	log := logf.FromContext(ctx)
+
+	// A Certificate without a revision has never been issued, so there is no
+	// restored Secret to keep.
+	cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing)
+	if crt.Status.Revision == nil || !policies.IsTriggerPolicyReason(cond.Reason) || !restored(crt, cond) {
+		return nil
+	}
+
+	input, err := c.dataForCertificate(ctx, crt)
+	if err != nil {
+		return err
+	}
+	if input.CurrentRevisionRequest != nil || input.NextRevisionRequest != nil {
+		return nil
+	}
+	if _, _, reissue := c.triggerPolicies.Evaluate(input); reissue {
+		return nil
+	}
+
+	message := fmt.Sprintf("Issuance cancelled as no CertificateRequest exists for revision %d or %d and the existing Secret is up to date, e.g. after a restore from a backup",
+		*crt.Status.Revision, *crt.Status.Revision+1)
+	log.V(logf.InfoLevel).Info(message)
+
+	crt = crt.DeepCopy()
+	apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionIssuing, cmmeta.ConditionFalse, reasonIssuanceCancelled, message)
+	if err := c.updateOrApplyStatus(ctx, crt); err != nil {
+		return err
+	}
+	c.recorder.Event(crt, corev1.EventTypeNormal, reasonIssuanceCancelled, message)
+
+	return nil
+}
+
+// restored returns true if the Certificate's Issuing condition was set before
+// the Certificate was created, which means that the Certificate was restored
+// along with its status, e.g. from a backup.
+func restored(crt *cmapi.Certificate, cond *cmapi.CertificateCondition) bool {
+	return cond.LastTransitionTime != nil && cond.LastTransitionTime.Before(&crt.CreationTimestamp)
+}
+
+// issuanceWindowMargin returns the period before the expiry of the
+// Certificate's certificate within which its re-issuance is not deferred: a
+// third of the certificate's duration, at most maxIssuanceWindowMargin.
+func issuanceWindowMargin(crt *cmapi.Certificate) time.Duration {
+	if crt.Status.NotBefore == nil {
+		return maxIssuanceWindowMargin
+	}
+	return min(maxIssuanceWindowMargin, crt.Status.NotAfter.Sub(crt.Status.NotBefore.Time)/3)
+}
+
+// lastEvaluation returns the evaluation to record in the status of the
+// Certificate for the given results of the trigger policies, or nil if the
+// recorded evaluation has the same outcome, so that the status is not written
+// on every evaluation. Messages are not compared, as they are only a human
+// readable description of the outcome.
+func (c *controller) lastEvaluation(crt *cmapi.Certificate, results []cmapi.CertificatePolicyResult) *cmapi.CertificatePolicyEvaluation {
+	if !utilfeature.DefaultFeatureGate.Enabled(feature.CertificatePolicyEvaluationStatus) {
+		return nil
+	}
+	if last := crt.Status.LastEvaluation; last != nil && slices.EqualFunc(last.Policies, results, sameOutcome) {
+		return nil
+	}
+	return &cmapi.CertificatePolicyEvaluation{
+		EvaluationTime: metav1.NewTime(c.clock.Now()),
+		Policies:       results,
+	}
+}
+
+func sameOutcome(a, b cmapi.CertificatePolicyResult) bool {
+	return a.PolicyName == b.PolicyName && a.Violated == b.Violated && a.Reason == b.Reason
+}
+
 // updateOrApplyStatus will update the controller status. If the
-// ServerSideApply feature is enabled, the managed fields will instead get
-// applied using the relevant Patch API call.
+// ServerSideApply feature is enabled, the managed fields, which are the
+// Issuing condition, the next issuance window time and the last evaluation,
+// will instead get applied using the relevant Patch API call.
 func (c *controller) updateOrApplyStatus(ctx context.Context, crt *cmapi.Certificate) error {
 	if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
 		var conditions []cmapi.CertificateCondition
@@ -235,7 +464,11 @@ func (c *controller) updateOrApplyStatus(ctx context.Context, crt *cmapi.Certifi
 		}
 		return internalcertificates.ApplyStatus(ctx, c.client, c.fieldManager, &cmapi.Certificate{
 			ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace, Name: crt.Name},
-			Status:     cmapi.CertificateStatus{Conditions: conditions},
+			Status: cmapi.CertificateStatus{
+				Conditions:             conditions,
+				NextIssuanceWindowTime: crt.Status.NextIssuanceWindowTime,
+				LastEvaluation:         crt.Status.LastEvaluation,
+			},
 		})
 	} else {
 		_, err := c.client.CertmanagerV1().Certificates(crt.Namespace).UpdateStatus(ctx, crt, metav1.UpdateOptions{})
@@ -249,6 +482,10 @@ func (c *controller) updateOrApplyStatus(ctx context.Context, crt *cmapi.Certifi
 // failure occurred,
 // so the returned delay will be backoff_period - (current_time - last_failure_time)
 //
+// The backoff period never exceeds a third of the Certificate's duration, so
+// that short-lived certificates are retried before they expire. For example,
+// a certificate with a duration of 30 minutes is retried every 10 minutes.
+//
 // Notably, it returns no back-off when the certificate doesn't
 // match the "next" certificate (since a mismatch means that this certificate
 // gets re-issued immediately).
@@ -287,7 +524,12 @@ func shouldBackoffReissuingOnFailure(log logr.Logger, c clock.Clock, crt *cmapi.
 	now := c.Now()
 	durationSinceFailure := now.Sub(crt.Status.LastFailureTime.Time)
 
-	initialDelay := time.Hour
+	// Never back off for longer than the default renewal window of the
+	// certificate, otherwise a short-lived certificate could expire before
+	// issuance is retried.
+	delayCap := min(maxDelay, apiutil.DefaultCertDuration(crt.Spec.Duration)/3)
+
+	initialDelay := min(time.Hour, delayCap)
 	delay := initialDelay
 	failedIssuanceAttempts := 0
 	// It is possible that crt.Status.LastFailureTime != nil &&
@@ -296,19 +538,20 @@ func shouldBackoffReissuingOnFailure(log logr.Logger, c clock.Clock, crt *cmapi.
 	// attempts were introduced). In such case delay = initialDelay.
 	if crt.Status.FailedIssuanceAttempts != nil {
 		failedIssuanceAttempts = *crt.Status.FailedIssuanceAttempts
-		delay = time.Hour * time.Duration(math.Pow(2, float64(failedIssuanceAttempts-1)))
+		delay = initialDelay * time.Duration(math.Pow(2, float64(failedIssuanceAttempts-1)))
 	}
 
-	// Ensure that maximum returned delay is 32 hours
+	// Ensure that maximum returned delay is 32 hours, or the cap for the
+	// certificate's duration if that is shorter.
 	// delay cannot be calculated for large issuance numbers, so we
 	// cannot reliably check if delay > maxDelay directly
 	// (see i.e the result of time.Duration(math.Pow(2, 99)))
-	if failedIssuanceAttempts > stopIncreaseBackoff {
-		delay = maxDelay
+	if failedIssuanceAttempts > stopIncreaseBackoff || delay > delayCap {
+		delay = delayCap
 	}
 
-	// Ensure that minimum returned delay is 1 hour. This is here to guard
-	// against an edge case where the delay duration got messed
+	// Ensure that minimum returned delay is the initial delay. This is here
+	// to guard against an edge case where the delay duration got messed
 	// up as a result of maths misuse in the previous calculations
 	if delay < initialDelay {
 		delay = initialDelay
@@ -355,7 +598,7 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 
 	ctrl, queue, mustSync := NewController(log,
 		ctx,
-		policies.NewTriggerPolicyChain(ctx.Clock).Evaluate,
+		policies.NewNamedTriggerPolicyChain(ctx.Clock, ctx.CertificateOptions.PrivateKeyDefaults),
 	)
 	c.controller = ctrl
 
@@ -28,13 +28,17 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	coretesting "k8s.io/client-go/testing"
+	featuregatetesting "k8s.io/component-base/featuregate/testing"
 	fakeclock "k8s.io/utils/clock/testing"
 	"k8s.io/utils/ptr"
 
 	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
 )
@@ -42,6 +46,9 @@ import (
 func Test_controller_ProcessItem(t *testing.T) {
 	fixedNow := metav1.NewTime(time.Now())
 	fixedClock := fakeclock.NewFakeClock(fixedNow.Time)
+	// restoredTransitionTime is the time at which the Issuing condition of a
+	// Certificate restored from a backup was set, before it was created.
+	restoredTransitionTime := metav1.NewTime(fixedNow.Add(-24 * time.Hour))
 
 	// We don't need to full bundle, just a simple CertificateRequest.
 	createCertificateRequestOrPanic := func(crt *cmapi.Certificate) *cmapi.CertificateRequest {
@@ -99,6 +106,126 @@ func Test_controller_ProcessItem(t *testing.T) {
 				}),
 			),
 		},
+		"should cancel a restored issuance if no CertificateRequest exists for the current or next revision and the Secret is up to date": {
+			existingCertificate: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateGeneration(42),
+				gen.SetCertificateRevision(12),
+				gen.SetCertificateCreationTimestamp(fixedNow),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+					Type:               "Issuing",
+					Status:             "True",
+					Reason:             "Renewal",
+					LastTransitionTime: &restoredTransitionTime,
+					ObservedGeneration: 42,
+				}),
+			),
+			wantDataForCertificateCalled: true,
+			mockDataForCertificateReturn: policies.Input{
+				Secret: gen.Secret("secret-1", gen.SetSecretNamespace("testns")),
+			},
+			wantShouldReissueCalled: true,
+			mockShouldReissue: func(*testing.T) policies.Func {
+				return func(policies.Input) (string, string, bool) {
+					return "", "", false
+				}
+			},
+			wantEvent: "Normal IssuanceCancelled Issuance cancelled as no CertificateRequest exists for revision 12 or 13 and the existing Secret is up to date, e.g. after a restore from a backup",
+			wantConditions: []cmapi.CertificateCondition{{
+				Type:               "Issuing",
+				Status:             "False",
+				Reason:             "IssuanceCancelled",
+				Message:            "Issuance cancelled as no CertificateRequest exists for revision 12 or 13 and the existing Secret is up to date, e.g. after a restore from a backup",
+				LastTransitionTime: &fixedNow,
+				ObservedGeneration: 42,
+			}},
+		},
+		"should not cancel a restored issuance if the Secret must be reissued": {
+			existingCertificate: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateGeneration(42),
+				gen.SetCertificateRevision(12),
+				gen.SetCertificateCreationTimestamp(fixedNow),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+					Type:               "Issuing",
+					Status:             "True",
+					Reason:             "Renewal",
+					LastTransitionTime: &restoredTransitionTime,
+					ObservedGeneration: 42,
+				}),
+			),
+			wantDataForCertificateCalled: true,
+			mockDataForCertificateReturn: policies.Input{
+				Secret: gen.Secret("secret-1", gen.SetSecretNamespace("testns")),
+			},
+			wantShouldReissueCalled: true,
+			mockShouldReissue: func(*testing.T) policies.Func {
+				return func(policies.Input) (string, string, bool) {
+					return "SecretMismatch", "Issuing certificate as Existing issued Secret is not up to date for spec: [spec.dnsNames]", true
+				}
+			},
+		},
+		"should not cancel an issuance if a CertificateRequest exists for the next revision": {
+			existingCertificate: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateGeneration(42),
+				gen.SetCertificateRevision(12),
+				gen.SetCertificateCreationTimestamp(fixedNow),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+					Type:               "Issuing",
+					Status:             "True",
+					Reason:             "Renewal",
+					LastTransitionTime: &restoredTransitionTime,
+					ObservedGeneration: 42,
+				}),
+			),
+			wantDataForCertificateCalled: true,
+			mockDataForCertificateReturn: policies.Input{
+				Secret: gen.Secret("secret-1", gen.SetSecretNamespace("testns")),
+				NextRevisionRequest: gen.CertificateRequest("cr-13", gen.SetCertificateRequestNamespace("testns"),
+					gen.SetCertificateRequestAnnotations(map[string]string{"cert-manager.io/certificate-revision": "13"}),
+				),
+			},
+		},
+		"should not cancel a manually triggered issuance": {
+			existingCertificate: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateGeneration(42),
+				gen.SetCertificateRevision(12),
+				gen.SetCertificateCreationTimestamp(fixedNow),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+					Type:               "Issuing",
+					Status:             "True",
+					Reason:             "ManuallyTriggered",
+					LastTransitionTime: &restoredTransitionTime,
+					ObservedGeneration: 42,
+				}),
+			),
+		},
+		"should not cancel an issuance triggered by another controller": {
+			existingCertificate: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateGeneration(42),
+				gen.SetCertificateRevision(12),
+				gen.SetCertificateCreationTimestamp(fixedNow),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+					Type:               "Issuing",
+					Status:             "True",
+					Reason:             "SetByAnotherController",
+					LastTransitionTime: &restoredTransitionTime,
+					ObservedGeneration: 42,
+				}),
+			),
+		},
+		"should not cancel an issuance of a Certificate which was not restored": {
+			existingCertificate: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateGeneration(42),
+				gen.SetCertificateRevision(12),
+				gen.SetCertificateCreationTimestamp(restoredTransitionTime),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+					Type:               "Issuing",
+					Status:             "True",
+					Reason:             "Renewal",
+					LastTransitionTime: &fixedNow,
+					ObservedGeneration: 42,
+				}),
+			),
+		},
 		"should call shouldReissue with the correct cert, secret and current CR": {
 			existingCertificate: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
 				gen.SetCertificateSecretName("secret-1"),
@@ -130,7 +257,19 @@ func Test_controller_ProcessItem(t *testing.T) {
 						),
 						Secret: gen.Secret("secret-1", gen.SetSecretNamespace("testns")),
 					}
-					assert.Equal(t, expectInput, gotInput)
+					// Only compare the exported fields, the Input passed to
+					// a policy in a chain also carries the chain's cache of
+					// decoded data.
+					assert.Equal(t, expectInput, policies.Input{
+						Certificate:            gotInput.Certificate,
+						Secret:                 gotInput.Secret,
+						CurrentRevisionRequest: gotInput.CurrentRevisionRequest,
+						NextRevisionRequest:    gotInput.NextRevisionRequest,
+						SecretCA:               gotInput.SecretCA,
+						EvaluationTime:         gotInput.EvaluationTime,
+						IssuerCASecret:         gotInput.IssuerCASecret,
+						UnavailableInputs:      gotInput.UnavailableInputs,
+					})
 					return "", "", false
 				}
 			},
@@ -163,6 +302,28 @@ func Test_controller_ProcessItem(t *testing.T) {
 				ObservedGeneration: 42,
 			}},
 		},
+		"should set the Issuing reason to the category of the violated policy": {
+			existingCertificate: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateGeneration(42),
+			),
+			wantDataForCertificateCalled: true,
+			mockDataForCertificateReturn: policies.Input{},
+			wantShouldReissueCalled:      true,
+			mockShouldReissue: func(*testing.T) policies.Func {
+				return func(policies.Input) (string, string, bool) {
+					return policies.Renewing, "Renewing certificate as renewal was scheduled at 2020-01-01 00:00:00 +0000 UTC", true
+				}
+			},
+			wantEvent: "Normal Issuing Renewing certificate as renewal was scheduled at 2020-01-01 00:00:00 +0000 UTC",
+			wantConditions: []cmapi.CertificateCondition{{
+				Type:               "Issuing",
+				Status:             "True",
+				Reason:             policies.IssuingReasonRenewal,
+				Message:            "Renewing certificate as renewal was scheduled at 2020-01-01 00:00:00 +0000 UTC",
+				LastTransitionTime: &fixedNow,
+				ObservedGeneration: 42,
+			}},
+		},
 		// The combinations of number of failed issuances and last
 		// failed issuance time that do or do not result in re-issuance
 		// are tested in Test_shouldBackoffReissuingOnFailure below
@@ -397,14 +558,14 @@ func Test_controller_ProcessItem(t *testing.T) {
 			}
 
 			gotShouldReissueCalled := false
-			w.shouldReissue = func(i policies.Input) (string, string, bool) {
+			w.triggerPolicies = policies.NamedChain{{Name: "Mock", Check: func(i policies.Input) (string, string, bool) {
 				gotShouldReissueCalled = true
 				if test.mockShouldReissue == nil {
 					t.Fatal("no mock set for shouldReissue, but shouldReissue has been called")
 					return "", "", false
 				}
 				return test.mockShouldReissue(t)(i)
-			}
+			}}}
 
 			// TODO(mael): we should really remove the Certificate field from
 			// DataForCertificate since the input certificate is always expected
@@ -467,6 +628,384 @@ func Test_controller_ProcessItem(t *testing.T) {
 	}
 }
 
+func Test_controller_ProcessItem_namespaceScoped(t *testing.T) {
+	tests := map[string]struct {
+		issuerRef                    cmmeta.ObjectReference
+		wantDataForCertificateCalled bool
+	}{
+		"should not trigger issuance for a Certificate referencing a ClusterIssuer": {
+			issuerRef:                    cmmeta.ObjectReference{Name: "ca", Kind: cmapi.ClusterIssuerKind},
+			wantDataForCertificateCalled: false,
+		},
+		"should evaluate a Certificate referencing an Issuer": {
+			issuerRef:                    cmmeta.ObjectReference{Name: "ca", Kind: cmapi.IssuerKind},
+			wantDataForCertificateCalled: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			crt := gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"), gen.SetCertificateIssuer(test.issuerRef))
+			builder := &testpkg.Builder{
+				T: t,
+				Context: &controllerpkg.Context{
+					RootContext:    context.Background(),
+					ContextOptions: controllerpkg.ContextOptions{Namespace: "testns"},
+				},
+				CertManagerObjects: []runtime.Object{crt},
+			}
+			builder.Init()
+
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+
+			gotDataForCertificateCalled := false
+			w.dataForCertificate = func(context.Context, *cmapi.Certificate) (policies.Input, error) {
+				gotDataForCertificateCalled = true
+				return policies.Input{}, fmt.Errorf("stop processing")
+			}
+
+			builder.Start()
+			defer builder.Stop()
+
+			key, err := controllerpkg.KeyFunc(crt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_ = w.controller.ProcessItem(context.Background(), key)
+
+			assert.Equal(t, test.wantDataForCertificateCalled, gotDataForCertificateCalled, "dataForCertificate func call")
+			assert.Empty(t, builder.ClusterScopedActions(), "cluster-scoped calls")
+
+			builder.CheckAndFinish()
+		})
+	}
+}
+
+func Test_controller_ProcessItem_issuanceWindow(t *testing.T) {
+	window := &cmapi.CertificateIssuanceWindow{
+		TimeZone: "Europe/London",
+		Ranges:   []cmapi.CertificateIssuanceWindowRange{{Start: "09:00", End: "17:00"}},
+	}
+	inAMonth := func(now time.Time) metav1.Time {
+		return metav1.NewTime(now.Add(30 * 24 * time.Hour))
+	}
+
+	tests := map[string]struct {
+		now                    time.Time
+		notBefore              func(now time.Time) metav1.Time
+		notAfter               func(now time.Time) metav1.Time
+		nextIssuanceWindowTime *metav1.Time
+		reissue                bool
+		// reason is the reason of the violated policy, ForceTriggered if
+		// empty.
+		reason string
+
+		wantIssuing                bool
+		wantNextIssuanceWindowTime *metav1.Time
+		wantUpdate                 bool
+		wantEvents                 []string
+	}{
+		"should set Issuing=True inside the issuance window": {
+			now:         time.Date(2026, 6, 1, 10, 0, 0, 0, time.UTC),
+			notAfter:    inAMonth,
+			reissue:     true,
+			wantIssuing: true,
+			wantUpdate:  true,
+			wantEvents:  []string{"Normal Issuing Re-issuance forced by unit test case"},
+		},
+		"should defer the re-issuance until the window opens after the clocks go forward": {
+			now:                        time.Date(2026, 3, 28, 18, 0, 0, 0, time.UTC),
+			notAfter:                   inAMonth,
+			reissue:                    true,
+			wantNextIssuanceWindowTime: ptr.To(metav1.NewTime(time.Date(2026, 3, 29, 8, 0, 0, 0, time.UTC))),
+			wantUpdate:                 true,
+			wantEvents:                 []string{"Normal IssuanceDeferred Re-issuance deferred until the issuance window opens at 2026-03-29T09:00:00+01:00"},
+		},
+		"should defer the re-issuance until the window opens after the clocks go back": {
+			now:                        time.Date(2026, 10, 24, 17, 0, 0, 0, time.UTC),
+			notAfter:                   inAMonth,
+			reissue:                    true,
+			wantNextIssuanceWindowTime: ptr.To(metav1.NewTime(time.Date(2026, 10, 25, 9, 0, 0, 0, time.UTC))),
+			wantUpdate:                 true,
+			wantEvents:                 []string{"Normal IssuanceDeferred Re-issuance deferred until the issuance window opens at 2026-10-25T09:00:00Z"},
+		},
+		"should not update the status if the deferral is already recorded": {
+			now:                    time.Date(2026, 3, 28, 18, 0, 0, 0, time.UTC),
+			notAfter:               inAMonth,
+			nextIssuanceWindowTime: ptr.To(metav1.NewTime(time.Date(2026, 3, 29, 8, 0, 0, 0, time.UTC))),
+			reissue:                true,
+		},
+		"should set Issuing=True outside the window if the certificate expires before the window opens": {
+			now: time.Date(2026, 3, 28, 18, 0, 0, 0, time.UTC),
+			notAfter: func(time.Time) metav1.Time {
+				return metav1.NewTime(time.Date(2026, 3, 29, 7, 0, 0, 0, time.UTC))
+			},
+			nextIssuanceWindowTime: ptr.To(metav1.NewTime(time.Date(2026, 3, 29, 8, 0, 0, 0, time.UTC))),
+			reissue:                true,
+			wantIssuing:            true,
+			wantUpdate:             true,
+			wantEvents: []string{
+				"Warning IssuanceWindowOverridden Re-issuing outside of the issuance window as the certificate expires at 2026-03-29T08:00:00+01:00, less than 24h0m0s after the window opens at 2026-03-29T09:00:00+01:00",
+				"Normal Issuing Re-issuance forced by unit test case",
+			},
+		},
+		"should set Issuing=True outside the window if the certificate expires too soon after the window opens": {
+			now: time.Date(2026, 3, 28, 18, 0, 0, 0, time.UTC),
+			notAfter: func(time.Time) metav1.Time {
+				return metav1.NewTime(time.Date(2026, 3, 29, 20, 0, 0, 0, time.UTC))
+			},
+			reissue:     true,
+			wantIssuing: true,
+			wantUpdate:  true,
+			wantEvents: []string{
+				"Warning IssuanceWindowOverridden Re-issuing outside of the issuance window as the certificate expires at 2026-03-29T21:00:00+01:00, less than 24h0m0s after the window opens at 2026-03-29T09:00:00+01:00",
+				"Normal Issuing Re-issuance forced by unit test case",
+			},
+		},
+		"should scale the margin before expiry with the duration of the certificate": {
+			now: time.Date(2026, 3, 28, 18, 0, 0, 0, time.UTC),
+			notBefore: func(time.Time) metav1.Time {
+				return metav1.NewTime(time.Date(2026, 3, 28, 8, 0, 0, 0, time.UTC))
+			},
+			notAfter: func(time.Time) metav1.Time {
+				return metav1.NewTime(time.Date(2026, 3, 29, 21, 0, 0, 0, time.UTC))
+			},
+			reissue:                    true,
+			wantNextIssuanceWindowTime: ptr.To(metav1.NewTime(time.Date(2026, 3, 29, 8, 0, 0, 0, time.UTC))),
+			wantUpdate:                 true,
+			wantEvents:                 []string{"Normal IssuanceDeferred Re-issuance deferred until the issuance window opens at 2026-03-29T09:00:00+01:00"},
+		},
+		"should set Issuing=True outside the window if the Secret is invalid": {
+			now:         time.Date(2026, 3, 28, 18, 0, 0, 0, time.UTC),
+			notAfter:    inAMonth,
+			reissue:     true,
+			reason:      policies.SecretMissing,
+			wantIssuing: true,
+			wantUpdate:  true,
+			wantEvents:  []string{"Normal Issuing Re-issuance forced by unit test case"},
+		},
+		"should set Issuing=True outside the window for a Certificate which has not been issued yet": {
+			now:         time.Date(2026, 3, 28, 18, 0, 0, 0, time.UTC),
+			reissue:     true,
+			wantIssuing: true,
+			wantUpdate:  true,
+			wantEvents:  []string{"Normal Issuing Re-issuance forced by unit test case"},
+		},
+		"should clear a deferral once the re-issuance is no longer required": {
+			now:                    time.Date(2026, 3, 28, 18, 0, 0, 0, time.UTC),
+			notAfter:               inAMonth,
+			nextIssuanceWindowTime: ptr.To(metav1.NewTime(time.Date(2026, 3, 29, 8, 0, 0, 0, time.UTC))),
+			reissue:                false,
+			wantUpdate:             true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			crt := gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateGeneration(42),
+				gen.SetCertificateIssuanceWindow(window),
+			)
+			if test.notBefore != nil {
+				crt.Status.NotBefore = ptr.To(test.notBefore(test.now))
+			}
+			if test.notAfter != nil {
+				crt.Status.NotAfter = ptr.To(test.notAfter(test.now))
+			}
+			crt.Status.NextIssuanceWindowTime = test.nextIssuanceWindowTime
+			reason := test.reason
+			if reason == "" {
+				reason = "ForceTriggered"
+			}
+
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fakeclock.NewFakeClock(test.now),
+				CertManagerObjects: []runtime.Object{crt},
+			}
+			builder.Init()
+
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+			w.triggerPolicies = policies.NamedChain{{Name: "Mock", Check: func(policies.Input) (string, string, bool) {
+				if !test.reissue {
+					return "", "", false
+				}
+				return reason, "Re-issuance forced by unit test case", true
+			}}}
+			w.dataForCertificate = func(context.Context, *cmapi.Certificate) (policies.Input, error) {
+				return policies.Input{Certificate: crt}, nil
+			}
+
+			if test.wantUpdate {
+				expectedCert := crt.DeepCopy()
+				expectedCert.Status.NextIssuanceWindowTime = test.wantNextIssuanceWindowTime
+				if test.wantIssuing {
+					expectedCert.Status.Conditions = []cmapi.CertificateCondition{{
+						Type:               "Issuing",
+						Status:             "True",
+						Reason:             policies.IssuingReason(reason),
+						Message:            "Re-issuance forced by unit test case",
+						LastTransitionTime: ptr.To(metav1.NewTime(test.now)),
+						ObservedGeneration: 42,
+					}}
+				}
+				builder.ExpectedActions = append(builder.ExpectedActions,
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						crt.Namespace,
+						expectedCert,
+					)),
+				)
+			}
+			builder.ExpectedEvents = test.wantEvents
+
+			builder.Start()
+			defer builder.Stop()
+
+			key, err := controllerpkg.KeyFunc(crt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.NoError(t, w.controller.ProcessItem(context.Background(), key))
+
+			builder.CheckAndFinish()
+		})
+	}
+}
+
+func Test_controller_ProcessItem_lastEvaluation(t *testing.T) {
+	now := time.Date(2026, 6, 1, 10, 0, 0, 0, time.UTC)
+	earlier := metav1.NewTime(now.Add(-time.Hour))
+	passed := []cmapi.CertificatePolicyResult{
+		{PolicyName: "First"},
+		{PolicyName: "Second"},
+	}
+	violated := []cmapi.CertificatePolicyResult{
+		{PolicyName: "First"},
+		{PolicyName: "Second", Violated: true, Reason: "Renewing", Message: "Renewing certificate"},
+	}
+
+	tests := map[string]struct {
+		disabled       bool
+		lastEvaluation *cmapi.CertificatePolicyEvaluation
+		reissue        bool
+
+		wantLastEvaluation *cmapi.CertificatePolicyEvaluation
+		wantUpdate         bool
+		wantEvents         []string
+	}{
+		"should record the first evaluation": {
+			wantLastEvaluation: &cmapi.CertificatePolicyEvaluation{EvaluationTime: metav1.NewTime(now), Policies: passed},
+			wantUpdate:         true,
+		},
+		"should not update the status if the outcome has not changed": {
+			lastEvaluation:     &cmapi.CertificatePolicyEvaluation{EvaluationTime: earlier, Policies: passed},
+			wantLastEvaluation: &cmapi.CertificatePolicyEvaluation{EvaluationTime: earlier, Policies: passed},
+		},
+		"should not update the status if only the message of a violation has changed": {
+			lastEvaluation: &cmapi.CertificatePolicyEvaluation{EvaluationTime: earlier, Policies: []cmapi.CertificatePolicyResult{
+				{PolicyName: "First"},
+				{PolicyName: "Second", Violated: true, Reason: "Renewing", Message: "Renewing certificate at an earlier time"},
+			}},
+			reissue: true,
+			wantLastEvaluation: &cmapi.CertificatePolicyEvaluation{EvaluationTime: earlier, Policies: []cmapi.CertificatePolicyResult{
+				{PolicyName: "First"},
+				{PolicyName: "Second", Violated: true, Reason: "Renewing", Message: "Renewing certificate at an earlier time"},
+			}},
+			// The Issuing condition is set regardless.
+			wantUpdate: true,
+			wantEvents: []string{"Normal Issuing Renewing certificate"},
+		},
+		"should record the violation along with the Issuing condition": {
+			lastEvaluation:     &cmapi.CertificatePolicyEvaluation{EvaluationTime: earlier, Policies: passed},
+			reissue:            true,
+			wantLastEvaluation: &cmapi.CertificatePolicyEvaluation{EvaluationTime: metav1.NewTime(now), Policies: violated},
+			wantUpdate:         true,
+			wantEvents:         []string{"Normal Issuing Renewing certificate"},
+		},
+		"should record that no policy is violated anymore": {
+			lastEvaluation:     &cmapi.CertificatePolicyEvaluation{EvaluationTime: earlier, Policies: violated},
+			wantLastEvaluation: &cmapi.CertificatePolicyEvaluation{EvaluationTime: metav1.NewTime(now), Policies: passed},
+			wantUpdate:         true,
+		},
+		"should not record the evaluation if the feature gate is disabled": {
+			disabled: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, feature.CertificatePolicyEvaluationStatus, !test.disabled)()
+
+			crt := gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"), gen.SetCertificateGeneration(42))
+			crt.Status.LastEvaluation = test.lastEvaluation
+
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fakeclock.NewFakeClock(now),
+				CertManagerObjects: []runtime.Object{crt},
+			}
+			builder.Init()
+
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+			w.triggerPolicies = policies.NamedChain{
+				{Name: "First", Check: func(policies.Input) (string, string, bool) { return "", "", false }},
+				{Name: "Second", Check: func(policies.Input) (string, string, bool) {
+					if !test.reissue {
+						return "", "", false
+					}
+					return "Renewing", "Renewing certificate", true
+				}},
+			}
+			w.dataForCertificate = func(context.Context, *cmapi.Certificate) (policies.Input, error) {
+				return policies.Input{Certificate: crt}, nil
+			}
+
+			if test.wantUpdate {
+				expected := crt.DeepCopy()
+				expected.Status.LastEvaluation = test.wantLastEvaluation
+				if test.reissue {
+					gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+						Type:               cmapi.CertificateConditionIssuing,
+						Status:             cmmeta.ConditionTrue,
+						Reason:             policies.IssuingReasonRenewal,
+						Message:            "Renewing certificate",
+						LastTransitionTime: ptr.To(metav1.NewTime(now)),
+						ObservedGeneration: 42,
+					})(expected)
+				}
+				builder.ExpectedActions = append(builder.ExpectedActions,
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						crt.Namespace,
+						expected,
+					)),
+				)
+			}
+			builder.ExpectedEvents = test.wantEvents
+
+			builder.Start()
+			defer builder.Stop()
+
+			key, err := controllerpkg.KeyFunc(crt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.NoError(t, w.controller.ProcessItem(context.Background(), key))
+
+			builder.CheckAndFinish()
+		})
+	}
+}
+
 func Test_shouldBackoffReissuingOnFailure(t *testing.T) {
 	clock := fakeclock.NewFakeClock(time.Date(2020, 11, 20, 16, 05, 00, 0000, time.Local))
 
@@ -732,6 +1271,95 @@ func Test_shouldBackoffReissuingOnFailure(t *testing.T) {
 			)),
 			wantBackoff: false,
 		},
+		"should back off from reissuing for a third of the duration of a 30 minute certificate after 1 failed issuance": {
+			givenCert: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateUID("cert-1-uid"),
+				gen.SetCertificateRevision(1),
+				gen.SetCertificateDNSNames("example.com"),
+				gen.SetCertificateDuration(&metav1.Duration{Duration: 30 * time.Minute}),
+				gen.SetCertificateLastFailureTime(metav1.NewTime(clock.Now())),
+				gen.SetCertificateIssuanceAttempts(ptr.To(1)),
+			),
+			givenNextCR: createCertificateRequestOrPanic(gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateUID("cert-1-uid"),
+				gen.SetCertificateRevision(1),
+				gen.SetCertificateDNSNames("example.com"),
+				gen.SetCertificateDuration(&metav1.Duration{Duration: 30 * time.Minute}),
+			)),
+			wantBackoff: true,
+			wantDelay:   10 * time.Minute,
+		},
+		"should not back off from reissuing a 30 minute certificate if there was 1 failed issuance 10 minutes ago": {
+			givenCert: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateUID("cert-1-uid"),
+				gen.SetCertificateRevision(1),
+				gen.SetCertificateDNSNames("example.com"),
+				gen.SetCertificateDuration(&metav1.Duration{Duration: 30 * time.Minute}),
+				gen.SetCertificateLastFailureTime(metav1.NewTime(clock.Now().Add(-10*time.Minute))),
+				gen.SetCertificateIssuanceAttempts(ptr.To(1)),
+			),
+			givenNextCR: createCertificateRequestOrPanic(gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateUID("cert-1-uid"),
+				gen.SetCertificateRevision(1),
+				gen.SetCertificateDNSNames("example.com"),
+				gen.SetCertificateDuration(&metav1.Duration{Duration: 30 * time.Minute}),
+			)),
+			wantBackoff: false,
+		},
+		"should back off from reissuing for 3m20s if there were 100 failed issuances of a 10 minute certificate": {
+			givenCert: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateUID("cert-1-uid"),
+				gen.SetCertificateRevision(1),
+				gen.SetCertificateDNSNames("example.com"),
+				gen.SetCertificateDuration(&metav1.Duration{Duration: 10 * time.Minute}),
+				gen.SetCertificateLastFailureTime(metav1.NewTime(clock.Now())),
+				gen.SetCertificateIssuanceAttempts(ptr.To(100)),
+			),
+			givenNextCR: createCertificateRequestOrPanic(gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateUID("cert-1-uid"),
+				gen.SetCertificateRevision(1),
+				gen.SetCertificateDNSNames("example.com"),
+				gen.SetCertificateDuration(&metav1.Duration{Duration: 10 * time.Minute}),
+			)),
+			wantBackoff: true,
+			wantDelay:   200 * time.Second,
+		},
+		"should back off from reissuing for 1 hour if there was 1 failed issuance of a 6 hour certificate": {
+			givenCert: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateUID("cert-1-uid"),
+				gen.SetCertificateRevision(1),
+				gen.SetCertificateDNSNames("example.com"),
+				gen.SetCertificateDuration(&metav1.Duration{Duration: 6 * time.Hour}),
+				gen.SetCertificateLastFailureTime(metav1.NewTime(clock.Now())),
+				gen.SetCertificateIssuanceAttempts(ptr.To(1)),
+			),
+			givenNextCR: createCertificateRequestOrPanic(gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateUID("cert-1-uid"),
+				gen.SetCertificateRevision(1),
+				gen.SetCertificateDNSNames("example.com"),
+				gen.SetCertificateDuration(&metav1.Duration{Duration: 6 * time.Hour}),
+			)),
+			wantBackoff: true,
+			wantDelay:   1 * time.Hour,
+		},
+		"should back off from reissuing for 2 hours if there were 3 failed issuances of a 6 hour certificate": {
+			givenCert: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateUID("cert-1-uid"),
+				gen.SetCertificateRevision(1),
+				gen.SetCertificateDNSNames("example.com"),
+				gen.SetCertificateDuration(&metav1.Duration{Duration: 6 * time.Hour}),
+				gen.SetCertificateLastFailureTime(metav1.NewTime(clock.Now())),
+				gen.SetCertificateIssuanceAttempts(ptr.To(3)),
+			),
+			givenNextCR: createCertificateRequestOrPanic(gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateUID("cert-1-uid"),
+				gen.SetCertificateRevision(1),
+				gen.SetCertificateDNSNames("example.com"),
+				gen.SetCertificateDuration(&metav1.Duration{Duration: 6 * time.Hour}),
+			)),
+			wantBackoff: true,
+			wantDelay:   2 * time.Hour,
+		},
 		// This scenario will happen if an issuance failed for a version of cert-manager that does not implement exponential backoff
 		"should back off from reissuing for 1 hour if there was a failed issuance, 0 minutes ago and issuance attempts is not set": {
 			givenCert: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
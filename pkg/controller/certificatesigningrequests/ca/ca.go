@@ -34,6 +34,7 @@ import (
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/util"
+	issuerca "github.com/cert-manager/cert-manager/pkg/issuer/ca"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	cmerrors "github.com/cert-manager/cert-manager/pkg/util/errors"
 	"github.com/cert-manager/cert-manager/pkg/util/kube"
@@ -94,26 +95,38 @@ func NewCA(ctx *controllerpkg.Context) certificatesigningrequests.Signer {
 func (c *CA) Sign(ctx context.Context, csr *certificatesv1.CertificateSigningRequest, issuerObj cmapi.GenericIssuer) error {
 	log := logf.FromContext(ctx, "sign")
 
-	secretName := issuerObj.GetSpec().CA.SecretName
 	resourceNamespace := c.issuerOptions.ResourceNamespace(issuerObj)
 
+	// The grant of a Secret in another namespace is checked on every sign, so
+	// that revoking it stops new issuance immediately.
+	secretNamespace, secretName, err := issuerca.SigningSecret(c.secretsLister, issuerObj, resourceNamespace)
+	if issuerca.IsNotGranted(err) {
+		message := fmt.Sprintf("Referenced secret %s/%s may not be used by this issuer", secretNamespace, secretName)
+		c.recorder.Eventf(csr, corev1.EventTypeWarning, "SecretNotGranted", "%s: %s", message, err)
+		return nil
+	}
+
 	// get a copy of the CA certificate named on the Issuer
-	caCerts, caKey, err := kube.SecretTLSKeyPairAndCA(ctx, c.secretsLister, resourceNamespace, issuerObj.GetSpec().CA.SecretName)
+	var caCerts []*x509.Certificate
+	var caKey crypto.Signer
+	if err == nil {
+		caCerts, caKey, err = kube.SecretTLSKeyPairAndCA(ctx, c.secretsLister, secretNamespace, secretName)
+	}
 	if apierrors.IsNotFound(err) {
-		message := fmt.Sprintf("Referenced secret %s/%s not found", resourceNamespace, secretName)
+		message := fmt.Sprintf("Referenced secret %s/%s not found", secretNamespace, secretName)
 		c.recorder.Event(csr, corev1.EventTypeWarning, "SecretMissing", message)
 		return nil
 	}
 
 	if cmerrors.IsInvalidData(err) {
-		message := fmt.Sprintf("Failed to parse signing CA keypair from secret %s/%s", resourceNamespace, secretName)
+		message := fmt.Sprintf("Failed to parse signing CA keypair from secret %s/%s", secretNamespace, secretName)
 		c.recorder.Eventf(csr, corev1.EventTypeWarning, "SecretInvalidData", "%s: %s", message, err)
 		return nil
 	}
 
 	if err != nil {
 		// We are probably in a network error here so we should backoff and retry
-		message := fmt.Sprintf("Failed to get certificate key pair from secret %s/%s", resourceNamespace, secretName)
+		message := fmt.Sprintf("Failed to get certificate key pair from secret %s/%s", secretNamespace, secretName)
 		c.recorder.Eventf(csr, corev1.EventTypeWarning, "SecretGetError", "%s: %s", message, err)
 		return err
 	}
@@ -131,6 +144,12 @@ func (c *CA) Sign(ctx context.Context, csr *certificatesv1.CertificateSigningReq
 	template.OCSPServer = issuerObj.GetSpec().CA.OCSPServers
 	template.IssuingCertificateURL = issuerObj.GetSpec().CA.IssuingCertificateURLs
 
+	// Only the certificate policies allowed by the issuer are included in the
+	// signed certificate, the others are dropped.
+	if removed := pki.FilterCertificatePolicies(template, issuerObj.GetSpec().CA.AllowedPolicyOIDs); len(removed) > 0 {
+		log.V(logf.DebugLevel).Info("removed certificate policies not allowed by the issuer", "policies", removed)
+	}
+
 	bundle, err := c.signingFn(caCerts, caKey, template)
 	if err != nil {
 		message := fmt.Sprintf("Error signing certificate: %s", err)
@@ -89,7 +89,8 @@ func (v *Vault) Sign(ctx context.Context, csr *certificatesv1.CertificateSigning
 	resourceNamespace := v.issuerOptions.ResourceNamespace(issuerObj)
 
 	createTokenFn := func(ns string) internalvault.CreateToken { return v.kclient.CoreV1().ServiceAccounts(ns).CreateToken }
-	client, err := v.clientBuilder(ctx, resourceNamespace, createTokenFn, v.secretsLister, issuerObj)
+	updateSecretFn := func(ns string) internalvault.UpdateSecret { return v.kclient.CoreV1().Secrets(ns).Update }
+	client, err := v.clientBuilder(ctx, resourceNamespace, createTokenFn, updateSecretFn, v.secretsLister, issuerObj)
 	if apierrors.IsNotFound(err) {
 		message := "Required secret resource not found"
 		log.Error(err, message)
@@ -115,7 +116,7 @@ func (v *Vault) Sign(ctx context.Context, csr *certificatesv1.CertificateSigning
 		return nil
 	}
 
-	certPEM, _, err := client.Sign(csr.Spec.Request, duration)
+	certPEM, _, err := client.Sign(ctx, csr.Spec.Request, duration)
 	if err != nil {
 		message := fmt.Sprintf("Vault failed to sign: %s", err)
 		log.Error(err, message)
@@ -34,6 +34,12 @@ func (c *controller) issuersForSecret(secret *corev1.Secret) ([]*v1.ClusterIssue
 
 	var affected []*v1.ClusterIssuer
 	for _, iss := range issuers {
+		// A CA issuer may reference a Secret in another namespace, whose
+		// grant is checked when the issuer is synced.
+		if ref := caSecretRef(iss.Spec.CA); ref != nil && ref.Namespace == secret.Namespace && ref.Name == secret.Name {
+			affected = append(affected, iss)
+			continue
+		}
 		if secret.Namespace != c.clusterResourceNamespace {
 			continue
 		}
@@ -49,8 +55,14 @@ func (c *controller) issuersForSecret(secret *corev1.Secret) ([]*v1.ClusterIssue
 					continue
 				}
 			}
+			if iss.Spec.ACME.CABundleSecretRef != nil {
+				if iss.Spec.ACME.CABundleSecretRef.Name == secret.Name {
+					affected = append(affected, iss)
+					continue
+				}
+			}
 		case iss.Spec.CA != nil:
-			if iss.Spec.CA.SecretName == secret.Name {
+			if iss.Spec.CA.SecretName == secret.Name || (iss.Spec.CA.SecretRef != nil && iss.Spec.CA.SecretRef.Namespace == "" && iss.Spec.CA.SecretRef.Name == secret.Name) {
 				affected = append(affected, iss)
 				continue
 			}
@@ -97,3 +109,12 @@ func (c *controller) issuersForSecret(secret *corev1.Secret) ([]*v1.ClusterIssue
 
 	return affected, nil
 }
+
+// caSecretRef returns the reference of a CA issuer to a Secret in another
+// namespace, or nil if it has none.
+func caSecretRef(ca *v1.CAIssuer) *v1.CAIssuerSecretReference {
+	if ca == nil || ca.SecretRef == nil || ca.SecretRef.Namespace == "" {
+		return nil
+	}
+	return ca.SecretRef
+}
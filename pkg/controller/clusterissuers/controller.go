@@ -18,6 +18,7 @@ package clusterissuers
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-logr/logr"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
@@ -25,12 +26,16 @@ import (
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 
+	internalissuers "github.com/cert-manager/cert-manager/internal/controller/issuers"
 	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
 	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/issuer"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
 )
 
 type controller struct {
@@ -60,6 +65,13 @@ type controller struct {
 
 	// fieldManager is the manager name used for the Apply operations.
 	fieldManager string
+
+	// acmeAccountVerificationInterval is the interval at which ACME issuers
+	// are re-synced, so that their ACME account is verified with the ACME
+	// server.
+	acmeAccountVerificationInterval time.Duration
+
+	metrics *metrics.Metrics
 }
 
 // Register registers and constructs the controller using the provided context.
@@ -88,12 +100,22 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 
 	// register handler functions
 	clusterIssuerInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: c.queue})
+	// ClusterIssuers sharing an ACME account private key Secret with other
+	// ClusterIssuers, or with Issuers in the cluster resource namespace, are
+	// re-synced when any of them changes.
+	issuerInformer := ctx.SharedInformerFactory.Certmanager().V1().Issuers()
+	mustSync = append(mustSync, issuerInformer.Informer().HasSynced)
+	accountKeyPeersChanged := acme.AccountKeyPeersChanged(c.log, issuerInformer.Lister(), c.clusterIssuerLister, ctx.IssuerOptions.ClusterResourceNamespace, c.enqueueClusterIssuer)
+	clusterIssuerInformer.Informer().AddEventHandler(accountKeyPeersChanged)
+	issuerInformer.Informer().AddEventHandler(accountKeyPeersChanged)
 	secretInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.secretDeleted})
 
 	// instantiate additional helpers used by this controller
 	c.issuerFactory = issuer.NewFactory(ctx)
 	c.cmClient = ctx.CMClient
 	c.fieldManager = ctx.FieldManager
+	c.acmeAccountVerificationInterval = ctx.ACMEOptions.AccountVerificationInterval
+	c.metrics = ctx.Metrics
 	c.recorder = ctx.Recorder
 	c.clusterResourceNamespace = ctx.IssuerOptions.ClusterResourceNamespace
 
@@ -127,6 +149,19 @@ func (c *controller) secretDeleted(obj interface{}) {
 	}
 }
 
+// enqueueClusterIssuer enqueues the given issuer if it is a ClusterIssuer.
+func (c *controller) enqueueClusterIssuer(iss v1.GenericIssuer) {
+	if _, ok := iss.(*v1.ClusterIssuer); !ok {
+		return
+	}
+	key, err := keyFunc(iss)
+	if err != nil {
+		c.log.Error(err, "error computing key for resource")
+		return
+	}
+	c.queue.AddRateLimited(key)
+}
+
 func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	log := logf.FromContext(ctx)
 
@@ -140,6 +175,7 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	if err != nil {
 		if k8sErrors.IsNotFound(err) {
 			log.Error(err, "clusterissuer in work queue no longer exists")
+			c.metrics.RemoveACMEAccountStatus(v1.ClusterIssuerKind, "", name)
 			return nil
 		}
 
@@ -147,7 +183,25 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	}
 
 	ctx = logf.NewContext(ctx, logf.WithResource(log, issuer))
-	return c.Sync(ctx, issuer)
+	err = c.Sync(ctx, issuer)
+
+	// While the ACME server of an issuer is unavailable, it is probed at a
+	// short interval so that pending Orders and Challenges are retried soon
+	// after it recovers. Once the condition is set, by Sync or by the orders
+	// and challenges controllers, the update of the issuer re-queues it.
+	if internalissuers.ACMEServerUnavailable(issuer) {
+		c.queue.AddAfter(key, internalissuers.ACMEServerProbeInterval)
+	}
+	if err != nil {
+		return err
+	}
+
+	// ACME issuers are re-synced periodically, so that an ACME account which
+	// has been deactivated by the ACME server is reported on the issuer.
+	if issuer.Spec.ACME != nil && c.acmeAccountVerificationInterval > 0 {
+		c.queue.AddAfter(key, c.acmeAccountVerificationInterval)
+	}
+	return nil
 }
 
 var keyFunc = controllerpkg.KeyFunc
@@ -46,7 +46,10 @@ import (
 	gwscheme "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/scheme"
 	gwinformers "sigs.k8s.io/gateway-api/pkg/client/informers/externalversions"
 
+	"github.com/cert-manager/cert-manager/internal/controller/certificates"
+	"github.com/cert-manager/cert-manager/internal/controller/certificates/keyprovider"
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	"github.com/cert-manager/cert-manager/internal/controller/issuerhealth"
 	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
 	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
@@ -57,6 +60,7 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/metrics"
 	"github.com/cert-manager/cert-manager/pkg/util"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
 // This sets the informer's resync period to 10 hours
@@ -109,6 +113,11 @@ type Context struct {
 	// factory with a http-01 resource label filter selector
 	HTTP01ResourceMetadataInformersFactory metadatainformer.SharedInformerFactory
 
+	// MetadataInformersFactory is an unfiltered metadata only informers
+	// factory. It is not scoped to a namespace, so it is used to watch
+	// cluster scoped resources such as CustomResourceDefinitions.
+	MetadataInformersFactory metadatainformer.SharedInformerFactory
+
 	// GWShared can be used to obtain SharedIndexInformer instances for
 	// gateway.networking.k8s.io types
 	GWShared             gwinformers.SharedInformerFactory
@@ -133,6 +142,22 @@ type ContextOptions struct {
 	// KubernetesAPIBurst is the value of the Maximum burst for throttle.
 	KubernetesAPIBurst int
 
+	// InformerListChunkSize is the maximum number of objects fetched by each
+	// LIST call made to fill the informer caches. If 0, all the objects of a
+	// type are listed at once.
+	InformerListChunkSize int
+
+	// SyncTimeout is the maximum time a controller may spend processing a
+	// single item, after which the context passed to its sync function is
+	// cancelled and the item is re-queued. If 0, no timeout is applied.
+	SyncTimeout time.Duration
+
+	// ProgressDeadline is how long a controller with items in its workqueue
+	// may go without successfully processing one before it is reported as
+	// not ready. If 0, controllers are always ready once their informers
+	// have synced.
+	ProgressDeadline time.Duration
+
 	// Namespace is the namespace to operate within.
 	// If unset, operates on all namespaces
 	Namespace string
@@ -144,6 +169,10 @@ type ContextOptions struct {
 	// Metrics is used for exposing Prometheus metrics across the controllers
 	Metrics *metrics.Metrics
 
+	// IssuerHealth records the outcome of CertificateRequests for each
+	// issuer, which is reported by the issuer health endpoint.
+	IssuerHealth *issuerhealth.Tracker
+
 	IssuerOptions
 	ACMEOptions
 	IngressShimOptions
@@ -192,6 +221,10 @@ type ACMEOptions struct {
 	// ACMEHTTP01SolverRunAsNonRoot sets the ACME pod's ability to run as root
 	ACMEHTTP01SolverRunAsNonRoot bool
 
+	// HTTP01SolverSharedPods is whether the HTTP01 Challenges of an Order
+	// are solved by a single solver pod.
+	HTTP01SolverSharedPods bool
+
 	// HTTP01SolverNameservers is a list of nameservers to use when performing self-checks
 	// for ACME HTTP01 validations.
 	HTTP01SolverNameservers []string
@@ -210,6 +243,21 @@ type ACMEOptions struct {
 
 	// DNS01CheckRetryPeriod is the time the controller should wait between checking if a ACME dns entry exists.
 	DNS01CheckRetryPeriod time.Duration
+
+	// DNS01OrphanedRecordSweepInterval is the interval at which DNS01
+	// challenge records which were never cleaned up are swept. A zero value
+	// disables the sweeper.
+	DNS01OrphanedRecordSweepInterval time.Duration
+
+	// DNS01OrphanedRecordMinAge is the minimum amount of time for which a
+	// record must have been found without a corresponding Challenge before it
+	// is deleted by the sweeper.
+	DNS01OrphanedRecordMinAge time.Duration
+
+	// AccountVerificationInterval is the interval at which the ACME account
+	// of each ACME issuer is verified with the ACME server. A zero value
+	// only verifies the account when the issuer is registered or changed.
+	AccountVerificationInterval time.Duration
 }
 
 // IngressShimOptions contain default Issuer GVK config for the certificate-shim controllers.
@@ -229,6 +277,49 @@ type CertificateOptions struct {
 	// CopiedAnnotationPrefixes defines which annotations should be copied
 	// Certificate -> CertificateRequest, CertificateRequest -> Order.
 	CopiedAnnotationPrefixes []string
+	// CertificateRequestEncodings is the encoding of spec.request on the
+	// CertificateRequests created for Certificates, keyed by the group of the
+	// referenced issuer. Groups which are not listed use PEM.
+	CertificateRequestEncodings map[string]pki.CertificateRequestEncoding
+	// IssuanceTimeout is the maximum time a CertificateRequest may go without
+	// any status progress before the issuance attempt is failed. A zero value
+	// disables the timeout.
+	IssuanceTimeout time.Duration
+	// ClockSkewTolerance is the maximum amount of time by which an issued
+	// certificate's NotBefore may be in the future when it is stored.
+	ClockSkewTolerance time.Duration
+	// MaxNotBeforeWait is the maximum amount of time an issued certificate
+	// which is not yet valid, beyond the clock skew tolerance, is waited for
+	// before its issuance fails.
+	MaxNotBeforeWait time.Duration
+	// VerifyIssuedCertificates is whether certificates returned by issuers
+	// are verified before they are stored in the Certificate's Secret.
+	VerifyIssuedCertificates bool
+	// VerifyIssuedCertificateChain is whether the chain of certificates
+	// returned by issuers is also verified against the CA certificate stored
+	// in the Secret.
+	VerifyIssuedCertificateChain bool
+	// MaxIssuanceFailureEvents is the maximum number of Certificates for
+	// which an Event is recorded when they fail to be issued by the same
+	// issuer with the same reason within IssuanceFailureEventWindow. A zero
+	// value records an Event for every failure.
+	MaxIssuanceFailureEvents int
+	// IssuanceFailureEventWindow is the window over which failing
+	// Certificates are counted.
+	IssuanceFailureEventWindow time.Duration
+	// IssuanceHistoryLimit is the number of the most recent issuance
+	// attempts recorded in the status of each Certificate. A zero value
+	// disables recording the issuance history.
+	IssuanceHistoryLimit int
+	// MaxSecretSize is the maximum size in bytes of the data written to a
+	// Certificate's Secret. A zero value disables the check.
+	MaxSecretSize int
+	// PrivateKeyDefaults are applied to Certificates which leave their
+	// private key algorithm or rotation policy unset.
+	PrivateKeyDefaults certificates.PrivateKeyDefaults
+	// KeyProvider creates and uses private keys for Certificates which set
+	// spec.privateKey.external. It is nil if no signer plugin is configured.
+	KeyProvider keyprovider.Provider
 }
 
 type SchedulerOptions struct {
@@ -282,13 +373,14 @@ func NewContextFactory(ctx context.Context, opts ContextOptions) (*ContextFactor
 		return nil, err
 	}
 
-	sharedInformerFactory := informers.NewSharedInformerFactoryWithOptions(clients.cmClient, resyncPeriod, informers.WithNamespace(opts.Namespace))
+	chunkedListOptions := internalinformers.ChunkedListOptions(int64(opts.InformerListChunkSize))
+	sharedInformerFactory := informers.NewSharedInformerFactoryWithOptions(clients.cmClient, resyncPeriod, informers.WithNamespace(opts.Namespace), informers.WithTweakListOptions(chunkedListOptions))
 
 	var kubeSharedInformerFactory internalinformers.KubeInformerFactory
 	if utilfeature.DefaultFeatureGate.Enabled(feature.SecretsFilteredCaching) {
-		kubeSharedInformerFactory = internalinformers.NewFilteredSecretsKubeInformerFactory(ctx, clients.kubeClient, clients.metadataOnlyClient, resyncPeriod, opts.Namespace)
+		kubeSharedInformerFactory = internalinformers.NewFilteredSecretsKubeInformerFactory(ctx, clients.kubeClient, clients.metadataOnlyClient, resyncPeriod, opts.Namespace, chunkedListOptions)
 	} else {
-		kubeSharedInformerFactory = internalinformers.NewBaseKubeInformerFactory(clients.kubeClient, resyncPeriod, opts.Namespace)
+		kubeSharedInformerFactory = internalinformers.NewBaseKubeInformerFactory(clients.kubeClient, resyncPeriod, opts.Namespace, chunkedListOptions)
 	}
 	r, err := labels.NewRequirement(cmacme.DomainLabelKey, selection.Exists, nil)
 	if err != nil {
@@ -302,10 +394,14 @@ func NewContextFactory(ctx context.Context, opts ContextOptions) (*ContextFactor
 		// here. If we start using it for other resources then we'll
 		// have to set the selectors on individual informers instead.
 		listOptions.LabelSelector = isHTTP01ChallengeResourceLabelSelector.String()
-
+		if chunkedListOptions != nil {
+			chunkedListOptions(listOptions)
+		}
 	})
 
-	gwSharedInformerFactory := gwinformers.NewSharedInformerFactoryWithOptions(clients.gwClient, resyncPeriod, gwinformers.WithNamespace(opts.Namespace))
+	metadataInformerFactory := metadatainformer.NewFilteredSharedInformerFactory(clients.metadataOnlyClient, resyncPeriod, metav1.NamespaceAll, chunkedListOptions)
+
+	gwSharedInformerFactory := gwinformers.NewSharedInformerFactoryWithOptions(clients.gwClient, resyncPeriod, gwinformers.WithNamespace(opts.Namespace), gwinformers.WithTweakListOptions(chunkedListOptions))
 
 	return &ContextFactory{
 		baseRestConfig: restConfig,
@@ -317,6 +413,7 @@ func NewContextFactory(ctx context.Context, opts ContextOptions) (*ContextFactor
 			GWShared:                               gwSharedInformerFactory,
 			GatewaySolverEnabled:                   clients.gatewayAvailable,
 			HTTP01ResourceMetadataInformersFactory: http01ResourceMetadataInformerFactory,
+			MetadataInformersFactory:               metadataInformerFactory,
 			ContextOptions:                         opts,
 		},
 	}, nil
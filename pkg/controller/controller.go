@@ -18,20 +18,29 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"runtime/debug"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"k8s.io/apimachinery/pkg/util/wait"
 	genericregistry "k8s.io/apiserver/pkg/registry/generic/registry"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
 
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/metrics"
 )
 
+// maxConsecutiveWorkerPanics is the number of times the workers of a
+// controller may panic in a row, without any item being processed
+// successfully in between, before the controller is reported as not live.
+const maxConsecutiveWorkerPanics = 10
+
 type runFunc func(context.Context)
 
 type runDurationFunc struct {
@@ -59,6 +68,7 @@ func NewController(
 		mustSync:         mustSync,
 		runDurationFuncs: runDurationFuncs,
 		queue:            queue,
+		clock:            clock.RealClock{},
 	}
 }
 
@@ -70,6 +80,16 @@ type controller struct {
 	// off the workqueue
 	syncHandler func(ctx context.Context, key string) error
 
+	// syncTimeout is the deadline set on the context passed to syncHandler.
+	// If zero, no deadline is set.
+	syncTimeout time.Duration
+
+	// progressDeadline is how long the controller may go with items in its
+	// workqueue without successfully processing one before it is reported as
+	// not ready. If zero, the controller is always ready once its informers
+	// have synced.
+	progressDeadline time.Duration
+
 	// mustSync is a slice of informers that must have synced before
 	// this controller can start
 	mustSync []cache.InformerSynced
@@ -86,9 +106,29 @@ type controller struct {
 
 	// metrics is used to expose Prometheus, shared by all controllers
 	metrics *metrics.Metrics
+
+	// clock is used to record when items were last processed
+	clock clock.Clock
+
+	// cachesSynced is set once all informers in mustSync have synced
+	cachesSynced atomic.Bool
+
+	// lastProgress is the time, in unix nanoseconds, at which an item was
+	// last processed successfully, or at which the caches synced
+	lastProgress atomic.Int64
+
+	// panics is the number of times workers have panicked since an item was
+	// last processed successfully
+	panics atomic.Int64
+
+	// lastPanic holds the value recovered from the worker that panicked
+	// last, if any
+	lastPanic atomic.Value
 }
 
-// Run starts the controller loop
+// Run starts the controller loop with the given number of workers. The
+// workqueue never hands the same key to more than one worker at a time, so an
+// object is never synced concurrently regardless of the number of workers.
 func (c *controller) Run(workers int, ctx context.Context) error {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
@@ -99,13 +139,17 @@ func (c *controller) Run(workers int, ctx context.Context) error {
 	if !cache.WaitForCacheSync(ctx.Done(), c.mustSync...) {
 		return fmt.Errorf("error waiting for informer caches to sync")
 	}
+	c.lastProgress.Store(c.clock.Now().UnixNano())
+	c.cachesSynced.Store(true)
 
 	var wg sync.WaitGroup
 	for i := 0; i < workers; i++ {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			c.worker(ctx)
+			// restart the worker until the queue is shut down
+			for c.worker(ctx) {
+			}
 		}()
 	}
 
@@ -127,12 +171,31 @@ func (c *controller) Run(workers int, ctx context.Context) error {
 	return nil
 }
 
-func (c *controller) worker(ctx context.Context) {
+// worker processes items from the queue until it is shut down. It returns
+// true if it stopped because the sync handler panicked, in which case the item
+// being processed is re-queued with backoff and the worker should be
+// restarted.
+func (c *controller) worker(ctx context.Context) (panicked bool) {
 	log := logf.FromContext(ctx)
 
+	var obj interface{}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error(fmt.Errorf("%v", r), "re-queuing item and restarting worker after it panicked", "key", obj, "stacktrace", string(debug.Stack()))
+			c.metrics.IncrementSyncErrorCount(c.name)
+			if obj != nil {
+				c.queue.AddRateLimited(obj)
+			}
+			c.lastPanic.Store(fmt.Sprintf("%v", r))
+			c.panics.Add(1)
+			panicked = true
+		}
+	}()
+
 	log.V(logf.DebugLevel).Info("starting worker")
 	for {
-		obj, shutdown := c.queue.Get()
+		var shutdown bool
+		obj, shutdown = c.queue.Get()
 		if shutdown {
 			break
 		}
@@ -150,10 +213,26 @@ func (c *controller) worker(ctx context.Context) {
 
 			// Increase sync count for this controller
 			c.metrics.IncrementSyncCallCount(c.name)
+			c.metrics.IncrementActiveWorkers(c.name)
+			defer c.metrics.DecrementActiveWorkers(c.name)
 
-			err := c.syncHandler(ctx, key)
+			syncCtx := ctx
+			if c.syncTimeout > 0 {
+				var cancel context.CancelFunc
+				syncCtx, cancel = context.WithTimeout(ctx, c.syncTimeout)
+				defer cancel()
+			}
+
+			err := c.syncHandler(syncCtx, key)
 			if err != nil {
-				if strings.Contains(err.Error(), genericregistry.OptimisticLockErrorMsg) {
+				if ctx.Err() == nil && errors.Is(syncCtx.Err(), context.DeadlineExceeded) {
+					// The sync handler gave up as the item was not
+					// processed in time, so the item is retried later rather
+					// than holding up the worker.
+					log.Error(err, "re-queuing item as it was not processed within the sync timeout", "timeout", c.syncTimeout)
+					c.metrics.IncrementSyncErrorCount(c.name)
+					c.metrics.IncrementSyncDeadlineExceededCount(c.name)
+				} else if strings.Contains(err.Error(), genericregistry.OptimisticLockErrorMsg) {
 					log.Info("re-queuing item due to optimistic locking on resource", "error", err.Error())
 					// These errors are not counted towards the controllerSyncErrorCount metric on purpose
 					// as they will go way with
@@ -167,8 +246,39 @@ func (c *controller) worker(ctx context.Context) {
 				return
 			}
 			log.V(logf.DebugLevel).Info("finished processing work item")
+			c.lastProgress.Store(c.clock.Now().UnixNano())
+			c.panics.Store(0)
 			c.queue.Forget(obj)
 		}()
 	}
 	log.V(logf.DebugLevel).Info("exiting worker loop")
+	return false
+}
+
+// Ready returns an error if the informer caches have not yet synced, or if
+// items are waiting in the workqueue but none have been processed
+// successfully for longer than the progress deadline.
+func (c *controller) Ready() error {
+	if !c.cachesSynced.Load() {
+		return fmt.Errorf("informer caches have not synced")
+	}
+	if c.progressDeadline == 0 || c.queue.Len() == 0 {
+		return nil
+	}
+	last := time.Unix(0, c.lastProgress.Load())
+	if since := c.clock.Since(last); since > c.progressDeadline {
+		return fmt.Errorf("%d items queued but no item has been processed successfully for %s", c.queue.Len(), since.Round(time.Second))
+	}
+	return nil
+}
+
+// Live returns an error if the controller's workers have kept panicking
+// without processing any item successfully in between. Workers are restarted
+// after a panic, so a single item which always panics does not make the
+// controller not live as long as other items are processed.
+func (c *controller) Live() error {
+	if n := c.panics.Load(); n >= maxConsecutiveWorkerPanics {
+		return fmt.Errorf("workers panicked %d times in a row, last panic: %v", n, c.lastPanic.Load())
+	}
+	return nil
 }
@@ -0,0 +1,335 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2/ktesting"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+)
+
+func newTestController(t *testing.T, syncFunc func(context.Context, string) error, mustSync ...cache.InformerSynced) (*controller, *fakeclock.FakeClock) {
+	log, _ := ktesting.NewTestContext(t)
+	fakeClock := fakeclock.NewFakeClock(time.Now())
+	queue := workqueue.NewRateLimitingQueueWithConfig(workqueue.DefaultControllerRateLimiter(), workqueue.RateLimitingQueueConfig{Name: "test"})
+	c := NewController("test", metrics.New(log, fakeClock), syncFunc, mustSync, nil, queue).(*controller)
+	c.clock = fakeClock
+	c.progressDeadline = 10 * time.Minute
+	return c, fakeClock
+}
+
+func TestControllerHealth(t *testing.T) {
+	t.Run("not ready but live while informers have not synced", func(t *testing.T) {
+		_, ctx := ktesting.NewTestContext(t)
+		ctx, cancel := context.WithCancel(ctx)
+		c, _ := newTestController(t, func(context.Context, string) error { return nil }, func() bool { return false })
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			_ = c.Run(1, ctx)
+		}()
+
+		if err := c.Ready(); err == nil {
+			t.Errorf("expected controller to not be ready")
+		}
+		if err := c.Live(); err != nil {
+			t.Errorf("expected controller to be live, got: %v", err)
+		}
+		cancel()
+		<-done
+	})
+
+	t.Run("not ready when queued items are not processed in time", func(t *testing.T) {
+		c, fakeClock := newTestController(t, nil)
+		c.cachesSynced.Store(true)
+		c.lastProgress.Store(fakeClock.Now().UnixNano())
+
+		if err := c.Ready(); err != nil {
+			t.Errorf("expected controller with an empty queue to be ready, got: %v", err)
+		}
+
+		c.queue.Add("item")
+		fakeClock.Step(c.progressDeadline - time.Second)
+		if err := c.Ready(); err != nil {
+			t.Errorf("expected controller to be ready within the progress deadline, got: %v", err)
+		}
+
+		fakeClock.Step(2 * time.Second)
+		if err := c.Ready(); err == nil {
+			t.Errorf("expected controller to not be ready after the progress deadline")
+		}
+	})
+
+	t.Run("an idle controller stays ready", func(t *testing.T) {
+		c, fakeClock := newTestController(t, nil)
+		c.cachesSynced.Store(true)
+		c.lastProgress.Store(fakeClock.Now().UnixNano())
+
+		fakeClock.Step(2 * c.progressDeadline)
+		if err := c.Ready(); err != nil {
+			t.Errorf("expected idle controller to be ready, got: %v", err)
+		}
+	})
+
+	t.Run("always ready without a progress deadline", func(t *testing.T) {
+		c, fakeClock := newTestController(t, nil)
+		c.progressDeadline = 0
+		c.cachesSynced.Store(true)
+		c.lastProgress.Store(fakeClock.Now().UnixNano())
+
+		c.queue.Add("item")
+		fakeClock.Step(time.Hour)
+		if err := c.Ready(); err != nil {
+			t.Errorf("expected controller to be ready, got: %v", err)
+		}
+	})
+
+	t.Run("not live once workers keep panicking", func(t *testing.T) {
+		_, ctx := ktesting.NewTestContext(t)
+		fail := true
+		c, _ := newTestController(t, func(context.Context, string) error {
+			if fail {
+				panic("boom")
+			}
+			return nil
+		})
+
+		for i := 1; i <= maxConsecutiveWorkerPanics; i++ {
+			c.queue.Add(fmt.Sprintf("item-%d", i))
+			if !c.worker(ctx) {
+				t.Fatalf("expected the worker to report that it panicked")
+			}
+			if err := c.Live(); (err != nil) != (i == maxConsecutiveWorkerPanics) {
+				t.Errorf("unexpected liveness after %d panics: %v", i, err)
+			}
+		}
+
+		// a successfully processed item makes the controller live again
+		fail = false
+		c.queue.Add("other")
+		c.queue.ShutDown()
+		if c.worker(ctx) {
+			t.Fatalf("expected the worker to exit without panicking")
+		}
+		if err := c.Live(); err != nil {
+			t.Errorf("expected controller to be live, got: %v", err)
+		}
+	})
+}
+
+func TestControllerWorkerPanic(t *testing.T) {
+	_, ctx := ktesting.NewTestContext(t)
+	ctx, cancel := context.WithCancel(ctx)
+
+	var calls atomic.Int32
+	processed := make(chan string, 1)
+	c, _ := newTestController(t, func(_ context.Context, key string) error {
+		if calls.Add(1) == 1 {
+			panic("boom")
+		}
+		processed <- key
+		return nil
+	})
+	c.queue.Add("item")
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = c.Run(1, ctx)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	// the only worker panics on the first attempt, and must be restarted to
+	// retry the item
+	select {
+	case key := <-processed:
+		if key != "item" {
+			t.Errorf("expected item %q to be processed, got %q", "item", key)
+		}
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatal("the item was not retried after the worker panicked")
+	}
+}
+
+func TestControllerSyncTimeout(t *testing.T) {
+	t.Run("a sync which does not complete in time is re-queued and frees up the worker", func(t *testing.T) {
+		_, ctx := ktesting.NewTestContext(t)
+		processed := make(chan string, 1)
+		c, _ := newTestController(t, func(ctx context.Context, key string) error {
+			if key == "hanging" {
+				// Behave like an issuer which never responds, and only
+				// returns once the sync is cancelled.
+				<-ctx.Done()
+				return ctx.Err()
+			}
+			processed <- key
+			return nil
+		})
+		c.syncTimeout = 50 * time.Millisecond
+
+		c.queue.Add("hanging")
+		c.queue.Add("other")
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			c.worker(ctx)
+		}()
+		defer func() {
+			c.queue.ShutDown()
+			<-done
+		}()
+
+		select {
+		case key := <-processed:
+			if key != "other" {
+				t.Errorf("expected item %q to be processed, got %q", "other", key)
+			}
+		case <-time.After(wait.ForeverTestTimeout):
+			t.Fatal("the worker was not freed up by the sync timeout")
+		}
+
+		if n := c.queue.NumRequeues("hanging"); n < 1 {
+			t.Errorf("expected the item which timed out to be re-queued")
+		}
+	})
+
+	t.Run("no deadline is set without a sync timeout", func(t *testing.T) {
+		_, ctx := ktesting.NewTestContext(t)
+		hasDeadline := true
+		c, _ := newTestController(t, func(ctx context.Context, key string) error {
+			_, hasDeadline = ctx.Deadline()
+			return nil
+		})
+
+		c.queue.Add("item")
+		c.queue.ShutDown()
+		c.worker(ctx)
+
+		if hasDeadline {
+			t.Errorf("expected the sync context to have no deadline")
+		}
+	})
+}
+
+func TestControllerWorkers(t *testing.T) {
+	// issuerLatency simulates the time an issuer takes to respond to a sync.
+	const issuerLatency = 20 * time.Millisecond
+
+	// runItems syncs the given number of distinct items using the given
+	// number of workers and returns the time taken to sync all of them.
+	runItems := func(t *testing.T, workers, items int) time.Duration {
+		_, ctx := ktesting.NewTestContext(t)
+		ctx, cancel := context.WithCancel(ctx)
+
+		var processed sync.WaitGroup
+		processed.Add(items)
+		c, _ := newTestController(t, func(ctx context.Context, key string) error {
+			time.Sleep(issuerLatency)
+			processed.Done()
+			return nil
+		})
+		for i := 0; i < items; i++ {
+			c.queue.Add(fmt.Sprintf("item-%d", i))
+		}
+
+		done := make(chan struct{})
+		start := time.Now()
+		go func() {
+			defer close(done)
+			_ = c.Run(workers, ctx)
+		}()
+		processed.Wait()
+		elapsed := time.Since(start)
+
+		cancel()
+		<-done
+		return elapsed
+	}
+
+	t.Run("more workers sync items faster", func(t *testing.T) {
+		oneWorker := runItems(t, 1, 20)
+		tenWorkers := runItems(t, 10, 20)
+		if tenWorkers > oneWorker/2 {
+			t.Errorf("expected 10 workers to sync 20 items at least twice as fast as 1 worker, took %s and %s", tenWorkers, oneWorker)
+		}
+	})
+
+	t.Run("an item is never synced by more than one worker at a time", func(t *testing.T) {
+		_, ctx := ktesting.NewTestContext(t)
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		const syncs = 10
+		var (
+			count      atomic.Int32
+			inFlight   atomic.Int32
+			concurrent atomic.Bool
+			processed  sync.WaitGroup
+			c          *controller
+		)
+		processed.Add(syncs)
+		c, _ = newTestController(t, func(ctx context.Context, key string) error {
+			if count.Add(1) > syncs {
+				return nil
+			}
+			defer processed.Done()
+			if inFlight.Add(1) > 1 {
+				concurrent.Store(true)
+			}
+			defer inFlight.Add(-1)
+			// Re-queue the item while it is being synced, as an informer
+			// event for the same object would.
+			for i := 0; i < 5; i++ {
+				c.queue.Add(key)
+			}
+			time.Sleep(issuerLatency)
+			return nil
+		})
+		c.queue.Add("same")
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			_ = c.Run(10, ctx)
+		}()
+		defer func() {
+			cancel()
+			<-done
+		}()
+		processed.Wait()
+
+		if concurrent.Load() {
+			t.Errorf("expected the item to never be synced concurrently")
+		}
+	})
+}
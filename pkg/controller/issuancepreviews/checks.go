@@ -0,0 +1,239 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuancepreviews
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	internalcmapi "github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	internalcmapiv1 "github.com/cert-manager/cert-manager/internal/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager/validation"
+	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller/acmeorders/selectors"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// evaluate runs every check against the Certificate described by the
+// IssuancePreview. Checks never create any resources.
+func (c *controller) evaluate(ctx context.Context, preview *cmapi.IssuancePreview) []cmapi.IssuancePreviewCheck {
+	crt := certificateForPreview(preview)
+
+	checks := []cmapi.IssuancePreviewCheck{
+		checkValidation(crt),
+	}
+
+	iss, issuerCheck := c.checkIssuerReady(crt)
+	checks = append(checks, issuerCheck)
+
+	if iss != nil && iss.GetSpec().ACME != nil {
+		checks = append(checks, checkSolverSelection(crt, iss.GetSpec().ACME.Solvers))
+	}
+
+	triggerCheck, err := c.checkTriggerPolicy(crt)
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "failed to evaluate trigger policy")
+		triggerCheck = cmapi.IssuancePreviewCheck{
+			Name:    cmapi.IssuancePreviewCheckTriggerPolicy,
+			Reason:  "Error",
+			Message: err.Error(),
+		}
+	}
+	checks = append(checks, triggerCheck)
+
+	return checks
+}
+
+// certificateForPreview synthesizes the Certificate that would be created
+// from the IssuancePreview. The Certificate has the same name and namespace
+// as the IssuancePreview.
+func certificateForPreview(preview *cmapi.IssuancePreview) *cmapi.Certificate {
+	crt := &cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        preview.Name,
+			Namespace:   preview.Namespace,
+			Labels:      preview.Labels,
+			Annotations: preview.Annotations,
+		},
+		Spec: *preview.Spec.CertificateSpec.DeepCopy(),
+	}
+	if preview.Spec.IssuerRef != nil {
+		crt.Spec.IssuerRef = *preview.Spec.IssuerRef
+	}
+	return crt
+}
+
+// checkValidation runs the validation performed by the webhook when a
+// Certificate is created.
+func checkValidation(crt *cmapi.Certificate) cmapi.IssuancePreviewCheck {
+	check := cmapi.IssuancePreviewCheck{Name: cmapi.IssuancePreviewCheckValidation}
+
+	internalSpec := &internalcmapi.CertificateSpec{}
+	if err := internalcmapiv1.Convert_v1_CertificateSpec_To_certmanager_CertificateSpec(&crt.Spec, internalSpec, nil); err != nil {
+		check.Reason = "Invalid"
+		check.Message = fmt.Sprintf("failed to convert certificate spec: %v", err)
+		return check
+	}
+
+	if errs := validation.ValidateCertificateSpec(internalSpec, field.NewPath("spec", "certificateSpec")); len(errs) > 0 {
+		check.Reason = "Invalid"
+		check.Message = errs.ToAggregate().Error()
+		return check
+	}
+
+	check.Passed = true
+	check.Reason = "Valid"
+	check.Message = "Certificate spec is valid"
+	return check
+}
+
+// checkIssuerReady checks that the referenced issuer exists and has a Ready
+// condition with status True. The issuer is returned if it was found.
+// Issuers from other API groups are not checked as their readiness is not
+// known to cert-manager.
+func (c *controller) checkIssuerReady(crt *cmapi.Certificate) (cmapi.GenericIssuer, cmapi.IssuancePreviewCheck) {
+	check := cmapi.IssuancePreviewCheck{Name: cmapi.IssuancePreviewCheckIssuerReady}
+	ref := crt.Spec.IssuerRef
+
+	if ref.Group != "" && ref.Group != cmapi.SchemeGroupVersion.Group {
+		check.Passed = true
+		check.Reason = "ExternalIssuer"
+		check.Message = fmt.Sprintf("Readiness of issuers in the %q group is not checked", ref.Group)
+		return nil, check
+	}
+
+	iss, err := c.helper.GetGenericIssuer(ref, crt.Namespace)
+	if apierrors.IsNotFound(err) {
+		check.Reason = "IssuerNotFound"
+		check.Message = fmt.Sprintf("Referenced %s %q not found", apiutil.IssuerKind(ref), ref.Name)
+		return nil, check
+	}
+	if err != nil {
+		check.Reason = "IssuerNotFound"
+		check.Message = err.Error()
+		return nil, check
+	}
+
+	if !apiutil.IssuerHasCondition(iss, cmapi.IssuerCondition{
+		Type:   cmapi.IssuerConditionReady,
+		Status: cmmeta.ConditionTrue,
+	}) {
+		check.Reason = "IssuerNotReady"
+		check.Message = fmt.Sprintf("Referenced %s %q is not ready", apiutil.IssuerKind(ref), ref.Name)
+		return iss, check
+	}
+
+	check.Passed = true
+	check.Reason = "IssuerReady"
+	check.Message = fmt.Sprintf("Referenced %s %q is ready", apiutil.IssuerKind(ref), ref.Name)
+	return iss, check
+}
+
+// checkSolverSelection checks that an ACME challenge solver can be selected
+// for every DNS name in the Certificate. As the challenge types offered by
+// the ACME server are not known until an Order is created, only DNS01
+// solvers are considered usable for wildcard names.
+func checkSolverSelection(crt *cmapi.Certificate, solvers []cmacme.ACMEChallengeSolver) cmapi.IssuancePreviewCheck {
+	check := cmapi.IssuancePreviewCheck{Name: cmapi.IssuancePreviewCheckSolverSelection}
+
+	dnsNames := crt.Spec.DNSNames
+	if cn := pki.EffectiveCommonName(crt.Spec); cn != "" && !slices.Contains(dnsNames, cn) {
+		dnsNames = append([]string{cn}, dnsNames...)
+	}
+
+	var (
+		unsolvable []string
+		ambiguous  []string
+		messages   []string
+	)
+	for _, dnsName := range dnsNames {
+		usable := func(*cmacme.ACMEChallengeSolver) bool { return true }
+		if strings.HasPrefix(dnsName, "*.") {
+			usable = func(s *cmacme.ACMEChallengeSolver) bool { return s.DNS01 != nil }
+		}
+
+		sel := selectors.SelectSolver(crt.ObjectMeta, dnsName, solvers, usable)
+		messages = append(messages, fmt.Sprintf("%s: %s", dnsName, sel.Explanation()))
+		switch {
+		case sel.Solver == nil:
+			unsolvable = append(unsolvable, dnsName)
+		case sel.Ambiguous:
+			ambiguous = append(ambiguous, dnsName)
+		}
+	}
+
+	switch {
+	case len(unsolvable) > 0:
+		check.Reason = "NoSolver"
+	case len(ambiguous) > 0:
+		check.Passed = true
+		check.Reason = "AmbiguousSolver"
+	default:
+		check.Passed = true
+		check.Reason = "SolverSelected"
+	}
+	check.Message = strings.Join(messages, "\n")
+	return check
+}
+
+// checkTriggerPolicy evaluates the issuance trigger policy chain against the
+// Secret named by the Certificate. A triggered policy is not a failure as
+// it only reports whether the Certificate would be issued immediately,
+// except if the Secret belongs to a different Certificate.
+func (c *controller) checkTriggerPolicy(crt *cmapi.Certificate) (cmapi.IssuancePreviewCheck, error) {
+	check := cmapi.IssuancePreviewCheck{Name: cmapi.IssuancePreviewCheckTriggerPolicy}
+
+	var secret *corev1.Secret
+	if crt.Spec.SecretName != "" {
+		var err error
+		secret, err = c.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
+		if apierrors.IsNotFound(err) {
+			secret = nil
+		} else if err != nil {
+			return check, err
+		}
+	}
+
+	reason, message, triggered := policies.NewTriggerPolicyChain(c.clock, c.privateKeyDefaults).Evaluate(policies.Input{
+		Certificate:    crt,
+		Secret:         secret,
+		EvaluationTime: c.clock.Now(),
+	})
+	if !triggered {
+		check.Passed = true
+		check.Reason = "UpToDate"
+		check.Message = "Issuance would not be triggered as the existing Secret is up to date"
+		return check, nil
+	}
+
+	check.Passed = reason != policies.IncorrectCertificate
+	check.Reason = reason
+	check.Message = message
+	return check, nil
+}
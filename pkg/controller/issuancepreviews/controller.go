@@ -0,0 +1,198 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuancepreviews
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
+	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+const (
+	ControllerName = "issuancepreviews"
+
+	// defaultTTL is the time after which an evaluated IssuancePreview is
+	// deleted if spec.ttlSecondsAfterFinished is not set.
+	defaultTTL = time.Hour
+)
+
+type controller struct {
+	issuancePreviewLister cmlisters.IssuancePreviewLister
+	secretLister          internalinformers.SecretLister
+	helper                issuer.Helper
+	client                cmclient.Interface
+
+	queue workqueue.RateLimitingInterface
+	clock clock.Clock
+
+	// privateKeyDefaults are applied to Certificates which leave their
+	// private key unspecified when previewing whether issuance is triggered.
+	privateKeyDefaults internalcertificates.PrivateKeyDefaults
+}
+
+func NewController(log logr.Logger, ctx *controllerpkg.Context) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
+	// create a queue used to queue up items to be processed
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Second*1, time.Second*30), ControllerName)
+
+	// obtain references to all the informers used by this controller
+	issuancePreviewInformer := ctx.SharedInformerFactory.Certmanager().V1().IssuancePreviews()
+	issuerInformer := ctx.SharedInformerFactory.Certmanager().V1().Issuers()
+	secretsInformer := ctx.KubeSharedInformerFactory.Secrets()
+
+	issuancePreviewInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+
+	// build a list of InformerSynced functions that will be returned by the Register method.
+	// the controller will only begin processing items once all of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		issuancePreviewInformer.Informer().HasSynced,
+		issuerInformer.Informer().HasSynced,
+		secretsInformer.Informer().HasSynced,
+	}
+
+	// If we are running in non-namespaced mode, we also obtain a lister for
+	// ClusterIssuers.
+	var clusterIssuerLister cmlisters.ClusterIssuerLister
+	if ctx.Namespace == "" {
+		clusterIssuerInformer := ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers()
+		clusterIssuerLister = clusterIssuerInformer.Lister()
+		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
+	}
+
+	return &controller{
+		issuancePreviewLister: issuancePreviewInformer.Lister(),
+		secretLister:          secretsInformer.Lister(),
+		helper:                issuer.NewHelper(issuerInformer.Lister(), clusterIssuerLister),
+		client:                ctx.CMClient,
+		queue:                 queue,
+		clock:                 ctx.Clock,
+		privateKeyDefaults:    ctx.CertificateOptions.PrivateKeyDefaults,
+	}, queue, mustSync
+}
+
+// ProcessItem evaluates an IssuancePreview that has not yet been evaluated
+// for its current generation, and deletes IssuancePreviews once their TTL has
+// expired.
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx).WithValues("key", key)
+
+	ctx = logf.NewContext(ctx, log)
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key passed to ProcessItem")
+		return nil
+	}
+
+	preview, err := c.issuancePreviewLister.IssuancePreviews(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("issuancepreview not found for key", "error", err.Error())
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	log = logf.WithResource(log, preview)
+	ctx = logf.NewContext(ctx, log)
+
+	if preview.Status.CompletionTime == nil || preview.Status.ObservedGeneration != preview.Generation {
+		return c.evaluateAndUpdate(ctx, preview)
+	}
+
+	ttl := defaultTTL
+	if preview.Spec.TTLSecondsAfterFinished != nil {
+		ttl = time.Duration(*preview.Spec.TTLSecondsAfterFinished) * time.Second
+	}
+	expiry := preview.Status.CompletionTime.Add(ttl)
+	if remaining := expiry.Sub(c.clock.Now()); remaining > 0 {
+		log.V(logf.DebugLevel).Info("issuancepreview has not expired, requeueing", "expiry", expiry)
+		c.queue.AddAfter(key, remaining)
+		return nil
+	}
+
+	log.V(logf.DebugLevel).Info("garbage collecting expired issuancepreview")
+	err = c.client.CertmanagerV1().IssuancePreviews(namespace).Delete(ctx, name, metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{UID: &preview.UID},
+	})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// evaluateAndUpdate runs all checks against the IssuancePreview and writes
+// the result to its status. The IssuancePreview will be requeued by the
+// resulting update event, at which point its TTL is handled.
+func (c *controller) evaluateAndUpdate(ctx context.Context, preview *cmapi.IssuancePreview) error {
+	checks := c.evaluate(ctx, preview)
+
+	verdict := cmapi.IssuancePreviewVerdictPass
+	for _, check := range checks {
+		if !check.Passed {
+			verdict = cmapi.IssuancePreviewVerdictFail
+			break
+		}
+	}
+
+	preview = preview.DeepCopy()
+	preview.Status = cmapi.IssuancePreviewStatus{
+		Verdict:            verdict,
+		Checks:             checks,
+		ObservedGeneration: preview.Generation,
+		CompletionTime:     &metav1.Time{Time: c.clock.Now()},
+	}
+	_, err := c.client.CertmanagerV1().IssuancePreviews(preview.Namespace).UpdateStatus(ctx, preview, metav1.UpdateOptions{})
+	return err
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+
+	ctrl, queue, mustSync := NewController(log, ctx)
+	c.controller = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controllerWrapper{}).
+			Complete()
+	})
+}
@@ -0,0 +1,385 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuancepreviews
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+var (
+	fixedClockStart = time.Now()
+	fixedClock      = fakeclock.NewFakeClock(fixedClockStart)
+)
+
+type previewModifier func(*cmapi.IssuancePreview)
+
+func preview(mods ...previewModifier) *cmapi.IssuancePreview {
+	p := &cmapi.IssuancePreview{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test-preview",
+			Namespace:  "testns",
+			Generation: 1,
+		},
+		Spec: cmapi.IssuancePreviewSpec{
+			CertificateSpec: cmapi.CertificateSpec{
+				SecretName: "test-secret",
+				DNSNames:   []string{"example.com"},
+				IssuerRef:  cmmeta.ObjectReference{Name: "test-issuer"},
+			},
+		},
+	}
+	for _, mod := range mods {
+		mod(p)
+	}
+	return p
+}
+
+func withDNSNames(names ...string) previewModifier {
+	return func(p *cmapi.IssuancePreview) {
+		p.Spec.CertificateSpec.DNSNames = names
+	}
+}
+
+func withIssuerRefOverride(ref cmmeta.ObjectReference) previewModifier {
+	return func(p *cmapi.IssuancePreview) {
+		p.Spec.IssuerRef = &ref
+	}
+}
+
+func withStatus(status cmapi.IssuancePreviewStatus) previewModifier {
+	return func(p *cmapi.IssuancePreview) {
+		p.Status = status
+	}
+}
+
+func readyIssuer(name string, mods ...gen.IssuerModifier) *cmapi.Issuer {
+	mods = append([]gen.IssuerModifier{
+		gen.SetIssuerNamespace("testns"),
+		gen.AddIssuerCondition(cmapi.IssuerCondition{
+			Type:   cmapi.IssuerConditionReady,
+			Status: cmmeta.ConditionTrue,
+		}),
+	}, mods...)
+	return gen.Issuer(name, mods...)
+}
+
+func TestEvaluate(t *testing.T) {
+	type expectedCheck struct {
+		name   string
+		passed bool
+		reason string
+	}
+
+	privKey := testcrypto.MustCreatePEMPrivateKey(t)
+	otherCert := testcrypto.MustCreateCert(t, privKey, gen.Certificate("another-certificate", gen.SetCertificateDNSNames("example.com")))
+
+	tests := map[string]struct {
+		preview    *cmapi.IssuancePreview
+		cmObjects  []runtime.Object
+		kubeObject []runtime.Object
+
+		expectedChecks []expectedCheck
+	}{
+		"CA issuer that is ready passes": {
+			preview:   preview(),
+			cmObjects: []runtime.Object{readyIssuer("test-issuer", gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca"}))},
+			expectedChecks: []expectedCheck{
+				{cmapi.IssuancePreviewCheckValidation, true, "Valid"},
+				{cmapi.IssuancePreviewCheckIssuerReady, true, "IssuerReady"},
+				{cmapi.IssuancePreviewCheckTriggerPolicy, true, "SecretMissing"},
+			},
+		},
+		"SelfSigned issuer that is not ready fails": {
+			preview: preview(),
+			cmObjects: []runtime.Object{gen.Issuer("test-issuer",
+				gen.SetIssuerNamespace("testns"),
+				gen.SetIssuerSelfSigned(cmapi.SelfSignedIssuer{}),
+			)},
+			expectedChecks: []expectedCheck{
+				{cmapi.IssuancePreviewCheckValidation, true, "Valid"},
+				{cmapi.IssuancePreviewCheckIssuerReady, false, "IssuerNotReady"},
+				{cmapi.IssuancePreviewCheckTriggerPolicy, true, "SecretMissing"},
+			},
+		},
+		"Vault issuer referenced by the issuerRef override passes": {
+			preview:   preview(withIssuerRefOverride(cmmeta.ObjectReference{Name: "vault"})),
+			cmObjects: []runtime.Object{readyIssuer("vault", gen.SetIssuerVaultURL("https://vault.example.com"))},
+			expectedChecks: []expectedCheck{
+				{cmapi.IssuancePreviewCheckValidation, true, "Valid"},
+				{cmapi.IssuancePreviewCheckIssuerReady, true, "IssuerReady"},
+				{cmapi.IssuancePreviewCheckTriggerPolicy, true, "SecretMissing"},
+			},
+		},
+		"Venafi issuer that is ready passes": {
+			preview:   preview(),
+			cmObjects: []runtime.Object{readyIssuer("test-issuer", gen.SetIssuerVenafi(cmapi.VenafiIssuer{Zone: "zone"}))},
+			expectedChecks: []expectedCheck{
+				{cmapi.IssuancePreviewCheckValidation, true, "Valid"},
+				{cmapi.IssuancePreviewCheckIssuerReady, true, "IssuerReady"},
+				{cmapi.IssuancePreviewCheckTriggerPolicy, true, "SecretMissing"},
+			},
+		},
+		"ClusterIssuer that does not exist fails": {
+			preview: preview(withIssuerRefOverride(cmmeta.ObjectReference{Name: "missing", Kind: cmapi.ClusterIssuerKind})),
+			expectedChecks: []expectedCheck{
+				{cmapi.IssuancePreviewCheckValidation, true, "Valid"},
+				{cmapi.IssuancePreviewCheckIssuerReady, false, "IssuerNotFound"},
+				{cmapi.IssuancePreviewCheckTriggerPolicy, true, "SecretMissing"},
+			},
+		},
+		"external issuers are not checked for readiness": {
+			preview: preview(withIssuerRefOverride(cmmeta.ObjectReference{Name: "external", Kind: "External", Group: "example.com"})),
+			expectedChecks: []expectedCheck{
+				{cmapi.IssuancePreviewCheckValidation, true, "Valid"},
+				{cmapi.IssuancePreviewCheckIssuerReady, true, "ExternalIssuer"},
+				{cmapi.IssuancePreviewCheckTriggerPolicy, true, "SecretMissing"},
+			},
+		},
+		"ACME issuer with a matching solver passes": {
+			preview: preview(withDNSNames("example.com", "*.example.com")),
+			cmObjects: []runtime.Object{readyIssuer("test-issuer", gen.SetIssuerACMESolvers([]cmacme.ACMEChallengeSolver{
+				{DNS01: &cmacme.ACMEChallengeSolverDNS01{}},
+			}))},
+			expectedChecks: []expectedCheck{
+				{cmapi.IssuancePreviewCheckValidation, true, "Valid"},
+				{cmapi.IssuancePreviewCheckIssuerReady, true, "IssuerReady"},
+				{cmapi.IssuancePreviewCheckSolverSelection, true, "SolverSelected"},
+				{cmapi.IssuancePreviewCheckTriggerPolicy, true, "SecretMissing"},
+			},
+		},
+		"ACME issuer with ambiguous solvers passes": {
+			preview: preview(),
+			cmObjects: []runtime.Object{readyIssuer("test-issuer", gen.SetIssuerACMESolvers([]cmacme.ACMEChallengeSolver{
+				{HTTP01: &cmacme.ACMEChallengeSolverHTTP01{}},
+				{DNS01: &cmacme.ACMEChallengeSolverDNS01{}},
+			}))},
+			expectedChecks: []expectedCheck{
+				{cmapi.IssuancePreviewCheckValidation, true, "Valid"},
+				{cmapi.IssuancePreviewCheckIssuerReady, true, "IssuerReady"},
+				{cmapi.IssuancePreviewCheckSolverSelection, true, "AmbiguousSolver"},
+				{cmapi.IssuancePreviewCheckTriggerPolicy, true, "SecretMissing"},
+			},
+		},
+		"ACME issuer without a DNS01 solver for a wildcard name fails": {
+			preview: preview(withDNSNames("*.example.com")),
+			cmObjects: []runtime.Object{readyIssuer("test-issuer", gen.SetIssuerACMESolvers([]cmacme.ACMEChallengeSolver{
+				{HTTP01: &cmacme.ACMEChallengeSolverHTTP01{}},
+			}))},
+			expectedChecks: []expectedCheck{
+				{cmapi.IssuancePreviewCheckValidation, true, "Valid"},
+				{cmapi.IssuancePreviewCheckIssuerReady, true, "IssuerReady"},
+				{cmapi.IssuancePreviewCheckSolverSelection, false, "NoSolver"},
+				{cmapi.IssuancePreviewCheckTriggerPolicy, true, "SecretMissing"},
+			},
+		},
+		"invalid certificate spec fails validation": {
+			preview:   preview(withDNSNames()),
+			cmObjects: []runtime.Object{readyIssuer("test-issuer", gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca"}))},
+			expectedChecks: []expectedCheck{
+				{cmapi.IssuancePreviewCheckValidation, false, "Invalid"},
+				{cmapi.IssuancePreviewCheckIssuerReady, true, "IssuerReady"},
+				{cmapi.IssuancePreviewCheckTriggerPolicy, true, "SecretMissing"},
+			},
+		},
+		"Secret belonging to another Certificate fails the trigger policy": {
+			preview:   preview(),
+			cmObjects: []runtime.Object{readyIssuer("test-issuer", gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca"}))},
+			kubeObject: []runtime.Object{&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-secret",
+					Namespace: "testns",
+					Annotations: map[string]string{
+						cmapi.IssuerNameAnnotationKey:  "test-issuer",
+						cmapi.IssuerKindAnnotationKey:  cmapi.IssuerKind,
+						cmapi.IssuerGroupAnnotationKey: "cert-manager.io",
+						cmapi.CertificateNameKey:       "another-certificate",
+					},
+				},
+				Data: map[string][]byte{
+					corev1.TLSPrivateKeyKey: privKey,
+					corev1.TLSCertKey:       otherCert,
+				},
+			}},
+			expectedChecks: []expectedCheck{
+				{cmapi.IssuancePreviewCheckValidation, true, "Valid"},
+				{cmapi.IssuancePreviewCheckIssuerReady, true, "IssuerReady"},
+				{cmapi.IssuancePreviewCheckTriggerPolicy, false, "IncorrectCertificate"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fixedClock,
+				CertManagerObjects: append([]runtime.Object{test.preview}, test.cmObjects...),
+				KubeObjects:        test.kubeObject,
+			}
+			builder.Init()
+
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+			builder.Start()
+			defer builder.Stop()
+
+			checks := w.controller.evaluate(context.Background(), test.preview)
+			if len(checks) != len(test.expectedChecks) {
+				t.Fatalf("expected %d checks, got %d: %+v", len(test.expectedChecks), len(checks), checks)
+			}
+			for i, exp := range test.expectedChecks {
+				got := checks[i]
+				if got.Name != exp.name || got.Passed != exp.passed || got.Reason != exp.reason {
+					t.Errorf("unexpected check %d: expected name=%s passed=%t reason=%s, got name=%s passed=%t reason=%s (%s)",
+						i, exp.name, exp.passed, exp.reason, got.Name, got.Passed, got.Reason, got.Message)
+				}
+			}
+		})
+	}
+}
+
+func TestProcessItem(t *testing.T) {
+	completed := metav1.NewTime(fixedClockStart.Add(-time.Minute))
+	evaluated := cmapi.IssuancePreviewStatus{
+		Verdict: cmapi.IssuancePreviewVerdictPass,
+		Checks: []cmapi.IssuancePreviewCheck{
+			{Name: cmapi.IssuancePreviewCheckIssuerReady, Passed: true, Reason: "ExternalIssuer"},
+		},
+		ObservedGeneration: 1,
+		CompletionTime:     &completed,
+	}
+
+	tests := map[string]struct {
+		preview         *cmapi.IssuancePreview
+		expectedActions []testpkg.Action
+	}{
+		"evaluates a new IssuancePreview and updates its status": {
+			preview: preview(withIssuerRefOverride(cmmeta.ObjectReference{Name: "external", Kind: "External", Group: "example.com"})),
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					cmapi.SchemeGroupVersion.WithResource("issuancepreviews"),
+					"status",
+					"testns",
+					preview(
+						withIssuerRefOverride(cmmeta.ObjectReference{Name: "external", Kind: "External", Group: "example.com"}),
+						withStatus(cmapi.IssuancePreviewStatus{
+							Verdict:            cmapi.IssuancePreviewVerdictPass,
+							ObservedGeneration: 1,
+							CompletionTime:     &metav1.Time{Time: fixedClockStart},
+							Checks: []cmapi.IssuancePreviewCheck{
+								{Name: cmapi.IssuancePreviewCheckValidation, Passed: true, Reason: "Valid", Message: "Certificate spec is valid"},
+								{Name: cmapi.IssuancePreviewCheckIssuerReady, Passed: true, Reason: "ExternalIssuer", Message: `Readiness of issuers in the "example.com" group is not checked`},
+								{Name: cmapi.IssuancePreviewCheckTriggerPolicy, Passed: true, Reason: "SecretMissing", Message: "Issuing certificate as Secret does not exist"},
+							},
+						}),
+					),
+				)),
+			},
+		},
+		"does nothing if the TTL has not expired": {
+			preview: preview(withStatus(evaluated)),
+		},
+		"deletes the IssuancePreview once the TTL has expired": {
+			preview: preview(withStatus(evaluated), func(p *cmapi.IssuancePreview) {
+				p.Spec.TTLSecondsAfterFinished = ptr.To(int32(30))
+			}),
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewDeleteAction(cmapi.SchemeGroupVersion.WithResource("issuancepreviews"), "testns", "test-preview")),
+			},
+		},
+		"re-evaluates the IssuancePreview if its generation has changed": {
+			preview: preview(withStatus(evaluated), func(p *cmapi.IssuancePreview) {
+				p.Generation = 2
+				p.Spec.IssuerRef = &cmmeta.ObjectReference{Name: "external", Kind: "External", Group: "example.com"}
+			}),
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					cmapi.SchemeGroupVersion.WithResource("issuancepreviews"),
+					"status",
+					"testns",
+					preview(
+						func(p *cmapi.IssuancePreview) { p.Generation = 2 },
+						withIssuerRefOverride(cmmeta.ObjectReference{Name: "external", Kind: "External", Group: "example.com"}),
+						withStatus(cmapi.IssuancePreviewStatus{
+							Verdict:            cmapi.IssuancePreviewVerdictPass,
+							ObservedGeneration: 2,
+							CompletionTime:     &metav1.Time{Time: fixedClockStart},
+							Checks: []cmapi.IssuancePreviewCheck{
+								{Name: cmapi.IssuancePreviewCheckValidation, Passed: true, Reason: "Valid", Message: "Certificate spec is valid"},
+								{Name: cmapi.IssuancePreviewCheckIssuerReady, Passed: true, Reason: "ExternalIssuer", Message: `Readiness of issuers in the "example.com" group is not checked`},
+								{Name: cmapi.IssuancePreviewCheckTriggerPolicy, Passed: true, Reason: "SecretMissing", Message: "Issuing certificate as Secret does not exist"},
+							},
+						}),
+					),
+				)),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fixedClock,
+				CertManagerObjects: []runtime.Object{test.preview},
+				ExpectedActions:    test.expectedActions,
+			}
+			builder.Init()
+
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+			builder.Start()
+			defer builder.Stop()
+
+			key, err := controllerpkg.KeyFunc(test.preview)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := w.controller.ProcessItem(context.Background(), key); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := builder.AllEventsCalled(); err != nil {
+				t.Error(err)
+			}
+			if err := builder.AllActionsExecuted(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -34,6 +34,13 @@ func (c *controller) issuersForSecret(secret *corev1.Secret) ([]*v1.Issuer, erro
 
 	var affected []*v1.Issuer
 	for _, iss := range issuers {
+		// A CA issuer may reference a Secret in another namespace, whose
+		// grant is checked when the issuer is synced.
+		if ref := caSecretRef(iss.Spec.CA); ref != nil && ref.Namespace == secret.Namespace && ref.Name == secret.Name {
+			affected = append(affected, iss)
+			continue
+		}
+
 		// only applicable for Issuer resources
 		if iss.Namespace != secret.Namespace {
 			continue
@@ -51,8 +58,14 @@ func (c *controller) issuersForSecret(secret *corev1.Secret) ([]*v1.Issuer, erro
 					continue
 				}
 			}
+			if iss.Spec.ACME.CABundleSecretRef != nil {
+				if iss.Spec.ACME.CABundleSecretRef.Name == secret.Name {
+					affected = append(affected, iss)
+					continue
+				}
+			}
 		case iss.Spec.CA != nil:
-			if iss.Spec.CA.SecretName == secret.Name {
+			if iss.Spec.CA.SecretName == secret.Name || (iss.Spec.CA.SecretRef != nil && iss.Spec.CA.SecretRef.Namespace == "" && iss.Spec.CA.SecretRef.Name == secret.Name) {
 				affected = append(affected, iss)
 				continue
 			}
@@ -99,3 +112,12 @@ func (c *controller) issuersForSecret(secret *corev1.Secret) ([]*v1.Issuer, erro
 
 	return affected, nil
 }
+
+// caSecretRef returns the reference of a CA issuer to a Secret in another
+// namespace, or nil if it has none.
+func caSecretRef(ca *v1.CAIssuer) *v1.CAIssuerSecretReference {
+	if ca == nil || ca.SecretRef == nil || ca.SecretRef.Namespace == "" {
+		return nil
+	}
+	return ca.SecretRef
+}
@@ -0,0 +1,295 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+)
+
+// Priority is the class of an item added to a priority queue. Items of a
+// higher class are processed before items of a lower class.
+type Priority int
+
+const (
+	PriorityLow Priority = iota
+	PriorityMedium
+	PriorityHigh
+)
+
+// numPriorities is the number of priority classes.
+const numPriorities = int(PriorityHigh) + 1
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityLow:
+		return "low"
+	case PriorityMedium:
+		return "medium"
+	case PriorityHigh:
+		return "high"
+	default:
+		return "unknown"
+	}
+}
+
+// PriorityFunc returns the priority of an item. It is called each time the
+// item is added to the queue, including when it is retried after an error.
+type PriorityFunc func(item interface{}) Priority
+
+// DefaultPriorityAgingPeriod is the default AgingPeriod of priority queues.
+const DefaultPriorityAgingPeriod = 10 * time.Minute
+
+// PriorityQueueOptions configures a priority queue.
+type PriorityQueueOptions struct {
+	// AgingPeriod is how much earlier an item is processed for each class
+	// that its priority is above that of another item. An item is processed
+	// before the items of lower classes which were added up to AgingPeriod
+	// later per class between them, but after those added before that. This
+	// means that items of lower classes are not starved, and wait at most
+	// AgingPeriod per class longer than they would have in a FIFO queue.
+	// Defaults to DefaultPriorityAgingPeriod.
+	AgingPeriod time.Duration
+
+	// Clock defaults to the real clock.
+	Clock clock.WithTicker
+
+	// Metrics, if set, is used to record how long items wait in the queue
+	// for each priority class.
+	Metrics *metrics.Metrics
+}
+
+// NewPriorityRateLimitingQueue returns a rate limited workqueue whose items
+// are processed in order of their priority, as returned by prioritize,
+// rather than in the order they were added. See PriorityQueueOptions for how
+// items of lower priority are kept from being starved.
+func NewPriorityRateLimitingQueue(name string, rateLimiter workqueue.RateLimiter, prioritize PriorityFunc, opts PriorityQueueOptions) workqueue.RateLimitingInterface {
+	if opts.Clock == nil {
+		opts.Clock = clock.RealClock{}
+	}
+	return workqueue.NewRateLimitingQueueWithConfig(rateLimiter, workqueue.RateLimitingQueueConfig{
+		Name:  name,
+		Clock: opts.Clock,
+		DelayingQueue: workqueue.NewDelayingQueueWithConfig(workqueue.DelayingQueueConfig{
+			Name:  name,
+			Clock: opts.Clock,
+			Queue: newPriorityQueue(name, prioritize, opts),
+		}),
+	})
+}
+
+// priorityQueue implements workqueue.Interface. As with the default
+// workqueue, an item is only queued once however often it is added, and an
+// item which is added whilst being processed is queued again once Done.
+type priorityQueue struct {
+	name        string
+	prioritize  PriorityFunc
+	agingPeriod time.Duration
+	clock       clock.PassiveClock
+	metrics     *metrics.Metrics
+
+	cond *sync.Cond
+
+	// classes holds the queued items of each priority in the order they were
+	// added. Entries which are no longer the entry of their item in queued
+	// have been moved to a higher class, and are skipped.
+	classes [numPriorities][]*queuedItem
+	// queued holds the entry of each item which is waiting to be processed.
+	queued map[interface{}]*queuedItem
+	// processing holds the items which are being processed.
+	processing map[interface{}]struct{}
+	// requeue holds the items which were added whilst being processed, and
+	// are queued once they are Done.
+	requeue map[interface{}]*queuedItem
+
+	shuttingDown bool
+	drain        bool
+}
+
+type queuedItem struct {
+	item     interface{}
+	priority Priority
+	added    time.Time
+}
+
+var _ workqueue.Interface = &priorityQueue{}
+
+func newPriorityQueue(name string, prioritize PriorityFunc, opts PriorityQueueOptions) *priorityQueue {
+	if opts.AgingPeriod <= 0 {
+		opts.AgingPeriod = DefaultPriorityAgingPeriod
+	}
+	if opts.Clock == nil {
+		opts.Clock = clock.RealClock{}
+	}
+	return &priorityQueue{
+		name:        name,
+		prioritize:  prioritize,
+		agingPeriod: opts.AgingPeriod,
+		clock:       opts.Clock,
+		metrics:     opts.Metrics,
+		cond:        sync.NewCond(&sync.Mutex{}),
+		queued:      make(map[interface{}]*queuedItem),
+		processing:  make(map[interface{}]struct{}),
+		requeue:     make(map[interface{}]*queuedItem),
+	}
+}
+
+func (q *priorityQueue) Add(item interface{}) {
+	// The priority is determined before taking the lock, as prioritize may
+	// read from informer caches.
+	entry := &queuedItem{item: item, priority: q.prioritize(item), added: q.clock.Now()}
+
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	if q.shuttingDown {
+		return
+	}
+
+	if _, ok := q.processing[item]; ok {
+		if existing, ok := q.requeue[item]; ok {
+			entry.added = existing.added
+			if existing.priority > entry.priority {
+				entry.priority = existing.priority
+			}
+		}
+		q.requeue[item] = entry
+		return
+	}
+	q.push(entry)
+}
+
+// push queues entry, unless its item is already queued with the same or a
+// higher priority. An item which is added again with a higher priority is
+// moved to that class, but keeps the time it was first added.
+func (q *priorityQueue) push(entry *queuedItem) {
+	if existing, ok := q.queued[entry.item]; ok {
+		if entry.priority <= existing.priority {
+			return
+		}
+		entry.added = existing.added
+	}
+	q.queued[entry.item] = entry
+	q.classes[entry.priority] = append(q.classes[entry.priority], entry)
+	q.cond.Signal()
+}
+
+func (q *priorityQueue) Len() int {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	return len(q.queued)
+}
+
+func (q *priorityQueue) Get() (interface{}, bool) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	for len(q.queued) == 0 && !q.shuttingDown {
+		q.cond.Wait()
+	}
+	if len(q.queued) == 0 {
+		// The queue is shutting down and has been drained.
+		return nil, true
+	}
+
+	entry := q.pop()
+	delete(q.queued, entry.item)
+	q.processing[entry.item] = struct{}{}
+	if q.metrics != nil {
+		q.metrics.ObserveQueueWaitDuration(q.name, entry.priority.String(), q.clock.Since(entry.added))
+	}
+	return entry.item, false
+}
+
+// pop removes and returns the next entry to be processed. Each class is FIFO,
+// and of the oldest entries of each class, the one added earliest once its
+// time is brought forward by the aging period for each class of its priority
+// is processed first. It must only be called if an item is queued.
+func (q *priorityQueue) pop() *queuedItem {
+	var next *queuedItem
+	var nextDue time.Time
+	for p := range q.classes {
+		head := q.head(Priority(p))
+		if head == nil {
+			continue
+		}
+		due := head.added.Add(-time.Duration(head.priority) * q.agingPeriod)
+		if next == nil || due.Before(nextDue) || (due.Equal(nextDue) && head.priority > next.priority) {
+			next, nextDue = head, due
+		}
+	}
+	class := q.classes[next.priority]
+	class[0] = nil
+	q.classes[next.priority] = class[1:]
+	return next
+}
+
+// head returns the oldest entry of the given class, after discarding the
+// entries of items which were moved to a higher class.
+func (q *priorityQueue) head(p Priority) *queuedItem {
+	class := q.classes[p]
+	for len(class) > 0 && q.queued[class[0].item] != class[0] {
+		class[0] = nil
+		class = class[1:]
+	}
+	q.classes[p] = class
+	if len(class) == 0 {
+		return nil
+	}
+	return class[0]
+}
+
+func (q *priorityQueue) Done(item interface{}) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	delete(q.processing, item)
+	if entry, ok := q.requeue[item]; ok {
+		delete(q.requeue, item)
+		q.push(entry)
+	} else if len(q.processing) == 0 {
+		q.cond.Signal()
+	}
+}
+
+func (q *priorityQueue) ShutDown() {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	q.drain = false
+	q.shuttingDown = true
+	q.cond.Broadcast()
+}
+
+func (q *priorityQueue) ShutDownWithDrain() {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	q.drain = true
+	q.shuttingDown = true
+	q.cond.Broadcast()
+	for len(q.processing) != 0 && q.drain {
+		q.cond.Wait()
+	}
+}
+
+func (q *priorityQueue) ShuttingDown() bool {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	return q.shuttingDown
+}
@@ -0,0 +1,299 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/util/workqueue"
+	fakeclock "k8s.io/utils/clock/testing"
+)
+
+// prioritizeByPrefix prioritizes items by the prefix of their key.
+func prioritizeByPrefix(item interface{}) Priority {
+	switch key := item.(string); {
+	case strings.HasPrefix(key, "high"):
+		return PriorityHigh
+	case strings.HasPrefix(key, "medium"):
+		return PriorityMedium
+	default:
+		return PriorityLow
+	}
+}
+
+func newTestPriorityQueue() (*priorityQueue, *fakeclock.FakeClock) {
+	clock := fakeclock.NewFakeClock(time.Now())
+	return newPriorityQueue("test", prioritizeByPrefix, PriorityQueueOptions{AgingPeriod: time.Minute, Clock: clock}), clock
+}
+
+// drain processes all queued items and returns them in the order processed.
+func drain(q workqueue.Interface) []string {
+	var processed []string
+	for q.Len() > 0 {
+		item, _ := q.Get()
+		processed = append(processed, item.(string))
+		q.Done(item)
+	}
+	return processed
+}
+
+func TestPriorityQueueOrder(t *testing.T) {
+	q, _ := newTestPriorityQueue()
+	for _, key := range []string{"low-1", "medium-1", "high-1", "low-2", "high-2", "medium-2"} {
+		q.Add(key)
+	}
+	assert.Equal(t, 6, q.Len())
+	assert.Equal(t, []string{"high-1", "high-2", "medium-1", "medium-2", "low-1", "low-2"}, drain(q))
+}
+
+func TestPriorityQueueDeduplicates(t *testing.T) {
+	priorities := map[string]Priority{"a": PriorityLow, "b": PriorityLow}
+	var mu sync.Mutex
+	q := newPriorityQueue("test", func(item interface{}) Priority {
+		mu.Lock()
+		defer mu.Unlock()
+		return priorities[item.(string)]
+	}, PriorityQueueOptions{Clock: fakeclock.NewFakeClock(time.Now())})
+	setPriority := func(key string, p Priority) {
+		mu.Lock()
+		defer mu.Unlock()
+		priorities[key] = p
+	}
+
+	q.Add("a")
+	q.Add("b")
+	q.Add("a")
+	assert.Equal(t, 2, q.Len(), "an item must only be queued once")
+
+	// Adding an item again with a higher priority moves it to that class.
+	setPriority("b", PriorityHigh)
+	q.Add("b")
+	assert.Equal(t, 2, q.Len())
+	// Adding an item again with a lower priority keeps its priority.
+	setPriority("b", PriorityLow)
+	q.Add("b")
+	assert.Equal(t, []string{"b", "a"}, drain(q))
+}
+
+func TestPriorityQueueAddWhileProcessing(t *testing.T) {
+	q, _ := newTestPriorityQueue()
+	q.Add("low-1")
+	q.Add("low-2")
+
+	item, _ := q.Get()
+	require.Equal(t, "low-1", item)
+	// An item added whilst it is processed is not handed to another worker
+	// until it is Done.
+	q.Add("low-1")
+	assert.Equal(t, 1, q.Len())
+	next, _ := q.Get()
+	assert.Equal(t, "low-2", next)
+	q.Done(next)
+	assert.Equal(t, 0, q.Len())
+
+	q.Done(item)
+	assert.Equal(t, []string{"low-1"}, drain(q))
+}
+
+func TestPriorityQueueAging(t *testing.T) {
+	tests := map[string]struct {
+		first  string
+		wait   time.Duration
+		second string
+
+		expected []string
+	}{
+		"a high priority item overtakes a recent low priority item": {
+			first: "low", wait: time.Minute, second: "high",
+			expected: []string{"high", "low"},
+		},
+		"a low priority item which waited longer than the aging period per class is not overtaken": {
+			first: "low", wait: 2*time.Minute + time.Second, second: "high",
+			expected: []string{"low", "high"},
+		},
+		"a medium priority item overtakes a low priority item within an aging period": {
+			first: "low", wait: 59 * time.Second, second: "medium",
+			expected: []string{"medium", "low"},
+		},
+		"a low priority item which waited longer than an aging period is not overtaken by a medium priority item": {
+			first: "low", wait: time.Minute + time.Second, second: "medium",
+			expected: []string{"low", "medium"},
+		},
+		"items of the same priority are processed in order": {
+			first: "high-1", wait: time.Hour, second: "high-2",
+			expected: []string{"high-1", "high-2"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			q, clock := newTestPriorityQueue()
+			q.Add(test.first)
+			clock.Step(test.wait)
+			q.Add(test.second)
+			assert.Equal(t, test.expected, drain(q))
+		})
+	}
+}
+
+func TestPriorityQueueShutDown(t *testing.T) {
+	q, _ := newTestPriorityQueue()
+	q.Add("low")
+	q.Add("high")
+	q.ShutDown()
+	assert.True(t, q.ShuttingDown())
+
+	q.Add("medium")
+	assert.Equal(t, 2, q.Len(), "items added after shut down must be ignored")
+
+	// Queued items are still handed out once the queue is shutting down.
+	item, shutdown := q.Get()
+	assert.Equal(t, "high", item)
+	assert.False(t, shutdown)
+	q.Done(item)
+	item, shutdown = q.Get()
+	assert.Equal(t, "low", item)
+	assert.False(t, shutdown)
+	q.Done(item)
+
+	_, shutdown = q.Get()
+	assert.True(t, shutdown)
+}
+
+func TestPriorityQueueShutDownWithDrain(t *testing.T) {
+	q, _ := newTestPriorityQueue()
+	q.Add("low")
+	item, _ := q.Get()
+
+	drained := make(chan struct{})
+	go func() {
+		defer close(drained)
+		q.ShutDownWithDrain()
+	}()
+	select {
+	case <-drained:
+		t.Fatal("expected ShutDownWithDrain to wait for items being processed")
+	case <-time.After(50 * time.Millisecond):
+	}
+	q.Done(item)
+	select {
+	case <-drained:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected ShutDownWithDrain to return once items were processed")
+	}
+}
+
+// TestPriorityQueueBacklog fills a queue with a backlog of low and medium
+// priority items, as during a renewal storm, and checks that high priority
+// items which are added later are processed first by concurrent workers,
+// whilst every item is processed exactly once.
+func TestPriorityQueueBacklog(t *testing.T) {
+	const (
+		backlog = 10000
+		high    = 100
+		workers = 8
+	)
+	clock := fakeclock.NewFakeClock(time.Now())
+	queue := NewPriorityRateLimitingQueue("test", workqueue.DefaultControllerRateLimiter(), prioritizeByPrefix, PriorityQueueOptions{Clock: clock})
+
+	expected := make(map[string]bool)
+	for i := 0; i < backlog; i++ {
+		key := fmt.Sprintf("low-%d", i)
+		if i%2 == 0 {
+			key = fmt.Sprintf("medium-%d", i)
+		}
+		queue.Add(key)
+		expected[key] = true
+	}
+	for i := 0; i < high; i++ {
+		key := fmt.Sprintf("high-%d", i)
+		queue.Add(key)
+		expected[key] = true
+	}
+	require.Equal(t, backlog+high, queue.Len())
+
+	var (
+		mu        sync.Mutex
+		processed []string
+		wg        sync.WaitGroup
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				// Items are recorded in the order they are handed out.
+				mu.Lock()
+				item, shutdown := queue.Get()
+				if shutdown {
+					mu.Unlock()
+					return
+				}
+				processed = append(processed, item.(string))
+				if len(processed) == backlog+high {
+					queue.ShutDown()
+				}
+				mu.Unlock()
+				queue.Done(item)
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.Len(t, processed, backlog+high)
+	seen := make(map[string]bool)
+	for _, key := range processed {
+		assert.False(t, seen[key], "item %q was processed more than once", key)
+		seen[key] = true
+	}
+	assert.Equal(t, expected, seen)
+
+	// The high priority items are processed first, followed by the medium
+	// priority items, even though they were added after the backlog.
+	for i, key := range processed {
+		switch {
+		case i < high:
+			assert.True(t, strings.HasPrefix(key, "high-"), "expected item %d to have a high priority, got %q", i, key)
+		case i < high+backlog/2:
+			assert.True(t, strings.HasPrefix(key, "medium-"), "expected item %d to have a medium priority, got %q", i, key)
+		default:
+			assert.True(t, strings.HasPrefix(key, "low-"), "expected item %d to have a low priority, got %q", i, key)
+		}
+	}
+}
+
+// BenchmarkPriorityQueue measures adding and processing items of mixed
+// priorities with a backlog of queued items.
+func BenchmarkPriorityQueue(b *testing.B) {
+	q := newPriorityQueue("bench", prioritizeByPrefix, PriorityQueueOptions{})
+	prefixes := []string{"low", "medium", "high"}
+	for i := 0; i < 10000; i++ {
+		q.Add(fmt.Sprintf("%s-backlog-%d", prefixes[i%3], i))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		q.Add(fmt.Sprintf("%s-%d", prefixes[i%3], i))
+		item, _ := q.Get()
+		q.Done(item)
+	}
+}
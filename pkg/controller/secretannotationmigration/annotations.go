@@ -0,0 +1,79 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretannotationmigration
+
+import (
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+const (
+	// The issuer annotation keys written by versions of cert-manager which
+	// used the certmanager.k8s.io API group.
+	deprecatedIssuerNameAnnotationKey = "certmanager.k8s.io/issuer-name"
+	deprecatedIssuerKindAnnotationKey = "certmanager.k8s.io/issuer-kind"
+)
+
+// migratedAnnotations returns the Secret annotations with the issuer
+// annotations rewritten into their current form, and whether any annotation
+// was changed:
+//   - the deprecated certmanager.k8s.io issuer annotations are replaced by
+//     the cert-manager.io annotations, unless those are already set;
+//   - the issuer kind and group are written in their canonical form, e.g. an
+//     empty group is recorded as cert-manager.io.
+//
+// The given annotations are not modified. Annotations which are already in
+// their current form are returned unchanged, so migrating is idempotent.
+func migratedAnnotations(annotations map[string]string) (map[string]string, bool) {
+	_, hasName := annotations[cmapi.IssuerNameAnnotationKey]
+	_, hasDeprecatedName := annotations[deprecatedIssuerNameAnnotationKey]
+	_, hasDeprecatedKind := annotations[deprecatedIssuerKindAnnotationKey]
+	if !hasName && !hasDeprecatedName && !hasDeprecatedKind {
+		// The Secret does not record an issuer.
+		return annotations, false
+	}
+
+	migrated := make(map[string]string, len(annotations)+1)
+	for k, v := range annotations {
+		migrated[k] = v
+	}
+
+	for deprecated, current := range map[string]string{
+		deprecatedIssuerNameAnnotationKey: cmapi.IssuerNameAnnotationKey,
+		deprecatedIssuerKindAnnotationKey: cmapi.IssuerKindAnnotationKey,
+	} {
+		value, ok := migrated[deprecated]
+		if !ok {
+			continue
+		}
+		if _, ok := migrated[current]; !ok {
+			migrated[current] = value
+		}
+		delete(migrated, deprecated)
+	}
+
+	kind, group := apiutil.NormalizeIssuerKindAndGroup(migrated[cmapi.IssuerKindAnnotationKey], migrated[cmapi.IssuerGroupAnnotationKey])
+	migrated[cmapi.IssuerKindAnnotationKey] = kind
+	migrated[cmapi.IssuerGroupAnnotationKey] = group
+
+	for k, v := range annotations {
+		if value, ok := migrated[k]; !ok || value != v {
+			return migrated, true
+		}
+	}
+	return migrated, len(migrated) != len(annotations)
+}
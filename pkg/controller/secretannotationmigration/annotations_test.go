@@ -0,0 +1,143 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretannotationmigration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_migratedAnnotations(t *testing.T) {
+	tests := map[string]struct {
+		annotations map[string]string
+		exp         map[string]string
+		expChanged  bool
+	}{
+		"Secrets without issuer annotations are not changed": {
+			annotations: map[string]string{"foo": "bar"},
+			exp:         map[string]string{"foo": "bar"},
+		},
+		"current annotations are not changed": {
+			annotations: map[string]string{
+				"cert-manager.io/issuer-name":  "ca",
+				"cert-manager.io/issuer-kind":  "ClusterIssuer",
+				"cert-manager.io/issuer-group": "cert-manager.io",
+			},
+			exp: map[string]string{
+				"cert-manager.io/issuer-name":  "ca",
+				"cert-manager.io/issuer-kind":  "ClusterIssuer",
+				"cert-manager.io/issuer-group": "cert-manager.io",
+			},
+		},
+		"an empty group is written as cert-manager.io": {
+			annotations: map[string]string{
+				"cert-manager.io/issuer-name":  "ca",
+				"cert-manager.io/issuer-kind":  "Issuer",
+				"cert-manager.io/issuer-group": "",
+			},
+			exp: map[string]string{
+				"cert-manager.io/issuer-name":  "ca",
+				"cert-manager.io/issuer-kind":  "Issuer",
+				"cert-manager.io/issuer-group": "cert-manager.io",
+			},
+			expChanged: true,
+		},
+		"missing kind and group are written": {
+			annotations: map[string]string{
+				"cert-manager.io/issuer-name": "ca",
+				"foo":                         "bar",
+			},
+			exp: map[string]string{
+				"cert-manager.io/issuer-name":  "ca",
+				"cert-manager.io/issuer-kind":  "Issuer",
+				"cert-manager.io/issuer-group": "cert-manager.io",
+				"foo":                          "bar",
+			},
+			expChanged: true,
+		},
+		"a group qualified kind is split": {
+			annotations: map[string]string{
+				"cert-manager.io/issuer-name": "ca",
+				"cert-manager.io/issuer-kind": "ClusterIssuer.cert-manager.io",
+			},
+			exp: map[string]string{
+				"cert-manager.io/issuer-name":  "ca",
+				"cert-manager.io/issuer-kind":  "ClusterIssuer",
+				"cert-manager.io/issuer-group": "cert-manager.io",
+			},
+			expChanged: true,
+		},
+		"external issuers are left untouched": {
+			annotations: map[string]string{
+				"cert-manager.io/issuer-name":  "aws",
+				"cert-manager.io/issuer-kind":  "AWSPCAClusterIssuer",
+				"cert-manager.io/issuer-group": "awspca.cert-manager.io",
+			},
+			exp: map[string]string{
+				"cert-manager.io/issuer-name":  "aws",
+				"cert-manager.io/issuer-kind":  "AWSPCAClusterIssuer",
+				"cert-manager.io/issuer-group": "awspca.cert-manager.io",
+			},
+		},
+		"deprecated annotations are replaced": {
+			annotations: map[string]string{
+				"certmanager.k8s.io/issuer-name": "ca",
+				"certmanager.k8s.io/issuer-kind": "ClusterIssuer",
+			},
+			exp: map[string]string{
+				"cert-manager.io/issuer-name":  "ca",
+				"cert-manager.io/issuer-kind":  "ClusterIssuer",
+				"cert-manager.io/issuer-group": "cert-manager.io",
+			},
+			expChanged: true,
+		},
+		"current annotations take precedence over deprecated annotations": {
+			annotations: map[string]string{
+				"certmanager.k8s.io/issuer-name": "old-ca",
+				"certmanager.k8s.io/issuer-kind": "Issuer",
+				"cert-manager.io/issuer-name":    "ca",
+				"cert-manager.io/issuer-kind":    "ClusterIssuer",
+				"cert-manager.io/issuer-group":   "cert-manager.io",
+			},
+			exp: map[string]string{
+				"cert-manager.io/issuer-name":  "ca",
+				"cert-manager.io/issuer-kind":  "ClusterIssuer",
+				"cert-manager.io/issuer-group": "cert-manager.io",
+			},
+			expChanged: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			original := make(map[string]string, len(test.annotations))
+			for k, v := range test.annotations {
+				original[k] = v
+			}
+
+			got, changed := migratedAnnotations(test.annotations)
+			assert.Equal(t, test.exp, got)
+			assert.Equal(t, test.expChanged, changed)
+			assert.Equal(t, original, test.annotations, "the given annotations must not be modified")
+
+			// Migrating is idempotent.
+			again, changed := migratedAnnotations(got)
+			assert.Equal(t, got, again)
+			assert.False(t, changed)
+		})
+	}
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package secretannotationmigration rewrites the issuer annotations of
+// Secrets written by older versions of cert-manager into their current form,
+// so that Certificates are not re-issued after an upgrade only because their
+// Secret records the issuer in a legacy format.
+package secretannotationmigration
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+)
+
+const (
+	ControllerName = "secret-annotation-migration"
+
+	// UpdateInterval is the minimum interval between two Secret updates made
+	// by the controller, so that migrating a large number of Secrets does not
+	// overload the API server.
+	UpdateInterval = time.Second / 5
+)
+
+// controller migrates the issuer annotations of Secrets. Secrets are only
+// queued while their annotations are in a legacy format, and are migrated in
+// place, so the migration is idempotent and resumes where it left off when
+// the controller is restarted.
+type controller struct {
+	secretLister internalinformers.SecretLister
+	client       kubernetes.Interface
+	metrics      *metrics.Metrics
+
+	queue workqueue.RateLimitingInterface
+	clock clock.Clock
+
+	lock sync.Mutex
+	// pending is the set of keys of Secrets which have been observed with
+	// legacy annotations and have not been migrated yet.
+	pending sets.Set[string]
+	// nextUpdate is the earliest time at which the next Secret may be
+	// updated.
+	nextUpdate time.Time
+}
+
+func NewController(log logr.Logger, ctx *controllerpkg.Context) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
+	// create a queue used to queue up items to be processed
+	queue := workqueue.NewNamedRateLimitingQueue(controllerpkg.DefaultItemBasedRateLimiter(), ControllerName)
+
+	// obtain references to all the informers used by this controller
+	secretInformer := ctx.KubeSharedInformerFactory.Secrets()
+
+	c := &controller{
+		secretLister: secretInformer.Lister(),
+		client:       ctx.Client,
+		metrics:      ctx.Metrics,
+		queue:        queue,
+		clock:        ctx.Clock,
+		pending:      sets.New[string](),
+	}
+
+	secretInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: c.enqueueLegacySecret(log),
+	})
+
+	// build a list of InformerSynced functions that will be returned by the Register method.
+	// the controller will only begin processing items once all of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		secretInformer.Informer().HasSynced,
+	}
+
+	return c, queue, mustSync
+}
+
+// ProcessItem migrates the annotations of the Secret if they are still in a
+// legacy format. Secret updates are paced by UpdateInterval: if the Secret
+// cannot be updated yet, it is requeued for when it can.
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx).WithValues("key", key)
+
+	ctx = logf.NewContext(ctx, log)
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key passed to ProcessItem")
+		return nil
+	}
+
+	secret, err := c.secretLister.Secrets(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("secret not found for key", "error", err.Error())
+		c.done(key)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	annotations, changed := migratedAnnotations(secret.Annotations)
+	if !changed {
+		c.done(key)
+		return nil
+	}
+
+	if wait := c.reserveUpdate(); wait > 0 {
+		log.V(logf.DebugLevel).Info("update rate limit reached, requeueing", "after", wait)
+		c.queue.AddAfter(key, wait)
+		return nil
+	}
+
+	// Only the annotations are changed, the certificate data of the Secret
+	// is left untouched. The update fails with a conflict if the Secret has
+	// changed since it was observed, in which case it is retried.
+	secret = secret.DeepCopy()
+	secret.Annotations = annotations
+	if _, err := c.client.CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+		c.metrics.IncrementSecretAnnotationMigrationCount("failed")
+		return err
+	}
+
+	log.V(logf.InfoLevel).Info("migrated legacy issuer annotations of secret")
+	c.metrics.IncrementSecretAnnotationMigrationCount("migrated")
+	c.done(key)
+	return nil
+}
+
+// reserveUpdate reserves the next Secret update. It returns zero if the
+// Secret may be updated now, or the time to wait until it may be updated.
+func (c *controller) reserveUpdate() time.Duration {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	now := c.clock.Now()
+	if wait := c.nextUpdate.Sub(now); wait > 0 {
+		return wait
+	}
+	c.nextUpdate = now.Add(UpdateInterval)
+	return 0
+}
+
+// done records that the Secret no longer needs to be migrated.
+func (c *controller) done(key string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.pending.Delete(key)
+	c.metrics.SetSecretAnnotationMigrationPending(c.pending.Len())
+}
+
+// enqueueLegacySecret returns a WorkFunc for Secret informers which queues
+// Secrets whose annotations are in a legacy format. Secrets whose annotations
+// are current are never queued, so that only the Secrets which need to be
+// migrated are processed.
+func (c *controller) enqueueLegacySecret(log logr.Logger) func(obj interface{}) {
+	return func(obj interface{}) {
+		metaObj, ok := controllerpkg.ToSecret(obj)
+		if !ok {
+			log.V(logf.ErrorLevel).Info("Secret informer returned a non-Secret object", "object", obj)
+			return
+		}
+		if _, changed := migratedAnnotations(metaObj.Annotations); !changed {
+			return
+		}
+
+		key, err := controllerpkg.KeyFunc(metaObj)
+		if err != nil {
+			log.Error(err, "error computing key for resource")
+			return
+		}
+
+		c.lock.Lock()
+		c.pending.Insert(key)
+		c.metrics.SetSecretAnnotationMigrationPending(c.pending.Len())
+		c.lock.Unlock()
+
+		c.queue.Add(key)
+	}
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+
+	ctrl, queue, mustSync := NewController(log, ctx)
+	c.controller = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controllerWrapper{}).
+			Complete()
+	})
+}
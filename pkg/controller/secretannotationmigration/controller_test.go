@@ -0,0 +1,258 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretannotationmigration
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+)
+
+func secretWithAnnotations(name string, annotations map[string]string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        name,
+			Annotations: annotations,
+		},
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       []byte("cert-" + name),
+			corev1.TLSPrivateKeyKey: []byte("key-" + name),
+		},
+		Type: corev1.SecretTypeTLS,
+	}
+}
+
+// startController starts the controller for the given Secrets, and waits for
+// it to have observed every Secret with legacy annotations.
+func startController(t *testing.T, clock *fakeclock.FakeClock, secrets []*corev1.Secret, expPending int) (*controllerWrapper, *testpkg.Builder) {
+	t.Helper()
+
+	var objects []runtime.Object
+	for _, secret := range secrets {
+		objects = append(objects, secret)
+	}
+	builder := &testpkg.Builder{
+		T:           t,
+		Clock:       clock,
+		KubeObjects: objects,
+	}
+	builder.Init()
+	w := &controllerWrapper{}
+	if _, _, err := w.Register(builder.Context); err != nil {
+		t.Fatal(err)
+	}
+	builder.Start()
+
+	var pending int
+	if err := wait.PollUntilContextTimeout(context.Background(), time.Millisecond*50, time.Second*5, true, func(context.Context) (bool, error) {
+		pending = pendingSecrets(w)
+		return pending == expPending, nil
+	}); err != nil {
+		t.Fatalf("expected %d pending Secrets, got %d", expPending, pending)
+	}
+	return w, builder
+}
+
+func pendingSecrets(w *controllerWrapper) int {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.pending.Len()
+}
+
+// waitForLegacySecrets waits for the informer cache to observe the expected
+// number of Secrets with legacy annotations.
+func waitForLegacySecrets(t *testing.T, w *controllerWrapper, exp int) {
+	t.Helper()
+	var legacy int
+	if err := wait.PollUntilContextTimeout(context.Background(), time.Millisecond*50, time.Second*5, true, func(context.Context) (bool, error) {
+		secrets, err := w.secretLister.Secrets("default").List(labels.Everything())
+		if err != nil {
+			return false, err
+		}
+		legacy = 0
+		for _, secret := range secrets {
+			if _, changed := migratedAnnotations(secret.Annotations); changed {
+				legacy++
+			}
+		}
+		return legacy == exp, nil
+	}); err != nil {
+		t.Fatalf("expected %d Secrets with legacy annotations, got %d", exp, legacy)
+	}
+}
+
+func secretUpdates(builder *testpkg.Builder) int {
+	var updates int
+	for _, action := range builder.FakeKubeClient().Actions() {
+		if action.Matches("update", "secrets") {
+			updates++
+		}
+	}
+	return updates
+}
+
+func listSecrets(t *testing.T, builder *testpkg.Builder) []*corev1.Secret {
+	t.Helper()
+	list, err := builder.FakeKubeClient().CoreV1().Secrets("default").List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var secrets []*corev1.Secret
+	for i := range list.Items {
+		secrets = append(secrets, &list.Items[i])
+	}
+	sort.Slice(secrets, func(i, j int) bool { return secrets[i].Name < secrets[j].Name })
+	return secrets
+}
+
+func TestLegacySecretsAreMigratedGradually(t *testing.T) {
+	// The population is made up of Secrets whose issuer annotations were
+	// written with an empty group or with the deprecated keys, Secrets with
+	// current annotations, and a Secret which is not managed by cert-manager.
+	var secrets []*corev1.Secret
+	for i := 0; i < 5; i++ {
+		secrets = append(secrets, secretWithAnnotations(fmt.Sprintf("empty-group-%d", i), map[string]string{
+			"cert-manager.io/issuer-name":  "ca",
+			"cert-manager.io/issuer-kind":  "Issuer",
+			"cert-manager.io/issuer-group": "",
+		}))
+	}
+	for i := 0; i < 2; i++ {
+		secrets = append(secrets, secretWithAnnotations(fmt.Sprintf("deprecated-%d", i), map[string]string{
+			"certmanager.k8s.io/issuer-name": "ca",
+			"certmanager.k8s.io/issuer-kind": "ClusterIssuer",
+		}))
+	}
+	for i := 0; i < 3; i++ {
+		secrets = append(secrets, secretWithAnnotations(fmt.Sprintf("current-%d", i), map[string]string{
+			"cert-manager.io/issuer-name":  "ca",
+			"cert-manager.io/issuer-kind":  "Issuer",
+			"cert-manager.io/issuer-group": "cert-manager.io",
+		}))
+	}
+	secrets = append(secrets, secretWithAnnotations("unmanaged", nil))
+
+	ctx := context.Background()
+	clock := fakeclock.NewFakeClock(time.Now())
+
+	// process processes every Secret once, and waits for the informer cache
+	// to observe the updates. As the clock does not move, at most one Secret
+	// may be updated.
+	process := func(w *controllerWrapper, builder *testpkg.Builder, expUpdates, expRemaining int) {
+		t.Helper()
+		before := secretUpdates(builder)
+		for _, secret := range secrets {
+			if err := w.controller.ProcessItem(ctx, "default/"+secret.Name); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if updates := secretUpdates(builder) - before; updates != expUpdates {
+			t.Fatalf("expected %d Secret updates, got %d", expUpdates, updates)
+		}
+		waitForLegacySecrets(t, w, expRemaining)
+		clock.Step(UpdateInterval)
+	}
+
+	w, builder := startController(t, clock, secrets, 7)
+	for i := 0; i < 3; i++ {
+		process(w, builder, 1, 6-i)
+	}
+	if pending := pendingSecrets(w); pending != 4 {
+		t.Errorf("expected 4 pending Secrets, got %d", pending)
+	}
+
+	// Restarting the controller resumes the migration with the Secrets which
+	// have not been migrated yet.
+	migrated := listSecrets(t, builder)
+	builder.Stop()
+	w, builder = startController(t, clock, migrated, 4)
+	defer builder.Stop()
+	for i := 0; i < 4; i++ {
+		process(w, builder, 1, 3-i)
+	}
+	if pending := pendingSecrets(w); pending != 0 {
+		t.Errorf("expected no pending Secrets, got %d", pending)
+	}
+
+	// Once every Secret has been migrated, the Secrets are not updated again.
+	process(w, builder, 0, 0)
+
+	for _, secret := range listSecrets(t, builder) {
+		var original *corev1.Secret
+		for _, s := range secrets {
+			if s.Name == secret.Name {
+				original = s
+			}
+		}
+		if !apiequality.Semantic.DeepEqual(original.Data, secret.Data) {
+			t.Errorf("%s: the data of the Secret was changed", secret.Name)
+		}
+		if original.Annotations == nil {
+			if len(secret.Annotations) > 0 {
+				t.Errorf("%s: unexpected annotations %v", secret.Name, secret.Annotations)
+			}
+			continue
+		}
+
+		exp := map[string]string{
+			"cert-manager.io/issuer-name":  "ca",
+			"cert-manager.io/issuer-kind":  "Issuer",
+			"cert-manager.io/issuer-group": "cert-manager.io",
+		}
+		if original.Annotations["certmanager.k8s.io/issuer-kind"] == "ClusterIssuer" {
+			exp["cert-manager.io/issuer-kind"] = "ClusterIssuer"
+		}
+		if !apiequality.Semantic.DeepEqual(exp, secret.Annotations) {
+			t.Errorf("%s: expected annotations %v, got %v", secret.Name, exp, secret.Annotations)
+		}
+	}
+}
+
+func TestFailedUpdatesAreRetried(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Now())
+	secret := secretWithAnnotations("empty-group", map[string]string{
+		"cert-manager.io/issuer-name":  "ca",
+		"cert-manager.io/issuer-kind":  "Issuer",
+		"cert-manager.io/issuer-group": "",
+	})
+	w, builder := startController(t, clock, []*corev1.Secret{secret}, 1)
+	defer builder.Stop()
+
+	builder.FakeKubeClient().PrependReactor("update", "secrets", func(coretesting.Action) (bool, runtime.Object, error) {
+		return true, nil, fmt.Errorf("simulated error")
+	})
+	if err := w.controller.ProcessItem(context.Background(), "default/empty-group"); err == nil {
+		t.Fatal("expected the failed update to be returned so that it is retried")
+	}
+	if pending := pendingSecrets(w); pending != 1 {
+		t.Errorf("expected the Secret to still be pending, got %d pending Secrets", pending)
+	}
+}
@@ -39,6 +39,7 @@ import (
 	gwfake "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/fake"
 	gwinformers "sigs.k8s.io/gateway-api/pkg/client/informers/externalversions"
 
+	"github.com/cert-manager/cert-manager/internal/controller/issuerhealth"
 	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
@@ -64,6 +65,8 @@ type StringGenerator func(n int) string
 // Currently, only KubeObjects, CertManagerObjects and GWObjects can be
 // specified. These will be auto loaded into the constructed fake Clientsets.
 // Call ToContext() to construct a new context using the given values.
+// If Context is set before calling Init() and has a Namespace, the informer
+// factories will be scoped to that namespace, as with the --namespace flag.
 type Builder struct {
 	T *testing.T
 
@@ -154,10 +157,11 @@ func (b *Builder) Init() {
 	b.FakeCMClient().PrependReactor("create", "*", b.generateNameReactor)
 	b.FakeGWClient().PrependReactor("create", "*", b.generateNameReactor)
 	b.FakeMetadataClient().PrependReactor("create", "*", b.generateNameReactor)
-	b.KubeSharedInformerFactory = internalinformers.NewBaseKubeInformerFactory(b.Client, informerResyncPeriod, "")
-	b.SharedInformerFactory = informers.NewSharedInformerFactory(b.CMClient, informerResyncPeriod)
-	b.GWShared = gwinformers.NewSharedInformerFactory(b.GWClient, informerResyncPeriod)
-	b.HTTP01ResourceMetadataInformersFactory = metadatainformer.NewFilteredSharedInformerFactory(b.MetadataClient, informerResyncPeriod, "", func(listOptions *metav1.ListOptions) {})
+	b.KubeSharedInformerFactory = internalinformers.NewBaseKubeInformerFactory(b.Client, informerResyncPeriod, b.Context.Namespace, nil)
+	b.SharedInformerFactory = informers.NewSharedInformerFactoryWithOptions(b.CMClient, informerResyncPeriod, informers.WithNamespace(b.Context.Namespace))
+	b.GWShared = gwinformers.NewSharedInformerFactoryWithOptions(b.GWClient, informerResyncPeriod, gwinformers.WithNamespace(b.Context.Namespace))
+	b.HTTP01ResourceMetadataInformersFactory = metadatainformer.NewFilteredSharedInformerFactory(b.MetadataClient, informerResyncPeriod, b.Context.Namespace, func(listOptions *metav1.ListOptions) {})
+	b.MetadataInformersFactory = metadatainformer.NewSharedInformerFactory(b.MetadataClient, informerResyncPeriod)
 	b.stopCh = make(chan struct{})
 	b.Metrics = metrics.New(logs.Log, clock.RealClock{})
 
@@ -167,6 +171,8 @@ func (b *Builder) Init() {
 	} else {
 		b.Context.Clock = b.Clock
 	}
+	b.IssuerHealth = issuerhealth.NewTracker(b.Context.Clock)
+
 	// Fix the clock used in apiutil so that calls to set status conditions
 	// can be predictably tested
 	apiutil.Clock = b.Context.Clock
@@ -290,6 +296,24 @@ func (b *Builder) AllActionsExecuted() error {
 	return utilerrors.NewAggregate(errs)
 }
 
+// ClusterScopedActions returns all actions, including "list" and "watch",
+// that were made against the fake clients without a namespace. It can be used
+// to assert that a controller makes no cluster-scoped calls when running with
+// a Namespace set on the Context.
+func (b *Builder) ClusterScopedActions() []coretesting.Action {
+	firedActions := b.FakeCMClient().Actions()
+	firedActions = append(firedActions, b.FakeKubeClient().Actions()...)
+	firedActions = append(firedActions, b.FakeGWClient().Actions()...)
+
+	var clusterScoped []coretesting.Action
+	for _, a := range firedActions {
+		if a.GetNamespace() == "" {
+			clusterScoped = append(clusterScoped, a)
+		}
+	}
+	return clusterScoped
+}
+
 func actionToString(a coretesting.Action) string {
 	return fmt.Sprintf("%s %s %q in namespace %s", a.GetVerb(), a.GetSubresource(), a.GetResource(), a.GetNamespace())
 }
@@ -312,6 +336,7 @@ func (b *Builder) Start() {
 	b.SharedInformerFactory.Start(b.stopCh)
 	b.GWShared.Start(b.stopCh)
 	b.HTTP01ResourceMetadataInformersFactory.Start(b.stopCh)
+	b.MetadataInformersFactory.Start(b.stopCh)
 
 	// wait for caches to sync
 	b.Sync()
@@ -338,6 +363,9 @@ func (b *Builder) Sync() {
 	if err := mustAllSync(b.HTTP01ResourceMetadataInformersFactory.WaitForCacheSync(b.stopCh)); err != nil {
 		panic("Error waiting for MetadataInformerFactory to sync:" + err.Error())
 	}
+	if err := mustAllSync(b.MetadataInformersFactory.WaitForCacheSync(b.stopCh)); err != nil {
+		panic("Error waiting for MetadataInformersFactory to sync:" + err.Error())
+	}
 
 	// Wait for the informerResyncPeriod to make sure any update made by any of the fake clients
 	// is reflected in the informer caches.
@@ -0,0 +1,356 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package inventory exports an inventory of the Certificates in a cluster,
+// one row per Certificate, joining in the certificate stored in its Secret,
+// its latest CertificateRequest and the result of the readiness policy checks.
+// It backs the `cmctl export inventory` command.
+package inventory
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"fmt"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/clock"
+
+	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
+	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// DefaultPageSize is the number of Certificates listed per request if
+// Options.PageSize is not set.
+const DefaultPageSize = 500
+
+const (
+	// PolicyStatusHealthy means that the Certificate passes every readiness
+	// policy check.
+	PolicyStatusHealthy = "Healthy"
+	// PolicyStatusViolation means that the Certificate violates a readiness
+	// policy, which is described by the row's policy reason and message.
+	PolicyStatusViolation = "Violation"
+	// PolicyStatusUnknown means that the readiness policies could not be
+	// evaluated, for example because the Secret could not be read. The row's
+	// error describes why.
+	PolicyStatusUnknown = "Unknown"
+)
+
+// Row is the inventory entry of a single Certificate.
+type Row struct {
+	Namespace   string `json:"namespace"`
+	Name        string `json:"name"`
+	SecretName  string `json:"secretName"`
+	IssuerName  string `json:"issuerName"`
+	IssuerKind  string `json:"issuerKind"`
+	IssuerGroup string `json:"issuerGroup"`
+
+	// The details of the certificate stored in the Secret. They are empty if
+	// the Secret does not hold a valid certificate.
+	SerialNumber string     `json:"serialNumber,omitempty"`
+	SubjectDN    string     `json:"subjectDN,omitempty"`
+	IssuerDN     string     `json:"issuerDN,omitempty"`
+	KeyAlgorithm string     `json:"keyAlgorithm,omitempty"`
+	NotBefore    *time.Time `json:"notBefore,omitempty"`
+	NotAfter     *time.Time `json:"notAfter,omitempty"`
+
+	// Ready is the status of the Certificate's Ready condition, as reported
+	// by the controller.
+	Ready string `json:"ready"`
+
+	// PolicyStatus is the result of evaluating the readiness policy checks
+	// client-side, one of PolicyStatusHealthy, PolicyStatusViolation or
+	// PolicyStatusUnknown.
+	PolicyStatus  string `json:"policyStatus"`
+	PolicyReason  string `json:"policyReason,omitempty"`
+	PolicyMessage string `json:"policyMessage,omitempty"`
+
+	// The CertificateRequest with the highest revision owned by the
+	// Certificate, and the reason of its Ready condition.
+	LatestRequestName     string `json:"latestRequestName,omitempty"`
+	LatestRequestRevision int    `json:"latestRequestRevision,omitempty"`
+	LatestRequestState    string `json:"latestRequestState,omitempty"`
+
+	// Error describes why the row is incomplete, e.g. if the Secret could
+	// not be read.
+	Error string `json:"error,omitempty"`
+}
+
+// RowWriter writes the rows of an inventory.
+type RowWriter interface {
+	// Write writes a single row.
+	Write(Row) error
+	// Close completes the output once every row has been written.
+	Close() error
+}
+
+// Options configure an export.
+type Options struct {
+	// Namespace restricts the export to a single namespace. Every namespace
+	// is exported if it is empty.
+	Namespace string
+
+	// PageSize is the number of Certificates and CertificateRequests listed
+	// per request, which bounds the memory used by the export.
+	// DefaultPageSize is used if it is zero.
+	PageSize int64
+
+	// Clock is used to evaluate the time based readiness policies. The real
+	// clock is used if it is nil.
+	Clock clock.Clock
+}
+
+// Export walks the Certificates page by page, and writes one row per
+// Certificate to w. Objects which cannot be read for a single Certificate,
+// e.g. a Secret which the caller is not allowed to get, are reported on its
+// row rather than failing the export. An error is only returned if the
+// Certificates cannot be listed, or a row cannot be written.
+// The readiness policies are evaluated with the built-in private key
+// defaults, as those configured on the controller are not known.
+func Export(ctx context.Context, kubeClient kubernetes.Interface, cmClient cmclient.Interface, opts Options, w RowWriter) error {
+	if opts.PageSize == 0 {
+		opts.PageSize = DefaultPageSize
+	}
+	if opts.Clock == nil {
+		opts.Clock = clock.RealClock{}
+	}
+	e := &exporter{
+		kubeClient:  kubeClient,
+		cmClient:    cmClient,
+		opts:        opts,
+		policyChain: policies.NewReadinessPolicyChain(opts.Clock, internalcertificates.PrivateKeyDefaults{}),
+	}
+
+	listOpts := metav1.ListOptions{Limit: opts.PageSize}
+	for {
+		list, err := cmClient.CertmanagerV1().Certificates(opts.Namespace).List(ctx, listOpts)
+		if err != nil {
+			return fmt.Errorf("failed to list Certificates: %w", err)
+		}
+		for _, row := range e.rowsForPage(ctx, list.Items) {
+			if err := w.Write(row); err != nil {
+				return fmt.Errorf("failed to write row for Certificate %s/%s: %w", row.Namespace, row.Name, err)
+			}
+		}
+		if list.Continue == "" {
+			break
+		}
+		listOpts.Continue = list.Continue
+	}
+
+	return w.Close()
+}
+
+type exporter struct {
+	kubeClient  kubernetes.Interface
+	cmClient    cmclient.Interface
+	opts        Options
+	policyChain policies.Chain
+}
+
+// certificateRequests are the CertificateRequests owned by a Certificate
+// which are relevant to its row.
+type certificateRequests struct {
+	current, next, latest *cmapi.CertificateRequest
+	latestRevision        int
+}
+
+// rowsForPage returns the rows of a page of Certificates. The
+// CertificateRequests are listed once per namespace of the page, and only
+// those owned by the page's Certificates are kept.
+func (e *exporter) rowsForPage(ctx context.Context, certs []cmapi.Certificate) []Row {
+	byNamespace := map[string]map[types.UID]*cmapi.Certificate{}
+	for i := range certs {
+		crt := &certs[i]
+		if byNamespace[crt.Namespace] == nil {
+			byNamespace[crt.Namespace] = map[types.UID]*cmapi.Certificate{}
+		}
+		byNamespace[crt.Namespace][crt.UID] = crt
+	}
+
+	reqs := map[types.UID]*certificateRequests{}
+	listErrs := map[string]error{}
+	for namespace, owners := range byNamespace {
+		if err := e.listRequests(ctx, namespace, owners, reqs); err != nil {
+			listErrs[namespace] = err
+		}
+	}
+
+	rows := make([]Row, 0, len(certs))
+	for i := range certs {
+		crt := &certs[i]
+		rows = append(rows, e.row(ctx, crt, reqs[crt.UID], listErrs[crt.Namespace]))
+	}
+	return rows
+}
+
+// listRequests records the CertificateRequests in the namespace which are
+// owned by one of the given Certificates.
+func (e *exporter) listRequests(ctx context.Context, namespace string, owners map[types.UID]*cmapi.Certificate, out map[types.UID]*certificateRequests) error {
+	listOpts := metav1.ListOptions{Limit: e.opts.PageSize}
+	for {
+		list, err := e.cmClient.CertmanagerV1().CertificateRequests(namespace).List(ctx, listOpts)
+		if err != nil {
+			return err
+		}
+		for i := range list.Items {
+			req := &list.Items[i]
+			ref := metav1.GetControllerOf(req)
+			if ref == nil {
+				continue
+			}
+			crt, ok := owners[ref.UID]
+			if !ok {
+				continue
+			}
+			revision, err := strconv.Atoi(req.Annotations[cmapi.CertificateRequestRevisionAnnotationKey])
+			if err != nil {
+				continue
+			}
+
+			// Only the relevant CertificateRequests are copied, so that the
+			// page they were listed in can be released.
+			found := out[crt.UID]
+			if found == nil {
+				found = &certificateRequests{}
+				out[crt.UID] = found
+			}
+			currentRevision := 0
+			if crt.Status.Revision != nil {
+				currentRevision = *crt.Status.Revision
+			}
+			switch revision {
+			case currentRevision:
+				found.current = req.DeepCopy()
+			case currentRevision + 1:
+				found.next = req.DeepCopy()
+			}
+			if revision > found.latestRevision {
+				found.latest = req.DeepCopy()
+				found.latestRevision = revision
+			}
+		}
+		if list.Continue == "" {
+			return nil
+		}
+		listOpts.Continue = list.Continue
+	}
+}
+
+// row returns the row of a single Certificate. reqsErr is the error with
+// which its CertificateRequests could not be listed, if any.
+func (e *exporter) row(ctx context.Context, crt *cmapi.Certificate, reqs *certificateRequests, reqsErr error) Row {
+	row := Row{
+		Namespace:    crt.Namespace,
+		Name:         crt.Name,
+		SecretName:   crt.Spec.SecretName,
+		IssuerName:   crt.Spec.IssuerRef.Name,
+		Ready:        string(cmmeta.ConditionUnknown),
+		PolicyStatus: PolicyStatusUnknown,
+	}
+	row.IssuerKind, row.IssuerGroup = apiutil.NormalizeIssuerKindAndGroup(crt.Spec.IssuerRef.Kind, crt.Spec.IssuerRef.Group)
+	if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionReady); cond != nil {
+		row.Ready = string(cond.Status)
+	}
+	if reqs == nil {
+		reqs = &certificateRequests{}
+	}
+	if reqs.latest != nil {
+		row.LatestRequestName = reqs.latest.Name
+		row.LatestRequestRevision = reqs.latestRevision
+		if cond := apiutil.GetCertificateRequestCondition(reqs.latest, cmapi.CertificateRequestConditionReady); cond != nil {
+			row.LatestRequestState = cond.Reason
+		}
+	}
+
+	secret, err := e.kubeClient.CoreV1().Secrets(crt.Namespace).Get(ctx, crt.Spec.SecretName, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		secret = nil
+	case err != nil:
+		row.Error = fmt.Sprintf("unable to read Secret %q: %v", crt.Spec.SecretName, err)
+		return row
+	default:
+		setCertificateDetails(&row, secret)
+	}
+
+	if reqsErr != nil {
+		row.Error = fmt.Sprintf("unable to list CertificateRequests: %v", reqsErr)
+		return row
+	}
+
+	reason, message, violated := e.policyChain.Evaluate(policies.Input{
+		Certificate:            crt,
+		Secret:                 secret,
+		CurrentRevisionRequest: reqs.current,
+		NextRevisionRequest:    reqs.next,
+		EvaluationTime:         e.opts.Clock.Now(),
+	})
+	if violated {
+		row.PolicyStatus = PolicyStatusViolation
+		row.PolicyReason = reason
+		row.PolicyMessage = message
+	} else {
+		row.PolicyStatus = PolicyStatusHealthy
+	}
+	return row
+}
+
+// setCertificateDetails sets the details of the certificate stored in the
+// Secret on the row. They are left empty if the certificate cannot be
+// decoded, which the readiness policies report.
+func setCertificateDetails(row *Row, secret *corev1.Secret) {
+	cert, err := pki.DecodeX509CertificateBytes(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return
+	}
+	notBefore, notAfter := cert.NotBefore.UTC(), cert.NotAfter.UTC()
+	row.SerialNumber = pki.FormatSerialNumber(cert.SerialNumber)
+	row.SubjectDN = cert.Subject.String()
+	row.IssuerDN = cert.Issuer.String()
+	row.KeyAlgorithm = keyAlgorithm(cert.PublicKey)
+	row.NotBefore = &notBefore
+	row.NotAfter = &notAfter
+}
+
+// keyAlgorithm describes the algorithm and size of the given public key, e.g.
+// "RSA-2048" or "ECDSA-P-256".
+func keyAlgorithm(pub crypto.PublicKey) string {
+	switch pub := pub.(type) {
+	case *rsa.PublicKey:
+		return fmt.Sprintf("RSA-%d", pub.N.BitLen())
+	case *ecdsa.PublicKey:
+		return "ECDSA-" + pub.Curve.Params().Name
+	case ed25519.PublicKey:
+		return "Ed25519"
+	default:
+		return "Unknown"
+	}
+}
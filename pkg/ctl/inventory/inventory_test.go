@@ -0,0 +1,211 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inventory
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+// inventoryFixture returns a fake clientset with a healthy Certificate, one
+// whose certificate has expired, one whose Secret does not exist and one
+// whose Secret the caller is not allowed to read.
+func inventoryFixture(t *testing.T, clock *fakeclock.FakeClock) (*kubefake.Clientset, *cmfake.Clientset) {
+	issuerRef := cmmeta.ObjectReference{Name: "ca-issuer", Kind: "Issuer"}
+
+	var kubeObjs, cmObjs []runtime.Object
+	addCertificate := func(namespace, name string, notBefore, notAfter time.Time, withSecret bool) {
+		crt := gen.Certificate(name,
+			gen.SetCertificateNamespace(namespace),
+			gen.SetCertificateUID(types.UID(namespace+"-"+name)),
+			gen.SetCertificateCommonName(name+".example.com"),
+			gen.SetCertificateSecretName(name+"-tls"),
+			gen.SetCertificateIssuer(issuerRef),
+			gen.SetCertificateRevision(1),
+			gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+				Type:   cmapi.CertificateConditionReady,
+				Status: cmmeta.ConditionTrue,
+			}),
+		)
+		cmObjs = append(cmObjs, crt)
+
+		pk := testcrypto.MustCreatePEMPrivateKey(t)
+		cmObjs = append(cmObjs, gen.CertificateRequest(name+"-1",
+			gen.SetCertificateRequestNamespace(namespace),
+			gen.SetCertificateRequestIssuer(issuerRef),
+			gen.SetCertificateRequestRevision("1"),
+			gen.AddCertificateRequestOwnerReferences(*metav1.NewControllerRef(crt, cmapi.SchemeGroupVersion.WithKind("Certificate"))),
+			gen.SetCertificateRequestCSR(testcrypto.MustGenerateCSRImpl(t, pk, crt)),
+			gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+				Type:   cmapi.CertificateRequestConditionReady,
+				Status: cmmeta.ConditionTrue,
+				Reason: cmapi.CertificateRequestReasonIssued,
+			}),
+		))
+
+		if !withSecret {
+			return
+		}
+		kubeObjs = append(kubeObjs, gen.Secret(name+"-tls",
+			gen.SetSecretNamespace(namespace),
+			gen.SetSecretAnnotations(map[string]string{
+				cmapi.IssuerNameAnnotationKey: issuerRef.Name,
+				cmapi.IssuerKindAnnotationKey: issuerRef.Kind,
+			}),
+			gen.SetSecretData(map[string][]byte{
+				corev1.TLSPrivateKeyKey: pk,
+				corev1.TLSCertKey:       testcrypto.MustCreateCertWithNotBeforeAfter(t, pk, crt, notBefore, notAfter),
+			}),
+		))
+	}
+
+	now := clock.Now()
+	addCertificate("team-a", "healthy", now.Add(-time.Hour), now.Add(time.Hour*24*30), true)
+	addCertificate("team-a", "expired", now.Add(-time.Hour*48), now.Add(-time.Hour), true)
+	addCertificate("team-b", "missing", now, now, false)
+	addCertificate("team-b", "forbidden", now.Add(-time.Hour), now.Add(time.Hour*24*30), true)
+
+	kubeClient := kubefake.NewSimpleClientset(kubeObjs...)
+	kubeClient.PrependReactor("get", "secrets", func(action coretesting.Action) (bool, runtime.Object, error) {
+		name := action.(coretesting.GetAction).GetName()
+		if name != "forbidden-tls" {
+			return false, nil, nil
+		}
+		return true, nil, apierrors.NewForbidden(corev1.Resource("secrets"), name, nil)
+	})
+	return kubeClient, cmfake.NewSimpleClientset(cmObjs...)
+}
+
+func TestExport(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Now().Truncate(time.Second))
+	kubeClient, cmClient := inventoryFixture(t, clock)
+
+	var out bytes.Buffer
+	w, err := NewWriter(FormatJSON, &out)
+	require.NoError(t, err)
+	require.NoError(t, Export(context.Background(), kubeClient, cmClient, Options{Clock: clock, PageSize: 2}, w))
+
+	var rows []Row
+	require.NoError(t, json.Unmarshal(out.Bytes(), &rows), "output must be a valid JSON array: %s", out.String())
+	require.Len(t, rows, 4)
+	byName := map[string]Row{}
+	for _, row := range rows {
+		byName[row.Name] = row
+	}
+
+	healthy := byName["healthy"]
+	assert.Equal(t, "team-a", healthy.Namespace)
+	assert.Equal(t, "healthy-tls", healthy.SecretName)
+	assert.Equal(t, "ca-issuer", healthy.IssuerName)
+	assert.Equal(t, "Issuer", healthy.IssuerKind)
+	assert.Equal(t, "cert-manager.io", healthy.IssuerGroup)
+	assert.Equal(t, "RSA-2048", healthy.KeyAlgorithm)
+	assert.Equal(t, "CN=healthy.example.com", healthy.SubjectDN)
+	assert.NotEmpty(t, healthy.SerialNumber)
+	require.NotNil(t, healthy.NotAfter)
+	assert.True(t, healthy.NotAfter.Equal(clock.Now().Add(time.Hour*24*30)))
+	assert.Equal(t, "True", healthy.Ready)
+	assert.Equal(t, PolicyStatusHealthy, healthy.PolicyStatus)
+	assert.Empty(t, healthy.PolicyReason)
+	assert.Equal(t, "healthy-1", healthy.LatestRequestName)
+	assert.Equal(t, 1, healthy.LatestRequestRevision)
+	assert.Equal(t, cmapi.CertificateRequestReasonIssued, healthy.LatestRequestState)
+	assert.Empty(t, healthy.Error)
+
+	expired := byName["expired"]
+	assert.Equal(t, PolicyStatusViolation, expired.PolicyStatus)
+	assert.Equal(t, policies.Expired, expired.PolicyReason)
+	assert.Empty(t, expired.Error)
+
+	missing := byName["missing"]
+	assert.Equal(t, PolicyStatusViolation, missing.PolicyStatus)
+	assert.Equal(t, policies.SecretMissing, missing.PolicyReason)
+	assert.Empty(t, missing.SerialNumber)
+	assert.Empty(t, missing.Error)
+
+	// A Secret which cannot be read is reported on its row, and does not
+	// prevent the other Certificates from being exported.
+	forbidden := byName["forbidden"]
+	assert.Equal(t, PolicyStatusUnknown, forbidden.PolicyStatus)
+	assert.Contains(t, forbidden.Error, "forbidden")
+	assert.Empty(t, forbidden.SerialNumber)
+	assert.Equal(t, "forbidden-1", forbidden.LatestRequestName)
+}
+
+func TestExportNamespace(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Now().Truncate(time.Second))
+	kubeClient, cmClient := inventoryFixture(t, clock)
+
+	var out bytes.Buffer
+	w, err := NewWriter(FormatCSV, &out)
+	require.NoError(t, err)
+	require.NoError(t, Export(context.Background(), kubeClient, cmClient, Options{Namespace: "team-b", Clock: clock}, w))
+
+	records, err := csv.NewReader(&out).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3, "expected a header and a row per Certificate in the namespace")
+	assert.Equal(t, csvHeader, records[0])
+
+	names := []string{records[1][1], records[2][1]}
+	sort.Strings(names)
+	assert.Equal(t, []string{"forbidden", "missing"}, names)
+	for _, record := range records[1:] {
+		assert.Equal(t, "team-b", record[0])
+		assert.Len(t, record, len(csvHeader))
+	}
+}
+
+func TestNewWriter(t *testing.T) {
+	for _, format := range []string{FormatCSV, FormatJSON} {
+		var out bytes.Buffer
+		w, err := NewWriter(format, &out)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		assert.NotEmpty(t, out.String(), "an empty inventory must still produce valid %s output", format)
+	}
+	var out bytes.Buffer
+	w, err := NewWriter(FormatJSON, &out)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	assert.Equal(t, "[]\n", out.String())
+
+	_, err = NewWriter("yaml", &out)
+	assert.Error(t, err)
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inventory
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+const (
+	// FormatCSV writes the inventory as CSV, with a header row.
+	FormatCSV = "csv"
+	// FormatJSON writes the inventory as a JSON array of rows.
+	FormatJSON = "json"
+)
+
+// NewWriter returns a RowWriter which writes rows to out in the given format.
+// Rows are written as they are received, so that the whole inventory does not
+// need to be held in memory.
+func NewWriter(format string, out io.Writer) (RowWriter, error) {
+	switch format {
+	case FormatCSV:
+		return &csvWriter{w: csv.NewWriter(out)}, nil
+	case FormatJSON:
+		return &jsonWriter{out: out, enc: json.NewEncoder(out)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported output format %q, must be one of %q or %q", format, FormatCSV, FormatJSON)
+	}
+}
+
+var csvHeader = []string{
+	"namespace", "name", "secretName", "issuerName", "issuerKind", "issuerGroup",
+	"serialNumber", "subjectDN", "issuerDN", "keyAlgorithm", "notBefore", "notAfter",
+	"ready", "policyStatus", "policyReason", "policyMessage",
+	"latestRequestName", "latestRequestRevision", "latestRequestState", "error",
+}
+
+type csvWriter struct {
+	w             *csv.Writer
+	headerWritten bool
+}
+
+func (c *csvWriter) Write(row Row) error {
+	if !c.headerWritten {
+		if err := c.w.Write(csvHeader); err != nil {
+			return err
+		}
+		c.headerWritten = true
+	}
+	revision := ""
+	if row.LatestRequestRevision > 0 {
+		revision = strconv.Itoa(row.LatestRequestRevision)
+	}
+	return c.w.Write([]string{
+		row.Namespace, row.Name, row.SecretName, row.IssuerName, row.IssuerKind, row.IssuerGroup,
+		row.SerialNumber, row.SubjectDN, row.IssuerDN, row.KeyAlgorithm, formatTime(row.NotBefore), formatTime(row.NotAfter),
+		row.Ready, row.PolicyStatus, row.PolicyReason, row.PolicyMessage,
+		row.LatestRequestName, revision, row.LatestRequestState, row.Error,
+	})
+}
+
+func (c *csvWriter) Close() error {
+	if !c.headerWritten {
+		if err := c.w.Write(csvHeader); err != nil {
+			return err
+		}
+		c.headerWritten = true
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+type jsonWriter struct {
+	out     io.Writer
+	enc     *json.Encoder
+	started bool
+}
+
+func (j *jsonWriter) Write(row Row) error {
+	sep := ","
+	if !j.started {
+		sep = "["
+		j.started = true
+	}
+	if _, err := io.WriteString(j.out, sep); err != nil {
+		return err
+	}
+	// Encode terminates each row with a newline.
+	return j.enc.Encode(row)
+}
+
+func (j *jsonWriter) Close() error {
+	end := "]\n"
+	if !j.started {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(j.out, end)
+	return err
+}
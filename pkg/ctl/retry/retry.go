@@ -0,0 +1,155 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package retry asks the cert-manager controller to retry the pending Orders
+// and Challenges of an ACME issuer immediately, without waiting for their
+// backoff to expire, for example once an outage of the ACME server is over.
+// It backs the `cmctl renew --issuer <name> --pending-only` command.
+package retry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
+
+	"github.com/cert-manager/cert-manager/pkg/acme"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+)
+
+// Options selects the issuer whose pending Orders and Challenges are retried.
+type Options struct {
+	// IssuerName is the name of the Issuer or ClusterIssuer.
+	IssuerName string
+	// IssuerKind is either Issuer or ClusterIssuer. Defaults to Issuer.
+	IssuerKind string
+	// Namespace is the namespace of the Issuer. It is ignored for
+	// ClusterIssuers.
+	Namespace string
+
+	// Clock is used to timestamp the request. Defaults to the real clock.
+	Clock clock.PassiveClock
+}
+
+// Result reports the Orders and Challenges which are retried.
+type Result struct {
+	PendingOrders     int
+	PendingChallenges int
+}
+
+// RetryPending sets the retry-pending annotation on the selected ACME issuer
+// to the current time. The controller then retries each of the issuer's
+// pending Orders and Challenges straight away, and resets their backoff.
+func RetryPending(ctx context.Context, cmClient cmclient.Interface, opts Options) (*Result, error) {
+	if opts.IssuerName == "" {
+		return nil, fmt.Errorf("an issuer name must be given")
+	}
+	if opts.Clock == nil {
+		opts.Clock = clock.RealClock{}
+	}
+
+	var (
+		issuer cmapi.GenericIssuer
+		err    error
+	)
+	switch opts.IssuerKind {
+	case "", cmapi.IssuerKind:
+		opts.IssuerKind = cmapi.IssuerKind
+		issuer, err = cmClient.CertmanagerV1().Issuers(opts.Namespace).Get(ctx, opts.IssuerName, metav1.GetOptions{})
+	case cmapi.ClusterIssuerKind:
+		opts.Namespace = ""
+		issuer, err = cmClient.CertmanagerV1().ClusterIssuers().Get(ctx, opts.IssuerName, metav1.GetOptions{})
+	default:
+		return nil, fmt.Errorf("unsupported issuer kind %q, must be one of %q or %q", opts.IssuerKind, cmapi.IssuerKind, cmapi.ClusterIssuerKind)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s %q: %w", opts.IssuerKind, opts.IssuerName, err)
+	}
+	if issuer.GetSpec().ACME == nil {
+		return nil, fmt.Errorf("%s %q is not an ACME issuer", opts.IssuerKind, opts.IssuerName)
+	}
+
+	result, err := countPending(ctx, cmClient, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				cmacme.RetryPendingAnnotationKey: opts.Clock.Now().UTC().Format(time.RFC3339Nano),
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if opts.IssuerKind == cmapi.ClusterIssuerKind {
+		_, err = cmClient.CertmanagerV1().ClusterIssuers().Patch(ctx, opts.IssuerName, types.MergePatchType, patch, metav1.PatchOptions{})
+	} else {
+		_, err = cmClient.CertmanagerV1().Issuers(opts.Namespace).Patch(ctx, opts.IssuerName, types.MergePatchType, patch, metav1.PatchOptions{})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to annotate %s %q: %w", opts.IssuerKind, opts.IssuerName, err)
+	}
+
+	return result, nil
+}
+
+// countPending counts the Orders and Challenges of the selected issuer which
+// are still pending. Orders and Challenges of a ClusterIssuer are counted
+// across all namespaces.
+func countPending(ctx context.Context, cmClient cmclient.Interface, opts Options) (*Result, error) {
+	matchesIssuer := func(ref cmmeta.ObjectReference) bool {
+		kind := ref.Kind
+		if kind == "" {
+			kind = cmapi.IssuerKind
+		}
+		return ref.Name == opts.IssuerName && kind == opts.IssuerKind
+	}
+
+	result := &Result{}
+	orders, err := cmClient.AcmeV1().Orders(opts.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Orders: %w", err)
+	}
+	for _, o := range orders.Items {
+		// Valid Orders are pending until their certificate is fetched.
+		done := acme.IsFailureState(o.Status.State) || (o.Status.State == cmacme.Valid && len(o.Status.Certificate) > 0)
+		if matchesIssuer(o.Spec.IssuerRef) && !done {
+			result.PendingOrders++
+		}
+	}
+
+	challenges, err := cmClient.AcmeV1().Challenges(opts.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Challenges: %w", err)
+	}
+	for _, ch := range challenges.Items {
+		if matchesIssuer(ch.Spec.IssuerRef) && !acme.IsFinalState(ch.Status.State) {
+			result.PendingChallenges++
+		}
+	}
+
+	return result, nil
+}
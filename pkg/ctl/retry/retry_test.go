@@ -0,0 +1,78 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package retry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestRetryPending(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	issuerRef := cmmeta.ObjectReference{Name: "acme", Kind: cmapi.IssuerKind}
+	clusterIssuerRef := cmmeta.ObjectReference{Name: "acme", Kind: cmapi.ClusterIssuerKind}
+
+	cmClient := cmfake.NewSimpleClientset(
+		gen.Issuer("acme", gen.SetIssuerNamespace("ns"), gen.SetIssuerACME(cmacme.ACMEIssuer{})),
+		gen.Issuer("ca", gen.SetIssuerNamespace("ns"), gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca"})),
+		gen.ClusterIssuer("acme", gen.SetIssuerACME(cmacme.ACMEIssuer{})),
+		gen.Order("pending", gen.SetOrderNamespace("ns"), gen.SetOrderIssuer(issuerRef), gen.SetOrderState(cmacme.Pending)),
+		gen.Order("valid-without-certificate", gen.SetOrderNamespace("ns"), gen.SetOrderIssuer(issuerRef), gen.SetOrderState(cmacme.Valid)),
+		gen.Order("valid", gen.SetOrderNamespace("ns"), gen.SetOrderIssuer(issuerRef), gen.SetOrderState(cmacme.Valid), gen.SetOrderCertificate([]byte("cert"))),
+		gen.Order("errored", gen.SetOrderNamespace("ns"), gen.SetOrderIssuer(issuerRef), gen.SetOrderState(cmacme.Errored)),
+		gen.Order("cluster", gen.SetOrderNamespace("other"), gen.SetOrderIssuer(clusterIssuerRef), gen.SetOrderState(cmacme.Pending)),
+		gen.Challenge("pending", gen.SetChallengeNamespace("ns"), gen.SetChallengeIssuer(issuerRef), gen.SetChallengeState(cmacme.Pending)),
+		gen.Challenge("valid", gen.SetChallengeNamespace("ns"), gen.SetChallengeIssuer(issuerRef), gen.SetChallengeState(cmacme.Valid)),
+		gen.Challenge("cluster", gen.SetChallengeNamespace("other"), gen.SetChallengeIssuer(clusterIssuerRef)),
+	)
+
+	result, err := RetryPending(context.Background(), cmClient, Options{IssuerName: "acme", Namespace: "ns", Clock: clock})
+	require.NoError(t, err)
+	assert.Equal(t, &Result{PendingOrders: 2, PendingChallenges: 1}, result)
+
+	issuer, err := cmClient.CertmanagerV1().Issuers("ns").Get(context.Background(), "acme", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "2024-01-01T12:00:00Z", issuer.Annotations[cmacme.RetryPendingAnnotationKey])
+
+	// Each request sets a new value, so that it is acted on by the controller.
+	clock.Step(time.Second)
+	result, err = RetryPending(context.Background(), cmClient, Options{IssuerName: "acme", IssuerKind: cmapi.ClusterIssuerKind, Clock: clock})
+	require.NoError(t, err)
+	assert.Equal(t, &Result{PendingOrders: 1, PendingChallenges: 1}, result)
+
+	clusterIssuer, err := cmClient.CertmanagerV1().ClusterIssuers().Get(context.Background(), "acme", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "2024-01-01T12:00:01Z", clusterIssuer.Annotations[cmacme.RetryPendingAnnotationKey])
+
+	_, err = RetryPending(context.Background(), cmClient, Options{IssuerName: "ca", Namespace: "ns", Clock: clock})
+	assert.ErrorContains(t, err, "is not an ACME issuer")
+
+	_, err = RetryPending(context.Background(), cmClient, Options{IssuerName: "missing", Namespace: "ns", Clock: clock})
+	assert.Error(t, err)
+}
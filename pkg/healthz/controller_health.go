@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package healthz
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/go-logr/logr"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apiserver/pkg/server/healthz"
+)
+
+// ControllerHealth is implemented by control loops that report their own
+// health.
+type ControllerHealth interface {
+	// Ready returns an error if the controller is not currently able to
+	// process work, for example because its informer caches have not synced
+	// or its workqueue has stopped making progress.
+	Ready() error
+	// Live returns an error if the controller can never recover without a
+	// restart, for example because its workers keep panicking.
+	Live() error
+}
+
+// The controllerHealthAdaptor aggregates the health of all registered
+// controllers. Controllers are registered once they have been started, which
+// only happens after this replica has been elected leader, so a replica that
+// is not the leader always reports healthy.
+type controllerHealthAdaptor struct {
+	log logr.Logger
+
+	lock        sync.Mutex
+	controllers map[string]ControllerHealth
+	// failing records the last reported error of each check so that
+	// transitions can be logged.
+	failing map[string]error
+}
+
+func newControllerHealthAdaptor(log logr.Logger) *controllerHealthAdaptor {
+	return &controllerHealthAdaptor{
+		log:         log,
+		controllers: make(map[string]ControllerHealth),
+		failing:     make(map[string]error),
+	}
+}
+
+// Register adds a controller to the set of controllers whose health is
+// reported by the /livez and /readyz endpoints.
+func (c *controllerHealthAdaptor) Register(name string, h ControllerHealth) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.controllers[name] = h
+}
+
+// readyzCheck returns a check which fails if any controller is not ready.
+func (c *controllerHealthAdaptor) readyzCheck() healthz.HealthChecker {
+	return healthz.NamedCheck("controllers", func(*http.Request) error {
+		return c.check("ready", ControllerHealth.Ready)
+	})
+}
+
+// livezCheck returns a check which fails if any controller is not live.
+func (c *controllerHealthAdaptor) livezCheck() healthz.HealthChecker {
+	return healthz.NamedCheck("controllers", func(*http.Request) error {
+		return c.check("live", ControllerHealth.Live)
+	})
+}
+
+func (c *controllerHealthAdaptor) check(kind string, fn func(ControllerHealth) error) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	names := make([]string, 0, len(c.controllers))
+	for name := range c.controllers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		err := fn(c.controllers[name])
+		key := kind + "/" + name
+		_, wasFailing := c.failing[key]
+		switch {
+		case err != nil && !wasFailing:
+			c.log.Error(err, "controller health check started failing", "controller", name, "check", kind)
+			c.failing[key] = err
+		case err == nil && wasFailing:
+			c.log.Info("controller health check is passing again", "controller", name, "check", kind)
+			delete(c.failing, key)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
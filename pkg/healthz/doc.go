@@ -14,10 +14,15 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-// Package healthz provides an HTTP server which responds to HTTP liveness probes
-// and performs health checks.
+// Package healthz provides an HTTP server which responds to HTTP liveness and
+// readiness probes and performs health checks.
 //
-// Currently it only checks that the LeaderElector has an up to date LeaderElectionRecord.
+// The /readyz endpoint fails if any registered controller has not synced its
+// informer caches, or has items queued but has stopped processing them.
+// The /livez endpoint fails if the workers of a registered controller keep
+// panicking, or if the system clock has drifted from the monotonic clock.
+//
+// The /livez endpoint also checks that the LeaderElector has an up to date LeaderElectionRecord.
 // Normally the parent process should exit if the LeaderElectionRecord is stale,
 // but it is possible that the process is prevented from exiting by a bug,
 // in which case this check will fail, the liveness probe will fail and then the
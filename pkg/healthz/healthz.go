@@ -27,6 +27,8 @@ import (
 	"k8s.io/apiserver/pkg/server/healthz"
 	"k8s.io/client-go/tools/leaderelection"
 	"k8s.io/utils/clock"
+
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
 )
 
 const (
@@ -38,9 +40,13 @@ const (
 
 // Server responds to HTTP requests to a /livez endpoint and responds with an
 // error if the LeaderElector has exited or has not observed the
-// LeaderElectionRecord for a given amount of time.
+// LeaderElectionRecord for a given amount of time, or if a registered
+// controller is no longer live.
+// It also responds to HTTP requests to a /readyz endpoint with an error if
+// any registered controller is not ready.
 type Server struct {
-	server *http.Server
+	server      *http.Server
+	controllers *controllerHealthAdaptor
 	// LeaderHealthzAdaptor is public so that it can be retrieved by the caller
 	// and used as the value for `LeaderElectionConfig.Watchdog` when
 	// initializing the LeaderElector.
@@ -53,9 +59,12 @@ type Server struct {
 func NewServer(leaderElectionHealthzAdaptorTimeout time.Duration) *Server {
 	leaderHealthzAdaptor := leaderelection.NewLeaderHealthzAdaptor(leaderElectionHealthzAdaptorTimeout)
 	clockHealthAdaptor := NewClockHealthAdaptor(clock.RealClock{})
+	controllerHealthAdaptor := newControllerHealthAdaptor(logf.Log.WithName("healthz"))
 	mux := http.NewServeMux()
-	healthz.InstallLivezHandler(mux, leaderHealthzAdaptor, clockHealthAdaptor)
+	healthz.InstallLivezHandler(mux, leaderHealthzAdaptor, clockHealthAdaptor, controllerHealthAdaptor.livezCheck())
+	healthz.InstallReadyzHandler(mux, controllerHealthAdaptor.readyzCheck())
 	return &Server{
+		controllers: controllerHealthAdaptor,
 		server: &http.Server{
 			ReadTimeout:    healthzServerReadTimeout,
 			WriteTimeout:   healthzServerWriteTimeout,
@@ -66,6 +75,12 @@ func NewServer(leaderElectionHealthzAdaptorTimeout time.Duration) *Server {
 	}
 }
 
+// RegisterController adds a controller whose health will be reported by the
+// /livez and /readyz endpoints.
+func (o *Server) RegisterController(name string, h ControllerHealth) {
+	o.controllers.Register(name, h)
+}
+
 // Start makes the server listen on the supplied socket, until the supplied
 // context is cancelled, after which the server will gracefully shutdown and Start will
 // exit.
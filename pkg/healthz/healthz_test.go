@@ -32,12 +32,17 @@ import (
 	"golang.org/x/sync/errgroup"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/leaderelection"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 	"k8s.io/klog/v2/ktesting"
+	"k8s.io/utils/clock"
 
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/healthz"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
 
 	_ "k8s.io/klog/v2/ktesting/init" // add command line flags
 )
@@ -303,6 +308,65 @@ func TestHealthzLivezLeaderElection(t *testing.T) {
 	}
 }
 
+// TestHealthzControllers checks that a controller whose informers never sync
+// causes the `/readyz` endpoint to fail, while `/livez` continues to pass.
+func TestHealthzControllers(t *testing.T) {
+	_, ctx := ktesting.NewTestContext(t)
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	baseURL := "http://" + l.Addr().String()
+
+	s := healthz.NewServer(0)
+
+	neverSynced := func() bool { return false }
+	queue := workqueue.NewRateLimitingQueueWithConfig(workqueue.DefaultControllerRateLimiter(), workqueue.RateLimitingQueueConfig{Name: "test"})
+	ctrl := controllerpkg.NewController("test", metrics.New(klog.FromContext(ctx), clock.RealClock{}), func(context.Context, string) error { return nil }, []cache.InformerSynced{neverSynced}, nil, queue)
+	h, ok := ctrl.(healthz.ControllerHealth)
+	require.True(t, ok, "controller does not implement ControllerHealth")
+	s.RegisterController("test", h)
+
+	g, gCTX := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		return s.Start(gCTX, l)
+	})
+	g.Go(func() error {
+		// Run returns an error once the context is cancelled as the
+		// informer never syncs.
+		_ = ctrl.Run(1, gCTX)
+		return nil
+	})
+
+	get := func(path string) (int, string) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+path, nil)
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, resp.Body.Close())
+		}()
+		bodyBytes, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, string(bodyBytes)
+	}
+
+	code, body := get("/readyz/controllers")
+	assert.Equal(t, http.StatusInternalServerError, code)
+	assert.Equal(t, "internal server error: test: informer caches have not synced\n", body)
+
+	code, _ = get("/readyz")
+	assert.Equal(t, http.StatusInternalServerError, code)
+
+	code, body = get("/livez/controllers")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "ok", body)
+
+	cancel()
+	require.NoError(t, g.Wait())
+}
+
 // fakeResourceLock implements resourcelock.Interface sufficiently to simulate:
 // * successful acquisition of the leader election lock by the local node,
 // * current possession of the leader election lock by a remote node, and
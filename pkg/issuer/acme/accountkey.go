@@ -0,0 +1,240 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/cert-manager/cert-manager/pkg/acme"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+const (
+	errorSharedAccountKeyConflict = "SharedAccountKeyConflict"
+
+	messageTemplateSharedAccountKeyConflict = "The ACME account private key in Secret %q is also used by %s with different registration parameters " +
+		"(server, email or external account binding); the ACME account will not be registered until they match or a different Secret is used"
+)
+
+// SharesAccountKey returns true if both ACME issuers, whose resources are
+// in the same namespace, store their ACME account private key in the same
+// Secret key.
+func SharesAccountKey(a, b *cmacme.ACMEIssuer) bool {
+	if a == nil || b == nil {
+		return false
+	}
+	return acme.PrivateKeySelector(a.PrivateKey) == acme.PrivateKeySelector(b.PrivateKey)
+}
+
+// sameRegistration returns true if both ACME issuers register the ACME
+// account with the same parameters, in which case they may share an account.
+func sameRegistration(a, b *cmacme.ACMEIssuer) bool {
+	if normalizeServerURL(a.Server) != normalizeServerURL(b.Server) || !strings.EqualFold(a.Email, b.Email) {
+		return false
+	}
+	aEAB, bEAB := a.ExternalAccountBinding, b.ExternalAccountBinding
+	if aEAB == nil || bEAB == nil {
+		return aEAB == bEAB
+	}
+	return aEAB.KeyID == bEAB.KeyID && aEAB.Key == bEAB.Key
+}
+
+// normalizeServerURL returns the ACME server URL with its scheme and host in
+// lower case, without the default port of the scheme and without a trailing
+// slash, so that equivalent URLs are not reported as conflicting.
+func normalizeServerURL(server string) string {
+	u, err := url.Parse(strings.TrimSpace(server))
+	if err != nil || u.Host == "" {
+		return server
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	if port := u.Port(); (u.Scheme == "https" && port == "443") || (u.Scheme == "http" && port == "80") {
+		u.Host = u.Hostname()
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	return u.String()
+}
+
+// AccountKeyPeers returns the other issuers storing their ACME account
+// private key in the same Secret key as the given issuer. Issuers in the
+// cluster resource namespace share the Secrets of ClusterIssuers. The
+// ClusterIssuer lister may be nil if ClusterIssuers are not watched.
+func AccountKeyPeers(issuerLister cmlisters.IssuerLister, clusterIssuerLister cmlisters.ClusterIssuerLister, clusterResourceNamespace string, iss v1.GenericIssuer) ([]v1.GenericIssuer, error) {
+	spec := iss.GetSpec().ACME
+	if spec == nil {
+		return nil, nil
+	}
+
+	ns := iss.GetNamespace()
+	if ns == "" {
+		ns = clusterResourceNamespace
+	}
+
+	var candidates []v1.GenericIssuer
+	if issuerLister != nil {
+		issuers, err := issuerLister.Issuers(ns).List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		for _, other := range issuers {
+			candidates = append(candidates, other)
+		}
+	}
+	if clusterIssuerLister != nil && ns == clusterResourceNamespace {
+		clusterIssuers, err := clusterIssuerLister.List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		for _, other := range clusterIssuers {
+			candidates = append(candidates, other)
+		}
+	}
+
+	var peers []v1.GenericIssuer
+	for _, other := range candidates {
+		isSelf := other.GetNamespace() == iss.GetNamespace() && other.GetName() == iss.GetName()
+		if isSelf || !SharesAccountKey(spec, other.GetSpec().ACME) {
+			continue
+		}
+		peers = append(peers, other)
+	}
+	return peers, nil
+}
+
+// AccountKeyPeersChanged returns an event handler for Issuers and
+// ClusterIssuers which calls enqueue with each issuer storing its ACME
+// account private key in the same Secret key as the changed issuer, so that
+// conflicts between their ACME account registrations are set or cleared on
+// all of them. Updates which do not change the spec of the issuer, nor
+// whether it holds a registered ACME account, are ignored.
+func AccountKeyPeersChanged(log logr.Logger, issuerLister cmlisters.IssuerLister, clusterIssuerLister cmlisters.ClusterIssuerLister, clusterResourceNamespace string, enqueue func(v1.GenericIssuer)) cache.ResourceEventHandler {
+	log = log.WithName("accountKeyPeersChanged")
+
+	changed := func(obj interface{}) {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		iss, ok := obj.(v1.GenericIssuer)
+		if !ok {
+			log.Error(nil, "object is not an Issuer or ClusterIssuer", "object", obj)
+			return
+		}
+		if iss.GetSpec().ACME == nil {
+			return
+		}
+
+		peers, err := AccountKeyPeers(issuerLister, clusterIssuerLister, clusterResourceNamespace, iss)
+		if err != nil {
+			logf.WithResource(log, iss).Error(err, "error listing issuers sharing the ACME account private key")
+			return
+		}
+		for _, peer := range peers {
+			enqueue(peer)
+		}
+	}
+
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: changed,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldIssuer, oldOK := oldObj.(v1.GenericIssuer)
+			newIssuer, newOK := newObj.(v1.GenericIssuer)
+			if oldOK && newOK && oldIssuer.GetGeneration() == newIssuer.GetGeneration() &&
+				holdsRegistration(oldIssuer) == holdsRegistration(newIssuer) {
+				return
+			}
+			changed(oldObj)
+			changed(newObj)
+		},
+		DeleteFunc: changed,
+	}
+}
+
+// holdsRegistration returns true if the issuer has a registered ACME account
+// and is Ready for its current generation, i.e. its registration has not been
+// changed since the account was registered.
+func holdsRegistration(iss v1.GenericIssuer) bool {
+	status := iss.GetStatus()
+	if status.ACME == nil || status.ACME.URI == "" {
+		return false
+	}
+	for _, cond := range status.Conditions {
+		if cond.Type == v1.IssuerConditionReady {
+			return cond.Status == cmmeta.ConditionTrue && cond.ObservedGeneration == iss.GetGeneration()
+		}
+	}
+	return false
+}
+
+// precedes returns true if the ACME account registration of issuer a takes
+// precedence over that of issuer b when they conflict: an issuer holding a
+// registered ACME account keeps it, so that a newly created or changed issuer
+// cannot break the issuers already using the account. Otherwise the issuer
+// created first takes precedence.
+func precedes(a, b v1.GenericIssuer) bool {
+	if aHolds, bHolds := holdsRegistration(a), holdsRegistration(b); aHolds != bHolds {
+		return aHolds
+	}
+	aCreated, bCreated := a.GetCreationTimestamp(), b.GetCreationTimestamp()
+	if !aCreated.Equal(&bCreated) {
+		return aCreated.Before(&bCreated)
+	}
+	if a.GetNamespace() != b.GetNamespace() {
+		return a.GetNamespace() < b.GetNamespace()
+	}
+	return a.GetName() < b.GetName()
+}
+
+// conflictingIssuers returns descriptions of the other issuers whose ACME
+// account private key is stored in the same Secret key as the issuer's but
+// which register the ACME account with different parameters, and whose
+// registration takes precedence over the issuer's. Issuers which are being
+// deleted are ignored.
+func (a *Acme) conflictingIssuers() ([]string, error) {
+	spec := a.issuer.GetSpec().ACME
+
+	peers, err := AccountKeyPeers(a.issuerLister, a.clusterIssuerLister, a.clusterResourceNamespace, a.issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	var conflicting []string
+	for _, other := range peers {
+		if other.GetDeletionTimestamp() != nil || sameRegistration(spec, other.GetSpec().ACME) || !precedes(other, a.issuer) {
+			continue
+		}
+		if other.GetNamespace() == "" {
+			conflicting = append(conflicting, fmt.Sprintf("ClusterIssuer %q", other.GetName()))
+		} else {
+			conflicting = append(conflicting, fmt.Sprintf("Issuer %q", other.GetNamespace()+"/"+other.GetName()))
+		}
+	}
+	sort.Strings(conflicting)
+
+	return conflicting, nil
+}
@@ -0,0 +1,279 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"context"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	acmeapi "golang.org/x/crypto/acme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	fakeregistry "github.com/cert-manager/cert-manager/pkg/acme/accounts/test"
+	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllertest "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestAcme_SetupSharedAccountKey(t *testing.T) {
+	const clusterResourceNamespace = "cert-manager"
+
+	rsaPrivKey := mustGenerateRSAKey(t)
+	baseIssuer := gen.Issuer("test-issuer",
+		gen.SetIssuerNamespace("default"),
+		gen.SetIssuerACMEURL(acmev2Prod),
+		gen.SetIssuerACMEEmail("test@example.com"),
+		gen.SetIssuerACMEPrivKeyRef("account-key"))
+	baseClusterIssuer := gen.ClusterIssuer("test-issuer",
+		gen.SetIssuerACMEURL(acmev2Prod),
+		gen.SetIssuerACMEEmail("test@example.com"),
+		gen.SetIssuerACMEPrivKeyRef("account-key"))
+	// registeredIssuer holds a registered ACME account for its current
+	// generation.
+	registeredIssuer := gen.IssuerFrom(baseIssuer,
+		setIssuerGeneration(1),
+		gen.SetIssuerACMEAccountURL(acmev2Prod+"/acct/1"),
+		gen.SetIssuerACMELastRegisteredEmail("test@example.com"),
+		gen.AddIssuerCondition(cmapi.IssuerCondition{
+			Type:               cmapi.IssuerConditionReady,
+			Status:             cmmeta.ConditionTrue,
+			Reason:             successAccountRegistered,
+			ObservedGeneration: 1,
+		}))
+	conflictCondition := cmapi.IssuerCondition{
+		Type:   cmapi.IssuerConditionSharedAccountKeyConflict,
+		Status: cmmeta.ConditionTrue,
+		Reason: errorSharedAccountKeyConflict,
+	}
+
+	tests := map[string]struct {
+		issuer         cmapi.GenericIssuer
+		issuers        []*cmapi.Issuer
+		clusterIssuers []*cmapi.ClusterIssuer
+
+		expectedConflictWith string
+		expectedRegistered   bool
+	}{
+		"an Issuer sharing the account key with a different email conflicts": {
+			issuer: baseIssuer,
+			issuers: []*cmapi.Issuer{
+				baseIssuer,
+				gen.IssuerFrom(baseIssuer, gen.SetIssuerName("other"), gen.SetIssuerACMEEmail("other@example.com")),
+			},
+			expectedConflictWith: `Issuer "default/other"`,
+		},
+		"an Issuer sharing the account key with a different external account binding conflicts": {
+			issuer: baseIssuer,
+			issuers: []*cmapi.Issuer{
+				baseIssuer,
+				gen.IssuerFrom(baseIssuer, gen.SetIssuerName("other"), gen.SetIssuerACMEEAB("kid", "eab")),
+			},
+			expectedConflictWith: `Issuer "default/other"`,
+		},
+		"all the conflicting Issuers are reported": {
+			issuer: baseIssuer,
+			issuers: []*cmapi.Issuer{
+				baseIssuer,
+				gen.IssuerFrom(baseIssuer, gen.SetIssuerName("other-b"), gen.SetIssuerACMEURL(acmev2Staging)),
+				gen.IssuerFrom(baseIssuer, gen.SetIssuerName("other-a"), gen.SetIssuerACMEEmail("other@example.com")),
+			},
+			expectedConflictWith: `Issuer "default/other-a", Issuer "default/other-b"`,
+		},
+		"an Issuer sharing the account key with identical registration parameters does not conflict": {
+			issuer: baseIssuer,
+			issuers: []*cmapi.Issuer{
+				baseIssuer,
+				gen.IssuerFrom(baseIssuer, gen.SetIssuerName("other"), gen.SetIssuerACMEEmail("Test@Example.com")),
+			},
+			expectedRegistered: true,
+		},
+		"an Issuer using another key of the same Secret does not conflict": {
+			issuer: baseIssuer,
+			issuers: []*cmapi.Issuer{
+				baseIssuer,
+				gen.IssuerFrom(baseIssuer, gen.SetIssuerName("other"), gen.SetIssuerACMEEmail("other@example.com"),
+					func(iss cmapi.GenericIssuer) { iss.GetSpec().ACME.PrivateKey.Key = "other.key" }),
+			},
+			expectedRegistered: true,
+		},
+		"an Issuer in another namespace does not conflict": {
+			issuer: baseIssuer,
+			issuers: []*cmapi.Issuer{
+				baseIssuer,
+				gen.IssuerFrom(baseIssuer, gen.SetIssuerNamespace("other"), gen.SetIssuerACMEEmail("other@example.com")),
+			},
+			expectedRegistered: true,
+		},
+		"a conflict is cleared once the registration parameters match": {
+			issuer: gen.IssuerFrom(baseIssuer, gen.AddIssuerCondition(conflictCondition)),
+			issuers: []*cmapi.Issuer{
+				baseIssuer,
+				gen.IssuerFrom(baseIssuer, gen.SetIssuerName("other")),
+			},
+			expectedRegistered: true,
+		},
+		"an Issuer sharing the account key with an equivalent server URL does not conflict": {
+			issuer: baseIssuer,
+			issuers: []*cmapi.Issuer{
+				baseIssuer,
+				gen.IssuerFrom(baseIssuer, gen.SetIssuerName("other"), gen.SetIssuerACMEURL("HTTPS://Acme-V02.api.letsencrypt.org:443/directory/")),
+			},
+			expectedRegistered: true,
+		},
+		"an Issuer holding the registration stays Ready when a conflicting Issuer is created": {
+			issuer: registeredIssuer,
+			issuers: []*cmapi.Issuer{
+				registeredIssuer,
+				gen.IssuerFrom(baseIssuer, gen.SetIssuerName("other"), gen.SetIssuerACMEEmail("other@example.com")),
+			},
+		},
+		"an Issuer conflicts with an Issuer holding the registration": {
+			issuer: gen.IssuerFrom(baseIssuer, gen.SetIssuerName("other"), gen.SetIssuerACMEEmail("other@example.com")),
+			issuers: []*cmapi.Issuer{
+				registeredIssuer,
+				gen.IssuerFrom(baseIssuer, gen.SetIssuerName("other"), gen.SetIssuerACMEEmail("other@example.com")),
+			},
+			expectedConflictWith: `Issuer "default/test-issuer"`,
+		},
+		"an Issuer whose registration changed no longer holds it": {
+			issuer: gen.IssuerFrom(registeredIssuer, gen.SetIssuerACMEEmail("changed@example.com"), setIssuerGeneration(2)),
+			issuers: []*cmapi.Issuer{
+				gen.IssuerFrom(registeredIssuer, gen.SetIssuerACMEEmail("changed@example.com"), setIssuerGeneration(2)),
+				gen.IssuerFrom(registeredIssuer, gen.SetIssuerName("other")),
+			},
+			expectedConflictWith: `Issuer "default/other"`,
+		},
+		"an Issuer created before a conflicting Issuer takes precedence": {
+			issuer: gen.IssuerFrom(baseIssuer, setIssuerCreationTimestamp(time.Unix(0, 0))),
+			issuers: []*cmapi.Issuer{
+				gen.IssuerFrom(baseIssuer, setIssuerCreationTimestamp(time.Unix(0, 0))),
+				gen.IssuerFrom(baseIssuer, gen.SetIssuerName("other"), gen.SetIssuerACMEEmail("other@example.com"),
+					setIssuerCreationTimestamp(time.Unix(60, 0))),
+			},
+			expectedRegistered: true,
+		},
+		"a ClusterIssuer sharing the account key with a different email conflicts": {
+			issuer: baseClusterIssuer,
+			clusterIssuers: []*cmapi.ClusterIssuer{
+				baseClusterIssuer,
+				gen.ClusterIssuerFrom(baseClusterIssuer.DeepCopy(), gen.SetIssuerName("other"), gen.SetIssuerACMEEmail("other@example.com")),
+			},
+			expectedConflictWith: `ClusterIssuer "other"`,
+		},
+		"an Issuer in the cluster resource namespace conflicts with a ClusterIssuer": {
+			issuer: gen.IssuerFrom(baseIssuer, gen.SetIssuerNamespace(clusterResourceNamespace)),
+			clusterIssuers: []*cmapi.ClusterIssuer{
+				gen.ClusterIssuerFrom(baseClusterIssuer.DeepCopy(), gen.SetIssuerACMEEmail("other@example.com")),
+			},
+			expectedConflictWith: `ClusterIssuer "test-issuer"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			issuerIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			for _, iss := range test.issuers {
+				require.NoError(t, issuerIndexer.Add(iss))
+			}
+			clusterIssuerIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			for _, iss := range test.clusterIssuers {
+				require.NoError(t, clusterIssuerIndexer.Add(iss))
+			}
+
+			clock := fakeclock.NewFakeClock(time.Now())
+			registered := false
+			cl := &acmecl.FakeACME{
+				FakeRegister: func(_ context.Context, a *acmeapi.Account, _ func(string) bool) (*acmeapi.Account, error) {
+					registered = true
+					return a, nil
+				},
+			}
+
+			recorder := new(controllertest.FakeRecorder)
+			a := Acme{
+				issuer:              test.issuer.DeepCopyObject().(cmapi.GenericIssuer),
+				recorder:            recorder,
+				issuerLister:        cmlisters.NewIssuerLister(issuerIndexer),
+				clusterIssuerLister: cmlisters.NewClusterIssuerLister(clusterIssuerIndexer),
+				accountRegistry: &fakeregistry.FakeRegistry{
+					RemoveClientFunc:        func(string) {},
+					AddClientFunc:           func(string, cmacme.ACMEIssuer, *rsa.PrivateKey, string) {},
+					IsKeyCheckSumCachedFunc: func(string, *rsa.PrivateKey) bool { return true },
+				},
+				keyFromSecret:            keyFromSecretMockBuilder(new(bool), rsaPrivKey, nil),
+				clientBuilder:            clientBuilderMock(cl),
+				clusterResourceNamespace: clusterResourceNamespace,
+				metrics:                  metrics.New(klog.Background(), clock),
+				clock:                    clock,
+			}
+			apiutil.Clock = clock
+
+			require.NoError(t, a.Setup(context.Background()))
+			assert.Equal(t, test.expectedRegistered, registered)
+
+			conditions := make(map[cmapi.IssuerConditionType]cmapi.IssuerCondition)
+			for _, cond := range a.issuer.GetStatus().Conditions {
+				conditions[cond.Type] = cond
+			}
+			ready, ok := conditions[cmapi.IssuerConditionReady]
+			require.True(t, ok, "expected a Ready condition")
+
+			if test.expectedConflictWith == "" {
+				assert.Len(t, conditions, 1)
+				assert.Equal(t, cmmeta.ConditionTrue, ready.Status)
+				assert.Empty(t, recorder.Events)
+				return
+			}
+
+			require.Len(t, conditions, 2)
+			conflict, ok := conditions[cmapi.IssuerConditionSharedAccountKeyConflict]
+			require.True(t, ok, "expected a SharedAccountKeyConflict condition")
+			assert.Equal(t, cmmeta.ConditionTrue, conflict.Status)
+			assert.Equal(t, errorSharedAccountKeyConflict, conflict.Reason)
+			assert.Contains(t, conflict.Message, `The ACME account private key in Secret "account-key" is also used by `+test.expectedConflictWith+` with different registration parameters`)
+			assert.Equal(t, cmmeta.ConditionFalse, ready.Status)
+			assert.Equal(t, errorSharedAccountKeyConflict, ready.Reason)
+			assert.Equal(t, conflict.Message, ready.Message)
+			assert.Len(t, recorder.Events, 1)
+		})
+	}
+}
+
+func setIssuerGeneration(generation int64) gen.IssuerModifier {
+	return func(iss cmapi.GenericIssuer) {
+		iss.GetObjectMeta().Generation = generation
+	}
+}
+
+func setIssuerCreationTimestamp(t time.Time) gen.IssuerModifier {
+	return func(iss cmapi.GenericIssuer) {
+		iss.GetObjectMeta().CreationTimestamp = metav1.NewTime(t)
+	}
+}
@@ -20,17 +20,21 @@ import (
 	"context"
 	"crypto"
 	"fmt"
+	"time"
 
 	core "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
 
 	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
 	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
 	"github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/issuer"
 	"github.com/cert-manager/cert-manager/pkg/metrics"
+	"github.com/cert-manager/cert-manager/pkg/util/errors"
 	"github.com/cert-manager/cert-manager/pkg/util/kube"
 )
 
@@ -43,10 +47,20 @@ type Acme struct {
 	secretsClient core.SecretsGetter
 	recorder      record.EventRecorder
 
+	// issuerLister and clusterIssuerLister are used to find other issuers
+	// sharing the ACME account private key Secret of the issuer.
+	issuerLister        cmlisters.IssuerLister
+	clusterIssuerLister cmlisters.ClusterIssuerLister
+
 	// keyFromSecret returns a decoded account key from a Kubernetes secret.
 	// It can be stubbed in unit tests.
 	keyFromSecret keyFromSecretFunc
 
+	// caBundleFromSecret returns the CA bundle used to verify the TLS
+	// certificate of the ACME server from a Kubernetes secret.
+	// It can be stubbed in unit tests.
+	caBundleFromSecret caBundleFromSecretFunc
+
 	// clientBuilder builds a new ACME client.
 	clientBuilder accounts.NewClientFunc
 
@@ -60,6 +74,13 @@ type Acme struct {
 
 	// userAgent is the string used as the UserAgent when making HTTP calls.
 	userAgent string
+
+	// accountVerificationInterval is the interval at which the ACME account
+	// is verified with the ACME server. If zero, the account is only
+	// verified when the issuer is registered or changed.
+	accountVerificationInterval time.Duration
+
+	clock clock.Clock
 }
 
 // New returns a new ACME issuer interface for the given issuer.
@@ -73,13 +94,23 @@ func New(ctx *controller.Context, issuer v1.GenericIssuer) (issuer.Interface, er
 	a := &Acme{
 		issuer:                   issuer,
 		keyFromSecret:            newKeyFromSecret(secretsLister),
+		caBundleFromSecret:       newCABundleFromSecret(secretsLister),
 		clientBuilder:            accounts.NewClient,
 		secretsClient:            ctx.Client.CoreV1(),
 		recorder:                 ctx.Recorder,
+		issuerLister:             ctx.SharedInformerFactory.Certmanager().V1().Issuers().Lister(),
 		clusterResourceNamespace: ctx.IssuerOptions.ClusterResourceNamespace,
 		accountRegistry:          ctx.ACMEOptions.AccountRegistry,
 		metrics:                  ctx.Metrics,
 		userAgent:                ctx.RESTConfig.UserAgent,
+
+		accountVerificationInterval: ctx.ACMEOptions.AccountVerificationInterval,
+		clock:                       ctx.Clock,
+	}
+	// ClusterIssuers are only watched if cert-manager is not limited to a
+	// single namespace.
+	if ctx.Namespace == "" {
+		a.clusterIssuerLister = ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers().Lister()
 	}
 
 	return a, nil
@@ -96,6 +127,26 @@ func newKeyFromSecret(secretLister internalinformers.SecretLister) keyFromSecret
 	}
 }
 
+// caBundleFromSecretFunc accepts namespace, name and keyName for secret, and
+// returns the CA bundle stored at keyName.
+type caBundleFromSecretFunc func(ctx context.Context, namespace, name, keyName string) ([]byte, error)
+
+// newCABundleFromSecret returns an implementation of caBundleFromSecretFunc
+// for a secrets lister.
+func newCABundleFromSecret(secretLister internalinformers.SecretLister) caBundleFromSecretFunc {
+	return func(ctx context.Context, namespace, name, keyName string) ([]byte, error) {
+		secret, err := secretLister.Secrets(namespace).Get(name)
+		if err != nil {
+			return nil, err
+		}
+		caBundle, ok := secret.Data[keyName]
+		if !ok || len(caBundle) == 0 {
+			return nil, errors.NewInvalidData("no data for %q in secret '%s/%s'", keyName, namespace, name)
+		}
+		return caBundle, nil
+	}
+}
+
 // Register this Issuer with the issuer factory
 func init() {
 	issuer.RegisterIssuer(apiutil.IssuerACME, New)
@@ -27,10 +27,16 @@ package acmedns
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/cpu/goacmedns"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
 )
 
 // DNSProvider is an implementation of the acme.ChallengeProvider interface
@@ -38,6 +44,9 @@ type DNSProvider struct {
 	dns01Nameservers []string
 	client           goacmedns.Client
 	accounts         map[string]goacmedns.Account
+	// invalidAccounts holds the reason the credentials of each domain which
+	// were skipped as invalid cannot be used.
+	invalidAccounts map[string]error
 }
 
 // NewDNSProvider returns a DNSProvider instance configured for ACME DNS
@@ -58,22 +67,71 @@ func NewDNSProviderHostBytes(host string, accountJSON []byte, dns01Nameservers [
 	if err := json.Unmarshal(accountJSON, &accounts); err != nil {
 		return nil, fmt.Errorf("Error unmarshalling accountJSON: %s", err)
 	}
+	if len(accounts) == 0 {
+		return nil, errors.New("accountJSON does not contain credentials for any domain")
+	}
+
+	valid, invalid := validateAccounts(accounts)
+	if len(valid) == 0 {
+		var errs []error
+		for _, domain := range sets.List(sets.KeySet(invalid)) {
+			errs = append(errs, invalid[domain])
+		}
+		return nil, utilerrors.NewAggregate(errs)
+	}
 
 	return &DNSProvider{
 		client:           client,
-		accounts:         accounts,
+		accounts:         valid,
+		invalidAccounts:  invalid,
 		dns01Nameservers: dns01Nameservers,
 	}, nil
 }
 
+// validateAccounts checks each domain's credentials on its own, and splits
+// them into the ones which can be used to update the TXT record and the
+// reason each of the others cannot. An invalid entry only prevents challenges
+// for its own domain from being solved.
+func validateAccounts(accounts map[string]goacmedns.Account) (map[string]goacmedns.Account, map[string]error) {
+	valid := make(map[string]goacmedns.Account, len(accounts))
+	invalid := make(map[string]error)
+
+	for domain, account := range accounts {
+		var missing []string
+		if account.SubDomain == "" {
+			missing = append(missing, "subdomain")
+		}
+		if account.Username == "" {
+			missing = append(missing, "username")
+		}
+		if account.Password == "" {
+			missing = append(missing, "password")
+		}
+		if len(missing) > 0 {
+			invalid[domain] = fmt.Errorf("invalid credentials for domain %s in accountJSON: missing %s", domain, strings.Join(missing, ", "))
+			continue
+		}
+		valid[domain] = account
+	}
+
+	return valid, invalid
+}
+
 // Present creates a TXT record to fulfil the dns-01 challenge
-func (c *DNSProvider) Present(_ context.Context, domain, fqdn, value string) error {
+func (c *DNSProvider) Present(ctx context.Context, domain, fqdn, value string) error {
 	if account, exists := c.accounts[domain]; exists {
-		// Update the acme-dns TXT record.
-		return c.client.UpdateTXTRecord(account, value)
+		// Update the acme-dns TXT record. The goacmedns client does not
+		// accept a context.
+		return util.CallWithContext(ctx, func() error {
+			return c.client.UpdateTXTRecord(account, value)
+		})
+	}
+	if err, invalid := c.invalidAccounts[domain]; invalid {
+		return err
 	}
 
-	return fmt.Errorf("account credentials not found for domain %s", domain)
+	return fmt.Errorf("account credentials not found for domain %s, the acme-dns account secret contains credentials for: %s",
+		domain, strings.Join(sets.List(sets.KeySet(c.accounts)), ", "))
 }
 
 // CleanUp removes the record matching the specified parameters. It is not
@@ -18,10 +18,15 @@ package acmedns
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
 )
@@ -68,6 +73,106 @@ func TestNoValidJson(t *testing.T) {
 	assert.Error(t, err, "Expected error constructing DNSProvider from invalid JSON")
 }
 
+func TestIncompleteJsonAccount(t *testing.T) {
+	accountJSON := []byte(`{
+        "domain": {
+            "fulldomain": "fooldom",
+            "subdomain": "subdoom",
+            "username": "usernom"
+        }
+    }`)
+	_, err := NewDNSProviderHostBytes("http://localhost/", accountJSON, util.RecursiveNameservers)
+	assert.EqualError(t, err, "invalid credentials for domain domain in accountJSON: missing password")
+}
+
+func TestPartiallyIncompleteJsonAccount(t *testing.T) {
+	accountJSON := []byte(`{
+        "example.com": {
+            "fulldomain": "fooldom",
+            "password": "secret",
+            "subdomain": "subdoom",
+            "username": "usernom"
+        },
+        "other.example.com": {
+            "fulldomain": "fooldom",
+            "subdomain": "subdoom"
+        }
+    }`)
+	provider, err := NewDNSProviderHostBytes("http://localhost/", accountJSON, util.RecursiveNameservers)
+	require.NoError(t, err, "Expected the invalid entry to be skipped")
+	assert.Contains(t, provider.accounts, "example.com")
+	assert.NotContains(t, provider.accounts, "other.example.com")
+
+	err = provider.Present(context.TODO(), "other.example.com", "_acme-challenge.other.example.com.", "LG3tptA6W7T1vw4ujbmDxH2lLu6r8TUIqLZD3pzPmgE")
+	assert.EqualError(t, err, "invalid credentials for domain other.example.com in accountJSON: missing username, password")
+}
+
+func TestNoJsonAccounts(t *testing.T) {
+	_, err := NewDNSProviderHostBytes("http://localhost/", []byte("{}"), util.RecursiveNameservers)
+	assert.Error(t, err, "Expected error constructing DNSProvider without any accounts")
+}
+
+func TestPresent(t *testing.T) {
+	var (
+		gotUser, gotKey string
+		gotUpdate       struct {
+			SubDomain string `json:"subdomain"`
+			Txt       string `json:"txt"`
+		}
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/update", r.URL.Path)
+		gotUser = r.Header.Get("X-Api-User")
+		gotKey = r.Header.Get("X-Api-Key")
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&gotUpdate))
+		fmt.Fprintf(w, `{"txt": %q}`, gotUpdate.Txt)
+	}))
+	defer server.Close()
+
+	accountJSON := []byte(`{
+        "example.com": {
+            "fulldomain": "d420c923-bbd7-4056-ab64-c3ca54c9b3cf.auth.example.org",
+            "password": "secret",
+            "subdomain": "d420c923-bbd7-4056-ab64-c3ca54c9b3cf",
+            "username": "usernom"
+        }
+    }`)
+	provider, err := NewDNSProviderHostBytes(server.URL, accountJSON, util.RecursiveNameservers)
+	require.NoError(t, err)
+
+	err = provider.Present(context.TODO(), "example.com", "_acme-challenge.example.com.", "LG3tptA6W7T1vw4ujbmDxH2lLu6r8TUIqLZD3pzPmgE")
+	require.NoError(t, err)
+	assert.Equal(t, "usernom", gotUser)
+	assert.Equal(t, "secret", gotKey)
+	assert.Equal(t, "d420c923-bbd7-4056-ab64-c3ca54c9b3cf", gotUpdate.SubDomain)
+	assert.Equal(t, "LG3tptA6W7T1vw4ujbmDxH2lLu6r8TUIqLZD3pzPmgE", gotUpdate.Txt)
+
+	err = provider.Present(context.TODO(), "other.example.com", "_acme-challenge.other.example.com.", "LG3tptA6W7T1vw4ujbmDxH2lLu6r8TUIqLZD3pzPmgE")
+	assert.EqualError(t, err, "account credentials not found for domain other.example.com, the acme-dns account secret contains credentials for: example.com")
+}
+
+func TestPresentServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"error": "forbidden"}`)
+	}))
+	defer server.Close()
+
+	accountJSON := []byte(`{
+        "example.com": {
+            "password": "wrong",
+            "subdomain": "subdoom",
+            "username": "usernom"
+        }
+    }`)
+	provider, err := NewDNSProviderHostBytes(server.URL, accountJSON, util.RecursiveNameservers)
+	require.NoError(t, err)
+
+	err = provider.Present(context.TODO(), "example.com", "_acme-challenge.example.com.", "LG3tptA6W7T1vw4ujbmDxH2lLu6r8TUIqLZD3pzPmgE")
+	assert.Error(t, err, "Expected error when the acme-dns server rejects the update")
+}
+
 func TestLiveAcmeDnsPresent(t *testing.T) {
 	if !acmednsLiveTest {
 		t.Skip("skipping live test")
@@ -112,7 +112,11 @@ func (a *DNSProvider) Present(ctx context.Context, domain, fqdn, value string) e
 	}
 	logf.V(logf.DebugLevel).Infof("recordName: %s", recordName)
 
-	record, err := a.dnsclient.GetRecord(hostedDomain, recordName, "TXT")
+	var record *dns.RecordBody
+	err = util.CallWithContext(ctx, func() (err error) {
+		record, err = a.dnsclient.GetRecord(hostedDomain, recordName, "TXT")
+		return err
+	})
 	if err != nil && !a.isNotFound(err) {
 		return fmt.Errorf("edgedns: failed to retrieve TXT record: %w", err)
 	}
@@ -132,7 +136,9 @@ func (a *DNSProvider) Present(ctx context.Context, domain, fqdn, value string) e
 		record.Target = append(record.Target, `"`+value+`"`)
 		record.TTL = a.TTL
 
-		err = a.dnsclient.RecordUpdate(record, hostedDomain)
+		err = util.CallWithContext(ctx, func() error {
+			return a.dnsclient.RecordUpdate(record, hostedDomain)
+		})
 		if err != nil {
 			return fmt.Errorf("edgedns: failed to update TXT record: %w", err)
 		}
@@ -147,7 +153,9 @@ func (a *DNSProvider) Present(ctx context.Context, domain, fqdn, value string) e
 		Target:     []string{`"` + value + `"`},
 	}
 
-	err = a.dnsclient.RecordSave(record, hostedDomain)
+	err = util.CallWithContext(ctx, func() error {
+		return a.dnsclient.RecordSave(record, hostedDomain)
+	})
 	if err != nil {
 		return fmt.Errorf("edgedns: failed to create TXT record: %w", err)
 	}
@@ -172,7 +180,11 @@ func (a *DNSProvider) CleanUp(ctx context.Context, domain, fqdn, value string) e
 	}
 	logf.V(logf.DebugLevel).Infof("recordName: %s", recordName)
 
-	existingRec, err := a.dnsclient.GetRecord(hostedDomain, recordName, "TXT")
+	var existingRec *dns.RecordBody
+	err = util.CallWithContext(ctx, func() (err error) {
+		existingRec, err = a.dnsclient.GetRecord(hostedDomain, recordName, "TXT")
+		return err
+	})
 	if err != nil {
 		if a.isNotFound(err) {
 			return nil
@@ -204,7 +216,9 @@ func (a *DNSProvider) CleanUp(ctx context.Context, domain, fqdn, value string) e
 	if len(newRData) > 0 {
 		existingRec.Target = newRData
 		logf.V(logf.DebugLevel).Infof("updating Akamai TXT record: %s, data: %s", existingRec.Name, newRData)
-		err = a.dnsclient.RecordUpdate(existingRec, hostedDomain)
+		err = util.CallWithContext(ctx, func() error {
+			return a.dnsclient.RecordUpdate(existingRec, hostedDomain)
+		})
 		if err != nil {
 			return fmt.Errorf("edgedns: TXT record update failed: %w", err)
 		}
@@ -213,7 +227,9 @@ func (a *DNSProvider) CleanUp(ctx context.Context, domain, fqdn, value string) e
 	}
 
 	logf.V(logf.DebugLevel).Infof("deleting Akamai TXT record %s", existingRec.Name)
-	err = a.dnsclient.RecordDelete(existingRec, hostedDomain)
+	err = util.CallWithContext(ctx, func() error {
+		return a.dnsclient.RecordDelete(existingRec, hostedDomain)
+	})
 	if err != nil {
 		return fmt.Errorf("edgedns: TXT record delete failed: %w", err)
 	}
@@ -34,6 +34,10 @@ import (
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 )
 
+const (
+	azureDNSTTL = 60
+)
+
 // DNSProvider implements the util.ChallengeProvider interface
 type DNSProvider struct {
 	dns01Nameservers  []string
@@ -41,12 +45,14 @@ type DNSProvider struct {
 	zoneClient        *dns.ZonesClient
 	resourceGroupName string
 	zoneName          string
+	ttl               int64
 	log               logr.Logger
 }
 
 // NewDNSProviderCredentials returns a DNSProvider instance configured for the Azure
-// DNS service using static credentials from its parameters
-func NewDNSProviderCredentials(environment, clientID, clientSecret, subscriptionID, tenantID, resourceGroupName, zoneName string, dns01Nameservers []string, ambient bool, managedIdentity *cmacme.AzureManagedIdentity) (*DNSProvider, error) {
+// DNS service using static credentials from its parameters.
+// If ttl is zero, the default TTL of 60 seconds is used for challenge records.
+func NewDNSProviderCredentials(environment, clientID, clientSecret, subscriptionID, tenantID, resourceGroupName, zoneName string, dns01Nameservers []string, ambient bool, managedIdentity *cmacme.AzureManagedIdentity, ttl int64) (*DNSProvider, error) {
 	cloudCfg, err := getCloudConfiguration(environment)
 	if err != nil {
 		return nil, err
@@ -72,6 +78,7 @@ func NewDNSProviderCredentials(environment, clientID, clientSecret, subscription
 		zoneClient:        zc,
 		resourceGroupName: resourceGroupName,
 		zoneName:          zoneName,
+		ttl:               ttl,
 		log:               logf.Log.WithName("azure-dns"),
 	}, nil
 }
@@ -198,6 +205,15 @@ func (c *DNSProvider) trimFqdn(fqdn string, zone string) string {
 	return strings.TrimSuffix(strings.TrimSuffix(fqdn, "."), "."+z)
 }
 
+// recordTTL returns the TTL to use for challenge records, falling back to the
+// default if none was configured.
+func (c *DNSProvider) recordTTL() int64 {
+	if c.ttl > 0 {
+		return c.ttl
+	}
+	return azureDNSTTL
+}
+
 // Updates or removes DNS TXT record while respecting optimistic concurrency control
 func (c *DNSProvider) updateTXTRecord(ctx context.Context, fqdn string, updater func(*dns.RecordSet)) error {
 	zone, err := c.getHostedZoneName(ctx, fqdn)
@@ -215,7 +231,7 @@ func (c *DNSProvider) updateTXTRecord(ctx context.Context, fqdn string, updater
 		if errors.As(err, &respErr); respErr.StatusCode == http.StatusNotFound {
 			set = &dns.RecordSet{
 				Properties: &dns.RecordSetProperties{
-					TTL:        to.Ptr(int64(60)),
+					TTL:        to.Ptr(c.recordTTL()),
 					TxtRecords: []*dns.TxtRecord{},
 				},
 				Etag: to.Ptr(""),
@@ -62,7 +62,7 @@ func TestLiveAzureDnsPresent(t *testing.T) {
 	if !azureLiveTest {
 		t.Skip("skipping live test")
 	}
-	provider, err := NewDNSProviderCredentials("", azureClientID, azureClientSecret, azuresubscriptionID, azureTenantID, azureResourceGroupName, azureHostedZoneName, util.RecursiveNameservers, false, &v1.AzureManagedIdentity{})
+	provider, err := NewDNSProviderCredentials("", azureClientID, azureClientSecret, azuresubscriptionID, azureTenantID, azureResourceGroupName, azureHostedZoneName, util.RecursiveNameservers, false, &v1.AzureManagedIdentity{}, 0)
 	assert.NoError(t, err)
 
 	err = provider.Present(context.TODO(), azureDomain, "_acme-challenge."+azureDomain+".", "123d==")
@@ -73,7 +73,7 @@ func TestLiveAzureDnsPresentMultiple(t *testing.T) {
 	if !azureLiveTest {
 		t.Skip("skipping live test")
 	}
-	provider, err := NewDNSProviderCredentials("", azureClientID, azureClientSecret, azuresubscriptionID, azureTenantID, azureResourceGroupName, azureHostedZoneName, util.RecursiveNameservers, false, &v1.AzureManagedIdentity{})
+	provider, err := NewDNSProviderCredentials("", azureClientID, azureClientSecret, azuresubscriptionID, azureTenantID, azureResourceGroupName, azureHostedZoneName, util.RecursiveNameservers, false, &v1.AzureManagedIdentity{}, 0)
 	assert.NoError(t, err)
 
 	err = provider.Present(context.TODO(), azureDomain, "_acme-challenge."+azureDomain+".", "123d==")
@@ -89,7 +89,7 @@ func TestLiveAzureDnsCleanUp(t *testing.T) {
 
 	time.Sleep(time.Second * 5)
 
-	provider, err := NewDNSProviderCredentials("", azureClientID, azureClientSecret, azuresubscriptionID, azureTenantID, azureResourceGroupName, azureHostedZoneName, util.RecursiveNameservers, false, &v1.AzureManagedIdentity{})
+	provider, err := NewDNSProviderCredentials("", azureClientID, azureClientSecret, azuresubscriptionID, azureTenantID, azureResourceGroupName, azureHostedZoneName, util.RecursiveNameservers, false, &v1.AzureManagedIdentity{}, 0)
 	assert.NoError(t, err)
 
 	err = provider.CleanUp(context.TODO(), azureDomain, "_acme-challenge."+azureDomain+".", "123d==")
@@ -103,7 +103,7 @@ func TestLiveAzureDnsCleanUpMultiple(t *testing.T) {
 
 	time.Sleep(time.Second * 10)
 
-	provider, err := NewDNSProviderCredentials("", azureClientID, azureClientSecret, azuresubscriptionID, azureTenantID, azureResourceGroupName, azureHostedZoneName, util.RecursiveNameservers, false, &v1.AzureManagedIdentity{})
+	provider, err := NewDNSProviderCredentials("", azureClientID, azureClientSecret, azuresubscriptionID, azureTenantID, azureResourceGroupName, azureHostedZoneName, util.RecursiveNameservers, false, &v1.AzureManagedIdentity{}, 0)
 	assert.NoError(t, err)
 
 	err = provider.CleanUp(context.TODO(), azureDomain, "_acme-challenge."+azureDomain+".", "123d==")
@@ -115,16 +115,16 @@ func TestLiveAzureDnsCleanUpMultiple(t *testing.T) {
 func TestInvalidAzureDns(t *testing.T) {
 	validEnv := []string{"", "AzurePublicCloud", "AzureChinaCloud", "AzureUSGovernmentCloud"}
 	for _, env := range validEnv {
-		_, err := NewDNSProviderCredentials(env, "cid", "secret", "", "tenid", "", "", util.RecursiveNameservers, false, &v1.AzureManagedIdentity{})
+		_, err := NewDNSProviderCredentials(env, "cid", "secret", "", "tenid", "", "", util.RecursiveNameservers, false, &v1.AzureManagedIdentity{}, 0)
 		assert.NoError(t, err)
 	}
 
 	// Invalid environment
-	_, err := NewDNSProviderCredentials("invalid env", "cid", "secret", "", "tenid", "", "", util.RecursiveNameservers, false, &v1.AzureManagedIdentity{})
+	_, err := NewDNSProviderCredentials("invalid env", "cid", "secret", "", "tenid", "", "", util.RecursiveNameservers, false, &v1.AzureManagedIdentity{}, 0)
 	assert.Error(t, err)
 
 	// Invalid tenantID
-	_, err = NewDNSProviderCredentials("", "cid", "secret", "", "invalid env value", "", "", util.RecursiveNameservers, false, &v1.AzureManagedIdentity{})
+	_, err = NewDNSProviderCredentials("", "cid", "secret", "", "invalid env value", "", "", util.RecursiveNameservers, false, &v1.AzureManagedIdentity{}, 0)
 	assert.Error(t, err)
 }
 
@@ -415,3 +415,71 @@ ERROR CODE: TEST_ERROR_CODE
 --------------------------------------------------------------------------------
 `, ts.URL))
 }
+
+func TestPresentTTL(t *testing.T) {
+	tests := map[string]struct {
+		ttl    int64
+		expTTL int64
+	}{
+		"should use the default TTL if none is configured": {
+			ttl:    0,
+			expTTL: 60,
+		},
+		"should use the configured TTL": {
+			ttl:    300,
+			expTTL: 300,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var recordSet dns.RecordSet
+			ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch r.Method {
+				case http.MethodGet:
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"error":{"code":"NotFound","message":"not found"}}`))
+				case http.MethodPut:
+					body, err := io.ReadAll(r.Body)
+					require.NoError(t, err)
+					require.NoError(t, json.Unmarshal(body, &recordSet))
+					_, _ = w.Write(body)
+				default:
+					require.FailNow(t, "unexpected request "+r.Method+" "+r.URL.Path)
+				}
+			}))
+			defer ts.Close()
+
+			clientOpt := policy.ClientOptions{
+				Cloud: cloud.Configuration{
+					ActiveDirectoryAuthorityHost: ts.URL,
+					Services: map[cloud.ServiceName]cloud.ServiceConfiguration{
+						cloud.ResourceManager: {
+							Audience: ts.URL,
+							Endpoint: ts.URL,
+						},
+					},
+				},
+				Transport: ts.Client(),
+			}
+
+			rc, err := dns.NewRecordSetsClient("subscriptionID", nil, &arm.ClientOptions{ClientOptions: clientOpt})
+			require.NoError(t, err)
+
+			dnsProvider := DNSProvider{
+				dns01Nameservers:  util.RecursiveNameservers,
+				resourceGroupName: "resourceGroupName",
+				zoneName:          "test.com",
+				ttl:               test.ttl,
+				recordClient:      rc,
+			}
+
+			err = dnsProvider.Present(context.TODO(), "test.com", "_acme-challenge.test.com.", "test123")
+			require.NoError(t, err)
+			require.NotNil(t, recordSet.Properties)
+			require.NotNil(t, recordSet.Properties.TTL)
+			assert.Equal(t, test.expTTL, *recordSet.Properties.TTL)
+		})
+	}
+}
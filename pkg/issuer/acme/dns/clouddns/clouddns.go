@@ -13,47 +13,89 @@ package clouddns
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
+	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 	"google.golang.org/api/dns/v1"
+	"google.golang.org/api/iamcredentials/v1"
 	"google.golang.org/api/option"
 
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 )
 
+const (
+	cloudDNSTTL = 60
+)
+
 // DNSProvider is an implementation of the DNSProvider interface.
 type DNSProvider struct {
 	hostedZoneName   string
 	dns01Nameservers []string
 	project          string
+	ttl              int64
 	client           *dns.Service
 	log              logr.Logger
+
+	// findZoneByFqdn looks up the zone apex of an FQDN in the public DNS.
+	// It is util.FindZoneByFqdn, unless replaced in tests.
+	findZoneByFqdn func(ctx context.Context, fqdn string, nameservers []string) (string, error)
 }
 
-// NewDNSProvider returns a new DNSProvider Instance with configuration
-func NewDNSProvider(ctx context.Context, project string, saBytes []byte, dns01Nameservers []string, ambient bool, hostedZoneName string) (*DNSProvider, error) {
+// NewDNSProvider returns a new DNSProvider Instance with configuration.
+// project is the Google Cloud project holding the managed zones, which may
+// differ from the project of the credentials. If impersonateServiceAccount is
+// set, the credentials are used to impersonate that service account, which
+// is then used to manage the records.
+func NewDNSProvider(ctx context.Context, project string, saBytes []byte, dns01Nameservers []string, ambient bool, hostedZoneName string, ttl int64, impersonateServiceAccount string) (*DNSProvider, error) {
 	// project is a required field
 	if project == "" {
 		return nil, fmt.Errorf("Google Cloud project name missing")
 	}
-	// if the service account bytes are not provided, we will attempt to instantiate
-	// with 'ambient credentials' (if they are allowed/enabled)
-	if len(saBytes) == 0 {
-		if !ambient {
-			return nil, fmt.Errorf("unable to construct clouddns provider: empty credentials; perhaps you meant to enable ambient credentials?")
-		}
-		return NewDNSProviderCredentials(ctx, project, dns01Nameservers, hostedZoneName)
+
+	// The credentials are only used to generate access tokens for the
+	// impersonated service account if set, which requires the cloud-platform
+	// scope.
+	scope := dns.NdevClouddnsReadwriteScope
+	if impersonateServiceAccount != "" {
+		scope = iamcredentials.CloudPlatformScope
 	}
+
+	var (
+		client *http.Client
+		err    error
+	)
+	switch {
 	// if service account data is provided, we instantiate using that
-	if len(saBytes) != 0 {
-		return NewDNSProviderServiceAccountBytes(ctx, project, saBytes, dns01Nameservers, hostedZoneName)
+	case len(saBytes) != 0:
+		client, err = serviceAccountClient(ctx, saBytes, scope)
+	// if the service account bytes are not provided, we will attempt to
+	// instantiate with 'ambient credentials' (if they are allowed/enabled).
+	// These include federated credentials configured for Workload Identity
+	// Federation.
+	case ambient:
+		client, err = ambientClient(ctx, scope)
+	default:
+		return nil, fmt.Errorf("unable to construct clouddns provider: empty credentials; perhaps you meant to enable ambient credentials?")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if impersonateServiceAccount != "" {
+		client, err = impersonatedClient(ctx, client, impersonateServiceAccount)
+		if err != nil {
+			return nil, err
+		}
 	}
-	return nil, fmt.Errorf("missing Google Cloud DNS provider credentials")
+
+	return newDNSProvider(ctx, project, client, dns01Nameservers, hostedZoneName, ttl)
 }
 
 // NewDNSProviderEnvironment returns a DNSProvider instance configured for Google Cloud
@@ -63,40 +105,29 @@ func NewDNSProvider(ctx context.Context, project string, saBytes []byte, dns01Na
 func NewDNSProviderEnvironment(ctx context.Context, dns01Nameservers []string, hostedZoneName string) (*DNSProvider, error) {
 	project := os.Getenv("GCE_PROJECT")
 	if saFile, ok := os.LookupEnv("GCE_SERVICE_ACCOUNT_FILE"); ok {
-		return NewDNSProviderServiceAccount(ctx, project, saFile, dns01Nameservers, hostedZoneName)
+		return NewDNSProviderServiceAccount(ctx, project, saFile, dns01Nameservers, hostedZoneName, 0)
 	}
-	return NewDNSProviderCredentials(ctx, project, dns01Nameservers, hostedZoneName)
+	return NewDNSProviderCredentials(ctx, project, dns01Nameservers, hostedZoneName, 0)
 }
 
 // NewDNSProviderCredentials uses the supplied credentials to return a
 // DNSProvider instance configured for Google Cloud DNS.
-func NewDNSProviderCredentials(ctx context.Context, project string, dns01Nameservers []string, hostedZoneName string) (*DNSProvider, error) {
+func NewDNSProviderCredentials(ctx context.Context, project string, dns01Nameservers []string, hostedZoneName string, ttl int64) (*DNSProvider, error) {
 	if project == "" {
 		return nil, fmt.Errorf("Google Cloud project name missing")
 	}
 
-	client, err := google.DefaultClient(ctx, dns.NdevClouddnsReadwriteScope)
-	if err != nil {
-		return nil, fmt.Errorf("Unable to get Google Cloud client: %v", err)
-	}
-
-	svc, err := dns.NewService(ctx, option.WithHTTPClient(client))
+	client, err := ambientClient(ctx, dns.NdevClouddnsReadwriteScope)
 	if err != nil {
-		return nil, fmt.Errorf("Unable to create Google Cloud DNS service: %v", err)
+		return nil, err
 	}
 
-	return &DNSProvider{
-		project:          project,
-		client:           svc,
-		dns01Nameservers: dns01Nameservers,
-		hostedZoneName:   hostedZoneName,
-		log:              logf.Log.WithName("clouddns"),
-	}, nil
+	return newDNSProvider(ctx, project, client, dns01Nameservers, hostedZoneName, ttl)
 }
 
 // NewDNSProviderServiceAccount uses the supplied service account JSON file to
 // return a DNSProvider instance configured for Google Cloud DNS.
-func NewDNSProviderServiceAccount(ctx context.Context, project string, saFile string, dns01Nameservers []string, hostedZoneName string) (*DNSProvider, error) {
+func NewDNSProviderServiceAccount(ctx context.Context, project string, saFile string, dns01Nameservers []string, hostedZoneName string, ttl int64) (*DNSProvider, error) {
 	if project == "" {
 		return nil, fmt.Errorf("Google Cloud project name missing")
 	}
@@ -108,12 +139,12 @@ func NewDNSProviderServiceAccount(ctx context.Context, project string, saFile st
 	if err != nil {
 		return nil, fmt.Errorf("Unable to read Service Account file: %v", err)
 	}
-	return NewDNSProviderServiceAccountBytes(ctx, project, dat, dns01Nameservers, hostedZoneName)
+	return NewDNSProviderServiceAccountBytes(ctx, project, dat, dns01Nameservers, hostedZoneName, ttl)
 }
 
 // NewDNSProviderServiceAccountBytes uses the supplied service account JSON
 // file data to return a DNSProvider instance configured for Google Cloud DNS.
-func NewDNSProviderServiceAccountBytes(ctx context.Context, project string, saBytes []byte, dns01Nameservers []string, hostedZoneName string) (*DNSProvider, error) {
+func NewDNSProviderServiceAccountBytes(ctx context.Context, project string, saBytes []byte, dns01Nameservers []string, hostedZoneName string, ttl int64) (*DNSProvider, error) {
 	if project == "" {
 		return nil, fmt.Errorf("Google Cloud project name missing")
 	}
@@ -121,23 +152,89 @@ func NewDNSProviderServiceAccountBytes(ctx context.Context, project string, saBy
 		return nil, fmt.Errorf("Google Cloud Service Account data missing")
 	}
 
-	conf, err := google.JWTConfigFromJSON(saBytes, dns.NdevClouddnsReadwriteScope)
+	client, err := serviceAccountClient(ctx, saBytes, dns.NdevClouddnsReadwriteScope)
+	if err != nil {
+		return nil, err
+	}
+
+	return newDNSProvider(ctx, project, client, dns01Nameservers, hostedZoneName, ttl)
+}
+
+// ambientClient returns an HTTP client authenticated with the application
+// default credentials, which may be federated credentials configured for
+// Workload Identity Federation, for the given OAuth2 scope.
+func ambientClient(ctx context.Context, scope string) (*http.Client, error) {
+	client, err := google.DefaultClient(ctx, scope)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to get Google Cloud client: %v", err)
+	}
+	return client, nil
+}
+
+// serviceAccountClient returns an HTTP client authenticated with the given
+// service account JSON key, for the given OAuth2 scope.
+func serviceAccountClient(ctx context.Context, saBytes []byte, scope string) (*http.Client, error) {
+	conf, err := google.JWTConfigFromJSON(saBytes, scope)
 	if err != nil {
 		return nil, fmt.Errorf("Unable to acquire config: %v", err)
 	}
+	return conf.Client(ctx), nil
+}
+
+// impersonatedClient returns an HTTP client authenticated as the service
+// account serviceAccount, using short-lived access tokens generated by the
+// IAM Service Account Credentials API with the credentials of client.
+// The options are used to create the API client, e.g. to set its endpoint.
+func impersonatedClient(ctx context.Context, client *http.Client, serviceAccount string, opts ...option.ClientOption) (*http.Client, error) {
+	svc, err := iamcredentials.NewService(ctx, append([]option.ClientOption{option.WithHTTPClient(client)}, opts...)...)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to create Google Cloud IAM credentials service: %v", err)
+	}
+	ts := oauth2.ReuseTokenSource(nil, &impersonatedTokenSource{
+		ctx:            ctx,
+		service:        svc,
+		serviceAccount: serviceAccount,
+	})
+	return oauth2.NewClient(ctx, ts), nil
+}
 
-	client := conf.Client(ctx)
+// impersonatedTokenSource generates access tokens for a service account which
+// is impersonated.
+type impersonatedTokenSource struct {
+	ctx            context.Context
+	service        *iamcredentials.Service
+	serviceAccount string
+}
 
+func (ts *impersonatedTokenSource) Token() (*oauth2.Token, error) {
+	name := fmt.Sprintf("projects/-/serviceAccounts/%s", ts.serviceAccount)
+	resp, err := ts.service.Projects.ServiceAccounts.GenerateAccessToken(name, &iamcredentials.GenerateAccessTokenRequest{
+		Scope: []string{dns.NdevClouddnsReadwriteScope},
+	}).Context(ts.ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("unable to impersonate Google Cloud service account %q: %v", ts.serviceAccount, err)
+	}
+	expiry, err := time.Parse(time.RFC3339, resp.ExpireTime)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the expiry time of the access token for Google Cloud service account %q: %v", ts.serviceAccount, err)
+	}
+	return &oauth2.Token{AccessToken: resp.AccessToken, TokenType: "Bearer", Expiry: expiry}, nil
+}
+
+func newDNSProvider(ctx context.Context, project string, client *http.Client, dns01Nameservers []string, hostedZoneName string, ttl int64) (*DNSProvider, error) {
 	svc, err := dns.NewService(ctx, option.WithHTTPClient(client))
 	if err != nil {
 		return nil, fmt.Errorf("Unable to create Google Cloud DNS service: %v", err)
 	}
+
 	return &DNSProvider{
 		project:          project,
 		client:           svc,
 		dns01Nameservers: dns01Nameservers,
 		hostedZoneName:   hostedZoneName,
+		ttl:              ttl,
 		log:              logf.Log.WithName("clouddns"),
+		findZoneByFqdn:   util.FindZoneByFqdn,
 	}, nil
 }
 
@@ -151,7 +248,7 @@ func (c *DNSProvider) Present(ctx context.Context, domain, fqdn, value string) e
 	rec := &dns.ResourceRecordSet{
 		Name:    fqdn,
 		Rrdatas: []string{value},
-		Ttl:     int64(60),
+		Ttl:     c.recordTTL(),
 		Type:    "TXT",
 	}
 	change := &dns.Change{}
@@ -203,6 +300,15 @@ func (c *DNSProvider) Present(ctx context.Context, domain, fqdn, value string) e
 	return nil
 }
 
+// recordTTL returns the TTL to use for challenge records, falling back to the
+// default if none was configured.
+func (c *DNSProvider) recordTTL() int64 {
+	if c.ttl > 0 {
+		return c.ttl
+	}
+	return cloudDNSTTL
+}
+
 // CleanUp removes the TXT record matching the specified parameters.
 func (c *DNSProvider) CleanUp(ctx context.Context, domain, fqdn, value string) error {
 	zone, err := c.getHostedZone(ctx, fqdn)
@@ -246,34 +352,60 @@ func (c *DNSProvider) getHostedZone(ctx context.Context, domain string) (string,
 		return c.hostedZoneName, nil
 	}
 
-	authZone, err := util.FindZoneByFqdn(ctx, util.ToFqdn(domain), c.dns01Nameservers)
-	if err != nil {
-		return "", err
-	}
+	fqdn := util.ToFqdn(domain)
 
-	zones, err := c.client.ManagedZones.
-		List(c.project).
-		DnsName(authZone).
-		Context(ctx).
-		Do()
-	if err != nil {
-		return "", fmt.Errorf("GoogleCloud API call failed: %v", err)
+	// The zone apex found in the public DNS is checked first. If there is no
+	// such managed zone in the project, e.g. for a private zone, every parent
+	// domain of the FQDN is checked, nearest first.
+	var candidates []string
+	if authZone, err := c.findZoneByFqdn(ctx, fqdn, c.dns01Nameservers); err == nil {
+		candidates = append(candidates, authZone)
+	} else {
+		c.log.V(logf.DebugLevel).Info("Unable to find the zone apex in the public DNS, checking the managed zones of the parent domains", "fqdn", fqdn, "error", err.Error())
 	}
-
-	if len(zones.ManagedZones) == 0 {
-		return "", fmt.Errorf("No matching GoogleCloud domain found for domain %s", authZone)
+	for _, candidate := range parentDomains(fqdn) {
+		if !slices.Contains(candidates, candidate) {
+			candidates = append(candidates, candidate)
+		}
 	}
 
-	// attempt to get the first public zone
-	for _, zone := range zones.ManagedZones {
-		if zone.Visibility == "public" {
-			return zone.Name, nil
+	for _, candidate := range candidates {
+		zones, err := c.client.ManagedZones.
+			List(c.project).
+			DnsName(candidate).
+			Context(ctx).
+			Do()
+		if err != nil {
+			return "", fmt.Errorf("GoogleCloud API call failed listing the managed zones of project %q: %v", c.project, err)
+		}
+		if len(zones.ManagedZones) == 0 {
+			continue
+		}
+
+		// attempt to get the first public zone
+		for _, zone := range zones.ManagedZones {
+			if zone.Visibility == "public" {
+				return zone.Name, nil
+			}
 		}
+
+		c.log.V(logf.DebugLevel).Info("No matching public GoogleCloud managed-zone for domain, falling back to a private managed-zone", "authZone", candidate)
+		// fall back to first available zone, if none public
+		return zones.ManagedZones[0].Name, nil
 	}
 
-	c.log.V(logf.DebugLevel).Info("No matching public GoogleCloud managed-zone for domain, falling back to a private managed-zone", "authZone", authZone)
-	// fall back to first available zone, if none public
-	return zones.ManagedZones[0].Name, nil
+	return "", fmt.Errorf("No matching GoogleCloud managed-zone found in project %q for domain %s, checked the DNS names: %s", c.project, fqdn, strings.Join(candidates, ", "))
+}
+
+// parentDomains returns the parent domains of the FQDN which may be the DNS
+// name of a managed zone, nearest first, excluding top-level domains.
+func parentDomains(fqdn string) []string {
+	labels := strings.Split(util.UnFqdn(fqdn), ".")
+	var domains []string
+	for i := 1; i < len(labels)-1; i++ {
+		domains = append(domains, util.ToFqdn(strings.Join(labels[i:], ".")))
+	}
+	return domains
 }
 
 func (c *DNSProvider) findTxtRecords(ctx context.Context, zone, fqdn, value string) ([]*dns.ResourceRecordSet, error) {
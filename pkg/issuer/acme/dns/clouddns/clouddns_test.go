@@ -10,13 +10,21 @@ package clouddns
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/oauth2/google"
 	"google.golang.org/api/dns/v1"
+	"google.golang.org/api/option"
 
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
 )
@@ -41,7 +49,7 @@ func TestNewDNSProviderValid(t *testing.T) {
 		t.Skip("skipping live test (requires credentials)")
 	}
 	t.Setenv("GCE_PROJECT", "")
-	_, err := NewDNSProviderCredentials(context.TODO(), "my-project", util.RecursiveNameservers, "")
+	_, err := NewDNSProviderCredentials(context.TODO(), "my-project", util.RecursiveNameservers, "", 0)
 	assert.NoError(t, err)
 }
 
@@ -65,7 +73,7 @@ func TestLiveGoogleCloudPresent(t *testing.T) {
 		t.Skip("skipping live test")
 	}
 
-	provider, err := NewDNSProviderCredentials(context.TODO(), gcloudProject, util.RecursiveNameservers, "")
+	provider, err := NewDNSProviderCredentials(context.TODO(), gcloudProject, util.RecursiveNameservers, "", 0)
 	assert.NoError(t, err)
 
 	err = provider.Present(context.TODO(), gcloudDomain, "_acme-challenge."+gcloudDomain+".", "123d==")
@@ -77,7 +85,7 @@ func TestLiveGoogleCloudPresentMultiple(t *testing.T) {
 		t.Skip("skipping live test")
 	}
 
-	provider, err := NewDNSProviderCredentials(context.TODO(), gcloudProject, util.RecursiveNameservers, "")
+	provider, err := NewDNSProviderCredentials(context.TODO(), gcloudProject, util.RecursiveNameservers, "", 0)
 	assert.NoError(t, err)
 
 	// Check that we're able to create multiple entries
@@ -94,7 +102,7 @@ func TestLiveGoogleCloudCleanUp(t *testing.T) {
 
 	time.Sleep(time.Second * 1)
 
-	provider, err := NewDNSProviderCredentials(context.TODO(), gcloudProject, util.RecursiveNameservers, "")
+	provider, err := NewDNSProviderCredentials(context.TODO(), gcloudProject, util.RecursiveNameservers, "", 0)
 	assert.NoError(t, err)
 
 	err = provider.CleanUp(context.TODO(), gcloudDomain, "_acme-challenge."+gcloudDomain+".", "123d==")
@@ -106,7 +114,7 @@ func TestDNSProvider_getHostedZone(t *testing.T) {
 		t.Skip("skipping live test")
 	}
 
-	testProvider, err := NewDNSProviderCredentials(context.TODO(), "my-project", util.RecursiveNameservers, "test-zone")
+	testProvider, err := NewDNSProviderCredentials(context.TODO(), "my-project", util.RecursiveNameservers, "test-zone", 0)
 	assert.NoError(t, err)
 
 	type args struct {
@@ -141,3 +149,202 @@ func TestDNSProvider_getHostedZone(t *testing.T) {
 		})
 	}
 }
+
+func TestDNSProvider_PresentTTL(t *testing.T) {
+	tests := map[string]struct {
+		ttl    int64
+		expTTL int64
+	}{
+		"should use the default TTL if none is configured": {
+			ttl:    0,
+			expTTL: 60,
+		},
+		"should use the configured TTL": {
+			ttl:    300,
+			expTTL: 300,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var change dns.Change
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/rrsets"):
+					_, _ = w.Write([]byte(`{"rrsets": []}`))
+				case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/changes"):
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&change))
+					_, _ = w.Write([]byte(`{"id": "1", "status": "done"}`))
+				default:
+					require.FailNow(t, "unexpected request "+r.Method+" "+r.URL.Path)
+				}
+			}))
+			defer ts.Close()
+
+			svc, err := dns.NewService(context.TODO(), option.WithEndpoint(ts.URL), option.WithHTTPClient(ts.Client()))
+			require.NoError(t, err)
+
+			provider := &DNSProvider{
+				project:        "my-project",
+				hostedZoneName: "test-zone",
+				ttl:            test.ttl,
+				client:         svc,
+			}
+
+			err = provider.Present(context.TODO(), "example.com", "_acme-challenge.example.com.", "123d==")
+			require.NoError(t, err)
+			require.Len(t, change.Additions, 1)
+			assert.Equal(t, test.expTTL, change.Additions[0].Ttl)
+		})
+	}
+}
+
+func TestDNSProvider_ImpersonateServiceAccount(t *testing.T) {
+	const serviceAccount = "dns01-solver@dns-project.iam.gserviceaccount.com"
+
+	var generatedTokens int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, ":generateAccessToken"):
+			assert.Contains(t, r.URL.Path, "/projects/-/serviceAccounts/"+serviceAccount)
+			generatedTokens++
+			expiry := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+			_, _ = w.Write([]byte(`{"accessToken": "impersonated-token", "expireTime": "` + expiry + `"}`))
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/rrsets"):
+			assert.Equal(t, "Bearer impersonated-token", r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte(`{"rrsets": []}`))
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/changes"):
+			assert.Equal(t, "Bearer impersonated-token", r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte(`{"id": "1", "status": "done"}`))
+		default:
+			require.FailNow(t, "unexpected request "+r.Method+" "+r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := impersonatedClient(context.TODO(), ts.Client(), serviceAccount, option.WithEndpoint(ts.URL))
+	require.NoError(t, err)
+	svc, err := dns.NewService(context.TODO(), option.WithEndpoint(ts.URL), option.WithHTTPClient(client))
+	require.NoError(t, err)
+
+	provider := &DNSProvider{
+		project:        "dns-project",
+		hostedZoneName: "test-zone",
+		client:         svc,
+	}
+
+	require.NoError(t, provider.Present(context.TODO(), "example.com", "_acme-challenge.example.com.", "123d=="))
+	require.NoError(t, provider.Present(context.TODO(), "example.com", "_acme-challenge.example.com.", "1123d=="))
+	// The access token is reused until it expires.
+	assert.Equal(t, 1, generatedTokens)
+}
+
+func TestDNSProvider_getHostedZoneDiscovery(t *testing.T) {
+	tests := map[string]struct {
+		authZone string
+		zones    map[string]string
+
+		expZone string
+		expErr  string
+	}{
+		"should use the managed zone of the zone apex found in the public DNS": {
+			authZone: "example.com.",
+			zones: map[string]string{
+				"example.com.": `{"managedZones": [{"name": "example-com", "visibility": "public"}]}`,
+			},
+			expZone: "example-com",
+		},
+		"should prefer a public managed zone over a private one": {
+			authZone: "example.com.",
+			zones: map[string]string{
+				"example.com.": `{"managedZones": [{"name": "private-example-com", "visibility": "private"}, {"name": "example-com", "visibility": "public"}]}`,
+			},
+			expZone: "example-com",
+		},
+		"should find the nearest managed zone of a parent domain if the zone apex is not found in the public DNS": {
+			zones: map[string]string{
+				"example.com.": `{"managedZones": [{"name": "example-com", "visibility": "private"}]}`,
+			},
+			expZone: "example-com",
+		},
+		"should find the managed zone of a parent domain if the zone apex has no managed zone in the project": {
+			authZone: "www.example.com.",
+			zones: map[string]string{
+				"example.com.": `{"managedZones": [{"name": "example-com", "visibility": "public"}]}`,
+			},
+			expZone: "example-com",
+		},
+		"should return an error naming the project and the checked DNS names if no managed zone is found": {
+			authZone: "example.com.",
+			expErr:   `No matching GoogleCloud managed-zone found in project "dns-project" for domain _acme-challenge.www.example.com., checked the DNS names: example.com., www.example.com.`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if r.Method != http.MethodGet || !strings.HasSuffix(r.URL.Path, "/projects/dns-project/managedZones") {
+					require.FailNow(t, "unexpected request "+r.Method+" "+r.URL.Path)
+				}
+				zones, ok := test.zones[r.URL.Query().Get("dnsName")]
+				if !ok {
+					zones = `{"managedZones": []}`
+				}
+				_, _ = w.Write([]byte(zones))
+			}))
+			defer ts.Close()
+
+			svc, err := dns.NewService(context.TODO(), option.WithEndpoint(ts.URL), option.WithHTTPClient(ts.Client()))
+			require.NoError(t, err)
+
+			provider := &DNSProvider{
+				project: "dns-project",
+				client:  svc,
+				log:     logr.Discard(),
+				findZoneByFqdn: func(_ context.Context, fqdn string, _ []string) (string, error) {
+					if test.authZone == "" {
+						return "", fmt.Errorf("could not find the start of authority for %s", fqdn)
+					}
+					return test.authZone, nil
+				},
+			}
+
+			zone, err := provider.getHostedZone(context.TODO(), "_acme-challenge.www.example.com.")
+			if test.expErr != "" {
+				assert.EqualError(t, err, test.expErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expZone, zone)
+		})
+	}
+}
+
+func Test_parentDomains(t *testing.T) {
+	tests := map[string]struct {
+		fqdn string
+		exp  []string
+	}{
+		"should return the parent domains nearest first": {
+			fqdn: "_acme-challenge.www.example.com.",
+			exp:  []string{"www.example.com.", "example.com."},
+		},
+		"should accept a domain without a trailing dot": {
+			fqdn: "_acme-challenge.example.com",
+			exp:  []string{"example.com."},
+		},
+		"should not return top-level domains": {
+			fqdn: "example.com.",
+			exp:  nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.exp, parentDomains(test.fqdn))
+		})
+	}
+}
@@ -159,3 +159,68 @@ func (c *DNSProvider) findTxtRecord(ctx context.Context, fqdn string) ([]godo.Do
 
 	return records, err
 }
+
+// ListChallengeRecords returns the DNS01 challenge TXT records in all of the
+// domains of the DigitalOcean account.
+func (c *DNSProvider) ListChallengeRecords(ctx context.Context) ([]util.ChallengeRecord, error) {
+	var domains []godo.Domain
+	opt := &godo.ListOptions{}
+	for {
+		page, resp, err := c.client.Domains.List(ctx, opt)
+		if err != nil {
+			return nil, err
+		}
+		domains = append(domains, page...)
+
+		if opt, err = nextPage(resp, opt); err != nil {
+			return nil, err
+		} else if opt == nil {
+			break
+		}
+	}
+
+	var challengeRecords []util.ChallengeRecord
+	for _, domain := range domains {
+		opt := &godo.ListOptions{}
+		for {
+			records, resp, err := c.client.Domains.RecordsByType(ctx, domain.Name, "TXT", opt)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, record := range records {
+				// The record Name is relative to the domain.
+				if !strings.HasPrefix(record.Name, util.ChallengeRecordLabel) {
+					continue
+				}
+				challengeRecords = append(challengeRecords, util.ChallengeRecord{
+					FQDN:  util.ToFqdn(record.Name + "." + domain.Name),
+					Value: record.Data,
+				})
+			}
+
+			if opt, err = nextPage(resp, opt); err != nil {
+				return nil, err
+			} else if opt == nil {
+				break
+			}
+		}
+	}
+
+	return challengeRecords, nil
+}
+
+// nextPage returns the options to list the page after the one in resp, or nil
+// if resp is the last page.
+func nextPage(resp *godo.Response, opt *godo.ListOptions) (*godo.ListOptions, error) {
+	if resp == nil || resp.Links == nil || resp.Links.IsLastPage() {
+		return nil, nil
+	}
+
+	page, err := resp.Links.CurrentPage()
+	if err != nil {
+		return nil, err
+	}
+
+	return &godo.ListOptions{Page: page + 1, PerPage: opt.PerPage}, nil
+}
@@ -21,11 +21,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	authv1 "k8s.io/api/authentication/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 
 	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
@@ -48,6 +50,12 @@ import (
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 )
 
+const (
+	// defaultInitialDelay is the amount of time to wait after the DNS01
+	// challenge record has propagated if no initialDelay is configured.
+	defaultInitialDelay = 60 * time.Second
+)
+
 // solver is the old solver type interface.
 // All new solvers should be implemented using the new webhook.Solver interface.
 type solver interface {
@@ -55,14 +63,21 @@ type solver interface {
 	CleanUp(ctx context.Context, domain, fqdn, value string) error
 }
 
+// contextSolver is implemented by webhook solvers which give up on a request
+// once the given context is done.
+type contextSolver interface {
+	PresentWithContext(ctx context.Context, ch *whapi.ChallengeRequest) error
+	CleanUpWithContext(ctx context.Context, ch *whapi.ChallengeRequest) error
+}
+
 // dnsProviderConstructors defines how each provider may be constructed.
 // It is useful for mocking out a given provider since an alternate set of
 // constructors may be set.
 type dnsProviderConstructors struct {
-	cloudDNS     func(ctx context.Context, project string, serviceAccount []byte, dns01Nameservers []string, ambient bool, hostedZoneName string) (*clouddns.DNSProvider, error)
+	cloudDNS     func(ctx context.Context, project string, serviceAccount []byte, dns01Nameservers []string, ambient bool, hostedZoneName string, ttl int64, impersonateServiceAccount string) (*clouddns.DNSProvider, error)
 	cloudFlare   func(email, apikey, apiToken string, dns01Nameservers []string, userAgent string) (*cloudflare.DNSProvider, error)
-	route53      func(ctx context.Context, accessKey, secretKey, hostedZoneID, region, role, webIdentityToken string, ambient bool, dns01Nameservers []string, userAgent string) (*route53.DNSProvider, error)
-	azureDNS     func(environment, clientID, clientSecret, subscriptionID, tenantID, resourceGroupName, hostedZoneName string, dns01Nameservers []string, ambient bool, managedIdentity *cmacme.AzureManagedIdentity) (*azuredns.DNSProvider, error)
+	route53      func(ctx context.Context, accessKey, secretKey, hostedZoneID, region, role, webIdentityToken string, ambient, preferPrivateZones bool, userAgent string, ttl int64) (*route53.DNSProvider, error)
+	azureDNS     func(environment, clientID, clientSecret, subscriptionID, tenantID, resourceGroupName, hostedZoneName string, dns01Nameservers []string, ambient bool, managedIdentity *cmacme.AzureManagedIdentity, ttl int64) (*azuredns.DNSProvider, error)
 	acmeDNS      func(host string, accountJson []byte, dns01Nameservers []string) (*acmedns.DNSProvider, error)
 	digitalOcean func(token string, dns01Nameservers []string, userAgent string) (*digitalocean.DNSProvider, error)
 }
@@ -75,6 +90,15 @@ type Solver struct {
 	secretLister            internalinformers.SecretLister
 	dnsProviderConstructors dnsProviderConstructors
 	webhookSolvers          map[string]webhook.Solver
+
+	// presentedRecords registers the records presented with DNS providers
+	// which can be swept. It is nil if the sweeper is disabled.
+	presentedRecords *presentedRecords
+
+	// propagated records when the challenge record of each Challenge was
+	// first observed to have propagated, to wait for the initial delay.
+	propagatedLock sync.Mutex
+	propagated     map[types.UID]time.Time
 }
 
 // Present performs the work to configure DNS to resolve a DNS01 challenge.
@@ -88,6 +112,9 @@ func (s *Solver) Present(ctx context.Context, issuer v1.GenericIssuer, ch *cmacm
 	}
 	if err == nil {
 		log.V(logf.InfoLevel).Info("presenting DNS01 challenge for domain")
+		if slv, ok := webhookSolver.(contextSolver); ok {
+			return slv.PresentWithContext(ctx, req)
+		}
 		return webhookSolver.Present(req)
 	}
 
@@ -103,7 +130,7 @@ func (s *Solver) Present(ctx context.Context, issuer v1.GenericIssuer, ch *cmacm
 
 	log.V(logf.DebugLevel).Info("presenting DNS01 challenge for domain")
 
-	return slv.Present(ctx, ch.Spec.DNSName, fqdn, ch.Spec.Key)
+	return s.presentedRecords.present(ctx, slv, ch.Spec.DNSName, fqdn, ch.Spec.Key)
 }
 
 // Check verifies that the DNS records for the ACME challenge have propagated.
@@ -123,22 +150,72 @@ func (s *Solver) Check(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.
 		return err
 	}
 	if !ok {
+		s.forgetPropagated(ch)
+		if timeout := propagationTimeout(ch); timeout > 0 && s.Clock.Since(ch.CreationTimestamp.Time) > timeout {
+			return fmt.Errorf("%w: DNS record for %q not propagated after %s", util.ErrPropagationTimeout, ch.Spec.DNSName, timeout)
+		}
 		return fmt.Errorf("DNS record for %q not yet propagated", ch.Spec.DNSName)
 	}
 
-	ttl := 60
-	log.V(logf.DebugLevel).Info("waiting DNS record TTL to allow the DNS01 record to propagate for domain", "ttl", ttl, "fqdn", fqdn)
-	time.Sleep(time.Second * time.Duration(ttl))
+	// The initial delay is waited for by re-queuing the Challenge, so that
+	// workers are not blocked.
+	delay := initialDelay(ch)
+	if remaining := delay - s.Clock.Since(s.propagatedAt(ch)); remaining > 0 {
+		log.V(logf.DebugLevel).Info("waiting to allow the DNS01 record to propagate for domain", "delay", delay, "remaining", remaining, "fqdn", fqdn)
+		return &util.InitialDelayError{Delay: delay, RetryAfter: remaining}
+	}
 	log.V(logf.DebugLevel).Info("ACME DNS01 validation record propagated", "fqdn", fqdn)
 
 	return nil
 }
 
+// propagatedAt returns the time at which the challenge record of the
+// Challenge was first observed to have propagated. This is only kept in memory,
+// so the initial delay starts again if the controller is restarted.
+func (s *Solver) propagatedAt(ch *cmacme.Challenge) time.Time {
+	s.propagatedLock.Lock()
+	defer s.propagatedLock.Unlock()
+	if s.propagated == nil {
+		s.propagated = make(map[types.UID]time.Time)
+	}
+	at, ok := s.propagated[ch.UID]
+	if !ok {
+		at = s.Clock.Now()
+		s.propagated[ch.UID] = at
+	}
+	return at
+}
+
+func (s *Solver) forgetPropagated(ch *cmacme.Challenge) {
+	s.propagatedLock.Lock()
+	defer s.propagatedLock.Unlock()
+	delete(s.propagated, ch.UID)
+}
+
+// initialDelay returns the amount of time to wait after the challenge record
+// has been observed before the ACME server is asked to validate it.
+func initialDelay(ch *cmacme.Challenge) time.Duration {
+	if cfg := ch.Spec.Solver.DNS01; cfg != nil && cfg.InitialDelay != nil {
+		return cfg.InitialDelay.Duration
+	}
+	return defaultInitialDelay
+}
+
+// propagationTimeout returns the maximum amount of time to wait for the
+// challenge record to propagate, or zero if no timeout is configured.
+func propagationTimeout(ch *cmacme.Challenge) time.Duration {
+	if cfg := ch.Spec.Solver.DNS01; cfg != nil && cfg.PropagationTimeout != nil {
+		return cfg.PropagationTimeout.Duration
+	}
+	return 0
+}
+
 // CleanUp removes DNS records which are no longer needed after
 // certificate issuance.
 func (s *Solver) CleanUp(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
 	log := logf.WithResource(logf.FromContext(ctx, "CleanUp"), ch).WithValues("domain", ch.Spec.DNSName)
 	ctx = logf.NewContext(ctx, log)
+	s.forgetPropagated(ch)
 
 	webhookSolver, req, err := s.prepareChallengeRequest(ctx, issuer, ch)
 	if err != nil && err != errNotFound {
@@ -146,6 +223,9 @@ func (s *Solver) CleanUp(ctx context.Context, issuer v1.GenericIssuer, ch *cmacm
 	}
 	if err == nil {
 		log.V(logf.DebugLevel).Info("cleaning up DNS01 challenge")
+		if slv, ok := webhookSolver.(contextSolver); ok {
+			return slv.CleanUpWithContext(ctx, req)
+		}
 		return webhookSolver.CleanUp(req)
 	}
 
@@ -159,7 +239,7 @@ func (s *Solver) CleanUp(ctx context.Context, issuer v1.GenericIssuer, ch *cmacm
 		return err
 	}
 
-	return slv.CleanUp(ctx, ch.Spec.DNSName, fqdn, ch.Spec.Key)
+	return s.presentedRecords.cleanUp(ctx, slv, ch.Spec.DNSName, fqdn, ch.Spec.Key)
 }
 
 func followCNAME(strategy cmacme.CNAMEStrategy) bool {
@@ -238,7 +318,7 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1.GenericIssuer
 		}
 
 		// attempt to construct the cloud dns provider
-		impl, err = s.dnsProviderConstructors.cloudDNS(ctx, providerConfig.CloudDNS.Project, keyData, s.DNS01Nameservers, s.CanUseAmbientCredentials(issuer), providerConfig.CloudDNS.HostedZoneName)
+		impl, err = s.dnsProviderConstructors.cloudDNS(ctx, providerConfig.CloudDNS.Project, keyData, s.DNS01Nameservers, s.CanUseAmbientCredentials(issuer), providerConfig.CloudDNS.HostedZoneName, ptr.Deref(providerConfig.CloudDNS.TTL, 0), providerConfig.CloudDNS.ImpersonateServiceAccount)
 		if err != nil {
 			return nil, nil, fmt.Errorf("error instantiating google clouddns challenge solver: %s", err)
 		}
@@ -374,8 +454,9 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1.GenericIssuer
 			providerConfig.Route53.Role,
 			webIdentityToken,
 			canUseAmbientCredentials,
-			s.DNS01Nameservers,
+			providerConfig.Route53.PreferPrivateZones,
 			s.RESTConfig.UserAgent,
+			ptr.Deref(providerConfig.Route53.TTL, 0),
 		)
 		if err != nil {
 			return nil, nil, fmt.Errorf("error instantiating route53 challenge solver: %s", err)
@@ -408,6 +489,7 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1.GenericIssuer
 			s.DNS01Nameservers,
 			canUseAmbientCredentials,
 			providerConfig.AzureDNS.ManagedIdentity,
+			ptr.Deref(providerConfig.AzureDNS.TTL, 0),
 		)
 		if err != nil {
 			return nil, nil, fmt.Errorf("error instantiating azuredns challenge solver: %s", err)
@@ -532,6 +614,11 @@ func NewSolver(ctx *controller.Context) (*Solver, error) {
 		}
 	}
 
+	var records *presentedRecords
+	if ctx.ACMEOptions.DNS01OrphanedRecordSweepInterval > 0 {
+		records = &presentedRecords{client: ctx.Client, namespace: ctx.IssuerOptions.ClusterResourceNamespace}
+	}
+
 	return &Solver{
 		Context:      ctx,
 		secretLister: ctx.KubeSharedInformerFactory.Secrets().Lister(),
@@ -543,7 +630,8 @@ func NewSolver(ctx *controller.Context) (*Solver, error) {
 			acmedns.NewDNSProviderHostBytes,
 			digitalocean.NewDNSProviderCredentials,
 		},
-		webhookSolvers: initialized,
+		webhookSolvers:   initialized,
+		presentedRecords: records,
 	}, nil
 }
 
@@ -18,13 +18,17 @@ package dns
 
 import (
 	"context"
+	"errors"
 	"reflect"
 	"testing"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
+	fakeclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
 
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
@@ -395,7 +399,7 @@ func TestRoute53TrimCreds(t *testing.T) {
 	expectedR53Call := []fakeDNSProviderCall{
 		{
 			name: "route53",
-			args: []interface{}{"test_with_spaces", "AKIENDINNEWLINE", "", "us-west-2", "", "", false, util.RecursiveNameservers},
+			args: []interface{}{"test_with_spaces", "AKIENDINNEWLINE", "", "us-west-2", "", "", false, false, int64(0)},
 		},
 	}
 
@@ -453,7 +457,7 @@ func TestRoute53SecretAccessKey(t *testing.T) {
 	expectedR53Call := []fakeDNSProviderCall{
 		{
 			name: "route53",
-			args: []interface{}{"AWSACCESSKEYID", "AKIENDINNEWLINE", "", "us-west-2", "", "", false, util.RecursiveNameservers},
+			args: []interface{}{"AWSACCESSKEYID", "AKIENDINNEWLINE", "", "us-west-2", "", "", false, false, int64(0)},
 		},
 	}
 
@@ -501,7 +505,7 @@ func TestRoute53AmbientCreds(t *testing.T) {
 			result{
 				expectedCall: &fakeDNSProviderCall{
 					name: "route53",
-					args: []interface{}{"", "", "", "us-west-2", "", "", true, util.RecursiveNameservers},
+					args: []interface{}{"", "", "", "us-west-2", "", "", true, false, int64(0)},
 				},
 			},
 		},
@@ -534,7 +538,7 @@ func TestRoute53AmbientCreds(t *testing.T) {
 			result{
 				expectedCall: &fakeDNSProviderCall{
 					name: "route53",
-					args: []interface{}{"", "", "", "us-west-2", "", "", false, util.RecursiveNameservers},
+					args: []interface{}{"", "", "", "us-west-2", "", "", false, false, int64(0)},
 				},
 			},
 		},
@@ -598,7 +602,7 @@ func TestRoute53AssumeRole(t *testing.T) {
 			result{
 				expectedCall: &fakeDNSProviderCall{
 					name: "route53",
-					args: []interface{}{"", "", "", "us-west-2", "my-role", "", true, util.RecursiveNameservers},
+					args: []interface{}{"", "", "", "us-west-2", "my-role", "", true, false, int64(0)},
 				},
 			},
 		},
@@ -632,7 +636,7 @@ func TestRoute53AssumeRole(t *testing.T) {
 			result{
 				expectedCall: &fakeDNSProviderCall{
 					name: "route53",
-					args: []interface{}{"", "", "", "us-west-2", "my-other-role", "", false, util.RecursiveNameservers},
+					args: []interface{}{"", "", "", "us-west-2", "my-other-role", "", false, false, int64(0)},
 				},
 			},
 		},
@@ -655,3 +659,199 @@ func TestRoute53AssumeRole(t *testing.T) {
 		}
 	}
 }
+
+func TestSolverForTTL(t *testing.T) {
+	ttl := ptr.To(int64(300))
+	tests := map[string]struct {
+		dns01        *cmacme.ACMEChallengeSolverDNS01
+		expectedCall fakeDNSProviderCall
+	}{
+		"passes the configured TTL to the clouddns provider": {
+			dns01: &cmacme.ACMEChallengeSolverDNS01{
+				CloudDNS: &cmacme.ACMEIssuerDNS01ProviderCloudDNS{
+					Project: "test-project",
+					TTL:     ttl,
+				},
+			},
+			expectedCall: fakeDNSProviderCall{
+				name: "clouddns",
+				args: []interface{}{"test-project", []byte(nil), util.RecursiveNameservers, true, "", int64(300), ""},
+			},
+		},
+		"passes the configured TTL to the route53 provider": {
+			dns01: &cmacme.ACMEChallengeSolverDNS01{
+				Route53: &cmacme.ACMEIssuerDNS01ProviderRoute53{
+					Region: "us-west-2",
+					TTL:    ttl,
+				},
+			},
+			expectedCall: fakeDNSProviderCall{
+				name: "route53",
+				args: []interface{}{"", "", "", "us-west-2", "", "", true, false, int64(300)},
+			},
+		},
+		"passes the configured TTL to the azuredns provider": {
+			dns01: &cmacme.ACMEChallengeSolverDNS01{
+				AzureDNS: &cmacme.ACMEIssuerDNS01ProviderAzureDNS{
+					SubscriptionID:    "test-subscription",
+					ResourceGroupName: "test-group",
+					TTL:               ttl,
+				},
+			},
+			expectedCall: fakeDNSProviderCall{
+				name: "azuredns",
+				args: []interface{}{"", "", "test-subscription", "", "test-group", "", util.RecursiveNameservers, true, (*cmacme.AzureManagedIdentity)(nil), int64(300)},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			f := &solverFixture{
+				Builder: &test.Builder{
+					Context: &controller.Context{
+						RESTConfig: new(rest.Config),
+						ContextOptions: controller.ContextOptions{
+							IssuerOptions: controller.IssuerOptions{
+								IssuerAmbientCredentials: true,
+							},
+						},
+					},
+				},
+				Issuer:       newIssuer(),
+				dnsProviders: newFakeDNSProviders(),
+				Challenge: &cmacme.Challenge{
+					Spec: cmacme.ChallengeSpec{
+						Solver: cmacme.ACMEChallengeSolver{
+							DNS01: tc.dns01,
+						},
+					},
+				},
+			}
+			f.Setup(t)
+			defer f.Finish(t)
+
+			_, _, err := f.Solver.solverForChallenge(context.Background(), f.Issuer, f.Challenge)
+			if err != nil {
+				t.Fatalf("expected solverFor to not error, but got: %s", err)
+			}
+
+			if !reflect.DeepEqual([]fakeDNSProviderCall{tc.expectedCall}, f.dnsProviders.calls) {
+				t.Fatalf("expected %+v == %+v", []fakeDNSProviderCall{tc.expectedCall}, f.dnsProviders.calls)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	fixedClockStart := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		dns01      *cmacme.ACMEChallengeSolverDNS01
+		created    time.Time
+		propagated bool
+
+		expectedErr        bool
+		expectedTimeoutErr bool
+		expectedDelay      time.Duration
+	}{
+		"waits the default delay once the record has propagated": {
+			dns01:         &cmacme.ACMEChallengeSolverDNS01{},
+			created:       fixedClockStart,
+			propagated:    true,
+			expectedDelay: 60 * time.Second,
+		},
+		"waits the configured initial delay once the record has propagated": {
+			dns01: &cmacme.ACMEChallengeSolverDNS01{
+				InitialDelay: &metav1.Duration{Duration: 5 * time.Minute},
+			},
+			created:       fixedClockStart,
+			propagated:    true,
+			expectedDelay: 5 * time.Minute,
+		},
+		"does not wait if the configured initial delay is zero": {
+			dns01: &cmacme.ACMEChallengeSolverDNS01{
+				InitialDelay: &metav1.Duration{},
+			},
+			created:    fixedClockStart,
+			propagated: true,
+		},
+		"returns a retryable error if the record has not propagated and no timeout is configured": {
+			dns01:       &cmacme.ACMEChallengeSolverDNS01{},
+			created:     fixedClockStart.Add(-24 * time.Hour),
+			expectedErr: true,
+		},
+		"returns a retryable error if the record has not propagated within the timeout": {
+			dns01: &cmacme.ACMEChallengeSolverDNS01{
+				PropagationTimeout: &metav1.Duration{Duration: 10 * time.Minute},
+			},
+			created:     fixedClockStart.Add(-5 * time.Minute),
+			expectedErr: true,
+		},
+		"returns a timeout error if the record has not propagated after the timeout": {
+			dns01: &cmacme.ACMEChallengeSolverDNS01{
+				PropagationTimeout: &metav1.Duration{Duration: 10 * time.Minute},
+			},
+			created:            fixedClockStart.Add(-11 * time.Minute),
+			expectedErr:        true,
+			expectedTimeoutErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			origPreCheckDNS := util.PreCheckDNS
+			defer func() { util.PreCheckDNS = origPreCheckDNS }()
+			util.PreCheckDNS = func(ctx context.Context, fqdn, value string, nameservers []string, useAuthoritative bool) (bool, error) {
+				return tc.propagated, nil
+			}
+
+			fakeClock := fakeclock.NewFakeClock(fixedClockStart)
+			f := &solverFixture{
+				Builder: &test.Builder{
+					Clock: fakeClock,
+				},
+				Issuer: newIssuer(),
+				Challenge: &cmacme.Challenge{
+					ObjectMeta: metav1.ObjectMeta{
+						CreationTimestamp: metav1.NewTime(tc.created),
+					},
+					Spec: cmacme.ChallengeSpec{
+						DNSName: "example.com",
+						Key:     "key",
+						Solver: cmacme.ACMEChallengeSolver{
+							DNS01: tc.dns01,
+						},
+					},
+				},
+			}
+			f.Setup(t)
+			defer f.Finish(t)
+
+			err := f.Solver.Check(context.Background(), f.Issuer, f.Challenge)
+
+			// The initial delay is returned as an error, rather than
+			// blocking, until it has passed.
+			if tc.expectedDelay > 0 {
+				var delayErr *util.InitialDelayError
+				if !errors.As(err, &delayErr) || delayErr.RetryAfter != tc.expectedDelay {
+					t.Fatalf("expected an initial delay error with a retry after %s, got: %v", tc.expectedDelay, err)
+				}
+				fakeClock.Step(tc.expectedDelay / 2)
+				err = f.Solver.Check(context.Background(), f.Issuer, f.Challenge)
+				if !errors.As(err, &delayErr) || delayErr.RetryAfter != tc.expectedDelay/2 {
+					t.Fatalf("expected an initial delay error with a retry after %s, got: %v", tc.expectedDelay/2, err)
+				}
+				fakeClock.Step(tc.expectedDelay / 2)
+				err = f.Solver.Check(context.Background(), f.Issuer, f.Challenge)
+			}
+
+			if tc.expectedErr != (err != nil) {
+				t.Fatalf("expected error=%t, got: %v", tc.expectedErr, err)
+			}
+			if tc.expectedTimeoutErr != errors.Is(err, util.ErrPropagationTimeout) {
+				t.Errorf("expected propagation timeout error=%t, got: %v", tc.expectedTimeoutErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+// presentedRecordsConfigMapName is the name of the ConfigMap in which the
+// DNS01 challenge records presented by cert-manager are registered.
+const presentedRecordsConfigMapName = "cert-manager-dns01-presented-records"
+
+// presentedRecords is a registry of the DNS01 challenge records presented by
+// cert-manager which have not been cleaned up yet. It is stored in a ConfigMap
+// in the cluster resource namespace, which maps the value of each record to
+// its name, so that it outlives the Challenges and the controller.
+// The Sweeper only ever deletes records found in the registry, so that the
+// challenge records of other ACME clients using the same zones are never
+// deleted.
+type presentedRecords struct {
+	client    kubernetes.Interface
+	namespace string
+}
+
+// present registers the record, and then presents it with the DNS provider.
+// The record is registered first so that it is known to the Sweeper even if
+// the controller is restarted before it has been cleaned up.
+// Records are only registered if the provider can list its records, and
+// their value is a valid ConfigMap key, as other records cannot be swept.
+func (r *presentedRecords) present(ctx context.Context, slv solver, domain, fqdn, value string) error {
+	if r != nil && r.tracks(slv, value) {
+		if err := r.add(ctx, util.ChallengeRecord{FQDN: fqdn, Value: value}); err != nil {
+			return fmt.Errorf("error registering DNS01 challenge record %q: %w", fqdn, err)
+		}
+	}
+
+	return slv.Present(ctx, domain, fqdn, value)
+}
+
+// cleanUp deletes the record with the DNS provider, and then removes it from
+// the registry.
+func (r *presentedRecords) cleanUp(ctx context.Context, slv solver, domain, fqdn, value string) error {
+	if err := slv.CleanUp(ctx, domain, fqdn, value); err != nil {
+		return err
+	}
+
+	if r != nil && r.tracks(slv, value) {
+		if err := r.remove(ctx, util.ChallengeRecord{FQDN: fqdn, Value: value}); err != nil {
+			// The record was deleted, so it will be removed from the
+			// registry by the next sweep.
+			logf.FromContext(ctx).Error(err, "error removing DNS01 challenge record from the registry", "fqdn", fqdn)
+		}
+	}
+
+	return nil
+}
+
+func (r *presentedRecords) tracks(slv solver, value string) bool {
+	if _, ok := slv.(recordLister); !ok {
+		return false
+	}
+	return len(validation.IsConfigMapKey(value)) == 0
+}
+
+// list returns the registered records, with their names normalized by
+// recordKey.
+func (r *presentedRecords) list(ctx context.Context) (sets.Set[util.ChallengeRecord], error) {
+	records := sets.New[util.ChallengeRecord]()
+
+	cm, err := r.client.CoreV1().ConfigMaps(r.namespace).Get(ctx, presentedRecordsConfigMapName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return records, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for value, fqdn := range cm.Data {
+		records.Insert(recordKey(util.ChallengeRecord{FQDN: fqdn, Value: value}))
+	}
+	return records, nil
+}
+
+func (r *presentedRecords) add(ctx context.Context, record util.ChallengeRecord) error {
+	record = recordKey(record)
+	return r.patch(ctx, map[string]*string{record.Value: &record.FQDN})
+}
+
+func (r *presentedRecords) remove(ctx context.Context, records ...util.ChallengeRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	data := make(map[string]*string, len(records))
+	for _, record := range records {
+		data[record.Value] = nil
+	}
+
+	err := r.patch(ctx, data)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// patch updates the given values of the registry with a merge patch, so that
+// concurrent updates of different records never conflict. A nil name removes
+// the value from the registry. The ConfigMap is created if it does not exist.
+func (r *presentedRecords) patch(ctx context.Context, data map[string]*string) error {
+	patch, err := json.Marshal(map[string]interface{}{"data": data})
+	if err != nil {
+		return err
+	}
+
+	configMaps := r.client.CoreV1().ConfigMaps(r.namespace)
+	_, err = configMaps.Patch(ctx, presentedRecordsConfigMapName, types.MergePatchType, patch, metav1.PatchOptions{})
+	if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: presentedRecordsConfigMapName, Namespace: r.namespace},
+		Data:       make(map[string]string),
+	}
+	for value, fqdn := range data {
+		if fqdn != nil {
+			cm.Data[value] = *fqdn
+		}
+	}
+	if len(cm.Data) == 0 {
+		return err
+	}
+
+	_, err = configMaps.Create(ctx, cm, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		// The ConfigMap was created concurrently.
+		_, err = configMaps.Patch(ctx, presentedRecordsConfigMapName, types.MergePatchType, patch, metav1.PatchOptions{})
+	}
+	return err
+}
+
+// recordKey returns the record with its name in lower case and fully
+// qualified, so that records can be compared with the records listed by the
+// DNS providers.
+func recordKey(record util.ChallengeRecord) util.ChallengeRecord {
+	return util.ChallengeRecord{
+		FQDN:  util.ToFqdn(strings.ToLower(record.FQDN)),
+		Value: record.Value,
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package rfc2136
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -89,12 +90,18 @@ func (s *Solver) Name() string {
 }
 
 func (s *Solver) Present(ch *whapi.ChallengeRequest) error {
+	return s.PresentWithContext(context.TODO(), ch)
+}
+
+// PresentWithContext creates a TXT record using the specified parameters,
+// giving up on the DNS update once ctx is done.
+func (s *Solver) PresentWithContext(ctx context.Context, ch *whapi.ChallengeRequest) error {
 	p, err := s.buildDNSProvider(ch)
 	if err != nil {
 		return err
 	}
 
-	err = p.Present(ch.DNSName, ch.ResolvedFQDN, ch.ResolvedZone, ch.Key)
+	err = p.Present(ctx, ch.DNSName, ch.ResolvedFQDN, ch.ResolvedZone, ch.Key)
 	if err != nil {
 		return err
 	}
@@ -103,12 +110,18 @@ func (s *Solver) Present(ch *whapi.ChallengeRequest) error {
 }
 
 func (s *Solver) CleanUp(ch *whapi.ChallengeRequest) error {
+	return s.CleanUpWithContext(context.TODO(), ch)
+}
+
+// CleanUpWithContext removes the TXT record matching the specified
+// parameters, giving up on the DNS update once ctx is done.
+func (s *Solver) CleanUpWithContext(ctx context.Context, ch *whapi.ChallengeRequest) error {
 	p, err := s.buildDNSProvider(ch)
 	if err != nil {
 		return err
 	}
 
-	err = p.CleanUp(ch.DNSName, ch.ResolvedFQDN, ch.ResolvedZone, ch.Key)
+	err = p.CleanUp(ctx, ch.DNSName, ch.ResolvedFQDN, ch.ResolvedZone, ch.Key)
 	if err != nil {
 		return err
 	}
@@ -22,6 +22,7 @@ limitations under the License.
 package rfc2136
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -96,16 +97,16 @@ func NewDNSProviderCredentials(nameserver, tsigAlgorithm, tsigKeyName, tsigSecre
 }
 
 // Present creates a TXT record using the specified parameters
-func (r *DNSProvider) Present(_, fqdn, zone, value string) error {
-	return r.changeRecord("INSERT", fqdn, zone, value, 60)
+func (r *DNSProvider) Present(ctx context.Context, _, fqdn, zone, value string) error {
+	return r.changeRecord(ctx, "INSERT", fqdn, zone, value, 60)
 }
 
 // CleanUp removes the TXT record matching the specified parameters
-func (r *DNSProvider) CleanUp(_, fqdn, zone, value string) error {
-	return r.changeRecord("REMOVE", fqdn, zone, value, 60)
+func (r *DNSProvider) CleanUp(ctx context.Context, _, fqdn, zone, value string) error {
+	return r.changeRecord(ctx, "REMOVE", fqdn, zone, value, 60)
 }
 
-func (r *DNSProvider) changeRecord(action, fqdn, zone, value string, ttl int) error {
+func (r *DNSProvider) changeRecord(ctx context.Context, action, fqdn, zone, value string, ttl int) error {
 	// Create RR
 	rr := new(dns.TXT)
 	rr.Hdr = dns.RR_Header{Name: fqdn, Rrtype: dns.TypeTXT, Class: dns.ClassINET, Ttl: uint32(ttl)}
@@ -134,7 +135,7 @@ func (r *DNSProvider) changeRecord(action, fqdn, zone, value string, ttl int) er
 	}
 
 	// Send the query
-	reply, _, err := c.Exchange(m, r.nameserver)
+	reply, _, err := c.ExchangeContext(ctx, m, r.nameserver)
 	if err != nil {
 		return fmt.Errorf("DNS update failed: %v", err)
 	}
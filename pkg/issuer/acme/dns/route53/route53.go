@@ -14,7 +14,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -38,10 +37,17 @@ const (
 
 // DNSProvider implements the util.ChallengeProvider interface
 type DNSProvider struct {
-	dns01Nameservers []string
-	client           *route53.Client
-	hostedZoneID     string
-	log              logr.Logger
+	client             *route53.Client
+	hostedZoneID       string
+	preferPrivateZones bool
+	ttl                int64
+	log                logr.Logger
+
+	// zoneCache caches the discovered hosted zones of challenge records.
+	// Entries are scoped to the credentials used by the provider by
+	// zoneCacheScope, as the same domain may be hosted in several accounts.
+	zoneCache      *hostedZoneCache
+	zoneCacheScope string
 
 	userAgent string
 }
@@ -171,12 +177,16 @@ func defaultSTSProvider(cfg aws.Config) StsClient {
 // NewDNSProvider returns a DNSProvider instance configured for the AWS
 // Route 53 service using static credentials from its parameters or, if they're
 // unset and the 'ambient' option is set, credentials from the environment.
+// If ttl is zero, the default TTL of 10 seconds is used for challenge records.
+// If hostedZoneID is empty, the hosted zone of each challenge record is
+// discovered among the public hosted zones, or also among the private hosted
+// zones, which are then preferred, if preferPrivateZones is set.
 func NewDNSProvider(
 	ctx context.Context,
 	accessKeyID, secretAccessKey, hostedZoneID, region, role, webIdentityToken string,
-	ambient bool,
-	dns01Nameservers []string,
+	ambient, preferPrivateZones bool,
 	userAgent string,
+	ttl int64,
 ) (*DNSProvider, error) {
 	provider := newSessionProvider(accessKeyID, secretAccessKey, region, role, webIdentityToken, ambient, userAgent)
 
@@ -188,27 +198,39 @@ func NewDNSProvider(
 	client := route53.NewFromConfig(cfg)
 
 	return &DNSProvider{
-		client:           client,
-		hostedZoneID:     hostedZoneID,
-		dns01Nameservers: dns01Nameservers,
-		log:              logf.Log.WithName("route53"),
-		userAgent:        userAgent,
+		client:             client,
+		hostedZoneID:       hostedZoneID,
+		preferPrivateZones: preferPrivateZones,
+		ttl:                ttl,
+		log:                logf.Log.WithName("route53"),
+		zoneCache:          defaultHostedZoneCache,
+		zoneCacheScope:     accessKeyID + "/" + role,
+		userAgent:          userAgent,
 	}, nil
 }
 
 // Present creates a TXT record using the specified parameters
 func (r *DNSProvider) Present(ctx context.Context, domain, fqdn, value string) error {
 	value = `"` + value + `"`
-	return r.changeRecord(ctx, route53types.ChangeActionUpsert, fqdn, value, route53TTL)
+	return r.changeRecord(ctx, route53types.ChangeActionUpsert, fqdn, value, r.recordTTL())
 }
 
 // CleanUp removes the TXT record matching the specified parameters
 func (r *DNSProvider) CleanUp(ctx context.Context, domain, fqdn, value string) error {
 	value = `"` + value + `"`
-	return r.changeRecord(ctx, route53types.ChangeActionDelete, fqdn, value, route53TTL)
+	return r.changeRecord(ctx, route53types.ChangeActionDelete, fqdn, value, r.recordTTL())
 }
 
-func (r *DNSProvider) changeRecord(ctx context.Context, action route53types.ChangeAction, fqdn, value string, ttl int) error {
+// recordTTL returns the TTL to use for challenge records, falling back to the
+// default if none was configured.
+func (r *DNSProvider) recordTTL() int64 {
+	if r.ttl > 0 {
+		return r.ttl
+	}
+	return route53TTL
+}
+
+func (r *DNSProvider) changeRecord(ctx context.Context, action route53types.ChangeAction, fqdn, value string, ttl int64) error {
 	hostedZoneID, err := r.getHostedZoneID(ctx, fqdn)
 	if err != nil {
 		return fmt.Errorf("failed to determine Route 53 hosted zone ID: %v", err)
@@ -236,6 +258,13 @@ func (r *DNSProvider) changeRecord(ctx context.Context, action route53types.Chan
 			// means it's already deleted, no need to consider it an error.
 			return nil
 		}
+		// The discovered hosted zone may have been deleted, or the record
+		// may no longer belong to it, so discover it again on the next try.
+		var noSuchHostedZone *route53types.NoSuchHostedZone
+		var invalidChangeBatch *route53types.InvalidChangeBatch
+		if errors.As(err, &noSuchHostedZone) || errors.As(err, &invalidChangeBatch) {
+			r.forgetHostedZoneID(fqdn)
+		}
 		return fmt.Errorf("failed to change Route 53 record set: %v", removeReqID(err))
 
 	}
@@ -257,55 +286,11 @@ func (r *DNSProvider) changeRecord(ctx context.Context, action route53types.Chan
 	})
 }
 
-func (r *DNSProvider) getHostedZoneID(ctx context.Context, fqdn string) (string, error) {
-	if r.hostedZoneID != "" {
-		return r.hostedZoneID, nil
-	}
-
-	authZone, err := util.FindZoneByFqdn(ctx, fqdn, r.dns01Nameservers)
-	if err != nil {
-		return "", fmt.Errorf("error finding zone from fqdn: %v", err)
-	}
-
-	// .DNSName should not have a trailing dot
-	reqParams := &route53.ListHostedZonesByNameInput{
-		DNSName: aws.String(util.UnFqdn(authZone)),
-	}
-	resp, err := r.client.ListHostedZonesByName(ctx, reqParams)
-	if err != nil {
-		return "", removeReqID(err)
-	}
-
-	zoneToID := make(map[string]string)
-	var hostedZones []string
-	for _, hostedZone := range resp.HostedZones {
-		// .Name has a trailing dot
-		if !hostedZone.Config.PrivateZone {
-			zoneToID[*hostedZone.Name] = *hostedZone.Id
-			hostedZones = append(hostedZones, *hostedZone.Name)
-		}
-	}
-	authZone, err = util.FindBestMatch(fqdn, hostedZones...)
-	if err != nil {
-		return "", fmt.Errorf("zone %s not found in Route 53 for domain %s", authZone, fqdn)
-	}
-
-	hostedZoneID, ok := zoneToID[authZone]
-
-	if len(hostedZoneID) == 0 || !ok {
-		return "", fmt.Errorf("zone %s not found in Route 53 for domain %s", authZone, fqdn)
-	}
-
-	hostedZoneID = strings.TrimPrefix(hostedZoneID, "/hostedzone/")
-
-	return hostedZoneID, nil
-}
-
-func newTXTRecordSet(fqdn, value string, ttl int) *route53types.ResourceRecordSet {
+func newTXTRecordSet(fqdn, value string, ttl int64) *route53types.ResourceRecordSet {
 	return &route53types.ResourceRecordSet{
 		Name:             aws.String(fqdn),
 		Type:             route53types.RRTypeTxt,
-		TTL:              aws.Int64(int64(ttl)),
+		TTL:              aws.Int64(ttl),
 		MultiValueAnswer: aws.Bool(true),
 		SetIdentifier:    aws.String(value),
 		ResourceRecords: []route53types.ResourceRecord{
@@ -12,6 +12,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -28,7 +29,6 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
-	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 )
 
@@ -52,7 +52,7 @@ func makeRoute53Provider(ts *httptest.Server) (*DNSProvider, error) {
 	}
 
 	client := route53.NewFromConfig(cfg)
-	return &DNSProvider{client: client, dns01Nameservers: util.RecursiveNameservers}, nil
+	return &DNSProvider{client: client}, nil
 }
 
 func TestAmbientCredentialsFromEnv(t *testing.T) {
@@ -60,7 +60,7 @@ func TestAmbientCredentialsFromEnv(t *testing.T) {
 	t.Setenv("AWS_SECRET_ACCESS_KEY", "123")
 	t.Setenv("AWS_REGION", "us-east-1")
 
-	provider, err := NewDNSProvider(context.TODO(), "", "", "", "", "", "", true, util.RecursiveNameservers, "cert-manager-test")
+	provider, err := NewDNSProvider(context.TODO(), "", "", "", "", "", "", true, false, "cert-manager-test", 0)
 	assert.NoError(t, err, "Expected no error constructing DNSProvider")
 
 	_, err = provider.client.Options().Credentials.Retrieve(context.TODO())
@@ -74,14 +74,14 @@ func TestNoCredentialsFromEnv(t *testing.T) {
 	t.Setenv("AWS_SECRET_ACCESS_KEY", "123")
 	t.Setenv("AWS_REGION", "us-east-1")
 
-	_, err := NewDNSProvider(context.TODO(), "", "", "", "", "", "", false, util.RecursiveNameservers, "cert-manager-test")
+	_, err := NewDNSProvider(context.TODO(), "", "", "", "", "", "", false, false, "cert-manager-test", 0)
 	assert.Error(t, err, "Expected error constructing DNSProvider with no credentials and not ambient")
 }
 
 func TestAmbientRegionFromEnv(t *testing.T) {
 	t.Setenv("AWS_REGION", "us-east-1")
 
-	provider, err := NewDNSProvider(context.TODO(), "", "", "", "", "", "", true, util.RecursiveNameservers, "cert-manager-test")
+	provider, err := NewDNSProvider(context.TODO(), "", "", "", "", "", "", true, false, "cert-manager-test", 0)
 	assert.NoError(t, err, "Expected no error constructing DNSProvider")
 
 	assert.Equal(t, "us-east-1", provider.client.Options().Region, "Expected Region to be set from environment")
@@ -90,7 +90,7 @@ func TestAmbientRegionFromEnv(t *testing.T) {
 func TestNoRegionFromEnv(t *testing.T) {
 	t.Setenv("AWS_REGION", "us-east-1")
 
-	provider, err := NewDNSProvider(context.TODO(), "marx", "swordfish", "", "", "", "", false, util.RecursiveNameservers, "cert-manager-test")
+	provider, err := NewDNSProvider(context.TODO(), "marx", "swordfish", "", "", "", "", false, false, "cert-manager-test", 0)
 	assert.NoError(t, err, "Expected no error constructing DNSProvider")
 
 	assert.Equal(t, "", provider.client.Options().Region, "Expected Region to not be set from environment")
@@ -137,6 +137,54 @@ func TestRoute53Present(t *testing.T) {
 	assert.Equal(t, `failed to change Route 53 record set: operation error Route 53: ChangeResourceRecordSets, https response error StatusCode: 403, RequestID: <REDACTED>, api error AccessDenied: User: arn:aws:iam::0123456789:user/test-cert-manager is not authorized to perform: route53:ChangeResourceRecordSets on resource: arn:aws:route53:::hostedzone/OPQRSTU`, err.Error())
 }
 
+func TestRoute53PresentTTL(t *testing.T) {
+	tests := map[string]struct {
+		ttl    int64
+		expTTL string
+	}{
+		"should use the default TTL if none is configured": {
+			ttl:    0,
+			expTTL: "<TTL>10</TTL>",
+		},
+		"should use the configured TTL": {
+			ttl:    300,
+			expTTL: "<TTL>300</TTL>",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var changeBody string
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var resp string
+				switch r.URL.Path {
+				case "/2013-04-01/hostedzone/ABCDEFG/rrset":
+					body, err := io.ReadAll(r.Body)
+					require.NoError(t, err)
+					changeBody = string(body)
+					resp = ChangeResourceRecordSetsResponse
+				case "/2013-04-01/change/123456":
+					resp = GetChangeResponse
+				default:
+					require.FailNow(t, "unexpected request path "+r.URL.Path)
+				}
+				w.Header().Set("Content-Type", "application/xml")
+				_, _ = w.Write([]byte(resp))
+			}))
+			defer ts.Close()
+
+			provider, err := makeRoute53Provider(ts)
+			require.NoError(t, err)
+			provider.hostedZoneID = "ABCDEFG"
+			provider.ttl = test.ttl
+
+			err = provider.Present(context.TODO(), "example.com", "_acme-challenge.example.com.", "123456d==")
+			require.NoError(t, err)
+			assert.Contains(t, changeBody, test.expTTL)
+		})
+	}
+}
+
 func TestAssumeRole(t *testing.T) {
 	creds := &ststypes.Credentials{
 		AccessKeyId:     aws.String("foo"),
@@ -0,0 +1,216 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package route53
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"k8s.io/utils/clock"
+
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+// hostedZoneCacheTTL is how long discovered hosted zones are cached for, so
+// that hosted zones created in the meantime are eventually picked up.
+const hostedZoneCacheTTL = time.Hour
+
+// defaultHostedZoneCache is shared by all the providers, as a new provider is
+// created for each change of a challenge record.
+var defaultHostedZoneCache = newHostedZoneCache(clock.RealClock{})
+
+// hostedZoneCache caches the IDs of the hosted zones discovered for challenge
+// records. A nil cache caches nothing.
+type hostedZoneCache struct {
+	clock clock.Clock
+
+	lock    sync.Mutex
+	entries map[string]hostedZoneCacheEntry
+}
+
+type hostedZoneCacheEntry struct {
+	hostedZoneID string
+	expires      time.Time
+}
+
+func newHostedZoneCache(clock clock.Clock) *hostedZoneCache {
+	return &hostedZoneCache{
+		clock:   clock,
+		entries: make(map[string]hostedZoneCacheEntry),
+	}
+}
+
+func (c *hostedZoneCache) get(key string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || !c.clock.Now().Before(entry.expires) {
+		delete(c.entries, key)
+		return "", false
+	}
+	return entry.hostedZoneID, true
+}
+
+func (c *hostedZoneCache) set(key, hostedZoneID string) {
+	if c == nil {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.entries[key] = hostedZoneCacheEntry{
+		hostedZoneID: hostedZoneID,
+		expires:      c.clock.Now().Add(hostedZoneCacheTTL),
+	}
+}
+
+func (c *hostedZoneCache) forget(key string) {
+	if c == nil {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	delete(c.entries, key)
+}
+
+// getHostedZoneID returns the ID of the hosted zone in which the record with
+// the given fqdn is changed: the configured hosted zone if set, or else the
+// most specific hosted zone whose name is a suffix of the fqdn.
+func (r *DNSProvider) getHostedZoneID(ctx context.Context, fqdn string) (string, error) {
+	if r.hostedZoneID != "" {
+		return r.hostedZoneID, nil
+	}
+
+	key := r.hostedZoneCacheKey(fqdn)
+	if hostedZoneID, ok := r.zoneCache.get(key); ok {
+		return hostedZoneID, nil
+	}
+
+	hostedZoneID, err := r.findHostedZoneID(ctx, fqdn)
+	if err != nil {
+		return "", err
+	}
+
+	r.zoneCache.set(key, hostedZoneID)
+	return hostedZoneID, nil
+}
+
+// forgetHostedZoneID removes the discovered hosted zone of the record with
+// the given fqdn from the cache.
+func (r *DNSProvider) forgetHostedZoneID(fqdn string) {
+	if r.hostedZoneID != "" {
+		return
+	}
+	r.zoneCache.forget(r.hostedZoneCacheKey(fqdn))
+}
+
+func (r *DNSProvider) hostedZoneCacheKey(fqdn string) string {
+	return strings.Join([]string{r.zoneCacheScope, strconv.FormatBool(r.preferPrivateZones), strings.ToLower(util.ToFqdn(fqdn))}, "/")
+}
+
+// findHostedZoneID looks up the hosted zones named after each suffix of the
+// fqdn, from the most to the least specific, and returns the ID of the first
+// one found. Private hosted zones are only considered if preferPrivateZones
+// is set, in which case they are chosen over public hosted zones with the
+// same name. Hosted zones of the same type are ordered by ID, so that the
+// same hosted zone is always chosen.
+func (r *DNSProvider) findHostedZoneID(ctx context.Context, fqdn string) (string, error) {
+	labels := strings.Split(strings.ToLower(util.UnFqdn(fqdn)), ".")
+	for i := range labels {
+		name := strings.Join(labels[i:], ".")
+
+		listed, err := r.listHostedZonesNamed(ctx, name)
+		if err != nil {
+			return "", err
+		}
+		hostedZones := listed[:0]
+		for _, hostedZone := range listed {
+			if hostedZone.Config.PrivateZone && !r.preferPrivateZones {
+				continue
+			}
+			hostedZones = append(hostedZones, hostedZone)
+		}
+		if len(hostedZones) == 0 {
+			continue
+		}
+
+		sort.SliceStable(hostedZones, func(i, j int) bool {
+			iPreferred := hostedZones[i].Config.PrivateZone == r.preferPrivateZones
+			jPreferred := hostedZones[j].Config.PrivateZone == r.preferPrivateZones
+			if iPreferred != jPreferred {
+				return iPreferred
+			}
+			return aws.ToString(hostedZones[i].Id) < aws.ToString(hostedZones[j].Id)
+		})
+
+		hostedZone := hostedZones[0]
+		r.log.V(logf.DebugLevel).Info("discovered hosted zone", "fqdn", fqdn, "zone", name, "id", aws.ToString(hostedZone.Id), "private", hostedZone.Config.PrivateZone)
+		return strings.TrimPrefix(aws.ToString(hostedZone.Id), "/hostedzone/"), nil
+	}
+
+	return "", fmt.Errorf("no hosted zone found in Route 53 for domain %s", fqdn)
+}
+
+// listHostedZonesNamed returns the hosted zones with the given name. As
+// hosted zones are listed in order of their name, only the pages which may
+// contain hosted zones with that name are listed.
+func (r *DNSProvider) listHostedZonesNamed(ctx context.Context, name string) ([]route53types.HostedZone, error) {
+	var hostedZones []route53types.HostedZone
+
+	// .DNSName should not have a trailing dot
+	reqParams := &route53.ListHostedZonesByNameInput{
+		DNSName: aws.String(name),
+	}
+	for {
+		resp, err := r.client.ListHostedZonesByName(ctx, reqParams)
+		if err != nil {
+			return nil, removeReqID(err)
+		}
+
+		for _, hostedZone := range resp.HostedZones {
+			// .Name has a trailing dot
+			if hostedZone.Config != nil && strings.EqualFold(util.UnFqdn(aws.ToString(hostedZone.Name)), name) {
+				hostedZones = append(hostedZones, hostedZone)
+			}
+		}
+
+		if !resp.IsTruncated || !strings.EqualFold(util.UnFqdn(aws.ToString(resp.NextDNSName)), name) {
+			return hostedZones, nil
+		}
+		reqParams = &route53.ListHostedZonesByNameInput{
+			DNSName:      resp.NextDNSName,
+			HostedZoneId: resp.NextHostedZoneId,
+		}
+	}
+}
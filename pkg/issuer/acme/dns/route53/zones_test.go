@@ -0,0 +1,311 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package route53
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fakeclock "k8s.io/utils/clock/testing"
+)
+
+const NoSuchHostedZoneResponse = `<?xml version="1.0"?>
+<ErrorResponse xmlns="https://route53.amazonaws.com/doc/2013-04-01/">
+  <Error>
+    <Type>Sender</Type>
+    <Code>NoSuchHostedZone</Code>
+    <Message>No hosted zone found with ID: Z1</Message>
+  </Error>
+  <RequestId>SOMEREQUESTID</RequestId>
+</ErrorResponse>`
+
+type fakeHostedZone struct {
+	id      string
+	name    string
+	private bool
+}
+
+// fakeRoute53 serves the hosted zones listed by name, and records the names
+// which were listed.
+type fakeRoute53 struct {
+	lock        sync.Mutex
+	hostedZones []fakeHostedZone
+	// deleted fails record set changes with a NoSuchHostedZone error.
+	deleted bool
+	listed  []string
+}
+
+func (f *fakeRoute53) setHostedZones(hostedZones []fakeHostedZone, deleted bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.hostedZones = hostedZones
+	f.deleted = deleted
+}
+
+func (f *fakeRoute53) listedNames() []string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.listed
+}
+
+func (f *fakeRoute53) serve(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.lock.Lock()
+		defer f.lock.Unlock()
+
+		w.Header().Set("Content-Type", "application/xml")
+		w.Header().Set("X-Amzn-Requestid", "SOMEREQUESTID")
+
+		switch {
+		case r.URL.Path == "/2013-04-01/hostedzonesbyname":
+			name := r.URL.Query().Get("dnsname")
+			f.listed = append(f.listed, name)
+
+			// Like Route 53, list the hosted zones from the given name,
+			// and not only the hosted zones with that name.
+			var hostedZones strings.Builder
+			for _, hostedZone := range f.hostedZones {
+				if hostedZone.name < name {
+					continue
+				}
+				fmt.Fprintf(&hostedZones, `<HostedZone><Id>/hostedzone/%s</Id><Name>%s.</Name><CallerReference>ref</CallerReference><Config><PrivateZone>%t</PrivateZone></Config></HostedZone>`, hostedZone.id, hostedZone.name, hostedZone.private)
+			}
+			_, _ = fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?>
+<ListHostedZonesByNameResponse xmlns="https://route53.amazonaws.com/doc/2013-04-01/"><HostedZones>%s</HostedZones><IsTruncated>false</IsTruncated><MaxItems>100</MaxItems></ListHostedZonesByNameResponse>`, hostedZones.String())
+		case strings.HasSuffix(r.URL.Path, "/rrset"):
+			if f.deleted {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(NoSuchHostedZoneResponse))
+				return
+			}
+			_, _ = w.Write([]byte(ChangeResourceRecordSetsResponse))
+		case r.URL.Path == "/2013-04-01/change/123456":
+			_, _ = w.Write([]byte(GetChangeResponse))
+		default:
+			require.FailNow(t, "unexpected request", r.URL.Path)
+		}
+	}))
+}
+
+func TestGetHostedZoneID(t *testing.T) {
+	tests := map[string]struct {
+		hostedZones        []fakeHostedZone
+		hostedZoneID       string
+		preferPrivateZones bool
+		fqdn               string
+
+		expectedID     string
+		expectedErr    string
+		expectedListed []string
+	}{
+		"the most specific of overlapping hosted zones is chosen": {
+			hostedZones: []fakeHostedZone{
+				{id: "Z1", name: "example.com"},
+				{id: "Z2", name: "internal.example.com"},
+			},
+			fqdn:           "_acme-challenge.app.internal.example.com.",
+			expectedID:     "Z2",
+			expectedListed: []string{"_acme-challenge.app.internal.example.com", "app.internal.example.com", "internal.example.com"},
+		},
+		"a parent hosted zone is chosen if there is no more specific one": {
+			hostedZones: []fakeHostedZone{
+				{id: "Z1", name: "example.com"},
+				{id: "Z2", name: "internal.example.com"},
+			},
+			fqdn:           "_acme-challenge.www.example.com.",
+			expectedID:     "Z1",
+			expectedListed: []string{"_acme-challenge.www.example.com", "www.example.com", "example.com"},
+		},
+		"hosted zones are matched case insensitively": {
+			hostedZones: []fakeHostedZone{
+				{id: "Z1", name: "example.com"},
+			},
+			fqdn:           "_acme-challenge.Example.COM.",
+			expectedID:     "Z1",
+			expectedListed: []string{"_acme-challenge.example.com", "example.com"},
+		},
+		"the public hosted zone is preferred by default": {
+			hostedZones: []fakeHostedZone{
+				{id: "Z1", name: "example.com", private: true},
+				{id: "Z2", name: "example.com"},
+			},
+			fqdn:           "_acme-challenge.example.com.",
+			expectedID:     "Z2",
+			expectedListed: []string{"_acme-challenge.example.com", "example.com"},
+		},
+		"the private hosted zone is preferred if configured": {
+			hostedZones: []fakeHostedZone{
+				{id: "Z1", name: "example.com"},
+				{id: "Z2", name: "example.com", private: true},
+			},
+			preferPrivateZones: true,
+			fqdn:               "_acme-challenge.example.com.",
+			expectedID:         "Z2",
+			expectedListed:     []string{"_acme-challenge.example.com", "example.com"},
+		},
+		"private hosted zones are ignored by default": {
+			hostedZones: []fakeHostedZone{
+				{id: "Z1", name: "sub.example.com", private: true},
+				{id: "Z2", name: "example.com"},
+			},
+			fqdn:           "_acme-challenge.sub.example.com.",
+			expectedID:     "Z2",
+			expectedListed: []string{"_acme-challenge.sub.example.com", "sub.example.com", "example.com"},
+		},
+		"no hosted zone is found if there are only private hosted zones by default": {
+			hostedZones: []fakeHostedZone{
+				{id: "Z1", name: "example.com", private: true},
+			},
+			fqdn:           "_acme-challenge.example.com.",
+			expectedErr:    "no hosted zone found in Route 53 for domain _acme-challenge.example.com.",
+			expectedListed: []string{"_acme-challenge.example.com", "example.com", "com"},
+		},
+		"a public hosted zone is chosen if there is no private one and private hosted zones are preferred": {
+			hostedZones: []fakeHostedZone{
+				{id: "Z1", name: "example.com"},
+			},
+			preferPrivateZones: true,
+			fqdn:               "_acme-challenge.example.com.",
+			expectedID:         "Z1",
+			expectedListed:     []string{"_acme-challenge.example.com", "example.com"},
+		},
+		"hosted zones of the same type are ordered by ID": {
+			hostedZones: []fakeHostedZone{
+				{id: "Z3", name: "example.com", private: true},
+				{id: "Z2", name: "example.com", private: true},
+				{id: "Z1", name: "example.com"},
+			},
+			preferPrivateZones: true,
+			fqdn:               "_acme-challenge.example.com.",
+			expectedID:         "Z2",
+			expectedListed:     []string{"_acme-challenge.example.com", "example.com"},
+		},
+		"the configured hosted zone overrides discovery": {
+			hostedZones: []fakeHostedZone{
+				{id: "Z1", name: "example.com"},
+			},
+			hostedZoneID: "ZCONFIGURED",
+			fqdn:         "_acme-challenge.example.com.",
+			expectedID:   "ZCONFIGURED",
+		},
+		"no hosted zone matches the domain": {
+			hostedZones: []fakeHostedZone{
+				{id: "Z1", name: "example.org"},
+			},
+			fqdn:           "_acme-challenge.example.com.",
+			expectedErr:    "no hosted zone found in Route 53 for domain _acme-challenge.example.com.",
+			expectedListed: []string{"_acme-challenge.example.com", "example.com", "com"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fake := &fakeRoute53{hostedZones: test.hostedZones}
+			ts := fake.serve(t)
+			defer ts.Close()
+
+			provider, err := makeRoute53Provider(ts)
+			require.NoError(t, err)
+			provider.hostedZoneID = test.hostedZoneID
+			provider.preferPrivateZones = test.preferPrivateZones
+
+			id, err := provider.getHostedZoneID(context.TODO(), test.fqdn)
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expectedID, id)
+			assert.Equal(t, test.expectedListed, fake.listedNames())
+		})
+	}
+}
+
+func TestGetHostedZoneIDCache(t *testing.T) {
+	fake := &fakeRoute53{hostedZones: []fakeHostedZone{{id: "Z1", name: "example.com"}}}
+	ts := fake.serve(t)
+	defer ts.Close()
+
+	clock := fakeclock.NewFakeClock(time.Now())
+	provider, err := makeRoute53Provider(ts)
+	require.NoError(t, err)
+	provider.zoneCache = newHostedZoneCache(clock)
+
+	id, err := provider.getHostedZoneID(context.TODO(), "_acme-challenge.example.com.")
+	require.NoError(t, err)
+	assert.Equal(t, "Z1", id)
+	assert.Len(t, fake.listedNames(), 2)
+
+	// The discovered hosted zone is cached.
+	id, err = provider.getHostedZoneID(context.TODO(), "_acme-challenge.example.com.")
+	require.NoError(t, err)
+	assert.Equal(t, "Z1", id)
+	assert.Len(t, fake.listedNames(), 2)
+
+	// The cache is scoped to the preferred hosted zone type.
+	provider.preferPrivateZones = true
+	_, err = provider.getHostedZoneID(context.TODO(), "_acme-challenge.example.com.")
+	require.NoError(t, err)
+	assert.Len(t, fake.listedNames(), 4)
+	provider.preferPrivateZones = false
+
+	// Hosted zones are discovered again once cached entries expire.
+	fake.setHostedZones([]fakeHostedZone{{id: "Z1", name: "example.com"}, {id: "Z2", name: "_acme-challenge.example.com"}}, false)
+	clock.Step(hostedZoneCacheTTL)
+	id, err = provider.getHostedZoneID(context.TODO(), "_acme-challenge.example.com.")
+	require.NoError(t, err)
+	assert.Equal(t, "Z2", id)
+	assert.Len(t, fake.listedNames(), 5)
+}
+
+func TestChangeRecordForgetsDeletedHostedZone(t *testing.T) {
+	fake := &fakeRoute53{
+		hostedZones: []fakeHostedZone{{id: "Z1", name: "example.com"}},
+		deleted:     true,
+	}
+	ts := fake.serve(t)
+	defer ts.Close()
+
+	provider, err := makeRoute53Provider(ts)
+	require.NoError(t, err)
+	provider.zoneCache = newHostedZoneCache(fakeclock.NewFakeClock(time.Now()))
+
+	err = provider.Present(context.TODO(), "example.com", "_acme-challenge.example.com.", "123456d==")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "NoSuchHostedZone")
+	assert.Len(t, fake.listedNames(), 2)
+
+	// The hosted zone was deleted and recreated with a new ID, which is
+	// discovered as the previously discovered hosted zone was forgotten.
+	fake.setHostedZones([]fakeHostedZone{{id: "Z2", name: "example.com"}}, false)
+	err = provider.Present(context.TODO(), "example.com", "_acme-challenge.example.com.", "123456d==")
+	require.NoError(t, err)
+	assert.Len(t, fake.listedNames(), 4)
+
+	id, err := provider.getHostedZoneID(context.TODO(), "_acme-challenge.example.com.")
+	require.NoError(t, err)
+	assert.Equal(t, "Z2", id)
+	assert.Len(t, fake.listedNames(), 4)
+}
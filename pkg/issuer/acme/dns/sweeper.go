@@ -0,0 +1,261 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/clock"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmacmelisters "github.com/cert-manager/cert-manager/pkg/client/listers/acme/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+// recordLister is an optional capability of the DNS providers, which lists the
+// DNS01 challenge TXT records in the zones they manage. Providers which do not
+// implement it are skipped by the Sweeper.
+type recordLister interface {
+	ListChallengeRecords(ctx context.Context) ([]util.ChallengeRecord, error)
+}
+
+// Sweeper deletes DNS01 challenge TXT records which were left behind in the
+// DNS providers of ACME issuers, e.g. because the controller was restarted
+// between presenting and cleaning up a challenge.
+// A record is considered orphaned if it was registered as presented by
+// cert-manager, and no Challenge has its value. It is deleted once it has been
+// found orphaned for longer than the minimum age. Records which were not
+// presented by cert-manager, such as the records of other ACME clients using
+// the same zones, are never deleted.
+type Sweeper struct {
+	records             *presentedRecords
+	challengeLister     cmacmelisters.ChallengeLister
+	issuerLister        cmlisters.IssuerLister
+	clusterIssuerLister cmlisters.ClusterIssuerLister
+
+	// providerFor returns the DNS provider configured by a solver of an
+	// issuer. It is a field so that it can be replaced in tests.
+	providerFor func(ctx context.Context, issuer v1.GenericIssuer, solver cmacme.ACMEChallengeSolver) (solver, error)
+
+	clock  clock.Clock
+	minAge time.Duration
+
+	// orphanedSince records when each orphaned record was first found. It is
+	// only kept in memory, so after a restart of the controller the minimum
+	// age is counted from the first sweep.
+	orphanedSince map[util.ChallengeRecord]time.Time
+}
+
+// NewSweeper returns a Sweeper which uses the DNS providers configured by the
+// ACME issuers in the given listers. The ClusterIssuer lister may be nil if
+// cert-manager is scoped to a namespace. The Solver must have been created
+// with the sweeper enabled, so that it registers the records it presents.
+func NewSweeper(s *Solver, challengeLister cmacmelisters.ChallengeLister, issuerLister cmlisters.IssuerLister, clusterIssuerLister cmlisters.ClusterIssuerLister, minAge time.Duration) *Sweeper {
+	return &Sweeper{
+		records:             s.presentedRecords,
+		challengeLister:     challengeLister,
+		issuerLister:        issuerLister,
+		clusterIssuerLister: clusterIssuerLister,
+		providerFor:         s.providerForSolver,
+		clock:               s.Clock,
+		minAge:              minAge,
+		orphanedSince:       make(map[util.ChallengeRecord]time.Time),
+	}
+}
+
+// Sweep lists the DNS01 challenge records of all the DNS providers which
+// support it, and deletes the records which have been orphaned for longer than
+// the minimum age.
+func (s *Sweeper) Sweep(ctx context.Context) error {
+	log := logf.FromContext(ctx, "sweeper")
+
+	challenges, err := s.challengeLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	challengeValues := sets.New[string]()
+	for _, ch := range challenges {
+		if ch.Spec.Type == cmacme.ACMEChallengeTypeDNS01 {
+			challengeValues.Insert(ch.Spec.Key)
+		}
+	}
+
+	registered, err := s.records.list(ctx)
+	if err != nil {
+		return fmt.Errorf("error listing presented DNS01 challenge records: %w", err)
+	}
+
+	issuers, err := s.listIssuers()
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	orphaned := make(map[util.ChallengeRecord]solver)
+	// inUse are the names of the records which belong to a Challenge. Some
+	// providers delete all the values of a record when cleaning up, so
+	// orphaned values of these records are only deleted once they are no
+	// longer in use.
+	inUse := sets.New[string]()
+	// found are the registered records which still exist.
+	found := sets.New[util.ChallengeRecord]()
+	for _, issuer := range issuers {
+		acme := issuer.GetSpec().ACME
+		if acme == nil {
+			continue
+		}
+
+		for _, solverConfig := range acme.Solvers {
+			if solverConfig.DNS01 == nil || solverConfig.DNS01.Webhook != nil {
+				continue
+			}
+
+			provider, err := s.providerFor(ctx, issuer, solverConfig)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("error creating DNS provider for issuer %q: %w", issuerName(issuer), err))
+				continue
+			}
+			lister, ok := provider.(recordLister)
+			if !ok {
+				log.V(logf.DebugLevel).Info("skipping DNS provider which cannot list its records", "issuer", issuerName(issuer))
+				continue
+			}
+
+			records, err := lister.ListChallengeRecords(ctx)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("error listing DNS01 challenge records for issuer %q: %w", issuerName(issuer), err))
+				continue
+			}
+
+			for _, record := range records {
+				if challengeValues.Has(record.Value) {
+					inUse.Insert(recordKey(record).FQDN)
+					continue
+				}
+				if !registered.Has(recordKey(record)) {
+					continue
+				}
+				found.Insert(recordKey(record))
+				orphaned[record] = provider
+			}
+		}
+	}
+
+	// Forget records which were deleted or claimed by a Challenge since the
+	// previous sweep.
+	for record := range s.orphanedSince {
+		if _, ok := orphaned[record]; !ok {
+			delete(s.orphanedSince, record)
+		}
+	}
+
+	// Registered records which no longer exist, e.g. because the controller
+	// was restarted after deleting them but before removing them from the
+	// registry, are removed from the registry. This is only safe if the
+	// records of all the providers could be listed.
+	var deleted []util.ChallengeRecord
+	if len(errs) == 0 {
+		for record := range registered {
+			if !found.Has(record) && !challengeValues.Has(record.Value) {
+				deleted = append(deleted, record)
+			}
+		}
+	}
+
+	now := s.clock.Now()
+	for record, provider := range orphaned {
+		since, ok := s.orphanedSince[record]
+		if !ok {
+			s.orphanedSince[record] = now
+			since = now
+		}
+		if now.Sub(since) < s.minAge || inUse.Has(recordKey(record).FQDN) {
+			continue
+		}
+
+		domain := util.UnFqdn(record.FQDN[strings.Index(record.FQDN, ".")+1:])
+		if err := provider.CleanUp(ctx, domain, record.FQDN, record.Value); err != nil {
+			errs = append(errs, fmt.Errorf("error deleting orphaned DNS01 challenge record %q: %w", record.FQDN, err))
+			continue
+		}
+
+		log.V(logf.InfoLevel).Info("deleted orphaned DNS01 challenge record", "fqdn", record.FQDN, "orphanedSince", since)
+		delete(s.orphanedSince, record)
+		deleted = append(deleted, recordKey(record))
+	}
+
+	if err := s.records.remove(ctx, deleted...); err != nil {
+		errs = append(errs, fmt.Errorf("error removing deleted DNS01 challenge records from the registry: %w", err))
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// listIssuers returns the Issuers and ClusterIssuers whose DNS providers are
+// swept.
+func (s *Sweeper) listIssuers() ([]v1.GenericIssuer, error) {
+	var issuers []v1.GenericIssuer
+
+	issuerList, err := s.issuerLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, issuer := range issuerList {
+		issuers = append(issuers, issuer)
+	}
+
+	if s.clusterIssuerLister == nil {
+		return issuers, nil
+	}
+
+	clusterIssuerList, err := s.clusterIssuerLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, clusterIssuer := range clusterIssuerList {
+		issuers = append(issuers, clusterIssuer)
+	}
+
+	return issuers, nil
+}
+
+// issuerName returns the name of an Issuer prefixed by its namespace, or the
+// name of a ClusterIssuer.
+func issuerName(issuer v1.GenericIssuer) string {
+	if ns := issuer.GetObjectMeta().Namespace; ns != "" {
+		return ns + "/" + issuer.GetObjectMeta().Name
+	}
+	return issuer.GetObjectMeta().Name
+}
+
+// providerForSolver returns the DNS provider configured by the given solver of
+// an issuer.
+func (s *Solver) providerForSolver(ctx context.Context, issuer v1.GenericIssuer, solverConfig cmacme.ACMEChallengeSolver) (solver, error) {
+	impl, _, err := s.solverForChallenge(ctx, issuer, &cmacme.Challenge{
+		Spec: cmacme.ChallengeSpec{Solver: solverConfig},
+	})
+	return impl, err
+}
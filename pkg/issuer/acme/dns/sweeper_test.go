@@ -0,0 +1,354 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmacmelisters "github.com/cert-manager/cert-manager/pkg/client/listers/acme/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+// fakeRecordProvider is a DNS provider which keeps its TXT records in memory
+// and can list them.
+type fakeRecordProvider struct {
+	records map[util.ChallengeRecord]struct{}
+}
+
+func newFakeRecordProvider() *fakeRecordProvider {
+	return &fakeRecordProvider{records: make(map[util.ChallengeRecord]struct{})}
+}
+
+func (f *fakeRecordProvider) Present(_ context.Context, _, fqdn, value string) error {
+	f.records[util.ChallengeRecord{FQDN: fqdn, Value: value}] = struct{}{}
+	return nil
+}
+
+func (f *fakeRecordProvider) CleanUp(_ context.Context, _, fqdn, value string) error {
+	delete(f.records, util.ChallengeRecord{FQDN: fqdn, Value: value})
+	return nil
+}
+
+func (f *fakeRecordProvider) ListChallengeRecords(context.Context) ([]util.ChallengeRecord, error) {
+	records := make([]util.ChallengeRecord, 0, len(f.records))
+	for record := range f.records {
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func (f *fakeRecordProvider) has(fqdn, value string) bool {
+	_, ok := f.records[util.ChallengeRecord{FQDN: fqdn, Value: value}]
+	return ok
+}
+
+// fakeProvider is a DNS provider which cannot list its records.
+type fakeProvider struct {
+	cleanedUp int
+}
+
+func (f *fakeProvider) Present(context.Context, string, string, string) error { return nil }
+
+func (f *fakeProvider) CleanUp(context.Context, string, string, string) error {
+	f.cleanedUp++
+	return nil
+}
+
+// sweeperFixture simulates a controller which presents DNS01 challenges, and
+// crashes before cleaning some of them up.
+type sweeperFixture struct {
+	t        *testing.T
+	clock    *fakeclock.FakeClock
+	provider solver
+	issuer   *v1.Issuer
+	client   *kubefake.Clientset
+	records  *presentedRecords
+
+	challenges cache.Indexer
+	issuers    cache.Indexer
+}
+
+func newSweeperFixture(t *testing.T, provider solver) *sweeperFixture {
+	client := kubefake.NewSimpleClientset()
+	f := &sweeperFixture{
+		client:   client,
+		records:  &presentedRecords{client: client, namespace: "cert-manager"},
+		t:        t,
+		clock:    fakeclock.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+		provider: provider,
+		issuer: gen.Issuer("test-issuer",
+			gen.SetIssuerNamespace("default"),
+			gen.SetIssuerACME(cmacme.ACMEIssuer{
+				Solvers: []cmacme.ACMEChallengeSolver{
+					{DNS01: &cmacme.ACMEChallengeSolverDNS01{DigitalOcean: &cmacme.ACMEIssuerDNS01ProviderDigitalOcean{}}},
+					{HTTP01: &cmacme.ACMEChallengeSolverHTTP01{}},
+					{DNS01: &cmacme.ACMEChallengeSolverDNS01{Webhook: &cmacme.ACMEIssuerDNS01ProviderWebhook{}}},
+				},
+			}),
+		),
+		challenges: cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}),
+		issuers:    cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}),
+	}
+	require.NoError(t, f.issuers.Add(f.issuer))
+	return f
+}
+
+// newSweeper returns a new Sweeper, as constructed after a restart of the
+// controller.
+func (f *sweeperFixture) newSweeper(minAge time.Duration) *Sweeper {
+	return &Sweeper{
+		records:         f.records,
+		challengeLister: cmacmelisters.NewChallengeLister(f.challenges),
+		issuerLister:    cmlisters.NewIssuerLister(f.issuers),
+		providerFor: func(_ context.Context, issuer v1.GenericIssuer, solverConfig cmacme.ACMEChallengeSolver) (solver, error) {
+			assert.Equal(f.t, f.issuer.Name, issuer.GetObjectMeta().Name)
+			assert.NotNil(f.t, solverConfig.DNS01.DigitalOcean)
+			return f.provider, nil
+		},
+		clock:         f.clock,
+		minAge:        minAge,
+		orphanedSince: make(map[util.ChallengeRecord]time.Time),
+	}
+}
+
+// present creates a Challenge for the domain and presents its record.
+func (f *sweeperFixture) present(name, domain string) (string, string) {
+	value := keyAuthorizationDigest(name)
+	require.NoError(f.t, f.challenges.Add(&cmacme.Challenge{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec: cmacme.ChallengeSpec{
+			Type:    cmacme.ACMEChallengeTypeDNS01,
+			DNSName: domain,
+			Key:     value,
+		},
+	}))
+
+	fqdn := "_acme-challenge." + domain + "."
+	require.NoError(f.t, f.records.present(context.Background(), f.provider, domain, fqdn, value))
+	return fqdn, value
+}
+
+// registered returns the records in the registry of presented records.
+func (f *sweeperFixture) registered() map[string]string {
+	cm, err := f.client.CoreV1().ConfigMaps("cert-manager").Get(context.Background(), presentedRecordsConfigMapName, metav1.GetOptions{})
+	require.NoError(f.t, err)
+	return cm.Data
+}
+
+// crash deletes the Challenge without cleaning up its record.
+func (f *sweeperFixture) crash(name string) {
+	require.NoError(f.t, f.challenges.Delete(&cmacme.Challenge{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+	}))
+}
+
+func keyAuthorizationDigest(keyAuthorization string) string {
+	digest := sha256.Sum256([]byte(keyAuthorization))
+	return base64.RawURLEncoding.EncodeToString(digest[:])
+}
+
+func TestSweeperDeletesOrphanedRecords(t *testing.T) {
+	provider := newFakeRecordProvider()
+	f := newSweeperFixture(t, provider)
+	sweeper := f.newSweeper(time.Hour)
+	ctx := context.Background()
+
+	// Records which were not presented by cert-manager, such as the records
+	// of other ACME clients using the zone, are never deleted.
+	unrelated := []util.ChallengeRecord{
+		{FQDN: "_acme-challenge.example.org.", Value: "manually created"},
+		{FQDN: "_acme-challenge.example.org.", Value: keyAuthorizationDigest("other client")},
+		{FQDN: "example.org.", Value: keyAuthorizationDigest("unrelated")},
+	}
+	for _, record := range unrelated {
+		require.NoError(t, provider.Present(ctx, "example.org", record.FQDN, record.Value))
+	}
+
+	liveFQDN, liveValue := f.present("live", "live.example.com")
+	firstFQDN, firstValue := f.present("first", "first.example.com")
+	f.crash("first")
+
+	require.NoError(t, sweeper.Sweep(ctx))
+	assert.True(t, provider.has(firstFQDN, firstValue), "orphaned record should be kept until it reaches the minimum age")
+
+	f.clock.Step(30 * time.Minute)
+	secondFQDN, secondValue := f.present("second", "second.example.com")
+	f.crash("second")
+
+	require.NoError(t, sweeper.Sweep(ctx))
+	assert.True(t, provider.has(firstFQDN, firstValue))
+	assert.True(t, provider.has(secondFQDN, secondValue))
+
+	f.clock.Step(31 * time.Minute)
+	require.NoError(t, sweeper.Sweep(ctx))
+	assert.False(t, provider.has(firstFQDN, firstValue), "orphaned record should be deleted once it reaches the minimum age")
+	assert.True(t, provider.has(secondFQDN, secondValue), "orphaned record should be kept until it reaches the minimum age")
+
+	f.clock.Step(30 * time.Minute)
+	require.NoError(t, sweeper.Sweep(ctx))
+	assert.False(t, provider.has(secondFQDN, secondValue))
+
+	assert.True(t, provider.has(liveFQDN, liveValue), "record of an existing Challenge should never be deleted")
+	for _, record := range unrelated {
+		assert.True(t, provider.has(record.FQDN, record.Value), "record which was not presented by cert-manager should never be deleted")
+	}
+	assert.Equal(t, map[string]string{liveValue: liveFQDN}, f.registered(), "deleted records should be removed from the registry")
+}
+
+func TestSweeperRemovesRecordsFromRegistry(t *testing.T) {
+	provider := newFakeRecordProvider()
+	f := newSweeperFixture(t, provider)
+	sweeper := f.newSweeper(time.Hour)
+	ctx := context.Background()
+
+	cleanedFQDN, cleanedValue := f.present("cleaned", "cleaned.example.com")
+	deletedFQDN, deletedValue := f.present("deleted", "deleted.example.com")
+	assert.Equal(t, map[string]string{cleanedValue: cleanedFQDN, deletedValue: deletedFQDN}, f.registered())
+
+	require.NoError(t, f.records.cleanUp(ctx, provider, "cleaned.example.com", cleanedFQDN, cleanedValue))
+	assert.Equal(t, map[string]string{deletedValue: deletedFQDN}, f.registered(), "record should be removed from the registry when it is cleaned up")
+
+	// The controller is restarted after deleting the record but before
+	// removing it from the registry.
+	require.NoError(t, provider.CleanUp(ctx, "deleted.example.com", deletedFQDN, deletedValue))
+	require.NoError(t, sweeper.Sweep(ctx))
+	assert.Equal(t, map[string]string{deletedValue: deletedFQDN}, f.registered(), "record of an existing Challenge should be kept in the registry")
+
+	f.crash("deleted")
+	require.NoError(t, sweeper.Sweep(ctx))
+	assert.Empty(t, f.registered(), "record which no longer exists should be removed from the registry")
+}
+
+func TestSweeperCountsMinimumAgeFromFirstSweepAfterRestart(t *testing.T) {
+	provider := newFakeRecordProvider()
+	f := newSweeperFixture(t, provider)
+	ctx := context.Background()
+
+	fqdn, value := f.present("orphan", "example.com")
+	f.crash("orphan")
+
+	require.NoError(t, f.newSweeper(time.Hour).Sweep(ctx))
+	f.clock.Step(50 * time.Minute)
+
+	// The controller is restarted, the record was first found 50 minutes ago
+	// but this is not known to the new sweeper.
+	sweeper := f.newSweeper(time.Hour)
+	require.NoError(t, sweeper.Sweep(ctx))
+	f.clock.Step(50 * time.Minute)
+	require.NoError(t, sweeper.Sweep(ctx))
+	assert.True(t, provider.has(fqdn, value))
+
+	f.clock.Step(10 * time.Minute)
+	require.NoError(t, sweeper.Sweep(ctx))
+	assert.False(t, provider.has(fqdn, value))
+}
+
+func TestSweeperKeepsOrphanedValuesOfRecordsInUse(t *testing.T) {
+	provider := newFakeRecordProvider()
+	f := newSweeperFixture(t, provider)
+	sweeper := f.newSweeper(time.Hour)
+	ctx := context.Background()
+
+	// The wildcard and apex challenges of a domain share the same record.
+	orphanFQDN, orphanValue := f.present("wildcard", "example.com")
+	f.crash("wildcard")
+	liveFQDN, liveValue := f.present("apex", "example.com")
+	require.Equal(t, orphanFQDN, liveFQDN)
+
+	require.NoError(t, sweeper.Sweep(ctx))
+	f.clock.Step(2 * time.Hour)
+	require.NoError(t, sweeper.Sweep(ctx))
+	assert.True(t, provider.has(orphanFQDN, orphanValue), "orphaned value should be kept while the record is in use")
+
+	f.crash("apex")
+	require.NoError(t, sweeper.Sweep(ctx))
+	assert.False(t, provider.has(orphanFQDN, orphanValue), "orphaned value should be deleted once the record is no longer in use")
+	assert.True(t, provider.has(liveFQDN, liveValue), "value of the deleted Challenge should be kept until it reaches the minimum age")
+}
+
+func TestSweeperForgetsRecordsClaimedByAChallenge(t *testing.T) {
+	provider := newFakeRecordProvider()
+	f := newSweeperFixture(t, provider)
+	sweeper := f.newSweeper(time.Hour)
+	ctx := context.Background()
+
+	fqdn, value := f.present("retried", "example.com")
+	f.crash("retried")
+	require.NoError(t, sweeper.Sweep(ctx))
+
+	// The Challenge is recreated with the same key and presents the record
+	// again, and is then deleted without cleaning up.
+	f.clock.Step(50 * time.Minute)
+	f.present("retried", "example.com")
+	require.NoError(t, sweeper.Sweep(ctx))
+	f.crash("retried")
+	require.NoError(t, sweeper.Sweep(ctx))
+
+	f.clock.Step(50 * time.Minute)
+	require.NoError(t, sweeper.Sweep(ctx))
+	assert.True(t, provider.has(fqdn, value), "minimum age should be counted from when the record was orphaned again")
+
+	f.clock.Step(10 * time.Minute)
+	require.NoError(t, sweeper.Sweep(ctx))
+	assert.False(t, provider.has(fqdn, value))
+}
+
+func TestSweeperSkipsProvidersWhichCannotListRecords(t *testing.T) {
+	provider := &fakeProvider{}
+	f := newSweeperFixture(t, provider)
+	sweeper := f.newSweeper(0)
+	ctx := context.Background()
+
+	f.present("orphan", "example.com")
+	f.crash("orphan")
+
+	require.NoError(t, sweeper.Sweep(ctx))
+	f.clock.Step(time.Hour)
+	require.NoError(t, sweeper.Sweep(ctx))
+	assert.Equal(t, 0, provider.cleanedUp)
+
+	_, err := f.client.CoreV1().ConfigMaps("cert-manager").Get(ctx, presentedRecordsConfigMapName, metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err), "records which cannot be swept should not be registered")
+}
+
+func TestSweeperReturnsProviderErrors(t *testing.T) {
+	f := newSweeperFixture(t, newFakeRecordProvider())
+	sweeper := f.newSweeper(time.Hour)
+	sweeper.providerFor = func(context.Context, v1.GenericIssuer, cmacme.ACMEChallengeSolver) (solver, error) {
+		return nil, errors.New("invalid credentials")
+	}
+
+	err := sweeper.Sweep(context.Background())
+	assert.EqualError(t, err, `error creating DNS provider for issuer "default/test-issuer": invalid credentials`)
+}
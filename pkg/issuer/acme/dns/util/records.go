@@ -0,0 +1,30 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+// ChallengeRecordLabel is the label prepended to a domain to obtain the name
+// of its DNS01 challenge TXT record.
+const ChallengeRecordLabel = "_acme-challenge"
+
+// ChallengeRecord is a DNS01 challenge TXT record found in a DNS zone.
+type ChallengeRecord struct {
+	// FQDN is the fully qualified name of the record, e.g.
+	// "_acme-challenge.example.com.".
+	FQDN string
+	// Value is the value of the TXT record.
+	Value string
+}
@@ -11,6 +11,7 @@ package util
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -29,6 +30,10 @@ type preCheckDNSFunc func(ctx context.Context, fqdn, value string, nameservers [
 type dnsQueryFunc func(ctx context.Context, fqdn string, rtype uint16, nameservers []string, recursive bool) (in *dns.Msg, err error)
 
 var (
+	// ErrPropagationTimeout is returned when a DNS01 challenge record has not
+	// propagated within the configured propagation timeout.
+	ErrPropagationTimeout = errors.New("timed out waiting for DNS01 challenge record to propagate")
+
 	// PreCheckDNS checks DNS propagation before notifying ACME that
 	// the DNS challenge is ready.
 	PreCheckDNS preCheckDNSFunc = checkDNSPropagation
@@ -40,6 +45,20 @@ var (
 	fqdnToZone     = map[string]string{}
 )
 
+// InitialDelayError is returned by the DNS01 solver once the challenge record
+// has propagated, until the configured initial delay has passed. The
+// Challenge should be checked again after RetryAfter.
+type InitialDelayError struct {
+	// Delay is the configured initial delay.
+	Delay time.Duration
+	// RetryAfter is the remaining time until the initial delay has passed.
+	RetryAfter time.Duration
+}
+
+func (e *InitialDelayError) Error() string {
+	return fmt.Sprintf("DNS01 challenge record has propagated, waiting %s before it is validated", e.Delay)
+}
+
 const defaultResolvConf = "/etc/resolv.conf"
 
 const issueTag = "issue"
@@ -44,12 +44,18 @@ func (r *Webhook) Name() string {
 
 // Present creates a TXT record using the specified parameters
 func (r *Webhook) Present(ch *v1alpha1.ChallengeRequest) error {
+	return r.PresentWithContext(context.TODO(), ch)
+}
+
+// PresentWithContext creates a TXT record using the specified parameters,
+// giving up on the call to the webhook once ctx is done.
+func (r *Webhook) PresentWithContext(ctx context.Context, ch *v1alpha1.ChallengeRequest) error {
 	cl, pl, solverName, err := r.buildPayload(ch, v1alpha1.ChallengeActionPresent)
 	if err != nil {
 		return err
 	}
 
-	result := cl.Post().Resource(solverName).Body(pl).Do(context.TODO())
+	result := cl.Post().Resource(solverName).Body(pl).Do(ctx)
 	// we will check this error after parsing the response
 	resErr := result.Error()
 
@@ -80,12 +86,18 @@ func (r *Webhook) Present(ch *v1alpha1.ChallengeRequest) error {
 
 // CleanUp removes the TXT record matching the specified parameters
 func (r *Webhook) CleanUp(ch *v1alpha1.ChallengeRequest) error {
+	return r.CleanUpWithContext(context.TODO(), ch)
+}
+
+// CleanUpWithContext removes the TXT record matching the specified
+// parameters, giving up on the call to the webhook once ctx is done.
+func (r *Webhook) CleanUpWithContext(ctx context.Context, ch *v1alpha1.ChallengeRequest) error {
 	cl, pl, solverName, err := r.buildPayload(ch, v1alpha1.ChallengeActionCleanUp)
 	if err != nil {
 		return err
 	}
 
-	result := cl.Post().Resource(solverName).Body(pl).Do(context.TODO())
+	result := cl.Post().Resource(solverName).Body(pl).Do(ctx)
 	// we will check this error after parsing the response
 	resErr := result.Error()
 
@@ -0,0 +1,98 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+// customConfigMapRef returns the namespace and name of the ConfigMap that the
+// given Challenge's custom HTTP01 solver publishes key authorizations to.
+// The ConfigMap is always in the namespace of the Challenge, so that an
+// Issuer cannot be used to write to ConfigMaps in other namespaces.
+func customConfigMapRef(ch *cmacme.Challenge) (string, string) {
+	return ch.Namespace, ch.Spec.Solver.HTTP01.Custom.ConfigMapName
+}
+
+// ensureCustom publishes the Challenge's token and key authorization to the
+// ConfigMap configured on the custom HTTP01 solver, creating the ConfigMap
+// if it does not yet exist. Other entries in the ConfigMap are left as is so
+// that a single ConfigMap can be shared between many Challenges.
+func (s *Solver) ensureCustom(ctx context.Context, ch *cmacme.Challenge) error {
+	namespace, name := customConfigMapRef(ch)
+	log := logf.FromContext(ctx).WithName("ensureCustom").WithValues("configmap", namespace+"/"+name)
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"data": map[string]interface{}{ch.Spec.Token: ch.Spec.Key},
+	})
+	if err != nil {
+		return err
+	}
+
+	log.V(logf.DebugLevel).Info("publishing HTTP01 challenge key authorization")
+	_, err = s.Client.CoreV1().ConfigMaps(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	log.V(logf.DebugLevel).Info("creating HTTP01 challenge ConfigMap as it does not exist")
+	_, err = s.Client.CoreV1().ConfigMaps(namespace).Create(ctx, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Data: map[string]string{ch.Spec.Token: ch.Spec.Key},
+	}, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("HTTP01 challenge ConfigMap %s/%s was created concurrently, retrying challenge sync", namespace, name)
+	}
+	return err
+}
+
+// cleanupCustom removes the Challenge's token from the ConfigMap configured
+// on the custom HTTP01 solver. A missing ConfigMap is not an error.
+func (s *Solver) cleanupCustom(ctx context.Context, ch *cmacme.Challenge) error {
+	if ch.Spec.Solver.HTTP01 == nil || ch.Spec.Solver.HTTP01.Custom == nil {
+		return nil
+	}
+	namespace, name := customConfigMapRef(ch)
+	log := logf.FromContext(ctx).WithName("cleanupCustom").WithValues("configmap", namespace+"/"+name)
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"data": map[string]interface{}{ch.Spec.Token: nil},
+	})
+	if err != nil {
+		return err
+	}
+
+	log.V(logf.DebugLevel).Info("removing HTTP01 challenge key authorization")
+	_, err = s.Client.CoreV1().ConfigMaps(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
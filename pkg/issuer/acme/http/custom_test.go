@@ -0,0 +1,166 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+)
+
+func customChallenge() *cmacme.Challenge {
+	return &cmacme.Challenge{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-challenge",
+			Namespace: defaultTestNamespace,
+		},
+		Spec: cmacme.ChallengeSpec{
+			DNSName: "example.com",
+			Token:   "token",
+			Key:     "key",
+			Solver: cmacme.ACMEChallengeSolver{
+				HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+					Custom: &cmacme.ACMEChallengeSolverHTTP01Custom{
+						ConfigMapName: "acme-challenges",
+					},
+				},
+			},
+		},
+	}
+}
+
+func buildCustomSolver(t *testing.T, objects ...runtime.Object) (*Solver, *testpkg.Builder) {
+	b := &testpkg.Builder{T: t, KubeObjects: objects}
+	b.InitWithRESTConfig()
+	s, err := NewSolver(b.Context)
+	require.NoError(t, err)
+	b.Start()
+	t.Cleanup(b.Stop)
+	return s, b
+}
+
+func TestCustomPresent(t *testing.T) {
+	tests := map[string]struct {
+		chal              *cmacme.Challenge
+		existing          []runtime.Object
+		expectedNamespace string
+		expectedData      map[string]string
+	}{
+		"should create the ConfigMap if it does not exist": {
+			chal:              customChallenge(),
+			expectedNamespace: defaultTestNamespace,
+			expectedData:      map[string]string{"token": "key"},
+		},
+		"should add the key authorization to an existing ConfigMap without removing other entries": {
+			chal: customChallenge(),
+			existing: []runtime.Object{&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "acme-challenges", Namespace: defaultTestNamespace},
+				Data:       map[string]string{"other": "value"},
+			}},
+			expectedNamespace: defaultTestNamespace,
+			expectedData:      map[string]string{"other": "value", "token": "key"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			s, b := buildCustomSolver(t, test.existing...)
+
+			require.NoError(t, s.Present(context.Background(), nil, test.chal))
+
+			cm, err := b.FakeKubeClient().CoreV1().ConfigMaps(test.expectedNamespace).Get(context.Background(), "acme-challenges", metav1.GetOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedData, cm.Data)
+
+			// No solver resources should be created for a custom solver.
+			for _, action := range b.FakeKubeClient().Actions() {
+				// The informers list and watch the resources they cache.
+				if action.GetVerb() == "list" || action.GetVerb() == "watch" {
+					continue
+				}
+				assert.Equal(t, "configmaps", action.GetResource().Resource, "unexpected action %v", action)
+			}
+		})
+	}
+}
+
+func TestCustomCheck(t *testing.T) {
+	chal := customChallenge()
+	s, b := buildCustomSolver(t)
+	s.requiredPasses = 1
+
+	var checkedURL *url.URL
+	var checkedKey string
+	s.testReachability = func(_ context.Context, u *url.URL, key string, _ []string, _ string) error {
+		// The key authorization must have been published before the
+		// self check is run.
+		cm, err := b.FakeKubeClient().CoreV1().ConfigMaps(defaultTestNamespace).Get(context.Background(), "acme-challenges", metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if cm.Data[chal.Spec.Token] != key {
+			return errors.New("key authorization not published")
+		}
+		checkedURL, checkedKey = u, key
+		return nil
+	}
+
+	require.NoError(t, s.Check(context.Background(), nil, chal))
+	assert.Equal(t, "http://example.com/.well-known/acme-challenge/token", checkedURL.String())
+	assert.Equal(t, "key", checkedKey)
+}
+
+func TestCustomCleanUp(t *testing.T) {
+	tests := map[string]struct {
+		existing     []runtime.Object
+		expectedData map[string]string
+	}{
+		"should remove only the challenge's key authorization": {
+			existing: []runtime.Object{&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "acme-challenges", Namespace: defaultTestNamespace},
+				Data:       map[string]string{"other": "value", "token": "key"},
+			}},
+			expectedData: map[string]string{"other": "value"},
+		},
+		"should not error if the ConfigMap does not exist": {},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			s, b := buildCustomSolver(t, test.existing...)
+
+			require.NoError(t, s.CleanUp(context.Background(), nil, customChallenge()))
+
+			cm, err := b.FakeKubeClient().CoreV1().ConfigMaps(defaultTestNamespace).Get(context.Background(), "acme-challenges", metav1.GetOptions{})
+			if test.expectedData == nil {
+				assert.True(t, apierrors.IsNotFound(err), "expected ConfigMap to not exist, got %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedData, cm.Data)
+		})
+	}
+}
@@ -113,7 +113,18 @@ func (s *Solver) Present(ctx context.Context, issuer v1.GenericIssuer, ch *cmacm
 	log := logf.FromContext(ctx).WithName(loggerName)
 	ctx = logf.NewContext(ctx, log)
 
-	podErr := s.ensurePod(ctx, ch)
+	// The custom solver relies on a user-managed solver to serve the
+	// challenge, so no pod, service or ingress is created for it.
+	if ch.Spec.Solver.HTTP01 != nil && ch.Spec.Solver.HTTP01.Custom != nil {
+		return s.ensureCustom(ctx, ch)
+	}
+
+	var podErr error
+	if group, owner, ok := s.sharedSolverGroup(ch); ok {
+		podErr = s.ensureSharedPod(ctx, ch, group, owner)
+	} else {
+		podErr = s.ensurePod(ctx, ch)
+	}
 	svcName, svcErr := s.ensureService(ctx, ch)
 	if svcErr != nil {
 		return utilerrors.NewAggregate([]error{podErr, svcErr})
@@ -184,10 +195,14 @@ func (s *Solver) Check(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.
 }
 
 // CleanUp will ensure the created service, ingress and pod are clean/deleted of any
-// cert-manager created data.
+// cert-manager created data, and that any key authorization published for a
+// custom solver or a shared solver pod is removed. A shared solver pod is
+// deleted once it no longer serves any challenge.
 func (s *Solver) CleanUp(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
 	var errs []error
+	errs = append(errs, s.cleanupCustom(ctx, ch))
 	errs = append(errs, s.cleanupPods(ctx, ch))
+	errs = append(errs, s.cleanupShared(ctx, ch))
 	errs = append(errs, s.cleanupServices(ctx, ch))
 	errs = append(errs, s.cleanupIngresses(ctx, ch))
 	return utilerrors.NewAggregate(errs)
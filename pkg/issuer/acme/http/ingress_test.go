@@ -497,6 +497,46 @@ func TestEnsureIngress(t *testing.T) {
 				assert.Equal(t, strPtr("nginx"), ingress.Spec.IngressClassName)
 			}),
 		},
+		"name field adds the challenge path to the existing ingress without changing its class": {
+			Builder: &test.Builder{
+				KubeObjects: []runtime.Object{
+					&networkingv1.Ingress{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:        "testingress",
+							Namespace:   defaultTestNamespace,
+							Annotations: map[string]string{"kubernetes.io/ingress.class": "traefik"},
+						},
+						Spec: networkingv1.IngressSpec{
+							IngressClassName: strPtr("nginx"),
+						},
+					},
+				},
+			},
+			Challenge: &cmacme.Challenge{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "testchal",
+					Namespace: defaultTestNamespace,
+				},
+				Spec: cmacme.ChallengeSpec{
+					DNSName: "example.com",
+					Token:   "abcd",
+					Solver: cmacme.ACMEChallengeSolver{HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+						Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+							Name: "testingress",
+						}}},
+				},
+			},
+			CheckFn: func(t *testing.T, s *solverFixture, _ ...interface{}) {
+				ingress, err := s.Builder.FakeKubeClient().NetworkingV1().Ingresses(defaultTestNamespace).Get(context.TODO(), "testingress", metav1.GetOptions{})
+				require.NoError(t, err)
+				assert.Equal(t, map[string]string{"kubernetes.io/ingress.class": "traefik"}, ingress.Annotations)
+				assert.Equal(t, strPtr("nginx"), ingress.Spec.IngressClassName)
+				require.Len(t, ingress.Spec.Rules, 1)
+				assert.Equal(t, "example.com", ingress.Spec.Rules[0].Host)
+				require.Len(t, ingress.Spec.Rules[0].HTTP.Paths, 1)
+				assert.Equal(t, "/.well-known/acme-challenge/abcd", ingress.Spec.Rules[0].HTTP.Paths[0].Path)
+			},
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -28,6 +28,7 @@ import (
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/utils/ptr"
 
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager/validation/util"
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 )
@@ -138,6 +139,10 @@ func (s *Solver) cleanupPods(ctx context.Context, ch *cmacme.Challenge) error {
 // createPod will create a challenge solving pod for the given certificate,
 // domain, token and key.
 func (s *Solver) createPod(ctx context.Context, ch *cmacme.Challenge) (*corev1.Pod, error) {
+	if err := validatePodTemplate(ch); err != nil {
+		return nil, err
+	}
+
 	return s.Client.CoreV1().Pods(ch.Namespace).Create(
 		ctx,
 		s.buildPod(ch),
@@ -160,6 +165,31 @@ func (s *Solver) buildPod(ch *cmacme.Challenge) *corev1.Pod {
 	return pod
 }
 
+// validatePodTemplate validates the image overrides of the challenge's pod
+// template. The webhook validates Issuers as they are created, but Challenges
+// may have been created from an Issuer which was never validated.
+func validatePodTemplate(ch *cmacme.Challenge) error {
+	if ch.Spec.Solver.HTTP01 == nil || ch.Spec.Solver.HTTP01.Ingress == nil || ch.Spec.Solver.HTTP01.Ingress.PodTemplate == nil {
+		return nil
+	}
+	spec := ch.Spec.Solver.HTTP01.Ingress.PodTemplate.Spec
+
+	if len(spec.Image) > 0 {
+		if err := util.ValidImageReference(spec.Image); err != nil {
+			return fmt.Errorf("invalid HTTP01 solver pod template image: %w", err)
+		}
+	}
+
+	switch spec.ImagePullPolicy {
+	case "", corev1.PullAlways, corev1.PullNever, corev1.PullIfNotPresent:
+	default:
+		return fmt.Errorf("invalid HTTP01 solver pod template imagePullPolicy %q, must be one of %q, %q or %q",
+			spec.ImagePullPolicy, corev1.PullAlways, corev1.PullNever, corev1.PullIfNotPresent)
+	}
+
+	return nil
+}
+
 // Note: this function builds pod spec using defaults and any configuration
 // options passed via flags to cert-manager controller.
 // Solver pod configuration via flags is a now deprecated
@@ -292,5 +322,15 @@ func (s *Solver) mergePodObjectMetaWithPodTemplate(pod *corev1.Pod, podTempl *cm
 
 	pod.Spec.ImagePullSecrets = append(pod.Spec.ImagePullSecrets, podTempl.Spec.ImagePullSecrets...)
 
+	// The pod template's image takes precedence over the image configured
+	// with the --acme-http01-solver-image flag.
+	if podTempl.Spec.Image != "" {
+		pod.Spec.Containers[0].Image = podTempl.Spec.Image
+	}
+
+	if podTempl.Spec.ImagePullPolicy != "" {
+		pod.Spec.Containers[0].ImagePullPolicy = podTempl.Spec.ImagePullPolicy
+	}
+
 	return pod
 }
@@ -159,6 +159,27 @@ func TestEnsurePod(t *testing.T) {
 			chal:        chal,
 			expectedErr: true,
 		},
+		"should not create a pod if the template image is invalid": {
+			builder: &testpkg.Builder{
+				PartialMetadataObjects: []runtime.Object{},
+				ExpectedActions:        []testpkg.Action{},
+			},
+			chal: func(ch cmacme.Challenge) *cmacme.Challenge {
+				ch.Spec.Solver = cmacme.ACMEChallengeSolver{
+					HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+						Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+							PodTemplate: &cmacme.ACMEChallengeSolverHTTP01IngressPodTemplate{
+								Spec: cmacme.ACMEChallengeSolverHTTP01IngressPodSpec{
+									Image: "https://registry.internal/acmesolver",
+								},
+							},
+						},
+					},
+				}
+				return &ch
+			}(*chal),
+			expectedErr: true,
+		},
 	}
 	for name, scenario := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -365,6 +386,57 @@ func TestMergePodObjectMetaWithPodTemplate(t *testing.T) {
 				}
 			},
 		},
+		"should use the image overrides in the template over the flag": {
+			Challenge: &cmacme.Challenge{
+				Spec: cmacme.ChallengeSpec{
+					DNSName: "example.com",
+					Solver: cmacme.ACMEChallengeSolver{
+						HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+							Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+								PodTemplate: &cmacme.ACMEChallengeSolverHTTP01IngressPodTemplate{
+									Spec: cmacme.ACMEChallengeSolverHTTP01IngressPodSpec{
+										Image:            "registry.internal:5000/mirror/acmesolver:v1",
+										ImagePullPolicy:  corev1.PullAlways,
+										ImagePullSecrets: []corev1.LocalObjectReference{{Name: "mirror-credentials"}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			PreFn: func(t *testing.T, s *solverFixture) {
+				s.Solver.ACMEOptions.HTTP01SolverImage = "quay.io/jetstack/cert-manager-acmesolver:v1"
+
+				resultingPod := s.Solver.buildDefaultPod(s.Challenge)
+				resultingPod.Spec.Tolerations = []corev1.Toleration{}
+				resultingPod.Spec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: "mirror-credentials"}}
+				resultingPod.Spec.Containers[0].Image = "registry.internal:5000/mirror/acmesolver:v1"
+				resultingPod.Spec.Containers[0].ImagePullPolicy = corev1.PullAlways
+				s.testResources[createdPodKey] = resultingPod
+
+				s.Builder.Sync()
+			},
+			CheckFn: func(t *testing.T, s *solverFixture, args ...interface{}) {
+				resultingPod := s.testResources[createdPodKey].(*corev1.Pod)
+
+				resp, ok := args[0].(*corev1.Pod)
+				if !ok {
+					t.Errorf("expected pod to be returned, but got %v", args[0])
+					t.Fail()
+					return
+				}
+
+				// ignore pointer differences here
+				resultingPod.OwnerReferences = resp.OwnerReferences
+
+				if resp.String() != resultingPod.String() {
+					t.Errorf("unexpected pod generated from merge\nexp=%s\ngot=%s",
+						resultingPod, resp)
+					t.Fail()
+				}
+			},
+		},
 		"should use default if nothing has changed in template": {
 			Challenge: &cmacme.Challenge{
 				Spec: cmacme.ChallengeSpec{
@@ -97,12 +97,16 @@ func (s *Solver) getServicesForChallenge(ctx context.Context, ch *cmacme.Challen
 }
 
 // createService will create the service required to solve this challenge
-// in the target API server.
+// in the target API server. If the challenge is served by a shared solver
+// pod, the service selects that pod.
 func (s *Solver) createService(ctx context.Context, ch *cmacme.Challenge) (*corev1.Service, error) {
 	svc, err := buildService(ch)
 	if err != nil {
 		return nil, err
 	}
+	if group, _, ok := s.sharedSolverGroup(ch); ok {
+		svc.Spec.Selector = sharedPodLabels(group)
+	}
 	return s.Client.CoreV1().Services(ch.Namespace).Create(ctx, svc, metav1.CreateOptions{})
 }
 
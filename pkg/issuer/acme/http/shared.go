@@ -0,0 +1,280 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/adler32"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+const (
+	// sharedChallengesDir is the directory the ConfigMap of a shared solver
+	// pod is mounted at, and which the acmesolver serves key authorizations
+	// from.
+	sharedChallengesDir = "/var/run/acmesolver/challenges"
+
+	sharedChallengesVolumeName = "challenges"
+)
+
+// sharedSolverGroup returns the group of Challenges which are served by the
+// same solver pod as the given Challenge, and a controller reference to the
+// Order the pod and its ConfigMap are owned by. ok is false if the Challenge
+// is served by a pod of its own, which is the case unless shared solver pods
+// are enabled and the Challenge was created by an Order.
+// Challenges of an Order which use different solvers are served by different
+// pods, as the solver determines how the pod is built.
+func (s *Solver) sharedSolverGroup(ch *cmacme.Challenge) (group string, owner *metav1.OwnerReference, ok bool) {
+	if !s.ACMEOptions.HTTP01SolverSharedPods {
+		return "", nil, false
+	}
+	if ch.Spec.Solver.HTTP01 == nil || ch.Spec.Solver.HTTP01.Custom != nil {
+		return "", nil, false
+	}
+	order := metav1.GetControllerOf(ch)
+	if order == nil || order.APIVersion != cmacme.SchemeGroupVersion.String() || order.Kind != cmacme.OrderKind {
+		return "", nil, false
+	}
+	solver, err := json.Marshal(ch.Spec.Solver)
+	if err != nil {
+		return "", nil, false
+	}
+	return fmt.Sprintf("%s-%d", order.UID, adler32.Checksum(solver)), order, true
+}
+
+func sharedPodLabels(group string) map[string]string {
+	return map[string]string{
+		cmacme.SolverIdentificationLabelKey: "true",
+		cmacme.SolverGroupLabelKey:          group,
+	}
+}
+
+func sharedConfigMapName(group string) string {
+	return "cm-acme-http-solver-" + group
+}
+
+// ensureSharedPod publishes the Challenge's token and key authorization to
+// the ConfigMap of its group, and ensures that a solver pod serving the
+// ConfigMap exists.
+func (s *Solver) ensureSharedPod(ctx context.Context, ch *cmacme.Challenge, group string, owner *metav1.OwnerReference) error {
+	log := logf.FromContext(ctx).WithName("ensureSharedPod").WithValues("group", group)
+	ctx = logf.NewContext(ctx, log)
+
+	if err := s.ensureSharedConfigMap(ctx, ch, group, owner); err != nil {
+		return err
+	}
+
+	log.V(logf.DebugLevel).Info("checking for existing shared HTTP01 solver pods")
+	existingPods, err := s.getSharedPods(ch.Namespace, group, owner)
+	if err != nil {
+		return err
+	}
+	if len(existingPods) == 1 {
+		logf.WithRelatedResource(log, existingPods[0]).Info("found one existing shared HTTP01 solver pod")
+		return nil
+	}
+	if len(existingPods) > 1 {
+		log.V(logf.InfoLevel).Info("multiple shared challenge solver pods found. cleaning up all existing pods.")
+		if err := s.deletePods(ctx, existingPods); err != nil {
+			return err
+		}
+		return fmt.Errorf("multiple existing shared challenge solver pods found and cleaned up. retrying challenge sync")
+	}
+
+	log.V(logf.InfoLevel).Info("creating shared HTTP01 challenge solver pod")
+	if err := validatePodTemplate(ch); err != nil {
+		return err
+	}
+	_, err = s.Client.CoreV1().Pods(ch.Namespace).Create(ctx, s.buildSharedPod(ch, group, owner), metav1.CreateOptions{})
+	return err
+}
+
+// ensureSharedConfigMap adds the Challenge's token and key authorization to
+// the ConfigMap of its group, creating the ConfigMap if it does not yet exist.
+func (s *Solver) ensureSharedConfigMap(ctx context.Context, ch *cmacme.Challenge, group string, owner *metav1.OwnerReference) error {
+	name := sharedConfigMapName(group)
+	log := logf.FromContext(ctx).WithValues("configmap", ch.Namespace+"/"+name)
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"data": map[string]interface{}{ch.Spec.Token: ch.Spec.Key},
+	})
+	if err != nil {
+		return err
+	}
+
+	log.V(logf.DebugLevel).Info("publishing HTTP01 challenge key authorization")
+	_, err = s.Client.CoreV1().ConfigMaps(ch.Namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	log.V(logf.DebugLevel).Info("creating shared HTTP01 challenge ConfigMap as it does not exist")
+	_, err = s.Client.CoreV1().ConfigMaps(ch.Namespace).Create(ctx, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       ch.Namespace,
+			Labels:          sharedPodLabels(group),
+			OwnerReferences: []metav1.OwnerReference{*owner},
+		},
+		Data: map[string]string{ch.Spec.Token: ch.Spec.Key},
+	}, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("shared HTTP01 challenge ConfigMap %s/%s was created concurrently, retrying challenge sync", ch.Namespace, name)
+	}
+	return err
+}
+
+// getSharedPods returns the solver pods serving the given group which are
+// owned by its Order.
+func (s *Solver) getSharedPods(namespace, group string, owner *metav1.OwnerReference) ([]*metav1.PartialObjectMetadata, error) {
+	podMetadataList, err := s.podLister.ByNamespace(namespace).List(labels.SelectorFromSet(sharedPodLabels(group)))
+	if err != nil {
+		return nil, err
+	}
+
+	var relevantPods []*metav1.PartialObjectMetadata
+	for _, pod := range podMetadataList {
+		p, ok := pod.(*metav1.PartialObjectMetadata)
+		if !ok {
+			return nil, fmt.Errorf("internal error: cannot cast PartialMetadata: %+#v", pod)
+		}
+		if ref := metav1.GetControllerOf(p); ref == nil || ref.UID != owner.UID {
+			continue
+		}
+		relevantPods = append(relevantPods, p)
+	}
+	return relevantPods, nil
+}
+
+// cleanupShared removes the Challenge's token from the ConfigMap of its
+// group. Once no tokens remain, the ConfigMap and the solver pod serving it
+// are deleted. If a token is added concurrently, the ConfigMap will have
+// changed and is not deleted.
+func (s *Solver) cleanupShared(ctx context.Context, ch *cmacme.Challenge) error {
+	group, owner, ok := s.sharedSolverGroup(ch)
+	if !ok {
+		return nil
+	}
+	name := sharedConfigMapName(group)
+	log := logf.FromContext(ctx).WithName("cleanupShared").WithValues("group", group, "configmap", ch.Namespace+"/"+name)
+	ctx = logf.NewContext(ctx, log)
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"data": map[string]interface{}{ch.Spec.Token: nil},
+	})
+	if err != nil {
+		return err
+	}
+
+	log.V(logf.DebugLevel).Info("removing HTTP01 challenge key authorization")
+	cm, err := s.Client.CoreV1().ConfigMaps(ch.Namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		// The ConfigMap has already been deleted, but the pod may not have
+		// been.
+	case err != nil:
+		return err
+	case len(cm.Data) > 0:
+		log.V(logf.DebugLevel).Info("shared HTTP01 solver pod is still serving other challenges", "challenges", len(cm.Data))
+		return nil
+	default:
+		log.V(logf.InfoLevel).Info("deleting shared HTTP01 challenge ConfigMap as it no longer serves any challenges")
+		err := s.Client.CoreV1().ConfigMaps(ch.Namespace).Delete(ctx, name, metav1.DeleteOptions{
+			Preconditions: &metav1.Preconditions{ResourceVersion: &cm.ResourceVersion},
+		})
+		if apierrors.IsConflict(err) {
+			log.V(logf.DebugLevel).Info("shared HTTP01 challenge ConfigMap was changed concurrently, not deleting it")
+			return nil
+		}
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	pods, err := s.getSharedPods(ch.Namespace, group, owner)
+	if err != nil {
+		return fmt.Errorf("error retrieving shared pods for cleanup: %w", err)
+	}
+	return s.deletePods(ctx, pods)
+}
+
+func (s *Solver) deletePods(ctx context.Context, pods []*metav1.PartialObjectMetadata) error {
+	log := logf.FromContext(ctx)
+
+	var errs []error
+	for _, pod := range pods {
+		log := logf.WithRelatedResource(log, pod)
+		log.V(logf.InfoLevel).Info("deleting pod resource")
+
+		err := s.Client.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			log.V(logf.WarnLevel).Info("failed to delete pod resource", "error", err)
+			errs = append(errs, fmt.Errorf("error deleting pod: %w", err))
+			continue
+		}
+		log.V(logf.InfoLevel).Info("successfully deleted pod resource")
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// buildSharedPod builds a solver pod which serves the key authorizations of
+// every Challenge in the group from the group's ConfigMap, mounted as a
+// volume. The kubelet updates the mounted files as Challenges are added to
+// and removed from the ConfigMap, so the pod does not need to be restarted.
+// It will not create the pod in the API server.
+func (s *Solver) buildSharedPod(ch *cmacme.Challenge, group string, owner *metav1.OwnerReference) *corev1.Pod {
+	pod := s.buildPod(ch)
+
+	delete(pod.Labels, cmacme.DomainLabelKey)
+	delete(pod.Labels, cmacme.TokenLabelKey)
+	for k, v := range sharedPodLabels(group) {
+		pod.Labels[k] = v
+	}
+	pod.OwnerReferences = []metav1.OwnerReference{*owner}
+
+	pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+		Name: sharedChallengesVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: sharedConfigMapName(group)},
+			},
+		},
+	})
+	container := &pod.Spec.Containers[0]
+	container.Args = []string{
+		fmt.Sprintf("--listen-port=%d", acmeSolverListenPort),
+		fmt.Sprintf("--challenges-dir=%s", sharedChallengesDir),
+	}
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      sharedChallengesVolumeName,
+		MountPath: sharedChallengesDir,
+		ReadOnly:  true,
+	})
+
+	return pod
+}
@@ -0,0 +1,242 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	metadatafake "k8s.io/client-go/metadata/fake"
+	"k8s.io/utils/ptr"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+)
+
+func sharedChallenges(order *cmacme.Order, n int) []*cmacme.Challenge {
+	var challenges []*cmacme.Challenge
+	for i := 0; i < n; i++ {
+		challenges = append(challenges, &cmacme.Challenge{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            fmt.Sprintf("test-challenge-%d", i),
+				Namespace:       defaultTestNamespace,
+				UID:             types.UID(fmt.Sprintf("challenge-uid-%d", i)),
+				OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(order, cmacme.SchemeGroupVersion.WithKind(cmacme.OrderKind))},
+			},
+			Spec: cmacme.ChallengeSpec{
+				DNSName: fmt.Sprintf("www%d.example.com", i),
+				Token:   fmt.Sprintf("token-%d", i),
+				Key:     fmt.Sprintf("key-%d", i),
+				Solver: cmacme.ACMEChallengeSolver{
+					HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+						Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+							IngressClassName: ptr.To("nginx"),
+						},
+					},
+				},
+			},
+		})
+	}
+	return challenges
+}
+
+// syncMetadata copies the pods and services of the fake clientset to the
+// fake metadata client, which the solver lists them from, as the two fakes do
+// not share their objects.
+func syncMetadata(t *testing.T, b *testpkg.Builder) {
+	t.Helper()
+	ctx := context.Background()
+
+	pods, err := b.FakeKubeClient().CoreV1().Pods(defaultTestNamespace).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	var podMetas []metav1.ObjectMeta
+	for _, pod := range pods.Items {
+		podMetas = append(podMetas, pod.ObjectMeta)
+	}
+	services, err := b.FakeKubeClient().CoreV1().Services(defaultTestNamespace).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	var serviceMetas []metav1.ObjectMeta
+	for _, svc := range services.Items {
+		serviceMetas = append(serviceMetas, svc.ObjectMeta)
+	}
+
+	replaceMetadata(t, b, "Pod", "pods", podMetas)
+	replaceMetadata(t, b, "Service", "services", serviceMetas)
+	b.Sync()
+}
+
+// replaceMetadata replaces the objects of the given resource in the fake
+// metadata client with the given objects.
+func replaceMetadata(t *testing.T, b *testpkg.Builder, kind, resource string, objects []metav1.ObjectMeta) {
+	t.Helper()
+	ctx := context.Background()
+
+	client := b.FakeMetadataClient().Resource(corev1.SchemeGroupVersion.WithResource(resource)).Namespace(defaultTestNamespace)
+	existing, err := client.List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	for _, obj := range existing.Items {
+		require.NoError(t, client.Delete(ctx, obj.Name, metav1.DeleteOptions{}))
+	}
+	for _, obj := range objects {
+		// The fake would generate a new name for the copy otherwise.
+		obj.GenerateName = ""
+		_, err := client.(metadatafake.MetadataClient).CreateFake(&metav1.PartialObjectMetadata{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: kind},
+			ObjectMeta: obj,
+		}, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+}
+
+func TestSharedSolverPod(t *testing.T) {
+	ctx := context.Background()
+	order := &cmacme.Order{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-order",
+			Namespace: defaultTestNamespace,
+			UID:       "order-uid",
+		},
+	}
+	challenges := sharedChallenges(order, 5)
+
+	b := &testpkg.Builder{T: t}
+	b.InitWithRESTConfig()
+	b.Context.ACMEOptions.HTTP01SolverSharedPods = true
+	b.Context.ACMEOptions.ACMEHTTP01SolverRunAsNonRoot = true
+	s, err := NewSolver(b.Context)
+	require.NoError(t, err)
+	b.Start()
+	defer b.Stop()
+
+	group, _, ok := s.sharedSolverGroup(challenges[0])
+	require.True(t, ok)
+
+	// The pod serves the key authorization of any token which is in the
+	// ConfigMap, so each self check only passes for its own token.
+	s.requiredPasses = 1
+	s.testReachability = func(_ context.Context, u *url.URL, key string, _ []string, _ string) error {
+		cm, err := b.FakeKubeClient().CoreV1().ConfigMaps(defaultTestNamespace).Get(ctx, sharedConfigMapName(group), metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		served, ok := cm.Data[path.Base(u.Path)]
+		if !ok {
+			return fmt.Errorf("no key authorization served for %s", u)
+		}
+		if served != key {
+			return fmt.Errorf("did not get expected response when querying endpoint, expected %q but got: %s", key, served)
+		}
+		return nil
+	}
+
+	listPods := func() []corev1.Pod {
+		t.Helper()
+		pods, err := b.FakeKubeClient().CoreV1().Pods(defaultTestNamespace).List(ctx, metav1.ListOptions{})
+		require.NoError(t, err)
+		return pods.Items
+	}
+
+	for _, ch := range challenges {
+		require.NoError(t, s.Present(ctx, nil, ch))
+		syncMetadata(t, b)
+	}
+
+	pods := listPods()
+	require.Len(t, pods, 1, "expected the challenges to share a single solver pod")
+	pod := pods[0]
+	assert.Equal(t, group, pod.Labels[cmacme.SolverGroupLabelKey])
+	assert.True(t, metav1.IsControlledBy(&pod, order), "expected the pod to be owned by the Order")
+	assert.Equal(t, []string{
+		fmt.Sprintf("--listen-port=%d", acmeSolverListenPort),
+		"--challenges-dir=" + sharedChallengesDir,
+	}, pod.Spec.Containers[0].Args)
+	require.Len(t, pod.Spec.Volumes, 1)
+	assert.Equal(t, sharedConfigMapName(group), pod.Spec.Volumes[0].ConfigMap.Name)
+
+	services, err := b.FakeKubeClient().CoreV1().Services(defaultTestNamespace).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, services.Items, 5, "expected one service per challenge")
+	for _, svc := range services.Items {
+		assert.True(t, labels.SelectorFromSet(svc.Spec.Selector).Matches(labels.Set(pod.Labels)), "expected service %s to select the shared pod", svc.Name)
+	}
+
+	for _, ch := range challenges {
+		require.NoError(t, s.Check(ctx, nil, ch))
+	}
+
+	// The challenges complete one after the other. The pod keeps serving the
+	// remaining challenges until the last one has been cleaned up.
+	for i, ch := range challenges {
+		require.NoError(t, s.CleanUp(ctx, nil, ch))
+		syncMetadata(t, b)
+
+		cm, err := b.FakeKubeClient().CoreV1().ConfigMaps(defaultTestNamespace).Get(ctx, sharedConfigMapName(group), metav1.GetOptions{})
+		if i == len(challenges)-1 {
+			assert.True(t, apierrors.IsNotFound(err), "expected the ConfigMap to be deleted, got %v", err)
+			assert.Empty(t, listPods(), "expected the shared pod to be deleted")
+			break
+		}
+		require.NoError(t, err)
+		assert.Len(t, cm.Data, len(challenges)-i-1)
+		assert.NotContains(t, cm.Data, ch.Spec.Token)
+		assert.Len(t, listPods(), 1)
+
+		for _, remaining := range challenges[i+1:] {
+			require.NoError(t, s.Check(ctx, nil, remaining))
+		}
+		assert.Error(t, s.testReachability(ctx, s.buildChallengeUrl(ch), ch.Spec.Key, nil, ""),
+			"expected the token of a cleaned up challenge to no longer be served")
+	}
+}
+
+func TestSharedSolverGroup(t *testing.T) {
+	order := &cmacme.Order{ObjectMeta: metav1.ObjectMeta{Name: "test-order", Namespace: defaultTestNamespace, UID: "order-uid"}}
+	challenges := sharedChallenges(order, 2)
+	otherSolver := challenges[1].DeepCopy()
+	otherSolver.Spec.Solver.HTTP01.Ingress.IngressClassName = ptr.To("traefik")
+	noOrder := challenges[1].DeepCopy()
+	noOrder.OwnerReferences = nil
+
+	b := &testpkg.Builder{T: t}
+	b.Init()
+	s := &Solver{Context: b.Context}
+
+	_, _, ok := s.sharedSolverGroup(challenges[0])
+	assert.False(t, ok, "challenges must not share pods unless enabled")
+
+	s.ACMEOptions.HTTP01SolverSharedPods = true
+	group0, _, ok := s.sharedSolverGroup(challenges[0])
+	require.True(t, ok)
+	group1, _, _ := s.sharedSolverGroup(challenges[1])
+	assert.Equal(t, group0, group1, "challenges of an Order with the same solver must share a pod")
+	groupOther, _, _ := s.sharedSolverGroup(otherSolver)
+	assert.NotEqual(t, group0, groupOther, "challenges with different solvers must not share a pod")
+	_, _, ok = s.sharedSolverGroup(noOrder)
+	assert.False(t, ok, "challenges not created by an Order must not share a pod")
+	_, _, ok = s.sharedSolverGroup(customChallenge())
+	assert.False(t, ok, "challenges solved by a custom solver must not share a pod")
+}
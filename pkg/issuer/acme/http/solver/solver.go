@@ -17,9 +17,14 @@ limitations under the License.
 package solver
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"net/http"
+	"os"
 	"path"
+	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
 
@@ -37,6 +42,11 @@ const (
 	defaultReadHeaderTimeout = 32 * time.Second
 )
 
+// tokenRegexp matches the base64url alphabet used by ACME challenge tokens.
+// Tokens are used as file names when serving from ChallengesDir, so anything
+// else is rejected to avoid reading files outside of the directory.
+var tokenRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
 type HTTP01Solver struct {
 	ListenPort int
 
@@ -44,6 +54,15 @@ type HTTP01Solver struct {
 	Token  string
 	Key    string
 
+	// ChallengesDir, if set, is a directory containing one file per
+	// challenge token whose contents are the key authorization to respond
+	// with, such as a mounted ConfigMap managed by the custom HTTP01 solver
+	// or by the controller for a solver pod shared between challenges.
+	// The files are read on every request, so that challenges can be added
+	// and removed while the solver is running.
+	// When set, Domain, Token and Key are ignored.
+	ChallengesDir string
+
 	http.Server
 }
 
@@ -52,10 +71,21 @@ func (h *HTTP01Solver) Listen(log logr.Logger) error {
 		"expected_domain", h.Domain,
 		"expected_token", h.Token,
 		"expected_key", h.Key,
+		"challenges_dir", h.ChallengesDir,
 		"listen_port", h.ListenPort,
 	)
 
-	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	h.Server = http.Server{
+		Addr:              fmt.Sprintf(":%d", h.ListenPort),
+		Handler:           h.handler(log),
+		ReadHeaderTimeout: defaultReadHeaderTimeout, // Mitigation for G112: Potential slowloris attack
+	}
+
+	return h.Server.ListenAndServe()
+}
+
+func (h *HTTP01Solver) handler(log logr.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// extract vars from the request
 		host := strings.Split(r.Host, ":")[0]
 		basePath := path.Dir(r.URL.EscapedPath())
@@ -81,6 +111,11 @@ func (h *HTTP01Solver) Listen(log logr.Logger) error {
 			return
 		}
 
+		if h.ChallengesDir != "" {
+			h.serveFromDir(log, w, r, token)
+			return
+		}
+
 		log.Info("comparing host", "expected_host", h.Domain)
 		if h.Domain != host {
 			log.Info("invalid host", "expected_host", h.Domain)
@@ -101,12 +136,31 @@ func (h *HTTP01Solver) Listen(log logr.Logger) error {
 		w.WriteHeader(http.StatusOK)
 		fmt.Fprint(w, h.Key)
 	})
+}
 
-	h.Server = http.Server{
-		Addr:              fmt.Sprintf(":%d", h.ListenPort),
-		Handler:           handler,
-		ReadHeaderTimeout: defaultReadHeaderTimeout, // Mitigation for G112: Potential slowloris attack
+// serveFromDir responds with the key authorization stored in ChallengesDir
+// for the given token, or a 404 if there is none.
+func (h *HTTP01Solver) serveFromDir(log logr.Logger, w http.ResponseWriter, r *http.Request, token string) {
+	if !tokenRegexp.MatchString(token) {
+		log.Info("invalid token")
+		http.NotFound(w, r)
+		return
 	}
 
-	return h.Server.ListenAndServe()
+	key, err := os.ReadFile(filepath.Join(h.ChallengesDir, token))
+	if errors.Is(err, fs.ErrNotExist) {
+		log.Info("no key found for token", "challenges_dir", h.ChallengesDir)
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		log.Error(err, "failed to read key for token", "challenges_dir", h.ChallengesDir)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	log.Info("got successful challenge request, writing key")
+	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(key)
 }
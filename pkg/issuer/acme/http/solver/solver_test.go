@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package solver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "dir-token"), []byte("dir-key"), 0600))
+
+	tests := map[string]struct {
+		solver       *HTTP01Solver
+		host         string
+		path         string
+		expectedCode int
+		expectedBody string
+	}{
+		"should respond with the key for the expected token": {
+			solver:       &HTTP01Solver{Domain: "example.com", Token: "token", Key: "key"},
+			host:         "example.com",
+			path:         HTTPChallengePath + "/token",
+			expectedCode: http.StatusOK,
+			expectedBody: "key",
+		},
+		"should 404 for an unexpected host": {
+			solver:       &HTTP01Solver{Domain: "example.com", Token: "token", Key: "key"},
+			host:         "other.com",
+			path:         HTTPChallengePath + "/token",
+			expectedCode: http.StatusNotFound,
+		},
+		"should 404 for an unexpected token": {
+			solver:       &HTTP01Solver{Domain: "example.com", Token: "token", Key: "key"},
+			host:         "example.com",
+			path:         HTTPChallengePath + "/other",
+			expectedCode: http.StatusNotFound,
+		},
+		"should respond with the key from the challenges directory for any host": {
+			solver:       &HTTP01Solver{ChallengesDir: dir},
+			host:         "any.example.com",
+			path:         HTTPChallengePath + "/dir-token",
+			expectedCode: http.StatusOK,
+			expectedBody: "dir-key",
+		},
+		"should 404 for a token missing from the challenges directory": {
+			solver:       &HTTP01Solver{ChallengesDir: dir},
+			host:         "example.com",
+			path:         HTTPChallengePath + "/missing",
+			expectedCode: http.StatusNotFound,
+		},
+		"should 404 for a token that is not a valid file name": {
+			solver:       &HTTP01Solver{ChallengesDir: dir},
+			host:         "example.com",
+			path:         HTTPChallengePath + "/..%2Fsecret",
+			expectedCode: http.StatusNotFound,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "http://"+test.host+test.path, nil)
+			rec := httptest.NewRecorder()
+
+			test.solver.handler(logr.Discard()).ServeHTTP(rec, req)
+
+			assert.Equal(t, test.expectedCode, rec.Code)
+			if test.expectedCode == http.StatusOK {
+				assert.Equal(t, test.expectedBody, rec.Body.String())
+			}
+		})
+	}
+}
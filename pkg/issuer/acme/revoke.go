@@ -0,0 +1,47 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/acme"
+
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
+)
+
+var _ issuer.Revoker = &Acme{}
+
+// Revoke revokes the certificate with the ACME server, signing the request
+// with the account key of the issuer.
+func (a *Acme) Revoke(ctx context.Context, crt *v1.Certificate, cert *x509.Certificate) error {
+	if cert == nil {
+		return errors.New("the certificate is required for revocation with an ACME server but was not found in the Secret")
+	}
+
+	cl, err := a.accountRegistry.GetClient(string(a.issuer.GetUID()))
+	if err != nil {
+		return fmt.Errorf("failed to get ACME client: %w", err)
+	}
+
+	// A nil key causes the account key to be used.
+	return cl.RevokeCert(ctx, nil, cert.Raw, acme.CRLReasonCessationOfOperation)
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	acmeapi "golang.org/x/crypto/acme"
+
+	fakeregistry "github.com/cert-manager/cert-manager/pkg/acme/accounts/test"
+	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestRevoke(t *testing.T) {
+	cert := &x509.Certificate{Raw: []byte("certificate")}
+
+	tests := map[string]struct {
+		cert         *x509.Certificate
+		getClientErr error
+		revokeErr    error
+
+		expRevoked bool
+		expErr     string
+	}{
+		"the certificate in the Secret is revoked with the account key": {
+			cert:       cert,
+			expRevoked: true,
+		},
+		"an error is returned if the certificate is not in the Secret": {
+			expErr: "the certificate is required for revocation with an ACME server but was not found in the Secret",
+		},
+		"an error is returned if the issuer has no registered ACME client": {
+			cert:         cert,
+			getClientErr: errors.New("ACME client for issuer not initialised/available"),
+			expErr:       "failed to get ACME client: ACME client for issuer not initialised/available",
+		},
+		"revocation errors are returned": {
+			cert:       cert,
+			revokeErr:  errors.New("failed to revoke"),
+			expRevoked: true,
+			expErr:     "failed to revoke",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var revoked []byte
+			cl := &acmecl.FakeACME{
+				FakeRevokeCert: func(_ context.Context, key crypto.Signer, der []byte, reason acmeapi.CRLReasonCode) error {
+					assert.Nil(t, key, "the account key should be used")
+					assert.Equal(t, acmeapi.CRLReasonCessationOfOperation, reason)
+					revoked = der
+					return test.revokeErr
+				},
+			}
+
+			a := &Acme{
+				issuer: gen.Issuer("acme-issuer", gen.SetIssuerUID("issuer-uid")),
+				accountRegistry: &fakeregistry.FakeRegistry{
+					GetClientFunc: func(uid string) (acmecl.Interface, error) {
+						assert.Equal(t, "issuer-uid", uid)
+						if test.getClientErr != nil {
+							return nil, test.getClientErr
+						}
+						return cl, nil
+					},
+				},
+			}
+
+			err := a.Revoke(context.TODO(), gen.Certificate("test"), test.cert)
+			if test.expErr != "" {
+				assert.EqualError(t, err, test.expErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expRevoked, revoked != nil)
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"crypto/x509"
 	"encoding/base64"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strings"
 
@@ -31,10 +32,12 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	internalissuers "github.com/cert-manager/cert-manager/internal/controller/issuers"
 	"github.com/cert-manager/cert-manager/pkg/acme"
 	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
 	"github.com/cert-manager/cert-manager/pkg/acme/client"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
@@ -43,28 +46,38 @@ import (
 )
 
 const (
-	errorAccountRegistrationFailed = "ErrRegisterACMEAccount"
-	errorAccountVerificationFailed = "ErrVerifyACMEAccount"
-	errorAccountUpdateFailed       = "ErrUpdateACMEAccount"
-	errorInvalidConfig             = "InvalidConfig"
-	errorInvalidURL                = "InvalidURL"
+	errorAccountRegistrationFailed       = "ErrRegisterACMEAccount"
+	errorAccountVerificationFailed       = "ErrVerifyACMEAccount"
+	errorAccountUpdateFailed             = "ErrUpdateACMEAccount"
+	errorInvalidConfig                   = "InvalidConfig"
+	errorInvalidURL                      = "InvalidURL"
+	errorInvalidTLSConfig                = "InvalidTLSConfig"
+	errorACMEServerTLSVerificationFailed = "ACMEServerTLSVerificationFailed"
+	errorAccountDeactivated              = "ACMEAccountDeactivated"
+	errorACMEServerUnavailable           = "ACMEServerUnavailable"
 
 	successAccountRegistered = "ACMEAccountRegistered"
 	successAccountVerified   = "ACMEAccountVerified"
 
-	messageAccountRegistrationFailed     = "Failed to register ACME account: "
-	messageAccountVerificationFailed     = "Failed to verify ACME account: "
-	messageAccountUpdateFailed           = "Failed to update ACME account:"
-	messageAccountRegistered             = "The ACME account was registered with the ACME server"
-	messageAccountVerified               = "The ACME account was verified with the ACME server"
-	messageNoSecretKeyGenerationDisabled = "the ACME issuer config has 'disableAccountKeyGeneration' set to true, but the secret was not found: "
-	messageInvalidPrivateKey             = "Account private key is invalid: "
+	messageAccountRegistrationFailed       = "Failed to register ACME account: "
+	messageAccountVerificationFailed       = "Failed to verify ACME account: "
+	messageAccountUpdateFailed             = "Failed to update ACME account:"
+	messageAccountRegistered               = "The ACME account was registered with the ACME server"
+	messageAccountVerified                 = "The ACME account was verified with the ACME server"
+	messageNoSecretKeyGenerationDisabled   = "the ACME issuer config has 'disableAccountKeyGeneration' set to true, but the secret was not found: "
+	messageInvalidPrivateKey               = "Account private key is invalid: "
+	messageInvalidTLSConfig                = "Invalid TLS configuration: "
+	messageACMEServerTLSVerificationFailed = "Failed to verify the TLS certificate of the ACME server: "
+	messageACMEServerUnavailable           = "The ACME account could not be verified with the ACME server, pending Orders and Challenges are retried once it responds: "
 
 	messageTemplateUpdateToV2              = "Your ACME server URL is set to a v1 endpoint (%s). You should update the spec.acme.server field to %q"
 	messageTemplateNotRSA                  = "ACME private key in %q is not of type RSA"
 	messageTemplateFailedToParseURL        = "Failed to parse existing ACME server URI %q: %v"
 	messageTemplateFailedToParseAccountURL = "Failed to parse existing ACME account URI %q: %v"
 	messageTemplateFailedToGetEABKey       = "failed to get External Account Binding key from secret: %v"
+	messageTemplateFailedToGetCABundle     = "failed to get CA bundle from secret: %v"
+	messageTemplateAccountDeactivated      = "The ACME account has been %s by the ACME server. Certificates cannot be issued by this issuer until " +
+		"a new ACME account is registered, which requires a new account private key"
 )
 
 // Setup will verify an existing ACME registration, or create one if not
@@ -102,6 +115,33 @@ func (a *Acme) Setup(ctx context.Context) error {
 
 	log = logf.WithRelatedResourceName(log, a.issuer.GetSpec().ACME.PrivateKey.Name, ns, "Secret")
 
+	// Issuers sharing the ACME account private key but registering the
+	// account with different parameters would keep updating the account on
+	// the ACME server. The issuer already holding the registration stays
+	// Ready, and only the other issuers stop registering the account until
+	// the conflict is resolved.
+	conflicting, err := a.conflictingIssuers()
+	if err != nil {
+		reason = errorAccountVerificationFailed
+		msg = messageAccountVerificationFailed + err.Error()
+		return fmt.Errorf(msg)
+	}
+	if len(conflicting) > 0 {
+		reason = errorSharedAccountKeyConflict
+		msg = fmt.Sprintf(messageTemplateSharedAccountKeyConflict, a.issuer.GetSpec().ACME.PrivateKey.Name, strings.Join(conflicting, ", "))
+		apiutil.SetIssuerCondition(a.issuer,
+			a.issuer.GetGeneration(),
+			v1.IssuerConditionSharedAccountKeyConflict,
+			cmmeta.ConditionTrue,
+			reason,
+			msg)
+		a.recorder.Event(a.issuer, corev1.EventTypeWarning, errorSharedAccountKeyConflict, msg)
+		// absorb errors as the conflicting issuers are re-synced when any
+		// of them is updated.
+		return nil
+	}
+	apiutil.RemoveIssuerCondition(a.issuer, v1.IssuerConditionSharedAccountKeyConflict)
+
 	// attempt to obtain the existing private key from the apiserver.
 	// if it does not exist then we generate one
 	// if it contains invalid data, warn the user and return without error.
@@ -160,7 +200,36 @@ func (a *Acme) Setup(ctx context.Context) error {
 	// this function.
 	a.accountRegistry.RemoveClient(string(a.issuer.GetUID()))
 
-	httpClient := accounts.BuildHTTPClientWithCABundle(a.metrics, a.issuer.GetSpec().ACME.SkipTLSVerify, a.issuer.GetSpec().ACME.CABundle)
+	// The issuer is re-synced whenever the Secret containing the CA bundle
+	// changes, so that a rotated CA bundle is used without a restart.
+	var secretCABundle []byte
+	if ref := a.issuer.GetSpec().ACME.CABundleSecretRef; ref != nil {
+		key := ref.Key
+		if key == "" {
+			key = cmmeta.TLSCAKey
+		}
+		secretCABundle, err = a.caBundleFromSecret(ctx, ns, ref.Name, key)
+		switch {
+		case apierrors.IsNotFound(err), errors.IsInvalidData(err):
+			reason = errorInvalidTLSConfig
+			msg = messageInvalidTLSConfig + fmt.Sprintf(messageTemplateFailedToGetCABundle, err)
+			// absorb errors as the issuer is re-synced when the Secret is
+			// created or updated
+			return nil
+		case err != nil:
+			reason = errorInvalidTLSConfig
+			msg = messageInvalidTLSConfig + fmt.Sprintf(messageTemplateFailedToGetCABundle, err)
+			return fmt.Errorf(msg)
+		}
+	}
+
+	httpClient, err := accounts.BuildHTTPClientForIssuer(a.metrics, a.issuer.GetSpec().ACME, secretCABundle)
+	if err != nil {
+		reason = errorInvalidTLSConfig
+		msg = messageInvalidTLSConfig + err.Error()
+		// absorb errors as retrying will not help resolve this error
+		return nil
+	}
 
 	cl := a.clientBuilder(httpClient, *a.issuer.GetSpec().ACME, rsaPk, a.userAgent)
 
@@ -196,17 +265,59 @@ func (a *Acme) Setup(ctx context.Context) error {
 		Status: cmmeta.ConditionTrue,
 	})
 
+	// A deactivated or revoked account cannot be used to issue certificates,
+	// so the issuer is not Ready and the client is not added to the account
+	// registry. As the account cannot be re-activated, this is not retried.
+	accountDeactivated := func(accountStatus string) error {
+		a.recordAccountStatus(accountStatus)
+		reason = errorAccountDeactivated
+		msg = fmt.Sprintf(messageTemplateAccountDeactivated, accountStatus)
+		log.V(logf.WarnLevel).Info("the ACME account has been deactivated by the ACME server", "status", accountStatus)
+		a.recorder.Event(a.issuer, corev1.EventTypeWarning, errorAccountDeactivated, msg)
+		return nil
+	}
+
 	// If the Host components of the server URL and the account URL match,
 	// and the cached email matches the registered email, then
 	// we skip re-checking the account status to save excess calls to the
-	// ACME api.
+	// ACME api. Issuers loading their CA bundle from a Secret always
+	// re-check it, so that a rotated CA bundle which does not verify the
+	// TLS certificate of the ACME server is reported.
 	if hasReadyCondition &&
+		a.issuer.GetSpec().ACME.CABundleSecretRef == nil &&
 		a.issuer.GetStatus().ACMEStatus().URI != "" &&
 		parsedAccountURL.Host == parsedServerURL.Host &&
 		a.issuer.GetStatus().ACMEStatus().LastRegisteredEmail == a.issuer.GetSpec().ACME.Email &&
 		isPKChecksumSame {
-		log.V(logf.InfoLevel).Info("skipping re-verifying ACME account as cached registration " +
-			"details look sufficient")
+		// The status of the account is still verified periodically, as
+		// the ACME server may deactivate or revoke it at any time.
+		var verifyErr error
+		if a.accountVerificationDue() {
+			log.V(logf.InfoLevel).Info("verifying the status of the ACME account with the ACME server")
+			account, err := cl.GetReg(ctx, a.issuer.GetStatus().ACMEStatus().URI)
+			if accountStatus, ok := deactivatedAccountStatus(err); ok {
+				return accountDeactivated(accountStatus)
+			}
+			switch {
+			case err != nil:
+				// The cached registration is kept, as failing to reach the
+				// ACME server does not mean that the account is not valid.
+				verifyErr = fmt.Errorf("failed to verify the status of the ACME account: %w", err)
+				apiutil.SetIssuerCondition(a.issuer,
+					a.issuer.GetGeneration(),
+					v1.IssuerConditionACMEServerUnavailable,
+					cmmeta.ConditionTrue,
+					errorACMEServerUnavailable,
+					messageACMEServerUnavailable+err.Error())
+			case isAccountDeactivated(account.Status):
+				return accountDeactivated(account.Status)
+			default:
+				a.recordAccountStatus(account.Status)
+			}
+		} else {
+			log.V(logf.InfoLevel).Info("skipping re-verifying ACME account as cached registration " +
+				"details look sufficient")
+		}
 
 		// Updating issuer's Ready condition here will ensure that observed
 		// generation gets bumped correctly if this re-sync was triggered by a
@@ -218,7 +329,7 @@ func (a *Acme) Setup(ctx context.Context) error {
 
 		// ensure the cached client in the account registry is up to date
 		a.accountRegistry.AddClient(httpClient, string(a.issuer.GetUID()), *a.issuer.GetSpec().ACME, rsaPk, a.userAgent)
-		return nil
+		return verifyErr
 	}
 
 	if parsedAccountURL.Host != parsedServerURL.Host {
@@ -264,6 +375,19 @@ func (a *Acme) Setup(ctx context.Context) error {
 		msg = messageAccountRegistrationFailed + err.Error()
 		log.Error(err, "failed to register an ACME account")
 
+		if accountStatus, ok := deactivatedAccountStatus(err); ok {
+			return accountDeactivated(accountStatus)
+		}
+
+		// A TLS verification failure is most likely caused by a CA bundle
+		// which does not contain the root of the ACME server's certificate.
+		// It is retried, as the certificate of the ACME server may change.
+		if verifyErr := tlsVerificationError(err); verifyErr != nil {
+			reason = errorACMEServerTLSVerificationFailed
+			msg = messageACMEServerTLSVerificationFailed + verifyErr.Error()
+			return err
+		}
+
 		acmeErr, ok := err.(*acmeapi.Error)
 		// If this is not an ACME error, we will simply return it and retry later
 		if !ok {
@@ -282,6 +406,9 @@ func (a *Acme) Setup(ctx context.Context) error {
 		// Otherwise if we receive anything other than a 400, we will retry.
 		return err
 	}
+	if isAccountDeactivated(account.Status) {
+		return accountDeactivated(account.Status)
+	}
 
 	// if we got an account successfully, we must check if the registered
 	// email is the same as in the issuer spec
@@ -322,12 +449,81 @@ func (a *Acme) Setup(ctx context.Context) error {
 	a.issuer.GetStatus().ACMEStatus().URI = account.URI
 	a.issuer.GetStatus().ACMEStatus().LastRegisteredEmail = registeredEmail
 	a.issuer.GetStatus().ACMEStatus().LastPrivateKeyHash = checksumString
+	a.recordAccountStatus(account.Status)
+	// The directory has already been discovered while registering the
+	// account, so this does not result in another request to the server.
+	if dir, err := cl.Discover(ctx); err != nil {
+		log.V(logf.DebugLevel).Info("failed to discover ACME directory", "error", err)
+	} else {
+		a.issuer.GetStatus().ACMEStatus().Directory = &cmacme.ACMEIssuerDirectoryStatus{
+			NewNonce:   dir.NonceURL,
+			NewAccount: dir.RegURL,
+			NewOrder:   dir.OrderURL,
+			RevokeCert: dir.RevokeURL,
+			KeyChange:  dir.KeyChangeURL,
+		}
+	}
 	// ensure the cached client in the account registry is up to date
 	a.accountRegistry.AddClient(httpClient, string(a.issuer.GetUID()), *a.issuer.GetSpec().ACME, rsaPk, a.userAgent)
 
 	return nil
 }
 
+// accountVerificationDue returns true if the status of the ACME account has
+// not been verified with the ACME server within the account verification
+// interval.
+func (a *Acme) accountVerificationDue() bool {
+	// While the ACME server is unavailable, it is probed on every sync so
+	// that pending Orders and Challenges are retried soon after it responds.
+	if internalissuers.ACMEServerUnavailable(a.issuer) {
+		return true
+	}
+	if a.accountVerificationInterval <= 0 {
+		return false
+	}
+	lastVerified := a.issuer.GetStatus().ACMEStatus().LastVerifiedTime
+	return lastVerified == nil || !a.clock.Now().Before(lastVerified.Add(a.accountVerificationInterval))
+}
+
+// recordAccountStatus records the status of the ACME account reported by the
+// ACME server on the issuer's status and in the metrics. Some ACME servers
+// do not return the status of accounts, which are then valid. As the ACME
+// server has responded, the ACMEServerUnavailable condition is removed.
+func (a *Acme) recordAccountStatus(accountStatus string) {
+	apiutil.RemoveIssuerCondition(a.issuer, v1.IssuerConditionACMEServerUnavailable)
+	if accountStatus == "" {
+		accountStatus = acmeapi.StatusValid
+	}
+	now := metav1.NewTime(a.clock.Now())
+	a.issuer.GetStatus().ACMEStatus().AccountStatus = accountStatus
+	a.issuer.GetStatus().ACMEStatus().LastVerifiedTime = &now
+	a.metrics.UpdateACMEAccountStatus(a.issuer, accountStatus)
+}
+
+func isAccountDeactivated(accountStatus string) bool {
+	return accountStatus == acmeapi.StatusDeactivated || accountStatus == acmeapi.StatusRevoked
+}
+
+// deactivatedAccountStatus returns the status of the ACME account if the error
+// returned by the ACME server reports that the account has been deactivated or
+// revoked. ACME servers reject requests signed with the key of such an account
+// as unauthorized rather than returning the account (RFC 8555, section 7.3.6),
+// so the status can only be found in the detail of the error.
+func deactivatedAccountStatus(err error) (string, bool) {
+	acmeErr, ok := err.(*acmeapi.Error)
+	if !ok || acmeErr.StatusCode != http.StatusForbidden || acmeErr.ProblemType != "urn:ietf:params:acme:error:unauthorized" {
+		return "", false
+	}
+	detail := strings.ToLower(acmeErr.Detail)
+	switch {
+	case strings.Contains(detail, acmeapi.StatusRevoked):
+		return acmeapi.StatusRevoked, true
+	case strings.Contains(detail, acmeapi.StatusDeactivated):
+		return acmeapi.StatusDeactivated, true
+	}
+	return "", false
+}
+
 func ensureEmailUpToDate(ctx context.Context, cl client.Interface, acc *acmeapi.Account, specEmail string) (*acmeapi.Account, string, error) {
 	log := logf.FromContext(ctx)
 
@@ -32,6 +32,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
 	fakeclock "k8s.io/utils/clock/testing"
 
 	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
@@ -42,6 +43,7 @@ import (
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	controllertest "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
 	"github.com/cert-manager/cert-manager/pkg/util/errors"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	"github.com/cert-manager/cert-manager/test/unit/coreclients"
@@ -568,6 +570,8 @@ func TestAcme_Setup(t *testing.T) {
 				keyFromSecret:   kfs,
 				clientBuilder:   clientBuilderMock(&cl),
 				recorder:        recorder,
+				metrics:         metrics.New(klog.Background(), fakeclock),
+				clock:           fakeclock,
 			}
 
 			// Stub the clock to get consistent last transition times on conditions.
@@ -620,6 +624,169 @@ func TestAcme_Setup(t *testing.T) {
 	}
 }
 
+func TestAcme_SetupAccountVerification(t *testing.T) {
+	const (
+		interval   = time.Hour
+		accountURI = "https://acme-v02.api.letsencrypt.org/acme/acct/1"
+		email      = "test@example.com"
+	)
+	clock := fakeclock.NewFakeClock(time.Now())
+	apiutil.Clock = clock
+
+	// The fake ACME server returns the account with accountStatus, and
+	// rejects the registration of a deactivated account as unauthorized, as
+	// ACME servers do.
+	var (
+		accountStatus = acmeapi.StatusValid
+		getRegErr     error
+		getRegCalls   int
+	)
+	cl := &acmecl.FakeACME{
+		FakeRegister: func(context.Context, *acmeapi.Account, func(string) bool) (*acmeapi.Account, error) {
+			if isAccountDeactivated(accountStatus) {
+				return nil, &acmeapi.Error{
+					StatusCode:  http.StatusForbidden,
+					ProblemType: "urn:ietf:params:acme:error:unauthorized",
+					Detail:      fmt.Sprintf("Account is not valid, has status %q", accountStatus),
+				}
+			}
+			return nil, acmeapi.ErrAccountAlreadyExists
+		},
+		FakeGetReg: func(context.Context, string) (*acmeapi.Account, error) {
+			getRegCalls++
+			if getRegErr != nil {
+				return nil, getRegErr
+			}
+			return &acmeapi.Account{URI: accountURI, Status: accountStatus, Contact: []string{"mailto:" + email}}, nil
+		},
+	}
+
+	clientCached := false
+	recorder := new(controllertest.FakeRecorder)
+	a := Acme{
+		issuer: gen.Issuer("test-issuer",
+			gen.SetIssuerACMEURL(acmev2Prod),
+			gen.SetIssuerACMEEmail(email)),
+		recorder: recorder,
+		accountRegistry: &fakeregistry.FakeRegistry{
+			RemoveClientFunc:        func(string) { clientCached = false },
+			AddClientFunc:           func(string, cmacme.ACMEIssuer, *rsa.PrivateKey, string) { clientCached = true },
+			IsKeyCheckSumCachedFunc: func(string, *rsa.PrivateKey) bool { return true },
+		},
+		keyFromSecret:               keyFromSecretMockBuilder(new(bool), mustGenerateRSAKey(t), nil),
+		clientBuilder:               clientBuilderMock(cl),
+		metrics:                     metrics.New(klog.Background(), clock),
+		accountVerificationInterval: interval,
+		clock:                       clock,
+	}
+
+	readyCondition := func() *cmapi.IssuerCondition {
+		for _, cond := range a.issuer.GetStatus().Conditions {
+			if cond.Type == cmapi.IssuerConditionReady {
+				return &cond
+			}
+		}
+		return nil
+	}
+
+	// setup runs Setup and checks the resulting status of the issuer.
+	setup := func(expErr bool, expReady cmmeta.ConditionStatus, expReason, expAccountStatus string, expLastVerified time.Time, expGetRegCalls int) {
+		t.Helper()
+		err := a.Setup(context.Background())
+		if expErr != (err != nil) {
+			t.Fatalf("expected error: %v, got: %v", expErr, err)
+		}
+		ready := readyCondition()
+		if ready == nil || ready.Status != expReady || ready.Reason != expReason {
+			t.Fatalf("expected Ready condition with status %s and reason %s, got: %+v", expReady, expReason, ready)
+		}
+		acmeStatus := a.issuer.GetStatus().ACMEStatus()
+		if acmeStatus.AccountStatus != expAccountStatus {
+			t.Errorf("expected account status %q, got %q", expAccountStatus, acmeStatus.AccountStatus)
+		}
+		if acmeStatus.LastVerifiedTime == nil || !acmeStatus.LastVerifiedTime.Time.Equal(expLastVerified) {
+			t.Errorf("expected the account to have been last verified at %v, got %v", expLastVerified, acmeStatus.LastVerifiedTime)
+		}
+		if getRegCalls != expGetRegCalls {
+			t.Errorf("expected the account to have been fetched %d times, got %d", expGetRegCalls, getRegCalls)
+		}
+		if clientCached != (expReady == cmmeta.ConditionTrue) {
+			t.Errorf("expected the ACME client to be cached only if the issuer is Ready, cached: %v", clientCached)
+		}
+	}
+
+	// The account is verified when it is registered.
+	registered := clock.Now()
+	setup(false, cmmeta.ConditionTrue, successAccountRegistered, acmeapi.StatusValid, registered, 1)
+
+	// The cached registration is used until the account is due to be
+	// verified again.
+	clock.Step(interval / 2)
+	setup(false, cmmeta.ConditionTrue, successAccountRegistered, acmeapi.StatusValid, registered, 1)
+	clock.Step(interval / 2)
+	verified := clock.Now()
+	setup(false, cmmeta.ConditionTrue, successAccountRegistered, acmeapi.StatusValid, verified, 2)
+
+	serverUnavailable := func() bool {
+		return apiutil.IssuerHasCondition(a.issuer, cmapi.IssuerCondition{
+			Type:   cmapi.IssuerConditionACMEServerUnavailable,
+			Status: cmmeta.ConditionTrue,
+		})
+	}
+	if serverUnavailable() {
+		t.Fatalf("expected no ACMEServerUnavailable condition whilst the ACME server responds")
+	}
+
+	// Failing to reach the ACME server does not make the issuer not Ready,
+	// but is retried and reported with the ACMEServerUnavailable condition.
+	clock.Step(interval)
+	getRegErr = fmt.Errorf("connection refused")
+	setup(true, cmmeta.ConditionTrue, successAccountRegistered, acmeapi.StatusValid, verified, 3)
+	if !serverUnavailable() {
+		t.Fatalf("expected the ACMEServerUnavailable condition to be set whilst the ACME server is unreachable")
+	}
+
+	// Once the ACME server responds again the condition is removed, which
+	// retries the pending Orders and Challenges of the issuer.
+	clock.Step(time.Minute)
+	getRegErr = nil
+	recovered := clock.Now()
+	setup(false, cmmeta.ConditionTrue, successAccountRegistered, acmeapi.StatusValid, recovered, 4)
+	if serverUnavailable() {
+		t.Fatalf("expected the ACMEServerUnavailable condition to be removed once the ACME server responds")
+	}
+
+	// An issuer marked unavailable by the orders or challenges controllers
+	// is probed before the account is due to be verified again.
+	clock.Step(time.Minute)
+	apiutil.SetIssuerCondition(a.issuer, a.issuer.GetGeneration(), cmapi.IssuerConditionACMEServerUnavailable, cmmeta.ConditionTrue, "ACMEServerUnavailable", "")
+	probed := clock.Now()
+	setup(false, cmmeta.ConditionTrue, successAccountRegistered, acmeapi.StatusValid, probed, 5)
+	if serverUnavailable() {
+		t.Fatalf("expected the ACMEServerUnavailable condition to be removed once the ACME server responds")
+	}
+
+	// The ACME server deactivates the account.
+	clock.Step(interval)
+	accountStatus = acmeapi.StatusDeactivated
+	deactivated := clock.Now()
+	setup(false, cmmeta.ConditionFalse, errorAccountDeactivated, acmeapi.StatusDeactivated, deactivated, 6)
+
+	expectedMessage := fmt.Sprintf(messageTemplateAccountDeactivated, acmeapi.StatusDeactivated)
+	if ready := readyCondition(); ready.Message != expectedMessage {
+		t.Errorf("expected Ready condition message %q, got %q", expectedMessage, ready.Message)
+	}
+	expectedEvents := []string{fmt.Sprintf("%s %s %s", corev1.EventTypeWarning, errorAccountDeactivated, expectedMessage)}
+	if !slices.Equal(expectedEvents, recorder.Events) {
+		t.Errorf("expected events:\n%+#v\ngot:%+#v", expectedEvents, recorder.Events)
+	}
+
+	// As the issuer is no longer Ready, the account is registered again,
+	// which the ACME server rejects.
+	clock.Step(interval)
+	setup(false, cmmeta.ConditionFalse, errorAccountDeactivated, acmeapi.StatusDeactivated, clock.Now(), 6)
+}
+
 // keyFromSecretMockBuilder returns a mock implementation of keyFromSecretFunc.
 func keyFromSecretMockBuilder(wasCalled *bool, key crypto.Signer, err error) keyFromSecretFunc {
 	return func(context.Context, string, string, string) (crypto.Signer, error) {
@@ -0,0 +1,202 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	acmeapi "golang.org/x/crypto/acme"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	fakeregistry "github.com/cert-manager/cert-manager/pkg/acme/accounts/test"
+	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	controllertest "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestAcme_SetupCABundleSecretRef(t *testing.T) {
+	acmeServer := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer acmeServer.Close()
+	// Every httptest server uses the same certificate, so the CA which does
+	// not verify the ACME server is an unrelated self-signed certificate.
+	otherCABundle := testcrypto.MustCreateCertWithNotBeforeAfter(t, testcrypto.MustCreatePEMPrivateKey(t),
+		gen.Certificate("other-ca", gen.SetCertificateCommonName("other-ca")), time.Now(), time.Now().Add(time.Hour))
+
+	caBundle := func(server *httptest.Server) []byte {
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	}
+
+	tests := map[string]struct {
+		inlineCABundle []byte
+		caBundle       []byte
+		caBundleErr    error
+
+		expectedErr    bool
+		expectedStatus cmmeta.ConditionStatus
+		expectedReason string
+	}{
+		"the ACME server is trusted if the CA bundle in the Secret contains its CA": {
+			caBundle:       caBundle(acmeServer),
+			expectedStatus: cmmeta.ConditionTrue,
+			expectedReason: successAccountRegistered,
+		},
+		"the CA bundle in the Secret is trusted in addition to the inline CA bundle": {
+			inlineCABundle: otherCABundle,
+			caBundle:       caBundle(acmeServer),
+			expectedStatus: cmmeta.ConditionTrue,
+			expectedReason: successAccountRegistered,
+		},
+		"a CA bundle in the Secret which does not verify the ACME server is reported": {
+			caBundle:       otherCABundle,
+			expectedErr:    true,
+			expectedStatus: cmmeta.ConditionFalse,
+			expectedReason: errorACMEServerTLSVerificationFailed,
+		},
+		"a missing Secret is reported without retrying": {
+			caBundleErr:    apierrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "ca-bundle"),
+			expectedStatus: cmmeta.ConditionFalse,
+			expectedReason: errorInvalidTLSConfig,
+		},
+		"other errors getting the Secret are retried": {
+			caBundleErr:    errors.New("some error"),
+			expectedErr:    true,
+			expectedStatus: cmmeta.ConditionFalse,
+			expectedReason: errorInvalidTLSConfig,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			issuer := gen.Issuer("test-issuer",
+				gen.SetIssuerNamespace("default"),
+				gen.SetIssuerACMEURL(acmeServer.URL),
+				gen.SetIssuerACMEEmail("test@example.com"),
+				gen.SetIssuerACMEPrivKeyRef("account-key"),
+				func(iss cmapi.GenericIssuer) {
+					iss.GetSpec().ACME.CABundle = test.inlineCABundle
+					iss.GetSpec().ACME.CABundleSecretRef = &cmmeta.SecretKeySelector{
+						LocalObjectReference: cmmeta.LocalObjectReference{Name: "ca-bundle"},
+					}
+				})
+
+			// The fake ACME client registers the account with a request to
+			// the ACME server, using the HTTP client built from the issuer's
+			// TLS settings.
+			clientBuilder := func(httpClient *http.Client, _ cmacme.ACMEIssuer, _ *rsa.PrivateKey, _ string) acmecl.Interface {
+				return &acmecl.FakeACME{
+					FakeRegister: func(ctx context.Context, a *acmeapi.Account, _ func(string) bool) (*acmeapi.Account, error) {
+						resp, err := httpClient.Get(acmeServer.URL)
+						if err != nil {
+							return nil, err
+						}
+						resp.Body.Close()
+						return a, nil
+					},
+				}
+			}
+
+			var gotKey string
+			a := Acme{
+				issuer:   issuer,
+				recorder: new(controllertest.FakeRecorder),
+				metrics:  metrics.New(klog.Background(), clock.RealClock{}),
+				accountRegistry: &fakeregistry.FakeRegistry{
+					RemoveClientFunc:        func(string) {},
+					AddClientFunc:           func(string, cmacme.ACMEIssuer, *rsa.PrivateKey, string) {},
+					IsKeyCheckSumCachedFunc: func(string, *rsa.PrivateKey) bool { return true },
+				},
+				keyFromSecret: keyFromSecretMockBuilder(new(bool), mustGenerateRSAKey(t), nil),
+				caBundleFromSecret: func(_ context.Context, namespace, name, key string) ([]byte, error) {
+					assert.Equal(t, "default", namespace)
+					assert.Equal(t, "ca-bundle", name)
+					gotKey = key
+					return test.caBundle, test.caBundleErr
+				},
+				clientBuilder: clientBuilder,
+				clock:         clock.RealClock{},
+			}
+			apiutil.Clock = fakeclock.NewFakeClock(time.Now())
+
+			err := a.Setup(context.Background())
+			if test.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			// The CA bundle is read from the ca.crt key by default.
+			assert.Equal(t, cmmeta.TLSCAKey, gotKey)
+
+			conditions := a.issuer.GetStatus().Conditions
+			require.Len(t, conditions, 1)
+			assert.Equal(t, cmapi.IssuerConditionReady, conditions[0].Type)
+			assert.Equal(t, test.expectedStatus, conditions[0].Status)
+			assert.Equal(t, test.expectedReason, conditions[0].Reason)
+		})
+	}
+}
+
+func TestTLSVerificationError(t *testing.T) {
+	unknownAuthorityErr := x509.UnknownAuthorityError{}
+	hostnameErr := x509.HostnameError{Host: "acme.example.com"}
+
+	tests := map[string]struct {
+		err      error
+		expected error
+	}{
+		"an unknown authority is a TLS verification failure": {
+			err:      fmt.Errorf("Post \"https://acme.example.com\": %w", unknownAuthorityErr),
+			expected: unknownAuthorityErr,
+		},
+		"a hostname mismatch is a TLS verification failure": {
+			err:      fmt.Errorf("Post \"https://acme.example.com\": %w", hostnameErr),
+			expected: hostnameErr,
+		},
+		"an ACME error is not a TLS verification failure": {
+			err: &acmeapi.Error{StatusCode: 400},
+		},
+		"a network error is not a TLS verification failure": {
+			err: errors.New("connection refused"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, tlsVerificationError(test.err))
+		})
+	}
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ca
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+const (
+	// CRLKey is the key of the PEM encoded CRL in the Secret and ConfigMap
+	// in which the CRL of a CA issuer is stored.
+	CRLKey = "ca.crl"
+
+	// DefaultCRLUpdateInterval is the interval at which the CRL of a CA
+	// issuer is signed again if none is configured.
+	DefaultCRLUpdateInterval = 24 * time.Hour
+)
+
+// CRLUpdateInterval returns the interval at which the given CRL is signed
+// again.
+func CRLUpdateInterval(crl *v1.CAIssuerCRL) time.Duration {
+	if crl.UpdateInterval == nil {
+		return DefaultCRLUpdateInterval
+	}
+	return crl.UpdateInterval.Duration
+}
+
+// SignCRL returns a PEM encoded CRL listing the given revoked certificates,
+// signed by the given CA at the time now. The nextUpdate of the CRL is set to
+// twice the update interval after now, so that it remains valid if signing it
+// again is delayed.
+func SignCRL(caCert *x509.Certificate, caKey crypto.Signer, revoked []v1.CAIssuerRevokedCertificate, number *big.Int, now time.Time, interval time.Duration) ([]byte, error) {
+	entries := make([]x509.RevocationListEntry, 0, len(revoked))
+	for _, r := range revoked {
+		serial, err := pki.ParseSerialNumber(r.SerialNumber)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, x509.RevocationListEntry{
+			SerialNumber:   serial,
+			RevocationTime: r.RevocationTime.UTC(),
+		})
+	}
+
+	template := &x509.RevocationList{
+		RevokedCertificateEntries: entries,
+		Number:                    number,
+		ThisUpdate:                now.UTC(),
+		NextUpdate:                now.Add(2 * interval).UTC(),
+	}
+	der, err := x509.CreateRevocationList(rand.Reader, template, caCert, caKey)
+	if err != nil {
+		return nil, fmt.Errorf("error signing CRL: %w", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: der}), nil
+}
+
+// UnexpiredRevokedCertificates returns the given revoked certificates which
+// have not expired at the time now. Expired certificates need not be listed
+// in a CRL, as they are rejected anyway (RFC 5280, section 3.3), so they are
+// removed from the status of the issuer and from its CRL. Certificates whose
+// expiry time is not known are kept.
+func UnexpiredRevokedCertificates(revoked []v1.CAIssuerRevokedCertificate, now time.Time) []v1.CAIssuerRevokedCertificate {
+	unexpired := make([]v1.CAIssuerRevokedCertificate, 0, len(revoked))
+	for _, r := range revoked {
+		if r.NotAfter == nil || now.Before(r.NotAfter.Time) {
+			unexpired = append(unexpired, r)
+		}
+	}
+	return unexpired
+}
+
+// NextRevokedCertificateExpiry returns the earliest expiry time of the given
+// revoked certificates which is after now, or false if there is none.
+func NextRevokedCertificateExpiry(revoked []v1.CAIssuerRevokedCertificate, now time.Time) (time.Time, bool) {
+	var next time.Time
+	for _, r := range revoked {
+		if r.NotAfter == nil || !now.Before(r.NotAfter.Time) {
+			continue
+		}
+		if next.IsZero() || r.NotAfter.Time.Before(next) {
+			next = r.NotAfter.Time
+		}
+	}
+	return next, !next.IsZero()
+}
+
+// ParseCRL parses a PEM encoded CRL.
+func ParseCRL(crlPEM []byte) (*x509.RevocationList, error) {
+	block, _ := pem.Decode(crlPEM)
+	if block == nil || block.Type != "X509 CRL" {
+		return nil, errors.New("error decoding CRL PEM block")
+	}
+	return x509.ParseRevocationList(block.Bytes)
+}
+
+// CRLNeedsUpdate returns true if the given CRL must be signed again: because
+// there is none, it was not signed by the given CA (e.g. because the CA has
+// been rotated), it does not list exactly the given revoked certificates, or
+// the update interval has elapsed since it was signed.
+func CRLNeedsUpdate(crl *x509.RevocationList, caCert *x509.Certificate, revoked []v1.CAIssuerRevokedCertificate, now time.Time, interval time.Duration) bool {
+	if crl == nil {
+		return true
+	}
+	if !bytes.Equal(crl.RawIssuer, caCert.RawSubject) || crl.CheckSignatureFrom(caCert) != nil {
+		return true
+	}
+	if !now.Before(crl.ThisUpdate.Add(interval)) {
+		return true
+	}
+
+	listed := make(map[string]struct{}, len(crl.RevokedCertificateEntries))
+	for _, entry := range crl.RevokedCertificateEntries {
+		listed[pki.FormatSerialNumber(entry.SerialNumber)] = struct{}{}
+	}
+	if len(listed) != len(revoked) {
+		return true
+	}
+	for _, r := range revoked {
+		serial, err := pki.ParseSerialNumber(r.SerialNumber)
+		if err != nil {
+			return true
+		}
+		if _, ok := listed[pki.FormatSerialNumber(serial)]; !ok {
+			return true
+		}
+	}
+	return false
+}
+
+// NextCRLNumber returns the CRL number of a CRL replacing the given one,
+// which may be nil. CRL numbers must increase monotonically, including when
+// the previous CRL has been lost, so the number is never lower than the
+// current Unix time.
+func NextCRLNumber(crl *x509.RevocationList, now time.Time) *big.Int {
+	number := big.NewInt(now.Unix())
+	if crl != nil && crl.Number != nil && crl.Number.Cmp(number) >= 0 {
+		number.Add(crl.Number, big.NewInt(1))
+	}
+	return number
+}
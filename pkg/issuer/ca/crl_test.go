@@ -0,0 +1,208 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ca
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+func mustCreateCA(t *testing.T, commonName string) (*x509.Certificate, crypto.Signer) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert, key
+}
+
+func TestSignCRL(t *testing.T) {
+	caCert, caKey := mustCreateCA(t, "ca")
+	now := time.Now().Truncate(time.Second)
+	revoked := []cmapi.CAIssuerRevokedCertificate{
+		{SerialNumber: "0a", RevocationTime: metav1.NewTime(now.Add(-time.Hour))},
+		{SerialNumber: "1f:02:9a", RevocationTime: metav1.NewTime(now.Add(-time.Minute))},
+	}
+
+	crlPEM, err := SignCRL(caCert, caKey, revoked, big.NewInt(42), now, time.Hour)
+	require.NoError(t, err)
+
+	crl, err := ParseCRL(crlPEM)
+	require.NoError(t, err)
+	assert.NoError(t, crl.CheckSignatureFrom(caCert))
+	assert.Equal(t, 0, crl.Number.Cmp(big.NewInt(42)))
+	assert.True(t, crl.ThisUpdate.Equal(now))
+	assert.True(t, crl.NextUpdate.Equal(now.Add(2*time.Hour)))
+
+	require.Len(t, crl.RevokedCertificateEntries, 2)
+	assert.Equal(t, 0, crl.RevokedCertificateEntries[0].SerialNumber.Cmp(big.NewInt(0x0a)))
+	assert.True(t, crl.RevokedCertificateEntries[0].RevocationTime.Equal(now.Add(-time.Hour)))
+	assert.Equal(t, 0, crl.RevokedCertificateEntries[1].SerialNumber.Cmp(big.NewInt(0x1f029a)))
+	assert.True(t, crl.RevokedCertificateEntries[1].RevocationTime.Equal(now.Add(-time.Minute)))
+
+	_, err = SignCRL(caCert, caKey, []cmapi.CAIssuerRevokedCertificate{{SerialNumber: "zz"}}, big.NewInt(1), now, time.Hour)
+	assert.Error(t, err)
+}
+
+func TestCRLNeedsUpdate(t *testing.T) {
+	caCert, caKey := mustCreateCA(t, "ca")
+	rotatedCert, _ := mustCreateCA(t, "ca")
+	otherCert, otherKey := mustCreateCA(t, "other-ca")
+
+	signedAt := time.Now().Truncate(time.Second)
+	revoked := []cmapi.CAIssuerRevokedCertificate{
+		{SerialNumber: "0a", RevocationTime: metav1.NewTime(signedAt.Add(-time.Hour))},
+	}
+	crlPEM, err := SignCRL(caCert, caKey, revoked, big.NewInt(1), signedAt, time.Hour)
+	require.NoError(t, err)
+	crl, err := ParseCRL(crlPEM)
+	require.NoError(t, err)
+
+	otherPEM, err := SignCRL(otherCert, otherKey, revoked, big.NewInt(1), signedAt, time.Hour)
+	require.NoError(t, err)
+	otherCRL, err := ParseCRL(otherPEM)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		crl     *x509.RevocationList
+		caCert  *x509.Certificate
+		revoked []cmapi.CAIssuerRevokedCertificate
+		now     time.Time
+
+		expected bool
+	}{
+		"a CRL is signed if there is none": {
+			caCert:   caCert,
+			revoked:  revoked,
+			now:      signedAt,
+			expected: true,
+		},
+		"an up to date CRL is kept": {
+			crl:     crl,
+			caCert:  caCert,
+			revoked: revoked,
+			now:     signedAt.Add(time.Hour - time.Second),
+		},
+		"serial numbers are compared regardless of their format": {
+			crl:     crl,
+			caCert:  caCert,
+			revoked: []cmapi.CAIssuerRevokedCertificate{{SerialNumber: "0A"}},
+			now:     signedAt,
+		},
+		"the CRL is signed again once the update interval has elapsed": {
+			crl:      crl,
+			caCert:   caCert,
+			revoked:  revoked,
+			now:      signedAt.Add(time.Hour),
+			expected: true,
+		},
+		"the CRL is signed again when a certificate is revoked": {
+			crl:    crl,
+			caCert: caCert,
+			revoked: append([]cmapi.CAIssuerRevokedCertificate{
+				{SerialNumber: "0b", RevocationTime: metav1.NewTime(signedAt)},
+			}, revoked...),
+			now:      signedAt,
+			expected: true,
+		},
+		"the CRL is signed again when a revocation is removed": {
+			crl:      crl,
+			caCert:   caCert,
+			now:      signedAt,
+			expected: true,
+		},
+		"the CRL is invalidated by the rotation of the CA key": {
+			crl:      crl,
+			caCert:   rotatedCert,
+			revoked:  revoked,
+			now:      signedAt,
+			expected: true,
+		},
+		"a CRL signed by another CA is replaced": {
+			crl:      otherCRL,
+			caCert:   caCert,
+			revoked:  revoked,
+			now:      signedAt,
+			expected: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, CRLNeedsUpdate(test.crl, test.caCert, test.revoked, test.now, time.Hour))
+		})
+	}
+}
+
+func TestNextCRLNumber(t *testing.T) {
+	now := time.Unix(1000, 0)
+
+	assert.Equal(t, int64(1000), NextCRLNumber(nil, now).Int64())
+	assert.Equal(t, int64(1000), NextCRLNumber(&x509.RevocationList{Number: big.NewInt(5)}, now).Int64())
+	assert.Equal(t, int64(1001), NextCRLNumber(&x509.RevocationList{Number: big.NewInt(1000)}, now).Int64())
+	assert.Equal(t, int64(2001), NextCRLNumber(&x509.RevocationList{Number: big.NewInt(2000)}, now).Int64())
+}
+
+func TestUnexpiredRevokedCertificates(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	expired := metav1.NewTime(now)
+	unexpired := metav1.NewTime(now.Add(time.Second))
+	revoked := []cmapi.CAIssuerRevokedCertificate{
+		{SerialNumber: "01", NotAfter: &expired},
+		{SerialNumber: "02", NotAfter: &unexpired},
+		{SerialNumber: "03"},
+	}
+
+	assert.Equal(t, revoked[1:], UnexpiredRevokedCertificates(revoked, now))
+	assert.Empty(t, UnexpiredRevokedCertificates(nil, now))
+
+	next, ok := NextRevokedCertificateExpiry(revoked, now)
+	assert.True(t, ok)
+	assert.Equal(t, unexpired.Time, next)
+
+	_, ok = NextRevokedCertificateExpiry(revoked, unexpired.Time)
+	assert.False(t, ok)
+}
+
+func TestCRLUpdateInterval(t *testing.T) {
+	assert.Equal(t, DefaultCRLUpdateInterval, CRLUpdateInterval(&cmapi.CAIssuerCRL{}))
+	assert.Equal(t, time.Hour, CRLUpdateInterval(&cmapi.CAIssuerCRL{UpdateInterval: &metav1.Duration{Duration: time.Hour}}))
+}
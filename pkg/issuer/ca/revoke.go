@@ -0,0 +1,110 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ca
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+var _ issuer.Revoker = &CA{}
+
+// Revoke records the certificate as revoked in the status of the issuer, from
+// which it is listed in the issuer's CRL the next time it is signed. The
+// serial number and expiry time recorded in the Certificate's status are used
+// if the certificate is no longer stored in the Secret. Revoked certificates
+// which have expired are removed from the status at the same time.
+// Revocation is only supported by CA issuers which generate a CRL.
+func (c *CA) Revoke(ctx context.Context, crt *v1.Certificate, cert *x509.Certificate) error {
+	if c.issuer.GetSpec().CA.CRL == nil {
+		return fmt.Errorf("no CRL is configured for the CA issuer: %w", issuer.ErrRevocationNotSupported)
+	}
+
+	serialNumber, notAfter := crt.Status.SerialNumber, crt.Status.NotAfter
+	if cert != nil {
+		serialNumber = pki.FormatSerialNumber(cert.SerialNumber)
+		notAfter = &metav1.Time{Time: cert.NotAfter}
+	}
+	if serialNumber == "" {
+		return errors.New("no serial number has been recorded for the certificate")
+	}
+	serial, err := pki.ParseSerialNumber(serialNumber)
+	if err != nil {
+		return err
+	}
+	serialNumber = pki.FormatSerialNumber(serial)
+
+	// The revoked certificates are read from and written to the API server
+	// directly, so that concurrent revocations are not lost.
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		iss, err := c.getIssuer(ctx)
+		if err != nil {
+			return err
+		}
+
+		status := iss.GetStatus()
+		if status.CA == nil {
+			status.CA = &v1.CAIssuerStatus{}
+		}
+		for _, revoked := range status.CA.RevokedCertificates {
+			if revoked.SerialNumber == serialNumber {
+				return nil
+			}
+		}
+		now := c.Clock.Now()
+		status.CA.RevokedCertificates = append(UnexpiredRevokedCertificates(status.CA.RevokedCertificates, now), v1.CAIssuerRevokedCertificate{
+			SerialNumber:   serialNumber,
+			RevocationTime: metav1.NewTime(now),
+			NotAfter:       notAfter,
+		})
+
+		return c.updateIssuerStatus(ctx, iss)
+	})
+}
+
+func (c *CA) getIssuer(ctx context.Context) (v1.GenericIssuer, error) {
+	switch iss := c.issuer.(type) {
+	case *v1.Issuer:
+		return c.CMClient.CertmanagerV1().Issuers(iss.Namespace).Get(ctx, iss.Name, metav1.GetOptions{})
+	case *v1.ClusterIssuer:
+		return c.CMClient.CertmanagerV1().ClusterIssuers().Get(ctx, iss.Name, metav1.GetOptions{})
+	default:
+		return nil, fmt.Errorf("unexpected issuer type %T", c.issuer)
+	}
+}
+
+func (c *CA) updateIssuerStatus(ctx context.Context, iss v1.GenericIssuer) error {
+	var err error
+	switch iss := iss.(type) {
+	case *v1.Issuer:
+		_, err = c.CMClient.CertmanagerV1().Issuers(iss.Namespace).UpdateStatus(ctx, iss, metav1.UpdateOptions{})
+	case *v1.ClusterIssuer:
+		_, err = c.CMClient.CertmanagerV1().ClusterIssuers().UpdateStatus(ctx, iss, metav1.UpdateOptions{})
+	default:
+		err = fmt.Errorf("unexpected issuer type %T", iss)
+	}
+	return err
+}
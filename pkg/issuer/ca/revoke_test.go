@@ -0,0 +1,157 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ca
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	"github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestRevoke(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	earlier := metav1.NewTime(now.Add(-time.Hour))
+	later := metav1.NewTime(now.Add(time.Hour))
+	crl := &cmapi.CAIssuerCRL{SecretName: "ca-crl"}
+
+	tests := map[string]struct {
+		issuer      cmapi.GenericIssuer
+		certificate *cmapi.Certificate
+		cert        *x509.Certificate
+
+		expRevoked []cmapi.CAIssuerRevokedCertificate
+		expErr     string
+		expNotSupp bool
+	}{
+		"the serial number of the certificate in the Secret is recorded": {
+			issuer:      gen.Issuer("ca", gen.SetIssuerNamespace("default"), gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca", CRL: crl})),
+			certificate: gen.Certificate("test", gen.SetCertificateSerialNumber("0a")),
+			cert:        &x509.Certificate{SerialNumber: big.NewInt(0x1f029a), NotAfter: later.Time},
+			expRevoked: []cmapi.CAIssuerRevokedCertificate{
+				{SerialNumber: "1f:02:9a", RevocationTime: metav1.NewTime(now), NotAfter: &later},
+			},
+		},
+		"the recorded serial number is used if the certificate is not in the Secret": {
+			issuer:      gen.ClusterIssuer("ca", gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca", CRL: crl})),
+			certificate: gen.Certificate("test", gen.SetCertificateSerialNumber("0a")),
+			expRevoked: []cmapi.CAIssuerRevokedCertificate{
+				{SerialNumber: "0a", RevocationTime: metav1.NewTime(now)},
+			},
+		},
+		"the recorded expiry time is used if the certificate is not in the Secret": {
+			issuer:      gen.ClusterIssuer("ca", gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca", CRL: crl})),
+			certificate: gen.Certificate("test", gen.SetCertificateSerialNumber("0a"), gen.SetCertificateNotAfter(later)),
+			expRevoked: []cmapi.CAIssuerRevokedCertificate{
+				{SerialNumber: "0a", RevocationTime: metav1.NewTime(now), NotAfter: &later},
+			},
+		},
+		"expired revoked certificates are removed": {
+			issuer: gen.Issuer("ca", gen.SetIssuerNamespace("default"), gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca", CRL: crl}),
+				func(iss cmapi.GenericIssuer) {
+					iss.GetStatus().CA = &cmapi.CAIssuerStatus{RevokedCertificates: []cmapi.CAIssuerRevokedCertificate{
+						{SerialNumber: "01", RevocationTime: earlier, NotAfter: &earlier},
+						{SerialNumber: "02", RevocationTime: earlier, NotAfter: &later},
+						{SerialNumber: "03", RevocationTime: earlier},
+					}}
+				}),
+			certificate: gen.Certificate("test", gen.SetCertificateSerialNumber("0a")),
+			expRevoked: []cmapi.CAIssuerRevokedCertificate{
+				{SerialNumber: "02", RevocationTime: earlier, NotAfter: &later},
+				{SerialNumber: "03", RevocationTime: earlier},
+				{SerialNumber: "0a", RevocationTime: metav1.NewTime(now)},
+			},
+		},
+		"revocations are appended to those already recorded": {
+			issuer: gen.Issuer("ca", gen.SetIssuerNamespace("default"), gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca", CRL: crl}),
+				func(iss cmapi.GenericIssuer) {
+					iss.GetStatus().CA = &cmapi.CAIssuerStatus{RevokedCertificates: []cmapi.CAIssuerRevokedCertificate{
+						{SerialNumber: "01", RevocationTime: earlier},
+					}}
+				}),
+			certificate: gen.Certificate("test", gen.SetCertificateSerialNumber("0a")),
+			expRevoked: []cmapi.CAIssuerRevokedCertificate{
+				{SerialNumber: "01", RevocationTime: earlier},
+				{SerialNumber: "0a", RevocationTime: metav1.NewTime(now)},
+			},
+		},
+		"a certificate which has already been revoked keeps its revocation time": {
+			issuer: gen.Issuer("ca", gen.SetIssuerNamespace("default"), gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca", CRL: crl}),
+				func(iss cmapi.GenericIssuer) {
+					iss.GetStatus().CA = &cmapi.CAIssuerStatus{RevokedCertificates: []cmapi.CAIssuerRevokedCertificate{
+						{SerialNumber: "0a", RevocationTime: earlier},
+					}}
+				}),
+			certificate: gen.Certificate("test", gen.SetCertificateSerialNumber("0a")),
+			expRevoked: []cmapi.CAIssuerRevokedCertificate{
+				{SerialNumber: "0a", RevocationTime: earlier},
+			},
+		},
+		"revocation is not supported without a CRL": {
+			issuer:      gen.Issuer("ca", gen.SetIssuerNamespace("default"), gen.SetIssuerCASecretName("ca")),
+			certificate: gen.Certificate("test", gen.SetCertificateSerialNumber("0a")),
+			expErr:      "no CRL is configured for the CA issuer: issuer does not support revocation",
+			expNotSupp:  true,
+		},
+		"an error is returned if no serial number is known": {
+			issuer:      gen.Issuer("ca", gen.SetIssuerNamespace("default"), gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca", CRL: crl})),
+			certificate: gen.Certificate("test"),
+			expErr:      "no serial number has been recorded for the certificate",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := cmfake.NewSimpleClientset(test.issuer)
+			c := &CA{
+				Context: &controller.Context{
+					CMClient: client,
+					ContextOptions: controller.ContextOptions{
+						Clock: fakeclock.NewFakeClock(now),
+					},
+				},
+				issuer: test.issuer,
+			}
+
+			err := c.Revoke(context.TODO(), test.certificate, test.cert)
+			if test.expErr != "" {
+				assert.EqualError(t, err, test.expErr)
+				assert.Equal(t, test.expNotSupp, errors.Is(err, issuer.ErrRevocationNotSupported))
+				return
+			}
+			require.NoError(t, err)
+
+			iss, err := c.getIssuer(context.TODO())
+			require.NoError(t, err)
+			require.NotNil(t, iss.GetStatus().CA)
+			assert.Equal(t, test.expRevoked, iss.GetStatus().CA.RevokedCertificates)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ca
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// NotGrantedError is returned when a CA issuer references a Secret in another
+// namespace which does not grant the namespace of the issuer access to it.
+type NotGrantedError struct {
+	SecretNamespace, SecretName string
+	IssuerNamespace             string
+}
+
+func (e *NotGrantedError) Error() string {
+	return fmt.Sprintf("secret %s/%s does not grant access to namespace %q in its %q annotation",
+		e.SecretNamespace, e.SecretName, e.IssuerNamespace, cmapi.AllowedIssuerNamespacesAnnotationKey)
+}
+
+// IsNotGranted returns true if err is a NotGrantedError.
+func IsNotGranted(err error) bool {
+	var notGranted *NotGrantedError
+	return errors.As(err, &notGranted)
+}
+
+// SigningSecret returns the namespace and name of the Secret containing the
+// signing CA keypair of the given CA issuer. resourceNamespace is the
+// namespace of the Issuer, or the cluster resource namespace for
+// ClusterIssuers.
+// A Secret in another namespace must grant resourceNamespace access to it,
+// which is checked every time it is called so that revoking the grant takes
+// effect immediately; a NotGrantedError is returned if it does not.
+func SigningSecret(secretsLister internalinformers.SecretLister, issuer cmapi.GenericIssuer, resourceNamespace string) (string, string, error) {
+	spec := issuer.GetSpec().CA
+	if spec.SecretRef == nil {
+		return resourceNamespace, spec.SecretName, nil
+	}
+
+	namespace, name := spec.SecretRef.Namespace, spec.SecretRef.Name
+	if namespace == "" || namespace == resourceNamespace {
+		return resourceNamespace, name, nil
+	}
+
+	secret, err := secretsLister.Secrets(namespace).Get(name)
+	if err != nil {
+		return namespace, name, err
+	}
+	for _, allowed := range strings.Split(secret.Annotations[cmapi.AllowedIssuerNamespacesAnnotationKey], ",") {
+		if strings.TrimSpace(allowed) == resourceNamespace {
+			return namespace, name, nil
+		}
+	}
+	return namespace, name, &NotGrantedError{SecretNamespace: namespace, SecretName: name, IssuerNamespace: resourceNamespace}
+}
@@ -29,12 +29,14 @@ import (
 )
 
 const (
-	errorGetKeyPair     = "ErrGetKeyPair"
-	errorInvalidKeyPair = "ErrInvalidKeyPair"
+	errorGetKeyPair       = "ErrGetKeyPair"
+	errorInvalidKeyPair   = "ErrInvalidKeyPair"
+	errorSecretNotGranted = "ErrSecretNotGranted"
 
 	successKeyPairVerified = "KeyPairVerified"
 
-	messageErrorGetKeyPair = "Error getting keypair for CA issuer: "
+	messageErrorGetKeyPair       = "Error getting keypair for CA issuer: "
+	messageErrorSecretNotGranted = "Signing CA secret may not be used by this issuer: "
 
 	messageKeyPairVerified = "Signing CA verified"
 )
@@ -43,7 +45,18 @@ const (
 func (c *CA) Setup(ctx context.Context) error {
 	log := logf.FromContext(ctx, "setup")
 
-	cert, err := kube.SecretTLSCert(ctx, c.secretsLister, c.resourceNamespace, c.issuer.GetSpec().CA.SecretName)
+	secretNamespace, secretName, err := SigningSecret(c.secretsLister, c.issuer, c.resourceNamespace)
+	if IsNotGranted(err) {
+		log.Error(err, "signing CA secret does not grant access to the issuer")
+		s := messageErrorSecretNotGranted + err.Error()
+		c.Recorder.Event(c.issuer, corev1.EventTypeWarning, errorSecretNotGranted, s)
+		apiutil.SetIssuerCondition(c.issuer, c.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorSecretNotGranted, s)
+		// Don't return an error here as the issuer is synced again once the
+		// Secret changes
+		return nil
+	}
+
+	cert, err := kube.SecretTLSCert(ctx, c.secretsLister, secretNamespace, secretName)
 	if err != nil {
 		log.Error(err, "error getting signing CA TLS certificate")
 		s := messageErrorGetKeyPair + err.Error()
@@ -52,7 +65,7 @@ func (c *CA) Setup(ctx context.Context) error {
 		return err
 	}
 
-	_, err = kube.SecretTLSKey(ctx, c.secretsLister, c.resourceNamespace, c.issuer.GetSpec().CA.SecretName)
+	_, err = kube.SecretTLSKey(ctx, c.secretsLister, secretNamespace, secretName)
 	if err != nil {
 		log.Error(err, "error getting signing CA private key")
 		s := messageErrorGetKeyPair + err.Error()
@@ -61,7 +74,7 @@ func (c *CA) Setup(ctx context.Context) error {
 		return err
 	}
 
-	log = logf.WithRelatedResourceName(log, c.issuer.GetSpec().CA.SecretName, c.resourceNamespace, "Secret")
+	log = logf.WithRelatedResourceName(log, secretName, secretNamespace, "Secret")
 	if !cert.IsCA {
 		s := messageErrorGetKeyPair + "certificate is not a CA"
 		log.Error(nil, "signing certificate is not a CA")
@@ -17,6 +17,7 @@ limitations under the License.
 package issuer
 
 import (
+	"errors"
 	"fmt"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
@@ -24,6 +25,11 @@ import (
 	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
 )
 
+// ErrClusterIssuersDisabled is returned when a ClusterIssuer is referenced
+// while cert-manager is scoped to a single namespace, in which case
+// ClusterIssuers are not supported.
+var ErrClusterIssuersDisabled = errors.New("ClusterIssuers are not supported as cert-manager is scoped to a single namespace")
+
 // Helper is an interface that defines a method that returns an issuer for the given
 // IssuerRef and namespace.
 type Helper interface {
@@ -59,13 +65,10 @@ func (h *helperImpl) GetGenericIssuer(ref cmmeta.ObjectReference, ns string) (cm
 	case "", cmapi.IssuerKind:
 		return h.issuerLister.Issuers(ns).Get(ref.Name)
 	case cmapi.ClusterIssuerKind:
-		// handle edge case where the ClusterIssuerLister is not set.
-		// this isn't actually a supported operating mode right now, nor is it
-		// exposed to users.
-		// we include it here in case we do allow this mode of operation again
-		// in future.
+		// The ClusterIssuerLister is not set when cert-manager is scoped to a
+		// single namespace using --namespace.
 		if h.clusterIssuerLister == nil {
-			return nil, fmt.Errorf("cannot get ClusterIssuer named %q as cert-manager is scoped to a single namespace", ref.Name)
+			return nil, fmt.Errorf("cannot get ClusterIssuer named %q: %w", ref.Name, ErrClusterIssuersDisabled)
 		}
 		return h.clusterIssuerLister.Get(ref.Name)
 	default:
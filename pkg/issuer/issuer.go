@@ -18,6 +18,10 @@ package issuer
 
 import (
 	"context"
+	"crypto/x509"
+	"errors"
+
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 )
 
 type Interface interface {
@@ -27,6 +31,20 @@ type Interface interface {
 	Setup(ctx context.Context) error
 }
 
+// Revoker is implemented by issuers that are able to revoke the certificates
+// they have issued.
+type Revoker interface {
+	// Revoke revokes a certificate issued for the given Certificate resource.
+	// The certificate is nil if it is no longer stored in the Certificate's
+	// Secret, in which case issuers that identify certificates by serial
+	// number may use the serial number recorded in the Certificate's status.
+	Revoke(ctx context.Context, crt *v1.Certificate, cert *x509.Certificate) error
+}
+
+// ErrRevocationNotSupported is returned by Revokers which support revocation
+// only when configured to, e.g. CA issuers which do not generate a CRL.
+var ErrRevocationNotSupported = errors.New("issuer does not support revocation")
+
 type IssueResponse struct {
 	// Certificate is the certificate resource that should be stored in the
 	// target secret.
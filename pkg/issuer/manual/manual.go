@@ -0,0 +1,42 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manual
+
+import (
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
+)
+
+// Manual is an Issuer implementation which delivers certificates that are
+// issued outside of cert-manager and provided by an operator.
+type Manual struct {
+	*controller.Context
+	issuer v1.GenericIssuer
+}
+
+func NewManual(ctx *controller.Context, issuer v1.GenericIssuer) (issuer.Interface, error) {
+	return &Manual{
+		Context: ctx,
+		issuer:  issuer,
+	}, nil
+}
+
+func init() {
+	issuer.RegisterIssuer(apiutil.IssuerManual, NewManual)
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+var _ issuer.Revoker = &Vault{}
+
+// Revoke revokes the certificate by serial number. The serial number recorded
+// in the Certificate's status is used if the certificate is no longer stored
+// in the Secret.
+func (v *Vault) Revoke(ctx context.Context, crt *v1.Certificate, cert *x509.Certificate) error {
+	serialNumber := crt.Status.SerialNumber
+	if cert != nil {
+		serialNumber = pki.FormatSerialNumber(cert.SerialNumber)
+	}
+	if serialNumber == "" {
+		return errors.New("no serial number has been recorded for the certificate")
+	}
+
+	client, err := v.clientBuilder(ctx, v.resourceNamespace, v.createTokenFn, v.updateSecretFn, v.secretsLister, v.issuer)
+	if err != nil {
+		return err
+	}
+
+	return client.Revoke(ctx, serialNumber)
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
+	vaultinternal "github.com/cert-manager/cert-manager/internal/vault"
+	fakevault "github.com/cert-manager/cert-manager/internal/vault/fake"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestRevoke(t *testing.T) {
+	tests := map[string]struct {
+		certificate *cmapi.Certificate
+		cert        *x509.Certificate
+		builderErr  error
+		revokeErr   error
+
+		expSerialNumber string
+		expErr          string
+	}{
+		"the serial number of the certificate in the Secret is revoked": {
+			certificate:     gen.Certificate("test", gen.SetCertificateSerialNumber("0a")),
+			cert:            &x509.Certificate{SerialNumber: big.NewInt(0x1f029a)},
+			expSerialNumber: "1f:02:9a",
+		},
+		"the recorded serial number is revoked if the certificate is not in the Secret": {
+			certificate:     gen.Certificate("test", gen.SetCertificateSerialNumber("0a")),
+			expSerialNumber: "0a",
+		},
+		"an error is returned if no serial number is known": {
+			certificate: gen.Certificate("test"),
+			expErr:      "no serial number has been recorded for the certificate",
+		},
+		"client build errors are returned": {
+			certificate: gen.Certificate("test", gen.SetCertificateSerialNumber("0a")),
+			builderErr:  errors.New("failed to log in"),
+			expErr:      "failed to log in",
+		},
+		"revocation errors are returned": {
+			certificate:     gen.Certificate("test", gen.SetCertificateSerialNumber("0a")),
+			revokeErr:       errors.New("failed to revoke"),
+			expSerialNumber: "0a",
+			expErr:          "failed to revoke",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var gotSerialNumber string
+			fake := fakevault.New()
+			fake.RevokeFn = func(_ context.Context, serialNumber string) error {
+				gotSerialNumber = serialNumber
+				return test.revokeErr
+			}
+
+			v := &Vault{
+				issuer:            gen.Issuer("vault-issuer", gen.SetIssuerVault(cmapi.VaultIssuer{})),
+				resourceNamespace: "test-namespace",
+				clientBuilder: func(_ context.Context, ns string, _ func(ns string) vaultinternal.CreateToken, _ func(ns string) vaultinternal.UpdateSecret, sl internalinformers.SecretLister,
+					iss cmapi.GenericIssuer) (vaultinternal.Interface, error) {
+					if test.builderErr != nil {
+						return nil, test.builderErr
+					}
+					return fake.New(ns, sl, iss)
+				},
+			}
+
+			err := v.Revoke(context.TODO(), test.certificate, test.cert)
+			if test.expErr != "" {
+				assert.EqualError(t, err, test.expErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expSerialNumber, gotSerialNumber)
+		})
+	}
+}
@@ -18,7 +18,9 @@ package vault
 
 import (
 	"context"
+	"errors"
 
+	"github.com/cert-manager/cert-manager/internal/tlsclient"
 	vaultinternal "github.com/cert-manager/cert-manager/internal/vault"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
@@ -30,13 +32,15 @@ const (
 	successVaultVerified = "VaultVerified"
 	messageVaultVerified = "Vault verified"
 
-	errorVault = "VaultError"
+	errorVault            = "VaultError"
+	errorVaultLoginFailed = "VaultLoginFailed"
 
 	messageVaultClientInitFailed = "Failed to initialize Vault client: "
 	messageVaultConfigRequired   = "Vault config cannot be empty"
 	messageServerAndPathRequired = "Vault server and path are required fields"
 	messageAuthFieldsRequired    = "Vault tokenSecretRef, appRole, or kubernetes is required"
 	messageMultipleAuthFieldsSet = "Multiple auth methods cannot be set on the same Vault issuer"
+	messageInvalidTLSConfig      = "Invalid Vault TLS configuration: "
 
 	messageKubeAuthRoleRequired      = "Vault Kubernetes auth requires a role to be set"
 	messageKubeAuthEitherRequired    = "Vault Kubernetes auth requires either secretRef.name or serviceAccountRef.name to be set"
@@ -124,15 +128,27 @@ func (v *Vault) Setup(ctx context.Context) error {
 		return nil
 	}
 
-	client, err := vaultinternal.New(ctx, v.resourceNamespace, v.createTokenFn, v.secretsLister, v.issuer)
+	if err := tlsclient.ForIssuer(v.issuer.GetSpec().Vault.TLS).Validate(); err != nil {
+		s := messageInvalidTLSConfig + err.Error()
+		logf.V(logf.WarnLevel).Infof("%s: %s", v.issuer.GetObjectMeta().Name, s)
+		apiutil.SetIssuerCondition(v.issuer, v.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorVault, s)
+		return nil
+	}
+
+	client, err := v.clientBuilder(ctx, v.resourceNamespace, v.createTokenFn, v.updateSecretFn, v.secretsLister, v.issuer)
 	if err != nil {
 		s := messageVaultClientInitFailed + err.Error()
 		logf.V(logf.WarnLevel).Infof("%s: %s", v.issuer.GetObjectMeta().Name, s)
-		apiutil.SetIssuerCondition(v.issuer, v.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorVault, s)
+		reason := errorVault
+		var loginErr *vaultinternal.LoginError
+		if errors.As(err, &loginErr) {
+			reason = errorVaultLoginFailed
+		}
+		apiutil.SetIssuerCondition(v.issuer, v.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, reason, s)
 		return err
 	}
 
-	if err := client.IsVaultInitializedAndUnsealed(); err != nil {
+	if err := client.IsVaultInitializedAndUnsealed(ctx); err != nil {
 		logf.V(logf.WarnLevel).Infof("%s: %s", v.issuer.GetObjectMeta().Name, err.Error())
 		apiutil.SetIssuerCondition(v.issuer, v.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorVault, err.Error())
 		return err
@@ -385,6 +385,27 @@ func TestVault_Setup(t *testing.T) {
 			},
 			expectErr: "Get \"https:///vault.example.com/v1/sys/health\": http: no Host in request URL",
 		},
+		{
+			name: "invalid tls: unknown minimum TLS version",
+			givenIssuer: v1.IssuerConfig{
+				Vault: &v1.VaultIssuer{
+					Path:   "pki_int",
+					Server: "https://vault.example.com",
+					Auth: v1.VaultAuth{
+						TokenSecretRef: &cmmeta.SecretKeySelector{
+							LocalObjectReference: cmmeta.LocalObjectReference{
+								Name: "cert-manager",
+							},
+						},
+					},
+					TLS: &cmmeta.ClientTLSConfig{
+						MinVersion: "VersionTLS14",
+					},
+				},
+			},
+			expectCond:    `Ready False: VaultError: Invalid Vault TLS configuration: invalid minimum TLS version: unknown tls version "VersionTLS14"`,
+			webhookReject: true,
+		},
 		{
 			name: "server with leading whitespace should fail to parse",
 			givenIssuer: v1.IssuerConfig{
@@ -422,6 +443,7 @@ func TestVault_Setup(t *testing.T) {
 				issuer:            givenIssuer,
 				Context:           &controller.Context{CMClient: cmclient},
 				resourceNamespace: "test-namespace",
+				clientBuilder:     vaultinternal.New,
 				createTokenFn: func(ns string) vaultinternal.CreateToken {
 					return func(ctx context.Context, saName string, req *authv1.TokenRequest, opts metav1.CreateOptions) (*authv1.TokenRequest, error) {
 						return &authv1.TokenRequest{Status: authv1.TokenRequestStatus{
@@ -429,6 +451,7 @@ func TestVault_Setup(t *testing.T) {
 						}}, nil
 					}
 				},
+				updateSecretFn: func(ns string) vaultinternal.UpdateSecret { return nil },
 				secretsLister: &testlisters.FakeSecretLister{
 					SecretsFn: func(namespace string) corelisters.SecretNamespaceLister {
 						return &testlisters.FakeSecretNamespaceLister{
@@ -38,7 +38,9 @@ type Vault struct {
 	resourceNamespace string
 
 	// For testing purposes.
-	createTokenFn func(ns string) vaultinternal.CreateToken
+	createTokenFn  func(ns string) vaultinternal.CreateToken
+	updateSecretFn func(ns string) vaultinternal.UpdateSecret
+	clientBuilder  vaultinternal.ClientBuilder
 }
 
 // NewVault returns a new Vault
@@ -51,6 +53,8 @@ func NewVault(ctx *controller.Context, issuer v1.GenericIssuer) (issuer.Interfac
 		secretsLister:     secretsLister,
 		resourceNamespace: ctx.IssuerOptions.ResourceNamespace(issuer),
 		createTokenFn:     func(ns string) vaultinternal.CreateToken { return ctx.Client.CoreV1().ServiceAccounts(ns).CreateToken },
+		updateSecretFn:    func(ns string) vaultinternal.UpdateSecret { return ctx.Client.CoreV1().Secrets(ns).Update },
+		clientBuilder:     vaultinternal.New,
 	}, nil
 }
 
@@ -30,6 +30,8 @@ type Connector struct {
 	RetrieveCertificateFunc   func(*certificate.Request) (*certificate.PEMCollection, error)
 	RequestCertificateFunc    func(*certificate.Request) (string, error)
 	RenewCertificateFunc      func(*certificate.RenewalRequest) (string, error)
+	RevokeCertificateFunc     func(*certificate.RevocationRequest) error
+	RetireCertificateFunc     func(*certificate.RetireRequest) error
 }
 
 func (f Connector) Default() *Connector {
@@ -73,3 +75,17 @@ func (f *Connector) RenewCertificate(req *certificate.RenewalRequest) (requestID
 	}
 	return f.Connector.RenewCertificate(req)
 }
+
+func (f *Connector) RevokeCertificate(req *certificate.RevocationRequest) error {
+	if f.RevokeCertificateFunc != nil {
+		return f.RevokeCertificateFunc(req)
+	}
+	return f.Connector.RevokeCertificate(req)
+}
+
+func (f *Connector) RetireCertificate(req *certificate.RetireRequest) error {
+	if f.RetireCertificateFunc != nil {
+		return f.RetireCertificateFunc(req)
+	}
+	return f.Connector.RetireCertificate(req)
+}
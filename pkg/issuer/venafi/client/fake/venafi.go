@@ -17,6 +17,8 @@ limitations under the License.
 package fake
 
 import (
+	"crypto/x509"
+
 	"github.com/Venafi/vcert/v5/pkg/endpoint"
 
 	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/api"
@@ -26,6 +28,7 @@ type Venafi struct {
 	PingFn                  func() error
 	RequestCertificateFn    func(csrPEM []byte, customFields []api.CustomField) (string, error)
 	RetrieveCertificateFn   func(pickupID string, csrPEM []byte, customFields []api.CustomField) ([]byte, error)
+	RevokeCertificateFn     func(cert *x509.Certificate) error
 	ReadZoneConfigurationFn func() (*endpoint.ZoneConfiguration, error)
 	VerifyCredentialsFn     func() error
 }
@@ -42,6 +45,10 @@ func (v *Venafi) RetrieveCertificate(pickupID string, csrPEM []byte, customField
 	return v.RetrieveCertificateFn(pickupID, csrPEM, customFields)
 }
 
+func (v *Venafi) RevokeCertificate(cert *x509.Certificate) error {
+	return v.RevokeCertificateFn(cert)
+}
+
 func (v *Venafi) ReadZoneConfiguration() (*endpoint.ZoneConfiguration, error) {
 	return v.ReadZoneConfigurationFn()
 }
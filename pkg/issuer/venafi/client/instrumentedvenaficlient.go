@@ -70,6 +70,24 @@ func (ic instrumentedConnector) RetrieveCertificate(req *certificate.Request) (*
 	return pemCollection, err
 }
 
+func (ic instrumentedConnector) RevokeCertificate(req *certificate.RevocationRequest) error {
+	start := time.Now()
+	ic.logger.V(logf.TraceLevel).Info("calling RevokeCertificate")
+	err := ic.conn.RevokeCertificate(req)
+	labels := []string{"revoke_certificate"}
+	ic.metrics.ObserveVenafiRequestDuration(time.Since(start), labels...)
+	return err
+}
+
+func (ic instrumentedConnector) RetireCertificate(req *certificate.RetireRequest) error {
+	start := time.Now()
+	ic.logger.V(logf.TraceLevel).Info("calling RetireCertificate")
+	err := ic.conn.RetireCertificate(req)
+	labels := []string{"retire_certificate"}
+	ic.metrics.ObserveVenafiRequestDuration(time.Since(start), labels...)
+	return err
+}
+
 func (ic instrumentedConnector) Ping() error {
 	start := time.Now()
 	ic.logger.V(logf.TraceLevel).Info("calling Ping")
@@ -0,0 +1,47 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"crypto/sha1" // #nosec G505 -- Venafi identifies certificates by their SHA-1 thumbprint
+	"crypto/x509"
+	"fmt"
+
+	"github.com/Venafi/vcert/v5/pkg/certificate"
+)
+
+const revocationComment = "Revoked by cert-manager as the Certificate resource was deleted"
+
+// RevokeCertificate revokes the certificate with TPP. Venafi Cloud does not
+// support revocation, so certificates issued by Venafi Cloud are retired
+// instead.
+func (v *Venafi) RevokeCertificate(cert *x509.Certificate) error {
+	thumbprint := fmt.Sprintf("%X", sha1.Sum(cert.Raw)) // #nosec G401
+
+	if v.cloudClient != nil {
+		return v.vcertClient.RetireCertificate(&certificate.RetireRequest{
+			Thumbprint:  thumbprint,
+			Description: revocationComment,
+		})
+	}
+
+	return v.vcertClient.RevokeCertificate(&certificate.RevocationRequest{
+		Thumbprint: thumbprint,
+		Reason:     "cessation-of-operation",
+		Comments:   revocationComment,
+	})
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"crypto/x509"
+	"errors"
+	"testing"
+
+	"github.com/Venafi/vcert/v5/pkg/certificate"
+	"github.com/Venafi/vcert/v5/pkg/venafi/cloud"
+	"github.com/stretchr/testify/assert"
+
+	internalfake "github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/fake"
+)
+
+func TestVenafi_RevokeCertificate(t *testing.T) {
+	cert := &x509.Certificate{Raw: []byte("certificate")}
+	// SHA-1 of "certificate"
+	const thumbprint = "735AD571C189D7BA84464BF4A9F1D2280175B128"
+
+	t.Run("TPP certificates are revoked", func(t *testing.T) {
+		var got *certificate.RevocationRequest
+		v := &Venafi{
+			vcertClient: internalfake.Connector{
+				RevokeCertificateFunc: func(req *certificate.RevocationRequest) error {
+					got = req
+					return nil
+				},
+				RetireCertificateFunc: func(*certificate.RetireRequest) error {
+					return errors.New("unexpected call to RetireCertificate")
+				},
+			}.Default(),
+		}
+
+		assert.NoError(t, v.RevokeCertificate(cert))
+		if assert.NotNil(t, got) {
+			assert.Equal(t, thumbprint, got.Thumbprint)
+			assert.Equal(t, "cessation-of-operation", got.Reason)
+		}
+	})
+
+	t.Run("Venafi Cloud certificates are retired", func(t *testing.T) {
+		var got *certificate.RetireRequest
+		v := &Venafi{
+			cloudClient: &cloud.Connector{},
+			vcertClient: internalfake.Connector{
+				RevokeCertificateFunc: func(*certificate.RevocationRequest) error {
+					return errors.New("unexpected call to RevokeCertificate")
+				},
+				RetireCertificateFunc: func(req *certificate.RetireRequest) error {
+					got = req
+					return nil
+				},
+			}.Default(),
+		}
+
+		assert.NoError(t, v.RevokeCertificate(cert))
+		if assert.NotNil(t, got) {
+			assert.Equal(t, thumbprint, got.Thumbprint)
+		}
+	})
+
+	t.Run("revocation errors are returned", func(t *testing.T) {
+		v := &Venafi{
+			vcertClient: internalfake.Connector{
+				RevokeCertificateFunc: func(*certificate.RevocationRequest) error {
+					return errors.New("revoke error")
+				},
+			}.Default(),
+		}
+
+		assert.EqualError(t, v.RevokeCertificate(cert), "revoke error")
+	})
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package client
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
@@ -33,7 +34,9 @@ import (
 	"k8s.io/utils/ptr"
 
 	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
+	"github.com/cert-manager/cert-manager/internal/tlsclient"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/api"
 	"github.com/cert-manager/cert-manager/pkg/metrics"
 	"github.com/cert-manager/cert-manager/pkg/util"
@@ -47,13 +50,14 @@ const (
 	defaultAPIKeyKey = "api-key"
 )
 
-type VenafiClientBuilder func(namespace string, secretsLister internalinformers.SecretLister,
+type VenafiClientBuilder func(ctx context.Context, namespace string, secretsLister internalinformers.SecretLister,
 	issuer cmapi.GenericIssuer, metrics *metrics.Metrics, logger logr.Logger, userAgent string) (Interface, error)
 
 // Interface implements a Venafi client
 type Interface interface {
 	RequestCertificate(csrPEM []byte, customFields []api.CustomField) (string, error)
 	RetrieveCertificate(pickupID string, csrPEM []byte, customFields []api.CustomField) ([]byte, error)
+	RevokeCertificate(cert *x509.Certificate) error
 	Ping() error
 	ReadZoneConfiguration() (*endpoint.ZoneConfiguration, error)
 	SetClient(endpoint.Connector)
@@ -81,14 +85,17 @@ type connector interface {
 	ReadZoneConfiguration() (config *endpoint.ZoneConfiguration, err error)
 	RequestCertificate(req *certificate.Request) (requestID string, err error)
 	RetrieveCertificate(req *certificate.Request) (certificates *certificate.PEMCollection, err error)
+	RevokeCertificate(req *certificate.RevocationRequest) error
+	RetireCertificate(req *certificate.RetireRequest) error
 	// TODO: (irbekrm) this method is never used- can it be removed?
 	RenewCertificate(req *certificate.RenewalRequest) (requestID string, err error)
 }
 
 // New constructs a Venafi client Interface. Errors may be network errors and
-// should be considered for retrying.
-func New(namespace string, secretsLister internalinformers.SecretLister, issuer cmapi.GenericIssuer, metrics *metrics.Metrics, logger logr.Logger, userAgent string) (Interface, error) {
-	cfg, err := configForIssuer(issuer, secretsLister, namespace, userAgent)
+// should be considered for retrying. The HTTP requests made by the client are
+// cancelled once ctx is done.
+func New(ctx context.Context, namespace string, secretsLister internalinformers.SecretLister, issuer cmapi.GenericIssuer, metrics *metrics.Metrics, logger logr.Logger, userAgent string) (Interface, error) {
+	cfg, err := configForIssuer(ctx, issuer, secretsLister, namespace, userAgent)
 	if err != nil {
 		return nil, err
 	}
@@ -128,7 +135,7 @@ func New(namespace string, secretsLister internalinformers.SecretLister, issuer
 
 // configForIssuer will convert a cert-manager Venafi issuer into a vcert.Config
 // that can be used to instantiate an API client.
-func configForIssuer(iss cmapi.GenericIssuer, secretsLister internalinformers.SecretLister, namespace string, userAgent string) (*vcert.Config, error) {
+func configForIssuer(ctx context.Context, iss cmapi.GenericIssuer, secretsLister internalinformers.SecretLister, namespace string, userAgent string) (*vcert.Config, error) {
 	venCfg := iss.GetSpec().Venafi
 
 	switch {
@@ -143,6 +150,17 @@ func configForIssuer(iss cmapi.GenericIssuer, secretsLister internalinformers.Se
 		password := string(tppSecret.Data[tppPasswordKey])
 		accessToken := string(tppSecret.Data[tppAccessTokenKey])
 
+		client, err := httpClientForVcert(&httpClientForVcertOptions{
+			Context:                 ctx,
+			UserAgent:               ptr.To(userAgent),
+			CABundle:                tpp.CABundle,
+			TLS:                     venCfg.TLS,
+			TLSRenegotiationSupport: ptr.To(tls.RenegotiateOnceAsClient),
+		})
+		if err != nil {
+			return nil, err
+		}
+
 		return &vcert.Config{
 			ConnectorType: endpoint.ConnectorTypeTPP,
 			BaseUrl:       tpp.URL,
@@ -162,11 +180,7 @@ func configForIssuer(iss cmapi.GenericIssuer, secretsLister internalinformers.Se
 				Password:    password,
 				AccessToken: accessToken,
 			},
-			Client: httpClientForVcert(&httpClientForVcertOptions{
-				UserAgent:               ptr.To(userAgent),
-				CABundle:                tpp.CABundle,
-				TLSRenegotiationSupport: ptr.To(tls.RenegotiateOnceAsClient),
-			}),
+			Client: client,
 		}, nil
 	case venCfg.Cloud != nil:
 		cloud := venCfg.Cloud
@@ -181,6 +195,15 @@ func configForIssuer(iss cmapi.GenericIssuer, secretsLister internalinformers.Se
 		}
 		apiKey := string(cloudSecret.Data[k])
 
+		client, err := httpClientForVcert(&httpClientForVcertOptions{
+			Context:   ctx,
+			UserAgent: ptr.To(userAgent),
+			TLS:       venCfg.TLS,
+		})
+		if err != nil {
+			return nil, err
+		}
+
 		return &vcert.Config{
 			ConnectorType: endpoint.ConnectorTypeCloud,
 			BaseUrl:       cloud.URL,
@@ -190,9 +213,7 @@ func configForIssuer(iss cmapi.GenericIssuer, secretsLister internalinformers.Se
 			Credentials: &endpoint.Authentication{
 				APIKey: apiKey,
 			},
-			Client: httpClientForVcert(&httpClientForVcertOptions{
-				UserAgent: ptr.To(userAgent),
-			}),
+			Client: client,
 		}, nil
 	}
 	// API validation in webhook and in the ClusterIssuer and Issuer controller
@@ -203,18 +224,26 @@ func configForIssuer(iss cmapi.GenericIssuer, secretsLister internalinformers.Se
 // httpClientForVcertOptions contains options for `httpClientForVcert`, to allow
 // you to customize the HTTP client.
 type httpClientForVcertOptions struct {
+	// Context will be used for all HTTP requests, as vcert does not accept
+	// a context, so that they are cancelled once it is done.
+	Context context.Context
 	// UserAgent will add a User-Agent header to all HTTP requests.
 	UserAgent *string
 	// CABundle will override the CA certificates used to verify server
 	// certificates.
 	CABundle []byte
+	// TLS will override the controller-wide minimum TLS version and cipher
+	// suites.
+	TLS *cmmeta.ClientTLSConfig
 	// TLSRenegotiationSupport will override the TLSRenegotiationSupport setting
 	// of the client.
 	TLSRenegotiationSupport *tls.RenegotiationSupport
 }
 
 // httpClientForVcert creates an HTTP client which matches the default HTTP client of vcert,
-// but allows you to customize client TLS renegotiation, and User-Agent.
+// but allows you to customize client TLS renegotiation, TLS versions and
+// cipher suites, and User-Agent.
+// An error is returned if the TLS version or cipher suites are invalid.
 //
 // Why is it necessary to create our own HTTP client for vcert?
 //
@@ -264,7 +293,7 @@ type httpClientForVcertOptions struct {
 //
 // [1] TLS protocol version support in Microsoft Windows: https://learn.microsoft.com/en-us/windows/win32/secauthn/protocols-in-tls-ssl--schannel-ssp-#tls-protocol-version-support
 // [2] Should I use SSL/TLS renegotiation?: https://security.stackexchange.com/a/24569
-func httpClientForVcert(options *httpClientForVcertOptions) *http.Client {
+func httpClientForVcert(options *httpClientForVcertOptions) (*http.Client, error) {
 	// Copy vcert's default HTTP transport, which is mostly identical to the
 	// http.DefaultTransport settings in Go's stdlib.
 	// https://github.com/Venafi/vcert/blob/89645a7710a7b529765274cb60dc5e28066217a1/pkg/venafi/tpp/tpp.go#L481-L513
@@ -288,10 +317,11 @@ func httpClientForVcert(options *httpClientForVcertOptions) *http.Client {
 	if tlsClientConfig == nil {
 		tlsClientConfig = &tls.Config{}
 	}
-	if len(options.CABundle) > 0 {
-		rootCAs := x509.NewCertPool()
-		rootCAs.AppendCertsFromPEM(options.CABundle)
-		tlsClientConfig.RootCAs = rootCAs
+	if err := tlsclient.ForIssuer(options.TLS).Apply(tlsClientConfig); err != nil {
+		return nil, fmt.Errorf("invalid TLS configuration: %w", err)
+	}
+	if err := tlsclient.SetRootCAs(tlsClientConfig, options.CABundle); err != nil {
+		return nil, err
 	}
 	transport.TLSClientConfig = tlsClientConfig
 
@@ -301,7 +331,10 @@ func httpClientForVcert(options *httpClientForVcertOptions) *http.Client {
 
 	var roundTripper http.RoundTripper = transport
 	if options.UserAgent != nil {
-		roundTripper = util.UserAgentRoundTripper(transport, *options.UserAgent)
+		roundTripper = util.UserAgentRoundTripper(roundTripper, *options.UserAgent)
+	}
+	if options.Context != nil {
+		roundTripper = contextRoundTripper{inner: roundTripper, ctx: options.Context}
 	}
 
 	// Copy vcert's initialization of the HTTP client, which overrides the default timeout.
@@ -309,7 +342,19 @@ func httpClientForVcert(options *httpClientForVcertOptions) *http.Client {
 	return &http.Client{
 		Transport: roundTripper,
 		Timeout:   time.Second * 30,
-	}
+	}, nil
+}
+
+// contextRoundTripper replaces the context of each request, so that the
+// requests made by vcert are cancelled once ctx is done.
+type contextRoundTripper struct {
+	inner http.RoundTripper
+	ctx   context.Context
+}
+
+// RoundTrip implements http.RoundTripper
+func (c contextRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return c.inner.RoundTrip(req.WithContext(c.ctx))
 }
 
 func (v *Venafi) Ping() error {
@@ -0,0 +1,43 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
+)
+
+var _ issuer.Revoker = &Venafi{}
+
+// Revoke revokes the certificate with TPP, or retires it with Venafi Cloud.
+func (v *Venafi) Revoke(ctx context.Context, crt *cmapi.Certificate, cert *x509.Certificate) error {
+	if cert == nil {
+		return errors.New("the certificate is required for revocation with Venafi but was not found in the Secret")
+	}
+
+	client, err := v.clientBuilder(ctx, v.resourceNamespace, v.secretsLister, v.issuer, v.Metrics, v.log, v.userAgent)
+	if err != nil {
+		return fmt.Errorf("error building client: %v", err)
+	}
+
+	return client.RevokeCertificate(cert)
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client"
+	internalvenafifake "github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/fake"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestRevoke(t *testing.T) {
+	cert := &x509.Certificate{Raw: []byte("certificate")}
+
+	tests := map[string]struct {
+		cert       *x509.Certificate
+		builderErr error
+		revokeErr  error
+
+		expRevoked bool
+		expErr     string
+	}{
+		"the certificate in the Secret is revoked": {
+			cert:       cert,
+			expRevoked: true,
+		},
+		"an error is returned if the certificate is not in the Secret": {
+			expErr: "the certificate is required for revocation with Venafi but was not found in the Secret",
+		},
+		"client build errors are returned": {
+			cert:       cert,
+			builderErr: errors.New("this is an error"),
+			expErr:     "error building client: this is an error",
+		},
+		"revocation errors are returned": {
+			cert:       cert,
+			revokeErr:  errors.New("failed to revoke"),
+			expRevoked: true,
+			expErr:     "failed to revoke",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var revoked *x509.Certificate
+			v := &Venafi{
+				issuer:  gen.Issuer("venafi-issuer"),
+				Context: &controllerpkg.Context{},
+				clientBuilder: func(context.Context, string, internalinformers.SecretLister, cmapi.GenericIssuer, *metrics.Metrics, logr.Logger, string) (client.Interface, error) {
+					if test.builderErr != nil {
+						return nil, test.builderErr
+					}
+					return &internalvenafifake.Venafi{
+						RevokeCertificateFn: func(cert *x509.Certificate) error {
+							revoked = cert
+							return test.revokeErr
+						},
+					}, nil
+				},
+			}
+
+			err := v.Revoke(context.TODO(), gen.Certificate("test"), test.cert)
+			if test.expErr != "" {
+				assert.EqualError(t, err, test.expErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expRevoked, revoked == cert)
+		})
+	}
+}
@@ -22,6 +22,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 
+	"github.com/cert-manager/cert-manager/internal/tlsclient"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
@@ -38,7 +39,16 @@ func (v *Venafi) Setup(ctx context.Context) (err error) {
 		}
 	}()
 
-	client, err := v.clientBuilder(v.resourceNamespace, v.secretsLister, v.issuer, v.Metrics, v.log, v.userAgent)
+	// An invalid TLS configuration will not be fixed by retrying, so we set
+	// the condition and return without an error.
+	if venCfg := v.issuer.GetSpec().Venafi; venCfg != nil {
+		if err := tlsclient.ForIssuer(venCfg.TLS).Validate(); err != nil {
+			apiutil.SetIssuerCondition(v.issuer, v.issuer.GetGeneration(), cmapi.IssuerConditionReady, cmmeta.ConditionFalse, "InvalidTLSConfig", fmt.Sprintf("Invalid TLS configuration: %v", err))
+			return nil
+		}
+	}
+
+	client, err := v.clientBuilder(ctx, v.resourceNamespace, v.secretsLister, v.issuer, v.Metrics, v.log, v.userAgent)
 	if err != nil {
 		return fmt.Errorf("error building client: %v", err)
 	}
@@ -27,6 +27,7 @@ import (
 
 	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	controllertest "github.com/cert-manager/cert-manager/pkg/controller/test"
 	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client"
@@ -39,12 +40,12 @@ import (
 func TestSetup(t *testing.T) {
 	baseIssuer := gen.Issuer("test-issuer")
 
-	failingClientBuilder := func(string, internalinformers.SecretLister,
+	failingClientBuilder := func(context.Context, string, internalinformers.SecretLister,
 		cmapi.GenericIssuer, *metrics.Metrics, logr.Logger, string) (client.Interface, error) {
 		return nil, errors.New("this is an error")
 	}
 
-	failingPingClient := func(string, internalinformers.SecretLister,
+	failingPingClient := func(context.Context, string, internalinformers.SecretLister,
 		cmapi.GenericIssuer, *metrics.Metrics, logr.Logger, string) (client.Interface, error) {
 		return &internalvenafifake.Venafi{
 			PingFn: func() error {
@@ -53,7 +54,7 @@ func TestSetup(t *testing.T) {
 		}, nil
 	}
 
-	pingClient := func(string, internalinformers.SecretLister,
+	pingClient := func(context.Context, string, internalinformers.SecretLister,
 		cmapi.GenericIssuer, *metrics.Metrics, logr.Logger, string) (client.Interface, error) {
 		return &internalvenafifake.Venafi{
 			PingFn: func() error {
@@ -62,7 +63,7 @@ func TestSetup(t *testing.T) {
 		}, nil
 	}
 
-	verifyCredentialsClient := func(string, internalinformers.SecretLister, cmapi.GenericIssuer, *metrics.Metrics, logr.Logger, string) (client.Interface, error) {
+	verifyCredentialsClient := func(context.Context, string, internalinformers.SecretLister, cmapi.GenericIssuer, *metrics.Metrics, logr.Logger, string) (client.Interface, error) {
 		return &internalvenafifake.Venafi{
 			PingFn: func() error {
 				return nil
@@ -73,7 +74,7 @@ func TestSetup(t *testing.T) {
 		}, nil
 	}
 
-	failingVerifyCredentialsClient := func(string, internalinformers.SecretLister, cmapi.GenericIssuer, *metrics.Metrics, logr.Logger, string) (client.Interface, error) {
+	failingVerifyCredentialsClient := func(context.Context, string, internalinformers.SecretLister, cmapi.GenericIssuer, *metrics.Metrics, logr.Logger, string) (client.Interface, error) {
 		return &internalvenafifake.Venafi{
 			PingFn: func() error {
 				return nil
@@ -134,6 +135,23 @@ func TestSetup(t *testing.T) {
 			},
 		},
 
+		"if the TLS configuration is invalid then should set condition without error": {
+			clientBuilder: pingClient,
+			iss: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+					TLS: &cmmeta.ClientTLSConfig{
+						MinVersion: "VersionTLS14",
+					},
+				}),
+			),
+			expectedErr: false,
+			expectedCondition: &cmapi.IssuerCondition{
+				Reason:  "InvalidTLSConfig",
+				Message: `Invalid TLS configuration: invalid minimum TLS version: unknown tls version "VersionTLS14"`,
+				Status:  "False",
+			},
+		},
+
 		"if verifyCredentials returns an error we should set condition to False": {
 			clientBuilder: failingVerifyCredentialsClient,
 			iss:           baseIssuer.DeepCopy(),
@@ -31,6 +31,7 @@ import (
 	"k8s.io/klog/v2"
 
 	"github.com/cert-manager/cert-manager/pkg/api"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 
 	_ "k8s.io/component-base/logs/json/register"
 )
@@ -104,6 +105,14 @@ const (
 	RelatedResourceNamespaceKey = "related_resource_namespace"
 	RelatedResourceKindKey      = "related_resource_kind"
 	RelatedResourceVersionKey   = "related_resource_version"
+
+	// ResourceObjectVersionKey holds the metadata.resourceVersion of the
+	// resource, whereas ResourceVersionKey holds its API version.
+	ResourceObjectVersionKey        = "resource_object_version"
+	RelatedResourceObjectVersionKey = "related_resource_object_version"
+
+	CertificateRevisionKey        = "certificate_revision"
+	CertificateRequestRevisionKey = "certificate_request_revision"
 )
 
 func WithResource(l logr.Logger, obj metav1.Object) logr.Logger {
@@ -121,6 +130,7 @@ func WithResource(l logr.Logger, obj metav1.Object) logr.Logger {
 		ResourceNamespaceKey, obj.GetNamespace(),
 		ResourceKindKey, gvk.Kind,
 		ResourceVersionKey, gvk.Version,
+		ResourceObjectVersionKey, obj.GetResourceVersion(),
 	)
 }
 
@@ -139,9 +149,33 @@ func WithRelatedResource(l logr.Logger, obj metav1.Object) logr.Logger {
 		RelatedResourceNamespaceKey, obj.GetNamespace(),
 		RelatedResourceKindKey, gvk.Kind,
 		RelatedResourceVersionKey, gvk.Version,
+		RelatedResourceObjectVersionKey, obj.GetResourceVersion(),
 	)
 }
 
+// WithCertificate returns a logger with the standard keys identifying the
+// given Certificate, including its current revision if one has been issued.
+// Controllers reconciling Certificates should use it, so that log lines can
+// be correlated across controllers.
+func WithCertificate(l logr.Logger, crt *cmapi.Certificate) logr.Logger {
+	l = WithResource(l, crt)
+	if crt.Status.Revision != nil {
+		l = l.WithValues(CertificateRevisionKey, *crt.Status.Revision)
+	}
+	return l
+}
+
+// WithRelatedCertificateRequest returns a logger with the standard keys
+// identifying the given CertificateRequest as a related resource, including
+// the Certificate revision it was created for.
+func WithRelatedCertificateRequest(l logr.Logger, req *cmapi.CertificateRequest) logr.Logger {
+	l = WithRelatedResource(l, req)
+	if revision, ok := req.Annotations[cmapi.CertificateRequestRevisionAnnotationKey]; ok {
+		l = l.WithValues(CertificateRequestRevisionKey, revision)
+	}
+	return l
+}
+
 func WithRelatedResourceName(l logr.Logger, name, namespace, kind string) logr.Logger {
 	return l.WithValues(
 		RelatedResourceNameKey, name,
@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+func TestWithCertificate(t *testing.T) {
+	revision := 3
+
+	tests := map[string]struct {
+		log     func(logr.Logger) logr.Logger
+		expKeys map[string]interface{}
+	}{
+		"Certificate without a revision": {
+			log: func(l logr.Logger) logr.Logger {
+				return WithCertificate(l, &cmapi.Certificate{
+					ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "ns", ResourceVersion: "10"},
+				})
+			},
+			expKeys: map[string]interface{}{
+				ResourceNameKey:          "test",
+				ResourceNamespaceKey:     "ns",
+				ResourceKindKey:          "Certificate",
+				ResourceVersionKey:       "v1",
+				ResourceObjectVersionKey: "10",
+			},
+		},
+		"Certificate with a revision": {
+			log: func(l logr.Logger) logr.Logger {
+				return WithCertificate(l, &cmapi.Certificate{
+					ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "ns", ResourceVersion: "10"},
+					Status:     cmapi.CertificateStatus{Revision: &revision},
+				})
+			},
+			expKeys: map[string]interface{}{
+				ResourceNameKey:          "test",
+				ResourceNamespaceKey:     "ns",
+				ResourceKindKey:          "Certificate",
+				ResourceVersionKey:       "v1",
+				ResourceObjectVersionKey: "10",
+				CertificateRevisionKey:   float64(3),
+			},
+		},
+		"related CertificateRequest": {
+			log: func(l logr.Logger) logr.Logger {
+				return WithRelatedCertificateRequest(l, &cmapi.CertificateRequest{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "test-1",
+						Namespace:       "ns",
+						ResourceVersion: "20",
+						Annotations:     map[string]string{cmapi.CertificateRequestRevisionAnnotationKey: "4"},
+					},
+				})
+			},
+			expKeys: map[string]interface{}{
+				RelatedResourceNameKey:          "test-1",
+				RelatedResourceNamespaceKey:     "ns",
+				RelatedResourceKindKey:          "CertificateRequest",
+				RelatedResourceVersionKey:       "v1",
+				RelatedResourceObjectVersionKey: "20",
+				CertificateRequestRevisionKey:   "4",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var line string
+			sink := funcr.NewJSON(func(obj string) { line = obj }, funcr.Options{})
+
+			test.log(sink).Info("test")
+
+			var got map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(line), &got))
+			for key, exp := range test.expKeys {
+				assert.Equal(t, exp, got[key], "unexpected value for key %q", key)
+			}
+			if _, ok := test.expKeys[CertificateRevisionKey]; !ok {
+				assert.NotContains(t, got, CertificateRevisionKey)
+			}
+		})
+	}
+}
@@ -18,8 +18,16 @@ package metrics
 
 import (
 	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 )
 
+// acmeAccountStatuses are the statuses of ACME accounts reported by the
+// acme_account_status metric.
+var acmeAccountStatuses = [...]string{"valid", "deactivated", "revoked"}
+
 // ObserveACMERequestDuration increases bucket counters for that ACME client duration.
 func (m *Metrics) ObserveACMERequestDuration(duration time.Duration, labels ...string) {
 	m.acmeClientRequestDurationSeconds.WithLabelValues(labels...).Observe(duration.Seconds())
@@ -29,3 +37,33 @@ func (m *Metrics) ObserveACMERequestDuration(duration time.Duration, labels ...s
 func (m *Metrics) IncrementACMERequestCount(labels ...string) {
 	m.acmeClientRequestCount.WithLabelValues(labels...).Inc()
 }
+
+// UpdateACMEAccountStatus will update the status of the ACME account of that
+// issuer.
+func (m *Metrics) UpdateACMEAccountStatus(issuer cmapi.GenericIssuer, current string) {
+	kind := cmapi.IssuerKind
+	if _, ok := issuer.(*cmapi.ClusterIssuer); ok {
+		kind = cmapi.ClusterIssuerKind
+	}
+
+	for _, status := range acmeAccountStatuses {
+		value := 0.0
+		if current == status {
+			value = 1.0
+		}
+
+		m.acmeAccountStatus.With(prometheus.Labels{
+			"name":      issuer.GetObjectMeta().Name,
+			"namespace": issuer.GetObjectMeta().Namespace,
+			"kind":      kind,
+			"status":    status,
+		}).Set(value)
+	}
+}
+
+// RemoveACMEAccountStatus will delete the ACME account status metric of that
+// issuer from continuing to be exposed. The namespace of ClusterIssuers is
+// empty.
+func (m *Metrics) RemoveACMEAccountStatus(kind, namespace, name string) {
+	m.acmeAccountStatus.DeletePartialMatch(prometheus.Labels{"name": name, "namespace": namespace, "kind": kind})
+}
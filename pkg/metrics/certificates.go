@@ -17,6 +17,8 @@ limitations under the License.
 package metrics
 
 import (
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"k8s.io/client-go/tools/cache"
 
@@ -30,6 +32,7 @@ func (m *Metrics) UpdateCertificate(crt *cmapi.Certificate) {
 	m.updateCertificateStatus(crt)
 	m.updateCertificateExpiry(crt)
 	m.updateCertificateRenewalTime(crt)
+	m.updateCertificateWaitingForApproval(crt)
 }
 
 // updateCertificateExpiry updates the expiry time of a certificate
@@ -65,6 +68,43 @@ func (m *Metrics) updateCertificateRenewalTime(crt *cmapi.Certificate) {
 
 }
 
+// updateCertificateWaitingForApproval updates whether the issuance of a
+// certificate is waiting for its CertificateRequest to be approved
+func (m *Metrics) updateCertificateWaitingForApproval(crt *cmapi.Certificate) {
+	waiting := 0.0
+
+	for _, c := range crt.Status.Conditions {
+		if c.Type == cmapi.CertificateConditionWaitingForApproval && c.Status == cmmeta.ConditionTrue {
+			waiting = 1.0
+		}
+	}
+
+	m.certificateWaitingForApproval.With(prometheus.Labels{
+		"name":         crt.Name,
+		"namespace":    crt.Namespace,
+		"issuer_name":  crt.Spec.IssuerRef.Name,
+		"issuer_kind":  crt.Spec.IssuerRef.Kind,
+		"issuer_group": crt.Spec.IssuerRef.Group}).Set(waiting)
+}
+
+// UpdateCertificateIssuerChainExpiry updates the expiry time of the issuer
+// chain stored with a certificate. A zero time is reported as 0, meaning that
+// the certificate has no issuer chain.
+func (m *Metrics) UpdateCertificateIssuerChainExpiry(crt *cmapi.Certificate, notAfter time.Time) {
+	expiryTime := 0.0
+
+	if !notAfter.IsZero() {
+		expiryTime = float64(notAfter.Unix())
+	}
+
+	m.certificateIssuerChainExpiryTime.With(prometheus.Labels{
+		"name":         crt.Name,
+		"namespace":    crt.Namespace,
+		"issuer_name":  crt.Spec.IssuerRef.Name,
+		"issuer_kind":  crt.Spec.IssuerRef.Kind,
+		"issuer_group": crt.Spec.IssuerRef.Group}).Set(expiryTime)
+}
+
 // updateCertificateStatus will update the metric for that Certificate
 func (m *Metrics) updateCertificateStatus(crt *cmapi.Certificate) {
 	for _, c := range crt.Status.Conditions {
@@ -109,4 +149,6 @@ func (m *Metrics) RemoveCertificate(key string) {
 	m.certificateExpiryTimeSeconds.DeletePartialMatch(prometheus.Labels{"name": name, "namespace": namespace})
 	m.certificateRenewalTimeSeconds.DeletePartialMatch(prometheus.Labels{"name": name, "namespace": namespace})
 	m.certificateReadyStatus.DeletePartialMatch(prometheus.Labels{"name": name, "namespace": namespace})
+	m.certificateIssuerChainExpiryTime.DeletePartialMatch(prometheus.Labels{"name": name, "namespace": namespace})
+	m.certificateWaitingForApproval.DeletePartialMatch(prometheus.Labels{"name": name, "namespace": namespace})
 }
@@ -220,6 +220,108 @@ func TestCertificateMetrics(t *testing.T) {
 	}
 }
 
+const issuerChainExpiryMetadata = `
+	# HELP certmanager_certificate_issuer_chain_expiration_timestamp_seconds The date after which the first certificate in the issuer chain stored with the certificate expires. Expressed as a Unix Epoch Time.
+	# TYPE certmanager_certificate_issuer_chain_expiration_timestamp_seconds gauge
+`
+
+func TestCertificateIssuerChainExpiryMetric(t *testing.T) {
+	crt := gen.Certificate("test-certificate",
+		gen.SetCertificateNamespace("test-ns"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{
+			Name:  "test-issuer",
+			Kind:  "test-issuer-kind",
+			Group: "test-issuer-group",
+		}),
+	)
+
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+	m.UpdateCertificateIssuerChainExpiry(crt, time.Unix(2208988804, 0))
+	if err := testutil.CollectAndCompare(m.certificateIssuerChainExpiryTime,
+		strings.NewReader(issuerChainExpiryMetadata+`
+	certmanager_certificate_issuer_chain_expiration_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="test-certificate",namespace="test-ns"} 2.208988804e+09
+`),
+		"certmanager_certificate_issuer_chain_expiration_timestamp_seconds",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	// A certificate without an issuer chain is reported as 0.
+	m.UpdateCertificateIssuerChainExpiry(crt, time.Time{})
+	if err := testutil.CollectAndCompare(m.certificateIssuerChainExpiryTime,
+		strings.NewReader(issuerChainExpiryMetadata+`
+	certmanager_certificate_issuer_chain_expiration_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="test-certificate",namespace="test-ns"} 0
+`),
+		"certmanager_certificate_issuer_chain_expiration_timestamp_seconds",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	m.RemoveCertificate("test-ns/test-certificate")
+	if count := testutil.CollectAndCount(m.certificateIssuerChainExpiryTime); count != 0 {
+		t.Errorf("expected the metric to be removed, got %d series", count)
+	}
+}
+
+const waitingForApprovalMetadata = `
+	# HELP certmanager_certificate_waiting_for_approval Whether the issuance of the certificate is waiting for its CertificateRequest to be approved, 1 if it is and 0 otherwise.
+	# TYPE certmanager_certificate_waiting_for_approval gauge
+`
+
+func TestCertificateWaitingForApprovalMetric(t *testing.T) {
+	crt := gen.Certificate("test-certificate",
+		gen.SetCertificateNamespace("test-ns"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{
+			Name:  "test-issuer",
+			Kind:  "test-issuer-kind",
+			Group: "test-issuer-group",
+		}),
+		gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+			Type:   cmapi.CertificateConditionIssuing,
+			Status: cmmeta.ConditionTrue,
+			Reason: "ManuallyTriggered",
+		}),
+		gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+			Type:   cmapi.CertificateConditionWaitingForApproval,
+			Status: cmmeta.ConditionTrue,
+			Reason: cmapi.CertificateReasonWaitingForApproval,
+		}),
+	)
+
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+	m.UpdateCertificate(crt)
+	if err := testutil.CollectAndCompare(m.certificateWaitingForApproval,
+		strings.NewReader(waitingForApprovalMetadata+`
+	certmanager_certificate_waiting_for_approval{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="test-certificate",namespace="test-ns"} 1
+`),
+		"certmanager_certificate_waiting_for_approval",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	// Once the CertificateRequest is approved, the certificate is no longer
+	// reported as waiting.
+	crt = gen.CertificateFrom(crt, gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+		Type:   cmapi.CertificateConditionWaitingForApproval,
+		Status: cmmeta.ConditionFalse,
+		Reason: cmapi.CertificateReasonApproved,
+	}))
+	m.UpdateCertificate(crt)
+	if err := testutil.CollectAndCompare(m.certificateWaitingForApproval,
+		strings.NewReader(waitingForApprovalMetadata+`
+	certmanager_certificate_waiting_for_approval{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="test-certificate",namespace="test-ns"} 0
+`),
+		"certmanager_certificate_waiting_for_approval",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	m.RemoveCertificate("test-ns/test-certificate")
+	if count := testutil.CollectAndCount(m.certificateWaitingForApproval); count != 0 {
+		t.Errorf("expected the metric to be removed, got %d series", count)
+	}
+}
+
 func TestCertificateCache(t *testing.T) {
 	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
 
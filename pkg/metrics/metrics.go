@@ -23,6 +23,9 @@ limitations under the License.
 // acme_client_request_duration_seconds{"scheme", "host", "path", "method", "status"}
 // venafi_client_request_duration_seconds{"scheme", "host", "path", "method", "status"}
 // controller_sync_call_count{"controller"}
+// controller_sync_deadline_exceeded_count{"controller"}
+// controller_queue_wait_duration_seconds{"controller", "priority"}
+// certificaterequest_approved_count{"origin"}
 package metrics
 
 import (
@@ -57,11 +60,23 @@ type Metrics struct {
 	certificateExpiryTimeSeconds       *prometheus.GaugeVec
 	certificateRenewalTimeSeconds      *prometheus.GaugeVec
 	certificateReadyStatus             *prometheus.GaugeVec
+	certificateIssuerChainExpiryTime   *prometheus.GaugeVec
+	certificateWaitingForApproval      *prometheus.GaugeVec
 	acmeClientRequestDurationSeconds   *prometheus.SummaryVec
 	acmeClientRequestCount             *prometheus.CounterVec
+	acmeAccountStatus                  *prometheus.GaugeVec
 	venafiClientRequestDurationSeconds *prometheus.SummaryVec
 	controllerSyncCallCount            *prometheus.CounterVec
 	controllerSyncErrorCount           *prometheus.CounterVec
+	controllerSyncDeadlineExceeded     *prometheus.CounterVec
+	controllerActiveWorkers            *prometheus.GaugeVec
+	controllerQueueWaitDuration        *prometheus.HistogramVec
+	certificateRequestApprovedCount    *prometheus.CounterVec
+	secretAnnotationMigrationCount     *prometheus.CounterVec
+	secretAnnotationMigrationPending   prometheus.Gauge
+
+	// handlers are additional handlers served by the metrics server.
+	handlers map[string]http.Handler
 }
 
 var readyConditionStatuses = [...]cmmeta.ConditionStatus{cmmeta.ConditionTrue, cmmeta.ConditionFalse, cmmeta.ConditionUnknown}
@@ -127,6 +142,24 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 			[]string{"name", "namespace", "condition", "issuer_name", "issuer_kind", "issuer_group"},
 		)
 
+		certificateIssuerChainExpiryTime = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "certificate_issuer_chain_expiration_timestamp_seconds",
+				Help:      "The date after which the first certificate in the issuer chain stored with the certificate expires. Expressed as a Unix Epoch Time.",
+			},
+			[]string{"name", "namespace", "issuer_name", "issuer_kind", "issuer_group"},
+		)
+
+		certificateWaitingForApproval = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "certificate_waiting_for_approval",
+				Help:      "Whether the issuance of the certificate is waiting for its CertificateRequest to be approved, 1 if it is and 0 otherwise.",
+			},
+			[]string{"name", "namespace", "issuer_name", "issuer_kind", "issuer_group"},
+		)
+
 		// acmeClientRequestCount is a Prometheus summary to collect the number of
 		// requests made to each endpoint with the ACME client.
 		acmeClientRequestCount = prometheus.NewCounterVec(
@@ -152,6 +185,15 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 			[]string{"scheme", "host", "path", "method", "status"},
 		)
 
+		acmeAccountStatus = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "acme_account_status",
+				Help:      "The status of the ACME account of an ACME issuer as most recently reported by the ACME server, 1 for the current status and 0 otherwise.",
+			},
+			[]string{"name", "namespace", "kind", "status"},
+		)
+
 		// venafiClientRequestDurationSeconds is a Prometheus summary to
 		// collect api call latencies for the Venafi client. This
 		// metric is in alpha since cert-manager 1.9. Move it to GA once
@@ -184,6 +226,60 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 			},
 			[]string{"controller"},
 		)
+
+		controllerSyncDeadlineExceeded = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "controller_sync_deadline_exceeded_count",
+				Help:      "The number of controller sync() calls which did not complete within the sync timeout.",
+			},
+			[]string{"controller"},
+		)
+
+		controllerActiveWorkers = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "controller_active_workers",
+				Help:      "The number of workers of a controller which are currently processing an item.",
+			},
+			[]string{"controller"},
+		)
+
+		controllerQueueWaitDuration = prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Name:      "controller_queue_wait_duration_seconds",
+				Help:      "How long items waited in the priority workqueue of a controller before being processed, by priority class.",
+				Buckets:   prometheus.ExponentialBuckets(0.01, 4, 10),
+			},
+			[]string{"controller", "priority"},
+		)
+
+		certificateRequestApprovedCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "certificaterequest_approved_count",
+				Help:      "The number of CertificateRequests approved by cert-manager, by whether they were created for a Certificate (managed) or directly (direct).",
+			},
+			[]string{"origin"},
+		)
+
+		secretAnnotationMigrationCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "secret_annotation_migration_count",
+				Help:      "The number of Secrets whose legacy issuer annotations were migrated, by result (migrated or failed).",
+			},
+			[]string{"result"},
+		)
+
+		secretAnnotationMigrationPending = prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "secret_annotation_migration_pending",
+				Help:      "The number of Secrets observed with legacy issuer annotations which have not been migrated yet.",
+			},
+		)
 	)
 
 	// Create server and register Prometheus metrics handler
@@ -196,16 +292,33 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 		certificateExpiryTimeSeconds:       certificateExpiryTimeSeconds,
 		certificateRenewalTimeSeconds:      certificateRenewalTimeSeconds,
 		certificateReadyStatus:             certificateReadyStatus,
+		certificateIssuerChainExpiryTime:   certificateIssuerChainExpiryTime,
+		certificateWaitingForApproval:      certificateWaitingForApproval,
 		acmeClientRequestCount:             acmeClientRequestCount,
 		acmeClientRequestDurationSeconds:   acmeClientRequestDurationSeconds,
+		acmeAccountStatus:                  acmeAccountStatus,
 		venafiClientRequestDurationSeconds: venafiClientRequestDurationSeconds,
 		controllerSyncCallCount:            controllerSyncCallCount,
 		controllerSyncErrorCount:           controllerSyncErrorCount,
+		controllerSyncDeadlineExceeded:     controllerSyncDeadlineExceeded,
+		controllerActiveWorkers:            controllerActiveWorkers,
+		controllerQueueWaitDuration:        controllerQueueWaitDuration,
+		certificateRequestApprovedCount:    certificateRequestApprovedCount,
+		secretAnnotationMigrationCount:     secretAnnotationMigrationCount,
+		secretAnnotationMigrationPending:   secretAnnotationMigrationPending,
+
+		handlers: make(map[string]http.Handler),
 	}
 
 	return m
 }
 
+// Handle registers an additional handler to be served by the metrics server
+// for the given pattern. It must be called before NewServer.
+func (m *Metrics) Handle(pattern string, handler http.Handler) {
+	m.handlers[pattern] = handler
+}
+
 // NewServer registers Prometheus metrics and returns a new Prometheus metrics HTTP server.
 func (m *Metrics) NewServer(ln net.Listener) *http.Server {
 	m.registry.MustRegister(m.clockTimeSeconds)
@@ -213,14 +326,26 @@ func (m *Metrics) NewServer(ln net.Listener) *http.Server {
 	m.registry.MustRegister(m.certificateExpiryTimeSeconds)
 	m.registry.MustRegister(m.certificateRenewalTimeSeconds)
 	m.registry.MustRegister(m.certificateReadyStatus)
+	m.registry.MustRegister(m.certificateIssuerChainExpiryTime)
+	m.registry.MustRegister(m.certificateWaitingForApproval)
 	m.registry.MustRegister(m.acmeClientRequestDurationSeconds)
 	m.registry.MustRegister(m.venafiClientRequestDurationSeconds)
 	m.registry.MustRegister(m.acmeClientRequestCount)
+	m.registry.MustRegister(m.acmeAccountStatus)
 	m.registry.MustRegister(m.controllerSyncCallCount)
 	m.registry.MustRegister(m.controllerSyncErrorCount)
+	m.registry.MustRegister(m.controllerSyncDeadlineExceeded)
+	m.registry.MustRegister(m.controllerActiveWorkers)
+	m.registry.MustRegister(m.controllerQueueWaitDuration)
+	m.registry.MustRegister(m.certificateRequestApprovedCount)
+	m.registry.MustRegister(m.secretAnnotationMigrationCount)
+	m.registry.MustRegister(m.secretAnnotationMigrationPending)
 
 	mux := http.NewServeMux()
 	mux.Handle("/metrics", promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
+	for pattern, handler := range m.handlers {
+		mux.Handle(pattern, handler)
+	}
 
 	server := &http.Server{
 		Addr:           ln.Addr().String(),
@@ -242,3 +367,45 @@ func (m *Metrics) IncrementSyncCallCount(controllerName string) {
 func (m *Metrics) IncrementSyncErrorCount(controllerName string) {
 	m.controllerSyncErrorCount.WithLabelValues(controllerName).Inc()
 }
+
+// IncrementSyncDeadlineExceededCount will increase the count of syncs of that
+// controller which did not complete within the sync timeout.
+func (m *Metrics) IncrementSyncDeadlineExceededCount(controllerName string) {
+	m.controllerSyncDeadlineExceeded.WithLabelValues(controllerName).Inc()
+}
+
+// IncrementActiveWorkers will increase the number of workers of that controller
+// which are processing an item.
+func (m *Metrics) IncrementActiveWorkers(controllerName string) {
+	m.controllerActiveWorkers.WithLabelValues(controllerName).Inc()
+}
+
+// DecrementActiveWorkers will decrease the number of workers of that controller
+// which are processing an item.
+func (m *Metrics) DecrementActiveWorkers(controllerName string) {
+	m.controllerActiveWorkers.WithLabelValues(controllerName).Dec()
+}
+
+// ObserveQueueWaitDuration will record how long an item of that priority
+// class waited in the workqueue of that controller before being processed.
+func (m *Metrics) ObserveQueueWaitDuration(controllerName, priority string, wait time.Duration) {
+	m.controllerQueueWaitDuration.WithLabelValues(controllerName, priority).Observe(wait.Seconds())
+}
+
+// IncrementCertificateRequestApprovedCount will increase the count of
+// CertificateRequests of that origin which were approved.
+func (m *Metrics) IncrementCertificateRequestApprovedCount(origin string) {
+	m.certificateRequestApprovedCount.WithLabelValues(origin).Inc()
+}
+
+// IncrementSecretAnnotationMigrationCount will increase the count of Secrets
+// whose issuer annotations were migrated with that result.
+func (m *Metrics) IncrementSecretAnnotationMigrationCount(result string) {
+	m.secretAnnotationMigrationCount.WithLabelValues(result).Inc()
+}
+
+// SetSecretAnnotationMigrationPending will set the number of Secrets whose
+// issuer annotations have not been migrated yet.
+func (m *Metrics) SetSecretAnnotationMigrationPending(pending int) {
+	m.secretAnnotationMigrationPending.Set(float64(pending))
+}
@@ -27,6 +27,8 @@ import (
 	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	fakeclock "k8s.io/utils/clock/testing"
+
+	"github.com/cert-manager/cert-manager/test/unit/gen"
 )
 
 func Test_clockTimeSeconds(t *testing.T) {
@@ -67,3 +69,72 @@ certmanager_clock_time_seconds_gauge %f
 		})
 	}
 }
+
+func Test_certificateRequestApprovedCount(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), fakeclock.NewFakeClock(time.Now()))
+
+	m.IncrementCertificateRequestApprovedCount("managed")
+	m.IncrementCertificateRequestApprovedCount("managed")
+	m.IncrementCertificateRequestApprovedCount("direct")
+
+	assert.NoError(t, testutil.CollectAndCompare(m.certificateRequestApprovedCount, strings.NewReader(`
+# HELP certmanager_certificaterequest_approved_count The number of CertificateRequests approved by cert-manager, by whether they were created for a Certificate (managed) or directly (direct).
+# TYPE certmanager_certificaterequest_approved_count counter
+certmanager_certificaterequest_approved_count{origin="direct"} 1
+certmanager_certificaterequest_approved_count{origin="managed"} 2
+`), "certmanager_certificaterequest_approved_count"))
+}
+
+func Test_controllerQueueWaitDuration(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), fakeclock.NewFakeClock(time.Now()))
+
+	m.ObserveQueueWaitDuration("issuing", "high", 20*time.Millisecond)
+	m.ObserveQueueWaitDuration("issuing", "high", time.Second)
+	m.ObserveQueueWaitDuration("issuing", "low", time.Minute)
+
+	assert.Equal(t, 2, testutil.CollectAndCount(m.controllerQueueWaitDuration, "certmanager_controller_queue_wait_duration_seconds"),
+		"expected a series per controller and priority class")
+}
+
+func Test_secretAnnotationMigration(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), fakeclock.NewFakeClock(time.Now()))
+
+	m.IncrementSecretAnnotationMigrationCount("migrated")
+	m.IncrementSecretAnnotationMigrationCount("migrated")
+	m.IncrementSecretAnnotationMigrationCount("failed")
+	m.SetSecretAnnotationMigrationPending(3)
+
+	assert.NoError(t, testutil.CollectAndCompare(m.secretAnnotationMigrationCount, strings.NewReader(`
+# HELP certmanager_secret_annotation_migration_count The number of Secrets whose legacy issuer annotations were migrated, by result (migrated or failed).
+# TYPE certmanager_secret_annotation_migration_count counter
+certmanager_secret_annotation_migration_count{result="failed"} 1
+certmanager_secret_annotation_migration_count{result="migrated"} 2
+`), "certmanager_secret_annotation_migration_count"))
+	assert.NoError(t, testutil.CollectAndCompare(m.secretAnnotationMigrationPending, strings.NewReader(`
+# HELP certmanager_secret_annotation_migration_pending The number of Secrets observed with legacy issuer annotations which have not been migrated yet.
+# TYPE certmanager_secret_annotation_migration_pending gauge
+certmanager_secret_annotation_migration_pending 3
+`), "certmanager_secret_annotation_migration_pending"))
+}
+
+func Test_acmeAccountStatus(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), fakeclock.NewFakeClock(time.Now()))
+
+	m.UpdateACMEAccountStatus(gen.Issuer("letsencrypt", gen.SetIssuerNamespace("team-a")), "valid")
+	m.UpdateACMEAccountStatus(gen.ClusterIssuer("letsencrypt"), "valid")
+	m.UpdateACMEAccountStatus(gen.ClusterIssuer("letsencrypt"), "deactivated")
+
+	assert.NoError(t, testutil.CollectAndCompare(m.acmeAccountStatus, strings.NewReader(`
+# HELP certmanager_acme_account_status The status of the ACME account of an ACME issuer as most recently reported by the ACME server, 1 for the current status and 0 otherwise.
+# TYPE certmanager_acme_account_status gauge
+certmanager_acme_account_status{kind="ClusterIssuer",name="letsencrypt",namespace="",status="deactivated"} 1
+certmanager_acme_account_status{kind="ClusterIssuer",name="letsencrypt",namespace="",status="revoked"} 0
+certmanager_acme_account_status{kind="ClusterIssuer",name="letsencrypt",namespace="",status="valid"} 0
+certmanager_acme_account_status{kind="Issuer",name="letsencrypt",namespace="team-a",status="deactivated"} 0
+certmanager_acme_account_status{kind="Issuer",name="letsencrypt",namespace="team-a",status="revoked"} 0
+certmanager_acme_account_status{kind="Issuer",name="letsencrypt",namespace="team-a",status="valid"} 1
+`), "certmanager_acme_account_status"))
+
+	m.RemoveACMEAccountStatus("ClusterIssuer", "", "letsencrypt")
+	assert.Equal(t, 3, testutil.CollectAndCount(m.acmeAccountStatus, "certmanager_acme_account_status"))
+}
@@ -202,6 +202,18 @@ func CertificateTemplateFromCSR(csr *x509.CertificateRequest, validatorMutators
 			template.ExcludedURIDomains = nameConstraints.ExcludedURIDomains
 		}
 
+		// RFC 5280, 4.2.1.4
+		if val.Id.Equal(OIDExtensionCertificatePolicies) {
+			policies, err := UnmarshalCertificatePolicies(val.Value)
+			if err != nil {
+				return err
+			}
+
+			if err := setCertificatePolicies(template, policies); err != nil {
+				return err
+			}
+		}
+
 		// RFC 5280, 4.2.1.3
 		if val.Id.Equal(OIDExtensionKeyUsage) {
 			usage, err := UnmarshalKeyUsage(val.Value)
@@ -30,6 +30,7 @@ import (
 	"net"
 	"net/netip"
 	"net/url"
+	"slices"
 
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
@@ -83,6 +84,27 @@ func SubjectForCertificate(crt *v1.Certificate) v1.X509Subject {
 	return *crt.Spec.Subject
 }
 
+// MaxCommonNameLength is the maximum length of a common name accepted in a
+// Certificate, as set by the ub-common-name upper bound of RFC 5280.
+const MaxCommonNameLength = 64
+
+// EffectiveCommonName returns the common name requested by the Certificate
+// spec. This is `commonName` if it is set. Otherwise, if
+// `commonNameFromFirstDNSName` is set and `literalSubject` is not, this is the
+// first DNS name, unless it is longer than MaxCommonNameLength.
+func EffectiveCommonName(spec v1.CertificateSpec) string {
+	if spec.CommonName != "" {
+		return spec.CommonName
+	}
+	if !spec.CommonNameFromFirstDNSName || spec.LiteralSubject != "" || len(spec.DNSNames) == 0 {
+		return ""
+	}
+	if len(spec.DNSNames[0]) > MaxCommonNameLength {
+		return ""
+	}
+	return spec.DNSNames[0]
+}
+
 var serialNumberLimit = new(big.Int).Lsh(big.NewInt(1), 128)
 
 func KeyUsagesForCertificateOrCertificateRequest(usages []v1.KeyUsage, isCA bool) (ku x509.KeyUsage, eku []x509.ExtKeyUsage, err error) {
@@ -177,7 +199,22 @@ func GenerateCSR(crt *v1.Certificate, optFuncs ...GenerateCSROption) (*x509.Cert
 	} else {
 		subject := SubjectForCertificate(crt)
 
-		commonName = crt.Spec.CommonName
+		commonName = EffectiveCommonName(crt.Spec)
+		// A common name which is also one of the DNS names is encoded in the
+		// same form as the DNS name, as required by CAs such as ACME servers.
+		// Names are compared once normalized, so that a common name which only
+		// differs from a DNS name in case is encoded exactly as the DNS name,
+		// and the request does not contain two forms of the same name.
+		if commonName != "" {
+			normalized := NormalizeDNSName(commonName)
+			if i := slices.IndexFunc(crt.Spec.DNSNames, func(name string) bool {
+				return NormalizeDNSName(name) == normalized
+			}); i >= 0 {
+				if ascii, err := DNSNameToASCII(crt.Spec.DNSNames[i]); err == nil {
+					commonName = ascii
+				}
+			}
+		}
 		rdnSubject = pkix.Name{
 			Country:            subject.Countries,
 			Organization:       subject.Organizations,
@@ -197,9 +234,16 @@ func GenerateCSR(crt *v1.Certificate, optFuncs ...GenerateCSROption) (*x509.Cert
 		return nil, err
 	}
 
+	// Internationalized DNS names are always encoded as A-labels, and names
+	// which only differ once normalized are only requested once.
+	dnsNames, err := DNSNamesToASCII(DeduplicateDNSNames(crt.Spec.DNSNames))
+	if err != nil {
+		return nil, err
+	}
+
 	sans := GeneralNames{
 		RFC822Names:                crt.Spec.EmailAddresses,
-		DNSNames:                   crt.Spec.DNSNames,
+		DNSNames:                   dnsNames,
 		UniformResourceIdentifiers: crt.Spec.URIs,
 		IPAddresses:                ipAddresses,
 	}
@@ -319,6 +363,24 @@ func GenerateCSR(crt *v1.Certificate, optFuncs ...GenerateCSROption) (*x509.Cert
 		}
 	}
 
+	if len(crt.Spec.PolicyOIDs) > 0 {
+		policies := make([]asn1.ObjectIdentifier, 0, len(crt.Spec.PolicyOIDs))
+		for _, policyOID := range crt.Spec.PolicyOIDs {
+			oid, err := ParsePolicyOID(policyOID)
+			if err != nil {
+				return nil, err
+			}
+			policies = append(policies, oid)
+		}
+
+		extension, err := MarshalCertificatePolicies(policies)
+		if err != nil {
+			return nil, err
+		}
+
+		extraExtensions = append(extraExtensions, extension)
+	}
+
 	cr := &x509.CertificateRequest{
 		// Version 0 is the only one defined in the PKCS#10 standard, RFC2986.
 		// This value isn't used by Go at the time of writing.
@@ -394,6 +456,51 @@ func EncodeCSR(template *x509.CertificateRequest, key crypto.Signer) ([]byte, er
 	return derBytes, nil
 }
 
+// CertificateRequestEncoding is the encoding of the CSR stored in the
+// spec.request field of a CertificateRequest.
+type CertificateRequestEncoding string
+
+const (
+	// CertificateRequestEncodingPEM encodes the CSR as a PEM block of type
+	// "CERTIFICATE REQUEST". This is the default encoding.
+	CertificateRequestEncodingPEM CertificateRequestEncoding = "PEM"
+
+	// CertificateRequestEncodingLegacyPEM encodes the CSR as a PEM block of
+	// type "NEW CERTIFICATE REQUEST", as expected by some legacy parsers.
+	CertificateRequestEncodingLegacyPEM CertificateRequestEncoding = "LegacyPEM"
+
+	// CertificateRequestEncodingDER stores the DER encoded CSR without any
+	// PEM armor.
+	CertificateRequestEncodingDER CertificateRequestEncoding = "DER"
+)
+
+// CertificateRequestEncodings is the list of supported CertificateRequestEncodings.
+var CertificateRequestEncodings = []CertificateRequestEncoding{
+	CertificateRequestEncodingPEM,
+	CertificateRequestEncodingLegacyPEM,
+	CertificateRequestEncodingDER,
+}
+
+// EncodeCertificateRequest encodes a DER encoded CSR using the given
+// encoding. An empty encoding is equivalent to CertificateRequestEncodingPEM.
+// The DER bytes of the CSR are never changed, so that the result can be
+// decoded with DecodeX509CertificateRequestBytes whatever the encoding.
+func EncodeCertificateRequest(csrDER []byte, encoding CertificateRequestEncoding) ([]byte, error) {
+	var blockType string
+	switch encoding {
+	case "", CertificateRequestEncodingPEM:
+		blockType = "CERTIFICATE REQUEST"
+	case CertificateRequestEncodingLegacyPEM:
+		blockType = "NEW CERTIFICATE REQUEST"
+	case CertificateRequestEncodingDER:
+		return bytes.Clone(csrDER), nil
+	default:
+		return nil, fmt.Errorf("unsupported certificate request encoding %q", encoding)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: csrDER}), nil
+}
+
 // EncodeX509 will encode a single *x509.Certificate into PEM format.
 func EncodeX509(cert *x509.Certificate) ([]byte, error) {
 	caPem := bytes.NewBuffer([]byte{})
@@ -467,18 +574,11 @@ func SignatureAlgorithm(crt *v1.Certificate) (x509.PublicKeyAlgorithm, x509.Sign
 		sigAlgo = x509.PureEd25519
 	case v1.ECDSAKeyAlgorithm:
 		pubKeyAlgo = x509.ECDSA
-		switch crt.Spec.PrivateKey.Size {
-		case 521:
-			sigAlgo = x509.ECDSAWithSHA512
-		case 384:
-			sigAlgo = x509.ECDSAWithSHA384
-		case 256:
-			sigAlgo = x509.ECDSAWithSHA256
-		case 0:
-			sigAlgo = x509.ECDSAWithSHA256
-		default:
+		k, ok := lookupECDSAKeySize(crt.Spec.PrivateKey.Size)
+		if !ok {
 			return x509.UnknownPublicKeyAlgorithm, x509.UnknownSignatureAlgorithm, fmt.Errorf("unsupported ecdsa keysize specified: %d", crt.Spec.PrivateKey.Size)
 		}
+		sigAlgo = k.signatureAlgorithm
 	default:
 		return x509.UnknownPublicKeyAlgorithm, x509.UnknownSignatureAlgorithm, fmt.Errorf("unsupported algorithm specified: %s. should be either 'ecdsa' or 'rsa", crt.Spec.PrivateKey.Algorithm)
 	}
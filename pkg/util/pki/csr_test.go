@@ -22,10 +22,13 @@ import (
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/asn1"
+	"encoding/hex"
+	"encoding/pem"
 	"fmt"
 	"math/big"
 	"net"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -315,6 +318,44 @@ func OtherNameSANRawVal(expectedOID asn1.ObjectIdentifier) (asn1.RawValue, error
 	return rawVal, nil
 }
 
+func TestEffectiveCommonName(t *testing.T) {
+	longName := strings.Repeat("a", 60) + ".example.com"
+	tests := map[string]struct {
+		spec     cmapi.CertificateSpec
+		expected string
+	}{
+		"commonName is used if set": {
+			spec:     cmapi.CertificateSpec{CommonName: "cn", CommonNameFromFirstDNSName: true, DNSNames: []string{"example.com"}},
+			expected: "cn",
+		},
+		"the first dnsName is not used if commonNameFromFirstDNSName is not set": {
+			spec: cmapi.CertificateSpec{DNSNames: []string{"example.com"}},
+		},
+		"the first dnsName is used if commonNameFromFirstDNSName is set": {
+			spec:     cmapi.CertificateSpec{CommonNameFromFirstDNSName: true, DNSNames: []string{"example.com", "www.example.com"}},
+			expected: "example.com",
+		},
+		"a wildcard first dnsName is used": {
+			spec:     cmapi.CertificateSpec{CommonNameFromFirstDNSName: true, DNSNames: []string{"*.example.com", "example.com"}},
+			expected: "*.example.com",
+		},
+		"a first dnsName longer than 64 characters is not used": {
+			spec: cmapi.CertificateSpec{CommonNameFromFirstDNSName: true, DNSNames: []string{longName, "example.com"}},
+		},
+		"the first dnsName is not used if literalSubject is set": {
+			spec: cmapi.CertificateSpec{CommonNameFromFirstDNSName: true, LiteralSubject: "O=example", DNSNames: []string{"example.com"}},
+		},
+		"nothing is used without dnsNames": {
+			spec: cmapi.CertificateSpec{CommonNameFromFirstDNSName: true, URIs: []string{"spiffe://example.com/foo"}},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, EffectiveCommonName(test.spec))
+		})
+	}
+}
+
 func TestGenerateCSR(t *testing.T) {
 	exampleLiteralSubject := "CN=actual-cn, OU=FooLong, OU=Bar, O=example.org"
 	exampleMultiValueRDNLiteralSubject := "CN=actual-cn, OU=FooLong+OU=Bar, O=example.org"
@@ -479,6 +520,63 @@ func TestGenerateCSR(t *testing.T) {
 				RawSubject: subjectGenerator(t, pkix.Name{CommonName: "example.org"}),
 			},
 		},
+		{
+			name: "Generate CSR from certificate with DNS names differing only in case, trailing dot or IDNA form",
+			crt: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				Subject:  &cmapi.X509Subject{Organizations: []string{"example inc."}},
+				DNSNames: []string{"Example.org", "example.org.", "EXAMPLE.ORG", "bücher.example.org", "xn--bcher-kva.example.org", "*.example.org", "www.example.org"},
+			}},
+			want: &x509.CertificateRequest{
+				Version:            0,
+				SignatureAlgorithm: x509.SHA256WithRSA,
+				PublicKeyAlgorithm: x509.RSA,
+				ExtraExtensions: []pkix.Extension{
+					sansGenerator(
+						t,
+						[]asn1.RawValue{
+							{Tag: nameTypeDNSName, Class: 2, Bytes: []byte("Example.org")},
+							{Tag: nameTypeDNSName, Class: 2, Bytes: []byte("xn--bcher-kva.example.org")},
+							{Tag: nameTypeDNSName, Class: 2, Bytes: []byte("*.example.org")},
+							{Tag: nameTypeDNSName, Class: 2, Bytes: []byte("www.example.org")},
+						},
+						false, // SAN is NOT critical as the Subject is not empty
+					),
+					{
+						Id:       OIDExtensionKeyUsage,
+						Value:    asn1DefaultKeyUsage,
+						Critical: true,
+					},
+				},
+				RawSubject: subjectGenerator(t, pkix.Name{Organization: []string{"example inc."}}),
+			},
+		},
+		{
+			name: "Generate CSR from certificate with CN equal to a DNS name in a different case",
+			crt: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				CommonName: "WWW.Example.org",
+				DNSNames:   []string{"www.example.org", "WWW.EXAMPLE.ORG"},
+			}},
+			want: &x509.CertificateRequest{
+				Version:            0,
+				SignatureAlgorithm: x509.SHA256WithRSA,
+				PublicKeyAlgorithm: x509.RSA,
+				ExtraExtensions: []pkix.Extension{
+					sansGenerator(
+						t,
+						[]asn1.RawValue{
+							{Tag: nameTypeDNSName, Class: 2, Bytes: []byte("www.example.org")},
+						},
+						false, // SAN is NOT critical as the Subject is not empty
+					),
+					{
+						Id:       OIDExtensionKeyUsage,
+						Value:    asn1DefaultKeyUsage,
+						Critical: true,
+					},
+				},
+				RawSubject: subjectGenerator(t, pkix.Name{CommonName: "www.example.org"}),
+			},
+		},
 		{
 			name: "Generate CSR from certificate with isCA set",
 			crt:  &cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.org", IsCA: true}},
@@ -876,6 +974,67 @@ func TestGenerateCSR(t *testing.T) {
 	}
 }
 
+// TestGenerateCSRSubjectMatchesOpenSSL checks the DER encoding of the subject
+// of generated CSRs against that of a CSR generated by OpenSSL, with
+// `string_mask = default`, using:
+//
+//	openssl req -new -subj "/C=DE/ST=Berlin/L=Berlin/street=Friedrichstrasse 123/postalCode=10117/O=Example GmbH/OU=Platform/CN=example.com/serialNumber=CSM01-4242"
+func TestGenerateCSRSubjectMatchesOpenSSL(t *testing.T) {
+	openSSLSubject, err := hex.DecodeString("" +
+		"3081b3310b3009060355040613024445310f300d060355040813064265726c696e310f300d060355040713064265726c" +
+		"696e311d301b060355040913144672696564726963687374726173736520313233310e300c0603550411130531303131" +
+		"3731153013060355040a130c4578616d706c6520476d62483111300f060355040b1308506c6174666f726d3114301206" +
+		"03550403130b6578616d706c652e636f6d311330110603550405130a43534d30312d34323432")
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		spec                                    cmapi.CertificateSpec
+		literalCertificateSubjectFeatureEnabled bool
+	}{
+		"subject": {
+			spec: cmapi.CertificateSpec{
+				CommonName: "example.com",
+				Subject: &cmapi.X509Subject{
+					Countries:           []string{"DE"},
+					Provinces:           []string{"Berlin"},
+					Localities:          []string{"Berlin"},
+					StreetAddresses:     []string{"Friedrichstrasse 123"},
+					PostalCodes:         []string{"10117"},
+					Organizations:       []string{"Example GmbH"},
+					OrganizationalUnits: []string{"Platform"},
+					SerialNumber:        "CSM01-4242",
+				},
+			},
+		},
+		"literal subject": {
+			spec: cmapi.CertificateSpec{
+				LiteralSubject: "SERIALNUMBER=CSM01-4242,CN=example.com,OU=Platform,O=Example GmbH,POSTALCODE=10117,STREET=Friedrichstrasse 123,L=Berlin,ST=Berlin,C=DE",
+			},
+			literalCertificateSubjectFeatureEnabled: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			csr, err := GenerateCSR(&cmapi.Certificate{Spec: test.spec}, WithUseLiteralSubject(test.literalCertificateSubjectFeatureEnabled))
+			require.NoError(t, err)
+			assert.Equal(t, openSSLSubject, csr.RawSubject)
+
+			// The subject must round trip through an encoded CSR.
+			pk, err := GenerateRSAPrivateKey(2048)
+			require.NoError(t, err)
+			csrDER, err := EncodeCSR(csr, pk)
+			require.NoError(t, err)
+			parsed, err := x509.ParseCertificateRequest(csrDER)
+			require.NoError(t, err)
+			assert.Equal(t, openSSLSubject, parsed.RawSubject)
+			assert.Equal(t, "CSM01-4242", parsed.Subject.SerialNumber)
+			assert.Equal(t, []string{"Friedrichstrasse 123"}, parsed.Subject.StreetAddress)
+			assert.Equal(t, []string{"10117"}, parsed.Subject.PostalCode)
+		})
+	}
+}
+
 func TestSignCSRTemplate(t *testing.T) {
 	// We want to test the behavior of SignCSRTemplate in various contexts;
 	// for that, we construct a chain of four certificates:
@@ -1007,6 +1166,60 @@ func TestSignCSRTemplate(t *testing.T) {
 	}
 }
 
+func TestEncodeCertificateRequest(t *testing.T) {
+	pk, err := GenerateECPrivateKey(256)
+	require.NoError(t, err)
+	csrDER, err := EncodeCSR(&x509.CertificateRequest{Subject: pkix.Name{CommonName: "example.com"}}, pk)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		encoding CertificateRequestEncoding
+
+		expected    []byte
+		expectedErr string
+	}{
+		"an empty encoding is PEM": {
+			expected: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER}),
+		},
+		"PEM": {
+			encoding: CertificateRequestEncodingPEM,
+			expected: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER}),
+		},
+		"LegacyPEM": {
+			encoding: CertificateRequestEncodingLegacyPEM,
+			expected: pem.EncodeToMemory(&pem.Block{Type: "NEW CERTIFICATE REQUEST", Bytes: csrDER}),
+		},
+		"DER": {
+			encoding: CertificateRequestEncodingDER,
+			expected: csrDER,
+		},
+		"unsupported encodings are an error": {
+			encoding:    "PKCS7",
+			expectedErr: `unsupported certificate request encoding "PKCS7"`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			encoded, err := EncodeCertificateRequest(csrDER, test.encoding)
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, encoded)
+
+			// Decoding always returns the original DER bytes.
+			csr, err := DecodeX509CertificateRequestBytes(encoded)
+			require.NoError(t, err)
+			assert.Equal(t, csrDER, csr.Raw)
+			assert.Equal(t, "example.com", csr.Subject.CommonName)
+		})
+	}
+
+	_, err = DecodeX509CertificateRequestBytes([]byte("not a certificate request"))
+	assert.EqualError(t, err, "error decoding certificate request PEM block")
+}
+
 func TestEncodeX509Chain(t *testing.T) {
 	root := mustCreateBundle(t, nil, "root")
 	intA1 := mustCreateBundle(t, root, "intA-1")
@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DeduplicateDNSNames returns the given DNS names without the names which are
+// equal to an earlier name once normalized, see NormalizeDNSName. The first
+// occurrence of each name is kept in the form it was given.
+func DeduplicateDNSNames(names []string) []string {
+	if names == nil {
+		return nil
+	}
+	seen := make(map[string]struct{}, len(names))
+	out := make([]string, 0, len(names))
+	for _, name := range names {
+		normalized := NormalizeDNSName(name)
+		if _, ok := seen[normalized]; ok {
+			continue
+		}
+		seen[normalized] = struct{}{}
+		out = append(out, name)
+	}
+	return out
+}
+
+// ValidateWildcardDNSName returns an error if name contains a wildcard in any
+// label other than the leftmost label, or if the leftmost label contains more
+// than the wildcard. As defined in RFC 6125, a wildcard only matches a single
+// label, so names such as "*.*.example.com" cannot be satisfied by any CA.
+func ValidateWildcardDNSName(name string) error {
+	if !strings.Contains(name, "*") {
+		return nil
+	}
+	first, rest, _ := strings.Cut(name, ".")
+	if strings.Contains(rest, "*") {
+		return fmt.Errorf("a wildcard is only allowed in the leftmost label")
+	}
+	if first != "*" {
+		return fmt.Errorf("a wildcard must be the whole of the leftmost label")
+	}
+	if rest == "" {
+		return fmt.Errorf("a wildcard must be followed by at least one label")
+	}
+	return nil
+}
+
+// WildcardDNSNameCovers returns true if wildcard is a wildcard DNS name, such
+// as "*.example.com", matching name, such as "www.example.com". Both names are
+// compared once normalized, see NormalizeDNSName. A wildcard only matches a
+// single label, so "*.example.com" matches neither "example.com" nor
+// "a.b.example.com", and a wildcard never covers another wildcard.
+func WildcardDNSNameCovers(wildcard, name string) bool {
+	wildcard, name = NormalizeDNSName(wildcard), NormalizeDNSName(name)
+	suffix, ok := strings.CutPrefix(wildcard, "*")
+	if !ok || !strings.HasPrefix(suffix, ".") {
+		return false
+	}
+	label, ok := strings.CutSuffix(name, suffix)
+	return ok && label != "" && label != "*" && !strings.Contains(label, ".")
+}
+
+// DNSNamesCoveredByWildcard returns each name which is also matched by a
+// wildcard DNS name in the same list, mapped to the first such wildcard.
+func DNSNamesCoveredByWildcard(names []string) map[string]string {
+	covered := map[string]string{}
+	for _, name := range names {
+		for _, wildcard := range names {
+			if WildcardDNSNameCovers(wildcard, name) {
+				covered[name] = wildcard
+				break
+			}
+		}
+	}
+	return covered
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDeduplicateDNSNames(t *testing.T) {
+	tests := map[string]struct {
+		names []string
+		exp   []string
+	}{
+		"nil names": {
+			names: nil,
+			exp:   nil,
+		},
+		"distinct names are kept in order": {
+			names: []string{"b.example.com", "a.example.com"},
+			exp:   []string{"b.example.com", "a.example.com"},
+		},
+		"names differing only in case are removed, keeping the first": {
+			names: []string{"Example.com", "example.com", "EXAMPLE.COM"},
+			exp:   []string{"Example.com"},
+		},
+		"names differing only by a trailing dot are removed": {
+			names: []string{"example.com.", "example.com"},
+			exp:   []string{"example.com."},
+		},
+		"U-label and A-label forms of a name are removed": {
+			names: []string{"bücher.example.com", "xn--bcher-kva.example.com"},
+			exp:   []string{"bücher.example.com"},
+		},
+		"duplicate wildcards are removed": {
+			names: []string{"*.example.com", "*.EXAMPLE.com"},
+			exp:   []string{"*.example.com"},
+		},
+		"a wildcard and a name it covers are both kept": {
+			names: []string{"*.example.com", "www.example.com", "example.com"},
+			exp:   []string{"*.example.com", "www.example.com", "example.com"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := DeduplicateDNSNames(test.names); !reflect.DeepEqual(got, test.exp) {
+				t.Errorf("expected %v, got %v", test.exp, got)
+			}
+		})
+	}
+}
+
+func TestValidateWildcardDNSName(t *testing.T) {
+	tests := map[string]struct {
+		name    string
+		wantErr bool
+	}{
+		"name without a wildcard":              {name: "www.example.com"},
+		"wildcard in the leftmost label":       {name: "*.example.com"},
+		"internationalized wildcard":           {name: "*.bücher.example.com"},
+		"multi-label wildcard":                 {name: "*.*.example.com", wantErr: true},
+		"wildcard in a later label":            {name: "www.*.example.com", wantErr: true},
+		"partial wildcard in leftmost label":   {name: "w*.example.com", wantErr: true},
+		"bare wildcard":                        {name: "*", wantErr: true},
+		"wildcard followed only by a dot":      {name: "*.", wantErr: true},
+		"wildcard in the rightmost label only": {name: "example.*", wantErr: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateWildcardDNSName(test.name)
+			if (err != nil) != test.wantErr {
+				t.Errorf("expected error: %v, got: %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestWildcardDNSNameCovers(t *testing.T) {
+	tests := map[string]struct {
+		wildcard, name string
+		exp            bool
+	}{
+		"a single label is covered":                {wildcard: "*.example.com", name: "www.example.com", exp: true},
+		"case is ignored":                          {wildcard: "*.Example.com", name: "WWW.example.COM", exp: true},
+		"a trailing dot is ignored":                {wildcard: "*.example.com.", name: "www.example.com", exp: true},
+		"U-labels are covered by A-labels":         {wildcard: "*.xn--bcher-kva.example.com", name: "www.bücher.example.com", exp: true},
+		"the parent domain is not covered":         {wildcard: "*.example.com", name: "example.com"},
+		"several labels are not covered":           {wildcard: "*.example.com", name: "a.b.example.com"},
+		"a different domain is not covered":        {wildcard: "*.example.com", name: "www.example.org"},
+		"a suffix match is not covered":            {wildcard: "*.example.com", name: "wwwexample.com"},
+		"a wildcard does not cover itself":         {wildcard: "*.example.com", name: "*.example.com"},
+		"a wildcard does not cover a sub-wildcard": {wildcard: "*.example.com", name: "*.www.example.com"},
+		"a name without a wildcard covers nothing": {wildcard: "example.com", name: "example.com"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := WildcardDNSNameCovers(test.wildcard, test.name); got != test.exp {
+				t.Errorf("expected %v, got %v", test.exp, got)
+			}
+		})
+	}
+}
+
+func TestDNSNamesCoveredByWildcard(t *testing.T) {
+	got := DNSNamesCoveredByWildcard([]string{"www.example.com", "example.com", "*.example.com", "*.EXAMPLE.com", "a.b.example.com", "API.example.com"})
+	exp := map[string]string{
+		"www.example.com": "*.example.com",
+		"API.example.com": "*.example.com",
+	}
+	if !reflect.DeepEqual(got, exp) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFingerprintSHA256(t *testing.T) {
+	// SHA-256 digest of "certificate"
+	cert := &x509.Certificate{Raw: []byte("certificate")}
+	assert.Equal(t, "03d66dd08835c1ca3f128cceacd1f31ac94163096b20f445ae84285bc0832d72", FingerprintSHA256(cert))
+}
+
+func TestPublicKeyFingerprintSHA256(t *testing.T) {
+	pk, err := GenerateECPrivateKey(256)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(pk.Public())
+	require.NoError(t, err)
+	sum := sha256.Sum256(der)
+
+	fingerprint, err := PublicKeyFingerprintSHA256(pk.Public())
+	require.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(sum[:]), fingerprint)
+
+	other, err := GenerateECPrivateKey(256)
+	require.NoError(t, err)
+	otherFingerprint, err := PublicKeyFingerprintSHA256(other.Public())
+	require.NoError(t, err)
+	assert.NotEqual(t, fingerprint, otherFingerprint)
+
+	_, err = PublicKeyFingerprintSHA256("not a key")
+	assert.Error(t, err)
+}
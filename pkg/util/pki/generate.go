@@ -47,6 +47,53 @@ const (
 	ECCurve521 = 521
 )
 
+// ecdsaKeySize describes a supported ECDSA key size.
+type ecdsaKeySize struct {
+	size               int
+	curve              func() elliptic.Curve
+	signatureAlgorithm x509.SignatureAlgorithm
+}
+
+// ecdsaKeySizes are the supported ECDSA key sizes, in ascending order.
+// Key generation, CSR signing, private key matching and validation all use
+// this table, so that they agree on which sizes are supported.
+var ecdsaKeySizes = []ecdsaKeySize{
+	{size: ECCurve256, curve: elliptic.P256, signatureAlgorithm: x509.ECDSAWithSHA256},
+	{size: ECCurve384, curve: elliptic.P384, signatureAlgorithm: x509.ECDSAWithSHA384},
+	{size: ECCurve521, curve: elliptic.P521, signatureAlgorithm: x509.ECDSAWithSHA512},
+}
+
+// lookupECDSAKeySize returns the supported ECDSA key size with the given
+// size. A size of 0 selects the default, ECCurve256.
+func lookupECDSAKeySize(size int) (ecdsaKeySize, bool) {
+	if size == 0 {
+		size = ECCurve256
+	}
+	for _, k := range ecdsaKeySizes {
+		if k.size == size {
+			return k, true
+		}
+	}
+	return ecdsaKeySize{}, false
+}
+
+// SupportedECDSAKeySizes returns the supported ECDSA key sizes, in ascending
+// order.
+func SupportedECDSAKeySizes() []int {
+	sizes := make([]int, len(ecdsaKeySizes))
+	for i, k := range ecdsaKeySizes {
+		sizes[i] = k.size
+	}
+	return sizes
+}
+
+// IsSupportedECDSAKeySize returns true if the given ECDSA key size is
+// supported.
+func IsSupportedECDSAKeySize(size int) bool {
+	_, ok := lookupECDSAKeySize(size)
+	return ok && size != 0
+}
+
 // GeneratePrivateKeyForCertificate will generate a private key suitable for
 // the provided cert-manager Certificate resource, taking into account the
 // parameters on the provided resource.
@@ -98,20 +145,12 @@ func GenerateRSAPrivateKey(keySize int) (*rsa.PrivateKey, error) {
 // GenerateECPrivateKey will generate an ECDSA private key of the given size.
 // It can be used to generate 256, 384 and 521 sized keys.
 func GenerateECPrivateKey(keySize int) (*ecdsa.PrivateKey, error) {
-	var ecCurve elliptic.Curve
-
-	switch keySize {
-	case ECCurve256:
-		ecCurve = elliptic.P256()
-	case ECCurve384:
-		ecCurve = elliptic.P384()
-	case ECCurve521:
-		ecCurve = elliptic.P521()
-	default:
+	k, ok := lookupECDSAKeySize(keySize)
+	if !ok || keySize == 0 {
 		return nil, fmt.Errorf("unsupported ecdsa key size specified: %d", keySize)
 	}
 
-	return ecdsa.GenerateKey(ecCurve, rand.Reader)
+	return ecdsa.GenerateKey(k.curve(), rand.Reader)
 }
 
 // GenerateEd25519PrivateKey will generate an Ed25519 private key
@@ -581,3 +581,88 @@ func TestPublicKeysEqualRSA(t *testing.T) {
 		t.Errorf("got an incorrect match from different RSA keys:\npub1: %#v\npub2: %#v\n", pub1, pub2)
 	}
 }
+
+// TestECDSAKeySizes asserts that key generation, CSR signing and private key
+// matching agree on every supported ECDSA key size, both when first issuing
+// and when renewing a certificate.
+func TestECDSAKeySizes(t *testing.T) {
+	expSignatureAlgorithms := map[int]x509.SignatureAlgorithm{
+		ECCurve256: x509.ECDSAWithSHA256,
+		ECCurve384: x509.ECDSAWithSHA384,
+		ECCurve521: x509.ECDSAWithSHA512,
+	}
+
+	for _, size := range append([]int{0}, SupportedECDSAKeySizes()...) {
+		for _, encoding := range []v1.PrivateKeyEncoding{v1.PKCS1, v1.PKCS8} {
+			t.Run(fmt.Sprintf("%d/%s", size, encoding), func(t *testing.T) {
+				crt := buildCertificateWithKeyParams(v1.ECDSAKeyAlgorithm, size)
+				crt.Spec.PrivateKey.Encoding = encoding
+
+				expSize := size
+				if expSize == 0 {
+					expSize = ECCurve256
+				}
+
+				var previous crypto.Signer
+				for _, stage := range []string{"issuance", "renewal"} {
+					pk, err := GeneratePrivateKeyForCertificate(crt)
+					if err != nil {
+						t.Fatalf("%s: failed to generate private key: %v", stage, err)
+					}
+
+					// Round trip the key as it would be stored in the Secret.
+					pkBytes, err := EncodePrivateKey(pk, encoding)
+					if err != nil {
+						t.Fatalf("%s: failed to encode private key: %v", stage, err)
+					}
+					pk, err = DecodePrivateKeyBytes(pkBytes)
+					if err != nil {
+						t.Fatalf("%s: failed to decode private key: %v", stage, err)
+					}
+
+					if bitSize := pk.(*ecdsa.PrivateKey).Curve.Params().BitSize; bitSize != expSize {
+						t.Errorf("%s: expected a %d bit key, got %d", stage, expSize, bitSize)
+					}
+					if violations, err := PrivateKeyMatchesSpec(pk, crt.Spec); err != nil || len(violations) > 0 {
+						t.Errorf("%s: expected private key to match spec, got violations=%v err=%v", stage, violations, err)
+					}
+					for _, otherSize := range SupportedECDSAKeySizes() {
+						if otherSize == expSize {
+							continue
+						}
+						otherSpec := buildCertificateWithKeyParams(v1.ECDSAKeyAlgorithm, otherSize).Spec
+						if violations, _ := PrivateKeyMatchesSpec(pk, otherSpec); len(violations) == 0 {
+							t.Errorf("%s: expected %d bit key not to match a spec with size %d", stage, expSize, otherSize)
+						}
+					}
+
+					template, err := GenerateCSR(crt)
+					if err != nil {
+						t.Fatalf("%s: failed to generate CSR: %v", stage, err)
+					}
+					csrDER, err := EncodeCSR(template, pk)
+					if err != nil {
+						t.Fatalf("%s: failed to sign CSR: %v", stage, err)
+					}
+					csr, err := DecodeX509CertificateRequestBytes(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER}))
+					if err != nil {
+						t.Fatalf("%s: failed to decode CSR: %v", stage, err)
+					}
+					if err := csr.CheckSignature(); err != nil {
+						t.Errorf("%s: invalid CSR signature: %v", stage, err)
+					}
+					if csr.SignatureAlgorithm != expSignatureAlgorithms[expSize] {
+						t.Errorf("%s: expected signature algorithm %s, got %s", stage, expSignatureAlgorithms[expSize], csr.SignatureAlgorithm)
+					}
+
+					if previous != nil {
+						if equal, _ := PublicKeysEqual(previous.Public(), pk.Public()); equal {
+							t.Errorf("%s: expected a new private key to be generated", stage)
+						}
+					}
+					previous = pk
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/idna"
+)
+
+var (
+	// idnaProfile converts internationalized domain names to A-labels using
+	// IDNA2008 (non-transitional) processing, as CAs expect. ASCII characters
+	// which are not allowed in hostnames, such as '_', are still permitted as
+	// they are commonly used in DNS names.
+	idnaProfile = idna.New(idna.MapForLookup(), idna.BidiRule(), idna.Transitional(false), idna.StrictDomainName(false))
+
+	// idna2003Profile converts names using the transitional processing of
+	// IDNA2003, and is only used to detect ambiguous names.
+	idna2003Profile = idna.New(idna.MapForLookup(), idna.BidiRule(), idna.Transitional(true), idna.StrictDomainName(false))
+)
+
+// DNSNameToASCII returns the form of a DNS name which is encoded in CSRs and
+// certificates. Names containing non-ASCII characters (U-labels) are converted
+// to A-labels (punycode), whereas ASCII names are returned unchanged. A
+// leading wildcard label is preserved.
+// An error is returned if the name is not a valid internationalized domain
+// name, or if it is ambiguous because IDNA2003 would convert it to a
+// different name, such as names containing 'ß'.
+func DNSNameToASCII(name string) (string, error) {
+	if isASCII(name) {
+		return name, nil
+	}
+
+	prefix := ""
+	if strings.HasPrefix(name, "*.") {
+		prefix, name = "*.", name[2:]
+	}
+	ascii, err := idnaProfile.ToASCII(name)
+	if err != nil {
+		return "", err
+	}
+	if idna2003, err := idna2003Profile.ToASCII(name); err == nil && idna2003 != ascii {
+		return "", fmt.Errorf("%q is ambiguous as it is converted to %q by IDNA2008 but to %q by IDNA2003", prefix+name, prefix+ascii, prefix+idna2003)
+	}
+	return prefix + ascii, nil
+}
+
+// DNSNamesToASCII calls DNSNameToASCII for each of the given names.
+func DNSNamesToASCII(names []string) ([]string, error) {
+	if names == nil {
+		return nil, nil
+	}
+	out := make([]string, len(names))
+	for i, name := range names {
+		ascii, err := DNSNameToASCII(name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid DNS name %q: %w", name, err)
+		}
+		out[i] = ascii
+	}
+	return out, nil
+}
+
+// NormalizeDNSName returns the form of a DNS name used to compare names: its
+// A-labels in lower case, without a trailing dot. This means that U-label and
+// A-label forms of the same name, and names differing only in case, are equal.
+// Names which cannot be converted to A-labels are only lower cased.
+func NormalizeDNSName(name string) string {
+	if ascii, err := DNSNameToASCII(name); err == nil {
+		name = ascii
+	}
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
+
+// NormalizeDNSNames calls NormalizeDNSName for each of the given names.
+func NormalizeDNSNames(names []string) []string {
+	if names == nil {
+		return nil
+	}
+	out := make([]string, len(names))
+	for i, name := range names {
+		out[i] = NormalizeDNSName(name)
+	}
+	return out
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"testing"
+)
+
+func TestDNSNameToASCII(t *testing.T) {
+	tests := map[string]struct {
+		name    string
+		exp     string
+		wantErr bool
+	}{
+		"ASCII names are unchanged": {
+			name: "example.com",
+			exp:  "example.com",
+		},
+		"ASCII names are not lower cased": {
+			name: "EXAMPLE.com",
+			exp:  "EXAMPLE.com",
+		},
+		"ASCII names with underscores are unchanged": {
+			name: "_acme-challenge.example.com",
+			exp:  "_acme-challenge.example.com",
+		},
+		"ASCII names with a trailing dot are unchanged": {
+			name: "example.com.",
+			exp:  "example.com.",
+		},
+		"U-labels are converted to A-labels": {
+			name: "münchen.example.com",
+			exp:  "xn--mnchen-3ya.example.com",
+		},
+		"upper case U-labels are converted to lower case A-labels": {
+			name: "MÜNCHEN.example.com",
+			exp:  "xn--mnchen-3ya.example.com",
+		},
+		"wildcards are preserved": {
+			name: "*.bücher.example.com",
+			exp:  "*.xn--bcher-kva.example.com",
+		},
+		"mixed Latin and Cyrillic labels are converted": {
+			name: "pаypal.com",
+			exp:  "xn--pypal-4ve.com",
+		},
+		"mixed Latin and Hebrew labels are rejected": {
+			name:    "exampleשלום.com",
+			wantErr: true,
+		},
+		"names which are converted differently by IDNA2003 are rejected": {
+			name:    "faß.de",
+			wantErr: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := DNSNameToASCII(test.name)
+			if test.wantErr != (err != nil) {
+				t.Fatalf("unexpected error, exp=%t, got=%v", test.wantErr, err)
+			}
+			if got != test.exp {
+				t.Errorf("unexpected name, exp=%q, got=%q", test.exp, got)
+			}
+		})
+	}
+}
+
+func TestNormalizeDNSName(t *testing.T) {
+	tests := map[string]struct {
+		a, b string
+	}{
+		"U-label and A-label": {
+			a: "münchen.example.com",
+			b: "xn--mnchen-3ya.example.com",
+		},
+		"upper case A-label": {
+			a: "münchen.example.com",
+			b: "XN--MNCHEN-3YA.EXAMPLE.COM",
+		},
+		"upper case U-label": {
+			a: "MÜNCHEN.example.com",
+			b: "xn--mnchen-3ya.example.com",
+		},
+		"trailing dot": {
+			a: "bücher.example.com.",
+			b: "xn--bcher-kva.example.com",
+		},
+		"wildcard": {
+			a: "*.Bücher.example.com",
+			b: "*.xn--bcher-kva.example.com",
+		},
+		"ASCII names": {
+			a: "Example.COM.",
+			b: "example.com",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if a, b := NormalizeDNSName(test.a), NormalizeDNSName(test.b); a != b {
+				t.Errorf("expected normalized names to be equal, got %q and %q", a, b)
+			}
+		})
+	}
+}
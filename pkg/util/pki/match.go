@@ -22,15 +22,17 @@ import (
 	"crypto/ecdsa"
 	"crypto/ed25519"
 	"crypto/rsa"
+	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/asn1"
 	"fmt"
 	"net"
-	"reflect"
+	"slices"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/cert-manager/cert-manager/pkg/util"
 )
@@ -38,25 +40,31 @@ import (
 // PrivateKeyMatchesSpec returns an error if the private key bit size
 // doesn't match the provided spec. RSA, Ed25519 and ECDSA are supported.
 // If any error is returned, a list of violations will also be returned.
+// Only the public half of the key is inspected, so the private key may be a
+// crypto.Signer whose key material is held elsewhere, such as in a KMS.
 func PrivateKeyMatchesSpec(pk crypto.PrivateKey, spec cmapi.CertificateSpec) ([]string, error) {
 	spec = *spec.DeepCopy()
 	if spec.PrivateKey == nil {
 		spec.PrivateKey = &cmapi.CertificatePrivateKey{}
 	}
+	var publicKey crypto.PublicKey
+	if signer, ok := pk.(crypto.Signer); ok {
+		publicKey = signer.Public()
+	}
 	switch spec.PrivateKey.Algorithm {
 	case "", cmapi.RSAKeyAlgorithm:
-		return rsaPrivateKeyMatchesSpec(pk, spec)
+		return rsaPublicKeyMatchesSpec(publicKey, spec)
 	case cmapi.Ed25519KeyAlgorithm:
-		return ed25519PrivateKeyMatchesSpec(pk)
+		return ed25519PublicKeyMatchesSpec(publicKey)
 	case cmapi.ECDSAKeyAlgorithm:
-		return ecdsaPrivateKeyMatchesSpec(pk, spec)
+		return ecdsaPublicKeyMatchesSpec(publicKey, spec)
 	default:
 		return nil, fmt.Errorf("unrecognised key algorithm type %q", spec.PrivateKey.Algorithm)
 	}
 }
 
-func rsaPrivateKeyMatchesSpec(pk crypto.PrivateKey, spec cmapi.CertificateSpec) ([]string, error) {
-	rsaPk, ok := pk.(*rsa.PrivateKey)
+func rsaPublicKeyMatchesSpec(publicKey crypto.PublicKey, spec cmapi.CertificateSpec) ([]string, error) {
+	rsaPub, ok := publicKey.(*rsa.PublicKey)
 	if !ok {
 		return []string{"spec.privateKey.algorithm"}, nil
 	}
@@ -70,14 +78,14 @@ func rsaPrivateKeyMatchesSpec(pk crypto.PrivateKey, spec cmapi.CertificateSpec)
 	if spec.PrivateKey.Size > 0 {
 		keySize = spec.PrivateKey.Size
 	}
-	if rsaPk.N.BitLen() != keySize {
+	if rsaPub.N.BitLen() != keySize {
 		violations = append(violations, "spec.privateKey.size")
 	}
 	return violations, nil
 }
 
-func ecdsaPrivateKeyMatchesSpec(pk crypto.PrivateKey, spec cmapi.CertificateSpec) ([]string, error) {
-	ecdsaPk, ok := pk.(*ecdsa.PrivateKey)
+func ecdsaPublicKeyMatchesSpec(publicKey crypto.PublicKey, spec cmapi.CertificateSpec) ([]string, error) {
+	ecdsaPub, ok := publicKey.(*ecdsa.PublicKey)
 	if !ok {
 		return []string{"spec.privateKey.algorithm"}, nil
 	}
@@ -87,18 +95,15 @@ func ecdsaPrivateKeyMatchesSpec(pk crypto.PrivateKey, spec cmapi.CertificateSpec
 	//  This requires careful handling in order to not interrupt users upgrading
 	//  from older versions.
 	// The default EC curve type is EC256
-	expectedKeySize := ECCurve256
-	if spec.PrivateKey.Size > 0 {
-		expectedKeySize = spec.PrivateKey.Size
-	}
-	if expectedKeySize != ecdsaPk.Curve.Params().BitSize {
+	k, ok := lookupECDSAKeySize(spec.PrivateKey.Size)
+	if !ok || k.curve().Params().Name != ecdsaPub.Curve.Params().Name {
 		violations = append(violations, "spec.privateKey.size")
 	}
 	return violations, nil
 }
 
-func ed25519PrivateKeyMatchesSpec(pk crypto.PrivateKey) ([]string, error) {
-	_, ok := pk.(ed25519.PrivateKey)
+func ed25519PublicKeyMatchesSpec(publicKey crypto.PublicKey) ([]string, error) {
+	_, ok := publicKey.(ed25519.PublicKey)
 	if !ok {
 		return []string{"spec.privateKey.algorithm"}, nil
 	}
@@ -126,6 +131,12 @@ func RequestMatchesSpec(req *cmapi.CertificateRequest, spec cmapi.CertificateSpe
 		return nil, err
 	}
 
+	return X509RequestMatchesSpec(req, x509req, spec)
+}
+
+// X509RequestMatchesSpec is RequestMatchesSpec for a CertificateRequest
+// whose x509 certificate request has already been decoded.
+func X509RequestMatchesSpec(req *cmapi.CertificateRequest, x509req *x509.CertificateRequest, spec cmapi.CertificateSpec) ([]string, error) {
 	// It is safe to mutate top-level fields in `spec` as it is not a pointer
 	// meaning changes will not effect the caller.
 	if spec.Subject == nil {
@@ -146,7 +157,9 @@ func RequestMatchesSpec(req *cmapi.CertificateRequest, spec cmapi.CertificateSpe
 		violations = append(violations, "spec.emailAddresses")
 	}
 
-	if !util.EqualUnsorted(x509req.DNSNames, spec.DNSNames) {
+	// DNS names are compared after normalization, as the CSR contains the
+	// A-label form of internationalized names, and each name only once.
+	if !sets.New(NormalizeDNSNames(x509req.DNSNames)...).Equal(sets.New(NormalizeDNSNames(spec.DNSNames)...)) {
 		violations = append(violations, "spec.dnsNames")
 	}
 
@@ -160,9 +173,17 @@ func RequestMatchesSpec(req *cmapi.CertificateRequest, spec cmapi.CertificateSpe
 		}
 	}
 
+	requestPolicyOIDs, err := policyOIDsFromExtensions(x509req.Extensions)
+	if err != nil {
+		return nil, err
+	}
+	if !util.EqualUnsorted(requestPolicyOIDs, spec.PolicyOIDs) {
+		violations = append(violations, "spec.policyOIDs")
+	}
+
 	if spec.LiteralSubject == "" {
 		// Comparing Subject fields
-		if x509req.Subject.CommonName != spec.CommonName {
+		if !commonNameMatches(x509req.Subject.CommonName, spec) {
 			violations = append(violations, "spec.commonName")
 		}
 		if x509req.Subject.SerialNumber != spec.Subject.SerialNumber {
@@ -181,10 +202,10 @@ func RequestMatchesSpec(req *cmapi.CertificateRequest, spec cmapi.CertificateSpe
 			violations = append(violations, "spec.subject.organizationalUnits")
 		}
 		if !util.EqualUnsorted(x509req.Subject.PostalCode, spec.Subject.PostalCodes) {
-			violations = append(violations, "spec.subject.postCodes")
+			violations = append(violations, "spec.subject.postalCodes")
 		}
 		if !util.EqualUnsorted(x509req.Subject.Province, spec.Subject.Provinces) {
-			violations = append(violations, "spec.subject.postCodes")
+			violations = append(violations, "spec.subject.provinces")
 		}
 		if !util.EqualUnsorted(x509req.Subject.StreetAddress, spec.Subject.StreetAddresses) {
 			violations = append(violations, "spec.subject.streetAddresses")
@@ -219,7 +240,7 @@ func RequestMatchesSpec(req *cmapi.CertificateRequest, spec cmapi.CertificateSpe
 		req.Spec.Duration.Duration != spec.Duration.Duration {
 		violations = append(violations, "spec.duration")
 	}
-	if !reflect.DeepEqual(req.Spec.IssuerRef, spec.IssuerRef) {
+	if !apiutil.IssuerRefsEqual(req.Spec.IssuerRef, spec.IssuerRef) {
 		violations = append(violations, "spec.issuerRef")
 	}
 
@@ -273,6 +294,47 @@ func matchOtherNames(extension []pkix.Extension, specOtherNames []cmapi.OtherNam
 	return true, nil
 }
 
+// policyOIDsFromExtensions returns the certificate policies encoded in the
+// extensions of a CSR in dotted string form. The policies in the issued
+// certificate are not compared, as the issuer may not include every requested
+// policy.
+func policyOIDsFromExtensions(extensions []pkix.Extension) ([]string, error) {
+	var policyOIDs []string
+	for _, extension := range extensions {
+		if !extension.Id.Equal(OIDExtensionCertificatePolicies) {
+			continue
+		}
+
+		policies, err := UnmarshalCertificatePolicies(extension.Value)
+		if err != nil {
+			return nil, err
+		}
+		for _, policy := range policies {
+			policyOIDs = append(policyOIDs, policy.String())
+		}
+	}
+	return policyOIDs, nil
+}
+
+// commonNameMatches returns true if the common name of a CSR matches the
+// Certificate spec. A common name which is also one of the DNS names may be
+// encoded in the A-label form of that DNS name, as done by GenerateCSR, so
+// such common names are compared once normalized. The common name defaulted
+// from the first DNS name is taken into account, see EffectiveCommonName.
+func commonNameMatches(commonName string, spec cmapi.CertificateSpec) bool {
+	specCommonName := EffectiveCommonName(spec)
+	if commonName == specCommonName {
+		return true
+	}
+	normalized := NormalizeDNSName(specCommonName)
+	if specCommonName == "" || !slices.ContainsFunc(spec.DNSNames, func(name string) bool {
+		return NormalizeDNSName(name) == normalized
+	}) {
+		return false
+	}
+	return NormalizeDNSName(commonName) == normalized
+}
+
 // SecretDataAltNamesMatchSpec will compare a Secret resource containing certificate
 // data to a CertificateSpec and return a list of 'violations' for any fields that
 // do not match their counterparts.
@@ -284,6 +346,12 @@ func SecretDataAltNamesMatchSpec(secret *corev1.Secret, spec cmapi.CertificateSp
 		return nil, err
 	}
 
+	return CertificateAltNamesMatchSpec(x509cert, spec), nil
+}
+
+// CertificateAltNamesMatchSpec is SecretDataAltNamesMatchSpec for a
+// certificate which has already been decoded.
+func CertificateAltNamesMatchSpec(x509cert *x509.Certificate, spec cmapi.CertificateSpec) []string {
 	var violations []string
 
 	// Perform a 'loose' check on the x509 certificate to determine if the
@@ -291,21 +359,29 @@ func SecretDataAltNamesMatchSpec(secret *corev1.Secret, spec cmapi.CertificateSp
 	// This check allows names to move between the DNSNames and CommonName
 	// field freely in order to account for CAs behaviour of promoting DNSNames
 	// to be CommonNames or vice-versa.
-	expectedDNSNames := sets.New[string](spec.DNSNames...)
-	if spec.CommonName != "" {
-		expectedDNSNames.Insert(spec.CommonName)
-	}
-	allDNSNames := sets.New[string](x509cert.DNSNames...)
-	if x509cert.Subject.CommonName != "" {
-		allDNSNames.Insert(x509cert.Subject.CommonName)
+	// Names are compared after normalization, so that U-label and A-label
+	// forms of internationalized names, and differences in case, do not
+	// cause a mismatch.
+	specDNSNames := NormalizeDNSNames(spec.DNSNames)
+	specCommonName := NormalizeDNSName(EffectiveCommonName(spec))
+	certDNSNames := NormalizeDNSNames(x509cert.DNSNames)
+	certCommonName := NormalizeDNSName(x509cert.Subject.CommonName)
+
+	expectedDNSNames := sets.New[string](specDNSNames...)
+	if specCommonName != "" {
+		expectedDNSNames.Insert(specCommonName)
+	}
+	allDNSNames := sets.New[string](certDNSNames...)
+	if certCommonName != "" {
+		allDNSNames.Insert(certCommonName)
 	}
 	if !allDNSNames.Equal(expectedDNSNames) {
 		// We know a mismatch occurred, so now determine which fields mismatched.
-		if (spec.CommonName != "" && !allDNSNames.Has(spec.CommonName)) || (x509cert.Subject.CommonName != "" && !expectedDNSNames.Has(x509cert.Subject.CommonName)) {
+		if (specCommonName != "" && !allDNSNames.Has(specCommonName)) || (certCommonName != "" && !expectedDNSNames.Has(certCommonName)) {
 			violations = append(violations, "spec.commonName")
 		}
 
-		if !allDNSNames.HasAll(spec.DNSNames...) || !expectedDNSNames.HasAll(x509cert.DNSNames...) {
+		if !allDNSNames.HasAll(specDNSNames...) || !expectedDNSNames.HasAll(certDNSNames...) {
 			violations = append(violations, "spec.dnsNames")
 		}
 	}
@@ -322,7 +398,7 @@ func SecretDataAltNamesMatchSpec(secret *corev1.Secret, spec cmapi.CertificateSp
 		violations = append(violations, "spec.emailAddresses")
 	}
 
-	return violations, nil
+	return violations
 }
 
 func extractSANExtension(extensions []pkix.Extension) (pkix.Extension, error) {
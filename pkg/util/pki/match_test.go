@@ -29,6 +29,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 )
 
 func mustGenerateRSA(t *testing.T, keySize int) crypto.PrivateKey {
@@ -277,6 +278,31 @@ func TestRequestMatchesSpecSubject(t *testing.T) {
 			literalSubject: "ST=example,C=US,O=#04024869",
 			x509CSR:        createCSRBlob("ST=example,C=US,O=#04024869"),
 		},
+		{
+			name: "Matching serial number, street addresses and postal codes",
+			subject: &cmapi.X509Subject{
+				SerialNumber:    "CSM01-4242",
+				StreetAddresses: []string{"Friedrichstrasse 123"},
+				PostalCodes:     []string{"10117"},
+			},
+			x509CSR: createCSRBlob("SERIALNUMBER=CSM01-4242,POSTALCODE=10117,STREET=Friedrichstrasse 123"),
+		},
+		{
+			name: "Mismatched serial number, street addresses and postal codes",
+			subject: &cmapi.X509Subject{
+				SerialNumber:    "CSM01-4243",
+				StreetAddresses: []string{"Unter den Linden 1"},
+				PostalCodes:     []string{"10117", "10119"},
+			},
+			x509CSR:    createCSRBlob("SERIALNUMBER=CSM01-4242,POSTALCODE=10117,STREET=Friedrichstrasse 123"),
+			violations: []string{"spec.subject.serialNumber", "spec.subject.postalCodes", "spec.subject.streetAddresses"},
+		},
+		{
+			name:       "Mismatched provinces",
+			subject:    &cmapi.X509Subject{Provinces: []string{"Berlin"}},
+			x509CSR:    createCSRBlob("ST=Brandenburg"),
+			violations: []string{"spec.subject.provinces"},
+		},
 	}
 
 	for _, test := range tests {
@@ -308,6 +334,224 @@ func TestRequestMatchesSpecSubject(t *testing.T) {
 	}
 }
 
+func TestRequestMatchesSpecDNSNames(t *testing.T) {
+	tests := map[string]struct {
+		crSpec     *cmapi.CertificateRequest
+		certSpec   cmapi.CertificateSpec
+		violations []string
+	}{
+		"should not report any violation if internationalized dnsNames were requested as A-labels": {
+			crSpec: MustBuildCertificateRequest(&cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				CommonName: "münchen.example.com",
+				DNSNames:   []string{"münchen.example.com", "bücher.example.com"},
+			}}, t),
+			certSpec: cmapi.CertificateSpec{
+				CommonName: "münchen.example.com",
+				DNSNames:   []string{"münchen.example.com", "bücher.example.com"},
+			},
+		},
+		"should not report any violation if the spec uses A-labels, upper case and trailing dots": {
+			crSpec: MustBuildCertificateRequest(&cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				DNSNames: []string{"münchen.example.com", "example.com"},
+			}}, t),
+			certSpec: cmapi.CertificateSpec{
+				DNSNames: []string{"XN--MNCHEN-3YA.example.com", "example.com."},
+			},
+		},
+		"should report violation if internationalized dnsNames differ": {
+			crSpec: MustBuildCertificateRequest(&cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				DNSNames: []string{"münchen.example.com"},
+			}}, t),
+			certSpec: cmapi.CertificateSpec{
+				DNSNames: []string{"bücher.example.com"},
+			},
+			violations: []string{"spec.dnsNames"},
+		},
+		"should not report any violation if the common name was defaulted from the first dnsName": {
+			crSpec: MustBuildCertificateRequest(&cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				CommonName: "example.com",
+				DNSNames:   []string{"example.com", "www.example.com"},
+			}}, t),
+			certSpec: cmapi.CertificateSpec{
+				CommonNameFromFirstDNSName: true,
+				DNSNames:                   []string{"example.com", "www.example.com"},
+			},
+		},
+		"should not report any violation if the request was generated with the common name defaulted from the first dnsName": {
+			crSpec: MustBuildCertificateRequest(&cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				CommonNameFromFirstDNSName: true,
+				DNSNames:                   []string{"*.example.com", "example.com"},
+			}}, t),
+			certSpec: cmapi.CertificateSpec{
+				CommonName: "*.example.com",
+				DNSNames:   []string{"*.example.com", "example.com"},
+			},
+		},
+		"should report violation if the common name was not defaulted from the first dnsName": {
+			crSpec: MustBuildCertificateRequest(&cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				DNSNames: []string{"example.com", "www.example.com"},
+			}}, t),
+			certSpec: cmapi.CertificateSpec{
+				CommonNameFromFirstDNSName: true,
+				DNSNames:                   []string{"example.com", "www.example.com"},
+			},
+			violations: []string{"spec.commonName"},
+		},
+		"should not report any violation if the spec contains dnsNames differing only in case": {
+			crSpec: MustBuildCertificateRequest(&cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				DNSNames: []string{"Example.com", "EXAMPLE.com.", "www.example.com"},
+			}}, t),
+			certSpec: cmapi.CertificateSpec{
+				DNSNames: []string{"Example.com", "EXAMPLE.com.", "www.example.com"},
+			},
+		},
+		"should not report any violation if the common name differs from a dnsName only in case": {
+			crSpec: MustBuildCertificateRequest(&cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				CommonName: "WWW.Example.com",
+				DNSNames:   []string{"www.example.com"},
+			}}, t),
+			certSpec: cmapi.CertificateSpec{
+				CommonName: "WWW.Example.com",
+				DNSNames:   []string{"www.example.com"},
+			},
+		},
+		"should not report any violation if a dnsName is covered by a wildcard in the spec": {
+			crSpec: MustBuildCertificateRequest(&cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				DNSNames: []string{"*.example.com", "www.example.com"},
+			}}, t),
+			certSpec: cmapi.CertificateSpec{
+				DNSNames: []string{"*.example.com", "www.example.com"},
+			},
+		},
+		"should report violation if a dnsName covered by a wildcard was removed": {
+			crSpec: MustBuildCertificateRequest(&cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				DNSNames: []string{"*.example.com", "www.example.com"},
+			}}, t),
+			certSpec: cmapi.CertificateSpec{
+				DNSNames: []string{"*.example.com"},
+			},
+			violations: []string{"spec.dnsNames"},
+		},
+		"should report violation if the common name differs from a dnsName other than in case": {
+			crSpec: MustBuildCertificateRequest(&cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				CommonName: "api.example.com",
+				DNSNames:   []string{"www.example.com"},
+			}}, t),
+			certSpec: cmapi.CertificateSpec{
+				CommonName: "WWW.example.com",
+				DNSNames:   []string{"www.example.com"},
+			},
+			violations: []string{"spec.commonName"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			violations, err := RequestMatchesSpec(test.crSpec, test.certSpec)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(violations, test.violations) {
+				t.Errorf("violations did not match, got=%s, exp=%s", violations, test.violations)
+			}
+		})
+	}
+}
+
+func TestRequestMatchesSpecPolicyOIDs(t *testing.T) {
+	tests := map[string]struct {
+		crSpec     *cmapi.CertificateRequest
+		certSpec   cmapi.CertificateSpec
+		violations []string
+	}{
+		"should not report any violation if the requested policies match in a different order": {
+			crSpec: MustBuildCertificateRequest(&cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				CommonName: "example.com",
+				PolicyOIDs: []string{"2.23.140.1.2.1", "1.3.6.1.4.1.44947.1.1.1"},
+			}}, t),
+			certSpec: cmapi.CertificateSpec{
+				CommonName: "example.com",
+				PolicyOIDs: []string{"1.3.6.1.4.1.44947.1.1.1", "2.23.140.1.2.1"},
+			},
+		},
+		"should report violation if a policy was added to the spec": {
+			crSpec: MustBuildCertificateRequest(&cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				CommonName: "example.com",
+			}}, t),
+			certSpec: cmapi.CertificateSpec{
+				CommonName: "example.com",
+				PolicyOIDs: []string{"2.23.140.1.2.1"},
+			},
+			violations: []string{"spec.policyOIDs"},
+		},
+		"should report violation if the requested policies differ": {
+			crSpec: MustBuildCertificateRequest(&cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				CommonName: "example.com",
+				PolicyOIDs: []string{"2.23.140.1.2.1"},
+			}}, t),
+			certSpec: cmapi.CertificateSpec{
+				CommonName: "example.com",
+				PolicyOIDs: []string{"2.23.140.1.2.2"},
+			},
+			violations: []string{"spec.policyOIDs"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			violations, err := RequestMatchesSpec(test.crSpec, test.certSpec)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(violations, test.violations) {
+				t.Errorf("violations did not match, got=%s, exp=%s", violations, test.violations)
+			}
+		})
+	}
+}
+
+func TestRequestMatchesSpecIssuerRef(t *testing.T) {
+	tests := map[string]struct {
+		crIssuerRef   cmmeta.ObjectReference
+		certIssuerRef cmmeta.ObjectReference
+		violations    []string
+	}{
+		"should not report any violation if the issuerRef is identical": {
+			crIssuerRef:   cmmeta.ObjectReference{Name: "ca", Kind: "Issuer", Group: "cert-manager.io"},
+			certIssuerRef: cmmeta.ObjectReference{Name: "ca", Kind: "Issuer", Group: "cert-manager.io"},
+		},
+		"should not report any violation if the kind and group were defaulted": {
+			crIssuerRef:   cmmeta.ObjectReference{Name: "ca"},
+			certIssuerRef: cmmeta.ObjectReference{Name: "ca", Kind: "Issuer", Group: "cert-manager.io"},
+		},
+		"should not report any violation if the kind is qualified with its group": {
+			crIssuerRef:   cmmeta.ObjectReference{Name: "ca", Kind: "ClusterIssuer.cert-manager.io"},
+			certIssuerRef: cmmeta.ObjectReference{Name: "ca", Kind: "ClusterIssuer"},
+		},
+		"should report violation if the group differs": {
+			crIssuerRef:   cmmeta.ObjectReference{Name: "ca", Kind: "Issuer"},
+			certIssuerRef: cmmeta.ObjectReference{Name: "ca", Kind: "Issuer", Group: "example.com"},
+			violations:    []string{"spec.issuerRef"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cr := MustBuildCertificateRequest(&cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				CommonName: "example.com",
+				IssuerRef:  test.crIssuerRef,
+			}}, t)
+			violations, err := RequestMatchesSpec(cr, cmapi.CertificateSpec{
+				CommonName: "example.com",
+				IssuerRef:  test.certIssuerRef,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(violations, test.violations) {
+				t.Errorf("violations did not match, got=%s, exp=%s", violations, test.violations)
+			}
+		})
+	}
+}
+
 func TestSecretDataAltNamesMatchSpec(t *testing.T) {
 	tests := map[string]struct {
 		data       []byte
@@ -412,6 +656,33 @@ func TestSecretDataAltNamesMatchSpec(t *testing.T) {
 				DNSNames:   []string{"at", "least", "one", "cn"},
 			}),
 		},
+		"should match if internationalized dnsNames differ only in their U-label and A-label form": {
+			spec: cmapi.CertificateSpec{
+				CommonName: "münchen.example.com",
+				DNSNames:   []string{"münchen.example.com", "xn--bcher-kva.example.com"},
+			},
+			data: selfSignCertificate(t, cmapi.CertificateSpec{
+				CommonName: "xn--mnchen-3ya.example.com",
+				DNSNames:   []string{"xn--mnchen-3ya.example.com", "bücher.example.com"},
+			}),
+		},
+		"should match if dnsNames differ only in case and trailing dots": {
+			spec: cmapi.CertificateSpec{
+				DNSNames: []string{"EXAMPLE.com.", "MÜNCHEN.example.com"},
+			},
+			data: selfSignCertificate(t, cmapi.CertificateSpec{
+				DNSNames: []string{"example.com", "xn--mnchen-3ya.example.com"},
+			}),
+		},
+		"should not match if internationalized dnsNames are different": {
+			spec: cmapi.CertificateSpec{
+				DNSNames: []string{"münchen.example.com"},
+			},
+			data: selfSignCertificate(t, cmapi.CertificateSpec{
+				DNSNames: []string{"bücher.example.com"},
+			}),
+			violations: []string{"spec.dnsNames"},
+		},
 		"should match if ipAddresses are equal": {
 			spec: cmapi.CertificateSpec{
 				IPAddresses: []string{"127.0.0.1"},
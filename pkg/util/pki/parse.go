@@ -112,9 +112,14 @@ func DecodeX509CertificateBytes(certBytes []byte) (*x509.Certificate, error) {
 }
 
 // DecodeX509CertificateRequestBytes will decode a PEM encoded x509 Certificate Request.
+// Certificate Requests which are DER encoded without any PEM armor, as
+// written with CertificateRequestEncodingDER, are also accepted.
 func DecodeX509CertificateRequestBytes(csrBytes []byte) (*x509.CertificateRequest, error) {
 	block, _ := pem.Decode(csrBytes)
 	if block == nil {
+		if csr, err := x509.ParseCertificateRequest(csrBytes); err == nil {
+			return csr, nil
+		}
 		return nil, errors.NewInvalidData("error decoding certificate request PEM block")
 	}
 
@@ -0,0 +1,157 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Copied from x509.go
+var (
+	OIDExtensionCertificatePolicies = []int{2, 5, 29, 32}
+)
+
+// Adapted from x509.go, policy qualifiers are not supported.
+type policyInformation struct {
+	Policy asn1.ObjectIdentifier
+	// policyQualifiers omitted
+}
+
+// ParsePolicyOID parses a certificate policy OID from its dotted string
+// representation. The OID must be in canonical form and must be encodable in
+// a certificate, i.e. "1.02" and "3.1" are rejected.
+func ParsePolicyOID(oidString string) (asn1.ObjectIdentifier, error) {
+	oid, err := ParseObjectIdentifier(oidString)
+	if err != nil {
+		return nil, err
+	}
+
+	if oid.String() != oidString {
+		return nil, fmt.Errorf("%q is not in canonical dotted form", oidString)
+	}
+
+	for _, arc := range oid {
+		if arc < 0 {
+			return nil, fmt.Errorf("%q contains a negative arc", oidString)
+		}
+	}
+
+	// asn1.Marshal checks that the first two arcs of the OID are valid.
+	if _, err := asn1.Marshal(oid); err != nil {
+		return nil, fmt.Errorf("%q is not a valid object identifier", oidString)
+	}
+
+	return oid, nil
+}
+
+// Adapted from x509.go
+func MarshalCertificatePolicies(policies []asn1.ObjectIdentifier) (pkix.Extension, error) {
+	ext := pkix.Extension{Id: OIDExtensionCertificatePolicies}
+
+	policyInfos := make([]policyInformation, 0, len(policies))
+	for _, policy := range policies {
+		policyInfos = append(policyInfos, policyInformation{Policy: policy})
+	}
+
+	var err error
+	ext.Value, err = asn1.Marshal(policyInfos)
+	return ext, err
+}
+
+// Adapted from x509.go
+func UnmarshalCertificatePolicies(value []byte) ([]asn1.ObjectIdentifier, error) {
+	var policyInfos []policyInformation
+
+	if rest, err := asn1.Unmarshal(value, &policyInfos); err != nil {
+		return nil, err
+	} else if len(rest) != 0 {
+		return nil, errors.New("x509: trailing data after X.509 certificate policies")
+	}
+
+	policies := make([]asn1.ObjectIdentifier, 0, len(policyInfos))
+	for _, policyInfo := range policyInfos {
+		policies = append(policies, policyInfo.Policy)
+	}
+	return policies, nil
+}
+
+// setCertificatePolicies sets the certificate policies of the template. Both
+// PolicyIdentifiers and Policies are set, as which one is used to encode the
+// certificatePolicies extension depends on the x509usepolicies GODEBUG
+// setting.
+func setCertificatePolicies(cert *x509.Certificate, policies []asn1.ObjectIdentifier) error {
+	cert.PolicyIdentifiers = nil
+	cert.Policies = nil
+	for _, policy := range policies {
+		arcs := make([]uint64, 0, len(policy))
+		for _, arc := range policy {
+			if arc < 0 {
+				return fmt.Errorf("invalid certificate policy %s", policy)
+			}
+			arcs = append(arcs, uint64(arc))
+		}
+
+		oid, err := x509.OIDFromInts(arcs)
+		if err != nil {
+			return fmt.Errorf("invalid certificate policy %s: %w", policy, err)
+		}
+
+		cert.PolicyIdentifiers = append(cert.PolicyIdentifiers, policy)
+		cert.Policies = append(cert.Policies, oid)
+	}
+	return nil
+}
+
+// FilterCertificatePolicies removes the certificate policies which are not in
+// allowed from a certificate template created by CertificateTemplateFromCSR,
+// and returns the removed policies. Policies in allowed which cannot be parsed
+// are ignored.
+func FilterCertificatePolicies(cert *x509.Certificate, allowed []string) []asn1.ObjectIdentifier {
+	var allowedPolicies []asn1.ObjectIdentifier
+	for _, oidString := range allowed {
+		oid, err := ParsePolicyOID(oidString)
+		if err != nil {
+			continue
+		}
+		allowedPolicies = append(allowedPolicies, oid)
+	}
+
+	var removed []asn1.ObjectIdentifier
+	var policyIdentifiers []asn1.ObjectIdentifier
+	var policies []x509.OID
+	for i, policy := range cert.PolicyIdentifiers {
+		if !slices.ContainsFunc(allowedPolicies, policy.Equal) {
+			removed = append(removed, policy)
+			continue
+		}
+
+		policyIdentifiers = append(policyIdentifiers, policy)
+		// Policies is set alongside PolicyIdentifiers by setCertificatePolicies.
+		if i < len(cert.Policies) {
+			policies = append(policies, cert.Policies[i])
+		}
+	}
+
+	cert.PolicyIdentifiers = policyIdentifiers
+	cert.Policies = policies
+	return removed
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto/x509"
+	"encoding/asn1"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+func TestParsePolicyOID(t *testing.T) {
+	tests := map[string]struct {
+		oid     string
+		want    asn1.ObjectIdentifier
+		wantErr bool
+	}{
+		"a valid OID is parsed":             {oid: "2.23.140.1.2.1", want: asn1.ObjectIdentifier{2, 23, 140, 1, 2, 1}},
+		"an empty OID is rejected":          {oid: "", wantErr: true},
+		"a non numeric arc is rejected":     {oid: "2.23.x", wantErr: true},
+		"an empty arc is rejected":          {oid: "2..1", wantErr: true},
+		"a negative arc is rejected":        {oid: "2.-23.1", wantErr: true},
+		"a non canonical arc is rejected":   {oid: "2.023.1", wantErr: true},
+		"a single arc is rejected":          {oid: "2", wantErr: true},
+		"an invalid first arc is rejected":  {oid: "3.1.2", wantErr: true},
+		"an invalid second arc is rejected": {oid: "1.40.2", wantErr: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParsePolicyOID(test.oid)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestMarshalUnmarshalCertificatePolicies(t *testing.T) {
+	policies := []asn1.ObjectIdentifier{{2, 23, 140, 1, 2, 1}, {1, 3, 6, 1, 4, 1, 44947, 1, 1, 1}}
+
+	ext, err := MarshalCertificatePolicies(policies)
+	require.NoError(t, err)
+	assert.True(t, ext.Id.Equal(OIDExtensionCertificatePolicies))
+	assert.False(t, ext.Critical)
+
+	got, err := UnmarshalCertificatePolicies(ext.Value)
+	require.NoError(t, err)
+	assert.Equal(t, policies, got)
+
+	_, err = UnmarshalCertificatePolicies(append(ext.Value, 0))
+	assert.Error(t, err)
+}
+
+func TestCertificateTemplateFromCSRPolicies(t *testing.T) {
+	csr, err := GenerateCSR(&cmapi.Certificate{Spec: cmapi.CertificateSpec{
+		CommonName: "example.com",
+		PolicyOIDs: []string{"2.23.140.1.2.1", "1.3.6.1.4.1.44947.1.1.1"},
+	}})
+	require.NoError(t, err)
+
+	template, err := CertificateTemplateFromCSR(csr)
+	require.NoError(t, err)
+	assert.Equal(t, []asn1.ObjectIdentifier{{2, 23, 140, 1, 2, 1}, {1, 3, 6, 1, 4, 1, 44947, 1, 1, 1}}, template.PolicyIdentifiers)
+	assert.Equal(t, []x509.OID{mustOIDFromInts(t, 2, 23, 140, 1, 2, 1), mustOIDFromInts(t, 1, 3, 6, 1, 4, 1, 44947, 1, 1, 1)}, template.Policies)
+
+	_, err = GenerateCSR(&cmapi.Certificate{Spec: cmapi.CertificateSpec{
+		CommonName: "example.com",
+		PolicyOIDs: []string{"2.23.140.1.2.x"},
+	}})
+	assert.Error(t, err)
+}
+
+func TestFilterCertificatePolicies(t *testing.T) {
+	newTemplate := func(t *testing.T) *x509.Certificate {
+		template := &x509.Certificate{}
+		require.NoError(t, setCertificatePolicies(template, []asn1.ObjectIdentifier{{2, 23, 140, 1, 2, 1}, {2, 23, 140, 1, 2, 2}}))
+		return template
+	}
+
+	tests := map[string]struct {
+		allowed []string
+
+		wantPolicies []asn1.ObjectIdentifier
+		wantRemoved  []asn1.ObjectIdentifier
+	}{
+		"all policies are kept if they are allowed": {
+			allowed:      []string{"2.23.140.1.2.2", "2.23.140.1.2.1"},
+			wantPolicies: []asn1.ObjectIdentifier{{2, 23, 140, 1, 2, 1}, {2, 23, 140, 1, 2, 2}},
+		},
+		"policies which are not allowed are removed": {
+			allowed:      []string{"2.23.140.1.2.2", "2.23.140.1.2.3"},
+			wantPolicies: []asn1.ObjectIdentifier{{2, 23, 140, 1, 2, 2}},
+			wantRemoved:  []asn1.ObjectIdentifier{{2, 23, 140, 1, 2, 1}},
+		},
+		"all policies are removed if none are allowed": {
+			wantRemoved: []asn1.ObjectIdentifier{{2, 23, 140, 1, 2, 1}, {2, 23, 140, 1, 2, 2}},
+		},
+		"malformed allowed policies are ignored": {
+			allowed:      []string{"2.23.140.1.2.x", "2.23.140.1.2.1"},
+			wantPolicies: []asn1.ObjectIdentifier{{2, 23, 140, 1, 2, 1}},
+			wantRemoved:  []asn1.ObjectIdentifier{{2, 23, 140, 1, 2, 2}},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			template := newTemplate(t)
+			removed := FilterCertificatePolicies(template, test.allowed)
+			assert.Equal(t, test.wantRemoved, removed)
+			assert.Equal(t, test.wantPolicies, template.PolicyIdentifiers)
+			assert.Len(t, template.Policies, len(test.wantPolicies))
+		})
+	}
+}
+
+func mustOIDFromInts(t *testing.T, arcs ...uint64) x509.OID {
+	oid, err := x509.OIDFromInts(arcs)
+	require.NoError(t, err)
+	return oid
+}
@@ -73,6 +73,18 @@ func TestRenewalTime(t *testing.T) {
 			renewBeforeOverride: &metav1.Duration{Duration: time.Hour * 24},
 			expectedRenewalTime: &metav1.Time{Time: now.Add(time.Minute * 3)}, // renew in 3 minutes
 		},
+		"5 minute cert, spec.renewBefore is not set": {
+			notBefore:           now,
+			notAfter:            now.Add(time.Minute * 5),
+			renewBeforeOverride: nil,
+			expectedRenewalTime: &metav1.Time{Time: now.Add(time.Second * 200)}, // renew in 3m20s
+		},
+		"10 minute cert whose duration is not divisible by 3 seconds": {
+			notBefore:           now,
+			notAfter:            now.Add(time.Minute * 10).Add(time.Second * -1),
+			renewBeforeOverride: nil,
+			expectedRenewalTime: &metav1.Time{Time: now.Add(time.Second * 399)}, // renew in 6m39s
+		},
 		// This test case is here to guard against an earlier bug where
 		// a non-truncated renewal time returned from this function
 		// caused certs to not be renewed.
@@ -0,0 +1,72 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto/x509"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/cryptobyte"
+)
+
+// OIDExtensionSCTList is the OID of the X.509 extension in which Signed
+// Certificate Timestamps are embedded in a certificate, see RFC 6962 section
+// 3.3.
+var OIDExtensionSCTList = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11129, 2, 4, 2}
+
+// EmbeddedSCTCount returns the number of Signed Certificate Timestamps which
+// are embedded in the given certificate, or 0 if it has no SCT list
+// extension. An error is returned if the extension is malformed.
+func EmbeddedSCTCount(cert *x509.Certificate) (int, error) {
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(OIDExtensionSCTList) {
+			return countSCTs(ext.Value)
+		}
+	}
+	return 0, nil
+}
+
+// countSCTs counts the SCTs in the value of an SCT list extension, which is
+// an ASN.1 OCTET STRING holding a TLS encoded SignedCertificateTimestampList.
+func countSCTs(value []byte) (int, error) {
+	var octets []byte
+	rest, err := asn1.Unmarshal(value, &octets)
+	if err != nil {
+		return 0, fmt.Errorf("failed to decode the SCT list extension: %w", err)
+	}
+	if len(rest) != 0 {
+		return 0, errors.New("trailing data after the SCT list extension")
+	}
+
+	var list cryptobyte.String
+	input := cryptobyte.String(octets)
+	if !input.ReadUint16LengthPrefixed(&list) || !input.Empty() {
+		return 0, errors.New("malformed SCT list")
+	}
+
+	count := 0
+	for !list.Empty() {
+		var sct cryptobyte.String
+		if !list.ReadUint16LengthPrefixed(&sct) || sct.Empty() {
+			return 0, errors.New("malformed SCT in SCT list")
+		}
+		count++
+	}
+	return count, nil
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/cryptobyte"
+)
+
+// sctListExtension returns an SCT list extension holding n SCTs. The SCTs are
+// not valid signatures by a log, as only their number is inspected.
+func sctListExtension(t *testing.T, n int) pkix.Extension {
+	var b cryptobyte.Builder
+	b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+		for i := 0; i < n; i++ {
+			b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+				b.AddUint8(0)                        // version
+				b.AddBytes(make([]byte, 32))         // log ID
+				b.AddUint64(uint64(1700000000 + i))  // timestamp
+				b.AddUint16(0)                       // extensions
+				b.AddBytes([]byte{4, 3, 0, 2, 0, 0}) // signature
+			})
+		}
+	})
+	list, err := b.Bytes()
+	require.NoError(t, err)
+	value, err := asn1.Marshal(list)
+	require.NoError(t, err)
+	return pkix.Extension{Id: OIDExtensionSCTList, Value: value}
+}
+
+// certificateWithExtensions returns a self-signed leaf certificate carrying
+// the given extra extensions.
+func certificateWithExtensions(t *testing.T, exts ...pkix.Extension) *x509.Certificate {
+	pk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:    big.NewInt(1),
+		Subject:         pkix.Name{CommonName: "example.com"},
+		DNSNames:        []string{"example.com"},
+		NotBefore:       time.Now(),
+		NotAfter:        time.Now().Add(time.Hour),
+		ExtraExtensions: exts,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, pk.Public(), pk)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+func TestEmbeddedSCTCount(t *testing.T) {
+	tests := map[string]struct {
+		exts      []pkix.Extension
+		wantCount int
+		wantErr   bool
+	}{
+		"a certificate without an SCT list": {
+			wantCount: 0,
+		},
+		"a certificate with an empty SCT list": {
+			exts:      []pkix.Extension{sctListExtension(t, 0)},
+			wantCount: 0,
+		},
+		"a certificate with 1 SCT": {
+			exts:      []pkix.Extension{sctListExtension(t, 1)},
+			wantCount: 1,
+		},
+		"a certificate with 2 SCTs": {
+			exts:      []pkix.Extension{sctListExtension(t, 2)},
+			wantCount: 2,
+		},
+		"a certificate with a malformed SCT list": {
+			exts: []pkix.Extension{{
+				Id:    OIDExtensionSCTList,
+				Value: func() []byte { v, _ := asn1.Marshal([]byte{0, 5, 0, 1}); return v }(),
+			}},
+			wantErr: true,
+		},
+		"a certificate with an SCT list which is not an octet string": {
+			exts:    []pkix.Extension{{Id: OIDExtensionSCTList, Value: []byte{0x02, 0x01, 0x01}}},
+			wantErr: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			count, err := EmbeddedSCTCount(certificateWithExtensions(t, test.exts...))
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.wantCount, count)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// FormatSerialNumber formats a certificate serial number as a lowercase,
+// colon separated hex string, e.g. "1f:02:9a". This is the format used by
+// Vault to identify certificates.
+func FormatSerialNumber(serial *big.Int) string {
+	b := serial.Bytes()
+	if len(b) == 0 {
+		return "00"
+	}
+
+	parts := make([]string, len(b))
+	for i, v := range b {
+		parts[i] = fmt.Sprintf("%02x", v)
+	}
+	return strings.Join(parts, ":")
+}
+
+// ParseSerialNumber parses a certificate serial number formatted by
+// FormatSerialNumber.
+func ParseSerialNumber(s string) (*big.Int, error) {
+	parts := strings.Split(s, ":")
+	for _, part := range parts {
+		if len(part) != 2 {
+			return nil, fmt.Errorf("invalid serial number %q", s)
+		}
+	}
+	serial, ok := new(big.Int).SetString(strings.Join(parts, ""), 16)
+	if !ok {
+		return nil, fmt.Errorf("invalid serial number %q", s)
+	}
+	return serial, nil
+}
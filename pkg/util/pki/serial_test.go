@@ -0,0 +1,63 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatSerialNumber(t *testing.T) {
+	tests := map[string]struct {
+		serial *big.Int
+		exp    string
+	}{
+		"zero": {
+			serial: big.NewInt(0),
+			exp:    "00",
+		},
+		"single byte is zero padded": {
+			serial: big.NewInt(10),
+			exp:    "0a",
+		},
+		"multiple bytes are colon separated": {
+			serial: big.NewInt(0x1f029a),
+			exp:    "1f:02:9a",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.exp, FormatSerialNumber(test.serial))
+		})
+	}
+}
+
+func TestParseSerialNumber(t *testing.T) {
+	for _, serial := range []*big.Int{big.NewInt(0), big.NewInt(10), big.NewInt(0x1f029a)} {
+		parsed, err := ParseSerialNumber(FormatSerialNumber(serial))
+		assert.NoError(t, err)
+		assert.Equal(t, 0, serial.Cmp(parsed))
+	}
+
+	for _, s := range []string{"", "zz", "1f:02:"} {
+		_, err := ParseSerialNumber(s)
+		assert.Error(t, err, s)
+	}
+}
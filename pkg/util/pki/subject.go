@@ -34,6 +34,7 @@ var OIDConstants = struct {
 	Locality           []int
 	Province           []int
 	StreetAddress      []int
+	PostalCode         []int
 	DomainComponent    []int
 	UniqueIdentifier   []int
 }{
@@ -45,6 +46,7 @@ var OIDConstants = struct {
 	Locality:           []int{2, 5, 4, 7},
 	Province:           []int{2, 5, 4, 8},
 	StreetAddress:      []int{2, 5, 4, 9},
+	PostalCode:         []int{2, 5, 4, 17},
 	DomainComponent:    []int{0, 9, 2342, 19200300, 100, 1, 25},
 	UniqueIdentifier:   []int{0, 9, 2342, 19200300, 100, 1, 1},
 }
@@ -61,6 +63,7 @@ var attributeTypeNames = map[string][]int{
 	"L":            OIDConstants.Locality,
 	"ST":           OIDConstants.Province,
 	"STREET":       OIDConstants.StreetAddress,
+	"POSTALCODE":   OIDConstants.PostalCode,
 	"DC":           OIDConstants.DomainComponent,
 	"UID":          OIDConstants.UniqueIdentifier,
 }
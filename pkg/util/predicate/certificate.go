@@ -44,3 +44,40 @@ func CertificateNextPrivateKeySecretName(name string) Func {
 		return *crt.Status.NextPrivateKeySecretName == name
 	}
 }
+
+// CertificateExternalCSRSecretName returns a predicate that used to filter
+// Certificates to only those with the given 'spec.externalCSR.secretRef.name'.
+func CertificateExternalCSRSecretName(name string) Func {
+	return func(obj runtime.Object) bool {
+		crt := obj.(*cmapi.Certificate)
+		if crt.Spec.ExternalCSR == nil {
+			return false
+		}
+		return crt.Spec.ExternalCSR.SecretRef.Name == name
+	}
+}
+
+// CertificateCABundleSecretName returns a predicate that used to filter
+// Certificates to only those whose 'spec.secretCAPolicy.bundleRef' refers to
+// the Secret with the given name.
+func CertificateCABundleSecretName(name string) Func {
+	return certificateCABundleRef("Secret", name)
+}
+
+// CertificateCABundleConfigMapName returns a predicate that used to filter
+// Certificates to only those whose 'spec.secretCAPolicy.bundleRef' refers to
+// the ConfigMap with the given name.
+func CertificateCABundleConfigMapName(name string) Func {
+	return certificateCABundleRef("ConfigMap", name)
+}
+
+func certificateCABundleRef(kind, name string) Func {
+	return func(obj runtime.Object) bool {
+		crt := obj.(*cmapi.Certificate)
+		if crt.Spec.SecretCAPolicy == nil || crt.Spec.SecretCAPolicy.BundleRef == nil {
+			return false
+		}
+		ref := crt.Spec.SecretCAPolicy.BundleRef
+		return ref.Kind == kind && ref.Name == name
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"k8s.io/utils/ptr"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 )
 
 func TestCertificateSecretName(t *testing.T) {
@@ -92,3 +93,98 @@ func TestCertificateNextPrivateKeySecretName(t *testing.T) {
 		})
 	}
 }
+
+func TestCertificateExternalCSRSecretName(t *testing.T) {
+	certWithExternalCSR := func(s string) *cmapi.Certificate {
+		return &cmapi.Certificate{
+			Spec: cmapi.CertificateSpec{
+				ExternalCSR: &cmapi.CertificateExternalCSR{
+					SecretRef: cmmeta.SecretKeySelector{
+						LocalObjectReference: cmmeta.LocalObjectReference{Name: s},
+					},
+				},
+			},
+		}
+	}
+	tests := map[string]struct {
+		secretName string
+		cert       *cmapi.Certificate
+		expected   bool
+	}{
+		"returns true if secret name matches": {
+			secretName: "abc",
+			cert:       certWithExternalCSR("abc"),
+			expected:   true,
+		},
+		"returns false if secret name does not match": {
+			secretName: "abc",
+			cert:       certWithExternalCSR("abcd"),
+			expected:   false,
+		},
+		"returns false if externalCSR is not set": {
+			secretName: "",
+			cert:       &cmapi.Certificate{},
+			expected:   false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := CertificateExternalCSRSecretName(test.secretName)(test.cert)
+			if got != test.expected {
+				t.Errorf("unexpected response: got=%t, exp=%t", got, test.expected)
+			}
+		})
+	}
+}
+
+func TestCertificateCABundleRef(t *testing.T) {
+	certWithBundleRef := func(kind, name string) *cmapi.Certificate {
+		return &cmapi.Certificate{
+			Spec: cmapi.CertificateSpec{
+				SecretCAPolicy: &cmapi.CertificateSecretCAPolicy{
+					Type:      cmapi.CertificateSecretCAPolicyFromSecretRef,
+					BundleRef: &cmapi.CertificateCABundleReference{Kind: kind, Name: name, Key: "ca.crt"},
+				},
+			},
+		}
+	}
+	tests := map[string]struct {
+		predicate Func
+		cert      *cmapi.Certificate
+		expected  bool
+	}{
+		"returns true if Secret name matches": {
+			predicate: CertificateCABundleSecretName("abc"),
+			cert:      certWithBundleRef("Secret", "abc"),
+			expected:  true,
+		},
+		"returns true if ConfigMap name matches": {
+			predicate: CertificateCABundleConfigMapName("abc"),
+			cert:      certWithBundleRef("ConfigMap", "abc"),
+			expected:  true,
+		},
+		"returns false if name does not match": {
+			predicate: CertificateCABundleSecretName("abc"),
+			cert:      certWithBundleRef("Secret", "abcd"),
+			expected:  false,
+		},
+		"returns false if kind does not match": {
+			predicate: CertificateCABundleSecretName("abc"),
+			cert:      certWithBundleRef("ConfigMap", "abc"),
+			expected:  false,
+		},
+		"returns false if secretCAPolicy is not set": {
+			predicate: CertificateCABundleConfigMapName(""),
+			cert:      &cmapi.Certificate{},
+			expected:  false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := test.predicate(test.cert)
+			if got != test.expected {
+				t.Errorf("unexpected response: got=%t, exp=%t", got, test.expected)
+			}
+		})
+	}
+}
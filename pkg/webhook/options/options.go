@@ -86,6 +86,23 @@ func AddConfigFlags(fs *pflag.FlagSet, c *config.WebhookConfiguration) {
 	fs.StringVar(&c.TLSConfig.MinTLSVersion, "tls-min-version", c.TLSConfig.MinTLSVersion,
 		"Minimum TLS version supported. If omitted, the default Go minimum version will be used. "+
 			"Possible values: "+strings.Join(tlsPossibleVersions, ", "))
+	fs.Int32Var(&c.CertificateSANsWarningThreshold, "certificate-sans-warning-threshold", c.CertificateSANsWarningThreshold, ""+
+		"The number of subject alternative names above which a warning is returned when a Certificate is created or updated. "+
+		"If 0, no warning is returned.")
+	fs.Int32Var(&c.MaxCertificateSANs, "max-certificate-sans", c.MaxCertificateSANs, ""+
+		"The maximum number of subject alternative names a Certificate may request. If 0, the number is not limited.")
+	fs.StringVar(&c.DuplicateDNSNamesPolicy, "duplicate-dns-names-policy", c.DuplicateDNSNamesPolicy, ""+
+		"What happens when a Certificate is created with DNS names which overlap with those of another Certificate, in "+
+		"any namespace, issued by the same ClusterIssuer. One of Ignore, Warn, which returns a warning naming the existing "+
+		"Certificate, or Strict, which rejects the Certificate.")
+	fs.StringVar(&c.MissingReferencesPolicy, "missing-references-policy", c.MissingReferencesPolicy, ""+
+		"What happens when a Certificate is created or updated referencing a keystore password Secret, an external CSR "+
+		"Secret, an Issuer or a ClusterIssuer which does not exist. One of Ignore, Warn, which returns a warning naming "+
+		"the missing object, or Strict, which rejects the Certificate. The webhook must be allowed to get Secrets, "+
+		"Issuers and ClusterIssuers; references it is not allowed to get are not checked.")
+	fs.StringVar(&c.ControllerUsername, "controller-username", c.ControllerUsername, ""+
+		"The username of the cert-manager controller, such as that of its service account. Only requests from this user "+
+		"may set or change the label marking CertificateRequests as created for a Certificate.")
 	fs.Var(cliflag.NewMapStringBool(&c.FeatureGates), "feature-gates", "A set of key=value pairs that describe feature gates for alpha/experimental features. "+
 		"Options are:\n"+strings.Join(utilfeature.DefaultFeatureGate.KnownFeatures(), "\n"))
 
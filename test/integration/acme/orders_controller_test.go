@@ -106,7 +106,8 @@ func TestAcmeOrdersController(t *testing.T) {
 			// A hack to ensure the status of the _ACME_ order gets set to valid
 			// when we're finalizing the order.
 			acmeOrder.Status = acmeapi.StatusValid
-			return [][]byte{}, "", nil
+			acmeOrder.CertURL = testName + "/cert"
+			return [][]byte{}, acmeOrder.CertURL, nil
 		},
 	}
 
@@ -299,6 +300,10 @@ func TestAcmeOrdersController(t *testing.T) {
 		if o.Status.State != cmacme.Valid {
 			return false, nil
 		}
+		// The ACME URLs should be recorded on the Order for debugging.
+		if o.Status.URL != testName || o.Status.FinalizeURL != testName || o.Status.CertificateURL != testName+"/cert" {
+			return false, fmt.Errorf("unexpected Order URLs: url=%q, finalizeURL=%q, certificateURL=%q", o.Status.URL, o.Status.FinalizeURL, o.Status.CertificateURL)
+		}
 		return true, nil
 	})
 	if err != nil {
@@ -196,7 +196,13 @@ certmanager_certificate_ready_status{condition="Unknown",issuer_group="test-issu
 # HELP certmanager_certificate_renewal_timestamp_seconds The number of seconds before expiration time the certificate should renew.
 # TYPE certmanager_certificate_renewal_timestamp_seconds gauge
 certmanager_certificate_renewal_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="Issuer",issuer_name="test-issuer",name="testcrt",namespace="testns"} 0
+# HELP certmanager_certificate_waiting_for_approval Whether the issuance of the certificate is waiting for its CertificateRequest to be approved, 1 if it is and 0 otherwise.
+# TYPE certmanager_certificate_waiting_for_approval gauge
+certmanager_certificate_waiting_for_approval{issuer_group="test-issuer-group",issuer_kind="Issuer",issuer_name="test-issuer",name="testcrt",namespace="testns"} 0
 ` + clockCounterMetric + clockGaugeMetric + `
+# HELP certmanager_controller_active_workers The number of workers of a controller which are currently processing an item.
+# TYPE certmanager_controller_active_workers gauge
+certmanager_controller_active_workers{controller="metrics_test"} 0
 # HELP certmanager_controller_sync_call_count The number of sync() calls made by a controller.
 # TYPE certmanager_controller_sync_call_count counter
 certmanager_controller_sync_call_count{controller="metrics_test"} 1
@@ -232,7 +238,13 @@ certmanager_certificate_ready_status{condition="Unknown",issuer_group="test-issu
 # HELP certmanager_certificate_renewal_timestamp_seconds The number of seconds before expiration time the certificate should renew.
 # TYPE certmanager_certificate_renewal_timestamp_seconds gauge
 certmanager_certificate_renewal_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="Issuer",issuer_name="test-issuer",name="testcrt",namespace="testns"} 100
+# HELP certmanager_certificate_waiting_for_approval Whether the issuance of the certificate is waiting for its CertificateRequest to be approved, 1 if it is and 0 otherwise.
+# TYPE certmanager_certificate_waiting_for_approval gauge
+certmanager_certificate_waiting_for_approval{issuer_group="test-issuer-group",issuer_kind="Issuer",issuer_name="test-issuer",name="testcrt",namespace="testns"} 0
 ` + clockCounterMetric + clockGaugeMetric + `
+# HELP certmanager_controller_active_workers The number of workers of a controller which are currently processing an item.
+# TYPE certmanager_controller_active_workers gauge
+certmanager_controller_active_workers{controller="metrics_test"} 0
 # HELP certmanager_controller_sync_call_count The number of sync() calls made by a controller.
 # TYPE certmanager_controller_sync_call_count counter
 certmanager_controller_sync_call_count{controller="metrics_test"} 2
@@ -245,6 +257,9 @@ certmanager_controller_sync_call_count{controller="metrics_test"} 2
 
 	// Should expose no Certificates and only metrics sync count increase
 	waitForMetrics(clockCounterMetric + clockGaugeMetric + `
+# HELP certmanager_controller_active_workers The number of workers of a controller which are currently processing an item.
+# TYPE certmanager_controller_active_workers gauge
+certmanager_controller_active_workers{controller="metrics_test"} 0
 # HELP certmanager_controller_sync_call_count The number of sync() calls made by a controller.
 # TYPE certmanager_controller_sync_call_count counter
 certmanager_controller_sync_call_count{controller="metrics_test"} 3
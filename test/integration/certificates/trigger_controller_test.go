@@ -32,6 +32,7 @@ import (
 	"k8s.io/utils/ptr"
 
 	"github.com/cert-manager/cert-manager/integration-tests/framework"
+	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
 	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
@@ -68,7 +69,7 @@ func TestTriggerController(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	shouldReissue := policies.NewTriggerPolicyChain(fakeClock).Evaluate
+	shouldReissue := policies.NewNamedTriggerPolicyChain(fakeClock, internalcertificates.PrivateKeyDefaults{})
 	controllerContext := &controllerpkg.Context{
 		Scheme:                    scheme,
 		Client:                    kubeClient,
@@ -120,7 +121,7 @@ func TestTriggerController_RenewNearExpiry(t *testing.T) {
 	// Only use the 'current certificate nearing expiry' policy chain during the
 	// test as we want to test the very specific cases of triggering/not
 	// triggering depending on whether a renewal is required.
-	shoudReissue := policies.Chain{policies.CurrentCertificateNearingExpiry(fakeClock)}.Evaluate
+	shoudReissue := policies.NamedChain{{Name: policies.PolicyCurrentCertificateNearingExpiry, Check: policies.CurrentCertificateNearingExpiry(fakeClock)}}
 	// Build, instantiate and run the trigger controller.
 	kubeClient, factory, cmCl, cmFactory, scheme := framework.NewClients(t, config)
 
@@ -241,7 +242,7 @@ func TestTriggerController_ExpBackoff(t *testing.T) {
 	// Issuing condition will be applied because SecretDoesNotExist policy
 	// will evaluate to true. However, this is not what we are testing in
 	// this test.
-	shoudReissue := policies.NewTriggerPolicyChain(fakeClock).Evaluate
+	shoudReissue := policies.NewNamedTriggerPolicyChain(fakeClock, internalcertificates.PrivateKeyDefaults{})
 	// Build, instantiate and run the trigger controller.
 	kubeClient, factory, cmCl, cmFactory, scheme := framework.NewClients(t, config)
 
@@ -339,6 +340,139 @@ func TestTriggerController_ExpBackoff(t *testing.T) {
 	ensureCertificateHasIssuingCondition(t, ctx, cmCl, namespace, certName)
 }
 
+// TestTriggerController_ShortLivedCertificate ensures that a certificate with
+// a duration of 10 minutes is renewed at its renewal time, several times in a
+// row, without relying on anything other than the renewal scheduled by the
+// trigger controller to re-queue the Certificate.
+func TestTriggerController_ShortLivedCertificate(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*60)
+	defer cancel()
+
+	config, stopFn := framework.RunControlPlane(t, ctx)
+	defer stopFn()
+
+	fakeClock := fakeclock.NewFakeClock(time.Now().Truncate(time.Second))
+	// Only use the 'current certificate nearing expiry' policy chain during the
+	// test as we want to test when renewals are triggered.
+	shouldReissue := policies.NamedChain{{Name: policies.PolicyCurrentCertificateNearingExpiry, Check: policies.CurrentCertificateNearingExpiry(fakeClock)}}
+	// Build, instantiate and run the trigger controller.
+	kubeClient, factory, cmCl, cmFactory, scheme := framework.NewClients(t, config)
+
+	namespace := "testns-short-lived"
+	secretName := "example"
+	certName := "testcrt"
+	duration := 10 * time.Minute
+
+	// Create namespace
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}
+	_, err := kubeClient.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert := &cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Name: certName, Namespace: namespace},
+		Spec: cmapi.CertificateSpec{
+			SecretName: secretName,
+			CommonName: "example.com",
+			Duration:   &metav1.Duration{Duration: duration},
+			IssuerRef:  cmmeta.ObjectReference{Name: "testissuer"}, // doesn't need to exist
+		},
+	}
+
+	sk, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	skBytes := pki.EncodePKCS1PrivateKey(sk)
+
+	// The Secret must exist before the Certificate is created, as the policy
+	// chain used in this test expects it to.
+	notBefore := fakeClock.Now()
+	_, err = kubeClient.CoreV1().Secrets(namespace).Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: namespace},
+		Data: map[string][]byte{
+			corev1.TLSCertKey: selfSignCertificateWithNotBeforeAfter(t, skBytes, cert, notBefore, notBefore.Add(duration)),
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	controllerContext := &controllerpkg.Context{
+		Scheme:                    scheme,
+		Client:                    kubeClient,
+		KubeSharedInformerFactory: factory,
+		CMClient:                  cmCl,
+		SharedInformerFactory:     cmFactory,
+		ContextOptions: controllerpkg.ContextOptions{
+			Clock: fakeClock,
+		},
+		Recorder:     framework.NewEventRecorder(t, scheme),
+		FieldManager: "cert-manager-certificates-trigger-test",
+	}
+	ctrl, queue, mustSync := trigger.NewController(logf.Log, controllerContext, shouldReissue)
+	c := controllerpkg.NewController(
+		"trigger_test",
+		metrics.New(logf.Log, clock.RealClock{}),
+		ctrl.ProcessItem,
+		mustSync,
+		nil,
+		queue,
+	)
+	stopController := framework.StartInformersAndController(t, factory, cmFactory, c)
+	defer stopController()
+
+	_, err = cmCl.CertmanagerV1().Certificates(namespace).Create(ctx, cert, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 1; i <= 3; i++ {
+		notAfter := notBefore.Add(duration)
+		renewalTime := pki.RenewalTime(notBefore, notAfter, nil)
+		if want := notBefore.Add(duration * 2 / 3); !renewalTime.Time.Equal(want) {
+			t.Fatalf("renewal %d: expected renewal time %s, got %s", i, want, renewalTime.Time)
+		}
+
+		// Simulate the issuing and readiness controllers having completed
+		// the previous issuance.
+		t.Logf("Renewal %d: certificate valid from %s to %s", i, notBefore, notAfter)
+		cert, err = cmCl.CertmanagerV1().Certificates(namespace).Get(ctx, certName, metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		apiutil.SetCertificateCondition(cert, cert.Generation, cmapi.CertificateConditionIssuing, cmmeta.ConditionFalse, "Issued", "")
+		cert.Status.NotBefore = &metav1.Time{Time: notBefore}
+		cert.Status.NotAfter = &metav1.Time{Time: notAfter}
+		cert.Status.RenewalTime = renewalTime
+		if _, err := cmCl.CertmanagerV1().Certificates(namespace).UpdateStatus(ctx, cert, metav1.UpdateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+
+		t.Log("Advance clock to a second before the renewal time")
+		fakeClock.SetTime(renewalTime.Add(-time.Second))
+		ensureCertificateDoesNotHaveIssuingCondition(t, ctx, cmCl, namespace, certName)
+
+		// The Certificate is not modified, so the renewal must be triggered
+		// by the re-queue scheduled for the renewal time.
+		t.Log("Advance clock to the renewal time")
+		fakeClock.SetTime(renewalTime.Time)
+		ensureCertificateHasIssuingCondition(t, ctx, cmCl, namespace, certName)
+
+		// Store the renewed certificate.
+		notBefore = fakeClock.Now()
+		secret, err := kubeClient.CoreV1().Secrets(namespace).Get(ctx, secretName, metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		secret.Data[corev1.TLSCertKey] = selfSignCertificateWithNotBeforeAfter(t, skBytes, cert, notBefore, notBefore.Add(duration))
+		if _, err := kubeClient.CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
 func ensureCertificateDoesNotHaveIssuingCondition(t *testing.T, ctx context.Context, cmCl cmclient.Interface, namespace, name string) {
 	t.Helper()
 
@@ -100,7 +100,8 @@ func RunControlPlane(t *testing.T, ctx context.Context, optionFunctions ...RunCo
 	}
 
 	webhookOpts, stopWebhook := webhooktesting.StartWebhookServer(
-		t, ctx, []string{"--kubeconfig", f.Name()},
+		// Controllers run in integration tests use the envtest admin user.
+		t, ctx, []string{"--kubeconfig", f.Name(), "--controller-username", "admin"},
 	)
 
 	crds := readCustomResourcesAtPath(t, *options.crdsDir)
@@ -59,7 +59,7 @@ func NewClients(t *testing.T, config *rest.Config) (kubernetes.Interface, intern
 	if err != nil {
 		t.Fatal(err)
 	}
-	factory := internalinformers.NewBaseKubeInformerFactory(cl, 0, "")
+	factory := internalinformers.NewBaseKubeInformerFactory(cl, 0, "", nil)
 
 	cmCl, err := cmclient.NewForConfigAndClient(config, httpClient)
 	if err != nil {
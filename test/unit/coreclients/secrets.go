@@ -58,23 +58,48 @@ func NewFakeSecretsGetter(mods ...FakeSecretsGetterModifier) *FakeSecretsGetter
 // FakeSecretsGetter(<namespace>).Create(<secret>, <opts>) is called.
 func SetFakeSecretsGetterCreate(s *corev1.Secret, err error) FakeSecretsGetterModifier {
 	return func(f *FakeSecretsGetter) {
-		f.c.CreateFn = func() (*corev1.Secret, error) {
+		f.c.CreateFn = func(*corev1.Secret) (*corev1.Secret, error) {
 			return s, err
 		}
 	}
 }
 
+// SetFakeSecretsGetterCreateFn is a modifier that can be used to inject code
+// when FakeSecretsGetter(<namespace>).Create(<secret>, <opts>) is called.
+func SetFakeSecretsGetterCreateFn(fn func(*corev1.Secret) (*corev1.Secret, error)) FakeSecretsGetterModifier {
+	return func(f *FakeSecretsGetter) {
+		f.c.CreateFn = fn
+	}
+}
+
+// SetFakeSecretsGetterDeleteFn is a modifier that can be used to inject code
+// when FakeSecretsGetter(<namespace>).Delete(<context>,<name>,<opts>) is
+// called.
+func SetFakeSecretsGetterDeleteFn(fn func(string, metav1.DeleteOptions) error) FakeSecretsGetterModifier {
+	return func(f *FakeSecretsGetter) {
+		f.c.DeleteFn = fn
+	}
+}
+
 // SetFakeSecretsGetterGet is a modifier that can be used to set secret and
 // error that will be returned when
 // FakeSecretsGetter(<namespace>).Get(<context>,<uid>,<opts>) is called.
 func SetFakeSecretsGetterGet(s *corev1.Secret, err error) FakeSecretsGetterModifier {
 	return func(f *FakeSecretsGetter) {
-		f.c.GetFn = func() (*corev1.Secret, error) {
+		f.c.GetFn = func(string) (*corev1.Secret, error) {
 			return s, err
 		}
 	}
 }
 
+// SetFakeSecretsGetterGetFn is a modifier that can be used to inject code
+// when FakeSecretsGetter(<namespace>).Get(<context>,<name>,<opts>) is called.
+func SetFakeSecretsGetterGetFn(fn func(string) (*corev1.Secret, error)) FakeSecretsGetterModifier {
+	return func(f *FakeSecretsGetter) {
+		f.c.GetFn = fn
+	}
+}
+
 // SetFakeSecretsGetterApplyFn is a function that can be used to inject code
 // when the FakeSecretsGetter is Applied.
 func SetFakeSecretsGetterApplyFn(fn ApplyFn) FakeSecretsGetterModifier {
@@ -90,11 +115,11 @@ func (f *FakeSecretsGetter) Secrets(string) typedcorev1.SecretInterface {
 type ApplyFn func(context.Context, *applyconfigurationscorev1.SecretApplyConfiguration, metav1.ApplyOptions) (*corev1.Secret, error)
 
 type fakeSecretClient struct {
-	CreateFn           func() (*corev1.Secret, error)
+	CreateFn           func(*corev1.Secret) (*corev1.Secret, error)
 	UpdateFn           func() (*corev1.Secret, error)
-	DeleteFn           func() error
+	DeleteFn           func(string, metav1.DeleteOptions) error
 	DeleteCollectionFn func() error
-	GetFn              func() (*corev1.Secret, error)
+	GetFn              func(string) (*corev1.Secret, error)
 	ListFn             func() (*corev1.SecretList, error)
 	WatchFn            func() (watch.Interface, error)
 	PatchFn            func() (*corev1.Secret, error)
@@ -103,24 +128,24 @@ type fakeSecretClient struct {
 	typedcorev1.SecretExpansion
 }
 
-func (f *fakeSecretClient) Create(context.Context, *corev1.Secret, metav1.CreateOptions) (*corev1.Secret, error) {
-	return f.CreateFn()
+func (f *fakeSecretClient) Create(_ context.Context, secret *corev1.Secret, _ metav1.CreateOptions) (*corev1.Secret, error) {
+	return f.CreateFn(secret)
 }
 
 func (f *fakeSecretClient) Update(context.Context, *corev1.Secret, metav1.UpdateOptions) (*corev1.Secret, error) {
 	return f.UpdateFn()
 }
 
-func (f *fakeSecretClient) Delete(context.Context, string, metav1.DeleteOptions) error {
-	return f.DeleteFn()
+func (f *fakeSecretClient) Delete(_ context.Context, name string, opts metav1.DeleteOptions) error {
+	return f.DeleteFn(name, opts)
 }
 
 func (f *fakeSecretClient) DeleteCollection(context.Context, metav1.DeleteOptions, metav1.ListOptions) error {
 	return f.DeleteCollectionFn()
 }
 
-func (f *fakeSecretClient) Get(context.Context, string, metav1.GetOptions) (*corev1.Secret, error) {
-	return f.GetFn()
+func (f *fakeSecretClient) Get(_ context.Context, name string, _ metav1.GetOptions) (*corev1.Secret, error) {
+	return f.GetFn(name)
 }
 
 func (f *fakeSecretClient) List(context.Context, metav1.ListOptions) (*corev1.SecretList, error) {
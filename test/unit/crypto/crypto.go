@@ -19,11 +19,14 @@ package crypto
 import (
 	"crypto"
 	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
 	"encoding/pem"
 	"fmt"
 	"testing"
 	"time"
 
+	"golang.org/x/crypto/cryptobyte"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/clock"
 
@@ -290,3 +293,43 @@ func MustCreateCert(t *testing.T, pkData []byte, spec *cmapi.Certificate) []byte
 
 	return certData
 }
+
+// MustCreateCertWithSCTs returns a PEM encoded certificate for the given
+// Certificate, self-signed by the given private key, which embeds n Signed
+// Certificate Timestamps. The SCTs are not signed by a log, as only their
+// presence is meant to be inspected.
+func MustCreateCertWithSCTs(t *testing.T, pk crypto.Signer, spec *cmapi.Certificate, n int) []byte {
+	var b cryptobyte.Builder
+	b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+		for i := 0; i < n; i++ {
+			b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+				b.AddUint8(0)                        // version
+				b.AddBytes(make([]byte, 32))         // log ID
+				b.AddUint64(uint64(1700000000 + i))  // timestamp
+				b.AddUint16(0)                       // extensions
+				b.AddBytes([]byte{4, 3, 0, 2, 0, 0}) // signature
+			})
+		}
+	})
+	list, err := b.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, err := asn1.Marshal(list)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template, err := pki.CertificateTemplateFromCertificate(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template.PublicKey = pk.Public()
+	template.ExtraExtensions = append(template.ExtraExtensions, pkix.Extension{Id: pki.OIDExtensionSCTList, Value: value})
+
+	certData, _, err := pki.SignCertificate(template, template, pk.Public(), pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return certData
+}
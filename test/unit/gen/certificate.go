@@ -136,6 +136,24 @@ func SetCertificateRenewBefore(renewBefore *metav1.Duration) CertificateModifier
 	}
 }
 
+func SetCertificateRenewBeforeChainExpiry(renewBeforeChainExpiry bool) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.Spec.RenewBeforeChainExpiry = renewBeforeChainExpiry
+	}
+}
+
+func SetCertificateChainExpiryExcludedFingerprints(fingerprints ...string) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.Spec.ChainExpiryExcludedFingerprints = fingerprints
+	}
+}
+
+func SetCertificateRolloutDelay(rolloutDelay metav1.Duration) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.Spec.RolloutDelay = &rolloutDelay
+	}
+}
+
 func SetCertificateNextPrivateKeySecretName(name string) CertificateModifier {
 	return func(crt *v1.Certificate) {
 		crt.Status.NextPrivateKeySecretName = &name
@@ -187,6 +205,12 @@ func SetCertificateRenewalTime(p metav1.Time) CertificateModifier {
 	}
 }
 
+func SetCertificateNextIssuanceWindowTime(p metav1.Time) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.Status.NextIssuanceWindowTime = &p
+	}
+}
+
 func SetCertificateOrganization(orgs ...string) CertificateModifier {
 	return func(ch *v1.Certificate) {
 		if ch.Spec.Subject == nil {
@@ -202,6 +226,12 @@ func SetCertificateNamespace(namespace string) CertificateModifier {
 	}
 }
 
+func SetCertificateName(name string) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.ObjectMeta.Name = name
+	}
+}
+
 func SetCertificateKeyUsages(usages ...v1.KeyUsage) CertificateModifier {
 	return func(crt *v1.Certificate) {
 		crt.Spec.Usages = usages
@@ -214,6 +244,62 @@ func SetCertificateRevision(revision int) CertificateModifier {
 	}
 }
 
+func SetCertificateSerialNumber(serialNumber string) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.Status.SerialNumber = serialNumber
+	}
+}
+
+func SetCertificateRevokeOnDelete(revokeOnDelete bool) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.Spec.RevokeOnDelete = &revokeOnDelete
+	}
+}
+
+func SetCertificateExternalCSR(secretName, key string) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.Spec.ExternalCSR = &v1.CertificateExternalCSR{
+			SecretRef: cmmeta.SecretKeySelector{
+				LocalObjectReference: cmmeta.LocalObjectReference{Name: secretName},
+				Key:                  key,
+			},
+		}
+	}
+}
+
+func SetCertificateExternalPrivateKey(keyRing string) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		if crt.Spec.PrivateKey == nil {
+			crt.Spec.PrivateKey = &v1.CertificatePrivateKey{}
+		}
+		crt.Spec.PrivateKey.External = &v1.CertificateExternalPrivateKey{KeyRing: keyRing}
+	}
+}
+
+func SetCertificateSecretCAPolicy(policy *v1.CertificateSecretCAPolicy) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.Spec.SecretCAPolicy = policy
+	}
+}
+
+func SetCertificateIssuanceWindow(window *v1.CertificateIssuanceWindow) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.Spec.IssuanceWindow = window
+	}
+}
+
+func SetCertificateFinalizers(finalizers ...string) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.Finalizers = finalizers
+	}
+}
+
+func SetCertificateDeletionTimestamp(ts metav1.Time) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.DeletionTimestamp = &ts
+	}
+}
+
 func SetCertificateUID(uid types.UID) CertificateModifier {
 	return func(crt *v1.Certificate) {
 		crt.UID = uid
@@ -140,6 +140,12 @@ func AddCertificateRequestAnnotations(annotations map[string]string) Certificate
 	}
 }
 
+func SetCertificateRequestOwnerReferences(owners ...metav1.OwnerReference) CertificateRequestModifier {
+	return func(cr *v1.CertificateRequest) {
+		cr.OwnerReferences = owners
+	}
+}
+
 func AddCertificateRequestOwnerReferences(owners ...metav1.OwnerReference) CertificateRequestModifier {
 	return func(cr *v1.CertificateRequest) {
 		cr.OwnerReferences = append(cr.OwnerReferences, owners...)
@@ -166,6 +172,12 @@ func DeleteCertificateRequestAnnotation(key string) CertificateRequestModifier {
 	}
 }
 
+func SetCertificateRequestLabels(labels map[string]string) CertificateRequestModifier {
+	return func(cr *v1.CertificateRequest) {
+		cr.Labels = labels
+	}
+}
+
 func SetCertificateRequestFailureTime(p metav1.Time) CertificateRequestModifier {
 	return func(cr *v1.CertificateRequest) {
 		cr.Status.FailureTime = &p
@@ -23,6 +23,7 @@ import (
 	"crypto/elliptic"
 	"crypto/rsa"
 	"crypto/x509"
+	"encoding/asn1"
 	"encoding/pem"
 	"fmt"
 	"net"
@@ -182,3 +183,23 @@ func SetCSREmails(emails []string) CSRModifier {
 		return nil
 	}
 }
+
+func SetCSRPolicyOIDs(policyOIDs ...string) CSRModifier {
+	return func(c *x509.CertificateRequest) error {
+		policies := make([]asn1.ObjectIdentifier, 0, len(policyOIDs))
+		for _, policyOID := range policyOIDs {
+			oid, err := pki.ParsePolicyOID(policyOID)
+			if err != nil {
+				return err
+			}
+			policies = append(policies, oid)
+		}
+
+		extension, err := pki.MarshalCertificatePolicies(policies)
+		if err != nil {
+			return err
+		}
+		c.ExtraExtensions = append(c.ExtraExtensions, extension)
+		return nil
+	}
+}
@@ -18,6 +18,7 @@ package gen
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
@@ -425,14 +426,38 @@ func SetIssuerVenafi(a v1.VenafiIssuer) IssuerModifier {
 	}
 }
 
+func SetIssuerManual(a v1.ManualIssuer) IssuerModifier {
+	return func(iss v1.GenericIssuer) {
+		iss.GetSpec().Manual = &a
+	}
+}
+
+func SetIssuerReadinessGates(gates ...v1.CertificateReadinessGate) IssuerModifier {
+	return func(iss v1.GenericIssuer) {
+		iss.GetSpec().ReadinessGates = gates
+	}
+}
+
 func AddIssuerCondition(c v1.IssuerCondition) IssuerModifier {
 	return func(iss v1.GenericIssuer) {
 		iss.GetStatus().Conditions = append(iss.GetStatus().Conditions, c)
 	}
 }
 
+func SetIssuerName(name string) IssuerModifier {
+	return func(iss v1.GenericIssuer) {
+		iss.GetObjectMeta().Name = name
+	}
+}
+
 func SetIssuerNamespace(namespace string) IssuerModifier {
 	return func(iss v1.GenericIssuer) {
 		iss.GetObjectMeta().Namespace = namespace
 	}
 }
+
+func SetIssuerUID(uid types.UID) IssuerModifier {
+	return func(iss v1.GenericIssuer) {
+		iss.GetObjectMeta().UID = uid
+	}
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package signer contains a conformance test suite for issuers that sign
+// CertificateRequests locally, such as the CA and SelfSigned issuers. Running
+// the same suite against each of them ensures that they do not drift in how
+// they interpret a CertificateRequest.
+package signer
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+// SignFunc signs the given CertificateRequest, whose CSR has been signed by
+// key. It returns the PEM encoded signed certificate, or nil if the request
+// was not signed, in which case the reason should have been recorded on the
+// CertificateRequest's Ready condition.
+type SignFunc func(t *testing.T, key crypto.Signer, cr *cmapi.CertificateRequest) []byte
+
+// RunConformance runs the signer conformance test suite using sign.
+func RunConformance(t *testing.T, sign SignFunc) {
+	tests := map[string]struct {
+		// crt is used to generate the CSR.
+		crt *cmapi.Certificate
+		// csrOpts are the options used to generate the CSR.
+		csrOpts []pki.GenerateCSROption
+		// crMods are applied to the CertificateRequest.
+		crMods []gen.CertificateRequestModifier
+
+		// assertCert is called with the signed certificate. If nil, the
+		// request is expected to fail.
+		assertCert func(t *testing.T, got *x509.Certificate)
+	}{
+		"should honor the requested duration exactly": {
+			crt: gen.Certificate("test", gen.SetCertificateCommonName("example.com")),
+			crMods: []gen.CertificateRequestModifier{
+				gen.SetCertificateRequestDuration(&metav1.Duration{Duration: 30 * time.Minute}),
+			},
+			assertCert: func(t *testing.T, got *x509.Certificate) {
+				// The certificate times are encoded with a second precision.
+				delta := math.Abs(got.NotAfter.Sub(got.NotBefore).Seconds() - (30 * time.Minute).Seconds())
+				assert.LessOrEqualf(t, delta, 1., "expected a duration of 30m, got %s", got.NotAfter.Sub(got.NotBefore))
+			},
+		},
+		"should encode the exact usages of the CertificateRequest": {
+			crt: gen.Certificate("test", gen.SetCertificateCommonName("example.com"),
+				gen.SetCertificateKeyUsages(cmapi.UsageDigitalSignature, cmapi.UsageClientAuth),
+			),
+			crMods: []gen.CertificateRequestModifier{
+				gen.SetCertificateRequestKeyUsages(cmapi.UsageDigitalSignature, cmapi.UsageClientAuth),
+			},
+			assertCert: func(t *testing.T, got *x509.Certificate) {
+				assert.Equal(t, x509.KeyUsageDigitalSignature, got.KeyUsage)
+				assert.Equal(t, []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}, got.ExtKeyUsage)
+			},
+		},
+		"should honor isCA": {
+			crt: gen.Certificate("test", gen.SetCertificateCommonName("example.com"), gen.SetCertificateIsCA(true)),
+			crMods: []gen.CertificateRequestModifier{
+				gen.SetCertificateRequestIsCA(true),
+			},
+			assertCert: func(t *testing.T, got *x509.Certificate) {
+				assert.True(t, got.BasicConstraintsValid)
+				assert.True(t, got.IsCA)
+				assert.NotZero(t, got.KeyUsage&x509.KeyUsageCertSign, "expected the cert sign key usage")
+			},
+		},
+		"should honor name constraints from the CSR": {
+			crt: gen.Certificate("test", gen.SetCertificateCommonName("example.com"), gen.SetCertificateIsCA(true),
+				func(crt *cmapi.Certificate) {
+					crt.Spec.NameConstraints = &cmapi.NameConstraints{
+						Permitted: &cmapi.NameConstraintItem{DNSDomains: []string{"example.com"}},
+					}
+				},
+			),
+			csrOpts: []pki.GenerateCSROption{pki.WithNameConstraints(true)},
+			crMods: []gen.CertificateRequestModifier{
+				gen.SetCertificateRequestIsCA(true),
+			},
+			assertCert: func(t *testing.T, got *x509.Certificate) {
+				assert.Equal(t, []string{"example.com"}, got.PermittedDNSDomains)
+			},
+		},
+		"should fail if the CSR's basic constraints do not match the CertificateRequest": {
+			crt:     gen.Certificate("test", gen.SetCertificateCommonName("example.com"), gen.SetCertificateIsCA(true)),
+			csrOpts: []pki.GenerateCSROption{pki.WithEncodeBasicConstraintsInRequest(true)},
+		},
+		"should fail if the CSR's usages do not match the CertificateRequest": {
+			crt: gen.Certificate("test", gen.SetCertificateCommonName("example.com"),
+				gen.SetCertificateKeyUsages(cmapi.UsageDigitalSignature, cmapi.UsageClientAuth),
+			),
+			crMods: []gen.CertificateRequestModifier{
+				gen.SetCertificateRequestKeyUsages(cmapi.UsageDigitalSignature, cmapi.UsageServerAuth),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			key, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+			require.NoError(t, err)
+
+			crt := gen.CertificateFrom(test.crt, gen.SetCertificateKeyAlgorithm(cmapi.ECDSAKeyAlgorithm), gen.SetCertificateKeySize(256))
+			template, err := pki.GenerateCSR(crt, test.csrOpts...)
+			require.NoError(t, err)
+			csrDER, err := pki.EncodeCSR(template, key)
+			require.NoError(t, err)
+			csrPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER})
+
+			cr := gen.CertificateRequest("test", append([]gen.CertificateRequestModifier{
+				gen.SetCertificateRequestNamespace(gen.DefaultTestNamespace),
+				gen.SetCertificateRequestCSR(csrPEM),
+			}, test.crMods...)...)
+
+			certPEM := sign(t, key, cr)
+
+			if test.assertCert == nil {
+				assert.Nil(t, certPEM, "expected the request not to be signed")
+				assert.Equal(t, cmapi.CertificateRequestReasonFailed, apiutil.CertificateRequestReadyReason(cr),
+					"expected the CertificateRequest to be marked as failed")
+				return
+			}
+
+			require.NotNil(t, certPEM, "expected the request to be signed, got condition %+v",
+				apiutil.GetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionReady))
+			cert, err := pki.DecodeX509CertificateBytes(certPEM)
+			require.NoError(t, err)
+			test.assertCert(t, cert)
+		})
+	}
+}